@@ -1,16 +1,26 @@
 package main
 
 import (
+	"achievement-management/internal/attachments"
+	"achievement-management/internal/backup"
 	"achievement-management/internal/config"
+	"achievement-management/internal/errorreport"
+	"achievement-management/internal/events"
 	"achievement-management/internal/handlers"
-	"achievement-management/internal/repository"
+	"achievement-management/internal/lambda"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/notifications"
+	"achievement-management/internal/scheduler"
 	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 // Version information (set by build flags)
@@ -32,24 +42,191 @@ func main() {
 	// コンテキストを作成
 	ctx := context.Background()
 
-	// DynamoDBリポジトリを初期化
-	dynamoRepo, err := repository.NewDynamoDBRepository(ctx, cfg)
+	// 設定したストレージのリポジトリを初期化
+	repos, err := storage.Open(ctx, cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize DynamoDB repository: %v", err)
+		log.Fatalf("Failed to initialize %s storage: %v", cfg.Storage.Driver, err)
 	}
+	defer repos.Close()
 
-	// 各リポジトリを初期化
-	achievementRepo := repository.NewAchievementRepository(dynamoRepo, cfg)
-	rewardRepo := repository.NewRewardRepository(dynamoRepo, cfg)
-	pointRepo := repository.NewPointRepository(dynamoRepo, cfg)
+	achievementRepo := repos.Achievements
+	rewardRepo := repos.Rewards
+	pointRepo := repos.Points
 
 	// サービス層を初期化
-	achievementService := services.NewAchievementService(achievementRepo, pointRepo)
-	rewardService := services.NewRewardService(rewardRepo, pointRepo)
+	limits := services.Limits{
+		MaxPoint:   cfg.Points.MaxPoint,
+		DailyQuota: cfg.Points.DailyQuota,
+		OverQuota:  services.OverQuotaAction(cfg.Points.OverQuota),
+	}
+	achievementService := services.NewAchievementServiceWithLimits(achievementRepo, pointRepo, services.StreakSettings{
+		Location: cfg.Streaks.Location(),
+		Bonuses:  cfg.Streaks.Bonuses(),
+	}, limits)
+	rewardService := services.NewRewardServiceWithReservations(rewardRepo, pointRepo, repos.Reservations, cfg.Refunds.Window(), limits)
 	pointService := services.NewPointService(pointRepo, achievementRepo)
 
+	// 達成目録・報酬の作成・更新・削除を操作履歴に記録し、元に戻せるようにする
+	journalService := services.NewJournalService(repos.Operations, achievementRepo, rewardRepo, cfg.Journal.Size)
+	achievementService = services.NewJournaledAchievementService(achievementService, journalService)
+	rewardService = services.NewJournaledRewardService(rewardService, journalService)
+
+	// 達成目録の作成・報酬の獲得を notifications で設定したチャンネルに通知する
+	notifier, err := notifications.New(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize notifications: %v", err)
+	}
+	achievementService = services.NewNotifyingAchievementService(achievementService, notifier)
+	rewardService = services.NewNotifyingRewardService(rewardService, pointRepo, notifier, cfg.Notifications.LowBalanceThreshold)
+
 	// HTTPサーバーを初期化
 	server := handlers.NewServer(achievementService, rewardService, pointService, cfg)
+	server.EnableBadges(services.NewBadgeService(repos.Badges, achievementRepo, pointRepo, nil))
+	server.EnableGoals(services.NewGoalService(repos.Goals, achievementRepo, pointRepo, notifications.Subscribe(events.NewWebhookBus(cfg.Goals.WebhookURLs, cfg.Webhooks.SecretFor), notifier)))
+	server.EnableQuests(services.NewQuestService(repos.Quests, achievementRepo, limits))
+	server.EnableWishlist(services.NewWishlistService(repos.Favorites, repos.Wishlist, rewardRepo, pointRepo))
+	server.EnableReservations(services.NewReservationService(repos.Reservations, rewardRepo, pointRepo))
+	server.EnableRecommendations(services.NewRecommendationService(rewardRepo, achievementRepo, pointRepo, repos.Reservations, cfg.Streaks.Location()))
+	server.EnableNotes(services.NewNoteService(repos.Notes, achievementRepo, rewardRepo, pointRepo))
+
+	allowanceService := services.NewAllowanceService(repos.Allowances, limits, cfg.Streaks.Location())
+	server.EnableAllowances(allowanceService)
+
+	reminderService, err := services.NewReminderService(achievementRepo, events.NewWebhookBus(cfg.Reminders.WebhookURLs, cfg.Webhooks.SecretFor), cfg.Reminders.Schedule, cfg.Streaks.Location())
+	if err != nil {
+		log.Fatalf("Failed to initialize reminders: %v", err)
+	}
+	server.EnableReminders(reminderService)
+
+	// カレンダーのフィードはトークンを設定した場合のみ配信する
+	if tokens := cfg.Calendar.Tokens(); len(tokens) > 0 {
+		server.EnableCalendar(tokens, cfg.Streaks.Location())
+	}
+
+	summaryService, err := services.NewSummaryService(achievementRepo, pointRepo, notifications.Subscribe(events.NewWebhookBus(cfg.Summaries.WebhookURLs, cfg.Webhooks.SecretFor), notifier), services.SummarySettings{
+		DailySchedule:  cfg.Summaries.DailySchedule,
+		WeeklySchedule: cfg.Summaries.WeeklySchedule,
+		Location:       cfg.Streaks.Location(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize summaries: %v", err)
+	}
+	server.EnableSummaries(summaryService)
+	server.EnableStats(services.NewStatsService(achievementRepo, pointRepo, cfg.Streaks.Location()))
+	server.EnableSuggestions(services.NewSuggestionService(achievementRepo, limits))
+	server.EnableBulk(services.NewBulkService(achievementService, rewardService, cfg.Bulk.Parallelism, cfg.Bulk.MaxItems))
+
+	consistencyService := services.NewConsistencyService(pointService, repos.Drift, events.NewWebhookBus(cfg.Consistency.WebhookURLs, cfg.Webhooks.SecretFor), cfg.Consistency.Interval(), cfg.Consistency.Threshold)
+	server.EnableConsistency(consistencyService)
+
+	// Discordのボットのコマンドは公開鍵を設定した場合のみ受け付ける
+	if key := cfg.Notifications.Discord.VerifyKey(); key != nil {
+		server.EnableDiscord(notifications.NewCommands(pointService, rewardService), key)
+	}
+
+	// 添付ファイルはS3の署名付きURLを使用するため、バケットを設定した場合のみ有効にする
+	if cfg.Attachments.Bucket != "" {
+		store, err := attachments.Open(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize attachments: %v", err)
+		}
+		server.EnableAttachments(services.NewAttachmentService(store, cfg.Attachments.Prefix, achievementRepo, pointRepo))
+	}
+
+	// バックアップの管理エンドポイントはトークンを設定した場合のみ公開する
+	if cfg.Backup.AdminToken != "" && cfg.Storage.Driver == config.StorageDriverDynamoDB {
+		backups, err := backup.Open(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize backups: %v", err)
+		}
+		server.EnableBackups(backups, cfg.Backup.AdminToken)
+	}
+
+	// ヘルスチェックでDynamoDBへの接続も確認する（確認結果は health_check.cache_ttl_seconds の間使い回す）
+	if cfg.HealthCheck.CheckDynamoDB && cfg.Storage.Driver == config.StorageDriverDynamoDB {
+		server.EnableDynamoDBHealthCheck(repos, cfg.HealthCheck)
+	}
+
+	// メンテナンスモードの管理エンドポイントもトークンを設定した場合のみ公開する
+	if cfg.Maintenance.AdminToken != "" {
+		server.EnableMaintenance(repos.Maintenance, cfg.Maintenance.AdminToken)
+	}
+
+	// 操作履歴の管理エンドポイント（元に戻す・やり直す）もトークンを設定した場合のみ公開する
+	if cfg.Journal.AdminToken != "" {
+		server.EnableJournal(journalService, cfg.Journal.AdminToken)
+	}
+
+	// 設定確認の管理エンドポイントもトークンを設定した場合のみ公開する
+	if cfg.Diagnostics.AdminToken != "" {
+		server.EnableConfigDiagnostics(cfg, cfg.Diagnostics.AdminToken)
+	}
+
+	// ログレベル変更の管理エンドポイントもトークンを設定した場合のみ公開する
+	if cfg.Logging.AdminToken != "" {
+		server.EnableLogLevel(cfg.Logging.AdminToken)
+	}
+
+	// X-API-Key ヘッダーのAPIキーを照合する（キーの管理エンドポイントはトークンを設定した場合のみ公開する）
+	server.EnableAPIKeys(services.NewAPIKeyService(repos.APIKeys), cfg.APIKeys)
+
+	// エラー管理サービスへの通知はDSNを設定した場合のみ有効にする
+	var reporter *errorreport.Client
+	if cfg.ErrorReporting.DSN != "" {
+		reporter, err = errorreport.New(cfg.ErrorReporting.DSN, cfg.Environment, Version)
+		if err != nil {
+			log.Fatalf("Failed to initialize error reporting: %v", err)
+		}
+		server.EnableErrorReporting(reporter)
+	}
+
+	if repos.Maintenance.ReadOnly() {
+		log.Println("Maintenance mode is enabled: writes are rejected until it is turned off")
+	}
+
+	// Lambdaで実行する場合は、API Gateway・ALBのイベントをルーターで処理する
+	// （実行環境は呼び出しの間停止するため、スケジューラーは起動しない。CLIのコマンドをスケジュールして実行する）
+	if lambda.Detected() {
+		if cfg.Reminders.Enabled || cfg.Summaries.Enabled || cfg.Consistency.Enabled || cfg.Allowances.Enabled {
+			log.Println("Schedulers are not started on AWS Lambda: run the CLI send/check/run commands on a schedule instead")
+		}
+		log.Println("Serving requests from the AWS Lambda runtime API")
+		// 実行環境が停止する前に、SNS・SQSにまとめて送信するイベントと送信待ちのエラーを呼び出しごとに送信する
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			server.Handler().ServeHTTP(w, r)
+			if err := notifications.Flush(r.Context(), notifier); err != nil {
+				log.Printf("Failed to flush notifications: %v", err)
+			}
+			if reporter != nil {
+				if err := reporter.Flush(r.Context()); err != nil {
+					log.Printf("Failed to flush error reports: %v", err)
+				}
+			}
+		})
+		lambda.Start(ctx, handler)
+		return
+	}
+
+	// リマインド・サマリー・整合性チェック・お小遣いのスケジューラーはAPIサーバーと同じプロセスで実行する（複数台で起動する場合は1台だけ有効にする）
+	if cfg.Reminders.Enabled || cfg.Summaries.Enabled || cfg.Consistency.Enabled || cfg.Allowances.Enabled {
+		logger, err := logging.NewLogger(cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize scheduler: %v", err)
+		}
+		server.LogLevels().Attach(logger)
+		if cfg.Reminders.Enabled {
+			go scheduler.New("reminders", reminderService, cfg.Reminders.Tenants, logger).Run(ctx)
+		}
+		if cfg.Summaries.Enabled {
+			go scheduler.New("summaries", summaryService, cfg.Summaries.Tenants, logger).Run(ctx)
+		}
+		if cfg.Consistency.Enabled {
+			go scheduler.New("consistency", consistencyService, cfg.Consistency.Tenants, logger).Run(ctx)
+		}
+		if cfg.Allowances.Enabled {
+			go scheduler.New("allowances", allowanceService, cfg.Allowances.Tenants, logger).Run(ctx)
+		}
+	}
 
 	// サーバーを起動
 	serverAddr := fmt.Sprintf(":%s", cfg.Server.Port)
@@ -68,4 +245,20 @@ func main() {
 	<-quit
 
 	log.Println("Server shutting down...")
+
+	// SNS・SQSにまとめて送信するイベントを送ってから終了する
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFlush()
+	if err := notifications.Flush(flushCtx, notifier); err != nil {
+		log.Printf("Failed to flush notifications: %v", err)
+	}
+
+	// 送信待ちのエラーを送ってから終了する
+	if reporter != nil {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := reporter.Close(closeCtx); err != nil {
+			log.Printf("Failed to flush error reports: %v", err)
+		}
+	}
 }
\ No newline at end of file
@@ -7,7 +7,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"achievement-management/internal/config"
 	"achievement-management/internal/models"
+	"achievement-management/internal/services"
 )
 
 // achievementCmd represents the achievement command
@@ -17,7 +19,8 @@ var achievementCmd = &cobra.Command{
 	Long: `Manage achievements in the system.
 
 You can create, list, update, and delete achievements using this command.
-Each achievement has a title, description, and point value.`,
+Each achievement has a title, description, point value, and optional category,
+and can be completed repeatedly to earn its points.`,
 }
 
 // achievementCreateCmd represents the achievement create command
@@ -25,43 +28,111 @@ var achievementCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new achievement",
 	Long: `Create a new achievement with the specified title, description, and point value.
+Pass --category to group it (e.g. "health" or "learning") in "points aggregate".
+Pass --due to be reminded from that date until it is completed, and --reminder
+with a cron expression to be reminded at that time (for items without a due
+date: on every day it has not been completed yet). Pass --difficulty (easy,
+medium or hard) to rate it; "achievement suggest-point" suggests a point value
+for a difficulty. Pass --max-per-day and --max-total to limit how often it can
+be completed (completing it beyond the limit is rejected). Pass --pinned to keep
+it at the top of "achievement list". Pass --points-per-hour to award points in
+proportion to the time tracked with "achievement timer" instead of --point.
 
 Example:
-  achievement-app achievement create --title "First Login" --description "Log in for the first time" --point 10`,
+  achievement-app achievement create --title "First Login" --description "Log in for the first time" --point 10
+  achievement-app achievement create --title "Morning run" --point 30 --category health
+  achievement-app achievement create --title "Stretch" --point 5 --reminder "0 21 * * *"
+  achievement-app achievement create --title "Tax return" --point 100 --due 2026-03-15
+  achievement-app achievement create --title "Full marathon" --point 500 --difficulty hard
+  achievement-app achievement create --title "Drank water" --point 1 --max-per-day 8
+  achievement-app achievement create --title "Learn Go" --point 200 --pinned
+  achievement-app achievement create --title "Practice piano" --point 10 --points-per-hour 40
+
+Pass --id with a ULID generated by the caller to make the create safe to retry:
+running it again with the same ID and values reports the existing achievement
+instead of creating a second one.
+
+Example:
+  achievement-app achievement create --id 01J2Z3V4W5X6Y7Z8A9BCDEFGHJ --title "First Login" --point 10`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
 		point, _ := cmd.Flags().GetInt("point")
+		category, _ := cmd.Flags().GetString("category")
+		dueDate, _ := cmd.Flags().GetString("due")
+		reminder, _ := cmd.Flags().GetString("reminder")
+		difficulty, _ := cmd.Flags().GetString("difficulty")
+		maxPerDay, _ := cmd.Flags().GetInt("max-per-day")
+		maxTotal, _ := cmd.Flags().GetInt("max-total")
+		pinned, _ := cmd.Flags().GetBool("pinned")
+		pointsPerHour, _ := cmd.Flags().GetInt("points-per-hour")
 
 		if title == "" {
-			return fmt.Errorf("title is required")
+			return msg.NewError("common.title_required")
 		}
 		if point <= 0 {
-			return fmt.Errorf("point must be a positive integer")
+			return msg.NewError("common.point_positive")
 		}
 
-		achievementService, _, _, err := initServices()
+		achievementService, _, _, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
 		achievement := &models.Achievement{
-			Title:       title,
-			Description: description,
-			Point:       point,
-			CreatedAt:   time.Now(),
+			ID:            id,
+			Title:         title,
+			Description:   description,
+			Point:         point,
+			Category:      category,
+			DueDate:       dueDate,
+			Reminder:      reminder,
+			Difficulty:    models.Difficulty(difficulty),
+			MaxPerDay:     maxPerDay,
+			MaxTotal:      maxTotal,
+			Pinned:        pinned,
+			PointsPerHour: pointsPerHour,
+			CreatedAt:     time.Now(),
 		}
 
-		if err := achievementService.Create(achievement); err != nil {
-			return fmt.Errorf("failed to create achievement: %w", err)
+		if err := achievementService.Create(cmd.Context(), achievement); err != nil {
+			return msg.Wrap(err, "achievement.create_failed")
+		}
+
+		fmt.Println(msg.T("achievement.created"))
+		fmt.Println(msg.T("label.id", achievement.ID))
+		fmt.Println(msg.T("label.title", achievement.Title))
+		fmt.Println(msg.T("label.description", achievement.Description))
+		fmt.Println(msg.T("label.points", achievement.Point))
+		if achievement.Category != "" {
+			fmt.Println(msg.T("label.category", achievement.Category))
+		}
+		if achievement.DueDate != "" {
+			fmt.Println(msg.T("label.due_date", achievement.DueDate))
+		}
+		if achievement.Reminder != "" {
+			fmt.Println(msg.T("label.reminder", achievement.Reminder))
+		}
+		if achievement.Difficulty != "" {
+			fmt.Println(msg.T("label.difficulty", achievement.Difficulty))
+		}
+		if achievement.MaxPerDay > 0 {
+			fmt.Println(msg.T("label.max_per_day", achievement.MaxPerDay))
+		}
+		if achievement.MaxTotal > 0 {
+			fmt.Println(msg.T("label.max_total", achievement.MaxTotal))
+		}
+		if achievement.Pinned {
+			fmt.Println(msg.T("label.pinned"))
+		}
+		if achievement.PointsPerHour > 0 {
+			fmt.Println(msg.T("label.points_per_hour", achievement.PointsPerHour))
 		}
+		fmt.Println(msg.T("label.created", achievement.CreatedAt.Format("2006-01-02 15:04:05")))
 
-		fmt.Printf("✅ Achievement created successfully!\n")
-		fmt.Printf("ID: %s\n", achievement.ID)
-		fmt.Printf("Title: %s\n", achievement.Title)
-		fmt.Printf("Description: %s\n", achievement.Description)
-		fmt.Printf("Points: %d\n", achievement.Point)
-		fmt.Printf("Created: %s\n", achievement.CreatedAt.Format("2006-01-02 15:04:05"))
+		printNewBadges(cmd.Context())
+		printReachedGoals(cmd.Context())
 
 		return nil
 	},
@@ -73,30 +144,60 @@ var achievementListCmd = &cobra.Command{
 	Short: "List all achievements",
 	Long: `List all achievements in the system.
 
+Pinned achievements come first, then the achievements ordered with
+"achievement reorder", then the rest in the order they were created.
+
 Example:
   achievement-app achievement list`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		achievementService, _, _, err := initServices()
+		achievementService, _, _, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
-		achievements, err := achievementService.List()
+		achievements, err := achievementService.List(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to list achievements: %w", err)
+			return msg.Wrap(err, "achievement.list_failed")
 		}
 
 		if len(achievements) == 0 {
-			fmt.Println("No achievements found.")
+			fmt.Println(msg.T("achievement.none"))
 			return nil
 		}
 
-		fmt.Printf("Found %d achievement(s):\n\n", len(achievements))
+		fmt.Printf("%s\n\n", msg.T("achievement.found", len(achievements)))
 		for i, achievement := range achievements {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, achievement.Title, achievement.ID)
-			fmt.Printf("   Description: %s\n", achievement.Description)
-			fmt.Printf("   Points: %d\n", achievement.Point)
-			fmt.Printf("   Created: %s\n", achievement.CreatedAt.Format("2006-01-02 15:04:05"))
+			fmt.Println(msg.T("list.item", i+1, achievement.Title, achievement.ID))
+			if achievement.Pinned {
+				fmt.Println(msg.T("list.pinned"))
+			}
+			fmt.Println(msg.T("list.description", achievement.Description))
+			fmt.Println(msg.T("list.points", achievement.Point))
+			if achievement.Category != "" {
+				fmt.Println(msg.T("list.category", achievement.Category))
+			}
+			if achievement.DueDate != "" {
+				fmt.Println(msg.T("list.due_date", achievement.DueDate))
+			}
+			if achievement.Reminder != "" {
+				fmt.Println(msg.T("list.reminder", achievement.Reminder))
+			}
+			if achievement.Difficulty != "" {
+				fmt.Println(msg.T("list.difficulty", achievement.Difficulty))
+			}
+			if achievement.MaxPerDay > 0 {
+				fmt.Println(msg.T("list.max_per_day", achievement.MaxPerDay))
+			}
+			if achievement.MaxTotal > 0 {
+				fmt.Println(msg.T("list.max_total", achievement.MaxTotal))
+			}
+			if achievement.PointsPerHour > 0 {
+				fmt.Println(msg.T("list.points_per_hour", achievement.PointsPerHour))
+			}
+			if achievement.TimerStartedAt != nil {
+				fmt.Println(msg.T("list.timer_running", achievement.TimerStartedAt.Local().Format("2006-01-02 15:04:05")))
+			}
+			fmt.Println(msg.T("list.created", achievement.CreatedAt.Format("2006-01-02 15:04:05")))
 			fmt.Println()
 		}
 
@@ -110,65 +211,165 @@ var achievementUpdateCmd = &cobra.Command{
 	Short: "Update an existing achievement",
 	Long: `Update an existing achievement by ID.
 
+Only the flags that are given are changed; pass --description "", --category "",
+--due "", --reminder "" or --difficulty "" to clear them, and --max-per-day 0 or
+--max-total 0 to remove a completion limit. Pass --pinned or --pinned=false to
+pin or unpin it, and --points-per-hour 0 to stop awarding points for tracked
+time. A before/after summary of the changed fields is shown.
+
+When --point changes the value, the difference is added to or subtracted from
+the balance in the same transaction as the update. The default comes from
+points.adjust_on_update; pass --with-points=false to keep the balance as is.
+
 Example:
-  achievement-app achievement update --id "01234567890" --title "Updated Title" --point 20`,
+  achievement-app achievement update --id "01234567890" --title "Updated Title" --point 20
+  achievement-app achievement update --id "01234567890" --description ""
+  achievement-app achievement update --id "01234567890" --category learning
+  achievement-app achievement update --id "01234567890" --due 2026-04-01 --reminder "0 9 * * 1-5"
+  achievement-app achievement update --id "01234567890" --max-per-day 8
+  achievement-app achievement update --id "01234567890" --pinned
+  achievement-app achievement update --id "01234567890" --point 5 --with-points=false`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id, _ := cmd.Flags().GetString("id")
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
-		pointStr, _ := cmd.Flags().GetString("point")
+		point, _ := cmd.Flags().GetInt("point")
+		category, _ := cmd.Flags().GetString("category")
+		dueDate, _ := cmd.Flags().GetString("due")
+		reminder, _ := cmd.Flags().GetString("reminder")
+		difficulty, _ := cmd.Flags().GetString("difficulty")
+		maxPerDay, _ := cmd.Flags().GetInt("max-per-day")
+		maxTotal, _ := cmd.Flags().GetInt("max-total")
+		pinned, _ := cmd.Flags().GetBool("pinned")
+		pointsPerHour, _ := cmd.Flags().GetInt("points-per-hour")
+		withPoints, _ := cmd.Flags().GetBool("with-points")
 
 		if id == "" {
-			return fmt.Errorf("id is required")
+			return msg.NewError("common.id_required")
+		}
+
+		flags := cmd.Flags()
+		if !flags.Changed("title") && !flags.Changed("description") && !flags.Changed("point") && !flags.Changed("category") &&
+			!flags.Changed("due") && !flags.Changed("reminder") && !flags.Changed("difficulty") && !flags.Changed("max-per-day") && !flags.Changed("max-total") &&
+			!flags.Changed("pinned") && !flags.Changed("points-per-hour") {
+			return msg.NewError("common.no_update_fields")
+		}
+		if flags.Changed("title") && title == "" {
+			return msg.NewError("common.title_required")
+		}
+		if flags.Changed("point") && point <= 0 {
+			return msg.NewError("common.point_positive")
 		}
 
-		achievementService, _, _, err := initServices()
+		cfg, err := config.LoadConfig()
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+		if !flags.Changed("with-points") {
+			withPoints = cfg.Points.AdjustOnUpdate
+		}
+
+		achievementService, _, _, err := newServices(cmd.Context(), cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
 		// Get existing achievement
-		existing, err := achievementService.GetByID(id)
+		existing, err := achievementService.GetByID(cmd.Context(), id)
 		if err != nil {
-			return fmt.Errorf("failed to get achievement: %w", err)
+			return msg.Wrap(err, "achievement.get_failed")
 		}
 
-		// Update fields if provided
+		// Update only the fields that were explicitly provided
 		updated := &models.Achievement{
-			ID:          existing.ID,
-			Title:       existing.Title,
-			Description: existing.Description,
-			Point:       existing.Point,
-			CreatedAt:   existing.CreatedAt,
+			ID:            existing.ID,
+			Title:         existing.Title,
+			Description:   existing.Description,
+			Point:         existing.Point,
+			Category:      existing.Category,
+			DueDate:       existing.DueDate,
+			Reminder:      existing.Reminder,
+			Difficulty:    existing.Difficulty,
+			MaxPerDay:     existing.MaxPerDay,
+			MaxTotal:      existing.MaxTotal,
+			Pinned:        existing.Pinned,
+			SortOrder:     existing.SortOrder,
+			PointsPerHour: existing.PointsPerHour,
+			CreatedAt:     existing.CreatedAt,
 		}
 
-		if title != "" {
+		if flags.Changed("title") {
 			updated.Title = title
 		}
-		if description != "" {
+		if flags.Changed("description") {
 			updated.Description = description
 		}
-		if pointStr != "" {
-			point, err := strconv.Atoi(pointStr)
-			if err != nil {
-				return fmt.Errorf("invalid point value: %w", err)
-			}
-			if point <= 0 {
-				return fmt.Errorf("point must be a positive integer")
-			}
+		if flags.Changed("point") {
 			updated.Point = point
 		}
+		if flags.Changed("category") {
+			updated.Category = category
+		}
+		if flags.Changed("due") {
+			updated.DueDate = dueDate
+		}
+		if flags.Changed("reminder") {
+			updated.Reminder = reminder
+		}
+		if flags.Changed("difficulty") {
+			updated.Difficulty = models.Difficulty(difficulty)
+		}
+		if flags.Changed("max-per-day") {
+			updated.MaxPerDay = maxPerDay
+		}
+		if flags.Changed("max-total") {
+			updated.MaxTotal = maxTotal
+		}
+		if flags.Changed("pinned") {
+			updated.Pinned = pinned
+		}
+		if flags.Changed("points-per-hour") {
+			updated.PointsPerHour = pointsPerHour
+		}
+
+		changes := []fieldChange{
+			{label: msg.T("field_label.title"), before: existing.Title, after: updated.Title},
+			{label: msg.T("field_label.description"), before: existing.Description, after: updated.Description},
+			{label: msg.T("field_label.points"), before: strconv.Itoa(existing.Point), after: strconv.Itoa(updated.Point)},
+			{label: msg.T("field_label.category"), before: existing.Category, after: updated.Category},
+			{label: msg.T("field_label.due_date"), before: existing.DueDate, after: updated.DueDate},
+			{label: msg.T("field_label.reminder"), before: existing.Reminder, after: updated.Reminder},
+			{label: msg.T("field_label.difficulty"), before: string(existing.Difficulty), after: string(updated.Difficulty)},
+			{label: msg.T("field_label.max_per_day"), before: repeatLimit(existing.MaxPerDay), after: repeatLimit(updated.MaxPerDay)},
+			{label: msg.T("field_label.max_total"), before: repeatLimit(existing.MaxTotal), after: repeatLimit(updated.MaxTotal)},
+			{label: msg.T("field_label.pinned"), before: strconv.FormatBool(existing.Pinned), after: strconv.FormatBool(updated.Pinned)},
+			{label: msg.T("field_label.points_per_hour"), before: repeatLimit(existing.PointsPerHour), after: repeatLimit(updated.PointsPerHour)},
+		}
+		if !hasChanges(changes) {
+			fmt.Println(msg.T("common.no_changes"))
+			return nil
+		}
 
-		if err := achievementService.Update(id, updated); err != nil {
-			return fmt.Errorf("failed to update achievement: %w", err)
+		if withPoints {
+			err = achievementService.Update(cmd.Context(), id, updated)
+		} else {
+			err = achievementService.UpdateWithoutPoints(cmd.Context(), id, updated)
+		}
+		if err != nil {
+			return msg.Wrap(err, "achievement.update_failed")
+		}
+		// The service normalizes the category and difficulty, so show the values that were stored.
+		changes[3].after = updated.Category
+		changes[6].after = string(updated.Difficulty)
+
+		fmt.Println(msg.T("achievement.updated"))
+		fmt.Println(msg.T("label.id", updated.ID))
+		printChanges(changes)
+		if delta := updated.Point - existing.Point; withPoints && delta != 0 {
+			fmt.Println(msg.T("achievement.points_adjusted", delta))
 		}
 
-		fmt.Printf("✅ Achievement updated successfully!\n")
-		fmt.Printf("ID: %s\n", updated.ID)
-		fmt.Printf("Title: %s\n", updated.Title)
-		fmt.Printf("Description: %s\n", updated.Description)
-		fmt.Printf("Points: %d\n", updated.Point)
-		fmt.Printf("Created: %s\n", updated.CreatedAt.Format("2006-01-02 15:04:05"))
+		printReachedGoals(cmd.Context())
 
 		return nil
 	},
@@ -178,61 +379,408 @@ Example:
 var achievementDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete an achievement",
-	Long: `Delete an achievement by ID.
+	Long: `Delete an achievement by ID, or delete every achievement matching a filter.
+
+Filters can be combined: --where may be repeated (all conditions must match) and
+--before limits the match to achievements created before the given date.
+Supported conditions are point (<, <=, >, >=, =, !=), title and description (=, !=).
+Matching achievements are previewed and must be confirmed unless --yes is given.
+
+With --with-points the points granted for each achievement are subtracted from
+the balance in the same transaction as the delete. The default comes from
+points.adjust_on_delete; pass --with-points=false to keep the balance as is.
+The delete fails if the balance no longer covers the points (they were spent).
+
+Filtered deletes run several achievements at a time (bulk.parallelism) and
+report each achievement that could not be deleted. With --all-or-nothing the
+remaining deletes stop at the first failure and the achievements already
+deleted are restored.
 
 Example:
-  achievement-app achievement delete --id "01234567890"`,
+  achievement-app achievement delete --id "01234567890"
+  achievement-app achievement delete --id "01234567890" --with-points
+  achievement-app achievement delete --where "point<10" --before 2023-01-01
+  achievement-app achievement delete --where "point<10" --with-points --all-or-nothing`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id, _ := cmd.Flags().GetString("id")
+		where, _ := cmd.Flags().GetStringArray("where")
+		beforeStr, _ := cmd.Flags().GetString("before")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		withPoints, _ := cmd.Flags().GetBool("with-points")
+		allOrNothing, _ := cmd.Flags().GetBool("all-or-nothing")
+
+		hasFilter := len(where) > 0 || beforeStr != ""
+		if id == "" && !hasFilter {
+			return msg.NewError("achievement.delete_target_required")
+		}
+		if id != "" && hasFilter {
+			return msg.NewError("achievement.delete_target_conflict")
+		}
 
-		if id == "" {
-			return fmt.Errorf("id is required")
+		var filter *services.AchievementFilter
+		if hasFilter {
+			var before time.Time
+			if beforeStr != "" {
+				parsed, err := time.ParseInLocation("2006-01-02", beforeStr, time.Local)
+				if err != nil {
+					return msg.Wrap(err, "common.invalid_date", beforeStr)
+				}
+				before = parsed
+			}
+
+			parsedFilter, err := services.NewAchievementFilter(where, before)
+			if err != nil {
+				return msg.Wrap(err, "achievement.invalid_filter")
+			}
+			filter = parsedFilter
 		}
 
-		achievementService, _, _, err := initServices()
+		cfg, err := config.LoadConfig()
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+		if !cmd.Flags().Changed("with-points") {
+			withPoints = cfg.Points.AdjustOnDelete
+		}
+
+		achievementService, rewardService, _, err := newServices(cmd.Context(), cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if filter != nil {
+			achievements, err := achievementService.List(cmd.Context())
+			if err != nil {
+				return msg.Wrap(err, "achievement.list_failed")
+			}
+
+			matched := filter.Apply(achievements)
+			if len(matched) == 0 {
+				fmt.Println(msg.T("achievement.none_matched"))
+				return nil
+			}
+
+			fmt.Printf("%s\n\n", msg.T("achievement.delete_preview", len(matched)))
+			for i, achievement := range matched {
+				fmt.Println(msg.T("list.item", i+1, achievement.Title, achievement.ID))
+				fmt.Println(msg.T("list.points", achievement.Point))
+				fmt.Println(msg.T("list.created", achievement.CreatedAt.Format("2006-01-02 15:04:05")))
+			}
+			fmt.Println()
+
+			if !assumeYes {
+				p := newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+				if !p.confirm(msg.T("achievement.delete_confirm", len(matched)), false) {
+					fmt.Println(msg.T("common.cancelled"))
+					return nil
+				}
+			}
+
+			ids := make([]string, 0, len(matched))
+			for _, achievement := range matched {
+				ids = append(ids, achievement.ID)
+			}
+			mode := models.BulkModeBestEffort
+			if allOrNothing {
+				mode = models.BulkModeAllOrNothing
+			}
+
+			// Each achievement is deleted in its own transaction so its points are subtracted with it.
+			// bulk.max_items limits API requests; the CLI deletes everything the filter matched.
+			bulkService := services.NewBulkService(achievementService, rewardService, cfg.Bulk.Parallelism, len(ids))
+			result, err := bulkService.DeleteAchievements(cmd.Context(), ids, withPoints, mode)
+			if err != nil {
+				return msg.Wrap(err, "achievement.batch_delete_failed", len(matched))
+			}
+
+			revoked := 0
+			for i, item := range result.Items {
+				switch {
+				case item.Err != nil:
+					fmt.Println(msg.T("achievement.batch_item_failed", matched[i].Title, matched[i].ID, msg.ErrorMessage(item.Err)))
+				case item.Status == models.BulkItemSucceeded:
+					revoked += matched[i].Point
+				}
+			}
+			if result.RolledBack {
+				fmt.Println(msg.T("achievement.batch_rolled_back"))
+			}
+			fmt.Println(msg.T("achievement.batch_deleted", result.Succeeded, len(matched)))
+			if withPoints && revoked > 0 {
+				fmt.Println(msg.T("achievement.points_revoked", revoked))
+			}
+			if result.Failed > 0 {
+				return msg.NewError("achievement.batch_delete_failed", result.Failed)
+			}
+			return nil
 		}
 
 		// Get achievement details before deletion for confirmation
-		achievement, err := achievementService.GetByID(id)
+		achievement, err := achievementService.GetByID(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "achievement.get_failed")
+		}
+
+		if withPoints {
+			err = achievementService.DeleteWithPoints(cmd.Context(), id)
+		} else {
+			err = achievementService.Delete(cmd.Context(), id)
+		}
+		if err != nil {
+			return msg.Wrap(err, "achievement.delete_failed")
+		}
+
+		fmt.Println(msg.T("achievement.deleted"))
+		fmt.Println(msg.T("common.deleted_item", achievement.Title, achievement.ID))
+		if withPoints {
+			fmt.Println(msg.T("achievement.points_revoked", achievement.Point))
+		}
+
+		return nil
+	},
+}
+
+// achievementReorderCmd represents the achievement reorder command
+var achievementReorderCmd = &cobra.Command{
+	Use:   "reorder",
+	Short: "Set the order of achievements in lists",
+	Long: `Set the order in which achievements are listed.
+
+The achievements given with --ids are listed in that order, after the pinned
+achievements; the order of every other achievement is cleared, so they follow
+in the order they were created.
+
+Example:
+  achievement-app achievement reorder --ids "01234567890,01234567891"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, _ := cmd.Flags().GetStringSlice("ids")
+
+		achievementService, _, _, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		achievements, err := achievementService.Reorder(cmd.Context(), ids)
+		if err != nil {
+			return msg.Wrap(err, "achievement.reorder_failed")
+		}
+
+		fmt.Println(msg.T("achievement.reordered"))
+		for i, achievement := range achievements {
+			fmt.Println(msg.T("list.item", i+1, achievement.Title, achievement.ID))
+			if achievement.Pinned {
+				fmt.Println(msg.T("list.pinned"))
+			}
+		}
+
+		return nil
+	},
+}
+
+// achievementCompleteCmd represents the achievement complete command
+var achievementCompleteCmd = &cobra.Command{
+	Use:   "complete",
+	Short: "Record that an achievement was completed",
+	Long: `Record a completion of an achievement and add its points to the balance.
+
+An achievement can be completed any number of times; every completion is
+recorded with the title and points the achievement had at that moment, and
+the points are granted in the same transaction as the record.
+
+Example:
+  achievement-app achievement complete --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		achievementService, _, _, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		completion, err := achievementService.Complete(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "achievement.complete_failed")
+		}
+
+		fmt.Println(msg.T("achievement.completed"))
+		fmt.Println(msg.T("label.id", completion.ID))
+		fmt.Println(msg.T("label.title", completion.AchievementTitle))
+		fmt.Println(msg.T("achievement.points_granted", completion.Point))
+
+		// The completion is already recorded, so a streak lookup failure only
+		// leaves the streak out of the output.
+		if streak, err := achievementService.GetStreak(cmd.Context(), id); err == nil {
+			if completion.BonusPoint > 0 {
+				fmt.Println(msg.T("achievement.bonus_granted", streak.Current, completion.BonusPoint))
+			}
+			fmt.Println(msg.T("label.streak", streak.Current, streak.Longest))
+		}
+
+		// A quest bonus can reach a points goal, so quests are checked first.
+		printCompletedQuests(cmd.Context())
+		printReachedGoals(cmd.Context())
+
+		return nil
+	},
+}
+
+// achievementCompletionsCmd represents the achievement completions command
+var achievementCompletionsCmd = &cobra.Command{
+	Use:   "completions",
+	Short: "List the completions of an achievement",
+	Long: `List every recorded completion of an achievement, oldest first.
+
+Example:
+  achievement-app achievement completions --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		achievementService, _, _, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to get achievement: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
-		if err := achievementService.Delete(id); err != nil {
-			return fmt.Errorf("failed to delete achievement: %w", err)
+		achievement, err := achievementService.GetByID(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "achievement.get_failed")
 		}
 
-		fmt.Printf("✅ Achievement deleted successfully!\n")
-		fmt.Printf("Deleted: %s (ID: %s)\n", achievement.Title, achievement.ID)
+		completions, err := achievementService.ListCompletions(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "achievement.completions_failed")
+		}
+
+		if len(completions) == 0 {
+			fmt.Println(msg.T("achievement.no_completions", achievement.Title))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("achievement.completions_found", achievement.Title, len(completions)))
+		for i, completion := range completions {
+			fmt.Println(msg.T("achievement.completion_item", i+1, completion.CompletedAt.Format("2006-01-02 15:04:05"), completion.Point+completion.BonusPoint, completion.ID))
+			if completion.StartedAt != nil {
+				fmt.Println(msg.T("achievement.completion_duration", trackedDuration(completion.DurationSeconds)))
+			}
+		}
+
+		streak, err := achievementService.GetStreak(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "achievement.streaks_failed")
+		}
+		fmt.Println()
+		fmt.Println(msg.T("label.streak", streak.Current, streak.Longest))
 
 		return nil
 	},
 }
 
+// achievementStreaksCmd represents the achievement streaks command
+var achievementStreaksCmd = &cobra.Command{
+	Use:   "streaks",
+	Short: "Show consecutive-day completion streaks",
+	Long: `Show the current and longest streak of consecutive days with at least one
+completion, across all achievements and for each achievement that has been
+completed. Days are counted in the timezone set by STREAKS_TIMEZONE.
+
+Example:
+  achievement-app achievement streaks`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		achievementService, _, _, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		summary, err := achievementService.GetStreaks(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "achievement.streaks_failed")
+		}
+
+		if len(summary.Achievements) == 0 {
+			fmt.Println(msg.T("achievement.streaks_none"))
+			return nil
+		}
+
+		fmt.Println(msg.T("achievement.streaks_title"))
+		fmt.Printf("%s\n\n", msg.T("achievement.streaks_global", summary.Global.Current, summary.Global.Longest))
+		for i, streak := range summary.Achievements {
+			fmt.Println(msg.T("list.item", i+1, streak.AchievementTitle, streak.AchievementID))
+			fmt.Println(msg.T("list.streak", streak.Current, streak.Longest))
+			fmt.Println(msg.T("list.last_completed", streak.LastCompletedOn))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// repeatLimit formats a completion limit for the change summary (empty when there is no limit)
+func repeatLimit(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return strconv.Itoa(limit)
+}
+
 func init() {
 	// Add subcommands to achievement command
 	achievementCmd.AddCommand(achievementCreateCmd)
 	achievementCmd.AddCommand(achievementListCmd)
 	achievementCmd.AddCommand(achievementUpdateCmd)
 	achievementCmd.AddCommand(achievementDeleteCmd)
+	achievementCmd.AddCommand(achievementReorderCmd)
+	achievementCmd.AddCommand(achievementCompleteCmd)
+	achievementCmd.AddCommand(achievementCompletionsCmd)
+	achievementCmd.AddCommand(achievementStreaksCmd)
 
 	// Flags for create command
+	achievementCreateCmd.Flags().String("id", "", "Client-supplied ULID (retrying with the same ID and values does not create a duplicate)")
 	achievementCreateCmd.Flags().String("title", "", "Achievement title (required)")
 	achievementCreateCmd.Flags().String("description", "", "Achievement description")
 	achievementCreateCmd.Flags().Int("point", 0, "Achievement point value (required)")
+	achievementCreateCmd.Flags().String("category", "", `Achievement category such as "health" or "learning"`)
+	achievementCreateCmd.Flags().String("due", "", "Due date (YYYY-MM-DD); reminded from that day until completed")
+	achievementCreateCmd.Flags().String("reminder", "", `Cron expression for when to remind, such as "0 21 * * *"`)
+	achievementCreateCmd.Flags().String("difficulty", "", "Difficulty (easy, medium or hard)")
+	achievementCreateCmd.Flags().Int("max-per-day", 0, "Maximum number of completions per day (0 for no limit)")
+	achievementCreateCmd.Flags().Int("max-total", 0, "Maximum number of completions in total (0 for no limit)")
+	achievementCreateCmd.Flags().Bool("pinned", false, "Keep the achievement at the top of lists")
+	achievementCreateCmd.Flags().Int("points-per-hour", 0, `Points per hour of time tracked with "achievement timer" (0 to award --point)`)
 	achievementCreateCmd.MarkFlagRequired("title")
 	achievementCreateCmd.MarkFlagRequired("point")
 
 	// Flags for update command
 	achievementUpdateCmd.Flags().String("id", "", "Achievement ID (required)")
 	achievementUpdateCmd.Flags().String("title", "", "New achievement title")
-	achievementUpdateCmd.Flags().String("description", "", "New achievement description")
-	achievementUpdateCmd.Flags().String("point", "", "New achievement point value")
+	achievementUpdateCmd.Flags().String("description", "", `New achievement description (use --description "" to clear)`)
+	achievementUpdateCmd.Flags().Int("point", 0, "New achievement point value")
+	achievementUpdateCmd.Flags().String("category", "", `New achievement category (use --category "" to clear)`)
+	achievementUpdateCmd.Flags().String("due", "", `New due date (YYYY-MM-DD, use --due "" to clear)`)
+	achievementUpdateCmd.Flags().String("reminder", "", `New reminder cron expression (use --reminder "" to clear)`)
+	achievementUpdateCmd.Flags().String("difficulty", "", `New difficulty (easy, medium or hard, use --difficulty "" to clear)`)
+	achievementUpdateCmd.Flags().Int("max-per-day", 0, "New maximum number of completions per day (use --max-per-day 0 to remove the limit)")
+	achievementUpdateCmd.Flags().Int("max-total", 0, "New maximum number of completions in total (use --max-total 0 to remove the limit)")
+	achievementUpdateCmd.Flags().Bool("pinned", false, "Pin the achievement to the top of lists (use --pinned=false to unpin)")
+	achievementUpdateCmd.Flags().Int("points-per-hour", 0, "New points per hour of tracked time (use --points-per-hour 0 to award --point)")
+	achievementUpdateCmd.Flags().Bool("with-points", true, "Apply the point difference to the balance (default from points.adjust_on_update)")
 	achievementUpdateCmd.MarkFlagRequired("id")
 
 	// Flags for delete command
-	achievementDeleteCmd.Flags().String("id", "", "Achievement ID (required)")
-	achievementDeleteCmd.MarkFlagRequired("id")
-}
\ No newline at end of file
+	achievementDeleteCmd.Flags().String("id", "", "Achievement ID")
+	achievementDeleteCmd.Flags().StringArray("where", nil, `Filter condition such as "point<10" (repeatable)`)
+	achievementDeleteCmd.Flags().String("before", "", "Only match achievements created before this date (YYYY-MM-DD)")
+	achievementDeleteCmd.Flags().BoolP("yes", "y", false, "Delete matching achievements without confirmation")
+	achievementDeleteCmd.Flags().Bool("with-points", false, "Subtract the achievements' points from the balance (default from points.adjust_on_delete)")
+	achievementDeleteCmd.Flags().Bool("all-or-nothing", false, "With a filter, restore the deleted achievements if any delete fails")
+
+	// Flags for reorder command
+	achievementReorderCmd.Flags().StringSlice("ids", nil, "Comma-separated achievement IDs in the order to list them (required)")
+	achievementReorderCmd.MarkFlagRequired("ids")
+
+	// Flags for complete command
+	achievementCompleteCmd.Flags().String("id", "", "Achievement ID (required)")
+	achievementCompleteCmd.MarkFlagRequired("id")
+
+	// Flags for completions command
+	achievementCompletionsCmd.Flags().String("id", "", "Achievement ID (required)")
+	achievementCompletionsCmd.MarkFlagRequired("id")
+}
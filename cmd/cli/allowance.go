@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// allowanceCmd represents the allowance command
+var allowanceCmd = &cobra.Command{
+	Use:   "allowance",
+	Short: "Manage scheduled point grants",
+	Long: `Manage allowances: points granted automatically on a schedule, such as
+pocket money every Monday.
+
+Each allowance has a cron schedule (minute hour day month weekday) evaluated in
+streaks.timezone. "serve" grants the due allowances every minute when
+allowances.enabled is set, and each grant is recorded in the point ledger as an
+allowance. An allowance is granted at most once per scheduled minute, even when
+several instances run the scheduler.`,
+}
+
+// allowanceCreateCmd represents the allowance create command
+var allowanceCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new allowance",
+	Long: `Create a new allowance that grants points on a cron schedule.
+
+Example:
+  achievement-app allowance create --title "Pocket money" --points 50 --schedule "0 9 * * 1"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title, _ := cmd.Flags().GetString("title")
+		points, _ := cmd.Flags().GetInt("points")
+		schedule, _ := cmd.Flags().GetString("schedule")
+
+		if title == "" {
+			return msg.NewError("common.title_required")
+		}
+
+		allowanceService, err := initAllowanceService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		allowance := &models.Allowance{
+			Title:    title,
+			Points:   points,
+			Schedule: schedule,
+		}
+		if err := allowanceService.Create(cmd.Context(), allowance); err != nil {
+			return msg.Wrap(err, "allowance.create_failed")
+		}
+
+		fmt.Println(msg.T("allowance.created"))
+		fmt.Println(msg.T("label.id", allowance.ID))
+		fmt.Println(msg.T("label.title", allowance.Title))
+		fmt.Println(msg.T("allowance.points", allowance.Points))
+		fmt.Println(msg.T("allowance.schedule", allowance.Schedule))
+
+		return nil
+	},
+}
+
+// allowanceListCmd represents the allowance list command
+var allowanceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all allowances",
+	Long: `List all allowances, oldest first, with the last time each was granted.
+
+Example:
+  achievement-app allowance list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		allowanceService, err := initAllowanceService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		allowances, err := allowanceService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "allowance.list_failed")
+		}
+
+		if len(allowances) == 0 {
+			fmt.Println(msg.T("allowance.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("allowance.found", len(allowances)))
+		for i, allowance := range allowances {
+			fmt.Println(msg.T("list.item", i+1, allowance.Title, allowance.ID))
+			fmt.Println(msg.T("allowance.points", allowance.Points))
+			fmt.Println(msg.T("allowance.schedule", allowance.Schedule))
+			if allowance.LastGrantedAt != nil {
+				fmt.Println(msg.T("allowance.last_granted", allowance.LastGrantedAt.Local().Format("2006-01-02 15:04")))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// allowanceDeleteCmd represents the allowance delete command
+var allowanceDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an allowance",
+	Long: `Delete an allowance by ID. Points that were already granted are kept.
+
+Example:
+  achievement-app allowance delete --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		allowanceService, err := initAllowanceService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if err := allowanceService.Delete(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "allowance.delete_failed")
+		}
+
+		fmt.Println(msg.T("allowance.deleted"))
+
+		return nil
+	},
+}
+
+// allowanceRunCmd represents the allowance run command
+var allowanceRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Grant the allowances due this minute",
+	Long: `Grant the allowances whose schedule matches the current minute.
+
+Use it from an external scheduler such as cron instead of allowances.enabled when
+the API server is not running. Allowances already granted this minute are skipped.
+
+Example:
+  achievement-app allowance run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		allowanceService, err := initAllowanceService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		at := time.Now().Truncate(time.Minute)
+		granted, err := allowanceService.NotifyDue(cmd.Context(), at)
+		if err != nil {
+			return msg.Wrap(err, "allowance.run_failed")
+		}
+
+		fmt.Println(msg.T("allowance.granted", granted, at.Format("2006-01-02 15:04")))
+		return nil
+	},
+}
+
+// initAllowanceService initializes the allowance service with the configured storage
+func initAllowanceService(ctx context.Context) (services.AllowanceService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return newAllowanceService(cfg, repos), nil
+}
+
+// newAllowanceService creates the allowance service that grants points into repos.Points
+func newAllowanceService(cfg *config.Config, repos *storage.Repositories) services.AllowanceService {
+	return services.NewAllowanceService(repos.Allowances, limits(cfg), cfg.Streaks.Location())
+}
+
+func init() {
+	// Add subcommands to allowance command
+	allowanceCmd.AddCommand(allowanceCreateCmd)
+	allowanceCmd.AddCommand(allowanceListCmd)
+	allowanceCmd.AddCommand(allowanceDeleteCmd)
+	allowanceCmd.AddCommand(allowanceRunCmd)
+
+	// Flags for create command
+	allowanceCreateCmd.Flags().String("title", "", "Allowance title (required)")
+	allowanceCreateCmd.Flags().Int("points", 0, "Points granted each time (required)")
+	allowanceCreateCmd.Flags().String("schedule", "", `Cron schedule in streaks.timezone, e.g. "0 9 * * 1" for Mondays at 9:00 (required)`)
+	allowanceCreateCmd.MarkFlagRequired("title")
+	allowanceCreateCmd.MarkFlagRequired("points")
+	allowanceCreateCmd.MarkFlagRequired("schedule")
+
+	// Flags for delete command
+	allowanceDeleteCmd.Flags().String("id", "", "Allowance ID (required)")
+	allowanceDeleteCmd.MarkFlagRequired("id")
+}
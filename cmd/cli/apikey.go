@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// apiKeyCmd represents the api-key command
+var apiKeyCmd = &cobra.Command{
+	Use:   "api-key",
+	Short: "Manage API keys",
+	Long: `Manage the API keys accepted by the API server in the X-API-Key header.
+
+Keys are stored hashed in the api_keys table, so a key is only shown when it is
+created or rotated. Set api_keys.required to reject /api requests without a key.
+Rotating a key replaces it immediately, and revoked keys are kept for the record.
+
+A key holds one or more scopes, and each route group requires one of them:
+
+  read                 GET requests on every route group
+  write                any request except admin operations
+  admin                any request, including the operation journal
+  achievements:read    GET /api/achievements
+  achievements:write   any request on /api/achievements
+  rewards:read         GET /api/rewards, favorites, wishlist and reservations
+  rewards:write        any request on those rewards routes
+  points:read          GET /api/points
+  points:adjust        any request on /api/points, such as refunds
+
+Routes outside these groups, such as /api/stats, require read or write.
+
+When tenancy is enabled, a key only works for the tenant it was created for
+(--tenant, or the default tenant when omitted), and the API server resolves
+the tenant of each request from its key.`,
+}
+
+// apiKeyCreateCmd represents the api-key create command
+var apiKeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new API key",
+	Long: `Create a new API key and print it once.
+
+Example:
+  achievement-app api-key create --label "Dashboard" --scope read
+  achievement-app api-key create --label "Habit tracker" --scope achievements:write,points:read
+  achievement-app api-key create --label "Family A" --scope write --tenant family-a`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		label, _ := cmd.Flags().GetString("label")
+		scope, _ := cmd.Flags().GetString("scope")
+
+		if label == "" {
+			return msg.NewError("apikey.label_required")
+		}
+
+		apiKeyService, err := initAPIKeyService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		key, secret, err := apiKeyService.Create(cmd.Context(), label, models.APIKeyScope(scope))
+		if err != nil {
+			return msg.Wrap(err, "apikey.create_failed")
+		}
+
+		fmt.Println(msg.T("apikey.created"))
+		printAPIKey(key)
+		fmt.Println(msg.T("apikey.key", secret))
+		fmt.Println(msg.T("apikey.key_notice"))
+
+		return nil
+	},
+}
+
+// apiKeyListCmd represents the api-key list command
+var apiKeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all API keys",
+	Long: `List all API keys, oldest first, including revoked keys. The keys themselves
+are never shown.
+
+Example:
+  achievement-app api-key list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKeyService, err := initAPIKeyService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		keys, err := apiKeyService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "apikey.list_failed")
+		}
+
+		if len(keys) == 0 {
+			fmt.Println(msg.T("apikey.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("apikey.found", len(keys)))
+		for i, key := range keys {
+			fmt.Println(msg.T("list.item", i+1, key.Label, key.ID))
+			fmt.Println(msg.T("apikey.scope", key.Scope))
+			if key.RotatedAt != nil {
+				fmt.Println(msg.T("apikey.rotated_at", key.RotatedAt.Local().Format("2006-01-02 15:04")))
+			}
+			if key.RevokedAt != nil {
+				fmt.Println(msg.T("apikey.revoked_at", key.RevokedAt.Local().Format("2006-01-02 15:04")))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// apiKeyUpdateCmd represents the api-key update command
+var apiKeyUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Change the label or scope of an API key",
+	Long: `Change the label or scope of an API key. Omitted flags are left unchanged, and
+the key itself keeps working.
+
+Example:
+  achievement-app api-key update --id "01234567890" --scope write`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		label, _ := cmd.Flags().GetString("label")
+		scope, _ := cmd.Flags().GetString("scope")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		apiKeyService, err := initAPIKeyService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		key, err := apiKeyService.Update(cmd.Context(), id, label, models.APIKeyScope(scope))
+		if err != nil {
+			return msg.Wrap(err, "apikey.update_failed")
+		}
+
+		fmt.Println(msg.T("apikey.updated"))
+		printAPIKey(key)
+
+		return nil
+	},
+}
+
+// apiKeyRotateCmd represents the api-key rotate command
+var apiKeyRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Replace an API key with a new one",
+	Long: `Replace an API key with a new one and print it once. The previous key stops
+working immediately; the label and scope are kept.
+
+Example:
+  achievement-app api-key rotate --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		apiKeyService, err := initAPIKeyService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		key, secret, err := apiKeyService.Rotate(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "apikey.rotate_failed")
+		}
+
+		fmt.Println(msg.T("apikey.rotated"))
+		printAPIKey(key)
+		fmt.Println(msg.T("apikey.key", secret))
+		fmt.Println(msg.T("apikey.key_notice"))
+
+		return nil
+	},
+}
+
+// apiKeyRevokeCmd represents the api-key revoke command
+var apiKeyRevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke an API key",
+	Long: `Revoke an API key. The key stops working immediately and cannot be rotated
+afterwards, but it stays in "api-key list" for the record.
+
+Example:
+  achievement-app api-key revoke --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		apiKeyService, err := initAPIKeyService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if _, err := apiKeyService.Revoke(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "apikey.revoke_failed")
+		}
+
+		fmt.Println(msg.T("apikey.revoked"))
+
+		return nil
+	},
+}
+
+// printAPIKey prints the ID, label and scope of an API key
+func printAPIKey(key *models.APIKey) {
+	fmt.Println(msg.T("label.id", key.ID))
+	fmt.Println(msg.T("label.title", key.Label))
+	fmt.Println(msg.T("apikey.scope", key.Scope))
+	if key.TenantID != "" {
+		fmt.Println(msg.T("apikey.tenant", key.TenantID))
+	}
+}
+
+// initAPIKeyService initializes the API key service with the configured storage
+func initAPIKeyService(ctx context.Context) (services.APIKeyService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewAPIKeyService(repos.APIKeys), nil
+}
+
+func init() {
+	// Add subcommands to api-key command
+	apiKeyCmd.AddCommand(apiKeyCreateCmd)
+	apiKeyCmd.AddCommand(apiKeyListCmd)
+	apiKeyCmd.AddCommand(apiKeyUpdateCmd)
+	apiKeyCmd.AddCommand(apiKeyRotateCmd)
+	apiKeyCmd.AddCommand(apiKeyRevokeCmd)
+
+	// Flags for create command
+	apiKeyCreateCmd.Flags().String("label", "", "Label describing who uses the key (required)")
+	apiKeyCreateCmd.Flags().String("scope", string(models.APIKeyScopeRead), "Comma-separated scopes, e.g. read or achievements:write,points:read")
+	apiKeyCreateCmd.MarkFlagRequired("label")
+
+	// Flags for update command
+	apiKeyUpdateCmd.Flags().String("id", "", "API key ID (required)")
+	apiKeyUpdateCmd.Flags().String("label", "", "New label")
+	apiKeyUpdateCmd.Flags().String("scope", "", "New comma-separated scopes, replacing the current ones")
+	apiKeyUpdateCmd.MarkFlagRequired("id")
+
+	// Flags for rotate command
+	apiKeyRotateCmd.Flags().String("id", "", "API key ID (required)")
+	apiKeyRotateCmd.MarkFlagRequired("id")
+
+	// Flags for revoke command
+	apiKeyRevokeCmd.Flags().String("id", "", "API key ID (required)")
+	apiKeyRevokeCmd.MarkFlagRequired("id")
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/backup"
+	"achievement-management/internal/config"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Export and restore table snapshots in S3",
+	Long: `Export the full contents of every DynamoDB table to S3 as JSON lines
+(gzip-compressed unless backup.gzip is false), and restore them from a snapshot.
+
+Snapshots cover every tenant. The bucket and key prefix are read from
+backup.bucket and backup.prefix (BACKUP_BUCKET, BACKUP_PREFIX).`,
+}
+
+// backupExportCmd represents the backup export command
+var backupExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all tables to a new snapshot",
+	Long: `Export all tables to a new snapshot and print its ID.
+
+Example:
+  achievement-app backup export`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backups, err := newBackupService(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		manifest, err := backups.Export(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "backup.export_failed")
+		}
+
+		for _, table := range manifest.Tables {
+			fmt.Println(msg.T("backup.table", table.Key, table.Items, table.Object))
+		}
+		fmt.Println(msg.T("backup.exported", manifest.ID))
+		return nil
+	},
+}
+
+// backupRestoreCmd represents the backup restore command
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <snapshot-id>",
+	Short: "Restore all tables from a snapshot",
+	Long: `Write every item of a snapshot back to the configured tables.
+
+Items with the same ID are overwritten; items created after the snapshot are kept.
+The tables are resolved from the current configuration, so a snapshot can be
+restored into another environment's tables.
+
+Example:
+  achievement-app backup restore 20250101T000000Z
+  achievement-app backup restore 20250101T000000Z --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotID := args[0]
+
+		backups, err := newBackupService(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if assumeYes, _ := cmd.Flags().GetBool("yes"); !assumeYes {
+			p := newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+			if !p.confirm(msg.T("backup.restore_confirm", snapshotID), false) {
+				fmt.Println(msg.T("common.cancelled"))
+				return nil
+			}
+		}
+
+		manifest, err := backups.Restore(cmd.Context(), snapshotID)
+		if err != nil {
+			return msg.Wrap(err, "backup.restore_failed", snapshotID)
+		}
+
+		for _, table := range manifest.Tables {
+			fmt.Println(msg.T("backup.table", table.Key, table.Items, table.Object))
+		}
+		fmt.Println(msg.T("backup.restored", manifest.ID))
+		return nil
+	},
+}
+
+// newBackupService initializes the backup service with the DynamoDB repository and the S3 bucket
+func newBackupService(ctx context.Context) (*backup.Service, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+	if err := requireDynamoDB(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Backup.Bucket == "" {
+		return nil, msg.NewError("backup.bucket_required")
+	}
+
+	backups, err := backup.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "backup.init_failed")
+	}
+	return backups, nil
+}
+
+func init() {
+	backupRestoreCmd.Flags().BoolP("yes", "y", false, "Restore without confirmation")
+
+	backupCmd.AddCommand(backupExportCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+}
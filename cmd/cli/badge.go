@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// badgeCmd represents the badge command
+var badgeCmd = &cobra.Command{
+	Use:   "badge",
+	Short: "View earned badges",
+	Long: `View the badges earned so far.
+
+Badges are checked after every achievement creation and reward redemption,
+and each badge is earned once.`,
+}
+
+// badgeListCmd represents the badge list command
+var badgeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List earned badges",
+	Long: `List the earned badges, oldest first.
+
+Example:
+  achievement-app badge list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		badgeService, err := initBadgeService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		badges, err := badgeService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "badge.list_failed")
+		}
+
+		if len(badges) == 0 {
+			fmt.Println(msg.T("badge.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("badge.found", len(badges)))
+		for i, badge := range badges {
+			fmt.Println(msg.T("list.item", i+1, badgeName(badge), badge.ID))
+			fmt.Println(msg.T("list.description", badgeDescription(badge)))
+			fmt.Println(msg.T("list.earned", badge.EarnedAt.Format("2006-01-02 15:04:05")))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// initBadgeService initializes the badge service with the configured storage
+func initBadgeService(ctx context.Context) (services.BadgeService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewBadgeService(repos.Badges, repos.Achievements, repos.Points, nil), nil
+}
+
+// printNewBadges checks the badge rules after a creation or redemption and prints
+// the badges it earned. The change itself has already succeeded, so a failure is
+// only reported as a warning.
+func printNewBadges(ctx context.Context) {
+	badgeService, err := initBadgeService(ctx)
+	if err != nil {
+		fmt.Println(msg.T("badge.evaluate_failed", msg.ErrorMessage(err)))
+		return
+	}
+
+	awarded, err := badgeService.Evaluate(ctx)
+	if err != nil {
+		fmt.Println(msg.T("badge.evaluate_failed", msg.ErrorMessage(err)))
+		return
+	}
+	for _, badge := range awarded {
+		fmt.Println(msg.T("badge.earned", badgeName(badge), badgeDescription(badge)))
+	}
+}
+
+// badgeName returns the translated name of a built-in badge, or the stored name
+func badgeName(badge *models.Badge) string {
+	return translated("badge.name."+badge.ID, badge.Name)
+}
+
+// badgeDescription returns the translated description of a built-in badge, or the stored description
+func badgeDescription(badge *models.Badge) string {
+	return translated("badge.description."+badge.ID, badge.Description)
+}
+
+// translated returns the message for key, or fallback when no catalog has it
+func translated(key, fallback string) string {
+	if message := msg.T(key); message != key {
+		return message
+	}
+	return fallback
+}
+
+func init() {
+	badgeCmd.AddCommand(badgeListCmd)
+}
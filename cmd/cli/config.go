@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+	Long:  `Inspect the configuration the CLI and API server load.`,
+}
+
+// configShowCmd represents the config show command
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective configuration",
+	Long: `Show the effective configuration after the config file and environment
+variables are applied. Secrets such as tokens, passwords and webhook URLs are
+masked.
+
+With --sources, each setting shows where its value came from: the default, the
+config file or an environment variable. Variables loaded from a .env file are
+shown as environment variables. An environment variable that does not change
+the value is not shown as the source.
+
+Example:
+  achievement-app config show
+  achievement-app config show --sources
+  achievement-app --config ./prod.yaml config show --sources`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showSources, _ := cmd.Flags().GetBool("sources")
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+
+		if path := cfg.FilePath(); path != "" {
+			fmt.Println(msg.T("config.file", path))
+		} else {
+			fmt.Println(msg.T("config.no_file"))
+		}
+		if path := cfg.EnvFilePath(); path != "" {
+			fmt.Println(msg.T("config.env_file", path))
+		}
+		fmt.Println()
+
+		for _, setting := range cfg.Settings() {
+			value := settingValue(setting.Value)
+			if showSources {
+				fmt.Println(msg.T("config.setting_with_source", setting.Key, value, msg.T("config.source."+string(setting.Source))))
+			} else {
+				fmt.Println(msg.T("config.setting", setting.Key, value))
+			}
+		}
+
+		return nil
+	},
+}
+
+// configEncryptCmd represents the config encrypt command
+var configEncryptCmd = &cobra.Command{
+	Use:   "encrypt [value]",
+	Short: "Encrypt a value for the config file",
+	Long: `Encrypt a secret, such as an AWS secret key or a webhook URL, so that it can be
+committed in a config file. Values starting with "enc:" are decrypted when the
+configuration is loaded; other values, such as table names, stay plain.
+
+Without --kms-key-id the value is encrypted with a key derived from the
+CONFIG_PASSPHRASE environment variable, which must also be set wherever the
+config is loaded. With --kms-key-id it is encrypted with the KMS key, and
+loading the config needs kms:Decrypt permission instead.
+
+The value is read from standard input when not given as an argument, to keep it
+out of the shell history.
+
+Example:
+  achievement-app config encrypt --kms-key-id alias/achievement-config
+  CONFIG_PASSPHRASE=... achievement-app config encrypt 'https://hooks.slack.com/services/...'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kmsKeyID, _ := cmd.Flags().GetString("kms-key-id")
+
+		var value string
+		if len(args) == 1 {
+			value = args[0]
+		} else {
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return msg.NewError("config.value_required")
+			}
+			value = strings.TrimRight(line, "\r\n")
+		}
+		if value == "" {
+			return msg.NewError("config.value_required")
+		}
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+		encrypted, err := config.EncryptSecret(cmd.Context(), cfg, value, kmsKeyID)
+		if err != nil {
+			return msg.Wrap(err, "config.encrypt_failed")
+		}
+		fmt.Println(encrypted)
+		return nil
+	},
+}
+
+// settingValue formats a setting value, quoting only empty strings
+func settingValue(value interface{}) string {
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configEncryptCmd)
+
+	configEncryptCmd.Flags().String("kms-key-id", "", "Encrypt with this KMS key ID, ARN or alias instead of CONFIG_PASSPHRASE")
+
+	configShowCmd.Flags().Bool("sources", false, "Show where each value came from (default, file or env)")
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/events"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// consistencyCmd represents the consistency command
+var consistencyCmd = &cobra.Command{
+	Use:   "consistency",
+	Short: "Check the point balance for drift",
+	Long: `Compare the points of all achievements with the current balance, as
+"points aggregate" does, and record a drift event when the difference exceeds
+consistency.threshold.
+
+"serve" runs the check every consistency.interval_minutes and sends each drift
+event to consistency.webhook_urls when consistency.enabled is set.`,
+}
+
+// consistencyCheckCmd represents the consistency check command
+var consistencyCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Run the consistency check now",
+	Long: `Aggregate the points right away, and record and send a drift event when the
+difference exceeds consistency.threshold.
+
+Use it from an external scheduler such as cron instead of consistency.enabled when
+the API server is not running.
+
+Example:
+  achievement-app consistency check`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		consistencyService, err := initConsistencyService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		summary, event, err := consistencyService.Check(cmd.Context())
+		if event != nil {
+			fmt.Println(msg.T("consistency.drift_detected", event.Difference, event.Threshold))
+			fmt.Println(msg.T("consistency.drift_recorded", event.ID))
+		}
+		if err != nil {
+			return msg.Wrap(err, "consistency.check_failed")
+		}
+		if event == nil {
+			fmt.Println(msg.T("consistency.ok", summary.Difference))
+		}
+		return nil
+	},
+}
+
+// consistencyDriftCmd represents the consistency drift command
+var consistencyDriftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "List the recorded drift events",
+	Long: `List the drift events recorded by the consistency check, oldest first.
+
+Example:
+  achievement-app consistency drift`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		consistencyService, err := initConsistencyService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		driftEvents, err := consistencyService.Drifts(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "consistency.list_failed")
+		}
+
+		if len(driftEvents) == 0 {
+			fmt.Println(msg.T("consistency.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("consistency.found", len(driftEvents)))
+		for _, event := range driftEvents {
+			fmt.Println(msg.T("consistency.item", event.DetectedAt.Format("2006-01-02 15:04:05"), event.ID))
+			fmt.Println(msg.T("consistency.item_detail", event.TotalPoints, event.CurrentBalance, event.Difference, event.Threshold))
+		}
+
+		return nil
+	},
+}
+
+// initConsistencyService creates the consistency checker for the configured storage
+func initConsistencyService(ctx context.Context) (services.ConsistencyService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return newConsistencyService(cfg, services.NewPointService(repos.Points, repos.Achievements), repos), nil
+}
+
+// newConsistencyService creates the consistency checker that records drift in repos.Drift
+func newConsistencyService(cfg *config.Config, pointService services.PointService, repos *storage.Repositories) services.ConsistencyService {
+	return services.NewConsistencyService(pointService, repos.Drift, events.NewWebhookBus(cfg.Consistency.WebhookURLs, cfg.Webhooks.SecretFor), cfg.Consistency.Interval(), cfg.Consistency.Threshold)
+}
+
+func init() {
+	consistencyCmd.AddCommand(consistencyCheckCmd)
+	consistencyCmd.AddCommand(consistencyDriftCmd)
+}
@@ -0,0 +1,38 @@
+package main
+
+import "fmt"
+
+// fieldChange holds the before/after values of a single field
+type fieldChange struct {
+	label  string
+	before string
+	after  string
+}
+
+// hasChanges reports whether any field value differs
+func hasChanges(changes []fieldChange) bool {
+	for _, change := range changes {
+		if change.before != change.after {
+			return true
+		}
+	}
+	return false
+}
+
+// printChanges prints the fields whose values differ as "label: before → after"
+func printChanges(changes []fieldChange) {
+	for _, change := range changes {
+		if change.before == change.after {
+			continue
+		}
+		fmt.Println(msg.T("common.change", change.label, displayValue(change.before), displayValue(change.after)))
+	}
+}
+
+// displayValue renders empty values visibly
+func displayValue(value string) string {
+	if value == "" {
+		return msg.T("common.empty_value")
+	}
+	return value
+}
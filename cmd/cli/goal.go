@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+	"achievement-management/internal/notifications"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// goalCmd represents the goal command
+var goalCmd = &cobra.Command{
+	Use:   "goal",
+	Short: "Manage goals",
+	Long: `Manage goals and track the progress towards them.
+
+A goal is either a number of points to save (type "points", compared with the
+current balance) or a number of achievements to create (type "achievements").
+Goals are checked after every achievement creation, completion, and update;
+a goal is reached once, and the configured webhooks are notified when it is.`,
+}
+
+// goalCreateCmd represents the goal create command
+var goalCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new goal",
+	Long: `Create a new goal with the specified title, type, and target.
+
+Example:
+  achievement-app goal create --title "Switch game" --description "Save up for the new Zelda" --type points --target 500
+  achievement-app goal create --title "Ten achievements" --type achievements --target 10`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		goalType, _ := cmd.Flags().GetString("type")
+		target, _ := cmd.Flags().GetInt("target")
+
+		if title == "" {
+			return msg.NewError("common.title_required")
+		}
+		if err := validateGoalFlags(goalType, target); err != nil {
+			return err
+		}
+
+		goalService, err := initGoalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		goal := &models.Goal{
+			Title:       title,
+			Description: description,
+			Type:        models.GoalType(goalType),
+			Target:      target,
+		}
+		if err := goalService.Create(cmd.Context(), goal); err != nil {
+			return msg.Wrap(err, "goal.create_failed")
+		}
+
+		fmt.Println(msg.T("goal.created"))
+		fmt.Println(msg.T("label.id", goal.ID))
+		fmt.Println(msg.T("label.title", goal.Title))
+		fmt.Println(msg.T("label.description", goal.Description))
+		fmt.Println(msg.T("label.goal_type", goalTypeName(goal.Type)))
+		fmt.Println(msg.T("label.target", goal.Target))
+		fmt.Println(msg.T("label.created", goal.CreatedAt.Format("2006-01-02 15:04:05")))
+
+		printReachedGoals(cmd.Context())
+
+		return nil
+	},
+}
+
+// goalListCmd represents the goal list command
+var goalListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all goals with their progress",
+	Long: `List all goals, oldest first, with the progress towards each of them.
+
+Example:
+  achievement-app goal list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		goalService, err := initGoalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		goals, err := goalService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "goal.list_failed")
+		}
+
+		if len(goals) == 0 {
+			fmt.Println(msg.T("goal.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("goal.found", len(goals)))
+		for i, progress := range goals {
+			goal := progress.Goal
+			fmt.Println(msg.T("list.item", i+1, goal.Title, goal.ID))
+			fmt.Println(msg.T("list.description", goal.Description))
+			fmt.Println(msg.T("list.goal_type", goalTypeName(goal.Type)))
+			fmt.Println(msg.T("list.progress", progress.Current, goal.Target, progress.Percent))
+			if goal.ReachedAt != nil {
+				fmt.Println(msg.T("list.reached", goal.ReachedAt.Format("2006-01-02 15:04:05")))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// goalGetCmd represents the goal get command
+var goalGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a goal and its progress",
+	Long: `Show a goal by ID together with the progress towards it.
+
+Example:
+  achievement-app goal get --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		goalService, err := initGoalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		progress, err := goalService.GetByID(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "goal.get_failed")
+		}
+
+		goal := progress.Goal
+		fmt.Println(msg.T("label.id", goal.ID))
+		fmt.Println(msg.T("label.title", goal.Title))
+		fmt.Println(msg.T("label.description", goal.Description))
+		fmt.Println(msg.T("label.goal_type", goalTypeName(goal.Type)))
+		fmt.Println(msg.T("label.progress", progress.Current, goal.Target, progress.Percent))
+		if goal.ReachedAt != nil {
+			fmt.Println(msg.T("label.reached", goal.ReachedAt.Format("2006-01-02 15:04:05")))
+		}
+		fmt.Println(msg.T("label.created", goal.CreatedAt.Format("2006-01-02 15:04:05")))
+
+		return nil
+	},
+}
+
+// goalUpdateCmd represents the goal update command
+var goalUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update an existing goal",
+	Long: `Update an existing goal by ID.
+
+Only the flags that are given are changed; pass --description "" to clear the
+description. Changing the type or target makes a reached goal pending again,
+so it is checked against the new target.
+
+Example:
+  achievement-app goal update --id "01234567890" --target 800`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		goalType, _ := cmd.Flags().GetString("type")
+		target, _ := cmd.Flags().GetInt("target")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		flags := cmd.Flags()
+		if !flags.Changed("title") && !flags.Changed("description") && !flags.Changed("type") && !flags.Changed("target") {
+			return msg.NewError("goal.no_update_fields")
+		}
+		if flags.Changed("title") && title == "" {
+			return msg.NewError("common.title_required")
+		}
+
+		goalService, err := initGoalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		existing, err := goalService.GetByID(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "goal.get_failed")
+		}
+
+		// Update only the fields that were explicitly provided
+		updated := &models.Goal{
+			Title:       existing.Goal.Title,
+			Description: existing.Goal.Description,
+			Type:        existing.Goal.Type,
+			Target:      existing.Goal.Target,
+		}
+		if flags.Changed("title") {
+			updated.Title = title
+		}
+		if flags.Changed("description") {
+			updated.Description = description
+		}
+		if flags.Changed("type") {
+			updated.Type = models.GoalType(goalType)
+		}
+		if flags.Changed("target") {
+			updated.Target = target
+		}
+		if err := validateGoalFlags(string(updated.Type), updated.Target); err != nil {
+			return err
+		}
+
+		changes := []fieldChange{
+			{label: msg.T("field_label.title"), before: existing.Goal.Title, after: updated.Title},
+			{label: msg.T("field_label.description"), before: existing.Goal.Description, after: updated.Description},
+			{label: msg.T("field_label.goal_type"), before: goalTypeName(existing.Goal.Type), after: goalTypeName(updated.Type)},
+			{label: msg.T("field_label.target"), before: strconv.Itoa(existing.Goal.Target), after: strconv.Itoa(updated.Target)},
+		}
+		if !hasChanges(changes) {
+			fmt.Println(msg.T("common.no_changes"))
+			return nil
+		}
+
+		if err := goalService.Update(cmd.Context(), id, updated); err != nil {
+			return msg.Wrap(err, "goal.update_failed")
+		}
+
+		fmt.Println(msg.T("goal.updated"))
+		fmt.Println(msg.T("label.id", updated.ID))
+		printChanges(changes)
+
+		printReachedGoals(cmd.Context())
+
+		return nil
+	},
+}
+
+// goalDeleteCmd represents the goal delete command
+var goalDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a goal",
+	Long: `Delete a goal by ID.
+
+Example:
+  achievement-app goal delete --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		goalService, err := initGoalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		// Get goal details before deletion for confirmation
+		progress, err := goalService.GetByID(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "goal.get_failed")
+		}
+
+		if err := goalService.Delete(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "goal.delete_failed")
+		}
+
+		fmt.Println(msg.T("goal.deleted"))
+		fmt.Println(msg.T("common.deleted_item", progress.Goal.Title, progress.Goal.ID))
+
+		return nil
+	},
+}
+
+// validateGoalFlags checks the goal type and target given on the command line
+func validateGoalFlags(goalType string, target int) error {
+	switch models.GoalType(goalType) {
+	case models.GoalTypePoints, models.GoalTypeAchievements:
+	default:
+		return msg.NewError("goal.invalid_type", goalType)
+	}
+	if target <= 0 {
+		return msg.NewError("goal.target_positive")
+	}
+	return nil
+}
+
+// goalTypeName returns the translated name of a goal type
+func goalTypeName(goalType models.GoalType) string {
+	return translated("goal.type."+string(goalType), string(goalType))
+}
+
+// initGoalService initializes the goal service with the configured storage and
+// the webhooks and notification channels that are notified when a goal is reached
+func initGoalService(ctx context.Context) (services.GoalService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	notifier, err := newNotifier(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_notifications_failed")
+	}
+
+	return services.NewGoalService(repos.Goals, repos.Achievements, repos.Points, notifications.Subscribe(events.NewWebhookBus(cfg.Goals.WebhookURLs, cfg.Webhooks.SecretFor), notifier)), nil
+}
+
+// printReachedGoals checks the pending goals after a change to the achievements
+// or goals and prints the ones it reached. A goal that was reached is recorded
+// even when its notification fails, so it is printed along with the warning.
+func printReachedGoals(ctx context.Context) {
+	goalService, err := initGoalService(ctx)
+	if err != nil {
+		fmt.Println(msg.T("goal.evaluate_failed", msg.ErrorMessage(err)))
+		return
+	}
+
+	reached, err := goalService.Evaluate(ctx)
+	if err != nil {
+		fmt.Println(msg.T("goal.evaluate_failed", msg.ErrorMessage(err)))
+	}
+	for _, progress := range reached {
+		fmt.Println(msg.T("goal.reached", progress.Goal.Title, progress.Current, progress.Goal.Target))
+	}
+}
+
+func init() {
+	// Add subcommands to goal command
+	goalCmd.AddCommand(goalCreateCmd)
+	goalCmd.AddCommand(goalListCmd)
+	goalCmd.AddCommand(goalGetCmd)
+	goalCmd.AddCommand(goalUpdateCmd)
+	goalCmd.AddCommand(goalDeleteCmd)
+
+	// Flags for create command
+	goalCreateCmd.Flags().String("title", "", "Goal title (required)")
+	goalCreateCmd.Flags().String("description", "", "Goal description")
+	goalCreateCmd.Flags().String("type", string(models.GoalTypePoints), "Goal type: points or achievements")
+	goalCreateCmd.Flags().Int("target", 0, "Number of points or achievements to reach (required)")
+	goalCreateCmd.MarkFlagRequired("title")
+	goalCreateCmd.MarkFlagRequired("target")
+
+	// Flags for get command
+	goalGetCmd.Flags().String("id", "", "Goal ID (required)")
+	goalGetCmd.MarkFlagRequired("id")
+
+	// Flags for update command
+	goalUpdateCmd.Flags().String("id", "", "Goal ID (required)")
+	goalUpdateCmd.Flags().String("title", "", "New goal title")
+	goalUpdateCmd.Flags().String("description", "", `New goal description (use --description "" to clear)`)
+	goalUpdateCmd.Flags().String("type", "", "New goal type: points or achievements")
+	goalUpdateCmd.Flags().Int("target", 0, "New number of points or achievements to reach")
+	goalUpdateCmd.MarkFlagRequired("id")
+
+	// Flags for delete command
+	goalDeleteCmd.Flags().String("id", "", "Goal ID (required)")
+	goalDeleteCmd.MarkFlagRequired("id")
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/infra"
+	"achievement-management/internal/repository"
+)
+
+// infraCmd represents the infra command
+var infraCmd = &cobra.Command{
+	Use:   "infra",
+	Short: "Manage infrastructure definitions",
+	Long:  `Generate infrastructure definitions that match the tables the application expects.`,
+}
+
+// infraGenerateCmd represents the infra generate command
+var infraGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate DynamoDB table definitions",
+	Long: `Generate CloudFormation or Terraform definitions for the DynamoDB tables
+(keys, global secondary indexes and TTL attributes) derived from the current configuration.
+
+Example:
+  achievement-app infra generate --format cloudformation
+  ENVIRONMENT=production achievement-app infra generate --format terraform -o dynamodb.tf`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		formatStr, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		format, err := infra.ParseFormat(formatStr)
+		if err != nil {
+			return msg.Wrap(err, "infra.invalid_format")
+		}
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return msg.Wrap(err, "infra.write_failed", output)
+			}
+			defer file.Close()
+			w = file
+		}
+
+		if err := infra.Generate(w, repository.TableDefinitions(cfg), format); err != nil {
+			return msg.Wrap(err, "infra.generate_failed")
+		}
+
+		if output != "" {
+			fmt.Println(msg.T("infra.written", output))
+		}
+
+		return nil
+	},
+}
+
+// infraBackfillCmd represents the infra backfill command
+var infraBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Add list index attributes to existing items",
+	Long: `Add the entity_type attribute to items created before list queries were introduced,
+so they appear in the creation-date indexes used by list commands.
+
+Example:
+  achievement-app infra backfill`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+		if err := requireDynamoDB(cfg); err != nil {
+			return err
+		}
+
+		repo, err := repository.NewDynamoDBRepository(cmd.Context(), cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_repository_failed")
+		}
+
+		updated, err := repository.BackfillEntityTypes(cmd.Context(), repo, cfg)
+		if err != nil {
+			return msg.Wrap(err, "infra.backfill_failed")
+		}
+
+		fmt.Println(msg.T("infra.backfilled", updated))
+		return nil
+	},
+}
+
+func init() {
+	infraGenerateCmd.Flags().String("format", "cloudformation", "Output format (cloudformation, terraform)")
+	infraGenerateCmd.Flags().StringP("output", "o", "", "Write the definitions to a file instead of stdout")
+
+	infraCmd.AddCommand(infraGenerateCmd)
+	infraCmd.AddCommand(infraBackfillCmd)
+}
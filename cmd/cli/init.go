@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// initCmd represents the init command
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Interactively set up the application",
+	Long: `Interactively set up the application for first use.
+
+The wizard asks for the storage driver and then the SQLite database path, the
+PostgreSQL connection string, or the AWS region, DynamoDB endpoint and table names. It writes the configuration
+file for the selected environment, optionally creates the DynamoDB tables, checks
+connectivity, and seeds an example achievement.
+
+Example:
+  achievement-app init
+  achievement-app init --env staging --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		env, _ := cmd.Flags().GetString("env")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+
+		p := newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+		ask := func(label, defaultValue string) string {
+			if assumeYes {
+				return defaultValue
+			}
+			return p.ask(label, defaultValue)
+		}
+		confirm := func(label string, defaultValue bool) bool {
+			if assumeYes {
+				return defaultValue
+			}
+			return p.confirm(label, defaultValue)
+		}
+
+		fmt.Println(msg.T("init.title"))
+		fmt.Printf("═══════════════════════════════\n")
+
+		env = ask(msg.T("init.ask_environment"), env)
+		cfg := config.NewDefaultConfig(env)
+
+		configPath := config.GetConfigPath(env)
+		if _, err := os.Stat(configPath); err == nil {
+			if !confirm(msg.T("init.confirm_overwrite", configPath), false) {
+				fmt.Println(msg.T("init.cancelled"))
+				return nil
+			}
+		}
+
+		// Storage
+		cfg.Storage.Driver = ask(msg.T("init.ask_storage_driver"), cfg.Storage.Driver)
+		switch cfg.Storage.Driver {
+		case config.StorageDriverSQLite:
+			cfg.Storage.SQLitePath = ask(msg.T("init.ask_sqlite_path"), cfg.Storage.SQLitePath)
+		case config.StorageDriverPostgres:
+			cfg.Storage.PostgresDSN = ask(msg.T("init.ask_postgres_dsn"), cfg.Storage.PostgresDSN)
+		case config.StorageDriverMemory:
+			// Nothing to configure; data lives only as long as the process
+		default:
+			// AWS settings
+			cfg.AWS.Region = ask(msg.T("init.ask_region"), cfg.AWS.Region)
+			cfg.AWS.DynamoDBEndpoint = ask(msg.T("init.ask_endpoint"), cfg.AWS.DynamoDBEndpoint)
+			if cfg.AWS.DynamoDBEndpoint == "" {
+				cfg.AWS.Profile = ask(msg.T("init.ask_profile"), cfg.AWS.Profile)
+			}
+
+			// Table names
+			cfg.Tables.Achievements = ask(msg.T("init.ask_achievements_table"), cfg.Tables.Achievements)
+			cfg.Tables.Rewards = ask(msg.T("init.ask_rewards_table"), cfg.Tables.Rewards)
+			cfg.Tables.CurrentPoints = ask(msg.T("init.ask_current_points_table"), cfg.Tables.CurrentPoints)
+			cfg.Tables.RewardHistory = ask(msg.T("init.ask_reward_history_table"), cfg.Tables.RewardHistory)
+			cfg.Tables.PointLedger = ask(msg.T("init.ask_point_ledger_table"), cfg.Tables.PointLedger)
+			cfg.Tables.Completions = ask(msg.T("init.ask_completions_table"), cfg.Tables.Completions)
+			cfg.Tables.Badges = ask(msg.T("init.ask_badges_table"), cfg.Tables.Badges)
+			cfg.Tables.Goals = ask(msg.T("init.ask_goals_table"), cfg.Tables.Goals)
+			cfg.Tables.Favorites = ask(msg.T("init.ask_favorites_table"), cfg.Tables.Favorites)
+			cfg.Tables.Wishlist = ask(msg.T("init.ask_wishlist_table"), cfg.Tables.Wishlist)
+			cfg.Tables.Notes = ask(msg.T("init.ask_notes_table"), cfg.Tables.Notes)
+			cfg.Tables.DriftEvents = ask(msg.T("init.ask_drift_events_table"), cfg.Tables.DriftEvents)
+			cfg.Tables.Reservations = ask(msg.T("init.ask_reservations_table"), cfg.Tables.Reservations)
+			cfg.Tables.Quests = ask(msg.T("init.ask_quests_table"), cfg.Tables.Quests)
+			cfg.Tables.Operations = ask(msg.T("init.ask_operations_table"), cfg.Tables.Operations)
+			cfg.Tables.Allowances = ask(msg.T("init.ask_allowances_table"), cfg.Tables.Allowances)
+			cfg.Tables.APIKeys = ask(msg.T("init.ask_api_keys_table"), cfg.Tables.APIKeys)
+		}
+
+		if err := config.ValidateConfig(cfg); err != nil {
+			return msg.Wrap(err, "init.invalid_config")
+		}
+
+		writtenPath, err := config.WriteConfigFile(cfg)
+		if err != nil {
+			return msg.Wrap(err, "init.write_config_failed")
+		}
+		fmt.Println(msg.T("init.config_written", writtenPath))
+
+		// SQL and in-memory storage need no tables to be created up front
+		ctx := cmd.Context()
+		if cfg.Storage.Driver == config.StorageDriverDynamoDB {
+			if err := setupDynamoDBTables(ctx, cfg, confirm); err != nil {
+				return err
+			}
+		}
+
+		if confirm(msg.T("init.confirm_seed"), true) {
+			achievementService, _, _, err := newServices(ctx, cfg)
+			if err != nil {
+				return msg.Wrap(err, "common.init_services_failed")
+			}
+
+			achievement := &models.Achievement{
+				Title:       msg.T("init.seed_title"),
+				Description: msg.T("init.seed_description"),
+				Point:       10,
+				CreatedAt:   time.Now(),
+			}
+			if err := achievementService.Create(cmd.Context(), achievement); err != nil {
+				return msg.Wrap(err, "init.seed_failed")
+			}
+			fmt.Println(msg.T("init.seeded", achievement.ID))
+		}
+
+		fmt.Println()
+		fmt.Println(msg.T("init.complete", cfg.Environment))
+
+		return nil
+	},
+}
+
+// setupDynamoDBTables optionally creates the DynamoDB tables and checks that they are reachable
+func setupDynamoDBTables(ctx context.Context, cfg *config.Config, confirm func(label string, defaultValue bool) bool) error {
+	client, err := repository.NewDynamoDBClient(ctx, cfg)
+	if err != nil {
+		return msg.Wrap(err, "init.client_failed")
+	}
+
+	tableManager := repository.NewTableManager(client)
+	tables := repository.TableDefinitions(cfg)
+
+	if confirm(msg.T("init.confirm_create_tables"), false) {
+		created, err := tableManager.CreateTables(ctx, tables)
+		if err != nil {
+			return msg.Wrap(err, "init.create_tables_failed")
+		}
+		if len(created) == 0 {
+			fmt.Println(msg.T("init.tables_exist"))
+		}
+		for _, name := range created {
+			fmt.Println(msg.T("init.table_created", name))
+		}
+	}
+
+	fmt.Println(msg.T("init.checking_connectivity"))
+	if err := tableManager.CheckTables(ctx, tables); err != nil {
+		return msg.Wrap(err, "init.connectivity_failed")
+	}
+	fmt.Println(msg.T("init.tables_reachable"))
+	return nil
+}
+
+func init() {
+	initCmd.Flags().String("env", "development", "Environment to configure (development, staging, production)")
+	initCmd.Flags().BoolP("yes", "y", false, "Accept all defaults without prompting")
+}
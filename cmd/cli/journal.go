@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the most recent achievement or reward change",
+	Long: `Revert the most recent create, update or delete of an achievement or reward
+by applying the inverse operation. Running undo again reverts the change before
+that, up to the configured journal size.
+
+An operation is not reverted if the achievement or reward has changed since.
+
+Example:
+  achievement-app undo`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		journalService, err := initJournalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		operation, err := journalService.Undo(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "journal.undo_failed")
+		}
+
+		fmt.Println(msg.T("journal.undone", operationLabel(operation)))
+		return nil
+	},
+}
+
+// redoCmd represents the redo command
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Re-apply the most recently undone change",
+	Long: `Re-apply the change most recently reverted by undo. Changes can no longer be
+redone once another achievement or reward change is made.
+
+Example:
+  achievement-app redo`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		journalService, err := initJournalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		operation, err := journalService.Redo(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "journal.redo_failed")
+		}
+
+		fmt.Println(msg.T("journal.redone", operationLabel(operation)))
+		return nil
+	},
+}
+
+// journalCmd represents the journal command
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "List the changes that can be undone or redone",
+	Long: `List the recorded achievement and reward changes, most recent first.
+Changes that have been undone are marked and can be redone.
+
+Example:
+  achievement-app journal`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		journalService, err := initJournalService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		operations, err := journalService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "journal.list_failed")
+		}
+
+		if len(operations) == 0 {
+			fmt.Println(msg.T("journal.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("journal.found", len(operations)))
+		for i := len(operations) - 1; i >= 0; i-- {
+			operation := operations[i]
+			fmt.Println(msg.T("journal.item", operation.CreatedAt.Format("2006-01-02 15:04:05"), operationLabel(operation)))
+			if operation.UndoneAt != nil {
+				fmt.Println(msg.T("journal.item_undone", operation.UndoneAt.Format("2006-01-02 15:04:05")))
+			}
+		}
+
+		return nil
+	},
+}
+
+// operationLabel describes an operation as "<action> <entity> <id>"
+func operationLabel(operation *models.Operation) string {
+	return fmt.Sprintf("%s %s %s", operation.Action, operation.Entity, operation.EntityID)
+}
+
+// initJournalService initializes the journal service with the configured storage
+func initJournalService(ctx context.Context) (services.JournalService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return newJournalService(cfg, repos), nil
+}
+
+// newJournalService creates the journal that records achievement and reward changes for undo
+func newJournalService(cfg *config.Config, repos *storage.Repositories) services.JournalService {
+	return services.NewJournalService(repos.Operations, repos.Achievements, repos.Rewards, cfg.Journal.Size)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/handlers"
+	"achievement-management/internal/logging"
+)
+
+// logLevelCmd represents the log-level command
+var logLevelCmd = &cobra.Command{
+	Use:   "log-level",
+	Short: "Show or change the log level of a running API server",
+	Long: `Show or change the log level of a running API server without restarting it.
+
+The server must be started with logging.admin_token (LOGGING_ADMIN_TOKEN) set.
+The same token is read from the configuration unless --token is given, and the
+server is expected on localhost at server.port unless --server is given.
+
+Only the application log changes; the access log and error log keep their
+levels.`,
+}
+
+// logLevelShowCmd represents the log-level show command
+var logLevelShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the current log level",
+	Long: `Show the current log level, the configured level and when a temporary
+change reverts.
+
+Example:
+  achievement-app log-level show`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := requestLogLevel(cmd, http.MethodGet, nil)
+		if err != nil {
+			return msg.Wrap(err, "log_level.request_failed")
+		}
+		printLogLevel(status)
+		return nil
+	},
+}
+
+// logLevelSetCmd represents the log-level set command
+var logLevelSetCmd = &cobra.Command{
+	Use:   "set <level>",
+	Short: "Change the log level",
+	Long: `Change the log level to debug, info, warn or error. With --for, the configured
+level is restored after the given duration; otherwise the level stays until
+"log-level reset" or a restart.
+
+Example:
+  achievement-app log-level set debug --for 10m`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		duration, _ := cmd.Flags().GetDuration("for")
+		if duration < 0 {
+			return msg.NewError("log_level.invalid_duration")
+		}
+
+		status, err := requestLogLevel(cmd, http.MethodPut, handlers.LogLevelRequest{
+			Level:           args[0],
+			DurationSeconds: int(duration.Round(time.Second).Seconds()),
+		})
+		if err != nil {
+			return msg.Wrap(err, "log_level.set_failed")
+		}
+		fmt.Println(msg.T("log_level.changed"))
+		printLogLevel(status)
+		return nil
+	},
+}
+
+// logLevelResetCmd represents the log-level reset command
+var logLevelResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Restore the configured log level",
+	Long: `Restore the configured log level and cancel any pending revert.
+
+Example:
+  achievement-app log-level reset`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := requestLogLevel(cmd, http.MethodDelete, nil)
+		if err != nil {
+			return msg.Wrap(err, "log_level.set_failed")
+		}
+		fmt.Println(msg.T("log_level.reset"))
+		printLogLevel(status)
+		return nil
+	},
+}
+
+// requestLogLevel calls the log level admin endpoint of the running server
+func requestLogLevel(cmd *cobra.Command, method string, body interface{}) (*logging.LevelStatus, error) {
+	server, _ := cmd.Flags().GetString("server")
+	token, _ := cmd.Flags().GetString("token")
+	if server == "" || token == "" {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return nil, msg.Wrap(err, "common.load_config_failed")
+		}
+		if server == "" {
+			server = "http://localhost:" + cfg.Server.Port
+		}
+		if token == "" {
+			token = cfg.Logging.AdminToken
+		}
+	}
+	if token == "" {
+		return nil, msg.NewError("log_level.token_required")
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(cmd.Context(), method, strings.TrimRight(server, "/")+"/admin/log-level", reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp handlers.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Message != "" {
+			return nil, fmt.Errorf("%s (status %d)", errResp.Message, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("server responded with status %d", resp.StatusCode)
+	}
+
+	var status logging.LevelStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// printLogLevel prints the current log level and when it reverts
+func printLogLevel(status *logging.LevelStatus) {
+	fmt.Println(msg.T("log_level.status", status.Level, status.DefaultLevel))
+	if status.RevertAt != nil {
+		fmt.Println(msg.T("log_level.revert_at", status.DefaultLevel, status.RevertAt.Local().Format("2006-01-02 15:04:05")))
+	}
+}
+
+func init() {
+	logLevelCmd.AddCommand(logLevelShowCmd)
+	logLevelCmd.AddCommand(logLevelSetCmd)
+	logLevelCmd.AddCommand(logLevelResetCmd)
+
+	logLevelCmd.PersistentFlags().String("server", "", "URL of the API server (default http://localhost:<server.port>)")
+	logLevelCmd.PersistentFlags().String("token", "", "Admin token (default logging.admin_token)")
+
+	logLevelSetCmd.Flags().Duration("for", 0, "Restore the configured level after this duration (e.g. 10m)")
+}
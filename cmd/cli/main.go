@@ -5,12 +5,20 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"os/user"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"achievement-management/internal/config"
-	"achievement-management/internal/repository"
+	"achievement-management/internal/events"
+	"achievement-management/internal/i18n"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/notifications"
 	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+	"achievement-management/internal/tenant"
 )
 
 // Version information (set by build flags)
@@ -24,8 +32,13 @@ var (
 	cfgFile   string
 	logLevel  string
 	verbose   bool
+	lang      string
+	tenantID  string
 )
 
+// msg localizes CLI output and errors
+var msg = i18n.NewLocalizer(i18n.DetectLang())
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "achievement-app",
@@ -36,12 +49,41 @@ A command-line interface for managing achievements, rewards, and points.
 This tool allows you to create, update, list, and delete achievements and rewards,
 as well as manage points and view aggregation reports.`,
 	Version: fmt.Sprintf("%s (built: %s, commit: %s)", Version, BuildTime, CommitHash),
+	// Errors are printed by Execute so that they can be localized
+	SilenceErrors: true,
+	// Commands operate on the tenant selected by --tenant
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if tenantID == "" {
+			return nil
+		}
+		if err := tenant.Validate(tenantID); err != nil {
+			return msg.NewError("common.invalid_tenant", tenantID)
+		}
+		cmd.SetContext(tenant.WithID(cmd.Context(), tenantID))
+		return nil
+	},
+}
+
+// cliActor identifies the OS user running the CLI in the audit log
+func cliActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "cli:" + u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return "cli:" + name
+	}
+	return "cli"
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// Commands receive a context that is cancelled on Ctrl+C.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(logging.WithActor(context.Background(), cliActor()), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	flushNotifiers()
 	if err != nil {
+		fmt.Fprintln(os.Stderr, msg.T("error.prefix", msg.ErrorMessage(err)))
 		os.Exit(1)
 	}
 }
@@ -50,48 +92,148 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.achievement-app.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file in JSON or YAML (default is config/{env}.json or .yaml, then $HOME/.achievement-app.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "output language (ja, en); detected from LANG when omitted")
+	rootCmd.PersistentFlags().StringVar(&tenantID, "tenant", "", "tenant to operate on when tables are shared by several families or teams (default tenant when omitted)")
 
 	// Add subcommands
 	rootCmd.AddCommand(achievementCmd)
 	rootCmd.AddCommand(rewardCmd)
 	rootCmd.AddCommand(pointsCmd)
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(infraCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(streamsCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(badgeCmd)
+	rootCmd.AddCommand(goalCmd)
+	rootCmd.AddCommand(questCmd)
+	rootCmd.AddCommand(allowanceCmd)
+	rootCmd.AddCommand(apiKeyCmd)
+	rootCmd.AddCommand(wishlistCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(reminderCmd)
+	rootCmd.AddCommand(summaryCmd)
+	rootCmd.AddCommand(consistencyCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+	rootCmd.AddCommand(journalCmd)
+	rootCmd.AddCommand(promoteCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(logLevelCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	if lang != "" {
+		parsed, err := i18n.ParseLang(lang)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, msg.T("error.prefix", err))
+			os.Exit(1)
+		}
+		msg = i18n.NewLocalizer(parsed)
+	}
+
 	if verbose {
 		log.SetOutput(os.Stdout)
 	}
+
+	// --config replaces the per-environment config file lookup
+	config.SetConfigFile(cfgFile)
 }
 
-// initServices initializes the services with DynamoDB repository
-func initServices() (services.AchievementService, services.RewardService, services.PointService, error) {
+// initServices initializes the services with the configured storage
+func initServices(ctx context.Context) (services.AchievementService, services.RewardService, services.PointService, error) {
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+		return nil, nil, nil, msg.Wrap(err, "common.load_config_failed")
 	}
-	
-	// Initialize DynamoDB repository
-	repo, err := repository.NewDynamoDBRepository(context.Background(), cfg)
+
+	return newServices(ctx, cfg)
+}
+
+// newServices initializes the services from the given configuration
+func newServices(ctx context.Context, cfg *config.Config) (services.AchievementService, services.RewardService, services.PointService, error) {
+	// Initialize repositories for the configured storage driver
+	repos, err := storage.Open(ctx, cfg)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to initialize repository: %w", err)
+		return nil, nil, nil, msg.Wrap(err, "common.init_repository_failed")
 	}
 
-	// Initialize services
-	achievementRepo := repository.NewAchievementRepository(repo, cfg)
-	rewardRepo := repository.NewRewardRepository(repo, cfg)
-	pointRepo := repository.NewPointRepository(repo, cfg)
+	// Initialize services; creates, updates and deletes are journaled so that "undo" can revert them
+	journalService := newJournalService(cfg, repos)
+	achievementService := services.NewJournaledAchievementService(services.NewAchievementServiceWithLimits(repos.Achievements, repos.Points, streakSettings(cfg), limits(cfg)), journalService)
+	rewardService := services.NewJournaledRewardService(services.NewRewardServiceWithReservations(repos.Rewards, repos.Points, repos.Reservations, cfg.Refunds.Window(), limits(cfg)), journalService)
+	pointService := services.NewPointService(repos.Points, repos.Achievements)
 
-	achievementService := services.NewAchievementService(achievementRepo, pointRepo)
-	rewardService := services.NewRewardService(rewardRepo, pointRepo)
-	pointService := services.NewPointService(pointRepo, achievementRepo)
+	// Post created achievements and redemptions to the configured notification channels
+	notifier, err := newNotifier(ctx, cfg)
+	if err != nil {
+		return nil, nil, nil, msg.Wrap(err, "common.init_notifications_failed")
+	}
+	achievementService = services.NewNotifyingAchievementService(achievementService, notifier)
+	rewardService = services.NewNotifyingRewardService(rewardService, repos.Points, notifier, cfg.Notifications.LowBalanceThreshold)
 
 	return achievementService, rewardService, pointService, nil
 }
 
+// notifiers are flushed before the CLI exits so that events batched for SNS/SQS are not lost
+var notifiers []events.Publisher
+
+// newNotifier creates the publisher for the configured notification channels
+// and remembers it so that Execute can flush it on exit
+func newNotifier(ctx context.Context, cfg *config.Config) (events.Publisher, error) {
+	notifier, err := notifications.New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if notifier != nil {
+		notifiers = append(notifiers, notifier)
+	}
+	return notifier, nil
+}
+
+// flushNotifiers sends the events still batched for SNS/SQS
+func flushNotifiers() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, notifier := range notifiers {
+		if err := notifications.Flush(ctx, notifier); err != nil {
+			fmt.Fprintln(os.Stderr, msg.T("error.prefix", err))
+		}
+	}
+}
+
+// streakSettings returns how streaks are counted and which milestones earn a bonus
+func streakSettings(cfg *config.Config) services.StreakSettings {
+	return services.StreakSettings{
+		Location: cfg.Streaks.Location(),
+		Bonuses:  cfg.Streaks.Bonuses(),
+	}
+}
+
+// limits converts the points configuration into the input limits of the services
+func limits(cfg *config.Config) services.Limits {
+	return services.Limits{
+		MaxPoint:   cfg.Points.MaxPoint,
+		DailyQuota: cfg.Points.DailyQuota,
+		OverQuota:  services.OverQuotaAction(cfg.Points.OverQuota),
+	}
+}
+
+// requireDynamoDB rejects commands that manage DynamoDB tables when another storage driver is configured
+func requireDynamoDB(cfg *config.Config) error {
+	if cfg.Storage.Driver != config.StorageDriverDynamoDB {
+		return msg.NewError("common.dynamodb_only", cfg.Storage.Driver)
+	}
+	return nil
+}
+
 func main() {
 	Execute()
 }
\ No newline at end of file
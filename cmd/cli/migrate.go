@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/migrations"
+	"achievement-management/internal/repository"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage schema migrations",
+	Long: `Apply and inspect schema migrations (new indexes, attribute backfills, TTL settings).
+Applied migrations are recorded in DynamoDB, so running "migrate up" after upgrading
+the application only applies the changes introduced since the last run.`,
+}
+
+// migrateUpCmd represents the migrate up command
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations",
+	Long: `Apply all pending migrations in order.
+
+Example:
+  achievement-app migrate up`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := newMigrator(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		applied, err := migrator.Up(cmd.Context())
+		for _, migration := range applied {
+			fmt.Println(msg.T("migrate.applied", migration.ID, migration.Description))
+		}
+		if err != nil {
+			return msg.Wrap(err, "migrate.up_failed")
+		}
+
+		if len(applied) == 0 {
+			fmt.Println(msg.T("migrate.up_to_date"))
+		}
+		return nil
+	},
+}
+
+// migrateStatusCmd represents the migrate status command
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Long: `Show every migration and whether it has been applied.
+
+Example:
+  achievement-app migrate status`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := newMigrator(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		statuses, err := migrator.Status(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "migrate.status_failed")
+		}
+
+		pending := 0
+		for _, status := range statuses {
+			if status.Applied {
+				fmt.Println(msg.T("migrate.status_applied", status.Migration.ID, status.AppliedAt.Format("2006-01-02 15:04:05")))
+			} else {
+				fmt.Println(msg.T("migrate.status_pending", status.Migration.ID))
+				pending++
+			}
+			fmt.Println(msg.T("migrate.description", status.Migration.Description))
+		}
+
+		fmt.Println()
+		fmt.Println(msg.T("migrate.pending_count", pending))
+		return nil
+	},
+}
+
+// newMigrator initializes the migrator with the DynamoDB repository and table manager
+func newMigrator(ctx context.Context) (*migrations.Migrator, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+	if err := requireDynamoDB(cfg); err != nil {
+		return nil, err
+	}
+
+	repo, err := repository.NewDynamoDBRepository(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	client, err := repository.NewDynamoDBClient(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	env := migrations.Env{
+		Config: cfg,
+		Repo:   repo,
+		Tables: repository.NewTableManager(client),
+	}
+	return migrations.NewMigrator(env, migrations.All()), nil
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+}
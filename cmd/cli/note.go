@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// noteTargetFlags maps each target flag to the kind of record it selects
+var noteTargetFlags = []struct {
+	flag       string
+	targetType models.NoteTargetType
+}{
+	{"achievement", models.NoteTargetAchievement},
+	{"reward", models.NoteTargetReward},
+	{"redemption", models.NoteTargetRedemption},
+}
+
+// noteCmd represents the note command
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Manage notes on achievements, rewards and redemptions",
+	Long: `Manage free-form notes attached to records, such as "redeemed for birthday dinner".
+
+Every subcommand selects the record with exactly one of --achievement,
+--reward or --redemption (a redemption history ID shown by "points history").
+Notes are listed in the order they were written.`,
+}
+
+// noteAddCmd represents the note add command
+var noteAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Attach a note to a record",
+	Long: `Attach a note to an achievement, a reward or a redemption history entry.
+
+Example:
+  achievement-app note add --redemption "01234567890" --body "Redeemed for birthday dinner"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetType, targetID, err := noteTarget(cmd)
+		if err != nil {
+			return err
+		}
+		body, _ := cmd.Flags().GetString("body")
+
+		noteService, err := initNoteService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		note := &models.Note{TargetType: targetType, TargetID: targetID, Body: body}
+		if err := noteService.Add(cmd.Context(), note); err != nil {
+			return msg.Wrap(err, "note.add_failed")
+		}
+
+		fmt.Println(msg.T("note.added"))
+		fmt.Println(msg.T("label.id", note.ID))
+		fmt.Println(msg.T("note.body", note.Body))
+		fmt.Println(msg.T("label.created", note.CreatedAt.Format("2006-01-02 15:04:05")))
+
+		return nil
+	},
+}
+
+// noteListCmd represents the note list command
+var noteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the notes on a record",
+	Long: `List the notes attached to an achievement, a reward or a redemption history entry.
+
+Example:
+  achievement-app note list --achievement "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetType, targetID, err := noteTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		noteService, err := initNoteService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		notes, err := noteService.List(cmd.Context(), targetType, targetID)
+		if err != nil {
+			return msg.Wrap(err, "note.list_failed")
+		}
+
+		if len(notes) == 0 {
+			fmt.Println(msg.T("note.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("note.found", len(notes)))
+		for _, note := range notes {
+			fmt.Println(msg.T("note.item", note.CreatedAt.Format("2006-01-02 15:04:05"), note.ID))
+			fmt.Println(msg.T("note.item_body", note.Body))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// noteDeleteCmd represents the note delete command
+var noteDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a note from a record",
+	Long: `Delete a note by ID. The record the note is attached to must be given as well.
+
+Example:
+  achievement-app note delete --reward "01234567890" --id "01J2Z3V4W5X6Y7Z8A9BCDEFGHJ"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetType, targetID, err := noteTarget(cmd)
+		if err != nil {
+			return err
+		}
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		noteService, err := initNoteService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if err := noteService.Delete(cmd.Context(), targetType, targetID, id); err != nil {
+			return msg.Wrap(err, "note.delete_failed")
+		}
+
+		fmt.Println(msg.T("note.deleted"))
+		fmt.Println(msg.T("label.id", id))
+
+		return nil
+	},
+}
+
+// noteTarget returns the record selected by the target flags, requiring exactly one of them
+func noteTarget(cmd *cobra.Command) (models.NoteTargetType, string, error) {
+	var targetType models.NoteTargetType
+	var targetID string
+	for _, target := range noteTargetFlags {
+		if !cmd.Flags().Changed(target.flag) {
+			continue
+		}
+		if targetType != "" {
+			return "", "", msg.NewError("note.target_required")
+		}
+		targetType = target.targetType
+		targetID, _ = cmd.Flags().GetString(target.flag)
+	}
+	if targetType == "" || targetID == "" {
+		return "", "", msg.NewError("note.target_required")
+	}
+	return targetType, targetID, nil
+}
+
+// initNoteService initializes the note service with the configured storage
+func initNoteService(ctx context.Context) (services.NoteService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewNoteService(repos.Notes, repos.Achievements, repos.Rewards, repos.Points), nil
+}
+
+func init() {
+	// Add subcommands to note command
+	noteCmd.AddCommand(noteAddCmd)
+	noteCmd.AddCommand(noteListCmd)
+	noteCmd.AddCommand(noteDeleteCmd)
+
+	// Every subcommand selects the record the notes belong to
+	for _, cmd := range []*cobra.Command{noteAddCmd, noteListCmd, noteDeleteCmd} {
+		cmd.Flags().String("achievement", "", "Achievement ID")
+		cmd.Flags().String("reward", "", "Reward ID")
+		cmd.Flags().String("redemption", "", "Redemption history ID")
+	}
+
+	// Flags for add command
+	noteAddCmd.Flags().String("body", "", "Note text (required)")
+	noteAddCmd.MarkFlagRequired("body")
+
+	// Flags for delete command
+	noteDeleteCmd.Flags().String("id", "", "Note ID (required)")
+	noteDeleteCmd.MarkFlagRequired("id")
+}
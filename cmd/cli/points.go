@@ -2,8 +2,12 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"achievement-management/internal/models"
 )
 
 // pointsCmd represents the points command
@@ -24,19 +28,19 @@ var pointsCurrentCmd = &cobra.Command{
 Example:
   achievement-app points current`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		_, _, pointService, err := initServices()
+		_, _, pointService, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
-		currentPoints, err := pointService.GetCurrentPoints()
+		currentPoints, err := pointService.GetCurrentPoints(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to get current points: %w", err)
+			return msg.Wrap(err, "points.get_failed")
 		}
 
-		fmt.Printf("💰 Current Point Balance\n")
-		fmt.Printf("Points: %d\n", currentPoints.Point)
-		fmt.Printf("Last Updated: %s\n", currentPoints.UpdatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Println(msg.T("points.current_title"))
+		fmt.Println(msg.T("label.points", currentPoints.Point))
+		fmt.Println(msg.T("points.last_updated", currentPoints.UpdatedAt.Format("2006-01-02 15:04:05")))
 
 		return nil
 	},
@@ -47,35 +51,48 @@ var pointsAggregateCmd = &cobra.Command{
 	Use:   "aggregate",
 	Short: "Show point aggregation summary",
 	Long: `Show a summary of points aggregated from all achievements and compare with current balance.
+The points and number of achievements in each category are listed, largest first.
 
 Example:
   achievement-app points aggregate`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		_, _, pointService, err := initServices()
+		_, _, pointService, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
-		summary, err := pointService.AggregatePoints()
+		summary, err := pointService.AggregatePoints(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to aggregate points: %w", err)
+			return msg.Wrap(err, "points.aggregate_failed")
 		}
 
-		fmt.Printf("📊 Point Aggregation Summary\n")
+		fmt.Println(msg.T("points.aggregate_title"))
 		fmt.Printf("═══════════════════════════════\n")
-		fmt.Printf("Total Achievements: %d\n", summary.TotalAchievements)
-		fmt.Printf("Total Points from Achievements: %d\n", summary.TotalPoints)
-		fmt.Printf("Current Balance: %d\n", summary.CurrentBalance)
-		fmt.Printf("Difference: %d\n", summary.Difference)
+		fmt.Println(msg.T("points.total_achievements", summary.TotalAchievements))
+		fmt.Println(msg.T("points.total_points", summary.TotalPoints))
+		fmt.Println(msg.T("points.current_balance", summary.CurrentBalance))
+		fmt.Println(msg.T("points.difference", summary.Difference))
 
 		if summary.Difference == 0 {
-			fmt.Printf("✅ Points are in sync!\n")
+			fmt.Println(msg.T("points.in_sync"))
 		} else if summary.Difference > 0 {
-			fmt.Printf("⚠️  Current balance is %d points higher than expected.\n", summary.Difference)
-			fmt.Printf("   This might indicate a data inconsistency.\n")
+			fmt.Println(msg.T("points.higher_than_expected", summary.Difference))
+			fmt.Println(msg.T("points.higher_note"))
 		} else {
-			fmt.Printf("⚠️  Current balance is %d points lower than expected.\n", -summary.Difference)
-			fmt.Printf("   This is normal if rewards have been redeemed.\n")
+			fmt.Println(msg.T("points.lower_than_expected", -summary.Difference))
+			fmt.Println(msg.T("points.lower_note"))
+		}
+
+		if len(summary.Categories) > 0 {
+			fmt.Println()
+			fmt.Println(msg.T("points.categories_title"))
+			for _, category := range summary.Categories {
+				name := category.Category
+				if name == "" {
+					name = msg.T("points.uncategorized")
+				}
+				fmt.Println(msg.T("points.category_line", name, category.Earned, category.Count))
+			}
 		}
 
 		return nil
@@ -88,32 +105,174 @@ var pointsHistoryCmd = &cobra.Command{
 	Short: "Show reward redemption history",
 	Long: `Show the history of reward redemptions.
 
+--from and --to limit the history to redemptions made on or after --from and
+before --to (dates are YYYY-MM-DD in local time).
+
 Example:
-  achievement-app points history`,
+  achievement-app points history
+  achievement-app points history --from 2024-06-01 --to 2024-07-01`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		_, _, pointService, err := initServices()
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+
+		from, err := parseDateFlag(fromStr)
+		if err != nil {
+			return err
+		}
+		to, err := parseDateFlag(toStr)
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return err
 		}
 
-		history, err := pointService.GetRewardHistory()
+		_, _, pointService, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to get reward history: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
-		fmt.Printf("📜 Reward Redemption History\n")
+		history, err := pointService.GetRewardHistoryBetween(cmd.Context(), from, to)
+		if err != nil {
+			return msg.Wrap(err, "points.history_failed")
+		}
+
+		fmt.Println(msg.T("points.history_title"))
 		fmt.Printf("═══════════════════════════════\n")
 
 		if len(history) == 0 {
-			fmt.Println("No reward redemptions found.")
+			fmt.Println(msg.T("points.history_none"))
 			return nil
 		}
 
-		fmt.Printf("Found %d redemption(s):\n\n", len(history))
+		fmt.Printf("%s\n\n", msg.T("points.history_found", len(history)))
 		for i, record := range history {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, record.RewardTitle, record.RewardID)
-			fmt.Printf("   Points Used: %d\n", record.PointCost)
-			fmt.Printf("   Redeemed: %s\n", record.RedeemedAt.Format("2006-01-02 15:04:05"))
+			fmt.Println(msg.T("list.item", i+1, record.RewardTitle, record.RewardID))
+			fmt.Println(msg.T("points.history_points_used", record.PointCost))
+			if record.Prize != "" {
+				fmt.Println(msg.T("points.history_prize", record.Prize))
+			}
+			fmt.Println(msg.T("points.history_id", record.ID))
+			fmt.Println(msg.T("points.history_redeemed", record.RedeemedAt.Format("2006-01-02 15:04:05")))
+			if record.RefundedAt != nil {
+				fmt.Println(msg.T("points.history_refunded", record.RefundedAt.Format("2006-01-02 15:04:05")))
+			}
+			if record.Note != "" {
+				fmt.Println(msg.T("points.history_note", record.Note))
+			}
+			if len(record.Tags) > 0 {
+				fmt.Println(msg.T("points.history_tags", strings.Join(record.Tags, ", ")))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// pointsAnnotateCmd represents the points annotate command
+var pointsAnnotateCmd = &cobra.Command{
+	Use:   "annotate",
+	Short: "Annotate a reward redemption",
+	Long: `Set the note and tags of a reward redemption by its history ID (shown by "points history").
+Only the flags you pass are changed; pass an empty value to clear them.
+The points of the redemption cannot be changed.
+
+Example:
+  achievement-app points annotate --id "01234567890" --note "Movie night with friends"
+  achievement-app points annotate --id "01234567890" --tags family,weekend
+  achievement-app points annotate --id "01234567890" --tags ""`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		id, _ := flags.GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+		if !flags.Changed("note") && !flags.Changed("tags") {
+			return msg.NewError("points.annotate_nothing")
+		}
+
+		var annotation models.RedemptionAnnotation
+		if flags.Changed("note") {
+			note, _ := flags.GetString("note")
+			annotation.Note = &note
+		}
+		if flags.Changed("tags") {
+			tags, _ := flags.GetStringSlice("tags")
+			annotation.Tags = &tags
+		}
+
+		_, _, pointService, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		_, history, err := pointService.AnnotateRedemption(cmd.Context(), id, annotation)
+		if err != nil {
+			return msg.Wrap(err, "points.annotate_failed")
+		}
+
+		fmt.Println(msg.T("points.annotated"))
+		fmt.Println(msg.T("reward.label", history.RewardTitle))
+		if history.Note != "" {
+			fmt.Println(msg.T("points.history_note", history.Note))
+		}
+		if len(history.Tags) > 0 {
+			fmt.Println(msg.T("points.history_tags", strings.Join(history.Tags, ", ")))
+		}
+
+		return nil
+	},
+}
+
+// parseDateFlag parses a YYYY-MM-DD flag value in local time; an empty value yields the zero time
+func parseDateFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, msg.Wrap(err, "common.invalid_date", value)
+	}
+	return parsed, nil
+}
+
+// pointsLedgerCmd represents the points ledger command
+var pointsLedgerCmd = &cobra.Command{
+	Use:   "ledger",
+	Short: "Show the point ledger",
+	Long: `Show every recorded point change (grants, spends and adjustments) in order.
+
+The sum of all entries equals the current balance, except for points that
+were recorded before the ledger was introduced.
+
+Example:
+  achievement-app points ledger`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, _, pointService, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		entries, err := pointService.GetLedger(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "points.ledger_failed")
+		}
+
+		fmt.Println(msg.T("points.ledger_title"))
+		fmt.Printf("═══════════════════════════════\n")
+
+		if len(entries) == 0 {
+			fmt.Println(msg.T("points.ledger_none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("points.ledger_found", len(entries)))
+		for i, entry := range entries {
+			fmt.Println(msg.T("points.ledger_entry", i+1, msg.T("points.ledger_type."+entry.Type), entry.Amount))
+			if entry.Reference != "" {
+				fmt.Println(msg.T("points.ledger_reference", entry.Reference))
+			}
+			fmt.Println(msg.T("points.ledger_recorded", entry.CreatedAt.Format("2006-01-02 15:04:05")))
 			fmt.Println()
 		}
 
@@ -126,4 +285,13 @@ func init() {
 	pointsCmd.AddCommand(pointsCurrentCmd)
 	pointsCmd.AddCommand(pointsAggregateCmd)
 	pointsCmd.AddCommand(pointsHistoryCmd)
+	pointsCmd.AddCommand(pointsLedgerCmd)
+	pointsCmd.AddCommand(pointsAnnotateCmd)
+
+	pointsHistoryCmd.Flags().String("from", "", "Only show redemptions on or after this date (YYYY-MM-DD)")
+	pointsHistoryCmd.Flags().String("to", "", "Only show redemptions before this date (YYYY-MM-DD)")
+
+	pointsAnnotateCmd.Flags().String("id", "", "Reward history ID (required)")
+	pointsAnnotateCmd.Flags().String("note", "", "What the redemption was used for")
+	pointsAnnotateCmd.Flags().StringSlice("tags", nil, "Comma-separated tags")
 }
\ No newline at end of file
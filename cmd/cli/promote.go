@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// promoteCmd represents the promote command
+var promoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Copy achievement and reward definitions to another environment",
+	Long: `Copy achievement and reward definitions from one environment's tables to
+another's, for example from staging to production. Completions, reward history
+and points are not copied, and creating or updating an achievement in the target
+does not change its points.
+
+Definitions are matched with the target by ID (--match id, created with the same
+ID) or by title (--match title, created with a new ID). A matched definition with
+different content is kept as is (--on-conflict skip) or overwritten
+(--on-conflict overwrite).
+
+The changes are listed and confirmed before anything is written.
+
+Example:
+  achievement-app promote --from staging --to production --all --dry-run
+  achievement-app promote --from staging --to production --achievements 01ARZ3NDEKTSV4RRFFQ69G5FAV
+  achievement-app promote --from staging --to production --all --match title --on-conflict overwrite --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		achievementIDs, _ := cmd.Flags().GetStringSlice("achievements")
+		rewardIDs, _ := cmd.Flags().GetStringSlice("rewards")
+		all, _ := cmd.Flags().GetBool("all")
+		match, _ := cmd.Flags().GetString("match")
+		conflict, _ := cmd.Flags().GetString("on-conflict")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+
+		sourceCfg, err := config.LoadConfigForEnvironment(from)
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+		targetCfg, err := config.LoadConfigForEnvironment(to)
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+		if sameStorage(sourceCfg, targetCfg) {
+			return msg.NewError("promotion.same_storage", from, to)
+		}
+
+		source, err := storage.Open(cmd.Context(), sourceCfg)
+		if err != nil {
+			return msg.Wrap(err, "promotion.open_failed", from)
+		}
+		defer source.Close()
+		target, err := storage.Open(cmd.Context(), targetCfg)
+		if err != nil {
+			return msg.Wrap(err, "promotion.open_failed", to)
+		}
+		defer target.Close()
+
+		promotionService := services.NewPromotionService(source.Achievements, source.Rewards, target.Achievements, target.Rewards)
+		opts := models.PromotionOptions{
+			AchievementIDs: achievementIDs,
+			RewardIDs:      rewardIDs,
+			All:            all,
+			Match:          models.PromotionMatch(match),
+			Conflict:       models.PromotionConflict(conflict),
+			DryRun:         true,
+		}
+
+		// List what would change before anything is written
+		plan, err := promotionService.Promote(cmd.Context(), opts)
+		if err != nil {
+			return msg.Wrap(err, "promotion.failed", from, to)
+		}
+		printPromotion(plan)
+
+		writes := promotionWrites(plan)
+		if dryRun || writes == 0 {
+			fmt.Println(msg.T("promotion.nothing_written", to))
+			return nil
+		}
+		if !assumeYes {
+			p := newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+			if !p.confirm(msg.T("promotion.confirm", writes, to), false) {
+				fmt.Println(msg.T("common.cancelled"))
+				return nil
+			}
+		}
+
+		opts.DryRun = false
+		result, err := promotionService.Promote(cmd.Context(), opts)
+		if err != nil {
+			return msg.Wrap(err, "promotion.failed", from, to)
+		}
+		fmt.Println(msg.T("promotion.done", promotionWrites(result), from, to))
+		return nil
+	},
+}
+
+// printPromotion prints the action taken (or to be taken) for each definition
+func printPromotion(result *models.PromotionResult) {
+	if len(result.Items) == 0 {
+		fmt.Println(msg.T("promotion.none"))
+		return
+	}
+	for _, item := range result.Items {
+		targetID := item.TargetID
+		if targetID == "" {
+			targetID = msg.T("promotion.new_id")
+		}
+		fmt.Println(msg.T("promotion.item", msg.T("promotion.action."+string(item.Action)), item.Entity, item.Title, item.SourceID, targetID))
+	}
+}
+
+// promotionWrites counts the definitions created or updated in the target
+func promotionWrites(result *models.PromotionResult) int {
+	count := 0
+	for _, item := range result.Items {
+		if item.Action == models.PromotionActionCreated || item.Action == models.PromotionActionUpdated {
+			count++
+		}
+	}
+	return count
+}
+
+// sameStorage reports whether both configurations resolve to the same tables,
+// in which case promoting would only rewrite the definitions in place
+func sameStorage(a, b *config.Config) bool {
+	if a.Storage.Driver != b.Storage.Driver {
+		return false
+	}
+	switch a.Storage.Driver {
+	case config.StorageDriverDynamoDB:
+		return a.AWS.Region == b.AWS.Region && a.AWS.DynamoDBEndpoint == b.AWS.DynamoDBEndpoint &&
+			a.Tables.Achievements == b.Tables.Achievements && a.Tables.Rewards == b.Tables.Rewards
+	case config.StorageDriverSQLite:
+		return a.Storage.SQLitePath == b.Storage.SQLitePath
+	case config.StorageDriverPostgres:
+		return a.Storage.PostgresDSN == b.Storage.PostgresDSN
+	}
+	return true
+}
+
+func init() {
+	promoteCmd.Flags().String("from", "", "Environment to copy the definitions from (e.g. staging)")
+	promoteCmd.Flags().String("to", "", "Environment to copy the definitions to (e.g. production)")
+	promoteCmd.Flags().StringSlice("achievements", nil, "IDs of the achievements to promote")
+	promoteCmd.Flags().StringSlice("rewards", nil, "IDs of the rewards to promote")
+	promoteCmd.Flags().Bool("all", false, "Promote every achievement and reward")
+	promoteCmd.Flags().String("match", string(models.PromotionMatchID), "Match target definitions by id or title")
+	promoteCmd.Flags().String("on-conflict", string(models.PromotionConflictSkip), "What to do when a matched definition differs (skip, overwrite)")
+	promoteCmd.Flags().Bool("dry-run", false, "List the changes without writing to the target")
+	promoteCmd.Flags().BoolP("yes", "y", false, "Promote without confirmation")
+	promoteCmd.MarkFlagRequired("from")
+	promoteCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// prompter reads interactive answers from the user
+type prompter struct {
+	reader *bufio.Reader
+	out    io.Writer
+}
+
+// newPrompter creates a prompter reading from in and writing prompts to out
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{
+		reader: bufio.NewReader(in),
+		out:    out,
+	}
+}
+
+// ask prompts for a value and returns defaultValue when the answer is empty
+func (p *prompter) ask(label, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	answer, _ := p.reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return defaultValue
+	}
+	return answer
+}
+
+// confirm prompts for a yes/no answer and returns defaultValue when the answer is empty
+func (p *prompter) confirm(label string, defaultValue bool) bool {
+	hint := "y/N"
+	if defaultValue {
+		hint = "Y/n"
+	}
+	fmt.Fprintf(p.out, "%s [%s]: ", label, hint)
+
+	answer, _ := p.reader.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return defaultValue
+	}
+}
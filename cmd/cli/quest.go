@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// questCmd represents the quest command
+var questCmd = &cobra.Command{
+	Use:   "quest",
+	Short: "Manage quests",
+	Long: `Manage quests: ordered chains of achievements with a bonus for finishing.
+
+Each step of a quest is an existing achievement. A step counts only when its
+achievement is completed after the previous step (the first step, after the
+quest is created), so completing a later step early does not count. Quests are
+checked after every achievement completion; when the last step is done the
+quest is completed once and its bonus points are granted.`,
+}
+
+// questCreateCmd represents the quest create command
+var questCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new quest",
+	Long: `Create a new quest from achievement IDs in the order they should be completed.
+
+Example:
+  achievement-app quest create --title "Morning routine" --steps 01AAA,01BBB,01CCC --bonus 50`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		steps, _ := cmd.Flags().GetStringSlice("steps")
+		bonus, _ := cmd.Flags().GetInt("bonus")
+
+		if title == "" {
+			return msg.NewError("common.title_required")
+		}
+		if len(steps) == 0 {
+			return msg.NewError("quest.steps_required")
+		}
+
+		questService, err := initQuestService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		quest := &models.Quest{
+			Title:       title,
+			Description: description,
+			Steps:       steps,
+			BonusPoint:  bonus,
+		}
+		if err := questService.Create(cmd.Context(), quest); err != nil {
+			return msg.Wrap(err, "quest.create_failed")
+		}
+
+		progress, err := questService.GetByID(cmd.Context(), quest.ID)
+		if err != nil {
+			return msg.Wrap(err, "quest.get_failed")
+		}
+
+		fmt.Println(msg.T("quest.created"))
+		printQuest(progress)
+
+		return nil
+	},
+}
+
+// questListCmd represents the quest list command
+var questListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all quests with their progress",
+	Long: `List all quests, oldest first, with the number of steps done.
+
+Example:
+  achievement-app quest list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		questService, err := initQuestService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		quests, err := questService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "quest.list_failed")
+		}
+
+		if len(quests) == 0 {
+			fmt.Println(msg.T("quest.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("quest.found", len(quests)))
+		for i, progress := range quests {
+			quest := progress.Quest
+			fmt.Println(msg.T("list.item", i+1, quest.Title, quest.ID))
+			fmt.Println(msg.T("list.description", quest.Description))
+			fmt.Println("   " + msg.T("quest.progress", progress.Completed, len(quest.Steps)))
+			fmt.Println("   " + msg.T("quest.bonus", quest.BonusPoint))
+			if quest.CompletedAt != nil {
+				fmt.Println("   " + msg.T("quest.finished", quest.CompletedAt.Format("2006-01-02 15:04:05")))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// questGetCmd represents the quest get command
+var questGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a quest and its steps",
+	Long: `Show a quest by ID with the status of each step.
+
+Example:
+  achievement-app quest get --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		questService, err := initQuestService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		progress, err := questService.GetByID(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "quest.get_failed")
+		}
+
+		printQuest(progress)
+
+		return nil
+	},
+}
+
+// questDeleteCmd represents the quest delete command
+var questDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a quest",
+	Long: `Delete a quest by ID. A bonus that was already granted is kept.
+
+Example:
+  achievement-app quest delete --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		questService, err := initQuestService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		// Get quest details before deletion for confirmation
+		progress, err := questService.GetByID(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "quest.get_failed")
+		}
+
+		if err := questService.Delete(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "quest.delete_failed")
+		}
+
+		fmt.Println(msg.T("quest.deleted"))
+		fmt.Println(msg.T("common.deleted_item", progress.Quest.Title, progress.Quest.ID))
+
+		return nil
+	},
+}
+
+// printQuest prints a quest with the status of each step
+func printQuest(progress *models.QuestProgress) {
+	quest := progress.Quest
+	fmt.Println(msg.T("label.id", quest.ID))
+	fmt.Println(msg.T("label.title", quest.Title))
+	fmt.Println(msg.T("label.description", quest.Description))
+	fmt.Println(msg.T("quest.bonus", quest.BonusPoint))
+	fmt.Println(msg.T("quest.progress", progress.Completed, len(quest.Steps)))
+	for i, step := range progress.Steps {
+		status := translated("quest.status."+string(step.Status), string(step.Status))
+		if step.CompletedAt != nil {
+			fmt.Println(msg.T("quest.step_done", i+1, status, step.Title, step.AchievementID, step.CompletedAt.Format("2006-01-02 15:04:05")))
+		} else {
+			fmt.Println(msg.T("quest.step", i+1, status, step.Title, step.AchievementID))
+		}
+	}
+	if quest.CompletedAt != nil {
+		fmt.Println(msg.T("quest.finished", quest.CompletedAt.Format("2006-01-02 15:04:05")))
+	}
+	fmt.Println(msg.T("label.created", quest.CreatedAt.Format("2006-01-02 15:04:05")))
+}
+
+// initQuestService initializes the quest service with the configured storage
+func initQuestService(ctx context.Context) (services.QuestService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewQuestService(repos.Quests, repos.Achievements, limits(cfg)), nil
+}
+
+// printCompletedQuests checks the pending quests after an achievement is
+// completed and prints the ones it completed together with their bonus.
+func printCompletedQuests(ctx context.Context) {
+	questService, err := initQuestService(ctx)
+	if err != nil {
+		fmt.Println(msg.T("quest.evaluate_failed", msg.ErrorMessage(err)))
+		return
+	}
+
+	completed, err := questService.Evaluate(ctx)
+	if err != nil {
+		fmt.Println(msg.T("quest.evaluate_failed", msg.ErrorMessage(err)))
+	}
+	for _, progress := range completed {
+		fmt.Println(msg.T("quest.completed", progress.Quest.Title, progress.Quest.BonusPoint))
+	}
+}
+
+func init() {
+	// Add subcommands to quest command
+	questCmd.AddCommand(questCreateCmd)
+	questCmd.AddCommand(questListCmd)
+	questCmd.AddCommand(questGetCmd)
+	questCmd.AddCommand(questDeleteCmd)
+
+	// Flags for create command
+	questCreateCmd.Flags().String("title", "", "Quest title (required)")
+	questCreateCmd.Flags().String("description", "", "Quest description")
+	questCreateCmd.Flags().StringSlice("steps", nil, "Comma-separated achievement IDs in the order to complete them (required)")
+	questCreateCmd.Flags().Int("bonus", 0, "Points granted when the last step is completed")
+	questCreateCmd.MarkFlagRequired("title")
+	questCreateCmd.MarkFlagRequired("steps")
+
+	// Flags for get command
+	questGetCmd.Flags().String("id", "", "Quest ID (required)")
+	questGetCmd.MarkFlagRequired("id")
+
+	// Flags for delete command
+	questDeleteCmd.Flags().String("id", "", "Quest ID (required)")
+	questDeleteCmd.MarkFlagRequired("id")
+}
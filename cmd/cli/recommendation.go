@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// rewardAffordableCmd represents the reward affordable command
+var rewardAffordableCmd = &cobra.Command{
+	Use:   "affordable",
+	Short: "List the rewards the current balance can cover",
+	Long: `List the rewards you can redeem right now, the ones that leave the fewest
+points over first. Points reserved for other rewards are not counted.
+
+The cheapest reward that is still out of reach is shown next, with an estimate
+of the days until it is affordable at the average daily earnings of the last
+30 days.
+
+Example:
+  achievement-app reward affordable`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		recommendationService, err := initRecommendationService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		affordable, err := recommendationService.Affordable(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "affordable.failed")
+		}
+
+		fmt.Println(msg.T("points.current_balance", affordable.Balance))
+		if len(affordable.Rewards) == 0 {
+			fmt.Println(msg.T("affordable.none"))
+		} else {
+			fmt.Printf("\n%s\n\n", msg.T("affordable.found", len(affordable.Rewards)))
+			for i, entry := range affordable.Rewards {
+				fmt.Println(msg.T("list.item", i+1, entry.Reward.Title, entry.Reward.ID))
+				fmt.Println(msg.T("list.point_cost", entry.Reward.Point))
+				fmt.Println(msg.T("affordable.remaining", entry.Remaining))
+				fmt.Println()
+			}
+		}
+
+		if affordable.Next == nil {
+			fmt.Println(msg.T("affordable.all"))
+			return nil
+		}
+		fmt.Println(msg.T("affordable.next", affordable.Next.Title, affordable.Next.ID, affordable.PointsNeeded))
+		if affordable.DaysUntilNext != nil {
+			fmt.Println(msg.T("affordable.next_days", affordable.AverageDailyPoints, *affordable.DaysUntilNext))
+		} else {
+			fmt.Println(msg.T("affordable.next_unknown"))
+		}
+
+		return nil
+	},
+}
+
+// initRecommendationService initializes the recommendation service with the configured storage
+func initRecommendationService(ctx context.Context) (services.RecommendationService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewRecommendationService(repos.Rewards, repos.Achievements, repos.Points, repos.Reservations, cfg.Streaks.Location()), nil
+}
+
+func init() {
+	rewardCmd.AddCommand(rewardAffordableCmd)
+}
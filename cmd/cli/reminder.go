@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// reminderCmd represents the reminder command
+var reminderCmd = &cobra.Command{
+	Use:   "reminder",
+	Short: "View and send reminders for incomplete achievements",
+	Long: `View and send reminders for achievements that are not completed yet.
+
+An achievement with --reminder and no due date is reminded at that time on every
+day it has not been completed. An achievement with --due is reminded from its due
+date until it is completed, at its --reminder time or at reminders.schedule.
+"serve" sends the reminders to reminders.webhook_urls when reminders.enabled is set.`,
+}
+
+// reminderListCmd represents the reminder list command
+var reminderListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List today's reminders",
+	Long: `List the achievements to be reminded of today, whatever their reminder time.
+
+Example:
+  achievement-app reminder list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminderService, err := initReminderService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		reminders, err := reminderService.Pending(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "reminder.list_failed")
+		}
+
+		if len(reminders) == 0 {
+			fmt.Println(msg.T("reminder.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("reminder.found", len(reminders)))
+		for i, reminder := range reminders {
+			fmt.Println(msg.T("list.item", i+1, reminder.AchievementTitle, reminder.AchievementID))
+			fmt.Println(msg.T("list.reminder_kind", reminderKindName(reminder)))
+			if reminder.DueDate != "" {
+				fmt.Println(msg.T("list.due_date", reminder.DueDate))
+			}
+			fmt.Println(msg.T("list.reminder", reminder.Schedule))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// reminderSendCmd represents the reminder send command
+var reminderSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send the reminders due this minute",
+	Long: `Send the reminders whose time matches the current minute to reminders.webhook_urls.
+
+Use it from an external scheduler such as cron instead of reminders.enabled when
+the API server is not running. Running it twice in the same minute sends the
+reminders twice.
+
+Example:
+  achievement-app reminder send`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminderService, err := initReminderService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		sent, err := reminderService.NotifyDue(cmd.Context(), time.Now())
+		if err != nil {
+			return msg.Wrap(err, "reminder.send_failed")
+		}
+
+		fmt.Println(msg.T("reminder.sent", sent))
+		return nil
+	},
+}
+
+// initReminderService creates the reminder service for the configured storage
+func initReminderService(ctx context.Context) (services.ReminderService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewReminderService(repos.Achievements, events.NewWebhookBus(cfg.Reminders.WebhookURLs, cfg.Webhooks.SecretFor), cfg.Reminders.Schedule, cfg.Streaks.Location())
+}
+
+// reminderKindName returns the translated reason for a reminder
+func reminderKindName(reminder *models.Reminder) string {
+	if reminder.Overdue {
+		return msg.T("reminder.kind.overdue")
+	}
+	return msg.T("reminder.kind." + string(reminder.Kind))
+}
+
+func init() {
+	reminderCmd.AddCommand(reminderListCmd)
+	reminderCmd.AddCommand(reminderSendCmd)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/report"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a monthly report",
+	Long: `Generate a monthly report of achievements completed, points earned and spent,
+rewards redeemed, and achievement streaks.
+
+Example:
+  achievement-app report --month 2024-06 --format markdown
+  achievement-app report --month 2024-06 --format html --output report-2024-06.html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		monthStr, _ := cmd.Flags().GetString("month")
+		formatStr, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		month := time.Now()
+		if monthStr != "" {
+			parsed, err := time.ParseInLocation("2006-01", monthStr, time.Local)
+			if err != nil {
+				return msg.Wrap(err, "report.invalid_month", monthStr)
+			}
+			month = parsed
+		}
+
+		format, err := report.ParseFormat(formatStr)
+		if err != nil {
+			return msg.Wrap(err, "report.invalid_format")
+		}
+
+		reportService, err := initReportService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		monthly, err := reportService.GenerateMonthlyReport(cmd.Context(), month)
+		if err != nil {
+			return msg.Wrap(err, "report.generate_failed")
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return msg.Wrap(err, "report.write_failed", output)
+			}
+			defer file.Close()
+			w = file
+		}
+
+		if err := report.RenderMonthly(w, monthly, format, msg.T); err != nil {
+			return msg.Wrap(err, "report.render_failed")
+		}
+
+		if output != "" {
+			fmt.Println(msg.T("report.written", output))
+		}
+
+		return nil
+	},
+}
+
+// initReportService initializes the report service with the configured storage
+func initReportService(ctx context.Context) (services.ReportService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewReportService(repos.Achievements, repos.Points), nil
+}
+
+func init() {
+	reportCmd.Flags().String("month", "", "Month to report on (YYYY-MM, default is the current month)")
+	reportCmd.Flags().String("format", "markdown", "Output format (html, markdown)")
+	reportCmd.Flags().StringP("output", "o", "", "Write the report to a file instead of stdout")
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// rewardReserveCmd represents the reward reserve command
+var rewardReserveCmd = &cobra.Command{
+	Use:   "reserve",
+	Short: "Reserve points for a reward",
+	Long: `Set aside points for a reward so they cannot be spent on other rewards.
+
+Reserving points for a reward that already has a reservation replaces the
+reserved amount. You can reserve up to the point cost of the reward, and all
+reservations together cannot exceed the current balance. Redeeming the reward
+releases its reservation.
+
+Example:
+  achievement-app reward reserve --id "01234567890" --points 300`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		points, _ := cmd.Flags().GetInt("points")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+		if points <= 0 {
+			return msg.NewError("reservation.points_positive")
+		}
+
+		reservationService, err := initReservationService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		reservation, err := reservationService.Reserve(cmd.Context(), id, points)
+		if err != nil {
+			return msg.Wrap(err, "reservation.reserve_failed")
+		}
+
+		fmt.Println(msg.T("reservation.reserved"))
+		fmt.Println(msg.T("label.id", reservation.RewardID))
+		fmt.Println(msg.T("label.points", reservation.Points))
+
+		return nil
+	},
+}
+
+// rewardReleaseCmd represents the reward release command
+var rewardReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Release the points reserved for a reward",
+	Long: `Release the points reserved for a reward so they can be spent on any reward.
+
+Example:
+  achievement-app reward release --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		reservationService, err := initReservationService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if err := reservationService.Release(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "reservation.release_failed")
+		}
+
+		fmt.Println(msg.T("reservation.released"))
+		fmt.Println(msg.T("label.id", id))
+
+		return nil
+	},
+}
+
+// rewardReservationsCmd represents the reward reservations command
+var rewardReservationsCmd = &cobra.Command{
+	Use:   "reservations",
+	Short: "List the points reserved for rewards",
+	Long: `List the reservations in the order they were made, with the balance that
+is left to spend on other rewards.
+
+Example:
+  achievement-app reward reservations`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reservationService, err := initReservationService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		summary, err := reservationService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "reservation.list_failed")
+		}
+
+		fmt.Println(msg.T("reservation.balance", summary.Balance, summary.Reserved, summary.Spendable))
+		if len(summary.Reservations) == 0 {
+			fmt.Println(msg.T("reservation.none"))
+			return nil
+		}
+
+		fmt.Printf("\n%s\n\n", msg.T("reservation.found", len(summary.Reservations)))
+		for i, entry := range summary.Reservations {
+			title := msg.T("reservation.deleted_reward")
+			if entry.Reward != nil {
+				title = entry.Reward.Title
+			}
+			fmt.Println(msg.T("list.item", i+1, title, entry.Reservation.RewardID))
+			fmt.Println(msg.T("reservation.points", entry.Reservation.Points))
+			if entry.Reward != nil {
+				fmt.Println(msg.T("list.point_cost", entry.Reward.Point))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// initReservationService initializes the reservation service with the configured storage
+func initReservationService(ctx context.Context) (services.ReservationService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewReservationService(repos.Reservations, repos.Rewards, repos.Points), nil
+}
+
+func init() {
+	// Reservations are managed as reward subcommands
+	rewardCmd.AddCommand(rewardReserveCmd)
+	rewardCmd.AddCommand(rewardReleaseCmd)
+	rewardCmd.AddCommand(rewardReservationsCmd)
+
+	rewardReserveCmd.Flags().String("id", "", "Reward ID (required)")
+	rewardReserveCmd.Flags().Int("points", 0, "Points to reserve (required)")
+	rewardReserveCmd.MarkFlagRequired("id")
+	rewardReserveCmd.MarkFlagRequired("points")
+	rewardReleaseCmd.Flags().String("id", "", "Reward ID (required)")
+	rewardReleaseCmd.MarkFlagRequired("id")
+}
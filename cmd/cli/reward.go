@@ -1,12 +1,15 @@
 package main
 
 import (
+	stderrors "errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
 )
 
@@ -16,7 +19,7 @@ var rewardCmd = &cobra.Command{
 	Short: "Manage rewards",
 	Long: `Manage rewards in the system.
 
-You can create, list, update, redeem, and delete rewards using this command.
+You can create, list, update, redeem, refund, and delete rewards using this command.
 Each reward has a title, description, and point cost.`,
 }
 
@@ -27,41 +30,65 @@ var rewardCreateCmd = &cobra.Command{
 	Long: `Create a new reward with the specified title, description, and point cost.
 
 Example:
-  achievement-app reward create --title "Coffee Voucher" --description "Free coffee at the office" --point 50`,
+  achievement-app reward create --title "Coffee Voucher" --description "Free coffee at the office" --point 50
+
+Pass --id with a ULID generated by the caller to make the create safe to retry:
+running it again with the same ID and values reports the existing reward
+instead of creating a second one.
+
+Example:
+  achievement-app reward create --id 01J2Z3V4W5X6Y7Z8A9BCDEFGHJ --title "Coffee Voucher" --point 50
+
+Pass --prize "title:weight" one or more times to create a raffle: redeeming it
+costs the point cost and draws one prize, with a chance proportional to its weight.
+
+Example:
+  achievement-app reward create --title "Gacha" --point 30 --prize "Coffee:7" --prize "Lunch:3"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
 		point, _ := cmd.Flags().GetInt("point")
+		prizeFlags, _ := cmd.Flags().GetStringArray("prize")
 
 		if title == "" {
-			return fmt.Errorf("title is required")
+			return msg.NewError("common.title_required")
 		}
 		if point <= 0 {
-			return fmt.Errorf("point must be a positive integer")
+			return msg.NewError("common.point_positive")
+		}
+		prizes, err := parsePrizes(prizeFlags)
+		if err != nil {
+			return err
 		}
 
-		_, rewardService, _, err := initServices()
+		_, rewardService, _, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
 		reward := &models.Reward{
+			ID:          id,
 			Title:       title,
 			Description: description,
 			Point:       point,
+			Prizes:      prizes,
 			CreatedAt:   time.Now(),
 		}
 
-		if err := rewardService.Create(reward); err != nil {
-			return fmt.Errorf("failed to create reward: %w", err)
+		if err := rewardService.Create(cmd.Context(), reward); err != nil {
+			return msg.Wrap(err, "reward.create_failed")
 		}
 
-		fmt.Printf("✅ Reward created successfully!\n")
-		fmt.Printf("ID: %s\n", reward.ID)
-		fmt.Printf("Title: %s\n", reward.Title)
-		fmt.Printf("Description: %s\n", reward.Description)
-		fmt.Printf("Point Cost: %d\n", reward.Point)
-		fmt.Printf("Created: %s\n", reward.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Println(msg.T("reward.created"))
+		fmt.Println(msg.T("label.id", reward.ID))
+		fmt.Println(msg.T("label.title", reward.Title))
+		fmt.Println(msg.T("label.description", reward.Description))
+		fmt.Println(msg.T("label.point_cost", reward.Point))
+		if len(reward.Prizes) > 0 {
+			fmt.Println(msg.T("label.prizes", formatPrizes(reward.Prizes)))
+		}
+		fmt.Println(msg.T("label.created", reward.CreatedAt.Format("2006-01-02 15:04:05")))
 
 		return nil
 	},
@@ -76,27 +103,30 @@ var rewardListCmd = &cobra.Command{
 Example:
   achievement-app reward list`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		_, rewardService, _, err := initServices()
+		_, rewardService, _, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
-		rewards, err := rewardService.List()
+		rewards, err := rewardService.List(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to list rewards: %w", err)
+			return msg.Wrap(err, "reward.list_failed")
 		}
 
 		if len(rewards) == 0 {
-			fmt.Println("No rewards found.")
+			fmt.Println(msg.T("reward.none"))
 			return nil
 		}
 
-		fmt.Printf("Found %d reward(s):\n\n", len(rewards))
+		fmt.Printf("%s\n\n", msg.T("reward.found", len(rewards)))
 		for i, reward := range rewards {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, reward.Title, reward.ID)
-			fmt.Printf("   Description: %s\n", reward.Description)
-			fmt.Printf("   Point Cost: %d\n", reward.Point)
-			fmt.Printf("   Created: %s\n", reward.CreatedAt.Format("2006-01-02 15:04:05"))
+			fmt.Println(msg.T("list.item", i+1, reward.Title, reward.ID))
+			fmt.Println(msg.T("list.description", reward.Description))
+			fmt.Println(msg.T("list.point_cost", reward.Point))
+			if len(reward.Prizes) > 0 {
+				fmt.Println(msg.T("list.prizes", formatPrizes(reward.Prizes)))
+			}
+			fmt.Println(msg.T("list.created", reward.CreatedAt.Format("2006-01-02 15:04:05")))
 			fmt.Println()
 		}
 
@@ -110,65 +140,91 @@ var rewardUpdateCmd = &cobra.Command{
 	Short: "Update an existing reward",
 	Long: `Update an existing reward by ID.
 
+Only the flags that are given are changed; pass --description "" to clear the
+description. --prize replaces the whole prize pool of a raffle. A before/after
+summary of the changed fields is shown.
+
 Example:
-  achievement-app reward update --id "01234567890" --title "Updated Title" --point 75`,
+  achievement-app reward update --id "01234567890" --title "Updated Title" --point 75
+  achievement-app reward update --id "01234567890" --description ""`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id, _ := cmd.Flags().GetString("id")
 		title, _ := cmd.Flags().GetString("title")
 		description, _ := cmd.Flags().GetString("description")
-		pointStr, _ := cmd.Flags().GetString("point")
+		point, _ := cmd.Flags().GetInt("point")
+		prizeFlags, _ := cmd.Flags().GetStringArray("prize")
 
 		if id == "" {
-			return fmt.Errorf("id is required")
+			return msg.NewError("common.id_required")
+		}
+
+		flags := cmd.Flags()
+		if !flags.Changed("title") && !flags.Changed("description") && !flags.Changed("point") && !flags.Changed("prize") {
+			return msg.NewError("common.no_update_fields")
+		}
+		if flags.Changed("title") && title == "" {
+			return msg.NewError("common.title_required")
+		}
+		if flags.Changed("point") && point <= 0 {
+			return msg.NewError("common.point_positive")
+		}
+		prizes, err := parsePrizes(prizeFlags)
+		if err != nil {
+			return err
 		}
 
-		_, rewardService, _, err := initServices()
+		_, rewardService, _, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
 		// Get existing reward
-		existing, err := rewardService.GetByID(id)
+		existing, err := rewardService.GetByID(cmd.Context(), id)
 		if err != nil {
-			return fmt.Errorf("failed to get reward: %w", err)
+			return msg.Wrap(err, "reward.get_failed")
 		}
 
-		// Update fields if provided
+		// Update only the fields that were explicitly provided
 		updated := &models.Reward{
 			ID:          existing.ID,
 			Title:       existing.Title,
 			Description: existing.Description,
 			Point:       existing.Point,
+			Prizes:      existing.Prizes,
 			CreatedAt:   existing.CreatedAt,
 		}
 
-		if title != "" {
+		if flags.Changed("title") {
 			updated.Title = title
 		}
-		if description != "" {
+		if flags.Changed("description") {
 			updated.Description = description
 		}
-		if pointStr != "" {
-			point, err := strconv.Atoi(pointStr)
-			if err != nil {
-				return fmt.Errorf("invalid point value: %w", err)
-			}
-			if point <= 0 {
-				return fmt.Errorf("point must be a positive integer")
-			}
+		if flags.Changed("point") {
 			updated.Point = point
 		}
+		if flags.Changed("prize") {
+			updated.Prizes = prizes
+		}
+
+		changes := []fieldChange{
+			{label: msg.T("field_label.title"), before: existing.Title, after: updated.Title},
+			{label: msg.T("field_label.description"), before: existing.Description, after: updated.Description},
+			{label: msg.T("field_label.point_cost"), before: strconv.Itoa(existing.Point), after: strconv.Itoa(updated.Point)},
+			{label: msg.T("field_label.prizes"), before: formatPrizes(existing.Prizes), after: formatPrizes(updated.Prizes)},
+		}
+		if !hasChanges(changes) {
+			fmt.Println(msg.T("common.no_changes"))
+			return nil
+		}
 
-		if err := rewardService.Update(id, updated); err != nil {
-			return fmt.Errorf("failed to update reward: %w", err)
+		if err := rewardService.Update(cmd.Context(), id, updated); err != nil {
+			return msg.Wrap(err, "reward.update_failed")
 		}
 
-		fmt.Printf("✅ Reward updated successfully!\n")
-		fmt.Printf("ID: %s\n", updated.ID)
-		fmt.Printf("Title: %s\n", updated.Title)
-		fmt.Printf("Description: %s\n", updated.Description)
-		fmt.Printf("Point Cost: %d\n", updated.Point)
-		fmt.Printf("Created: %s\n", updated.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Println(msg.T("reward.updated"))
+		fmt.Println(msg.T("label.id", updated.ID))
+		printChanges(changes)
 
 		return nil
 	},
@@ -180,53 +236,110 @@ var rewardRedeemCmd = &cobra.Command{
 	Short: "Redeem a reward",
 	Long: `Redeem a reward by ID. This will deduct the required points from your current balance.
 
+For a raffle, the prize that was drawn is shown.
+
 Example:
   achievement-app reward redeem --id "01234567890"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id, _ := cmd.Flags().GetString("id")
 
 		if id == "" {
-			return fmt.Errorf("id is required")
+			return msg.NewError("common.id_required")
 		}
 
-		_, rewardService, pointService, err := initServices()
+		_, rewardService, pointService, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
 		// Get reward details before redemption
-		reward, err := rewardService.GetByID(id)
+		reward, err := rewardService.GetByID(cmd.Context(), id)
 		if err != nil {
-			return fmt.Errorf("failed to get reward: %w", err)
+			return msg.Wrap(err, "reward.get_failed")
 		}
 
 		// Get current points to show before/after
-		currentPoints, err := pointService.GetCurrentPoints()
+		currentPoints, err := pointService.GetCurrentPoints(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to get current points: %w", err)
+			return msg.Wrap(err, "points.get_failed")
 		}
 
-		fmt.Printf("Redeeming reward: %s\n", reward.Title)
-		fmt.Printf("Point cost: %d\n", reward.Point)
-		fmt.Printf("Current balance: %d\n", currentPoints.Point)
+		fmt.Println(msg.T("reward.redeeming", reward.Title))
+		fmt.Println(msg.T("reward.redeem_cost", reward.Point))
+		fmt.Println(msg.T("reward.current_balance", currentPoints.Point))
 
 		if currentPoints.Point < reward.Point {
-			return fmt.Errorf("insufficient points. Required: %d, Available: %d", reward.Point, currentPoints.Point)
+			return msg.NewError("reward.insufficient_points", reward.Point, currentPoints.Point)
 		}
 
-		if err := rewardService.Redeem(id); err != nil {
-			return fmt.Errorf("failed to redeem reward: %w", err)
+		history, err := rewardService.Redeem(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "reward.redeem_failed")
+		}
+		if history.Prize != "" {
+			fmt.Println(msg.T("reward.prize_won", history.Prize))
 		}
 
 		// Get updated points
-		updatedPoints, err := pointService.GetCurrentPoints()
+		updatedPoints, err := pointService.GetCurrentPoints(cmd.Context())
+		if err != nil {
+			fmt.Println(msg.T("reward.redeemed_balance_failed", msg.ErrorMessage(err)))
+		} else {
+			fmt.Println(msg.T("reward.redeemed"))
+			fmt.Println(msg.T("reward.label", reward.Title))
+			fmt.Println(msg.T("reward.points_deducted", reward.Point))
+			fmt.Println(msg.T("reward.new_balance", updatedPoints.Point))
+		}
+
+		printNewBadges(cmd.Context())
+
+		return nil
+	},
+}
+
+// rewardRefundCmd represents the reward refund command
+var rewardRefundCmd = &cobra.Command{
+	Use:   "refund",
+	Short: "Refund a reward redemption",
+	Long: `Refund a reward redemption by its history ID (shown by "points history").
+The point cost is restored to your balance and the history entry is marked as refunded.
+
+Redemptions can be refunded within the configured window (refunds.window_hours, 24 hours by default).
+After the window has passed, pass --admin to refund as an administrator.
+
+Example:
+  achievement-app reward refund --id "01234567890"
+  achievement-app reward refund --id "01234567890" --admin`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		admin, _ := cmd.Flags().GetBool("admin")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		_, rewardService, pointService, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		history, err := rewardService.Refund(cmd.Context(), id, admin)
+		if err != nil {
+			if stderrors.Is(err, errors.ErrForbidden) {
+				return msg.NewError("reward.refund_window_passed")
+			}
+			return msg.Wrap(err, "reward.refund_failed")
+		}
+
+		fmt.Println(msg.T("reward.refunded"))
+		fmt.Println(msg.T("reward.label", history.RewardTitle))
+		fmt.Println(msg.T("reward.points_restored", history.PointCost))
+
+		updatedPoints, err := pointService.GetCurrentPoints(cmd.Context())
 		if err != nil {
-			fmt.Printf("⚠️  Reward redeemed but failed to get updated balance: %v\n", err)
+			fmt.Println(msg.T("reward.refunded_balance_failed", msg.ErrorMessage(err)))
 		} else {
-			fmt.Printf("✅ Reward redeemed successfully!\n")
-			fmt.Printf("Reward: %s\n", reward.Title)
-			fmt.Printf("Points deducted: %d\n", reward.Point)
-			fmt.Printf("New balance: %d\n", updatedPoints.Point)
+			fmt.Println(msg.T("reward.new_balance", updatedPoints.Point))
 		}
 
 		return nil
@@ -245,26 +358,26 @@ Example:
 		id, _ := cmd.Flags().GetString("id")
 
 		if id == "" {
-			return fmt.Errorf("id is required")
+			return msg.NewError("common.id_required")
 		}
 
-		_, rewardService, _, err := initServices()
+		_, rewardService, _, err := initServices(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failed to initialize services: %w", err)
+			return msg.Wrap(err, "common.init_services_failed")
 		}
 
 		// Get reward details before deletion for confirmation
-		reward, err := rewardService.GetByID(id)
+		reward, err := rewardService.GetByID(cmd.Context(), id)
 		if err != nil {
-			return fmt.Errorf("failed to get reward: %w", err)
+			return msg.Wrap(err, "reward.get_failed")
 		}
 
-		if err := rewardService.Delete(id); err != nil {
-			return fmt.Errorf("failed to delete reward: %w", err)
+		if err := rewardService.Delete(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "reward.delete_failed")
 		}
 
-		fmt.Printf("✅ Reward deleted successfully!\n")
-		fmt.Printf("Deleted: %s (ID: %s)\n", reward.Title, reward.ID)
+		fmt.Println(msg.T("reward.deleted"))
+		fmt.Println(msg.T("common.deleted_item", reward.Title, reward.ID))
 
 		return nil
 	},
@@ -276,27 +389,66 @@ func init() {
 	rewardCmd.AddCommand(rewardListCmd)
 	rewardCmd.AddCommand(rewardUpdateCmd)
 	rewardCmd.AddCommand(rewardRedeemCmd)
+	rewardCmd.AddCommand(rewardRefundCmd)
 	rewardCmd.AddCommand(rewardDeleteCmd)
 
 	// Flags for create command
+	rewardCreateCmd.Flags().String("id", "", "Client-supplied ULID (retrying with the same ID and values does not create a duplicate)")
 	rewardCreateCmd.Flags().String("title", "", "Reward title (required)")
 	rewardCreateCmd.Flags().String("description", "", "Reward description")
 	rewardCreateCmd.Flags().Int("point", 0, "Reward point cost (required)")
 	rewardCreateCmd.MarkFlagRequired("title")
 	rewardCreateCmd.MarkFlagRequired("point")
 
+	rewardCreateCmd.Flags().StringArray("prize", nil, `Raffle prize as "title:weight" (repeatable)`)
+
 	// Flags for update command
 	rewardUpdateCmd.Flags().String("id", "", "Reward ID (required)")
 	rewardUpdateCmd.Flags().String("title", "", "New reward title")
-	rewardUpdateCmd.Flags().String("description", "", "New reward description")
-	rewardUpdateCmd.Flags().String("point", "", "New reward point cost")
+	rewardUpdateCmd.Flags().String("description", "", `New reward description (use --description "" to clear)`)
+	rewardUpdateCmd.Flags().Int("point", 0, "New reward point cost")
+	rewardUpdateCmd.Flags().StringArray("prize", nil, `Raffle prize as "title:weight", replacing the prize pool (repeatable)`)
 	rewardUpdateCmd.MarkFlagRequired("id")
 
 	// Flags for redeem command
 	rewardRedeemCmd.Flags().String("id", "", "Reward ID (required)")
 	rewardRedeemCmd.MarkFlagRequired("id")
 
+	// Flags for refund command
+	rewardRefundCmd.Flags().String("id", "", "Reward history ID (required)")
+	rewardRefundCmd.Flags().Bool("admin", false, "Refund as an administrator even after the refund window has passed")
+	rewardRefundCmd.MarkFlagRequired("id")
+
 	// Flags for delete command
 	rewardDeleteCmd.Flags().String("id", "", "Reward ID (required)")
 	rewardDeleteCmd.MarkFlagRequired("id")
+}
+
+// parsePrizes parses --prize values given as "title:weight"
+func parsePrizes(values []string) ([]models.RafflePrize, error) {
+	prizes := make([]models.RafflePrize, 0, len(values))
+	for _, value := range values {
+		i := strings.LastIndex(value, ":")
+		if i < 0 {
+			return nil, msg.NewError("reward.invalid_prize", value)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(value[i+1:]))
+		if err != nil {
+			return nil, msg.NewError("reward.invalid_prize", value)
+		}
+		prizes = append(prizes, models.RafflePrize{Title: strings.TrimSpace(value[:i]), Weight: weight})
+	}
+	if len(prizes) == 0 {
+		return nil, nil
+	}
+	return prizes, nil
+}
+
+// formatPrizes formats a raffle's prize pool as "title:weight, ..."
+func formatPrizes(prizes []models.RafflePrize) string {
+	formatted := make([]string, len(prizes))
+	for i, prize := range prizes {
+		formatted[i] = fmt.Sprintf("%s:%d", prize.Title, prize.Weight)
+	}
+	return strings.Join(formatted, ", ")
 }
\ No newline at end of file
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/attachments"
+	"achievement-management/internal/backup"
+	"achievement-management/internal/config"
+	"achievement-management/internal/errorreport"
+	"achievement-management/internal/events"
+	"achievement-management/internal/handlers"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/models"
+	"achievement-management/internal/notifications"
+	"achievement-management/internal/scheduler"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the HTTP API server",
+	Long: `Start the HTTP API server on server.port (SERVER_PORT) using the configured
+storage driver, until interrupted.
+
+With --demo the server keeps its data in memory instead, seeded with example
+achievements and rewards, so it can be tried without DynamoDB or a database.
+Demo data is discarded when the server stops.
+
+Example:
+  achievement-app serve
+  achievement-app serve --demo --port 3000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+
+		demo, _ := cmd.Flags().GetBool("demo")
+		if demo {
+			cfg.Storage.Driver = config.StorageDriverMemory
+		}
+		if port, _ := cmd.Flags().GetString("port"); port != "" {
+			cfg.Server.Port = port
+		}
+
+		repos, err := storage.Open(ctx, cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_repository_failed")
+		}
+		defer repos.Close()
+
+		achievementService := services.NewAchievementServiceWithLimits(repos.Achievements, repos.Points, streakSettings(cfg), limits(cfg))
+		rewardService := services.NewRewardServiceWithReservations(repos.Rewards, repos.Points, repos.Reservations, cfg.Refunds.Window(), limits(cfg))
+		pointService := services.NewPointService(repos.Points, repos.Achievements)
+
+		if demo {
+			if err := seedDemoData(ctx, achievementService, rewardService); err != nil {
+				return msg.Wrap(err, "serve.seed_failed")
+			}
+			fmt.Println(msg.T("serve.demo_mode"))
+		}
+
+		// Journal creates, updates and deletes after seeding so that the demo data cannot be undone
+		journalService := newJournalService(cfg, repos)
+		achievementService = services.NewJournaledAchievementService(achievementService, journalService)
+		rewardService = services.NewJournaledRewardService(rewardService, journalService)
+
+		// Post created achievements and redemptions to the configured notification channels
+		notifier, err := newNotifier(ctx, cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_notifications_failed")
+		}
+		achievementService = services.NewNotifyingAchievementService(achievementService, notifier)
+		rewardService = services.NewNotifyingRewardService(rewardService, repos.Points, notifier, cfg.Notifications.LowBalanceThreshold)
+
+		server := handlers.NewServer(achievementService, rewardService, pointService, cfg)
+		server.EnableBadges(services.NewBadgeService(repos.Badges, repos.Achievements, repos.Points, nil))
+		server.EnableGoals(services.NewGoalService(repos.Goals, repos.Achievements, repos.Points, notifications.Subscribe(events.NewWebhookBus(cfg.Goals.WebhookURLs, cfg.Webhooks.SecretFor), notifier)))
+		server.EnableQuests(services.NewQuestService(repos.Quests, repos.Achievements, limits(cfg)))
+		server.EnableWishlist(services.NewWishlistService(repos.Favorites, repos.Wishlist, repos.Rewards, repos.Points))
+		server.EnableReservations(services.NewReservationService(repos.Reservations, repos.Rewards, repos.Points))
+		server.EnableRecommendations(services.NewRecommendationService(repos.Rewards, repos.Achievements, repos.Points, repos.Reservations, cfg.Streaks.Location()))
+		server.EnableNotes(services.NewNoteService(repos.Notes, repos.Achievements, repos.Rewards, repos.Points))
+
+		allowanceService := newAllowanceService(cfg, repos)
+		server.EnableAllowances(allowanceService)
+
+		reminderService, err := services.NewReminderService(repos.Achievements, events.NewWebhookBus(cfg.Reminders.WebhookURLs, cfg.Webhooks.SecretFor), cfg.Reminders.Schedule, cfg.Streaks.Location())
+		if err != nil {
+			return msg.Wrap(err, "reminder.init_failed")
+		}
+		server.EnableReminders(reminderService)
+
+		// The calendar feed is only served when a token is configured
+		if tokens := cfg.Calendar.Tokens(); len(tokens) > 0 {
+			server.EnableCalendar(tokens, cfg.Streaks.Location())
+		}
+
+		summaryService, err := services.NewSummaryService(repos.Achievements, repos.Points, notifications.Subscribe(events.NewWebhookBus(cfg.Summaries.WebhookURLs, cfg.Webhooks.SecretFor), notifier), summarySettings(cfg))
+		if err != nil {
+			return msg.Wrap(err, "summary.init_failed")
+		}
+		server.EnableSummaries(summaryService)
+		server.EnableStats(services.NewStatsService(repos.Achievements, repos.Points, cfg.Streaks.Location()))
+		server.EnableSuggestions(services.NewSuggestionService(repos.Achievements, limits(cfg)))
+		server.EnableBulk(services.NewBulkService(achievementService, rewardService, cfg.Bulk.Parallelism, cfg.Bulk.MaxItems))
+
+		consistencyService := newConsistencyService(cfg, pointService, repos)
+		server.EnableConsistency(consistencyService)
+
+		// Discord bot commands are only accepted when the application's public key is configured
+		if key := cfg.Notifications.Discord.VerifyKey(); key != nil {
+			server.EnableDiscord(notifications.NewCommands(pointService, rewardService), key)
+		}
+
+		// Attachments are presigned against S3, so they are only served when a bucket is configured
+		if cfg.Attachments.Bucket != "" {
+			store, err := attachments.Open(ctx, cfg)
+			if err != nil {
+				return msg.Wrap(err, "attachments.init_failed")
+			}
+			server.EnableAttachments(services.NewAttachmentService(store, cfg.Attachments.Prefix, repos.Achievements, repos.Points))
+		}
+
+		// The backup admin endpoint exports whole DynamoDB tables, so it is only served when a token is configured
+		if cfg.Backup.AdminToken != "" && cfg.Storage.Driver == config.StorageDriverDynamoDB {
+			backups, err := backup.Open(ctx, cfg)
+			if err != nil {
+				return msg.Wrap(err, "backup.init_failed")
+			}
+			server.EnableBackups(backups, cfg.Backup.AdminToken)
+		}
+
+		// The health check pings DynamoDB only when asked to; results are cached for health_check.cache_ttl_seconds
+		if cfg.HealthCheck.CheckDynamoDB && cfg.Storage.Driver == config.StorageDriverDynamoDB {
+			server.EnableDynamoDBHealthCheck(repos, cfg.HealthCheck)
+		}
+
+		// Maintenance mode can be toggled at runtime only when a token is configured
+		if cfg.Maintenance.AdminToken != "" {
+			server.EnableMaintenance(repos.Maintenance, cfg.Maintenance.AdminToken)
+		}
+
+		// Undo and redo are admin operations, so the journal endpoints are only served when a token is configured
+		if cfg.Journal.AdminToken != "" {
+			server.EnableJournal(journalService, cfg.Journal.AdminToken)
+		}
+
+		// The effective configuration is only served when a token is configured; secrets are masked either way
+		if cfg.Diagnostics.AdminToken != "" {
+			server.EnableConfigDiagnostics(cfg, cfg.Diagnostics.AdminToken)
+		}
+
+		// The log level can only be changed at runtime when a token is configured
+		if cfg.Logging.AdminToken != "" {
+			server.EnableLogLevel(cfg.Logging.AdminToken)
+		}
+
+		// Keys in the X-API-Key header are always checked; the key management endpoints are only served when a token is configured
+		server.EnableAPIKeys(services.NewAPIKeyService(repos.APIKeys), cfg.APIKeys)
+
+		// Errors are only sent to an error tracker when a DSN is configured
+		if cfg.ErrorReporting.DSN != "" {
+			reporter, err := errorreport.New(cfg.ErrorReporting.DSN, cfg.Environment, Version)
+			if err != nil {
+				return msg.Wrap(err, "serve.failed")
+			}
+			server.EnableErrorReporting(reporter)
+			// Send the queued reports before exiting
+			defer func() {
+				closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = reporter.Close(closeCtx)
+			}()
+		}
+
+		// Reminders, summaries, the consistency checker and allowances run in the server process; enable them on a single instance when running several
+		if cfg.Reminders.Enabled || cfg.Summaries.Enabled || cfg.Consistency.Enabled || cfg.Allowances.Enabled {
+			logger, err := logging.NewLogger(cfg)
+			if err != nil {
+				return msg.Wrap(err, "serve.failed")
+			}
+			server.LogLevels().Attach(logger)
+			if cfg.Reminders.Enabled {
+				go scheduler.New("reminders", reminderService, cfg.Reminders.Tenants, logger).Run(ctx)
+			}
+			if cfg.Summaries.Enabled {
+				go scheduler.New("summaries", summaryService, cfg.Summaries.Tenants, logger).Run(ctx)
+			}
+			if cfg.Consistency.Enabled {
+				go scheduler.New("consistency", consistencyService, cfg.Consistency.Tenants, logger).Run(ctx)
+			}
+			if cfg.Allowances.Enabled {
+				go scheduler.New("allowances", allowanceService, cfg.Allowances.Tenants, logger).Run(ctx)
+			}
+		}
+
+		httpServer := &http.Server{
+			Addr:         ":" + cfg.Server.Port,
+			Handler:      server.GetRouter(),
+			ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+			WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+		}
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- httpServer.ListenAndServe()
+		}()
+		fmt.Println(msg.T("serve.listening", httpServer.Addr, cfg.Storage.Driver))
+
+		select {
+		case err := <-errCh:
+			return msg.Wrap(err, "serve.failed")
+		case <-ctx.Done():
+		}
+
+		// Give in-flight requests a few seconds to finish
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+			return msg.Wrap(err, "serve.failed")
+		}
+		fmt.Println(msg.T("serve.stopped"))
+		return nil
+	},
+}
+
+// seedDemoData creates the example achievements and rewards shown in demo mode
+func seedDemoData(ctx context.Context, achievementService services.AchievementService, rewardService services.RewardService) error {
+	achievements := []*models.Achievement{
+		{Title: msg.T("serve.demo_achievement_setup"), Point: 10},
+		{Title: msg.T("serve.demo_achievement_workout"), Point: 30},
+		{Title: msg.T("serve.demo_achievement_book"), Point: 50},
+	}
+	for _, achievement := range achievements {
+		if err := achievementService.Create(ctx, achievement); err != nil {
+			return err
+		}
+	}
+
+	rewards := []*models.Reward{
+		{Title: msg.T("serve.demo_reward_coffee"), Point: 20},
+		{Title: msg.T("serve.demo_reward_movie"), Point: 100},
+	}
+	for _, reward := range rewards {
+		if err := rewardService.Create(ctx, reward); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	serveCmd.Flags().Bool("demo", false, "Serve in-memory example data instead of the configured storage")
+	serveCmd.Flags().String("port", "", "Port to listen on (default is server.port from the configuration)")
+}
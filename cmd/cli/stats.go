@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show item counts, the current balance and point trends",
+	Long: `Show the number of achievements, rewards and reward redemptions along with
+the current point balance, followed by the points earned and spent over the last
+7 and 30 days and the best day and week so far.
+
+Counts are read without scanning the tables. With the dynamodb storage driver they
+come from the table metadata, which DynamoDB refreshes about every six hours.
+When tenancy is enabled they are counted exactly for the selected tenant instead,
+with a COUNT query on the entity_type index.
+
+Example:
+  achievement-app stats`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+
+		achievementService, rewardService, pointService, err := newServices(cmd.Context(), cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		achievements, err := achievementService.Count(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "stats.failed")
+		}
+		rewards, err := rewardService.Count(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "stats.failed")
+		}
+		history, err := pointService.CountRewardHistory(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "stats.failed")
+		}
+		currentPoints, err := pointService.GetCurrentPoints(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "points.get_failed")
+		}
+
+		statsService, err := newStatsService(cmd.Context(), cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+		trends, err := statsService.Trends(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "stats.trends_failed")
+		}
+
+		fmt.Println(msg.T("stats.title"))
+		fmt.Printf("═══════════════════════════════\n")
+		fmt.Println(msg.T("stats.achievements", achievements))
+		fmt.Println(msg.T("stats.rewards", rewards))
+		fmt.Println(msg.T("stats.reward_history", history))
+		fmt.Println(msg.T("points.current_balance", currentPoints.Point))
+
+		fmt.Println()
+		fmt.Println(msg.T("stats.trends_title"))
+		fmt.Printf("═══════════════════════════════\n")
+		for _, window := range []models.TrendWindow{trends.Last7Days, trends.Last30Days} {
+			fmt.Println(msg.T("stats.window", window.Days, window.PointsEarned, window.PointsSpent, window.AverageDailyPoints))
+		}
+		if trends.BestDay != nil {
+			fmt.Println(msg.T("stats.best_day", trends.BestDay.From, trends.BestDay.Points))
+		}
+		if trends.BestWeek != nil {
+			fmt.Println(msg.T("stats.best_week", trends.BestWeek.From, trends.BestWeek.To, trends.BestWeek.Points))
+		}
+
+		if cfg.Storage.Driver == config.StorageDriverDynamoDB && !cfg.Tenancy.Enabled {
+			fmt.Println()
+			fmt.Println(msg.T("stats.approximate_note"))
+		}
+
+		return nil
+	},
+}
+
+// newStatsService creates the trend statistics service for the configured storage
+func newStatsService(ctx context.Context, cfg *config.Config) (services.StatsService, error) {
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewStatsService(repos.Achievements, repos.Points, cfg.Streaks.Location()), nil
+}
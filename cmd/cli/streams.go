@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/events"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/repository"
+)
+
+// streamsCmd represents the streams command
+var streamsCmd = &cobra.Command{
+	Use:   "streams",
+	Short: "Deliver table changes as events",
+	Long: `Read the DynamoDB Streams of the application tables and deliver every change
+as an event, including changes made directly in DynamoDB rather than through the app.
+Requires streams.enabled (or STREAMS_ENABLED=true) in the configuration.`,
+}
+
+// streamsConsumeCmd represents the streams consume command
+var streamsConsumeCmd = &cobra.Command{
+	Use:   "consume",
+	Short: "Consume table streams and publish change events",
+	Long: `Consume the table streams until interrupted, printing each change event and
+POSTing it as JSON to every URL in streams.webhook_urls (STREAMS_WEBHOOK_URLS).
+
+Streams are enabled on tables that do not have one yet. Only changes made while
+the consumer is running are delivered.
+
+Example:
+  STREAMS_ENABLED=true achievement-app streams consume
+  STREAMS_ENABLED=true STREAMS_WEBHOOK_URLS=https://example.com/hooks/achievements achievement-app streams consume`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return msg.Wrap(err, "common.load_config_failed")
+		}
+		if err := requireDynamoDB(cfg); err != nil {
+			return err
+		}
+		if !cfg.Streams.Enabled {
+			return msg.NewError("streams.disabled")
+		}
+
+		logger, err := logging.NewLogger(cfg)
+		if err != nil {
+			return msg.Wrap(err, "streams.consume_failed")
+		}
+
+		sources, err := streamSources(ctx, cfg)
+		if err != nil {
+			return err
+		}
+
+		client, err := repository.NewDynamoDBStreamsClient(ctx, cfg)
+		if err != nil {
+			return msg.Wrap(err, "common.init_repository_failed")
+		}
+
+		bus := events.NewBus(events.PublisherFunc(printEvent))
+		for _, url := range cfg.Streams.WebhookURLs {
+			bus.Subscribe(events.NewSignedWebhookPublisher(url, cfg.Webhooks.SecretFor(url), nil))
+		}
+
+		fmt.Println(msg.T("streams.consuming", len(sources), len(cfg.Streams.WebhookURLs)))
+		consumer := events.NewStreamConsumer(client, bus, logger, time.Duration(cfg.Streams.PollIntervalMs)*time.Millisecond)
+		if err := consumer.Run(ctx, sources); err != nil {
+			return msg.Wrap(err, "streams.consume_failed")
+		}
+
+		fmt.Println(msg.T("streams.stopped"))
+		return nil
+	},
+}
+
+// streamSources enables streams where missing and resolves the stream ARN of every table
+func streamSources(ctx context.Context, cfg *config.Config) ([]events.StreamSource, error) {
+	client, err := repository.NewDynamoDBClient(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+	tables := repository.NewTableManager(client)
+	definitions := repository.TableDefinitions(cfg)
+
+	enabled, err := tables.EnsureStreams(ctx, definitions)
+	if err != nil {
+		return nil, msg.Wrap(err, "streams.enable_failed")
+	}
+	for _, name := range enabled {
+		fmt.Println(msg.T("streams.enabled", name))
+	}
+
+	var sources []events.StreamSource
+	for _, def := range definitions {
+		arn, err := tables.StreamARN(ctx, def.Name)
+		if err != nil {
+			return nil, msg.Wrap(err, "streams.enable_failed")
+		}
+		sources = append(sources, events.StreamSource{Table: def.Key, StreamARN: arn})
+	}
+	return sources, nil
+}
+
+// printEvent prints a one-line summary of a change event
+func printEvent(ctx context.Context, event events.Event) error {
+	fmt.Println(msg.T("streams.event", event.OccurredAt.Format("2006-01-02 15:04:05"), event.Type, event.Key["id"]))
+	return nil
+}
+
+func init() {
+	streamsCmd.AddCommand(streamsConsumeCmd)
+}
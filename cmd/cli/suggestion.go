@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// achievementSuggestPointCmd represents the achievement suggest-point command
+var achievementSuggestPointCmd = &cobra.Command{
+	Use:   "suggest-point",
+	Short: "Suggest a point value for a difficulty",
+	Long: `Suggest a point value for a new achievement of the given difficulty
+(easy, medium or hard).
+
+With at least 3 achievements of that difficulty, the median of their points is
+suggested with the middle half as the typical range. Otherwise the suggestion
+comes from the points of all achievements (lower quarter for easy, median for
+medium, upper quarter for hard), or from built-in defaults when there are fewer
+than 3 achievements. Suggestions never exceed points.max_point.
+
+Example:
+  achievement-app achievement suggest-point --difficulty hard`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		difficulty, _ := cmd.Flags().GetString("difficulty")
+
+		suggestionService, err := initSuggestionService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		suggestion, err := suggestionService.SuggestPoint(cmd.Context(), models.Difficulty(difficulty))
+		if err != nil {
+			return msg.Wrap(err, "suggestion.failed")
+		}
+
+		fmt.Println(msg.T("suggestion.point", suggestion.Difficulty, suggestion.Point))
+		fmt.Println(msg.T("suggestion.range", suggestion.Min, suggestion.Max))
+		switch suggestion.Basis {
+		case models.SuggestionBasisDifficulty:
+			fmt.Println(msg.T("suggestion.basis_difficulty", suggestion.Samples))
+		case models.SuggestionBasisHistory:
+			fmt.Println(msg.T("suggestion.basis_history", suggestion.Samples))
+		default:
+			fmt.Println(msg.T("suggestion.basis_default"))
+		}
+		return nil
+	},
+}
+
+// initSuggestionService initializes the point suggestion service with the configured storage
+func initSuggestionService(ctx context.Context) (services.SuggestionService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewSuggestionService(repos.Achievements, limits(cfg)), nil
+}
+
+func init() {
+	achievementCmd.AddCommand(achievementSuggestPointCmd)
+
+	achievementSuggestPointCmd.Flags().String("difficulty", "", "Difficulty (easy, medium or hard, required)")
+	achievementSuggestPointCmd.MarkFlagRequired("difficulty")
+}
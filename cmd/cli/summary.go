@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+	"achievement-management/internal/notifications"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// summaryCmd represents the summary command
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "View and send daily or weekly summaries",
+	Long: `View and send summaries of the points earned and spent over a day or a week,
+together with the current balance.
+
+A daily summary covers yesterday and a weekly summary the 7 days up to
+yesterday, in streaks.timezone. "serve" sends them to summaries.webhook_urls at
+summaries.daily_schedule and summaries.weekly_schedule when summaries.enabled is set.`,
+}
+
+// summaryShowCmd represents the summary show command
+var summaryShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a summary",
+	Long: `Show the summary for yesterday, or for the period ending on --date.
+
+Example:
+  achievement-app summary show
+  achievement-app summary show --period weekly
+  achievement-app summary show --date 2024-06-09`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		summary, _, err := generateSummary(cmd)
+		if err != nil {
+			return err
+		}
+
+		printSummary(summary)
+		return nil
+	},
+}
+
+// summarySendCmd represents the summary send command
+var summarySendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send a summary to the configured webhooks",
+	Long: `Generate a summary and send it to summaries.webhook_urls right away.
+
+Use it from an external scheduler such as cron instead of summaries.enabled when
+the API server is not running.
+
+Example:
+  achievement-app summary send
+  achievement-app summary send --period weekly`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		summary, summaryService, err := generateSummary(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := summaryService.Notify(cmd.Context(), summary); err != nil {
+			return msg.Wrap(err, "summary.send_failed")
+		}
+
+		printSummary(summary)
+		fmt.Println(msg.T("summary.sent"))
+		return nil
+	},
+}
+
+// generateSummary generates the summary selected by the --period and --date flags
+func generateSummary(cmd *cobra.Command) (*models.Summary, services.SummaryService, error) {
+	period, _ := cmd.Flags().GetString("period")
+	date, _ := cmd.Flags().GetString("date")
+
+	if period != string(models.SummaryDaily) && period != string(models.SummaryWeekly) {
+		return nil, nil, msg.NewError("summary.invalid_period", period)
+	}
+
+	summaryService, err := initSummaryService(cmd.Context())
+	if err != nil {
+		return nil, nil, msg.Wrap(err, "common.init_services_failed")
+	}
+
+	summary, err := summaryService.Generate(cmd.Context(), models.SummaryPeriod(period), date)
+	if err != nil {
+		return nil, nil, msg.Wrap(err, "summary.generate_failed")
+	}
+	return summary, summaryService, nil
+}
+
+// printSummary prints the translated summary with its breakdown
+func printSummary(summary *models.Summary) {
+	if summary.Period == models.SummaryWeekly {
+		fmt.Println(msg.T("summary.title.weekly", summary.From, summary.To))
+	} else {
+		fmt.Println(msg.T("summary.title.daily", summary.To))
+	}
+	fmt.Println(msg.T("summary.earned", summary.PointsEarned, summary.Achievements, summary.Completions))
+	fmt.Println(msg.T("summary.spent", summary.PointsSpent, summary.Redemptions))
+	fmt.Println(msg.T("summary.balance", summary.Balance))
+}
+
+// initSummaryService creates the summary service for the configured storage
+func initSummaryService(ctx context.Context) (services.SummaryService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	notifier, err := newNotifier(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_notifications_failed")
+	}
+
+	return services.NewSummaryService(repos.Achievements, repos.Points, notifications.Subscribe(events.NewWebhookBus(cfg.Summaries.WebhookURLs, cfg.Webhooks.SecretFor), notifier), summarySettings(cfg))
+}
+
+// summarySettings converts the summaries configuration into service settings
+func summarySettings(cfg *config.Config) services.SummarySettings {
+	return services.SummarySettings{
+		DailySchedule:  cfg.Summaries.DailySchedule,
+		WeeklySchedule: cfg.Summaries.WeeklySchedule,
+		Location:       cfg.Streaks.Location(),
+	}
+}
+
+func init() {
+	summaryCmd.AddCommand(summaryShowCmd)
+	summaryCmd.AddCommand(summarySendCmd)
+
+	for _, cmd := range []*cobra.Command{summaryShowCmd, summarySendCmd} {
+		cmd.Flags().String("period", string(models.SummaryDaily), "Summary period (daily or weekly)")
+		cmd.Flags().String("date", "", "Last day of the period (YYYY-MM-DD, default yesterday)")
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// achievementTimerCmd represents the achievement timer command
+var achievementTimerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Track time spent on an achievement",
+	Long: `Start and stop a timer on an achievement to track the time spent on it.
+
+Stopping the timer records a completion with the tracked time. An achievement
+with --points-per-hour set awards points in proportion to the tracked time
+(rounded down, at most points.max_point); any other achievement awards its
+usual points. Completion limits, streak bonuses and the daily quota apply as
+for "achievement complete". Only one timer can run per achievement.`,
+}
+
+// achievementTimerStartCmd represents the achievement timer start command
+var achievementTimerStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the timer on an achievement",
+	Long: `Start the timer on an achievement.
+
+Example:
+  achievement-app achievement timer start --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		achievementService, _, _, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		achievement, err := achievementService.StartTimer(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "achievement.timer_start_failed")
+		}
+
+		fmt.Println(msg.T("achievement.timer_started", achievement.Title, achievement.TimerStartedAt.Local().Format("2006-01-02 15:04:05")))
+		if achievement.PointsPerHour > 0 {
+			fmt.Println(msg.T("label.points_per_hour", achievement.PointsPerHour))
+		}
+
+		return nil
+	},
+}
+
+// achievementTimerStopCmd represents the achievement timer stop command
+var achievementTimerStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the timer and record a completion",
+	Long: `Stop the running timer on an achievement and record a completion with the
+tracked time, adding its points to the balance.
+
+Example:
+  achievement-app achievement timer stop --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		achievementService, _, _, err := initServices(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		completion, err := achievementService.StopTimer(cmd.Context(), id)
+		if err != nil {
+			return msg.Wrap(err, "achievement.timer_stop_failed")
+		}
+
+		fmt.Println(msg.T("achievement.timer_stopped", trackedDuration(completion.DurationSeconds)))
+		fmt.Println(msg.T("label.id", completion.ID))
+		fmt.Println(msg.T("label.title", completion.AchievementTitle))
+		fmt.Println(msg.T("achievement.points_granted", completion.Point))
+
+		// The completion is already recorded, so a streak lookup failure only
+		// leaves the streak out of the output.
+		if streak, err := achievementService.GetStreak(cmd.Context(), id); err == nil {
+			if completion.BonusPoint > 0 {
+				fmt.Println(msg.T("achievement.bonus_granted", streak.Current, completion.BonusPoint))
+			}
+			fmt.Println(msg.T("label.streak", streak.Current, streak.Longest))
+		}
+
+		printCompletedQuests(cmd.Context())
+		printReachedGoals(cmd.Context())
+
+		return nil
+	},
+}
+
+// trackedDuration formats a tracked time such as "1h30m0s"
+func trackedDuration(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func init() {
+	achievementCmd.AddCommand(achievementTimerCmd)
+	achievementTimerCmd.AddCommand(achievementTimerStartCmd)
+	achievementTimerCmd.AddCommand(achievementTimerStopCmd)
+
+	achievementTimerStartCmd.Flags().String("id", "", "Achievement ID (required)")
+	achievementTimerStartCmd.MarkFlagRequired("id")
+
+	achievementTimerStopCmd.Flags().String("id", "", "Achievement ID (required)")
+	achievementTimerStopCmd.MarkFlagRequired("id")
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/storage"
+)
+
+// rewardFavoriteCmd represents the reward favorite command
+var rewardFavoriteCmd = &cobra.Command{
+	Use:   "favorite",
+	Short: "Add a reward to the favorites",
+	Long: `Add a reward to the favorites. Favoriting a reward twice has no effect.
+
+Example:
+  achievement-app reward favorite --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		wishlistService, err := initWishlistService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if err := wishlistService.AddFavorite(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "favorite.add_failed")
+		}
+
+		fmt.Println(msg.T("favorite.added"))
+		fmt.Println(msg.T("label.id", id))
+
+		return nil
+	},
+}
+
+// rewardUnfavoriteCmd represents the reward unfavorite command
+var rewardUnfavoriteCmd = &cobra.Command{
+	Use:   "unfavorite",
+	Short: "Remove a reward from the favorites",
+	Long: `Remove a reward from the favorites.
+
+Example:
+  achievement-app reward unfavorite --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		wishlistService, err := initWishlistService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if err := wishlistService.RemoveFavorite(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "favorite.remove_failed")
+		}
+
+		fmt.Println(msg.T("favorite.removed"))
+		fmt.Println(msg.T("label.id", id))
+
+		return nil
+	},
+}
+
+// rewardFavoritesCmd represents the reward favorites command
+var rewardFavoritesCmd = &cobra.Command{
+	Use:   "favorites",
+	Short: "List the favorite rewards",
+	Long: `List the favorite rewards in the order they were favorited.
+
+Example:
+  achievement-app reward favorites`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wishlistService, err := initWishlistService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		rewards, err := wishlistService.ListFavorites(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "favorite.list_failed")
+		}
+
+		if len(rewards) == 0 {
+			fmt.Println(msg.T("favorite.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("favorite.found", len(rewards)))
+		for i, reward := range rewards {
+			fmt.Println(msg.T("list.item", i+1, reward.Title, reward.ID))
+			fmt.Println(msg.T("list.description", reward.Description))
+			fmt.Println(msg.T("list.point_cost", reward.Point))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// wishlistCmd represents the wishlist command
+var wishlistCmd = &cobra.Command{
+	Use:   "wishlist",
+	Short: "Manage the reward wishlist",
+	Long: `Manage the wishlist of rewards you are saving up for.
+
+Each reward on the wishlist has a priority; a smaller number comes first, and
+rewards with the same priority are listed in the order they were added. The
+list shows how many more points are needed until each reward is affordable.`,
+}
+
+// wishlistAddCmd represents the wishlist add command
+var wishlistAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a reward to the wishlist",
+	Long: `Add a reward to the wishlist with the given priority.
+
+Example:
+  achievement-app wishlist add --id "01234567890" --priority 1`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		priority, _ := cmd.Flags().GetInt("priority")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+		if priority < 0 {
+			return msg.NewError("wishlist.priority_negative")
+		}
+
+		wishlistService, err := initWishlistService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		item := &models.WishlistItem{RewardID: id, Priority: priority}
+		if err := wishlistService.Add(cmd.Context(), item); err != nil {
+			return msg.Wrap(err, "wishlist.add_failed")
+		}
+
+		fmt.Println(msg.T("wishlist.added"))
+		fmt.Println(msg.T("label.id", id))
+		fmt.Println(msg.T("label.priority", item.Priority))
+
+		return nil
+	},
+}
+
+// wishlistListCmd represents the wishlist list command
+var wishlistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the wishlist with the points needed for each reward",
+	Long: `List the wishlist by priority, with the points still needed until each
+reward is affordable with the current balance.
+
+Example:
+  achievement-app wishlist list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wishlistService, err := initWishlistService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		entries, err := wishlistService.List(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "wishlist.list_failed")
+		}
+
+		if len(entries) == 0 {
+			fmt.Println(msg.T("wishlist.none"))
+			return nil
+		}
+
+		fmt.Printf("%s\n\n", msg.T("wishlist.found", len(entries)))
+		for i, entry := range entries {
+			reward := entry.Reward
+			fmt.Println(msg.T("list.item", i+1, reward.Title, reward.ID))
+			fmt.Println(msg.T("list.priority", entry.Item.Priority))
+			fmt.Println(msg.T("list.point_cost", reward.Point))
+			if entry.PointsNeeded == 0 {
+				fmt.Println(msg.T("list.affordable"))
+			} else {
+				fmt.Println(msg.T("list.points_needed", entry.PointsNeeded))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// wishlistPriorityCmd represents the wishlist priority command
+var wishlistPriorityCmd = &cobra.Command{
+	Use:   "priority",
+	Short: "Change the priority of a reward on the wishlist",
+	Long: `Change the priority of a reward on the wishlist.
+
+Example:
+  achievement-app wishlist priority --id "01234567890" --priority 0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		priority, _ := cmd.Flags().GetInt("priority")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+		if priority < 0 {
+			return msg.NewError("wishlist.priority_negative")
+		}
+
+		wishlistService, err := initWishlistService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if err := wishlistService.UpdatePriority(cmd.Context(), id, priority); err != nil {
+			return msg.Wrap(err, "wishlist.update_failed")
+		}
+
+		fmt.Println(msg.T("wishlist.updated"))
+		fmt.Println(msg.T("label.id", id))
+		fmt.Println(msg.T("label.priority", priority))
+
+		return nil
+	},
+}
+
+// wishlistRemoveCmd represents the wishlist remove command
+var wishlistRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a reward from the wishlist",
+	Long: `Remove a reward from the wishlist. The reward itself is not deleted.
+
+Example:
+  achievement-app wishlist remove --id "01234567890"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+
+		if id == "" {
+			return msg.NewError("common.id_required")
+		}
+
+		wishlistService, err := initWishlistService(cmd.Context())
+		if err != nil {
+			return msg.Wrap(err, "common.init_services_failed")
+		}
+
+		if err := wishlistService.Remove(cmd.Context(), id); err != nil {
+			return msg.Wrap(err, "wishlist.remove_failed")
+		}
+
+		fmt.Println(msg.T("wishlist.removed"))
+		fmt.Println(msg.T("label.id", id))
+
+		return nil
+	},
+}
+
+// initWishlistService initializes the favorites and wishlist service with the configured storage
+func initWishlistService(ctx context.Context) (services.WishlistService, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, msg.Wrap(err, "common.load_config_failed")
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return nil, msg.Wrap(err, "common.init_repository_failed")
+	}
+
+	return services.NewWishlistService(repos.Favorites, repos.Wishlist, repos.Rewards, repos.Points), nil
+}
+
+func init() {
+	// Favorites are managed as reward subcommands
+	rewardCmd.AddCommand(rewardFavoriteCmd)
+	rewardCmd.AddCommand(rewardUnfavoriteCmd)
+	rewardCmd.AddCommand(rewardFavoritesCmd)
+
+	// Add subcommands to wishlist command
+	wishlistCmd.AddCommand(wishlistAddCmd)
+	wishlistCmd.AddCommand(wishlistListCmd)
+	wishlistCmd.AddCommand(wishlistPriorityCmd)
+	wishlistCmd.AddCommand(wishlistRemoveCmd)
+
+	// Flags for favorite commands
+	rewardFavoriteCmd.Flags().String("id", "", "Reward ID (required)")
+	rewardFavoriteCmd.MarkFlagRequired("id")
+	rewardUnfavoriteCmd.Flags().String("id", "", "Reward ID (required)")
+	rewardUnfavoriteCmd.MarkFlagRequired("id")
+
+	// Flags for add command
+	wishlistAddCmd.Flags().String("id", "", "Reward ID (required)")
+	wishlistAddCmd.Flags().Int("priority", 0, "Priority (smaller comes first)")
+	wishlistAddCmd.MarkFlagRequired("id")
+
+	// Flags for priority command
+	wishlistPriorityCmd.Flags().String("id", "", "Reward ID (required)")
+	wishlistPriorityCmd.Flags().Int("priority", 0, "New priority (smaller comes first, required)")
+	wishlistPriorityCmd.MarkFlagRequired("id")
+	wishlistPriorityCmd.MarkFlagRequired("priority")
+
+	// Flags for remove command
+	wishlistRemoveCmd.Flags().String("id", "", "Reward ID (required)")
+	wishlistRemoveCmd.MarkFlagRequired("id")
+}
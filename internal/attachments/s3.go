@@ -0,0 +1,118 @@
+package attachments
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// PresignAPI 署名付きURLの発行に使用するS3操作のインターフェース
+type PresignAPI interface {
+	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+// S3Store S3のバケットに保存する添付ファイルの署名付きURLを発行する
+type S3Store struct {
+	client         PresignAPI
+	bucket         string
+	uploadExpiry   time.Duration
+	downloadExpiry time.Duration
+}
+
+// NewS3Store 添付ファイルの保存先を作成
+func NewS3Store(client PresignAPI, cfg config.AttachmentsConfig) *S3Store {
+	return &S3Store{
+		client:         client,
+		bucket:         cfg.Bucket,
+		uploadExpiry:   cfg.UploadExpiry(),
+		downloadExpiry: cfg.DownloadExpiry(),
+	}
+}
+
+// NewPresignClient 設定からS3の署名付きURLのクライアントを作成（endpoint を指定した場合はパス形式でアクセスする）
+func NewPresignClient(ctx context.Context, appConfig *config.Config) (*s3.PresignClient, error) {
+	awsConfig, err := repository.LoadAWSConfig(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if appConfig.Attachments.Endpoint != "" {
+			o.BaseEndpoint = aws.String(appConfig.Attachments.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return s3.NewPresignClient(client), nil
+}
+
+// Open 設定のS3のバケットを使用する添付ファイルの保存先を作成
+func Open(ctx context.Context, appConfig *config.Config) (*S3Store, error) {
+	if appConfig.Attachments.Bucket == "" {
+		return nil, fmt.Errorf("attachments bucket is not configured")
+	}
+
+	client, err := NewPresignClient(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewS3Store(client, appConfig.Attachments), nil
+}
+
+// PresignUpload オブジェクトをアップロードする署名付きURLを発行
+func (s *S3Store) PresignUpload(ctx context.Context, key, contentType string) (*models.AttachmentUpload, error) {
+	expiresAt := time.Now().Add(s.uploadExpiry)
+	request, err := s.client.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(s.uploadExpiry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign upload to s3://%s/%s: %w", s.bucket, key, err)
+	}
+
+	// ダウンロード時にファイルの種類がわかるよう、Content-Type を付けてアップロードさせる
+	headers := signedHeaders(request.SignedHeader)
+	headers["Content-Type"] = contentType
+
+	return &models.AttachmentUpload{
+		Key:       key,
+		URL:       request.URL,
+		Method:    request.Method,
+		Headers:   headers,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// PresignDownload オブジェクトをダウンロードする署名付きURLを発行
+func (s *S3Store) PresignDownload(ctx context.Context, key string) (string, error) {
+	request, err := s.client.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(s.downloadExpiry))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign download of s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return request.URL, nil
+}
+
+// signedHeaders クライアントが送信する必要がある署名済みヘッダー（Host はHTTPクライアントが付けるため除く）
+func signedHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name := range header {
+		if http.CanonicalHeaderKey(name) == "Host" {
+			continue
+		}
+		headers[name] = header.Get(name)
+	}
+	return headers
+}
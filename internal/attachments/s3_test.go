@@ -0,0 +1,60 @@
+package attachments
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/config"
+)
+
+// newTestStore 固定の認証情報でローカルのエンドポイントに署名する保存先（署名はリクエストを送信せずに計算する）
+func newTestStore() *S3Store {
+	client := s3.New(s3.Options{
+		Region: "ap-northeast-1",
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		}),
+		BaseEndpoint: aws.String("http://localhost:9000"),
+		UsePathStyle: true,
+	})
+	return NewS3Store(s3.NewPresignClient(client), config.AttachmentsConfig{
+		Bucket:                "receipts",
+		UploadExpiryMinutes:   15,
+		DownloadExpiryMinutes: 60,
+	})
+}
+
+func TestS3Store_PresignUpload(t *testing.T) {
+	store := newTestStore()
+
+	before := time.Now()
+	upload, err := store.PresignUpload(context.Background(), "attachments/default/redemption/h1/1.jpg", "image/jpeg")
+	require.NoError(t, err)
+
+	assert.Equal(t, "attachments/default/redemption/h1/1.jpg", upload.Key)
+	assert.Equal(t, http.MethodPut, upload.Method)
+	assert.True(t, strings.HasPrefix(upload.URL, "http://localhost:9000/receipts/attachments/default/redemption/h1/1.jpg?"), upload.URL)
+	assert.Contains(t, upload.URL, "X-Amz-Expires=900")
+	// クライアントには Content-Type を付けてアップロードさせる
+	assert.Equal(t, "image/jpeg", upload.Headers["Content-Type"])
+	assert.NotContains(t, upload.Headers, "Host")
+	assert.WithinDuration(t, before.Add(15*time.Minute), upload.ExpiresAt, time.Minute)
+}
+
+func TestS3Store_PresignDownload(t *testing.T) {
+	store := newTestStore()
+
+	url, err := store.PresignDownload(context.Background(), "attachments/default/achievement/a1/1.png")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(url, "http://localhost:9000/receipts/attachments/default/achievement/a1/1.png?"), url)
+	assert.Contains(t, url, "X-Amz-Expires=3600")
+	assert.Contains(t, url, "X-Amz-Signature=")
+}
@@ -0,0 +1,415 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/logging"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// 監査ログの対象の種類（entity の値）
+const (
+	// EntityAchievement 達成目録
+	EntityAchievement = "achievement"
+	// EntityCompletion 達成記録
+	EntityCompletion = "completion"
+	// EntityReward 報酬
+	EntityReward = "reward"
+	// EntityRedemption 報酬の獲得履歴
+	EntityRedemption = "redemption"
+	// EntityPoints 現在のポイント
+	EntityPoints = "points"
+)
+
+// currentPointsID 現在のポイントのID
+const currentPointsID = "current"
+
+// recorder 成功した変更を監査ログに記録する
+type recorder struct {
+	logger *logging.AuditLogger
+}
+
+// record 変更を記録する（記録できなかった場合は変更を取り消せないため、リクエストのログに残す）
+func (r recorder) record(ctx context.Context, action, entity, id, operation string, before, after interface{}) {
+	err := r.logger.Log(ctx, logging.AuditRecord{
+		Action:     action,
+		Entity:     entity,
+		EntityID:   id,
+		Operation:  operation,
+		BeforeHash: logging.AuditHash(before),
+		AfterHash:  logging.AuditHash(after),
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithFields(map[string]interface{}{
+			"entity":    entity,
+			"entity_id": id,
+			"operation": operation,
+			"error":     err.Error(),
+		}).Error("Failed to write audit record")
+	}
+}
+
+// AchievementRepository 達成目録・達成記録の変更を監査ログに記録する達成目録リポジトリ
+type AchievementRepository struct {
+	next repository.AchievementRepository
+	recorder
+}
+
+// NewAchievementRepository 達成目録リポジトリに監査ログの記録を追加
+func NewAchievementRepository(next repository.AchievementRepository, logger *logging.AuditLogger) repository.AchievementRepository {
+	return &AchievementRepository{next: next, recorder: recorder{logger: logger}}
+}
+
+// before 変更前の達成目録（取得できない場合は nil）
+func (r *AchievementRepository) before(ctx context.Context, id string) *models.Achievement {
+	achievement, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return achievement
+}
+
+// Create 達成目録を作成
+func (r *AchievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.next.Create(ctx, achievement); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionCreate, EntityAchievement, achievement.ID, "Create", nil, achievement)
+	return nil
+}
+
+// CreateWithPoints 達成目録の作成・ポイント加算・台帳への記録を実行
+func (r *AchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.next.CreateWithPoints(ctx, achievement); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionCreate, EntityAchievement, achievement.ID, "CreateWithPoints", nil, achievement)
+	return nil
+}
+
+// Update 達成目録を更新
+func (r *AchievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
+	before := r.before(ctx, achievement.ID)
+	if err := r.next.Update(ctx, achievement); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityAchievement, achievement.ID, "Update", before, achievement)
+	return nil
+}
+
+// UpdateWithPoints 達成目録を更新し、ポイントの差分を反映
+func (r *AchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	before := r.before(ctx, achievement.ID)
+	if err := r.next.UpdateWithPoints(ctx, achievement); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityAchievement, achievement.ID, "UpdateWithPoints", before, achievement)
+	return nil
+}
+
+// GetByID IDで達成目録を取得
+func (r *AchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべての達成目録を取得
+func (r *AchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
+	return r.next.List(ctx)
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイントのみを取得
+func (r *AchievementRepository) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	return r.next.ListSummaries(ctx)
+}
+
+// Count 達成目録の件数を取得
+func (r *AchievementRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 達成目録を削除
+func (r *AchievementRepository) Delete(ctx context.Context, id string) error {
+	before := r.before(ctx, id)
+	if err := r.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionDelete, EntityAchievement, id, "Delete", before, nil)
+	return nil
+}
+
+// DeleteWithPoints 達成目録の削除・ポイント減算・台帳への記録を実行
+func (r *AchievementRepository) DeleteWithPoints(ctx context.Context, id string) error {
+	before := r.before(ctx, id)
+	if err := r.next.DeleteWithPoints(ctx, id); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionDelete, EntityAchievement, id, "DeleteWithPoints", before, nil)
+	return nil
+}
+
+// DeleteMany 複数の達成目録をまとめて削除
+func (r *AchievementRepository) DeleteMany(ctx context.Context, ids []string) error {
+	before := make([]*models.Achievement, len(ids))
+	for i, id := range ids {
+		before[i] = r.before(ctx, id)
+	}
+	if err := r.next.DeleteMany(ctx, ids); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		r.record(ctx, logging.AuditActionDelete, EntityAchievement, id, "DeleteMany", before[i], nil)
+	}
+	return nil
+}
+
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存
+func (r *AchievementRepository) SetAttachment(ctx context.Context, id, key string) error {
+	before := r.before(ctx, id)
+	if err := r.next.SetAttachment(ctx, id, key); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityAchievement, id, "SetAttachment", before, r.before(ctx, id))
+	return nil
+}
+
+// StartTimer 達成目録のタイマーを開始
+func (r *AchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) error {
+	before := r.before(ctx, id)
+	if err := r.next.StartTimer(ctx, id, at); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityAchievement, id, "StartTimer", before, r.before(ctx, id))
+	return nil
+}
+
+// Complete 達成記録を作成し、ポイントを付与
+func (r *AchievementRepository) Complete(ctx context.Context, completion *models.Completion) error {
+	if err := r.next.Complete(ctx, completion); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionCreate, EntityCompletion, completion.ID, "Complete", nil, completion)
+	return nil
+}
+
+// ListCompletions 達成目録の達成記録を取得
+func (r *AchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	return r.next.ListCompletions(ctx, achievementID)
+}
+
+// RewardRepository 報酬の変更を監査ログに記録する報酬リポジトリ
+type RewardRepository struct {
+	next repository.RewardRepository
+	recorder
+}
+
+// NewRewardRepository 報酬リポジトリに監査ログの記録を追加
+func NewRewardRepository(next repository.RewardRepository, logger *logging.AuditLogger) repository.RewardRepository {
+	return &RewardRepository{next: next, recorder: recorder{logger: logger}}
+}
+
+// before 変更前の報酬（取得できない場合は nil）
+func (r *RewardRepository) before(ctx context.Context, id string) *models.Reward {
+	reward, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return reward
+}
+
+// Create 報酬を作成
+func (r *RewardRepository) Create(ctx context.Context, reward *models.Reward) error {
+	if err := r.next.Create(ctx, reward); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionCreate, EntityReward, reward.ID, "Create", nil, reward)
+	return nil
+}
+
+// Update 報酬を更新
+func (r *RewardRepository) Update(ctx context.Context, reward *models.Reward) error {
+	before := r.before(ctx, reward.ID)
+	if err := r.next.Update(ctx, reward); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityReward, reward.ID, "Update", before, reward)
+	return nil
+}
+
+// GetByID IDで報酬を取得
+func (r *RewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// GetByIDs 複数のIDで報酬を取得
+func (r *RewardRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	return r.next.GetByIDs(ctx, ids)
+}
+
+// List すべての報酬を取得
+func (r *RewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
+	return r.next.List(ctx)
+}
+
+// Count 報酬の件数を取得
+func (r *RewardRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 報酬を削除
+func (r *RewardRepository) Delete(ctx context.Context, id string) error {
+	before := r.before(ctx, id)
+	if err := r.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionDelete, EntityReward, id, "Delete", before, nil)
+	return nil
+}
+
+// PointRepository ポイント・獲得履歴の変更を監査ログに記録するポイントリポジトリ
+type PointRepository struct {
+	next repository.PointRepository
+	recorder
+}
+
+// NewPointRepository ポイントリポジトリに監査ログの記録を追加
+func NewPointRepository(next repository.PointRepository, logger *logging.AuditLogger) repository.PointRepository {
+	return &PointRepository{next: next, recorder: recorder{logger: logger}}
+}
+
+// currentPoints 変更前後の現在のポイント（取得できない場合は nil）
+func (r *PointRepository) currentPoints(ctx context.Context) *models.CurrentPoints {
+	points, err := r.next.GetCurrentPointsConsistent(ctx)
+	if err != nil {
+		return nil
+	}
+	return points
+}
+
+// redemption 変更前後の獲得履歴（取得できない場合は nil）
+func (r *PointRepository) redemption(ctx context.Context, id string) *models.RewardHistory {
+	history, err := r.next.GetRewardHistoryByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return history
+}
+
+// GetCurrentPoints 現在のポイントを取得
+func (r *PointRepository) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.next.GetCurrentPoints(ctx)
+}
+
+// GetCurrentPointsConsistent 直前の書き込みを反映した現在のポイントを取得
+func (r *PointRepository) GetCurrentPointsConsistent(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.next.GetCurrentPointsConsistent(ctx)
+}
+
+// UpdateCurrentPoints 現在のポイントを更新
+func (r *PointRepository) UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) error {
+	before := r.currentPoints(ctx)
+	if err := r.next.UpdateCurrentPoints(ctx, points); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityPoints, currentPointsID, "UpdateCurrentPoints", before, points)
+	return nil
+}
+
+// CreateRewardHistory 報酬獲得履歴を作成
+func (r *PointRepository) CreateRewardHistory(ctx context.Context, history *models.RewardHistory) error {
+	if err := r.next.CreateRewardHistory(ctx, history); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionCreate, EntityRedemption, history.ID, "CreateRewardHistory", nil, history)
+	return nil
+}
+
+// GetRewardHistory 報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
+	return r.next.GetRewardHistory(ctx)
+}
+
+// GetRewardHistoryBetween 期間内の報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	return r.next.GetRewardHistoryBetween(ctx, from, to)
+}
+
+// CountRewardHistory 報酬獲得履歴の件数を取得
+func (r *PointRepository) CountRewardHistory(ctx context.Context) (int, error) {
+	return r.next.CountRewardHistory(ctx)
+}
+
+// RedeemPoints ポイント減算・獲得履歴の作成・台帳への記録を実行
+func (r *PointRepository) RedeemPoints(ctx context.Context, history *models.RewardHistory) error {
+	if err := r.next.RedeemPoints(ctx, history); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionCreate, EntityRedemption, history.ID, "RedeemPoints", nil, history)
+	return nil
+}
+
+// GetRewardHistoryByID IDで報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryByID(ctx context.Context, id string) (*models.RewardHistory, error) {
+	return r.next.GetRewardHistoryByID(ctx, id)
+}
+
+// RefundRedemption 獲得の取り消し・ポイントの返還・台帳への記録を実行
+func (r *PointRepository) RefundRedemption(ctx context.Context, history *models.RewardHistory) error {
+	before := r.redemption(ctx, history.ID)
+	if err := r.next.RefundRedemption(ctx, history); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityRedemption, history.ID, "RefundRedemption", before, history)
+	return nil
+}
+
+// SetRedemptionAttachment 報酬獲得履歴に添付ファイルのオブジェクトキーを保存
+func (r *PointRepository) SetRedemptionAttachment(ctx context.Context, id, key string) error {
+	before := r.redemption(ctx, id)
+	if err := r.next.SetRedemptionAttachment(ctx, id, key); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityRedemption, id, "SetRedemptionAttachment", before, r.redemption(ctx, id))
+	return nil
+}
+
+// AnnotateRedemption 報酬獲得履歴のメモとタグを保存
+func (r *PointRepository) AnnotateRedemption(ctx context.Context, id, note string, tags []string) error {
+	before := r.redemption(ctx, id)
+	if err := r.next.AnnotateRedemption(ctx, id, note, tags); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityRedemption, id, "AnnotateRedemption", before, r.redemption(ctx, id))
+	return nil
+}
+
+// GetLedger ポイント台帳を取得
+func (r *PointRepository) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	return r.next.GetLedger(ctx)
+}
+
+// GetLedgerBetween 記録日時の範囲を指定してポイント台帳を取得
+func (r *PointRepository) GetLedgerBetween(ctx context.Context, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	return r.next.GetLedgerBetween(ctx, from, to)
+}
+
+// AddPoints ポイントを加算
+func (r *PointRepository) AddPoints(ctx context.Context, points int) error {
+	before := r.currentPoints(ctx)
+	if err := r.next.AddPoints(ctx, points); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityPoints, currentPointsID, "AddPoints", before, r.currentPoints(ctx))
+	return nil
+}
+
+// SubtractPoints ポイントを減算
+func (r *PointRepository) SubtractPoints(ctx context.Context, points int) error {
+	before := r.currentPoints(ctx)
+	if err := r.next.SubtractPoints(ctx, points); err != nil {
+		return err
+	}
+	r.record(ctx, logging.AuditActionUpdate, EntityPoints, currentPointsID, "SubtractPoints", before, r.currentPoints(ctx))
+	return nil
+}
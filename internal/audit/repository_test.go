@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"achievement-management/internal/logging"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository/memory"
+)
+
+// records 監査ログに記録されたレコード
+func records(t *testing.T, buf *bytes.Buffer) []logging.AuditRecord {
+	t.Helper()
+	var result []logging.AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record logging.AuditRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Failed to decode audit record %q: %v", line, err)
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+func TestAchievementRepository_RecordsChanges(t *testing.T) {
+	ctx := logging.WithActor(context.Background(), "user-42")
+	var buf bytes.Buffer
+	repo := NewAchievementRepository(memory.NewAchievementRepository(memory.NewStore()), logging.NewAuditLoggerWithOutput(&buf))
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	created := logging.AuditHash(achievement)
+	updated := *achievement
+	updated.Point = 20
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := repo.Delete(ctx, achievement.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	// 失敗した変更は記録しない
+	if err := repo.Delete(ctx, "missing"); err == nil {
+		t.Fatal("Expected not found error")
+	}
+
+	got := records(t, &buf)
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 audit records, got %+v", got)
+	}
+	for i, action := range []string{logging.AuditActionCreate, logging.AuditActionUpdate, logging.AuditActionDelete} {
+		if got[i].Action != action || got[i].Entity != EntityAchievement || got[i].EntityID != achievement.ID || got[i].Actor != "user-42" {
+			t.Errorf("Unexpected record %d: %+v", i, got[i])
+		}
+	}
+	if got[0].BeforeHash != "" || got[0].AfterHash != created {
+		t.Errorf("Expected only the after hash on create, got %+v", got[0])
+	}
+	if got[1].BeforeHash != created || got[1].AfterHash != logging.AuditHash(&updated) {
+		t.Errorf("Expected before and after hashes on update, got %+v", got[1])
+	}
+	if got[2].BeforeHash != got[1].AfterHash || got[2].AfterHash != "" {
+		t.Errorf("Expected only the before hash on delete, got %+v", got[2])
+	}
+}
+
+func TestPointRepository_RecordsChanges(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	store := memory.NewStore()
+	repo := NewPointRepository(memory.NewPointRepository(store), logging.NewAuditLoggerWithOutput(&buf))
+
+	if err := repo.AddPoints(ctx, 50); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	history := &models.RewardHistory{RewardID: "r1", RewardTitle: "おやつ", PointCost: 30}
+	if err := repo.RedeemPoints(ctx, history); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+
+	got := records(t, &buf)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 audit records, got %+v", got)
+	}
+	if got[0].Entity != EntityPoints || got[0].Action != logging.AuditActionUpdate || got[0].AfterHash == "" || got[0].Actor != logging.ActorSystem {
+		t.Errorf("Unexpected points record: %+v", got[0])
+	}
+	if got[1].Entity != EntityRedemption || got[1].Action != logging.AuditActionCreate || got[1].EntityID != history.ID {
+		t.Errorf("Unexpected redemption record: %+v", got[1])
+	}
+}
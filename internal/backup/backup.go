@@ -0,0 +1,259 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/repository"
+)
+
+const (
+	// manifestName スナップショットの内容を記録するオブジェクト名
+	manifestName = "manifest.json"
+	// snapshotIDLayout スナップショットIDの書式（作成日時のUTC）
+	snapshotIDLayout = "20060102T150405Z"
+	// restoreBatchSize 復元時に1回の一括書き込みにまとめる件数
+	restoreBatchSize = 100
+)
+
+// ObjectStore スナップショットの保存先
+type ObjectStore interface {
+	Put(ctx context.Context, key string, body []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// Manifest スナップショットの内容
+type Manifest struct {
+	ID        string          `json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Gzip      bool            `json:"gzip"`
+	Tables    []TableSnapshot `json:"tables"`
+}
+
+// TableSnapshot スナップショットに含まれる1テーブル分の内容
+type TableSnapshot struct {
+	// Key 設定ファイル上のテーブルの識別子（復元先のテーブルの特定に使用する）
+	Key string `json:"key"`
+	// Name エクスポート元のテーブル名
+	Name string `json:"name"`
+	// Object テーブルの内容を保存したオブジェクトのキー
+	Object string `json:"object"`
+	Items  int    `json:"items"`
+}
+
+// Service テーブルの内容をJSON Lines形式でエクスポート・復元する
+//
+// テーブルの全件を対象にするため、すべてのテナントのアイテムを含む。
+type Service struct {
+	repo   repository.Repository
+	store  ObjectStore
+	tables []repository.TableDefinition
+	prefix string
+	gzip   bool
+	// segments, workers エクスポート時の並列スキャンのセグメント数と同時実行数
+	segments int
+	workers  int
+	now      func() time.Time
+}
+
+// NewService バックアップサービスを作成
+func NewService(repo repository.Repository, store ObjectStore, cfg *config.Config) *Service {
+	return &Service{
+		repo:     repo,
+		store:    store,
+		tables:   repository.TableDefinitions(cfg),
+		prefix:   cfg.Backup.Prefix,
+		gzip:     cfg.Backup.Gzip,
+		segments: cfg.Backup.ScanSegments,
+		workers:  cfg.Backup.ScanWorkers,
+		now:      time.Now,
+	}
+}
+
+// Export すべてのテーブルの内容を新しいスナップショットとして保存
+//
+// テーブルごとに全件をメモリ上で組み立ててから保存する。マニフェストは最後に保存するため、
+// 途中で失敗したスナップショットは復元できない。
+func (s *Service) Export(ctx context.Context) (*Manifest, error) {
+	createdAt := s.now().UTC()
+	manifest := &Manifest{
+		ID:        createdAt.Format(snapshotIDLayout),
+		CreatedAt: createdAt,
+		Gzip:      s.gzip,
+	}
+
+	for _, table := range s.tables {
+		body, count, err := s.exportTable(ctx, table.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		object := s.objectKey(manifest.ID, table.Key+".jsonl")
+		if s.gzip {
+			object += ".gz"
+		}
+		if err := s.store.Put(ctx, object, body); err != nil {
+			return nil, fmt.Errorf("failed to save table %s: %w", table.Name, err)
+		}
+
+		manifest.Tables = append(manifest.Tables, TableSnapshot{
+			Key:    table.Key,
+			Name:   table.Name,
+			Object: object,
+			Items:  count,
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := s.store.Put(ctx, s.objectKey(manifest.ID, manifestName), data); err != nil {
+		return nil, fmt.Errorf("failed to save manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// Restore スナップショットの内容を現在の設定のテーブルに書き戻す
+//
+// スナップショットと同じIDのアイテムは上書きするが、スナップショットに含まれないアイテムは削除しない。
+func (s *Service) Restore(ctx context.Context, snapshotID string) (*Manifest, error) {
+	if snapshotID == "" || strings.Contains(snapshotID, "/") {
+		return nil, &errors.ValidationError{Field: "snapshot_id", Message: "snapshot_id must be the id of an exported snapshot"}
+	}
+
+	manifest, err := s.loadManifest(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 復元を始める前にすべてのテーブルの復元先を確認する
+	targets := make(map[string]string, len(s.tables))
+	for _, table := range s.tables {
+		targets[table.Key] = table.Name
+	}
+	for _, table := range manifest.Tables {
+		if _, ok := targets[table.Key]; !ok {
+			return nil, fmt.Errorf("snapshot %s contains unknown table %s", snapshotID, table.Key)
+		}
+	}
+
+	for _, table := range manifest.Tables {
+		if err := s.restoreTable(ctx, table, targets[table.Key], manifest.Gzip); err != nil {
+			return nil, err
+		}
+	}
+
+	return manifest, nil
+}
+
+// exportTable テーブルの全件を並列スキャンで読み込んでJSON Lines形式に変換（行の順序はスキャンの順序に依存しない）
+func (s *Service) exportTable(ctx context.Context, tableName string) ([]byte, int, error) {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var zw *gzip.Writer
+	if s.gzip {
+		zw = gzip.NewWriter(&buf)
+		w = zw
+	}
+
+	encoder := json.NewEncoder(w)
+	count := 0
+	input := repository.ScanInput{TableName: tableName}
+	err := repository.ParallelScanEach(ctx, s.repo, input, s.segments, s.workers, func(item repository.Item) error {
+		var values map[string]interface{}
+		if err := item.Unmarshal(&values); err != nil {
+			return err
+		}
+		count++
+		return encoder.Encode(values)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to export table %s: %w", tableName, err)
+	}
+
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return nil, 0, fmt.Errorf("failed to compress table %s: %w", tableName, err)
+		}
+	}
+	return buf.Bytes(), count, nil
+}
+
+// restoreTable 1テーブル分のオブジェクトを読み込んでまとめて書き込み
+func (s *Service) restoreTable(ctx context.Context, table TableSnapshot, tableName string, compressed bool) error {
+	body, err := s.store.Get(ctx, table.Object)
+	if err != nil {
+		return fmt.Errorf("failed to read table %s from snapshot: %w", table.Key, err)
+	}
+	defer body.Close()
+
+	var r io.Reader = body
+	if compressed {
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return fmt.Errorf("failed to decompress table %s: %w", table.Key, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	decoder := json.NewDecoder(r)
+	batch := make([]interface{}, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.repo.BatchPutItems(ctx, tableName, batch); err != nil {
+			return fmt.Errorf("failed to restore table %s: %w", tableName, err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		var values map[string]interface{}
+		if err := decoder.Decode(&values); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to decode table %s: %w", table.Key, err)
+		}
+
+		batch = append(batch, values)
+		if len(batch) == restoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// loadManifest スナップショットのマニフェストを取得
+func (s *Service) loadManifest(ctx context.Context, snapshotID string) (*Manifest, error) {
+	body, err := s.store.Get(ctx, s.objectKey(snapshotID, manifestName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of snapshot %s: %w", snapshotID, err)
+	}
+	defer body.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest of snapshot %s: %w", snapshotID, err)
+	}
+	return &manifest, nil
+}
+
+// objectKey スナップショット内のオブジェクトのキー
+func (s *Service) objectKey(snapshotID, name string) string {
+	return s.prefix + snapshotID + "/" + name
+}
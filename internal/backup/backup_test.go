@@ -0,0 +1,230 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/repository"
+)
+
+// memoryStore オブジェクトをメモリ上に保存する保存先
+type memoryStore struct {
+	objects map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{objects: map[string][]byte{}}
+}
+
+func (s *memoryStore) Put(ctx context.Context, key string, body []byte) error {
+	s.objects[key] = append([]byte(nil), body...)
+	return nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	body, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", key, errors.ErrNotFound)
+	}
+	return io.NopCloser(bytes.NewReader(body)), nil
+}
+
+// fakeRepository テーブルのアイテムをメモリ上に保持するリポジトリ
+type fakeRepository struct {
+	repository.Repository
+	tables map[string][]map[string]interface{}
+	puts   map[string]int
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{tables: map[string][]map[string]interface{}{}, puts: map[string]int{}}
+}
+
+func (r *fakeRepository) ScanEach(ctx context.Context, input repository.ScanInput, fn repository.ItemHandler) error {
+	for i, values := range r.tables[input.TableName] {
+		// 並列スキャンの場合は担当するセグメントのアイテムのみ返す
+		if input.TotalSegments > 0 && i%input.TotalSegments != input.Segment {
+			continue
+		}
+		item, err := repository.NewItem(values)
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepository) BatchPutItems(ctx context.Context, tableName string, items []interface{}) error {
+	r.puts[tableName]++
+	for _, item := range items {
+		r.tables[tableName] = append(r.tables[tableName], item.(map[string]interface{}))
+	}
+	return nil
+}
+
+func testConfig(prefix string, gzip bool) *config.Config {
+	return &config.Config{
+		Tables: config.TableConfig{
+			Achievements:  prefix + "achievements",
+			Rewards:       prefix + "rewards",
+			CurrentPoints: prefix + "current_points",
+			RewardHistory: prefix + "reward_history",
+			PointLedger:   prefix + "point_ledger",
+			Completions:   prefix + "completions",
+			Badges:        prefix + "badges",
+			Goals:         prefix + "goals",
+			Favorites:     prefix + "favorites",
+			Wishlist:      prefix + "wishlist",
+			Notes:         prefix + "notes",
+			DriftEvents:   prefix + "drift_events",
+			Reservations:  prefix + "reservations",
+			Quests:        prefix + "quests",
+			Operations:    prefix + "operations",
+			Allowances:    prefix + "allowances",
+			APIKeys:       prefix + "api_keys",
+		},
+		Backup: config.BackupConfig{Prefix: "backups/", Gzip: gzip},
+	}
+}
+
+func newTestService(repo repository.Repository, store ObjectStore, cfg *config.Config) *Service {
+	service := NewService(repo, store, cfg)
+	service.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return service
+}
+
+func TestService_ExportAndRestore(t *testing.T) {
+	for _, gzip := range []bool{true, false} {
+		t.Run(fmt.Sprintf("gzip=%v", gzip), func(t *testing.T) {
+			ctx := context.Background()
+			store := newMemoryStore()
+
+			source := newFakeRepository()
+			source.tables["prod-achievements"] = []map[string]interface{}{
+				{"id": "a1", "title": "Run", "point": 10, "entity_type": "achievement"},
+				{"id": "acme#a2", "title": "Read", "point": 20, "entity_type": "acme#achievement"},
+			}
+			source.tables["prod-current_points"] = []map[string]interface{}{
+				{"id": "current", "point": 30},
+			}
+
+			manifest, err := newTestService(source, store, testConfig("prod-", gzip)).Export(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, "20250102T030405Z", manifest.ID)
+			assert.Equal(t, gzip, manifest.Gzip)
+			require.Len(t, manifest.Tables, 17)
+			assert.Equal(t, "achievements", manifest.Tables[0].Key)
+			assert.Equal(t, "prod-achievements", manifest.Tables[0].Name)
+			assert.Equal(t, 2, manifest.Tables[0].Items)
+			assert.Equal(t, 0, manifest.Tables[1].Items)
+
+			object := "backups/20250102T030405Z/achievements.jsonl"
+			if gzip {
+				object += ".gz"
+			}
+			assert.Equal(t, object, manifest.Tables[0].Object)
+			assert.Contains(t, store.objects, object)
+			assert.Contains(t, store.objects, "backups/20250102T030405Z/manifest.json")
+
+			// 別の環境のテーブルに復元する
+			target := newFakeRepository()
+			restored, err := newTestService(target, store, testConfig("staging-", gzip)).Restore(ctx, manifest.ID)
+			require.NoError(t, err)
+
+			assert.Equal(t, manifest.ID, restored.ID)
+			assert.ElementsMatch(t, []map[string]interface{}{
+				{"id": "a1", "title": "Run", "point": float64(10), "entity_type": "achievement"},
+				{"id": "acme#a2", "title": "Read", "point": float64(20), "entity_type": "acme#achievement"},
+			}, target.tables["staging-achievements"])
+			assert.Equal(t, []map[string]interface{}{{"id": "current", "point": float64(30)}}, target.tables["staging-current_points"])
+			assert.NotContains(t, target.puts, "staging-rewards")
+		})
+	}
+}
+
+func TestService_RestoreWritesInBatches(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	source := newFakeRepository()
+	for i := 0; i < restoreBatchSize+1; i++ {
+		source.tables["achievements"] = append(source.tables["achievements"], map[string]interface{}{"id": fmt.Sprintf("a%d", i)})
+	}
+
+	cfg := testConfig("", true)
+	manifest, err := newTestService(source, store, cfg).Export(ctx)
+	require.NoError(t, err)
+
+	target := newFakeRepository()
+	_, err = newTestService(target, store, cfg).Restore(ctx, manifest.ID)
+	require.NoError(t, err)
+
+	assert.Len(t, target.tables["achievements"], restoreBatchSize+1)
+	assert.Equal(t, 2, target.puts["achievements"])
+}
+
+func TestService_ExportParallelScan(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	source := newFakeRepository()
+	for i := 0; i < 250; i++ {
+		source.tables["reward_history"] = append(source.tables["reward_history"], map[string]interface{}{"id": fmt.Sprintf("h%d", i)})
+	}
+
+	cfg := testConfig("", false)
+	cfg.Backup.ScanSegments = 8
+	cfg.Backup.ScanWorkers = 3
+	manifest, err := newTestService(source, store, cfg).Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 250, manifest.Tables[3].Items)
+
+	// すべてのセグメントのアイテムが1回ずつ含まれる
+	target := newFakeRepository()
+	_, err = newTestService(target, store, cfg).Restore(ctx, manifest.ID)
+	require.NoError(t, err)
+	ids := map[interface{}]bool{}
+	for _, values := range target.tables["reward_history"] {
+		ids[values["id"]] = true
+	}
+	assert.Len(t, target.tables["reward_history"], 250)
+	assert.Len(t, ids, 250)
+}
+
+func TestService_RestoreErrors(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	service := newTestService(newFakeRepository(), store, testConfig("", true))
+
+	// スナップショットIDの検証
+	for _, id := range []string{"", "../other"} {
+		_, err := service.Restore(ctx, id)
+		var validationErr *errors.ValidationError
+		assert.True(t, stderrors.As(err, &validationErr), id)
+	}
+
+	// 存在しないスナップショット
+	_, err := service.Restore(ctx, "20250102T030405Z")
+	assert.True(t, stderrors.Is(err, errors.ErrNotFound))
+
+	// 現在の設定に無いテーブルを含むスナップショットは何も書き込まない
+	store.objects["backups/old/manifest.json"] = []byte(`{"id":"old","tables":[{"key":"achievements","object":"backups/old/achievements.jsonl"},{"key":"unknown","object":"backups/old/unknown.jsonl"}]}`)
+	repo := newFakeRepository()
+	_, err = newTestService(repo, store, testConfig("", false)).Restore(ctx, "old")
+	assert.ErrorContains(t, err, "unknown table unknown")
+	assert.Empty(t, repo.puts)
+}
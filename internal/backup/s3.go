@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/repository"
+)
+
+// S3API スナップショットの保存に使用するS3操作のインターフェース
+type S3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// S3Store S3のバケットにスナップショットを保存する
+type S3Store struct {
+	client S3API
+	bucket string
+}
+
+// NewS3Store S3の保存先を作成
+func NewS3Store(client S3API, bucket string) *S3Store {
+	return &S3Store{client: client, bucket: bucket}
+}
+
+// NewS3Client 設定からS3クライアントを作成（endpoint を指定した場合はパス形式でアクセスする）
+func NewS3Client(ctx context.Context, appConfig *config.Config) (*s3.Client, error) {
+	awsConfig, err := repository.LoadAWSConfig(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if appConfig.Backup.Endpoint != "" {
+			o.BaseEndpoint = aws.String(appConfig.Backup.Endpoint)
+			o.UsePathStyle = true
+		}
+	}), nil
+}
+
+// Open 設定のDynamoDBとS3のバケットを使用するバックアップサービスを作成
+func Open(ctx context.Context, appConfig *config.Config) (*Service, error) {
+	if appConfig.Backup.Bucket == "" {
+		return nil, fmt.Errorf("backup bucket is not configured")
+	}
+
+	repo, err := repository.NewDynamoDBRepository(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewS3Client(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewService(repo, NewS3Store(client, appConfig.Backup.Bucket), appConfig), nil
+}
+
+// Put オブジェクトを保存
+func (s *S3Store) Put(ctx context.Context, key string, body []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return nil
+}
+
+// Get オブジェクトを取得（存在しない場合は ErrNotFound を返す）
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if stderrors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("s3://%s/%s: %w", s.bucket, key, errors.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return output.Body, nil
+}
@@ -0,0 +1,58 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+)
+
+// fakeS3Client オブジェクトをメモリ上に保存するS3クライアント
+type fakeS3Client struct {
+	objects map[string][]byte
+}
+
+func (c *fakeS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	body, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.objects[*params.Bucket+"/"+*params.Key] = body
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (c *fakeS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	body, ok := c.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func TestS3Store_PutAndGet(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeS3Client{objects: map[string][]byte{}}
+	store := NewS3Store(client, "my-bucket")
+
+	require.NoError(t, store.Put(ctx, "backups/1/manifest.json", []byte(`{"id":"1"}`)))
+	assert.Equal(t, []byte(`{"id":"1"}`), client.objects["my-bucket/backups/1/manifest.json"])
+
+	body, err := store.Get(ctx, "backups/1/manifest.json")
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"1"}`, string(data))
+
+	// 存在しないキーは ErrNotFound として返す
+	_, err = store.Get(ctx, "backups/2/manifest.json")
+	assert.True(t, stderrors.Is(err, errors.ErrNotFound))
+}
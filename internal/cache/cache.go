@@ -0,0 +1,16 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache 読み取り結果を保持するキャッシュ
+type Cache interface {
+	// Get キーの値を取得（存在しないか期限切れの場合は false）
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set 有効期間を指定して値を保存
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete キーを削除（存在しないキーは無視される）
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRU_GetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10)
+
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Fatal("Expected miss for unknown key")
+	}
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	if value, ok, err := c.Get(ctx, "a"); !ok || err != nil || string(value) != "1" {
+		t.Errorf("Expected hit with value 1, got %q %v %v", value, ok, err)
+	}
+
+	// 上書きすると値が更新される
+	c.Set(ctx, "a", []byte("2"), time.Minute)
+	if value, _, _ := c.Get(ctx, "a"); string(value) != "2" {
+		t.Errorf("Expected value 2 after overwrite, got %q", value)
+	}
+
+	c.Delete(ctx, "a", "missing")
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("Expected miss after delete")
+	}
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "a", []byte("1"), 30*time.Second)
+
+	now = now.Add(29 * time.Second)
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Error("Expected hit before ttl")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("Expected miss after ttl")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected expired entry to be removed, got %d entries", c.Len())
+	}
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	c.Set(ctx, "b", []byte("2"), time.Minute)
+	// a を参照して b を最も古い状態にする
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Errorf("Expected %s to remain", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisClient RedisCacheが使用するRedisクライアントの操作（テストで差し替えられるように定義）
+type redisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+// RedisCache Redisを使用したキャッシュ（複数のAPIサーバーでキャッシュと無効化を共有できる）
+type RedisCache struct {
+	client redisClient
+}
+
+// NewRedisCache Redisキャッシュを作成
+func NewRedisCache(client redisClient) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Get キーの値を取得
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if stderrors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set 有効期間を指定して値を保存
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete キーを削除
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedisClient テスト用のRedisクライアント
+type fakeRedisClient struct {
+	values map[string]string
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func newFakeRedisClient() *fakeRedisClient {
+	return &fakeRedisClient{values: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (f *fakeRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	if f.err != nil {
+		return redis.NewStringResult("", f.err)
+	}
+	value, ok := f.values[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(value, nil)
+}
+
+func (f *fakeRedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	f.values[key] = string(value.([]byte))
+	f.ttls[key] = expiration
+	return redis.NewStatusResult("OK", f.err)
+}
+
+func (f *fakeRedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	return redis.NewIntResult(int64(len(keys)), f.err)
+}
+
+func TestRedisCache_GetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeRedisClient()
+	c := NewRedisCache(client)
+
+	if _, ok, err := c.Get(ctx, "a"); ok || err != nil {
+		t.Fatalf("Expected miss without error for unknown key, got %v %v", ok, err)
+	}
+
+	if err := c.Set(ctx, "a", []byte("1"), 30*time.Second); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if client.ttls["a"] != 30*time.Second {
+		t.Errorf("Expected ttl to be passed to redis, got %v", client.ttls["a"])
+	}
+	if value, ok, err := c.Get(ctx, "a"); !ok || err != nil || string(value) != "1" {
+		t.Errorf("Expected hit with value 1, got %q %v %v", value, ok, err)
+	}
+
+	if err := c.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("Expected miss after delete")
+	}
+}
+
+func TestRedisCache_Error(t *testing.T) {
+	client := newFakeRedisClient()
+	client.err = fmt.Errorf("connection refused")
+	c := NewRedisCache(client)
+
+	if _, ok, err := c.Get(context.Background(), "a"); ok || err == nil {
+		t.Errorf("Expected error from redis, got %v %v", ok, err)
+	}
+}
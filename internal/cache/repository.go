@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// AchievementRepository GetByID と List の結果をキャッシュする達成目録リポジトリ
+type AchievementRepository struct {
+	next  repository.AchievementRepository
+	cache Cache
+	keys  keys
+	ttl   time.Duration
+}
+
+// NewAchievementRepository 達成目録リポジトリにキャッシュを追加
+// table はキャッシュキーに含める名前（同じRedisを共有する環境同士でキーが衝突しないように設定のテーブル名を使う）
+func NewAchievementRepository(next repository.AchievementRepository, cache Cache, table string, ttl time.Duration) repository.AchievementRepository {
+	return &AchievementRepository{next: next, cache: cache, keys: newKeys("achievements", table), ttl: ttl}
+}
+
+// Create 達成目録を作成し、一覧のキャッシュを破棄
+func (r *AchievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.next.Create(ctx, achievement); err != nil {
+		return err
+	}
+	r.cache.Delete(ctx, r.keys.list(ctx))
+	return nil
+}
+
+// CreateWithPoints 達成目録を作成してポイントを加算し、一覧のキャッシュを破棄
+func (r *AchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.next.CreateWithPoints(ctx, achievement); err != nil {
+		return err
+	}
+	r.cache.Delete(ctx, r.keys.list(ctx))
+	return nil
+}
+
+// Update 達成目録を更新し、キャッシュを破棄
+func (r *AchievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
+	err := r.next.Update(ctx, achievement)
+	if achievement != nil {
+		// 失敗した場合も書き込まれた可能性があるため破棄する
+		r.cache.Delete(ctx, r.keys.item(ctx, achievement.ID), r.keys.list(ctx))
+	}
+	return err
+}
+
+// UpdateWithPoints 達成目録を更新してポイントの差分を反映し、キャッシュを破棄
+func (r *AchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	err := r.next.UpdateWithPoints(ctx, achievement)
+	if achievement != nil {
+		r.cache.Delete(ctx, r.keys.item(ctx, achievement.ID), r.keys.list(ctx))
+	}
+	return err
+}
+
+// GetByID IDで達成目録を取得（キャッシュに無い場合のみリポジトリから読み取る）
+func (r *AchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	if id == "" {
+		return r.next.GetByID(ctx, id)
+	}
+	return readThrough(ctx, r.cache, r.keys.item(ctx, id), r.ttl, func() (*models.Achievement, error) {
+		return r.next.GetByID(ctx, id)
+	})
+}
+
+// List すべての達成目録を取得（キャッシュに無い場合のみリポジトリから読み取る）
+func (r *AchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
+	return readThrough(ctx, r.cache, r.keys.list(ctx), r.ttl, func() ([]*models.Achievement, error) {
+		return r.next.List(ctx)
+	})
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイントのみを取得（集計用のためキャッシュしない）
+func (r *AchievementRepository) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	return r.next.ListSummaries(ctx)
+}
+
+// Count 達成目録の件数を取得（リポジトリ側で安価に取得できるためキャッシュしない）
+func (r *AchievementRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 達成目録を削除し、キャッシュを破棄
+func (r *AchievementRepository) Delete(ctx context.Context, id string) error {
+	err := r.next.Delete(ctx, id)
+	r.cache.Delete(ctx, r.keys.item(ctx, id), r.keys.list(ctx))
+	return err
+}
+
+// DeleteWithPoints 達成目録を削除してポイントを減算し、キャッシュを破棄
+func (r *AchievementRepository) DeleteWithPoints(ctx context.Context, id string) error {
+	err := r.next.DeleteWithPoints(ctx, id)
+	r.cache.Delete(ctx, r.keys.item(ctx, id), r.keys.list(ctx))
+	return err
+}
+
+// DeleteMany 複数の達成目録を削除し、キャッシュを破棄
+func (r *AchievementRepository) DeleteMany(ctx context.Context, ids []string) error {
+	err := r.next.DeleteMany(ctx, ids)
+	r.cache.Delete(ctx, append(r.keys.items(ctx, ids), r.keys.list(ctx))...)
+	return err
+}
+
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存し、キャッシュを破棄
+func (r *AchievementRepository) SetAttachment(ctx context.Context, id, key string) error {
+	err := r.next.SetAttachment(ctx, id, key)
+	r.cache.Delete(ctx, r.keys.item(ctx, id), r.keys.list(ctx))
+	return err
+}
+
+// StartTimer 達成目録のタイマーを開始し、キャッシュを破棄
+func (r *AchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) error {
+	err := r.next.StartTimer(ctx, id, at)
+	r.cache.Delete(ctx, r.keys.item(ctx, id), r.keys.list(ctx))
+	return err
+}
+
+// Complete 達成記録を作成（タイマーを止めて達成した場合以外は達成目録は変わらないためキャッシュは破棄しない）
+func (r *AchievementRepository) Complete(ctx context.Context, completion *models.Completion) error {
+	err := r.next.Complete(ctx, completion)
+	if completion != nil && completion.StartedAt != nil {
+		r.cache.Delete(ctx, r.keys.item(ctx, completion.AchievementID), r.keys.list(ctx))
+	}
+	return err
+}
+
+// ListCompletions 達成目録の達成記録を取得（キャッシュしない）
+func (r *AchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	return r.next.ListCompletions(ctx, achievementID)
+}
+
+// RewardRepository GetByID と List の結果をキャッシュする報酬リポジトリ
+type RewardRepository struct {
+	next  repository.RewardRepository
+	cache Cache
+	keys  keys
+	ttl   time.Duration
+}
+
+// NewRewardRepository 報酬リポジトリにキャッシュを追加
+func NewRewardRepository(next repository.RewardRepository, cache Cache, table string, ttl time.Duration) repository.RewardRepository {
+	return &RewardRepository{next: next, cache: cache, keys: newKeys("rewards", table), ttl: ttl}
+}
+
+// Create 報酬を作成し、一覧のキャッシュを破棄
+func (r *RewardRepository) Create(ctx context.Context, reward *models.Reward) error {
+	if err := r.next.Create(ctx, reward); err != nil {
+		return err
+	}
+	r.cache.Delete(ctx, r.keys.list(ctx))
+	return nil
+}
+
+// Update 報酬を更新し、キャッシュを破棄
+func (r *RewardRepository) Update(ctx context.Context, reward *models.Reward) error {
+	err := r.next.Update(ctx, reward)
+	if reward != nil {
+		// 失敗した場合も書き込まれた可能性があるため破棄する
+		r.cache.Delete(ctx, r.keys.item(ctx, reward.ID), r.keys.list(ctx))
+	}
+	return err
+}
+
+// GetByID IDで報酬を取得（キャッシュに無い場合のみリポジトリから読み取る）
+func (r *RewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	if id == "" {
+		return r.next.GetByID(ctx, id)
+	}
+	return readThrough(ctx, r.cache, r.keys.item(ctx, id), r.ttl, func() (*models.Reward, error) {
+		return r.next.GetByID(ctx, id)
+	})
+}
+
+// GetByIDs 複数のIDの報酬をまとめて取得（キャッシュしない）
+func (r *RewardRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	return r.next.GetByIDs(ctx, ids)
+}
+
+// List すべての報酬を取得（キャッシュに無い場合のみリポジトリから読み取る）
+func (r *RewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
+	return readThrough(ctx, r.cache, r.keys.list(ctx), r.ttl, func() ([]*models.Reward, error) {
+		return r.next.List(ctx)
+	})
+}
+
+// Count 報酬の件数を取得（キャッシュしない）
+func (r *RewardRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 報酬を削除し、キャッシュを破棄
+func (r *RewardRepository) Delete(ctx context.Context, id string) error {
+	err := r.next.Delete(ctx, id)
+	r.cache.Delete(ctx, r.keys.item(ctx, id), r.keys.list(ctx))
+	return err
+}
+
+// keys テーブルごとのキャッシュキー
+type keys string
+
+// newKeys 種類とテーブル名からキャッシュキーの接頭辞を作成
+func newKeys(kind, table string) keys {
+	return keys(kind + ":" + table)
+}
+
+// tenant テナントごとのキャッシュキーの接頭辞（テナント間でキャッシュを共有しない）
+func (k keys) tenant(ctx context.Context) string {
+	return string(k) + ":" + tenant.FromContext(ctx)
+}
+
+// item 1件のキャッシュキー
+func (k keys) item(ctx context.Context, id string) string {
+	return k.tenant(ctx) + ":id:" + id
+}
+
+// items 複数件のキャッシュキー
+func (k keys) items(ctx context.Context, ids []string) []string {
+	items := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		items = append(items, k.item(ctx, id))
+	}
+	return items
+}
+
+// list 一覧のキャッシュキー
+func (k keys) list(ctx context.Context) string {
+	return k.tenant(ctx) + ":list"
+}
+
+// readThrough キャッシュに無い場合は load の結果を保存して返す
+// キャッシュの読み書きに失敗した場合はリポジトリの結果をそのまま使う（無効化の失敗や他のプロセスの書き込みは ttl で解消される）
+func readThrough[T any](ctx context.Context, cache Cache, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	if data, ok, err := cache.Get(ctx, key); err == nil && ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	if data, err := json.Marshal(value); err == nil {
+		cache.Set(ctx, key, data, ttl)
+	}
+	return value, nil
+}
@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/repository/memory"
+	"achievement-management/internal/tenant"
+)
+
+// countingAchievementRepository 読み取り回数を数える達成目録リポジトリ
+type countingAchievementRepository struct {
+	repository.AchievementRepository
+	gets  int
+	lists int
+}
+
+func (r *countingAchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	r.gets++
+	return r.AchievementRepository.GetByID(ctx, id)
+}
+
+func (r *countingAchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
+	r.lists++
+	return r.AchievementRepository.List(ctx)
+}
+
+// countingRewardRepository 読み取り回数を数える報酬リポジトリ
+type countingRewardRepository struct {
+	repository.RewardRepository
+	gets  int
+	lists int
+}
+
+func (r *countingRewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	r.gets++
+	return r.RewardRepository.GetByID(ctx, id)
+}
+
+func (r *countingRewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
+	r.lists++
+	return r.RewardRepository.List(ctx)
+}
+
+// failingCache 常に失敗するキャッシュ
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, fmt.Errorf("cache unavailable")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return fmt.Errorf("cache unavailable")
+}
+
+func (failingCache) Delete(ctx context.Context, keys ...string) error {
+	return fmt.Errorf("cache unavailable")
+}
+
+func TestAchievementRepository_ReadThrough(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingAchievementRepository{AchievementRepository: memory.NewAchievementRepository(memory.NewStore())}
+	repo := NewAchievementRepository(inner, NewLRU(100), "achievements", time.Minute)
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		list, err := repo.List(ctx)
+		if err != nil || len(list) != 1 {
+			t.Fatalf("Expected 1 achievement, got %v (%v)", list, err)
+		}
+		got, err := repo.GetByID(ctx, achievement.ID)
+		if err != nil || got.Title != "初回ログイン" || !got.CreatedAt.Equal(achievement.CreatedAt) {
+			t.Fatalf("Unexpected achievement: %+v (%v)", got, err)
+		}
+	}
+	if inner.lists != 1 || inner.gets != 1 {
+		t.Errorf("Expected 1 list and 1 get from the repository, got %d and %d", inner.lists, inner.gets)
+	}
+
+	// 見つからない場合はキャッシュしない
+	for i := 0; i < 2; i++ {
+		if _, err := repo.GetByID(ctx, "missing"); err != errors.ErrNotFound {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	}
+	if inner.gets != 3 {
+		t.Errorf("Expected misses to reach the repository, got %d gets", inner.gets)
+	}
+}
+
+func TestAchievementRepository_TenantsDoNotShareCache(t *testing.T) {
+	inner := &countingAchievementRepository{AchievementRepository: memory.NewAchievementRepository(memory.NewStore())}
+	repo := NewAchievementRepository(inner, NewLRU(100), "achievements", time.Minute)
+	familyA := tenant.WithID(context.Background(), "family-a")
+	familyB := tenant.WithID(context.Background(), "family-b")
+
+	if err := repo.Create(familyA, &models.Achievement{ID: "shared-id", Title: "家族A", Point: 10}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if got, err := repo.GetByID(familyA, "shared-id"); err != nil || got.Title != "家族A" {
+		t.Fatalf("Unexpected achievement: %+v (%v)", got, err)
+	}
+	if list, _ := repo.List(familyA); len(list) != 1 {
+		t.Fatalf("Expected 1 achievement for family-a, got %d", len(list))
+	}
+
+	// 別のテナントでは同じIDでもキャッシュを使わない
+	if _, err := repo.GetByID(familyB, "shared-id"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for family-b, got %v", err)
+	}
+	if list, _ := repo.List(familyB); len(list) != 0 {
+		t.Errorf("Expected no achievements for family-b, got %d", len(list))
+	}
+	if inner.gets != 2 || inner.lists != 2 {
+		t.Errorf("Expected each tenant to read from the repository, got %d gets and %d lists", inner.gets, inner.lists)
+	}
+}
+
+func TestAchievementRepository_InvalidatesOnWrite(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingAchievementRepository{AchievementRepository: memory.NewAchievementRepository(memory.NewStore())}
+	repo := NewAchievementRepository(inner, NewLRU(100), "achievements", time.Minute)
+
+	achievement := &models.Achievement{ID: "a", Title: "初回ログイン", Point: 10}
+	repo.Create(ctx, achievement)
+	repo.GetByID(ctx, "a")
+	repo.List(ctx)
+
+	if err := repo.Update(ctx, &models.Achievement{ID: "a", Title: "更新", Point: 20}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, _ := repo.GetByID(ctx, "a")
+	list, _ := repo.List(ctx)
+	if got.Title != "更新" || list[0].Title != "更新" {
+		t.Errorf("Expected updated title after update, got %s and %s", got.Title, list[0].Title)
+	}
+
+	if err := repo.UpdateWithPoints(ctx, &models.Achievement{ID: "a", Title: "更新", Point: 30}); err != nil {
+		t.Fatalf("UpdateWithPoints failed: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, "a")
+	list, _ = repo.List(ctx)
+	if got.Point != 30 || list[0].Point != 30 {
+		t.Errorf("Expected updated point after UpdateWithPoints, got %d and %d", got.Point, list[0].Point)
+	}
+
+	repo.Create(ctx, &models.Achievement{ID: "b", Title: "2件目", Point: 5})
+	if list, _ := repo.List(ctx); len(list) != 2 {
+		t.Errorf("Expected 2 achievements after create, got %d", len(list))
+	}
+
+	if err := repo.DeleteMany(ctx, []string{"a", "b"}); err != nil {
+		t.Fatalf("DeleteMany failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "a"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if list, _ := repo.List(ctx); len(list) != 0 {
+		t.Errorf("Expected no achievements after delete, got %d", len(list))
+	}
+
+	if err := repo.Update(ctx, nil); err == nil {
+		t.Error("Expected validation error for nil achievement")
+	}
+}
+
+func TestRewardRepository_ReadThroughAndInvalidate(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingRewardRepository{RewardRepository: memory.NewRewardRepository(memory.NewStore())}
+	// 達成目録と同じキャッシュを共有してもキーが衝突しない
+	shared := NewLRU(100)
+	repo := NewRewardRepository(inner, shared, "table", time.Minute)
+	achievements := NewAchievementRepository(memory.NewAchievementRepository(memory.NewStore()), shared, "table", time.Minute)
+
+	repo.Create(ctx, &models.Reward{ID: "r", Title: "コーヒー券", Point: 100})
+	achievements.Create(ctx, &models.Achievement{ID: "r", Title: "初回ログイン", Point: 10})
+
+	repo.GetByID(ctx, "r")
+	achievements.GetByID(ctx, "r")
+	if got, _ := repo.GetByID(ctx, "r"); got.Title != "コーヒー券" || inner.gets != 1 {
+		t.Errorf("Expected cached reward, got %+v after %d gets", got, inner.gets)
+	}
+
+	repo.List(ctx)
+	repo.List(ctx)
+	if inner.lists != 1 {
+		t.Errorf("Expected 1 list from the repository, got %d", inner.lists)
+	}
+
+	if err := repo.Delete(ctx, "r"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "r"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if list, _ := repo.List(ctx); len(list) != 0 {
+		t.Errorf("Expected no rewards after delete, got %d", len(list))
+	}
+}
+
+func TestAchievementRepository_CacheUnavailable(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingAchievementRepository{AchievementRepository: memory.NewAchievementRepository(memory.NewStore())}
+	repo := NewAchievementRepository(inner, failingCache{}, "achievements", time.Minute)
+
+	// キャッシュが使えない場合もリポジトリから読み書きできる
+	if err := repo.Create(ctx, &models.Achievement{ID: "a", Title: "初回ログイン", Point: 10}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if got, err := repo.GetByID(ctx, "a"); err != nil || got.ID != "a" {
+		t.Errorf("Expected achievement from repository, got %v (%v)", got, err)
+	}
+	if list, err := repo.List(ctx); err != nil || len(list) != 1 {
+		t.Errorf("Expected 1 achievement from repository, got %v (%v)", list, err)
+	}
+}
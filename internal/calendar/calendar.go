@@ -0,0 +1,165 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"achievement-management/internal/cron"
+	"achievement-management/internal/models"
+)
+
+const (
+	// productID フィードを作成したアプリケーション（PRODID）
+	productID = "-//achievement-management//calendar//EN"
+	// uidDomain イベントのUIDの後ろに付けるドメイン
+	uidDomain = "achievement-management"
+	// maxLineOctets 折り返す前の1行の最大のバイト数（RFC 5545 3.1）
+	maxLineOctets = 75
+
+	dateLayout     = "2006-01-02"
+	icalDate       = "20060102"
+	icalDateTime   = "20060102T150405"
+	icalDateTimeUT = "20060102T150405Z"
+)
+
+// Render 期限のある達成目録を終日のイベント、リマインドを設定した達成目録を繰り返しのイベントにした iCalendar（RFC 5545）を w に書き出す
+//
+// 繰り返しのイベントは location のタイムゾーンでリマインドの時刻に繰り返す（最初の回は達成目録を作成した後の最初のリマインド）。
+// 期限・リマインドの無い達成目録と、解析できないリマインドは含めない。now はイベントの作成日時（DTSTAMP）。
+func Render(w io.Writer, achievements []*models.Achievement, location *time.Location, now time.Time) error {
+	out := &writer{w: bufio.NewWriter(w)}
+	out.line("BEGIN:VCALENDAR")
+	out.line("VERSION:2.0")
+	out.line("PRODID:" + productID)
+	out.line("CALSCALE:GREGORIAN")
+	out.line("METHOD:PUBLISH")
+	out.line("X-WR-CALNAME:Achievements")
+	if zone := tzid(location); zone != "" {
+		out.line("X-WR-TIMEZONE:" + zone)
+	}
+
+	stamp := "DTSTAMP:" + now.UTC().Format(icalDateTimeUT)
+	for _, achievement := range achievements {
+		switch {
+		case achievement.DueDate != "":
+			due, err := time.Parse(dateLayout, achievement.DueDate)
+			if err != nil {
+				// 作成時に検証しているため、解析できないのは検証を追加する前のデータのみ
+				continue
+			}
+			out.line("BEGIN:VEVENT")
+			out.line(fmt.Sprintf("UID:%s-due@%s", achievement.ID, uidDomain))
+			out.line(stamp)
+			out.line("DTSTART;VALUE=DATE:" + due.Format(icalDate))
+			out.line("DTEND;VALUE=DATE:" + due.AddDate(0, 0, 1).Format(icalDate))
+			writeDetails(out, achievement)
+			out.line("END:VEVENT")
+		case achievement.Reminder != "":
+			schedule, err := cron.Parse(achievement.Reminder)
+			if err != nil {
+				continue
+			}
+			created := achievement.CreatedAt
+			if created.IsZero() {
+				created = now
+			}
+			// 日と曜日を両方指定したリマインドは日・曜日ごとのイベントに分ける
+			for i, part := range schedule.Split() {
+				rule, _ := part.RRule()
+				start := part.Next(created.In(location).Add(-time.Minute))
+				if start.IsZero() {
+					continue
+				}
+				out.line("BEGIN:VEVENT")
+				out.line(fmt.Sprintf("UID:%s-reminder-%d@%s", achievement.ID, i, uidDomain))
+				out.line(stamp)
+				out.line(dateTime("DTSTART", start, location))
+				out.line("RRULE:" + rule)
+				writeDetails(out, achievement)
+				out.line("END:VEVENT")
+			}
+		}
+	}
+
+	out.line("END:VCALENDAR")
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// writeDetails イベントのタイトル・説明・分類
+func writeDetails(out *writer, achievement *models.Achievement) {
+	out.line(fmt.Sprintf("SUMMARY:%s (%dpt)", escape(achievement.Title), achievement.Point))
+	if achievement.Description != "" {
+		out.line("DESCRIPTION:" + escape(achievement.Description))
+	}
+	if achievement.Category != "" {
+		out.line("CATEGORIES:" + escape(achievement.Category))
+	}
+}
+
+// tzid タイムゾーンの識別子（UTCの場合と、識別子の分からないローカルタイムゾーンの場合は空）
+func tzid(location *time.Location) string {
+	switch name := location.String(); name {
+	case "UTC", "Local":
+		return ""
+	default:
+		return name
+	}
+}
+
+// dateTime 日時のプロパティ（UTCはZ付き、ローカルタイムゾーンは見る人のタイムゾーンの日時、それ以外はTZID付き）
+func dateTime(property string, t time.Time, location *time.Location) string {
+	switch name := location.String(); name {
+	case "UTC":
+		return property + ":" + t.UTC().Format(icalDateTimeUT)
+	case "Local":
+		return property + ":" + t.Format(icalDateTime)
+	default:
+		return property + ";TZID=" + name + ":" + t.Format(icalDateTime)
+	}
+}
+
+// escape テキストの値の特殊文字をエスケープ（RFC 5545 3.3.11）
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(text)
+}
+
+// writer 行を CRLF で区切り、長い行を折り返して書き出す（最初のエラーを記録する）
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line 1行を書き出す（75バイトを超える場合はUTF-8の文字の途中で切らないように折り返す）
+func (o *writer) line(text string) {
+	if o.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range text {
+		size := len(string(r))
+		if width+size > maxLineOctets {
+			// 続きの行は先頭の空白の1バイトを含めて数える
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	_, o.err = o.w.WriteString(b.String())
+}
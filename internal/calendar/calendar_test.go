@@ -0,0 +1,87 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/models"
+)
+
+func render(t *testing.T, achievements []*models.Achievement, location *time.Location) string {
+	t.Helper()
+	var buf bytes.Buffer
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	if err := Render(&buf, achievements, location, now); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestRender_DueDate(t *testing.T) {
+	output := render(t, []*models.Achievement{
+		{ID: "1", Title: "Submit report", Description: "Q2, draft; final", Point: 30, Category: "work", DueDate: "2024-06-30"},
+		{ID: "2", Title: "No schedule", Point: 5},
+	}, time.UTC)
+
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"UID:1-due@achievement-management\r\n",
+		"DTSTAMP:20240603T120000Z\r\n",
+		"DTSTART;VALUE=DATE:20240630\r\n",
+		"DTEND;VALUE=DATE:20240701\r\n",
+		"SUMMARY:Submit report (30pt)\r\n",
+		`DESCRIPTION:Q2\, draft\; final` + "\r\n",
+		"CATEGORIES:work\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "No schedule") {
+		t.Error("Expected achievements without a due date or reminder to be skipped")
+	}
+}
+
+func TestRender_Reminder(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Time zone data is not available: %v", err)
+	}
+	output := render(t, []*models.Achievement{
+		{ID: "1", Title: "Stretch", Point: 5, Reminder: "0 8 1 * 1", CreatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "2", Title: "Broken", Point: 5, Reminder: "not a cron"},
+	}, tokyo)
+
+	for _, expected := range []string{
+		"X-WR-TIMEZONE:Asia/Tokyo\r\n",
+		// 2024-06-01 09:00 JST に作成したため、毎月1日の最初の回は翌月、月曜日の最初の回は 2024-06-03
+		"UID:1-reminder-0@achievement-management\r\nDTSTAMP:20240603T120000Z\r\nDTSTART;TZID=Asia/Tokyo:20240701T080000\r\nRRULE:FREQ=DAILY;BYHOUR=8;BYMINUTE=0;BYMONTHDAY=1\r\n",
+		"UID:1-reminder-1@achievement-management\r\nDTSTAMP:20240603T120000Z\r\nDTSTART;TZID=Asia/Tokyo:20240603T080000\r\nRRULE:FREQ=DAILY;BYHOUR=8;BYMINUTE=0;BYDAY=MO\r\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "Broken") {
+		t.Error("Expected invalid reminders to be skipped")
+	}
+}
+
+func TestRender_FoldsLongLines(t *testing.T) {
+	output := render(t, []*models.Achievement{
+		{ID: "1", Title: strings.Repeat("達成", 40), Point: 10, DueDate: "2024-06-30"},
+	}, time.UTC)
+
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Expected lines of at most %d octets, got %d: %q", maxLineOctets, len(line), line)
+		}
+	}
+	unfolded := strings.ReplaceAll(output, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("達成", 40)+" (10pt)\r\n") {
+		t.Errorf("Expected the folded summary to unfold to the title, got:\n%s", output)
+	}
+}
@@ -1,12 +1,24 @@
 package config
 
 import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+
+	"achievement-management/internal/cron"
+	"achievement-management/internal/tenant"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config アプリケーション設定
@@ -14,6 +26,9 @@ type Config struct {
 	// 環境設定
 	Environment string `json:"environment"`
 	
+	// ストレージ設定
+	Storage StorageConfig `json:"storage"`
+	
 	// AWS設定
 	AWS AWSConfig `json:"aws"`
 	
@@ -22,12 +37,122 @@ type Config struct {
 	
 	// リトライ設定
 	Retry RetryConfig `json:"retry"`
+
+	// サーキットブレーカー設定
+	CircuitBreaker CircuitBreakerConfig `json:"circuit_breaker"`
 	
 	// サーバー設定
 	Server ServerConfig `json:"server"`
 	
 	// ログ設定
 	Logging LoggingConfig `json:"logging"`
+
+	// DynamoDB Streams設定
+	Streams StreamsConfig `json:"streams"`
+
+	// 読み取りキャッシュ設定
+	Cache CacheConfig `json:"cache"`
+
+	// メトリクス設定
+	Metrics MetricsConfig `json:"metrics"`
+	Tenancy TenancyConfig `json:"tenancy"`
+
+	// バックアップ設定
+	Backup BackupConfig `json:"backup"`
+
+	// メンテナンスモード設定
+	Maintenance MaintenanceConfig `json:"maintenance"`
+
+	// 属性の暗号化設定
+	Encryption EncryptionConfig `json:"encryption"`
+
+	// ポイント設定
+	Points PointsConfig `json:"points"`
+
+	// 連続達成日数（ストリーク）設定
+	Streaks StreaksConfig `json:"streaks"`
+
+	// 目標設定
+	Goals GoalsConfig `json:"goals"`
+
+	// 報酬獲得の取り消し設定
+	Refunds RefundsConfig `json:"refunds"`
+
+	// 添付ファイル設定
+	Attachments AttachmentsConfig `json:"attachments"`
+
+	// リマインド設定
+	Reminders RemindersConfig `json:"reminders"`
+
+	// サマリー設定
+	Summaries SummariesConfig `json:"summaries"`
+
+	// 整合性チェック設定
+	Consistency ConsistencyConfig `json:"consistency"`
+
+	// 操作履歴（元に戻す・やり直す）設定
+	Journal JournalConfig `json:"journal"`
+
+	// 一括操作設定
+	Bulk BulkConfig `json:"bulk"`
+
+	// お小遣い（定期的なポイントの付与）設定
+	Allowances AllowancesConfig `json:"allowances"`
+
+	// 設定の診断設定
+	Diagnostics DiagnosticsConfig `json:"diagnostics"`
+
+	// エラー通知設定
+	ErrorReporting ErrorReportingConfig `json:"error_reporting"`
+
+	// ヘルスチェック設定
+	HealthCheck HealthCheckConfig `json:"health_check"`
+
+	// セキュリティ設定
+	Security SecurityConfig `json:"security"`
+
+	// Webhookの署名設定
+	Webhooks WebhooksConfig `json:"webhooks"`
+
+	// APIキー設定
+	APIKeys APIKeysConfig `json:"api_keys"`
+
+	// リクエスト数の制限設定
+	RateLimit RateLimitConfig `json:"rate_limit"`
+
+	// 通知設定
+	Notifications NotificationsConfig `json:"notifications"`
+
+	// カレンダーのフィード設定
+	Calendar CalendarConfig `json:"calendar"`
+
+	// filePath 読み込んだ設定ファイルのパス
+	filePath string
+	// envFilePath 読み込んだ .env ファイルのパス
+	envFilePath string
+	// sources 既定値以外から読み込んだ設定項目の読み込み元
+	sources map[string]Source
+}
+
+// ストレージの種類
+const (
+	// StorageDriverDynamoDB DynamoDBに保存する
+	StorageDriverDynamoDB = "dynamodb"
+	// StorageDriverSQLite ローカルのSQLiteファイルに保存する（AWSを使用しない）
+	StorageDriverSQLite = "sqlite"
+	// StorageDriverPostgres PostgreSQLに保存する（RDSなどでSQLによる集計を行う場合）
+	StorageDriverPostgres = "postgres"
+	// StorageDriverMemory プロセスのメモリに保存する（デモやテスト用。終了時にデータは破棄される）
+	StorageDriverMemory = "memory"
+)
+
+// StorageConfig ストレージの設定
+type StorageConfig struct {
+	Driver      string `json:"driver"`
+	// SQLitePath SQLiteのデータベースファイルのパス（driver が sqlite の場合のみ使用）
+	SQLitePath  string `json:"sqlite_path"`
+	// PostgresDSN PostgreSQLの接続文字列（driver が postgres の場合のみ使用）
+	PostgresDSN string `json:"postgres_dsn"`
 }
 
 // AWSConfig AWS関連の設定
@@ -37,6 +162,8 @@ type AWSConfig struct {
 	Profile          string `json:"profile"`
 	AccessKeyID      string `json:"access_key_id"`
 	SecretAccessKey  string `json:"secret_access_key"`
+	// ConsistentRead GetItemを常に強い整合性で読み取る（読み取りキャパシティは2倍消費する）
+	ConsistentRead   bool   `json:"consistent_read"`
 }
 
 // TableConfig テーブル名の設定
@@ -45,6 +172,34 @@ type TableConfig struct {
 	Rewards        string `json:"rewards"`
 	CurrentPoints  string `json:"current_points"`
 	RewardHistory  string `json:"reward_history"`
+	// PointLedger ポイントの増減をすべて追記するポイント台帳のテーブル名
+	PointLedger    string `json:"point_ledger"`
+	// Completions 達成目録を達成するたびに追記する達成記録のテーブル名
+	Completions    string `json:"completions"`
+	// Badges 獲得したバッジのテーブル名
+	Badges         string `json:"badges"`
+	// Goals ポイント・達成目録の件数の目標のテーブル名
+	Goals          string `json:"goals"`
+	// Favorites お気に入りに登録した報酬のテーブル名
+	Favorites      string `json:"favorites"`
+	// Wishlist ほしいものリストに入れた報酬と優先度のテーブル名
+	Wishlist       string `json:"wishlist"`
+	// Notes 達成目録・報酬・報酬獲得履歴に付けたメモのテーブル名
+	Notes          string `json:"notes"`
+	// DriftEvents 整合性チェックで検出したポイントの差異のテーブル名
+	DriftEvents    string `json:"drift_events"`
+	// Reservations 報酬のために取り置いたポイントのテーブル名
+	Reservations   string `json:"reservations"`
+	// Quests 順番に達成する達成目録の連なり（クエスト）のテーブル名
+	Quests         string `json:"quests"`
+	// Operations 元に戻せる操作（作成・更新・削除）を記録する操作履歴のテーブル名
+	Operations     string `json:"operations"`
+	// Allowances 定期的にポイントを付与するお小遣いのルールのテーブル名
+	Allowances     string `json:"allowances"`
+	// APIKeys APIキー（ハッシュ値）のテーブル名
+	APIKeys        string `json:"api_keys"`
+	// TTLAttribute DynamoDBのTTLで自動削除する日時（UNIX時間の秒）を書き込む属性名（空の場合はTTLを使用しない）
+	TTLAttribute   string `json:"ttl_attribute"`
 }
 
 // RetryConfig リトライ設定
@@ -53,11 +208,22 @@ type RetryConfig struct {
 	BackoffMs  int `json:"backoff_ms"`
 }
 
+// CircuitBreakerConfig DynamoDBの障害時に呼び出しを遮断するサーキットブレーカーの設定
+type CircuitBreakerConfig struct {
+	Enabled          bool `json:"enabled"`
+	// FailureThreshold 遮断するまでに連続して失敗する回数（リトライ後に失敗した呼び出しを1回と数える）
+	FailureThreshold int  `json:"failure_threshold"`
+	// OpenSeconds 遮断してから試しに呼び出しを再開するまでの秒数
+	OpenSeconds      int  `json:"open_seconds"`
+}
+
 // ServerConfig サーバー設定
 type ServerConfig struct {
 	Port         string `json:"port"`
 	ReadTimeout  int    `json:"read_timeout"`
 	WriteTimeout int    `json:"write_timeout"`
+	// Mode Ginの動作モード（debug・release・test、空の場合は logging.level が debug なら debug、それ以外は release）
+	Mode string `json:"mode"`
 }
 
 // LoggingConfig ログ設定
@@ -65,25 +231,675 @@ type LoggingConfig struct {
 	Level  string `json:"level"`
 	Format string `json:"format"`
 	Output string `json:"output"`
+	// Rotation output がファイルの場合のローテーション設定
+	Rotation LogRotationConfig `json:"rotation"`
+	// Access アクセスログの出力先・形式と記録するリクエストの設定
+	Access AccessLogConfig `json:"access"`
+	// Error エラーログの出力先・形式の設定
+	Error ErrorLogConfig `json:"error"`
+	// Audit 監査ログ（誰が何を変更したか）の設定
+	Audit AuditLogConfig `json:"audit"`
+	// AdminToken APIサーバーのログレベル変更用管理エンドポイントのBearerトークン（空の場合はエンドポイントを公開しない）
+	AdminToken string `json:"admin_token"`
+}
+
+// AccessLogConfig アクセスログの出力先・形式と、除外・間引くリクエストの設定
+//
+// ステータスコードが400以上のレスポンスは除外・間引きの対象にせず、常に記録する。
+type AccessLogConfig struct {
+	// Format アクセスログの形式（json または text、空の場合は logging.format）
+	Format string `json:"format"`
+	// Output アクセスログの出力先（stdout・stderr またはファイルのパス、空の場合は logging.output）
+	Output string `json:"output"`
+	// ExcludePaths アクセスログに記録しないパス（完全一致）
+	ExcludePaths []string `json:"exclude_paths"`
+	// Sample ルート（例: /api/achievements/:id）ごとに、N件に1件だけ記録する場合の N
+	Sample map[string]int `json:"sample"`
+}
+
+// AuditLogConfig 監査ログの設定
+//
+// 監査ログはアプリケーションのログとは別の出力先に、常にJSONで1行1件追記する。
+type AuditLogConfig struct {
+	// Enabled 達成目録・報酬・ポイントの変更を監査ログに記録する
+	Enabled bool `json:"enabled"`
+	// Output 監査ログの出力先（stdout・stderr またはファイルのパス）
+	Output string `json:"output"`
+	// ActorHeader 変更した利用者のIDを受け取るヘッダー（認証を行う security.trusted_proxies のプロキシが設定する）
+	ActorHeader string `json:"actor_header"`
+}
+
+// ErrorLogConfig エラーログの出力先・形式の設定
+type ErrorLogConfig struct {
+	// Format エラーログの形式（json または text、空の場合は logging.format）
+	Format string `json:"format"`
+	// Output エラーログの出力先（stdout・stderr またはファイルのパス、空の場合は logging.output）
+	Output string `json:"output"`
+}
+
+// LogRotationConfig ログファイルのローテーションと古いファイルの保持の設定
+//
+// ローテーションしたファイルは元のファイル名に日時を付けて同じディレクトリに保存する（例: app-2024-06-10T12-00-00.000.log）。
+type LogRotationConfig struct {
+	// MaxSizeMB ファイルがこのサイズ（MB）を超える前にローテーションする（0の場合はサイズではローテーションしない）
+	MaxSizeMB int `json:"max_size_mb"`
+	// IntervalHours ファイルを開いてからこの時間が経過した後の最初の書き込みでローテーションする（0の場合は時間ではローテーションしない）
+	IntervalHours int `json:"interval_hours"`
+	// MaxBackups 保持するローテーションしたファイルの数（0の場合は数では削除しない）
+	MaxBackups int `json:"max_backups"`
+	// MaxAgeDays ローテーションしたファイルを保持する日数（0の場合は日数では削除しない）
+	MaxAgeDays int `json:"max_age_days"`
+	// Compress ローテーションしたファイルをgzipで圧縮する
+	Compress bool `json:"compress"`
+}
+
+// StreamsConfig DynamoDB Streamsの変更イベント配信設定
+type StreamsConfig struct {
+	// Enabled テーブルのストリームを有効にする（変更イベントの配信に必要）
+	Enabled        bool     `json:"enabled"`
+	// WebhookURLs 変更イベントをPOSTする送信先
+	WebhookURLs    []string `json:"webhook_urls"`
+	// PollIntervalMs 新しいレコードが無い場合にストリームを再取得するまでの間隔
+	PollIntervalMs int      `json:"poll_interval_ms"`
+}
+
+// キャッシュの種類
+const (
+	// CacheDriverMemory プロセス内のLRUキャッシュ
+	CacheDriverMemory = "memory"
+	// CacheDriverRedis Redisを使用したキャッシュ（複数のプロセスで共有できる）
+	CacheDriverRedis = "redis"
+)
+
+// MetricsConfig リポジトリ呼び出しのメトリクス設定
+type MetricsConfig struct {
+	// Enabled リポジトリ呼び出しの回数・レイテンシ・エラーの種類を集計し、APIサーバーで公開する
+	Enabled bool `json:"enabled"`
+	// Path Prometheus形式のメトリクスを公開するパス
+	Path string `json:"path"`
+}
+
+// TenancyConfig 複数の家族・チームで同じテーブルを共有するためのテナント設定
+type TenancyConfig struct {
+	// Enabled リクエストごとにテナントを解決する（無効の場合はすべて既定のテナントとして扱う）
+	Enabled bool `json:"enabled"`
+	// Header 認証済みのテナントIDを受け取るヘッダー（認証を行うプロキシが設定する）
+	Header string `json:"header"`
+}
+
+// BackupConfig テーブルの内容をS3にエクスポートするバックアップ設定
+type BackupConfig struct {
+	// Bucket スナップショットを保存するS3バケット（空の場合はバックアップを使用しない）
+	Bucket     string `json:"bucket"`
+	// Prefix スナップショットのオブジェクトキーの接頭辞
+	Prefix     string `json:"prefix"`
+	// Gzip テーブルの内容をgzipで圧縮して保存する
+	Gzip       bool   `json:"gzip"`
+	// Endpoint S3互換ストレージのエンドポイント（ローカル開発用。空の場合はAWSのS3を使用する）
+	Endpoint   string `json:"endpoint"`
+	// AdminToken APIサーバーのバックアップ用管理エンドポイントのBearerトークン（空の場合はエンドポイントを公開しない）
+	AdminToken string `json:"admin_token"`
+	// ScanSegments エクスポート時に各テーブルを分割する並列スキャンのセグメント数（1以下の場合は分割しない）
+	ScanSegments int `json:"scan_segments"`
+	// ScanWorkers 並列スキャンで同時に実行するセグメント数の上限（0の場合はセグメント数と同じ）
+	ScanWorkers int `json:"scan_workers"`
+}
+
+// MaintenanceConfig マイグレーションや復元の間に書き込みを停止するメンテナンスモードの設定
+type MaintenanceConfig struct {
+	// ReadOnly 起動時から書き込みを拒否する（読み取りは引き続き利用できる）
+	ReadOnly   bool   `json:"read_only"`
+	// AdminToken APIサーバーのメンテナンスモード切り替え用管理エンドポイントのBearerトークン（空の場合はエンドポイントを公開しない）
+	AdminToken string `json:"admin_token"`
+}
+
+// PointsConfig ポイントの増減に関する設定
+type PointsConfig struct {
+	// AdjustOnDelete 達成目録の削除時に付与したポイントを減算する（APIの adjust_points とCLIの --with-points の既定値）
+	AdjustOnDelete bool `json:"adjust_on_delete"`
+	// AdjustOnUpdate 達成目録のポイントを変更した際に差分を現在のポイントに反映する（APIの adjust_points とCLIの --with-points の既定値）
+	AdjustOnUpdate bool `json:"adjust_on_update"`
+	// MaxPoint 達成目録・報酬に設定できるポイントの上限（1000000 のような入力ミスを防ぐ）
+	MaxPoint int `json:"max_point"`
+	// DailyQuota 1日に達成目録の作成・達成で獲得できるポイントの上限（0の場合は制限しない）
+	DailyQuota int `json:"daily_quota"`
+	// OverQuota 獲得ポイントが上限を超える作成・達成の扱い（reject: 拒否する、zero: ポイントを付与せずに記録する）
+	OverQuota string `json:"over_quota"`
+}
+
+const (
+	// OverQuotaReject 1日の獲得ポイントの上限を超える作成・達成を拒否する
+	OverQuotaReject = "reject"
+	// OverQuotaZero 1日の獲得ポイントの上限を超える作成・達成はポイントを付与せずに記録する
+	OverQuotaZero = "zero"
+)
+
+// StreaksConfig 連続達成日数（ストリーク）の数え方と節目のボーナスの設定
+type StreaksConfig struct {
+	// Timezone 日付の区切りに使うタイムゾーン（IANAのタイムゾーン名。空の場合はサーバーのローカル時刻）
+	Timezone string `json:"timezone"`
+	// BonusEnabled 達成目録の連続達成日数が節目に達した達成にボーナスポイントを付与する
+	BonusEnabled bool `json:"bonus_enabled"`
+	// Milestones 節目の連続達成日数とボーナスポイント（JSONでは {"7": 10} のように日数をキーにする）
+	Milestones map[int]int `json:"milestones"`
+}
+
+// Location 日付の区切りに使うタイムゾーン（読み込めない場合はサーバーのローカル時刻）
+func (c StreaksConfig) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+// Bonuses ボーナスを付与する節目（ボーナスが無効な場合はnil）
+func (c StreaksConfig) Bonuses() map[int]int {
+	if !c.BonusEnabled {
+		return nil
+	}
+	return c.Milestones
+}
+
+// GoalsConfig 目標の達成の通知設定
+type GoalsConfig struct {
+	// WebhookURLs 目標を達成した際に goals.reached イベントをPOSTする送信先（空の場合は通知しない）
+	WebhookURLs []string `json:"webhook_urls"`
+}
+
+// RefundsConfig 報酬獲得の取り消し（ポイントの返還）の設定
+type RefundsConfig struct {
+	// WindowHours 獲得から取り消せるまでの時間（経過後の取り消しは管理者のみ。0の場合は常に管理者のみ）
+	WindowHours int `json:"window_hours"`
+	// AdminToken APIサーバーで期間の経過後の取り消しを許可するBearerトークン（空の場合は期間内の取り消しのみ受け付ける）
+	AdminToken string `json:"admin_token"`
+}
+
+// Window 獲得から取り消せるまでの期間
+func (c RefundsConfig) Window() time.Duration {
+	return time.Duration(c.WindowHours) * time.Hour
+}
+
+// AttachmentsConfig 達成目録・報酬獲得履歴に添付するファイル（画像・レシートなど）の保存先の設定
+type AttachmentsConfig struct {
+	// Bucket 添付ファイルを保存するS3バケット（空の場合は添付ファイルを使用しない）
+	Bucket string `json:"bucket"`
+	// Prefix 添付ファイルのオブジェクトキーの接頭辞
+	Prefix string `json:"prefix"`
+	// Endpoint S3互換ストレージのエンドポイント（ローカル開発用。空の場合はAWSのS3を使用する）
+	Endpoint string `json:"endpoint"`
+	// UploadExpiryMinutes アップロード用の署名付きURLの有効期間（分）
+	UploadExpiryMinutes int `json:"upload_expiry_minutes"`
+	// DownloadExpiryMinutes レスポンスに含めるダウンロード用の署名付きURLの有効期間（分）
+	DownloadExpiryMinutes int `json:"download_expiry_minutes"`
+}
+
+// UploadExpiry アップロード用の署名付きURLの有効期間
+func (c AttachmentsConfig) UploadExpiry() time.Duration {
+	return time.Duration(c.UploadExpiryMinutes) * time.Minute
+}
+
+// DownloadExpiry ダウンロード用の署名付きURLの有効期間
+func (c AttachmentsConfig) DownloadExpiry() time.Duration {
+	return time.Duration(c.DownloadExpiryMinutes) * time.Minute
+}
+
+// maxPresignMinutes 署名付きURLに設定できる有効期間の上限（SigV4の上限の7日）
+const maxPresignMinutes = 7 * 24 * 60
+
+// RemindersConfig 未達成の達成目録のリマインドの設定
+type RemindersConfig struct {
+	// Enabled APIサーバーでリマインドのスケジューラーを実行する
+	Enabled bool `json:"enabled"`
+	// Schedule reminder を指定していない期限のある達成目録をリマインドする時刻のcron式（streaks.timezone の時刻で判定する）
+	Schedule string `json:"schedule"`
+	// WebhookURLs achievements.reminder イベントをPOSTする送信先（空の場合はスケジューラーを実行しても通知しない）
+	WebhookURLs []string `json:"webhook_urls"`
+	// Tenants リマインドするテナント（空の場合は既定のテナントのみ）
+	Tenants []string `json:"tenants"`
+}
+
+// SummariesConfig 日次・週次のサマリーの配信設定
+type SummariesConfig struct {
+	// Enabled APIサーバーでサマリーを配信するスケジューラーを実行する
+	Enabled bool `json:"enabled"`
+	// DailySchedule 昨日のサマリーを配信する時刻のcron式（streaks.timezone の時刻で判定する。空の場合は配信しない）
+	DailySchedule string `json:"daily_schedule"`
+	// WeeklySchedule 昨日までの7日間のサマリーを配信する時刻のcron式（空の場合は配信しない）
+	WeeklySchedule string `json:"weekly_schedule"`
+	// WebhookURLs summaries.generated イベントをPOSTする送信先
+	WebhookURLs []string `json:"webhook_urls"`
+	// Tenants サマリーを配信するテナント（空の場合は既定のテナントのみ）
+	Tenants []string `json:"tenants"`
+}
+
+// ConsistencyConfig 達成目録のポイントの合計と現在のポイントの差異を定期的に確認する整合性チェックの設定
+type ConsistencyConfig struct {
+	// Enabled APIサーバーで整合性チェックのスケジューラーを実行する
+	Enabled bool `json:"enabled"`
+	// IntervalMinutes 整合性チェックを実行する間隔（分）
+	IntervalMinutes int `json:"interval_minutes"`
+	// Threshold 許容する差異（差異の絶対値がこの値を超えた場合に記録して通知する）
+	Threshold int `json:"threshold"`
+	// WebhookURLs points.drift_detected イベントをPOSTする送信先（空の場合は記録とログの出力のみ）
+	WebhookURLs []string `json:"webhook_urls"`
+	// Tenants 整合性チェックを実行するテナント（空の場合は既定のテナントのみ）
+	Tenants []string `json:"tenants"`
+}
+
+// Interval 整合性チェックを実行する間隔
+func (c ConsistencyConfig) Interval() time.Duration {
+	return time.Duration(c.IntervalMinutes) * time.Minute
+}
+
+// JournalConfig 達成目録・報酬の作成・更新・削除を元に戻すための操作履歴の設定
+type JournalConfig struct {
+	// Size 操作履歴に保持する操作の件数（古い操作から削除し、元に戻せなくなる）
+	Size int `json:"size"`
+	// AdminToken APIサーバーの操作履歴の管理エンドポイント（元に戻す・やり直す）のBearerトークン（空の場合はエンドポイントを公開しない）
+	AdminToken string `json:"admin_token"`
+}
+
+// AllowancesConfig お小遣いのルールに従って定期的にポイントを付与する設定
+type AllowancesConfig struct {
+	// Enabled APIサーバーでお小遣いを付与するスケジューラーを実行する
+	Enabled bool `json:"enabled"`
+	// Tenants お小遣いを付与するテナント（空の場合は既定のテナントのみ）
+	Tenants []string `json:"tenants"`
+}
+
+// DiagnosticsConfig 実際に使われている設定を確認する診断の設定
+type DiagnosticsConfig struct {
+	// AdminToken APIサーバーの設定確認用管理エンドポイントのBearerトークン（空の場合はエンドポイントを公開しない）
+	AdminToken string `json:"admin_token"`
+}
+
+// ErrorReportingConfig パニックやデータベース・サービスのエラーをエラー管理サービスに送る設定
+type ErrorReportingConfig struct {
+	// DSN Sentry互換のDSN（例: https://<公開キー>@sentry.example.com/<プロジェクトID>、空の場合は送らない）
+	DSN string `json:"dsn"`
+}
+
+// HealthCheckConfig /health で依存先を確認する設定
+type HealthCheckConfig struct {
+	// CheckDynamoDB DynamoDBに接続できるかも確認する（storage.driver が dynamodb の場合のみ使用）
+	CheckDynamoDB bool `json:"check_dynamodb"`
+	// TimeoutMs 依存先の確認を打ち切るまでのミリ秒数
+	TimeoutMs int `json:"timeout_ms"`
+	// CacheTTLSeconds 確認結果を使い回す秒数（ロードバランサーの頻繁なヘルスチェックで毎回データベースに問い合わせないようにする。0 の場合は毎回確認する）
+	CacheTTLSeconds int `json:"cache_ttl_seconds"`
+}
+
+// Timeout 依存先の確認を打ち切るまでの時間
+func (c HealthCheckConfig) Timeout() time.Duration {
+	return time.Duration(c.TimeoutMs) * time.Millisecond
+}
+
+// CacheTTL 確認結果を使い回す期間
+func (c HealthCheckConfig) CacheTTL() time.Duration {
+	return time.Duration(c.CacheTTLSeconds) * time.Second
+}
+
+// SecurityConfig APIサーバーのセキュリティ設定
+type SecurityConfig struct {
+	// ContentSecurityPolicy レスポンスに付ける Content-Security-Policy ヘッダーの値（空の場合は付けない）
+	ContentSecurityPolicy string `json:"content_security_policy"`
+	// AllowedCIDRs 接続を許可する接続元のIPアドレスの範囲（CIDR表記またはIPアドレス。空の場合はすべて許可）
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+	// DeniedCIDRs 接続を拒否する接続元のIPアドレスの範囲（allowed_cidrs に含まれていても拒否する）
+	DeniedCIDRs []string `json:"denied_cidrs"`
+	// TrustedProxies X-Forwarded-For の接続元を信頼するプロキシのIPアドレスの範囲（空の場合はTCPの接続元で判定する）
+	TrustedProxies []string `json:"trusted_proxies"`
+}
+
+// WebhooksConfig 送信するWebhookへの署名と、受信するWebhookの署名の検証の設定
+type WebhooksConfig struct {
+	// SigningSecret すべての送信先に共通の署名の秘密鍵（空の場合は署名しない）
+	SigningSecret string `json:"signing_secret"`
+	// EndpointSecrets 送信先のURLごとの署名の秘密鍵（signing_secret より優先する）
+	EndpointSecrets map[string]string `json:"endpoint_secrets"`
+	// ToleranceSeconds 受信したWebhookの署名の時刻として許容するずれの秒数（この間は同じ署名の再送を拒否する）
+	ToleranceSeconds int `json:"tolerance_seconds"`
+}
+
+// SecretFor 送信先のURLに署名する秘密鍵（空の場合は署名しない）
+func (c WebhooksConfig) SecretFor(url string) string {
+	if secret, ok := c.EndpointSecrets[url]; ok {
+		return secret
+	}
+	return c.SigningSecret
+}
+
+// Tolerance 受信したWebhookの署名の時刻として許容するずれ
+func (c WebhooksConfig) Tolerance() time.Duration {
+	return time.Duration(c.ToleranceSeconds) * time.Second
+}
+
+// APIKeysConfig テーブルに保存するAPIキーでの /api の認証の設定
+type APIKeysConfig struct {
+	// Required /api へのリクエストに有効なAPIキー（X-API-Key ヘッダー）を必須にする（無効の場合もAPIキーを指定したリクエストは検証する）
+	Required bool `json:"required"`
+	// AdminToken APIサーバーのAPIキーの管理エンドポイントのBearerトークン（空の場合はエンドポイントを公開しない）
+	AdminToken string `json:"admin_token"`
+}
+
+// NotificationEvents 通知できるイベントの種類
+var NotificationEvents = []string{"achievements.created", "rewards.redeemed", "points.low_balance", "goals.reached", "summaries.generated"}
+
+// NotificationsConfig 達成目録の作成・報酬の獲得などをチャットに投稿する通知の設定
+type NotificationsConfig struct {
+	// Slack SlackのIncoming Webhookへの通知
+	Slack SlackNotificationsConfig `json:"slack"`
+	// Discord DiscordのWebhookへの通知とボットのコマンド
+	Discord DiscordNotificationsConfig `json:"discord"`
+	// Email メールでの通知
+	Email EmailNotificationsConfig `json:"email"`
+	// EventBridge Amazon EventBridge のイベントバスへの送信
+	EventBridge EventBridgeNotificationsConfig `json:"eventbridge"`
+	// SNS Amazon SNS のトピックへの送信
+	SNS SNSNotificationsConfig `json:"sns"`
+	// SQS Amazon SQS のキューへの送信
+	SQS SQSNotificationsConfig `json:"sqs"`
+	// BatchSize SNS・SQSにまとめて送信するイベントの件数（1〜10）
+	BatchSize int `json:"batch_size"`
+	// BatchWaitMs SNS・SQSに送信するまで保留する最大のミリ秒（0の場合はまとめずにすぐ送信する）
+	BatchWaitMs int `json:"batch_wait_ms"`
+	// LowBalanceThreshold 報酬を獲得した後の現在のポイントがこの値を下回ったら points.low_balance を通知する（0の場合は通知しない）
+	LowBalanceThreshold int `json:"low_balance_threshold"`
+}
+
+// SlackNotificationsConfig SlackのIncoming Webhookへの通知の設定
+type SlackNotificationsConfig struct {
+	// WebhookURL Incoming WebhookのURL（空の場合は通知しない）
+	WebhookURL string `json:"webhook_url"`
+	// Channel 投稿先のチャンネル（#kudos など。空の場合はWebhookに設定したチャンネル）
+	Channel string `json:"channel"`
+	// Events 通知するイベントの種類（空の場合はすべて）
+	Events []string `json:"events"`
+}
+
+// DiscordNotificationsConfig DiscordのWebhookへの通知と、ボットのコマンド（/points・/redeem）の設定
+type DiscordNotificationsConfig struct {
+	// WebhookURL チャンネルのWebhookのURL（空の場合は通知しない）
+	WebhookURL string `json:"webhook_url"`
+	// Events 通知するイベントの種類（空の場合はすべて）
+	Events []string `json:"events"`
+	// PublicKey ボットのアプリケーションの公開鍵（16進数。設定した場合はAPIサーバーの /integrations/discord/interactions でコマンドを受け付ける）
+	PublicKey string `json:"public_key"`
+}
+
+// VerifyKey コマンドのリクエストの署名を検証する公開鍵（public_key が空または不正な場合はnil）
+func (c DiscordNotificationsConfig) VerifyKey() ed25519.PublicKey {
+	key, err := hex.DecodeString(c.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil
+	}
+	return ed25519.PublicKey(key)
+}
+
+// EmailNotificationsConfig メールでの通知の設定
+type EmailNotificationsConfig struct {
+	// Provider 送信方法（smtp・ses。空の場合は送信しない）
+	Provider string `json:"provider"`
+	// From 送信元のアドレス
+	From string `json:"from"`
+	// To 送信先のアドレス
+	To []string `json:"to"`
+	// Events 送信するイベントの種類（空の場合はすべて。サマリーは週ごとのサマリーのみ送信する）
+	Events []string `json:"events"`
+	// SMTPHost provider が smtp の場合に接続するSMTPサーバー
+	SMTPHost string `json:"smtp_host"`
+	// SMTPPort SMTPサーバーのポート（587 などSTARTTLSに対応したポート）
+	SMTPPort     int    `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"smtp_password"`
+	// SESEndpoint provider が ses の場合のエンドポイント（LocalStackなど。空の場合は aws.region のエンドポイント）
+	SESEndpoint string `json:"ses_endpoint"`
+}
+
+// EventBridgeNotificationsConfig Amazon EventBridge のイベントバスへイベントを送信する設定
+type EventBridgeNotificationsConfig struct {
+	// BusName 送信先のイベントバスの名前またはARN（default など。空の場合は送信しない）
+	BusName string `json:"bus_name"`
+	// Source イベントの source
+	Source string `json:"source"`
+	// DetailTypePrefix イベントの種類の前に付ける detail-type のプレフィックス（AchievementManagement. など）
+	DetailTypePrefix string `json:"detail_type_prefix"`
+	// Events 送信するイベントの種類（空の場合はすべて）
+	Events []string `json:"events"`
+	// Endpoint EventBridgeのエンドポイント（LocalStackなど。空の場合は aws.region のエンドポイント）
+	Endpoint string `json:"endpoint"`
+}
+
+// SNSNotificationsConfig Amazon SNS のトピックへイベントを送信する設定
+type SNSNotificationsConfig struct {
+	// TopicARN 送信先のトピックのARN（空の場合は送信しない）
+	TopicARN string `json:"topic_arn"`
+	// Events 送信するイベントの種類（空の場合はすべて）
+	Events []string `json:"events"`
+	// DeadLetterQueueURL 送信できなかったイベントを送るSQSのキューのURL（空の場合は破棄してエラーを記録する）
+	DeadLetterQueueURL string `json:"dead_letter_queue_url"`
+	// Endpoint SNSのエンドポイント（LocalStackなど。空の場合は aws.region のエンドポイント）
+	Endpoint string `json:"endpoint"`
+}
+
+// SQSNotificationsConfig Amazon SQS のキューへイベントを送信する設定
+type SQSNotificationsConfig struct {
+	// QueueURL 送信先のキューのURL（空の場合は送信しない）
+	QueueURL string `json:"queue_url"`
+	// Events 送信するイベントの種類（空の場合はすべて）
+	Events []string `json:"events"`
+	// DeadLetterQueueURL 送信できなかったイベントを送るキューのURL（空の場合は破棄してエラーを記録する）
+	DeadLetterQueueURL string `json:"dead_letter_queue_url"`
+	// Endpoint SQSのエンドポイント（LocalStackなど。空の場合は aws.region のエンドポイント。SNSのデッドレターキューにも使用する）
+	Endpoint string `json:"endpoint"`
+}
+
+// メールの送信方法
+const (
+	// EmailProviderSMTP SMTPサーバーから送信する
+	EmailProviderSMTP = "smtp"
+	// EmailProviderSES Amazon SESのAPIで送信する
+	EmailProviderSES = "ses"
+)
+
+// RateLimitConfig /api へのリクエスト数の制限設定（APIキー・利用者・接続元ごとに1分あたりの件数で制限する）
+type RateLimitConfig struct {
+	// Enabled リクエスト数を制限する
+	Enabled bool `json:"enabled"`
+	// RequestsPerMinute 階層を割り当てていないAPIキー・利用者・接続元の1分あたりのリクエスト数
+	RequestsPerMinute int `json:"requests_per_minute"`
+	// Tiers 階層ごとの1分あたりのリクエスト数（0の場合は制限しない）
+	Tiers map[string]int `json:"tiers"`
+	// APIKeyTiers APIキーのIDごとの階層
+	APIKeyTiers map[string]string `json:"api_key_tiers"`
+	// ActorHeader APIキーの無いリクエストの利用者を識別するヘッダー（認証するプロキシが付けるヘッダー。空の場合は接続元ごとに制限する）
+	ActorHeader string `json:"actor_header"`
+	// ActorTiers 利用者ごとの階層
+	ActorTiers map[string]string `json:"actor_tiers"`
+}
+
+// CalendarConfig 期限・リマインドのある達成目録を配信する iCalendar のフィード（/api/calendar.ics）の設定
+//
+// カレンダーアプリはヘッダーを設定できないため、APIキーの代わりにURLの token パラメータで認証する。
+type CalendarConfig struct {
+	// Token 既定のテナントのフィードのトークン（空の場合は既定のテナントのフィードを配信しない）
+	Token string `json:"token"`
+	// TenantTokens テナントIDごとのフィードのトークン
+	TenantTokens map[string]string `json:"tenant_tokens"`
+}
+
+// minCalendarTokenLength フィードのトークンの最小の長さ（URLに含めて共有するため推測されにくい長さにする）
+const minCalendarTokenLength = 16
+
+// Tokens フィードのトークンごとのテナントID（トークンが無い場合は空）
+func (c CalendarConfig) Tokens() map[string]string {
+	tokens := map[string]string{}
+	if c.Token != "" {
+		tokens[c.Token] = tenant.DefaultID
+	}
+	for tenantID, token := range c.TenantTokens {
+		if token != "" {
+			tokens[token] = tenantID
+		}
+	}
+	return tokens
+}
+
+// Limit 階層の1分あたりのリクエスト数（階層が空または未定義の場合は requests_per_minute）
+func (c RateLimitConfig) Limit(tier string) int {
+	if limit, ok := c.Tiers[tier]; ok && tier != "" {
+		return limit
+	}
+	return c.RequestsPerMinute
+}
+
+// AllowedNetworks 接続を許可するIPアドレスの範囲（空の場合は nil）
+func (c SecurityConfig) AllowedNetworks() []*net.IPNet {
+	return parseNetworks(c.AllowedCIDRs)
+}
+
+// DeniedNetworks 接続を拒否するIPアドレスの範囲（空の場合は nil）
+func (c SecurityConfig) DeniedNetworks() []*net.IPNet {
+	return parseNetworks(c.DeniedCIDRs)
+}
+
+// TrustedNetworks 信頼するプロキシのIPアドレスの範囲（空の場合は nil）
+func (c SecurityConfig) TrustedNetworks() []*net.IPNet {
+	return parseNetworks(c.TrustedProxies)
+}
+
+// parseNetworks CIDR表記またはIPアドレスの範囲（読み込めないものは無視する）
+func parseNetworks(values []string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, value := range values {
+		if network, err := parseNetwork(value); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+// parseNetwork CIDR表記の範囲（IPアドレスの場合はそのアドレスだけの範囲）
+func parseNetwork(value string) (*net.IPNet, error) {
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address: %s", value)
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 8 * net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(value)
+	return network, err
+}
+
+// BulkConfig 達成目録・報酬の一括作成・削除の設定
+type BulkConfig struct {
+	// Parallelism 一括操作で同時に実行する項目数
+	Parallelism int `json:"parallelism"`
+	// MaxItems 1回の一括操作で受け付ける項目数の上限
+	MaxItems int `json:"max_items"`
+}
+
+// EncryptionConfig 説明などの機微な属性をリポジトリで暗号化する設定
+type EncryptionConfig struct {
+	// Provider 暗号鍵の提供方法（passphrase または kms。空の場合は暗号化しない）
+	Provider string `json:"provider"`
+	// Passphrase 暗号鍵を導出するパスフレーズ（provider が passphrase の場合のみ使用）
+	Passphrase string `json:"passphrase"`
+	// KMSKeyID データキーを生成するKMSキーのIDまたはARN（provider が kms の場合のみ使用）
+	KMSKeyID string `json:"kms_key_id"`
+	// Tables 説明を暗号化するテーブルの識別子（achievements, rewards）
+	Tables []string `json:"tables"`
+}
+
+// 暗号鍵の提供方法
+const (
+	// EncryptionProviderPassphrase パスフレーズから導出した鍵で暗号化する
+	EncryptionProviderPassphrase = "passphrase"
+	// EncryptionProviderKMS KMSで生成したデータキーで暗号化する（エンベロープ暗号化）
+	EncryptionProviderKMS = "kms"
+)
+
+// EncryptableTables 説明を暗号化できるテーブルの識別子
+var EncryptableTables = []string{"achievements", "rewards"}
+
+// CacheConfig 達成目録と報酬の読み取りキャッシュ設定
+type CacheConfig struct {
+	// Enabled GetByID と List の結果をキャッシュする
+	Enabled       bool   `json:"enabled"`
+	Driver        string `json:"driver"`
+	// TTLSeconds キャッシュの有効期間（他のプロセスからの書き込みはこの期間まで反映されない場合がある）
+	TTLSeconds    int    `json:"ttl_seconds"`
+	// Capacity プロセス内キャッシュに保持する最大件数（driver が memory の場合のみ使用）
+	Capacity      int    `json:"capacity"`
+	// RedisAddr Redisのアドレス（driver が redis の場合のみ使用）
+	RedisAddr     string `json:"redis_addr"`
+	RedisPassword string `json:"redis_password"`
+	RedisDB       int    `json:"redis_db"`
 }
 
 // LoadConfig 設定ファイルと環境変数から設定を読み込み
 func LoadConfig() (*Config, error) {
+	// .env の ENVIRONMENT も使えるように、環境を取得する前に読み込む
+	if _, err := loadDotEnv(DotEnvFile); err != nil {
+		return nil, err
+	}
+
+	// 環境を取得
+	config, err := LoadConfigForEnvironment(getEnv("ENVIRONMENT", "development"))
+	if err != nil {
+		return nil, err
+	}
+	if os.Getenv("ENVIRONMENT") != "" {
+		config.setSource("environment", SourceEnv)
+	}
+	return config, nil
+}
+
+// LoadConfigForEnvironment ENVIRONMENT にかかわらず、指定した環境の設定を読み込む
+//
+// 環境変数による上書きは LoadConfig と同じく適用する。
+func LoadConfigForEnvironment(env string) (*Config, error) {
 	// デフォルト設定
 	config := getDefaultConfig()
-	
-	// 環境を取得
-	env := getEnv("ENVIRONMENT", "development")
 	config.Environment = env
 	
+	// .env の環境変数を設定（設定済みの環境変数は上書きしない）
+	envFile, err := loadDotEnv(DotEnvFile)
+	if err != nil {
+		return nil, err
+	}
+	config.envFilePath = envFile
+	
 	// 設定ファイルから読み込み
 	if err := loadConfigFile(config, env); err != nil {
-		// 設定ファイルが見つからない場合は警告のみ
+		// 指定した設定ファイルを読み込めない場合はエラー、環境別の設定ファイルが見つからない場合は警告のみ
+		if explicitConfigFile() != "" {
+			return nil, err
+		}
 		fmt.Printf("Warning: Could not load config file for environment '%s': %v\n", env, err)
 	}
 	
-	// 環境変数で上書き
+	// 環境変数で上書き（ENVIRONMENT には上書きさせない）
+	before := config.settingValues()
 	overrideWithEnvVars(config)
+	config.Environment = env
+	config.recordChanges(before, SourceEnv)
+	config.setSource("environment", SourceDefault)
+
+	// 暗号化して記載した値（enc: で始まる値）を復号
+	if err := decryptSecrets(context.Background(), config); err != nil {
+		return nil, err
+	}
 	
 	// 設定値の検証
 	if err := validateConfig(config); err != nil {
@@ -97,6 +913,10 @@ func LoadConfig() (*Config, error) {
 func getDefaultConfig() *Config {
 	return &Config{
 		Environment: "development",
+		Storage: StorageConfig{
+			Driver:     StorageDriverDynamoDB,
+			SQLitePath: "achievement.db",
+		},
 		AWS: AWSConfig{
 			Region:           "us-east-1",
 			DynamoDBEndpoint: "",
@@ -107,11 +927,30 @@ func getDefaultConfig() *Config {
 			Rewards:       "rewards",
 			CurrentPoints: "current_points",
 			RewardHistory: "reward_history",
+			PointLedger:   "point_ledger",
+			Completions:   "completions",
+			Badges:        "badges",
+			Goals:         "goals",
+			Favorites:     "favorites",
+			Wishlist:      "wishlist",
+			Notes:         "notes",
+			DriftEvents:   "drift_events",
+			Reservations:  "reservations",
+			Quests:        "quests",
+			Operations:    "operations",
+			Allowances:    "allowances",
+			APIKeys:       "api_keys",
+			TTLAttribute:  "expires_at",
 		},
 		Retry: RetryConfig{
 			MaxRetries: 3,
 			BackoffMs:  100,
 		},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 5,
+			OpenSeconds:      30,
+		},
 		Server: ServerConfig{
 			Port:         "8080",
 			ReadTimeout:  30,
@@ -121,39 +960,201 @@ func getDefaultConfig() *Config {
 			Level:  "info",
 			Format: "json",
 			Output: "stdout",
+			Rotation: LogRotationConfig{
+				MaxSizeMB: 100,
+			},
+			Access: AccessLogConfig{
+				ExcludePaths: []string{"/health", "/metrics"},
+			},
+			Audit: AuditLogConfig{
+				Enabled:     false,
+				Output:      "audit.log",
+				ActorHeader: "X-Actor-ID",
+			},
+		},
+		Streams: StreamsConfig{
+			Enabled:        false,
+			PollIntervalMs: 1000,
+		},
+		Cache: CacheConfig{
+			Enabled:    false,
+			Driver:     CacheDriverMemory,
+			TTLSeconds: 30,
+			Capacity:   1000,
+			RedisAddr:  "localhost:6379",
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tenancy: TenancyConfig{
+			Enabled: false,
+			Header:  "X-Tenant-ID",
+		},
+		Backup: BackupConfig{
+			Prefix:       "backups/",
+			Gzip:         true,
+			ScanSegments: 4,
+		},
+		Points: PointsConfig{
+			AdjustOnUpdate: true,
+			MaxPoint:       100000,
+			OverQuota:      OverQuotaReject,
+		},
+		Streaks: StreaksConfig{
+			Milestones: map[int]int{7: 10, 30: 50, 100: 200},
+		},
+		Refunds: RefundsConfig{
+			WindowHours: 24,
+		},
+		Attachments: AttachmentsConfig{
+			Prefix:                "attachments/",
+			UploadExpiryMinutes:   15,
+			DownloadExpiryMinutes: 60,
 		},
+		Reminders: RemindersConfig{
+			Schedule: "0 20 * * *",
+		},
+		Summaries: SummariesConfig{
+			DailySchedule:  "0 8 * * *",
+			WeeklySchedule: "0 8 * * 1",
+		},
+		Consistency: ConsistencyConfig{
+			IntervalMinutes: 60,
+		},
+		Journal: JournalConfig{
+			Size: 20,
+		},
+		Bulk: BulkConfig{
+			Parallelism: 4,
+			MaxItems:    100,
+		},
+		HealthCheck: HealthCheckConfig{
+			CheckDynamoDB:   false,
+			TimeoutMs:       2000,
+			CacheTTLSeconds: 5,
+		},
+		Security: SecurityConfig{
+			ContentSecurityPolicy: "default-src 'self'; frame-ancestors 'none'",
+		},
+		Webhooks: WebhooksConfig{
+			ToleranceSeconds: 300,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           false,
+			RequestsPerMinute: 120,
+		},
+		Notifications: NotificationsConfig{
+			Email: EmailNotificationsConfig{
+				SMTPPort: 587,
+			},
+			EventBridge: EventBridgeNotificationsConfig{
+				Source: "achievement-management",
+			},
+			BatchSize:   10,
+			BatchWaitMs: 1000,
+		},
+	}
+}
+
+// configFile SetConfigFile で指定した設定ファイル
+var configFile string
+
+// SetConfigFile 環境にかかわらず読み込む設定ファイルを指定する（空の場合は CONFIG_FILE、未設定なら環境別の設定ファイル）
+func SetConfigFile(path string) {
+	configFile = path
+}
+
+// explicitConfigFile SetConfigFile または CONFIG_FILE で指定した設定ファイル
+func explicitConfigFile() string {
+	if configFile != "" {
+		return configFile
 	}
+	return os.Getenv("CONFIG_FILE")
+}
+
+// environmentConfigPaths 環境別の設定ファイルの候補（先にあるものを優先）
+func environmentConfigPaths(env string) []string {
+	var paths []string
+	for _, dir := range []string{"config", "configs", ""} {
+		for _, ext := range []string{".json", ".yaml", ".yml"} {
+			paths = append(paths, filepath.Join(dir, env+ext))
+		}
+	}
+	return paths
+}
+
+// homeConfigPath 環境別の設定ファイルが無い場合に読み込むホームディレクトリの設定ファイル
+func homeConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".achievement-app.yaml")
 }
 
 // loadConfigFile 設定ファイルから設定を読み込み
+//
+// SetConfigFile・CONFIG_FILE で指定したファイル、環境別の設定ファイル、$HOME/.achievement-app.yaml の順に探し、
+// 最初に見つかった1つだけを読み込む。拡張子が .yaml・.yml のファイルはYAML、それ以外はJSONとして読み込む。
 func loadConfigFile(config *Config, env string) error {
+	if path := explicitConfigFile(); path != "" {
+		configData, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+		return parseConfigFile(config, path, configData)
+	}
+
 	// 設定ファイルのパスを決定
-	configPaths := []string{
-		fmt.Sprintf("config/%s.json", env),
-		fmt.Sprintf("configs/%s.json", env),
-		fmt.Sprintf("%s.json", env),
+	configPaths := environmentConfigPaths(env)
+	if home := homeConfigPath(); home != "" {
+		configPaths = append(configPaths, home)
 	}
 	
-	var configData []byte
-	var err error
-	
 	for _, path := range configPaths {
-		if configData, err = os.ReadFile(path); err == nil {
-			break
+		if configData, err := os.ReadFile(path); err == nil {
+			return parseConfigFile(config, path, configData)
 		}
 	}
 	
-	if err != nil {
-		return fmt.Errorf("config file not found for environment '%s'", env)
+	return fmt.Errorf("config file not found for environment '%s'", env)
+}
+
+// parseConfigFile 設定ファイルの内容を拡張子に応じて読み込む
+func parseConfigFile(config *Config, path string, data []byte) error {
+	if isYAMLFile(path) {
+		// YAMLのキーもJSONと同じ名前にするため、JSONに変換してから読み込む
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		converted, err := json.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		data = converted
+	}
+	
+	if err := json.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
 	}
 	
-	if err := json.Unmarshal(configData, config); err != nil {
-		return fmt.Errorf("failed to parse config file: %w", err)
+	config.filePath = path
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err == nil {
+		config.recordFileKeys(raw)
 	}
 	
 	return nil
 }
 
+// isYAMLFile 拡張子からYAMLの設定ファイルかどうかを判定
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
 // overrideWithEnvVars 環境変数で設定を上書き
 func overrideWithEnvVars(config *Config) {
 	// 環境設定
@@ -161,6 +1162,17 @@ func overrideWithEnvVars(config *Config) {
 		config.Environment = env
 	}
 	
+	// ストレージ設定
+	if driver := os.Getenv("STORAGE_DRIVER"); driver != "" {
+		config.Storage.Driver = driver
+	}
+	if path := os.Getenv("SQLITE_PATH"); path != "" {
+		config.Storage.SQLitePath = path
+	}
+	if dsn := os.Getenv("POSTGRES_DSN"); dsn != "" {
+		config.Storage.PostgresDSN = dsn
+	}
+	
 	// AWS設定
 	if region := os.Getenv("AWS_REGION"); region != "" {
 		config.AWS.Region = region
@@ -171,6 +1183,11 @@ func overrideWithEnvVars(config *Config) {
 	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
 		config.AWS.Profile = profile
 	}
+	if consistent := os.Getenv("DYNAMODB_CONSISTENT_READ"); consistent != "" {
+		if value, err := strconv.ParseBool(consistent); err == nil {
+			config.AWS.ConsistentRead = value
+		}
+	}
 	if accessKey := os.Getenv("AWS_ACCESS_KEY_ID"); accessKey != "" {
 		config.AWS.AccessKeyID = accessKey
 	}
@@ -191,14 +1208,69 @@ func overrideWithEnvVars(config *Config) {
 	if table := os.Getenv("REWARD_HISTORY_TABLE"); table != "" {
 		config.Tables.RewardHistory = table
 	}
-	
-	// リトライ設定
-	if retries := getEnvAsInt("MAX_RETRIES", 0); retries > 0 {
-		config.Retry.MaxRetries = retries
+	if table := os.Getenv("POINT_LEDGER_TABLE"); table != "" {
+		config.Tables.PointLedger = table
 	}
-	if backoff := getEnvAsInt("RETRY_BACKOFF_MS", 0); backoff > 0 {
+	if table := os.Getenv("COMPLETIONS_TABLE"); table != "" {
+		config.Tables.Completions = table
+	}
+	if table := os.Getenv("BADGES_TABLE"); table != "" {
+		config.Tables.Badges = table
+	}
+	if table := os.Getenv("GOALS_TABLE"); table != "" {
+		config.Tables.Goals = table
+	}
+	if table := os.Getenv("FAVORITES_TABLE"); table != "" {
+		config.Tables.Favorites = table
+	}
+	if table := os.Getenv("WISHLIST_TABLE"); table != "" {
+		config.Tables.Wishlist = table
+	}
+	if table := os.Getenv("NOTES_TABLE"); table != "" {
+		config.Tables.Notes = table
+	}
+	if table := os.Getenv("DRIFT_EVENTS_TABLE"); table != "" {
+		config.Tables.DriftEvents = table
+	}
+	if table := os.Getenv("RESERVATIONS_TABLE"); table != "" {
+		config.Tables.Reservations = table
+	}
+	if table := os.Getenv("QUESTS_TABLE"); table != "" {
+		config.Tables.Quests = table
+	}
+	if table := os.Getenv("OPERATIONS_TABLE"); table != "" {
+		config.Tables.Operations = table
+	}
+	if table := os.Getenv("ALLOWANCES_TABLE"); table != "" {
+		config.Tables.Allowances = table
+	}
+	if table := os.Getenv("API_KEYS_TABLE"); table != "" {
+		config.Tables.APIKeys = table
+	}
+	if attribute := os.Getenv("DYNAMODB_TTL_ATTRIBUTE"); attribute != "" {
+		config.Tables.TTLAttribute = attribute
+	}
+	
+	// リトライ設定
+	if retries := getEnvAsInt("MAX_RETRIES", 0); retries > 0 {
+		config.Retry.MaxRetries = retries
+	}
+	if backoff := getEnvAsInt("RETRY_BACKOFF_MS", 0); backoff > 0 {
 		config.Retry.BackoffMs = backoff
 	}
+
+	// サーキットブレーカー設定
+	if enabled := os.Getenv("CIRCUIT_BREAKER_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.CircuitBreaker.Enabled = value
+		}
+	}
+	if threshold := getEnvAsInt("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 0); threshold > 0 {
+		config.CircuitBreaker.FailureThreshold = threshold
+	}
+	if seconds := getEnvAsInt("CIRCUIT_BREAKER_OPEN_SECONDS", 0); seconds > 0 {
+		config.CircuitBreaker.OpenSeconds = seconds
+	}
 	
 	// サーバー設定
 	if port := os.Getenv("SERVER_PORT"); port != "" {
@@ -210,6 +1282,9 @@ func overrideWithEnvVars(config *Config) {
 	if timeout := getEnvAsInt("SERVER_WRITE_TIMEOUT", 0); timeout > 0 {
 		config.Server.WriteTimeout = timeout
 	}
+	if mode := os.Getenv("SERVER_MODE"); mode != "" {
+		config.Server.Mode = mode
+	}
 	
 	// ログ設定
 	if level := os.Getenv("LOG_LEVEL"); level != "" {
@@ -221,6 +1296,533 @@ func overrideWithEnvVars(config *Config) {
 	if output := os.Getenv("LOG_OUTPUT"); output != "" {
 		config.Logging.Output = output
 	}
+	if maxSize := os.Getenv("LOG_MAX_SIZE_MB"); maxSize != "" {
+		if value, err := strconv.Atoi(maxSize); err == nil {
+			config.Logging.Rotation.MaxSizeMB = value
+		}
+	}
+	if interval := os.Getenv("LOG_ROTATE_INTERVAL_HOURS"); interval != "" {
+		if value, err := strconv.Atoi(interval); err == nil {
+			config.Logging.Rotation.IntervalHours = value
+		}
+	}
+	if maxBackups := os.Getenv("LOG_MAX_BACKUPS"); maxBackups != "" {
+		if value, err := strconv.Atoi(maxBackups); err == nil {
+			config.Logging.Rotation.MaxBackups = value
+		}
+	}
+	if maxAge := os.Getenv("LOG_MAX_AGE_DAYS"); maxAge != "" {
+		if value, err := strconv.Atoi(maxAge); err == nil {
+			config.Logging.Rotation.MaxAgeDays = value
+		}
+	}
+	if compress := os.Getenv("LOG_COMPRESS"); compress != "" {
+		if value, err := strconv.ParseBool(compress); err == nil {
+			config.Logging.Rotation.Compress = value
+		}
+	}
+	if paths, ok := os.LookupEnv("LOG_ACCESS_EXCLUDE_PATHS"); ok {
+		// 空の場合はすべてのパスを記録する
+		config.Logging.Access.ExcludePaths = splitList(paths)
+	}
+	if format := os.Getenv("LOG_ACCESS_FORMAT"); format != "" {
+		config.Logging.Access.Format = format
+	}
+	if output := os.Getenv("LOG_ACCESS_OUTPUT"); output != "" {
+		config.Logging.Access.Output = output
+	}
+	if format := os.Getenv("LOG_ERROR_FORMAT"); format != "" {
+		config.Logging.Error.Format = format
+	}
+	if output := os.Getenv("LOG_ERROR_OUTPUT"); output != "" {
+		config.Logging.Error.Output = output
+	}
+	if enabled := os.Getenv("LOG_AUDIT_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Logging.Audit.Enabled = value
+		}
+	}
+	if output := os.Getenv("LOG_AUDIT_OUTPUT"); output != "" {
+		config.Logging.Audit.Output = output
+	}
+	if header := os.Getenv("LOG_AUDIT_ACTOR_HEADER"); header != "" {
+		config.Logging.Audit.ActorHeader = header
+	}
+	if sample := os.Getenv("LOG_ACCESS_SAMPLE"); sample != "" {
+		if value, err := parseAccessSample(sample); err == nil {
+			config.Logging.Access.Sample = value
+		}
+	}
+	if token := os.Getenv("LOGGING_ADMIN_TOKEN"); token != "" {
+		config.Logging.AdminToken = token
+	}
+	
+	// DynamoDB Streams設定
+	if enabled := os.Getenv("STREAMS_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Streams.Enabled = value
+		}
+	}
+	if urls := os.Getenv("STREAMS_WEBHOOK_URLS"); urls != "" {
+		config.Streams.WebhookURLs = splitList(urls)
+	}
+	if interval := getEnvAsInt("STREAMS_POLL_INTERVAL_MS", 0); interval > 0 {
+		config.Streams.PollIntervalMs = interval
+	}
+
+	// 読み取りキャッシュ設定
+	if enabled := os.Getenv("CACHE_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Cache.Enabled = value
+		}
+	}
+	if driver := os.Getenv("CACHE_DRIVER"); driver != "" {
+		config.Cache.Driver = driver
+	}
+	if ttl := getEnvAsInt("CACHE_TTL_SECONDS", 0); ttl > 0 {
+		config.Cache.TTLSeconds = ttl
+	}
+	if capacity := getEnvAsInt("CACHE_CAPACITY", 0); capacity > 0 {
+		config.Cache.Capacity = capacity
+	}
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		config.Cache.RedisAddr = addr
+	}
+	if password := os.Getenv("REDIS_PASSWORD"); password != "" {
+		config.Cache.RedisPassword = password
+	}
+	if db := getEnvAsInt("REDIS_DB", -1); db >= 0 {
+		config.Cache.RedisDB = db
+	}
+
+	// メトリクス設定
+	if enabled := os.Getenv("METRICS_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Metrics.Enabled = value
+		}
+	}
+	if path := os.Getenv("METRICS_PATH"); path != "" {
+		config.Metrics.Path = path
+	}
+
+	// テナント設定
+	if enabled := os.Getenv("TENANCY_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Tenancy.Enabled = value
+		}
+	}
+	if header := os.Getenv("TENANCY_HEADER"); header != "" {
+		config.Tenancy.Header = header
+	}
+
+	// バックアップ設定
+	if bucket := os.Getenv("BACKUP_BUCKET"); bucket != "" {
+		config.Backup.Bucket = bucket
+	}
+	if prefix := os.Getenv("BACKUP_PREFIX"); prefix != "" {
+		config.Backup.Prefix = prefix
+	}
+	if gzip := os.Getenv("BACKUP_GZIP"); gzip != "" {
+		if value, err := strconv.ParseBool(gzip); err == nil {
+			config.Backup.Gzip = value
+		}
+	}
+	if endpoint := os.Getenv("BACKUP_S3_ENDPOINT"); endpoint != "" {
+		config.Backup.Endpoint = endpoint
+	}
+	if token := os.Getenv("BACKUP_ADMIN_TOKEN"); token != "" {
+		config.Backup.AdminToken = token
+	}
+	if segments := getEnvAsInt("BACKUP_SCAN_SEGMENTS", 0); segments > 0 {
+		config.Backup.ScanSegments = segments
+	}
+	if workers := getEnvAsInt("BACKUP_SCAN_WORKERS", 0); workers > 0 {
+		config.Backup.ScanWorkers = workers
+	}
+
+	// メンテナンスモード設定
+	if readOnly := os.Getenv("MAINTENANCE_READ_ONLY"); readOnly != "" {
+		if value, err := strconv.ParseBool(readOnly); err == nil {
+			config.Maintenance.ReadOnly = value
+		}
+	}
+	if token := os.Getenv("MAINTENANCE_ADMIN_TOKEN"); token != "" {
+		config.Maintenance.AdminToken = token
+	}
+
+	// 属性の暗号化設定
+	if provider := os.Getenv("ENCRYPTION_PROVIDER"); provider != "" {
+		config.Encryption.Provider = provider
+	}
+	if passphrase := os.Getenv("ENCRYPTION_PASSPHRASE"); passphrase != "" {
+		config.Encryption.Passphrase = passphrase
+	}
+	if keyID := os.Getenv("ENCRYPTION_KMS_KEY_ID"); keyID != "" {
+		config.Encryption.KMSKeyID = keyID
+	}
+	if tables := os.Getenv("ENCRYPTION_TABLES"); tables != "" {
+		config.Encryption.Tables = splitList(tables)
+	}
+
+	// ポイント設定
+	if adjust := os.Getenv("POINTS_ADJUST_ON_DELETE"); adjust != "" {
+		if value, err := strconv.ParseBool(adjust); err == nil {
+			config.Points.AdjustOnDelete = value
+		}
+	}
+	if adjust := os.Getenv("POINTS_ADJUST_ON_UPDATE"); adjust != "" {
+		if value, err := strconv.ParseBool(adjust); err == nil {
+			config.Points.AdjustOnUpdate = value
+		}
+	}
+	if max := os.Getenv("POINTS_MAX_POINT"); max != "" {
+		if value, err := strconv.Atoi(max); err == nil {
+			config.Points.MaxPoint = value
+		}
+	}
+	if quota := os.Getenv("POINTS_DAILY_QUOTA"); quota != "" {
+		if value, err := strconv.Atoi(quota); err == nil {
+			config.Points.DailyQuota = value
+		}
+	}
+	if overQuota := os.Getenv("POINTS_OVER_QUOTA"); overQuota != "" {
+		config.Points.OverQuota = overQuota
+	}
+
+	// 連続達成日数設定
+	if timezone := os.Getenv("STREAKS_TIMEZONE"); timezone != "" {
+		config.Streaks.Timezone = timezone
+	}
+	if enabled := os.Getenv("STREAKS_BONUS_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Streaks.BonusEnabled = value
+		}
+	}
+	if milestones := os.Getenv("STREAKS_MILESTONES"); milestones != "" {
+		if value, err := parseMilestones(milestones); err == nil {
+			config.Streaks.Milestones = value
+		}
+	}
+
+	// 目標設定
+	if urls := os.Getenv("GOALS_WEBHOOK_URLS"); urls != "" {
+		config.Goals.WebhookURLs = splitList(urls)
+	}
+
+	// 報酬獲得の取り消し設定
+	if hours := os.Getenv("REFUNDS_WINDOW_HOURS"); hours != "" {
+		if value, err := strconv.Atoi(hours); err == nil {
+			config.Refunds.WindowHours = value
+		}
+	}
+	if token := os.Getenv("REFUNDS_ADMIN_TOKEN"); token != "" {
+		config.Refunds.AdminToken = token
+	}
+
+	// 添付ファイル設定
+	if bucket := os.Getenv("ATTACHMENTS_BUCKET"); bucket != "" {
+		config.Attachments.Bucket = bucket
+	}
+	if prefix := os.Getenv("ATTACHMENTS_PREFIX"); prefix != "" {
+		config.Attachments.Prefix = prefix
+	}
+	if endpoint := os.Getenv("ATTACHMENTS_S3_ENDPOINT"); endpoint != "" {
+		config.Attachments.Endpoint = endpoint
+	}
+	if minutes := getEnvAsInt("ATTACHMENTS_UPLOAD_EXPIRY_MINUTES", 0); minutes > 0 {
+		config.Attachments.UploadExpiryMinutes = minutes
+	}
+	if minutes := getEnvAsInt("ATTACHMENTS_DOWNLOAD_EXPIRY_MINUTES", 0); minutes > 0 {
+		config.Attachments.DownloadExpiryMinutes = minutes
+	}
+
+	// リマインド設定
+	if enabled := os.Getenv("REMINDERS_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Reminders.Enabled = value
+		}
+	}
+	if schedule := os.Getenv("REMINDERS_SCHEDULE"); schedule != "" {
+		config.Reminders.Schedule = schedule
+	}
+	if urls := os.Getenv("REMINDERS_WEBHOOK_URLS"); urls != "" {
+		config.Reminders.WebhookURLs = splitList(urls)
+	}
+	if tenants := os.Getenv("REMINDERS_TENANTS"); tenants != "" {
+		config.Reminders.Tenants = splitList(tenants)
+	}
+
+	// サマリー設定
+	if enabled := os.Getenv("SUMMARIES_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Summaries.Enabled = value
+		}
+	}
+	if schedule := os.Getenv("SUMMARIES_DAILY_SCHEDULE"); schedule != "" {
+		config.Summaries.DailySchedule = schedule
+	}
+	if schedule := os.Getenv("SUMMARIES_WEEKLY_SCHEDULE"); schedule != "" {
+		config.Summaries.WeeklySchedule = schedule
+	}
+	if urls := os.Getenv("SUMMARIES_WEBHOOK_URLS"); urls != "" {
+		config.Summaries.WebhookURLs = splitList(urls)
+	}
+	if tenants := os.Getenv("SUMMARIES_TENANTS"); tenants != "" {
+		config.Summaries.Tenants = splitList(tenants)
+	}
+
+	// 整合性チェック設定
+	if enabled := os.Getenv("CONSISTENCY_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Consistency.Enabled = value
+		}
+	}
+	if interval := os.Getenv("CONSISTENCY_INTERVAL_MINUTES"); interval != "" {
+		if value, err := strconv.Atoi(interval); err == nil {
+			config.Consistency.IntervalMinutes = value
+		}
+	}
+	if threshold := os.Getenv("CONSISTENCY_THRESHOLD"); threshold != "" {
+		if value, err := strconv.Atoi(threshold); err == nil {
+			config.Consistency.Threshold = value
+		}
+	}
+	if urls := os.Getenv("CONSISTENCY_WEBHOOK_URLS"); urls != "" {
+		config.Consistency.WebhookURLs = splitList(urls)
+	}
+	if tenants := os.Getenv("CONSISTENCY_TENANTS"); tenants != "" {
+		config.Consistency.Tenants = splitList(tenants)
+	}
+
+	// 操作履歴設定
+	if size := os.Getenv("JOURNAL_SIZE"); size != "" {
+		if value, err := strconv.Atoi(size); err == nil {
+			config.Journal.Size = value
+		}
+	}
+	if token := os.Getenv("JOURNAL_ADMIN_TOKEN"); token != "" {
+		config.Journal.AdminToken = token
+	}
+
+	// 一括操作設定
+	if parallelism := os.Getenv("BULK_PARALLELISM"); parallelism != "" {
+		if value, err := strconv.Atoi(parallelism); err == nil {
+			config.Bulk.Parallelism = value
+		}
+	}
+	if maxItems := os.Getenv("BULK_MAX_ITEMS"); maxItems != "" {
+		if value, err := strconv.Atoi(maxItems); err == nil {
+			config.Bulk.MaxItems = value
+		}
+	}
+
+	// お小遣い設定
+	if enabled := os.Getenv("ALLOWANCES_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.Allowances.Enabled = value
+		}
+	}
+	if tenants := os.Getenv("ALLOWANCES_TENANTS"); tenants != "" {
+		config.Allowances.Tenants = splitList(tenants)
+	}
+
+	// 設定の診断設定
+	if token := os.Getenv("DIAGNOSTICS_ADMIN_TOKEN"); token != "" {
+		config.Diagnostics.AdminToken = token
+	}
+
+	// エラー通知設定
+	if dsn := os.Getenv("ERROR_REPORTING_DSN"); dsn != "" {
+		config.ErrorReporting.DSN = dsn
+	}
+
+	// ヘルスチェック設定
+	if check := os.Getenv("HEALTH_CHECK_DYNAMODB"); check != "" {
+		if value, err := strconv.ParseBool(check); err == nil {
+			config.HealthCheck.CheckDynamoDB = value
+		}
+	}
+	if timeout := getEnvAsInt("HEALTH_CHECK_TIMEOUT_MS", 0); timeout > 0 {
+		config.HealthCheck.TimeoutMs = timeout
+	}
+	if ttl := getEnvAsInt("HEALTH_CHECK_CACHE_TTL_SECONDS", -1); ttl >= 0 {
+		config.HealthCheck.CacheTTLSeconds = ttl
+	}
+
+	// セキュリティ設定
+	if policy := os.Getenv("SECURITY_CONTENT_SECURITY_POLICY"); policy != "" {
+		config.Security.ContentSecurityPolicy = policy
+	}
+	if cidrs := os.Getenv("SECURITY_ALLOWED_CIDRS"); cidrs != "" {
+		config.Security.AllowedCIDRs = splitList(cidrs)
+	}
+	if cidrs := os.Getenv("SECURITY_DENIED_CIDRS"); cidrs != "" {
+		config.Security.DeniedCIDRs = splitList(cidrs)
+	}
+	if proxies := os.Getenv("SECURITY_TRUSTED_PROXIES"); proxies != "" {
+		config.Security.TrustedProxies = splitList(proxies)
+	}
+
+	// Webhookの署名設定（送信先ごとの秘密鍵は設定ファイルで指定する）
+	if secret := os.Getenv("WEBHOOK_SIGNING_SECRET"); secret != "" {
+		config.Webhooks.SigningSecret = secret
+	}
+	if tolerance := getEnvAsInt("WEBHOOK_TOLERANCE_SECONDS", 0); tolerance > 0 {
+		config.Webhooks.ToleranceSeconds = tolerance
+	}
+
+	// APIキー設定
+	if required := os.Getenv("API_KEYS_REQUIRED"); required != "" {
+		if value, err := strconv.ParseBool(required); err == nil {
+			config.APIKeys.Required = value
+		}
+	}
+	if token := os.Getenv("API_KEYS_ADMIN_TOKEN"); token != "" {
+		config.APIKeys.AdminToken = token
+	}
+
+	// 通知設定
+	if url := os.Getenv("SLACK_WEBHOOK_URL"); url != "" {
+		config.Notifications.Slack.WebhookURL = url
+	}
+	if channel := os.Getenv("SLACK_CHANNEL"); channel != "" {
+		config.Notifications.Slack.Channel = channel
+	}
+	if events := os.Getenv("SLACK_EVENTS"); events != "" {
+		config.Notifications.Slack.Events = splitList(events)
+	}
+	if url := os.Getenv("DISCORD_WEBHOOK_URL"); url != "" {
+		config.Notifications.Discord.WebhookURL = url
+	}
+	if events := os.Getenv("DISCORD_EVENTS"); events != "" {
+		config.Notifications.Discord.Events = splitList(events)
+	}
+	if key := os.Getenv("DISCORD_PUBLIC_KEY"); key != "" {
+		config.Notifications.Discord.PublicKey = key
+	}
+	if provider := os.Getenv("EMAIL_PROVIDER"); provider != "" {
+		config.Notifications.Email.Provider = provider
+	}
+	if from := os.Getenv("EMAIL_FROM"); from != "" {
+		config.Notifications.Email.From = from
+	}
+	if to := os.Getenv("EMAIL_TO"); to != "" {
+		config.Notifications.Email.To = splitList(to)
+	}
+	if events := os.Getenv("EMAIL_EVENTS"); events != "" {
+		config.Notifications.Email.Events = splitList(events)
+	}
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		config.Notifications.Email.SMTPHost = host
+	}
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		if value, err := strconv.Atoi(port); err == nil {
+			config.Notifications.Email.SMTPPort = value
+		}
+	}
+	if username := os.Getenv("SMTP_USERNAME"); username != "" {
+		config.Notifications.Email.SMTPUsername = username
+	}
+	if password := os.Getenv("SMTP_PASSWORD"); password != "" {
+		config.Notifications.Email.SMTPPassword = password
+	}
+	if endpoint := os.Getenv("SES_ENDPOINT"); endpoint != "" {
+		config.Notifications.Email.SESEndpoint = endpoint
+	}
+	if busName := os.Getenv("EVENTBRIDGE_BUS_NAME"); busName != "" {
+		config.Notifications.EventBridge.BusName = busName
+	}
+	if source := os.Getenv("EVENTBRIDGE_SOURCE"); source != "" {
+		config.Notifications.EventBridge.Source = source
+	}
+	if prefix := os.Getenv("EVENTBRIDGE_DETAIL_TYPE_PREFIX"); prefix != "" {
+		config.Notifications.EventBridge.DetailTypePrefix = prefix
+	}
+	if events := os.Getenv("EVENTBRIDGE_EVENTS"); events != "" {
+		config.Notifications.EventBridge.Events = splitList(events)
+	}
+	if endpoint := os.Getenv("EVENTBRIDGE_ENDPOINT"); endpoint != "" {
+		config.Notifications.EventBridge.Endpoint = endpoint
+	}
+	if arn := os.Getenv("SNS_TOPIC_ARN"); arn != "" {
+		config.Notifications.SNS.TopicARN = arn
+	}
+	if events := os.Getenv("SNS_EVENTS"); events != "" {
+		config.Notifications.SNS.Events = splitList(events)
+	}
+	if url := os.Getenv("SNS_DEAD_LETTER_QUEUE_URL"); url != "" {
+		config.Notifications.SNS.DeadLetterQueueURL = url
+	}
+	if endpoint := os.Getenv("SNS_ENDPOINT"); endpoint != "" {
+		config.Notifications.SNS.Endpoint = endpoint
+	}
+	if url := os.Getenv("SQS_QUEUE_URL"); url != "" {
+		config.Notifications.SQS.QueueURL = url
+	}
+	if events := os.Getenv("SQS_EVENTS"); events != "" {
+		config.Notifications.SQS.Events = splitList(events)
+	}
+	if url := os.Getenv("SQS_DEAD_LETTER_QUEUE_URL"); url != "" {
+		config.Notifications.SQS.DeadLetterQueueURL = url
+	}
+	if endpoint := os.Getenv("SQS_ENDPOINT"); endpoint != "" {
+		config.Notifications.SQS.Endpoint = endpoint
+	}
+	if size := os.Getenv("NOTIFICATIONS_BATCH_SIZE"); size != "" {
+		if value, err := strconv.Atoi(size); err == nil {
+			config.Notifications.BatchSize = value
+		}
+	}
+	if wait := os.Getenv("NOTIFICATIONS_BATCH_WAIT_MS"); wait != "" {
+		if value, err := strconv.Atoi(wait); err == nil {
+			config.Notifications.BatchWaitMs = value
+		}
+	}
+	if threshold := os.Getenv("NOTIFICATIONS_LOW_BALANCE_THRESHOLD"); threshold != "" {
+		if value, err := strconv.Atoi(threshold); err == nil {
+			config.Notifications.LowBalanceThreshold = value
+		}
+	}
+
+	// リクエスト数の制限設定
+	if enabled := os.Getenv("RATE_LIMIT_ENABLED"); enabled != "" {
+		if value, err := strconv.ParseBool(enabled); err == nil {
+			config.RateLimit.Enabled = value
+		}
+	}
+	if requests := os.Getenv("RATE_LIMIT_REQUESTS_PER_MINUTE"); requests != "" {
+		if value, err := strconv.Atoi(requests); err == nil {
+			config.RateLimit.RequestsPerMinute = value
+		}
+	}
+	if tiers := os.Getenv("RATE_LIMIT_TIERS"); tiers != "" {
+		if value, err := parseRateLimitTiers(tiers); err == nil {
+			config.RateLimit.Tiers = value
+		}
+	}
+	if tiers := os.Getenv("RATE_LIMIT_API_KEY_TIERS"); tiers != "" {
+		if value, err := parseTierAssignments(tiers); err == nil {
+			config.RateLimit.APIKeyTiers = value
+		}
+	}
+	if header := os.Getenv("RATE_LIMIT_ACTOR_HEADER"); header != "" {
+		config.RateLimit.ActorHeader = header
+	}
+	if tiers := os.Getenv("RATE_LIMIT_ACTOR_TIERS"); tiers != "" {
+		if value, err := parseTierAssignments(tiers); err == nil {
+			config.RateLimit.ActorTiers = value
+		}
+	}
+
+	// カレンダーのフィード設定
+	if token := os.Getenv("CALENDAR_TOKEN"); token != "" {
+		config.Calendar.Token = token
+	}
+	if tokens := os.Getenv("CALENDAR_TENANT_TOKENS"); tokens != "" {
+		if value, err := parseCalendarTokens(tokens); err == nil {
+			config.Calendar.TenantTokens = value
+		}
+	}
 }
 
 // validateConfig 設定値の検証
@@ -234,6 +1836,19 @@ func validateConfig(config *Config) error {
 			config.Environment, strings.Join(validEnvs, ", ")))
 	}
 	
+	// ストレージ設定の検証
+	validDrivers := []string{StorageDriverDynamoDB, StorageDriverSQLite, StorageDriverPostgres, StorageDriverMemory}
+	if !contains(validDrivers, config.Storage.Driver) {
+		errors = append(errors, fmt.Sprintf("invalid storage driver: %s (must be one of: %s)", 
+			config.Storage.Driver, strings.Join(validDrivers, ", ")))
+	}
+	if config.Storage.Driver == StorageDriverSQLite && config.Storage.SQLitePath == "" {
+		errors = append(errors, "sqlite path is required when the storage driver is sqlite")
+	}
+	if config.Storage.Driver == StorageDriverPostgres && config.Storage.PostgresDSN == "" {
+		errors = append(errors, "postgres dsn is required when the storage driver is postgres")
+	}
+	
 	// AWS設定の検証
 	if config.AWS.Region == "" {
 		errors = append(errors, "AWS region is required")
@@ -252,6 +1867,45 @@ func validateConfig(config *Config) error {
 	if config.Tables.RewardHistory == "" {
 		errors = append(errors, "reward history table name is required")
 	}
+	if config.Tables.PointLedger == "" {
+		errors = append(errors, "point ledger table name is required")
+	}
+	if config.Tables.Completions == "" {
+		errors = append(errors, "completions table name is required")
+	}
+	if config.Tables.Badges == "" {
+		errors = append(errors, "badges table name is required")
+	}
+	if config.Tables.Goals == "" {
+		errors = append(errors, "goals table name is required")
+	}
+	if config.Tables.Favorites == "" {
+		errors = append(errors, "favorites table name is required")
+	}
+	if config.Tables.Wishlist == "" {
+		errors = append(errors, "wishlist table name is required")
+	}
+	if config.Tables.Notes == "" {
+		errors = append(errors, "notes table name is required")
+	}
+	if config.Tables.DriftEvents == "" {
+		errors = append(errors, "drift events table name is required")
+	}
+	if config.Tables.Reservations == "" {
+		errors = append(errors, "reservations table name is required")
+	}
+	if config.Tables.Quests == "" {
+		errors = append(errors, "quests table name is required")
+	}
+	if config.Tables.Operations == "" {
+		errors = append(errors, "operations table name is required")
+	}
+	if config.Tables.Allowances == "" {
+		errors = append(errors, "allowances table name is required")
+	}
+	if config.Tables.APIKeys == "" {
+		errors = append(errors, "api keys table name is required")
+	}
 	
 	// リトライ設定の検証
 	if config.Retry.MaxRetries < 0 {
@@ -260,6 +1914,15 @@ func validateConfig(config *Config) error {
 	if config.Retry.BackoffMs < 0 {
 		errors = append(errors, "backoff milliseconds must be non-negative")
 	}
+
+	if config.CircuitBreaker.Enabled {
+		if config.CircuitBreaker.FailureThreshold <= 0 {
+			errors = append(errors, "circuit breaker failure threshold must be positive")
+		}
+		if config.CircuitBreaker.OpenSeconds <= 0 {
+			errors = append(errors, "circuit breaker open seconds must be positive")
+		}
+	}
 	
 	// サーバー設定の検証
 	if config.Server.Port == "" {
@@ -271,6 +1934,11 @@ func validateConfig(config *Config) error {
 	if config.Server.WriteTimeout <= 0 {
 		errors = append(errors, "server write timeout must be positive")
 	}
+	validServerModes := []string{"debug", "release", "test"}
+	if mode := config.Server.Mode; mode != "" && !contains(validServerModes, mode) {
+		errors = append(errors, fmt.Sprintf("invalid server mode: %s (must be one of: %s)",
+			mode, strings.Join(validServerModes, ", ")))
+	}
 	
 	// ログ設定の検証
 	validLogLevels := []string{"debug", "info", "warn", "error"}
@@ -285,6 +1953,345 @@ func validateConfig(config *Config) error {
 			config.Logging.Format, strings.Join(validLogFormats, ", ")))
 	}
 	
+	if format := config.Logging.Access.Format; format != "" && !contains(validLogFormats, format) {
+		errors = append(errors, fmt.Sprintf("invalid access log format: %s (must be one of: %s)",
+			format, strings.Join(validLogFormats, ", ")))
+	}
+	if format := config.Logging.Error.Format; format != "" && !contains(validLogFormats, format) {
+		errors = append(errors, fmt.Sprintf("invalid error log format: %s (must be one of: %s)",
+			format, strings.Join(validLogFormats, ", ")))
+	}
+	if config.Logging.Audit.Enabled {
+		if config.Logging.Audit.Output == "" {
+			errors = append(errors, "audit log output is required when the audit log is enabled")
+		}
+		if config.Logging.Audit.ActorHeader == "" {
+			errors = append(errors, "audit log actor header is required when the audit log is enabled")
+		}
+	}
+
+	rotation := config.Logging.Rotation
+	if rotation.MaxSizeMB < 0 || rotation.IntervalHours < 0 || rotation.MaxBackups < 0 || rotation.MaxAgeDays < 0 {
+		errors = append(errors, "log rotation max size, interval, max backups and max age must be non-negative")
+	}
+	for route, rate := range config.Logging.Access.Sample {
+		if rate < 1 {
+			errors = append(errors, fmt.Sprintf("access log sample rate for %s must be at least 1", route))
+		}
+	}
+	
+	// DynamoDB Streams設定の検証
+	if config.Streams.PollIntervalMs < 0 {
+		errors = append(errors, "streams poll interval must be non-negative")
+	}
+	for _, url := range config.Streams.WebhookURLs {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			errors = append(errors, fmt.Sprintf("invalid streams webhook url: %s (must start with http:// or https://)", url))
+		}
+	}
+	
+	// 読み取りキャッシュ設定の検証
+	if config.Cache.Enabled {
+		validCacheDrivers := []string{CacheDriverMemory, CacheDriverRedis}
+		if !contains(validCacheDrivers, config.Cache.Driver) {
+			errors = append(errors, fmt.Sprintf("invalid cache driver: %s (must be one of: %s)",
+				config.Cache.Driver, strings.Join(validCacheDrivers, ", ")))
+		}
+		if config.Cache.TTLSeconds <= 0 {
+			errors = append(errors, "cache ttl must be positive")
+		}
+		if config.Cache.Driver == CacheDriverMemory && config.Cache.Capacity <= 0 {
+			errors = append(errors, "cache capacity must be positive")
+		}
+		if config.Cache.Driver == CacheDriverRedis && config.Cache.RedisAddr == "" {
+			errors = append(errors, "redis address is required when the cache driver is redis")
+		}
+	}
+
+	if config.Metrics.Enabled && !strings.HasPrefix(config.Metrics.Path, "/") {
+		errors = append(errors, fmt.Sprintf("invalid metrics path: %s (must start with /)", config.Metrics.Path))
+	}
+
+	if config.Tenancy.Enabled && config.Tenancy.Header == "" {
+		errors = append(errors, "tenancy header is required when tenancy is enabled")
+	}
+
+	if config.Backup.AdminToken != "" && config.Backup.Bucket == "" {
+		errors = append(errors, "backup bucket is required when the backup admin token is set")
+	}
+	// DynamoDBのScanが受け付けるセグメント数は1,000,000まで
+	if config.Backup.ScanSegments < 0 || config.Backup.ScanSegments > 1000000 {
+		errors = append(errors, "backup scan segments must be between 0 and 1000000")
+	}
+	if config.Backup.ScanWorkers < 0 {
+		errors = append(errors, "backup scan workers must be non-negative")
+	}
+
+	// 属性の暗号化設定の検証
+	switch config.Encryption.Provider {
+	case "":
+	case EncryptionProviderPassphrase:
+		if config.Encryption.Passphrase == "" {
+			errors = append(errors, "encryption passphrase is required when the encryption provider is passphrase")
+		}
+	case EncryptionProviderKMS:
+		if config.Encryption.KMSKeyID == "" {
+			errors = append(errors, "encryption kms key id is required when the encryption provider is kms")
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("invalid encryption provider: %s (must be one of: %s, %s)",
+			config.Encryption.Provider, EncryptionProviderPassphrase, EncryptionProviderKMS))
+	}
+	for _, table := range config.Encryption.Tables {
+		if !contains(EncryptableTables, table) {
+			errors = append(errors, fmt.Sprintf("invalid encryption table: %s (must be one of: %s)", table, strings.Join(EncryptableTables, ", ")))
+		}
+	}
+
+	// ポイント設定の検証
+	if config.Points.MaxPoint <= 0 {
+		errors = append(errors, "points max point must be positive")
+	}
+	if config.Points.DailyQuota < 0 {
+		errors = append(errors, "points daily quota must not be negative")
+	}
+	if config.Points.OverQuota != OverQuotaReject && config.Points.OverQuota != OverQuotaZero {
+		errors = append(errors, fmt.Sprintf("invalid points over quota: %s (must be one of: %s, %s)",
+			config.Points.OverQuota, OverQuotaReject, OverQuotaZero))
+	}
+
+	// 連続達成日数設定の検証
+	if config.Streaks.Timezone != "" {
+		if _, err := time.LoadLocation(config.Streaks.Timezone); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid streaks timezone: %s", config.Streaks.Timezone))
+		}
+	}
+	for days, bonus := range config.Streaks.Milestones {
+		if days < 2 || bonus <= 0 {
+			errors = append(errors, fmt.Sprintf("invalid streak milestone %d:%d (days must be at least 2 and the bonus positive)", days, bonus))
+		}
+	}
+
+	// 目標設定の検証
+	for _, url := range config.Goals.WebhookURLs {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			errors = append(errors, fmt.Sprintf("invalid goals webhook url: %s (must start with http:// or https://)", url))
+		}
+	}
+
+	// 報酬獲得の取り消し設定の検証
+	if config.Refunds.WindowHours < 0 {
+		errors = append(errors, "refunds window hours cannot be negative")
+	}
+
+	// 添付ファイル設定の検証
+	if config.Attachments.UploadExpiryMinutes < 1 || config.Attachments.UploadExpiryMinutes > maxPresignMinutes {
+		errors = append(errors, fmt.Sprintf("attachments upload expiry minutes must be between 1 and %d", maxPresignMinutes))
+	}
+	if config.Attachments.DownloadExpiryMinutes < 1 || config.Attachments.DownloadExpiryMinutes > maxPresignMinutes {
+		errors = append(errors, fmt.Sprintf("attachments download expiry minutes must be between 1 and %d", maxPresignMinutes))
+	}
+
+	// リマインド設定の検証
+	if _, err := cron.Parse(config.Reminders.Schedule); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid reminders schedule: %v", err))
+	}
+	for _, url := range config.Reminders.WebhookURLs {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			errors = append(errors, fmt.Sprintf("invalid reminders webhook url: %s (must start with http:// or https://)", url))
+		}
+	}
+
+	// サマリー設定の検証
+	for _, schedule := range []string{config.Summaries.DailySchedule, config.Summaries.WeeklySchedule} {
+		if schedule == "" {
+			continue
+		}
+		if _, err := cron.Parse(schedule); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid summaries schedule: %v", err))
+		}
+	}
+	for _, url := range config.Summaries.WebhookURLs {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			errors = append(errors, fmt.Sprintf("invalid summaries webhook url: %s (must start with http:// or https://)", url))
+		}
+	}
+
+	// 整合性チェック設定の検証
+	if config.Consistency.IntervalMinutes <= 0 {
+		errors = append(errors, "consistency interval_minutes must be positive")
+	}
+	if config.Consistency.Threshold < 0 {
+		errors = append(errors, "consistency threshold cannot be negative")
+	}
+	for _, url := range config.Consistency.WebhookURLs {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			errors = append(errors, fmt.Sprintf("invalid consistency webhook url: %s (must start with http:// or https://)", url))
+		}
+	}
+
+	// 操作履歴設定の検証
+	if config.Journal.Size <= 0 {
+		errors = append(errors, "journal size must be positive")
+	}
+
+	// 一括操作設定の検証
+	if config.Bulk.Parallelism <= 0 {
+		errors = append(errors, "bulk parallelism must be positive")
+	}
+	if config.Bulk.MaxItems <= 0 {
+		errors = append(errors, "bulk max items must be positive")
+	}
+
+	// エラー通知設定の検証
+	if dsn := config.ErrorReporting.DSN; dsn != "" && !validDSN(dsn) {
+		errors = append(errors, "invalid error reporting dsn (must be http(s)://<public key>@<host>/<project id>)")
+	}
+
+	// ヘルスチェック設定の検証
+	if config.HealthCheck.TimeoutMs <= 0 {
+		errors = append(errors, "health check timeout must be positive")
+	}
+	if config.HealthCheck.CacheTTLSeconds < 0 {
+		errors = append(errors, "health check cache ttl must not be negative")
+	}
+
+	// Webhookの署名設定の検証
+	if config.Webhooks.ToleranceSeconds <= 0 {
+		errors = append(errors, "webhook signature tolerance must be positive")
+	}
+
+	// セキュリティ設定の検証
+	for _, cidrs := range [][]string{config.Security.AllowedCIDRs, config.Security.DeniedCIDRs, config.Security.TrustedProxies} {
+		for _, cidr := range cidrs {
+			if _, err := parseNetwork(cidr); err != nil {
+				errors = append(errors, fmt.Sprintf("invalid security cidr: %s (must be a CIDR range or an IP address)", cidr))
+			}
+		}
+	}
+
+	// 通知設定の検証
+	channels := []struct {
+		name       string
+		webhookURL string
+		events     []string
+	}{
+		{"slack", config.Notifications.Slack.WebhookURL, config.Notifications.Slack.Events},
+		{"discord", config.Notifications.Discord.WebhookURL, config.Notifications.Discord.Events},
+		{"email", "", config.Notifications.Email.Events},
+		{"eventbridge", "", config.Notifications.EventBridge.Events},
+		{"sns", "", config.Notifications.SNS.Events},
+		{"sqs", "", config.Notifications.SQS.Events},
+	}
+	for _, channel := range channels {
+		// URLにはトークンが含まれるため、エラーには含めない
+		if url := channel.webhookURL; url != "" && !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+			errors = append(errors, fmt.Sprintf("invalid %s webhook url (must start with http:// or https://)", channel.name))
+		}
+		for _, event := range channel.events {
+			if !contains(NotificationEvents, event) {
+				errors = append(errors, fmt.Sprintf("invalid %s notification event: %s (must be one of: %s)", channel.name, event, strings.Join(NotificationEvents, ", ")))
+			}
+		}
+	}
+	if email := config.Notifications.Email; email.Provider != "" {
+		if email.Provider != EmailProviderSMTP && email.Provider != EmailProviderSES {
+			errors = append(errors, fmt.Sprintf("invalid email provider: %s (must be one of: %s, %s)", email.Provider, EmailProviderSMTP, EmailProviderSES))
+		}
+		if _, err := mail.ParseAddress(email.From); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid email from address: %q", email.From))
+		}
+		if len(email.To) == 0 {
+			errors = append(errors, "email to addresses are required when an email provider is set")
+		}
+		for _, to := range email.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				errors = append(errors, fmt.Sprintf("invalid email to address: %q", to))
+			}
+		}
+		if email.Provider == EmailProviderSMTP {
+			if email.SMTPHost == "" {
+				errors = append(errors, "smtp host is required when the email provider is smtp")
+			}
+			if email.SMTPPort < 1 || email.SMTPPort > 65535 {
+				errors = append(errors, fmt.Sprintf("invalid smtp port: %d", email.SMTPPort))
+			}
+		}
+	}
+	if config.Notifications.EventBridge.BusName != "" && config.Notifications.EventBridge.Source == "" {
+		errors = append(errors, "eventbridge source is required when an eventbridge bus name is set")
+	}
+	if arn := config.Notifications.SNS.TopicARN; arn != "" && !strings.HasPrefix(arn, "arn:") {
+		errors = append(errors, fmt.Sprintf("invalid sns topic arn: %s", arn))
+	}
+	queues := []struct {
+		name string
+		url  string
+	}{
+		{"sns dead-letter queue", config.Notifications.SNS.DeadLetterQueueURL},
+		{"sqs queue", config.Notifications.SQS.QueueURL},
+		{"sqs dead-letter queue", config.Notifications.SQS.DeadLetterQueueURL},
+	}
+	for _, queue := range queues {
+		if queue.url != "" && !strings.HasPrefix(queue.url, "https://") && !strings.HasPrefix(queue.url, "http://") {
+			errors = append(errors, fmt.Sprintf("invalid %s url: %s (must start with http:// or https://)", queue.name, queue.url))
+		}
+	}
+	if config.Notifications.BatchSize < 1 || config.Notifications.BatchSize > 10 {
+		errors = append(errors, fmt.Sprintf("notifications batch size must be between 1 and 10, got %d", config.Notifications.BatchSize))
+	}
+	if config.Notifications.BatchWaitMs < 0 {
+		errors = append(errors, "notifications batch wait cannot be negative")
+	}
+	if config.Notifications.Discord.PublicKey != "" && config.Notifications.Discord.VerifyKey() == nil {
+		errors = append(errors, "invalid discord public key (must be the 64 hex characters shown in the developer portal)")
+	}
+	if config.Notifications.LowBalanceThreshold < 0 {
+		errors = append(errors, "notifications low balance threshold cannot be negative")
+	}
+
+	// リクエスト数の制限設定の検証
+	if config.RateLimit.Enabled && config.RateLimit.RequestsPerMinute < 1 {
+		errors = append(errors, "rate limit requests per minute must be at least 1")
+	}
+	// 利用者のヘッダーはクライアントが自由に付けられるため、信頼するプロキシから届いた場合のみ使用する
+	if config.RateLimit.ActorHeader != "" && len(config.Security.TrustedProxies) == 0 {
+		errors = append(errors, "rate limit actor header requires security trusted proxies")
+	}
+	for tier, limit := range config.RateLimit.Tiers {
+		if limit < 0 {
+			errors = append(errors, fmt.Sprintf("rate limit for tier %s must not be negative", tier))
+		}
+	}
+	for _, assignments := range []map[string]string{config.RateLimit.APIKeyTiers, config.RateLimit.ActorTiers} {
+		for id, tier := range assignments {
+			if _, ok := config.RateLimit.Tiers[tier]; !ok {
+				errors = append(errors, fmt.Sprintf("rate limit tier %s assigned to %s is not defined in rate_limit.tiers", tier, id))
+			}
+		}
+	}
+
+	// カレンダーのフィード設定の検証
+	calendarTokens := map[string]bool{}
+	if token := config.Calendar.Token; token != "" {
+		if len(token) < minCalendarTokenLength {
+			errors = append(errors, fmt.Sprintf("calendar token must be at least %d characters", minCalendarTokenLength))
+		}
+		calendarTokens[token] = true
+	}
+	for tenantID, token := range config.Calendar.TenantTokens {
+		if err := tenant.Validate(tenantID); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid calendar tenant %s: %v", tenantID, err))
+		}
+		if len(token) < minCalendarTokenLength {
+			errors = append(errors, fmt.Sprintf("calendar token for tenant %s must be at least %d characters", tenantID, minCalendarTokenLength))
+		}
+		if calendarTokens[token] {
+			errors = append(errors, fmt.Sprintf("calendar token for tenant %s is used by another tenant", tenantID))
+		}
+		calendarTokens[token] = true
+	}
+	
 	if len(errors) > 0 {
 		return fmt.Errorf("validation errors: %s", strings.Join(errors, "; "))
 	}
@@ -302,16 +2309,109 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// splitList カンマ区切りの文字列を空要素を除いて分割
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseMilestones "7:10,30:50" 形式の節目（日数:ボーナスポイント）を解析
+func parseMilestones(value string) (map[int]int, error) {
+	milestones := map[int]int{}
+	for _, item := range splitList(value) {
+		daysStr, bonusStr, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid streak milestone: %s", item)
+		}
+		days, err := strconv.Atoi(strings.TrimSpace(daysStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid streak milestone: %s", item)
+		}
+		bonus, err := strconv.Atoi(strings.TrimSpace(bonusStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid streak milestone: %s", item)
+		}
+		milestones[days] = bonus
+	}
+	return milestones, nil
+}
+
+// parseAccessSample "/api/achievements=10,/api/points/current=5" 形式のルートごとの間引き（N件に1件）を解析
+//
+// ルートに : が含まれるため、ルートと件数は = で区切る。
+func parseAccessSample(value string) (map[string]int, error) {
+	sample := map[string]int{}
+	for _, item := range splitList(value) {
+		i := strings.LastIndex(item, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid access log sample: %s", item)
+		}
+		rate, err := strconv.Atoi(strings.TrimSpace(item[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid access log sample: %s", item)
+		}
+		sample[strings.TrimSpace(item[:i])] = rate
+	}
+	return sample, nil
+}
+
+// parseRateLimitTiers "partner=600,internal=0" 形式の階層ごとの1分あたりのリクエスト数を解析
+func parseRateLimitTiers(value string) (map[string]int, error) {
+	tiers := map[string]int{}
+	for _, item := range splitList(value) {
+		name, limit, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid rate limit tier: %s", item)
+		}
+		requests, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit tier: %s", item)
+		}
+		tiers[strings.TrimSpace(name)] = requests
+	}
+	return tiers, nil
+}
+
+// parseTierAssignments "01J8...=partner,alice=internal" 形式のAPIキー・利用者ごとの階層を解析
+func parseTierAssignments(value string) (map[string]string, error) {
+	assignments := map[string]string{}
+	for _, item := range splitList(value) {
+		id, tier, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(id) == "" || strings.TrimSpace(tier) == "" {
+			return nil, fmt.Errorf("invalid rate limit tier assignment: %s", item)
+		}
+		assignments[strings.TrimSpace(id)] = strings.TrimSpace(tier)
+	}
+	return assignments, nil
+}
+
+// parseCalendarTokens "default=...,family-a=..." 形式のテナントIDごとのフィードのトークンを解析
+func parseCalendarTokens(value string) (map[string]string, error) {
+	tokens := map[string]string{}
+	for _, item := range splitList(value) {
+		tenantID, token, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(tenantID) == "" || strings.TrimSpace(token) == "" {
+			return nil, fmt.Errorf("invalid calendar tenant token: %s", item)
+		}
+		tokens[strings.TrimSpace(tenantID)] = strings.TrimSpace(token)
+	}
+	return tokens, nil
+}
+
 // GetConfigPath 設定ファイルのパスを取得
+//
+// SetConfigFile・CONFIG_FILE で指定したファイル、既存の環境別の設定ファイル、config/{env}.json の順に返す。
 func GetConfigPath(env string) string {
-	// 設定ファイルのパスを決定
-	configPaths := []string{
-		fmt.Sprintf("config/%s.json", env),
-		fmt.Sprintf("configs/%s.json", env),
-		fmt.Sprintf("%s.json", env),
+	if path := explicitConfigFile(); path != "" {
+		return path
 	}
 	
-	for _, path := range configPaths {
+	for _, path := range environmentConfigPaths(env) {
 		if _, err := os.Stat(path); err == nil {
 			return path
 		}
@@ -322,6 +2422,12 @@ func GetConfigPath(env string) string {
 
 // CreateConfigFile 設定ファイルを作成
 func CreateConfigFile(env string) error {
+	_, err := WriteConfigFile(NewDefaultConfig(env))
+	return err
+}
+
+// NewDefaultConfig 環境別のデフォルト設定を作成
+func NewDefaultConfig(env string) *Config {
 	config := getDefaultConfig()
 	config.Environment = env
 	
@@ -333,32 +2439,91 @@ func CreateConfigFile(env string) error {
 		config.Tables.Rewards = "prod-rewards"
 		config.Tables.CurrentPoints = "prod-current-points"
 		config.Tables.RewardHistory = "prod-reward-history"
+		config.Tables.PointLedger = "prod-point-ledger"
+		config.Tables.Completions = "prod-completions"
+		config.Tables.Badges = "prod-badges"
+		config.Tables.Goals = "prod-goals"
+		config.Tables.Favorites = "prod-favorites"
+		config.Tables.Wishlist = "prod-wishlist"
+		config.Tables.Notes = "prod-notes"
+		config.Tables.DriftEvents = "prod-drift-events"
+		config.Tables.Reservations = "prod-reservations"
+		config.Tables.Quests = "prod-quests"
+		config.Tables.Operations = "prod-operations"
+		config.Tables.Allowances = "prod-allowances"
+		config.Tables.APIKeys = "prod-api-keys"
 	case "staging":
 		config.Logging.Level = "info"
 		config.Tables.Achievements = "staging-achievements"
 		config.Tables.Rewards = "staging-rewards"
 		config.Tables.CurrentPoints = "staging-current-points"
 		config.Tables.RewardHistory = "staging-reward-history"
+		config.Tables.PointLedger = "staging-point-ledger"
+		config.Tables.Completions = "staging-completions"
+		config.Tables.Badges = "staging-badges"
+		config.Tables.Goals = "staging-goals"
+		config.Tables.Favorites = "staging-favorites"
+		config.Tables.Wishlist = "staging-wishlist"
+		config.Tables.Notes = "staging-notes"
+		config.Tables.DriftEvents = "staging-drift-events"
+		config.Tables.Reservations = "staging-reservations"
+		config.Tables.Quests = "staging-quests"
+		config.Tables.Operations = "staging-operations"
+		config.Tables.Allowances = "staging-allowances"
+		config.Tables.APIKeys = "staging-api-keys"
 	}
 	
-	configPath := GetConfigPath(env)
+	return config
+}
+
+// ValidateConfig 設定値を検証
+func ValidateConfig(config *Config) error {
+	return validateConfig(config)
+}
+
+// WriteConfigFile 設定を環境別の設定ファイルに書き込み、書き込んだパスを返す
+func WriteConfigFile(config *Config) (string, error) {
+	configPath := GetConfigPath(config.Environment)
 	
 	// ディレクトリを作成
 	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+		return "", fmt.Errorf("failed to create config directory: %w", err)
 	}
 	
-	// JSON形式で保存
+	// JSON形式で保存（YAMLの設定ファイルはYAML形式）
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if isYAMLFile(configPath) {
+		if data, err = jsonToYAML(data); err != nil {
+			return "", fmt.Errorf("failed to marshal config: %w", err)
+		}
 	}
 	
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+		return "", fmt.Errorf("failed to write config file: %w", err)
 	}
 	
-	return nil
+	return configPath, nil
+}
+
+// jsonToYAML JSONをキーの順序を保ったままブロック形式のYAMLに変換
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearYAMLStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// clearYAMLStyle JSONから読み込んだフロー形式・引用符付きのスタイルを既定に戻す
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
 }
 
 // getEnv 環境変数を取得（デフォルト値付き）
@@ -377,4 +2542,16 @@ func getEnvAsInt(key string, defaultValue int) int {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// validDSN Sentry互換のDSNの形式か（スキーム・公開キー・ホスト・プロジェクトIDがあるか）
+func validDSN(dsn string) bool {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return false
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	return u.User != nil && u.User.Username() != "" && u.Host != "" && strings.Trim(u.Path, "/") != ""
+}
@@ -2,7 +2,10 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig_DefaultValues(t *testing.T) {
@@ -27,6 +30,14 @@ func TestLoadConfig_DefaultValues(t *testing.T) {
 		t.Errorf("Expected achievements table 'achievements', got '%s'", config.Tables.Achievements)
 	}
 	
+	if config.Tables.TTLAttribute != "expires_at" {
+		t.Errorf("Expected TTL attribute 'expires_at', got '%s'", config.Tables.TTLAttribute)
+	}
+	
+	if config.Storage.Driver != StorageDriverDynamoDB {
+		t.Errorf("Expected storage driver 'dynamodb', got '%s'", config.Storage.Driver)
+	}
+	
 	if config.Server.Port != "8080" {
 		t.Errorf("Expected server port '8080', got '%s'", config.Server.Port)
 	}
@@ -43,6 +54,8 @@ func TestLoadConfig_EnvironmentVariables(t *testing.T) {
 	os.Setenv("ACHIEVEMENTS_TABLE", "prod-achievements")
 	os.Setenv("SERVER_PORT", "9000")
 	os.Setenv("LOG_LEVEL", "error")
+	os.Setenv("DYNAMODB_TTL_ATTRIBUTE", "purge_at")
+	os.Setenv("DYNAMODB_CONSISTENT_READ", "true")
 	
 	defer func() {
 		os.Clearenv()
@@ -66,6 +79,14 @@ func TestLoadConfig_EnvironmentVariables(t *testing.T) {
 		t.Errorf("Expected achievements table 'prod-achievements', got '%s'", config.Tables.Achievements)
 	}
 	
+	if config.Tables.TTLAttribute != "purge_at" {
+		t.Errorf("Expected TTL attribute 'purge_at', got '%s'", config.Tables.TTLAttribute)
+	}
+	
+	if !config.AWS.ConsistentRead {
+		t.Error("Expected consistent read to be enabled")
+	}
+	
 	if config.Server.Port != "9000" {
 		t.Errorf("Expected server port '9000', got '%s'", config.Server.Port)
 	}
@@ -75,6 +96,29 @@ func TestLoadConfig_EnvironmentVariables(t *testing.T) {
 	}
 }
 
+func TestLoadConfigForEnvironment_IgnoresEnvironmentVariable(t *testing.T) {
+	os.Setenv("ENVIRONMENT", "production")
+	os.Setenv("AWS_REGION", "ap-northeast-1")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfigForEnvironment("staging")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	if config.Environment != "staging" {
+		t.Errorf("Expected environment 'staging', got '%s'", config.Environment)
+	}
+	
+	// その他の環境変数は LoadConfig と同じく適用する
+	if config.AWS.Region != "ap-northeast-1" {
+		t.Errorf("Expected AWS region 'ap-northeast-1', got '%s'", config.AWS.Region)
+	}
+}
+
 func TestValidateConfig_InvalidEnvironment(t *testing.T) {
 	config := getDefaultConfig()
 	config.Environment = "invalid"
@@ -105,6 +149,113 @@ func TestValidateConfig_InvalidLogLevel(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_InvalidOverQuota(t *testing.T) {
+	config := getDefaultConfig()
+	config.Points.DailyQuota = 100
+	config.Points.OverQuota = "ignore"
+	
+	err := validateConfig(config)
+	if err == nil {
+		t.Error("Expected validation error for invalid over quota")
+	}
+}
+
+func TestLoadConfig_StreamsEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("STREAMS_ENABLED", "true")
+	os.Setenv("STREAMS_WEBHOOK_URLS", "https://example.com/hook, http://localhost:9000/events,")
+	os.Setenv("STREAMS_POLL_INTERVAL_MS", "250")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	if !config.Streams.Enabled {
+		t.Error("Expected streams to be enabled")
+	}
+	
+	if len(config.Streams.WebhookURLs) != 2 || config.Streams.WebhookURLs[1] != "http://localhost:9000/events" {
+		t.Errorf("Unexpected webhook URLs: %v", config.Streams.WebhookURLs)
+	}
+	
+	if config.Streams.PollIntervalMs != 250 {
+		t.Errorf("Expected poll interval 250, got %d", config.Streams.PollIntervalMs)
+	}
+}
+
+func TestValidateConfig_InvalidWebhookURL(t *testing.T) {
+	config := getDefaultConfig()
+	config.Streams.WebhookURLs = []string{"example.com/hook"}
+	
+	err := validateConfig(config)
+	if err == nil {
+		t.Error("Expected validation error for webhook URL without scheme")
+	}
+}
+
+func TestLoadConfig_StorageEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("STORAGE_DRIVER", "sqlite")
+	os.Setenv("SQLITE_PATH", "/var/lib/achievement/data.db")
+	os.Setenv("POSTGRES_DSN", "postgres://achievement@db:5432/achievement")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	if config.Storage.Driver != StorageDriverSQLite {
+		t.Errorf("Expected storage driver 'sqlite', got '%s'", config.Storage.Driver)
+	}
+	
+	if config.Storage.SQLitePath != "/var/lib/achievement/data.db" {
+		t.Errorf("Unexpected sqlite path: %s", config.Storage.SQLitePath)
+	}
+	
+	if config.Storage.PostgresDSN != "postgres://achievement@db:5432/achievement" {
+		t.Errorf("Unexpected postgres dsn: %s", config.Storage.PostgresDSN)
+	}
+}
+
+func TestValidateConfig_InvalidStorageDriver(t *testing.T) {
+	config := getDefaultConfig()
+	config.Storage.Driver = "mysql"
+	
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for unsupported storage driver")
+	}
+	
+	config.Storage.Driver = StorageDriverSQLite
+	config.Storage.SQLitePath = ""
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for sqlite without a path")
+	}
+	
+	config.Storage.Driver = StorageDriverPostgres
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for postgres without a dsn")
+	}
+	
+	config.Storage.PostgresDSN = "postgres://achievement@localhost:5432/achievement"
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected postgres with a dsn to be valid, got %v", err)
+	}
+	
+	config.Storage.Driver = StorageDriverMemory
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected memory storage to be valid, got %v", err)
+	}
+}
+
 func TestCreateConfigFile(t *testing.T) {
 	// Create temporary directory
 	tmpDir := t.TempDir()
@@ -123,6 +274,204 @@ func TestCreateConfigFile(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_YAMLFile(t *testing.T) {
+	os.Clearenv()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalWd)
+
+	os.MkdirAll("config", 0755)
+	yamlConfig := "storage:\n  driver: sqlite\n  sqlite_path: data/app.db\nserver:\n  port: \"9090\"\n  read_timeout: 15\n"
+	if err := os.WriteFile("config/staging.yml", []byte(yamlConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfigForEnvironment("staging")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if config.Storage.Driver != StorageDriverSQLite || config.Storage.SQLitePath != "data/app.db" {
+		t.Errorf("Expected sqlite storage at data/app.db, got %+v", config.Storage)
+	}
+	if config.Server.Port != "9090" || config.Server.ReadTimeout != 15 {
+		t.Errorf("Expected server port 9090 and read timeout 15, got %+v", config.Server)
+	}
+	// ファイルに無い項目は既定値のまま
+	if config.Server.WriteTimeout != 30 {
+		t.Errorf("Expected default write timeout 30, got %d", config.Server.WriteTimeout)
+	}
+}
+
+func TestLoadConfig_HomeConfigFile(t *testing.T) {
+	os.Clearenv()
+	home := t.TempDir()
+	os.Setenv("HOME", home)
+	defer os.Clearenv()
+	if err := os.WriteFile(filepath.Join(home, ".achievement-app.yaml"), []byte("storage:\n  driver: memory\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalWd)
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Storage.Driver != StorageDriverMemory {
+		t.Errorf("Expected storage driver from the home config file, got '%s'", config.Storage.Driver)
+	}
+
+	// 環境別の設定ファイルがある場合はホームディレクトリの設定ファイルを読み込まない
+	os.MkdirAll("config", 0755)
+	os.WriteFile("config/development.json", []byte(`{"storage": {"driver": "sqlite"}}`), 0644)
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Storage.Driver != StorageDriverSQLite {
+		t.Errorf("Expected storage driver from the environment config file, got '%s'", config.Storage.Driver)
+	}
+}
+
+func TestLoadConfig_ExplicitConfigFile(t *testing.T) {
+	os.Clearenv()
+	defer SetConfigFile("")
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "custom.json")
+	if err := os.WriteFile(path, []byte(`{"logging": {"level": "debug"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	SetConfigFile(path)
+	config, err := LoadConfigForEnvironment("production")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Logging.Level != "debug" || config.Environment != "production" {
+		t.Errorf("Expected debug logging for production, got '%s' for '%s'", config.Logging.Level, config.Environment)
+	}
+	if GetConfigPath("production") != path {
+		t.Errorf("Expected config path %s, got %s", path, GetConfigPath("production"))
+	}
+
+	// 指定した設定ファイルが無い場合はエラー
+	SetConfigFile(filepath.Join(tmpDir, "missing.yaml"))
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected error for a missing config file")
+	}
+
+	// CONFIG_FILE でも指定できる
+	SetConfigFile("")
+	os.Setenv("CONFIG_FILE", path)
+	defer os.Clearenv()
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Logging.Level != "debug" {
+		t.Errorf("Expected log level from CONFIG_FILE, got '%s'", config.Logging.Level)
+	}
+}
+
+func TestWriteConfigFile_YAML(t *testing.T) {
+	os.Clearenv()
+	defer SetConfigFile("")
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	SetConfigFile(path)
+
+	original := NewDefaultConfig("staging")
+	original.Storage.Driver = StorageDriverSQLite
+	if _, err := WriteConfigFile(original); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "driver: sqlite") {
+		t.Errorf("Expected block-style YAML, got:\n%s", data)
+	}
+
+	config, err := LoadConfigForEnvironment("staging")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Storage.Driver != StorageDriverSQLite || config.Tables.Achievements != "staging-achievements" {
+		t.Errorf("Expected the written config to be read back, got %+v %+v", config.Storage, config.Tables)
+	}
+}
+
+func TestLoadConfig_Sources(t *testing.T) {
+	os.Clearenv()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalWd)
+
+	os.MkdirAll("config", 0755)
+	yamlConfig := "storage:\n  driver: sqlite\n  sqlite_path: data/app.db\nserver:\n  port: \"9090\"\nstreaks:\n  milestones:\n    3: 5\n"
+	if err := os.WriteFile("config/development.yaml", []byte(yamlConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("SERVER_PORT", "9000")
+	os.Setenv("LOG_LEVEL", "info") // 既定値と同じ値は環境変数から読み込んだものとみなさない
+	os.Setenv("MAINTENANCE_ADMIN_TOKEN", "s3cret")
+	defer os.Clearenv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.FilePath() != filepath.Join("config", "development.yaml") {
+		t.Errorf("Expected config file path config/development.yaml, got '%s'", config.FilePath())
+	}
+
+	settings := make(map[string]Setting)
+	for _, setting := range config.Settings() {
+		settings[setting.Key] = setting
+	}
+	expected := map[string]Source{
+		"environment":             SourceDefault,
+		"storage.driver":          SourceFile,
+		"storage.sqlite_path":     SourceFile,
+		"storage.postgres_dsn":    SourceDefault,
+		"server.port":             SourceEnv,
+		"logging.level":           SourceDefault,
+		"streaks.milestones":      SourceFile,
+		"maintenance.admin_token": SourceEnv,
+	}
+	for key, source := range expected {
+		if settings[key].Source != source {
+			t.Errorf("Expected %s to come from %s, got %s", key, source, settings[key].Source)
+		}
+	}
+
+	// 秘密の値は伏せ、空の場合はそのまま
+	if settings["maintenance.admin_token"].Value != MaskedValue {
+		t.Errorf("Expected admin token to be masked, got %v", settings["maintenance.admin_token"].Value)
+	}
+	if settings["journal.admin_token"].Value != "" || !settings["journal.admin_token"].Secret {
+		t.Errorf("Expected empty secret to stay empty, got %+v", settings["journal.admin_token"])
+	}
+	if settings["server.port"].Value != "9000" {
+		t.Errorf("Expected server port 9000, got %v", settings["server.port"].Value)
+	}
+
+	os.Setenv("ENVIRONMENT", "staging")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, setting := range config.Settings() {
+		if setting.Key == "environment" && setting.Source != SourceEnv {
+			t.Errorf("Expected environment to come from env, got %s", setting.Source)
+		}
+	}
+}
+
 func TestGetEnvAsInt(t *testing.T) {
 	os.Setenv("TEST_INT", "42")
 	defer os.Unsetenv("TEST_INT")
@@ -148,4 +497,1286 @@ func TestContains(t *testing.T) {
 	if contains(slice, "d") {
 		t.Error("Expected 'd' not to be found in slice")
 	}
-}
\ No newline at end of file
+}
+func TestLoadConfig_CacheEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CACHE_ENABLED", "true")
+	os.Setenv("CACHE_DRIVER", "redis")
+	os.Setenv("CACHE_TTL_SECONDS", "10")
+	os.Setenv("REDIS_ADDR", "redis:6379")
+	os.Setenv("REDIS_DB", "2")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	if !config.Cache.Enabled || config.Cache.Driver != CacheDriverRedis {
+		t.Errorf("Expected redis cache to be enabled, got %+v", config.Cache)
+	}
+	if config.Cache.TTLSeconds != 10 || config.Cache.RedisAddr != "redis:6379" || config.Cache.RedisDB != 2 {
+		t.Errorf("Unexpected cache config: %+v", config.Cache)
+	}
+}
+
+func TestValidateConfig_Cache(t *testing.T) {
+	config := getDefaultConfig()
+	
+	// 無効な場合は検証しない
+	config.Cache.Driver = "memcached"
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected disabled cache not to be validated, got %v", err)
+	}
+	
+	config.Cache.Enabled = true
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for unsupported cache driver")
+	}
+	
+	config.Cache.Driver = CacheDriverMemory
+	config.Cache.Capacity = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for zero capacity")
+	}
+	
+	config.Cache.Capacity = 100
+	config.Cache.TTLSeconds = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for zero ttl")
+	}
+	
+	config.Cache.TTLSeconds = 30
+	config.Cache.Driver = CacheDriverRedis
+	config.Cache.RedisAddr = ""
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for redis without an address")
+	}
+}
+
+func TestLoadConfig_MetricsEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("METRICS_ENABLED", "false")
+	os.Setenv("METRICS_PATH", "/internal/metrics")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	if config.Metrics.Enabled || config.Metrics.Path != "/internal/metrics" {
+		t.Errorf("Unexpected metrics config: %+v", config.Metrics)
+	}
+}
+
+func TestValidateConfig_Metrics(t *testing.T) {
+	config := getDefaultConfig()
+	
+	if !config.Metrics.Enabled || config.Metrics.Path != "/metrics" {
+		t.Errorf("Expected metrics to be enabled at /metrics by default, got %+v", config.Metrics)
+	}
+	
+	config.Metrics.Path = "metrics"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for metrics path without leading slash")
+	}
+	
+	// 無効な場合は検証しない
+	config.Metrics.Enabled = false
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected disabled metrics not to be validated, got %v", err)
+	}
+}
+
+func TestLoadConfig_TenancyEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("TENANCY_ENABLED", "true")
+	os.Setenv("TENANCY_HEADER", "X-Family-ID")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	if !config.Tenancy.Enabled || config.Tenancy.Header != "X-Family-ID" {
+		t.Errorf("Unexpected tenancy config: %+v", config.Tenancy)
+	}
+}
+
+func TestValidateConfig_Tenancy(t *testing.T) {
+	config := getDefaultConfig()
+	
+	if config.Tenancy.Enabled || config.Tenancy.Header != "X-Tenant-ID" {
+		t.Errorf("Expected tenancy to be disabled with X-Tenant-ID by default, got %+v", config.Tenancy)
+	}
+	
+	config.Tenancy.Enabled = true
+	config.Tenancy.Header = ""
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for tenancy without a header")
+	}
+}
+
+func TestLoadConfig_BackupEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BACKUP_BUCKET", "achievement-backups")
+	os.Setenv("BACKUP_PREFIX", "prod/")
+	os.Setenv("BACKUP_GZIP", "false")
+	os.Setenv("BACKUP_S3_ENDPOINT", "http://localhost:9000")
+	os.Setenv("BACKUP_ADMIN_TOKEN", "secret")
+	os.Setenv("BACKUP_SCAN_SEGMENTS", "16")
+	os.Setenv("BACKUP_SCAN_WORKERS", "8")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	expected := BackupConfig{
+		Bucket:     "achievement-backups",
+		Prefix:     "prod/",
+		Gzip:       false,
+		Endpoint:   "http://localhost:9000",
+		AdminToken: "secret",
+		ScanSegments: 16,
+		ScanWorkers:  8,
+	}
+	if config.Backup != expected {
+		t.Errorf("Unexpected backup config: %+v", config.Backup)
+	}
+}
+
+func TestValidateConfig_Backup(t *testing.T) {
+	config := getDefaultConfig()
+	
+	if config.Backup.Bucket != "" || config.Backup.Prefix != "backups/" || !config.Backup.Gzip {
+		t.Errorf("Expected gzip backups under backups/ without a bucket by default, got %+v", config.Backup)
+	}
+	
+	config.Backup.AdminToken = "secret"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an admin token without a bucket")
+	}
+	
+	config.Backup.Bucket = "achievement-backups"
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected no validation error, got %v", err)
+	}
+
+	config.Backup.ScanSegments = -1
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for negative scan segments")
+	}
+}
+
+func TestLoadConfig_CircuitBreakerEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CIRCUIT_BREAKER_ENABLED", "false")
+	os.Setenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD", "10")
+	os.Setenv("CIRCUIT_BREAKER_OPEN_SECONDS", "60")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	expected := CircuitBreakerConfig{Enabled: false, FailureThreshold: 10, OpenSeconds: 60}
+	if config.CircuitBreaker != expected {
+		t.Errorf("Unexpected circuit breaker config: %+v", config.CircuitBreaker)
+	}
+}
+
+func TestValidateConfig_CircuitBreaker(t *testing.T) {
+	config := getDefaultConfig()
+	
+	if !config.CircuitBreaker.Enabled || config.CircuitBreaker.FailureThreshold != 5 || config.CircuitBreaker.OpenSeconds != 30 {
+		t.Errorf("Unexpected default circuit breaker config: %+v", config.CircuitBreaker)
+	}
+	
+	config.CircuitBreaker.FailureThreshold = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a zero failure threshold")
+	}
+	
+	config.CircuitBreaker.FailureThreshold = 5
+	config.CircuitBreaker.OpenSeconds = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for zero open seconds")
+	}
+	
+	// 無効の場合は検証しない
+	config.CircuitBreaker.Enabled = false
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected no validation error, got %v", err)
+	}
+}
+
+func TestLoadConfig_MaintenanceEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MAINTENANCE_READ_ONLY", "true")
+	os.Setenv("MAINTENANCE_ADMIN_TOKEN", "secret")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	expected := MaintenanceConfig{ReadOnly: true, AdminToken: "secret"}
+	if config.Maintenance != expected {
+		t.Errorf("Unexpected maintenance config: %+v", config.Maintenance)
+	}
+}
+
+func TestLoadConfig_EncryptionEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("ENCRYPTION_PROVIDER", "kms")
+	os.Setenv("ENCRYPTION_KMS_KEY_ID", "alias/achievements")
+	os.Setenv("ENCRYPTION_TABLES", "achievements, rewards")
+	
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	
+	if config.Encryption.Provider != EncryptionProviderKMS || config.Encryption.KMSKeyID != "alias/achievements" {
+		t.Errorf("Unexpected encryption config: %+v", config.Encryption)
+	}
+	if len(config.Encryption.Tables) != 2 || config.Encryption.Tables[0] != "achievements" || config.Encryption.Tables[1] != "rewards" {
+		t.Errorf("Expected tables [achievements rewards], got %v", config.Encryption.Tables)
+	}
+}
+
+func TestValidateConfig_Encryption(t *testing.T) {
+	config := getDefaultConfig()
+	
+	if config.Encryption.Provider != "" {
+		t.Errorf("Expected encryption to be disabled by default, got %+v", config.Encryption)
+	}
+	
+	config.Encryption.Provider = EncryptionProviderPassphrase
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a missing passphrase")
+	}
+	
+	config.Encryption.Passphrase = "secret"
+	config.Encryption.Tables = []string{"achievements"}
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected no validation error, got %v", err)
+	}
+	
+	config.Encryption.Tables = []string{"points"}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an unsupported table")
+	}
+	
+	config.Encryption.Tables = nil
+	config.Encryption.Provider = EncryptionProviderKMS
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a missing KMS key id")
+	}
+	
+	config.Encryption.Provider = "vault"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an invalid provider")
+	}
+}
+
+func TestLoadConfig_PointsEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Points.AdjustOnDelete {
+		t.Error("Expected points not to be adjusted on delete by default")
+	}
+	if !config.Points.AdjustOnUpdate {
+		t.Error("Expected points to be adjusted on update by default")
+	}
+	if config.Points.MaxPoint != 100000 {
+		t.Errorf("Expected default max point 100000, got %d", config.Points.MaxPoint)
+	}
+	
+	os.Setenv("POINTS_ADJUST_ON_DELETE", "true")
+	os.Setenv("POINTS_ADJUST_ON_UPDATE", "false")
+	os.Setenv("POINTS_MAX_POINT", "5000")
+	defer func() {
+		os.Clearenv()
+	}()
+	
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.Points.AdjustOnDelete {
+		t.Error("Expected POINTS_ADJUST_ON_DELETE to enable adjustment on delete")
+	}
+	if config.Points.AdjustOnUpdate {
+		t.Error("Expected POINTS_ADJUST_ON_UPDATE to disable adjustment on update")
+	}
+	if config.Points.MaxPoint != 5000 {
+		t.Errorf("Expected POINTS_MAX_POINT to set max point 5000, got %d", config.Points.MaxPoint)
+	}
+	
+	config.Points.MaxPoint = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a non-positive max point")
+	}
+}
+
+func TestLoadConfig_StreaksEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Streaks.Bonuses() != nil {
+		t.Errorf("Expected streak bonuses to be disabled by default, got %v", config.Streaks.Bonuses())
+	}
+	if config.Streaks.Location() != time.Local {
+		t.Errorf("Expected the local time zone by default, got %v", config.Streaks.Location())
+	}
+
+	os.Setenv("STREAKS_TIMEZONE", "Asia/Tokyo")
+	os.Setenv("STREAKS_BONUS_ENABLED", "true")
+	os.Setenv("STREAKS_MILESTONES", "3:5, 10:20")
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Streaks.Location().String() != "Asia/Tokyo" {
+		t.Errorf("Expected Asia/Tokyo, got %v", config.Streaks.Location())
+	}
+	bonuses := config.Streaks.Bonuses()
+	if len(bonuses) != 2 || bonuses[3] != 5 || bonuses[10] != 20 {
+		t.Errorf("Expected milestones 3:5 and 10:20, got %v", bonuses)
+	}
+}
+
+func TestValidateConfig_Streaks(t *testing.T) {
+	config := getDefaultConfig()
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected no validation error, got %v", err)
+	}
+
+	config.Streaks.Timezone = "Mars/Olympus"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an unknown time zone")
+	}
+
+	config.Streaks.Timezone = ""
+	config.Streaks.Milestones = map[int]int{1: 10}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a one-day milestone")
+	}
+
+	config.Streaks.Milestones = map[int]int{7: 0}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a zero bonus")
+	}
+}
+
+func TestLoadConfig_GoalsEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("GOALS_TABLE", "test-goals")
+	os.Setenv("GOALS_WEBHOOK_URLS", "https://example.com/goals, http://localhost:9000/goals")
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Tables.Goals != "test-goals" {
+		t.Errorf("Expected goals table test-goals, got %s", config.Tables.Goals)
+	}
+	if len(config.Goals.WebhookURLs) != 2 || config.Goals.WebhookURLs[0] != "https://example.com/goals" {
+		t.Errorf("Unexpected goal webhook URLs: %v", config.Goals.WebhookURLs)
+	}
+}
+
+func TestValidateConfig_Goals(t *testing.T) {
+	config := getDefaultConfig()
+	config.Goals.WebhookURLs = []string{"example.com/goals"}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for goal webhook URL without scheme")
+	}
+
+	config.Goals.WebhookURLs = nil
+	config.Tables.Goals = ""
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an empty goals table name")
+	}
+}
+
+func TestLoadConfig_WishlistEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("FAVORITES_TABLE", "test-favorites")
+	os.Setenv("WISHLIST_TABLE", "test-wishlist")
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Tables.Favorites != "test-favorites" {
+		t.Errorf("Expected favorites table test-favorites, got %s", config.Tables.Favorites)
+	}
+	if config.Tables.Wishlist != "test-wishlist" {
+		t.Errorf("Expected wishlist table test-wishlist, got %s", config.Tables.Wishlist)
+	}
+
+	config.Tables.Wishlist = ""
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an empty wishlist table name")
+	}
+}
+
+func TestLoadConfig_RefundsEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Refunds.Window() != 24*time.Hour {
+		t.Errorf("Expected default refund window of 24h, got %s", config.Refunds.Window())
+	}
+
+	os.Setenv("REFUNDS_WINDOW_HOURS", "2")
+	os.Setenv("REFUNDS_ADMIN_TOKEN", "refund-secret")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Refunds.Window() != 2*time.Hour {
+		t.Errorf("Expected refund window of 2h, got %s", config.Refunds.Window())
+	}
+	if config.Refunds.AdminToken != "refund-secret" {
+		t.Errorf("Expected refund admin token refund-secret, got %s", config.Refunds.AdminToken)
+	}
+
+	config.Refunds.WindowHours = -1
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a negative refund window")
+	}
+}
+
+func TestLoadConfig_NotesEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("NOTES_TABLE", "test-notes")
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Tables.Notes != "test-notes" {
+		t.Errorf("Expected notes table test-notes, got %s", config.Tables.Notes)
+	}
+
+	config.Tables.Notes = ""
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an empty notes table name")
+	}
+}
+
+func TestLoadConfig_AttachmentsEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Attachments.Bucket != "" {
+		t.Errorf("Expected attachments to be disabled by default, got bucket %s", config.Attachments.Bucket)
+	}
+	if config.Attachments.UploadExpiry() != 15*time.Minute || config.Attachments.DownloadExpiry() != time.Hour {
+		t.Errorf("Expected default expiries of 15m and 1h, got %s and %s",
+			config.Attachments.UploadExpiry(), config.Attachments.DownloadExpiry())
+	}
+
+	os.Setenv("ATTACHMENTS_BUCKET", "receipts")
+	os.Setenv("ATTACHMENTS_PREFIX", "files/")
+	os.Setenv("ATTACHMENTS_S3_ENDPOINT", "http://localhost:9000")
+	os.Setenv("ATTACHMENTS_UPLOAD_EXPIRY_MINUTES", "5")
+	os.Setenv("ATTACHMENTS_DOWNLOAD_EXPIRY_MINUTES", "30")
+
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Attachments.Bucket != "receipts" || config.Attachments.Prefix != "files/" {
+		t.Errorf("Expected bucket receipts and prefix files/, got %s and %s", config.Attachments.Bucket, config.Attachments.Prefix)
+	}
+	if config.Attachments.Endpoint != "http://localhost:9000" {
+		t.Errorf("Expected attachments endpoint http://localhost:9000, got %s", config.Attachments.Endpoint)
+	}
+	if config.Attachments.UploadExpiry() != 5*time.Minute || config.Attachments.DownloadExpiry() != 30*time.Minute {
+		t.Errorf("Expected expiries of 5m and 30m, got %s and %s",
+			config.Attachments.UploadExpiry(), config.Attachments.DownloadExpiry())
+	}
+
+	config.Attachments.DownloadExpiryMinutes = 7*24*60 + 1
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a download expiry longer than 7 days")
+	}
+}
+
+func TestLoadConfig_RemindersEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Reminders.Enabled {
+		t.Error("Expected reminders to be disabled by default")
+	}
+	if config.Reminders.Schedule != "0 20 * * *" {
+		t.Errorf("Expected default reminders schedule 0 20 * * *, got %s", config.Reminders.Schedule)
+	}
+
+	os.Setenv("REMINDERS_ENABLED", "true")
+	os.Setenv("REMINDERS_SCHEDULE", "30 7 * * 1-5")
+	os.Setenv("REMINDERS_WEBHOOK_URLS", "https://example.com/hook")
+	os.Setenv("REMINDERS_TENANTS", "default, team-a")
+
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.Reminders.Enabled || config.Reminders.Schedule != "30 7 * * 1-5" {
+		t.Errorf("Expected reminders enabled at 30 7 * * 1-5, got %v and %s", config.Reminders.Enabled, config.Reminders.Schedule)
+	}
+	if len(config.Reminders.WebhookURLs) != 1 || config.Reminders.WebhookURLs[0] != "https://example.com/hook" {
+		t.Errorf("Expected one reminders webhook url, got %v", config.Reminders.WebhookURLs)
+	}
+	if len(config.Reminders.Tenants) != 2 || config.Reminders.Tenants[1] != "team-a" {
+		t.Errorf("Expected tenants default and team-a, got %v", config.Reminders.Tenants)
+	}
+
+	config.Reminders.Schedule = "0 25 * * *"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an invalid reminders schedule")
+	}
+}
+
+func TestLoadConfig_SummariesEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Summaries.Enabled {
+		t.Error("Expected summaries to be disabled by default")
+	}
+	if config.Summaries.DailySchedule != "0 8 * * *" || config.Summaries.WeeklySchedule != "0 8 * * 1" {
+		t.Errorf("Expected default schedules 0 8 * * * and 0 8 * * 1, got %s and %s",
+			config.Summaries.DailySchedule, config.Summaries.WeeklySchedule)
+	}
+
+	os.Setenv("SUMMARIES_ENABLED", "true")
+	os.Setenv("SUMMARIES_DAILY_SCHEDULE", "30 7 * * *")
+	os.Setenv("SUMMARIES_WEEKLY_SCHEDULE", "0 9 * * 0")
+	os.Setenv("SUMMARIES_WEBHOOK_URLS", "https://example.com/summaries")
+	os.Setenv("SUMMARIES_TENANTS", "team-a")
+
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.Summaries.Enabled || config.Summaries.DailySchedule != "30 7 * * *" || config.Summaries.WeeklySchedule != "0 9 * * 0" {
+		t.Errorf("Unexpected summaries config: %+v", config.Summaries)
+	}
+	if len(config.Summaries.WebhookURLs) != 1 || len(config.Summaries.Tenants) != 1 || config.Summaries.Tenants[0] != "team-a" {
+		t.Errorf("Unexpected summaries webhook urls or tenants: %+v", config.Summaries)
+	}
+
+	// 空のスケジュールはその期間を配信しない
+	config.Summaries.WeeklySchedule = ""
+	if err := validateConfig(config); err != nil {
+		t.Errorf("Expected an empty weekly schedule to be valid, got %v", err)
+	}
+	config.Summaries.DailySchedule = "daily"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an invalid summaries schedule")
+	}
+}
+
+func TestLoadConfig_ConsistencyEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Consistency.Enabled {
+		t.Error("Expected the consistency checker to be disabled by default")
+	}
+	if config.Consistency.Interval() != time.Hour || config.Consistency.Threshold != 0 {
+		t.Errorf("Expected an hourly check with threshold 0, got %v and %d", config.Consistency.Interval(), config.Consistency.Threshold)
+	}
+	if config.Tables.DriftEvents != "drift_events" {
+		t.Errorf("Expected default drift events table drift_events, got %s", config.Tables.DriftEvents)
+	}
+
+	os.Setenv("CONSISTENCY_ENABLED", "true")
+	os.Setenv("CONSISTENCY_INTERVAL_MINUTES", "15")
+	os.Setenv("CONSISTENCY_THRESHOLD", "10")
+	os.Setenv("CONSISTENCY_WEBHOOK_URLS", "https://example.com/drift")
+	os.Setenv("CONSISTENCY_TENANTS", "team-a,team-b")
+	os.Setenv("DRIFT_EVENTS_TABLE", "custom-drift-events")
+
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.Consistency.Enabled || config.Consistency.Interval() != 15*time.Minute || config.Consistency.Threshold != 10 {
+		t.Errorf("Unexpected consistency config: %+v", config.Consistency)
+	}
+	if len(config.Consistency.WebhookURLs) != 1 || len(config.Consistency.Tenants) != 2 || config.Tables.DriftEvents != "custom-drift-events" {
+		t.Errorf("Unexpected consistency webhook urls, tenants or table: %+v / %s", config.Consistency, config.Tables.DriftEvents)
+	}
+
+	config.Consistency.IntervalMinutes = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a zero consistency interval")
+	}
+	config.Consistency.IntervalMinutes = 15
+	config.Consistency.Threshold = -1
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a negative consistency threshold")
+	}
+}
+
+func TestLoadConfig_BulkEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer func() {
+		os.Clearenv()
+	}()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Bulk.Parallelism != 4 || config.Bulk.MaxItems != 100 {
+		t.Errorf("Expected parallelism 4 and max items 100 by default, got %+v", config.Bulk)
+	}
+
+	os.Setenv("BULK_PARALLELISM", "8")
+	os.Setenv("BULK_MAX_ITEMS", "500")
+
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Bulk.Parallelism != 8 || config.Bulk.MaxItems != 500 {
+		t.Errorf("Unexpected bulk config: %+v", config.Bulk)
+	}
+
+	config.Bulk.Parallelism = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for zero bulk parallelism")
+	}
+	config.Bulk.Parallelism = 8
+	config.Bulk.MaxItems = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for zero bulk max items")
+	}
+}
+
+func TestLoadConfig_LogRotationEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("LOG_MAX_SIZE_MB", "50")
+	os.Setenv("LOG_ROTATE_INTERVAL_HOURS", "24")
+	os.Setenv("LOG_MAX_BACKUPS", "7")
+	os.Setenv("LOG_MAX_AGE_DAYS", "30")
+	os.Setenv("LOG_COMPRESS", "true")
+	defer os.Clearenv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := LogRotationConfig{MaxSizeMB: 50, IntervalHours: 24, MaxBackups: 7, MaxAgeDays: 30, Compress: true}
+	if config.Logging.Rotation != expected {
+		t.Errorf("Expected rotation %+v, got %+v", expected, config.Logging.Rotation)
+	}
+}
+
+func TestValidateConfig_LogRotation(t *testing.T) {
+	config := getDefaultConfig()
+
+	if config.Logging.Rotation.MaxSizeMB != 100 || config.Logging.Rotation.MaxBackups != 0 {
+		t.Errorf("Unexpected default log rotation config: %+v", config.Logging.Rotation)
+	}
+
+	config.Logging.Rotation.MaxBackups = -1
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for negative max backups")
+	}
+}
+
+func TestLoadConfig_AccessLogEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(config.Logging.Access.ExcludePaths, ",") != "/health,/metrics" {
+		t.Errorf("Expected /health and /metrics to be excluded by default, got %v", config.Logging.Access.ExcludePaths)
+	}
+
+	os.Setenv("LOG_ACCESS_EXCLUDE_PATHS", "")
+	os.Setenv("LOG_ACCESS_SAMPLE", "/api/achievements/:id=10, /api/points/current=5")
+	os.Setenv("LOGGING_ADMIN_TOKEN", "log-secret")
+	os.Setenv("LOG_ACCESS_FORMAT", "json")
+	os.Setenv("LOG_ACCESS_OUTPUT", "/var/log/achievement/access.log")
+	os.Setenv("LOG_ERROR_FORMAT", "text")
+	os.Setenv("LOG_ERROR_OUTPUT", "stderr")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Logging.AdminToken != "log-secret" {
+		t.Errorf("Expected logging admin token from LOGGING_ADMIN_TOKEN, got %q", config.Logging.AdminToken)
+	}
+	if config.Logging.Access.Output != "/var/log/achievement/access.log" || config.Logging.Access.Format != "json" {
+		t.Errorf("Unexpected access log output: %+v", config.Logging.Access)
+	}
+	if config.Logging.Error.Output != "stderr" || config.Logging.Error.Format != "text" {
+		t.Errorf("Unexpected error log output: %+v", config.Logging.Error)
+	}
+	if len(config.Logging.Access.ExcludePaths) != 0 {
+		t.Errorf("Expected an empty LOG_ACCESS_EXCLUDE_PATHS to log every path, got %v", config.Logging.Access.ExcludePaths)
+	}
+	if config.Logging.Access.Sample["/api/achievements/:id"] != 10 || config.Logging.Access.Sample["/api/points/current"] != 5 {
+		t.Errorf("Unexpected access log sample: %v", config.Logging.Access.Sample)
+	}
+
+	config.Logging.Access.Sample["/api/rewards"] = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a zero sample rate")
+	}
+	delete(config.Logging.Access.Sample, "/api/rewards")
+	config.Logging.Error.Format = "xml"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an unknown error log format")
+	}
+}
+
+func TestLoadConfig_ErrorReportingEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("ERROR_REPORTING_DSN", "https://public@sentry.example.com/42")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.ErrorReporting.DSN != "https://public@sentry.example.com/42" {
+		t.Errorf("Unexpected error reporting dsn: %s", config.ErrorReporting.DSN)
+	}
+	for _, setting := range config.Settings() {
+		if setting.Key == "error_reporting.dsn" && setting.Value != MaskedValue {
+			t.Errorf("Expected the dsn to be masked, got %v", setting.Value)
+		}
+	}
+
+	for _, dsn := range []string{"sentry.example.com/42", "https://sentry.example.com/42", "https://public@sentry.example.com"} {
+		config.ErrorReporting.DSN = dsn
+		if err := validateConfig(config); err == nil {
+			t.Errorf("Expected validation error for dsn %s", dsn)
+		}
+	}
+}
+
+func TestParseDotEnv(t *testing.T) {
+	data := "# ローカル設定\n\nexport STORAGE_DRIVER=sqlite\nSERVER_PORT = 9090 # コメント\nAPP_NAME=\"Achievement \\\"App\\\"\\nDev\"\nLOG_FORMAT='text # not a comment'\nEMPTY=\n"
+	vars, err := parseDotEnv([]byte(data))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []dotEnvVar{
+		{key: "STORAGE_DRIVER", value: "sqlite"},
+		{key: "SERVER_PORT", value: "9090"},
+		{key: "APP_NAME", value: "Achievement \"App\"\nDev"},
+		{key: "LOG_FORMAT", value: "text # not a comment"},
+		{key: "EMPTY", value: ""},
+	}
+	if len(vars) != len(expected) {
+		t.Fatalf("Expected %d variables, got %+v", len(expected), vars)
+	}
+	for i, v := range expected {
+		if vars[i] != v {
+			t.Errorf("Expected %+v, got %+v", v, vars[i])
+		}
+	}
+
+	for _, invalid := range []string{"NO_EQUALS", "1KEY=value", "KEY=\"unterminated", "KEY='value' trailing"} {
+		if _, err := parseDotEnv([]byte(invalid)); err == nil {
+			t.Errorf("Expected parse error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadConfig_DotEnv(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalWd)
+
+	os.MkdirAll("config", 0755)
+	if err := os.WriteFile("config/development.yml", []byte("server:\n  port: \"7070\"\n  read_timeout: 15\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(DotEnvFile, []byte("SERVER_PORT=9090\nLOG_LEVEL=debug\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("LOG_LEVEL", "warn")
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 設定ファイル < .env < 環境変数
+	if config.Server.Port != "9090" {
+		t.Errorf("Expected .env to override the config file port, got %s", config.Server.Port)
+	}
+	if config.Server.ReadTimeout != 15 {
+		t.Errorf("Expected read timeout 15 from the config file, got %d", config.Server.ReadTimeout)
+	}
+	if config.Logging.Level != "warn" {
+		t.Errorf("Expected the environment variable to override .env, got %s", config.Logging.Level)
+	}
+	if config.EnvFilePath() != DotEnvFile {
+		t.Errorf("Expected env file path %s, got %s", DotEnvFile, config.EnvFilePath())
+	}
+	if config.sourceOf("server.port") != SourceEnv {
+		t.Errorf("Expected .env values to be reported as env, got %s", config.sourceOf("server.port"))
+	}
+
+	if err := os.WriteFile(DotEnvFile, []byte("SERVER_PORT\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected error for an invalid .env file")
+	}
+}
+
+func TestLoadConfig_ServerModeEnvironmentVariable(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("SERVER_MODE", "release")
+	os.Setenv("LOG_LEVEL", "debug")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Server.Mode != "release" || config.Logging.Level != "debug" {
+		t.Errorf("Expected release mode with debug logs, got mode %s and level %s", config.Server.Mode, config.Logging.Level)
+	}
+
+	config.Server.Mode = "verbose"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an unknown server mode")
+	}
+}
+
+func TestLoadConfig_AuditLogEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Logging.Audit.Enabled || config.Logging.Audit.Output != "audit.log" || config.Logging.Audit.ActorHeader != "X-Actor-ID" {
+		t.Errorf("Unexpected default audit log config: %+v", config.Logging.Audit)
+	}
+
+	os.Setenv("LOG_AUDIT_ENABLED", "true")
+	os.Setenv("LOG_AUDIT_OUTPUT", "/var/log/achievement/audit.log")
+	os.Setenv("LOG_AUDIT_ACTOR_HEADER", "X-User")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.Logging.Audit.Enabled || config.Logging.Audit.Output != "/var/log/achievement/audit.log" || config.Logging.Audit.ActorHeader != "X-User" {
+		t.Errorf("Unexpected audit log config: %+v", config.Logging.Audit)
+	}
+
+	config.Logging.Audit.Output = ""
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an enabled audit log without output")
+	}
+}
+
+func TestLoadConfig_HealthCheckEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.HealthCheck.CheckDynamoDB || config.HealthCheck.Timeout() != 2*time.Second || config.HealthCheck.CacheTTL() != 5*time.Second {
+		t.Errorf("Unexpected default health check config: %+v", config.HealthCheck)
+	}
+
+	os.Setenv("HEALTH_CHECK_DYNAMODB", "true")
+	os.Setenv("HEALTH_CHECK_TIMEOUT_MS", "500")
+	os.Setenv("HEALTH_CHECK_CACHE_TTL_SECONDS", "0")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.HealthCheck.CheckDynamoDB || config.HealthCheck.Timeout() != 500*time.Millisecond || config.HealthCheck.CacheTTL() != 0 {
+		t.Errorf("Expected health check config from environment variables, got %+v", config.HealthCheck)
+	}
+
+	config.HealthCheck.TimeoutMs = 0
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for a zero health check timeout")
+	}
+}
+
+func TestLoadConfig_SecurityEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Security.ContentSecurityPolicy != "default-src 'self'; frame-ancestors 'none'" {
+		t.Errorf("Unexpected default content security policy: %q", config.Security.ContentSecurityPolicy)
+	}
+
+	os.Setenv("SECURITY_CONTENT_SECURITY_POLICY", "default-src 'self'; img-src 'self' data:")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Security.ContentSecurityPolicy != "default-src 'self'; img-src 'self' data:" {
+		t.Errorf("Expected content security policy from environment variable, got %q", config.Security.ContentSecurityPolicy)
+	}
+
+	os.Setenv("SECURITY_ALLOWED_CIDRS", "192.168.1.0/24, 10.0.0.5")
+	os.Setenv("SECURITY_DENIED_CIDRS", "192.168.1.66")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	allowed := config.Security.AllowedNetworks()
+	if len(allowed) != 2 || allowed[0].String() != "192.168.1.0/24" || allowed[1].String() != "10.0.0.5/32" {
+		t.Errorf("Unexpected allowed networks: %v", allowed)
+	}
+	if denied := config.Security.DeniedNetworks(); len(denied) != 1 || denied[0].String() != "192.168.1.66/32" {
+		t.Errorf("Unexpected denied networks: %v", denied)
+	}
+
+	config.Security.AllowedCIDRs = []string{"192.168.1.0/33"}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected validation error for an invalid cidr")
+	}
+}
+
+func TestLoadConfig_WebhookSigningEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("WEBHOOK_SIGNING_SECRET", "shared-secret")
+	os.Setenv("WEBHOOK_TOLERANCE_SECONDS", "60")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Webhooks.Tolerance() != time.Minute {
+		t.Errorf("Expected tolerance of 1 minute, got %v", config.Webhooks.Tolerance())
+	}
+
+	config.Webhooks.EndpointSecrets = map[string]string{"https://hooks.example.com/a": "endpoint-secret"}
+	if secret := config.Webhooks.SecretFor("https://hooks.example.com/a"); secret != "endpoint-secret" {
+		t.Errorf("Expected endpoint secret, got %q", secret)
+	}
+	if secret := config.Webhooks.SecretFor("https://hooks.example.com/b"); secret != "shared-secret" {
+		t.Errorf("Expected shared secret, got %q", secret)
+	}
+}
+
+func TestLoadConfig_APIKeysEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("API_KEYS_TABLE", "custom-api-keys")
+	os.Setenv("API_KEYS_REQUIRED", "true")
+	os.Setenv("API_KEYS_ADMIN_TOKEN", "admin-secret")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Tables.APIKeys != "custom-api-keys" {
+		t.Errorf("Expected custom-api-keys, got %s", config.Tables.APIKeys)
+	}
+	if !config.APIKeys.Required || config.APIKeys.AdminToken != "admin-secret" {
+		t.Errorf("Expected required API keys with an admin token, got %+v", config.APIKeys)
+	}
+}
+
+func TestLoadConfig_RateLimitEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("RATE_LIMIT_ENABLED", "true")
+	os.Setenv("RATE_LIMIT_REQUESTS_PER_MINUTE", "60")
+	os.Setenv("RATE_LIMIT_TIERS", "partner=600, internal=0")
+	os.Setenv("RATE_LIMIT_API_KEY_TIERS", "01J8ZK=partner")
+	os.Setenv("RATE_LIMIT_ACTOR_HEADER", "X-Forwarded-User")
+	os.Setenv("RATE_LIMIT_ACTOR_TIERS", "alice=internal")
+	os.Setenv("SECURITY_TRUSTED_PROXIES", "10.0.0.0/8")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.RateLimit.Enabled || config.RateLimit.ActorHeader != "X-Forwarded-User" {
+		t.Errorf("Expected rate limits enabled with an actor header, got %+v", config.RateLimit)
+	}
+	if limit := config.RateLimit.Limit(config.RateLimit.APIKeyTiers["01J8ZK"]); limit != 600 {
+		t.Errorf("Expected 600 requests for the partner tier, got %d", limit)
+	}
+	if limit := config.RateLimit.Limit(config.RateLimit.ActorTiers["alice"]); limit != 0 {
+		t.Errorf("Expected no limit for the internal tier, got %d", limit)
+	}
+	if limit := config.RateLimit.Limit(""); limit != 60 {
+		t.Errorf("Expected 60 requests without a tier, got %d", limit)
+	}
+
+	os.Setenv("RATE_LIMIT_API_KEY_TIERS", "01J8ZK=gold")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for an undefined tier")
+	}
+	os.Setenv("RATE_LIMIT_API_KEY_TIERS", "01J8ZK=partner")
+
+	os.Unsetenv("SECURITY_TRUSTED_PROXIES")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for an actor header without trusted proxies")
+	}
+}
+
+func TestLoadConfig_NotificationsEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("SLACK_WEBHOOK_URL", "https://hooks.slack.com/services/T000/B000/XXXX")
+	os.Setenv("SLACK_CHANNEL", "#kudos")
+	os.Setenv("SLACK_EVENTS", "achievements.created, rewards.redeemed")
+	os.Setenv("NOTIFICATIONS_LOW_BALANCE_THRESHOLD", "100")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Notifications.Slack.Channel != "#kudos" || len(config.Notifications.Slack.Events) != 2 {
+		t.Errorf("Expected slack notifications for two events in #kudos, got %+v", config.Notifications.Slack)
+	}
+	if config.Notifications.LowBalanceThreshold != 100 {
+		t.Errorf("Expected low balance threshold 100, got %d", config.Notifications.LowBalanceThreshold)
+	}
+
+	os.Setenv("SLACK_EVENTS", "achievements.reminder")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for an unsupported notification event")
+	}
+}
+
+func TestLoadConfig_DiscordEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	publicKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	os.Setenv("DISCORD_WEBHOOK_URL", "https://discord.com/api/webhooks/1/token")
+	os.Setenv("DISCORD_EVENTS", "rewards.redeemed")
+	os.Setenv("DISCORD_PUBLIC_KEY", publicKey)
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(config.Notifications.Discord.Events) != 1 || config.Notifications.Discord.VerifyKey() == nil {
+		t.Errorf("Expected discord notifications with a public key, got %+v", config.Notifications.Discord)
+	}
+
+	os.Setenv("DISCORD_PUBLIC_KEY", "not-a-key")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for an invalid discord public key")
+	}
+}
+
+func TestLoadConfig_EmailEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("EMAIL_PROVIDER", "smtp")
+	os.Setenv("EMAIL_FROM", "Achievements <app@example.com>")
+	os.Setenv("EMAIL_TO", "alice@example.com, bob@example.com")
+	os.Setenv("EMAIL_EVENTS", "goals.reached,summaries.generated")
+	os.Setenv("SMTP_HOST", "smtp.example.com")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	email := config.Notifications.Email
+	if email.SMTPPort != 587 || len(email.To) != 2 || len(email.Events) != 2 {
+		t.Errorf("Expected smtp on port 587 to two recipients, got %+v", email)
+	}
+
+	os.Unsetenv("SMTP_HOST")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error without an smtp host")
+	}
+
+	os.Setenv("EMAIL_PROVIDER", "ses")
+	os.Setenv("EMAIL_TO", "not an address")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for an invalid recipient")
+	}
+}
+
+func TestLoadConfig_EventBridgeEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("EVENTBRIDGE_BUS_NAME", "family")
+	os.Setenv("EVENTBRIDGE_DETAIL_TYPE_PREFIX", "AchievementManagement.")
+	os.Setenv("EVENTBRIDGE_EVENTS", "achievements.created,rewards.redeemed")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	eventBridge := config.Notifications.EventBridge
+	if eventBridge.BusName != "family" || eventBridge.Source != "achievement-management" || eventBridge.DetailTypePrefix != "AchievementManagement." || len(eventBridge.Events) != 2 {
+		t.Errorf("Expected the family bus with the default source, got %+v", eventBridge)
+	}
+
+	os.Setenv("EVENTBRIDGE_EVENTS", "achievements.deleted")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for an unsupported eventbridge event")
+	}
+}
+
+func TestLoadConfig_SNSAndSQSEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("SNS_TOPIC_ARN", "arn:aws:sns:ap-northeast-1:123456789012:events")
+	os.Setenv("SQS_QUEUE_URL", "https://sqs.ap-northeast-1.amazonaws.com/123456789012/events")
+	os.Setenv("SQS_DEAD_LETTER_QUEUE_URL", "https://sqs.ap-northeast-1.amazonaws.com/123456789012/events-dlq")
+	os.Setenv("NOTIFICATIONS_BATCH_SIZE", "5")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	notifications := config.Notifications
+	if notifications.SNS.TopicARN == "" || notifications.SQS.DeadLetterQueueURL == "" || notifications.BatchSize != 5 || notifications.BatchWaitMs != 1000 {
+		t.Errorf("Expected sns and sqs with a batch of 5, got %+v", notifications)
+	}
+
+	os.Setenv("NOTIFICATIONS_BATCH_SIZE", "11")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for a batch larger than 10")
+	}
+
+	os.Setenv("NOTIFICATIONS_BATCH_SIZE", "10")
+	os.Setenv("SNS_TOPIC_ARN", "events")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for an invalid topic arn")
+	}
+}
+
+func TestLoadConfig_CalendarEnvironmentVariables(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	os.Setenv("CALENDAR_TOKEN", "0123456789abcdef")
+	os.Setenv("CALENDAR_TENANT_TOKENS", "family-a=fedcba9876543210, family-b=aaaabbbbccccdddd")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tokens := config.Calendar.Tokens()
+	if len(tokens) != 3 || tokens["0123456789abcdef"] != "default" || tokens["fedcba9876543210"] != "family-a" {
+		t.Errorf("Expected tokens for the default tenant and both families, got %v", tokens)
+	}
+
+	os.Setenv("CALENDAR_TOKEN", "short")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for a short token")
+	}
+
+	os.Setenv("CALENDAR_TOKEN", "fedcba9876543210")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected validation error for a token shared by two tenants")
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DotEnvFile ローカル開発用に環境変数を記載するファイル（カレントディレクトリから読み込む）
+const DotEnvFile = ".env"
+
+// loadDotEnv .env ファイルがある場合は、記載された環境変数のうち未設定のものを設定する
+//
+// 設定済みの環境変数は上書きしないため、優先順位は 設定ファイル < .env < 環境変数 になる。
+// ファイルが無い場合は何もせず空のパスを返す。
+func loadDotEnv(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	vars, err := parseDotEnv(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, v := range vars {
+		if _, ok := os.LookupEnv(v.key); ok {
+			continue
+		}
+		if err := os.Setenv(v.key, v.value); err != nil {
+			return "", fmt.Errorf("failed to set %s from %s: %w", v.key, path, err)
+		}
+	}
+	return path, nil
+}
+
+// dotEnvVar .env ファイルの1行
+type dotEnvVar struct {
+	key   string
+	value string
+}
+
+// parseDotEnv .env ファイルの KEY=VALUE を記載順に返す
+//
+// 空行と # で始まる行は無視し、行頭の export は取り除く。値はダブルクォート（\n などのエスケープを展開）と
+// シングルクォート（そのまま）で囲める。囲まない値は前後の空白と、空白に続く # 以降のコメントを取り除く。
+func parseDotEnv(data []byte) ([]dotEnvVar, error) {
+	var vars []dotEnvVar
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !validEnvKey(key) {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+
+		value, err := dotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		vars = append(vars, dotEnvVar{key: key, value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// dotEnvValue クォートとコメントを取り除いた値
+func dotEnvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch quote := raw[0]; quote {
+	case '"', '\'':
+		end := strings.IndexByte(raw[1:], quote)
+		for quote == '"' && end > 0 && raw[end] == '\\' {
+			// エスケープしたダブルクォートは値に含める
+			next := strings.IndexByte(raw[end+2:], quote)
+			if next < 0 {
+				end = -1
+				break
+			}
+			end += next + 1
+		}
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		value := raw[1 : end+1]
+		if rest := strings.TrimSpace(raw[end+2:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected characters after quoted value")
+		}
+		if quote == '"' {
+			value = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value)
+		}
+		return value, nil
+	}
+
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = raw[:i]
+	}
+	return strings.TrimSpace(raw), nil
+}
+
+// validEnvKey 環境変数名として使える名前か（英数字とアンダースコア、先頭は数字以外）
+func validEnvKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, r := range key {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
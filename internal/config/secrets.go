@@ -0,0 +1,235 @@
+package config
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// EncryptedPrefix 設定ファイルに暗号化して記載した値の先頭（enc:passphrase:... または enc:kms:...）
+const EncryptedPrefix = "enc:"
+
+// PassphraseEnv 設定値の暗号鍵を導出するパスフレーズを受け取る環境変数（設定ファイルには記載できない）
+const PassphraseEnv = "CONFIG_PASSPHRASE"
+
+// 設定値の暗号化の方法
+const (
+	// SecretProviderPassphrase CONFIG_PASSPHRASE から導出した鍵で暗号化する
+	SecretProviderPassphrase = "passphrase"
+	// SecretProviderKMS KMSキーで直接暗号化する（復号にキーのIDは不要）
+	SecretProviderKMS = "kms"
+)
+
+const (
+	// secretPassphraseSalt パスフレーズから鍵を導出する際のソルト（属性の暗号化とは別の鍵にする）
+	secretPassphraseSalt = "achievement-management/config/v1"
+	// secretPassphraseIterations PBKDF2の繰り返し回数（読み込みごとに1回だけ導出する）
+	secretPassphraseIterations = 600000
+)
+
+// KMSAPI 設定値の暗号化・復号で使用するKMSの操作
+type KMSAPI interface {
+	Encrypt(ctx context.Context, params *kms.EncryptInput, optFns ...func(*kms.Options)) (*kms.EncryptOutput, error)
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// newKMSClient 設定値の暗号化・復号で使用するKMSクライアントを作成
+//
+// aws.access_key_id などの認証情報も暗号化できるように、認証情報は設定値ではなく aws.profile または
+// 既定の認証情報（環境変数・インスタンスロールなど）から取得する。
+var newKMSClient = func(ctx context.Context, c *Config) (KMSAPI, error) {
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(c.AWS.Region)}
+	if c.AWS.Profile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(c.AWS.Profile))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config for KMS: %w", err)
+	}
+	return kms.NewFromConfig(awsCfg), nil
+}
+
+// secretDecrypter 設定値を復号する（鍵とKMSクライアントは必要になった時に1回だけ用意する）
+type secretDecrypter struct {
+	config *Config
+	key    []byte
+	kms    KMSAPI
+}
+
+// decryptSecrets enc: で始まる設定値を復号する（文字列と、リスト・マップの文字列の要素が対象）
+func decryptSecrets(ctx context.Context, c *Config) error {
+	decrypter := &secretDecrypter{config: c}
+	var errs []string
+	walkSettings(reflect.ValueOf(c).Elem(), "", func(key string, value reflect.Value) {
+		switch {
+		case value.Kind() == reflect.String:
+			if err := decrypter.decryptValue(ctx, value); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+			}
+		case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+			for i := 0; i < value.Len(); i++ {
+				if err := decrypter.decryptValue(ctx, value.Index(i)); err != nil {
+					errs = append(errs, fmt.Sprintf("%s[%d]: %v", key, i, err))
+				}
+			}
+		case value.Kind() == reflect.Map && value.Type().Elem().Kind() == reflect.String:
+			// マップの値は直接書き換えられないため、復号した値で置き換える
+			iter := value.MapRange()
+			for iter.Next() {
+				element := reflect.New(value.Type().Elem()).Elem()
+				element.Set(iter.Value())
+				if err := decrypter.decryptValue(ctx, element); err != nil {
+					errs = append(errs, fmt.Sprintf("%s[%v]: %v", key, iter.Key(), err))
+					continue
+				}
+				value.SetMapIndex(iter.Key(), element)
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to decrypt config values: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// decryptValue 暗号化した値であれば復号して置き換える
+func (d *secretDecrypter) decryptValue(ctx context.Context, value reflect.Value) error {
+	if !strings.HasPrefix(value.String(), EncryptedPrefix) {
+		return nil
+	}
+	plaintext, err := d.decrypt(ctx, value.String())
+	if err != nil {
+		return err
+	}
+	value.SetString(plaintext)
+	return nil
+}
+
+// decrypt enc:{provider}:{暗号文} の値を復号
+func (d *secretDecrypter) decrypt(ctx context.Context, value string) (string, error) {
+	provider, encoded, ok := strings.Cut(strings.TrimPrefix(value, EncryptedPrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+
+	switch provider {
+	case SecretProviderPassphrase:
+		if d.key == nil {
+			if d.key, err = secretKey(os.Getenv(PassphraseEnv)); err != nil {
+				return "", err
+			}
+		}
+		return openSecret(d.key, ciphertext)
+	case SecretProviderKMS:
+		if d.kms == nil {
+			if d.kms, err = newKMSClient(ctx, d.config); err != nil {
+				return "", err
+			}
+		}
+		resp, err := d.kms.Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: ciphertext})
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt with KMS: %w", err)
+		}
+		return string(resp.Plaintext), nil
+	default:
+		return "", fmt.Errorf("unsupported encryption provider: %s", provider)
+	}
+}
+
+// EncryptSecret 設定ファイルに記載する暗号化した値を作成
+//
+// kmsKeyID を指定した場合はKMSキーで、指定しない場合は CONFIG_PASSPHRASE から導出した鍵で暗号化する。
+func EncryptSecret(ctx context.Context, c *Config, plaintext, kmsKeyID string) (string, error) {
+	if kmsKeyID != "" {
+		client, err := newKMSClient(ctx, c)
+		if err != nil {
+			return "", err
+		}
+		resp, err := client.Encrypt(ctx, &kms.EncryptInput{KeyId: aws.String(kmsKeyID), Plaintext: []byte(plaintext)})
+		if err != nil {
+			return "", fmt.Errorf("failed to encrypt with %s: %w", kmsKeyID, err)
+		}
+		return encodeSecret(SecretProviderKMS, resp.CiphertextBlob), nil
+	}
+
+	key, err := secretKey(os.Getenv(PassphraseEnv))
+	if err != nil {
+		return "", err
+	}
+	sealed, err := sealSecret(key, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return encodeSecret(SecretProviderPassphrase, sealed), nil
+}
+
+// encodeSecret 暗号文を enc:{provider}:{暗号文} の形式にまとめる
+func encodeSecret(provider string, ciphertext []byte) string {
+	return EncryptedPrefix + provider + ":" + base64.RawStdEncoding.EncodeToString(ciphertext)
+}
+
+// secretKey パスフレーズから設定値の暗号鍵を導出
+func secretKey(passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("%s is required to use passphrase encrypted values", PassphraseEnv)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, []byte(secretPassphraseSalt), secretPassphraseIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive config encryption key: %w", err)
+	}
+	return key, nil
+}
+
+// sealSecret AES-256-GCMで暗号化し、nonce を先頭に付けて返す
+func sealSecret(key, plaintext []byte) ([]byte, error) {
+	aead, err := secretGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openSecret sealSecret した値を復号
+func openSecret(key, sealed []byte) (string, error) {
+	aead, err := secretGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value (wrong %s?): %w", PassphraseEnv, err)
+	}
+	return string(plaintext), nil
+}
+
+func secretGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
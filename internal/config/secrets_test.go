@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// fakeKMS KMSキーの代わりに値の前後に印を付けるKMS
+type fakeKMS struct{}
+
+func (fakeKMS) Encrypt(ctx context.Context, params *kms.EncryptInput, optFns ...func(*kms.Options)) (*kms.EncryptOutput, error) {
+	return &kms.EncryptOutput{CiphertextBlob: []byte("kms(" + string(params.Plaintext) + ")")}, nil
+}
+
+func (fakeKMS) Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	blob := string(params.CiphertextBlob)
+	if !strings.HasPrefix(blob, "kms(") || !strings.HasSuffix(blob, ")") {
+		return nil, fmt.Errorf("invalid ciphertext")
+	}
+	return &kms.DecryptOutput{Plaintext: []byte(blob[4 : len(blob)-1])}, nil
+}
+
+func TestLoadConfig_EncryptedValues(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+	defer SetConfigFile("")
+	defer func(original func(context.Context, *Config) (KMSAPI, error)) { newKMSClient = original }(newKMSClient)
+	newKMSClient = func(context.Context, *Config) (KMSAPI, error) { return fakeKMS{}, nil }
+
+	ctx := context.Background()
+	os.Setenv(PassphraseEnv, "correct horse battery staple")
+	secretKey, err := EncryptSecret(ctx, getDefaultConfig(), "wJalrXUtnFEMI", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	webhookURL, err := EncryptSecret(ctx, getDefaultConfig(), "https://hooks.example.com/T000/B000", "alias/config")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(secretKey, "enc:passphrase:") || !strings.HasPrefix(webhookURL, "enc:kms:") {
+		t.Fatalf("Unexpected encrypted values: %s, %s", secretKey, webhookURL)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := fmt.Sprintf(`{"aws": {"secret_access_key": %q}, "tables": {"achievements": "plain_achievements"}, "goals": {"webhook_urls": [%q]}, "webhooks": {"endpoint_secrets": {"https://hooks.example.com/T000/B000": %q}}}`, secretKey, webhookURL, secretKey)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	SetConfigFile(path)
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.AWS.SecretAccessKey != "wJalrXUtnFEMI" {
+		t.Errorf("Expected decrypted secret access key, got %q", config.AWS.SecretAccessKey)
+	}
+	if len(config.Goals.WebhookURLs) != 1 || config.Goals.WebhookURLs[0] != "https://hooks.example.com/T000/B000" {
+		t.Errorf("Expected decrypted webhook URL, got %v", config.Goals.WebhookURLs)
+	}
+	if secret := config.Webhooks.SecretFor("https://hooks.example.com/T000/B000"); secret != "wJalrXUtnFEMI" {
+		t.Errorf("Expected decrypted endpoint secret, got %q", secret)
+	}
+	if config.Tables.Achievements != "plain_achievements" {
+		t.Errorf("Expected plain table name, got %q", config.Tables.Achievements)
+	}
+
+	// パスフレーズが違う場合は読み込めない
+	os.Setenv(PassphraseEnv, "wrong passphrase")
+	if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "aws.secret_access_key") {
+		t.Errorf("Expected decryption error for aws.secret_access_key, got %v", err)
+	}
+}
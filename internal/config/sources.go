@@ -0,0 +1,178 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Source 設定値の読み込み元
+type Source string
+
+const (
+	// SourceDefault 既定値
+	SourceDefault Source = "default"
+	// SourceFile 設定ファイル
+	SourceFile Source = "file"
+	// SourceEnv 環境変数
+	SourceEnv Source = "env"
+)
+
+// MaskedValue 秘密の設定値の代わりに表示する値
+const MaskedValue = "********"
+
+// secretKeys 値を伏せる設定項目（ドットでつないだキーの最後の要素）
+var secretKeys = map[string]bool{
+	"access_key_id":     true,
+	"secret_access_key": true,
+	"postgres_dsn":      true,
+	"redis_password":    true,
+	"smtp_password":     true,
+	"admin_token":       true,
+	"passphrase":        true,
+	// エラー通知のDSNには公開キーが含まれる
+	"dsn": true,
+	// Slack などのWebhookのURLにはトークンが含まれる
+	"webhook_urls": true,
+	"webhook_url":  true,
+	// Webhookの署名の秘密鍵
+	"signing_secret":   true,
+	"endpoint_secrets": true,
+	// カレンダーのフィードのトークン
+	"token":         true,
+	"tenant_tokens": true,
+}
+
+// Setting 設定項目1つの値と読み込み元
+type Setting struct {
+	// Key 設定ファイルのキーをドットでつないだもの（例: storage.driver）
+	Key string `json:"key"`
+	// Value 設定値（秘密の値は空でなければ MaskedValue）
+	Value  interface{} `json:"value"`
+	Source Source      `json:"source"`
+	Secret bool        `json:"secret,omitempty"`
+}
+
+// FilePath 読み込んだ設定ファイルのパス（読み込んでいない場合は空）
+func (c *Config) FilePath() string {
+	return c.filePath
+}
+
+// EnvFilePath 読み込んだ .env ファイルのパス（無かった場合は空）
+func (c *Config) EnvFilePath() string {
+	return c.envFilePath
+}
+
+// Settings 設定項目を定義順に、秘密の値を伏せて返す
+func (c *Config) Settings() []Setting {
+	var settings []Setting
+	walkSettings(reflect.ValueOf(c).Elem(), "", func(key string, value reflect.Value) {
+		setting := Setting{Key: key, Value: value.Interface(), Source: c.sourceOf(key)}
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			// 未設定のリストも空のリストとして表示する
+			setting.Value = reflect.MakeSlice(value.Type(), 0, 0).Interface()
+		}
+		if secretKeys[key[strings.LastIndex(key, ".")+1:]] {
+			setting.Secret = true
+			if !isEmptyValue(value) {
+				setting.Value = MaskedValue
+			}
+		}
+		settings = append(settings, setting)
+	})
+	return settings
+}
+
+// sourceOf 設定項目の読み込み元
+func (c *Config) sourceOf(key string) Source {
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// setSource 設定項目の読み込み元を記録する
+func (c *Config) setSource(key string, source Source) {
+	if source == SourceDefault {
+		delete(c.sources, key)
+		return
+	}
+	if c.sources == nil {
+		c.sources = make(map[string]Source)
+	}
+	c.sources[key] = source
+}
+
+// recordFileKeys 設定ファイルに記載された設定項目を設定ファイルから読み込んだものとして記録する
+func (c *Config) recordFileKeys(raw map[string]interface{}) {
+	walkSettings(reflect.ValueOf(c).Elem(), "", func(key string, _ reflect.Value) {
+		if hasPath(raw, key) {
+			c.setSource(key, SourceFile)
+		}
+	})
+}
+
+// settingValues 設定項目ごとの現在の値
+func (c *Config) settingValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	walkSettings(reflect.ValueOf(c).Elem(), "", func(key string, value reflect.Value) {
+		values[key] = value.Interface()
+	})
+	return values
+}
+
+// recordChanges before から値が変わった設定項目を source から読み込んだものとして記録する
+//
+// 環境変数を設定していても値が変わらない場合は、それまでの読み込み元のままになる。
+func (c *Config) recordChanges(before map[string]interface{}, source Source) {
+	walkSettings(reflect.ValueOf(c).Elem(), "", func(key string, value reflect.Value) {
+		if !reflect.DeepEqual(before[key], value.Interface()) {
+			c.setSource(key, source)
+		}
+	})
+}
+
+// walkSettings JSONのタグを持つ公開フィールドを定義順にたどり、構造体以外の値ごとに fn を呼ぶ
+func walkSettings(v reflect.Value, prefix string, fn func(key string, value reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		key := prefix + name
+		if field.Type.Kind() == reflect.Struct {
+			walkSettings(v.Field(i), key+".", fn)
+			continue
+		}
+		fn(key, v.Field(i))
+	}
+}
+
+// hasPath 設定ファイルの内容にドットでつないだキーがあるか
+func hasPath(raw map[string]interface{}, key string) bool {
+	parts := strings.Split(key, ".")
+	current := raw
+	for i, part := range parts {
+		value, ok := current[part]
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 {
+			return true
+		}
+		if current, ok = value.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return false
+}
+
+// isEmptyValue 設定値が空か（空のスライス・マップも空とみなす）
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,211 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// descriptors よく使う式の別名
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// field cron式の1つのフィールドの範囲
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// Schedule 分・時・日・月・曜日の5つのフィールドからなるcron式
+type Schedule struct {
+	expr string
+	// sets フィールドごとに一致する値
+	sets [5]map[int]bool
+	// domAny, dowAny 日・曜日を * にしたか（どちらか一方だけを指定した場合は指定した方だけで判定する）
+	domAny, dowAny bool
+}
+
+// Parse cron式を解析（* ・数値・範囲 1-5・リスト 1,3・間隔 */15 と @daily などの別名に対応）
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if alias, ok := descriptors[spec]; ok {
+		spec = alias
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day month weekday)", expr)
+	}
+
+	schedule := &Schedule{expr: expr}
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		schedule.sets[i] = set
+	}
+	schedule.domAny = parts[2] == "*"
+	schedule.dowAny = parts[4] == "*"
+	return schedule, nil
+}
+
+// parseField フィールドの値をカンマ区切りの要素ごとに展開
+func parseField(part string, f field) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, item := range strings.Split(part, ",") {
+		step := 1
+		if base, stepText, ok := strings.Cut(item, "/"); ok {
+			value, err := strconv.Atoi(stepText)
+			if err != nil || value <= 0 {
+				return nil, fmt.Errorf("invalid step %q in %s", stepText, f.name)
+			}
+			item, step = base, value
+		}
+
+		low, high := f.min, f.max
+		if item != "*" {
+			lowText, highText, isRange := strings.Cut(item, "-")
+			var err error
+			if low, err = parseValue(lowText, f); err != nil {
+				return nil, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseValue(highText, f); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				// 5/15 のように開始値だけを指定した間隔は最大値まで続ける
+				high = f.max
+			}
+			if low > high {
+				return nil, fmt.Errorf("invalid range %q in %s", item, f.name)
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set[value] = true
+		}
+	}
+	return set, nil
+}
+
+// parseValue フィールドの範囲内の数値を解析（曜日の7は日曜日の0として扱う）
+func parseValue(text string, f field) (int, error) {
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s", text, f.name)
+	}
+	if f.name == "day of week" && value == 7 {
+		value = 0
+	}
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("%s must be between %d and %d, got %d", f.name, f.min, f.max, value)
+	}
+	return value, nil
+}
+
+// String 解析したcron式
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Matches t の分がスケジュールに一致するか（t のタイムゾーンで判定する）
+func (s *Schedule) Matches(t time.Time) bool {
+	if !s.sets[0][t.Minute()] || !s.sets[1][t.Hour()] || !s.sets[3][int(t.Month())] {
+		return false
+	}
+
+	dom, dow := s.sets[2][t.Day()], s.sets[4][int(t.Weekday())]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		// 日と曜日を両方指定した場合はどちらかに一致すればよい（標準のcronと同じ）
+		return dom || dow
+	}
+}
+
+// Next after より後でスケジュールに一致する最初の分（1年以内に一致しない場合はゼロ値）
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	for end := t.AddDate(1, 0, 0); t.Before(end); t = t.Add(time.Minute) {
+		if s.Matches(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// weekdays iCalendarの曜日（日曜日の0から）
+var weekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Split 日と曜日を両方指定したスケジュールを、日だけ・曜日だけのスケジュールに分ける（それ以外はそのまま1つで返す）
+//
+// どちらかに一致すればよいスケジュールは iCalendar の1つの繰り返しルールでは表せないため、RRule の前に分ける。
+func (s *Schedule) Split() []*Schedule {
+	if s.domAny || s.dowAny {
+		return []*Schedule{s}
+	}
+	byDay, byWeekday := *s, *s
+	byDay.dowAny = true
+	byWeekday.domAny = true
+	return []*Schedule{&byDay, &byWeekday}
+}
+
+// RRule 同じ時刻に繰り返す iCalendar の繰り返しルール（日と曜日を両方指定した場合は false。Split で分けてから使用する）
+func (s *Schedule) RRule() (string, bool) {
+	if !s.domAny && !s.dowAny {
+		return "", false
+	}
+
+	rule := "FREQ=DAILY;BYHOUR=" + join(s.sets[1], nil) + ";BYMINUTE=" + join(s.sets[0], nil)
+	if len(s.sets[3]) < fields[3].max {
+		rule += ";BYMONTH=" + join(s.sets[3], nil)
+	}
+	switch {
+	case !s.domAny:
+		rule += ";BYMONTHDAY=" + join(s.sets[2], nil)
+	case !s.dowAny:
+		rule += ";BYDAY=" + join(s.sets[4], weekdays)
+	}
+	return rule, true
+}
+
+// join フィールドの値を昇順にカンマ区切りで連結（names を指定した場合は値の代わりに名前を使用する）
+func join(set map[int]bool, names []string) string {
+	values := make([]int, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+
+	items := make([]string, len(values))
+	for i, value := range values {
+		if names != nil {
+			items[i] = names[value]
+		} else {
+			items[i] = strconv.Itoa(value)
+		}
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Matches(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		expr    string
+		at      time.Time
+		matches bool
+	}{
+		{"0 20 * * *", time.Date(2024, 6, 3, 20, 0, 0, 0, tokyo), true},
+		{"0 20 * * *", time.Date(2024, 6, 3, 20, 1, 0, 0, tokyo), false},
+		{"*/15 9-17 * * 1-5", time.Date(2024, 6, 3, 9, 45, 0, 0, tokyo), true},  // 月曜日
+		{"*/15 9-17 * * 1-5", time.Date(2024, 6, 2, 9, 45, 0, 0, tokyo), false}, // 日曜日
+		{"30 7 * * 0,6", time.Date(2024, 6, 2, 7, 30, 0, 0, tokyo), true},
+		{"0 9 * * 7", time.Date(2024, 6, 2, 9, 0, 0, 0, tokyo), true}, // 7も日曜日
+		{"5/20 * * * *", time.Date(2024, 6, 3, 10, 45, 0, 0, tokyo), true},
+		{"5/20 * * * *", time.Date(2024, 6, 3, 10, 40, 0, 0, tokyo), false},
+		{"@daily", time.Date(2024, 6, 3, 0, 0, 0, 0, tokyo), true},
+		// 日と曜日を両方指定した場合はどちらかに一致すればよい
+		{"0 8 1 * 1", time.Date(2024, 6, 1, 8, 0, 0, 0, tokyo), true},
+		{"0 8 1 * 1", time.Date(2024, 6, 3, 8, 0, 0, 0, tokyo), true},
+		{"0 8 1 * 1", time.Date(2024, 6, 4, 8, 0, 0, 0, tokyo), false},
+	}
+	for _, tt := range tests {
+		schedule, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.expr, err)
+		}
+		if got := schedule.Matches(tt.at); got != tt.matches {
+			t.Errorf("%q at %s: expected %v, got %v", tt.expr, tt.at, tt.matches, got)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "0 20 * *", "60 * * * *", "0 24 * * *", "* * 0 * *", "0 20 * * 8", "*/0 * * * *", "10-5 * * * *", "a * * * *", "@yearly"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	schedule, err := Parse("0 20 * * *")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	after := time.Date(2024, 6, 3, 20, 0, 30, 0, time.UTC)
+	if next := schedule.Next(after); !next.Equal(time.Date(2024, 6, 4, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected next run on the following day, got %s", next)
+	}
+	if next := schedule.Next(after.Add(-time.Hour)); !next.Equal(time.Date(2024, 6, 3, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected next run later the same day, got %s", next)
+	}
+}
+
+func TestSchedule_RRule(t *testing.T) {
+	tests := []struct {
+		expr  string
+		rules []string
+	}{
+		{"0 20 * * *", []string{"FREQ=DAILY;BYHOUR=20;BYMINUTE=0"}},
+		{"0,30 9-10 * * 1-5", []string{"FREQ=DAILY;BYHOUR=9,10;BYMINUTE=0,30;BYDAY=MO,TU,WE,TH,FR"}},
+		{"0 9 * * 7", []string{"FREQ=DAILY;BYHOUR=9;BYMINUTE=0;BYDAY=SU"}},
+		{"@monthly", []string{"FREQ=DAILY;BYHOUR=0;BYMINUTE=0;BYMONTHDAY=1"}},
+		{"0 8 1 */6 *", []string{"FREQ=DAILY;BYHOUR=8;BYMINUTE=0;BYMONTH=1,7;BYMONTHDAY=1"}},
+		// 日と曜日を両方指定した場合は日のルールと曜日のルールに分ける
+		{"0 8 1 * 1", []string{"FREQ=DAILY;BYHOUR=8;BYMINUTE=0;BYMONTHDAY=1", "FREQ=DAILY;BYHOUR=8;BYMINUTE=0;BYDAY=MO"}},
+	}
+	for _, tt := range tests {
+		schedule, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.expr, err)
+		}
+		if _, ok := schedule.RRule(); ok != (len(tt.rules) == 1) {
+			t.Errorf("%q: unexpected RRule result before Split", tt.expr)
+		}
+
+		schedules := schedule.Split()
+		if len(schedules) != len(tt.rules) {
+			t.Fatalf("%q: expected %d schedules, got %d", tt.expr, len(tt.rules), len(schedules))
+		}
+		for i, s := range schedules {
+			if rule, ok := s.RRule(); !ok || rule != tt.rules[i] {
+				t.Errorf("%q: expected %q, got %q", tt.expr, tt.rules[i], rule)
+			}
+		}
+	}
+}
+
+func TestSchedule_Split(t *testing.T) {
+	schedule, err := Parse("0 8 1 * 1")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	schedules := schedule.Split()
+
+	saturday := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC)
+	if !schedules[0].Matches(saturday) || schedules[0].Matches(monday) {
+		t.Error("Expected the first schedule to match only the day of month")
+	}
+	if schedules[1].Matches(saturday) || !schedules[1].Matches(monday) {
+		t.Error("Expected the second schedule to match only the day of week")
+	}
+	if !schedule.Matches(saturday) || !schedule.Matches(monday) {
+		t.Error("Expected the original schedule to be unchanged")
+	}
+}
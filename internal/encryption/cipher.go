@@ -0,0 +1,153 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// prefix 暗号化した値の先頭に付ける識別子（暗号化前に保存した値と区別するため）
+const prefix = "enc:v1:"
+
+// Cipher 属性の値を暗号化・復号する
+type Cipher interface {
+	// Encrypt 値を暗号化（空の値はそのまま返す）
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+	// Decrypt 暗号化した値を復号（暗号化していない値はそのまま返す）
+	Decrypt(ctx context.Context, value string) (string, error)
+}
+
+// IsEncrypted 値が暗号化されているかどうか
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// encode 暗号化した値を「enc:v1:{provider}:{部品}:...」の形式にまとめる
+func encode(provider string, parts ...[]byte) string {
+	encoded := make([]string, len(parts))
+	for i, part := range parts {
+		encoded[i] = base64.RawStdEncoding.EncodeToString(part)
+	}
+	return prefix + provider + ":" + strings.Join(encoded, ":")
+}
+
+// decode encode した値を部品に分解（provider と部品の数が一致しない場合はエラー）
+func decode(value, provider string, count int) ([][]byte, error) {
+	rest := strings.TrimPrefix(value, prefix)
+	name, rest, _ := strings.Cut(rest, ":")
+	if name != provider {
+		return nil, fmt.Errorf("value was encrypted with provider %q, not %q", name, provider)
+	}
+
+	encoded := strings.Split(rest, ":")
+	if len(encoded) != count {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+	parts := make([][]byte, count)
+	for i, part := range encoded {
+		decoded, err := base64.RawStdEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("malformed encrypted value: %w", err)
+		}
+		parts[i] = decoded
+	}
+	return parts, nil
+}
+
+// seal AES-256-GCMで暗号化し、nonce を先頭に付けて返す
+func seal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open seal した値を復号
+func open(key, sealed []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+const (
+	// passphraseSalt パスフレーズから鍵を導出する際のソルト
+	//
+	// 同じパスフレーズからどのプロセスでも同じ鍵を導出するため固定にする。値ごとの違いは nonce で確保する。
+	passphraseSalt = "achievement-management/encryption/v1"
+	// passphraseIterations PBKDF2の繰り返し回数（プロセスごとに1回だけ導出する）
+	passphraseIterations = 600000
+)
+
+// PassphraseCipher パスフレーズから導出した鍵で暗号化する
+type PassphraseCipher struct {
+	key []byte
+}
+
+// NewPassphraseCipher パスフレーズから鍵を導出して作成
+func NewPassphraseCipher(passphrase string) (*PassphraseCipher, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("encryption passphrase is required")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, []byte(passphraseSalt), passphraseIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+	return &PassphraseCipher{key: key}, nil
+}
+
+// Encrypt 値を暗号化
+func (c *PassphraseCipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	sealed, err := seal(c.key, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return encode("passphrase", sealed), nil
+}
+
+// Decrypt 暗号化した値を復号
+func (c *PassphraseCipher) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	parts, err := decode(value, "passphrase", 1)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(c.key, parts[0])
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,79 @@
+package encryption
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassphraseCipher_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewPassphraseCipher("correct horse battery staple")
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt(ctx, "毎朝5kmのランニング")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:passphrase:"))
+	assert.NotContains(t, encrypted, "ランニング")
+
+	// 同じ値でも nonce が異なるため暗号文は一致しない
+	again, err := c.Encrypt(ctx, "毎朝5kmのランニング")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again)
+
+	decrypted, err := c.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "毎朝5kmのランニング", decrypted)
+
+	// 同じパスフレーズから作成した別のインスタンスでも復号できる
+	other, err := NewPassphraseCipher("correct horse battery staple")
+	require.NoError(t, err)
+	decrypted, err = other.Decrypt(ctx, again)
+	require.NoError(t, err)
+	assert.Equal(t, "毎朝5kmのランニング", decrypted)
+}
+
+func TestPassphraseCipher_PlaintextAndEmpty(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewPassphraseCipher("secret")
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, encrypted)
+
+	// 暗号化を有効にする前に保存した値はそのまま読み取れる
+	decrypted, err := c.Decrypt(ctx, "暗号化前の説明")
+	require.NoError(t, err)
+	assert.Equal(t, "暗号化前の説明", decrypted)
+}
+
+func TestPassphraseCipher_Errors(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewPassphraseCipher("")
+	assert.Error(t, err)
+
+	c, err := NewPassphraseCipher("secret")
+	require.NoError(t, err)
+	encrypted, err := c.Encrypt(ctx, "説明")
+	require.NoError(t, err)
+
+	// 異なるパスフレーズでは復号できない
+	wrong, err := NewPassphraseCipher("another secret")
+	require.NoError(t, err)
+	_, err = wrong.Decrypt(ctx, encrypted)
+	assert.Error(t, err)
+
+	for _, value := range []string{
+		"enc:v1:kms:AAAA:BBBB",
+		"enc:v1:passphrase:not-base64!",
+		"enc:v1:passphrase:AAAA:BBBB",
+		"enc:v1:passphrase:AAAA",
+	} {
+		_, err := c.Decrypt(ctx, value)
+		assert.Error(t, err, value)
+	}
+}
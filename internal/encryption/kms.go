@@ -0,0 +1,113 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// KMSAPI エンベロープ暗号化で使用するKMSの操作
+type KMSAPI interface {
+	GenerateDataKey(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error)
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// KMSCipher KMSで生成したデータキーで暗号化する
+//
+// データキーはプロセスごとに1つ生成し、KMSで暗号化したデータキーを値に含める。
+// 復号時はデータキーごとに1回だけKMSで復号し、結果をプロセス内に保持する。
+type KMSCipher struct {
+	client KMSAPI
+	keyID  string
+
+	mu sync.Mutex
+	// dataKey, encryptedKey 暗号化に使用するデータキーと、KMSで暗号化したデータキー
+	dataKey      []byte
+	encryptedKey []byte
+	// keys 暗号化したデータキーごとの復号済みのデータキー
+	keys map[string][]byte
+}
+
+// NewKMSCipher KMSキーを指定して作成
+func NewKMSCipher(client KMSAPI, keyID string) *KMSCipher {
+	return &KMSCipher{client: client, keyID: keyID, keys: map[string][]byte{}}
+}
+
+// Encrypt 値を暗号化
+func (c *KMSCipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	dataKey, encryptedKey, err := c.currentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return encode("kms", encryptedKey, sealed), nil
+}
+
+// Decrypt 暗号化した値を復号
+func (c *KMSCipher) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	parts, err := decode(value, "kms", 2)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := c.decryptKey(ctx, parts[0])
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataKey, parts[1])
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// currentKey 暗号化に使用するデータキーを取得（初回のみKMSで生成する）
+func (c *KMSCipher) currentKey(ctx context.Context) ([]byte, []byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dataKey == nil {
+		resp, err := c.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+			KeyId:   aws.String(c.keyID),
+			KeySpec: types.DataKeySpecAes256,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate data key with %s: %w", c.keyID, err)
+		}
+		c.dataKey = resp.Plaintext
+		c.encryptedKey = resp.CiphertextBlob
+		c.keys[string(resp.CiphertextBlob)] = resp.Plaintext
+	}
+	return c.dataKey, c.encryptedKey, nil
+}
+
+// decryptKey 暗号化したデータキーを復号
+func (c *KMSCipher) decryptKey(ctx context.Context, encryptedKey []byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.keys[string(encryptedKey)]; ok {
+		return key, nil
+	}
+	resp, err := c.client.Decrypt(ctx, &kms.DecryptInput{
+		CiphertextBlob: encryptedKey,
+		KeyId:          aws.String(c.keyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	c.keys[string(encryptedKey)] = resp.Plaintext
+	return resp.Plaintext, nil
+}
@@ -0,0 +1,107 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKMS データキーを反転したバイト列を「暗号化した」データキーとして扱うKMS
+type fakeKMS struct {
+	mu            sync.Mutex
+	generateCalls int
+	decryptCalls  int
+	err           error
+}
+
+func (f *fakeKMS) GenerateDataKey(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.generateCalls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	key := bytes.Repeat([]byte{byte(f.generateCalls)}, 32)
+	return &kms.GenerateDataKeyOutput{Plaintext: key, CiphertextBlob: reverse(key)}, nil
+}
+
+func (f *fakeKMS) Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.decryptCalls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &kms.DecryptOutput{Plaintext: reverse(params.CiphertextBlob)}, nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func TestKMSCipher_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeKMS{}
+	c := NewKMSCipher(client, "alias/achievements")
+
+	first, err := c.Encrypt(ctx, "説明1")
+	require.NoError(t, err)
+	second, err := c.Encrypt(ctx, "説明2")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first, "enc:v1:kms:"))
+
+	// データキーはプロセスごとに1回だけ生成する
+	assert.Equal(t, 1, client.generateCalls)
+
+	decrypted, err := c.Decrypt(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, "説明1", decrypted)
+	assert.Equal(t, 0, client.decryptCalls)
+
+	// 別のプロセスで暗号化した値はKMSでデータキーを復号し、以降は保持したデータキーを使う
+	other := NewKMSCipher(&fakeKMS{generateCalls: 1}, "alias/achievements")
+	encrypted, err := other.Encrypt(ctx, "別プロセスの説明")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		decrypted, err = c.Decrypt(ctx, encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "別プロセスの説明", decrypted)
+	}
+	assert.Equal(t, 1, client.decryptCalls)
+
+	decrypted, err = c.Decrypt(ctx, second)
+	require.NoError(t, err)
+	assert.Equal(t, "説明2", decrypted)
+}
+
+func TestKMSCipher_Errors(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeKMS{err: errors.New("access denied")}
+	c := NewKMSCipher(client, "alias/achievements")
+
+	_, err := c.Encrypt(ctx, "説明")
+	assert.ErrorContains(t, err, "access denied")
+
+	_, err = c.Decrypt(ctx, encode("kms", []byte("key"), []byte("sealed")))
+	assert.ErrorContains(t, err, "access denied")
+
+	// パスフレーズで暗号化した値は復号できない
+	_, err = c.Decrypt(ctx, "enc:v1:passphrase:AAAA")
+	assert.Error(t, err)
+
+	// 暗号化していない値はKMSを呼び出さずにそのまま返す
+	decrypted, err := c.Decrypt(ctx, "平文")
+	require.NoError(t, err)
+	assert.Equal(t, "平文", decrypted)
+}
@@ -0,0 +1,234 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// AchievementRepository 説明を暗号化して保存する達成目録リポジトリ
+type AchievementRepository struct {
+	next   repository.AchievementRepository
+	cipher Cipher
+}
+
+// NewAchievementRepository 達成目録リポジトリに説明の暗号化を追加
+func NewAchievementRepository(next repository.AchievementRepository, cipher Cipher) repository.AchievementRepository {
+	return &AchievementRepository{next: next, cipher: cipher}
+}
+
+// Create 達成目録を作成
+func (r *AchievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
+	return r.write(ctx, achievement, r.next.Create)
+}
+
+// CreateWithPoints 達成目録の作成・ポイント加算・台帳への記録を実行
+func (r *AchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	return r.write(ctx, achievement, r.next.CreateWithPoints)
+}
+
+// Update 達成目録を更新
+func (r *AchievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
+	return r.write(ctx, achievement, r.next.Update)
+}
+
+// UpdateWithPoints 達成目録を更新し、ポイントの差分を反映
+func (r *AchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	return r.write(ctx, achievement, r.next.UpdateWithPoints)
+}
+
+// GetByID IDで達成目録を取得
+func (r *AchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	achievement, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.decrypt(ctx, achievement)
+}
+
+// List すべての達成目録を取得
+func (r *AchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
+	achievements, err := r.next.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]*models.Achievement, len(achievements))
+	for i, achievement := range achievements {
+		if decrypted[i], err = r.decrypt(ctx, achievement); err != nil {
+			return nil, err
+		}
+	}
+	return decrypted, nil
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイントのみを取得（説明を含まないため復号しない）
+func (r *AchievementRepository) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	return r.next.ListSummaries(ctx)
+}
+
+// Count 達成目録の件数を取得
+func (r *AchievementRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 達成目録を削除
+func (r *AchievementRepository) Delete(ctx context.Context, id string) error {
+	return r.next.Delete(ctx, id)
+}
+
+// DeleteWithPoints 達成目録の削除・ポイント減算・台帳への記録を実行
+func (r *AchievementRepository) DeleteWithPoints(ctx context.Context, id string) error {
+	return r.next.DeleteWithPoints(ctx, id)
+}
+
+// DeleteMany 複数の達成目録を削除
+func (r *AchievementRepository) DeleteMany(ctx context.Context, ids []string) error {
+	return r.next.DeleteMany(ctx, ids)
+}
+
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存（オブジェクトキーは暗号化しない）
+func (r *AchievementRepository) SetAttachment(ctx context.Context, id, key string) error {
+	return r.next.SetAttachment(ctx, id, key)
+}
+
+// StartTimer 達成目録のタイマーを開始
+func (r *AchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) error {
+	return r.next.StartTimer(ctx, id, at)
+}
+
+// Complete 達成記録を作成（達成記録には暗号化する属性がない）
+func (r *AchievementRepository) Complete(ctx context.Context, completion *models.Completion) error {
+	return r.next.Complete(ctx, completion)
+}
+
+// ListCompletions 達成目録の達成記録を取得
+func (r *AchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	return r.next.ListCompletions(ctx, achievementID)
+}
+
+// write 説明を暗号化して書き込み、呼び出し元の達成目録には平文の説明を戻す
+//
+// 書き込み中に設定されたIDやバージョンを呼び出し元に残すため、コピーではなく同じ値を書き換える。
+func (r *AchievementRepository) write(ctx context.Context, achievement *models.Achievement, fn func(context.Context, *models.Achievement) error) error {
+	if achievement == nil {
+		return fn(ctx, achievement)
+	}
+	plaintext := achievement.Description
+	encrypted, err := r.cipher.Encrypt(ctx, plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt description of achievement: %w", err)
+	}
+	achievement.Description = encrypted
+	defer func() { achievement.Description = plaintext }()
+	return fn(ctx, achievement)
+}
+
+// decrypt 説明を復号した達成目録を返す（キャッシュが保持する値を書き換えないようにコピーする）
+func (r *AchievementRepository) decrypt(ctx context.Context, achievement *models.Achievement) (*models.Achievement, error) {
+	description, err := r.cipher.Decrypt(ctx, achievement.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt description of achievement %s: %w", achievement.ID, err)
+	}
+	decrypted := *achievement
+	decrypted.Description = description
+	return &decrypted, nil
+}
+
+// RewardRepository 説明を暗号化して保存する報酬リポジトリ
+type RewardRepository struct {
+	next   repository.RewardRepository
+	cipher Cipher
+}
+
+// NewRewardRepository 報酬リポジトリに説明の暗号化を追加
+func NewRewardRepository(next repository.RewardRepository, cipher Cipher) repository.RewardRepository {
+	return &RewardRepository{next: next, cipher: cipher}
+}
+
+// Create 報酬を作成
+func (r *RewardRepository) Create(ctx context.Context, reward *models.Reward) error {
+	return r.write(ctx, reward, r.next.Create)
+}
+
+// Update 報酬を更新
+func (r *RewardRepository) Update(ctx context.Context, reward *models.Reward) error {
+	return r.write(ctx, reward, r.next.Update)
+}
+
+// GetByID IDで報酬を取得
+func (r *RewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	reward, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.decrypt(ctx, reward)
+}
+
+// GetByIDs 複数のIDで報酬を取得
+func (r *RewardRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	rewards, err := r.next.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptAll(ctx, rewards)
+}
+
+// List すべての報酬を取得
+func (r *RewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
+	rewards, err := r.next.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptAll(ctx, rewards)
+}
+
+// Count 報酬の件数を取得
+func (r *RewardRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 報酬を削除
+func (r *RewardRepository) Delete(ctx context.Context, id string) error {
+	return r.next.Delete(ctx, id)
+}
+
+// write 説明を暗号化して書き込み、呼び出し元の報酬には平文の説明を戻す
+func (r *RewardRepository) write(ctx context.Context, reward *models.Reward, fn func(context.Context, *models.Reward) error) error {
+	if reward == nil {
+		return fn(ctx, reward)
+	}
+	plaintext := reward.Description
+	encrypted, err := r.cipher.Encrypt(ctx, plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt description of reward: %w", err)
+	}
+	reward.Description = encrypted
+	defer func() { reward.Description = plaintext }()
+	return fn(ctx, reward)
+}
+
+// decrypt 説明を復号した報酬を返す
+func (r *RewardRepository) decrypt(ctx context.Context, reward *models.Reward) (*models.Reward, error) {
+	description, err := r.cipher.Decrypt(ctx, reward.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt description of reward %s: %w", reward.ID, err)
+	}
+	decrypted := *reward
+	decrypted.Description = description
+	return &decrypted, nil
+}
+
+// decryptAll 複数の報酬の説明を復号
+func (r *RewardRepository) decryptAll(ctx context.Context, rewards []*models.Reward) ([]*models.Reward, error) {
+	decrypted := make([]*models.Reward, len(rewards))
+	for i, reward := range rewards {
+		var err error
+		if decrypted[i], err = r.decrypt(ctx, reward); err != nil {
+			return nil, err
+		}
+	}
+	return decrypted, nil
+}
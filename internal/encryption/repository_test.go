@@ -0,0 +1,106 @@
+package encryption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository/memory"
+)
+
+func newTestCipher(t *testing.T) Cipher {
+	t.Helper()
+	c, err := NewPassphraseCipher("secret")
+	require.NoError(t, err)
+	return c
+}
+
+func TestAchievementRepository_EncryptsDescription(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	inner := memory.NewAchievementRepository(store)
+	repo := NewAchievementRepository(inner, newTestCipher(t))
+
+	achievement := &models.Achievement{Title: "早起き", Description: "6時に起きた", Point: 10}
+	require.NoError(t, repo.CreateWithPoints(ctx, achievement))
+
+	// 呼び出し元には生成されたIDと平文の説明が残る
+	assert.NotEmpty(t, achievement.ID)
+	assert.Equal(t, "6時に起きた", achievement.Description)
+
+	stored, err := inner.GetByID(ctx, achievement.ID)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(stored.Description))
+	assert.Equal(t, "早起き", stored.Title)
+
+	got, err := repo.GetByID(ctx, achievement.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "6時に起きた", got.Description)
+
+	achievement.Description = "5時に起きた"
+	require.NoError(t, repo.Update(ctx, achievement))
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "5時に起きた", list[0].Description)
+}
+
+func TestAchievementRepository_ReadsPlaintext(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	inner := memory.NewAchievementRepository(store)
+
+	// 暗号化を有効にする前に保存した達成目録
+	legacy := &models.Achievement{Title: "読書", Description: "1冊読んだ", Point: 5}
+	require.NoError(t, inner.Create(ctx, legacy))
+
+	repo := NewAchievementRepository(inner, newTestCipher(t))
+	got, err := repo.GetByID(ctx, legacy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1冊読んだ", got.Description)
+}
+
+func TestAchievementRepository_DecryptError(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	inner := memory.NewAchievementRepository(store)
+
+	wrong, err := NewPassphraseCipher("another secret")
+	require.NoError(t, err)
+	achievement := &models.Achievement{Title: "早起き", Description: "6時に起きた", Point: 10}
+	require.NoError(t, NewAchievementRepository(inner, wrong).Create(ctx, achievement))
+
+	repo := NewAchievementRepository(inner, newTestCipher(t))
+	_, err = repo.GetByID(ctx, achievement.ID)
+	assert.ErrorContains(t, err, achievement.ID)
+	_, err = repo.List(ctx)
+	assert.Error(t, err)
+}
+
+func TestRewardRepository_EncryptsDescription(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	inner := memory.NewRewardRepository(store)
+	repo := NewRewardRepository(inner, newTestCipher(t))
+
+	reward := &models.Reward{Title: "コーヒー券", Description: "駅前のカフェで使える", Point: 100}
+	require.NoError(t, repo.Create(ctx, reward))
+	assert.Equal(t, "駅前のカフェで使える", reward.Description)
+
+	stored, err := inner.GetByID(ctx, reward.ID)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(stored.Description))
+
+	got, err := repo.GetByIDs(ctx, []string{reward.ID})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "駅前のカフェで使える", got[0].Description)
+
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "駅前のカフェで使える", list[0].Description)
+}
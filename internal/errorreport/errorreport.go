@@ -0,0 +1,323 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"achievement-management/internal/errors"
+)
+
+const (
+	// sentryVersion 送信するイベントのプロトコルのバージョン
+	sentryVersion = "7"
+	// clientName X-Sentry-Auth ヘッダーに付けるクライアント名
+	clientName = "achievement-management"
+	// defaultTimeout イベント送信のタイムアウト
+	defaultTimeout = 10 * time.Second
+	// queueSize 送信待ちにできるイベント数（超えた分は送らずに捨てる）
+	queueSize = 100
+)
+
+// tagKeys イベントのタグにする相関フィールド（それ以外のフィールドは extra に入れる）
+var tagKeys = map[string]bool{
+	"request_id": true,
+	"route":      true,
+	"method":     true,
+	"tenant_id":  true,
+	"job":        true,
+	"component":  true,
+	"operation":  true,
+}
+
+// Event エラー管理サービスに送るイベント
+type Event struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Platform    string                 `json:"platform"`
+	Environment string                 `json:"environment,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	Message     string                 `json:"message"`
+	Exception   *Exceptions            `json:"exception,omitempty"`
+	Request     *Request               `json:"request,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+// Exceptions イベントの例外
+type Exceptions struct {
+	Values []Exception `json:"values"`
+}
+
+// Exception 例外の型と内容
+type Exception struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Request イベントが起きたリクエスト
+type Request struct {
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// Client Sentry互換のエラー管理サービスにイベントを非同期で送るクライアント
+type Client struct {
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	client      *http.Client
+	now         func() time.Time
+
+	mu     sync.Mutex
+	closed bool
+	queue  chan *Event
+	done   chan struct{}
+	// pending 送信待ち・送信中のイベントの数、flushed 送り終えるのを待っている Flush
+	pending int
+	flushed []chan struct{}
+}
+
+// New DSNのプロジェクトにイベントを送るクライアントを作成し、送信を開始する
+func New(dsn, environment, release string) (*Client, error) {
+	endpoint, key, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		endpoint:    endpoint,
+		auth:        fmt.Sprintf("Sentry sentry_version=%s, sentry_key=%s, sentry_client=%s/%s", sentryVersion, key, clientName, release),
+		environment: environment,
+		release:     release,
+		client:      &http.Client{Timeout: defaultTimeout},
+		queue:       make(chan *Event, queueSize),
+		done:        make(chan struct{}),
+		now:         time.Now,
+	}
+	go c.run()
+	return c, nil
+}
+
+// parseDSN DSN（https://<公開キー>@<ホスト>/<プロジェクトID>）からイベントの送信先と公開キーを取り出す
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid error reporting dsn: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", "", fmt.Errorf("invalid error reporting dsn: scheme must be http or https")
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", fmt.Errorf("invalid error reporting dsn: missing public key")
+	}
+
+	path := strings.Trim(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	project := path[slash+1:]
+	if u.Host == "" || project == "" {
+		return "", "", fmt.Errorf("invalid error reporting dsn: missing host or project id")
+	}
+
+	// プロジェクトIDの前のパスはエラー管理サービスをサブパスで公開している場合のもの
+	prefix := ""
+	if slash >= 0 {
+		prefix = "/" + path[:slash]
+	}
+	endpoint := fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, prefix, project)
+	return endpoint, u.User.Username(), nil
+}
+
+// Reportable エラー管理サービスに送るエラーか（データベース・サービスのエラーのうち、見つからなかったもの以外）
+func Reportable(err error) bool {
+	if err == nil || stderrors.Is(err, errors.ErrNotFound) {
+		return false
+	}
+	var dbErr *errors.DatabaseError
+	if stderrors.As(err, &dbErr) {
+		return true
+	}
+	var serviceErr *errors.ServiceError
+	return stderrors.As(err, &serviceErr)
+}
+
+// Report データベース・サービスのエラーをフィールド（request_id などの相関フィールド）と合わせて送る
+//
+// それ以外のエラーは入力の誤りなど想定内のものとして送らない。
+func (c *Client) Report(err error, fields map[string]interface{}) {
+	if !Reportable(err) {
+		return
+	}
+	event := c.newEvent("error", err.Error(), fields)
+	event.Exception = &Exceptions{Values: []Exception{{Type: fmt.Sprintf("%T", err), Value: err.Error()}}}
+	c.enqueue(event)
+}
+
+// ReportPanic 回復したパニックをスタックトレースと合わせて送る
+func (c *Client) ReportPanic(recovered interface{}, stack []byte, fields map[string]interface{}) {
+	message := fmt.Sprint(recovered)
+	event := c.newEvent("fatal", message, fields)
+	event.Exception = &Exceptions{Values: []Exception{{Type: fmt.Sprintf("panic(%T)", recovered), Value: message}}}
+	if len(stack) > 0 {
+		event.Extra["stack"] = string(stack)
+	}
+	c.enqueue(event)
+}
+
+// Close 送信待ちのイベントを ctx の期限まで送ってから送信を終える
+func (c *Client) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error reporting stopped with events unsent: %w", ctx.Err())
+	}
+}
+
+// Flush 送信待ちのイベントを ctx の期限まで送る（Close と異なり、その後も送信を続ける）
+//
+// AWS Lambdaでは呼び出しの間に実行環境が停止するため、呼び出しごとに送り終えるのを待つ。
+func (c *Client) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	if c.pending == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	flushed := make(chan struct{})
+	c.flushed = append(c.flushed, flushed)
+	c.mu.Unlock()
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error reporting flush stopped with events unsent: %w", ctx.Err())
+	}
+}
+
+// newEvent フィールドを相関フィールドのタグ・リクエスト・その他の extra に振り分けたイベント
+func (c *Client) newEvent(level, message string, fields map[string]interface{}) *Event {
+	event := &Event{
+		EventID:     newEventID(),
+		Timestamp:   c.now().UTC().Format(time.RFC3339),
+		Level:       level,
+		Platform:    "go",
+		Environment: c.environment,
+		Release:     c.release,
+		Message:     message,
+		Tags:        make(map[string]string),
+		Extra:       make(map[string]interface{}),
+	}
+	for key, value := range fields {
+		switch {
+		case tagKeys[key]:
+			event.Tags[key] = fmt.Sprint(value)
+		case key == "path" || key == "endpoint":
+			if event.Request == nil {
+				event.Request = &Request{}
+			}
+			event.Request.URL = fmt.Sprint(value)
+		default:
+			event.Extra[key] = value
+		}
+	}
+	if method, ok := event.Tags["method"]; ok && event.Request != nil {
+		event.Request.Method = method
+	}
+	return event
+}
+
+// enqueue イベントを送信待ちにする（送信待ちがいっぱいの場合・終了後は捨てる）
+func (c *Client) enqueue(event *Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.queue <- event:
+		c.pending++
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: error reporting queue is full, dropping event %s\n", event.EventID)
+	}
+}
+
+// run 送信待ちのイベントを順に送る
+func (c *Client) run() {
+	defer close(c.done)
+	for event := range c.queue {
+		if err := c.send(event); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to report error event %s: %v\n", event.EventID, err)
+		}
+		c.sent()
+	}
+}
+
+// sent 送り終えたイベントを数え、送信待ちが無くなれば Flush の待機を終える
+func (c *Client) sent() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending--
+	if c.pending > 0 {
+		return
+	}
+	for _, flushed := range c.flushed {
+		close(flushed)
+	}
+	c.flushed = nil
+}
+
+// send イベントをPOSTし、2xx以外の応答をエラーとして返す
+func (c *Client) send(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create error reporting request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", c.auth)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 接続を再利用できるように本文を読み捨てる
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error reporting responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// newEventID イベントID（ハイフンなしの32文字の16進数）
+func newEventID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,194 @@
+package errorreport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+)
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		dsn      string
+		endpoint string
+		key      string
+		wantErr  bool
+	}{
+		{dsn: "https://public@sentry.example.com/42", endpoint: "https://sentry.example.com/api/42/store/", key: "public"},
+		{dsn: "http://public@localhost:9000/sentry/7", endpoint: "http://localhost:9000/sentry/api/7/store/", key: "public"},
+		{dsn: "https://sentry.example.com/42", wantErr: true},
+		{dsn: "https://public@sentry.example.com/", wantErr: true},
+		{dsn: "ftp://public@sentry.example.com/42", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		endpoint, key, err := parseDSN(tt.dsn)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %s", tt.dsn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", tt.dsn, err)
+			continue
+		}
+		if endpoint != tt.endpoint || key != tt.key {
+			t.Errorf("parseDSN(%s) = %s, %s; expected %s, %s", tt.dsn, endpoint, key, tt.endpoint, tt.key)
+		}
+	}
+}
+
+func TestReportable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "database error", err: &errors.DatabaseError{Operation: "get", Table: "achievements", Cause: fmt.Errorf("timeout")}, want: true},
+		{name: "wrapped service error", err: fmt.Errorf("achievement a1: %w", &errors.ServiceError{Operation: "complete", Message: "failed"}), want: true},
+		{name: "not found", err: &errors.DatabaseError{Operation: "get", Table: "achievements", Cause: errors.ErrNotFound}, want: false},
+		{name: "validation error", err: &errors.ValidationError{Field: "title", Message: "required"}, want: false},
+		{name: "plain error", err: fmt.Errorf("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := Reportable(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestClient_ReportAndReportPanic(t *testing.T) {
+	var mu sync.Mutex
+	var auth []string
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		mu.Lock()
+		auth = append(auth, r.Header.Get("X-Sentry-Auth"))
+		received = append(received, event)
+		mu.Unlock()
+		if r.URL.Path != "/api/42/store/" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://public@", 1) + "/42"
+	client, err := New(dsn, "production", "1.2.3")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	fields := map[string]interface{}{"request_id": "req-1", "route": "/api/achievements/:id", "method": "GET", "path": "/api/achievements/a1", "table": "achievements"}
+	client.Report(&errors.DatabaseError{Operation: "get", Table: "achievements", Cause: fmt.Errorf("timeout")}, fields)
+	// 想定内のエラーは送らない
+	client.Report(&errors.ValidationError{Field: "title", Message: "required"}, fields)
+	client.ReportPanic("nil map", []byte("goroutine 1 [running]"), fields)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(received))
+	}
+	if !strings.Contains(auth[0], "sentry_key=public") || !strings.Contains(auth[0], "sentry_version=7") {
+		t.Errorf("Unexpected X-Sentry-Auth header %q", auth[0])
+	}
+
+	reported := received[0]
+	if reported.Level != "error" || reported.Environment != "production" || reported.Release != "1.2.3" || len(reported.EventID) != 32 {
+		t.Errorf("Unexpected event: %+v", reported)
+	}
+	if reported.Exception == nil || reported.Exception.Values[0].Type != "*errors.DatabaseError" {
+		t.Errorf("Expected the error type in the exception, got %+v", reported.Exception)
+	}
+	if reported.Tags["request_id"] != "req-1" || reported.Tags["route"] != "/api/achievements/:id" {
+		t.Errorf("Expected correlation fields as tags, got %v", reported.Tags)
+	}
+	if reported.Request == nil || reported.Request.URL != "/api/achievements/a1" || reported.Request.Method != "GET" {
+		t.Errorf("Expected the request, got %+v", reported.Request)
+	}
+	if reported.Extra["table"] != "achievements" {
+		t.Errorf("Expected other fields as extra, got %v", reported.Extra)
+	}
+
+	panicked := received[1]
+	if panicked.Level != "fatal" || panicked.Message != "nil map" || panicked.Extra["stack"] != "goroutine 1 [running]" {
+		t.Errorf("Unexpected panic event: %+v", panicked)
+	}
+
+	// 終了後の報告は送らずに捨てる
+	client.Report(&errors.ServiceError{Operation: "complete", Message: "failed"}, nil)
+}
+
+func TestClient_Flush(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	received := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		mu.Lock()
+		received++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://public@", 1) + "/42"
+	client, err := New(dsn, "production", "1.2.3")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	// 送信待ちが無い場合はすぐに戻る
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	client.Report(&errors.ServiceError{Operation: "complete", Message: "failed"}, nil)
+	client.Report(&errors.ServiceError{Operation: "redeem", Message: "failed"}, nil)
+
+	// 送り終える前に期限が来た場合はエラー
+	expired, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := client.Flush(expired); err == nil {
+		t.Fatal("Expected error when the flush deadline passes")
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	mu.Lock()
+	if received != 2 {
+		t.Errorf("Expected 2 events sent by the flush, got %d", received)
+	}
+	mu.Unlock()
+
+	// Flush の後も送信を続ける
+	client.Report(&errors.ServiceError{Operation: "refund", Message: "failed"}, nil)
+	if err := client.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if received != 3 {
+		t.Errorf("Expected 3 events, got %d", received)
+	}
+}
@@ -0,0 +1,57 @@
+package errors
+
+// Code APIのエラーレスポンスで返す機械可読なエラーコード
+//
+// クライアントはメッセージではなくこの値で分岐する。一度公開した値は変更しない。
+type Code string
+
+const (
+	// 入力・ビジネスルール
+	CodeValidation          Code = "VALIDATION_ERROR"
+	CodeBusinessRule        Code = "BUSINESS_RULE_VIOLATION"
+	CodeInsufficientPoints  Code = "INSUFFICIENT_POINTS"
+	CodePointsReserved      Code = "POINTS_RESERVED"
+	CodeDailyQuotaExceeded  Code = "DAILY_QUOTA_EXCEEDED"
+	CodeCompletionLimit     Code = "COMPLETION_LIMIT_REACHED"
+	CodeTimerAlreadyRunning Code = "TIMER_ALREADY_RUNNING"
+	CodeTimerNotRunning     Code = "TIMER_NOT_RUNNING"
+	CodeAlreadyRefunded     Code = "ALREADY_REFUNDED"
+	CodeNothingToUndo       Code = "NOTHING_TO_UNDO"
+	CodeNothingToRedo       Code = "NOTHING_TO_REDO"
+
+	// 見つからない（リソースがわかる場合はリソースごとのコード）
+	CodeNotFound             Code = "NOT_FOUND"
+	CodeAchievementNotFound  Code = "ACHIEVEMENT_NOT_FOUND"
+	CodeRewardNotFound       Code = "REWARD_NOT_FOUND"
+	CodeRedemptionNotFound   Code = "REDEMPTION_NOT_FOUND"
+	CodeGoalNotFound         Code = "GOAL_NOT_FOUND"
+	CodeQuestNotFound        Code = "QUEST_NOT_FOUND"
+	CodeAllowanceNotFound    Code = "ALLOWANCE_NOT_FOUND"
+	CodeNoteNotFound         Code = "NOTE_NOT_FOUND"
+	CodeWishlistItemNotFound Code = "WISHLIST_ITEM_NOT_FOUND"
+	CodeBackupNotFound       Code = "BACKUP_NOT_FOUND"
+	CodeAPIKeyNotFound       Code = "API_KEY_NOT_FOUND"
+
+	// 競合・認可・ストレージの状態
+	CodeDuplicateResource  Code = "DUPLICATE_RESOURCE"
+	CodeVersionConflict    Code = "VERSION_CONFLICT"
+	CodeForbidden          Code = "FORBIDDEN"
+	CodeUnauthorized       Code = "UNAUTHORIZED"
+	CodeInsufficientScope  Code = "INSUFFICIENT_SCOPE"
+	CodeIPNotAllowed       Code = "IP_NOT_ALLOWED"
+	CodeTenantRequired     Code = "TENANT_REQUIRED"
+	CodeInvalidTenant      Code = "INVALID_TENANT"
+	CodeReadOnly           Code = "READ_ONLY"
+	CodeServiceUnavailable Code = "SERVICE_UNAVAILABLE"
+	CodeThrottled          Code = "THROTTLED"
+	CodeRateLimited        Code = "RATE_LIMITED"
+	CodeInternal           Code = "INTERNAL_ERROR"
+)
+
+// ErrorCode ビジネスロジックエラーのエラーコード（Code を指定していない場合は CodeBusinessRule）
+func (e BusinessLogicError) ErrorCode() Code {
+	if e.Code == "" {
+		return CodeBusinessRule
+	}
+	return e.Code
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Common error types
@@ -12,6 +13,9 @@ var (
 	ErrInsufficientPoints = errors.New("insufficient points")
 	ErrDuplicateResource  = errors.New("resource already exists")
 	ErrDatabaseOperation  = errors.New("database operation failed")
+	ErrVersionConflict    = errors.New("resource was modified by another request")
+	ErrReadOnly           = errors.New("storage is read-only for maintenance")
+	ErrForbidden          = errors.New("operation requires an administrator")
 )
 
 // ValidationError バリデーションエラー
@@ -28,6 +32,8 @@ func (e ValidationError) Error() string {
 type BusinessLogicError struct {
 	Operation string
 	Reason    string
+	// Code APIのエラーレスポンスで返すエラーコード（空の場合は CodeBusinessRule）
+	Code Code
 }
 
 func (e BusinessLogicError) Error() string {
@@ -65,4 +71,23 @@ func (e ServiceError) Error() string {
 
 func (e ServiceError) Unwrap() error {
 	return e.Cause
-}
\ No newline at end of file
+}
+// UnavailableError ストレージが一時的に利用できない（RetryAfter の経過後に再試行できる）
+type UnavailableError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e UnavailableError) Error() string {
+	return fmt.Sprintf("service unavailable: %s (retry after %s)", e.Reason, e.RetryAfter)
+}
+
+// ThrottledError ストレージのスループットの上限を超えた（RetryAfter の経過後に再試行できる）
+type ThrottledError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e ThrottledError) Error() string {
+	return fmt.Sprintf("request throttled: %s (retry after %s)", e.Reason, e.RetryAfter)
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Bus 登録されたすべての購読者にイベントを配信するイベントバス
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []Publisher
+}
+
+// NewBus イベントバスを作成
+func NewBus(subscribers ...Publisher) *Bus {
+	return &Bus{subscribers: subscribers}
+}
+
+// Subscribe 購読者を追加
+func (b *Bus) Subscribe(subscriber Publisher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Publish すべての購読者にイベントを配信
+//
+// 一部の購読者が失敗しても残りの購読者には配信し、失敗をまとめて返す。
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	subscribers := append([]Publisher(nil), b.subscribers...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		if err := subscriber.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBus_Publish(t *testing.T) {
+	var received []string
+	record := func(name string, err error) Publisher {
+		return PublisherFunc(func(ctx context.Context, event Event) error {
+			received = append(received, name+":"+event.ID)
+			return err
+		})
+	}
+
+	failure := errors.New("subscriber failed")
+	bus := NewBus(record("first", failure))
+	bus.Subscribe(record("second", nil))
+
+	err := bus.Publish(context.Background(), Event{ID: "event-1"})
+
+	// 失敗した購読者があっても残りの購読者に配信されることを確認
+	if len(received) != 2 || received[0] != "first:event-1" || received[1] != "second:event-1" {
+		t.Errorf("Expected both subscribers to receive the event, got %v", received)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected subscriber error, got %v", err)
+	}
+}
+
+func TestBus_PublishWithoutSubscribers(t *testing.T) {
+	if err := NewBus().Publish(context.Background(), Event{ID: "event-1"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Action 変更の種類
+type Action string
+
+const (
+	// ActionCreated アイテムの作成
+	ActionCreated Action = "created"
+	// ActionUpdated アイテムの更新
+	ActionUpdated Action = "updated"
+	// ActionDeleted アイテムの削除（TTLによる自動削除を含む）
+	ActionDeleted Action = "deleted"
+	// ActionReached 目標の達成（目標サービスが通知する）
+	ActionReached Action = "reached"
+	// ActionReminded 未達成の達成目録のリマインド（リマインドのスケジューラーが通知する）
+	ActionReminded Action = "reminded"
+	// ActionGenerated サマリーの作成（サマリーサービスが通知する）
+	ActionGenerated Action = "generated"
+	// ActionDetected ポイントの差異の検出（整合性チェックが通知する）
+	ActionDetected Action = "detected"
+)
+
+// イベントの発生元
+const (
+	// SourceDynamoDBStream DynamoDB Streamsから取得したイベントの発生元
+	SourceDynamoDBStream = "dynamodb_stream"
+	// SourceGoalService 目標サービスが通知したイベントの発生元
+	SourceGoalService = "goal_service"
+	// SourceReminderService リマインドサービスが通知したイベントの発生元
+	SourceReminderService = "reminder_service"
+	// SourceSummaryService サマリーサービスが通知したイベントの発生元
+	SourceSummaryService = "summary_service"
+	// SourceConsistencyService 整合性チェックのサービスが通知したイベントの発生元
+	SourceConsistencyService = "consistency_service"
+	// SourceNotificationService 達成目録の作成・報酬の獲得の通知の発生元
+	SourceNotificationService = "notification_service"
+)
+
+// Event テーブルの変更イベント
+type Event struct {
+	// ID イベントの識別子（同じ変更が重複して配信された場合の判定に使用）
+	ID string `json:"id"`
+	// Type 「テーブルの識別子.変更の種類」（achievements.created など）
+	Type string `json:"type"`
+	// Table 設定ファイル上のテーブルの識別子（achievements など）
+	Table  string `json:"table"`
+	Action Action `json:"action"`
+	Key    map[string]interface{} `json:"key"`
+	// Item 変更後のアイテム（削除の場合は空）
+	Item map[string]interface{} `json:"item,omitempty"`
+	// Previous 変更前のアイテム（作成の場合は空）
+	Previous   map[string]interface{} `json:"previous,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Source     string                 `json:"source"`
+}
+
+// Publisher 変更イベントの配信先のインターフェース
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// PublisherFunc 関数をPublisherとして使用するためのアダプター
+type PublisherFunc func(ctx context.Context, event Event) error
+
+// Publish 関数を呼び出してイベントを配信
+func (f PublisherFunc) Publish(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
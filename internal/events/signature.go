@@ -0,0 +1,133 @@
+package events
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SignatureHeader Webhookの署名を送るヘッダー（t={UNIX秒},v1={HMAC-SHA256の16進数}）
+const SignatureHeader = "X-Webhook-Signature"
+
+// DefaultSignatureTolerance 署名の時刻として許容する既定のずれ
+const DefaultSignatureTolerance = 5 * time.Minute
+
+var (
+	// ErrInvalidSignature 署名が無い・形式が不正・秘密鍵と一致しない
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrSignatureExpired 署名の時刻が許容するずれを超えている
+	ErrSignatureExpired = errors.New("webhook signature timestamp is outside the tolerance")
+	// ErrReplayedSignature 同じ署名を検証済み（再送による再実行）
+	ErrReplayedSignature = errors.New("webhook signature was already used")
+)
+
+// Sign 本文の署名ヘッダーの値を作成
+//
+// 署名は「{UNIX秒}.{本文}」のHMAC-SHA256で、時刻を含めることで古いリクエストの再送を検証側で拒否できる。
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", unix, signature(secret, unix, body))
+}
+
+// signature 時刻と本文のHMAC-SHA256（16進数）
+func signature(secret string, unix int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(unix, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureVerifier 受信したWebhookの署名を検証する（受信するエンドポイントごとに作成する）
+//
+// 検証した署名は許容するずれの間だけ保持し、同じリクエストの再送を拒否する。
+type SignatureVerifier struct {
+	secret    string
+	tolerance time.Duration
+	now       func() time.Time
+
+	mu sync.Mutex
+	// seen 検証した署名と、保持する期限
+	seen map[string]time.Time
+}
+
+// NewSignatureVerifier 秘密鍵と許容する時刻のずれを指定して作成（tolerance が0以下の場合は DefaultSignatureTolerance）
+func NewSignatureVerifier(secret string, tolerance time.Duration) *SignatureVerifier {
+	if tolerance <= 0 {
+		tolerance = DefaultSignatureTolerance
+	}
+	return &SignatureVerifier{secret: secret, tolerance: tolerance, now: time.Now, seen: map[string]time.Time{}}
+}
+
+// Verify 署名ヘッダーの値と本文を検証する
+//
+// 秘密鍵を切り替える間は送信側が v1 を複数付けられるように、いずれか1つが一致すれば有効とする。
+func (v *SignatureVerifier) Verify(header string, body []byte) error {
+	unix, signatures, err := parseSignatureHeader(header)
+	if err != nil {
+		return err
+	}
+
+	now := v.now()
+	signedAt := time.Unix(unix, 0)
+	if now.Sub(signedAt) > v.tolerance || signedAt.Sub(now) > v.tolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := signature(v.secret, unix, body)
+	var matched bool
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			matched = true
+		}
+	}
+	if !matched {
+		return ErrInvalidSignature
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for sig, expiry := range v.seen {
+		if now.After(expiry) {
+			delete(v.seen, sig)
+		}
+	}
+	if _, ok := v.seen[expected]; ok {
+		return ErrReplayedSignature
+	}
+	v.seen[expected] = signedAt.Add(v.tolerance)
+	return nil
+}
+
+// parseSignatureHeader 署名ヘッダーの時刻と v1 の署名
+func parseSignatureHeader(header string) (int64, []string, error) {
+	var unix int64
+	var hasTimestamp bool
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return 0, nil, ErrInvalidSignature
+		}
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, nil, ErrInvalidSignature
+			}
+			unix, hasTimestamp = parsed, true
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if !hasTimestamp || len(signatures) == 0 {
+		return 0, nil, ErrInvalidSignature
+	}
+	return unix, signatures, nil
+}
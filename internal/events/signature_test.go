@@ -0,0 +1,52 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignatureVerifier_Verify(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"event-1"}`)
+
+	verifier := NewSignatureVerifier("secret", 5*time.Minute)
+	verifier.now = func() time.Time { return now }
+
+	header := Sign("secret", now.Add(-time.Minute), body)
+	if err := verifier.Verify(header, body); err != nil {
+		t.Fatalf("Expected valid signature, got %v", err)
+	}
+
+	// 同じ署名の再送は拒否する
+	if err := verifier.Verify(header, body); !errors.Is(err, ErrReplayedSignature) {
+		t.Errorf("Expected ErrReplayedSignature, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		header   string
+		body     []byte
+		expected error
+	}{
+		{name: "tampered body", header: Sign("secret", now, body), body: []byte(`{"id":"event-2"}`), expected: ErrInvalidSignature},
+		{name: "wrong secret", header: Sign("other", now, body), body: body, expected: ErrInvalidSignature},
+		{name: "too old", header: Sign("secret", now.Add(-6*time.Minute), body), body: body, expected: ErrSignatureExpired},
+		{name: "too far in the future", header: Sign("secret", now.Add(6*time.Minute), body), body: body, expected: ErrSignatureExpired},
+		{name: "missing signature", header: "", body: body, expected: ErrInvalidSignature},
+		{name: "missing timestamp", header: "v1=abc", body: body, expected: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifier.Verify(tt.header, tt.body); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// 秘密鍵の切り替え中は、いずれかの v1 が一致すれば有効
+	rotated := Sign("secret", now, []byte(`{"id":"event-3"}`)) + ",v1=" + signature("old", now.Unix(), []byte(`{"id":"event-3"}`))
+	if err := verifier.Verify(rotated, []byte(`{"id":"event-3"}`)); err != nil {
+		t.Errorf("Expected valid signature during rotation, got %v", err)
+	}
+}
@@ -0,0 +1,331 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"achievement-management/internal/logging"
+	"achievement-management/internal/repository"
+)
+
+// StreamsAPI DynamoDB Streams操作のインターフェース
+type StreamsAPI interface {
+	DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error)
+	GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error)
+	GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error)
+}
+
+// StreamSource 変更イベントを読み取るテーブルのストリーム
+type StreamSource struct {
+	// Table 設定ファイル上のテーブルの識別子（イベントの種類に使用）
+	Table     string
+	StreamARN string
+}
+
+// StreamConsumer DynamoDB Streamsのレコードを変更イベントとして配信する
+//
+// 起動後に発生した変更のみを配信し、読み取り位置は保存しない（停止中の変更は配信されない）。
+// 配信順はシャード内でのみ保証される。
+type StreamConsumer struct {
+	client            StreamsAPI
+	publisher         Publisher
+	logger            logging.Logger
+	pollInterval      time.Duration
+	discoveryInterval time.Duration
+}
+
+// NewStreamConsumer ストリームの読み取りを作成
+func NewStreamConsumer(client StreamsAPI, publisher Publisher, logger logging.Logger, pollInterval time.Duration) *StreamConsumer {
+	return &StreamConsumer{
+		client:            client,
+		publisher:         publisher,
+		logger:            logger,
+		pollInterval:      pollInterval,
+		discoveryInterval: 30 * time.Second,
+	}
+}
+
+// Run ctxがキャンセルされるまで各ストリームの変更を配信
+//
+// いずれかのストリームの読み取りが回復できないエラーで停止した場合は、すべての読み取りを停止してエラーを返す。
+func (c *StreamConsumer) Run(ctx context.Context, sources []StreamSource) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(sources))
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.consumeStream(ctx, source); err != nil {
+				errs[i] = err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// consumeStream ストリームのシャードを定期的に確認し、新しいシャードの読み取りを開始
+func (c *StreamConsumer) consumeStream(ctx context.Context, source StreamSource) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	failed := make(chan error, 1)
+	started := map[string]bool{}
+	initial := true
+
+	for {
+		shards, err := c.listShards(ctx, source.StreamARN)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		for _, shard := range shards {
+			shardID := aws.ToString(shard.ShardId)
+			if started[shardID] {
+				continue
+			}
+			started[shardID] = true
+
+			// 起動時に存在するシャードは最新の位置から、起動後に作成されたシャードは先頭から読む
+			iteratorType := types.ShardIteratorTypeTrimHorizon
+			if initial {
+				if isClosed(shard) {
+					continue
+				}
+				iteratorType = types.ShardIteratorTypeLatest
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := c.consumeShard(ctx, source, shardID, iteratorType); err != nil {
+					select {
+					case failed <- err:
+					default:
+					}
+				}
+			}()
+		}
+		initial = false
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-failed:
+			return err
+		case <-time.After(c.discoveryInterval):
+		}
+	}
+}
+
+// consumeShard シャードが閉じられるかctxがキャンセルされるまでレコードを読み取って配信
+func (c *StreamConsumer) consumeShard(ctx context.Context, source StreamSource, shardID string, iteratorType types.ShardIteratorType) error {
+	iterator, err := c.shardIterator(ctx, source.StreamARN, shardID, iteratorType, "")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	var lastSequence string
+	for iterator != "" {
+		resp, err := c.client.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{ShardIterator: aws.String(iterator)})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			var expired *types.ExpiredIteratorException
+			switch {
+			case errors.As(err, &expired):
+				// 期限切れのイテレーターは最後に配信したレコードの次から取り直す
+				if lastSequence != "" {
+					iterator, err = c.shardIterator(ctx, source.StreamARN, shardID, types.ShardIteratorTypeAfterSequenceNumber, lastSequence)
+				} else {
+					iterator, err = c.shardIterator(ctx, source.StreamARN, shardID, iteratorType, "")
+				}
+				if err != nil {
+					return err
+				}
+				continue
+			case repository.IsRetryable(err):
+				c.logger.Warnf("Retrying stream read of %s (shard %s): %v", source.Table, shardID, err)
+				if !c.wait(ctx) {
+					return nil
+				}
+				continue
+			default:
+				return fmt.Errorf("failed to read shard %s of %s stream: %w", shardID, source.Table, err)
+			}
+		}
+
+		for _, record := range resp.Records {
+			c.deliver(ctx, source, record)
+			if record.Dynamodb != nil {
+				lastSequence = aws.ToString(record.Dynamodb.SequenceNumber)
+			}
+		}
+
+		iterator = aws.ToString(resp.NextShardIterator)
+		if len(resp.Records) == 0 && iterator != "" && !c.wait(ctx) {
+			return nil
+		}
+	}
+
+	// NextShardIteratorが無い場合はシャードが閉じられている（後続のシャードはconsumeStreamが検出する）
+	return nil
+}
+
+// deliver レコードをイベントに変換して配信
+//
+// 配信に失敗したイベントはログに残して次のレコードに進む（シャードの読み取りを止めない）。
+func (c *StreamConsumer) deliver(ctx context.Context, source StreamSource, record types.Record) {
+	event, err := NewStreamEvent(source.Table, record)
+	if err != nil {
+		c.logger.Warnf("Skipping stream record %s of %s: %v", aws.ToString(record.EventID), source.Table, err)
+		return
+	}
+
+	if err := c.publisher.Publish(ctx, event); err != nil && ctx.Err() == nil {
+		c.logger.WithFields(map[string]interface{}{
+			"event_id":   event.ID,
+			"event_type": event.Type,
+		}).Errorf("Failed to publish change event: %v", err)
+	}
+}
+
+// listShards ストリームのすべてのシャードを取得
+func (c *StreamConsumer) listShards(ctx context.Context, streamARN string) ([]types.Shard, error) {
+	var shards []types.Shard
+	var startShardID *string
+
+	for {
+		resp, err := c.client.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             aws.String(streamARN),
+			ExclusiveStartShardId: startShardID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe stream %s: %w", streamARN, err)
+		}
+
+		shards = append(shards, resp.StreamDescription.Shards...)
+		startShardID = resp.StreamDescription.LastEvaluatedShardId
+		if startShardID == nil {
+			return shards, nil
+		}
+	}
+}
+
+// shardIterator シャードの読み取り位置を取得
+func (c *StreamConsumer) shardIterator(ctx context.Context, streamARN, shardID string, iteratorType types.ShardIteratorType, sequenceNumber string) (string, error) {
+	input := &dynamodbstreams.GetShardIteratorInput{
+		StreamArn:         aws.String(streamARN),
+		ShardId:           aws.String(shardID),
+		ShardIteratorType: iteratorType,
+	}
+	if sequenceNumber != "" {
+		input.SequenceNumber = aws.String(sequenceNumber)
+	}
+
+	resp, err := c.client.GetShardIterator(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to get iterator for shard %s: %w", shardID, err)
+	}
+	return aws.ToString(resp.ShardIterator), nil
+}
+
+// wait ポーリング間隔だけ待機（ctxがキャンセルされた場合はfalse）
+func (c *StreamConsumer) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(c.pollInterval):
+		return true
+	}
+}
+
+// isClosed シャードが閉じられている（新しいレコードが追加されない）かどうか
+func isClosed(shard types.Shard) bool {
+	return shard.SequenceNumberRange != nil && shard.SequenceNumberRange.EndingSequenceNumber != nil
+}
+
+// NewStreamEvent ストリームのレコードから変更イベントを作成
+func NewStreamEvent(table string, record types.Record) (Event, error) {
+	if record.Dynamodb == nil {
+		return Event{}, fmt.Errorf("stream record has no data")
+	}
+
+	var action Action
+	switch record.EventName {
+	case types.OperationTypeInsert:
+		action = ActionCreated
+	case types.OperationTypeModify:
+		action = ActionUpdated
+	case types.OperationTypeRemove:
+		action = ActionDeleted
+	default:
+		return Event{}, fmt.Errorf("unknown stream operation: %s", record.EventName)
+	}
+
+	key, err := decodeImage(record.Dynamodb.Keys)
+	if err != nil {
+		return Event{}, err
+	}
+	item, err := decodeImage(record.Dynamodb.NewImage)
+	if err != nil {
+		return Event{}, err
+	}
+	previous, err := decodeImage(record.Dynamodb.OldImage)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:         aws.ToString(record.EventID),
+		Type:       fmt.Sprintf("%s.%s", table, action),
+		Table:      table,
+		Action:     action,
+		Key:        key,
+		Item:       item,
+		Previous:   previous,
+		OccurredAt: aws.ToTime(record.Dynamodb.ApproximateCreationDateTime),
+		Source:     SourceDynamoDBStream,
+	}, nil
+}
+
+// decodeImage ストリームのアイテムを汎用的なマップに変換
+func decodeImage(image map[string]types.AttributeValue) (map[string]interface{}, error) {
+	if len(image) == 0 {
+		return nil, nil
+	}
+
+	converted, err := attributevalue.FromDynamoDBStreamsMap(image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert stream image: %w", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := attributevalue.UnmarshalMap(converted, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stream image: %w", err)
+	}
+	return decoded, nil
+}
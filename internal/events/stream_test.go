@@ -0,0 +1,178 @@
+package events
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/logging"
+)
+
+// MockStreamsClient DynamoDB Streamsクライアントのモック
+//
+// シャードごとにGetRecordsの応答を順に返し、応答が尽きた後は空の応答を返す。
+type MockStreamsClient struct {
+	mu        sync.Mutex
+	shards    []types.Shard
+	records   map[string][][]types.Record
+	expired   map[string]bool
+	iterators []*dynamodbstreams.GetShardIteratorInput
+}
+
+func (m *MockStreamsClient) DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error) {
+	return &dynamodbstreams.DescribeStreamOutput{
+		StreamDescription: &types.StreamDescription{Shards: m.shards},
+	}, nil
+}
+
+func (m *MockStreamsClient) GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.iterators = append(m.iterators, params)
+	return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: params.ShardId}, nil
+}
+
+func (m *MockStreamsClient) GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	shardID := aws.ToString(params.ShardIterator)
+	if m.expired[shardID] {
+		delete(m.expired, shardID)
+		return nil, &types.ExpiredIteratorException{Message: aws.String("iterator expired")}
+	}
+
+	var records []types.Record
+	if pending := m.records[shardID]; len(pending) > 0 {
+		records = pending[0]
+		m.records[shardID] = pending[1:]
+	}
+	return &dynamodbstreams.GetRecordsOutput{Records: records, NextShardIterator: params.ShardIterator}, nil
+}
+
+func testLogger() logging.Logger {
+	cfg := &config.Config{Logging: config.LoggingConfig{Level: "error", Format: "json"}}
+	return logging.NewLoggerWithOutput(cfg, io.Discard)
+}
+
+func streamRecord(eventID string, operation types.OperationType, sequence string, newImage, oldImage map[string]types.AttributeValue) types.Record {
+	return types.Record{
+		EventID:   aws.String(eventID),
+		EventName: operation,
+		Dynamodb: &types.StreamRecord{
+			Keys:                        map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "achievement-1"}},
+			NewImage:                    newImage,
+			OldImage:                    oldImage,
+			SequenceNumber:              aws.String(sequence),
+			ApproximateCreationDateTime: aws.Time(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)),
+		},
+	}
+}
+
+func TestNewStreamEvent(t *testing.T) {
+	image := map[string]types.AttributeValue{
+		"id":    &types.AttributeValueMemberS{Value: "achievement-1"},
+		"point": &types.AttributeValueMemberN{Value: "10"},
+	}
+
+	event, err := NewStreamEvent("achievements", streamRecord("event-1", types.OperationTypeModify, "100", image, image))
+	if err != nil {
+		t.Fatalf("NewStreamEvent failed: %v", err)
+	}
+
+	if event.Type != "achievements.updated" || event.Action != ActionUpdated {
+		t.Errorf("Unexpected event type: %s (%s)", event.Type, event.Action)
+	}
+	if event.Key["id"] != "achievement-1" || event.Item["point"] != float64(10) || event.Previous == nil {
+		t.Errorf("Unexpected images: key=%v item=%v previous=%v", event.Key, event.Item, event.Previous)
+	}
+	if event.Source != SourceDynamoDBStream || event.OccurredAt.IsZero() {
+		t.Errorf("Unexpected source or time: %s %s", event.Source, event.OccurredAt)
+	}
+
+	removed, err := NewStreamEvent("achievements", streamRecord("event-2", types.OperationTypeRemove, "101", nil, image))
+	if err != nil {
+		t.Fatalf("NewStreamEvent failed: %v", err)
+	}
+	if removed.Action != ActionDeleted || removed.Item != nil {
+		t.Errorf("Expected deleted event without item, got %+v", removed)
+	}
+
+	if _, err := NewStreamEvent("achievements", types.Record{EventID: aws.String("event-3")}); err == nil {
+		t.Error("Expected error for record without data")
+	}
+}
+
+func TestStreamConsumer_Run(t *testing.T) {
+	image := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "achievement-1"}}
+	client := &MockStreamsClient{
+		shards: []types.Shard{
+			{ShardId: aws.String("open")},
+			// 起動時に閉じられているシャードは読み取らない
+			{ShardId: aws.String("closed"), SequenceNumberRange: &types.SequenceNumberRange{EndingSequenceNumber: aws.String("50")}},
+		},
+		records: map[string][][]types.Record{
+			"open": {
+				{streamRecord("event-1", types.OperationTypeInsert, "100", image, nil)},
+				{streamRecord("event-2", types.OperationTypeRemove, "101", nil, image)},
+			},
+		},
+		// 1件目の配信後にイテレーターが期限切れになる
+		expired: map[string]bool{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []Event
+	publisher := PublisherFunc(func(ctx context.Context, event Event) error {
+		received = append(received, event)
+		if len(received) == 1 {
+			client.expired["open"] = true
+		}
+		if len(received) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	consumer := NewStreamConsumer(client, publisher, testLogger(), time.Millisecond)
+	done := make(chan error, 1)
+	go func() {
+		done <- consumer.Run(ctx, []StreamSource{{Table: "achievements", StreamARN: "arn:aws:dynamodb:stream/achievements"}})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop after the context was cancelled")
+	}
+
+	if len(received) != 2 || received[0].Type != "achievements.created" || received[1].Type != "achievements.deleted" {
+		t.Fatalf("Unexpected events: %+v", received)
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.iterators) != 2 {
+		t.Fatalf("Expected initial and renewed iterators, got %d", len(client.iterators))
+	}
+	if client.iterators[0].ShardIteratorType != types.ShardIteratorTypeLatest {
+		t.Errorf("Expected LATEST iterator at startup, got %s", client.iterators[0].ShardIteratorType)
+	}
+	// 期限切れのイテレーターは最後に配信したレコードの次から取り直す
+	renewed := client.iterators[1]
+	if renewed.ShardIteratorType != types.ShardIteratorTypeAfterSequenceNumber || aws.ToString(renewed.SequenceNumber) != "100" {
+		t.Errorf("Expected AFTER_SEQUENCE_NUMBER 100, got %s %s", renewed.ShardIteratorType, aws.ToString(renewed.SequenceNumber))
+	}
+}
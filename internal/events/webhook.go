@@ -0,0 +1,83 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// defaultWebhookTimeout Webhook送信のタイムアウト
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookPublisher イベントをJSONとしてURLにPOSTする配信先
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+	// secret 本文に署名する秘密鍵（空の場合は署名しない）
+	secret string
+	now    func() time.Time
+}
+
+// NewWebhookPublisher Webhookの配信先を作成（clientがnilの場合はタイムアウト付きのクライアントを使用）
+func NewWebhookPublisher(url string, client *http.Client) *WebhookPublisher {
+	return NewSignedWebhookPublisher(url, "", client)
+}
+
+// NewSignedWebhookPublisher 本文に secret で署名して送るWebhookの配信先を作成（secret が空の場合は署名しない）
+func NewSignedWebhookPublisher(url, secret string, client *http.Client) *WebhookPublisher {
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &WebhookPublisher{url: url, client: client, secret: secret, now: time.Now}
+}
+
+// NewWebhookBus URLごとのWebhookに配信するイベントバスを作成（URLが空の場合は何も配信しない）
+//
+// secretFor は送信先ごとの署名の秘密鍵を返す（nil の場合や空を返した送信先には署名しない）。
+func NewWebhookBus(urls []string, secretFor func(url string) string) *Bus {
+	bus := NewBus()
+	for _, url := range urls {
+		var secret string
+		if secretFor != nil {
+			secret = secretFor(url)
+		}
+		bus.Subscribe(NewSignedWebhookPublisher(url, secret, nil))
+	}
+	return bus
+}
+
+// Publish イベントをPOSTし、2xx以外の応答をエラーとして返す
+func (p *WebhookPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", event.ID)
+	req.Header.Set("X-Event-Type", event.Type)
+	if p.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(p.secret, p.now(), body))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event %s to %s: %w", event.ID, p.url, err)
+	}
+	defer resp.Body.Close()
+	// 接続を再利用できるように本文を読み捨てる
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d for event %s", p.url, resp.StatusCode, event.ID)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	var received Event
+	var eventType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		eventType = r.Header.Get("X-Event-Type")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	event := Event{
+		ID:     "event-1",
+		Type:   "achievements.created",
+		Table:  "achievements",
+		Action: ActionCreated,
+		Key:    map[string]interface{}{"id": "achievement-1"},
+		Source: SourceDynamoDBStream,
+	}
+	if err := NewWebhookPublisher(server.URL, nil).Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if eventType != "achievements.created" {
+		t.Errorf("Expected X-Event-Type header, got %q", eventType)
+	}
+	if received.ID != "event-1" || received.Key["id"] != "achievement-1" {
+		t.Errorf("Unexpected payload: %+v", received)
+	}
+}
+
+func TestWebhookPublisher_Signed(t *testing.T) {
+	verifier := NewSignatureVerifier("endpoint-secret", 0)
+	var verifyErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verifyErr = verifier.Verify(r.Header.Get(SignatureHeader), body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := NewSignedWebhookPublisher(server.URL, "endpoint-secret", nil).Publish(context.Background(), Event{ID: "event-1"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if verifyErr != nil {
+		t.Errorf("Expected a valid signature, got %v", verifyErr)
+	}
+}
+
+func TestWebhookPublisher_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookPublisher(server.URL, nil).Publish(context.Background(), Event{ID: "event-1"}); err == nil {
+		t.Error("Expected error for non-2xx response")
+	}
+}
+
+func TestNewWebhookBus(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookBus([]string{server.URL, server.URL + "/second"}, nil).Publish(context.Background(), Event{ID: "event-1"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected one request per URL, got %d", calls)
+	}
+
+	// URLがない場合は何も配信しない
+	if err := NewWebhookBus(nil, nil).Publish(context.Background(), Event{ID: "event-2"}); err != nil {
+		t.Errorf("Expected no error without subscribers, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"achievement-management/internal/models"
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,7 +25,7 @@ func setupTestServer() (*Server, *MockAchievementService, *MockRewardService, *M
 	mockRewardService := &MockRewardService{}
 	mockPointService := &MockPointService{}
 	
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 	
 	return server, mockAchievementService, mockRewardService, mockPointService
 }
@@ -51,6 +52,75 @@ func TestCreateAchievement(t *testing.T) {
 			},
 			expectedStatus: http.StatusCreated,
 		},
+		{
+			name: "呼び出し側で指定したIDで作成",
+			requestBody: CreateAchievementRequest{
+				ID:          "01J00000000000000000000000",
+				Title:       "テスト達成目録",
+				Description: "テスト用の達成目録です",
+				Point:       100,
+			},
+			setupMock: func() {
+				mockAchievementService.On("Create", mock.MatchedBy(func(achievement *models.Achievement) bool {
+					return achievement.ID == "01J00000000000000000000000"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "期限とリマインドを指定して作成",
+			requestBody: CreateAchievementRequest{
+				Title:    "確定申告",
+				Point:    100,
+				DueDate:  "2024-03-15",
+				Reminder: "0 9 * * *",
+			},
+			setupMock: func() {
+				mockAchievementService.On("Create", mock.MatchedBy(func(achievement *models.Achievement) bool {
+					return achievement.DueDate == "2024-03-15" && achievement.Reminder == "0 9 * * *"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "難易度を指定して作成",
+			requestBody: CreateAchievementRequest{
+				Title:      "フルマラソン",
+				Point:      500,
+				Difficulty: "hard",
+			},
+			setupMock: func() {
+				mockAchievementService.On("Create", mock.MatchedBy(func(achievement *models.Achievement) bool {
+					return achievement.Difficulty == models.DifficultyHard
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "達成できる回数の上限を指定して作成",
+			requestBody: CreateAchievementRequest{
+				Title:     "水を飲んだ",
+				Point:     1,
+				MaxPerDay: 8,
+			},
+			setupMock: func() {
+				mockAchievementService.On("Create", mock.MatchedBy(func(achievement *models.Achievement) bool {
+					return achievement.MaxPerDay == 8 && achievement.MaxTotal == 0
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "達成できる回数の上限が負の場合",
+			requestBody: CreateAchievementRequest{
+				Title:     "水を飲んだ",
+				Point:     1,
+				MaxPerDay: -1,
+			},
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation_error",
+		},
 		{
 			name: "タイトルが空の場合",
 			requestBody: CreateAchievementRequest{
@@ -90,6 +160,19 @@ func TestCreateAchievement(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  "internal_error",
 		},
+		{
+			name: "同じIDが既に存在する場合",
+			requestBody: CreateAchievementRequest{
+				Title:       "テスト達成目録",
+				Description: "テスト用の達成目録です",
+				Point:       100,
+			},
+			setupMock: func() {
+				mockAchievementService.On("Create", mock.AnythingOfType("*models.Achievement")).Return(errors.ErrDuplicateResource)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "conflict",
+		},
 	}
 
 	for _, tt := range tests {
@@ -216,6 +299,106 @@ func TestListAchievements(t *testing.T) {
 	}
 }
 
+func TestReorderAchievements(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+
+	mockAchievementService.On("Reorder", []string{"test-id-2", "test-id-1"}).Return([]*models.Achievement{
+		{ID: "test-id-3", Title: "固定", Point: 10, Pinned: true},
+		{ID: "test-id-2", Title: "達成目録2", Point: 200, SortOrder: 1},
+		{ID: "test-id-1", Title: "達成目録1", Point: 100, SortOrder: 2},
+	}, nil)
+
+	body, _ := json.Marshal(ReorderAchievementsRequest{IDs: []string{"test-id-2", "test-id-1"}})
+	req := httptest.NewRequest(http.MethodPut, "/api/achievements/order", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ListAchievementsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.Count)
+	assert.True(t, response.Achievements[0].Pinned)
+	assert.Equal(t, 1, response.Achievements[1].SortOrder)
+	mockAchievementService.AssertExpectations(t)
+}
+
+func TestReorderAchievements_UnknownID(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+
+	mockAchievementService.On("Reorder", []string{"missing"}).Return(nil, fmt.Errorf("achievement missing: %w", errors.ErrNotFound))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/achievements/order", bytes.NewBufferString(`{"ids": ["missing"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCountAchievements(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+
+	tests := []struct {
+		name           string
+		setupMock      func()
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "正常な件数取得",
+			setupMock: func() {
+				mockAchievementService.On("Count").Return(42, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  42,
+		},
+		{
+			name: "サービスエラー",
+			setupMock: func() {
+				mockAchievementService.On("Count").Return(0, &errors.DatabaseError{
+					Operation: "Count",
+					Table:     "achievements",
+					Cause:     errors.ErrDatabaseOperation,
+				})
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックのセットアップ
+			tt.setupMock()
+
+			// リクエストの作成（/:id ではなく件数取得として扱われること）
+			req := httptest.NewRequest(http.MethodGet, "/api/achievements/count", nil)
+			w := httptest.NewRecorder()
+
+			// リクエストの実行
+			server.GetRouter().ServeHTTP(w, req)
+
+			// ステータスコードの検証
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// 正常な場合のレスポンス検証
+			if tt.expectedStatus == http.StatusOK {
+				var response CountResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, response.Count)
+			}
+
+			// モックの検証
+			mockAchievementService.AssertExpectations(t)
+			mockAchievementService.AssertNotCalled(t, "GetByID", "count")
+
+			// モックのリセット
+			mockAchievementService.ExpectedCalls = nil
+		})
+	}
+}
+
 func TestGetAchievement(t *testing.T) {
 	server, mockAchievementService, _, _ := setupTestServer()
 
@@ -295,6 +478,7 @@ func TestUpdateAchievement(t *testing.T) {
 	tests := []struct {
 		name           string
 		achievementID  string
+		query          string
 		requestBody    interface{}
 		setupMock      func()
 		expectedStatus int
@@ -344,6 +528,63 @@ func TestUpdateAchievement(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:          "他の更新と競合",
+			achievementID: "test-id",
+			requestBody: UpdateAchievementRequest{
+				Title:       "タイトル",
+				Description: "説明",
+				Point:       100,
+				Version:     1,
+			},
+			setupMock: func() {
+				mockAchievementService.On("Update", "test-id", mock.MatchedBy(func(a *models.Achievement) bool {
+					return a.Version == 1
+				})).Return(errors.ErrVersionConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:          "減らしたポイントの残高が不足",
+			achievementID: "spent-id",
+			requestBody: UpdateAchievementRequest{
+				Title:       "タイトル",
+				Description: "説明",
+				Point:       10,
+			},
+			setupMock: func() {
+				mockAchievementService.On("Update", "spent-id", mock.AnythingOfType("*models.Achievement")).
+					Return(&errors.BusinessLogicError{Operation: "Update", Reason: "insufficient points"})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "ポイントの差分を反映せずに更新",
+			achievementID: "keep-id",
+			query:         "?adjust_points=false",
+			requestBody: UpdateAchievementRequest{
+				Title:       "タイトル",
+				Description: "説明",
+				Point:       50,
+			},
+			setupMock: func() {
+				mockAchievementService.On("UpdateWithoutPoints", "keep-id", mock.AnythingOfType("*models.Achievement")).Return(nil)
+				mockAchievementService.On("GetByID", "keep-id").Return(&models.Achievement{ID: "keep-id", Title: "タイトル", Point: 50}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "adjust_pointsが真偽値でない",
+			achievementID: "test-id",
+			query:         "?adjust_points=maybe",
+			requestBody: UpdateAchievementRequest{
+				Title:       "タイトル",
+				Description: "説明",
+				Point:       100,
+			},
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -353,7 +594,7 @@ func TestUpdateAchievement(t *testing.T) {
 
 			// リクエストボディの作成
 			body, _ := json.Marshal(tt.requestBody)
-			url := "/api/achievements/" + tt.achievementID
+			url := "/api/achievements/" + tt.achievementID + tt.query
 			req := httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
 			req.Header.Set("Content-Type", "application/json")
 
@@ -381,6 +622,7 @@ func TestDeleteAchievement(t *testing.T) {
 	tests := []struct {
 		name           string
 		achievementID  string
+		query          string
 		setupMock      func()
 		expectedStatus int
 	}{
@@ -400,6 +642,31 @@ func TestDeleteAchievement(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:          "ポイントを減算して削除",
+			achievementID: "test-id",
+			query:         "?adjust_points=true",
+			setupMock: func() {
+				mockAchievementService.On("DeleteWithPoints", "test-id").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "減算するポイントが残高に足りない",
+			achievementID: "test-id",
+			query:         "?adjust_points=true",
+			setupMock: func() {
+				mockAchievementService.On("DeleteWithPoints", "test-id").Return(&errors.BusinessLogicError{Operation: "DeleteWithPoints", Reason: "insufficient points"})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "adjust_pointsが真偽値でない",
+			achievementID:  "test-id",
+			query:          "?adjust_points=maybe",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -408,7 +675,7 @@ func TestDeleteAchievement(t *testing.T) {
 			tt.setupMock()
 
 			// リクエストの作成
-			url := "/api/achievements/" + tt.achievementID
+			url := "/api/achievements/" + tt.achievementID + tt.query
 			req := httptest.NewRequest(http.MethodDelete, url, nil)
 			w := httptest.NewRecorder()
 
@@ -433,4 +700,171 @@ func TestDeleteAchievement(t *testing.T) {
 			mockAchievementService.ExpectedCalls = nil
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestDeleteAchievement_AdjustPointsByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAchievementService := &MockAchievementService{}
+	cfg := testConfig()
+	cfg.Points.AdjustOnDelete = true
+	server := NewServer(mockAchievementService, &MockRewardService{}, &MockPointService{}, cfg)
+
+	// 設定で有効な場合は adjust_points を指定しなくても減算する
+	mockAchievementService.On("DeleteWithPoints", "test-id").Return(nil)
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/achievements/test-id", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// adjust_points=false で減算せずに削除できる
+	mockAchievementService.On("Delete", "other-id").Return(nil)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/achievements/other-id?adjust_points=false", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockAchievementService.AssertExpectations(t)
+}
+
+func TestCompleteAchievement(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	completedAt := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	mockAchievementService.On("Complete", "test-id").Return(&models.Completion{
+		ID:               "completion-id",
+		AchievementID:    "test-id",
+		AchievementTitle: "朝のランニング",
+		Point:            30,
+		BonusPoint:       10,
+		CompletedAt:      completedAt,
+	}, nil)
+	mockAchievementService.On("GetStreak", "test-id").Return(&models.Streak{Current: 7, Longest: 7, LastCompletedOn: "2024-06-01"}, nil).Once()
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/achievements/test-id/complete", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response CompleteAchievementResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "completion-id", response.ID)
+	assert.Equal(t, "test-id", response.AchievementID)
+	assert.Equal(t, 30, response.Point)
+	assert.Equal(t, 10, response.BonusPoint)
+	assert.True(t, completedAt.Equal(response.CompletedAt))
+	assert.Equal(t, &StreakResponse{Current: 7, Longest: 7, LastCompletedOn: "2024-06-01"}, response.Streak)
+
+	// 達成は記録済みのため、連続達成日数を取得できなくても成功として返す
+	mockAchievementService.On("GetStreak", "test-id").Return(nil, errors.ErrNotFound).Once()
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/achievements/test-id/complete", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), `"streak"`)
+
+	// 存在しない達成目録は達成できない
+	mockAchievementService.On("Complete", "missing").Return(nil, errors.ErrNotFound)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/achievements/missing/complete", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockAchievementService.AssertExpectations(t)
+}
+
+func TestAchievementTimer(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	startedAt := time.Date(2024, 6, 1, 7, 0, 0, 0, time.UTC)
+
+	mockAchievementService.On("StartTimer", "test-id").Return(&models.Achievement{
+		ID:             "test-id",
+		Title:          "ピアノの練習",
+		Point:          10,
+		PointsPerHour:  40,
+		TimerStartedAt: &startedAt,
+	}, nil)
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/achievements/test-id/timer/start", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var achievement AchievementResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &achievement))
+	assert.Equal(t, 40, achievement.PointsPerHour)
+	if assert.NotNil(t, achievement.TimerStartedAt) {
+		assert.True(t, startedAt.Equal(*achievement.TimerStartedAt))
+	}
+
+	mockAchievementService.On("StopTimer", "test-id").Return(&models.Completion{
+		ID:               "completion-id",
+		AchievementID:    "test-id",
+		AchievementTitle: "ピアノの練習",
+		Point:            60,
+		CompletedAt:      startedAt.Add(90 * time.Minute),
+		StartedAt:        &startedAt,
+		DurationSeconds:  5400,
+	}, nil)
+	mockAchievementService.On("GetStreak", "test-id").Return(&models.Streak{Current: 1, Longest: 1, LastCompletedOn: "2024-06-01"}, nil)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/achievements/test-id/timer/stop", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var completion CompleteAchievementResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &completion))
+	assert.Equal(t, 60, completion.Point)
+	assert.Equal(t, 5400, completion.DurationSeconds)
+	assert.Equal(t, 1, completion.Streak.Current)
+
+	// タイマーが動いていない場合は400、同時に止めた場合は409
+	mockAchievementService.On("StopTimer", "idle").Return(nil, &errors.BusinessLogicError{Operation: "StopTimer", Reason: "timer is not running"})
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/achievements/idle/timer/stop", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockAchievementService.On("StartTimer", "racing").Return(nil, errors.ErrVersionConflict)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/achievements/racing/timer/start", nil))
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	mockAchievementService.AssertExpectations(t)
+}
+
+func TestListCompletions(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+
+	mockAchievementService.On("ListCompletions", "test-id").Return([]*models.Completion{
+		{ID: "c1", AchievementID: "test-id", Point: 30},
+		{ID: "c2", AchievementID: "test-id", Point: 30},
+	}, nil)
+	mockAchievementService.On("GetStreak", "test-id").Return(&models.Streak{Current: 0, Longest: 2, LastCompletedOn: "2024-06-01"}, nil)
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/achievements/test-id/completions", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ListCompletionsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, "c2", response.Completions[1].ID)
+	assert.Equal(t, 2, response.Streak.Longest)
+
+	mockAchievementService.AssertExpectations(t)
+}
+
+func TestGetStreaks(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+
+	mockAchievementService.On("GetStreaks").Return(&models.StreakSummary{
+		Global: models.Streak{Current: 4, Longest: 9, LastCompletedOn: "2024-06-10"},
+		Achievements: []models.AchievementStreak{
+			{AchievementID: "read", AchievementTitle: "読書", Streak: models.Streak{Current: 3, Longest: 3, LastCompletedOn: "2024-06-10"}},
+		},
+	}, nil)
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/streaks", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response StreaksResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 4, response.Global.Current)
+	assert.Equal(t, 9, response.Global.Longest)
+	assert.Len(t, response.Achievements, 1)
+	assert.Equal(t, "read", response.Achievements[0].AchievementID)
+	assert.Equal(t, 3, response.Achievements[0].Current)
+	assert.Contains(t, w.Body.String(), `"achievements":[{"achievement_id":"read","achievement_title":"読書","current":3`)
+
+	mockAchievementService.AssertExpectations(t)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableAllowances 定期的にポイントを付与するお小遣いのルールのエンドポイントを登録（付与はスケジューラーが行う）
+func (s *Server) EnableAllowances(allowances services.AllowanceService) {
+	s.allowanceService = allowances
+
+	group := s.api.Group("/allowances")
+	{
+		group.POST("", s.createAllowance)
+		group.GET("", s.listAllowances)
+		group.DELETE("/:id", s.deleteAllowance)
+	}
+}
+
+// createAllowance POST /api/allowances - お小遣いのルール作成
+func (s *Server) createAllowance(c *gin.Context) {
+	var req AllowanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	allowance := req.ToModel()
+	if err := s.allowanceService.Create(c.Request.Context(), allowance); err != nil {
+		s.requestErrorLogger(c).LogServiceError("allowance", "create", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"allowance_id": allowance.ID,
+		"points":       allowance.Points,
+		"schedule":     allowance.Schedule,
+	}).Info("Allowance created successfully")
+
+	c.JSON(http.StatusCreated, newAllowanceResponse(allowance))
+}
+
+// listAllowances GET /api/allowances - お小遣いのルール一覧取得
+func (s *Server) listAllowances(c *gin.Context) {
+	allowances, err := s.allowanceService.List(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]AllowanceResponse, len(allowances))
+	for i, allowance := range allowances {
+		response[i] = newAllowanceResponse(allowance)
+	}
+
+	c.JSON(http.StatusOK, ListAllowancesResponse{
+		Allowances: response,
+		Count:      len(response),
+	})
+}
+
+// deleteAllowance DELETE /api/allowances/{id} - お小遣いのルール削除（付与済みのポイントは取り消さない）
+func (s *Server) deleteAllowance(c *gin.Context) {
+	if err := s.allowanceService.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Allowance deleted successfully",
+	})
+}
+
+// AllowanceRequest お小遣いのルール作成リクエスト
+type AllowanceRequest struct {
+	Title  string `json:"title" binding:"required"`
+	Points int    `json:"points" binding:"required,min=1"`
+	// Schedule 付与する日時のcron式（例: 毎週月曜日の9時は "0 9 * * 1"）
+	Schedule string `json:"schedule" binding:"required"`
+}
+
+// ToModel リクエストをモデルに変換
+func (r *AllowanceRequest) ToModel() *models.Allowance {
+	return &models.Allowance{
+		Title:    r.Title,
+		Points:   r.Points,
+		Schedule: r.Schedule,
+	}
+}
+
+// AllowanceResponse お小遣いのルールのレスポンス
+type AllowanceResponse struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Points        int        `json:"points"`
+	Schedule      string     `json:"schedule"`
+	LastGrantedAt *time.Time `json:"last_granted_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// newAllowanceResponse お小遣いのルールをレスポンスに変換
+func newAllowanceResponse(allowance *models.Allowance) AllowanceResponse {
+	return AllowanceResponse{
+		ID:            allowance.ID,
+		Title:         allowance.Title,
+		Points:        allowance.Points,
+		Schedule:      allowance.Schedule,
+		LastGrantedAt: allowance.LastGrantedAt,
+		CreatedAt:     allowance.CreatedAt,
+	}
+}
+
+// ListAllowancesResponse お小遣いのルール一覧レスポンス
+type ListAllowancesResponse struct {
+	Allowances []AllowanceResponse `json:"allowances"`
+	Count      int                 `json:"count"`
+}
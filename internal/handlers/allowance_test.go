@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockAllowanceService モックのお小遣いのルールのサービス
+type MockAllowanceService struct {
+	mock.Mock
+}
+
+func (m *MockAllowanceService) Create(ctx context.Context, allowance *models.Allowance) error {
+	args := m.Called(allowance)
+	return args.Error(0)
+}
+
+func (m *MockAllowanceService) List(ctx context.Context) ([]*models.Allowance, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Allowance), args.Error(1)
+}
+
+func (m *MockAllowanceService) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAllowanceService) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	args := m.Called(at)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCreateAllowance(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	allowanceService := &MockAllowanceService{}
+	server.EnableAllowances(allowanceService)
+
+	allowanceService.On("Create", mock.MatchedBy(func(allowance *models.Allowance) bool {
+		allowance.ID = "allowance-1"
+		return allowance.Title == "お小遣い" && allowance.Points == 50 && allowance.Schedule == "0 9 * * 1"
+	})).Return(nil)
+
+	body := `{"title": "お小遣い", "points": 50, "schedule": "0 9 * * 1"}`
+	req := httptest.NewRequest("POST", "/api/allowances", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var response AllowanceResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "allowance-1", response.ID)
+	assert.Nil(t, response.LastGrantedAt)
+	allowanceService.AssertExpectations(t)
+}
+
+func TestCreateAllowance_ValidationError(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	allowanceService := &MockAllowanceService{}
+	server.EnableAllowances(allowanceService)
+
+	for _, body := range []string{
+		`{"title": "お小遣い", "schedule": "0 9 * * 1"}`,
+		`{"title": "お小遣い", "points": -5, "schedule": "0 9 * * 1"}`,
+		`{"title": "お小遣い", "points": 50}`,
+	} {
+		req := httptest.NewRequest("POST", "/api/allowances", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+
+	// 解析できないcron式はサービスのバリデーションエラー
+	allowanceService.On("Create", mock.Anything).Return(&errors.ValidationError{Field: "schedule", Message: "invalid cron expression"})
+	req := httptest.NewRequest("POST", "/api/allowances", strings.NewReader(`{"title": "お小遣い", "points": 50, "schedule": "every monday"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestListAllowances(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	allowanceService := &MockAllowanceService{}
+	server.EnableAllowances(allowanceService)
+
+	grantedAt := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	allowanceService.On("List").Return([]*models.Allowance{
+		{ID: "allowance-1", Title: "お小遣い", Points: 50, Schedule: "0 9 * * 1", LastGrantedAt: &grantedAt},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/allowances", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListAllowancesResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	require.NotNil(t, response.Allowances[0].LastGrantedAt)
+	assert.True(t, grantedAt.Equal(*response.Allowances[0].LastGrantedAt))
+}
+
+func TestDeleteAllowance_NotFound(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	allowanceService := &MockAllowanceService{}
+	server.EnableAllowances(allowanceService)
+
+	allowanceService.On("Delete", "missing").Return(errors.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("DELETE", "/api/allowances/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
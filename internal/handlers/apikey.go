@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/tenant"
+)
+
+// APIKeyHeader /api へのリクエストでAPIキーを指定するヘッダー（管理用のBearerトークンとは別に指定できる）
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyContextKey 認証したAPIキーをリクエストに保持するキー
+const apiKeyContextKey = "api_key"
+
+// apiKeyRouteScope ルートグループに必要なAPIキーの範囲
+type apiKeyRouteScope struct {
+	// prefix ルートグループのパス（ginのルートのパターンで比較する）
+	prefix string
+	// read GET・HEAD・OPTIONS に必要な範囲
+	read models.APIKeyScope
+	// write それ以外のメソッドに必要な範囲
+	write models.APIKeyScope
+}
+
+// apiKeyRouteScopes ルートグループごとに必要なAPIキーの範囲（一致しないルートは read・write）
+//
+// ルートグループの下に後から登録したルート（メモや添付ファイルなど）にも同じ範囲を適用する。
+var apiKeyRouteScopes = []apiKeyRouteScope{
+	{prefix: "/api/achievements", read: models.APIKeyScopeAchievementsRead, write: models.APIKeyScopeAchievementsWrite},
+	{prefix: "/api/rewards", read: models.APIKeyScopeRewardsRead, write: models.APIKeyScopeRewardsWrite},
+	{prefix: "/api/favorites", read: models.APIKeyScopeRewardsRead, write: models.APIKeyScopeRewardsWrite},
+	{prefix: "/api/wishlist", read: models.APIKeyScopeRewardsRead, write: models.APIKeyScopeRewardsWrite},
+	{prefix: "/api/reservations", read: models.APIKeyScopeRewardsRead, write: models.APIKeyScopeRewardsWrite},
+	{prefix: "/api/points", read: models.APIKeyScopePointsRead, write: models.APIKeyScopePointsAdjust},
+	{prefix: "/api/journal", read: models.APIKeyScopeAdmin, write: models.APIKeyScopeAdmin},
+}
+
+// requiredAPIKeyScope ルートのパターンとメソッドに必要なAPIキーの範囲
+func requiredAPIKeyScope(route, method string) models.APIKeyScope {
+	for _, rule := range apiKeyRouteScopes {
+		if route == rule.prefix || strings.HasPrefix(route, rule.prefix+"/") {
+			if isReadMethod(method) {
+				return rule.read
+			}
+			return rule.write
+		}
+	}
+	if isReadMethod(method) {
+		return models.APIKeyScopeRead
+	}
+	return models.APIKeyScopeWrite
+}
+
+// EnableAPIKeys /api へのリクエストをテーブルに保存したAPIキーで認証する
+//
+// cfg.Required の場合はAPIキーの無いリクエストを拒否する。cfg.AdminToken を設定した場合は、
+// そのBearerトークンで保護したAPIキーの管理エンドポイントを /admin/api-keys に登録する。
+func (s *Server) EnableAPIKeys(apiKeys services.APIKeyService, cfg config.APIKeysConfig) {
+	s.apiKeyService = apiKeys
+	s.apiKeysRequired = cfg.Required
+
+	if cfg.AdminToken == "" {
+		return
+	}
+	admin := s.router.Group("/admin/api-keys")
+	admin.Use(AdminTokenMiddleware(cfg.AdminToken))
+	{
+		admin.POST("", s.createAPIKey)
+		admin.GET("", s.listAPIKeys)
+		admin.PATCH("/:id", s.updateAPIKey)
+		admin.POST("/:id/rotate", s.rotateAPIKey)
+		admin.POST("/:id/revoke", s.revokeAPIKey)
+	}
+}
+
+// APIKeyMiddleware X-API-Key ヘッダーのAPIキーを認証し、ルートグループごとに必要な範囲を持つキーのみ許可するミドルウェア
+//
+// EnableAPIKeys を呼び出すまでは何もしない。APIキーはテナントに関係なくサーバー全体で管理し、
+// 認証したキーの TenantID を TenantMiddleware がリクエストのテナントにする。
+func (s *Server) APIKeyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.apiKeyService == nil {
+			c.Next()
+			return
+		}
+
+		secret := c.GetHeader(APIKeyHeader)
+		if secret == "" {
+			if s.apiKeysRequired {
+				abortInvalidAPIKey(c, APIKeyHeader+" header is required")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		key, err := s.apiKeyService.Authenticate(c.Request.Context(), secret)
+		if err != nil {
+			if stderrors.Is(err, services.ErrInvalidAPIKey) {
+				abortInvalidAPIKey(c, "a valid API key is required")
+				return
+			}
+			s.requestErrorLogger(c).LogServiceError("api_key", "authenticate", err)
+			handleServiceError(c, err)
+			c.Abort()
+			return
+		}
+		if required := requiredAPIKeyScope(c.FullPath(), c.Request.Method); !key.Scope.Grants(required) {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+				Error:     "insufficient_scope",
+				Message:   "the API key does not have the " + string(required) + " scope",
+				Code:      http.StatusForbidden,
+				ErrorCode: errors.CodeInsufficientScope,
+			})
+			return
+		}
+
+		c.Set(apiKeyContextKey, key)
+		c.Request = c.Request.WithContext(logging.AddFields(c.Request.Context(), map[string]interface{}{"api_key_id": key.ID}))
+		c.Next()
+	}
+}
+
+// authenticatedAPIKey APIKeyMiddleware で認証したAPIキー（APIキーの無いリクエストの場合は false）
+func authenticatedAPIKey(c *gin.Context) (*models.APIKey, bool) {
+	value, ok := c.Get(apiKeyContextKey)
+	if !ok {
+		return nil, false
+	}
+	key, ok := value.(*models.APIKey)
+	return key, ok
+}
+
+// abortInvalidAPIKey APIキーが無い・無効な場合のレスポンス
+func abortInvalidAPIKey(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+		Error:     "unauthorized",
+		Message:   message,
+		Code:      http.StatusUnauthorized,
+		ErrorCode: errors.CodeUnauthorized,
+	})
+}
+
+// isReadMethod 読み取りのみのキーで許可するメソッドか
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// createAPIKey POST /admin/api-keys - APIキー発行
+func (s *Server) createAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if req.TenantID != "" {
+		if err := tenant.Validate(req.TenantID); err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		ctx = tenant.WithID(ctx, req.TenantID)
+	}
+	key, secret, err := s.apiKeyService.Create(ctx, req.Label, req.Scope)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("api_key", "create", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"audit":      true,
+		"api_key_id": key.ID,
+		"scope":      key.Scope,
+		"tenant_id":  key.TenantID,
+	}).Info("API key created")
+
+	c.JSON(http.StatusCreated, newAPIKeySecretResponse(key, secret))
+}
+
+// listAPIKeys GET /admin/api-keys - APIキー一覧取得（キー自体は返さない）
+func (s *Server) listAPIKeys(c *gin.Context) {
+	keys, err := s.apiKeyService.List(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("api_key", "list", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		response[i] = newAPIKeyResponse(key)
+	}
+
+	c.JSON(http.StatusOK, ListAPIKeysResponse{
+		APIKeys: response,
+		Count:   len(response),
+	})
+}
+
+// updateAPIKey PATCH /admin/api-keys/{id} - APIキーのラベル・範囲変更
+func (s *Server) updateAPIKey(c *gin.Context) {
+	var req UpdateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	key, err := s.apiKeyService.Update(c.Request.Context(), c.Param("id"), req.Label, req.Scope)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("api_key", "update", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"audit":      true,
+		"api_key_id": key.ID,
+		"scope":      key.Scope,
+	}).Info("API key updated")
+
+	c.JSON(http.StatusOK, newAPIKeyResponse(key))
+}
+
+// rotateAPIKey POST /admin/api-keys/{id}/rotate - APIキー再発行（以前のキーはすぐに使えなくなる）
+func (s *Server) rotateAPIKey(c *gin.Context) {
+	key, secret, err := s.apiKeyService.Rotate(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("api_key", "rotate", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"audit":      true,
+		"api_key_id": key.ID,
+	}).Info("API key rotated")
+
+	c.JSON(http.StatusOK, newAPIKeySecretResponse(key, secret))
+}
+
+// revokeAPIKey POST /admin/api-keys/{id}/revoke - APIキーを無効にする
+func (s *Server) revokeAPIKey(c *gin.Context) {
+	key, err := s.apiKeyService.Revoke(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("api_key", "revoke", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"audit":      true,
+		"api_key_id": key.ID,
+	}).Info("API key revoked")
+
+	c.JSON(http.StatusOK, newAPIKeyResponse(key))
+}
+
+// CreateAPIKeyRequest APIキー発行リクエスト（scope は空白またはカンマ区切り、tenant_id を省略した場合は既定のテナント）
+type CreateAPIKeyRequest struct {
+	Label    string             `json:"label" binding:"required"`
+	Scope    models.APIKeyScope `json:"scope" binding:"required"`
+	TenantID string             `json:"tenant_id"`
+}
+
+// UpdateAPIKeyRequest APIキーのラベル・範囲変更リクエスト（省略した項目は変更しない）
+type UpdateAPIKeyRequest struct {
+	Label string             `json:"label"`
+	Scope models.APIKeyScope `json:"scope"`
+}
+
+// APIKeyResponse APIキーのレスポンス（キー自体は含まない）
+type APIKeyResponse struct {
+	ID        string             `json:"id"`
+	Label     string             `json:"label"`
+	Scope     models.APIKeyScope `json:"scope"`
+	TenantID  string             `json:"tenant_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	RotatedAt *time.Time         `json:"rotated_at,omitempty"`
+	RevokedAt *time.Time         `json:"revoked_at,omitempty"`
+}
+
+// APIKeySecretResponse 発行・再発行したAPIキーのレスポンス（キーはこのレスポンスでのみ返す）
+type APIKeySecretResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// ListAPIKeysResponse APIキー一覧レスポンス
+type ListAPIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+	Count   int              `json:"count"`
+}
+
+// newAPIKeyResponse APIキーをレスポンスに変換
+func newAPIKeyResponse(key *models.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:        key.ID,
+		Label:     key.Label,
+		Scope:     key.Scope,
+		TenantID:  key.TenantID,
+		CreatedAt: key.CreatedAt,
+		RotatedAt: key.RotatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+// newAPIKeySecretResponse 発行・再発行したAPIキーをレスポンスに変換
+func newAPIKeySecretResponse(key *models.APIKey, secret string) APIKeySecretResponse {
+	return APIKeySecretResponse{APIKeyResponse: newAPIKeyResponse(key), Key: secret}
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+	"achievement-management/internal/tenant"
+)
+
+// MockAPIKeyService モックのAPIキーのサービス
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) Create(ctx context.Context, label string, scope models.APIKeyScope) (*models.APIKey, string, error) {
+	args := m.Called(label, scope)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyService) List(ctx context.Context) ([]*models.APIKey, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) Update(ctx context.Context, id, label string, scope models.APIKeyScope) (*models.APIKey, error) {
+	args := m.Called(id, label, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) Rotate(ctx context.Context, id string) (*models.APIKey, string, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyService) Revoke(ctx context.Context, id string) (*models.APIKey, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) Authenticate(ctx context.Context, secret string) (*models.APIKey, error) {
+	args := m.Called(secret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+// setupAPIKeyServer APIキーを有効にし、/api/probe に任意のメソッドで応答するサーバー
+func setupAPIKeyServer(cfg config.APIKeysConfig) (*Server, *MockAPIKeyService) {
+	server, _, _, _ := setupTestServer()
+	apiKeyService := &MockAPIKeyService{}
+	server.EnableAPIKeys(apiKeyService, cfg)
+	server.api.Any("/probe", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return server, apiKeyService
+}
+
+func doAPIKeyRequest(server *Server, method, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/probe", nil)
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	server, apiKeyService := setupAPIKeyServer(config.APIKeysConfig{})
+	apiKeyService.On("Authenticate", "amk_read_secret").Return(&models.APIKey{ID: "read", Scope: models.APIKeyScopeRead}, nil)
+	apiKeyService.On("Authenticate", "amk_write_secret").Return(&models.APIKey{ID: "write", Scope: models.APIKeyScopeWrite}, nil)
+	apiKeyService.On("Authenticate", "amk_revoked_secret").Return(nil, services.ErrInvalidAPIKey)
+
+	// 必須でない場合はキーの無いリクエストを許可する
+	assert.Equal(t, http.StatusNoContent, doAPIKeyRequest(server, http.MethodPost, "").Code)
+	assert.Equal(t, http.StatusNoContent, doAPIKeyRequest(server, http.MethodGet, "amk_read_secret").Code)
+	assert.Equal(t, http.StatusNoContent, doAPIKeyRequest(server, http.MethodPost, "amk_write_secret").Code)
+
+	rr := doAPIKeyRequest(server, http.MethodPost, "amk_read_secret")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), errors.CodeInsufficientScope)
+
+	rr = doAPIKeyRequest(server, http.MethodGet, "amk_revoked_secret")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), errors.CodeUnauthorized)
+}
+
+func TestAPIKeyMiddleware_Required(t *testing.T) {
+	server, apiKeyService := setupAPIKeyServer(config.APIKeysConfig{Required: true})
+	apiKeyService.On("Authenticate", "amk_read_secret").Return(&models.APIKey{ID: "read", Scope: models.APIKeyScopeRead}, nil)
+
+	assert.Equal(t, http.StatusUnauthorized, doAPIKeyRequest(server, http.MethodGet, "").Code)
+	assert.Equal(t, http.StatusNoContent, doAPIKeyRequest(server, http.MethodGet, "amk_read_secret").Code)
+
+	// ヘルスチェックはAPIキーに関係なく応答する
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.NotEqual(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAPIKeyMiddleware_TenantFromKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := testConfig()
+	cfg.Tenancy = config.TenancyConfig{Enabled: true, Header: "X-Tenant-ID"}
+	server := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, cfg)
+	apiKeyService := &MockAPIKeyService{}
+	server.EnableAPIKeys(apiKeyService, config.APIKeysConfig{})
+	server.api.GET("/probe", func(c *gin.Context) { c.String(http.StatusOK, tenant.FromContext(c.Request.Context())) })
+	apiKeyService.On("Authenticate", "amk_family_secret").Return(&models.APIKey{ID: "family", Scope: models.APIKeyScopeRead, TenantID: "family-a"}, nil)
+
+	doRequest := func(key, tenantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/probe", nil)
+		if key != "" {
+			req.Header.Set(APIKeyHeader, key)
+		}
+		if tenantID != "" {
+			req.Header.Set("X-Tenant-ID", tenantID)
+		}
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// テナントはキーから決まる
+	rr := doRequest("amk_family_secret", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "family-a", rr.Body.String())
+
+	// キーと別のテナントは指定できない
+	rr = doRequest("amk_family_secret", "family-b")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// 信頼するプロキシ以外からのヘッダーだけではテナントを選べない
+	rr = doRequest("", "family-b")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAPIKeyAdminEndpoints(t *testing.T) {
+	server, apiKeyService := setupAPIKeyServer(config.APIKeysConfig{AdminToken: "admin-secret"})
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	key := &models.APIKey{ID: "key-1", Label: "ダッシュボード", Scope: models.APIKeyScopeRead, Hash: "5e884898", CreatedAt: createdAt}
+	apiKeyService.On("Create", "ダッシュボード", models.APIKeyScopeRead).Return(key, "amk_key-1_secret", nil)
+	apiKeyService.On("List").Return([]*models.APIKey{key}, nil)
+
+	assert.Equal(t, http.StatusUnauthorized, doAdminRequest(server, http.MethodGet, "/admin/api-keys", "").Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/api-keys", strings.NewReader(`{"label": "ダッシュボード", "scope": "read"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var created APIKeySecretResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.Equal(t, "key-1", created.ID)
+	assert.Equal(t, "amk_key-1_secret", created.Key)
+	assert.NotContains(t, rr.Body.String(), key.Hash)
+
+	rr = doAdminRequest(server, http.MethodGet, "/admin/api-keys", "admin-secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var listed ListAPIKeysResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &listed))
+	assert.Equal(t, 1, listed.Count)
+	assert.NotContains(t, rr.Body.String(), "amk_key-1_secret")
+	assert.NotContains(t, rr.Body.String(), key.Hash)
+	apiKeyService.AssertExpectations(t)
+}
+
+func TestAPIKeyAdminEndpoints_RotateAndRevoke(t *testing.T) {
+	server, apiKeyService := setupAPIKeyServer(config.APIKeysConfig{AdminToken: "admin-secret"})
+	revokedAt := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	apiKeyService.On("Rotate", "key-1").Return(&models.APIKey{ID: "key-1", Scope: models.APIKeyScopeWrite}, "amk_key-1_new", nil)
+	apiKeyService.On("Revoke", "key-1").Return(&models.APIKey{ID: "key-1", Scope: models.APIKeyScopeWrite, RevokedAt: &revokedAt}, nil)
+	apiKeyService.On("Rotate", "missing").Return(nil, "", errors.ErrNotFound)
+
+	rr := doAdminRequest(server, http.MethodPost, "/admin/api-keys/key-1/rotate", "admin-secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "amk_key-1_new")
+
+	rr = doAdminRequest(server, http.MethodPost, "/admin/api-keys/key-1/revoke", "admin-secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var revoked APIKeyResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &revoked))
+	require.NotNil(t, revoked.RevokedAt)
+	assert.True(t, revokedAt.Equal(*revoked.RevokedAt))
+
+	rr = doAdminRequest(server, http.MethodPost, "/admin/api-keys/missing/rotate", "admin-secret")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), errors.CodeAPIKeyNotFound)
+}
+
+func TestAPIKeyAdminEndpoints_DisabledWithoutToken(t *testing.T) {
+	server, _ := setupAPIKeyServer(config.APIKeysConfig{})
+
+	rr := doAdminRequest(server, http.MethodGet, "/admin/api-keys", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRequiredAPIKeyScope(t *testing.T) {
+	tests := []struct {
+		route    string
+		method   string
+		key      models.APIKeyScope
+		expected bool
+	}{
+		{"/api/achievements", http.MethodGet, "achievements:read", true},
+		{"/api/achievements/:id/notes", http.MethodPost, "achievements:read", false},
+		{"/api/achievements/:id/complete", http.MethodPost, "achievements:write", true},
+		{"/api/rewards/:id/redeem", http.MethodPost, "achievements:write", false},
+		{"/api/points/current", http.MethodGet, "points:adjust", true},
+		{"/api/points/history/:id/refund", http.MethodPost, "points:adjust", true},
+		{"/api/points/history/:id/refund", http.MethodPost, "write achievements:read", true},
+		{"/api/points/ledger", http.MethodGet, "read", true},
+		{"/api/wishlist", http.MethodPut, "rewards:read", false},
+		{"/api/stats", http.MethodGet, "achievements:read points:read", false},
+		{"/api/stats", http.MethodGet, "read", true},
+		{"/api/journal/undo", http.MethodPost, "write", false},
+		{"/api/journal/undo", http.MethodPost, "admin", true},
+		// 接頭辞はパスの区切りで比較する
+		{"/api/pointsx", http.MethodPost, "points:adjust", false},
+	}
+	for _, tt := range tests {
+		required := requiredAPIKeyScope(tt.route, tt.method)
+		assert.Equal(t, tt.expected, tt.key.Grants(required), "%s %s with %q (requires %s)", tt.method, tt.route, tt.key, required)
+	}
+}
+
+func TestAPIKeyMiddleware_ResourceScope(t *testing.T) {
+	server, apiKeyService := setupAPIKeyServer(config.APIKeysConfig{})
+	apiKeyService.On("Authenticate", "amk_dashboard_secret").Return(&models.APIKey{ID: "dashboard", Scope: "achievements:read points:read"}, nil)
+
+	// 対象ごとの範囲のキーは、対象を定めていないルートには使えない
+	rr := doAPIKeyRequest(server, http.MethodGet, "amk_dashboard_secret")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "the API key does not have the read scope")
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableAttachments 達成目録・報酬獲得履歴の添付ファイルのエンドポイントを登録し、レスポンスにダウンロード用の署名付きURLを含める
+func (s *Server) EnableAttachments(attachments services.AttachmentService) {
+	s.attachmentService = attachments
+
+	s.api.POST("/achievements/:id/attachment", s.createAttachmentUpload(models.AttachmentTargetAchievement))
+	s.api.POST("/points/history/:id/attachment", s.createAttachmentUpload(models.AttachmentTargetRedemption))
+}
+
+// createAttachmentUpload POST /api/{achievements|points/history}/{id}/attachment - 添付ファイルのアップロード用の署名付きURLを発行
+//
+// クライアントは有効期限までに url へ method と headers を指定してファイルを直接アップロードする。
+func (s *Server) createAttachmentUpload(targetType models.AttachmentTargetType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AttachmentUploadRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:     "validation_error",
+				Message:   "Invalid request body: " + err.Error(),
+				Code:      400,
+				ErrorCode: errors.CodeValidation,
+			})
+			return
+		}
+
+		upload, err := s.attachmentService.CreateUpload(c.Request.Context(), targetType, c.Param("id"), req.ContentType)
+		if err != nil {
+			s.requestErrorLogger(c).LogServiceError("attachment", "create_upload", err)
+			handleServiceError(c, err)
+			return
+		}
+
+		s.requestLogger(c).WithFields(map[string]interface{}{
+			"target_type":    targetType,
+			"target_id":      c.Param("id"),
+			"attachment_key": upload.Key,
+		}).Info("Attachment upload URL issued successfully")
+
+		c.JSON(http.StatusCreated, AttachmentUploadResponse{
+			Key:       upload.Key,
+			UploadURL: upload.URL,
+			Method:    upload.Method,
+			Headers:   upload.Headers,
+			ExpiresAt: upload.ExpiresAt,
+		})
+	}
+}
+
+// attachmentURL レスポンスに含める添付ファイルのダウンロード用URL（添付ファイルが無い・発行に失敗した場合は空）
+func (s *Server) attachmentURL(c *gin.Context, key string) string {
+	if s.attachmentService == nil || key == "" {
+		return ""
+	}
+
+	url, err := s.attachmentService.DownloadURL(c.Request.Context(), key)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("attachment", "download_url", err)
+		return ""
+	}
+	return url
+}
+
+// AttachmentUploadRequest 添付ファイルのアップロード先の発行リクエスト
+type AttachmentUploadRequest struct {
+	// ContentType アップロードするファイルの種類（image/jpeg・image/png・application/pdf など）
+	ContentType string `json:"content_type" binding:"required"`
+}
+
+// AttachmentUploadResponse 添付ファイルのアップロード先のレスポンス
+type AttachmentUploadResponse struct {
+	Key       string            `json:"key"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
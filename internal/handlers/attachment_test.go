@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockAttachmentService モックの添付ファイルのサービス
+type MockAttachmentService struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentService) CreateUpload(ctx context.Context, targetType models.AttachmentTargetType, targetID, contentType string) (*models.AttachmentUpload, error) {
+	args := m.Called(targetType, targetID, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AttachmentUpload), args.Error(1)
+}
+
+func (m *MockAttachmentService) DownloadURL(ctx context.Context, key string) (string, error) {
+	args := m.Called(key)
+	return args.String(0), args.Error(1)
+}
+
+func TestCreateAttachmentUpload(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	attachmentService := &MockAttachmentService{}
+	server.EnableAttachments(attachmentService)
+
+	expiresAt := time.Date(2024, 6, 1, 19, 15, 0, 0, time.UTC)
+	attachmentService.On("CreateUpload", models.AttachmentTargetRedemption, "history-1", "image/jpeg").Return(&models.AttachmentUpload{
+		Key:       "attachments/default/redemption/history-1/1.jpg",
+		URL:       "https://receipts.s3.amazonaws.com/attachments/default/redemption/history-1/1.jpg?X-Amz-Signature=abc",
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": "image/jpeg"},
+		ExpiresAt: expiresAt,
+	}, nil)
+	attachmentService.On("CreateUpload", models.AttachmentTargetAchievement, "missing", "image/png").Return(nil, errors.ErrNotFound)
+	attachmentService.On("CreateUpload", models.AttachmentTargetAchievement, "achievement-1", "text/html").Return(nil,
+		&errors.ValidationError{Field: "content_type", Message: "unsupported content_type"})
+
+	tests := []struct {
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{"/api/points/history/history-1/attachment", `{"content_type": "image/jpeg"}`, http.StatusCreated},
+		{"/api/achievements/missing/attachment", `{"content_type": "image/png"}`, http.StatusNotFound},
+		{"/api/achievements/achievement-1/attachment", `{"content_type": "text/html"}`, http.StatusBadRequest},
+		{"/api/achievements/achievement-1/attachment", `{}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.expectedStatus, rr.Code, tt.path)
+		if rr.Code == http.StatusCreated {
+			var response AttachmentUploadResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, "attachments/default/redemption/history-1/1.jpg", response.Key)
+			assert.Equal(t, http.MethodPut, response.Method)
+			assert.Equal(t, "image/jpeg", response.Headers["Content-Type"])
+			assert.True(t, response.ExpiresAt.Equal(expiresAt))
+		}
+	}
+	attachmentService.AssertNumberOfCalls(t, "CreateUpload", 3)
+}
+
+func TestAttachmentURLInResponses(t *testing.T) {
+	server, mockAchievementService, _, mockPointService := setupTestServer()
+
+	mockAchievementService.On("GetByID", "achievement-1").Return(&models.Achievement{
+		ID: "achievement-1", Title: "レシートを保存", Point: 10, AttachmentKey: "attachments/default/achievement/achievement-1/1.jpg",
+	}, nil)
+	mockPointService.On("GetRewardHistory").Return([]*models.RewardHistory{
+		{ID: "history-1", RewardID: "reward-1", PointCost: 30, AttachmentKey: "attachments/default/redemption/history-1/1.pdf"},
+		{ID: "history-2", RewardID: "reward-1", PointCost: 30},
+	}, nil)
+
+	// 添付ファイルを有効にしていない場合はURLを含めない
+	req := httptest.NewRequest(http.MethodGet, "/api/achievements/achievement-1", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "attachment_url")
+
+	attachmentService := &MockAttachmentService{}
+	attachmentService.On("DownloadURL", "attachments/default/achievement/achievement-1/1.jpg").
+		Return("https://receipts.s3.amazonaws.com/attachments/default/achievement/achievement-1/1.jpg?X-Amz-Signature=abc", nil)
+	attachmentService.On("DownloadURL", "attachments/default/redemption/history-1/1.pdf").
+		Return("https://receipts.s3.amazonaws.com/attachments/default/redemption/history-1/1.pdf?X-Amz-Signature=abc", nil)
+	server.EnableAttachments(attachmentService)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/achievements/achievement-1", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var achievement AchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &achievement))
+	assert.Equal(t, "https://receipts.s3.amazonaws.com/attachments/default/achievement/achievement-1/1.jpg?X-Amz-Signature=abc", achievement.AttachmentURL)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/points/history", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var history ListRewardHistoryResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &history))
+	require.Len(t, history.History, 2)
+	assert.Equal(t, "https://receipts.s3.amazonaws.com/attachments/default/redemption/history-1/1.pdf?X-Amz-Signature=abc", history.History[0].AttachmentURL)
+	// 添付していない履歴にはURLを含めない
+	assert.Empty(t, history.History[1].AttachmentURL)
+	attachmentService.AssertNumberOfCalls(t, "DownloadURL", 2)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/backup"
+	"achievement-management/internal/errors"
+)
+
+// BackupService バックアップの管理エンドポイントから使用する操作
+type BackupService interface {
+	Export(ctx context.Context) (*backup.Manifest, error)
+	Restore(ctx context.Context, snapshotID string) (*backup.Manifest, error)
+}
+
+// EnableBackups バックアップの管理エンドポイントを登録（adminToken のBearerトークンで保護する）
+//
+// テーブル全体（すべてのテナント）を対象にするため、/api とは別に登録してテナントを解決しない。
+func (s *Server) EnableBackups(backups BackupService, adminToken string) {
+	s.backupService = backups
+
+	admin := s.router.Group("/admin")
+	admin.Use(AdminTokenMiddleware(adminToken))
+	{
+		admin.POST("/backups", s.exportBackup)
+		admin.POST("/backups/:id/restore", s.restoreBackup)
+	}
+}
+
+// exportBackup POST /admin/backups - スナップショット作成
+func (s *Server) exportBackup(c *gin.Context) {
+	manifest, err := s.backupService.Export(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("backup", "export", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithField("snapshot_id", manifest.ID).Info("Backup exported successfully")
+
+	c.JSON(http.StatusCreated, newBackupResponse(manifest))
+}
+
+// restoreBackup POST /admin/backups/{id}/restore - スナップショットから復元
+func (s *Server) restoreBackup(c *gin.Context) {
+	id := c.Param("id")
+
+	manifest, err := s.backupService.Restore(c.Request.Context(), id)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("backup", "restore", err)
+		if stderrors.Is(err, errors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:     "not_found",
+				Message:   "Snapshot not found",
+				Code:      404,
+				ErrorCode: errors.CodeBackupNotFound,
+			})
+			return
+		}
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithField("snapshot_id", manifest.ID).Info("Backup restored successfully")
+
+	c.JSON(http.StatusOK, newBackupResponse(manifest))
+}
+
+// BackupTableResponse スナップショットに含まれるテーブルのレスポンス
+type BackupTableResponse struct {
+	Table  string `json:"table"`
+	Object string `json:"object"`
+	Items  int    `json:"items"`
+}
+
+// BackupResponse スナップショットのレスポンス
+type BackupResponse struct {
+	ID        string                `json:"id"`
+	CreatedAt time.Time             `json:"created_at"`
+	Gzip      bool                  `json:"gzip"`
+	Tables    []BackupTableResponse `json:"tables"`
+}
+
+// newBackupResponse マニフェストをレスポンスに変換
+func newBackupResponse(manifest *backup.Manifest) BackupResponse {
+	tables := make([]BackupTableResponse, len(manifest.Tables))
+	for i, table := range manifest.Tables {
+		tables[i] = BackupTableResponse{
+			Table:  table.Key,
+			Object: table.Object,
+			Items:  table.Items,
+		}
+	}
+
+	return BackupResponse{
+		ID:        manifest.ID,
+		CreatedAt: manifest.CreatedAt,
+		Gzip:      manifest.Gzip,
+		Tables:    tables,
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/backup"
+	"achievement-management/internal/errors"
+)
+
+// MockBackupService モックのバックアップサービス
+type MockBackupService struct {
+	mock.Mock
+}
+
+func (m *MockBackupService) Export(ctx context.Context) (*backup.Manifest, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*backup.Manifest), args.Error(1)
+}
+
+func (m *MockBackupService) Restore(ctx context.Context, snapshotID string) (*backup.Manifest, error) {
+	args := m.Called(snapshotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*backup.Manifest), args.Error(1)
+}
+
+func newBackupTestServer(backups BackupService) *Server {
+	server := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, testConfig())
+	server.EnableBackups(backups, "secret")
+	return server
+}
+
+func doAdminRequest(server *Server, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestBackupEndpoints(t *testing.T) {
+	manifest := &backup.Manifest{
+		ID:        "20250102T030405Z",
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Gzip:      true,
+		Tables: []backup.TableSnapshot{
+			{Key: "achievements", Name: "achievements", Object: "backups/20250102T030405Z/achievements.jsonl.gz", Items: 2},
+		},
+	}
+
+	t.Run("Export", func(t *testing.T) {
+		backups := &MockBackupService{}
+		backups.On("Export").Return(manifest, nil)
+
+		rr := doAdminRequest(newBackupTestServer(backups), "POST", "/admin/backups", "secret")
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var response BackupResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, manifest.ID, response.ID)
+		assert.Equal(t, []BackupTableResponse{{Table: "achievements", Object: manifest.Tables[0].Object, Items: 2}}, response.Tables)
+		backups.AssertExpectations(t)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		backups := &MockBackupService{}
+		backups.On("Restore", manifest.ID).Return(manifest, nil)
+
+		rr := doAdminRequest(newBackupTestServer(backups), "POST", "/admin/backups/"+manifest.ID+"/restore", "secret")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), manifest.ID)
+		backups.AssertExpectations(t)
+	})
+
+	t.Run("Restore unknown snapshot", func(t *testing.T) {
+		backups := &MockBackupService{}
+		backups.On("Restore", "missing").Return(nil, fmt.Errorf("failed to read manifest: %w", errors.ErrNotFound))
+
+		rr := doAdminRequest(newBackupTestServer(backups), "POST", "/admin/backups/missing/restore", "secret")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Export failure", func(t *testing.T) {
+		backups := &MockBackupService{}
+		backups.On("Export").Return(nil, assert.AnError)
+
+		rr := doAdminRequest(newBackupTestServer(backups), "POST", "/admin/backups", "secret")
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestBackupEndpoints_RequireAdminToken(t *testing.T) {
+	backups := &MockBackupService{}
+	server := newBackupTestServer(backups)
+
+	for _, token := range []string{"", "wrong"} {
+		rr := doAdminRequest(server, "POST", "/admin/backups", token)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	}
+	backups.AssertNotCalled(t, "Export")
+
+	// 有効にしていない場合はルートを登録しない
+	disabled := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, testConfig())
+	rr := doAdminRequest(disabled, "POST", "/admin/backups", "secret")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableBadges バッジの一覧エンドポイントを登録し、達成目録の作成・報酬の獲得の後にバッジの獲得条件を評価する
+func (s *Server) EnableBadges(badges services.BadgeService) {
+	s.badgeService = badges
+
+	s.api.GET("/badges", s.listBadges)
+}
+
+// listBadges GET /api/badges - 獲得したバッジ一覧取得
+func (s *Server) listBadges(c *gin.Context) {
+	badges, err := s.badgeService.List(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListBadgesResponse{
+		Badges: newBadgeResponses(badges),
+		Count:  len(badges),
+	})
+}
+
+// evaluateBadges バッジの獲得条件を評価し、新たに獲得したバッジを返す
+//
+// 作成・獲得は完了しているため、評価に失敗した場合はログに記録してバッジを返さない。
+func (s *Server) evaluateBadges(c *gin.Context) []BadgeResponse {
+	if s.badgeService == nil {
+		return nil
+	}
+
+	awarded, err := s.badgeService.Evaluate(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("badge", "evaluate", err)
+		return nil
+	}
+	for _, badge := range awarded {
+		s.requestLogger(c).WithField("badge_id", badge.ID).Info("Badge earned")
+	}
+	return newBadgeResponses(awarded)
+}
+
+// BadgeResponse 獲得したバッジのレスポンス
+type BadgeResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	EarnedAt    time.Time `json:"earned_at"`
+}
+
+// newBadgeResponses 獲得したバッジをレスポンスに変換
+func newBadgeResponses(badges []*models.Badge) []BadgeResponse {
+	response := make([]BadgeResponse, len(badges))
+	for i, badge := range badges {
+		response[i] = BadgeResponse{
+			ID:          badge.ID,
+			Name:        badge.Name,
+			Description: badge.Description,
+			EarnedAt:    badge.EarnedAt,
+		}
+	}
+	return response
+}
+
+// ListBadgesResponse 獲得したバッジ一覧レスポンス
+type ListBadgesResponse struct {
+	Badges []BadgeResponse `json:"badges"`
+	Count  int             `json:"count"`
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockBadgeService モックのバッジサービス
+type MockBadgeService struct {
+	mock.Mock
+}
+
+func (m *MockBadgeService) List(ctx context.Context) ([]*models.Badge, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Badge), args.Error(1)
+}
+
+func (m *MockBadgeService) Evaluate(ctx context.Context) ([]*models.Badge, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Badge), args.Error(1)
+}
+
+func TestListBadges(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	badgeService := &MockBadgeService{}
+	server.EnableBadges(badgeService)
+
+	earnedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	badgeService.On("List").Return([]*models.Badge{
+		{ID: "first_achievement", Name: "First Step", Description: "Create your first achievement", EarnedAt: earnedAt},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/api/badges", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListBadgesResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "first_achievement", response.Badges[0].ID)
+	assert.Equal(t, "First Step", response.Badges[0].Name)
+	assert.True(t, earnedAt.Equal(response.Badges[0].EarnedAt))
+}
+
+func TestListBadges_NotEnabled(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/badges", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCreateAchievement_EvaluatesBadges(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	badgeService := &MockBadgeService{}
+	server.EnableBadges(badgeService)
+
+	mockAchievementService.On("Create", mock.AnythingOfType("*models.Achievement")).Return(nil)
+	badgeService.On("Evaluate").Return([]*models.Badge{{ID: "first_achievement", Name: "First Step"}}, nil).Once()
+
+	body := `{"title": "朝のランニング", "point": 10}`
+	req := httptest.NewRequest("POST", "/api/achievements", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var response CreateAchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "朝のランニング", response.Title)
+	require.Len(t, response.Badges, 1)
+	assert.Equal(t, "first_achievement", response.Badges[0].ID)
+
+	// 評価に失敗しても作成は成功として返し、バッジを含めない
+	badgeService.On("Evaluate").Return(nil, &errors.DatabaseError{Operation: "List", Table: "badges"}).Once()
+
+	req = httptest.NewRequest("POST", "/api/achievements", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	assert.NotContains(t, rr.Body.String(), `"badges"`)
+	badgeService.AssertExpectations(t)
+}
+
+func TestRedeemReward_EvaluatesBadges(t *testing.T) {
+	server, _, mockRewardService, _ := setupTestServer()
+	badgeService := &MockBadgeService{}
+	server.EnableBadges(badgeService)
+
+	mockRewardService.On("Redeem", "reward-id").Return(&models.RewardHistory{ID: "history-id", RewardID: "reward-id"}, nil)
+	badgeService.On("Evaluate").Return([]*models.Badge{{ID: "first_redemption", Name: "First Reward"}}, nil)
+
+	req := httptest.NewRequest("POST", "/api/rewards/reward-id/redeem", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Message string          `json:"message"`
+		Badges  []BadgeResponse `json:"badges"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "Reward redeemed successfully", response.Message)
+	require.Len(t, response.Badges, 1)
+	assert.Equal(t, "first_redemption", response.Badges[0].ID)
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableBulk 達成目録・報酬の一括作成・削除のエンドポイントを登録
+func (s *Server) EnableBulk(bulk services.BulkService) {
+	s.bulkService = bulk
+
+	group := s.api.Group("/bulk")
+	{
+		group.POST("/achievements", s.bulkCreateAchievements)
+		group.POST("/achievements/delete", s.bulkDeleteAchievements)
+		group.POST("/rewards", s.bulkCreateRewards)
+		group.POST("/rewards/delete", s.bulkDeleteRewards)
+	}
+}
+
+// bulkCreateAchievements POST /api/bulk/achievements - 達成目録を一括で作成
+func (s *Server) bulkCreateAchievements(c *gin.Context) {
+	var req BulkCreateAchievementsRequest
+	if !bindBulkRequest(c, &req) {
+		return
+	}
+
+	achievements := make([]*models.Achievement, len(req.Achievements))
+	for i := range req.Achievements {
+		achievements[i] = req.Achievements[i].ToModel()
+	}
+
+	result, err := s.bulkService.CreateAchievements(c.Request.Context(), achievements, req.Mode)
+	s.respondBulk(c, "create_achievements", result, err)
+}
+
+// bulkDeleteAchievements POST /api/bulk/achievements/delete - 達成目録を一括で削除
+func (s *Server) bulkDeleteAchievements(c *gin.Context) {
+	var req BulkDeleteAchievementsRequest
+	if !bindBulkRequest(c, &req) {
+		return
+	}
+
+	adjustPoints := s.adjustPointsOnDelete
+	if req.AdjustPoints != nil {
+		adjustPoints = *req.AdjustPoints
+	}
+
+	result, err := s.bulkService.DeleteAchievements(c.Request.Context(), req.IDs, adjustPoints, req.Mode)
+	s.respondBulk(c, "delete_achievements", result, err)
+}
+
+// bulkCreateRewards POST /api/bulk/rewards - 報酬を一括で作成
+func (s *Server) bulkCreateRewards(c *gin.Context) {
+	var req BulkCreateRewardsRequest
+	if !bindBulkRequest(c, &req) {
+		return
+	}
+
+	rewards := make([]*models.Reward, len(req.Rewards))
+	for i := range req.Rewards {
+		rewards[i] = req.Rewards[i].ToModel()
+	}
+
+	result, err := s.bulkService.CreateRewards(c.Request.Context(), rewards, req.Mode)
+	s.respondBulk(c, "create_rewards", result, err)
+}
+
+// bulkDeleteRewards POST /api/bulk/rewards/delete - 報酬を一括で削除
+func (s *Server) bulkDeleteRewards(c *gin.Context) {
+	var req BulkDeleteRewardsRequest
+	if !bindBulkRequest(c, &req) {
+		return
+	}
+
+	result, err := s.bulkService.DeleteRewards(c.Request.Context(), req.IDs, req.Mode)
+	s.respondBulk(c, "delete_rewards", result, err)
+}
+
+// bindBulkRequest 一括操作のリクエストを読み込む（失敗した場合は400を返して false）
+func bindBulkRequest(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return false
+	}
+	return true
+}
+
+// respondBulk 一括操作の結果を返す（失敗した項目がある場合は 207 Multi-Status）
+func (s *Server) respondBulk(c *gin.Context, operation string, result *models.BulkResult, err error) {
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("bulk", operation, err)
+		handleServiceError(c, err)
+		return
+	}
+
+	items := make([]BulkItemResponse, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = BulkItemResponse{Index: item.Index, ID: item.ID, Status: item.Status}
+		if item.Err != nil {
+			_, response := serviceErrorResponse(item.Err)
+			items[i].Error = &response
+		}
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"operation":   operation,
+		"mode":        result.Mode,
+		"succeeded":   result.Succeeded,
+		"failed":      result.Failed,
+		"rolled_back": result.RolledBack,
+	}).Info("Bulk operation completed")
+
+	status := http.StatusOK
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, BulkResponse{
+		Mode:       result.Mode,
+		Items:      items,
+		Succeeded:  result.Succeeded,
+		Failed:     result.Failed,
+		RolledBack: result.RolledBack,
+	})
+}
+
+// BulkCreateAchievementsRequest 達成目録の一括作成リクエスト
+type BulkCreateAchievementsRequest struct {
+	// Mode 一部が失敗した場合の扱い（best_effort・all_or_nothing。省略した場合は best_effort）
+	Mode         models.BulkMode            `json:"mode"`
+	Achievements []CreateAchievementRequest `json:"achievements" binding:"required,dive"`
+}
+
+// BulkDeleteAchievementsRequest 達成目録の一括削除リクエスト
+type BulkDeleteAchievementsRequest struct {
+	Mode models.BulkMode `json:"mode"`
+	IDs  []string        `json:"ids" binding:"required"`
+	// AdjustPoints 付与したポイントも減算するか（省略した場合は points.adjust_on_delete）
+	AdjustPoints *bool `json:"adjust_points"`
+}
+
+// BulkCreateRewardsRequest 報酬の一括作成リクエスト
+type BulkCreateRewardsRequest struct {
+	Mode    models.BulkMode       `json:"mode"`
+	Rewards []CreateRewardRequest `json:"rewards" binding:"required,dive"`
+}
+
+// BulkDeleteRewardsRequest 報酬の一括削除リクエスト
+type BulkDeleteRewardsRequest struct {
+	Mode models.BulkMode `json:"mode"`
+	IDs  []string        `json:"ids" binding:"required"`
+}
+
+// BulkItemResponse 一括操作の項目ごとの結果のレスポンス
+type BulkItemResponse struct {
+	Index  int                   `json:"index"`
+	ID     string                `json:"id,omitempty"`
+	Status models.BulkItemStatus `json:"status"`
+	// Error 失敗した理由（1件ずつ実行した場合と同じエラー）
+	Error *ErrorResponse `json:"error,omitempty"`
+}
+
+// BulkResponse 一括操作の結果のレスポンス
+type BulkResponse struct {
+	Mode       models.BulkMode    `json:"mode"`
+	Items      []BulkItemResponse `json:"items"`
+	Succeeded  int                `json:"succeeded"`
+	Failed     int                `json:"failed"`
+	RolledBack bool               `json:"rolled_back"`
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockBulkService モックの一括操作サービス
+type MockBulkService struct {
+	mock.Mock
+}
+
+func (m *MockBulkService) CreateAchievements(ctx context.Context, achievements []*models.Achievement, mode models.BulkMode) (*models.BulkResult, error) {
+	args := m.Called(achievements, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BulkResult), args.Error(1)
+}
+
+func (m *MockBulkService) DeleteAchievements(ctx context.Context, ids []string, withPoints bool, mode models.BulkMode) (*models.BulkResult, error) {
+	args := m.Called(ids, withPoints, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BulkResult), args.Error(1)
+}
+
+func (m *MockBulkService) CreateRewards(ctx context.Context, rewards []*models.Reward, mode models.BulkMode) (*models.BulkResult, error) {
+	args := m.Called(rewards, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BulkResult), args.Error(1)
+}
+
+func (m *MockBulkService) DeleteRewards(ctx context.Context, ids []string, mode models.BulkMode) (*models.BulkResult, error) {
+	args := m.Called(ids, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BulkResult), args.Error(1)
+}
+
+func doBulkRequest(server *Server, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestBulkCreateAchievements(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	bulk := &MockBulkService{}
+	server.EnableBulk(bulk)
+
+	bulk.On("CreateAchievements", mock.MatchedBy(func(achievements []*models.Achievement) bool {
+		return len(achievements) == 2 && achievements[0].Title == "朝活" && achievements[1].Point == 20
+	}), models.BulkModeAllOrNothing).Return(&models.BulkResult{
+		Mode: models.BulkModeAllOrNothing,
+		Items: []*models.BulkItemResult{
+			{Index: 0, ID: "a1", Status: models.BulkItemSucceeded},
+			{Index: 1, ID: "a2", Status: models.BulkItemSucceeded},
+		},
+		Succeeded: 2,
+	}, nil)
+
+	rr := doBulkRequest(server, "/api/bulk/achievements", `{"mode": "all_or_nothing", "achievements": [{"title": "朝活", "point": 10}, {"title": "読書", "point": 20}]}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response BulkResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Succeeded)
+	assert.Equal(t, "a2", response.Items[1].ID)
+	assert.Nil(t, response.Items[1].Error)
+}
+
+func TestBulkCreateAchievements_InvalidItem(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	bulk := &MockBulkService{}
+	server.EnableBulk(bulk)
+
+	// 各項目も1件ずつの作成と同じく検証する
+	rr := doBulkRequest(server, "/api/bulk/achievements", `{"achievements": [{"title": "朝活", "point": 10}, {"title": "読書"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	bulk.AssertNotCalled(t, "CreateAchievements", mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteAchievements_PartialFailure(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	bulk := &MockBulkService{}
+	server.EnableBulk(bulk)
+
+	bulk.On("DeleteAchievements", []string{"a1", "missing"}, true, models.BulkMode("")).Return(&models.BulkResult{
+		Mode: models.BulkModeBestEffort,
+		Items: []*models.BulkItemResult{
+			{Index: 0, ID: "a1", Status: models.BulkItemSucceeded},
+			{Index: 1, ID: "missing", Status: models.BulkItemFailed, Err: errors.ErrNotFound},
+		},
+		Succeeded: 1,
+		Failed:    1,
+	}, nil)
+
+	rr := doBulkRequest(server, "/api/bulk/achievements/delete", `{"ids": ["a1", "missing"], "adjust_points": true}`)
+	require.Equal(t, http.StatusMultiStatus, rr.Code)
+
+	var response BulkResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Failed)
+	require.NotNil(t, response.Items[1].Error)
+	assert.Equal(t, "not_found", response.Items[1].Error.Error)
+	assert.Equal(t, 404, response.Items[1].Error.Code)
+}
+
+func TestBulkDeleteRewards_TooManyItems(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	bulk := &MockBulkService{}
+	server.EnableBulk(bulk)
+
+	bulk.On("DeleteRewards", []string{"r1", "r2"}, models.BulkModeBestEffort).Return(nil, &errors.ValidationError{Field: "items", Message: "at most 1 items can be processed at once"})
+
+	rr := doBulkRequest(server, "/api/bulk/rewards/delete", `{"mode": "best_effort", "ids": ["r1", "r2"]}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestBulkCreateRewards_RolledBack(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	bulk := &MockBulkService{}
+	server.EnableBulk(bulk)
+
+	bulk.On("CreateRewards", mock.AnythingOfType("[]*models.Reward"), models.BulkModeAllOrNothing).Return(&models.BulkResult{
+		Mode: models.BulkModeAllOrNothing,
+		Items: []*models.BulkItemResult{
+			{Index: 0, ID: "r1", Status: models.BulkItemRolledBack},
+			{Index: 1, Status: models.BulkItemFailed, Err: &errors.ValidationError{Field: "point", Message: "point exceeds the maximum"}},
+		},
+		Failed:     1,
+		RolledBack: true,
+	}, nil)
+
+	rr := doBulkRequest(server, "/api/bulk/rewards", `{"mode": "all_or_nothing", "rewards": [{"title": "コーヒー", "point": 30}, {"title": "旅行", "point": 999999}]}`)
+	require.Equal(t, http.StatusMultiStatus, rr.Code)
+
+	var response BulkResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.True(t, response.RolledBack)
+	assert.Equal(t, models.BulkItemRolledBack, response.Items[0].Status)
+	assert.Equal(t, "validation_error", response.Items[1].Error.Error)
+}
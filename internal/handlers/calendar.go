@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/calendar"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/tenant"
+)
+
+// EnableCalendar 期限・リマインドのある達成目録の iCalendar のフィードを登録（tokens はフィードのトークンごとのテナントID）
+//
+// Google カレンダー・Apple カレンダーなどはヘッダーを設定できないため、/api のAPIキー・テナントのヘッダーの代わりに
+// /api/calendar.ics?token=... のトークンで認証し、トークンのテナントの達成目録を location のタイムゾーンで配信する。
+func (s *Server) EnableCalendar(tokens map[string]string, location *time.Location) {
+	s.router.GET("/api/calendar.ics", s.getCalendar(tokens, location))
+}
+
+// getCalendar GET /api/calendar.ics - 達成目録の期限・リマインドのフィード取得
+func (s *Server) getCalendar(tokens map[string]string, location *time.Location) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, ok := calendarTenant(tokens, c.Query("token"))
+		if !ok {
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:     "unauthorized",
+				Message:   "Invalid calendar token",
+				Code:      http.StatusUnauthorized,
+				ErrorCode: errors.CodeUnauthorized,
+			})
+			return
+		}
+
+		ctx := tenant.WithID(c.Request.Context(), tenantID)
+		achievements, err := s.achievementService.List(ctx)
+		if err != nil {
+			s.requestErrorLogger(c).LogServiceError("calendar", "list", err)
+			handleServiceError(c, err)
+			return
+		}
+
+		var body bytes.Buffer
+		if err := calendar.Render(&body, achievements, location, time.Now()); err != nil {
+			s.requestErrorLogger(c).LogServiceError("calendar", "render", err)
+			handleServiceError(c, err)
+			return
+		}
+
+		c.Header("Content-Disposition", `inline; filename="achievements.ics"`)
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", body.Bytes())
+	}
+}
+
+// calendarTenant トークンのテナントID（どのトークンとも一致時間が変わらないように、すべてのトークンと比較する）
+func calendarTenant(tokens map[string]string, token string) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+	tenantID, found := "", false
+	for candidate, id := range tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(candidate)) == 1 {
+			tenantID, found = id, true
+		}
+	}
+	return tenantID, found
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/models"
+)
+
+func TestGetCalendar(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	server.EnableCalendar(map[string]string{"0123456789abcdef": "default"}, time.UTC)
+
+	mockAchievementService.On("List").Return([]*models.Achievement{
+		{ID: "tax", Title: "Tax return", Point: 50, DueDate: "2024-06-09"},
+		{ID: "run", Title: "Run", Point: 10, Reminder: "0 7 * * *", CreatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/calendar.ics?token=0123456789abcdef", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "DTSTART;VALUE=DATE:20240609\r\n")
+	assert.Contains(t, rr.Body.String(), "DTSTART:20240601T070000Z\r\nRRULE:FREQ=DAILY;BYHOUR=7;BYMINUTE=0\r\n")
+}
+
+func TestGetCalendar_InvalidToken(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	server.EnableCalendar(map[string]string{"0123456789abcdef": "default"}, time.UTC)
+
+	for _, path := range []string{"/api/calendar.ics", "/api/calendar.ics?token=wrong"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, path)
+	}
+	mockAchievementService.AssertNotCalled(t, "List")
+}
+
+func TestCalendarTenant(t *testing.T) {
+	tokens := map[string]string{"0123456789abcdef": "default", "fedcba9876543210": "family-a"}
+
+	tenantID, ok := calendarTenant(tokens, "fedcba9876543210")
+	assert.True(t, ok)
+	assert.Equal(t, "family-a", tenantID)
+
+	_, ok = calendarTenant(tokens, "")
+	assert.False(t, ok)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/services"
+)
+
+// EnableConsistency 整合性チェックで検出したポイントの差異のエンドポイントを登録
+func (s *Server) EnableConsistency(consistency services.ConsistencyService) {
+	s.consistencyService = consistency
+
+	s.api.GET("/consistency/drift", s.listDriftEvents)
+}
+
+// listDriftEvents GET /api/consistency/drift - 整合性チェックで記録したポイントの差異一覧取得（検出日時の順）
+func (s *Server) listDriftEvents(c *gin.Context) {
+	driftEvents, err := s.consistencyService.Drifts(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("consistency", "drifts", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]DriftEventResponse, len(driftEvents))
+	for i, event := range driftEvents {
+		response[i] = DriftEventResponse{
+			ID:             event.ID,
+			TotalPoints:    event.TotalPoints,
+			CurrentBalance: event.CurrentBalance,
+			Difference:     event.Difference,
+			Threshold:      event.Threshold,
+			DetectedAt:     event.DetectedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, ListDriftEventsResponse{
+		DriftEvents: response,
+		Count:       len(response),
+	})
+}
+
+// DriftEventResponse ポイントの差異のレスポンス
+type DriftEventResponse struct {
+	ID             string    `json:"id"`
+	TotalPoints    int       `json:"total_points"`
+	CurrentBalance int       `json:"current_balance"`
+	Difference     int       `json:"difference"`
+	Threshold      int       `json:"threshold"`
+	DetectedAt     time.Time `json:"detected_at"`
+}
+
+// ListDriftEventsResponse ポイントの差異一覧レスポンス
+type ListDriftEventsResponse struct {
+	DriftEvents []DriftEventResponse `json:"drift_events"`
+	Count       int                  `json:"count"`
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/models"
+)
+
+// MockConsistencyService モックの整合性チェックのサービス
+type MockConsistencyService struct {
+	mock.Mock
+}
+
+func (m *MockConsistencyService) Check(ctx context.Context) (*models.PointSummary, *models.DriftEvent, error) {
+	args := m.Called()
+	summary, _ := args.Get(0).(*models.PointSummary)
+	event, _ := args.Get(1).(*models.DriftEvent)
+	return summary, event, args.Error(2)
+}
+
+func (m *MockConsistencyService) Drifts(ctx context.Context) ([]*models.DriftEvent, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.DriftEvent), args.Error(1)
+}
+
+func (m *MockConsistencyService) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	args := m.Called(at)
+	return args.Int(0), args.Error(1)
+}
+
+func TestListDriftEvents(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	consistencyService := &MockConsistencyService{}
+	server.EnableConsistency(consistencyService)
+
+	detectedAt := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	consistencyService.On("Drifts").Return([]*models.DriftEvent{
+		{ID: "drift-1", TotalPoints: 150, CurrentBalance: 100, Difference: 50, Threshold: 10, DetectedAt: detectedAt},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/consistency/drift", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListDriftEventsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, 1, response.Count)
+	assert.Equal(t, "drift-1", response.DriftEvents[0].ID)
+	assert.Equal(t, 50, response.DriftEvents[0].Difference)
+	assert.True(t, response.DriftEvents[0].DetectedAt.Equal(detectedAt))
+}
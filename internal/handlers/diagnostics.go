@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/config"
+)
+
+// EnableConfigDiagnostics 実際に使われている設定を確認する管理エンドポイントを登録（adminToken のBearerトークンで保護する）
+func (s *Server) EnableConfigDiagnostics(cfg *config.Config, adminToken string) {
+	s.effectiveConfig = cfg
+
+	admin := s.router.Group("/admin")
+	admin.Use(AdminTokenMiddleware(adminToken))
+	{
+		admin.GET("/config", s.getConfig)
+	}
+}
+
+// ConfigResponse 設定確認のレスポンス
+type ConfigResponse struct {
+	Environment string           `json:"environment"`
+	ConfigFile  string           `json:"config_file,omitempty"`
+	Settings    []config.Setting `json:"settings"`
+}
+
+// getConfig GET /admin/config - 設定項目ごとの値（秘密の値は伏せる）と読み込み元を取得
+func (s *Server) getConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, ConfigResponse{
+		Environment: s.effectiveConfig.Environment,
+		ConfigFile:  s.effectiveConfig.FilePath(),
+		Settings:    s.effectiveConfig.Settings(),
+	})
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/notifications"
+	"achievement-management/internal/tenant"
+)
+
+// Discordのインタラクションの種類
+const (
+	discordInteractionPing               = 1
+	discordInteractionApplicationCommand = 2
+)
+
+// Discordのインタラクションへの応答の種類
+const (
+	discordResponsePong           = 1
+	discordResponseChannelMessage = 4
+)
+
+// ChatCommands チャットのボットのコマンドを実行し、応答を返す
+type ChatCommands interface {
+	Run(ctx context.Context, input string) (string, error)
+}
+
+// EnableDiscord Discordのボットのコマンドを受け付けるエンドポイントを登録（publicKey でリクエストの署名を検証する）
+//
+// Discordの Interactions Endpoint URL に /integrations/discord/interactions を設定し、
+// スラッシュコマンド /points と /redeem（title オプション）を登録して使用する。コマンドは既定のテナントで実行する。
+func (s *Server) EnableDiscord(commands ChatCommands, publicKey ed25519.PublicKey) {
+	s.router.POST("/integrations/discord/interactions", s.runDiscordCommand(commands, publicKey))
+}
+
+// discordInteraction Discordから送られるインタラクション
+type discordInteraction struct {
+	Type int `json:"type"`
+	Data struct {
+		Name    string `json:"name"`
+		Options []struct {
+			Name  string      `json:"name"`
+			Value interface{} `json:"value"`
+		} `json:"options"`
+	} `json:"data"`
+}
+
+// discordInteractionResponse インタラクションへの応答
+type discordInteractionResponse struct {
+	Type int                           `json:"type"`
+	Data *notifications.DiscordMessage `json:"data,omitempty"`
+}
+
+// runDiscordCommand POST /integrations/discord/interactions - Discordのコマンドを実行
+func (s *Server) runDiscordCommand(commands ChatCommands, publicKey ed25519.PublicKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil || !verifyDiscordSignature(publicKey, c.GetHeader("X-Signature-Ed25519"), c.GetHeader("X-Signature-Timestamp"), body) {
+			// Discordはエンドポイントの登録時に、不正な署名のリクエストに401を返すことを確認する
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:     "unauthorized",
+				Message:   "Invalid request signature",
+				Code:      http.StatusUnauthorized,
+				ErrorCode: errors.CodeUnauthorized,
+			})
+			return
+		}
+
+		var interaction discordInteraction
+		if err := json.Unmarshal(body, &interaction); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:     "validation_error",
+				Message:   "Invalid request body: " + err.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: errors.CodeValidation,
+			})
+			return
+		}
+
+		switch interaction.Type {
+		case discordInteractionPing:
+			c.JSON(http.StatusOK, discordInteractionResponse{Type: discordResponsePong})
+			return
+		case discordInteractionApplicationCommand:
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:     "validation_error",
+				Message:   fmt.Sprintf("Unsupported interaction type %d", interaction.Type),
+				Code:      http.StatusBadRequest,
+				ErrorCode: errors.CodeValidation,
+			})
+			return
+		}
+
+		// オプションの値はコマンドの引数としてつなげる（/redeem title:コーヒー は !redeem コーヒー）
+		input := []string{interaction.Data.Name}
+		for _, option := range interaction.Data.Options {
+			input = append(input, fmt.Sprint(option.Value))
+		}
+
+		ctx := tenant.WithID(c.Request.Context(), tenant.DefaultID)
+		reply, err := commands.Run(ctx, strings.Join(input, " "))
+		if err != nil {
+			s.requestErrorLogger(c).LogServiceError("discord", interaction.Data.Name, err)
+			reply = "Something went wrong, please try again later"
+		}
+
+		s.requestLogger(c).WithField("command", interaction.Data.Name).Info("Discord command processed")
+
+		message := notifications.NewDiscordMessage(reply)
+		c.JSON(http.StatusOK, discordInteractionResponse{Type: discordResponseChannelMessage, Data: &message})
+	}
+}
+
+// verifyDiscordSignature タイムスタンプと本文に対するDiscordの署名（16進数のEd25519）を検証
+func verifyDiscordSignature(publicKey ed25519.PublicKey, signature, timestamp string, body []byte) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize || timestamp == "" {
+		return false
+	}
+	return ed25519.Verify(publicKey, append([]byte(timestamp), body...), sig)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingChatCommands 実行したコマンドを記録し、固定の応答を返すコマンド
+type recordingChatCommands struct {
+	inputs []string
+	reply  string
+}
+
+func (c *recordingChatCommands) Run(ctx context.Context, input string) (string, error) {
+	c.inputs = append(c.inputs, input)
+	return c.reply, nil
+}
+
+func doDiscordRequest(server *Server, privateKey ed25519.PrivateKey, body string) *httptest.ResponseRecorder {
+	timestamp := "1735787045"
+	req := httptest.NewRequest(http.MethodPost, "/integrations/discord/interactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp+body))))
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestDiscordInteractions(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	server, _, _, _ := setupTestServer()
+	commands := &recordingChatCommands{reply: "Redeemed **Coffee** for 30 pt"}
+	server.EnableDiscord(commands, publicKey)
+
+	rr := doDiscordRequest(server, privateKey, `{"type": 1}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"type": 1}`, rr.Body.String())
+
+	rr = doDiscordRequest(server, privateKey, `{"type": 2, "data": {"name": "redeem", "options": [{"name": "title", "value": "Coffee"}]}}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Type int `json:"type"`
+		Data struct {
+			Content string `json:"content"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 4, response.Type)
+	assert.Equal(t, "Redeemed **Coffee** for 30 pt", response.Data.Content)
+	assert.Equal(t, []string{"redeem Coffee"}, commands.inputs)
+}
+
+func TestDiscordInteractions_InvalidSignature(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	server, _, _, _ := setupTestServer()
+	commands := &recordingChatCommands{}
+	server.EnableDiscord(commands, publicKey)
+
+	rr := doDiscordRequest(server, otherKey, `{"type": 2, "data": {"name": "points"}}`)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Empty(t, commands.inputs)
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableGoals 目標のエンドポイントを登録し、達成目録の作成・達成・更新の後に目標の達成を評価する
+func (s *Server) EnableGoals(goals services.GoalService) {
+	s.goalService = goals
+
+	group := s.api.Group("/goals")
+	{
+		group.POST("", s.createGoal)
+		group.GET("", s.listGoals)
+		group.GET("/:id", s.getGoal)
+		group.PUT("/:id", s.updateGoal)
+		group.DELETE("/:id", s.deleteGoal)
+	}
+}
+
+// createGoal POST /api/goals - 目標作成（作成時点で達成している場合は達成を記録する）
+func (s *Server) createGoal(c *gin.Context) {
+	var req GoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	goal := req.ToModel()
+	if err := s.goalService.Create(c.Request.Context(), goal); err != nil {
+		s.requestErrorLogger(c).LogServiceError("goal", "create", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"goal_id": goal.ID,
+		"type":    goal.Type,
+		"target":  goal.Target,
+	}).Info("Goal created successfully")
+
+	s.respondGoal(c, http.StatusCreated, goal.ID)
+}
+
+// listGoals GET /api/goals - 目標と進捗の一覧取得
+func (s *Server) listGoals(c *gin.Context) {
+	progress, err := s.goalService.List(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListGoalsResponse{
+		Goals: newGoalResponses(progress),
+		Count: len(progress),
+	})
+}
+
+// getGoal GET /api/goals/{id} - 目標と進捗の取得
+func (s *Server) getGoal(c *gin.Context) {
+	progress, err := s.goalService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newGoalResponse(progress))
+}
+
+// updateGoal PUT /api/goals/{id} - 目標更新（種類・目標値を変更した場合は改めて達成を評価する）
+func (s *Server) updateGoal(c *gin.Context) {
+	var req GoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	id := c.Param("id")
+	if err := s.goalService.Update(c.Request.Context(), id, req.ToModel()); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	s.respondGoal(c, http.StatusOK, id)
+}
+
+// deleteGoal DELETE /api/goals/{id} - 目標削除
+func (s *Server) deleteGoal(c *gin.Context) {
+	if err := s.goalService.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Goal deleted successfully",
+	})
+}
+
+// respondGoal 目標の達成を評価したうえで、目標と進捗を返す
+func (s *Server) respondGoal(c *gin.Context, status int, id string) {
+	s.evaluateGoals(c)
+
+	progress, err := s.goalService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(status, newGoalResponse(progress))
+}
+
+// evaluateGoals 目標の達成を評価し、新たに達成した目標を返す
+//
+// 評価に失敗した場合はログに記録する。達成の記録後に通知だけが失敗した場合は、達成した目標を返す。
+func (s *Server) evaluateGoals(c *gin.Context) []GoalResponse {
+	if s.goalService == nil {
+		return nil
+	}
+
+	reached, err := s.goalService.Evaluate(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("goal", "evaluate", err)
+	}
+	for _, progress := range reached {
+		s.requestLogger(c).WithField("goal_id", progress.Goal.ID).Info("Goal reached")
+	}
+	if len(reached) == 0 {
+		return nil
+	}
+	return newGoalResponses(reached)
+}
+
+// GoalRequest 目標作成・更新リクエスト
+type GoalRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	// Type points（ポイントを貯める）または achievements（達成目録を作成する）
+	Type   string `json:"type" binding:"required,oneof=points achievements"`
+	Target int    `json:"target" binding:"required,min=1"`
+}
+
+// ToModel リクエストをモデルに変換
+func (r *GoalRequest) ToModel() *models.Goal {
+	return &models.Goal{
+		Title:       r.Title,
+		Description: r.Description,
+		Type:        models.GoalType(r.Type),
+		Target:      r.Target,
+	}
+}
+
+// GoalResponse 目標と進捗のレスポンス
+type GoalResponse struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"`
+	Target      int        `json:"target"`
+	Current     int        `json:"current"`
+	Percent     int        `json:"percent"`
+	ReachedAt   *time.Time `json:"reached_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// newGoalResponse 目標と進捗をレスポンスに変換
+func newGoalResponse(progress *models.GoalProgress) GoalResponse {
+	return GoalResponse{
+		ID:          progress.Goal.ID,
+		Title:       progress.Goal.Title,
+		Description: progress.Goal.Description,
+		Type:        string(progress.Goal.Type),
+		Target:      progress.Goal.Target,
+		Current:     progress.Current,
+		Percent:     progress.Percent,
+		ReachedAt:   progress.Goal.ReachedAt,
+		CreatedAt:   progress.Goal.CreatedAt,
+	}
+}
+
+// newGoalResponses 目標と進捗の一覧をレスポンスに変換
+func newGoalResponses(progress []*models.GoalProgress) []GoalResponse {
+	response := make([]GoalResponse, len(progress))
+	for i, p := range progress {
+		response[i] = newGoalResponse(p)
+	}
+	return response
+}
+
+// ListGoalsResponse 目標一覧レスポンス
+type ListGoalsResponse struct {
+	Goals []GoalResponse `json:"goals"`
+	Count int            `json:"count"`
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockGoalService モックの目標サービス
+type MockGoalService struct {
+	mock.Mock
+}
+
+func (m *MockGoalService) Create(ctx context.Context, goal *models.Goal) error {
+	args := m.Called(goal)
+	return args.Error(0)
+}
+
+func (m *MockGoalService) Update(ctx context.Context, id string, goal *models.Goal) error {
+	args := m.Called(id, goal)
+	return args.Error(0)
+}
+
+func (m *MockGoalService) GetByID(ctx context.Context, id string) (*models.GoalProgress, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GoalProgress), args.Error(1)
+}
+
+func (m *MockGoalService) List(ctx context.Context) ([]*models.GoalProgress, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GoalProgress), args.Error(1)
+}
+
+func (m *MockGoalService) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockGoalService) Evaluate(ctx context.Context) ([]*models.GoalProgress, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GoalProgress), args.Error(1)
+}
+
+func TestCreateGoal(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	goalService := &MockGoalService{}
+	server.EnableGoals(goalService)
+
+	reachedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	goalService.On("Create", mock.MatchedBy(func(goal *models.Goal) bool {
+		goal.ID = "goal-1"
+		return goal.Type == models.GoalTypePoints && goal.Target == 500
+	})).Return(nil)
+	goalService.On("Evaluate").Return([]*models.GoalProgress{}, nil)
+	goalService.On("GetByID", "goal-1").Return(&models.GoalProgress{
+		Goal:    &models.Goal{ID: "goal-1", Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 500, ReachedAt: &reachedAt},
+		Current: 520,
+		Percent: 100,
+	}, nil)
+
+	body := `{"title": "Switchのゲーム", "type": "points", "target": 500}`
+	req := httptest.NewRequest("POST", "/api/goals", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var response GoalResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "goal-1", response.ID)
+	assert.Equal(t, 520, response.Current)
+	assert.Equal(t, 100, response.Percent)
+	require.NotNil(t, response.ReachedAt)
+	goalService.AssertExpectations(t)
+}
+
+func TestCreateGoal_ValidationError(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	server.EnableGoals(&MockGoalService{})
+
+	for _, body := range []string{
+		`{"title": "連続達成", "type": "streak", "target": 7}`,
+		`{"title": "Switchのゲーム", "type": "points", "target": 0}`,
+	} {
+		req := httptest.NewRequest("POST", "/api/goals", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+}
+
+func TestListGoals(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	goalService := &MockGoalService{}
+	server.EnableGoals(goalService)
+
+	goalService.On("List").Return([]*models.GoalProgress{
+		{Goal: &models.Goal{ID: "goal-1", Title: "達成目録10件", Type: models.GoalTypeAchievements, Target: 10}, Current: 4, Percent: 40},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/goals", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListGoalsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "achievements", response.Goals[0].Type)
+	assert.Equal(t, 40, response.Goals[0].Percent)
+	assert.Nil(t, response.Goals[0].ReachedAt)
+}
+
+func TestGetGoal_NotFound(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	goalService := &MockGoalService{}
+	server.EnableGoals(goalService)
+
+	goalService.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/goals/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGoals_NotEnabled(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/goals", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCompleteAchievement_EvaluatesGoals(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	goalService := &MockGoalService{}
+	server.EnableGoals(goalService)
+
+	mockAchievementService.On("Complete", "test-id").Return(&models.Completion{ID: "completion-id", AchievementID: "test-id", Point: 30}, nil)
+	mockAchievementService.On("GetStreak", "test-id").Return(&models.Streak{Current: 1, Longest: 1}, nil)
+	reachedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	reached := []*models.GoalProgress{
+		{Goal: &models.Goal{ID: "goal-1", Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 500, ReachedAt: &reachedAt}, Current: 510, Percent: 100},
+	}
+	// 通知に失敗しても達成した目標は返す
+	goalService.On("Evaluate").Return(reached, stderrors.New("webhook unavailable"))
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/achievements/test-id/complete", nil))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var response CompleteAchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.GoalsReached, 1)
+	assert.Equal(t, "goal-1", response.GoalsReached[0].ID)
+}
+
+func TestUpdateAchievement_EvaluatesGoals(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	goalService := &MockGoalService{}
+	server.EnableGoals(goalService)
+
+	mockAchievementService.On("Update", "test-id", mock.AnythingOfType("*models.Achievement")).Return(nil)
+	mockAchievementService.On("GetByID", "test-id").Return(&models.Achievement{ID: "test-id", Title: "朝のランニング", Point: 50}, nil)
+	goalService.On("Evaluate").Return([]*models.GoalProgress{}, nil)
+
+	body := `{"title": "朝のランニング", "point": 50}`
+	req := httptest.NewRequest(http.MethodPut, "/api/achievements/test-id", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	// 新たに達成した目標がない場合は含めない
+	assert.NotContains(t, rr.Body.String(), `"goals_reached"`)
+	goalService.AssertExpectations(t)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/config"
+)
+
+// HealthChecker ヘルスチェックで接続を確認する依存先（storage.Repositories が実装する）
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+}
+
+// dependencyCheck 依存先の確認結果を一定期間使い回す
+type dependencyCheck struct {
+	name    string
+	checker HealthChecker
+	timeout time.Duration
+	ttl     time.Duration
+	now     func() time.Time
+
+	// mu 確認中は他のリクエストを待たせ、同時に届いたヘルスチェックで依存先に何度も問い合わせないようにする
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+// check 依存先に接続できるか（ttl 以内に確認した場合は前回の結果）
+func (d *dependencyCheck) check(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.checkedAt.IsZero() && d.now().Sub(d.checkedAt) < d.ttl {
+		return d.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	d.err = d.checker.Ping(ctx)
+	d.checkedAt = d.now()
+	return d.err
+}
+
+// EnableDynamoDBHealthCheck /health でDynamoDBに接続できるかも確認する（接続できない場合は503を返す）
+func (s *Server) EnableDynamoDBHealthCheck(checker HealthChecker, cfg config.HealthCheckConfig) {
+	s.dependencyChecks = append(s.dependencyChecks, &dependencyCheck{
+		name:    "dynamodb",
+		checker: checker,
+		timeout: cfg.Timeout(),
+		ttl:     cfg.CacheTTL(),
+		now:     time.Now,
+	})
+}
+
+// HealthResponse ヘルスチェックのレスポンス
+type HealthResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	// Checks 確認した依存先ごとの状態（ok・unavailable）
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// healthCheck ヘルスチェックハンドラー
+func (s *Server) healthCheck(c *gin.Context) {
+	response := HealthResponse{
+		Status:  "ok",
+		Message: "Achievement Management API is running",
+	}
+	status := http.StatusOK
+	if len(s.dependencyChecks) > 0 {
+		response.Checks = make(map[string]string, len(s.dependencyChecks))
+	}
+
+	for _, dependency := range s.dependencyChecks {
+		if err := dependency.check(c.Request.Context()); err != nil {
+			// 原因はヘルスチェックのレスポンスでは返さず、ログにだけ記録する
+			s.requestLogger(c).WithFields(map[string]interface{}{"dependency": dependency.name, "error": err.Error()}).Warn("Health check failed")
+			response.Checks[dependency.name] = "unavailable"
+			response.Status = "unavailable"
+			response.Message = "A dependency of the Achievement Management API is unavailable"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		response.Checks[dependency.name] = "ok"
+	}
+
+	c.JSON(status, response)
+}
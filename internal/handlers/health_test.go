@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"achievement-management/internal/config"
+)
+
+// countingChecker Ping の呼び出し回数を数える依存先
+type countingChecker struct {
+	calls int
+	err   error
+}
+
+func (c *countingChecker) Ping(ctx context.Context) error {
+	c.calls++
+	if _, ok := ctx.Deadline(); !ok {
+		return stderrors.New("ping without timeout")
+	}
+	return c.err
+}
+
+func TestHealthCheck_DynamoDB(t *testing.T) {
+	server := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, testConfig())
+	checker := &countingChecker{}
+	server.EnableDynamoDBHealthCheck(checker, config.HealthCheckConfig{CheckDynamoDB: true, TimeoutMs: 100, CacheTTLSeconds: 5})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	server.dependencyChecks[0].now = func() time.Time { return now }
+
+	health := func() (int, HealthResponse) {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/health", nil)
+		server.router.ServeHTTP(rr, req)
+		var response HealthResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr.Code, response
+	}
+
+	code, response := health()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", response.Status)
+	assert.Equal(t, map[string]string{"dynamodb": "ok"}, response.Checks)
+
+	// キャッシュの有効期間内は前回の結果を返し、DynamoDBに問い合わせない
+	checker.err = stderrors.New("connection refused")
+	code, _ = health()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, checker.calls)
+
+	// 有効期間が過ぎたら確認し直す
+	now = now.Add(5 * time.Second)
+	code, response = health()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", response.Status)
+	assert.Equal(t, map[string]string{"dynamodb": "unavailable"}, response.Checks)
+	assert.Equal(t, 2, checker.calls)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableJournal 操作履歴の管理エンドポイント（元に戻す・やり直す）を登録（adminToken のBearerトークンで保護する）
+//
+// 操作履歴はテナントごとに記録するため、/admin ではなくテナントを解決する /api に登録する。
+// 管理用トークンはテナントに関係しないため、対象のテナントはAPIキーまたは信頼するプロキシのヘッダーで決まる。
+func (s *Server) EnableJournal(journal services.JournalService, adminToken string) {
+	s.journalService = journal
+
+	group := s.api.Group("/journal")
+	group.Use(AdminTokenMiddleware(adminToken))
+	{
+		group.GET("", s.listOperations)
+		group.POST("/undo", s.undoOperation)
+		group.POST("/redo", s.redoOperation)
+	}
+}
+
+// listOperations GET /api/journal - 操作履歴を新しい順に取得
+func (s *Server) listOperations(c *gin.Context) {
+	operations, err := s.journalService.List(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("journal", "list", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	responses := make([]OperationResponse, 0, len(operations))
+	for i := len(operations) - 1; i >= 0; i-- {
+		responses = append(responses, newOperationResponse(operations[i]))
+	}
+
+	c.JSON(http.StatusOK, OperationListResponse{
+		Operations: responses,
+		Count:      len(responses),
+	})
+}
+
+// undoOperation POST /api/journal/undo - 最後の操作を元に戻す
+func (s *Server) undoOperation(c *gin.Context) {
+	operation, err := s.journalService.Undo(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("journal", "undo", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"audit":        true,
+		"operation_id": operation.ID,
+		"entity":       operation.Entity,
+		"entity_id":    operation.EntityID,
+		"action":       operation.Action,
+	}).Info("Operation undone")
+
+	c.JSON(http.StatusOK, newOperationResponse(operation))
+}
+
+// redoOperation POST /api/journal/redo - 最後に元に戻した操作をやり直す
+func (s *Server) redoOperation(c *gin.Context) {
+	operation, err := s.journalService.Redo(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("journal", "redo", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"audit":        true,
+		"operation_id": operation.ID,
+		"entity":       operation.Entity,
+		"entity_id":    operation.EntityID,
+		"action":       operation.Action,
+	}).Info("Operation redone")
+
+	c.JSON(http.StatusOK, newOperationResponse(operation))
+}
+
+// OperationResponse 操作履歴の操作のレスポンス
+type OperationResponse struct {
+	ID         string                 `json:"id"`
+	Entity     models.OperationEntity `json:"entity"`
+	Action     models.OperationAction `json:"action"`
+	EntityID   string                 `json:"entity_id"`
+	WithPoints bool                   `json:"with_points"`
+	// Before 操作前の記録（作成の場合は省略）
+	Before json.RawMessage `json:"before,omitempty"`
+	// After 操作後の記録（削除の場合は省略）
+	After     json.RawMessage `json:"after,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	// UndoneAt 元に戻した日時（元に戻していない場合は省略）
+	UndoneAt *time.Time `json:"undone_at,omitempty"`
+}
+
+// OperationListResponse 操作履歴のレスポンス
+type OperationListResponse struct {
+	Operations []OperationResponse `json:"operations"`
+	Count      int                 `json:"count"`
+}
+
+// newOperationResponse 操作をレスポンスに変換
+func newOperationResponse(operation *models.Operation) OperationResponse {
+	response := OperationResponse{
+		ID:         operation.ID,
+		Entity:     operation.Entity,
+		Action:     operation.Action,
+		EntityID:   operation.EntityID,
+		WithPoints: operation.WithPoints,
+		CreatedAt:  operation.CreatedAt,
+		UndoneAt:   operation.UndoneAt,
+	}
+	if operation.Before != "" {
+		response.Before = json.RawMessage(operation.Before)
+	}
+	if operation.After != "" {
+		response.After = json.RawMessage(operation.After)
+	}
+	return response
+}
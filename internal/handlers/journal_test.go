@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockJournalService モックの操作履歴サービス
+type MockJournalService struct {
+	mock.Mock
+}
+
+func (m *MockJournalService) Record(ctx context.Context, operation *models.Operation) error {
+	args := m.Called(operation)
+	return args.Error(0)
+}
+
+func (m *MockJournalService) List(ctx context.Context) ([]*models.Operation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Operation), args.Error(1)
+}
+
+func (m *MockJournalService) Undo(ctx context.Context) (*models.Operation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Operation), args.Error(1)
+}
+
+func (m *MockJournalService) Redo(ctx context.Context) (*models.Operation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Operation), args.Error(1)
+}
+
+func TestListOperations(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	journal := &MockJournalService{}
+	server.EnableJournal(journal, "secret")
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	journal.On("List").Return([]*models.Operation{
+		{ID: "op-1", Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-1", After: `{"id":"reward-1","point":30}`, CreatedAt: createdAt},
+		{ID: "op-2", Entity: models.OperationEntityReward, Action: models.OperationActionDelete, EntityID: "reward-1", Before: `{"id":"reward-1","point":30}`, CreatedAt: createdAt.Add(time.Minute)},
+	}, nil)
+
+	rr := doAdminRequest(server, "GET", "/api/journal", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response OperationListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, 2, response.Count)
+	// 新しい順に返す
+	assert.Equal(t, "op-2", response.Operations[0].ID)
+	assert.JSONEq(t, `{"id":"reward-1","point":30}`, string(response.Operations[0].Before))
+	assert.Empty(t, response.Operations[0].After)
+	assert.Equal(t, "op-1", response.Operations[1].ID)
+}
+
+func TestUndoOperation(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	journal := &MockJournalService{}
+	server.EnableJournal(journal, "secret")
+
+	undoneAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	journal.On("Undo").Return(&models.Operation{ID: "op-1", Entity: models.OperationEntityAchievement, Action: models.OperationActionCreate, EntityID: "achievement-1", UndoneAt: &undoneAt}, nil)
+
+	rr := doAdminRequest(server, "POST", "/api/journal/undo", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response OperationResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "op-1", response.ID)
+	require.NotNil(t, response.UndoneAt)
+	assert.True(t, response.UndoneAt.Equal(undoneAt))
+}
+
+func TestUndoOperation_ChangedSince(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	journal := &MockJournalService{}
+	server.EnableJournal(journal, "secret")
+
+	journal.On("Undo").Return(nil, fmt.Errorf("achievement achievement-1 has changed since the operation was recorded: %w", errors.ErrVersionConflict))
+
+	rr := doAdminRequest(server, "POST", "/api/journal/undo", "secret")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestRedoOperation_NothingToRedo(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	journal := &MockJournalService{}
+	server.EnableJournal(journal, "secret")
+
+	journal.On("Redo").Return(nil, &errors.BusinessLogicError{Operation: "Redo", Reason: "nothing to redo"})
+
+	rr := doAdminRequest(server, "POST", "/api/journal/redo", "secret")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestJournalEndpoints_RequireAdminToken(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	journal := &MockJournalService{}
+	server.EnableJournal(journal, "secret")
+
+	for _, token := range []string{"", "wrong"} {
+		rr := doAdminRequest(server, "POST", "/api/journal/undo", token)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	}
+	journal.AssertNotCalled(t, "Undo")
+}
+
+func TestJournalEndpoints_IgnoreTenantHeaderFromUntrustedClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := testConfig()
+	cfg.Tenancy = config.TenancyConfig{Enabled: true, Header: "X-Tenant-ID"}
+	server := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, cfg)
+	journal := &MockJournalService{}
+	server.EnableJournal(journal, "secret")
+
+	// 管理用トークンはテナントに関係しないため、ヘッダーで他のテナントの操作を取り消せない
+	req := httptest.NewRequest(http.MethodPost, "/api/journal/undo", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Tenant-ID", "family-b")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	journal.AssertNotCalled(t, "Undo")
+}
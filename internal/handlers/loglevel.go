@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/logging"
+)
+
+// LogLevels 実行中に変更できるログレベル（スケジューラーなど、サーバーの外で作成したLoggerを Attach する）
+func (s *Server) LogLevels() *logging.LevelController {
+	return s.logLevels
+}
+
+// EnableLogLevel ログレベルを再起動せずに変更する管理エンドポイントを登録（adminToken のBearerトークンで保護する）
+func (s *Server) EnableLogLevel(adminToken string) {
+	admin := s.router.Group("/admin")
+	admin.Use(AdminTokenMiddleware(adminToken))
+	{
+		admin.GET("/log-level", s.getLogLevel)
+		admin.PUT("/log-level", s.updateLogLevel)
+		admin.DELETE("/log-level", s.resetLogLevel)
+	}
+}
+
+// LogLevelRequest ログレベル変更リクエスト
+type LogLevelRequest struct {
+	// Level 変更後のログレベル（debug・info・warn・error など）
+	Level string `json:"level" binding:"required"`
+	// DurationSeconds 設定したログレベルに戻すまでの秒数（省略した場合は戻さない）
+	DurationSeconds int `json:"duration_seconds" binding:"min=0"`
+}
+
+// getLogLevel GET /admin/log-level - 現在のログレベルと元に戻す予定の取得
+func (s *Server) getLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, s.logLevels.Status())
+}
+
+// updateLogLevel PUT /admin/log-level - ログレベルの変更（duration_seconds を指定した場合は、その後に設定したログレベルに戻す）
+func (s *Server) updateLogLevel(c *gin.Context) {
+	var req LogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	status, err := s.logLevels.Set(req.Level, time.Duration(req.DurationSeconds)*time.Second)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid log level: " + req.Level,
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"level":            status.Level,
+		"duration_seconds": req.DurationSeconds,
+	}).Warn("Log level changed")
+
+	c.JSON(http.StatusOK, status)
+}
+
+// resetLogLevel DELETE /admin/log-level - 設定したログレベルに戻す
+func (s *Server) resetLogLevel(c *gin.Context) {
+	status := s.logLevels.Reset()
+
+	s.requestLogger(c).WithField("level", status.Level).Warn("Log level reset")
+
+	c.JSON(http.StatusOK, status)
+}
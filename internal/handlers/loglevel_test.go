@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/logging"
+)
+
+func doLogLevelRequest(server *Server, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("PUT", "/admin/log-level", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestLogLevelEndpoints(t *testing.T) {
+	server := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, testConfig())
+	server.EnableLogLevel("secret")
+
+	for _, token := range []string{"", "wrong"} {
+		rr := doLogLevelRequest(server, `{"level": "debug"}`, token)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	}
+
+	rr := doLogLevelRequest(server, `{"level": "debug", "duration_seconds": 600}`, "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var status logging.LevelStatus
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal(t, "debug", status.Level)
+	assert.Equal(t, testConfig().Logging.Level, status.DefaultLevel)
+	require.NotNil(t, status.RevertAt)
+
+	rr = doAdminRequest(server, "GET", "/admin/log-level", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal(t, "debug", status.Level)
+
+	rr = doAdminRequest(server, "DELETE", "/admin/log-level", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	status = logging.LevelStatus{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal(t, testConfig().Logging.Level, status.Level)
+	assert.Nil(t, status.RevertAt)
+
+	// 不正なログレベルでは変更しない
+	rr = doLogLevelRequest(server, `{"level": "verbose"}`, "secret")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = doLogLevelRequest(server, `{"level": "debug", "duration_seconds": -1}`, "secret")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, testConfig().Logging.Level, server.LogLevels().Status().Level)
+}
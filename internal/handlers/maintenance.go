@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+)
+
+// MaintenanceMode メンテナンスの管理エンドポイントから切り替える状態
+type MaintenanceMode interface {
+	ReadOnly() bool
+	SetReadOnly(readOnly bool)
+}
+
+// EnableMaintenance メンテナンスモードの管理エンドポイントを登録（adminToken のBearerトークンで保護する）
+func (s *Server) EnableMaintenance(mode MaintenanceMode, adminToken string) {
+	s.maintenanceMode = mode
+
+	admin := s.router.Group("/admin")
+	admin.Use(AdminTokenMiddleware(adminToken))
+	{
+		admin.GET("/maintenance", s.getMaintenance)
+		admin.PUT("/maintenance", s.updateMaintenance)
+	}
+}
+
+// MaintenanceRequest メンテナンスモード切り替えリクエスト
+type MaintenanceRequest struct {
+	ReadOnly *bool `json:"read_only" binding:"required"`
+}
+
+// MaintenanceResponse メンテナンスモードのレスポンス
+type MaintenanceResponse struct {
+	ReadOnly bool `json:"read_only"`
+}
+
+// getMaintenance GET /admin/maintenance - メンテナンスモードの状態取得
+func (s *Server) getMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, MaintenanceResponse{ReadOnly: s.maintenanceMode.ReadOnly()})
+}
+
+// updateMaintenance PUT /admin/maintenance - メンテナンスモードの切り替え
+func (s *Server) updateMaintenance(c *gin.Context) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	s.maintenanceMode.SetReadOnly(*req.ReadOnly)
+
+	s.requestLogger(c).WithField("read_only", *req.ReadOnly).Info("Maintenance mode updated")
+
+	c.JSON(http.StatusOK, MaintenanceResponse{ReadOnly: *req.ReadOnly})
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/maintenance"
+)
+
+func doMaintenanceRequest(server *Server, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("PUT", "/admin/maintenance", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestMaintenanceEndpoints(t *testing.T) {
+	mode := maintenance.NewMode(false)
+	server := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, testConfig())
+	server.EnableMaintenance(mode, "secret")
+
+	rr := doMaintenanceRequest(server, `{"read_only": true}`, "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, mode.ReadOnly())
+
+	rr = doAdminRequest(server, "GET", "/admin/maintenance", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response MaintenanceResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.True(t, response.ReadOnly)
+
+	rr = doMaintenanceRequest(server, `{"read_only": false}`, "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, mode.ReadOnly())
+
+	// read_only を省略した場合は状態を変えない
+	rr = doMaintenanceRequest(server, `{}`, "secret")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.False(t, mode.ReadOnly())
+}
+
+func TestMaintenanceEndpoints_RequireAdminToken(t *testing.T) {
+	mode := maintenance.NewMode(false)
+	server := NewServer(&MockAchievementService{}, &MockRewardService{}, &MockPointService{}, testConfig())
+	server.EnableMaintenance(mode, "secret")
+
+	for _, token := range []string{"", "wrong"} {
+		rr := doMaintenanceRequest(server, `{"read_only": true}`, token)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	}
+	assert.False(t, mode.ReadOnly())
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"achievement-management/internal/config"
+	"achievement-management/internal/repository/memory"
+	"achievement-management/internal/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMemoryServer インメモリのリポジトリと実際のサービスを使用するサーバーを作成
+func newMemoryServer() *Server {
+	return newMemoryServerWithConfig(testConfig())
+}
+
+// newMemoryServerWithConfig 指定した設定でインメモリのサーバーを作成
+func newMemoryServerWithConfig(cfg *config.Config) *Server {
+	store := memory.NewStore()
+	achievementRepo := memory.NewAchievementRepository(store)
+	rewardRepo := memory.NewRewardRepository(store)
+	pointRepo := memory.NewPointRepository(store)
+
+	return NewServer(
+		services.NewAchievementService(achievementRepo, pointRepo),
+		services.NewRewardService(rewardRepo, pointRepo),
+		services.NewPointService(pointRepo, achievementRepo),
+		cfg,
+	)
+}
+
+// doJSON リクエストを実行してレスポンスを返す
+func doJSON(t *testing.T, server *Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	return doJSONAsTenant(t, server, "", method, path, body)
+}
+
+// doJSONAsTenant テナントを指定してリクエストを実行し、レスポンスを返す
+func doJSONAsTenant(t *testing.T, server *Server, tenantID, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	// テナントのヘッダーは信頼するプロキシ（192.0.2.1/32）からのリクエストとして送る
+	req.RemoteAddr = "192.0.2.1:1234"
+	if tenantID != "" {
+		req.Header.Set("X-Tenant-ID", tenantID)
+	}
+
+	rr := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(rr, req)
+	return rr
+}
+
+func TestMemoryServer_AchievementAndRedeemFlow(t *testing.T) {
+	server := newMemoryServer()
+
+	// 達成目録を作成するとポイントが加算される
+	rr := doJSON(t, server, "POST", "/api/achievements", `{"title":"初回ログイン","point":100}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = doJSON(t, server, "POST", "/api/rewards", `{"title":"コーヒー券","point":60}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var reward RewardResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &reward))
+
+	rr = doJSON(t, server, "POST", "/api/rewards/"+reward.ID+"/redeem", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// 残高不足の場合は獲得できない
+	rr = doJSON(t, server, "POST", "/api/rewards/"+reward.ID+"/redeem", "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = doJSON(t, server, "GET", "/api/points/current", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var points CurrentPointsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &points))
+	assert.Equal(t, 40, points.Point)
+
+	rr = doJSON(t, server, "GET", "/api/points/history", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "コーヒー券")
+	var history ListRewardHistoryResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &history))
+
+	// 台帳には加算と獲得による減算が記録され、合計は残高と一致する
+	rr = doJSON(t, server, "GET", "/api/points/ledger", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var ledger ListPointLedgerResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &ledger))
+	require.Equal(t, 2, ledger.Count)
+	assert.Equal(t, "grant", ledger.Entries[0].Type)
+	assert.Equal(t, 100, ledger.Entries[0].Amount)
+	assert.Equal(t, "spend", ledger.Entries[1].Type)
+	assert.Equal(t, -60, ledger.Entries[1].Amount)
+	assert.Equal(t, history.History[0].ID, ledger.Entries[1].Reference)
+	assert.Equal(t, points.Point, ledger.Entries[0].Amount+ledger.Entries[1].Amount)
+}
+
+func TestMemoryServer_CompleteAchievement(t *testing.T) {
+	server := newMemoryServer()
+
+	rr := doJSON(t, server, "POST", "/api/achievements", `{"title":"朝のランニング","point":30}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var achievement AchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &achievement))
+
+	// 同じ達成目録を繰り返し達成でき、そのたびにポイントが加算される
+	for i := 0; i < 2; i++ {
+		rr = doJSON(t, server, "POST", "/api/achievements/"+achievement.ID+"/complete", "")
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+
+	rr = doJSON(t, server, "GET", "/api/achievements/"+achievement.ID+"/completions", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var completions ListCompletionsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &completions))
+	require.Equal(t, 2, completions.Count)
+	assert.Equal(t, "朝のランニング", completions.Completions[0].AchievementTitle)
+
+	rr = doJSON(t, server, "GET", "/api/points/current", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var points CurrentPointsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &points))
+	assert.Equal(t, 90, points.Point)
+
+	// 台帳の付与は達成記録を参照する
+	rr = doJSON(t, server, "GET", "/api/points/ledger", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var ledger ListPointLedgerResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &ledger))
+	require.Equal(t, 3, ledger.Count)
+	assert.Equal(t, completions.Completions[1].ID, ledger.Entries[2].Reference)
+
+	// 同じ日に2回達成しても連続達成日数は1日
+	assert.Equal(t, 1, completions.Streak.Current)
+	rr = doJSON(t, server, "GET", "/api/streaks", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var streaks StreaksResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &streaks))
+	assert.Equal(t, 1, streaks.Global.Current)
+	require.Len(t, streaks.Achievements, 1)
+	assert.Equal(t, achievement.ID, streaks.Achievements[0].AchievementID)
+
+	rr = doJSON(t, server, "POST", "/api/achievements/missing/complete", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestMemoryServer_NotFound(t *testing.T) {
+	server := newMemoryServer()
+
+	rr := doJSON(t, server, "GET", "/api/achievements/missing", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = doJSON(t, server, "DELETE", "/api/rewards/missing", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestMemoryServer_TenantsAreIsolated(t *testing.T) {
+	cfg := testConfig()
+	cfg.Tenancy = config.TenancyConfig{Enabled: true, Header: "X-Tenant-ID"}
+	cfg.Security.TrustedProxies = []string{"192.0.2.1/32"}
+	server := newMemoryServerWithConfig(cfg)
+
+	rr := doJSONAsTenant(t, server, "family-a", "POST", "/api/achievements", `{"title":"初回ログイン","point":100}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var achievement AchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &achievement))
+
+	// 別のテナントからは見えない
+	rr = doJSONAsTenant(t, server, "family-b", "GET", "/api/achievements/"+achievement.ID, "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = doJSONAsTenant(t, server, "family-b", "GET", "/api/points/current", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var points CurrentPointsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &points))
+	assert.Equal(t, 0, points.Point)
+
+	rr = doJSONAsTenant(t, server, "family-a", "GET", "/api/points/current", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &points))
+	assert.Equal(t, 100, points.Point)
+
+	// テナントを指定しない場合は拒否する
+	rr = doJSON(t, server, "GET", "/api/achievements", "")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestMemoryServer_TenantKeyPrefixCannotReachOtherTenants(t *testing.T) {
+	cfg := testConfig()
+	cfg.Tenancy = config.TenancyConfig{Enabled: true, Header: "X-Tenant-ID"}
+	cfg.Security.TrustedProxies = []string{"192.0.2.1/32"}
+	server := newMemoryServerWithConfig(cfg)
+
+	rr := doJSONAsTenant(t, server, "family-b", "POST", "/api/achievements", `{"title":"初回ログイン","point":100}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var achievement AchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &achievement))
+
+	// 既定のテナントのキーには接頭辞が無いため、「{テナントID}#{ID}」を指定しても他のテナントのアイテムには届かない
+	path := "/api/achievements/family-b%23" + achievement.ID
+	for _, tenantID := range []string{"default", "family-a"} {
+		rr = doJSONAsTenant(t, server, tenantID, "GET", path, "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, tenantID)
+
+		rr = doJSONAsTenant(t, server, tenantID, "PUT", path, `{"title":"上書き","point":1}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, tenantID)
+
+		rr = doJSONAsTenant(t, server, tenantID, "DELETE", path, "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, tenantID)
+
+		rr = doJSONAsTenant(t, server, tenantID, "POST", path+"/complete", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, tenantID)
+	}
+
+	// 元のテナントのアイテムは変わっていない
+	rr = doJSONAsTenant(t, server, "family-b", "GET", "/api/achievements/"+achievement.ID, "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var stored AchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stored))
+	assert.Equal(t, "初回ログイン", stored.Title)
+
+	// テナントを分けていない場合も区切り文字を含むIDは拒否する
+	rr = doJSON(t, newMemoryServer(), "GET", path, "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -1,14 +1,30 @@
 package handlers
 
 import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/metrics"
+	"achievement-management/internal/tenant"
 )
 
 // ErrorResponse エラーレスポンス形式
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
-	Code    int    `json:"code"`
+	// Code HTTPステータスコード
+	Code int `json:"code"`
+	// ErrorCode クライアントが分岐に使う機械可読なエラーコード（例: ACHIEVEMENT_NOT_FOUND）
+	ErrorCode errors.Code `json:"error_code"`
+	// RequestID 問い合わせの際にログと突き合わせるリクエストID（パニックから回復した場合に設定する）
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // ValidationError バリデーションエラー
@@ -20,10 +36,49 @@ func (e *ValidationError) Error() string {
 	return e.Message
 }
 
+// ErrorHandlerMiddleware ハンドラーで記録されたエラーを共通のエラーレスポンスに変換するミドルウェア
+func ErrorHandlerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
 
+		err := c.Errors.Last()
+		switch err.Type {
+		case gin.ErrorTypeBind:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:     "validation_error",
+				Message:   err.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: errors.CodeValidation,
+			})
+		case gin.ErrorTypePublic:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:     "bad_request",
+				Message:   err.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: errors.CodeValidation,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:     "internal_error",
+				Message:   "Internal server error",
+				Code:      http.StatusInternalServerError,
+				ErrorCode: errors.CodeInternal,
+			})
+		}
+	}
+}
 
 // CORSMiddleware CORS設定ミドルウェア
 func (s *Server) CORSMiddleware() gin.HandlerFunc {
+	return CORSMiddleware()
+}
+
+// CORSMiddleware CORS設定ミドルウェア
+func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
@@ -36,4 +91,193 @@ func (s *Server) CORSMiddleware() gin.HandlerFunc {
 
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// SecurityHeadersMiddleware ブラウザ向けのセキュリティヘッダーを付けるミドルウェア（Web UIも同じサーバーから配信するため）
+//
+// Content-Security-Policy は security.content_security_policy が空の場合は付けない。
+func SecurityHeadersMiddleware(securityConfig config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Header("X-Frame-Options", "DENY")
+		c.Header("Referrer-Policy", "no-referrer")
+		if securityConfig.ContentSecurityPolicy != "" {
+			c.Header("Content-Security-Policy", securityConfig.ContentSecurityPolicy)
+		}
+		c.Next()
+	}
+}
+
+// IPFilterMiddleware 接続元のIPアドレスが security.allowed_cidrs に含まれない、または security.denied_cidrs に含まれる場合は403を返すミドルウェア
+//
+// security.trusted_proxies を設定した場合は、信頼するプロキシが付けた X-Forwarded-For の接続元で判定する。
+func IPFilterMiddleware(securityConfig config.SecurityConfig) gin.HandlerFunc {
+	allowed := securityConfig.AllowedNetworks()
+	denied := securityConfig.DeniedNetworks()
+	useClientIP := len(securityConfig.TrustedProxies) > 0
+
+	return func(c *gin.Context) {
+		if len(allowed) == 0 && len(denied) == 0 {
+			c.Next()
+			return
+		}
+
+		remote := c.RemoteIP()
+		if useClientIP {
+			remote = c.ClientIP()
+		}
+		ip := net.ParseIP(remote)
+		if ip == nil || containsIP(denied, ip) || (len(allowed) > 0 && !containsIP(allowed, ip)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+				Error:     "forbidden",
+				Message:   "Access from this IP address is not allowed",
+				Code:      http.StatusForbidden,
+				ErrorCode: errors.CodeIPNotAllowed,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// containsIP ip がいずれかの範囲に含まれるか
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantMiddleware 認証済みのテナントIDをリクエストのコンテキストに設定するミドルウェア（APIKeyMiddleware の後に使用する）
+//
+// テナントはAPIキーで認証したリクエストではキーの TenantID とし、ヘッダーで別のテナントを指定した場合は拒否する。
+// APIキーの無いリクエストは、利用者を認証した信頼するプロキシ（security.trusted_proxies）からの場合のみヘッダーのテナントを使用する。
+func TenantMiddleware(tenancyConfig config.TenancyConfig, securityConfig config.SecurityConfig) gin.HandlerFunc {
+	trustedProxies := securityConfig.TrustedNetworks()
+	return func(c *gin.Context) {
+		// 無効の場合はすべて既定のテナントとして扱う
+		if !tenancyConfig.Enabled {
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader(tenancyConfig.Header)
+		tenantID := header
+		if key, ok := authenticatedAPIKey(c); ok {
+			tenantID = key.TenantID
+			if tenantID == "" {
+				tenantID = tenant.DefaultID
+			}
+			if header != "" && header != tenantID {
+				c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+					Error:     "forbidden",
+					Message:   "the API key does not belong to the tenant in the " + tenancyConfig.Header + " header",
+					Code:      http.StatusForbidden,
+					ErrorCode: errors.CodeForbidden,
+				})
+				return
+			}
+		} else if !fromTrustedProxy(c, trustedProxies) {
+			// 誰でも設定できるヘッダーでは他のテナントを操作できないようにする
+			tenantID = ""
+		}
+
+		if tenantID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+				Error:     "tenant_required",
+				Message:   "an API key or a trusted proxy setting the " + tenancyConfig.Header + " header is required",
+				Code:      http.StatusUnauthorized,
+				ErrorCode: errors.CodeTenantRequired,
+			})
+			return
+		}
+		if err := tenant.Validate(tenantID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+				Error:     "invalid_tenant",
+				Message:   err.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: errors.CodeInvalidTenant,
+			})
+			return
+		}
+
+		ctx := tenant.WithID(c.Request.Context(), tenantID)
+		c.Request = c.Request.WithContext(logging.AddFields(ctx, map[string]interface{}{"tenant_id": tenantID}))
+		c.Next()
+	}
+}
+
+// ActorMiddleware 変更した利用者を監査ログの actor としてリクエストのコンテキストに設定するミドルウェア（APIKeyMiddleware の後に使用する）
+//
+// 監査ログが無効の場合は何もしない。利用者を認証した信頼するプロキシ（security.trusted_proxies）からのリクエストはヘッダーの値、
+// それ以外はAPIキーで認証したリクエストを api_key:{キーのID} とし、どちらも無い場合は anonymous とする。
+func ActorMiddleware(auditConfig config.AuditLogConfig, securityConfig config.SecurityConfig) gin.HandlerFunc {
+	trustedProxies := securityConfig.TrustedNetworks()
+	return func(c *gin.Context) {
+		if !auditConfig.Enabled {
+			c.Next()
+			return
+		}
+
+		var actor string
+		// 誰でも設定できるヘッダーで他の利用者になりすませないようにする
+		if fromTrustedProxy(c, trustedProxies) {
+			actor = strings.TrimSpace(c.GetHeader(auditConfig.ActorHeader))
+		}
+		if actor == "" {
+			if key, ok := authenticatedAPIKey(c); ok {
+				actor = "api_key:" + key.ID
+			}
+		}
+		if actor == "" {
+			actor = logging.ActorAnonymous
+		}
+		ctx := logging.WithActor(c.Request.Context(), actor)
+		c.Request = c.Request.WithContext(logging.AddFields(ctx, map[string]interface{}{"actor": actor}))
+		c.Next()
+	}
+}
+
+// PanicResponse パニックから回復したリクエストのパニックの回数を記録し、共通のエラーレスポンスを返す（logging.RecoveryMiddleware に渡す）
+//
+// パニックの前にレスポンスを書き込んでいた場合は、本文を追加せずに中断する。
+func PanicResponse(c *gin.Context) {
+	metrics.Default.ObservePanic(c.FullPath())
+	if c.Writer.Written() {
+		c.Abort()
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{
+		Error:     "internal_error",
+		Message:   "Internal server error",
+		Code:      http.StatusInternalServerError,
+		ErrorCode: errors.CodeInternal,
+		RequestID: c.Writer.Header().Get(logging.RequestIDHeader),
+	})
+}
+
+// AdminTokenMiddleware 管理エンドポイントへのリクエストを Authorization ヘッダーのBearerトークンで認証するミドルウェア
+func AdminTokenMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasAdminToken(c, token) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+				Error:     "unauthorized",
+				Message:   "a valid admin token is required",
+				Code:      http.StatusUnauthorized,
+				ErrorCode: errors.CodeUnauthorized,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// hasAdminToken Authorization ヘッダーに管理用トークンが指定されているか（トークンが未設定の場合は常に false）
+func hasAdminToken(c *gin.Context, token string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte("Bearer "+token)) == 1
+}
@@ -1,12 +1,22 @@
 package handlers
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/metrics"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
 )
 
 func TestErrorHandlerMiddleware(t *testing.T) {
@@ -127,4 +137,351 @@ func TestCORSMiddleware(t *testing.T) {
 		assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
 		assert.Equal(t, "Content-Type, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
 	})
-}
\ No newline at end of file
+}
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{name: "with content security policy", policy: "default-src 'self'"},
+		{name: "without content security policy", policy: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(SecurityHeadersMiddleware(config.SecurityConfig{ContentSecurityPolicy: tt.policy}))
+			router.GET("/test", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "test"})
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+			assert.Equal(t, "DENY", rr.Header().Get("X-Frame-Options"))
+			assert.Equal(t, "no-referrer", rr.Header().Get("Referrer-Policy"))
+			assert.Equal(t, tt.policy, rr.Header().Get("Content-Security-Policy"))
+		})
+	}
+}
+
+func TestIPFilterMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		security       config.SecurityConfig
+		remoteAddr     string
+		forwardedFor   string
+		expectedStatus int
+	}{
+		{
+			name:           "no lists allow everyone",
+			remoteAddr:     "203.0.113.5:40000",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "address in allowlist",
+			security:       config.SecurityConfig{AllowedCIDRs: []string{"192.168.1.0/24"}},
+			remoteAddr:     "192.168.1.20:40000",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "address outside allowlist",
+			security:       config.SecurityConfig{AllowedCIDRs: []string{"192.168.1.0/24"}},
+			remoteAddr:     "203.0.113.5:40000",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "denylist wins over allowlist",
+			security:       config.SecurityConfig{AllowedCIDRs: []string{"192.168.1.0/24"}, DeniedCIDRs: []string{"192.168.1.66"}},
+			remoteAddr:     "192.168.1.66:40000",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "forwarded address is ignored without trusted proxies",
+			security:       config.SecurityConfig{AllowedCIDRs: []string{"192.168.1.0/24"}},
+			remoteAddr:     "203.0.113.5:40000",
+			forwardedFor:   "192.168.1.20",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "forwarded address from a trusted proxy",
+			security:       config.SecurityConfig{AllowedCIDRs: []string{"192.168.1.0/24"}, TrustedProxies: []string{"10.0.0.1"}},
+			remoteAddr:     "10.0.0.1:40000",
+			forwardedFor:   "192.168.1.20",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			if len(tt.security.TrustedProxies) > 0 {
+				assert.NoError(t, router.SetTrustedProxies(tt.security.TrustedProxies))
+			}
+			router.Use(IPFilterMiddleware(tt.security))
+			router.GET("/test", func(c *gin.Context) {
+				c.String(http.StatusOK, "ok")
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				assert.Contains(t, rr.Body.String(), string(errors.CodeIPNotAllowed))
+			}
+		})
+	}
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// テストのリクエストの送信元（192.0.2.1）を信頼するプロキシとする設定
+	trusted := config.SecurityConfig{TrustedProxies: []string{"192.0.2.1/32"}}
+
+	// 認証済みのAPIキーを設定し、解決したテナントを返すテストハンドラー
+	newRouter := func(tenancyConfig config.TenancyConfig, securityConfig config.SecurityConfig, key *models.APIKey) *gin.Engine {
+		router := gin.New()
+		if key != nil {
+			router.Use(func(c *gin.Context) {
+				c.Set(apiKeyContextKey, key)
+			})
+		}
+		router.Use(TenantMiddleware(tenancyConfig, securityConfig))
+		router.GET("/test", func(c *gin.Context) {
+			c.String(http.StatusOK, tenant.FromContext(c.Request.Context()))
+		})
+		return router
+	}
+
+	enabled := config.TenancyConfig{Enabled: true, Header: "X-Tenant-ID"}
+	tests := []struct {
+		name           string
+		config         config.TenancyConfig
+		security       config.SecurityConfig
+		key            *models.APIKey
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Disabled ignores header",
+			config:         config.TenancyConfig{Enabled: false, Header: "X-Tenant-ID"},
+			header:         "family-a",
+			expectedStatus: http.StatusOK,
+			expectedBody:   tenant.DefaultID,
+		},
+		{
+			name:           "Enabled with tenant from trusted proxy",
+			config:         enabled,
+			security:       trusted,
+			header:         "family-a",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "family-a",
+		},
+		{
+			name:           "Enabled ignores header from untrusted client",
+			config:         enabled,
+			header:         "family-a",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "tenant_required",
+		},
+		{
+			name:           "Enabled without tenant",
+			config:         enabled,
+			security:       trusted,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "tenant_required",
+		},
+		{
+			name:           "Enabled with invalid tenant",
+			config:         enabled,
+			security:       trusted,
+			header:         "family#a",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid_tenant",
+		},
+		{
+			name:           "Enabled with tenant from API key",
+			config:         enabled,
+			key:            &models.APIKey{ID: "key-1", TenantID: "family-a"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "family-a",
+		},
+		{
+			name:           "Enabled with API key for the default tenant",
+			config:         enabled,
+			key:            &models.APIKey{ID: "key-1"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   tenant.DefaultID,
+		},
+		{
+			name:           "Enabled with API key and matching header",
+			config:         enabled,
+			key:            &models.APIKey{ID: "key-1", TenantID: "family-a"},
+			header:         "family-a",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "family-a",
+		},
+		{
+			name:           "Enabled with API key for another tenant",
+			config:         enabled,
+			security:       trusted,
+			key:            &models.APIKey{ID: "key-1", TenantID: "family-a"},
+			header:         "family-b",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   string(errors.CodeForbidden),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/test", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+			newRouter(tt.config, tt.security, tt.key).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestTenantMiddleware_AddsTenantToRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(logging.RequestLoggerMiddleware(logging.NewLoggerWithOutput(testConfig(), io.Discard)))
+	router.Use(TenantMiddleware(
+		config.TenancyConfig{Enabled: true, Header: "X-Tenant-ID"},
+		config.SecurityConfig{TrustedProxies: []string{"192.0.2.1/32"}},
+	))
+	router.GET("/test", func(c *gin.Context) {
+		fields := logging.Fields(c.Request.Context())
+		c.String(http.StatusOK, "%v %v", fields["tenant_id"], fields["route"])
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Tenant-ID", "family-a")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "family-a /test", w.Body.String())
+}
+
+func TestActorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// 認証済みのAPIキーを設定し、監査ログの actor を返すテストハンドラー
+	newRouter := func(securityConfig config.SecurityConfig, key *models.APIKey) *gin.Engine {
+		router := gin.New()
+		router.Use(logging.RequestLoggerMiddleware(logging.NewLoggerWithOutput(testConfig(), io.Discard)))
+		if key != nil {
+			router.Use(func(c *gin.Context) {
+				c.Set(apiKeyContextKey, key)
+			})
+		}
+		router.Use(ActorMiddleware(config.AuditLogConfig{Enabled: true, ActorHeader: "X-Actor-ID"}, securityConfig))
+		router.GET("/test", func(c *gin.Context) {
+			ctx := c.Request.Context()
+			c.String(http.StatusOK, "%s %v", logging.Actor(ctx), logging.Fields(ctx)["actor"])
+		})
+		return router
+	}
+
+	trusted := config.SecurityConfig{TrustedProxies: []string{"192.0.2.1/32"}}
+	tests := []struct {
+		name     string
+		security config.SecurityConfig
+		key      *models.APIKey
+		header   string
+		expected string
+	}{
+		{
+			name:     "header from trusted proxy",
+			security: trusted,
+			header:   "user-42",
+			expected: "user-42 user-42",
+		},
+		{
+			name:     "header from untrusted client is ignored",
+			header:   "user-42",
+			expected: "anonymous anonymous",
+		},
+		{
+			name:     "API key",
+			key:      &models.APIKey{ID: "key-1"},
+			expected: "api_key:key-1 api_key:key-1",
+		},
+		{
+			name:     "untrusted client cannot override the API key",
+			key:      &models.APIKey{ID: "key-1"},
+			header:   "user-42",
+			expected: "api_key:key-1 api_key:key-1",
+		},
+		{
+			name:     "anonymous",
+			security: trusted,
+			expected: "anonymous anonymous",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/test", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tt.header != "" {
+				req.Header.Set("X-Actor-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+			newRouter(tt.security, tt.key).ServeHTTP(w, req)
+			assert.Equal(t, tt.expected, w.Body.String())
+		})
+	}
+}
+
+func TestPanicResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(logging.RequestLoggerMiddleware(logging.NewLoggerWithOutput(testConfig(), io.Discard)))
+	errorLogger, err := logging.NewErrorLogger(testConfig())
+	assert.NoError(t, err)
+	router.Use(logging.RecoveryMiddleware(errorLogger, PanicResponse))
+	router.GET("/test-panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	req, _ := http.NewRequest("GET", "/test-panic", nil)
+	req.Header.Set(logging.RequestIDHeader, "req-panic")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "internal_error", response.Error)
+	assert.Equal(t, errors.CodeInternal, response.ErrorCode)
+	assert.Equal(t, "req-panic", response.RequestID)
+
+	var out strings.Builder
+	assert.NoError(t, metrics.Default.WritePrometheus(&out))
+	assert.Contains(t, out.String(), `achievement_http_panics_total{route="/test-panic"} 1`+"\n")
+}
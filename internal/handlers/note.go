@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableNotes 達成目録・報酬・報酬獲得履歴のメモのエンドポイントを登録
+func (s *Server) EnableNotes(notes services.NoteService) {
+	s.noteService = notes
+
+	targets := []struct {
+		path       string
+		targetType models.NoteTargetType
+	}{
+		{"/achievements/:id", models.NoteTargetAchievement},
+		{"/rewards/:id", models.NoteTargetReward},
+		{"/points/history/:id", models.NoteTargetRedemption},
+	}
+	for _, target := range targets {
+		s.api.POST(target.path+"/notes", s.addNote(target.targetType))
+		s.api.GET(target.path+"/notes", s.listNotes(target.targetType))
+		s.api.DELETE(target.path+"/notes/:note_id", s.deleteNote(target.targetType))
+	}
+}
+
+// addNote POST /api/{achievements|rewards|points/history}/{id}/notes - 記録にメモを付ける
+func (s *Server) addNote(targetType models.NoteTargetType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req NoteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:     "validation_error",
+				Message:   "Invalid request body: " + err.Error(),
+				Code:      400,
+				ErrorCode: errors.CodeValidation,
+			})
+			return
+		}
+
+		note := &models.Note{TargetType: targetType, TargetID: c.Param("id"), Body: req.Body}
+		if err := s.noteService.Add(c.Request.Context(), note); err != nil {
+			s.requestErrorLogger(c).LogServiceError("note", "add", err)
+			handleServiceError(c, err)
+			return
+		}
+
+		s.requestLogger(c).WithFields(map[string]interface{}{
+			"note_id":     note.ID,
+			"target_type": note.TargetType,
+			"target_id":   note.TargetID,
+		}).Info("Note added successfully")
+
+		c.JSON(http.StatusCreated, newNoteResponse(note))
+	}
+}
+
+// listNotes GET /api/{achievements|rewards|points/history}/{id}/notes - 記録に付けたメモを作成日時順に取得
+func (s *Server) listNotes(targetType models.NoteTargetType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		notes, err := s.noteService.List(c.Request.Context(), targetType, c.Param("id"))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		response := make([]NoteResponse, len(notes))
+		for i, note := range notes {
+			response[i] = newNoteResponse(note)
+		}
+
+		c.JSON(http.StatusOK, ListNotesResponse{
+			Notes: response,
+			Count: len(response),
+		})
+	}
+}
+
+// deleteNote DELETE /api/{achievements|rewards|points/history}/{id}/notes/{note_id} - 記録に付けたメモを削除
+func (s *Server) deleteNote(targetType models.NoteTargetType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.noteService.Delete(c.Request.Context(), targetType, c.Param("id"), c.Param("note_id")); err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Note deleted successfully",
+		})
+	}
+}
+
+// NoteRequest メモ作成リクエスト
+type NoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// NoteResponse メモのレスポンス
+type NoteResponse struct {
+	ID         string    `json:"id"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// newNoteResponse メモをレスポンスに変換
+func newNoteResponse(note *models.Note) NoteResponse {
+	return NoteResponse{
+		ID:         note.ID,
+		TargetType: string(note.TargetType),
+		TargetID:   note.TargetID,
+		Body:       note.Body,
+		CreatedAt:  note.CreatedAt,
+	}
+}
+
+// ListNotesResponse メモ一覧レスポンス
+type ListNotesResponse struct {
+	Notes []NoteResponse `json:"notes"`
+	Count int            `json:"count"`
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockNoteService モックの記録に付けるメモのサービス
+type MockNoteService struct {
+	mock.Mock
+}
+
+func (m *MockNoteService) Add(ctx context.Context, note *models.Note) error {
+	args := m.Called(note)
+	return args.Error(0)
+}
+
+func (m *MockNoteService) List(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error) {
+	args := m.Called(targetType, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Note), args.Error(1)
+}
+
+func (m *MockNoteService) Delete(ctx context.Context, targetType models.NoteTargetType, targetID, noteID string) error {
+	args := m.Called(targetType, targetID, noteID)
+	return args.Error(0)
+}
+
+func TestAddNote(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	noteService := &MockNoteService{}
+	server.EnableNotes(noteService)
+
+	noteService.On("Add", mock.MatchedBy(func(note *models.Note) bool {
+		return note.TargetType == models.NoteTargetRedemption && note.TargetID == "history-1"
+	})).Run(func(args mock.Arguments) {
+		note := args.Get(0).(*models.Note)
+		note.ID = "note-1"
+		note.CreatedAt = time.Date(2024, 6, 1, 19, 0, 0, 0, time.UTC)
+	}).Return(nil)
+	noteService.On("Add", mock.MatchedBy(func(note *models.Note) bool {
+		return note.TargetID == "missing"
+	})).Return(errors.ErrNotFound)
+
+	tests := []struct {
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{"/api/points/history/history-1/notes", `{"body": "誕生日のディナーに使った"}`, http.StatusCreated},
+		{"/api/achievements/missing/notes", `{"body": "メモ"}`, http.StatusNotFound},
+		{"/api/rewards/missing/notes", `{"body": "メモ"}`, http.StatusNotFound},
+		{"/api/achievements/achievement-1/notes", `{}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.expectedStatus, rr.Code, tt.path)
+		if rr.Code == http.StatusCreated {
+			var response NoteResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, "note-1", response.ID)
+			assert.Equal(t, "redemption", response.TargetType)
+			assert.Equal(t, "誕生日のディナーに使った", response.Body)
+		}
+	}
+}
+
+func TestListNotes(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	noteService := &MockNoteService{}
+	server.EnableNotes(noteService)
+
+	noteService.On("List", models.NoteTargetAchievement, "achievement-1").Return([]*models.Note{
+		{ID: "note-1", TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "雨の中で走った"},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/achievements/achievement-1/notes", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListNotesResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, 1, response.Count)
+	assert.Equal(t, "雨の中で走った", response.Notes[0].Body)
+}
+
+func TestDeleteNote(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	noteService := &MockNoteService{}
+	server.EnableNotes(noteService)
+
+	noteService.On("Delete", models.NoteTargetReward, "reward-1", "note-1").Return(nil)
+	noteService.On("Delete", models.NoteTargetReward, "reward-2", "note-1").Return(errors.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/rewards/reward-1/notes/note-1", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/rewards/reward-2/notes/note-1", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
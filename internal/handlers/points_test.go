@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetCurrentPoints_Success(t *testing.T) {
@@ -28,7 +30,7 @@ func TestGetCurrentPoints_Success(t *testing.T) {
 	mockPointService.On("GetCurrentPoints").Return(expectedPoints, nil)
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/points/current", nil)
@@ -67,7 +69,7 @@ func TestGetCurrentPoints_ServiceError(t *testing.T) {
 	})
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/points/current", nil)
@@ -105,11 +107,15 @@ func TestAggregatePoints_Success(t *testing.T) {
 		TotalPoints:       500,
 		CurrentBalance:    150,
 		Difference:        -350,
+		Categories: []models.CategoryPoints{
+			{Category: "health", Earned: 300, Count: 3},
+			{Category: "learning", Earned: 200, Count: 2},
+		},
 	}
 	mockPointService.On("AggregatePoints").Return(expectedSummary, nil)
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/points/aggregate", nil)
@@ -131,6 +137,10 @@ func TestAggregatePoints_Success(t *testing.T) {
 	assert.Equal(t, expectedSummary.TotalPoints, response.TotalPoints)
 	assert.Equal(t, expectedSummary.CurrentBalance, response.CurrentBalance)
 	assert.Equal(t, expectedSummary.Difference, response.Difference)
+	assert.Equal(t, []CategoryPointsResponse{
+		{Category: "health", Earned: 300, Count: 3},
+		{Category: "learning", Earned: 200, Count: 2},
+	}, response.Categories)
 
 	// モックが呼ばれたことを確認
 	mockPointService.AssertExpectations(t)
@@ -149,7 +159,7 @@ func TestAggregatePoints_ServiceError(t *testing.T) {
 	})
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/points/aggregate", nil)
@@ -202,7 +212,7 @@ func TestGetPointsHistory_Success(t *testing.T) {
 	mockPointService.On("GetRewardHistory").Return(expectedHistory, nil)
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/points/history", nil)
@@ -252,7 +262,7 @@ func TestGetPointsHistory_EmptyHistory(t *testing.T) {
 	mockPointService.On("GetRewardHistory").Return(expectedHistory, nil)
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/points/history", nil)
@@ -290,7 +300,7 @@ func TestGetPointsHistory_ServiceError(t *testing.T) {
 	})
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/points/history", nil)
@@ -312,6 +322,296 @@ func TestGetPointsHistory_ServiceError(t *testing.T) {
 	assert.Equal(t, "Internal server error", response.Message)
 	assert.Equal(t, 500, response.Code)
 
+	// モックが呼ばれたことを確認
+	mockPointService.AssertExpectations(t)
+}
+
+func TestGetPointsHistory_DateRange(t *testing.T) {
+	// モックサービスを作成
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// モックの期待値を設定（from・to が解析されて渡される）
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	expectedHistory := []*models.RewardHistory{
+		{ID: "history-1", RewardID: "reward-1", RewardTitle: "Test Reward 1", PointCost: 50, RedeemedAt: from.Add(time.Hour)},
+	}
+	mockPointService.On("GetRewardHistoryBetween", from, to).Return(expectedHistory, nil)
+
+	// サーバーを作成
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	// テストリクエストを作成
+	req, err := http.NewRequest("GET", "/api/points/history?from=2024-06-01T00:00:00Z&to=2024-07-01T00:00:00Z", nil)
+	assert.NoError(t, err)
+
+	// リクエストを実行
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	// レスポンスを検証
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response ListRewardHistoryResponse
+	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "history-1", response.History[0].ID)
+
+	// モックが呼ばれたことを確認
+	mockPointService.AssertExpectations(t)
+}
+
+func TestGetPointsHistory_InvalidDate(t *testing.T) {
+	// モックサービスを作成
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// サーバーを作成
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	// テストリクエストを作成（RFC3339 ではない from）
+	req, err := http.NewRequest("GET", "/api/points/history?from=2024-06-01", nil)
+	assert.NoError(t, err)
+
+	// リクエストを実行
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	// レスポンスを検証
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation_error", response.Error)
+
+	// サービスは呼ばれない
+	mockPointService.AssertExpectations(t)
+}
+
+func TestCountPointsHistory(t *testing.T) {
+	server, _, _, mockPointService := setupTestServer()
+
+	mockPointService.On("CountRewardHistory").Return(7, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/points/history/count", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response CountResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, response.Count)
+
+	mockPointService.AssertExpectations(t)
+}
+
+func TestRefundPointsHistory(t *testing.T) {
+	server, _, mockRewardService, _ := setupTestServer()
+
+	refundedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockRewardService.On("Refund", "history-1", false).Return(&models.RewardHistory{
+		ID:          "history-1",
+		RewardID:    "reward-1",
+		RewardTitle: "Test Reward",
+		PointCost:   50,
+		RedeemedAt:  refundedAt.Add(-time.Hour),
+		RefundedAt:  &refundedAt,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/points/history/history-1/refund", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response RewardHistoryResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "history-1", response.ID)
+	assert.Equal(t, 50, response.PointCost)
+	if assert.NotNil(t, response.RefundedAt) {
+		assert.True(t, refundedAt.Equal(*response.RefundedAt))
+	}
+
+	mockRewardService.AssertExpectations(t)
+}
+
+func TestRefundPointsHistory_AdminToken(t *testing.T) {
+	mockRewardService := &MockRewardService{}
+	cfg := testConfig()
+	cfg.Refunds.AdminToken = "secret"
+	server := NewServer(&MockAchievementService{}, mockRewardService, &MockPointService{}, cfg)
+
+	mockRewardService.On("Refund", "history-1", true).Return(&models.RewardHistory{ID: "history-1", PointCost: 50}, nil)
+	mockRewardService.On("Refund", "history-2", false).Return(nil, fmt.Errorf("refund window of 24h0m0s has passed: %w", errors.ErrForbidden))
+
+	// 管理用トークンを指定した場合は取り消し期間の経過後も取り消せる
+	req := httptest.NewRequest(http.MethodPost, "/api/points/history/history-1/refund", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// トークンが一致しない場合は管理者として扱わない
+	req = httptest.NewRequest(http.MethodPost, "/api/points/history/history-2/refund", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	var response ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "forbidden", response.Error)
+
+	mockRewardService.AssertExpectations(t)
+}
+
+func TestRefundPointsHistory_AlreadyRefunded(t *testing.T) {
+	server, _, mockRewardService, _ := setupTestServer()
+
+	// トークンが未設定の場合は Authorization ヘッダーを指定しても管理者として扱わない
+	mockRewardService.On("Refund", "history-1", false).Return(nil, &errors.BusinessLogicError{
+		Operation: "Refund",
+		Reason:    "redemption has already been refunded",
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/points/history/history-1/refund", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockRewardService.AssertExpectations(t)
+}
+
+func TestAnnotatePointsHistory(t *testing.T) {
+	server, _, _, mockPointService := setupTestServer()
+
+	previous := &models.RewardHistory{ID: "history-1", RewardTitle: "Test Reward", PointCost: 50}
+	updated := &models.RewardHistory{ID: "history-1", RewardTitle: "Test Reward", PointCost: 50, Note: "Movie night", Tags: []string{"family"}}
+	mockPointService.On("AnnotateRedemption", "history-1", mock.MatchedBy(func(annotation models.RedemptionAnnotation) bool {
+		return annotation.Note != nil && *annotation.Note == "Movie night" &&
+			annotation.Tags != nil && len(*annotation.Tags) == 1 && (*annotation.Tags)[0] == "family"
+	})).Return(previous, updated, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/points/history/history-1", strings.NewReader(`{"note":"Movie night","tags":["family"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response RewardHistoryResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Movie night", response.Note)
+	assert.Equal(t, []string{"family"}, response.Tags)
+	assert.Equal(t, 50, response.PointCost)
+
+	mockPointService.AssertExpectations(t)
+}
+
+func TestAnnotatePointsHistory_PointCostRejected(t *testing.T) {
+	server, _, _, mockPointService := setupTestServer()
+
+	// ポイントは変更できない
+	req := httptest.NewRequest(http.MethodPatch, "/api/points/history/history-1", strings.NewReader(`{"note":"Movie night","point_cost":10}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockPointService.AssertNotCalled(t, "AnnotateRedemption", mock.Anything, mock.Anything)
+}
+
+func TestAnnotatePointsHistory_NotFound(t *testing.T) {
+	server, _, _, mockPointService := setupTestServer()
+
+	mockPointService.On("AnnotateRedemption", "missing", mock.Anything).Return(nil, nil, errors.ErrNotFound)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/points/history/missing", strings.NewReader(`{"note":"Movie night"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockPointService.AssertExpectations(t)
+}
+
+func TestGetPointsLedger_Success(t *testing.T) {
+	// モックサービスを作成
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// モックの期待値を設定
+	now := time.Now()
+	expectedEntries := []*models.PointLedgerEntry{
+		{ID: "entry-1", Type: models.LedgerEntryGrant, Amount: 100, CreatedAt: now.Add(-time.Hour)},
+		{ID: "entry-2", Type: models.LedgerEntrySpend, Amount: -50, Reference: "history-1", CreatedAt: now},
+	}
+	mockPointService.On("GetLedger").Return(expectedEntries, nil)
+
+	// サーバーを作成
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	req, err := http.NewRequest("GET", "/api/points/ledger", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	// レスポンスを検証
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response ListPointLedgerResponse
+	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.Count)
+	assert.Len(t, response.Entries, 2)
+	assert.Equal(t, "grant", response.Entries[0].Type)
+	assert.Equal(t, 100, response.Entries[0].Amount)
+	assert.Empty(t, response.Entries[0].Reference)
+	assert.Equal(t, "spend", response.Entries[1].Type)
+	assert.Equal(t, -50, response.Entries[1].Amount)
+	assert.Equal(t, "history-1", response.Entries[1].Reference)
+
+	// モックが呼ばれたことを確認
+	mockPointService.AssertExpectations(t)
+}
+
+func TestGetPointsLedger_ServiceError(t *testing.T) {
+	// モックサービスを作成
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// モックの期待値を設定（エラーを返す）
+	mockPointService.On("GetLedger").Return(nil, &errors.DatabaseError{
+		Operation: "GetLedger",
+		Cause:     fmt.Errorf("table not found"),
+	})
+
+	// サーバーを作成
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	req, err := http.NewRequest("GET", "/api/points/ledger", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	// レスポンスを検証
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
 	// モックが呼ばれたことを確認
 	mockPointService.AssertExpectations(t)
 }
\ No newline at end of file
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableQuests クエストのエンドポイントを登録し、達成目録の達成の後にクエストの完了を評価する
+func (s *Server) EnableQuests(quests services.QuestService) {
+	s.questService = quests
+
+	group := s.api.Group("/quests")
+	{
+		group.POST("", s.createQuest)
+		group.GET("", s.listQuests)
+		group.GET("/:id", s.getQuest)
+		group.DELETE("/:id", s.deleteQuest)
+	}
+}
+
+// createQuest POST /api/quests - クエスト作成
+func (s *Server) createQuest(c *gin.Context) {
+	var req QuestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	quest := req.ToModel()
+	if err := s.questService.Create(c.Request.Context(), quest); err != nil {
+		s.requestErrorLogger(c).LogServiceError("quest", "create", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"quest_id":    quest.ID,
+		"steps":       len(quest.Steps),
+		"bonus_point": quest.BonusPoint,
+	}).Info("Quest created successfully")
+
+	progress, err := s.questService.GetByID(c.Request.Context(), quest.ID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newQuestResponse(progress))
+}
+
+// listQuests GET /api/quests - クエストと進捗の一覧取得
+func (s *Server) listQuests(c *gin.Context) {
+	progress, err := s.questService.List(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListQuestsResponse{
+		Quests: newQuestResponses(progress),
+		Count:  len(progress),
+	})
+}
+
+// getQuest GET /api/quests/{id} - クエストと進捗の取得
+func (s *Server) getQuest(c *gin.Context) {
+	progress, err := s.questService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newQuestResponse(progress))
+}
+
+// deleteQuest DELETE /api/quests/{id} - クエスト削除（付与済みのボーナスは取り消さない）
+func (s *Server) deleteQuest(c *gin.Context) {
+	if err := s.questService.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quest deleted successfully",
+	})
+}
+
+// evaluateQuests クエストの完了を評価し、新たに完了したクエストを返す（評価に失敗した場合はログに記録する）
+func (s *Server) evaluateQuests(c *gin.Context) []QuestResponse {
+	if s.questService == nil {
+		return nil
+	}
+
+	completed, err := s.questService.Evaluate(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("quest", "evaluate", err)
+	}
+	for _, progress := range completed {
+		s.requestLogger(c).WithFields(map[string]interface{}{
+			"quest_id":    progress.Quest.ID,
+			"bonus_point": progress.Quest.BonusPoint,
+		}).Info("Quest completed")
+	}
+	if len(completed) == 0 {
+		return nil
+	}
+	return newQuestResponses(completed)
+}
+
+// QuestRequest クエスト作成リクエスト
+type QuestRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	// Steps 達成する順の達成目録のID
+	Steps      []string `json:"steps" binding:"required,min=1"`
+	BonusPoint int      `json:"bonus_point" binding:"min=0"`
+}
+
+// ToModel リクエストをモデルに変換
+func (r *QuestRequest) ToModel() *models.Quest {
+	return &models.Quest{
+		Title:       r.Title,
+		Description: r.Description,
+		Steps:       r.Steps,
+		BonusPoint:  r.BonusPoint,
+	}
+}
+
+// QuestStepResponse クエストのステップの進捗のレスポンス
+type QuestStepResponse struct {
+	AchievementID string `json:"achievement_id"`
+	Title         string `json:"title"`
+	// Status completed（達成済み）、unlocked（次に達成する）、locked（前のステップが未達成）
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// QuestResponse クエストと進捗のレスポンス
+type QuestResponse struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Steps       []QuestStepResponse `json:"steps"`
+	// Completed 達成済みのステップの数
+	Completed   int        `json:"completed"`
+	BonusPoint  int        `json:"bonus_point"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// newQuestResponse クエストと進捗をレスポンスに変換
+func newQuestResponse(progress *models.QuestProgress) QuestResponse {
+	steps := make([]QuestStepResponse, len(progress.Steps))
+	for i, step := range progress.Steps {
+		steps[i] = QuestStepResponse{
+			AchievementID: step.AchievementID,
+			Title:         step.Title,
+			Status:        string(step.Status),
+			CompletedAt:   step.CompletedAt,
+		}
+	}
+	return QuestResponse{
+		ID:          progress.Quest.ID,
+		Title:       progress.Quest.Title,
+		Description: progress.Quest.Description,
+		Steps:       steps,
+		Completed:   progress.Completed,
+		BonusPoint:  progress.Quest.BonusPoint,
+		CompletedAt: progress.Quest.CompletedAt,
+		CreatedAt:   progress.Quest.CreatedAt,
+	}
+}
+
+// newQuestResponses クエストと進捗の一覧をレスポンスに変換
+func newQuestResponses(progress []*models.QuestProgress) []QuestResponse {
+	response := make([]QuestResponse, len(progress))
+	for i, p := range progress {
+		response[i] = newQuestResponse(p)
+	}
+	return response
+}
+
+// ListQuestsResponse クエスト一覧レスポンス
+type ListQuestsResponse struct {
+	Quests []QuestResponse `json:"quests"`
+	Count  int             `json:"count"`
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockQuestService モックのクエストサービス
+type MockQuestService struct {
+	mock.Mock
+}
+
+func (m *MockQuestService) Create(ctx context.Context, quest *models.Quest) error {
+	args := m.Called(quest)
+	return args.Error(0)
+}
+
+func (m *MockQuestService) GetByID(ctx context.Context, id string) (*models.QuestProgress, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.QuestProgress), args.Error(1)
+}
+
+func (m *MockQuestService) List(ctx context.Context) ([]*models.QuestProgress, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.QuestProgress), args.Error(1)
+}
+
+func (m *MockQuestService) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockQuestService) Evaluate(ctx context.Context) ([]*models.QuestProgress, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.QuestProgress), args.Error(1)
+}
+
+func TestCreateQuest(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	questService := &MockQuestService{}
+	server.EnableQuests(questService)
+
+	questService.On("Create", mock.MatchedBy(func(quest *models.Quest) bool {
+		quest.ID = "quest-1"
+		return len(quest.Steps) == 2 && quest.BonusPoint == 50
+	})).Return(nil)
+	questService.On("GetByID", "quest-1").Return(&models.QuestProgress{
+		Quest: &models.Quest{ID: "quest-1", Title: "朝活", Steps: []string{"wake-up", "run"}, BonusPoint: 50},
+		Steps: []*models.QuestStep{
+			{AchievementID: "wake-up", Title: "早起き", Status: models.QuestStepUnlocked},
+			{AchievementID: "run", Title: "ランニング", Status: models.QuestStepLocked},
+		},
+	}, nil)
+
+	body := `{"title": "朝活", "steps": ["wake-up", "run"], "bonus_point": 50}`
+	req := httptest.NewRequest("POST", "/api/quests", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var response QuestResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "quest-1", response.ID)
+	require.Len(t, response.Steps, 2)
+	assert.Equal(t, "unlocked", response.Steps[0].Status)
+	assert.Equal(t, "locked", response.Steps[1].Status)
+	questService.AssertExpectations(t)
+}
+
+func TestCreateQuest_ValidationError(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	questService := &MockQuestService{}
+	server.EnableQuests(questService)
+
+	for _, body := range []string{
+		`{"title": "朝活", "steps": []}`,
+		`{"title": "朝活", "steps": ["run"], "bonus_point": -1}`,
+	} {
+		req := httptest.NewRequest("POST", "/api/quests", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+
+	// 存在しない達成目録はサービスのバリデーションエラー
+	questService.On("Create", mock.Anything).Return(&errors.ValidationError{Field: "steps", Message: "achievement missing not found"})
+	req := httptest.NewRequest("POST", "/api/quests", strings.NewReader(`{"title": "朝活", "steps": ["missing"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestListQuests(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	questService := &MockQuestService{}
+	server.EnableQuests(questService)
+
+	questService.On("List").Return([]*models.QuestProgress{
+		{Quest: &models.Quest{ID: "quest-1", Title: "朝活", Steps: []string{"run"}}, Steps: []*models.QuestStep{{AchievementID: "run", Status: models.QuestStepUnlocked}}},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/quests", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListQuestsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, 0, response.Quests[0].Completed)
+}
+
+func TestGetQuest_NotFound(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	questService := &MockQuestService{}
+	server.EnableQuests(questService)
+
+	questService.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/quests/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCompleteAchievement_EvaluatesQuests(t *testing.T) {
+	server, mockAchievementService, _, _ := setupTestServer()
+	questService := &MockQuestService{}
+	server.EnableQuests(questService)
+
+	mockAchievementService.On("Complete", "run").Return(&models.Completion{ID: "completion-id", AchievementID: "run", Point: 30}, nil)
+	mockAchievementService.On("GetStreak", "run").Return(&models.Streak{Current: 1, Longest: 1}, nil)
+	completedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	questService.On("Evaluate").Return([]*models.QuestProgress{
+		{
+			Quest:     &models.Quest{ID: "quest-1", Title: "朝活", Steps: []string{"run"}, BonusPoint: 50, CompletedAt: &completedAt},
+			Steps:     []*models.QuestStep{{AchievementID: "run", Status: models.QuestStepCompleted, CompletedAt: &completedAt}},
+			Completed: 1,
+		},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/achievements/run/complete", nil))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var response CompleteAchievementResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.QuestsCompleted, 1)
+	assert.Equal(t, "quest-1", response.QuestsCompleted[0].ID)
+	assert.Equal(t, 50, response.QuestsCompleted[0].BonusPoint)
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// rateLimitWindow リクエスト数を数える期間
+const rateLimitWindow = time.Minute
+
+// rateLimiter キーごとのリクエスト数を1分単位の固定の期間で数える（プロセスごとに数えるため、複数台で起動する場合は台数分まで許可する）
+type rateLimiter struct {
+	now func() time.Time
+
+	mu sync.Mutex
+	// windowStart 数えている期間の開始時刻（期間が変わったらすべてのキーの件数を破棄する）
+	windowStart time.Time
+	counts      map[string]int
+}
+
+// rateLimitResult リクエストを数えた結果
+type rateLimitResult struct {
+	allowed   bool
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// newRateLimiter リクエスト数を数える rateLimiter を作成
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		now:    time.Now,
+		counts: make(map[string]int),
+	}
+}
+
+// allow key のリクエストを1件数え、現在の期間で limit 件以内か
+func (l *rateLimiter) allow(key string, limit int) rateLimitResult {
+	return l.count(key, limit, true)
+}
+
+// peek key のリクエストが現在の期間でまだ limit 件に達していないか（数えない）
+func (l *rateLimiter) peek(key string, limit int) rateLimitResult {
+	return l.count(key, limit, false)
+}
+
+// count key のリクエストが limit 件に達していないか判定し、take の場合は1件数える
+func (l *rateLimiter) count(key string, limit int, take bool) rateLimitResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := l.now().Truncate(rateLimitWindow)
+	if !start.Equal(l.windowStart) {
+		l.windowStart = start
+		l.counts = make(map[string]int)
+	}
+
+	result := rateLimitResult{limit: limit, reset: start.Add(rateLimitWindow)}
+	if l.counts[key] >= limit {
+		return result
+	}
+	if take {
+		l.counts[key]++
+	}
+	result.allowed = true
+	result.remaining = limit - l.counts[key]
+	return result
+}
+
+// RateLimitMiddleware /api へのリクエスト数を rate_limit の設定で制限するミドルウェア
+//
+// APIキーのリクエストはキーごと、信頼するプロキシから rate_limit.actor_header が届いた場合は利用者ごと、それ以外は接続元ごとに数える。
+// 制限する場合は X-RateLimit-Limit・X-RateLimit-Remaining・X-RateLimit-Reset（UNIX秒）を付け、超えた場合は429を返す。
+// APIキーを照合した後に実行する。
+func RateLimitMiddleware(rateLimitConfig config.RateLimitConfig, securityConfig config.SecurityConfig) gin.HandlerFunc {
+	return rateLimitMiddleware(rateLimitConfig, securityConfig, newRateLimiter())
+}
+
+// rateLimitMiddleware limiter で数える RateLimitMiddleware
+func rateLimitMiddleware(rateLimitConfig config.RateLimitConfig, securityConfig config.SecurityConfig, limiter *rateLimiter) gin.HandlerFunc {
+	useClientIP := len(securityConfig.TrustedProxies) > 0
+	trustedProxies := securityConfig.TrustedNetworks()
+
+	return func(c *gin.Context) {
+		if !rateLimitConfig.Enabled {
+			c.Next()
+			return
+		}
+
+		key, tier := rateLimitKey(c, rateLimitConfig, useClientIP, trustedProxies)
+		limit := rateLimitConfig.Limit(tier)
+		// 0 の階層は制限しない
+		if limit == 0 {
+			c.Next()
+			return
+		}
+
+		result := limiter.allow(key, limit)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(result.reset.Unix(), 10))
+		if !result.allowed {
+			abortRateLimited(c, limiter, result)
+			return
+		}
+		c.Next()
+	}
+}
+
+// FailedAuthRateLimitMiddleware 認証に失敗したリクエスト（401）を接続元ごとに数え、rate_limit.requests_per_minute 件に達した接続元のリクエストを拒否するミドルウェア
+//
+// APIキーを総当たりで試すリクエストは RateLimitMiddleware に届く前に401になるため、APIキーを照合する前に実行する。
+func FailedAuthRateLimitMiddleware(rateLimitConfig config.RateLimitConfig, securityConfig config.SecurityConfig) gin.HandlerFunc {
+	return failedAuthRateLimitMiddleware(rateLimitConfig, securityConfig, newRateLimiter())
+}
+
+// failedAuthRateLimitMiddleware limiter で数える FailedAuthRateLimitMiddleware
+func failedAuthRateLimitMiddleware(rateLimitConfig config.RateLimitConfig, securityConfig config.SecurityConfig, limiter *rateLimiter) gin.HandlerFunc {
+	useClientIP := len(securityConfig.TrustedProxies) > 0
+
+	return func(c *gin.Context) {
+		if !rateLimitConfig.Enabled {
+			c.Next()
+			return
+		}
+
+		key := "auth_failure:" + clientAddress(c, useClientIP)
+		if result := limiter.peek(key, rateLimitConfig.RequestsPerMinute); !result.allowed {
+			abortRateLimited(c, limiter, result)
+			return
+		}
+		c.Next()
+		if c.Writer.Status() == http.StatusUnauthorized {
+			limiter.allow(key, rateLimitConfig.RequestsPerMinute)
+		}
+	}
+}
+
+// abortRateLimited 上限を超えたリクエストに429を返す
+func abortRateLimited(c *gin.Context, limiter *rateLimiter, result rateLimitResult) {
+	retryAfter := int(result.reset.Sub(limiter.now()).Seconds()) + 1
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{
+		Error:     "rate_limited",
+		Message:   "Too many requests, retry after the rate limit resets",
+		Code:      http.StatusTooManyRequests,
+		ErrorCode: errors.CodeRateLimited,
+	})
+}
+
+// rateLimitKey リクエストを数えるキーと、割り当てた階層
+//
+// 利用者のヘッダーはクライアントが自由に付けられるため、信頼するプロキシから直接届いたリクエストの場合のみ使用する。
+func rateLimitKey(c *gin.Context, rateLimitConfig config.RateLimitConfig, useClientIP bool, trustedProxies []*net.IPNet) (string, string) {
+	if value, ok := c.Get(apiKeyContextKey); ok {
+		key := value.(*models.APIKey)
+		return "api_key:" + key.ID, rateLimitConfig.APIKeyTiers[key.ID]
+	}
+	if rateLimitConfig.ActorHeader != "" && fromTrustedProxy(c, trustedProxies) {
+		if actor := strings.TrimSpace(c.GetHeader(rateLimitConfig.ActorHeader)); actor != "" {
+			return "actor:" + actor, rateLimitConfig.ActorTiers[actor]
+		}
+	}
+	return "ip:" + clientAddress(c, useClientIP), ""
+}
+
+// clientAddress リクエストの接続元（信頼するプロキシを設定した場合は X-Forwarded-For の接続元）
+func clientAddress(c *gin.Context, useClientIP bool) string {
+	if useClientIP {
+		return c.ClientIP()
+	}
+	return c.RemoteIP()
+}
+
+// fromTrustedProxy TCPの接続元が信頼するプロキシか
+func fromTrustedProxy(c *gin.Context, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(c.RemoteIP())
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
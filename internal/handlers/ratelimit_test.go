@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+func TestRateLimiter_Window(t *testing.T) {
+	limiter := newRateLimiter()
+	now := time.Date(2025, 1, 2, 3, 4, 30, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	first := limiter.allow("ip:192.0.2.1", 2)
+	assert.True(t, first.allowed)
+	assert.Equal(t, 1, first.remaining)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 5, 0, 0, time.UTC), first.reset)
+	assert.True(t, limiter.allow("ip:192.0.2.1", 2).allowed)
+	assert.False(t, limiter.allow("ip:192.0.2.1", 2).allowed)
+	// キーごとに数える
+	assert.True(t, limiter.allow("ip:192.0.2.2", 2).allowed)
+
+	// 次の期間になったら数え直す
+	now = now.Add(30 * time.Second)
+	assert.True(t, limiter.allow("ip:192.0.2.1", 2).allowed)
+}
+
+// setupRateLimitRouter rate_limit を有効にし、X-Test-Key ヘッダーの値をAPIキーのIDとして扱うルーター（httptest の接続元 192.0.2.1 を信頼するプロキシにする）
+func setupRateLimitRouter(rateLimitConfig config.RateLimitConfig) *gin.Engine {
+	return setupRateLimitRouterWithSecurity(rateLimitConfig, config.SecurityConfig{TrustedProxies: []string{"192.0.2.1"}})
+}
+
+// setupRateLimitRouterWithSecurity securityConfig の信頼するプロキシで setupRateLimitRouter と同じルーターを作成
+func setupRateLimitRouterWithSecurity(rateLimitConfig config.RateLimitConfig, securityConfig config.SecurityConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	limiter := newRateLimiter()
+	now := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if id := c.GetHeader("X-Test-Key"); id != "" {
+			c.Set(apiKeyContextKey, &models.APIKey{ID: id, Scope: models.APIKeyScopeWrite})
+		}
+	})
+	router.Use(rateLimitMiddleware(rateLimitConfig, securityConfig, limiter))
+	router.GET("/test", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return router
+}
+
+func doRateLimitRequest(router *gin.Engine, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	router := setupRateLimitRouter(config.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerMinute: 2,
+		Tiers:             map[string]int{"partner": 3, "internal": 0},
+		APIKeyTiers:       map[string]string{"partner-key": "partner", "internal-key": "internal"},
+		ActorHeader:       "X-User",
+		ActorTiers:        map[string]string{"alice": "partner"},
+	})
+
+	rr := doRateLimitRequest(router, nil)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1735787100", rr.Header().Get("X-RateLimit-Reset"))
+	doRateLimitRequest(router, nil)
+
+	rr = doRateLimitRequest(router, nil)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "61", rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), errors.CodeRateLimited)
+
+	// APIキー・利用者は接続元とは別に、割り当てた階層の件数まで許可する
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusNoContent, doRateLimitRequest(router, map[string]string{"X-Test-Key": "partner-key"}).Code)
+		assert.Equal(t, http.StatusNoContent, doRateLimitRequest(router, map[string]string{"X-User": "alice"}).Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitRequest(router, map[string]string{"X-Test-Key": "partner-key"}).Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitRequest(router, map[string]string{"X-User": "alice"}).Code)
+	assert.Equal(t, http.StatusNoContent, doRateLimitRequest(router, map[string]string{"X-User": "bob"}).Code)
+
+	// 0 の階層は制限せず、ヘッダーも付けない
+	for i := 0; i < 5; i++ {
+		rr = doRateLimitRequest(router, map[string]string{"X-Test-Key": "internal-key"})
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	}
+	assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimitMiddleware_Disabled(t *testing.T) {
+	router := setupRateLimitRouter(config.RateLimitConfig{RequestsPerMinute: 1})
+
+	for i := 0; i < 3; i++ {
+		rr := doRateLimitRequest(router, nil)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+	}
+}
+
+func TestRateLimitMiddleware_IgnoresActorHeaderFromUntrustedClients(t *testing.T) {
+	router := setupRateLimitRouterWithSecurity(config.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerMinute: 2,
+		ActorHeader:       "X-User",
+	}, config.SecurityConfig{TrustedProxies: []string{"10.0.0.0/8"}})
+
+	// ヘッダーの値を変えても接続元ごとに数える
+	for _, actor := range []string{"alice", "bob"} {
+		assert.Equal(t, http.StatusNoContent, doRateLimitRequest(router, map[string]string{"X-User": actor}).Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitRequest(router, map[string]string{"X-User": "carol"}).Code)
+}
+
+func TestFailedAuthRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := newRateLimiter()
+	now := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.Use(failedAuthRateLimitMiddleware(config.RateLimitConfig{Enabled: true, RequestsPerMinute: 2}, config.SecurityConfig{}, limiter))
+	router.GET("/test", func(c *gin.Context) {
+		if c.GetHeader("X-API-Key") != "valid" {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	// 認証に成功したリクエストは数えない
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusNoContent, doRateLimitRequest(router, map[string]string{"X-API-Key": "valid"}).Code)
+	}
+
+	// 誤ったAPIキーを上限まで試した接続元は、APIキーを照合する前に拒否する
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusUnauthorized, doRateLimitRequest(router, map[string]string{"X-API-Key": "guess"}).Code)
+	}
+	rr := doRateLimitRequest(router, map[string]string{"X-API-Key": "guess"})
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "61", rr.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitRequest(router, map[string]string{"X-API-Key": "valid"}).Code)
+
+	// 次の期間になったら数え直す
+	now = now.Add(time.Minute)
+	assert.Equal(t, http.StatusNoContent, doRateLimitRequest(router, map[string]string{"X-API-Key": "valid"}).Code)
+}
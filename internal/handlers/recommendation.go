@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/services"
+)
+
+// EnableRecommendations 現在のポイントで獲得できる報酬のエンドポイントを登録
+func (s *Server) EnableRecommendations(recommendations services.RecommendationService) {
+	s.recommendationService = recommendations
+
+	s.api.GET("/rewards/affordable", s.listAffordableRewards)
+}
+
+// listAffordableRewards GET /api/rewards/affordable - 現在のポイントで獲得できる報酬を残高に近い順に取得（次の報酬を獲得できるまでの日数の見込み付き）
+func (s *Server) listAffordableRewards(c *gin.Context) {
+	affordable, err := s.recommendationService.Affordable(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("recommendation", "affordable", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	rewards := make([]AffordableRewardResponse, len(affordable.Rewards))
+	for i, entry := range affordable.Rewards {
+		rewards[i] = AffordableRewardResponse{
+			Reward:    newRewardResponse(entry.Reward),
+			Remaining: entry.Remaining,
+		}
+	}
+
+	response := AffordableRewardsResponse{
+		Rewards:            rewards,
+		Count:              len(rewards),
+		Balance:            affordable.Balance,
+		Spendable:          affordable.Spendable,
+		AverageDailyPoints: affordable.AverageDailyPoints,
+		GeneratedAt:        affordable.GeneratedAt,
+	}
+	if affordable.Next != nil {
+		response.Next = &NextRewardResponse{
+			Reward:        newRewardResponse(affordable.Next),
+			PointsNeeded:  affordable.PointsNeeded,
+			EstimatedDays: affordable.DaysUntilNext,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AffordableRewardResponse 現在のポイントで獲得できる報酬のレスポンス
+type AffordableRewardResponse struct {
+	Reward RewardResponse `json:"reward"`
+	// Remaining 獲得した後に使えるポイント
+	Remaining int `json:"remaining"`
+}
+
+// NextRewardResponse まだ獲得できない報酬のうち、最も少ないポイントで獲得できる報酬のレスポンス
+type NextRewardResponse struct {
+	Reward       RewardResponse `json:"reward"`
+	PointsNeeded int            `json:"points_needed"`
+	// EstimatedDays 直近30日のペースで獲得した場合に獲得できるまでの日数（獲得がない場合は省略）
+	EstimatedDays *int `json:"estimated_days,omitempty"`
+}
+
+// AffordableRewardsResponse 現在のポイントで獲得できる報酬の一覧レスポンス
+type AffordableRewardsResponse struct {
+	Rewards []AffordableRewardResponse `json:"rewards"`
+	Count   int                        `json:"count"`
+	Balance int                        `json:"balance"`
+	// Spendable 取り置きを除いて使えるポイント
+	Spendable          int     `json:"spendable"`
+	AverageDailyPoints float64 `json:"average_daily_points"`
+	// Next 次に獲得できる見込みの報酬（すべての報酬を獲得できる場合は省略）
+	Next        *NextRewardResponse `json:"next,omitempty"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/models"
+)
+
+// MockRecommendationService モックの報酬の提案サービス
+type MockRecommendationService struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationService) Affordable(ctx context.Context) (*models.AffordableRewards, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AffordableRewards), args.Error(1)
+}
+
+func TestListAffordableRewards(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	recommendationService := &MockRecommendationService{}
+	server.EnableRecommendations(recommendationService)
+
+	days := 7
+	recommendationService.On("Affordable").Return(&models.AffordableRewards{
+		Balance:   200,
+		Spendable: 100,
+		Rewards: []*models.AffordableReward{
+			{Reward: &models.Reward{ID: "tea", Title: "紅茶", Point: 90}, Remaining: 10},
+			{Reward: &models.Reward{ID: "coffee", Title: "コーヒー", Point: 50}, Remaining: 50},
+		},
+		AverageDailyPoints: 3,
+		Next:               &models.Reward{ID: "book", Title: "本", Point: 120},
+		PointsNeeded:       20,
+		DaysUntilNext:      &days,
+	}, nil)
+
+	// /rewards/{id} より優先して一致することを確認
+	req := httptest.NewRequest(http.MethodGet, "/api/rewards/affordable", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response AffordableRewardsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, "tea", response.Rewards[0].Reward.ID)
+	assert.Equal(t, 10, response.Rewards[0].Remaining)
+	assert.Equal(t, 100, response.Spendable)
+	require.NotNil(t, response.Next)
+	assert.Equal(t, "book", response.Next.Reward.ID)
+	assert.Equal(t, 20, response.Next.PointsNeeded)
+	require.NotNil(t, response.Next.EstimatedDays)
+	assert.Equal(t, 7, *response.Next.EstimatedDays)
+}
+
+func TestListAffordableRewards_AllAffordable(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	recommendationService := &MockRecommendationService{}
+	server.EnableRecommendations(recommendationService)
+
+	recommendationService.On("Affordable").Return(&models.AffordableRewards{
+		Balance:   100,
+		Spendable: 100,
+		Rewards:   []*models.AffordableReward{{Reward: &models.Reward{ID: "coffee", Title: "コーヒー", Point: 50}, Remaining: 50}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rewards/affordable", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	// すべての報酬を獲得できる場合は次の報酬を省略する
+	assert.NotContains(t, rr.Body.String(), `"next"`)
+}
+
+func TestListAffordableRewards_Error(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	recommendationService := &MockRecommendationService{}
+	server.EnableRecommendations(recommendationService)
+
+	recommendationService.On("Affordable").Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rewards/affordable", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/services"
+)
+
+// EnableReminders 今日リマインドの対象になる達成目録のエンドポイントを登録
+func (s *Server) EnableReminders(reminders services.ReminderService) {
+	s.reminderService = reminders
+
+	s.api.GET("/reminders", s.listReminders)
+}
+
+// listReminders GET /api/reminders - 今日リマインドの対象になる未達成の達成目録一覧取得
+func (s *Server) listReminders(c *gin.Context) {
+	reminders, err := s.reminderService.Pending(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("reminder", "pending", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]ReminderResponse, len(reminders))
+	for i, reminder := range reminders {
+		response[i] = ReminderResponse{
+			AchievementID:    reminder.AchievementID,
+			AchievementTitle: reminder.AchievementTitle,
+			Kind:             string(reminder.Kind),
+			DueDate:          reminder.DueDate,
+			Overdue:          reminder.Overdue,
+			Schedule:         reminder.Schedule,
+		}
+	}
+
+	c.JSON(http.StatusOK, ListRemindersResponse{
+		Reminders: response,
+		Count:     len(response),
+	})
+}
+
+// ReminderResponse リマインドのレスポンス
+type ReminderResponse struct {
+	AchievementID    string `json:"achievement_id"`
+	AchievementTitle string `json:"achievement_title"`
+	// Kind recurring（今日まだ達成していない）または due（期限を迎えてまだ達成していない）
+	Kind     string `json:"kind"`
+	DueDate  string `json:"due_date,omitempty"`
+	Overdue  bool   `json:"overdue"`
+	Schedule string `json:"schedule"`
+}
+
+// ListRemindersResponse リマインド一覧レスポンス
+type ListRemindersResponse struct {
+	Reminders []ReminderResponse `json:"reminders"`
+	Count     int                `json:"count"`
+}
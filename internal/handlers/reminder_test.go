@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/models"
+)
+
+// MockReminderService モックのリマインドサービス
+type MockReminderService struct {
+	mock.Mock
+}
+
+func (m *MockReminderService) Pending(ctx context.Context) ([]*models.Reminder, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reminder), args.Error(1)
+}
+
+func (m *MockReminderService) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	args := m.Called(at)
+	return args.Int(0), args.Error(1)
+}
+
+func TestListReminders(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	reminderService := &MockReminderService{}
+	server.EnableReminders(reminderService)
+
+	reminderService.On("Pending").Return([]*models.Reminder{
+		{AchievementID: "run", AchievementTitle: "Run", Kind: models.ReminderRecurring, Schedule: "0 7 * * *"},
+		{AchievementID: "tax", AchievementTitle: "Tax return", Kind: models.ReminderDue, DueDate: "2024-06-09", Overdue: true, Schedule: "0 20 * * *"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/reminders", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListRemindersResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, "recurring", response.Reminders[0].Kind)
+	assert.Equal(t, "due", response.Reminders[1].Kind)
+	assert.Equal(t, "2024-06-09", response.Reminders[1].DueDate)
+	assert.True(t, response.Reminders[1].Overdue)
+}
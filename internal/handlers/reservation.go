@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/services"
+)
+
+// EnableReservations 報酬のためのポイントの取り置きのエンドポイントを登録
+func (s *Server) EnableReservations(reservations services.ReservationService) {
+	s.reservationService = reservations
+
+	s.api.PUT("/rewards/:id/reservation", s.reserveReward)
+	s.api.DELETE("/rewards/:id/reservation", s.releaseReservation)
+	s.api.GET("/reservations", s.listReservations)
+}
+
+// reserveReward PUT /api/rewards/{id}/reservation - 報酬のためにポイントを取り置く（取り置き済みの場合は取り置くポイントを置き換える）
+func (s *Server) reserveReward(c *gin.Context) {
+	var req ReserveRewardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	reservation, err := s.reservationService.Reserve(c.Request.Context(), c.Param("id"), req.Points)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("reservation", "reserve", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"reward_id": reservation.RewardID,
+		"points":    reservation.Points,
+	}).Info("Points reserved for reward")
+
+	c.JSON(http.StatusOK, ReservationResponse{
+		RewardID:   reservation.RewardID,
+		Points:     reservation.Points,
+		ReservedAt: reservation.CreatedAt,
+		UpdatedAt:  reservation.UpdatedAt,
+	})
+}
+
+// releaseReservation DELETE /api/rewards/{id}/reservation - 取り置きを解除
+func (s *Server) releaseReservation(c *gin.Context) {
+	if err := s.reservationService.Release(c.Request.Context(), c.Param("id")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reservation released",
+	})
+}
+
+// listReservations GET /api/reservations - 取り置きの一覧と、取り置きを除いて使えるポイントを取得
+func (s *Server) listReservations(c *gin.Context) {
+	summary, err := s.reservationService.List(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]ReservationResponse, len(summary.Reservations))
+	for i, entry := range summary.Reservations {
+		response[i] = ReservationResponse{
+			RewardID:   entry.Reservation.RewardID,
+			Points:     entry.Reservation.Points,
+			ReservedAt: entry.Reservation.CreatedAt,
+			UpdatedAt:  entry.Reservation.UpdatedAt,
+		}
+		if entry.Reward != nil {
+			reward := newRewardResponse(entry.Reward)
+			response[i].Reward = &reward
+		}
+	}
+
+	c.JSON(http.StatusOK, ListReservationsResponse{
+		Balance:      summary.Balance,
+		Reserved:     summary.Reserved,
+		Spendable:    summary.Spendable,
+		Reservations: response,
+		Count:        len(response),
+	})
+}
+
+// ReserveRewardRequest ポイントの取り置きリクエスト
+type ReserveRewardRequest struct {
+	Points int `json:"points" binding:"required,min=1"`
+}
+
+// ReservationResponse ポイントの取り置きのレスポンス
+type ReservationResponse struct {
+	RewardID string `json:"reward_id"`
+	// Reward 取り置いた報酬（取り置いた後に削除された場合は省略）
+	Reward     *RewardResponse `json:"reward,omitempty"`
+	Points     int             `json:"points"`
+	ReservedAt time.Time       `json:"reserved_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// ListReservationsResponse ポイントの取り置き一覧レスポンス
+type ListReservationsResponse struct {
+	Balance      int                   `json:"balance"`
+	Reserved     int                   `json:"reserved"`
+	Spendable    int                   `json:"spendable"`
+	Reservations []ReservationResponse `json:"reservations"`
+	Count        int                   `json:"count"`
+}
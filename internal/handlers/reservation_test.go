@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockReservationService モックのポイントの取り置きサービス
+type MockReservationService struct {
+	mock.Mock
+}
+
+func (m *MockReservationService) Reserve(ctx context.Context, rewardID string, points int) (*models.Reservation, error) {
+	args := m.Called(rewardID, points)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationService) Release(ctx context.Context, rewardID string) error {
+	args := m.Called(rewardID)
+	return args.Error(0)
+}
+
+func (m *MockReservationService) List(ctx context.Context) (*models.ReservationSummary, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReservationSummary), args.Error(1)
+}
+
+func TestReservations(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	reservationService := &MockReservationService{}
+	server.EnableReservations(reservationService)
+
+	reservationService.On("Reserve", "switch", 250).Return(&models.Reservation{RewardID: "switch", Points: 250}, nil)
+	reservationService.On("Reserve", "switch", 900).Return(nil, &errors.BusinessLogicError{Operation: "Reserve", Reason: "insufficient points"})
+	reservationService.On("Release", "switch").Return(nil)
+	reservationService.On("List").Return(&models.ReservationSummary{
+		Balance:   300,
+		Reserved:  350,
+		Spendable: 0,
+		Reservations: []*models.ReservationEntry{
+			{Reservation: &models.Reservation{RewardID: "switch", Points: 250}, Reward: &models.Reward{ID: "switch", Title: "Switchのゲーム", Point: 300}},
+			{Reservation: &models.Reservation{RewardID: "deleted", Points: 100}},
+		},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/rewards/switch/reservation", strings.NewReader(`{"points":250}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/rewards/switch/reservation", strings.NewReader(`{"points":900}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/rewards/switch/reservation", strings.NewReader(`{"points":0}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/reservations", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response ListReservationsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 300, response.Balance)
+	assert.Equal(t, 350, response.Reserved)
+	assert.Equal(t, 0, response.Spendable)
+	require.Equal(t, 2, response.Count)
+	assert.Equal(t, "Switchのゲーム", response.Reservations[0].Reward.Title)
+	assert.Nil(t, response.Reservations[1].Reward)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/rewards/switch/reservation", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	reservationService.AssertExpectations(t)
+}
@@ -36,10 +36,42 @@ func TestCreateReward(t *testing.T) {
 				Point:       100,
 			},
 			setupMock: func(m *MockRewardService) {
-				m.On("Create", mock.AnythingOfType("*models.Reward")).Return(nil)
+				// IDはリポジトリで採番される
+				m.On("Create", mock.AnythingOfType("*models.Reward")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.Reward).ID = "01J0000000000000000000000R"
+				}).Return(nil)
 			},
 			expectedStatus: http.StatusCreated,
 		},
+		{
+			name: "呼び出し側で指定したID",
+			requestBody: CreateRewardRequest{
+				ID:          "01J0000000000000000000000R",
+				Title:       "Test Reward",
+				Description: "Test Description",
+				Point:       100,
+			},
+			setupMock: func(m *MockRewardService) {
+				m.On("Create", mock.MatchedBy(func(reward *models.Reward) bool {
+					return reward.ID == "01J0000000000000000000000R"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "IDがULIDではない場合",
+			requestBody: CreateRewardRequest{
+				ID:          "not-a-ulid",
+				Title:       "Test Reward",
+				Description: "Test Description",
+				Point:       100,
+			},
+			setupMock: func(m *MockRewardService) {
+				m.On("Create", mock.AnythingOfType("*models.Reward")).Return(&errors.ValidationError{Field: "id", Message: "id must be a valid ULID"})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation_error",
+		},
 		{
 			name: "タイトル未入力エラー",
 			requestBody: CreateRewardRequest{
@@ -50,6 +82,36 @@ func TestCreateReward(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "validation_error",
 		},
+		{
+			name: "抽選型の報酬",
+			requestBody: CreateRewardRequest{
+				ID:          "01J0000000000000000000000R",
+				Title:       "Test Reward",
+				Description: "Test Description",
+				Point:       100,
+				Prizes: []RafflePrizeRequest{
+					{Title: "コーヒー", Weight: 7},
+					{Title: "ランチ", Weight: 3},
+				},
+			},
+			setupMock: func(m *MockRewardService) {
+				m.On("Create", mock.MatchedBy(func(reward *models.Reward) bool {
+					return len(reward.Prizes) == 2 && reward.Prizes[1] == models.RafflePrize{Title: "ランチ", Weight: 3}
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "景品の重みが0",
+			requestBody: CreateRewardRequest{
+				Title:  "ガチャ",
+				Point:  30,
+				Prizes: []RafflePrizeRequest{{Title: "コーヒー", Weight: 0}},
+			},
+			setupMock:      func(m *MockRewardService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation_error",
+		},
 		{
 			name: "ポイント未入力エラー",
 			requestBody: CreateRewardRequest{
@@ -105,7 +167,7 @@ func TestCreateReward(t *testing.T) {
 			tt.setupMock(mockRewardService)
 
 			// サーバーの作成
-			server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+			server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 			// リクエストボディの作成
 			var body []byte
@@ -221,7 +283,7 @@ func TestListRewards(t *testing.T) {
 			tt.setupMock(mockRewardService)
 
 			// サーバーの作成
-			server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+			server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 			// HTTPリクエストの作成
 			req, err := http.NewRequest("GET", "/api/rewards", nil)
@@ -257,6 +319,25 @@ func TestListRewards(t *testing.T) {
 	}
 }
 
+func TestCountRewards(t *testing.T) {
+	server, _, mockRewardService, _ := setupTestServer()
+
+	mockRewardService.On("Count").Return(3, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rewards/count", nil)
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response CountResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response.Count)
+
+	mockRewardService.AssertExpectations(t)
+}
+
 func TestGetReward(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -286,7 +367,7 @@ func TestGetReward(t *testing.T) {
 			name:     "存在しない報酬",
 			rewardID: "nonexistent",
 			setupMock: func(m *MockRewardService) {
-				m.On("GetByID", "nonexistent").Return(nil, fmt.Errorf("resource not found"))
+				m.On("GetByID", "nonexistent").Return(nil, errors.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "not_found",
@@ -314,7 +395,7 @@ func TestGetReward(t *testing.T) {
 			tt.setupMock(mockRewardService)
 
 			// サーバーの作成
-			server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+			server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 			// HTTPリクエストの作成
 			url := "/api/rewards/" + tt.rewardID
@@ -408,7 +489,7 @@ func TestUpdateReward(t *testing.T) {
 				Point:       150,
 			},
 			setupMock: func(m *MockRewardService) {
-				m.On("Update", "nonexistent", mock.AnythingOfType("*models.Reward")).Return(fmt.Errorf("resource not found"))
+				m.On("Update", "nonexistent", mock.AnythingOfType("*models.Reward")).Return(errors.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "not_found",
@@ -424,7 +505,7 @@ func TestUpdateReward(t *testing.T) {
 			tt.setupMock(mockRewardService)
 
 			// サーバーの作成
-			server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+			server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 			// リクエストボディの作成
 			body, err := json.Marshal(tt.requestBody)
@@ -493,7 +574,7 @@ func TestDeleteReward(t *testing.T) {
 			name:     "存在しない報酬削除",
 			rewardID: "nonexistent",
 			setupMock: func(m *MockRewardService) {
-				m.On("Delete", "nonexistent").Return(fmt.Errorf("resource not found"))
+				m.On("Delete", "nonexistent").Return(errors.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "not_found",
@@ -521,7 +602,7 @@ func TestDeleteReward(t *testing.T) {
 			tt.setupMock(mockRewardService)
 
 			// サーバーの作成
-			server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+			server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 			// HTTPリクエストの作成
 			url := "/api/rewards/" + tt.rewardID
@@ -568,20 +649,30 @@ func TestRedeemReward(t *testing.T) {
 		setupMock      func(*MockRewardService)
 		expectedStatus int
 		expectedError  string
+		expectedPrize  string
 	}{
 		{
 			name:     "正常な報酬獲得",
 			rewardID: "reward1",
 			setupMock: func(m *MockRewardService) {
-				m.On("Redeem", "reward1").Return(nil)
+				m.On("Redeem", "reward1").Return(&models.RewardHistory{ID: "history1", RewardID: "reward1", PointCost: 50}, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:     "抽選型の報酬獲得",
+			rewardID: "gacha",
+			setupMock: func(m *MockRewardService) {
+				m.On("Redeem", "gacha").Return(&models.RewardHistory{ID: "history2", RewardID: "gacha", PointCost: 30, Prize: "ランチ"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedPrize:  "ランチ",
+		},
 		{
 			name:     "存在しない報酬獲得",
 			rewardID: "nonexistent",
 			setupMock: func(m *MockRewardService) {
-				m.On("Redeem", "nonexistent").Return(fmt.Errorf("resource not found"))
+				m.On("Redeem", "nonexistent").Return(nil, errors.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "not_found",
@@ -590,7 +681,7 @@ func TestRedeemReward(t *testing.T) {
 			name:     "ポイント不足エラー",
 			rewardID: "reward1",
 			setupMock: func(m *MockRewardService) {
-				m.On("Redeem", "reward1").Return(&errors.BusinessLogicError{
+				m.On("Redeem", "reward1").Return(nil, &errors.BusinessLogicError{
 					Operation: "Redeem",
 					Reason:    "insufficient points",
 				})
@@ -602,7 +693,7 @@ func TestRedeemReward(t *testing.T) {
 			name:     "サービスエラー",
 			rewardID: "reward1",
 			setupMock: func(m *MockRewardService) {
-				m.On("Redeem", "reward1").Return(&errors.DatabaseError{
+				m.On("Redeem", "reward1").Return(nil, &errors.DatabaseError{
 					Operation: "Redeem",
 					Cause:     fmt.Errorf("database error"),
 				})
@@ -621,7 +712,7 @@ func TestRedeemReward(t *testing.T) {
 			tt.setupMock(mockRewardService)
 
 			// サーバーの作成
-			server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+			server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 			// HTTPリクエストの作成
 			url := "/api/rewards/" + tt.rewardID + "/redeem"
@@ -651,6 +742,12 @@ func TestRedeemReward(t *testing.T) {
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				assert.NoError(t, err)
 				assert.Equal(t, "Reward redeemed successfully", response["message"])
+				history := response["history"].(map[string]interface{})
+				if tt.expectedPrize != "" {
+					assert.Equal(t, tt.expectedPrize, history["prize"])
+				} else {
+					assert.NotContains(t, history, "prize")
+				}
 			}
 
 			// モックの検証
@@ -4,25 +4,71 @@ import (
 	"achievement-management/internal/config"
 	"achievement-management/internal/errors"
 	"achievement-management/internal/logging"
+	"achievement-management/internal/metrics"
 	"achievement-management/internal/models"
 	"achievement-management/internal/services"
-	"crypto/rand"
+	stderrors "errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/oklog/ulid/v2"
 )
 
 // Server HTTPサーバー
 type Server struct {
-	achievementService services.AchievementService
-	rewardService      services.RewardService
-	pointService       services.PointService
-	router             *gin.Engine
-	logger             logging.Logger
-	accessLogger       *logging.AccessLogger
-	errorLogger        *logging.ErrorLogger
+	achievementService    services.AchievementService
+	rewardService         services.RewardService
+	pointService          services.PointService
+	backupService         BackupService
+	maintenanceMode       MaintenanceMode
+	effectiveConfig       *config.Config
+	badgeService          services.BadgeService
+	goalService           services.GoalService
+	questService          services.QuestService
+	wishlistService       services.WishlistService
+	reservationService    services.ReservationService
+	recommendationService services.RecommendationService
+	suggestionService     services.SuggestionService
+	noteService           services.NoteService
+	attachmentService     services.AttachmentService
+	reminderService       services.ReminderService
+	allowanceService      services.AllowanceService
+	summaryService        services.SummaryService
+	statsService          services.StatsService
+	consistencyService    services.ConsistencyService
+	journalService        services.JournalService
+	bulkService           services.BulkService
+	apiKeyService         services.APIKeyService
+	// apiKeysRequired /api へのリクエストにAPIキーを必須とするか
+	apiKeysRequired bool
+	// adjustPointsOnDelete adjust_points を指定しない達成目録の削除でポイントを減算するか
+	adjustPointsOnDelete bool
+	// adjustPointsOnUpdate adjust_points を指定しない達成目録の更新でポイントの差分を反映するか
+	adjustPointsOnUpdate bool
+	// refundAdminToken 取り消し期間の経過後に報酬獲得を取り消せる管理用トークン
+	refundAdminToken string
+	router           *gin.Engine
+	// api テナントを解決する /api のルートグループ（任意の機能のエンドポイントを後から登録する）
+	api          *gin.RouterGroup
+	accessLogger *logging.AccessLogger
+	errorLogger  *logging.ErrorLogger
+	logLevels    *logging.LevelController
+	// dependencyChecks ヘルスチェックで接続を確認する依存先
+	dependencyChecks []*dependencyCheck
+}
+
+// ginMode 設定したGinのモード（未設定の場合はログレベルが debug なら debug、それ以外は release）
+func ginMode(config *config.Config) string {
+	if config.Server.Mode != "" {
+		return config.Server.Mode
+	}
+	if config.Logging.Level == "debug" {
+		return gin.DebugMode
+	}
+	return gin.ReleaseMode
 }
 
 // NewServer 新しいサーバーインスタンスを作成
@@ -32,12 +78,8 @@ func NewServer(
 	pointService services.PointService,
 	config *config.Config,
 ) *Server {
-	// ログ設定に基づいてGinのモードを設定
-	if config.Logging.Level == "debug" {
-		gin.SetMode(gin.DebugMode)
-	} else {
-		gin.SetMode(gin.ReleaseMode)
-	}
+	// Ginのモードを設定
+	gin.SetMode(ginMode(config))
 
 	// ロガーを初期化
 	logger, err := logging.NewLogger(config)
@@ -55,46 +97,81 @@ func NewServer(
 		panic("Failed to initialize error logger: " + err.Error())
 	}
 
+	// リクエストのロガーのレベルは実行中に変更できる（アクセスログ・エラーログのレベルは変えない）
+	logLevels, err := logging.NewLevelController(config.Logging.Level)
+	if err != nil {
+		panic("Failed to initialize log level: " + err.Error())
+	}
+	logLevels.Attach(logger)
+
 	router := gin.New()
+	if len(config.Security.TrustedProxies) > 0 {
+		if err := router.SetTrustedProxies(config.Security.TrustedProxies); err != nil {
+			panic("Failed to set trusted proxies: " + err.Error())
+		}
+	}
 
 	server := &Server{
-		achievementService: achievementService,
-		rewardService:      rewardService,
-		pointService:       pointService,
-		router:             router,
-		logger:             logger,
-		accessLogger:       accessLogger,
-		errorLogger:        errorLogger,
+		achievementService:   achievementService,
+		rewardService:        rewardService,
+		pointService:         pointService,
+		adjustPointsOnDelete: config.Points.AdjustOnDelete,
+		adjustPointsOnUpdate: config.Points.AdjustOnUpdate,
+		refundAdminToken:     config.Refunds.AdminToken,
+		router:               router,
+		accessLogger:         accessLogger,
+		errorLogger:          errorLogger,
+		logLevels:            logLevels,
 	}
 
-	// ミドルウェアの設定
+	// ミドルウェアの設定（接続元の制限は最初に、リクエストのロガーはそれ以外のミドルウェアより先に設定する）
+	router.Use(IPFilterMiddleware(config.Security))
+	router.Use(logging.RequestLoggerMiddleware(logger))
 	router.Use(logging.LoggingMiddleware(accessLogger))
 	router.Use(logging.ErrorLoggingMiddleware(errorLogger))
-	router.Use(logging.RecoveryMiddleware(errorLogger))
+	router.Use(logging.RecoveryMiddleware(errorLogger, PanicResponse))
+	router.Use(SecurityHeadersMiddleware(config.Security))
 	router.Use(server.CORSMiddleware())
 
 	// ルートの設定
-	server.setupRoutes()
+	server.setupRoutes(config)
 
 	return server
 }
 
 // setupRoutes ルートの設定
-func (s *Server) setupRoutes() {
+func (s *Server) setupRoutes(cfg *config.Config) {
 	// ヘルスチェックエンドポイント
 	s.router.GET("/health", s.healthCheck)
 
-	// APIルートグループ
+	// リポジトリ呼び出しのメトリクス（Prometheus形式）
+	if cfg.Metrics.Enabled {
+		s.router.GET(cfg.Metrics.Path, gin.WrapH(metrics.Default.Handler()))
+	}
+
+	// APIルートグループ（ヘルスチェックとメトリクスはテナントに依存しない）
 	api := s.router.Group("/api")
+	api.Use(FailedAuthRateLimitMiddleware(cfg.RateLimit, cfg.Security))
+	api.Use(s.APIKeyMiddleware())
+	api.Use(RateLimitMiddleware(cfg.RateLimit, cfg.Security))
+	api.Use(TenantMiddleware(cfg.Tenancy, cfg.Security))
+	api.Use(ActorMiddleware(cfg.Logging.Audit, cfg.Security))
+	s.api = api
 	{
 		// 達成目録エンドポイント（後で実装）
 		achievements := api.Group("/achievements")
 		{
 			achievements.POST("", s.createAchievement)
 			achievements.GET("", s.listAchievements)
+			achievements.GET("/count", s.countAchievements)
+			achievements.PUT("/order", s.reorderAchievements)
 			achievements.GET("/:id", s.getAchievement)
 			achievements.PUT("/:id", s.updateAchievement)
 			achievements.DELETE("/:id", s.deleteAchievement)
+			achievements.POST("/:id/complete", s.completeAchievement)
+			achievements.POST("/:id/timer/start", s.startAchievementTimer)
+			achievements.POST("/:id/timer/stop", s.stopAchievementTimer)
+			achievements.GET("/:id/completions", s.listCompletions)
 		}
 
 		// 報酬エンドポイント（後で実装）
@@ -102,6 +179,7 @@ func (s *Server) setupRoutes() {
 		{
 			rewards.POST("", s.createReward)
 			rewards.GET("", s.listRewards)
+			rewards.GET("/count", s.countRewards)
 			rewards.GET("/:id", s.getReward)
 			rewards.PUT("/:id", s.updateReward)
 			rewards.DELETE("/:id", s.deleteReward)
@@ -114,16 +192,30 @@ func (s *Server) setupRoutes() {
 			points.GET("/current", s.getCurrentPoints)
 			points.GET("/aggregate", s.aggregatePoints)
 			points.GET("/history", s.getPointsHistory)
+			points.GET("/history/count", s.countPointsHistory)
+			points.PATCH("/history/:id", s.annotatePointsHistory)
+			points.POST("/history/:id/refund", s.refundPointsHistory)
+			points.GET("/ledger", s.getPointsLedger)
 		}
+
+		// 連続達成日数エンドポイント
+		api.GET("/streaks", s.getStreaks)
 	}
 }
 
-// healthCheck ヘルスチェックハンドラー
-func (s *Server) healthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"message": "Achievement Management API is running",
-	})
+// requestLogger リクエストのロガー（request_id・method・route、テナントを解決した場合は tenant_id を設定済み）
+func (s *Server) requestLogger(c *gin.Context) logging.Logger {
+	return logging.FromContext(c.Request.Context())
+}
+
+// requestErrorLogger リクエストの相関フィールドを付けて記録するエラーログ用のLogger
+func (s *Server) requestErrorLogger(c *gin.Context) *logging.ErrorLogger {
+	return s.errorLogger.For(c.Request.Context())
+}
+
+// EnableErrorReporting 回復したパニックと記録したデータベース・サービスのエラーを、リクエストの相関フィールドと合わせて reporter に送る
+func (s *Server) EnableErrorReporting(reporter logging.ErrorReporter) {
+	s.errorLogger.SetReporter(reporter)
 }
 
 // Run サーバーを起動
@@ -131,6 +223,11 @@ func (s *Server) Run(addr string) error {
 	return s.router.Run(addr)
 }
 
+// Handler リクエストを処理する http.Handler（Lambdaなど、Run 以外の方法で起動する場合に使用する）
+func (s *Server) Handler() http.Handler {
+	return s.router
+}
+
 // GetRouter ルーターを取得（テスト用）
 func (s *Server) GetRouter() *gin.Engine {
 	return s.router
@@ -140,64 +237,133 @@ func (s *Server) GetRouter() *gin.Engine {
 
 // createAchievement POST /api/achievements - 達成目録作成
 func (s *Server) createAchievement(c *gin.Context) {
-	s.logger.WithField("endpoint", "create_achievement").Debug("Processing create achievement request")
+	s.requestLogger(c).WithField("endpoint", "create_achievement").Debug("Processing create achievement request")
 
 	var req CreateAchievementRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		s.errorLogger.LogAPIError("/api/achievements", "POST", 400, err)
+		s.requestErrorLogger(c).LogAPIError("/api/achievements", "POST", 400, err)
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
 	achievement := req.ToModel()
-	if err := s.achievementService.Create(achievement); err != nil {
-		s.errorLogger.LogServiceError("achievement", "create", err)
+	if err := s.achievementService.Create(c.Request.Context(), achievement); err != nil {
+		s.requestErrorLogger(c).LogServiceError("achievement", "create", err)
 		handleServiceError(c, err)
 		return
 	}
 
-	s.logger.WithFields(map[string]interface{}{
+	s.requestLogger(c).WithFields(map[string]interface{}{
 		"achievement_id": achievement.ID,
 		"title":          achievement.Title,
 		"point":          achievement.Point,
 	}).Info("Achievement created successfully")
 
-	c.JSON(http.StatusCreated, AchievementResponse{
-		ID:          achievement.ID,
-		Title:       achievement.Title,
-		Description: achievement.Description,
-		Point:       achievement.Point,
-		CreatedAt:   achievement.CreatedAt,
+	c.JSON(http.StatusCreated, CreateAchievementResponse{
+		AchievementResponse: AchievementResponse{
+			ID:             achievement.ID,
+			Title:          achievement.Title,
+			Description:    achievement.Description,
+			Point:          achievement.Point,
+			Category:       achievement.Category,
+			DueDate:        achievement.DueDate,
+			Reminder:       achievement.Reminder,
+			Difficulty:     string(achievement.Difficulty),
+			MaxPerDay:      achievement.MaxPerDay,
+			MaxTotal:       achievement.MaxTotal,
+			Pinned:         achievement.Pinned,
+			SortOrder:      achievement.SortOrder,
+			PointsPerHour:  achievement.PointsPerHour,
+			TimerStartedAt: achievement.TimerStartedAt,
+			CreatedAt:      achievement.CreatedAt,
+			Version:        achievement.Version,
+		},
+		Badges:       s.evaluateBadges(c),
+		GoalsReached: s.evaluateGoals(c),
 	})
 }
 
-// listAchievements GET /api/achievements - 達成目録一覧取得
+// listAchievements GET /api/achievements - 達成目録一覧取得（固定した達成目録・並び順を指定した達成目録を先頭に返す）
 func (s *Server) listAchievements(c *gin.Context) {
-	achievements, err := s.achievementService.List()
+	achievements, err := s.achievementService.List(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, s.newListAchievementsResponse(c, achievements))
+}
+
+// reorderAchievements PUT /api/achievements/order - 達成目録の並び順を設定（指定しなかった達成目録の並び順は消す）
+func (s *Server) reorderAchievements(c *gin.Context) {
+	var req ReorderAchievementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	achievements, err := s.achievementService.Reorder(c.Request.Context(), req.IDs)
 	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("achievement", "reorder", err)
 		handleServiceError(c, err)
 		return
 	}
 
+	s.requestLogger(c).WithField("count", len(req.IDs)).Info("Achievements reordered successfully")
+
+	c.JSON(http.StatusOK, s.newListAchievementsResponse(c, achievements))
+}
+
+// newListAchievementsResponse 達成目録一覧のレスポンスを作成
+func (s *Server) newListAchievementsResponse(c *gin.Context, achievements []*models.Achievement) ListAchievementsResponse {
 	response := make([]AchievementResponse, len(achievements))
 	for i, achievement := range achievements {
 		response[i] = AchievementResponse{
-			ID:          achievement.ID,
-			Title:       achievement.Title,
-			Description: achievement.Description,
-			Point:       achievement.Point,
-			CreatedAt:   achievement.CreatedAt,
+			ID:             achievement.ID,
+			Title:          achievement.Title,
+			Description:    achievement.Description,
+			Point:          achievement.Point,
+			Category:       achievement.Category,
+			DueDate:        achievement.DueDate,
+			Reminder:       achievement.Reminder,
+			Difficulty:     string(achievement.Difficulty),
+			MaxPerDay:      achievement.MaxPerDay,
+			MaxTotal:       achievement.MaxTotal,
+			Pinned:         achievement.Pinned,
+			SortOrder:      achievement.SortOrder,
+			PointsPerHour:  achievement.PointsPerHour,
+			TimerStartedAt: achievement.TimerStartedAt,
+			CreatedAt:      achievement.CreatedAt,
+			Version:        achievement.Version,
+			AttachmentURL:  s.attachmentURL(c, achievement.AttachmentKey),
 		}
 	}
 
-	c.JSON(http.StatusOK, ListAchievementsResponse{
+	return ListAchievementsResponse{
 		Achievements: response,
 		Count:        len(response),
-	})
+	}
+}
+
+// countAchievements GET /api/achievements/count - 達成目録の件数取得（テーブルをスキャンしない）
+func (s *Server) countAchievements(c *gin.Context) {
+	count, err := s.achievementService.Count(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountResponse{Count: count})
 }
 
 // getAchievement GET /api/achievements/{id} - 達成目録詳細取得
@@ -205,36 +371,50 @@ func (s *Server) getAchievement(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Achievement ID is required",
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Achievement ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
-	achievement, err := s.achievementService.GetByID(id)
+	achievement, err := s.achievementService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, AchievementResponse{
-		ID:          achievement.ID,
-		Title:       achievement.Title,
-		Description: achievement.Description,
-		Point:       achievement.Point,
-		CreatedAt:   achievement.CreatedAt,
+		ID:             achievement.ID,
+		Title:          achievement.Title,
+		Description:    achievement.Description,
+		Point:          achievement.Point,
+		Category:       achievement.Category,
+		DueDate:        achievement.DueDate,
+		Reminder:       achievement.Reminder,
+		Difficulty:     string(achievement.Difficulty),
+		MaxPerDay:      achievement.MaxPerDay,
+		MaxTotal:       achievement.MaxTotal,
+		Pinned:         achievement.Pinned,
+		SortOrder:      achievement.SortOrder,
+		PointsPerHour:  achievement.PointsPerHour,
+		TimerStartedAt: achievement.TimerStartedAt,
+		CreatedAt:      achievement.CreatedAt,
+		Version:        achievement.Version,
+		AttachmentURL:  s.attachmentURL(c, achievement.AttachmentKey),
 	})
 }
 
-// updateAchievement PUT /api/achievements/{id} - 達成目録更新
+// updateAchievement PUT /api/achievements/{id}?adjust_points=false - 達成目録更新（adjust_points=false の場合はポイントの差分を反映しない）
 func (s *Server) updateAchievement(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Achievement ID is required",
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Achievement ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
@@ -242,48 +422,87 @@ func (s *Server) updateAchievement(c *gin.Context) {
 	var req UpdateAchievementRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
+	adjustPoints, err := parseBoolQuery(c, "adjust_points", s.adjustPointsOnUpdate)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
 	achievement := req.ToModel()
-	if err := s.achievementService.Update(id, achievement); err != nil {
+	if adjustPoints {
+		err = s.achievementService.Update(c.Request.Context(), id, achievement)
+	} else {
+		err = s.achievementService.UpdateWithoutPoints(c.Request.Context(), id, achievement)
+	}
+	if err != nil {
 		handleServiceError(c, err)
 		return
 	}
 
 	// 更新後のデータを取得して返す
-	updatedAchievement, err := s.achievementService.GetByID(id)
+	updatedAchievement, err := s.achievementService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, AchievementResponse{
-		ID:          updatedAchievement.ID,
-		Title:       updatedAchievement.Title,
-		Description: updatedAchievement.Description,
-		Point:       updatedAchievement.Point,
-		CreatedAt:   updatedAchievement.CreatedAt,
+	c.JSON(http.StatusOK, UpdateAchievementResponse{
+		AchievementResponse: AchievementResponse{
+			ID:             updatedAchievement.ID,
+			Title:          updatedAchievement.Title,
+			Description:    updatedAchievement.Description,
+			Point:          updatedAchievement.Point,
+			Category:       updatedAchievement.Category,
+			DueDate:        updatedAchievement.DueDate,
+			Reminder:       updatedAchievement.Reminder,
+			Difficulty:     string(updatedAchievement.Difficulty),
+			MaxPerDay:      updatedAchievement.MaxPerDay,
+			MaxTotal:       updatedAchievement.MaxTotal,
+			Pinned:         updatedAchievement.Pinned,
+			SortOrder:      updatedAchievement.SortOrder,
+			PointsPerHour:  updatedAchievement.PointsPerHour,
+			TimerStartedAt: updatedAchievement.TimerStartedAt,
+			CreatedAt:      updatedAchievement.CreatedAt,
+			Version:        updatedAchievement.Version,
+			AttachmentURL:  s.attachmentURL(c, updatedAchievement.AttachmentKey),
+		},
+		GoalsReached: s.evaluateGoals(c),
 	})
 }
 
-// deleteAchievement DELETE /api/achievements/{id} - 達成目録削除
+// deleteAchievement DELETE /api/achievements/{id}?adjust_points=true - 達成目録削除（adjust_points の場合は付与したポイントも減算）
 func (s *Server) deleteAchievement(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Achievement ID is required",
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Achievement ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
-	if err := s.achievementService.Delete(id); err != nil {
+	adjustPoints, err := parseBoolQuery(c, "adjust_points", s.adjustPointsOnDelete)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	if adjustPoints {
+		err = s.achievementService.DeleteWithPoints(c.Request.Context(), id)
+	} else {
+		err = s.achievementService.Delete(c.Request.Context(), id)
+	}
+	if err != nil {
 		handleServiceError(c, err)
 		return
 	}
@@ -293,6 +512,193 @@ func (s *Server) deleteAchievement(c *gin.Context) {
 	})
 }
 
+// completeAchievement POST /api/achievements/{id}/complete - 達成記録作成（達成目録のポイントを付与）
+func (s *Server) completeAchievement(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Achievement ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	completion, err := s.achievementService.Complete(c.Request.Context(), id)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("achievement", "complete", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"achievement_id": completion.AchievementID,
+		"completion_id":  completion.ID,
+		"point":          completion.Point,
+		"bonus_point":    completion.BonusPoint,
+	}).Info("Achievement completed successfully")
+
+	// クエストのボーナスで目標に届く場合があるため、目標より先にクエストを評価する
+	questsCompleted := s.evaluateQuests(c)
+
+	c.JSON(http.StatusCreated, CompleteAchievementResponse{
+		CompletionResponse: newCompletionResponse(completion),
+		Streak:             s.achievementStreak(c, completion.AchievementID),
+		QuestsCompleted:    questsCompleted,
+		GoalsReached:       s.evaluateGoals(c),
+	})
+}
+
+// startAchievementTimer POST /api/achievements/{id}/timer/start - 達成目録のタイマーを開始
+func (s *Server) startAchievementTimer(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Achievement ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	achievement, err := s.achievementService.StartTimer(c.Request.Context(), id)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("achievement", "start_timer", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"achievement_id": achievement.ID,
+		"started_at":     achievement.TimerStartedAt,
+	}).Info("Achievement timer started")
+
+	c.JSON(http.StatusOK, AchievementResponse{
+		ID:             achievement.ID,
+		Title:          achievement.Title,
+		Description:    achievement.Description,
+		Point:          achievement.Point,
+		Category:       achievement.Category,
+		DueDate:        achievement.DueDate,
+		Reminder:       achievement.Reminder,
+		Difficulty:     string(achievement.Difficulty),
+		MaxPerDay:      achievement.MaxPerDay,
+		MaxTotal:       achievement.MaxTotal,
+		Pinned:         achievement.Pinned,
+		SortOrder:      achievement.SortOrder,
+		PointsPerHour:  achievement.PointsPerHour,
+		TimerStartedAt: achievement.TimerStartedAt,
+		CreatedAt:      achievement.CreatedAt,
+		Version:        achievement.Version,
+		AttachmentURL:  s.attachmentURL(c, achievement.AttachmentKey),
+	})
+}
+
+// stopAchievementTimer POST /api/achievements/{id}/timer/stop - 達成目録のタイマーを止めて達成記録を作成（計測した時間に応じたポイントを付与）
+func (s *Server) stopAchievementTimer(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Achievement ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	completion, err := s.achievementService.StopTimer(c.Request.Context(), id)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("achievement", "stop_timer", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"achievement_id":   completion.AchievementID,
+		"completion_id":    completion.ID,
+		"duration_seconds": completion.DurationSeconds,
+		"point":            completion.Point,
+		"bonus_point":      completion.BonusPoint,
+	}).Info("Achievement timer stopped")
+
+	questsCompleted := s.evaluateQuests(c)
+
+	c.JSON(http.StatusCreated, CompleteAchievementResponse{
+		CompletionResponse: newCompletionResponse(completion),
+		Streak:             s.achievementStreak(c, completion.AchievementID),
+		QuestsCompleted:    questsCompleted,
+		GoalsReached:       s.evaluateGoals(c),
+	})
+}
+
+// listCompletions GET /api/achievements/{id}/completions - 達成記録一覧取得
+func (s *Server) listCompletions(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Achievement ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	completions, err := s.achievementService.ListCompletions(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]CompletionResponse, len(completions))
+	for i, completion := range completions {
+		response[i] = newCompletionResponse(completion)
+	}
+
+	c.JSON(http.StatusOK, ListCompletionsResponse{
+		Completions: response,
+		Count:       len(response),
+		Streak:      s.achievementStreak(c, id),
+	})
+}
+
+// achievementStreak レスポンスに含める達成目録の連続達成日数（達成の記録は済んでいるため、取得に失敗した場合は省略する）
+func (s *Server) achievementStreak(c *gin.Context, achievementID string) *StreakResponse {
+	streak, err := s.achievementService.GetStreak(c.Request.Context(), achievementID)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("achievement", "get_streak", err)
+		return nil
+	}
+	response := newStreakResponse(*streak)
+	return &response
+}
+
+// getStreaks GET /api/streaks - 全体と達成目録ごとの連続達成日数取得
+func (s *Server) getStreaks(c *gin.Context) {
+	summary, err := s.achievementService.GetStreaks(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	achievements := make([]AchievementStreakResponse, len(summary.Achievements))
+	for i, streak := range summary.Achievements {
+		achievements[i] = AchievementStreakResponse{
+			AchievementID:    streak.AchievementID,
+			AchievementTitle: streak.AchievementTitle,
+			StreakResponse:   newStreakResponse(streak.Streak),
+		}
+	}
+
+	c.JSON(http.StatusOK, StreaksResponse{
+		Global:       newStreakResponse(summary.Global),
+		Achievements: achievements,
+	})
+}
+
 // Reward API endpoints implementation
 
 // createReward POST /api/rewards - 報酬作成
@@ -300,31 +706,26 @@ func (s *Server) createReward(c *gin.Context) {
 	var req CreateRewardRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
 	reward := req.ToModel()
-	if err := s.rewardService.Create(reward); err != nil {
+	if err := s.rewardService.Create(c.Request.Context(), reward); err != nil {
 		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, RewardResponse{
-		ID:          reward.ID,
-		Title:       reward.Title,
-		Description: reward.Description,
-		Point:       reward.Point,
-		CreatedAt:   reward.CreatedAt,
-	})
+	c.JSON(http.StatusCreated, newRewardResponse(reward))
 }
 
 // listRewards GET /api/rewards - 報酬一覧取得
 func (s *Server) listRewards(c *gin.Context) {
-	rewards, err := s.rewardService.List()
+	rewards, err := s.rewardService.List(c.Request.Context())
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -332,13 +733,7 @@ func (s *Server) listRewards(c *gin.Context) {
 
 	response := make([]RewardResponse, len(rewards))
 	for i, reward := range rewards {
-		response[i] = RewardResponse{
-			ID:          reward.ID,
-			Title:       reward.Title,
-			Description: reward.Description,
-			Point:       reward.Point,
-			CreatedAt:   reward.CreatedAt,
-		}
+		response[i] = newRewardResponse(reward)
 	}
 
 	c.JSON(http.StatusOK, ListRewardsResponse{
@@ -347,31 +742,37 @@ func (s *Server) listRewards(c *gin.Context) {
 	})
 }
 
+// countRewards GET /api/rewards/count - 報酬の件数取得
+func (s *Server) countRewards(c *gin.Context) {
+	count, err := s.rewardService.Count(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountResponse{Count: count})
+}
+
 // getReward GET /api/rewards/{id} - 報酬詳細取得
 func (s *Server) getReward(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Reward ID is required",
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Reward ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
-	reward, err := s.rewardService.GetByID(id)
+	reward, err := s.rewardService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, RewardResponse{
-		ID:          reward.ID,
-		Title:       reward.Title,
-		Description: reward.Description,
-		Point:       reward.Point,
-		CreatedAt:   reward.CreatedAt,
-	})
+	c.JSON(http.StatusOK, newRewardResponse(reward))
 }
 
 // updateReward PUT /api/rewards/{id} - 報酬更新
@@ -379,9 +780,10 @@ func (s *Server) updateReward(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Reward ID is required",
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Reward ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
@@ -389,33 +791,28 @@ func (s *Server) updateReward(c *gin.Context) {
 	var req UpdateRewardRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
 	reward := req.ToModel()
-	if err := s.rewardService.Update(id, reward); err != nil {
+	if err := s.rewardService.Update(c.Request.Context(), id, reward); err != nil {
 		handleServiceError(c, err)
 		return
 	}
 
 	// 更新後のデータを取得して返す
-	updatedReward, err := s.rewardService.GetByID(id)
+	updatedReward, err := s.rewardService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, RewardResponse{
-		ID:          updatedReward.ID,
-		Title:       updatedReward.Title,
-		Description: updatedReward.Description,
-		Point:       updatedReward.Point,
-		CreatedAt:   updatedReward.CreatedAt,
-	})
+	c.JSON(http.StatusOK, newRewardResponse(updatedReward))
 }
 
 // deleteReward DELETE /api/rewards/{id} - 報酬削除
@@ -423,14 +820,15 @@ func (s *Server) deleteReward(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Reward ID is required",
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Reward ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
-	if err := s.rewardService.Delete(id); err != nil {
+	if err := s.rewardService.Delete(c.Request.Context(), id); err != nil {
 		handleServiceError(c, err)
 		return
 	}
@@ -442,36 +840,47 @@ func (s *Server) deleteReward(c *gin.Context) {
 
 // redeemReward POST /api/rewards/{id}/redeem - 報酬獲得
 func (s *Server) redeemReward(c *gin.Context) {
-	s.logger.WithField("endpoint", "redeem_reward").Debug("Processing reward redemption request")
+	s.requestLogger(c).WithField("endpoint", "redeem_reward").Debug("Processing reward redemption request")
 
 	id := c.Param("id")
 	if id == "" {
-		s.errorLogger.LogAPIError("/api/rewards/{id}/redeem", "POST", 400,
+		s.requestErrorLogger(c).LogAPIError("/api/rewards/{id}/redeem", "POST", 400,
 			&ValidationError{Message: "Reward ID is required"})
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Reward ID is required",
-			Code:    400,
+			Error:     "validation_error",
+			Message:   "Reward ID is required",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
 		})
 		return
 	}
 
-	if err := s.rewardService.Redeem(id); err != nil {
-		s.errorLogger.LogServiceError("reward", "redeem", err)
+	history, err := s.rewardService.Redeem(c.Request.Context(), id)
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("reward", "redeem", err)
 		handleServiceError(c, err)
 		return
 	}
 
-	s.logger.WithField("reward_id", id).Info("Reward redeemed successfully")
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"reward_id": id,
+		"prize":     history.Prize,
+	}).Info("Reward redeemed successfully")
 
-	c.JSON(http.StatusOK, gin.H{
+	// 抽選型の報酬で当選した景品は history.prize で返す
+	response := gin.H{
 		"message": "Reward redeemed successfully",
-	})
+		"history": s.newRewardHistoryResponse(c, history),
+	}
+	if badges := s.evaluateBadges(c); len(badges) > 0 {
+		response["badges"] = badges
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // getCurrentPoints GET /api/points/current - 現在のポイント取得
 func (s *Server) getCurrentPoints(c *gin.Context) {
-	currentPoints, err := s.pointService.GetCurrentPoints()
+	currentPoints, err := s.pointService.GetCurrentPoints(c.Request.Context())
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -486,7 +895,7 @@ func (s *Server) getCurrentPoints(c *gin.Context) {
 
 // aggregatePoints GET /api/points/aggregate - ポイント集計
 func (s *Server) aggregatePoints(c *gin.Context) {
-	summary, err := s.pointService.AggregatePoints()
+	summary, err := s.pointService.AggregatePoints(c.Request.Context())
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -497,12 +906,42 @@ func (s *Server) aggregatePoints(c *gin.Context) {
 		TotalPoints:       summary.TotalPoints,
 		CurrentBalance:    summary.CurrentBalance,
 		Difference:        summary.Difference,
+		Categories:        newCategoryPointsResponses(summary.Categories),
 	})
 }
 
-// getPointsHistory GET /api/points/history - 報酬獲得履歴取得
+// newCategoryPointsResponses 分類ごとの集計結果をレスポンスに変換
+func newCategoryPointsResponses(categories []models.CategoryPoints) []CategoryPointsResponse {
+	response := make([]CategoryPointsResponse, len(categories))
+	for i, category := range categories {
+		response[i] = CategoryPointsResponse{
+			Category: category.Category,
+			Earned:   category.Earned,
+			Count:    category.Count,
+		}
+	}
+	return response
+}
+
+// getPointsHistory GET /api/points/history - 報酬獲得履歴取得（from・to（RFC3339）で獲得日時の範囲を指定できる）
 func (s *Server) getPointsHistory(c *gin.Context) {
-	history, err := s.pointService.GetRewardHistory()
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	var history []*models.RewardHistory
+	if from.IsZero() && to.IsZero() {
+		history, err = s.pointService.GetRewardHistory(c.Request.Context())
+	} else {
+		history, err = s.pointService.GetRewardHistoryBetween(c.Request.Context(), from, to)
+	}
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -510,13 +949,7 @@ func (s *Server) getPointsHistory(c *gin.Context) {
 
 	response := make([]RewardHistoryResponse, len(history))
 	for i, record := range history {
-		response[i] = RewardHistoryResponse{
-			ID:          record.ID,
-			RewardID:    record.RewardID,
-			RewardTitle: record.RewardTitle,
-			PointCost:   record.PointCost,
-			RedeemedAt:  record.RedeemedAt,
-		}
+		response[i] = s.newRewardHistoryResponse(c, record)
 	}
 
 	c.JSON(http.StatusOK, ListRewardHistoryResponse{
@@ -525,49 +958,277 @@ func (s *Server) getPointsHistory(c *gin.Context) {
 	})
 }
 
+// refundPointsHistory POST /api/points/history/{id}/refund - 報酬獲得の取り消し
+//
+// 取り消し期間の経過後は Authorization: Bearer <管理用トークン> を指定した場合のみ取り消せる。
+func (s *Server) refundPointsHistory(c *gin.Context) {
+	id := c.Param("id")
+	history, err := s.rewardService.Refund(c.Request.Context(), id, hasAdminToken(c, s.refundAdminToken))
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("reward", "refund", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"history_id": history.ID,
+		"point_cost": history.PointCost,
+	}).Info("Reward redemption refunded successfully")
+
+	c.JSON(http.StatusOK, s.newRewardHistoryResponse(c, history))
+}
+
+// annotatePointsHistory PATCH /api/points/history/{id} - 報酬獲得履歴の注記・タグの変更（ポイントは変更できない）
+//
+// 変更前と変更後の注記・タグを監査のためにログに記録する。
+func (s *Server) annotatePointsHistory(c *gin.Context) {
+	var req AnnotateRewardHistoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+	if req.PointCost != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "point_cost cannot be changed",
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	previous, history, err := s.pointService.AnnotateRedemption(c.Request.Context(), c.Param("id"), models.RedemptionAnnotation{
+		Note: req.Note,
+		Tags: req.Tags,
+	})
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("point", "annotate_redemption", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"audit":         true,
+		"history_id":    history.ID,
+		"previous_note": previous.Note,
+		"note":          history.Note,
+		"previous_tags": previous.Tags,
+		"tags":          history.Tags,
+	}).Info("Reward history annotated")
+
+	c.JSON(http.StatusOK, s.newRewardHistoryResponse(c, history))
+}
+
+// newRewardHistoryResponse 報酬獲得履歴をレスポンスに変換
+func (s *Server) newRewardHistoryResponse(c *gin.Context, history *models.RewardHistory) RewardHistoryResponse {
+	return RewardHistoryResponse{
+		ID:            history.ID,
+		RewardID:      history.RewardID,
+		RewardTitle:   history.RewardTitle,
+		PointCost:     history.PointCost,
+		RedeemedAt:    history.RedeemedAt,
+		RefundedAt:    history.RefundedAt,
+		Note:          history.Note,
+		Tags:          history.Tags,
+		AttachmentURL: s.attachmentURL(c, history.AttachmentKey),
+		Prize:         history.Prize,
+	}
+}
+
+// countPointsHistory GET /api/points/history/count - 報酬獲得履歴の件数取得
+func (s *Server) countPointsHistory(c *gin.Context) {
+	count, err := s.pointService.CountRewardHistory(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountResponse{Count: count})
+}
+
+// parseBoolQuery 真偽値のクエリパラメータを取得（指定されていない場合は defaultValue）
+func parseBoolQuery(c *gin.Context, name string, defaultValue bool) (bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &errors.ValidationError{Field: name, Message: name + " must be a boolean"}
+	}
+	return parsed, nil
+}
+
+// parseTimeQuery RFC3339形式のクエリパラメータを取得（指定されていない場合はゼロ値）
+func parseTimeQuery(c *gin.Context, name string) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, &errors.ValidationError{Field: name, Message: name + " must be an RFC3339 timestamp"}
+	}
+	return t, nil
+}
+
+// getPointsLedger GET /api/points/ledger - ポイント台帳取得
+func (s *Server) getPointsLedger(c *gin.Context) {
+	entries, err := s.pointService.GetLedger(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]PointLedgerEntryResponse, len(entries))
+	for i, entry := range entries {
+		response[i] = PointLedgerEntryResponse{
+			ID:        entry.ID,
+			Type:      entry.Type,
+			Amount:    entry.Amount,
+			Reference: entry.Reference,
+			CreatedAt: entry.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, ListPointLedgerResponse{
+		Entries: response,
+		Count:   len(response),
+	})
+}
+
 // Achievement API request/response types
 
 // CreateAchievementRequest 達成目録作成リクエスト
 type CreateAchievementRequest struct {
+	// ID 呼び出し側で採番したID（ULID。省略した場合はサーバーで採番し、同じIDと内容で再送した場合は作成済みの達成目録を返す）
+	ID          string `json:"id"`
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description"`
 	Point       int    `json:"point" binding:"required,min=1"`
+	// Category 分類（health・learning など。省略した場合は未分類）
+	Category string `json:"category"`
+	// DueDate 期限（YYYY-MM-DD。省略した場合は期限なし）
+	DueDate string `json:"due_date"`
+	// Reminder リマインドする時刻のcron式（"0 20 * * *" など。省略した場合は期限のある達成目録のみ既定の時刻にリマインドする）
+	Reminder string `json:"reminder"`
+	// Difficulty 難易度（easy・medium・hard。省略した場合は未設定）
+	Difficulty string `json:"difficulty"`
+	// MaxPerDay 1日に達成できる回数の上限（省略した場合は制限なし）
+	MaxPerDay int `json:"max_per_day" binding:"min=0"`
+	// MaxTotal 通算で達成できる回数の上限（省略した場合は制限なし）
+	MaxTotal int `json:"max_total" binding:"min=0"`
+	// Pinned 一覧の先頭に固定するか
+	Pinned bool `json:"pinned"`
+	// PointsPerHour タイマーで計測した時間1時間あたりのポイント（省略した場合はタイマーを止めても point を付与する）
+	PointsPerHour int `json:"points_per_hour" binding:"min=0"`
 }
 
 // ToModel リクエストをモデルに変換
 func (r *CreateAchievementRequest) ToModel() *models.Achievement {
 	return &models.Achievement{
-		ID:          ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String(),
-		Title:       r.Title,
-		Description: r.Description,
-		Point:       r.Point,
-		CreatedAt:   time.Now(),
+		ID:            r.ID,
+		Title:         r.Title,
+		Description:   r.Description,
+		Point:         r.Point,
+		Category:      r.Category,
+		DueDate:       r.DueDate,
+		Reminder:      r.Reminder,
+		Difficulty:    models.Difficulty(r.Difficulty),
+		MaxPerDay:     r.MaxPerDay,
+		MaxTotal:      r.MaxTotal,
+		Pinned:        r.Pinned,
+		PointsPerHour: r.PointsPerHour,
+		CreatedAt:     time.Now(),
 	}
 }
 
 // UpdateAchievementRequest 達成目録更新リクエスト
 type UpdateAchievementRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description"`
-	Point       int    `json:"point" binding:"required,min=1"`
+	Title         string `json:"title" binding:"required"`
+	Description   string `json:"description"`
+	Point         int    `json:"point" binding:"required,min=1"`
+	Category      string `json:"category"`                        // 省略した場合は未分類にする
+	DueDate       string `json:"due_date"`                        // 省略した場合は期限を消す
+	Reminder      string `json:"reminder"`                        // 省略した場合はリマインドの時刻を消す
+	Difficulty    string `json:"difficulty"`                      // 省略した場合は難易度を消す
+	MaxPerDay     int    `json:"max_per_day" binding:"min=0"`     // 省略した場合は1日の回数を制限しない
+	MaxTotal      int    `json:"max_total" binding:"min=0"`       // 省略した場合は通算の回数を制限しない
+	Pinned        bool   `json:"pinned"`                          // 省略した場合は固定を外す
+	SortOrder     int    `json:"sort_order" binding:"min=0"`      // 省略した場合は並び順を消す
+	PointsPerHour int    `json:"points_per_hour" binding:"min=0"` // 省略した場合はタイマーを止めても point を付与する
+	Version       int    `json:"version"`                         // 取得した時点のバージョン（指定した場合は他の更新と競合すると409を返す）
 }
 
 // ToModel リクエストをモデルに変換
 func (r *UpdateAchievementRequest) ToModel() *models.Achievement {
 	return &models.Achievement{
-		Title:       r.Title,
-		Description: r.Description,
-		Point:       r.Point,
+		Title:         r.Title,
+		Description:   r.Description,
+		Point:         r.Point,
+		Category:      r.Category,
+		DueDate:       r.DueDate,
+		Reminder:      r.Reminder,
+		Difficulty:    models.Difficulty(r.Difficulty),
+		MaxPerDay:     r.MaxPerDay,
+		MaxTotal:      r.MaxTotal,
+		Pinned:        r.Pinned,
+		SortOrder:     r.SortOrder,
+		PointsPerHour: r.PointsPerHour,
+		Version:       r.Version,
 	}
 }
 
+// ReorderAchievementsRequest 達成目録の並び替えリクエスト
+type ReorderAchievementsRequest struct {
+	// IDs 一覧に表示する順の達成目録ID
+	IDs []string `json:"ids" binding:"required"`
+}
+
 // AchievementResponse 達成目録レスポンス
 type AchievementResponse struct {
 	ID          string    `json:"id"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	Point       int       `json:"point"`
+	Category    string    `json:"category"`
+	DueDate     string    `json:"due_date,omitempty"`
+	Reminder    string    `json:"reminder,omitempty"`
+	Difficulty  string    `json:"difficulty,omitempty"`
+	MaxPerDay   int       `json:"max_per_day,omitempty"`
+	MaxTotal    int       `json:"max_total,omitempty"`
+	Pinned      bool      `json:"pinned"`
+	SortOrder   int       `json:"sort_order,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
+	Version     int       `json:"version"`
+	// PointsPerHour タイマーで計測した時間1時間あたりのポイント（設定していない場合は省略）
+	PointsPerHour int `json:"points_per_hour,omitempty"`
+	// TimerStartedAt 動いているタイマーを開始した日時（タイマーが止まっている場合は省略）
+	TimerStartedAt *time.Time `json:"timer_started_at,omitempty"`
+	// AttachmentURL 添付ファイルをダウンロードする署名付きURL（添付していない場合は省略）
+	AttachmentURL string `json:"attachment_url,omitempty"`
+}
+
+// CreateAchievementResponse 達成目録作成レスポンス（作成で新たに獲得したバッジ・達成した目標を含む）
+type CreateAchievementResponse struct {
+	AchievementResponse
+	Badges []BadgeResponse `json:"badges,omitempty"`
+	// GoalsReached 作成によって新たに達成した目標
+	GoalsReached []GoalResponse `json:"goals_reached,omitempty"`
+}
+
+// UpdateAchievementResponse 達成目録更新レスポンス
+type UpdateAchievementResponse struct {
+	AchievementResponse
+	// GoalsReached ポイントの差分の反映によって新たに達成した目標
+	GoalsReached []GoalResponse `json:"goals_reached,omitempty"`
 }
 
 // ListAchievementsResponse 達成目録一覧レスポンス
@@ -576,22 +1237,106 @@ type ListAchievementsResponse struct {
 	Count        int                   `json:"count"`
 }
 
+// CompletionResponse 達成記録レスポンス
+type CompletionResponse struct {
+	ID               string    `json:"id"`
+	AchievementID    string    `json:"achievement_id"`
+	AchievementTitle string    `json:"achievement_title"`
+	Point            int       `json:"point"`
+	BonusPoint       int       `json:"bonus_point"`
+	CompletedAt      time.Time `json:"completed_at"`
+	// StartedAt タイマーを止めて達成した場合の、タイマーを開始した日時
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	// DurationSeconds タイマーで計測した時間（秒）
+	DurationSeconds int `json:"duration_seconds,omitempty"`
+}
+
+// newCompletionResponse 達成記録をレスポンスに変換
+func newCompletionResponse(completion *models.Completion) CompletionResponse {
+	return CompletionResponse{
+		ID:               completion.ID,
+		AchievementID:    completion.AchievementID,
+		AchievementTitle: completion.AchievementTitle,
+		Point:            completion.Point,
+		BonusPoint:       completion.BonusPoint,
+		CompletedAt:      completion.CompletedAt,
+		StartedAt:        completion.StartedAt,
+		DurationSeconds:  completion.DurationSeconds,
+	}
+}
+
+// CompleteAchievementResponse 達成記録作成レスポンス（達成後の連続達成日数を含む）
+type CompleteAchievementResponse struct {
+	CompletionResponse
+	Streak *StreakResponse `json:"streak,omitempty"`
+	// QuestsCompleted 達成によってすべてのステップを達成したクエスト
+	QuestsCompleted []QuestResponse `json:"quests_completed,omitempty"`
+	// GoalsReached 付与したポイントによって新たに達成した目標
+	GoalsReached []GoalResponse `json:"goals_reached,omitempty"`
+}
+
+// ListCompletionsResponse 達成記録一覧レスポンス
+type ListCompletionsResponse struct {
+	Completions []CompletionResponse `json:"completions"`
+	Count       int                  `json:"count"`
+	Streak      *StreakResponse      `json:"streak,omitempty"`
+}
+
+// StreakResponse 連続達成日数レスポンス
+type StreakResponse struct {
+	Current         int    `json:"current"`
+	Longest         int    `json:"longest"`
+	LastCompletedOn string `json:"last_completed_on,omitempty"`
+}
+
+// newStreakResponse 連続達成日数をレスポンスに変換
+func newStreakResponse(streak models.Streak) StreakResponse {
+	return StreakResponse{
+		Current:         streak.Current,
+		Longest:         streak.Longest,
+		LastCompletedOn: streak.LastCompletedOn,
+	}
+}
+
+// AchievementStreakResponse 達成目録ごとの連続達成日数レスポンス
+type AchievementStreakResponse struct {
+	AchievementID    string `json:"achievement_id"`
+	AchievementTitle string `json:"achievement_title"`
+	StreakResponse
+}
+
+// StreaksResponse 連続達成日数一覧レスポンス
+type StreaksResponse struct {
+	Global       StreakResponse              `json:"global"`
+	Achievements []AchievementStreakResponse `json:"achievements"`
+}
+
+// CountResponse 件数レスポンス（DynamoDBでテナントを分けない場合はおおよその件数）
+type CountResponse struct {
+	Count int `json:"count"`
+}
+
 // Reward API request/response types
 
 // CreateRewardRequest 報酬作成リクエスト
 type CreateRewardRequest struct {
+	// ID 呼び出し側で採番したID（ULID。省略した場合はサーバーで採番し、同じIDと内容で再送した場合は作成済みの報酬を返す）
+	ID          string `json:"id"`
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description"`
 	Point       int    `json:"point" binding:"required,min=1"`
+	// Prizes 抽選型の報酬の景品（省略した場合は通常の報酬）
+	Prizes []RafflePrizeRequest `json:"prizes" binding:"omitempty,dive"`
 }
 
 // ToModel リクエストをモデルに変換
 func (r *CreateRewardRequest) ToModel() *models.Reward {
 	return &models.Reward{
-		ID:          ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String(),
+		ID:          r.ID,
 		Title:       r.Title,
 		Description: r.Description,
 		Point:       r.Point,
+		Prizes:      toRafflePrizes(r.Prizes),
 		CreatedAt:   time.Now(),
 	}
 }
@@ -601,6 +1346,9 @@ type UpdateRewardRequest struct {
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description"`
 	Point       int    `json:"point" binding:"required,min=1"`
+	Version     int    `json:"version"` // 取得した時点のバージョン（指定した場合は他の更新と競合すると409を返す）
+	// Prizes 抽選型の報酬の景品（省略した場合は通常の報酬に戻す）
+	Prizes []RafflePrizeRequest `json:"prizes" binding:"omitempty,dive"`
 }
 
 // ToModel リクエストをモデルに変換
@@ -609,9 +1357,29 @@ func (r *UpdateRewardRequest) ToModel() *models.Reward {
 		Title:       r.Title,
 		Description: r.Description,
 		Point:       r.Point,
+		Version:     r.Version,
+		Prizes:      toRafflePrizes(r.Prizes),
 	}
 }
 
+// RafflePrizeRequest 抽選型の報酬の景品
+type RafflePrizeRequest struct {
+	Title  string `json:"title" binding:"required"`
+	Weight int    `json:"weight" binding:"required,min=1"`
+}
+
+// toRafflePrizes リクエストの景品をモデルに変換（景品が無い場合は nil）
+func toRafflePrizes(prizes []RafflePrizeRequest) []models.RafflePrize {
+	if len(prizes) == 0 {
+		return nil
+	}
+	converted := make([]models.RafflePrize, len(prizes))
+	for i, prize := range prizes {
+		converted[i] = models.RafflePrize{Title: prize.Title, Weight: prize.Weight}
+	}
+	return converted
+}
+
 // RewardResponse 報酬レスポンス
 type RewardResponse struct {
 	ID          string    `json:"id"`
@@ -619,6 +1387,9 @@ type RewardResponse struct {
 	Description string    `json:"description"`
 	Point       int       `json:"point"`
 	CreatedAt   time.Time `json:"created_at"`
+	Version     int       `json:"version"`
+	// Prizes 抽選型の報酬の景品（通常の報酬の場合は省略）
+	Prizes []models.RafflePrize `json:"prizes,omitempty"`
 }
 
 // ListRewardsResponse 報酬一覧レスポンス
@@ -642,15 +1413,40 @@ type PointSummaryResponse struct {
 	TotalPoints       int `json:"total_points"`
 	CurrentBalance    int `json:"current_balance"`
 	Difference        int `json:"difference"`
+	// Categories 分類ごとの内訳（ポイントの多い順。category が空の要素は未分類の達成目録）
+	Categories []CategoryPointsResponse `json:"categories"`
+}
+
+// CategoryPointsResponse 分類ごとのポイント集計レスポンス
+type CategoryPointsResponse struct {
+	Category string `json:"category"`
+	Earned   int    `json:"earned"`
+	Count    int    `json:"count"`
 }
 
 // RewardHistoryResponse 報酬獲得履歴レスポンス
 type RewardHistoryResponse struct {
-	ID          string    `json:"id"`
-	RewardID    string    `json:"reward_id"`
-	RewardTitle string    `json:"reward_title"`
-	PointCost   int       `json:"point_cost"`
-	RedeemedAt  time.Time `json:"redeemed_at"`
+	ID          string     `json:"id"`
+	RewardID    string     `json:"reward_id"`
+	RewardTitle string     `json:"reward_title"`
+	PointCost   int        `json:"point_cost"`
+	RedeemedAt  time.Time  `json:"redeemed_at"`
+	RefundedAt  *time.Time `json:"refunded_at,omitempty"`
+	// Note 獲得した報酬を何に使ったかなどの注記（未設定の場合は省略）
+	Note string   `json:"note,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+	// AttachmentURL 添付ファイルをダウンロードする署名付きURL（添付していない場合は省略）
+	AttachmentURL string `json:"attachment_url,omitempty"`
+	// Prize 抽選型の報酬で当選した景品（通常の報酬の場合は省略）
+	Prize string `json:"prize,omitempty"`
+}
+
+// AnnotateRewardHistoryRequest 報酬獲得履歴の注記の変更リクエスト（省略した項目は変更しない）
+type AnnotateRewardHistoryRequest struct {
+	Note *string   `json:"note"`
+	Tags *[]string `json:"tags"`
+	// PointCost ポイントは変更できないため、指定された場合はリクエストを拒否する
+	PointCost *int `json:"point_cost"`
 }
 
 // ListRewardHistoryResponse 報酬獲得履歴一覧レスポンス
@@ -659,50 +1455,171 @@ type ListRewardHistoryResponse struct {
 	Count   int                     `json:"count"`
 }
 
+// PointLedgerEntryResponse ポイント台帳のエントリレスポンス
+type PointLedgerEntryResponse struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Amount    int       `json:"amount"`
+	Reference string    `json:"reference,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListPointLedgerResponse ポイント台帳一覧レスポンス
+type ListPointLedgerResponse struct {
+	Entries []PointLedgerEntryResponse `json:"entries"`
+	Count   int                        `json:"count"`
+}
+
 // handleServiceError サービス層のエラーをHTTPレスポンスに変換
 func handleServiceError(c *gin.Context, err error) {
+	// ストレージの障害で呼び出しを遮断している場合・スループットの上限を超えた場合は再試行までの秒数を返す
+	var unavailable *errors.UnavailableError
+	if stderrors.As(err, &unavailable) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(unavailable.RetryAfter.Seconds()))))
+	}
+	var throttled *errors.ThrottledError
+	if stderrors.As(err, &throttled) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	}
+
+	status, response := serviceErrorResponse(err)
+	// 見つからない場合は、ルートからわかるリソースごとのエラーコードを返す
+	if response.ErrorCode == errors.CodeNotFound {
+		response.ErrorCode = notFoundCode(c.FullPath())
+	}
+	c.JSON(status, response)
+}
+
+// notFoundResources IDのパラメーターが続くルートのコレクションごとの、見つからない場合のエラーコード
+var notFoundResources = map[string]errors.Code{
+	"achievements": errors.CodeAchievementNotFound,
+	"rewards":      errors.CodeRewardNotFound,
+	"history":      errors.CodeRedemptionNotFound,
+	"goals":        errors.CodeGoalNotFound,
+	"quests":       errors.CodeQuestNotFound,
+	"allowances":   errors.CodeAllowanceNotFound,
+	"notes":        errors.CodeNoteNotFound,
+	"wishlist":     errors.CodeWishlistItemNotFound,
+	"backups":      errors.CodeBackupNotFound,
+	"api-keys":     errors.CodeAPIKeyNotFound,
+}
+
+// notFoundCode ルート（例: /api/achievements/:id/completions）のIDで指定したリソースが見つからない場合のエラーコード
+//
+// IDのパラメーターが続く最後のコレクションをリソースとする（/api/achievements/:id/notes/:note_id はメモ）。
+// リソースがわからない場合は CodeNotFound を返す。
+func notFoundCode(route string) errors.Code {
+	code := errors.CodeNotFound
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if resource, ok := notFoundResources[segments[i]]; ok && strings.HasPrefix(segments[i+1], ":") {
+			code = resource
+		}
+	}
+	return code
+}
+
+// serviceErrorResponse サービス層のエラーに対応するHTTPステータスとエラーレスポンス
+func serviceErrorResponse(err error) (int, ErrorResponse) {
+	// ストレージの障害で呼び出しを遮断している場合は待たずに503を返す
+	var unavailable *errors.UnavailableError
+	if stderrors.As(err, &unavailable) {
+		return http.StatusServiceUnavailable, ErrorResponse{
+			Error:     "service_unavailable",
+			Message:   "Storage is temporarily unavailable",
+			Code:      503,
+			ErrorCode: errors.CodeServiceUnavailable,
+		}
+	}
+
+	// スループットの上限を超えた場合はクライアントに送信の速度を落とすよう429を返す
+	var throttled *errors.ThrottledError
+	if stderrors.As(err, &throttled) {
+		return http.StatusTooManyRequests, ErrorResponse{
+			Error:     "throttled",
+			Message:   "Storage throughput exceeded, retry later",
+			Code:      429,
+			ErrorCode: errors.CodeThrottled,
+		}
+	}
+
+	// メンテナンス中の書き込みは再試行の時期がわからないため Retry-After を付けない
+	if stderrors.Is(err, errors.ErrReadOnly) {
+		return http.StatusServiceUnavailable, ErrorResponse{
+			Error:     "maintenance",
+			Message:   "Writes are disabled during maintenance",
+			Code:      503,
+			ErrorCode: errors.CodeReadOnly,
+		}
+	}
+
 	switch e := err.(type) {
 	case *errors.ValidationError:
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: e.Error(),
-			Code:    400,
-		})
+		return http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   e.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		}
 	case *errors.BusinessLogicError:
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "business_logic_error",
-			Message: e.Error(),
-			Code:    400,
-		})
+		return http.StatusBadRequest, ErrorResponse{
+			Error:     "business_logic_error",
+			Message:   e.Error(),
+			Code:      400,
+			ErrorCode: e.ErrorCode(),
+		}
 	case *errors.DatabaseError:
 		// データベースエラーの詳細は隠して一般的なメッセージを返す
-		if e.Cause != nil && e.Cause.Error() == "resource not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Resource not found",
-				Code:    404,
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Internal server error",
-				Code:    500,
-			})
+		if stderrors.Is(e.Cause, errors.ErrNotFound) {
+			return http.StatusNotFound, ErrorResponse{
+				Error:     "not_found",
+				Message:   "Resource not found",
+				Code:      404,
+				ErrorCode: errors.CodeNotFound,
+			}
+		}
+		return http.StatusInternalServerError, ErrorResponse{
+			Error:     "internal_error",
+			Message:   "Internal server error",
+			Code:      500,
+			ErrorCode: errors.CodeInternal,
+		}
+	}
+
+	// その他のエラーは内部サーバーエラーとして扱う
+	if stderrors.Is(err, errors.ErrNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error:     "not_found",
+			Message:   "Resource not found",
+			Code:      404,
+			ErrorCode: errors.CodeNotFound,
+		}
+	} else if stderrors.Is(err, errors.ErrDuplicateResource) {
+		return http.StatusConflict, ErrorResponse{
+			Error:     "conflict",
+			Message:   "Resource already exists",
+			Code:      409,
+			ErrorCode: errors.CodeDuplicateResource,
 		}
-	default:
-		// その他のエラーは内部サーバーエラーとして扱う
-		if err.Error() == "resource not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Resource not found",
-				Code:    404,
-			})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Internal server error",
-				Code:    500,
-			})
+	} else if stderrors.Is(err, errors.ErrVersionConflict) {
+		return http.StatusConflict, ErrorResponse{
+			Error:     "conflict",
+			Message:   "Resource was modified by another request",
+			Code:      409,
+			ErrorCode: errors.CodeVersionConflict,
 		}
+	} else if stderrors.Is(err, errors.ErrForbidden) {
+		return http.StatusForbidden, ErrorResponse{
+			Error:     "forbidden",
+			Message:   err.Error(),
+			Code:      403,
+			ErrorCode: errors.CodeForbidden,
+		}
+	}
+	return http.StatusInternalServerError, ErrorResponse{
+		Error:     "internal_error",
+		Message:   "Internal server error",
+		Code:      500,
+		ErrorCode: errors.CodeInternal,
 	}
 }
@@ -1,31 +1,62 @@
 package handlers
 
 import (
+	"context"
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// testConfig テスト用の設定
+func testConfig() *config.Config {
+	return &config.Config{
+		Logging: config.LoggingConfig{
+			Level:  "error",
+			Format: "json",
+			Output: "stdout",
+		},
+		Points: config.PointsConfig{
+			AdjustOnUpdate: true,
+		},
+	}
+}
+
 // MockAchievementService モックの達成目録サービス
 type MockAchievementService struct {
 	mock.Mock
 }
 
-func (m *MockAchievementService) Create(achievement *models.Achievement) error {
+func (m *MockAchievementService) Create(ctx context.Context, achievement *models.Achievement) error {
 	args := m.Called(achievement)
 	return args.Error(0)
 }
 
-func (m *MockAchievementService) Update(id string, achievement *models.Achievement) error {
+func (m *MockAchievementService) Update(ctx context.Context, id string, achievement *models.Achievement) error {
 	args := m.Called(id, achievement)
 	return args.Error(0)
 }
 
-func (m *MockAchievementService) GetByID(id string) (*models.Achievement, error) {
+func (m *MockAchievementService) UpdateWithoutPoints(ctx context.Context, id string, achievement *models.Achievement) error {
+	args := m.Called(id, achievement)
+	return args.Error(0)
+}
+
+func (m *MockAchievementService) Restore(ctx context.Context, achievement *models.Achievement, withPoints bool) error {
+	args := m.Called(achievement, withPoints)
+	return args.Error(0)
+}
+
+func (m *MockAchievementService) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -33,7 +64,7 @@ func (m *MockAchievementService) GetByID(id string) (*models.Achievement, error)
 	return args.Get(0).(*models.Achievement), args.Error(1)
 }
 
-func (m *MockAchievementService) List() ([]*models.Achievement, error) {
+func (m *MockAchievementService) List(ctx context.Context) ([]*models.Achievement, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -41,27 +72,98 @@ func (m *MockAchievementService) List() ([]*models.Achievement, error) {
 	return args.Get(0).([]*models.Achievement), args.Error(1)
 }
 
-func (m *MockAchievementService) Delete(id string) error {
+func (m *MockAchievementService) Reorder(ctx context.Context, ids []string) ([]*models.Achievement, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Achievement), args.Error(1)
+}
+
+func (m *MockAchievementService) Count(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAchievementService) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAchievementService) DeleteWithPoints(ctx context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+func (m *MockAchievementService) DeleteMany(ctx context.Context, ids []string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *MockAchievementService) Complete(ctx context.Context, id string) (*models.Completion, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Completion), args.Error(1)
+}
+
+func (m *MockAchievementService) StartTimer(ctx context.Context, id string) (*models.Achievement, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Achievement), args.Error(1)
+}
+
+func (m *MockAchievementService) StopTimer(ctx context.Context, id string) (*models.Completion, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Completion), args.Error(1)
+}
+
+func (m *MockAchievementService) ListCompletions(ctx context.Context, id string) ([]*models.Completion, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Completion), args.Error(1)
+}
+
+func (m *MockAchievementService) GetStreak(ctx context.Context, id string) (*models.Streak, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Streak), args.Error(1)
+}
+
+func (m *MockAchievementService) GetStreaks(ctx context.Context) (*models.StreakSummary, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakSummary), args.Error(1)
+}
+
 // MockRewardService モックの報酬サービス
 type MockRewardService struct {
 	mock.Mock
 }
 
-func (m *MockRewardService) Create(reward *models.Reward) error {
+func (m *MockRewardService) Create(ctx context.Context, reward *models.Reward) error {
 	args := m.Called(reward)
 	return args.Error(0)
 }
 
-func (m *MockRewardService) Update(id string, reward *models.Reward) error {
+func (m *MockRewardService) Update(ctx context.Context, id string, reward *models.Reward) error {
 	args := m.Called(id, reward)
 	return args.Error(0)
 }
 
-func (m *MockRewardService) GetByID(id string) (*models.Reward, error) {
+func (m *MockRewardService) GetByID(ctx context.Context, id string) (*models.Reward, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -69,7 +171,7 @@ func (m *MockRewardService) GetByID(id string) (*models.Reward, error) {
 	return args.Get(0).(*models.Reward), args.Error(1)
 }
 
-func (m *MockRewardService) List() ([]*models.Reward, error) {
+func (m *MockRewardService) List(ctx context.Context) ([]*models.Reward, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -77,14 +179,30 @@ func (m *MockRewardService) List() ([]*models.Reward, error) {
 	return args.Get(0).([]*models.Reward), args.Error(1)
 }
 
-func (m *MockRewardService) Delete(id string) error {
+func (m *MockRewardService) Count(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRewardService) Delete(ctx context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockRewardService) Redeem(rewardID string) error {
+func (m *MockRewardService) Refund(ctx context.Context, historyID string, admin bool) (*models.RewardHistory, error) {
+	args := m.Called(historyID, admin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RewardHistory), args.Error(1)
+}
+
+func (m *MockRewardService) Redeem(ctx context.Context, rewardID string) (*models.RewardHistory, error) {
 	args := m.Called(rewardID)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RewardHistory), args.Error(1)
 }
 
 // MockPointService モックのポイントサービス
@@ -92,7 +210,7 @@ type MockPointService struct {
 	mock.Mock
 }
 
-func (m *MockPointService) GetCurrentPoints() (*models.CurrentPoints, error) {
+func (m *MockPointService) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -100,17 +218,17 @@ func (m *MockPointService) GetCurrentPoints() (*models.CurrentPoints, error) {
 	return args.Get(0).(*models.CurrentPoints), args.Error(1)
 }
 
-func (m *MockPointService) AddPoints(points int) error {
+func (m *MockPointService) AddPoints(ctx context.Context, points int) error {
 	args := m.Called(points)
 	return args.Error(0)
 }
 
-func (m *MockPointService) SubtractPoints(points int) error {
+func (m *MockPointService) SubtractPoints(ctx context.Context, points int) error {
 	args := m.Called(points)
 	return args.Error(0)
 }
 
-func (m *MockPointService) AggregatePoints() (*models.PointSummary, error) {
+func (m *MockPointService) AggregatePoints(ctx context.Context) (*models.PointSummary, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -118,7 +236,7 @@ func (m *MockPointService) AggregatePoints() (*models.PointSummary, error) {
 	return args.Get(0).(*models.PointSummary), args.Error(1)
 }
 
-func (m *MockPointService) GetRewardHistory() ([]*models.RewardHistory, error) {
+func (m *MockPointService) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -126,6 +244,35 @@ func (m *MockPointService) GetRewardHistory() ([]*models.RewardHistory, error) {
 	return args.Get(0).([]*models.RewardHistory), args.Error(1)
 }
 
+func (m *MockPointService) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.RewardHistory), args.Error(1)
+}
+
+func (m *MockPointService) CountRewardHistory(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPointService) AnnotateRedemption(ctx context.Context, id string, annotation models.RedemptionAnnotation) (*models.RewardHistory, *models.RewardHistory, error) {
+	args := m.Called(id, annotation)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.RewardHistory), args.Get(1).(*models.RewardHistory), args.Error(2)
+}
+
+func (m *MockPointService) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PointLedgerEntry), args.Error(1)
+}
+
 func TestNewServer(t *testing.T) {
 	// モックサービスを作成
 	mockAchievementService := &MockAchievementService{}
@@ -133,7 +280,7 @@ func TestNewServer(t *testing.T) {
 	mockPointService := &MockPointService{}
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// サーバーが正しく初期化されていることを確認
 	assert.NotNil(t, server)
@@ -150,7 +297,7 @@ func TestHealthCheck(t *testing.T) {
 	mockPointService := &MockPointService{}
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/health", nil)
@@ -167,6 +314,34 @@ func TestHealthCheck(t *testing.T) {
 	assert.Contains(t, rr.Body.String(), "Achievement Management API is running")
 }
 
+func TestMetricsEndpoint(t *testing.T) {
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// メトリクスを有効にしてサーバーを作成
+	cfg := testConfig()
+	cfg.Metrics = config.MetricsConfig{Enabled: true, Path: "/metrics"}
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, cfg)
+
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rr.Body.String(), "# TYPE achievement_repository_call_duration_seconds histogram")
+
+	// 無効な場合はルートを登録しない
+	disabled := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+	rr = httptest.NewRecorder()
+	disabled.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
 func TestRouteSetup(t *testing.T) {
 	// モックサービスを作成
 	mockAchievementService := &MockAchievementService{}
@@ -174,7 +349,7 @@ func TestRouteSetup(t *testing.T) {
 	mockPointService := &MockPointService{}
 
 	// サーバーを作成
-	server := NewServer(mockAchievementService, mockRewardService, mockPointService)
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
 
 	// 各エンドポイントが正しく設定されていることを確認
 	testCases := []struct {
@@ -213,4 +388,147 @@ func TestRouteSetup(t *testing.T) {
 			assert.Equal(t, tc.status, rr.Code)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestStorageUnavailable(t *testing.T) {
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// サーキットブレーカーが遮断している場合のエラー
+	unavailable := &errors.DatabaseError{
+		Operation: "List",
+		Table:     "achievements",
+		Cause:     fmt.Errorf("failed to query table achievements: %w", &errors.UnavailableError{Reason: "DynamoDB is failing", RetryAfter: 12500 * time.Millisecond}),
+	}
+	mockAchievementService.On("List").Return(nil, unavailable)
+
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	req, err := http.NewRequest("GET", "/api/achievements", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "13", rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), "service_unavailable")
+}
+
+func TestStorageThrottled(t *testing.T) {
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// リトライしてもスロットリングが解消しなかった場合のエラー
+	throttled := &errors.DatabaseError{
+		Operation: "List",
+		Table:     "rewards",
+		Cause:     fmt.Errorf("failed to scan table rewards: %w", &errors.ThrottledError{Reason: "ProvisionedThroughputExceededException", RetryAfter: 1500 * time.Millisecond}),
+	}
+	mockRewardService.On("List").Return(nil, throttled)
+
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	req, err := http.NewRequest("GET", "/api/rewards", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), "throttled")
+}
+
+func TestStorageReadOnly(t *testing.T) {
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	// メンテナンス中に書き込んだ場合のエラー
+	readOnly := &errors.DatabaseError{
+		Operation: "Delete",
+		Table:     "achievements",
+		Cause:     errors.ErrReadOnly,
+	}
+	mockAchievementService.On("Delete", "ach-1").Return(readOnly)
+
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	req, err := http.NewRequest("DELETE", "/api/achievements/ach-1", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Empty(t, rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), "maintenance")
+}
+
+func TestErrorCodes(t *testing.T) {
+	mockAchievementService := &MockAchievementService{}
+	mockRewardService := &MockRewardService{}
+	mockPointService := &MockPointService{}
+
+	mockAchievementService.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	mockRewardService.On("Redeem", "missing").Return(nil, &errors.DatabaseError{Operation: "GetByID", Table: "rewards", Cause: errors.ErrNotFound})
+	mockRewardService.On("Redeem", "expensive").Return(nil, &errors.BusinessLogicError{Operation: "Redeem", Reason: "insufficient points", Code: errors.CodeInsufficientPoints})
+	mockAchievementService.On("Delete", "a1").Return(&errors.BusinessLogicError{Operation: "Delete", Reason: "not allowed"})
+
+	server := NewServer(mockAchievementService, mockRewardService, mockPointService, testConfig())
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		code   errors.Code
+	}{
+		{method: "GET", path: "/api/achievements/missing", status: http.StatusNotFound, code: errors.CodeAchievementNotFound},
+		{method: "POST", path: "/api/rewards/missing/redeem", status: http.StatusNotFound, code: errors.CodeRewardNotFound},
+		{method: "POST", path: "/api/rewards/expensive/redeem", status: http.StatusBadRequest, code: errors.CodeInsufficientPoints},
+		{method: "DELETE", path: "/api/achievements/a1", status: http.StatusBadRequest, code: errors.CodeBusinessRule},
+		{method: "POST", path: "/api/achievements", status: http.StatusBadRequest, code: errors.CodeValidation},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, tt.path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		var response ErrorResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, tt.status, rr.Code, tt.path)
+		assert.Equal(t, tt.code, response.ErrorCode, tt.path)
+	}
+}
+
+func TestNotFoundCode(t *testing.T) {
+	assert.Equal(t, errors.CodeAchievementNotFound, notFoundCode("/api/achievements/:id/completions"))
+	assert.Equal(t, errors.CodeNoteNotFound, notFoundCode("/api/achievements/:id/notes/:note_id"))
+	assert.Equal(t, errors.CodeRedemptionNotFound, notFoundCode("/api/points/history/:id/refund"))
+	assert.Equal(t, errors.CodeRewardNotFound, notFoundCode("/api/rewards/:id/favorite"))
+	assert.Equal(t, errors.CodeNotFound, notFoundCode("/api/bulk/achievements"))
+}
+
+func TestGinMode(t *testing.T) {
+	tests := []struct {
+		mode  string
+		level string
+		want  string
+	}{
+		{mode: "", level: "debug", want: gin.DebugMode},
+		{mode: "", level: "info", want: gin.ReleaseMode},
+		{mode: "release", level: "debug", want: gin.ReleaseMode},
+		{mode: "debug", level: "error", want: gin.DebugMode},
+	}
+
+	for _, tt := range tests {
+		cfg := testConfig()
+		cfg.Server.Mode = tt.mode
+		cfg.Logging.Level = tt.level
+		assert.Equal(t, tt.want, ginMode(cfg), "mode=%q level=%q", tt.mode, tt.level)
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableStats ポイントの推移の統計のエンドポイントを登録
+func (s *Server) EnableStats(stats services.StatsService) {
+	s.statsService = stats
+
+	s.api.GET("/stats", s.getStats)
+}
+
+// getStats GET /api/stats - 直近7日・30日の獲得・使用ポイントと、最も獲得した日・週を取得
+func (s *Server) getStats(c *gin.Context) {
+	stats, err := s.statsService.Trends(c.Request.Context())
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("stats", "trends", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, StatsResponse{
+		Last7Days:   newTrendWindowResponse(stats.Last7Days),
+		Last30Days:  newTrendWindowResponse(stats.Last30Days),
+		BestDay:     newBestPeriodResponse(stats.BestDay),
+		BestWeek:    newBestPeriodResponse(stats.BestWeek),
+		GeneratedAt: stats.GeneratedAt,
+	})
+}
+
+// newTrendWindowResponse 直近の期間の集計をレスポンスに変換
+func newTrendWindowResponse(window models.TrendWindow) TrendWindowResponse {
+	return TrendWindowResponse{
+		Days:               window.Days,
+		From:               window.From,
+		To:                 window.To,
+		PointsEarned:       window.PointsEarned,
+		PointsSpent:        window.PointsSpent,
+		AverageDailyPoints: window.AverageDailyPoints,
+	}
+}
+
+// newBestPeriodResponse 最も獲得した期間をレスポンスに変換（nilの場合はnil）
+func newBestPeriodResponse(best *models.BestPeriod) *BestPeriodResponse {
+	if best == nil {
+		return nil
+	}
+	return &BestPeriodResponse{From: best.From, To: best.To, Points: best.Points}
+}
+
+// StatsResponse ポイントの推移の統計のレスポンス
+type StatsResponse struct {
+	Last7Days   TrendWindowResponse `json:"last_7_days"`
+	Last30Days  TrendWindowResponse `json:"last_30_days"`
+	BestDay     *BestPeriodResponse `json:"best_day,omitempty"`
+	BestWeek    *BestPeriodResponse `json:"best_week,omitempty"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
+
+// TrendWindowResponse 直近の期間に獲得・使用したポイントのレスポンス
+type TrendWindowResponse struct {
+	Days               int     `json:"days"`
+	From               string  `json:"from"`
+	To                 string  `json:"to"`
+	PointsEarned       int     `json:"points_earned"`
+	PointsSpent        int     `json:"points_spent"`
+	AverageDailyPoints float64 `json:"average_daily_points"`
+}
+
+// BestPeriodResponse 獲得ポイントが最も多かった期間のレスポンス
+type BestPeriodResponse struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Points int    `json:"points"`
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/models"
+)
+
+// MockStatsService モックの統計サービス
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) Trends(ctx context.Context) (*models.TrendStats, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TrendStats), args.Error(1)
+}
+
+func TestGetStats(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	statsService := &MockStatsService{}
+	server.EnableStats(statsService)
+
+	statsService.On("Trends").Return(&models.TrendStats{
+		Last7Days:  models.TrendWindow{Days: 7, From: "2024-06-06", To: "2024-06-12", PointsEarned: 70, PointsSpent: 40, AverageDailyPoints: 10},
+		Last30Days: models.TrendWindow{Days: 30, From: "2024-05-14", To: "2024-06-12", PointsEarned: 150, PointsSpent: 40, AverageDailyPoints: 5},
+		BestDay:    &models.BestPeriod{From: "2024-06-10", To: "2024-06-10", Points: 40},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/stats", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response StatsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 70, response.Last7Days.PointsEarned)
+	assert.Equal(t, 10.0, response.Last7Days.AverageDailyPoints)
+	assert.Equal(t, "2024-05-14", response.Last30Days.From)
+	require.NotNil(t, response.BestDay)
+	assert.Equal(t, 40, response.BestDay.Points)
+	// 獲得がない期間は省略する
+	assert.Nil(t, response.BestWeek)
+	assert.NotContains(t, rr.Body.String(), "best_week")
+}
+
+func TestGetStats_Error(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	statsService := &MockStatsService{}
+	server.EnableStats(statsService)
+
+	statsService.On("Trends").Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/stats", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableSuggestions 難易度から達成目録のポイントを提案するエンドポイントを登録
+func (s *Server) EnableSuggestions(suggestions services.SuggestionService) {
+	s.suggestionService = suggestions
+
+	s.api.GET("/achievements/suggest-point", s.suggestPoint)
+}
+
+// suggestPoint GET /api/achievements/suggest-point?difficulty=hard - 難易度とこれまでの達成目録のポイントからポイントを提案
+func (s *Server) suggestPoint(c *gin.Context) {
+	suggestion, err := s.suggestionService.SuggestPoint(c.Request.Context(), models.Difficulty(c.Query("difficulty")))
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("suggestion", "suggest_point", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PointSuggestionResponse{
+		Difficulty: string(suggestion.Difficulty),
+		Point:      suggestion.Point,
+		Min:        suggestion.Min,
+		Max:        suggestion.Max,
+		Samples:    suggestion.Samples,
+		Basis:      suggestion.Basis,
+	})
+}
+
+// PointSuggestionResponse ポイントの提案のレスポンス
+type PointSuggestionResponse struct {
+	Difficulty string `json:"difficulty"`
+	Point      int    `json:"point"`
+	Min        int    `json:"min"`
+	Max        int    `json:"max"`
+	// Samples 提案に使った達成目録の件数（既定値を提案した場合は0）
+	Samples int `json:"samples"`
+	// Basis 提案の根拠（difficulty: 同じ難易度の達成目録、history: すべての達成目録、default: 既定値）
+	Basis string `json:"basis"`
+}
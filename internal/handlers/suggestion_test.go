@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockSuggestionService モックのポイント提案サービス
+type MockSuggestionService struct {
+	mock.Mock
+}
+
+func (m *MockSuggestionService) SuggestPoint(ctx context.Context, difficulty models.Difficulty) (*models.PointSuggestion, error) {
+	args := m.Called(difficulty)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointSuggestion), args.Error(1)
+}
+
+func TestSuggestPoint(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	suggestionService := &MockSuggestionService{}
+	server.EnableSuggestions(suggestionService)
+
+	suggestionService.On("SuggestPoint", models.DifficultyHard).Return(&models.PointSuggestion{
+		Difficulty: models.DifficultyHard, Point: 125, Min: 88, Max: 163, Samples: 4, Basis: "difficulty",
+	}, nil)
+	suggestionService.On("SuggestPoint", models.Difficulty("")).Return(nil, &errors.ValidationError{Field: "difficulty", Message: "difficulty must be easy, medium or hard"})
+
+	// 達成目録の取得（/api/achievements/:id）ではなく提案を返す
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/achievements/suggest-point?difficulty=hard", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response PointSuggestionResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, PointSuggestionResponse{Difficulty: "hard", Point: 125, Min: 88, Max: 163, Samples: 4, Basis: "difficulty"}, response)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/achievements/suggest-point", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	suggestionService.AssertExpectations(t)
+}
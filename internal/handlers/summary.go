@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableSummaries 日次・週次のサマリーのエンドポイントを登録
+func (s *Server) EnableSummaries(summaries services.SummaryService) {
+	s.summaryService = summaries
+
+	s.api.GET("/summaries", s.getSummary)
+}
+
+// getSummary GET /api/summaries?period=weekly&date=2024-06-09 - サマリー取得（既定は昨日の daily。date は期間の最後の日）
+func (s *Server) getSummary(c *gin.Context) {
+	period := models.SummaryPeriod(c.DefaultQuery("period", string(models.SummaryDaily)))
+
+	summary, err := s.summaryService.Generate(c.Request.Context(), period, c.Query("date"))
+	if err != nil {
+		s.requestErrorLogger(c).LogServiceError("summary", "generate", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SummaryResponse{
+		Period:       string(summary.Period),
+		From:         summary.From,
+		To:           summary.To,
+		PointsEarned: summary.PointsEarned,
+		Achievements: summary.Achievements,
+		Completions:  summary.Completions,
+		PointsSpent:  summary.PointsSpent,
+		Redemptions:  summary.Redemptions,
+		Balance:      summary.Balance,
+		Text:         summary.Text,
+		GeneratedAt:  summary.GeneratedAt,
+	})
+}
+
+// SummaryResponse サマリーのレスポンス
+type SummaryResponse struct {
+	Period       string    `json:"period"`
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	PointsEarned int       `json:"points_earned"`
+	Achievements int       `json:"achievements"`
+	Completions  int       `json:"completions"`
+	PointsSpent  int       `json:"points_spent"`
+	Redemptions  int       `json:"redemptions"`
+	Balance      int       `json:"balance"`
+	Text         string    `json:"text"`
+	GeneratedAt  time.Time `json:"generated_at"`
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockSummaryService モックのサマリーサービス
+type MockSummaryService struct {
+	mock.Mock
+}
+
+func (m *MockSummaryService) Generate(ctx context.Context, period models.SummaryPeriod, to string) (*models.Summary, error) {
+	args := m.Called(period, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Summary), args.Error(1)
+}
+
+func (m *MockSummaryService) Notify(ctx context.Context, summary *models.Summary) error {
+	args := m.Called(summary)
+	return args.Error(0)
+}
+
+func (m *MockSummaryService) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	args := m.Called(at)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetSummary(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	summaryService := &MockSummaryService{}
+	server.EnableSummaries(summaryService)
+
+	summaryService.On("Generate", models.SummaryDaily, "").Return(&models.Summary{
+		Period: models.SummaryDaily, From: "2024-06-09", To: "2024-06-09",
+		PointsEarned: 60, Achievements: 2, Completions: 3, Balance: 120,
+		Text: "Yesterday you earned 60 points from 2 achievements; your balance is 120 points.",
+	}, nil)
+	summaryService.On("Generate", models.SummaryWeekly, "2024-06-09").Return(&models.Summary{
+		Period: models.SummaryWeekly, From: "2024-06-03", To: "2024-06-09",
+	}, nil)
+	summaryService.On("Generate", models.SummaryPeriod("monthly"), "").Return(nil,
+		&errors.ValidationError{Field: "period", Message: "period must be daily or weekly"})
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedFrom   string
+	}{
+		{"/api/summaries", http.StatusOK, "2024-06-09"},
+		{"/api/summaries?period=weekly&date=2024-06-09", http.StatusOK, "2024-06-03"},
+		{"/api/summaries?period=monthly", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		require.Equal(t, tt.expectedStatus, rr.Code, tt.path)
+		if rr.Code == http.StatusOK {
+			var response SummaryResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedFrom, response.From, tt.path)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// EnableWishlist 報酬のお気に入り・ほしいものリストのエンドポイントを登録
+func (s *Server) EnableWishlist(wishlist services.WishlistService) {
+	s.wishlistService = wishlist
+
+	s.api.PUT("/rewards/:id/favorite", s.addFavorite)
+	s.api.DELETE("/rewards/:id/favorite", s.removeFavorite)
+	s.api.GET("/favorites", s.listFavorites)
+
+	group := s.api.Group("/wishlist")
+	{
+		group.POST("", s.addWishlistItem)
+		group.GET("", s.listWishlist)
+		group.PUT("/:reward_id", s.updateWishlistItem)
+		group.DELETE("/:reward_id", s.removeWishlistItem)
+	}
+}
+
+// addFavorite PUT /api/rewards/{id}/favorite - 報酬をお気に入りに登録（登録済みの場合も成功）
+func (s *Server) addFavorite(c *gin.Context) {
+	rewardID := c.Param("id")
+	if err := s.wishlistService.AddFavorite(c.Request.Context(), rewardID); err != nil {
+		s.requestErrorLogger(c).LogServiceError("wishlist", "add_favorite", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Reward added to favorites",
+		"reward_id": rewardID,
+	})
+}
+
+// removeFavorite DELETE /api/rewards/{id}/favorite - 報酬をお気に入りから外す
+func (s *Server) removeFavorite(c *gin.Context) {
+	if err := s.wishlistService.RemoveFavorite(c.Request.Context(), c.Param("id")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reward removed from favorites",
+	})
+}
+
+// listFavorites GET /api/favorites - お気に入りの報酬一覧取得
+func (s *Server) listFavorites(c *gin.Context) {
+	rewards, err := s.wishlistService.ListFavorites(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]RewardResponse, len(rewards))
+	for i, reward := range rewards {
+		response[i] = newRewardResponse(reward)
+	}
+
+	c.JSON(http.StatusOK, ListRewardsResponse{
+		Rewards: response,
+		Count:   len(response),
+	})
+}
+
+// addWishlistItem POST /api/wishlist - 報酬をほしいものリストに追加
+func (s *Server) addWishlistItem(c *gin.Context) {
+	var req AddWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	item := &models.WishlistItem{RewardID: req.RewardID, Priority: req.Priority}
+	if err := s.wishlistService.Add(c.Request.Context(), item); err != nil {
+		s.requestErrorLogger(c).LogServiceError("wishlist", "add", err)
+		handleServiceError(c, err)
+		return
+	}
+
+	s.requestLogger(c).WithFields(map[string]interface{}{
+		"reward_id": item.RewardID,
+		"priority":  item.Priority,
+	}).Info("Reward added to wishlist")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "Reward added to wishlist",
+		"reward_id": item.RewardID,
+		"priority":  item.Priority,
+	})
+}
+
+// listWishlist GET /api/wishlist - ほしいものリストを優先度順に取得（獲得できるまでに必要なポイント付き）
+func (s *Server) listWishlist(c *gin.Context) {
+	entries, err := s.wishlistService.List(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	response := make([]WishlistItemResponse, len(entries))
+	for i, entry := range entries {
+		response[i] = WishlistItemResponse{
+			Reward:       newRewardResponse(entry.Reward),
+			Priority:     entry.Item.Priority,
+			PointsNeeded: entry.PointsNeeded,
+			Affordable:   entry.PointsNeeded == 0,
+			AddedAt:      entry.Item.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, ListWishlistResponse{
+		Items: response,
+		Count: len(response),
+	})
+}
+
+// updateWishlistItem PUT /api/wishlist/{reward_id} - ほしいものリストの報酬の優先度を変更
+func (s *Server) updateWishlistItem(c *gin.Context) {
+	var req UpdateWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "validation_error",
+			Message:   "Invalid request body: " + err.Error(),
+			Code:      400,
+			ErrorCode: errors.CodeValidation,
+		})
+		return
+	}
+
+	rewardID := c.Param("reward_id")
+	if err := s.wishlistService.UpdatePriority(c.Request.Context(), rewardID, *req.Priority); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Wishlist priority updated",
+		"reward_id": rewardID,
+		"priority":  *req.Priority,
+	})
+}
+
+// removeWishlistItem DELETE /api/wishlist/{reward_id} - 報酬をほしいものリストから外す
+func (s *Server) removeWishlistItem(c *gin.Context) {
+	if err := s.wishlistService.Remove(c.Request.Context(), c.Param("reward_id")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reward removed from wishlist",
+	})
+}
+
+// newRewardResponse 報酬をレスポンスに変換
+func newRewardResponse(reward *models.Reward) RewardResponse {
+	return RewardResponse{
+		ID:          reward.ID,
+		Title:       reward.Title,
+		Description: reward.Description,
+		Point:       reward.Point,
+		CreatedAt:   reward.CreatedAt,
+		Version:     reward.Version,
+		Prizes:      reward.Prizes,
+	}
+}
+
+// AddWishlistItemRequest ほしいものリスト追加リクエスト
+type AddWishlistItemRequest struct {
+	RewardID string `json:"reward_id" binding:"required"`
+	// Priority 小さいほど優先度が高い（省略時は0）
+	Priority int `json:"priority" binding:"min=0"`
+}
+
+// UpdateWishlistItemRequest ほしいものリストの優先度変更リクエスト
+type UpdateWishlistItemRequest struct {
+	Priority *int `json:"priority" binding:"required,min=0"`
+}
+
+// WishlistItemResponse ほしいものリストの報酬のレスポンス
+type WishlistItemResponse struct {
+	Reward       RewardResponse `json:"reward"`
+	Priority     int            `json:"priority"`
+	PointsNeeded int            `json:"points_needed"`
+	Affordable   bool           `json:"affordable"`
+	AddedAt      time.Time      `json:"added_at"`
+}
+
+// ListWishlistResponse ほしいものリストのレスポンス
+type ListWishlistResponse struct {
+	Items []WishlistItemResponse `json:"items"`
+	Count int                    `json:"count"`
+}
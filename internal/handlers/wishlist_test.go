@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// MockWishlistService モックのお気に入り・ほしいものリストサービス
+type MockWishlistService struct {
+	mock.Mock
+}
+
+func (m *MockWishlistService) AddFavorite(ctx context.Context, rewardID string) error {
+	args := m.Called(rewardID)
+	return args.Error(0)
+}
+
+func (m *MockWishlistService) RemoveFavorite(ctx context.Context, rewardID string) error {
+	args := m.Called(rewardID)
+	return args.Error(0)
+}
+
+func (m *MockWishlistService) ListFavorites(ctx context.Context) ([]*models.Reward, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reward), args.Error(1)
+}
+
+func (m *MockWishlistService) Add(ctx context.Context, item *models.WishlistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockWishlistService) UpdatePriority(ctx context.Context, rewardID string, priority int) error {
+	args := m.Called(rewardID, priority)
+	return args.Error(0)
+}
+
+func (m *MockWishlistService) Remove(ctx context.Context, rewardID string) error {
+	args := m.Called(rewardID)
+	return args.Error(0)
+}
+
+func (m *MockWishlistService) List(ctx context.Context) ([]*models.WishlistEntry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WishlistEntry), args.Error(1)
+}
+
+func TestFavorites(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	wishlistService := &MockWishlistService{}
+	server.EnableWishlist(wishlistService)
+
+	wishlistService.On("AddFavorite", "reward-1").Return(nil)
+	wishlistService.On("AddFavorite", "missing").Return(errors.ErrNotFound)
+	wishlistService.On("ListFavorites").Return([]*models.Reward{{ID: "reward-1", Title: "ケーキ", Point: 80}}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/rewards/reward-1/favorite", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/rewards/missing/favorite", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/favorites", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListRewardsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, 1, response.Count)
+	assert.Equal(t, "reward-1", response.Rewards[0].ID)
+}
+
+func TestAddWishlistItem(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	wishlistService := &MockWishlistService{}
+	server.EnableWishlist(wishlistService)
+
+	wishlistService.On("Add", mock.MatchedBy(func(item *models.WishlistItem) bool {
+		return item.RewardID == "reward-1" && item.Priority == 2
+	})).Return(nil)
+	wishlistService.On("Add", mock.MatchedBy(func(item *models.WishlistItem) bool {
+		return item.RewardID == "reward-2"
+	})).Return(errors.ErrDuplicateResource)
+
+	tests := []struct {
+		body           string
+		expectedStatus int
+	}{
+		{`{"reward_id": "reward-1", "priority": 2}`, http.StatusCreated},
+		{`{"reward_id": "reward-2"}`, http.StatusConflict},
+		{`{"priority": 1}`, http.StatusBadRequest},
+		{`{"reward_id": "reward-1", "priority": -1}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/wishlist", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.expectedStatus, rr.Code, tt.body)
+	}
+}
+
+func TestListWishlist(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	wishlistService := &MockWishlistService{}
+	server.EnableWishlist(wishlistService)
+
+	wishlistService.On("List").Return([]*models.WishlistEntry{
+		{Item: &models.WishlistItem{RewardID: "game", Priority: 0}, Reward: &models.Reward{ID: "game", Title: "ゲーム", Point: 500}, PointsNeeded: 380},
+		{Item: &models.WishlistItem{RewardID: "cake", Priority: 1}, Reward: &models.Reward{ID: "cake", Title: "ケーキ", Point: 80}, PointsNeeded: 0},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/wishlist", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ListWishlistResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, 2, response.Count)
+	assert.Equal(t, "game", response.Items[0].Reward.ID)
+	assert.Equal(t, 380, response.Items[0].PointsNeeded)
+	assert.False(t, response.Items[0].Affordable)
+	assert.True(t, response.Items[1].Affordable)
+}
+
+func TestUpdateWishlistItem(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+	wishlistService := &MockWishlistService{}
+	server.EnableWishlist(wishlistService)
+
+	wishlistService.On("UpdatePriority", "reward-1", 0).Return(nil)
+	wishlistService.On("UpdatePriority", "missing", 3).Return(errors.ErrNotFound)
+
+	tests := []struct {
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		// 優先度0への変更も受け付ける
+		{"/api/wishlist/reward-1", `{"priority": 0}`, http.StatusOK},
+		{"/api/wishlist/missing", `{"priority": 3}`, http.StatusNotFound},
+		{"/api/wishlist/reward-1", `{}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.expectedStatus, rr.Code, tt.body)
+	}
+	wishlistService.AssertExpectations(t)
+}
+
+func TestWishlist_NotEnabled(t *testing.T) {
+	server, _, _, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/wishlist", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,197 @@
+package i18n
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"achievement-management/internal/errors"
+)
+
+// Lang 表示言語
+type Lang string
+
+const (
+	// English 英語
+	English Lang = "en"
+	// Japanese 日本語
+	Japanese Lang = "ja"
+)
+
+// catalogs 言語ごとのメッセージカタログ
+var catalogs = map[Lang]map[string]string{
+	English:  messagesEN,
+	Japanese: messagesJA,
+}
+
+// ParseLang 文字列から言語を判定
+func ParseLang(value string) (Lang, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "en", "english":
+		return English, nil
+	case "ja", "japanese":
+		return Japanese, nil
+	default:
+		return "", fmt.Errorf("unsupported language: %s (must be one of: en, ja)", value)
+	}
+}
+
+// DetectLang 環境変数（LC_ALL, LC_MESSAGES, LANG）から言語を判定
+func DetectLang() Lang {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(value), "ja") {
+			return Japanese
+		}
+		return English
+	}
+	return English
+}
+
+// Localizer メッセージのローカライズを行う
+type Localizer struct {
+	lang Lang
+}
+
+// NewLocalizer 指定した言語のLocalizerを作成
+func NewLocalizer(lang Lang) *Localizer {
+	if _, ok := catalogs[lang]; !ok {
+		lang = English
+	}
+	return &Localizer{lang: lang}
+}
+
+// Lang 現在の言語を取得
+func (l *Localizer) Lang() Lang {
+	return l.lang
+}
+
+// T メッセージキーをローカライズ（見つからない場合は英語、次にキー自体を返す）
+func (l *Localizer) T(key string, args ...interface{}) string {
+	format, ok := l.lookup(key)
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// NewError ローカライズされたメッセージでエラーを作成
+func (l *Localizer) NewError(key string, args ...interface{}) error {
+	return stderrors.New(l.T(key, args...))
+}
+
+// Wrap ローカライズされたメッセージでエラーをラップ
+func (l *Localizer) Wrap(err error, key string, args ...interface{}) error {
+	return &localizedError{
+		message: l.T(key, args...),
+		cause:   err,
+		loc:     l,
+	}
+}
+
+// ErrorMessage エラーをローカライズされたメッセージに変換
+func (l *Localizer) ErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if le, ok := err.(*localizedError); ok {
+		return le.Error()
+	}
+
+	var validationErr *errors.ValidationError
+	if stderrors.As(err, &validationErr) {
+		return l.T("error.validation", l.field(validationErr.Field), l.validationMessage(validationErr.Message))
+	}
+
+	var businessErr *errors.BusinessLogicError
+	if stderrors.As(err, &businessErr) {
+		return l.T("error.business_logic", businessErr.Operation, l.validationMessage(businessErr.Reason))
+	}
+
+	switch {
+	case stderrors.Is(err, errors.ErrNotFound):
+		return l.T("error.not_found")
+	case stderrors.Is(err, errors.ErrInsufficientPoints):
+		return l.T("error.insufficient_points")
+	case stderrors.Is(err, errors.ErrDuplicateResource):
+		return l.T("error.duplicate_resource")
+	case stderrors.Is(err, errors.ErrReadOnly):
+		return l.T("error.read_only")
+	case stderrors.Is(err, errors.ErrForbidden):
+		return l.T("error.forbidden")
+	}
+
+	var throttledErr *errors.ThrottledError
+	if stderrors.As(err, &throttledErr) {
+		return l.T("error.throttled", int(math.Ceil(throttledErr.RetryAfter.Seconds())))
+	}
+
+	var databaseErr *errors.DatabaseError
+	if stderrors.As(err, &databaseErr) {
+		return l.T("error.database", databaseErr.Operation, databaseErr.Table, databaseErr.Cause)
+	}
+
+	var serviceErr *errors.ServiceError
+	if stderrors.As(err, &serviceErr) {
+		if serviceErr.Cause != nil {
+			return l.T("error.service_with_cause", serviceErr.Operation, serviceErr.Message, l.ErrorMessage(serviceErr.Cause))
+		}
+		return l.T("error.service", serviceErr.Operation, serviceErr.Message)
+	}
+
+	return err.Error()
+}
+
+// lookup 現在の言語、次に英語のカタログからメッセージを検索
+func (l *Localizer) lookup(key string) (string, bool) {
+	if message, ok := catalogs[l.lang][key]; ok {
+		return message, true
+	}
+	if message, ok := catalogs[English][key]; ok {
+		return message, true
+	}
+	return "", false
+}
+
+// field フィールド名をローカライズ
+func (l *Localizer) field(name string) string {
+	if message, ok := l.lookup("field." + name); ok {
+		return message
+	}
+	return name
+}
+
+// validationMessage 検証メッセージをローカライズ
+func (l *Localizer) validationMessage(message string) string {
+	if translated, ok := l.lookup("message." + message); ok {
+		return translated
+	}
+	return message
+}
+
+// localizedError ローカライズされたメッセージを持つエラー
+type localizedError struct {
+	message string
+	cause   error
+	loc     *Localizer
+}
+
+func (e *localizedError) Error() string {
+	if e.cause == nil {
+		return e.message
+	}
+	return fmt.Sprintf("%s: %s", e.message, e.loc.ErrorMessage(e.cause))
+}
+
+func (e *localizedError) Unwrap() error {
+	return e.cause
+}
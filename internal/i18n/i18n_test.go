@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+)
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Lang
+		wantErr  bool
+	}{
+		{input: "ja", expected: Japanese},
+		{input: "EN", expected: English},
+		{input: "japanese", expected: Japanese},
+		{input: "fr", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		lang, err := ParseLang(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tt.input, err)
+		}
+		if lang != tt.expected {
+			t.Errorf("Expected %s for %q, got %s", tt.expected, tt.input, lang)
+		}
+	}
+}
+
+func TestDetectLang(t *testing.T) {
+	os.Unsetenv("LC_ALL")
+	os.Unsetenv("LC_MESSAGES")
+	os.Setenv("LANG", "ja_JP.UTF-8")
+	defer os.Unsetenv("LANG")
+
+	if lang := DetectLang(); lang != Japanese {
+		t.Errorf("Expected ja, got %s", lang)
+	}
+
+	os.Setenv("LC_ALL", "en_US.UTF-8")
+	defer os.Unsetenv("LC_ALL")
+
+	if lang := DetectLang(); lang != English {
+		t.Errorf("Expected LC_ALL to take precedence, got %s", lang)
+	}
+}
+
+func TestLocalizer_T(t *testing.T) {
+	ja := NewLocalizer(Japanese)
+	en := NewLocalizer(English)
+
+	if got := en.T("achievement.found", 3); got != "Found 3 achievement(s):" {
+		t.Errorf("Unexpected English message: %s", got)
+	}
+	if got := ja.T("achievement.found", 3); got != "3件の達成目録が見つかりました:" {
+		t.Errorf("Unexpected Japanese message: %s", got)
+	}
+
+	// 未知のキーはキー自体を返す
+	if got := ja.T("unknown.key"); got != "unknown.key" {
+		t.Errorf("Expected key fallback, got %s", got)
+	}
+}
+
+func TestLocalizer_ErrorMessage(t *testing.T) {
+	ja := NewLocalizer(Japanese)
+	en := NewLocalizer(English)
+
+	validationErr := &errors.ValidationError{Field: "title", Message: "title is required"}
+
+	if got := en.ErrorMessage(validationErr); got != validationErr.Error() {
+		t.Errorf("Expected English message to match error text, got %s", got)
+	}
+	if got := ja.ErrorMessage(validationErr); got != "入力エラー（タイトル）: 必須です" {
+		t.Errorf("Unexpected Japanese validation message: %s", got)
+	}
+
+	wrapped := ja.Wrap(errors.ErrNotFound, "achievement.get_failed")
+	if got := ja.ErrorMessage(wrapped); got != "達成目録の取得に失敗しました: リソースが見つかりません" {
+		t.Errorf("Unexpected wrapped message: %s", got)
+	}
+
+	throttled := &errors.DatabaseError{Operation: "List", Table: "rewards", Cause: &errors.ThrottledError{Reason: "ThrottlingException", RetryAfter: 1500 * time.Millisecond}}
+	if got := ja.ErrorMessage(throttled); got != "ストレージのスループットの上限を超えました。2秒後に再度実行してください" {
+		t.Errorf("Unexpected throttled message: %s", got)
+	}
+
+	forbidden := fmt.Errorf("refund window of 24h0m0s has passed: %w", errors.ErrForbidden)
+	if got := ja.ErrorMessage(forbidden); got != "管理者のみ実行できます" {
+		t.Errorf("Unexpected forbidden message: %s", got)
+	}
+
+	// 未知のエラーはそのまま返す
+	plain := fmt.Errorf("something happened")
+	if got := ja.ErrorMessage(plain); got != "something happened" {
+		t.Errorf("Expected plain error text, got %s", got)
+	}
+}
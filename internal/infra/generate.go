@@ -0,0 +1,141 @@
+package infra
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"achievement-management/internal/repository"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Format インフラテンプレートの出力形式
+type Format string
+
+const (
+	// FormatCloudFormation CloudFormationテンプレート（JSON）
+	FormatCloudFormation Format = "cloudformation"
+	// FormatTerraform Terraform設定（HCL）
+	FormatTerraform Format = "terraform"
+)
+
+// ParseFormat 文字列から出力形式を判定
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "cloudformation", "cfn":
+		return FormatCloudFormation, nil
+	case "terraform", "tf":
+		return FormatTerraform, nil
+	default:
+		return "", fmt.Errorf("unsupported infra format: %s (must be one of: cloudformation, terraform)", value)
+	}
+}
+
+// Generate テーブル定義から指定した形式のテンプレートを出力
+func Generate(w io.Writer, definitions []repository.TableDefinition, format Format) error {
+	switch format {
+	case FormatCloudFormation:
+		return generateCloudFormation(w, definitions)
+	case FormatTerraform:
+		return generateTerraform(w, definitions)
+	default:
+		return fmt.Errorf("unsupported infra format: %s", format)
+	}
+}
+
+// generateCloudFormation CloudFormationテンプレートを出力
+func generateCloudFormation(w io.Writer, definitions []repository.TableDefinition) error {
+	resources := map[string]interface{}{}
+	for _, def := range definitions {
+		resources[resourceName(def.Key)] = map[string]interface{}{
+			"Type":       "AWS::DynamoDB::Table",
+			"Properties": cloudFormationProperties(def),
+		}
+	}
+
+	tmpl := map[string]interface{}{
+		"AWSTemplateFormatVersion": "2010-09-09",
+		"Description":              "DynamoDB tables for achievement-management",
+		"Resources":                resources,
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(tmpl); err != nil {
+		return fmt.Errorf("failed to encode cloudformation template: %w", err)
+	}
+	return nil
+}
+
+// cloudFormationProperties テーブル定義をAWS::DynamoDB::Tableのプロパティに変換
+func cloudFormationProperties(def repository.TableDefinition) map[string]interface{} {
+	var attributes []map[string]string
+	for _, name := range def.KeyAttributes() {
+		attributes = append(attributes, map[string]string{"AttributeName": name, "AttributeType": "S"})
+	}
+
+	properties := map[string]interface{}{
+		"TableName":            def.Name,
+		"BillingMode":          "PAY_PER_REQUEST",
+		"AttributeDefinitions": attributes,
+		"KeySchema": []map[string]string{
+			{"AttributeName": def.HashKey, "KeyType": "HASH"},
+		},
+	}
+
+	if len(def.Indexes) > 0 {
+		var indexes []map[string]interface{}
+		for _, index := range def.Indexes {
+			keySchema := []map[string]string{{"AttributeName": index.HashKey, "KeyType": "HASH"}}
+			if index.RangeKey != "" {
+				keySchema = append(keySchema, map[string]string{"AttributeName": index.RangeKey, "KeyType": "RANGE"})
+			}
+			indexes = append(indexes, map[string]interface{}{
+				"IndexName":  index.Name,
+				"KeySchema":  keySchema,
+				"Projection": map[string]string{"ProjectionType": "ALL"},
+			})
+		}
+		properties["GlobalSecondaryIndexes"] = indexes
+	}
+
+	if def.TTLAttribute != "" {
+		properties["TimeToLiveSpecification"] = map[string]interface{}{
+			"AttributeName": def.TTLAttribute,
+			"Enabled":       true,
+		}
+	}
+
+	if def.StreamEnabled {
+		properties["StreamSpecification"] = map[string]string{"StreamViewType": "NEW_AND_OLD_IMAGES"}
+	}
+
+	return properties
+}
+
+// generateTerraform Terraform設定を出力
+func generateTerraform(w io.Writer, definitions []repository.TableDefinition) error {
+	tmpl, err := template.New("dynamodb.tf.tmpl").ParseFS(templateFS, "templates/dynamodb.tf.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to parse terraform template: %w", err)
+	}
+	return tmpl.Execute(w, definitions)
+}
+
+// resourceName テーブルの識別子からCloudFormationの論理IDを作成（reward_history → RewardHistoryTable）
+func resourceName(key string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(key, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	b.WriteString("Table")
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package infra
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"achievement-management/internal/repository"
+)
+
+func testDefinitions() []repository.TableDefinition {
+	return []repository.TableDefinition{
+		{Key: "achievements", Name: "test-achievements", HashKey: "id"},
+		{
+			Key:     "reward_history",
+			Name:    "test-reward-history",
+			HashKey: "id",
+			Indexes: []repository.IndexDefinition{
+				{Name: "reward-index", HashKey: "reward_id", RangeKey: "redeemed_at"},
+			},
+			TTLAttribute:  "expires_at",
+			StreamEnabled: true,
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("CFN"); err != nil || f != FormatCloudFormation {
+		t.Errorf("Expected cloudformation, got %s (%v)", f, err)
+	}
+	if f, err := ParseFormat("terraform"); err != nil || f != FormatTerraform {
+		t.Errorf("Expected terraform, got %s (%v)", f, err)
+	}
+	if _, err := ParseFormat("pulumi"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestGenerate_CloudFormation(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Generate(&buf, testDefinitions(), FormatCloudFormation); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var tmpl struct {
+		Resources map[string]struct {
+			Type       string
+			Properties map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &tmpl); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	achievements, ok := tmpl.Resources["AchievementsTable"]
+	if !ok || achievements.Type != "AWS::DynamoDB::Table" {
+		t.Fatalf("Expected AchievementsTable resource, got %v", tmpl.Resources)
+	}
+	if achievements.Properties["TableName"] != "test-achievements" {
+		t.Errorf("Unexpected table name: %v", achievements.Properties["TableName"])
+	}
+	if _, ok := achievements.Properties["GlobalSecondaryIndexes"]; ok {
+		t.Error("Expected no indexes on achievements table")
+	}
+
+	history := tmpl.Resources["RewardHistoryTable"].Properties
+	if indexes, ok := history["GlobalSecondaryIndexes"].([]interface{}); !ok || len(indexes) != 1 {
+		t.Errorf("Expected 1 index on reward history table, got %v", history["GlobalSecondaryIndexes"])
+	}
+	if attributes, ok := history["AttributeDefinitions"].([]interface{}); !ok || len(attributes) != 3 {
+		t.Errorf("Expected 3 attribute definitions, got %v", history["AttributeDefinitions"])
+	}
+	if _, ok := history["TimeToLiveSpecification"]; !ok {
+		t.Error("Expected TTL specification on reward history table")
+	}
+	if _, ok := history["StreamSpecification"]; !ok {
+		t.Error("Expected stream specification on reward history table")
+	}
+	if _, ok := achievements.Properties["StreamSpecification"]; ok {
+		t.Error("Expected no stream specification on achievements table")
+	}
+}
+
+func TestGenerate_Terraform(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Generate(&buf, testDefinitions(), FormatTerraform); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	output := buf.String()
+	expected := []string{
+		`resource "aws_dynamodb_table" "achievements" {`,
+		`name         = "test-reward-history"`,
+		`range_key       = "redeemed_at"`,
+		`attribute_name = "expires_at"`,
+		`stream_view_type = "NEW_AND_OLD_IMAGES"`,
+	}
+	for _, s := range expected {
+		if !strings.Contains(output, s) {
+			t.Errorf("Expected %q in output:\n%s", s, output)
+		}
+	}
+	if strings.Count(output, "stream_enabled") != 1 {
+		t.Errorf("Expected stream settings only on reward history table:\n%s", output)
+	}
+	if strings.Count(output, "global_secondary_index {") != 1 {
+		t.Errorf("Expected exactly one index block:\n%s", output)
+	}
+}
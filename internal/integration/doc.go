@@ -0,0 +1,5 @@
+// Package integration DynamoDB Local に対してリポジトリとサービスを実行する結合テスト
+//
+// テストは integration ビルドタグを指定した場合のみビルドされる（make test-integration）。
+// 接続先は DYNAMODB_ENDPOINT（未設定の場合は http://localhost:8000）。
+package integration
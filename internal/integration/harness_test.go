@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/oklog/ulid/v2"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/storage"
+)
+
+// defaultEndpoint docker-compose の dynamodb-local サービスのエンドポイント
+const defaultEndpoint = "http://localhost:8000"
+
+// newTestConfig テストごとに異なるテーブル名を使用する設定を作成
+func newTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	endpoint := os.Getenv("DYNAMODB_ENDPOINT")
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	// DynamoDB Local は認証情報を検証しないが、SDKは署名のために必要とする
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" && os.Getenv("AWS_PROFILE") == "" {
+		t.Setenv("AWS_ACCESS_KEY_ID", "local")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "local")
+	}
+
+	cfg := config.NewDefaultConfig("test")
+	cfg.Storage.Driver = config.StorageDriverDynamoDB
+	cfg.AWS.DynamoDBEndpoint = endpoint
+	cfg.AWS.ConsistentRead = true
+	cfg.Metrics.Enabled = false
+	cfg.Cache.Enabled = false
+
+	prefix := "it-" + strings.ToLower(ulid.Make().String()) + "-"
+	cfg.Tables.Achievements = prefix + "achievements"
+	cfg.Tables.Rewards = prefix + "rewards"
+	cfg.Tables.CurrentPoints = prefix + "current_points"
+	cfg.Tables.RewardHistory = prefix + "reward_history"
+	cfg.Tables.PointLedger = prefix + "point_ledger"
+	return cfg
+}
+
+// newTestRepositories テーブルを作成してリポジトリ一式を返す（テーブルはテスト終了時に削除する）
+func newTestRepositories(t *testing.T) *storage.Repositories {
+	t.Helper()
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+
+	client, err := repository.NewDynamoDBClient(ctx, cfg)
+	if err != nil {
+		t.Fatalf("NewDynamoDBClient failed: %v", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := client.ListTables(pingCtx, &dynamodb.ListTablesInput{Limit: aws.Int32(1)}); err != nil {
+		t.Fatalf("DynamoDB Local is not reachable at %s (start it with `docker compose up -d dynamodb-local`): %v", cfg.AWS.DynamoDBEndpoint, err)
+	}
+
+	definitions := repository.TableDefinitions(cfg)
+	t.Cleanup(func() {
+		for _, def := range definitions {
+			if _, err := client.DeleteTable(context.Background(), &dynamodb.DeleteTableInput{TableName: aws.String(def.Name)}); err != nil {
+				t.Logf("Failed to delete table %s: %v", def.Name, err)
+			}
+		}
+	})
+	if _, err := repository.NewTableManager(client).CreateTables(ctx, definitions); err != nil {
+		t.Fatalf("CreateTables failed: %v", err)
+	}
+
+	repos, err := storage.Open(ctx, cfg)
+	if err != nil {
+		t.Fatalf("storage.Open failed: %v", err)
+	}
+	t.Cleanup(func() { repos.Close() })
+	return repos
+}
@@ -0,0 +1,221 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestAchievementRepository_CreateWithPoints(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories(t)
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repos.Achievements.CreateWithPoints(ctx, achievement); err != nil {
+		t.Fatalf("CreateWithPoints failed: %v", err)
+	}
+
+	// 同じIDの場合はトランザクション全体が取り消される
+	err := repos.Achievements.CreateWithPoints(ctx, &models.Achievement{ID: achievement.ID, Title: "重複", Point: 50})
+	if err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	got, err := repos.Achievements.GetByID(ctx, achievement.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "初回ログイン" || got.Version != 1 {
+		t.Errorf("Unexpected achievement: %+v", got)
+	}
+	points, err := repos.Points.GetCurrentPointsConsistent(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPointsConsistent failed: %v", err)
+	}
+	if points.Point != 10 {
+		t.Errorf("Expected 10 points, got %d", points.Point)
+	}
+	entries, err := repos.Points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Reference != achievement.ID {
+		t.Errorf("Unexpected ledger entries: %+v", entries)
+	}
+}
+
+func TestAchievementRepository_VersionConflict(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories(t)
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repos.Achievements.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	first := *achievement
+	first.Title = "更新1"
+	if err := repos.Achievements.Update(ctx, &first); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// 古いバージョンのままの更新は条件式で拒否される
+	stale := *achievement
+	stale.Title = "更新2"
+	if err := repos.Achievements.Update(ctx, &stale); !stderrors.Is(err, errors.ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	if err := repos.Achievements.Update(ctx, &models.Achievement{ID: "missing", Title: "A", Point: 1}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for missing achievement, got %v", err)
+	}
+}
+
+func TestPointRepository_RedeemPoints(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories(t)
+
+	// 残高のアイテムが無い状態では獲得できない
+	err := repos.Points.RedeemPoints(ctx, &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 10})
+	if err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints before any points, got %v", err)
+	}
+
+	if err := repos.Points.AddPoints(ctx, 100); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	if err := repos.Points.RedeemPoints(ctx, &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 30}); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+	// 残高不足の場合は履歴・台帳も書き込まれない
+	err = repos.Points.RedeemPoints(ctx, &models.RewardHistory{RewardID: "r2", RewardTitle: "ケーキ", PointCost: 500})
+	if err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+
+	points, err := repos.Points.GetCurrentPointsConsistent(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPointsConsistent failed: %v", err)
+	}
+	if points.Point != 70 {
+		t.Errorf("Expected 70 points, got %d", points.Point)
+	}
+	history, err := repos.Points.GetRewardHistory(ctx)
+	if err != nil {
+		t.Fatalf("GetRewardHistory failed: %v", err)
+	}
+	if len(history) != 1 {
+		t.Errorf("Expected 1 history entry, got %d", len(history))
+	}
+	entries, err := repos.Points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 ledger entries, got %d", len(entries))
+	}
+}
+
+func TestPointRepository_RedeemPoints_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories(t)
+
+	if err := repos.Points.AddPoints(ctx, 100); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+
+	// 同時に獲得しても残高の条件式により1件だけ成功する
+	const attempts = 5
+	results := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- repos.Points.RedeemPoints(ctx, &models.RewardHistory{RewardID: "r1", RewardTitle: "ケーキ", PointCost: 60})
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("Expected exactly 1 redemption to succeed, got %d", succeeded)
+	}
+
+	points, err := repos.Points.GetCurrentPointsConsistent(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPointsConsistent failed: %v", err)
+	}
+	if points.Point != 40 {
+		t.Errorf("Expected 40 points, got %d", points.Point)
+	}
+}
+
+func TestPointRepository_GetRewardHistoryBetween(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories(t)
+
+	if err := repos.Points.AddPoints(ctx, 100); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i, title := range []string{"5月末", "6月初", "6月末"} {
+		redeemedAt := base.Add(time.Duration(i*15-1) * 24 * time.Hour)
+		history := &models.RewardHistory{RewardID: "r1", RewardTitle: title, PointCost: 10, RedeemedAt: redeemedAt}
+		if err := repos.Points.RedeemPoints(ctx, history); err != nil {
+			t.Fatalf("RedeemPoints failed: %v", err)
+		}
+	}
+
+	// GSIのソートキーで範囲を絞り込む
+	history, err := repos.Points.GetRewardHistoryBetween(ctx, base, base.AddDate(0, 0, 20))
+	if err != nil {
+		t.Fatalf("GetRewardHistoryBetween failed: %v", err)
+	}
+	if len(history) != 1 || history[0].RewardTitle != "6月初" {
+		t.Errorf("Unexpected history: %+v", history)
+	}
+}
+
+func TestRepositories_TenantsAreIsolated(t *testing.T) {
+	repos := newTestRepositories(t)
+	tenantA := tenant.WithID(context.Background(), "tenant-a")
+	tenantB := tenant.WithID(context.Background(), "tenant-b")
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repos.Achievements.CreateWithPoints(tenantA, achievement); err != nil {
+		t.Fatalf("CreateWithPoints failed: %v", err)
+	}
+
+	if _, err := repos.Achievements.GetByID(tenantB, achievement.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound from another tenant, got %v", err)
+	}
+	list, err := repos.Achievements.List(tenantB)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("Expected no achievements for another tenant, got %d", len(list))
+	}
+	points, err := repos.Points.GetCurrentPointsConsistent(tenantB)
+	if err != nil {
+		t.Fatalf("GetCurrentPointsConsistent failed: %v", err)
+	}
+	if points.Point != 0 {
+		t.Errorf("Expected 0 points for another tenant, got %d", points.Point)
+	}
+}
@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+func TestServices_AchievementToRewardFlow(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories(t)
+	achievementService := services.NewAchievementService(repos.Achievements, repos.Points)
+	rewardService := services.NewRewardService(repos.Rewards, repos.Points)
+	pointService := services.NewPointService(repos.Points, repos.Achievements)
+
+	for _, achievement := range []*models.Achievement{
+		{Title: "初回ログイン", Point: 30},
+		{Title: "連続ログイン", Point: 40},
+	} {
+		if err := achievementService.Create(ctx, achievement); err != nil {
+			t.Fatalf("Create achievement failed: %v", err)
+		}
+	}
+
+	reward := &models.Reward{Title: "コーヒー券", Point: 50}
+	if err := rewardService.Create(ctx, reward); err != nil {
+		t.Fatalf("Create reward failed: %v", err)
+	}
+	if _, err := rewardService.Redeem(ctx, reward.ID); err != nil {
+		t.Fatalf("Redeem failed: %v", err)
+	}
+
+	// 残り20ポイントでは2回目は獲得できない
+	_, err := rewardService.Redeem(ctx, reward.ID)
+	var businessErr *errors.BusinessLogicError
+	if !stderrors.As(err, &businessErr) && err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected insufficient points error, got %v", err)
+	}
+
+	points, err := pointService.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if points.Point != 20 {
+		t.Errorf("Expected 20 points, got %d", points.Point)
+	}
+
+	history, err := pointService.GetRewardHistory(ctx)
+	if err != nil {
+		t.Fatalf("GetRewardHistory failed: %v", err)
+	}
+	if len(history) != 1 || history[0].RewardID != reward.ID {
+		t.Errorf("Unexpected history: %+v", history)
+	}
+
+	// 台帳の合計は残高と一致する
+	entries, err := pointService.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	sum := 0
+	for _, entry := range entries {
+		sum += entry.Amount
+	}
+	if sum != points.Point {
+		t.Errorf("Expected ledger sum %d to match balance %d", sum, points.Point)
+	}
+}
@@ -0,0 +1,25 @@
+package lambda
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+)
+
+// runtimeAPIEnv Lambdaが Runtime API のホスト:ポートを設定する環境変数
+const runtimeAPIEnv = "AWS_LAMBDA_RUNTIME_API"
+
+// Detected Lambdaの実行環境（カスタムランタイム）で起動したかどうか
+func Detected() bool {
+	return os.Getenv(runtimeAPIEnv) != ""
+}
+
+// Start Lambdaの実行環境で、API Gateway・ALBのイベントを handler で処理する（ctx は呼び出しごとのコンテキストの親にする）
+//
+// イベントの受け取りと結果の返却は aws-lambda-go が行い、戻らない。
+// handler のパニックは呼び出しの失敗として返し、Runtime API との通信に失敗した場合はプロセスを終了する（Lambdaは実行環境を作り直す）。
+func Start(ctx context.Context, handler http.Handler) {
+	awslambda.StartWithOptions(NewProxy(handler), awslambda.WithContext(ctx))
+}
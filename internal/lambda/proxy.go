@@ -0,0 +1,264 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+)
+
+// イベントの形式
+const (
+	eventAPIGatewayV1 = "apigateway_v1"
+	eventAPIGatewayV2 = "apigateway_v2"
+	eventALB          = "alb"
+)
+
+// Proxy API Gateway（REST API・HTTP API）とALBのイベントを http.Handler で処理するアダプター
+//
+// 応答はまとめて返すため、Lambdaの応答サイズの上限（6MB）を超える応答は返せない。
+type Proxy struct {
+	handler http.Handler
+}
+
+// Proxy は aws-lambda-go のハンドラーとして呼び出す
+var _ awslambda.Handler = (*Proxy)(nil)
+
+// NewProxy handler でイベントを処理する Proxy を作成
+func NewProxy(handler http.Handler) *Proxy {
+	return &Proxy{handler: handler}
+}
+
+// proxyRequest API Gateway（ペイロード形式 1.0・2.0）とALBのイベント
+type proxyRequest struct {
+	// Version HTTP API のペイロード形式 2.0 の場合は "2.0"
+	Version string `json:"version"`
+
+	// HTTPMethod・Path ペイロード形式 1.0 とALB
+	HTTPMethod                      string              `json:"httpMethod"`
+	Path                            string              `json:"path"`
+	QueryStringParameters           map[string]string   `json:"queryStringParameters"`
+	MultiValueQueryStringParameters map[string][]string `json:"multiValueQueryStringParameters"`
+	Headers                         map[string]string   `json:"headers"`
+	MultiValueHeaders               map[string][]string `json:"multiValueHeaders"`
+
+	// RawPath・RawQueryString・Cookies ペイロード形式 2.0
+	RawPath        string   `json:"rawPath"`
+	RawQueryString string   `json:"rawQueryString"`
+	Cookies        []string `json:"cookies"`
+
+	Body            string `json:"body"`
+	IsBase64Encoded bool   `json:"isBase64Encoded"`
+
+	RequestContext struct {
+		// ELB ALBのイベントの場合のみ設定される
+		ELB *struct {
+			TargetGroupArn string `json:"targetGroupArn"`
+		} `json:"elb"`
+		HTTP struct {
+			Method   string `json:"method"`
+			SourceIP string `json:"sourceIp"`
+		} `json:"http"`
+		Identity struct {
+			SourceIP string `json:"sourceIp"`
+		} `json:"identity"`
+	} `json:"requestContext"`
+}
+
+// kind イベントの形式
+func (r *proxyRequest) kind() (string, error) {
+	switch {
+	case r.Version == "2.0":
+		return eventAPIGatewayV2, nil
+	case r.RequestContext.ELB != nil:
+		return eventALB, nil
+	case r.HTTPMethod != "":
+		return eventAPIGatewayV1, nil
+	}
+	return "", fmt.Errorf("unsupported event: expected an api gateway or alb request")
+}
+
+// proxyResponse API Gateway とALBへの応答
+type proxyResponse struct {
+	StatusCode int `json:"statusCode"`
+	// StatusDescription ALBの場合のみ（"200 OK" の形式）
+	StatusDescription string              `json:"statusDescription,omitempty"`
+	Headers           map[string]string   `json:"headers,omitempty"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders,omitempty"`
+	// Cookies ペイロード形式 2.0 の場合の Set-Cookie
+	Cookies         []string `json:"cookies,omitempty"`
+	Body            string   `json:"body"`
+	IsBase64Encoded bool     `json:"isBase64Encoded"`
+}
+
+// Invoke イベントをHTTPリクエストにして処理し、応答のJSONを返す（awslambda.Handler の実装）
+func (p *Proxy) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	var event proxyRequest
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+	kind, err := event.kind()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newHTTPRequest(ctx, kind, &event)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	p.handler.ServeHTTP(recorder, req)
+
+	return json.Marshal(newProxyResponse(kind, &event, recorder))
+}
+
+// newHTTPRequest イベントからHTTPリクエストを作成
+func newHTTPRequest(ctx context.Context, kind string, event *proxyRequest) (*http.Request, error) {
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 request body: %w", err)
+		}
+		body = decoded
+	}
+
+	method := event.HTTPMethod
+	if kind == eventAPIGatewayV2 {
+		method = event.RequestContext.HTTP.Method
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "/", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// REST API はパスとクエリをデコード済みで渡し、HTTP API とALBはリクエストのまま渡す
+	switch kind {
+	case eventAPIGatewayV1:
+		req.URL = &url.URL{Path: event.Path, RawQuery: encodeQuery(event, url.QueryEscape)}
+	case eventAPIGatewayV2:
+		req.URL, err = url.Parse(event.RawPath)
+		if err == nil {
+			req.URL.RawQuery = event.RawQueryString
+		}
+	case eventALB:
+		req.URL, err = url.Parse(event.Path)
+		if err == nil {
+			req.URL.RawQuery = encodeQuery(event, func(s string) string { return s })
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid request path %q: %w", event.Path+event.RawPath, err)
+	}
+	req.RequestURI = req.URL.RequestURI()
+
+	if len(event.MultiValueHeaders) > 0 {
+		for name, values := range event.MultiValueHeaders {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+	} else {
+		for name, value := range event.Headers {
+			req.Header.Set(name, value)
+		}
+	}
+	if len(event.Cookies) > 0 {
+		req.Header.Set("Cookie", strings.Join(event.Cookies, "; "))
+	}
+	req.Host = req.Header.Get("Host")
+	if req.Header.Get("Content-Length") == "" {
+		req.ContentLength = int64(len(body))
+	}
+
+	// 接続元の制限・リクエスト数の制限で使用する接続元（ALBは X-Forwarded-For の末尾が接続元）
+	sourceIP := event.RequestContext.Identity.SourceIP
+	switch kind {
+	case eventAPIGatewayV2:
+		sourceIP = event.RequestContext.HTTP.SourceIP
+	case eventALB:
+		forwarded := strings.Split(req.Header.Get("X-Forwarded-For"), ",")
+		sourceIP = strings.TrimSpace(forwarded[len(forwarded)-1])
+	}
+	if sourceIP != "" {
+		req.RemoteAddr = net.JoinHostPort(sourceIP, "0")
+	}
+	return req, nil
+}
+
+// encodeQuery ペイロード形式 1.0 とALBのクエリ文字列（escape で値をエスケープする）
+func encodeQuery(event *proxyRequest, escape func(string) string) string {
+	params := event.MultiValueQueryStringParameters
+	if len(params) == 0 {
+		params = make(map[string][]string, len(event.QueryStringParameters))
+		for name, value := range event.QueryStringParameters {
+			params[name] = []string{value}
+		}
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var query []string
+	for _, name := range names {
+		for _, value := range params[name] {
+			query = append(query, escape(name)+"="+escape(value))
+		}
+	}
+	return strings.Join(query, "&")
+}
+
+// newProxyResponse 記録した応答をイベントの形式の応答にする
+func newProxyResponse(kind string, event *proxyRequest, recorder *httptest.ResponseRecorder) proxyResponse {
+	result := recorder.Result()
+	response := proxyResponse{StatusCode: result.StatusCode}
+
+	// テキストでない応答（gzipで圧縮したCSVなど）はBase64で返す
+	body := recorder.Body.Bytes()
+	if utf8.Valid(body) {
+		response.Body = string(body)
+	} else {
+		response.Body = base64.StdEncoding.EncodeToString(body)
+		response.IsBase64Encoded = true
+	}
+
+	switch kind {
+	case eventAPIGatewayV1:
+		response.MultiValueHeaders = result.Header
+	case eventAPIGatewayV2:
+		// 同じ名前のヘッダーはカンマでつなげ、Set-Cookie は cookies で返す
+		response.Cookies = result.Header.Values("Set-Cookie")
+		response.Headers = make(map[string]string, len(result.Header))
+		for name, values := range result.Header {
+			if name != "Set-Cookie" {
+				response.Headers[name] = strings.Join(values, ",")
+			}
+		}
+	case eventALB:
+		response.StatusDescription = fmt.Sprintf("%d %s", result.StatusCode, http.StatusText(result.StatusCode))
+		// ターゲットグループで複数値のヘッダーを有効にした場合は、リクエストと同じく multiValueHeaders で返す
+		if len(event.MultiValueHeaders) > 0 {
+			response.MultiValueHeaders = result.Header
+		} else {
+			// 複数値のヘッダーを有効にしていない場合、同じ名前のヘッダーは最後の値だけを返す
+			response.Headers = make(map[string]string, len(result.Header))
+			for name, values := range result.Header {
+				response.Headers[name] = values[len(values)-1]
+			}
+		}
+	}
+	return response
+}
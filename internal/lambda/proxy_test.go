@@ -0,0 +1,155 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoHandler 受け取ったリクエストの内容を応答するハンドラー
+func echoHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"query":  r.URL.RawQuery,
+			"host":   r.Host,
+			"remote": r.RemoteAddr,
+			"cookie": r.Header.Get("Cookie"),
+			"accept": r.Header.Get("Accept"),
+			"body":   string(body),
+		})
+	})
+}
+
+func handle(t *testing.T, handler http.Handler, event string) (proxyResponse, map[string]string) {
+	payload, err := NewProxy(handler).Invoke(context.Background(), []byte(event))
+	require.NoError(t, err)
+
+	var response proxyResponse
+	require.NoError(t, json.Unmarshal(payload, &response))
+	var echoed map[string]string
+	if response.StatusCode == http.StatusCreated {
+		require.NoError(t, json.Unmarshal([]byte(response.Body), &echoed))
+	}
+	return response, echoed
+}
+
+func TestProxy_APIGatewayV1(t *testing.T) {
+	response, echoed := handle(t, echoHandler(t), `{
+		"httpMethod": "POST",
+		"path": "/api/achievements",
+		"multiValueQueryStringParameters": {"tag": ["a b", "c"], "limit": ["10"]},
+		"headers": {"Host": "api.example.com", "Accept": "application/json"},
+		"multiValueHeaders": {"Host": ["api.example.com"], "Accept": ["application/json"]},
+		"requestContext": {"identity": {"sourceIp": "203.0.113.1"}},
+		"body": "eyJ0aXRsZSI6InRlc3QifQ==",
+		"isBase64Encoded": true
+	}`)
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, []string{"a=1", "b=2"}, response.MultiValueHeaders["Set-Cookie"])
+	assert.Empty(t, response.Headers)
+	assert.False(t, response.IsBase64Encoded)
+	assert.Equal(t, map[string]string{
+		"method": "POST",
+		"path":   "/api/achievements",
+		"query":  "limit=10&tag=a+b&tag=c",
+		"host":   "api.example.com",
+		"remote": "203.0.113.1:0",
+		"cookie": "",
+		"accept": "application/json",
+		"body":   `{"title":"test"}`,
+	}, echoed)
+}
+
+func TestProxy_APIGatewayV2(t *testing.T) {
+	response, echoed := handle(t, echoHandler(t), `{
+		"version": "2.0",
+		"rawPath": "/api/rewards/a%2Fb",
+		"rawQueryString": "q=%E3%81%82",
+		"cookies": ["session=x", "theme=dark"],
+		"headers": {"host": "api.example.com", "accept": "application/json"},
+		"requestContext": {"http": {"method": "GET", "sourceIp": "203.0.113.2"}},
+		"body": ""
+	}`)
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, []string{"a=1", "b=2"}, response.Cookies)
+	assert.Equal(t, "application/json", response.Headers["Content-Type"])
+	assert.NotContains(t, response.Headers, "Set-Cookie")
+	assert.Equal(t, "GET", echoed["method"])
+	assert.Equal(t, "/api/rewards/a/b", echoed["path"])
+	assert.Equal(t, "q=%E3%81%82", echoed["query"])
+	assert.Equal(t, "session=x; theme=dark", echoed["cookie"])
+	assert.Equal(t, "203.0.113.2:0", echoed["remote"])
+}
+
+func TestProxy_ALB(t *testing.T) {
+	t.Run("single value headers", func(t *testing.T) {
+		response, echoed := handle(t, echoHandler(t), `{
+			"httpMethod": "GET",
+			"path": "/api/points",
+			"queryStringParameters": {"from": "2024-01-01%2000%3A00"},
+			"headers": {"host": "alb.example.com", "x-forwarded-for": "198.51.100.1, 203.0.113.3"},
+			"requestContext": {"elb": {"targetGroupArn": "arn:aws:elasticloadbalancing:ap-northeast-1:123456789012:targetgroup/api/abc"}},
+			"body": ""
+		}`)
+
+		assert.Equal(t, "201 Created", response.StatusDescription)
+		assert.Equal(t, "b=2", response.Headers["Set-Cookie"])
+		assert.Empty(t, response.MultiValueHeaders)
+		assert.Equal(t, "from=2024-01-01%2000%3A00", echoed["query"])
+		assert.Equal(t, "alb.example.com", echoed["host"])
+		assert.Equal(t, "203.0.113.3:0", echoed["remote"])
+	})
+
+	t.Run("multi value headers", func(t *testing.T) {
+		response, _ := handle(t, echoHandler(t), `{
+			"httpMethod": "GET",
+			"path": "/api/points",
+			"multiValueHeaders": {"host": ["alb.example.com"]},
+			"requestContext": {"elb": {"targetGroupArn": "arn"}},
+			"body": ""
+		}`)
+
+		assert.Equal(t, []string{"a=1", "b=2"}, response.MultiValueHeaders["Set-Cookie"])
+		assert.Empty(t, response.Headers)
+	})
+}
+
+func TestProxy_BinaryResponse(t *testing.T) {
+	binary := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Write(binary)
+	})
+
+	response, _ := handle(t, handler, `{"httpMethod": "GET", "path": "/api/export", "requestContext": {}}`)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.True(t, response.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(binary), response.Body)
+}
+
+func TestProxy_UnsupportedEvent(t *testing.T) {
+	proxy := NewProxy(echoHandler(t))
+
+	_, err := proxy.Invoke(context.Background(), []byte(`{"Records": []}`))
+	assert.ErrorContains(t, err, "unsupported event")
+
+	_, err = proxy.Invoke(context.Background(), []byte(`not json`))
+	assert.ErrorContains(t, err, "failed to parse event")
+}
@@ -0,0 +1,147 @@
+package logging
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"achievement-management/internal/config"
+)
+
+// 監査ログの変更の種類
+const (
+	// AuditActionCreate 作成
+	AuditActionCreate = "create"
+	// AuditActionUpdate 更新
+	AuditActionUpdate = "update"
+	// AuditActionDelete 削除
+	AuditActionDelete = "delete"
+)
+
+// 変更した利用者を特定できない場合の actor
+const (
+	// ActorAnonymous APIで actor のヘッダーが無いリクエスト
+	ActorAnonymous = "anonymous"
+	// ActorSystem スケジューラーなどリクエスト以外からの変更
+	ActorSystem = "system"
+)
+
+// actorKey 変更した利用者をコンテキストに保存するキー
+type actorKey struct{}
+
+// WithActor 変更した利用者をコンテキストに保存する（監査ログの actor になる）
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor コンテキストに保存した変更した利用者（無い場合は ActorSystem）
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return ActorSystem
+}
+
+// AuditRecord 監査ログの1件（記録した後は変更しない）
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+	// Actor 変更した利用者
+	Actor string `json:"actor"`
+	// Action 変更の種類（create・update・delete）
+	Action string `json:"action"`
+	// Entity 変更した対象の種類（achievement・reward など）
+	Entity string `json:"entity"`
+	// EntityID 変更した対象のID
+	EntityID string `json:"entity_id"`
+	// Operation 変更した操作（リポジトリのメソッド名）
+	Operation string `json:"operation"`
+	// BeforeHash 変更前の対象のハッシュ（作成の場合は空）
+	BeforeHash string `json:"before_hash,omitempty"`
+	// AfterHash 変更後の対象のハッシュ（削除の場合は空）
+	AfterHash string `json:"after_hash,omitempty"`
+	// RequestID 変更したリクエストのID
+	RequestID string `json:"request_id,omitempty"`
+	// TenantID 変更したテナント
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// AuditLogger 監査ログ用のLogger（運用のログとは別の出力先に、JSONで1行1件追記する）
+type AuditLogger struct {
+	mu  sync.Mutex
+	out io.Writer
+	now func() time.Time
+}
+
+// NewAuditLogger 監査ログ用のLoggerを作成
+//
+// ファイルに出力する場合は logging.rotation のサイズ・時間でローテーションするが、監査ログは削除しないため
+// ローテーションしたファイルの保持数・保持日数は適用しない。
+func NewAuditLogger(config *config.Config) (*AuditLogger, error) {
+	switch strings.ToLower(config.Logging.Audit.Output) {
+	case "stdout":
+		return NewAuditLoggerWithOutput(os.Stdout), nil
+	case "stderr":
+		return NewAuditLoggerWithOutput(os.Stderr), nil
+	}
+
+	rotation := config.Logging.Rotation
+	rotation.MaxBackups = 0
+	rotation.MaxAgeDays = 0
+	file, err := openLogFile(config.Logging.Audit.Output, rotation)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuditLoggerWithOutput(file), nil
+}
+
+// NewAuditLoggerWithOutput 出力先を指定して監査ログ用のLoggerを作成
+func NewAuditLoggerWithOutput(output io.Writer) *AuditLogger {
+	return &AuditLogger{out: output, now: time.Now}
+}
+
+// Log 変更を記録する（actor・request_id・tenant_id はコンテキストから設定する）
+func (a *AuditLogger) Log(ctx context.Context, record AuditRecord) error {
+	record.Time = a.now().UTC()
+	record.Actor = Actor(ctx)
+	fields := Fields(ctx)
+	if id, ok := fields["request_id"].(string); ok {
+		record.RequestID = id
+	}
+	if id, ok := fields["tenant_id"].(string); ok {
+		record.TenantID = id
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	// 1件を1回で書き込み、同時に記録しても行が混ざらないようにする
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.out.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+// AuditHash 対象のJSONのSHA-256（"sha256:" に続く16進数、対象が nil の場合は空）
+func AuditHash(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+)
+
+func TestAuditLogger_Log(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewAuditLoggerWithOutput(&buf)
+	logger.now = func() time.Time { return time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC) }
+
+	ctx := NewContext(WithActor(context.Background(), "user-42"), NewLoggerWithOutput(&config.Config{Logging: config.LoggingConfig{Level: "info", Format: "json"}}, io.Discard), map[string]interface{}{
+		"request_id": "req-1",
+		"tenant_id":  "family-a",
+	})
+	err := logger.Log(ctx, AuditRecord{
+		Action:     AuditActionUpdate,
+		Entity:     "achievement",
+		EntityID:   "a1",
+		Operation:  "Update",
+		BeforeHash: AuditHash(map[string]int{"point": 10}),
+		AfterHash:  AuditHash(map[string]int{"point": 20}),
+	})
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if err := logger.Log(context.Background(), AuditRecord{Action: AuditActionCreate, Entity: "reward", EntityID: "r1", Operation: "Create"}); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 records, got %d: %s", len(lines), buf.String())
+	}
+
+	var record AuditRecord
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("Failed to decode record: %v", err)
+	}
+	if record.Actor != "user-42" || record.RequestID != "req-1" || record.TenantID != "family-a" {
+		t.Errorf("Expected actor and correlation fields from the context, got %+v", record)
+	}
+	if !record.Time.Equal(time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)) || record.Action != AuditActionUpdate || record.EntityID != "a1" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if !strings.HasPrefix(record.BeforeHash, "sha256:") || record.BeforeHash == record.AfterHash {
+		t.Errorf("Expected distinct before and after hashes, got %s and %s", record.BeforeHash, record.AfterHash)
+	}
+
+	// リクエスト以外からの変更は system
+	var systemRecord AuditRecord
+	if err := json.Unmarshal([]byte(lines[1]), &systemRecord); err != nil {
+		t.Fatalf("Failed to decode record: %v", err)
+	}
+	if systemRecord.Actor != ActorSystem || systemRecord.BeforeHash != "" || systemRecord.RequestID != "" {
+		t.Errorf("Expected a system record without before hash, got %+v", systemRecord)
+	}
+}
+
+func TestAuditHash(t *testing.T) {
+	type item struct {
+		ID    string `json:"id"`
+		Point int    `json:"point"`
+	}
+	if AuditHash(&item{ID: "a1", Point: 10}) != AuditHash(&item{ID: "a1", Point: 10}) {
+		t.Error("Expected the same hash for the same state")
+	}
+	if AuditHash(&item{ID: "a1", Point: 10}) == AuditHash(&item{ID: "a1", Point: 20}) {
+		t.Error("Expected different hashes for different states")
+	}
+	var missing *item
+	if AuditHash(nil) != "" || AuditHash(missing) != "" {
+		t.Error("Expected an empty hash for nil")
+	}
+}
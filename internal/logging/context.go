@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"context"
+)
+
+// contextKey リクエストのロガーをコンテキストに保存するキー
+type contextKey struct{}
+
+// requestLog コンテキストに保存するロガーと、ロガーに設定した相関フィールド
+type requestLog struct {
+	logger Logger
+	fields map[string]interface{}
+}
+
+// NewContext fields を設定した logger をコンテキストに保存する
+//
+// コンテキストにすでにロガーがある場合は、そのロガーのフィールドも引き継ぐ。
+func NewContext(ctx context.Context, logger Logger, fields map[string]interface{}) context.Context {
+	merged := make(map[string]interface{}, len(fields))
+	if current, ok := ctx.Value(contextKey{}).(*requestLog); ok {
+		for k, v := range current.fields {
+			merged[k] = v
+		}
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, contextKey{}, &requestLog{logger: logger.WithFields(merged), fields: merged})
+}
+
+// AddFields コンテキストのロガーにフィールドを追加する（ロガーが無い場合は ctx をそのまま返す）
+func AddFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	current, ok := ctx.Value(contextKey{}).(*requestLog)
+	if !ok {
+		return ctx
+	}
+	return NewContext(ctx, current.logger, fields)
+}
+
+// FromContext コンテキストに保存したロガー（無い場合は何も出力しないロガー）
+//
+// サービスはこのロガーを使うことで、APIのリクエストやスケジューラーのジョブと相関の取れたログを出力できる。
+func FromContext(ctx context.Context) Logger {
+	if current, ok := ctx.Value(contextKey{}).(*requestLog); ok {
+		return current.logger
+	}
+	return nopLogger{}
+}
+
+// Fields コンテキストのロガーに設定した相関フィールド（request_id など）
+func Fields(ctx context.Context) map[string]interface{} {
+	if current, ok := ctx.Value(contextKey{}).(*requestLog); ok {
+		return current.fields
+	}
+	return nil
+}
+
+// nopLogger 何も出力しないLogger
+type nopLogger struct{}
+
+func (nopLogger) Debug(args ...interface{})                         {}
+func (nopLogger) Debugf(format string, args ...interface{})         {}
+func (nopLogger) Info(args ...interface{})                          {}
+func (nopLogger) Infof(format string, args ...interface{})          {}
+func (nopLogger) Warn(args ...interface{})                          {}
+func (nopLogger) Warnf(format string, args ...interface{})          {}
+func (nopLogger) Error(args ...interface{})                         {}
+func (nopLogger) Errorf(format string, args ...interface{})         {}
+func (l nopLogger) WithField(key string, value interface{}) Logger  { return l }
+func (l nopLogger) WithFields(fields map[string]interface{}) Logger { return l }
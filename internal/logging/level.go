@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LevelStatus 現在のログレベルと元に戻す予定
+type LevelStatus struct {
+	// Level 現在のログレベル
+	Level string `json:"level"`
+	// DefaultLevel 設定したログレベル（元に戻した後のレベル）
+	DefaultLevel string `json:"default_level"`
+	// RevertAt 設定したログレベルに戻す日時（戻す予定がない場合は nil）
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+// LevelController 再起動せずに変更できるログレベル（Attach したLoggerのレベルをまとめて変更する）
+//
+// 期間を指定して変更した場合は、期間が過ぎると設定したログレベルに戻す。
+type LevelController struct {
+	mu       sync.Mutex
+	base     logrus.Level
+	level    logrus.Level
+	revertAt time.Time
+	timer    *time.Timer
+	// changes 変更した回数（期間が過ぎる前に再び変更した場合は元に戻さないために使う）
+	changes int
+	loggers []*logrus.Logger
+}
+
+// NewLevelController 設定したログレベルから始める LevelController を作成
+func NewLevelController(level string) (*LevelController, error) {
+	base, err := logrus.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelController{base: base, level: base}, nil
+}
+
+// Attach Loggerのログレベルを現在のレベルにし、以後の変更を反映する（logrus以外のLoggerは対象外）
+func (c *LevelController) Attach(logger Logger) {
+	l, ok := logger.(*LogrusLogger)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l.logger.SetLevel(c.level)
+	c.loggers = append(c.loggers, l.logger)
+}
+
+// Set ログレベルを変更する（duration が正の場合は、その期間が過ぎると設定したログレベルに戻す）
+func (c *LevelController) Set(level string, duration time.Duration) (LevelStatus, error) {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return LevelStatus{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apply(parsed)
+	if duration > 0 {
+		c.revertAt = time.Now().Add(duration)
+		changes := c.changes
+		c.timer = time.AfterFunc(duration, func() { c.revert(changes) })
+	}
+	return c.status(), nil
+}
+
+// Reset 設定したログレベルに戻す
+func (c *LevelController) Reset() LevelStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apply(c.base)
+	return c.status()
+}
+
+// Status 現在のログレベルと元に戻す予定
+func (c *LevelController) Status() LevelStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status()
+}
+
+// revert 期間が過ぎたときに設定したログレベルに戻す（その後に変更した場合は戻さない）
+func (c *LevelController) revert(changes int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.changes != changes {
+		return
+	}
+	c.apply(c.base)
+}
+
+// apply Attach したLoggerのログレベルを変更し、元に戻す予定を取り消す
+func (c *LevelController) apply(level logrus.Level) {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	c.revertAt = time.Time{}
+	c.changes++
+	c.level = level
+	for _, logger := range c.loggers {
+		logger.SetLevel(level)
+	}
+}
+
+// status 現在のログレベルと元に戻す予定（mu を取得して呼ぶ）
+func (c *LevelController) status() LevelStatus {
+	status := LevelStatus{Level: c.level.String(), DefaultLevel: c.base.String()}
+	if !c.revertAt.IsZero() {
+		revertAt := c.revertAt
+		status.RevertAt = &revertAt
+	}
+	return status
+}
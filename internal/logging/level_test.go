@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+)
+
+func TestLevelController(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &config.Config{Logging: config.LoggingConfig{Level: "info", Format: "json"}}
+	logger := NewLoggerWithOutput(cfg, &buf)
+
+	levels, err := NewLevelController("info")
+	if err != nil {
+		t.Fatalf("NewLevelController failed: %v", err)
+	}
+	levels.Attach(logger)
+
+	logger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected debug logs to be hidden at info, got %s", buf.String())
+	}
+
+	status, err := levels.Set("debug", 0)
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if status.Level != "debug" || status.DefaultLevel != "info" || status.RevertAt != nil {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+	logger.Debug("shown")
+	if buf.Len() == 0 {
+		t.Error("Expected debug logs after changing the level")
+	}
+
+	if status := levels.Reset(); status.Level != "info" {
+		t.Errorf("Expected reset to restore info, got %+v", status)
+	}
+	if _, err := levels.Set("verbose", 0); err == nil {
+		t.Error("Expected error for an unknown level")
+	}
+}
+
+func TestLevelController_Reverts(t *testing.T) {
+	levels, err := NewLevelController("warn")
+	if err != nil {
+		t.Fatalf("NewLevelController failed: %v", err)
+	}
+
+	status, err := levels.Set("debug", 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if status.RevertAt == nil {
+		t.Fatal("Expected a revert time")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for levels.Status().Level != "warning" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the level to revert, got %+v", levels.Status())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if levels.Status().RevertAt != nil {
+		t.Errorf("Expected no revert time after reverting, got %+v", levels.Status())
+	}
+
+	// 期間が過ぎる前に変更した場合は、前の変更の期間では戻さない
+	if _, err := levels.Set("debug", 20*time.Millisecond); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := levels.Set("info", 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if level := levels.Status().Level; level != "info" {
+		t.Errorf("Expected info to stay, got %s", level)
+	}
+}
@@ -1,9 +1,12 @@
 package logging
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -65,8 +68,8 @@ func NewLogger(config *config.Config) (Logger, error) {
 	case "stderr":
 		logger.SetOutput(os.Stderr)
 	default:
-		// ファイルパスとして扱う
-		file, err := os.OpenFile(config.Logging.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		// ファイルパスとして扱う（同じファイルに出力するLoggerはローテーションするファイルを共有する）
+		file, err := openLogFile(config.Logging.Output, config.Logging.Rotation)
 		if err != nil {
 			return nil, err
 		}
@@ -171,6 +174,44 @@ func (l *LogrusLogger) WithFields(fields map[string]interface{}) Logger {
 // AccessLogger アクセスログ用のLogger
 type AccessLogger struct {
 	logger Logger
+	// filter 記録しないリクエストの判定（nil の場合はすべて記録する）
+	filter *accessFilter
+}
+
+// accessFilter 設定したパスを除外し、ルートごとにN件に1件だけ記録する
+type accessFilter struct {
+	excluded map[string]bool
+	sample   map[string]int
+	mu       sync.Mutex
+	counts   map[string]uint64
+}
+
+// newAccessFilter アクセスログの設定から除外・間引きの判定を作成
+func newAccessFilter(cfg config.AccessLogConfig) *accessFilter {
+	excluded := make(map[string]bool, len(cfg.ExcludePaths))
+	for _, path := range cfg.ExcludePaths {
+		excluded[path] = true
+	}
+	return &accessFilter{excluded: excluded, sample: cfg.Sample, counts: make(map[string]uint64)}
+}
+
+// allow リクエストを記録するかと、間引いている場合は何件に1件記録しているか（間引いていない場合は1）
+func (f *accessFilter) allow(path, route string, statusCode int) (int, bool) {
+	if f == nil || statusCode >= 400 {
+		return 1, true
+	}
+	if f.excluded[path] {
+		return 0, false
+	}
+	rate := f.sample[route]
+	if rate <= 1 {
+		return 1, true
+	}
+	f.mu.Lock()
+	n := f.counts[route]
+	f.counts[route] = n + 1
+	f.mu.Unlock()
+	return rate, n%uint64(rate) == 0
 }
 
 // NewAccessLogger アクセスログ用のLoggerを作成
@@ -178,6 +219,13 @@ func NewAccessLogger(config *config.Config) (*AccessLogger, error) {
 	// アクセスログ用の設定を作成
 	accessConfig := *config
 	accessConfig.Logging.Level = "info"
+	// 出力先・形式は logging.access で指定した場合のみ変える
+	if config.Logging.Access.Format != "" {
+		accessConfig.Logging.Format = config.Logging.Access.Format
+	}
+	if config.Logging.Access.Output != "" {
+		accessConfig.Logging.Output = config.Logging.Access.Output
+	}
 	
 	logger, err := NewLogger(&accessConfig)
 	if err != nil {
@@ -186,9 +234,29 @@ func NewAccessLogger(config *config.Config) (*AccessLogger, error) {
 	
 	return &AccessLogger{
 		logger: logger,
+		filter: newAccessFilter(config.Logging.Access),
 	}, nil
 }
 
+// For ctx のロガーの相関フィールド（request_id など）を付けて記録するアクセスログ用のLogger
+func (a *AccessLogger) For(ctx context.Context) *AccessLogger {
+	return &AccessLogger{logger: a.logger.WithFields(Fields(ctx)), filter: a.filter}
+}
+
+// Sampled リクエストを記録する場合は記録に使うLoggerを返す（間引いている場合は sample_rate を付ける）
+//
+// 除外したパスと間引いたリクエストは記録しない。ステータスコードが400以上の場合は常に記録する。
+func (a *AccessLogger) Sampled(path, route string, statusCode int) (*AccessLogger, bool) {
+	rate, ok := a.filter.allow(path, route, statusCode)
+	if !ok {
+		return nil, false
+	}
+	if rate > 1 {
+		return &AccessLogger{logger: a.logger.WithField("sample_rate", rate), filter: a.filter}, true
+	}
+	return a, true
+}
+
 // LogRequest HTTPリクエストをログに記録
 func (a *AccessLogger) LogRequest(method, path, remoteAddr string, statusCode int, duration time.Duration) {
 	a.logger.WithFields(map[string]interface{}{
@@ -201,9 +269,21 @@ func (a *AccessLogger) LogRequest(method, path, remoteAddr string, statusCode in
 	}).Info("HTTP request")
 }
 
+// ErrorReporter エラーとパニックをエラー管理サービスに送るもの
+type ErrorReporter interface {
+	// Report エラーを送る（送るかどうかは ErrorReporter が決める）
+	Report(err error, fields map[string]interface{})
+	// ReportPanic 回復したパニックをスタックトレースと合わせて送る
+	ReportPanic(recovered interface{}, stack []byte, fields map[string]interface{})
+}
+
 // ErrorLogger エラーログ用のLogger
 type ErrorLogger struct {
 	logger Logger
+	// reporter 記録したエラーを送る先（nil の場合は送らない）
+	reporter ErrorReporter
+	// fields For で付けた相関フィールド（エラー管理サービスにも送る）
+	fields map[string]interface{}
 }
 
 // NewErrorLogger エラーログ用のLoggerを作成
@@ -211,6 +291,13 @@ func NewErrorLogger(config *config.Config) (*ErrorLogger, error) {
 	// エラーログ用の設定を作成
 	errorConfig := *config
 	errorConfig.Logging.Level = "error"
+	// 出力先・形式は logging.error で指定した場合のみ変える
+	if config.Logging.Error.Format != "" {
+		errorConfig.Logging.Format = config.Logging.Error.Format
+	}
+	if config.Logging.Error.Output != "" {
+		errorConfig.Logging.Output = config.Logging.Error.Output
+	}
 	
 	logger, err := NewLogger(&errorConfig)
 	if err != nil {
@@ -222,8 +309,53 @@ func NewErrorLogger(config *config.Config) (*ErrorLogger, error) {
 	}, nil
 }
 
-// LogError エラーをログに記録
+// For ctx のロガーの相関フィールド（request_id など）を付けて記録するエラーログ用のLogger
+func (e *ErrorLogger) For(ctx context.Context) *ErrorLogger {
+	fields := Fields(ctx)
+	return &ErrorLogger{logger: e.logger.WithFields(fields), reporter: e.reporter, fields: fields}
+}
+
+// SetReporter 記録したエラーと回復したパニックを reporter に送る
+//
+// For で作成したLoggerには、SetReporter を呼んだ後に作成したものにだけ反映される。
+func (e *ErrorLogger) SetReporter(reporter ErrorReporter) {
+	e.reporter = reporter
+}
+
+// reportFields 相関フィールドに fields を重ねたエラー管理サービスに送るフィールド
+func (e *ErrorLogger) reportFields(fields map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(e.fields)+len(fields))
+	for k, v := range e.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
+}
+
+// LogPanic 回復したパニックをスタックトレースと合わせて記録し、エラー管理サービスに送る
+func (e *ErrorLogger) LogPanic(recovered interface{}, stack []byte, fields map[string]interface{}) {
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("%v", recovered)
+	}
+	e.log("panic_recovery", "middleware", err, fields)
+	if e.reporter != nil {
+		e.reporter.ReportPanic(recovered, stack, e.reportFields(fields))
+	}
+}
+
+// LogError エラーをログに記録し、エラー管理サービスに送る
 func (e *ErrorLogger) LogError(operation, component string, err error, fields map[string]interface{}) {
+	logFields := e.log(operation, component, err, fields)
+	if e.reporter != nil {
+		e.reporter.Report(err, e.reportFields(logFields))
+	}
+}
+
+// log エラーをログに記録し、記録したフィールドを返す
+func (e *ErrorLogger) log(operation, component string, err error, fields map[string]interface{}) map[string]interface{} {
 	logFields := map[string]interface{}{
 		"operation": operation,
 		"component": component,
@@ -237,6 +369,7 @@ func (e *ErrorLogger) LogError(operation, component string, err error, fields ma
 	}
 	
 	e.logger.WithFields(logFields).Error("Operation failed")
+	return logFields
 }
 
 // LogDatabaseError データベースエラーをログに記録
@@ -3,6 +3,8 @@ package logging
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -240,4 +242,45 @@ type testError struct {
 
 func (e *testError) Error() string {
 	return e.message
-}
\ No newline at end of file
+}
+
+func TestAccessAndErrorLoggers_SeparateOutputs(t *testing.T) {
+	dir := t.TempDir()
+	accessPath := filepath.Join(dir, "access.log")
+	errorPath := filepath.Join(dir, "error.log")
+	cfg := &config.Config{Logging: config.LoggingConfig{
+		Level:  "info",
+		Format: "json",
+		Output: "stdout",
+		Access: config.AccessLogConfig{Format: "json", Output: accessPath},
+		Error:  config.ErrorLogConfig{Format: "text", Output: errorPath},
+	}}
+
+	accessLogger, err := NewAccessLogger(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	errorLogger, err := NewErrorLogger(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	accessLogger.LogRequest("GET", "/api/achievements", "127.0.0.1", 200, time.Millisecond)
+	errorLogger.LogError("create", "service", os.ErrInvalid, nil)
+
+	accessOutput, err := os.ReadFile(accessPath)
+	if err != nil {
+		t.Fatalf("Failed to read access log: %v", err)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(accessOutput, &entry); err != nil || entry["path"] != "/api/achievements" {
+		t.Errorf("Expected a JSON access log entry, got %q", accessOutput)
+	}
+
+	errorOutput, err := os.ReadFile(errorPath)
+	if err != nil {
+		t.Fatalf("Failed to read error log: %v", err)
+	}
+	if !strings.Contains(string(errorOutput), `msg="Operation failed"`) || strings.Contains(string(errorOutput), "/api/achievements") {
+		t.Errorf("Expected only the error as text in the error log, got %q", errorOutput)
+	}
+}
@@ -1,11 +1,61 @@
 package logging
 
 import (
+	"runtime/debug"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oklog/ulid/v2"
 )
 
+// RequestIDHeader リクエストIDを受け取り、レスポンスで返すヘッダー
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength 受け取ったリクエストIDをそのまま使う最大の長さ
+const maxRequestIDLength = 128
+
+// RequestLoggerMiddleware request_id・method・route を設定したロガーをリクエストのコンテキストに保存するミドルウェア
+//
+// リクエストIDは X-Request-ID ヘッダーの値（無い場合や不正な場合は新しく生成した値）を使い、レスポンスのヘッダーでも返す。
+// ハンドラーとサービスは FromContext でこのロガーを取得する。
+func RequestLoggerMiddleware(logger Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = ulid.Make().String()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		fields := map[string]interface{}{
+			"request_id": requestID,
+			"method":     c.Request.Method,
+		}
+		// ルートが無いリクエスト（404）は route を設定しない
+		if route := c.FullPath(); route != "" {
+			fields["route"] = route
+		}
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), logger, fields))
+
+		c.Next()
+	}
+}
+
+// validRequestID ログにそのまま出力できるリクエストIDか（英数字と - _ . : のみ）
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // LoggingMiddleware HTTPリクエストのログを記録するミドルウェア
 func LoggingMiddleware(accessLogger *AccessLogger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -14,9 +64,13 @@ func LoggingMiddleware(accessLogger *AccessLogger) gin.HandlerFunc {
 		// リクエストを処理
 		c.Next()
 		
-		// ログを記録
+		// ログを記録（除外したパスと間引いたリクエストは記録しない）
 		duration := time.Since(start)
-		accessLogger.LogRequest(
+		logger, ok := accessLogger.Sampled(c.Request.URL.Path, c.FullPath(), c.Writer.Status())
+		if !ok {
+			return
+		}
+		logger.For(c.Request.Context()).LogRequest(
 			c.Request.Method,
 			c.Request.URL.Path,
 			c.ClientIP(),
@@ -34,7 +88,7 @@ func ErrorLoggingMiddleware(errorLogger *ErrorLogger) gin.HandlerFunc {
 		// エラーがある場合はログに記録
 		if len(c.Errors) > 0 {
 			for _, err := range c.Errors {
-				errorLogger.LogAPIError(
+				errorLogger.For(c.Request.Context()).LogAPIError(
 					c.Request.URL.Path,
 					c.Request.Method,
 					c.Writer.Status(),
@@ -45,15 +99,19 @@ func ErrorLoggingMiddleware(errorLogger *ErrorLogger) gin.HandlerFunc {
 	}
 }
 
-// RecoveryMiddleware パニックからの回復とログ記録
-func RecoveryMiddleware(errorLogger *ErrorLogger) gin.HandlerFunc {
+// RecoveryMiddleware パニックからの回復とログ記録（エラー管理サービスを設定している場合はスタックトレースと合わせて送る）
+//
+// 記録した後は respond で応答する。respond が nil の場合は本文なしの500を返す。
+func RecoveryMiddleware(errorLogger *ErrorLogger, respond gin.HandlerFunc) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		if err, ok := recovered.(error); ok {
-			errorLogger.LogError("panic_recovery", "middleware", err, map[string]interface{}{
-				"path":   c.Request.URL.Path,
-				"method": c.Request.Method,
-			})
+		errorLogger.For(c.Request.Context()).LogPanic(recovered, debug.Stack(), map[string]interface{}{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+		})
+		if respond == nil {
+			c.AbortWithStatus(500)
+			return
 		}
-		c.AbortWithStatus(500)
+		respond(c)
 	})
 }
\ No newline at end of file
@@ -0,0 +1,219 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"achievement-management/internal/config"
+)
+
+// decodeLogLines JSON形式のログを1行ずつ読み込む
+func decodeLogLines(t *testing.T, output string) []map[string]interface{} {
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to parse log entry %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestRequestLoggerMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	cfg := &config.Config{Logging: config.LoggingConfig{Level: "info", Format: "json"}}
+	logger := NewLoggerWithOutput(cfg, &buf)
+
+	router := gin.New()
+	router.Use(RequestLoggerMiddleware(logger))
+	router.Use(LoggingMiddleware(&AccessLogger{logger: logger}))
+	router.GET("/api/achievements/:id", func(c *gin.Context) {
+		FromContext(c.Request.Context()).Info("handled")
+		c.Status(http.StatusOK)
+	})
+
+	// 受け取ったリクエストIDをそのまま使う
+	req := httptest.NewRequest("GET", "/api/achievements/a1", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Header().Get(RequestIDHeader) != "req-123" {
+		t.Errorf("Expected request ID 'req-123' in the response, got %q", rr.Header().Get(RequestIDHeader))
+	}
+	entries := decodeLogLines(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected handler and access log entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry["request_id"] != "req-123" {
+			t.Errorf("Expected request_id 'req-123', got %v", entry["request_id"])
+		}
+		if entry["route"] != "/api/achievements/:id" {
+			t.Errorf("Expected route '/api/achievements/:id', got %v", entry["route"])
+		}
+		if entry["method"] != "GET" {
+			t.Errorf("Expected method 'GET', got %v", entry["method"])
+		}
+	}
+	if entries[1]["type"] != "access" {
+		t.Errorf("Expected the access log last, got %v", entries[1])
+	}
+
+	// 不正なリクエストIDは使わずに生成する
+	buf.Reset()
+	req = httptest.NewRequest("GET", "/api/achievements/a1", nil)
+	req.Header.Set(RequestIDHeader, "bad id\n")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	generated := rr.Header().Get(RequestIDHeader)
+	if generated == "" || generated == "bad id\n" {
+		t.Errorf("Expected a generated request ID, got %q", generated)
+	}
+	if entry := decodeLogLines(t, buf.String())[0]; entry["request_id"] != generated {
+		t.Errorf("Expected request_id %q, got %v", generated, entry["request_id"])
+	}
+}
+
+func TestContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &config.Config{Logging: config.LoggingConfig{Level: "info", Format: "json"}}
+	logger := NewLoggerWithOutput(cfg, &buf)
+
+	// ロガーが無い場合は何も出力しない
+	FromContext(context.Background()).Error("dropped")
+	if AddFields(context.Background(), map[string]interface{}{"tenant_id": "a"}) != context.Background() {
+		t.Error("Expected AddFields without a logger to return the context as is")
+	}
+
+	ctx := NewContext(context.Background(), logger, map[string]interface{}{"request_id": "r1"})
+	ctx = AddFields(ctx, map[string]interface{}{"tenant_id": "family-a"})
+	FromContext(ctx).Info("service log")
+	(&ErrorLogger{logger: logger}).For(ctx).LogServiceError("reward", "redeem", context.Canceled)
+
+	entries := decodeLogLines(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry["request_id"] != "r1" || entry["tenant_id"] != "family-a" {
+			t.Errorf("Expected correlation fields, got %v", entry)
+		}
+	}
+	if entries[1]["service"] != "reward" {
+		t.Errorf("Expected service error fields, got %v", entries[1])
+	}
+}
+
+func TestLoggingMiddleware_ExcludesAndSamples(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	cfg := &config.Config{Logging: config.LoggingConfig{Level: "info", Format: "json"}}
+	accessLogger := &AccessLogger{
+		logger: NewLoggerWithOutput(cfg, &buf),
+		filter: newAccessFilter(config.AccessLogConfig{
+			ExcludePaths: []string{"/health"},
+			Sample:       map[string]int{"/api/achievements/:id": 3},
+		}),
+	}
+
+	router := gin.New()
+	router.Use(LoggingMiddleware(accessLogger))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/api/achievements/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	router.GET("/api/rewards", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(path string) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+	request("/health")
+	for i := 0; i < 6; i++ {
+		request("/api/achievements/a1")
+	}
+	// エラーは間引かずに記録する
+	request("/api/achievements/missing")
+	request("/api/rewards")
+
+	entries := decodeLogLines(t, buf.String())
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry["path"].(string))
+	}
+	expected := []string{"/api/achievements/a1", "/api/achievements/a1", "/api/achievements/missing", "/api/rewards"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected %v, got %v", expected, paths)
+	}
+	if entries[0]["sample_rate"] != float64(3) {
+		t.Errorf("Expected sample_rate 3 on sampled entries, got %v", entries[0]["sample_rate"])
+	}
+	if _, ok := entries[3]["sample_rate"]; ok {
+		t.Errorf("Expected no sample_rate on routes that are not sampled, got %v", entries[3])
+	}
+}
+
+// recordingReporter 送られたエラーとパニックを記録する ErrorReporter
+type recordingReporter struct {
+	errors []error
+	panics []interface{}
+	fields []map[string]interface{}
+}
+
+func (r *recordingReporter) Report(err error, fields map[string]interface{}) {
+	r.errors = append(r.errors, err)
+	r.fields = append(r.fields, fields)
+}
+
+func (r *recordingReporter) ReportPanic(recovered interface{}, stack []byte, fields map[string]interface{}) {
+	r.panics = append(r.panics, recovered)
+	r.fields = append(r.fields, fields)
+}
+
+func TestRecoveryMiddleware_ReportsPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	cfg := &config.Config{Logging: config.LoggingConfig{Level: "info", Format: "json"}}
+	reporter := &recordingReporter{}
+	errorLogger := &ErrorLogger{logger: NewLoggerWithOutput(cfg, &buf)}
+	errorLogger.SetReporter(reporter)
+
+	router := gin.New()
+	router.Use(RequestLoggerMiddleware(NewLoggerWithOutput(cfg, &bytes.Buffer{})))
+	router.Use(RecoveryMiddleware(errorLogger, nil))
+	router.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	req := httptest.NewRequest("GET", "/boom", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", w.Code)
+	}
+	// エラーでないパニックも記録する
+	entries := decodeLogLines(t, buf.String())
+	if len(entries) != 1 || entries[0]["error"] != "boom" || entries[0]["request_id"] != "req-1" {
+		t.Errorf("Expected the panic to be logged with request_id, got %v", entries)
+	}
+	if len(reporter.panics) != 1 || reporter.panics[0] != "boom" {
+		t.Fatalf("Expected the panic to be reported, got %v", reporter.panics)
+	}
+	if reporter.fields[0]["request_id"] != "req-1" || reporter.fields[0]["route"] != "/boom" {
+		t.Errorf("Expected request context in the report, got %v", reporter.fields[0])
+	}
+}
@@ -0,0 +1,279 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"achievement-management/internal/config"
+)
+
+// backupTimeFormat ローテーションしたファイル名に付ける日時（UTC）
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile サイズと経過時間でローテーションし、古いファイルを削除するログファイル
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	rotation config.LogRotationConfig
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	now      func() time.Time
+	// cleanup 実行中のローテーションしたファイルの圧縮・削除（cleanupMu で1つずつ実行する）
+	cleanup   sync.WaitGroup
+	cleanupMu sync.Mutex
+}
+
+// openFiles プロセス内で開いているログファイル（同じファイルに出力するLoggerで共有し、ローテーションが競合しないようにする）
+var openFiles = struct {
+	sync.Mutex
+	files map[string]*RotatingFile
+}{files: make(map[string]*RotatingFile)}
+
+// openLogFile ログファイルを開く（同じパスのファイルをすでに開いている場合はそれを返す）
+func openLogFile(path string, rotation config.LogRotationConfig) (*RotatingFile, error) {
+	key, err := filepath.Abs(path)
+	if err != nil {
+		key = path
+	}
+
+	openFiles.Lock()
+	defer openFiles.Unlock()
+	if file, ok := openFiles.files[key]; ok {
+		return file, nil
+	}
+	file, err := NewRotatingFile(path, rotation)
+	if err != nil {
+		return nil, err
+	}
+	openFiles.files[key] = file
+	return file, nil
+}
+
+// NewRotatingFile ローテーションするログファイルを開く（ファイルがある場合は追記する）
+func NewRotatingFile(path string, rotation config.LogRotationConfig) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, rotation: rotation, now: time.Now}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write ローテーションが必要な場合はローテーションしてから書き込む
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close ファイルを閉じる（実行中の圧縮・削除の完了を待つ）
+func (f *RotatingFile) Close() error {
+	defer f.cleanup.Wait()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// shouldRotate n バイトを書き込む前にローテーションするか（空のファイルはローテーションしない）
+func (f *RotatingFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.rotation.MaxSizeMB > 0 && f.size+int64(n) > int64(f.rotation.MaxSizeMB)*1024*1024 {
+		return true
+	}
+	return f.rotation.IntervalHours > 0 && f.now().Sub(f.openedAt) >= time.Duration(f.rotation.IntervalHours)*time.Hour
+}
+
+// open ファイルを追記で開く
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+// rotate 現在のファイルを日時を付けた名前に変更し、新しいファイルを開く
+//
+// 名前を変更できない場合は元のファイルを開き直して書き込みを続ける。
+// 圧縮と古いファイルの削除は書き込みを止めないよう、ロックを持たないゴルーチンで行う。
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	now := f.now()
+	backup := f.backupPath(now)
+	if err := os.Rename(f.path, backup); err != nil {
+		if openErr := f.open(); openErr != nil {
+			return fmt.Errorf("failed to rotate log file: %w (reopen: %v)", err, openErr)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: failed to rotate log file %s: %v\n", f.path, err)
+		return nil
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.cleanup.Add(1)
+	go func() {
+		defer f.cleanup.Done()
+		f.cleanupBackups(backup, now)
+	}()
+	return nil
+}
+
+// cleanupBackups ローテーションしたファイルを圧縮し、保持する数・日数を超えたファイルを削除する（失敗しても書き込みは続ける）
+func (f *RotatingFile) cleanupBackups(backup string, now time.Time) {
+	f.cleanupMu.Lock()
+	defer f.cleanupMu.Unlock()
+
+	if f.rotation.Compress {
+		if err := compressFile(backup); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to compress rotated log file %s: %v\n", backup, err)
+		}
+	}
+	if err := f.removeOldBackups(now); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove old log files: %v\n", err)
+	}
+}
+
+// backupPath ローテーションしたファイルのパス（例: logs/app-2024-06-10T12-00-00.000.log）
+func (f *RotatingFile) backupPath(at time.Time) string {
+	prefix, ext := f.backupNameParts()
+	return filepath.Join(filepath.Dir(f.path), prefix+at.UTC().Format(backupTimeFormat)+ext)
+}
+
+// backupNameParts ローテーションしたファイル名の日時の前後
+func (f *RotatingFile) backupNameParts() (string, string) {
+	name := filepath.Base(f.path)
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + "-", ext
+}
+
+// logBackup ローテーションしたファイル
+type logBackup struct {
+	path string
+	at   time.Time
+}
+
+// removeOldBackups 保持する数・日数（now から数える）を超えたローテーションしたファイルを削除する
+func (f *RotatingFile) removeOldBackups(now time.Time) error {
+	if f.rotation.MaxBackups == 0 && f.rotation.MaxAgeDays == 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-time.Duration(f.rotation.MaxAgeDays) * 24 * time.Hour)
+	for i, backup := range backups {
+		expired := f.rotation.MaxAgeDays > 0 && backup.at.Before(cutoff)
+		if (f.rotation.MaxBackups > 0 && i >= f.rotation.MaxBackups) || expired {
+			if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// backups ローテーションしたファイルを新しい順に返す
+func (f *RotatingFile) backups() ([]logBackup, error) {
+	dir := filepath.Dir(f.path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, ext := f.backupNameParts()
+	var backups []logBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, prefix)
+		if trimmed := strings.TrimSuffix(stamp, ext+".gz"); trimmed != stamp {
+			stamp = trimmed
+		} else if trimmed := strings.TrimSuffix(stamp, ext); trimmed != stamp || ext == "" {
+			stamp = trimmed
+		} else {
+			continue
+		}
+		at, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, logBackup{path: filepath.Join(dir, name), at: at})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].at.After(backups[j].at) })
+	return backups, nil
+}
+
+// compressFile ファイルをgzipで圧縮して .gz を付けたファイルに置き換える
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+	src.Close()
+	return os.Remove(path)
+}
@@ -0,0 +1,179 @@
+package logging
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+)
+
+// newTestRotatingFile 現在時刻を固定したローテーションするログファイルを作成
+func newTestRotatingFile(t *testing.T, rotation config.LogRotationConfig, now *time.Time) (*RotatingFile, string) {
+	t.Helper()
+	dir := t.TempDir()
+	f, err := NewRotatingFile(filepath.Join(dir, "app.log"), rotation)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	f.now = func() time.Time { return *now }
+	f.openedAt = *now
+	t.Cleanup(func() { f.Close() })
+	return f, dir
+}
+
+// listDir ディレクトリのファイル名
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return names
+}
+
+func TestRotatingFile_RotatesBySize(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	f, dir := newTestRotatingFile(t, config.LogRotationConfig{MaxSizeMB: 1}, &now)
+
+	line := bytes.Repeat([]byte("a"), 600*1024)
+	f.Write(line)
+	now = now.Add(time.Second)
+	// 1MBを超えるため、書き込む前にローテーションする
+	f.Write(line)
+
+	names := listDir(t, dir)
+	if len(names) != 2 || names[0] != "app-2024-06-10T12-00-01.000.log" || names[1] != "app.log" {
+		t.Fatalf("Expected one rotated file and the current file, got %v", names)
+	}
+	info, _ := os.Stat(filepath.Join(dir, "app.log"))
+	if info.Size() != int64(len(line)) {
+		t.Errorf("Expected the current file to hold only the last write, got %d bytes", info.Size())
+	}
+}
+
+func TestRotatingFile_RotatesByInterval(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	f, dir := newTestRotatingFile(t, config.LogRotationConfig{IntervalHours: 24}, &now)
+
+	f.Write([]byte("day 1\n"))
+	now = now.Add(23 * time.Hour)
+	f.Write([]byte("day 1 later\n"))
+	if len(listDir(t, dir)) != 1 {
+		t.Fatalf("Expected no rotation before the interval, got %v", listDir(t, dir))
+	}
+
+	now = now.Add(time.Hour)
+	f.Write([]byte("day 2\n"))
+	data, _ := os.ReadFile(filepath.Join(dir, "app-2024-06-11T12-00-00.000.log"))
+	if string(data) != "day 1\nday 1 later\n" {
+		t.Errorf("Expected the first day in the rotated file, got %q", data)
+	}
+}
+
+func TestRotatingFile_Retention(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	f, dir := newTestRotatingFile(t, config.LogRotationConfig{IntervalHours: 24, MaxBackups: 2, MaxAgeDays: 3}, &now)
+
+	for i := 0; i < 4; i++ {
+		f.Write([]byte("entry\n"))
+		now = now.Add(24 * time.Hour)
+	}
+	f.Write([]byte("entry\n"))
+	f.cleanup.Wait()
+
+	// 新しい2つだけを残す
+	names := listDir(t, dir)
+	expected := []string{"app-2024-06-13T12-00-00.000.log", "app-2024-06-14T12-00-00.000.log", "app.log"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+
+	// 保持する日数を過ぎたファイルは数に関係なく削除する
+	now = now.Add(4 * 24 * time.Hour)
+	f.Write([]byte("entry\n"))
+	f.cleanup.Wait()
+	names = listDir(t, dir)
+	if len(names) != 2 || names[0] != "app-2024-06-18T12-00-00.000.log" {
+		t.Errorf("Expected only the newest rotated file, got %v", names)
+	}
+}
+
+func TestRotatingFile_Compress(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	f, dir := newTestRotatingFile(t, config.LogRotationConfig{IntervalHours: 1, Compress: true}, &now)
+
+	f.Write([]byte("first\n"))
+	now = now.Add(time.Hour)
+	f.Write([]byte("second\n"))
+	// 圧縮は書き込みとは別に行う
+	f.cleanup.Wait()
+
+	file, err := os.Open(filepath.Join(dir, "app-2024-06-10T13-00-00.000.log.gz"))
+	if err != nil {
+		t.Fatalf("Expected a compressed rotated file, got %v (%v)", err, listDir(t, dir))
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(gz)
+	if string(data) != "first\n" {
+		t.Errorf("Expected the rotated content, got %q", data)
+	}
+	if len(listDir(t, dir)) != 2 {
+		t.Errorf("Expected the uncompressed rotated file to be removed, got %v", listDir(t, dir))
+	}
+}
+
+func TestRotatingFile_RenameFailureKeepsWriting(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	f, dir := newTestRotatingFile(t, config.LogRotationConfig{IntervalHours: 1}, &now)
+
+	// ローテーション先に空でないディレクトリがあるため名前を変更できない
+	blocked := filepath.Join(dir, "app-2024-06-10T13-00-00.000.log")
+	if err := os.MkdirAll(filepath.Join(blocked, "keep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	f.Write([]byte("first\n"))
+	now = now.Add(time.Hour)
+	if _, err := f.Write([]byte("second\n")); err != nil {
+		t.Fatalf("Expected the write to succeed after a failed rotation, got %v", err)
+	}
+	if _, err := f.Write([]byte("third\n")); err != nil {
+		t.Fatalf("Expected later writes to succeed, got %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+	if string(data) != "first\nsecond\nthird\n" {
+		t.Errorf("Expected every write in the original file, got %q", data)
+	}
+}
+
+func TestNewLogger_SharesLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.log")
+	cfg := &config.Config{Logging: config.LoggingConfig{Level: "info", Format: "json", Output: path}}
+
+	first, err := openLogFile(cfg.Logging.Output, cfg.Logging.Rotation)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, err := openLogFile(cfg.Logging.Output, cfg.Logging.Rotation)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first != second {
+		t.Error("Expected loggers writing to the same file to share it")
+	}
+}
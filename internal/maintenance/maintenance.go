@@ -0,0 +1,39 @@
+package maintenance
+
+import (
+	"sync/atomic"
+
+	"achievement-management/internal/errors"
+)
+
+// Mode 書き込みを停止するメンテナンスモードの状態
+//
+// 同じプロセス内のリポジトリで共有し、実行中に切り替えられる。
+type Mode struct {
+	readOnly atomic.Bool
+}
+
+// NewMode メンテナンスモードの状態を作成（readOnly は起動時の状態）
+func NewMode(readOnly bool) *Mode {
+	m := &Mode{}
+	m.readOnly.Store(readOnly)
+	return m
+}
+
+// ReadOnly 書き込みを停止しているかどうか
+func (m *Mode) ReadOnly() bool {
+	return m.readOnly.Load()
+}
+
+// SetReadOnly 書き込みの停止・再開を切り替え
+func (m *Mode) SetReadOnly(readOnly bool) {
+	m.readOnly.Store(readOnly)
+}
+
+// checkWrite 書き込みを停止している場合は errors.ErrReadOnly を返す
+func (m *Mode) checkWrite() error {
+	if m.ReadOnly() {
+		return errors.ErrReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,704 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// AchievementRepository メンテナンス中は書き込みを拒否する達成目録リポジトリ
+type AchievementRepository struct {
+	next repository.AchievementRepository
+	mode *Mode
+}
+
+// NewAchievementRepository 達成目録リポジトリにメンテナンスモードの確認を追加
+func NewAchievementRepository(next repository.AchievementRepository, mode *Mode) repository.AchievementRepository {
+	return &AchievementRepository{next: next, mode: mode}
+}
+
+// Create 達成目録を作成
+func (r *AchievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Create(ctx, achievement)
+}
+
+// CreateWithPoints 達成目録の作成・ポイント加算・台帳への記録を実行
+func (r *AchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.CreateWithPoints(ctx, achievement)
+}
+
+// Update 達成目録を更新
+func (r *AchievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Update(ctx, achievement)
+}
+
+// UpdateWithPoints 達成目録を更新し、ポイントの差分を反映
+func (r *AchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.UpdateWithPoints(ctx, achievement)
+}
+
+// GetByID IDで達成目録を取得
+func (r *AchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべての達成目録を取得
+func (r *AchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
+	return r.next.List(ctx)
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイントのみを取得
+func (r *AchievementRepository) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	return r.next.ListSummaries(ctx)
+}
+
+// Count 達成目録の件数を取得
+func (r *AchievementRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 達成目録を削除
+func (r *AchievementRepository) Delete(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// DeleteWithPoints 達成目録の削除・ポイント減算・台帳への記録を実行
+func (r *AchievementRepository) DeleteWithPoints(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.DeleteWithPoints(ctx, id)
+}
+
+// DeleteMany 複数の達成目録をまとめて削除
+func (r *AchievementRepository) DeleteMany(ctx context.Context, ids []string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.DeleteMany(ctx, ids)
+}
+
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存
+func (r *AchievementRepository) SetAttachment(ctx context.Context, id, key string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.SetAttachment(ctx, id, key)
+}
+
+// StartTimer 達成目録のタイマーを開始
+func (r *AchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.StartTimer(ctx, id, at)
+}
+
+// Complete 達成記録を作成し、ポイントを付与
+func (r *AchievementRepository) Complete(ctx context.Context, completion *models.Completion) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Complete(ctx, completion)
+}
+
+// ListCompletions 達成目録の達成記録を取得
+func (r *AchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	return r.next.ListCompletions(ctx, achievementID)
+}
+
+// RewardRepository メンテナンス中は書き込みを拒否する報酬リポジトリ
+type RewardRepository struct {
+	next repository.RewardRepository
+	mode *Mode
+}
+
+// NewRewardRepository 報酬リポジトリにメンテナンスモードの確認を追加
+func NewRewardRepository(next repository.RewardRepository, mode *Mode) repository.RewardRepository {
+	return &RewardRepository{next: next, mode: mode}
+}
+
+// Create 報酬を作成
+func (r *RewardRepository) Create(ctx context.Context, reward *models.Reward) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Create(ctx, reward)
+}
+
+// Update 報酬を更新
+func (r *RewardRepository) Update(ctx context.Context, reward *models.Reward) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Update(ctx, reward)
+}
+
+// GetByID IDで報酬を取得
+func (r *RewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// GetByIDs 複数のIDの報酬をまとめて取得
+func (r *RewardRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	return r.next.GetByIDs(ctx, ids)
+}
+
+// List すべての報酬を取得
+func (r *RewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
+	return r.next.List(ctx)
+}
+
+// Count 報酬の件数を取得
+func (r *RewardRepository) Count(ctx context.Context) (int, error) {
+	return r.next.Count(ctx)
+}
+
+// Delete 報酬を削除
+func (r *RewardRepository) Delete(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// PointRepository メンテナンス中は書き込みを拒否するポイントリポジトリ
+type PointRepository struct {
+	next repository.PointRepository
+	mode *Mode
+}
+
+// NewPointRepository ポイントリポジトリにメンテナンスモードの確認を追加
+func NewPointRepository(next repository.PointRepository, mode *Mode) repository.PointRepository {
+	return &PointRepository{next: next, mode: mode}
+}
+
+// GetCurrentPoints 現在のポイントを取得
+func (r *PointRepository) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.next.GetCurrentPoints(ctx)
+}
+
+// GetCurrentPointsConsistent 直前の書き込みを反映した現在のポイントを取得
+func (r *PointRepository) GetCurrentPointsConsistent(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.next.GetCurrentPointsConsistent(ctx)
+}
+
+// UpdateCurrentPoints 現在のポイントを修正
+func (r *PointRepository) UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.UpdateCurrentPoints(ctx, points)
+}
+
+// CreateRewardHistory 報酬獲得履歴を作成
+func (r *PointRepository) CreateRewardHistory(ctx context.Context, history *models.RewardHistory) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.CreateRewardHistory(ctx, history)
+}
+
+// GetRewardHistory 報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
+	return r.next.GetRewardHistory(ctx)
+}
+
+// GetRewardHistoryBetween 獲得日時の範囲を指定して報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	return r.next.GetRewardHistoryBetween(ctx, from, to)
+}
+
+// CountRewardHistory 報酬獲得履歴の件数を取得
+func (r *PointRepository) CountRewardHistory(ctx context.Context) (int, error) {
+	return r.next.CountRewardHistory(ctx)
+}
+
+// RedeemPoints 報酬獲得のポイント減算・台帳への記録・履歴記録を実行
+func (r *PointRepository) RedeemPoints(ctx context.Context, history *models.RewardHistory) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.RedeemPoints(ctx, history)
+}
+
+// GetRewardHistoryByID IDで報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryByID(ctx context.Context, id string) (*models.RewardHistory, error) {
+	return r.next.GetRewardHistoryByID(ctx, id)
+}
+
+// RefundRedemption 報酬獲得の取り消し・ポイントの返還・台帳への記録を実行
+func (r *PointRepository) RefundRedemption(ctx context.Context, history *models.RewardHistory) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.RefundRedemption(ctx, history)
+}
+
+// SetRedemptionAttachment 報酬獲得履歴に添付ファイルのオブジェクトキーを保存
+func (r *PointRepository) SetRedemptionAttachment(ctx context.Context, id, key string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.SetRedemptionAttachment(ctx, id, key)
+}
+
+// AnnotateRedemption 報酬獲得履歴の注記とタグを置き換える
+func (r *PointRepository) AnnotateRedemption(ctx context.Context, id, note string, tags []string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.AnnotateRedemption(ctx, id, note, tags)
+}
+
+// GetLedger ポイント台帳を取得
+func (r *PointRepository) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	return r.next.GetLedger(ctx)
+}
+
+// GetLedgerBetween 記録日時の範囲を指定してポイント台帳を取得
+func (r *PointRepository) GetLedgerBetween(ctx context.Context, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	return r.next.GetLedgerBetween(ctx, from, to)
+}
+
+// AddPoints ポイントを加算
+func (r *PointRepository) AddPoints(ctx context.Context, points int) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.AddPoints(ctx, points)
+}
+
+// SubtractPoints ポイントを減算
+func (r *PointRepository) SubtractPoints(ctx context.Context, points int) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.SubtractPoints(ctx, points)
+}
+
+// BadgeRepository メンテナンス中は書き込みを拒否するバッジリポジトリ
+type BadgeRepository struct {
+	next repository.BadgeRepository
+	mode *Mode
+}
+
+// NewBadgeRepository バッジリポジトリにメンテナンスモードの確認を追加
+func NewBadgeRepository(next repository.BadgeRepository, mode *Mode) repository.BadgeRepository {
+	return &BadgeRepository{next: next, mode: mode}
+}
+
+// Award バッジの獲得を記録
+func (r *BadgeRepository) Award(ctx context.Context, badge *models.Badge) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Award(ctx, badge)
+}
+
+// List 獲得したバッジを取得
+func (r *BadgeRepository) List(ctx context.Context) ([]*models.Badge, error) {
+	return r.next.List(ctx)
+}
+
+// GoalRepository メンテナンス中は書き込みを拒否する目標リポジトリ
+type GoalRepository struct {
+	next repository.GoalRepository
+	mode *Mode
+}
+
+// NewGoalRepository 目標リポジトリにメンテナンスモードの確認を追加
+func NewGoalRepository(next repository.GoalRepository, mode *Mode) repository.GoalRepository {
+	return &GoalRepository{next: next, mode: mode}
+}
+
+// Create 目標を作成
+func (r *GoalRepository) Create(ctx context.Context, goal *models.Goal) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Create(ctx, goal)
+}
+
+// Update 目標を更新
+func (r *GoalRepository) Update(ctx context.Context, goal *models.Goal) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Update(ctx, goal)
+}
+
+// GetByID IDで目標を取得
+func (r *GoalRepository) GetByID(ctx context.Context, id string) (*models.Goal, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべての目標を取得
+func (r *GoalRepository) List(ctx context.Context) ([]*models.Goal, error) {
+	return r.next.List(ctx)
+}
+
+// Delete 目標を削除
+func (r *GoalRepository) Delete(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// MarkReached 目標の達成日時を記録
+func (r *GoalRepository) MarkReached(ctx context.Context, id string, reachedAt time.Time) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.MarkReached(ctx, id, reachedAt)
+}
+
+// FavoriteRepository メンテナンス中は書き込みを拒否するお気に入りリポジトリ
+type FavoriteRepository struct {
+	next repository.FavoriteRepository
+	mode *Mode
+}
+
+// NewFavoriteRepository お気に入りリポジトリにメンテナンスモードの確認を追加
+func NewFavoriteRepository(next repository.FavoriteRepository, mode *Mode) repository.FavoriteRepository {
+	return &FavoriteRepository{next: next, mode: mode}
+}
+
+// Add 報酬をお気に入りに登録
+func (r *FavoriteRepository) Add(ctx context.Context, favorite *models.Favorite) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Add(ctx, favorite)
+}
+
+// Remove 報酬をお気に入りから外す
+func (r *FavoriteRepository) Remove(ctx context.Context, rewardID string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Remove(ctx, rewardID)
+}
+
+// List お気に入りを取得
+func (r *FavoriteRepository) List(ctx context.Context) ([]*models.Favorite, error) {
+	return r.next.List(ctx)
+}
+
+// WishlistRepository メンテナンス中は書き込みを拒否するほしいものリストリポジトリ
+type WishlistRepository struct {
+	next repository.WishlistRepository
+	mode *Mode
+}
+
+// NewWishlistRepository ほしいものリストリポジトリにメンテナンスモードの確認を追加
+func NewWishlistRepository(next repository.WishlistRepository, mode *Mode) repository.WishlistRepository {
+	return &WishlistRepository{next: next, mode: mode}
+}
+
+// Add 報酬をほしいものリストに追加
+func (r *WishlistRepository) Add(ctx context.Context, item *models.WishlistItem) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Add(ctx, item)
+}
+
+// UpdatePriority ほしいものリストの報酬の優先度を変更
+func (r *WishlistRepository) UpdatePriority(ctx context.Context, rewardID string, priority int) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.UpdatePriority(ctx, rewardID, priority)
+}
+
+// Remove 報酬をほしいものリストから外す
+func (r *WishlistRepository) Remove(ctx context.Context, rewardID string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Remove(ctx, rewardID)
+}
+
+// List ほしいものリストを取得
+func (r *WishlistRepository) List(ctx context.Context) ([]*models.WishlistItem, error) {
+	return r.next.List(ctx)
+}
+
+// NoteRepository メンテナンス中は書き込みを拒否するメモリポジトリ
+type NoteRepository struct {
+	next repository.NoteRepository
+	mode *Mode
+}
+
+// NewNoteRepository メモリポジトリにメンテナンスモードの確認を追加
+func NewNoteRepository(next repository.NoteRepository, mode *Mode) repository.NoteRepository {
+	return &NoteRepository{next: next, mode: mode}
+}
+
+// Create メモを作成
+func (r *NoteRepository) Create(ctx context.Context, note *models.Note) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Create(ctx, note)
+}
+
+// GetByID IDでメモを取得
+func (r *NoteRepository) GetByID(ctx context.Context, id string) (*models.Note, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// ListByTarget 記録に付けたメモを取得
+func (r *NoteRepository) ListByTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error) {
+	return r.next.ListByTarget(ctx, targetType, targetID)
+}
+
+// Delete メモを削除
+func (r *NoteRepository) Delete(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// DriftRepository メンテナンス中は書き込みを拒否するポイントの差異のリポジトリ
+type DriftRepository struct {
+	next repository.DriftRepository
+	mode *Mode
+}
+
+// NewDriftRepository ポイントの差異のリポジトリにメンテナンスモードの確認を追加
+func NewDriftRepository(next repository.DriftRepository, mode *Mode) repository.DriftRepository {
+	return &DriftRepository{next: next, mode: mode}
+}
+
+// Record 検出したポイントの差異を記録
+func (r *DriftRepository) Record(ctx context.Context, event *models.DriftEvent) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Record(ctx, event)
+}
+
+// List 記録したポイントの差異を取得
+func (r *DriftRepository) List(ctx context.Context) ([]*models.DriftEvent, error) {
+	return r.next.List(ctx)
+}
+
+// ReservationRepository メンテナンス中は書き込みを拒否するポイントの取り置きのリポジトリ
+type ReservationRepository struct {
+	next repository.ReservationRepository
+	mode *Mode
+}
+
+// NewReservationRepository ポイントの取り置きのリポジトリにメンテナンスモードの確認を追加
+func NewReservationRepository(next repository.ReservationRepository, mode *Mode) repository.ReservationRepository {
+	return &ReservationRepository{next: next, mode: mode}
+}
+
+// Put 報酬のためにポイントを取り置く
+func (r *ReservationRepository) Put(ctx context.Context, reservation *models.Reservation) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Put(ctx, reservation)
+}
+
+// Remove 取り置きを解除
+func (r *ReservationRepository) Remove(ctx context.Context, rewardID string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Remove(ctx, rewardID)
+}
+
+// List 取り置きを取得
+func (r *ReservationRepository) List(ctx context.Context) ([]*models.Reservation, error) {
+	return r.next.List(ctx)
+}
+
+// QuestRepository メンテナンス中は書き込みを拒否するクエストリポジトリ
+type QuestRepository struct {
+	next repository.QuestRepository
+	mode *Mode
+}
+
+// NewQuestRepository クエストリポジトリにメンテナンスモードの確認を追加
+func NewQuestRepository(next repository.QuestRepository, mode *Mode) repository.QuestRepository {
+	return &QuestRepository{next: next, mode: mode}
+}
+
+// Create クエストを作成
+func (r *QuestRepository) Create(ctx context.Context, quest *models.Quest) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Create(ctx, quest)
+}
+
+// GetByID IDでクエストを取得
+func (r *QuestRepository) GetByID(ctx context.Context, id string) (*models.Quest, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべてのクエストを取得
+func (r *QuestRepository) List(ctx context.Context) ([]*models.Quest, error) {
+	return r.next.List(ctx)
+}
+
+// Delete クエストを削除
+func (r *QuestRepository) Delete(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// Complete クエストの完了を記録してボーナスを付与
+func (r *QuestRepository) Complete(ctx context.Context, quest *models.Quest) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Complete(ctx, quest)
+}
+
+// OperationRepository メンテナンス中は書き込みを拒否する操作履歴のリポジトリ
+type OperationRepository struct {
+	next repository.OperationRepository
+	mode *Mode
+}
+
+// NewOperationRepository 操作履歴のリポジトリにメンテナンスモードの確認を追加
+func NewOperationRepository(next repository.OperationRepository, mode *Mode) repository.OperationRepository {
+	return &OperationRepository{next: next, mode: mode}
+}
+
+// Record 操作を記録
+func (r *OperationRepository) Record(ctx context.Context, operation *models.Operation) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Record(ctx, operation)
+}
+
+// List 操作履歴を取得
+func (r *OperationRepository) List(ctx context.Context) ([]*models.Operation, error) {
+	return r.next.List(ctx)
+}
+
+// SetUndone 操作を元に戻した日時を記録
+func (r *OperationRepository) SetUndone(ctx context.Context, id string, undoneAt *time.Time) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.SetUndone(ctx, id, undoneAt)
+}
+
+// Delete 操作を操作履歴から削除
+func (r *OperationRepository) Delete(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// AllowanceRepository メンテナンス中は書き込みを拒否するお小遣いのルールのリポジトリ
+type AllowanceRepository struct {
+	next repository.AllowanceRepository
+	mode *Mode
+}
+
+// NewAllowanceRepository お小遣いのルールのリポジトリにメンテナンスモードの確認を追加
+func NewAllowanceRepository(next repository.AllowanceRepository, mode *Mode) repository.AllowanceRepository {
+	return &AllowanceRepository{next: next, mode: mode}
+}
+
+// Create お小遣いのルールを作成
+func (r *AllowanceRepository) Create(ctx context.Context, allowance *models.Allowance) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Create(ctx, allowance)
+}
+
+// GetByID IDでお小遣いのルールを取得
+func (r *AllowanceRepository) GetByID(ctx context.Context, id string) (*models.Allowance, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべてのお小遣いのルールを取得
+func (r *AllowanceRepository) List(ctx context.Context) ([]*models.Allowance, error) {
+	return r.next.List(ctx)
+}
+
+// Delete お小遣いのルールを削除
+func (r *AllowanceRepository) Delete(ctx context.Context, id string) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// Grant お小遣いのポイントを付与
+func (r *AllowanceRepository) Grant(ctx context.Context, id string, points int, at time.Time) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Grant(ctx, id, points, at)
+}
+
+// APIKeyRepository メンテナンス中は書き込みを拒否するAPIキーのリポジトリ
+type APIKeyRepository struct {
+	next repository.APIKeyRepository
+	mode *Mode
+}
+
+// NewAPIKeyRepository APIキーのリポジトリにメンテナンスモードの確認を追加
+func NewAPIKeyRepository(next repository.APIKeyRepository, mode *Mode) repository.APIKeyRepository {
+	return &APIKeyRepository{next: next, mode: mode}
+}
+
+// Create APIキーを作成
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Create(ctx, key)
+}
+
+// Update APIキーを更新
+func (r *APIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	if err := r.mode.checkWrite(); err != nil {
+		return err
+	}
+	return r.next.Update(ctx, key)
+}
+
+// GetByID IDでAPIキーを取得
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	return r.next.GetByID(ctx, id)
+}
+
+// List APIキーを取得
+func (r *APIKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	return r.next.List(ctx)
+}
@@ -0,0 +1,302 @@
+package maintenance
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository/memory"
+)
+
+func TestAchievementRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	mode := NewMode(false)
+	repo := NewAchievementRepository(memory.NewAchievementRepository(memory.NewStore()), mode)
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	mode.SetReadOnly(true)
+
+	if err := repo.Create(ctx, &models.Achievement{Title: "連続ログイン", Point: 20}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Create, got %v", err)
+	}
+	if err := repo.CreateWithPoints(ctx, &models.Achievement{Title: "連続ログイン", Point: 20}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from CreateWithPoints, got %v", err)
+	}
+	if err := repo.Update(ctx, achievement); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Update, got %v", err)
+	}
+	if err := repo.UpdateWithPoints(ctx, achievement); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdateWithPoints, got %v", err)
+	}
+	if err := repo.Delete(ctx, achievement.ID); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Delete, got %v", err)
+	}
+	if err := repo.DeleteWithPoints(ctx, achievement.ID); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteWithPoints, got %v", err)
+	}
+	if err := repo.DeleteMany(ctx, []string{achievement.ID}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteMany, got %v", err)
+	}
+	if err := repo.Complete(ctx, &models.Completion{AchievementID: achievement.ID, Point: 10}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Complete, got %v", err)
+	}
+
+	// 読み取りはメンテナンス中も利用できる
+	if _, err := repo.GetByID(ctx, achievement.ID); err != nil {
+		t.Errorf("GetByID failed while read-only: %v", err)
+	}
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed while read-only: %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("Expected 1 achievement, got %d", len(list))
+	}
+	if _, err := repo.ListCompletions(ctx, achievement.ID); err != nil {
+		t.Errorf("ListCompletions failed while read-only: %v", err)
+	}
+
+	mode.SetReadOnly(false)
+
+	if err := repo.Delete(ctx, achievement.ID); err != nil {
+		t.Errorf("Delete failed after maintenance: %v", err)
+	}
+}
+
+func TestRewardRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRewardRepository(memory.NewRewardRepository(memory.NewStore()), NewMode(true))
+
+	if err := repo.Create(ctx, &models.Reward{Title: "コーヒー券", Point: 50}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Create, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestBadgeRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBadgeRepository(memory.NewBadgeRepository(memory.NewStore()), NewMode(true))
+
+	if err := repo.Award(ctx, &models.Badge{ID: "first_achievement", Name: "First Step"}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Award, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestGoalRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	mode := NewMode(false)
+	repo := NewGoalRepository(memory.NewGoalRepository(memory.NewStore()), mode)
+
+	goal := &models.Goal{Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 500}
+	if err := repo.Create(ctx, goal); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	mode.SetReadOnly(true)
+
+	if err := repo.Update(ctx, goal); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Update, got %v", err)
+	}
+	if err := repo.MarkReached(ctx, goal.ID, time.Now()); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from MarkReached, got %v", err)
+	}
+	if err := repo.Delete(ctx, goal.ID); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Delete, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestPointRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	mode := NewMode(false)
+	repo := NewPointRepository(memory.NewPointRepository(memory.NewStore()), mode)
+
+	if err := repo.AddPoints(ctx, 100); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+
+	mode.SetReadOnly(true)
+
+	if err := repo.AddPoints(ctx, 10); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from AddPoints, got %v", err)
+	}
+	if err := repo.SubtractPoints(ctx, 10); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from SubtractPoints, got %v", err)
+	}
+	err := repo.RedeemPoints(ctx, &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 50})
+	if !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from RedeemPoints, got %v", err)
+	}
+	err = repo.RefundRedemption(ctx, &models.RewardHistory{ID: "h1", RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 50})
+	if !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from RefundRedemption, got %v", err)
+	}
+
+	points, err := repo.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed while read-only: %v", err)
+	}
+	if points.Point != 100 {
+		t.Errorf("Expected balance to stay at 100, got %d", points.Point)
+	}
+}
+
+func TestFavoriteAndWishlistRepositories_RejectWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	mode := NewMode(true)
+	store := memory.NewStore()
+	favorites := NewFavoriteRepository(memory.NewFavoriteRepository(store), mode)
+	wishlist := NewWishlistRepository(memory.NewWishlistRepository(store), mode)
+
+	if err := favorites.Add(ctx, &models.Favorite{RewardID: "reward-1"}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from favorite Add, got %v", err)
+	}
+	if err := wishlist.Add(ctx, &models.WishlistItem{RewardID: "reward-1"}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from wishlist Add, got %v", err)
+	}
+	if err := wishlist.UpdatePriority(ctx, "reward-1", 2); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdatePriority, got %v", err)
+	}
+	if _, err := wishlist.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestNoteRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	mode := NewMode(false)
+	repo := NewNoteRepository(memory.NewNoteRepository(memory.NewStore()), mode)
+
+	note := &models.Note{TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "雨の中で走った"}
+	if err := repo.Create(ctx, note); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	mode.SetReadOnly(true)
+
+	if err := repo.Create(ctx, &models.Note{TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "メモ"}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Create, got %v", err)
+	}
+	if err := repo.Delete(ctx, note.ID); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Delete, got %v", err)
+	}
+	notes, err := repo.ListByTarget(ctx, models.NoteTargetAchievement, "achievement-1")
+	if err != nil {
+		t.Fatalf("ListByTarget failed while read-only: %v", err)
+	}
+	if len(notes) != 1 {
+		t.Errorf("Expected 1 note, got %d", len(notes))
+	}
+}
+
+func TestDriftRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewDriftRepository(memory.NewDriftRepository(memory.NewStore()), NewMode(true))
+
+	if err := repo.Record(ctx, &models.DriftEvent{Difference: 20}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Record, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestReservationRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewReservationRepository(memory.NewReservationRepository(memory.NewStore()), NewMode(true))
+
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "switch", Points: 300}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Put, got %v", err)
+	}
+	if err := repo.Remove(ctx, "switch"); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Remove, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestQuestRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewQuestRepository(memory.NewQuestRepository(memory.NewStore()), NewMode(true))
+
+	if err := repo.Create(ctx, &models.Quest{Title: "朝活", Steps: []string{"run"}}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Create, got %v", err)
+	}
+	if err := repo.Complete(ctx, &models.Quest{ID: "quest-123"}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Complete, got %v", err)
+	}
+	if err := repo.Delete(ctx, "quest-123"); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Delete, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestOperationRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOperationRepository(memory.NewOperationRepository(memory.NewStore()), NewMode(true))
+
+	operation := &models.Operation{Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-123"}
+	if err := repo.Record(ctx, operation); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Record, got %v", err)
+	}
+	if err := repo.SetUndone(ctx, "op-123", nil); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from SetUndone, got %v", err)
+	}
+	if err := repo.Delete(ctx, "op-123"); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Delete, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestAllowanceRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAllowanceRepository(memory.NewAllowanceRepository(memory.NewStore()), NewMode(true))
+
+	if err := repo.Create(ctx, &models.Allowance{Title: "お小遣い", Points: 50, Schedule: "0 9 * * 1"}); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Create, got %v", err)
+	}
+	if err := repo.Grant(ctx, "allowance-123", 50, time.Now()); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Grant, got %v", err)
+	}
+	if err := repo.Delete(ctx, "allowance-123"); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Delete, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
+
+func TestAPIKeyRepository_RejectsWritesWhileReadOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPIKeyRepository(memory.NewAPIKeyRepository(memory.NewStore()), NewMode(true))
+
+	key := &models.APIKey{ID: "key-123", Label: "ダッシュボード", Scope: models.APIKeyScopeRead, Hash: "5e884898"}
+	if err := repo.Create(ctx, key); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Create, got %v", err)
+	}
+	if err := repo.Update(ctx, key); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Update, got %v", err)
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Errorf("List failed while read-only: %v", err)
+	}
+}
@@ -0,0 +1,319 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/repository"
+)
+
+// メトリクス名
+const (
+	// repositoryCallMetric リポジトリ呼び出しのレイテンシのヒストグラム名
+	repositoryCallMetric = "achievement_repository_call_duration_seconds"
+	// dynamoDBCallMetric DynamoDBのAPI呼び出し（リトライの1回ごと）のレイテンシのヒストグラム名
+	dynamoDBCallMetric = "achievement_dynamodb_call_duration_seconds"
+	// dynamoDBThrottleMetric DynamoDBのスロットリングの回数のカウンター名
+	dynamoDBThrottleMetric = "achievement_dynamodb_throttles_total"
+	// dynamoDBErrorMetric DynamoDBのスロットリング以外のエラーの回数のカウンター名
+	dynamoDBErrorMetric = "achievement_dynamodb_errors_total"
+	// panicMetric APIサーバーがパニックから回復した回数のカウンター名
+	panicMetric = "achievement_http_panics_total"
+)
+
+// DefaultBuckets レイテンシのヒストグラムの上限値（秒）
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// エラーの種類（error_class ラベルの値）
+const (
+	// ErrorClassNone 成功
+	ErrorClassNone = "none"
+	// ErrorClassThrottled DynamoDBのスロットリング（リトライしても解消しなかった場合）
+	ErrorClassThrottled = "throttled"
+	// ErrorClassCircuitOpen サーキットブレーカーが呼び出しを遮断した
+	ErrorClassCircuitOpen = "circuit_open"
+	// ErrorClassTimeout コンテキストの期限切れ
+	ErrorClassTimeout = "timeout"
+	// ErrorClassCanceled コンテキストのキャンセル
+	ErrorClassCanceled = "canceled"
+	// ErrorClassNotFound 対象のアイテムが存在しない
+	ErrorClassNotFound = "not_found"
+	// ErrorClassResourceNotFound テーブルまたはインデックスが存在しない
+	ErrorClassResourceNotFound = "resource_not_found"
+	// ErrorClassNetwork DynamoDBにリクエストを送信できなかった
+	ErrorClassNetwork = "network"
+	// ErrorClassValidation 入力値の検証エラー
+	ErrorClassValidation = "validation"
+	// ErrorClassConflict 重複・楽観的ロックの競合
+	ErrorClassConflict = "conflict"
+	// ErrorClassConditionFailed DynamoDBの条件付き書き込みの条件を満たさなかった
+	ErrorClassConditionFailed = "condition_failed"
+	// ErrorClassInsufficientPoints 残高不足
+	ErrorClassInsufficientPoints = "insufficient_points"
+	// ErrorClassInternal その他のストレージのエラー
+	ErrorClassInternal = "internal"
+)
+
+// Default アプリケーション全体で共有するレジストリ（storage.Open が記録し、APIサーバーが公開する）
+var Default = NewRegistry()
+
+// callKey 集計の単位
+type callKey struct {
+	operation  string
+	table      string
+	errorClass string
+}
+
+// histogram レイテンシの累積
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Registry リポジトリ呼び出しとDynamoDBのAPI呼び出しの回数・レイテンシ・エラーの種類と、パニックの回数を集計する
+type Registry struct {
+	mu      sync.Mutex
+	buckets []float64
+	calls   map[callKey]*histogram
+	// dynamoDBCalls DynamoDBのAPI呼び出しのレイテンシ（errorClass は使わない）
+	dynamoDBCalls map[callKey]*histogram
+	// dynamoDBThrottles DynamoDBのスロットリングの回数（errorClass は使わない）
+	dynamoDBThrottles map[callKey]uint64
+	// dynamoDBErrors DynamoDBのスロットリング以外のエラーの回数
+	dynamoDBErrors map[callKey]uint64
+	// panics ルートごとのパニックから回復した回数
+	panics map[string]uint64
+}
+
+// NewRegistry 空のレジストリを作成
+func NewRegistry() *Registry {
+	return &Registry{
+		buckets:           DefaultBuckets,
+		calls:             map[callKey]*histogram{},
+		dynamoDBCalls:     map[callKey]*histogram{},
+		dynamoDBThrottles: map[callKey]uint64{},
+		dynamoDBErrors:    map[callKey]uint64{},
+		panics:            map[string]uint64{},
+	}
+}
+
+// ObserveRepositoryCall リポジトリ呼び出し1回の結果を記録
+func (r *Registry) ObserveRepositoryCall(operation, table string, duration time.Duration, err error) {
+	key := callKey{operation: operation, table: table, errorClass: ErrorClass(err)}
+	seconds := duration.Seconds()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observe(r.calls, key, seconds)
+}
+
+// ObserveDynamoDBCall DynamoDBのAPI呼び出し1回の結果を記録（repository.CallObserver の実装）
+//
+// レイテンシはエラーの種類によらず記録し、スロットリングとそれ以外のエラーは別のカウンターで数える。
+// 条件付き書き込みの失敗は正常な応答のためエラーとして数えない。
+func (r *Registry) ObserveDynamoDBCall(operation, table string, duration time.Duration, err error) {
+	key := callKey{operation: operation, table: table}
+	errorClass := ErrorClass(err)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observe(r.dynamoDBCalls, key, duration.Seconds())
+	switch errorClass {
+	case ErrorClassNone, ErrorClassConditionFailed:
+	case ErrorClassThrottled:
+		r.dynamoDBThrottles[key]++
+	default:
+		r.dynamoDBErrors[callKey{operation: operation, table: table, errorClass: errorClass}]++
+	}
+}
+
+// ObservePanic APIサーバーがパニックから回復したことを記録（route はルートのパス、ルートに一致しない場合は空）
+func (r *Registry) ObservePanic(route string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.panics[route]++
+}
+
+// observe ヒストグラムに1件追加（mu を取得して呼ぶ）
+func (r *Registry) observe(histograms map[callKey]*histogram, key callKey, seconds float64) {
+	h, ok := histograms[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		histograms[key] = h
+	}
+	for i, upper := range r.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// WritePrometheus 集計結果をPrometheusのテキスト形式で書き込み
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	r.mu.Lock()
+	calls := snapshotHistograms(r.calls)
+	dynamoDBCalls := snapshotHistograms(r.dynamoDBCalls)
+	dynamoDBThrottles := make(map[callKey]uint64, len(r.dynamoDBThrottles))
+	for key, count := range r.dynamoDBThrottles {
+		dynamoDBThrottles[key] = count
+	}
+	dynamoDBErrors := make(map[callKey]uint64, len(r.dynamoDBErrors))
+	for key, count := range r.dynamoDBErrors {
+		dynamoDBErrors[key] = count
+	}
+	routes := make([]string, 0, len(r.panics))
+	panics := make(map[string]uint64, len(r.panics))
+	for route, count := range r.panics {
+		routes = append(routes, route)
+		panics[route] = count
+	}
+	r.mu.Unlock()
+	sort.Strings(routes)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# HELP %s Latency of repository calls by operation, table and error class.\n", repositoryCallMetric)
+	fmt.Fprintf(bw, "# TYPE %s histogram\n", repositoryCallMetric)
+	for _, key := range sortedKeys(calls) {
+		r.writeHistogram(bw, repositoryCallMetric, errorClassLabels(key), calls[key])
+	}
+	fmt.Fprintf(bw, "# HELP %s Requests that panicked and were recovered by route.\n", panicMetric)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", panicMetric)
+	for _, route := range routes {
+		fmt.Fprintf(bw, "%s{route=\"%s\"} %d\n", panicMetric, escapeLabel(route), panics[route])
+	}
+
+	if len(dynamoDBCalls) == 0 {
+		return bw.Flush()
+	}
+	fmt.Fprintf(bw, "# HELP %s Latency of DynamoDB API calls (each retry attempt) by operation and table.\n", dynamoDBCallMetric)
+	fmt.Fprintf(bw, "# TYPE %s histogram\n", dynamoDBCallMetric)
+	for _, key := range sortedKeys(dynamoDBCalls) {
+		r.writeHistogram(bw, dynamoDBCallMetric, tableLabels(key), dynamoDBCalls[key])
+	}
+	fmt.Fprintf(bw, "# HELP %s DynamoDB API calls rejected by throttling, including those that succeeded on retry.\n", dynamoDBThrottleMetric)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", dynamoDBThrottleMetric)
+	for _, key := range sortedKeys(dynamoDBThrottles) {
+		fmt.Fprintf(bw, "%s{%s} %d\n", dynamoDBThrottleMetric, tableLabels(key), dynamoDBThrottles[key])
+	}
+	fmt.Fprintf(bw, "# HELP %s DynamoDB API calls that failed for reasons other than throttling by error class.\n", dynamoDBErrorMetric)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", dynamoDBErrorMetric)
+	for _, key := range sortedKeys(dynamoDBErrors) {
+		fmt.Fprintf(bw, "%s{%s} %d\n", dynamoDBErrorMetric, errorClassLabels(key), dynamoDBErrors[key])
+	}
+	return bw.Flush()
+}
+
+// writeHistogram ヒストグラム1系列を書き込み
+func (r *Registry) writeHistogram(w io.Writer, name, labels string, h histogram) {
+	for i, upper := range r.buckets {
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(upper), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+	fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, h.count)
+}
+
+// snapshotHistograms ヒストグラムのコピー（mu を取得して呼ぶ）
+func snapshotHistograms(histograms map[callKey]*histogram) map[callKey]histogram {
+	snapshot := make(map[callKey]histogram, len(histograms))
+	for key, h := range histograms {
+		snapshot[key] = histogram{counts: append([]uint64(nil), h.counts...), count: h.count, sum: h.sum}
+	}
+	return snapshot
+}
+
+// sortedKeys 出力順（operation・table・error_class の順）に並べた集計の単位
+func sortedKeys[V any](values map[callKey]V) []callKey {
+	keys := make([]callKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].operation != keys[j].operation {
+			return keys[i].operation < keys[j].operation
+		}
+		if keys[i].table != keys[j].table {
+			return keys[i].table < keys[j].table
+		}
+		return keys[i].errorClass < keys[j].errorClass
+	})
+	return keys
+}
+
+// tableLabels operation・table のラベル
+func tableLabels(key callKey) string {
+	return fmt.Sprintf(`operation="%s",table="%s"`, escapeLabel(key.operation), escapeLabel(key.table))
+}
+
+// errorClassLabels operation・table・error_class のラベル
+func errorClassLabels(key callKey) string {
+	return fmt.Sprintf(`%s,error_class="%s"`, tableLabels(key), escapeLabel(key.errorClass))
+}
+
+// Handler 集計結果をPrometheusのテキスト形式で返すHTTPハンドラー
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := r.WritePrometheus(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// ErrorClass エラーを error_class ラベルの値に分類
+func ErrorClass(err error) string {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	var validationErr *errors.ValidationError
+	switch {
+	case stderrors.Is(err, repository.ErrCircuitOpen):
+		return ErrorClassCircuitOpen
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case stderrors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case repository.IsThrottled(err):
+		return ErrorClassThrottled
+	case stderrors.Is(err, errors.ErrNotFound), stderrors.Is(err, repository.ErrItemNotFound):
+		return ErrorClassNotFound
+	case stderrors.Is(err, repository.ErrResourceNotFound):
+		return ErrorClassResourceNotFound
+	case stderrors.Is(err, repository.ErrNetwork):
+		return ErrorClassNetwork
+	case stderrors.As(err, &validationErr):
+		return ErrorClassValidation
+	case stderrors.Is(err, errors.ErrDuplicateResource), stderrors.Is(err, errors.ErrVersionConflict):
+		return ErrorClassConflict
+	case stderrors.Is(err, repository.ErrConditionFailed):
+		return ErrorClassConditionFailed
+	case stderrors.Is(err, errors.ErrInsufficientPoints):
+		return ErrorClassInsufficientPoints
+	default:
+		return ErrorClassInternal
+	}
+}
+
+// escapeLabel Prometheusのラベル値をエスケープ
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatFloat Prometheusの数値表現に変換
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
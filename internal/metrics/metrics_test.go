@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/repository"
+)
+
+func TestRegistry_WritePrometheus(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveRepositoryCall("GetByID", "achievements", 20*time.Millisecond, nil)
+	registry.ObserveRepositoryCall("GetByID", "achievements", 300*time.Millisecond, nil)
+	registry.ObserveRepositoryCall("GetByID", "achievements", time.Millisecond, errors.ErrNotFound)
+
+	var out strings.Builder
+	if err := registry.WritePrometheus(&out); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	body := out.String()
+
+	expected := []string{
+		"# TYPE achievement_repository_call_duration_seconds histogram",
+		`achievement_repository_call_duration_seconds_bucket{operation="GetByID",table="achievements",error_class="none",le="0.01"} 0`,
+		`achievement_repository_call_duration_seconds_bucket{operation="GetByID",table="achievements",error_class="none",le="0.025"} 1`,
+		`achievement_repository_call_duration_seconds_bucket{operation="GetByID",table="achievements",error_class="none",le="0.5"} 2`,
+		`achievement_repository_call_duration_seconds_bucket{operation="GetByID",table="achievements",error_class="none",le="+Inf"} 2`,
+		`achievement_repository_call_duration_seconds_sum{operation="GetByID",table="achievements",error_class="none"} 0.32`,
+		`achievement_repository_call_duration_seconds_count{operation="GetByID",table="achievements",error_class="none"} 2`,
+		`achievement_repository_call_duration_seconds_count{operation="GetByID",table="achievements",error_class="not_found"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveRepositoryCall("List", `table"with\quotes`, time.Millisecond, nil)
+
+	rr := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type: %s", rr.Header().Get("Content-Type"))
+	}
+	// ラベル値はエスケープされる
+	if !strings.Contains(rr.Body.String(), `table="table\"with\\quotes"`) {
+		t.Errorf("Expected escaped label value, got:\n%s", rr.Body.String())
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "success", err: nil, expected: ErrorClassNone},
+		{name: "throttled", err: &errors.DatabaseError{Operation: "List", Cause: fmt.Errorf("failed to query: %w", &smithy.GenericAPIError{Code: "ProvisionedThroughputExceededException"})}, expected: ErrorClassThrottled},
+		{name: "timeout", err: &errors.DatabaseError{Operation: "List", Cause: context.DeadlineExceeded}, expected: ErrorClassTimeout},
+		{name: "canceled", err: context.Canceled, expected: ErrorClassCanceled},
+		{name: "circuit open", err: &errors.DatabaseError{Operation: "List", Cause: fmt.Errorf("failed to query: %w", repository.ErrCircuitOpen)}, expected: ErrorClassCircuitOpen},
+		{name: "not found", err: errors.ErrNotFound, expected: ErrorClassNotFound},
+		{name: "item not found", err: fmt.Errorf("%w in table achievements", repository.ErrItemNotFound), expected: ErrorClassNotFound},
+		{name: "missing table", err: &errors.DatabaseError{Operation: "List", Cause: fmt.Errorf("failed to query: %w", repository.ErrResourceNotFound)}, expected: ErrorClassResourceNotFound},
+		{name: "network", err: &errors.DatabaseError{Operation: "List", Cause: fmt.Errorf("failed to query: %w", repository.ErrNetwork)}, expected: ErrorClassNetwork},
+		{name: "validation", err: &errors.ValidationError{Field: "id", Message: "id is required"}, expected: ErrorClassValidation},
+		{name: "duplicate", err: errors.ErrDuplicateResource, expected: ErrorClassConflict},
+		{name: "version conflict", err: errors.ErrVersionConflict, expected: ErrorClassConflict},
+		{name: "insufficient points", err: errors.ErrInsufficientPoints, expected: ErrorClassInsufficientPoints},
+		{name: "other", err: &errors.DatabaseError{Operation: "List", Cause: stderrors.New("boom")}, expected: ErrorClassInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorClass(tt.err); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRegistry_ObserveDynamoDBCall(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveDynamoDBCall("GetItem", "achievements", 20*time.Millisecond, nil)
+	registry.ObserveDynamoDBCall("GetItem", "achievements", 5*time.Millisecond, fmt.Errorf("get: %w", repository.ErrThrottled))
+	registry.ObserveDynamoDBCall("PutItem", "achievements", 5*time.Millisecond, fmt.Errorf("put: %w", repository.ErrConditionFailed))
+	registry.ObserveDynamoDBCall("Query", "point-ledger", 5*time.Millisecond, fmt.Errorf("query: %w", repository.ErrNetwork))
+
+	var out strings.Builder
+	if err := registry.WritePrometheus(&out); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	body := out.String()
+
+	expected := []string{
+		"# TYPE achievement_dynamodb_call_duration_seconds histogram",
+		`achievement_dynamodb_call_duration_seconds_bucket{operation="GetItem",table="achievements",le="0.005"} 1`,
+		`achievement_dynamodb_call_duration_seconds_count{operation="GetItem",table="achievements"} 2`,
+		`achievement_dynamodb_call_duration_seconds_count{operation="PutItem",table="achievements"} 1`,
+		"# TYPE achievement_dynamodb_throttles_total counter",
+		`achievement_dynamodb_throttles_total{operation="GetItem",table="achievements"} 1`,
+		"# TYPE achievement_dynamodb_errors_total counter",
+		`achievement_dynamodb_errors_total{operation="Query",table="point-ledger",error_class="network"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, body)
+		}
+	}
+	// 条件付き書き込みの失敗はエラーとして数えない
+	if strings.Contains(body, `achievement_dynamodb_errors_total{operation="PutItem"`) {
+		t.Errorf("Expected condition failures not to be counted as errors, got:\n%s", body)
+	}
+}
+
+func TestRegistry_ObservePanic(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObservePanic("/api/achievements/:id")
+	registry.ObservePanic("/api/achievements/:id")
+	registry.ObservePanic("")
+
+	var out strings.Builder
+	if err := registry.WritePrometheus(&out); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	body := out.String()
+
+	expected := []string{
+		"# TYPE achievement_http_panics_total counter",
+		`achievement_http_panics_total{route=""} 1`,
+		`achievement_http_panics_total{route="/api/achievements/:id"} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, body)
+		}
+	}
+}
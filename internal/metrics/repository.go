@@ -0,0 +1,664 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// AchievementRepository 呼び出しごとにレイテンシとエラーの種類を記録する達成目録リポジトリ
+type AchievementRepository struct {
+	next     repository.AchievementRepository
+	registry *Registry
+	table    string
+}
+
+// NewAchievementRepository 達成目録リポジトリにメトリクスの記録を追加
+func NewAchievementRepository(next repository.AchievementRepository, registry *Registry, table string) repository.AchievementRepository {
+	return &AchievementRepository{next: next, registry: registry, table: table}
+}
+
+// Create 達成目録を作成
+func (r *AchievementRepository) Create(ctx context.Context, achievement *models.Achievement) (err error) {
+	defer r.registry.track("Create", r.table, time.Now(), &err)
+	return r.next.Create(ctx, achievement)
+}
+
+// CreateWithPoints 達成目録の作成・ポイント加算・台帳への記録を実行
+func (r *AchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) (err error) {
+	defer r.registry.track("CreateWithPoints", r.table, time.Now(), &err)
+	return r.next.CreateWithPoints(ctx, achievement)
+}
+
+// Update 達成目録を更新
+func (r *AchievementRepository) Update(ctx context.Context, achievement *models.Achievement) (err error) {
+	defer r.registry.track("Update", r.table, time.Now(), &err)
+	return r.next.Update(ctx, achievement)
+}
+
+// UpdateWithPoints 達成目録を更新し、ポイントの差分を反映
+func (r *AchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) (err error) {
+	defer r.registry.track("UpdateWithPoints", r.table, time.Now(), &err)
+	return r.next.UpdateWithPoints(ctx, achievement)
+}
+
+// GetByID IDで達成目録を取得
+func (r *AchievementRepository) GetByID(ctx context.Context, id string) (_ *models.Achievement, err error) {
+	defer r.registry.track("GetByID", r.table, time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべての達成目録を取得
+func (r *AchievementRepository) List(ctx context.Context) (_ []*models.Achievement, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイントのみを取得
+func (r *AchievementRepository) ListSummaries(ctx context.Context) (_ []*models.Achievement, err error) {
+	defer r.registry.track("ListSummaries", r.table, time.Now(), &err)
+	return r.next.ListSummaries(ctx)
+}
+
+// Count 達成目録の件数を取得
+func (r *AchievementRepository) Count(ctx context.Context) (_ int, err error) {
+	defer r.registry.track("Count", r.table, time.Now(), &err)
+	return r.next.Count(ctx)
+}
+
+// Delete 達成目録を削除
+func (r *AchievementRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.registry.track("Delete", r.table, time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+// DeleteWithPoints 達成目録の削除・ポイント減算・台帳への記録を実行
+func (r *AchievementRepository) DeleteWithPoints(ctx context.Context, id string) (err error) {
+	defer r.registry.track("DeleteWithPoints", r.table, time.Now(), &err)
+	return r.next.DeleteWithPoints(ctx, id)
+}
+
+// DeleteMany 複数の達成目録をまとめて削除
+func (r *AchievementRepository) DeleteMany(ctx context.Context, ids []string) (err error) {
+	defer r.registry.track("DeleteMany", r.table, time.Now(), &err)
+	return r.next.DeleteMany(ctx, ids)
+}
+
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存
+func (r *AchievementRepository) SetAttachment(ctx context.Context, id, key string) (err error) {
+	defer r.registry.track("SetAttachment", r.table, time.Now(), &err)
+	return r.next.SetAttachment(ctx, id, key)
+}
+
+// StartTimer 達成目録のタイマーを開始
+func (r *AchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) (err error) {
+	defer r.registry.track("StartTimer", r.table, time.Now(), &err)
+	return r.next.StartTimer(ctx, id, at)
+}
+
+// Complete 達成記録を作成し、ポイントを付与
+func (r *AchievementRepository) Complete(ctx context.Context, completion *models.Completion) (err error) {
+	defer r.registry.track("Complete", r.table, time.Now(), &err)
+	return r.next.Complete(ctx, completion)
+}
+
+// ListCompletions 達成目録の達成記録を取得
+func (r *AchievementRepository) ListCompletions(ctx context.Context, achievementID string) (_ []*models.Completion, err error) {
+	defer r.registry.track("ListCompletions", r.table, time.Now(), &err)
+	return r.next.ListCompletions(ctx, achievementID)
+}
+
+// RewardRepository 呼び出しごとにレイテンシとエラーの種類を記録する報酬リポジトリ
+type RewardRepository struct {
+	next     repository.RewardRepository
+	registry *Registry
+	table    string
+}
+
+// NewRewardRepository 報酬リポジトリにメトリクスの記録を追加
+func NewRewardRepository(next repository.RewardRepository, registry *Registry, table string) repository.RewardRepository {
+	return &RewardRepository{next: next, registry: registry, table: table}
+}
+
+// Create 報酬を作成
+func (r *RewardRepository) Create(ctx context.Context, reward *models.Reward) (err error) {
+	defer r.registry.track("Create", r.table, time.Now(), &err)
+	return r.next.Create(ctx, reward)
+}
+
+// Update 報酬を更新
+func (r *RewardRepository) Update(ctx context.Context, reward *models.Reward) (err error) {
+	defer r.registry.track("Update", r.table, time.Now(), &err)
+	return r.next.Update(ctx, reward)
+}
+
+// GetByID IDで報酬を取得
+func (r *RewardRepository) GetByID(ctx context.Context, id string) (_ *models.Reward, err error) {
+	defer r.registry.track("GetByID", r.table, time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+// GetByIDs 複数のIDの報酬をまとめて取得
+func (r *RewardRepository) GetByIDs(ctx context.Context, ids []string) (_ []*models.Reward, err error) {
+	defer r.registry.track("GetByIDs", r.table, time.Now(), &err)
+	return r.next.GetByIDs(ctx, ids)
+}
+
+// List すべての報酬を取得
+func (r *RewardRepository) List(ctx context.Context) (_ []*models.Reward, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// Count 報酬の件数を取得
+func (r *RewardRepository) Count(ctx context.Context) (_ int, err error) {
+	defer r.registry.track("Count", r.table, time.Now(), &err)
+	return r.next.Count(ctx)
+}
+
+// Delete 報酬を削除
+func (r *RewardRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.registry.track("Delete", r.table, time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+// PointRepository 呼び出しごとにレイテンシとエラーの種類を記録するポイントリポジトリ
+// 複数のテーブルに書き込む操作は、主に更新するテーブル（残高の増減は current_points、報酬獲得は reward_history）として記録する
+type PointRepository struct {
+	next     repository.PointRepository
+	registry *Registry
+	tables   config.TableConfig
+}
+
+// NewPointRepository ポイントリポジトリにメトリクスの記録を追加
+func NewPointRepository(next repository.PointRepository, registry *Registry, tables config.TableConfig) repository.PointRepository {
+	return &PointRepository{next: next, registry: registry, tables: tables}
+}
+
+// GetCurrentPoints 現在のポイントを取得
+func (r *PointRepository) GetCurrentPoints(ctx context.Context) (_ *models.CurrentPoints, err error) {
+	defer r.registry.track("GetCurrentPoints", r.tables.CurrentPoints, time.Now(), &err)
+	return r.next.GetCurrentPoints(ctx)
+}
+
+// GetCurrentPointsConsistent 直前の書き込みを反映した現在のポイントを取得
+func (r *PointRepository) GetCurrentPointsConsistent(ctx context.Context) (_ *models.CurrentPoints, err error) {
+	defer r.registry.track("GetCurrentPointsConsistent", r.tables.CurrentPoints, time.Now(), &err)
+	return r.next.GetCurrentPointsConsistent(ctx)
+}
+
+// UpdateCurrentPoints 現在のポイントを修正
+func (r *PointRepository) UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) (err error) {
+	defer r.registry.track("UpdateCurrentPoints", r.tables.CurrentPoints, time.Now(), &err)
+	return r.next.UpdateCurrentPoints(ctx, points)
+}
+
+// CreateRewardHistory 報酬獲得履歴を作成
+func (r *PointRepository) CreateRewardHistory(ctx context.Context, history *models.RewardHistory) (err error) {
+	defer r.registry.track("CreateRewardHistory", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.CreateRewardHistory(ctx, history)
+}
+
+// GetRewardHistory 報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistory(ctx context.Context) (_ []*models.RewardHistory, err error) {
+	defer r.registry.track("GetRewardHistory", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.GetRewardHistory(ctx)
+}
+
+// GetRewardHistoryBetween 獲得日時の範囲を指定して報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) (_ []*models.RewardHistory, err error) {
+	defer r.registry.track("GetRewardHistoryBetween", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.GetRewardHistoryBetween(ctx, from, to)
+}
+
+// CountRewardHistory 報酬獲得履歴の件数を取得
+func (r *PointRepository) CountRewardHistory(ctx context.Context) (_ int, err error) {
+	defer r.registry.track("CountRewardHistory", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.CountRewardHistory(ctx)
+}
+
+// RedeemPoints 報酬獲得のポイント減算・台帳への記録・履歴記録を実行
+func (r *PointRepository) RedeemPoints(ctx context.Context, history *models.RewardHistory) (err error) {
+	defer r.registry.track("RedeemPoints", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.RedeemPoints(ctx, history)
+}
+
+// GetRewardHistoryByID IDで報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryByID(ctx context.Context, id string) (_ *models.RewardHistory, err error) {
+	defer r.registry.track("GetRewardHistoryByID", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.GetRewardHistoryByID(ctx, id)
+}
+
+// RefundRedemption 報酬獲得の取り消し・ポイントの返還・台帳への記録を実行
+func (r *PointRepository) RefundRedemption(ctx context.Context, history *models.RewardHistory) (err error) {
+	defer r.registry.track("RefundRedemption", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.RefundRedemption(ctx, history)
+}
+
+// SetRedemptionAttachment 報酬獲得履歴に添付ファイルのオブジェクトキーを保存
+func (r *PointRepository) SetRedemptionAttachment(ctx context.Context, id, key string) (err error) {
+	defer r.registry.track("SetRedemptionAttachment", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.SetRedemptionAttachment(ctx, id, key)
+}
+
+// AnnotateRedemption 報酬獲得履歴の注記とタグを置き換える
+func (r *PointRepository) AnnotateRedemption(ctx context.Context, id, note string, tags []string) (err error) {
+	defer r.registry.track("AnnotateRedemption", r.tables.RewardHistory, time.Now(), &err)
+	return r.next.AnnotateRedemption(ctx, id, note, tags)
+}
+
+// GetLedger ポイント台帳を取得
+func (r *PointRepository) GetLedger(ctx context.Context) (_ []*models.PointLedgerEntry, err error) {
+	defer r.registry.track("GetLedger", r.tables.PointLedger, time.Now(), &err)
+	return r.next.GetLedger(ctx)
+}
+
+// GetLedgerBetween 記録日時の範囲を指定してポイント台帳を取得
+func (r *PointRepository) GetLedgerBetween(ctx context.Context, from, to time.Time) (_ []*models.PointLedgerEntry, err error) {
+	defer r.registry.track("GetLedgerBetween", r.tables.PointLedger, time.Now(), &err)
+	return r.next.GetLedgerBetween(ctx, from, to)
+}
+
+// AddPoints ポイントを加算
+func (r *PointRepository) AddPoints(ctx context.Context, points int) (err error) {
+	defer r.registry.track("AddPoints", r.tables.CurrentPoints, time.Now(), &err)
+	return r.next.AddPoints(ctx, points)
+}
+
+// SubtractPoints ポイントを減算
+func (r *PointRepository) SubtractPoints(ctx context.Context, points int) (err error) {
+	defer r.registry.track("SubtractPoints", r.tables.CurrentPoints, time.Now(), &err)
+	return r.next.SubtractPoints(ctx, points)
+}
+
+// BadgeRepository 呼び出しごとにレイテンシとエラーの種類を記録するバッジリポジトリ
+type BadgeRepository struct {
+	next     repository.BadgeRepository
+	registry *Registry
+	table    string
+}
+
+// NewBadgeRepository バッジリポジトリにメトリクスの記録を追加
+func NewBadgeRepository(next repository.BadgeRepository, registry *Registry, table string) repository.BadgeRepository {
+	return &BadgeRepository{next: next, registry: registry, table: table}
+}
+
+// Award バッジの獲得を記録
+func (r *BadgeRepository) Award(ctx context.Context, badge *models.Badge) (err error) {
+	defer r.registry.track("Award", r.table, time.Now(), &err)
+	return r.next.Award(ctx, badge)
+}
+
+// List 獲得したバッジを取得
+func (r *BadgeRepository) List(ctx context.Context) (_ []*models.Badge, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// GoalRepository 呼び出しごとにレイテンシとエラーの種類を記録する目標リポジトリ
+type GoalRepository struct {
+	next     repository.GoalRepository
+	registry *Registry
+	table    string
+}
+
+// NewGoalRepository 目標リポジトリにメトリクスの記録を追加
+func NewGoalRepository(next repository.GoalRepository, registry *Registry, table string) repository.GoalRepository {
+	return &GoalRepository{next: next, registry: registry, table: table}
+}
+
+// Create 目標を作成
+func (r *GoalRepository) Create(ctx context.Context, goal *models.Goal) (err error) {
+	defer r.registry.track("Create", r.table, time.Now(), &err)
+	return r.next.Create(ctx, goal)
+}
+
+// Update 目標を更新
+func (r *GoalRepository) Update(ctx context.Context, goal *models.Goal) (err error) {
+	defer r.registry.track("Update", r.table, time.Now(), &err)
+	return r.next.Update(ctx, goal)
+}
+
+// GetByID IDで目標を取得
+func (r *GoalRepository) GetByID(ctx context.Context, id string) (_ *models.Goal, err error) {
+	defer r.registry.track("GetByID", r.table, time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべての目標を取得
+func (r *GoalRepository) List(ctx context.Context) (_ []*models.Goal, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// Delete 目標を削除
+func (r *GoalRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.registry.track("Delete", r.table, time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+// MarkReached 目標の達成日時を記録
+func (r *GoalRepository) MarkReached(ctx context.Context, id string, reachedAt time.Time) (err error) {
+	defer r.registry.track("MarkReached", r.table, time.Now(), &err)
+	return r.next.MarkReached(ctx, id, reachedAt)
+}
+
+// track 呼び出しの終了時に開始からの経過時間と結果を記録（defer で使用する）
+func (r *Registry) track(operation, table string, start time.Time, err *error) {
+	r.ObserveRepositoryCall(operation, table, time.Since(start), *err)
+}
+
+// FavoriteRepository 呼び出しごとにレイテンシとエラーの種類を記録するお気に入りリポジトリ
+type FavoriteRepository struct {
+	next     repository.FavoriteRepository
+	registry *Registry
+	table    string
+}
+
+// NewFavoriteRepository お気に入りリポジトリにメトリクスの記録を追加
+func NewFavoriteRepository(next repository.FavoriteRepository, registry *Registry, table string) repository.FavoriteRepository {
+	return &FavoriteRepository{next: next, registry: registry, table: table}
+}
+
+// Add 報酬をお気に入りに登録
+func (r *FavoriteRepository) Add(ctx context.Context, favorite *models.Favorite) (err error) {
+	defer r.registry.track("Add", r.table, time.Now(), &err)
+	return r.next.Add(ctx, favorite)
+}
+
+// Remove 報酬をお気に入りから外す
+func (r *FavoriteRepository) Remove(ctx context.Context, rewardID string) (err error) {
+	defer r.registry.track("Remove", r.table, time.Now(), &err)
+	return r.next.Remove(ctx, rewardID)
+}
+
+// List お気に入りを取得
+func (r *FavoriteRepository) List(ctx context.Context) (_ []*models.Favorite, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// WishlistRepository 呼び出しごとにレイテンシとエラーの種類を記録するほしいものリストリポジトリ
+type WishlistRepository struct {
+	next     repository.WishlistRepository
+	registry *Registry
+	table    string
+}
+
+// NewWishlistRepository ほしいものリストリポジトリにメトリクスの記録を追加
+func NewWishlistRepository(next repository.WishlistRepository, registry *Registry, table string) repository.WishlistRepository {
+	return &WishlistRepository{next: next, registry: registry, table: table}
+}
+
+// Add 報酬をほしいものリストに追加
+func (r *WishlistRepository) Add(ctx context.Context, item *models.WishlistItem) (err error) {
+	defer r.registry.track("Add", r.table, time.Now(), &err)
+	return r.next.Add(ctx, item)
+}
+
+// UpdatePriority ほしいものリストの報酬の優先度を変更
+func (r *WishlistRepository) UpdatePriority(ctx context.Context, rewardID string, priority int) (err error) {
+	defer r.registry.track("UpdatePriority", r.table, time.Now(), &err)
+	return r.next.UpdatePriority(ctx, rewardID, priority)
+}
+
+// Remove 報酬をほしいものリストから外す
+func (r *WishlistRepository) Remove(ctx context.Context, rewardID string) (err error) {
+	defer r.registry.track("Remove", r.table, time.Now(), &err)
+	return r.next.Remove(ctx, rewardID)
+}
+
+// List ほしいものリストを取得
+func (r *WishlistRepository) List(ctx context.Context) (_ []*models.WishlistItem, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// NoteRepository 呼び出しごとにレイテンシとエラーの種類を記録するメモリポジトリ
+type NoteRepository struct {
+	next     repository.NoteRepository
+	registry *Registry
+	table    string
+}
+
+// NewNoteRepository メモリポジトリにメトリクスの記録を追加
+func NewNoteRepository(next repository.NoteRepository, registry *Registry, table string) repository.NoteRepository {
+	return &NoteRepository{next: next, registry: registry, table: table}
+}
+
+// Create メモを作成
+func (r *NoteRepository) Create(ctx context.Context, note *models.Note) (err error) {
+	defer r.registry.track("Create", r.table, time.Now(), &err)
+	return r.next.Create(ctx, note)
+}
+
+// GetByID IDでメモを取得
+func (r *NoteRepository) GetByID(ctx context.Context, id string) (_ *models.Note, err error) {
+	defer r.registry.track("GetByID", r.table, time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+// ListByTarget 記録に付けたメモを取得
+func (r *NoteRepository) ListByTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) (_ []*models.Note, err error) {
+	defer r.registry.track("ListByTarget", r.table, time.Now(), &err)
+	return r.next.ListByTarget(ctx, targetType, targetID)
+}
+
+// Delete メモを削除
+func (r *NoteRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.registry.track("Delete", r.table, time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+// DriftRepository 呼び出しごとにレイテンシとエラーの種類を記録するポイントの差異のリポジトリ
+type DriftRepository struct {
+	next     repository.DriftRepository
+	registry *Registry
+	table    string
+}
+
+// NewDriftRepository ポイントの差異のリポジトリにメトリクスの記録を追加
+func NewDriftRepository(next repository.DriftRepository, registry *Registry, table string) repository.DriftRepository {
+	return &DriftRepository{next: next, registry: registry, table: table}
+}
+
+// Record 検出したポイントの差異を記録
+func (r *DriftRepository) Record(ctx context.Context, event *models.DriftEvent) (err error) {
+	defer r.registry.track("Record", r.table, time.Now(), &err)
+	return r.next.Record(ctx, event)
+}
+
+// List 記録したポイントの差異を取得
+func (r *DriftRepository) List(ctx context.Context) (_ []*models.DriftEvent, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// ReservationRepository 呼び出しごとにレイテンシとエラーの種類を記録するポイントの取り置きのリポジトリ
+type ReservationRepository struct {
+	next     repository.ReservationRepository
+	registry *Registry
+	table    string
+}
+
+// NewReservationRepository ポイントの取り置きのリポジトリにメトリクスの記録を追加
+func NewReservationRepository(next repository.ReservationRepository, registry *Registry, table string) repository.ReservationRepository {
+	return &ReservationRepository{next: next, registry: registry, table: table}
+}
+
+// Put 報酬のためにポイントを取り置く
+func (r *ReservationRepository) Put(ctx context.Context, reservation *models.Reservation) (err error) {
+	defer r.registry.track("Put", r.table, time.Now(), &err)
+	return r.next.Put(ctx, reservation)
+}
+
+// Remove 取り置きを解除
+func (r *ReservationRepository) Remove(ctx context.Context, rewardID string) (err error) {
+	defer r.registry.track("Remove", r.table, time.Now(), &err)
+	return r.next.Remove(ctx, rewardID)
+}
+
+// List 取り置きを取得
+func (r *ReservationRepository) List(ctx context.Context) (_ []*models.Reservation, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// QuestRepository 呼び出しごとにレイテンシとエラーの種類を記録するクエストリポジトリ
+type QuestRepository struct {
+	next     repository.QuestRepository
+	registry *Registry
+	table    string
+}
+
+// NewQuestRepository クエストリポジトリにメトリクスの記録を追加
+func NewQuestRepository(next repository.QuestRepository, registry *Registry, table string) repository.QuestRepository {
+	return &QuestRepository{next: next, registry: registry, table: table}
+}
+
+// Create クエストを作成
+func (r *QuestRepository) Create(ctx context.Context, quest *models.Quest) (err error) {
+	defer r.registry.track("Create", r.table, time.Now(), &err)
+	return r.next.Create(ctx, quest)
+}
+
+// GetByID IDでクエストを取得
+func (r *QuestRepository) GetByID(ctx context.Context, id string) (_ *models.Quest, err error) {
+	defer r.registry.track("GetByID", r.table, time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべてのクエストを取得
+func (r *QuestRepository) List(ctx context.Context) (_ []*models.Quest, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// Delete クエストを削除
+func (r *QuestRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.registry.track("Delete", r.table, time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+// Complete クエストの完了を記録してボーナスを付与
+func (r *QuestRepository) Complete(ctx context.Context, quest *models.Quest) (err error) {
+	defer r.registry.track("Complete", r.table, time.Now(), &err)
+	return r.next.Complete(ctx, quest)
+}
+
+// OperationRepository 呼び出しごとにレイテンシとエラーの種類を記録する操作履歴のリポジトリ
+type OperationRepository struct {
+	next     repository.OperationRepository
+	registry *Registry
+	table    string
+}
+
+// NewOperationRepository 操作履歴のリポジトリにメトリクスの記録を追加
+func NewOperationRepository(next repository.OperationRepository, registry *Registry, table string) repository.OperationRepository {
+	return &OperationRepository{next: next, registry: registry, table: table}
+}
+
+// Record 操作を記録
+func (r *OperationRepository) Record(ctx context.Context, operation *models.Operation) (err error) {
+	defer r.registry.track("Record", r.table, time.Now(), &err)
+	return r.next.Record(ctx, operation)
+}
+
+// List 操作履歴を取得
+func (r *OperationRepository) List(ctx context.Context) (_ []*models.Operation, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// SetUndone 操作を元に戻した日時を記録
+func (r *OperationRepository) SetUndone(ctx context.Context, id string, undoneAt *time.Time) (err error) {
+	defer r.registry.track("SetUndone", r.table, time.Now(), &err)
+	return r.next.SetUndone(ctx, id, undoneAt)
+}
+
+// Delete 操作を操作履歴から削除
+func (r *OperationRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.registry.track("Delete", r.table, time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+// AllowanceRepository 呼び出しごとにレイテンシとエラーの種類を記録するお小遣いのルールのリポジトリ
+type AllowanceRepository struct {
+	next     repository.AllowanceRepository
+	registry *Registry
+	table    string
+}
+
+// NewAllowanceRepository お小遣いのルールのリポジトリにメトリクスの記録を追加
+func NewAllowanceRepository(next repository.AllowanceRepository, registry *Registry, table string) repository.AllowanceRepository {
+	return &AllowanceRepository{next: next, registry: registry, table: table}
+}
+
+// Create お小遣いのルールを作成
+func (r *AllowanceRepository) Create(ctx context.Context, allowance *models.Allowance) (err error) {
+	defer r.registry.track("Create", r.table, time.Now(), &err)
+	return r.next.Create(ctx, allowance)
+}
+
+// GetByID IDでお小遣いのルールを取得
+func (r *AllowanceRepository) GetByID(ctx context.Context, id string) (_ *models.Allowance, err error) {
+	defer r.registry.track("GetByID", r.table, time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+// List すべてのお小遣いのルールを取得
+func (r *AllowanceRepository) List(ctx context.Context) (_ []*models.Allowance, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
+
+// Delete お小遣いのルールを削除
+func (r *AllowanceRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.registry.track("Delete", r.table, time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+// Grant お小遣いのポイントを付与
+func (r *AllowanceRepository) Grant(ctx context.Context, id string, points int, at time.Time) (err error) {
+	defer r.registry.track("Grant", r.table, time.Now(), &err)
+	return r.next.Grant(ctx, id, points, at)
+}
+
+// APIKeyRepository 呼び出しごとにレイテンシとエラーの種類を記録するAPIキーのリポジトリ
+type APIKeyRepository struct {
+	next     repository.APIKeyRepository
+	registry *Registry
+	table    string
+}
+
+// NewAPIKeyRepository APIキーのリポジトリにメトリクスの記録を追加
+func NewAPIKeyRepository(next repository.APIKeyRepository, registry *Registry, table string) repository.APIKeyRepository {
+	return &APIKeyRepository{next: next, registry: registry, table: table}
+}
+
+// Create APIキーを作成
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) (err error) {
+	defer r.registry.track("Create", r.table, time.Now(), &err)
+	return r.next.Create(ctx, key)
+}
+
+// Update APIキーを更新
+func (r *APIKeyRepository) Update(ctx context.Context, key *models.APIKey) (err error) {
+	defer r.registry.track("Update", r.table, time.Now(), &err)
+	return r.next.Update(ctx, key)
+}
+
+// GetByID IDでAPIキーを取得
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (_ *models.APIKey, err error) {
+	defer r.registry.track("GetByID", r.table, time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+// List APIキーを取得
+func (r *APIKeyRepository) List(ctx context.Context) (_ []*models.APIKey, err error) {
+	defer r.registry.track("List", r.table, time.Now(), &err)
+	return r.next.List(ctx)
+}
@@ -0,0 +1,316 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository/memory"
+)
+
+// callCount 記録された呼び出し回数
+func callCount(registry *Registry, operation, table, errorClass string) uint64 {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if h, ok := registry.calls[callKey{operation: operation, table: table, errorClass: errorClass}]; ok {
+		return h.count
+	}
+	return 0
+}
+
+func TestAchievementRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewAchievementRepository(memory.NewAchievementRepository(memory.NewStore()), registry, "test-achievements")
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, achievement.ID); err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "missing"); err == nil {
+		t.Fatal("Expected not found error")
+	}
+
+	if got := callCount(registry, "Create", "test-achievements", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Create, got %d", got)
+	}
+	if got := callCount(registry, "GetByID", "test-achievements", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful GetByID, got %d", got)
+	}
+	if got := callCount(registry, "GetByID", "test-achievements", ErrorClassNotFound); got != 1 {
+		t.Errorf("Expected 1 not found GetByID, got %d", got)
+	}
+}
+
+func TestRewardRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewRewardRepository(memory.NewRewardRepository(memory.NewStore()), registry, "test-rewards")
+
+	if err := repo.Create(ctx, &models.Reward{Title: ""}); err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if _, err := repo.List(ctx); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if got := callCount(registry, "Create", "test-rewards", ErrorClassValidation); got != 1 {
+		t.Errorf("Expected 1 invalid Create, got %d", got)
+	}
+	if got := callCount(registry, "List", "test-rewards", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful List, got %d", got)
+	}
+}
+
+func TestBadgeRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewBadgeRepository(memory.NewBadgeRepository(memory.NewStore()), registry, "test-badges")
+
+	badge := &models.Badge{ID: "first_achievement", Name: "First Step"}
+	if err := repo.Award(ctx, badge); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if err := repo.Award(ctx, badge); err == nil {
+		t.Fatal("Expected duplicate error")
+	}
+
+	if got := callCount(registry, "Award", "test-badges", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Award, got %d", got)
+	}
+	if got := callCount(registry, "Award", "test-badges", ErrorClassConflict); got != 1 {
+		t.Errorf("Expected 1 conflicting Award, got %d", got)
+	}
+}
+
+func TestGoalRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewGoalRepository(memory.NewGoalRepository(memory.NewStore()), registry, "test-goals")
+
+	goal := &models.Goal{Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 500}
+	if err := repo.Create(ctx, goal); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.MarkReached(ctx, goal.ID, time.Now()); err != nil {
+		t.Fatalf("MarkReached failed: %v", err)
+	}
+	if err := repo.MarkReached(ctx, goal.ID, time.Now()); err == nil {
+		t.Fatal("Expected conflict for a reached goal")
+	}
+
+	if got := callCount(registry, "Create", "test-goals", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Create, got %d", got)
+	}
+	if got := callCount(registry, "MarkReached", "test-goals", ErrorClassConflict); got != 1 {
+		t.Errorf("Expected 1 conflicting MarkReached, got %d", got)
+	}
+}
+
+func TestPointRepository_RecordsCallsByTable(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	tables := config.TableConfig{CurrentPoints: "test-current-points", RewardHistory: "test-reward-history", PointLedger: "test-point-ledger"}
+	repo := NewPointRepository(memory.NewPointRepository(memory.NewStore()), registry, tables)
+
+	if err := repo.AddPoints(ctx, 10); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	err := repo.RedeemPoints(ctx, &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 50})
+	if err == nil {
+		t.Fatal("Expected insufficient points error")
+	}
+	if _, err := repo.GetLedger(ctx); err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+
+	if got := callCount(registry, "AddPoints", "test-current-points", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful AddPoints, got %d", got)
+	}
+	if got := callCount(registry, "RedeemPoints", "test-reward-history", ErrorClassInsufficientPoints); got != 1 {
+		t.Errorf("Expected 1 RedeemPoints with insufficient points, got %d", got)
+	}
+	if got := callCount(registry, "GetLedger", "test-point-ledger", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful GetLedger, got %d", got)
+	}
+}
+
+func TestWishlistRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewWishlistRepository(memory.NewWishlistRepository(memory.NewStore()), registry, "test-wishlist")
+
+	if err := repo.Add(ctx, &models.WishlistItem{RewardID: "reward-1", Priority: 1}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := repo.UpdatePriority(ctx, "missing", 2); err == nil {
+		t.Fatal("Expected not found for a reward that is not on the wishlist")
+	}
+
+	if got := callCount(registry, "Add", "test-wishlist", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Add, got %d", got)
+	}
+	if got := callCount(registry, "UpdatePriority", "test-wishlist", ErrorClassNotFound); got != 1 {
+		t.Errorf("Expected 1 not found UpdatePriority, got %d", got)
+	}
+}
+
+func TestNoteRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewNoteRepository(memory.NewNoteRepository(memory.NewStore()), registry, "test-notes")
+
+	if err := repo.Create(ctx, &models.Note{TargetType: models.NoteTargetReward, TargetID: "reward-1", Body: "友達へのプレゼント"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Delete(ctx, "missing"); err == nil {
+		t.Fatal("Expected not found for a missing note")
+	}
+
+	if got := callCount(registry, "Create", "test-notes", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Create, got %d", got)
+	}
+	if got := callCount(registry, "Delete", "test-notes", ErrorClassNotFound); got != 1 {
+		t.Errorf("Expected 1 not found Delete, got %d", got)
+	}
+}
+
+func TestDriftRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewDriftRepository(memory.NewDriftRepository(memory.NewStore()), registry, "test-drift-events")
+
+	if err := repo.Record(ctx, &models.DriftEvent{Difference: 20}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := repo.Record(ctx, &models.DriftEvent{Difference: 20, Threshold: -1}); err == nil {
+		t.Fatal("Expected validation error for a negative threshold")
+	}
+
+	if got := callCount(registry, "Record", "test-drift-events", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Record, got %d", got)
+	}
+	if got := callCount(registry, "Record", "test-drift-events", ErrorClassValidation); got != 1 {
+		t.Errorf("Expected 1 invalid Record, got %d", got)
+	}
+}
+
+func TestReservationRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	store := memory.NewStore()
+	if err := memory.NewPointRepository(store).UpdateCurrentPoints(ctx, &models.CurrentPoints{Point: 1000}); err != nil {
+		t.Fatalf("UpdateCurrentPoints failed: %v", err)
+	}
+	repo := NewReservationRepository(memory.NewReservationRepository(store), registry, "test-reservations")
+
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "switch", Points: 300}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := repo.Remove(ctx, "coffee"); err == nil {
+		t.Fatal("Expected not found error for a reward without a reservation")
+	}
+
+	if got := callCount(registry, "Put", "test-reservations", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Put, got %d", got)
+	}
+	if got := callCount(registry, "Remove", "test-reservations", ErrorClassNotFound); got != 1 {
+		t.Errorf("Expected 1 not found Remove, got %d", got)
+	}
+}
+
+func TestQuestRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewQuestRepository(memory.NewQuestRepository(memory.NewStore()), registry, "test-quests")
+
+	quest := &models.Quest{Title: "朝活", Steps: []string{"run"}}
+	if err := repo.Create(ctx, quest); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Complete(ctx, quest); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if err := repo.Complete(ctx, quest); err == nil {
+		t.Fatal("Expected conflict error for a completed quest")
+	}
+
+	if got := callCount(registry, "Create", "test-quests", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Create, got %d", got)
+	}
+	if got := callCount(registry, "Complete", "test-quests", ErrorClassConflict); got != 1 {
+		t.Errorf("Expected 1 conflicting Complete, got %d", got)
+	}
+}
+
+func TestOperationRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewOperationRepository(memory.NewOperationRepository(memory.NewStore()), registry, "test-operations")
+
+	operation := &models.Operation{Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-123"}
+	if err := repo.Record(ctx, operation); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := repo.SetUndone(ctx, "missing", nil); err == nil {
+		t.Fatal("Expected not found error for a missing operation")
+	}
+
+	if got := callCount(registry, "Record", "test-operations", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Record, got %d", got)
+	}
+	if got := callCount(registry, "SetUndone", "test-operations", ErrorClassNotFound); got != 1 {
+		t.Errorf("Expected 1 not found SetUndone, got %d", got)
+	}
+}
+
+func TestAllowanceRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewAllowanceRepository(memory.NewAllowanceRepository(memory.NewStore()), registry, "test-allowances")
+
+	allowance := &models.Allowance{Title: "お小遣い", Points: 50, Schedule: "0 9 * * 1"}
+	if err := repo.Create(ctx, allowance); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	at := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	if err := repo.Grant(ctx, allowance.ID, allowance.Points, at); err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+	if err := repo.Grant(ctx, allowance.ID, allowance.Points, at); err == nil {
+		t.Fatal("Expected conflict error for an allowance already granted at the same minute")
+	}
+
+	if got := callCount(registry, "Create", "test-allowances", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Create, got %d", got)
+	}
+	if got := callCount(registry, "Grant", "test-allowances", ErrorClassConflict); got != 1 {
+		t.Errorf("Expected 1 conflicting Grant, got %d", got)
+	}
+}
+
+func TestAPIKeyRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+	repo := NewAPIKeyRepository(memory.NewAPIKeyRepository(memory.NewStore()), registry, "test-api-keys")
+
+	if err := repo.Create(ctx, &models.APIKey{Label: "ダッシュボード", Scope: models.APIKeyScopeRead, Hash: "5e884898"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "missing"); err == nil {
+		t.Fatal("Expected not found error for a missing key")
+	}
+
+	if got := callCount(registry, "Create", "test-api-keys", ErrorClassNone); got != 1 {
+		t.Errorf("Expected 1 successful Create, got %d", got)
+	}
+	if got := callCount(registry, "GetByID", "test-api-keys", ErrorClassNotFound); got != 1 {
+		t.Errorf("Expected 1 not found GetByID, got %d", got)
+	}
+}
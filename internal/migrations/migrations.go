@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/repository"
+)
+
+// Migration スキーマ変更1件（GSIの追加、属性の付与、TTLの有効化など）
+//
+// Up は途中で失敗して再実行されても問題ないように冪等に実装する。
+type Migration struct {
+	ID          string
+	Description string
+	Up          func(ctx context.Context, env Env) error
+}
+
+// Env マイグレーションから利用できる依存関係
+type Env struct {
+	Config *config.Config
+	Repo   repository.Repository
+	Tables *repository.TableManager
+}
+
+// Status マイグレーションの適用状況
+type Status struct {
+	Migration Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator マイグレーションの適用と状況確認を行う
+type Migrator struct {
+	env        Env
+	store      *metadataStore
+	migrations []Migration
+}
+
+// NewMigrator マイグレーターを作成
+func NewMigrator(env Env, migrations []Migration) *Migrator {
+	return &Migrator{
+		env:        env,
+		store:      newMetadataStore(env.Repo, env.Config.Tables.CurrentPoints),
+		migrations: migrations,
+	}
+}
+
+// Status すべてのマイグレーションの適用状況を定義順に取得
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.store.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied.appliedAt(migration.ID)
+		statuses = append(statuses, Status{
+			Migration: migration,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+
+	return statuses, nil
+}
+
+// Up 未適用のマイグレーションを定義順に適用し、適用したマイグレーションを返す
+//
+// 失敗した場合はそれ以降のマイグレーションを適用せず、それまでに適用したものを返す。
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	record, err := m.store.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, migration := range m.migrations {
+		if _, ok := record.appliedAt(migration.ID); ok {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.env); err != nil {
+			return applied, fmt.Errorf("migration %s failed: %w", migration.ID, err)
+		}
+
+		// 1件ごとに記録して、途中で失敗しても適用済みのものを再実行しないようにする
+		record.Applied = append(record.Applied, AppliedMigration{ID: migration.ID, AppliedAt: time.Now()})
+		if err := m.store.save(ctx, record); err != nil {
+			return applied, fmt.Errorf("failed to record migration %s: %w", migration.ID, err)
+		}
+
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
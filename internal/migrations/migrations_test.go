@@ -0,0 +1,171 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/repository"
+)
+
+// fakeRepository 記録用アイテムをメモリ上に保存するリポジトリ
+type fakeRepository struct {
+	repository.Repository
+	items map[string]map[string]types.AttributeValue
+	puts  int
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{items: map[string]map[string]types.AttributeValue{}}
+}
+
+func (r *fakeRepository) GetItem(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error {
+	item, ok := r.items[fmt.Sprintf("%s/%v", tableName, key["id"])]
+	if !ok {
+		return fmt.Errorf("%w in table %s", repository.ErrItemNotFound, tableName)
+	}
+	return attributevalue.UnmarshalMap(item, result)
+}
+
+func (r *fakeRepository) PutItem(ctx context.Context, tableName string, item interface{}) error {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+	var id string
+	if err := attributevalue.Unmarshal(av["id"], &id); err != nil {
+		return err
+	}
+	r.items[tableName+"/"+id] = av
+	r.puts++
+	return nil
+}
+
+func testEnv(repo repository.Repository) Env {
+	return Env{
+		Config: &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}},
+		Repo:   repo,
+	}
+}
+
+// recordingMigration 実行された順序を記録するマイグレーション
+func recordingMigration(id string, ran *[]string, err error) Migration {
+	return Migration{
+		ID:          id,
+		Description: "test migration " + id,
+		Up: func(ctx context.Context, env Env) error {
+			*ran = append(*ran, id)
+			return err
+		},
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	repo := newFakeRepository()
+	var ran []string
+	migrator := NewMigrator(testEnv(repo), []Migration{
+		recordingMigration("0001", &ran, nil),
+		recordingMigration("0002", &ran, nil),
+	})
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if len(applied) != 2 || len(ran) != 2 || ran[0] != "0001" || ran[1] != "0002" {
+		t.Errorf("Expected migrations to run in order, got %v", ran)
+	}
+
+	// 2回目は適用済みのため何も実行されないことを確認
+	applied, err = migrator.Up(context.Background())
+	if err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if len(applied) != 0 || len(ran) != 2 {
+		t.Errorf("Expected no migrations to run again, got %v", ran)
+	}
+}
+
+func TestMigrator_Up_StopsOnFailure(t *testing.T) {
+	repo := newFakeRepository()
+	var ran []string
+	failure := errors.New("index creation failed")
+	migrations := []Migration{
+		recordingMigration("0001", &ran, nil),
+		recordingMigration("0002", &ran, failure),
+		recordingMigration("0003", &ran, nil),
+	}
+
+	applied, err := NewMigrator(testEnv(repo), migrations).Up(context.Background())
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected migration failure, got %v", err)
+	}
+	if len(applied) != 1 || applied[0].ID != "0001" {
+		t.Errorf("Expected only 0001 to be applied, got %v", applied)
+	}
+	if len(ran) != 2 {
+		t.Errorf("Expected 0003 not to run, got %v", ran)
+	}
+
+	// 失敗したマイグレーションから再開されることを確認
+	ran = nil
+	migrations[1] = recordingMigration("0002", &ran, nil)
+	if _, err := NewMigrator(testEnv(repo), migrations).Up(context.Background()); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if len(ran) != 2 || ran[0] != "0002" || ran[1] != "0003" {
+		t.Errorf("Expected 0002 and 0003 to run, got %v", ran)
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	repo := newFakeRepository()
+	var ran []string
+	migrator := NewMigrator(testEnv(repo), []Migration{recordingMigration("0001", &ran, nil)})
+
+	statuses, err := migrator.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Applied {
+		t.Errorf("Expected 0001 to be pending, got %+v", statuses)
+	}
+
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+
+	// 別のマイグレーターからも適用済みとして見えることを確認
+	migrator = NewMigrator(testEnv(repo), []Migration{
+		recordingMigration("0001", &ran, nil),
+		recordingMigration("0002", &ran, nil),
+	})
+	statuses, err = migrator.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !statuses[0].Applied || statuses[0].AppliedAt.IsZero() {
+		t.Errorf("Expected 0001 to be applied, got %+v", statuses[0])
+	}
+	if statuses[1].Applied {
+		t.Errorf("Expected 0002 to be pending, got %+v", statuses[1])
+	}
+}
+
+func TestAll_UniqueIDs(t *testing.T) {
+	seen := map[string]bool{}
+	for _, migration := range All() {
+		if migration.ID == "" || migration.Up == nil {
+			t.Errorf("Migration %q is incomplete", migration.ID)
+		}
+		if seen[migration.ID] {
+			t.Errorf("Duplicate migration ID %q", migration.ID)
+		}
+		seen[migration.ID] = true
+	}
+}
@@ -0,0 +1,229 @@
+package migrations
+
+import (
+	"context"
+
+	"achievement-management/internal/repository"
+)
+
+// All アプリケーションのマイグレーション一覧（適用順）
+//
+// 新しいマイグレーションは末尾に追加し、適用済みのものは変更・削除しない。
+func All() []Migration {
+	return []Migration{
+		{
+			ID:          "0001_list_indexes",
+			Description: "Add the entity_type/created_at and entity_type/redeemed_at global secondary indexes",
+			Up: func(ctx context.Context, env Env) error {
+				_, err := env.Tables.EnsureIndexes(ctx, repository.TableDefinitions(env.Config))
+				return err
+			},
+		},
+		{
+			ID:          "0002_backfill_entity_type",
+			Description: "Add the entity_type attribute to items created before list indexes existed",
+			Up: func(ctx context.Context, env Env) error {
+				_, err := repository.BackfillEntityTypes(ctx, env.Repo, env.Config)
+				return err
+			},
+		},
+		{
+			ID:          "0003_enable_ttl",
+			Description: "Enable DynamoDB TTL on the configured expiry attribute so expired items are purged",
+			Up: func(ctx context.Context, env Env) error {
+				_, err := env.Tables.EnsureTTL(ctx, repository.TableDefinitions(env.Config))
+				return err
+			},
+		},
+		{
+			// streams.enabled が無効な場合は何もしない（後から有効にしたテーブルは streams consume の起動時に有効化される）
+			ID:          "0004_enable_streams",
+			Description: "Enable DynamoDB Streams on the tables when streams.enabled is set, for change event delivery",
+			Up: func(ctx context.Context, env Env) error {
+				_, err := env.Tables.EnsureStreams(ctx, repository.TableDefinitions(env.Config))
+				return err
+			},
+		},
+		{
+			// 台帳の導入前の残高には対応するエントリがないため、既存の残高は移行しない
+			ID:          "0005_point_ledger_table",
+			Description: "Create the append-only point_ledger table that records every point change",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "point_ledger" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// 旧インデックス（entity_type-redeemed_at-index）は読み取りに使わなくなるため、不要であれば手動で削除する
+			ID:          "0006_redeemed_at_key_index",
+			Description: "Add the sortable redeemed_at_key attribute to reward history and index it for date range queries",
+			Up: func(ctx context.Context, env Env) error {
+				if _, err := repository.BackfillRedeemedAtKeys(ctx, env.Repo, env.Config); err != nil {
+					return err
+				}
+				_, err := env.Tables.EnsureIndexes(ctx, repository.TableDefinitions(env.Config))
+				return err
+			},
+		},
+		{
+			// 導入前の達成目録は作成時にポイントを付与済みのため、達成記録は作成しない
+			ID:          "0007_completions_table",
+			Description: "Create the completions table that records each time an achievement is completed",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "completions" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// 導入前の達成・報酬獲得で条件を満たすバッジは、次の評価で獲得する
+			ID:          "0008_badges_table",
+			Description: "Create the badges table that stores earned badges",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "badges" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0009_goals_table",
+			Description: "Create the goals table that stores point and achievement count goals",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "goals" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0010_favorites_and_wishlist_tables",
+			Description: "Create the favorites and wishlist tables that store the rewards saved by the user",
+			Up: func(ctx context.Context, env Env) error {
+				var definitions []repository.TableDefinition
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "favorites" || def.Key == "wishlist" {
+						definitions = append(definitions, def)
+					}
+				}
+				_, err := env.Tables.CreateTables(ctx, definitions)
+				return err
+			},
+		},
+		{
+			ID:          "0011_notes_table",
+			Description: "Create the notes table that stores notes attached to achievements, rewards and redemptions",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "notes" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0012_drift_events_table",
+			Description: "Create the drift_events table that records point drift found by the consistency checker",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "drift_events" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0013_reservations_table",
+			Description: "Create the reservations table that stores the points set aside for rewards",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "reservations" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0014_quests_table",
+			Description: "Create the quests table that stores ordered chains of achievements",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "quests" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0015_operations_table",
+			Description: "Create the operations table that journals undoable creates, updates and deletes",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "operations" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0016_allowances_table",
+			Description: "Create the allowances table that stores scheduled point grants",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "allowances" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0017_api_keys_table",
+			Description: "Create the api_keys table that stores hashed API keys",
+			Up: func(ctx context.Context, env Env) error {
+				for _, def := range repository.TableDefinitions(env.Config) {
+					if def.Key == "api_keys" {
+						_, err := env.Tables.CreateTables(ctx, []repository.TableDefinition{def})
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// 取り置き・解除と同時に実行すると合計がずれるため、書き込みを止めてから適用する
+			ID:          "0018_reserved_points",
+			Description: "Store the total of reserved points on the current points item so redemptions can check it atomically",
+			Up: func(ctx context.Context, env Env) error {
+				_, err := repository.BackfillReservedPoints(ctx, env.Repo, env.Config)
+				return err
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/repository"
+)
+
+// MetadataID 適用済みマイグレーションを記録するアイテムのID
+//
+// current_points テーブルはIDを指定して1件ずつ参照するだけなので、同じテーブルに記録用のアイテムを置く。
+const MetadataID = "schema_migrations"
+
+// AppliedMigration 適用済みのマイグレーション
+type AppliedMigration struct {
+	ID        string    `dynamodbav:"id"`
+	AppliedAt time.Time `dynamodbav:"applied_at"`
+}
+
+// metadataItem 適用済みマイグレーションの記録
+type metadataItem struct {
+	ID      string             `dynamodbav:"id"`
+	Applied []AppliedMigration `dynamodbav:"applied"`
+}
+
+// appliedAt マイグレーションの適用日時（未適用の場合はfalse）
+func (i *metadataItem) appliedAt(id string) (time.Time, bool) {
+	for _, applied := range i.Applied {
+		if applied.ID == id {
+			return applied.AppliedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// metadataStore 適用済みマイグレーションの記録を読み書きする
+type metadataStore struct {
+	repo  repository.Repository
+	table string
+}
+
+// newMetadataStore 記録用のストアを作成
+func newMetadataStore(repo repository.Repository, table string) *metadataStore {
+	return &metadataStore{repo: repo, table: table}
+}
+
+// load 記録を取得（まだ記録が無い場合は空の記録を返す）
+func (s *metadataStore) load(ctx context.Context) (*metadataItem, error) {
+	item := &metadataItem{}
+	err := s.repo.GetItem(ctx, s.table, map[string]interface{}{"id": MetadataID}, item)
+	if err != nil {
+		if errors.Is(err, repository.ErrItemNotFound) {
+			return &metadataItem{ID: MetadataID}, nil
+		}
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+	return item, nil
+}
+
+// save 記録を保存
+func (s *metadataStore) save(ctx context.Context, item *metadataItem) error {
+	item.ID = MetadataID
+	return s.repo.PutItem(ctx, s.table, item)
+}
@@ -8,5 +8,101 @@ type Achievement struct {
 	Title       string    `json:"title" dynamodbav:"title"`
 	Description string    `json:"description" dynamodbav:"description"`
 	Point       int       `json:"point" dynamodbav:"point"`
+	Category    string    `json:"category" dynamodbav:"category,omitempty"` // 分類（health・learning など。空の場合は未分類）
 	CreatedAt   time.Time `json:"created_at" dynamodbav:"created_at"`
+	Version     int       `json:"version" dynamodbav:"version"` // 更新のたびに1ずつ増える（楽観的ロック用）
+	// AttachmentKey 添付ファイル（画像など）のS3オブジェクトキー（添付していない場合は空）
+	AttachmentKey string `json:"attachment_key,omitempty" dynamodbav:"attachment_key,omitempty"`
+	// DueDate 期限（YYYY-MM-DD。期限の日から、一度も達成していない間はリマインドする。空の場合は期限なし）
+	DueDate string `json:"due_date,omitempty" dynamodbav:"due_date,omitempty"`
+	// Reminder リマインドする時刻のcron式（期限の無い達成目録はその日まだ達成していない場合にリマインドする。空の場合は期限のある達成目録のみ reminders.schedule でリマインドする）
+	Reminder string `json:"reminder,omitempty" dynamodbav:"reminder,omitempty"`
+	// Difficulty 難易度（easy・medium・hard。空の場合は未設定）
+	Difficulty Difficulty `json:"difficulty,omitempty" dynamodbav:"difficulty,omitempty"`
+	// MaxPerDay 1日に達成できる回数の上限（0の場合は制限なし）
+	MaxPerDay int `json:"max_per_day,omitempty" dynamodbav:"max_per_day,omitempty"`
+	// MaxTotal 通算で達成できる回数の上限（0の場合は制限なし）
+	MaxTotal int `json:"max_total,omitempty" dynamodbav:"max_total,omitempty"`
+	// Pinned 一覧の先頭に固定するか
+	Pinned bool `json:"pinned,omitempty" dynamodbav:"pinned,omitempty"`
+	// SortOrder 一覧での並び順（1から昇順。0の場合は並び順を指定した達成目録の後に作成日時順）
+	SortOrder int `json:"sort_order,omitempty" dynamodbav:"sort_order,omitempty"`
+	// PointsPerHour 計測した時間1時間あたりのポイント（タイマーを止めて達成した場合に Point の代わりに付与する。0の場合は Point を付与する）
+	PointsPerHour int `json:"points_per_hour,omitempty" dynamodbav:"points_per_hour,omitempty"`
+	// TimerStartedAt 動いているタイマーを開始した日時（タイマーが止まっている場合はnil。更新してもバージョンは進めない）
+	TimerStartedAt *time.Time `json:"timer_started_at,omitempty" dynamodbav:"timer_started_at,omitempty"`
+}
+
+// Difficulty 達成目録の難易度
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// Difficulties 設定できる難易度（易しい順）
+var Difficulties = []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard}
+
+// PointSuggestion 難易度から提案する達成目録のポイント
+type PointSuggestion struct {
+	Difficulty Difficulty `json:"difficulty"`
+	Point      int        `json:"point"` // 提案するポイント
+	Min        int        `json:"min"`   // 目安の範囲の下限
+	Max        int        `json:"max"`   // 目安の範囲の上限
+	Samples    int        `json:"samples"`
+	Basis      string     `json:"basis"` // 提案の根拠（SuggestionBasisDifficulty など）
+}
+
+// ポイントの提案の根拠
+const (
+	SuggestionBasisDifficulty = "difficulty" // 同じ難易度の達成目録のポイント
+	SuggestionBasisHistory    = "history"    // すべての達成目録のポイント
+	SuggestionBasisDefault    = "default"    // 達成目録が少ない場合の既定値
+)
+
+// Completion 達成目録の達成記録（1つの達成目録を何度でも達成でき、達成のたびにポイントを付与する）
+type Completion struct {
+	ID               string    `json:"id" dynamodbav:"id"`
+	AchievementID    string    `json:"achievement_id" dynamodbav:"achievement_id"`
+	AchievementTitle string    `json:"achievement_title" dynamodbav:"achievement_title"`
+	Point            int       `json:"point" dynamodbav:"point"`             // 達成した時点の達成目録のポイント
+	BonusPoint       int       `json:"bonus_point" dynamodbav:"bonus_point"` // 連続達成日数が節目に達した場合に追加で付与したポイント
+	CompletedAt      time.Time `json:"completed_at" dynamodbav:"completed_at"`
+	// StartedAt タイマーを止めて達成した場合の、タイマーを開始した日時（タイマーを使わずに達成した場合はnil）
+	StartedAt *time.Time `json:"started_at,omitempty" dynamodbav:"started_at,omitempty"`
+	// DurationSeconds タイマーで計測した時間（秒）
+	DurationSeconds int `json:"duration_seconds,omitempty" dynamodbav:"duration_seconds,omitempty"`
+	// Limit 達成記録を作成するときに確認する達成回数の上限（保存しない。nilの場合は確認しない）
+	Limit *CompletionLimit `json:"-" dynamodbav:"-"`
+}
+
+// CompletionLimit 達成回数の上限（ストレージが達成記録の作成と同じトランザクションで確認する）
+type CompletionLimit struct {
+	MaxPerDay int // 1日に達成できる回数の上限（0の場合は制限なし）
+	MaxTotal  int // 通算で達成できる回数の上限（0の場合は制限なし）
+	// DayStart・DayEnd 1日の回数を数える期間（連続達成日数と同じタイムゾーンの達成日の0時から翌日の0時まで）
+	DayStart time.Time
+	DayEnd   time.Time
+}
+
+// Streak 連続達成日数（1日に何度達成しても1日として数える）
+type Streak struct {
+	Current         int    `json:"current"`                     // 今日または昨日まで続いている連続日数（途切れている場合は0）
+	Longest         int    `json:"longest"`                     // これまでで最も長い連続日数
+	LastCompletedOn string `json:"last_completed_on,omitempty"` // 最後に達成した日付（YYYY-MM-DD）
+}
+
+// AchievementStreak 達成目録ごとの連続達成日数
+type AchievementStreak struct {
+	AchievementID    string `json:"achievement_id"`
+	AchievementTitle string `json:"achievement_title"`
+	Streak
+}
+
+// StreakSummary すべての達成目録を合わせた連続達成日数と、達成目録ごとの連続達成日数
+type StreakSummary struct {
+	Global       Streak              `json:"global"`
+	Achievements []AchievementStreak `json:"achievements"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Allowance 決まった日時に定期的にポイントを付与するお小遣いのルール（「毎週月曜日に50ポイント」など）
+type Allowance struct {
+	ID    string `json:"id" dynamodbav:"id"`
+	Title string `json:"title" dynamodbav:"title"`
+	// Points 1回に付与するポイント
+	Points int `json:"points" dynamodbav:"points"`
+	// Schedule 付与する日時のcron式（streaks.timezone の時刻で判定する）
+	Schedule string `json:"schedule" dynamodbav:"schedule"`
+	// LastGrantedAt 最後に付与した分（一度も付与していない場合はnil）
+	LastGrantedAt *time.Time `json:"last_granted_at,omitempty" dynamodbav:"last_granted_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" dynamodbav:"created_at"`
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKeyScope APIキーで許可する操作の範囲（OAuthの scope と同じく、複数の範囲は空白区切りで表す）
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead すべての読み取り（GET・HEAD）
+	APIKeyScopeRead APIKeyScope = "read"
+	// APIKeyScopeWrite すべての読み取りと書き込み（admin の操作を除く）
+	APIKeyScopeWrite APIKeyScope = "write"
+	// APIKeyScopeAdmin すべての操作（操作履歴など管理者向けの操作を含む）
+	APIKeyScopeAdmin APIKeyScope = "admin"
+	// APIKeyScopeAchievementsRead 達成目録の読み取り
+	APIKeyScopeAchievementsRead APIKeyScope = "achievements:read"
+	// APIKeyScopeAchievementsWrite 達成目録の作成・更新・削除・達成
+	APIKeyScopeAchievementsWrite APIKeyScope = "achievements:write"
+	// APIKeyScopeRewardsRead 報酬の読み取り
+	APIKeyScopeRewardsRead APIKeyScope = "rewards:read"
+	// APIKeyScopeRewardsWrite 報酬の作成・更新・削除・獲得
+	APIKeyScopeRewardsWrite APIKeyScope = "rewards:write"
+	// APIKeyScopePointsRead ポイントと履歴の読み取り
+	APIKeyScopePointsRead APIKeyScope = "points:read"
+	// APIKeyScopePointsAdjust 報酬獲得履歴の修正・取り消しなどポイントを変える操作
+	APIKeyScopePointsAdjust APIKeyScope = "points:adjust"
+)
+
+// APIKeyScopes 定義済みの範囲
+var APIKeyScopes = []APIKeyScope{
+	APIKeyScopeRead,
+	APIKeyScopeWrite,
+	APIKeyScopeAdmin,
+	APIKeyScopeAchievementsRead,
+	APIKeyScopeAchievementsWrite,
+	APIKeyScopeRewardsRead,
+	APIKeyScopeRewardsWrite,
+	APIKeyScopePointsRead,
+	APIKeyScopePointsAdjust,
+}
+
+// ParseAPIKeyScope 空白またはカンマ区切りの範囲を、重複を除いた空白区切りに正規化
+func ParseAPIKeyScope(value string) APIKeyScope {
+	var scopes []string
+	seen := make(map[string]bool)
+	for _, scope := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return APIKeyScope(strings.Join(scopes, " "))
+}
+
+// Scopes 空白区切りの範囲を1つずつに分割
+func (s APIKeyScope) Scopes() []APIKeyScope {
+	fields := strings.Fields(string(s))
+	scopes := make([]APIKeyScope, len(fields))
+	for i, field := range fields {
+		scopes[i] = APIKeyScope(field)
+	}
+	return scopes
+}
+
+// Valid 1つ以上の範囲を含み、すべて定義済みの範囲か
+func (s APIKeyScope) Valid() bool {
+	scopes := s.Scopes()
+	if len(scopes) == 0 {
+		return false
+	}
+	for _, scope := range scopes {
+		known := false
+		for _, defined := range APIKeyScopes {
+			if scope == defined {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return false
+		}
+	}
+	return true
+}
+
+// Grants required の範囲の操作を許可するか
+//
+// admin はすべて、write は admin 以外のすべて、read はすべての読み取りを許可する。
+// 「対象:read」以外の対象ごとの範囲は、同じ対象の読み取りも許可する。
+func (s APIKeyScope) Grants(required APIKeyScope) bool {
+	resource, action, _ := strings.Cut(string(required), ":")
+	for _, scope := range s.Scopes() {
+		switch {
+		case scope == required, scope == APIKeyScopeAdmin:
+			return true
+		case scope == APIKeyScopeWrite && required != APIKeyScopeAdmin:
+			return true
+		case scope == APIKeyScopeRead && (required == APIKeyScopeRead || action == "read"):
+			return true
+		case action == "read" && strings.HasPrefix(string(scope), resource+":"):
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey /api の認証に使うAPIキー
+//
+// キー自体は作成・再発行時に一度だけ返し、保存するのはハッシュ値のみとする。
+type APIKey struct {
+	ID string `json:"id" dynamodbav:"id"`
+	// Label 用途を区別する名前（「ダッシュボード」など）
+	Label string `json:"label" dynamodbav:"label"`
+	// Scope 許可する範囲（空白区切り）
+	Scope APIKeyScope `json:"scope" dynamodbav:"scope"`
+	// TenantID キーで操作できるテナント（空の場合は既定のテナント）
+	TenantID string `json:"tenant_id,omitempty" dynamodbav:"tenant_id,omitempty"`
+	// Hash キーのSHA-256（16進数）
+	Hash      string    `json:"-" dynamodbav:"hash"`
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	// RotatedAt 最後にキーを再発行した日時（再発行していない場合はnil）
+	RotatedAt *time.Time `json:"rotated_at,omitempty" dynamodbav:"rotated_at,omitempty"`
+	// RevokedAt 無効にした日時（有効な場合はnil）
+	RevokedAt *time.Time `json:"revoked_at,omitempty" dynamodbav:"revoked_at,omitempty"`
+}
+
+// Revoked 無効にしたキーか
+func (k *APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// AttachmentTargetType ファイルを添付する記録の種類
+type AttachmentTargetType string
+
+const (
+	// AttachmentTargetAchievement 達成目録
+	AttachmentTargetAchievement AttachmentTargetType = "achievement"
+	// AttachmentTargetRedemption 報酬獲得履歴
+	AttachmentTargetRedemption AttachmentTargetType = "redemption"
+)
+
+// AttachmentUpload 添付ファイルのアップロード先（クライアントは有効期限までに URL へ直接アップロードする）
+type AttachmentUpload struct {
+	// Key 記録に保存したS3オブジェクトキー
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+	Method string `json:"method"`
+	// Headers アップロードのリクエストに含めるヘッダー（Content-Type と署名済みのヘッダー）
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
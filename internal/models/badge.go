@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Badge 獲得したバッジ
+type Badge struct {
+	// ID バッジ定義の識別子（同じバッジは1回だけ獲得できる）
+	ID          string    `json:"id" dynamodbav:"id"`
+	Name        string    `json:"name" dynamodbav:"name"`
+	Description string    `json:"description" dynamodbav:"description"`
+	EarnedAt    time.Time `json:"earned_at" dynamodbav:"earned_at"`
+}
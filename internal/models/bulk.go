@@ -0,0 +1,45 @@
+package models
+
+// BulkMode 一括操作の一部が失敗した場合の扱い
+type BulkMode string
+
+const (
+	// BulkModeBestEffort 失敗した項目があっても残りの項目を実行し、成功した項目はそのまま残す
+	BulkModeBestEffort BulkMode = "best_effort"
+	// BulkModeAllOrNothing 失敗した項目があった場合は残りの項目を実行せず、成功した項目も逆の操作で取り消す
+	BulkModeAllOrNothing BulkMode = "all_or_nothing"
+)
+
+// BulkItemStatus 一括操作の項目ごとの結果
+type BulkItemStatus string
+
+const (
+	BulkItemSucceeded BulkItemStatus = "succeeded"
+	BulkItemFailed    BulkItemStatus = "failed"
+	// BulkItemSkipped 他の項目が失敗したため実行しなかった（all_or_nothing の場合）
+	BulkItemSkipped BulkItemStatus = "skipped"
+	// BulkItemRolledBack 成功したが、他の項目が失敗したため取り消した（all_or_nothing の場合）
+	BulkItemRolledBack BulkItemStatus = "rolled_back"
+)
+
+// BulkItemResult 一括操作の項目の結果
+type BulkItemResult struct {
+	// Index 要求した項目の位置
+	Index int `json:"index"`
+	// ID 対象のID（作成に失敗した場合は空）
+	ID     string         `json:"id,omitempty"`
+	Status BulkItemStatus `json:"status"`
+	// Err 失敗した理由（取り消しに失敗した場合は取り消しのエラー）
+	Err error `json:"-"`
+}
+
+// BulkResult 一括操作の結果
+type BulkResult struct {
+	Mode BulkMode `json:"mode"`
+	// Items 要求した順の項目ごとの結果
+	Items     []*BulkItemResult `json:"items"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	// RolledBack 失敗した項目があったため、成功した項目を取り消したか（all_or_nothing の場合）
+	RolledBack bool `json:"rolled_back"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// DriftEvent 整合性チェックで検出した、達成目録のポイントの合計と現在のポイントの差異
+type DriftEvent struct {
+	ID string `json:"id" dynamodbav:"id"`
+	// TotalPoints 検出した時点の全達成目録のポイントの合計
+	TotalPoints int `json:"total_points" dynamodbav:"total_points"`
+	// CurrentBalance 検出した時点の現在のポイント
+	CurrentBalance int `json:"current_balance" dynamodbav:"current_balance"`
+	// Difference 達成目録のポイントの合計 - 現在のポイント
+	Difference int `json:"difference" dynamodbav:"difference"`
+	// Threshold 検出に使用した差異の許容値（差異の絶対値がこの値を超えた場合に記録する）
+	Threshold  int       `json:"threshold" dynamodbav:"threshold"`
+	DetectedAt time.Time `json:"detected_at" dynamodbav:"detected_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// GoalType 目標の種類
+type GoalType string
+
+const (
+	// GoalTypePoints 現在のポイントを目標のポイントまで貯める
+	GoalTypePoints GoalType = "points"
+	// GoalTypeAchievements 達成目録を目標の件数まで作成する
+	GoalTypeAchievements GoalType = "achievements"
+)
+
+// Goal 目標（「Switchのゲームのために500ポイント貯める」など）
+type Goal struct {
+	ID          string   `json:"id" dynamodbav:"id"`
+	Title       string   `json:"title" dynamodbav:"title"`
+	Description string   `json:"description" dynamodbav:"description"`
+	Type        GoalType `json:"type" dynamodbav:"type"`
+	// Target 目標のポイント、または達成目録の件数
+	Target int `json:"target" dynamodbav:"target"`
+	// ReachedAt 目標を初めて達成した日時（未達成の場合はnil。達成後に下回っても保持する）
+	ReachedAt *time.Time `json:"reached_at,omitempty" dynamodbav:"reached_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" dynamodbav:"created_at"`
+}
+
+// GoalProgress 目標の進捗
+type GoalProgress struct {
+	Goal *Goal `json:"goal"`
+	// Current 現在のポイント、または達成目録の件数
+	Current int `json:"current"`
+	// Percent 目標に対する現在の値の割合（0〜100）
+	Percent int `json:"percent"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// NoteTargetType メモを付ける記録の種類
+type NoteTargetType string
+
+const (
+	// NoteTargetAchievement 達成目録
+	NoteTargetAchievement NoteTargetType = "achievement"
+	// NoteTargetReward 報酬
+	NoteTargetReward NoteTargetType = "reward"
+	// NoteTargetRedemption 報酬獲得履歴
+	NoteTargetRedemption NoteTargetType = "redemption"
+)
+
+// Note 記録に付けるメモ（「誕生日のディナーに使った」など）
+type Note struct {
+	ID         string         `json:"id" dynamodbav:"id"`
+	TargetType NoteTargetType `json:"target_type" dynamodbav:"target_type"`
+	// TargetID メモを付けた達成目録・報酬・報酬獲得履歴のID
+	TargetID  string    `json:"target_id" dynamodbav:"target_id"`
+	Body      string    `json:"body" dynamodbav:"body"`
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+}
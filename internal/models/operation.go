@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// OperationEntity 操作履歴に記録する操作の対象
+type OperationEntity string
+
+const (
+	OperationEntityAchievement OperationEntity = "achievement"
+	OperationEntityReward      OperationEntity = "reward"
+)
+
+// OperationAction 操作履歴に記録する操作の種類
+type OperationAction string
+
+const (
+	OperationActionCreate OperationAction = "create"
+	OperationActionUpdate OperationAction = "update"
+	OperationActionDelete OperationAction = "delete"
+)
+
+// Operation 元に戻せる操作（作成・更新・削除）の記録
+//
+// 操作の前後の記録を保持し、逆の操作を適用して元に戻す。元に戻した操作は、次に別の操作を記録するまでやり直せる。
+type Operation struct {
+	ID       string          `json:"id" dynamodbav:"id"`
+	Entity   OperationEntity `json:"entity" dynamodbav:"entity"`
+	Action   OperationAction `json:"action" dynamodbav:"action"`
+	EntityID string          `json:"entity_id" dynamodbav:"entity_id"`
+	// WithPoints 達成目録のポイントを現在のポイントに反映した操作か（元に戻す際も反映する）
+	WithPoints bool `json:"with_points" dynamodbav:"with_points"`
+	// Before 操作前の記録のJSON（作成の場合は空）
+	Before string `json:"before,omitempty" dynamodbav:"before,omitempty"`
+	// After 操作後の記録のJSON（削除の場合は空）
+	After     string    `json:"after,omitempty" dynamodbav:"after,omitempty"`
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	// UndoneAt 元に戻した日時（元に戻していない場合はnil）
+	UndoneAt *time.Time `json:"undone_at,omitempty" dynamodbav:"undone_at,omitempty"`
+}
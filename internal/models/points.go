@@ -7,6 +7,8 @@ type CurrentPoints struct {
 	ID        string    `json:"id" dynamodbav:"id"` // 固定値 "current"
 	Point     int       `json:"point" dynamodbav:"point"`
 	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+	// Reserved 報酬のために取り置いたポイントの合計（取り置きと同時に増減し、報酬の獲得には point - reserved までしか使えない）
+	Reserved int `json:"reserved" dynamodbav:"reserved"`
 }
 
 // RewardHistory 報酬獲得履歴
@@ -16,12 +18,64 @@ type RewardHistory struct {
 	RewardTitle string    `json:"reward_title" dynamodbav:"reward_title"`
 	PointCost   int       `json:"point_cost" dynamodbav:"point_cost"`
 	RedeemedAt  time.Time `json:"redeemed_at" dynamodbav:"redeemed_at"`
+	// RefundedAt 獲得を取り消してポイントを返還した日時（取り消していない場合はnil）
+	RefundedAt *time.Time `json:"refunded_at,omitempty" dynamodbav:"refunded_at,omitempty"`
+	// AttachmentKey 添付ファイル（レシートなど）のS3オブジェクトキー（添付していない場合は空）
+	AttachmentKey string `json:"attachment_key,omitempty" dynamodbav:"attachment_key,omitempty"`
+	// Note 獲得した報酬を実際に何に使ったかなどの注記（後から変更できる。ポイントには影響しない）
+	Note string `json:"note,omitempty" dynamodbav:"note,omitempty"`
+	// Tags 注記のタグ（後から変更できる）
+	Tags []string `json:"tags,omitempty" dynamodbav:"tags,omitempty"`
+	// Prize 抽選型の報酬で当選した景品（通常の報酬の場合は空）
+	Prize string `json:"prize,omitempty" dynamodbav:"prize,omitempty"`
 }
 
+// RedemptionAnnotation 報酬獲得履歴の注記の変更（nilの項目は変更しない）
+type RedemptionAnnotation struct {
+	Note *string
+	Tags *[]string
+}
+
+// PointLedgerEntry ポイント台帳のエントリ（ポイントの増減ごとに追記し、更新・削除しない）
+type PointLedgerEntry struct {
+	ID        string    `json:"id" dynamodbav:"id"`
+	Type      string    `json:"type" dynamodbav:"type"`
+	Amount    int       `json:"amount" dynamodbav:"amount"`                           // 加算は正、減算は負の値
+	Reference string    `json:"reference,omitempty" dynamodbav:"reference,omitempty"` // 報酬獲得・取り消しの場合は報酬獲得履歴、お小遣いの場合はルールのID
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+}
+
+// ポイント台帳のエントリの種類
+const (
+	// LedgerEntryGrant ポイントの付与（達成目録の追加など）
+	LedgerEntryGrant = "grant"
+	// LedgerEntrySpend ポイントの使用（報酬獲得など）
+	LedgerEntrySpend = "spend"
+	// LedgerEntryRevoke 付与したポイントの取り消し（達成目録の削除など）
+	LedgerEntryRevoke = "revoke"
+	// LedgerEntryAdjustment 残高の直接の修正
+	LedgerEntryAdjustment = "adjustment"
+	// LedgerEntryBonus 連続達成日数の節目に達した達成へのボーナス
+	LedgerEntryBonus = "bonus"
+	// LedgerEntryRefund 報酬獲得の取り消しによるポイントの返還
+	LedgerEntryRefund = "refund"
+	// LedgerEntryAllowance お小遣いのルールによる定期的なポイントの付与
+	LedgerEntryAllowance = "allowance"
+)
+
 // PointSummary ポイント集計結果
 type PointSummary struct {
 	TotalAchievements int `json:"total_achievements"`
 	TotalPoints       int `json:"total_points"`
 	CurrentBalance    int `json:"current_balance"`
 	Difference        int `json:"difference"`
+	// Categories 分類ごとの内訳（ポイントの多い順）
+	Categories []CategoryPoints `json:"categories"`
+}
+
+// CategoryPoints 分類ごとのポイント集計結果
+type CategoryPoints struct {
+	Category string `json:"category"` // 空の場合は未分類の達成目録
+	Earned   int    `json:"earned"`
+	Count    int    `json:"count"`
 }
@@ -0,0 +1,60 @@
+package models
+
+// PromotionMatch 昇格先の既存の定義と照合する方法
+type PromotionMatch string
+
+const (
+	// PromotionMatchID 同じIDの定義を同じものとみなす（作成する場合も同じIDで作成する）
+	PromotionMatchID PromotionMatch = "id"
+	// PromotionMatchTitle 同じタイトルの定義を同じものとみなす（作成する場合は昇格先で新しいIDを割り当てる）
+	PromotionMatchTitle PromotionMatch = "title"
+)
+
+// PromotionConflict 昇格先に同じ定義があり内容が異なる場合の扱い
+type PromotionConflict string
+
+const (
+	// PromotionConflictSkip 昇格先の定義をそのまま残す
+	PromotionConflictSkip PromotionConflict = "skip"
+	// PromotionConflictOverwrite 昇格元の内容で上書きする
+	PromotionConflictOverwrite PromotionConflict = "overwrite"
+)
+
+// PromotionAction 定義ごとに行った（dry run の場合は行う）操作
+type PromotionAction string
+
+const (
+	PromotionActionCreated   PromotionAction = "created"
+	PromotionActionUpdated   PromotionAction = "updated"
+	PromotionActionSkipped   PromotionAction = "skipped"   // 内容が異なるが PromotionConflictSkip のため残した
+	PromotionActionUnchanged PromotionAction = "unchanged" // 昇格先に同じ内容の定義がある
+)
+
+// PromotionOptions 昇格する定義と照合・競合の扱い
+type PromotionOptions struct {
+	// AchievementIDs・RewardIDs 昇格元の達成目録・報酬のID（All の場合は無視する）
+	AchievementIDs []string
+	RewardIDs      []string
+	// All 昇格元のすべての達成目録・報酬を昇格する
+	All      bool
+	Match    PromotionMatch
+	Conflict PromotionConflict
+	// DryRun 昇格先に書き込まずに行う操作だけを返す
+	DryRun bool
+}
+
+// PromotionItem 定義ごとの昇格の結果
+type PromotionItem struct {
+	Entity   OperationEntity `json:"entity"`
+	SourceID string          `json:"source_id"`
+	// TargetID 昇格先の定義のID（dry run で作成する場合は空）
+	TargetID string          `json:"target_id,omitempty"`
+	Title    string          `json:"title"`
+	Action   PromotionAction `json:"action"`
+}
+
+// PromotionResult 昇格の結果
+type PromotionResult struct {
+	Items  []*PromotionItem `json:"items"`
+	DryRun bool             `json:"dry_run"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Quest クエスト（順番に達成する達成目録の連なり。すべて達成するとボーナスのポイントを付与する）
+type Quest struct {
+	ID          string `json:"id" dynamodbav:"id"`
+	Title       string `json:"title" dynamodbav:"title"`
+	Description string `json:"description" dynamodbav:"description"`
+	// Steps 達成する順の達成目録のID
+	Steps []string `json:"steps" dynamodbav:"steps"`
+	// BonusPoint すべてのステップを達成した際に付与するポイント（0の場合は付与しない）
+	BonusPoint int `json:"bonus_point" dynamodbav:"bonus_point"`
+	// CompletedAt すべてのステップを達成した日時（未完了の場合はnil）
+	CompletedAt *time.Time `json:"completed_at,omitempty" dynamodbav:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" dynamodbav:"created_at"`
+}
+
+// QuestStepStatus クエストのステップの状態
+type QuestStepStatus string
+
+const (
+	// QuestStepCompleted 達成済みのステップ
+	QuestStepCompleted QuestStepStatus = "completed"
+	// QuestStepUnlocked 前のステップをすべて達成し、次に達成するステップ
+	QuestStepUnlocked QuestStepStatus = "unlocked"
+	// QuestStepLocked 前のステップが未達成のステップ（この間の達成は数えない）
+	QuestStepLocked QuestStepStatus = "locked"
+)
+
+// QuestStep クエストのステップの進捗
+type QuestStep struct {
+	AchievementID string `json:"achievement_id"`
+	// Title 達成目録のタイトル（達成目録が削除された場合は空）
+	Title  string          `json:"title"`
+	Status QuestStepStatus `json:"status"`
+	// CompletedAt ステップを達成した日時（前のステップの達成後の最初の達成）
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// QuestProgress クエストの進捗
+type QuestProgress struct {
+	Quest *Quest       `json:"quest"`
+	Steps []*QuestStep `json:"steps"`
+	// Completed 達成済みのステップの数
+	Completed int `json:"completed"`
+}
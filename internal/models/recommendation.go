@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// AffordableReward 現在のポイントで獲得できる報酬
+type AffordableReward struct {
+	Reward *Reward `json:"reward"`
+	// Remaining 獲得した後に使えるポイント
+	Remaining int `json:"remaining"`
+}
+
+// AffordableRewards 現在のポイントで獲得できる報酬と、次の報酬を獲得できるまでの見込み
+type AffordableRewards struct {
+	// Balance 現在のポイント
+	Balance int `json:"balance"`
+	// Spendable 取り置きを除いて使えるポイント（報酬ごとに、その報酬のための取り置きは使えるポイントに含める）
+	Spendable int `json:"spendable"`
+	// Rewards 獲得できる報酬（獲得した後に残るポイントが少ない順）
+	Rewards []*AffordableReward `json:"rewards"`
+	// AverageDailyPoints 直近30日の1日あたりの獲得ポイント
+	AverageDailyPoints float64 `json:"average_daily_points"`
+	// Next まだ獲得できない報酬のうち、最も少ないポイントで獲得できる報酬（ない場合はnil）
+	Next *Reward `json:"next,omitempty"`
+	// PointsNeeded Next を獲得するのに足りないポイント
+	PointsNeeded int `json:"points_needed,omitempty"`
+	// DaysUntilNext 直近30日のペースで獲得した場合に Next を獲得できるまでの日数（Next がない、または獲得がない場合はnil）
+	DaysUntilNext *int      `json:"days_until_next,omitempty"`
+	GeneratedAt   time.Time `json:"generated_at"`
+}
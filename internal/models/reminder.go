@@ -0,0 +1,23 @@
+package models
+
+// ReminderKind リマインドの理由
+type ReminderKind string
+
+const (
+	// ReminderRecurring 毎日達成する達成目録を今日まだ達成していない
+	ReminderRecurring ReminderKind = "recurring"
+	// ReminderDue 期限を迎えた達成目録をまだ達成していない
+	ReminderDue ReminderKind = "due"
+)
+
+// Reminder 達成を促すリマインド
+type Reminder struct {
+	AchievementID    string       `json:"achievement_id"`
+	AchievementTitle string       `json:"achievement_title"`
+	Kind             ReminderKind `json:"kind"`
+	DueDate          string       `json:"due_date,omitempty"`
+	// Overdue 期限の日を過ぎている
+	Overdue bool `json:"overdue,omitempty"`
+	// Schedule リマインドする時刻のcron式（達成目録の reminder または reminders.schedule）
+	Schedule string `json:"schedule"`
+}
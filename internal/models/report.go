@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// MonthlyReport 月次レポート
+type MonthlyReport struct {
+	Month         string           `json:"month"` // "2006-01" 形式
+	Achievements  []*Achievement   `json:"achievements"`
+	Redemptions   []*RewardHistory `json:"redemptions"`
+	PointsEarned  int              `json:"points_earned"`
+	PointsSpent   int              `json:"points_spent"`
+	NetPoints     int              `json:"net_points"`
+	ActiveDays    int              `json:"active_days"`
+	LongestStreak int              `json:"longest_streak"`
+	GeneratedAt   time.Time        `json:"generated_at"`
+}
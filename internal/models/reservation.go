@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Reservation 報酬のために取り置いたポイント（取り置いたポイントは他の報酬の獲得に使えない）
+type Reservation struct {
+	// RewardID ポイントを取り置いた報酬のID（報酬ごとに1つだけ取り置ける）
+	RewardID  string    `json:"reward_id" dynamodbav:"id"`
+	Points    int       `json:"points" dynamodbav:"points"`
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+}
+
+// ReservationEntry 取り置きとポイントを取り置いた報酬
+type ReservationEntry struct {
+	Reservation *Reservation `json:"reservation"`
+	// Reward 取り置いた報酬（取り置いた後に削除された場合はnil）
+	Reward *Reward `json:"reward"`
+}
+
+// ReservationSummary 取り置きの一覧と、取り置きを除いて使えるポイント
+type ReservationSummary struct {
+	// Balance 現在のポイント
+	Balance int `json:"balance"`
+	// Reserved 取り置いたポイントの合計
+	Reserved int `json:"reserved"`
+	// Spendable 取り置きを除いて報酬の獲得に使えるポイント（取り置きが残高を超えている場合は0）
+	Spendable    int                 `json:"spendable"`
+	Reservations []*ReservationEntry `json:"reservations"`
+}
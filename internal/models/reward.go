@@ -9,4 +9,14 @@ type Reward struct {
 	Description string    `json:"description" dynamodbav:"description"`
 	Point       int       `json:"point" dynamodbav:"point"`
 	CreatedAt   time.Time `json:"created_at" dynamodbav:"created_at"`
+	Version     int       `json:"version" dynamodbav:"version"` // 更新のたびに1ずつ増える（楽観的ロック用）
+	// Prizes 抽選型の報酬の景品（空の場合は通常の報酬。獲得すると重みに応じて1つを抽選する）
+	Prizes []RafflePrize `json:"prizes,omitempty" dynamodbav:"prizes,omitempty"`
+}
+
+// RafflePrize 抽選型の報酬の景品
+type RafflePrize struct {
+	Title string `json:"title" dynamodbav:"title"`
+	// Weight 当選しやすさ（当選する確率は Weight / すべての景品の Weight の合計）
+	Weight int `json:"weight" dynamodbav:"weight"`
 }
\ No newline at end of file
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// TrendWindow 直近の日数（今日を含む）に獲得・使用したポイント
+type TrendWindow struct {
+	Days int    `json:"days"`
+	From string `json:"from"` // 期間の最初の日（YYYY-MM-DD）
+	To   string `json:"to"`   // 期間の最後の日（YYYY-MM-DD。今日）
+	// PointsEarned 期間中に作成・達成した達成目録のポイントの合計
+	PointsEarned int `json:"points_earned"`
+	// PointsSpent 期間中の報酬獲得で使用したポイント（取り消した報酬獲得は含めない）
+	PointsSpent int `json:"points_spent"`
+	// AverageDailyPoints 期間中の1日あたりの獲得ポイント
+	AverageDailyPoints float64 `json:"average_daily_points"`
+}
+
+// BestPeriod 獲得ポイントが最も多かった日・週
+type BestPeriod struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Points int    `json:"points"`
+}
+
+// TrendStats 獲得・使用したポイントの推移の統計
+type TrendStats struct {
+	Last7Days  TrendWindow `json:"last_7_days"`
+	Last30Days TrendWindow `json:"last_30_days"`
+	// BestDay これまでで獲得ポイントが最も多かった日（獲得がない場合はnil）
+	BestDay *BestPeriod `json:"best_day,omitempty"`
+	// BestWeek これまでで獲得ポイントが最も多かった週（月曜日から日曜日。獲得がない場合はnil）
+	BestWeek    *BestPeriod `json:"best_week,omitempty"`
+	GeneratedAt time.Time   `json:"generated_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// SummaryPeriod サマリーの集計期間
+type SummaryPeriod string
+
+const (
+	// SummaryDaily 1日（既定は昨日）のサマリー
+	SummaryDaily SummaryPeriod = "daily"
+	// SummaryWeekly 7日間（既定は昨日までの7日間）のサマリー
+	SummaryWeekly SummaryPeriod = "weekly"
+)
+
+// Summary 期間中に獲得・使用したポイントと現在の残高のサマリー
+type Summary struct {
+	Period SummaryPeriod `json:"period"`
+	From   string        `json:"from"` // 期間の最初の日（YYYY-MM-DD）
+	To     string        `json:"to"`   // 期間の最後の日（YYYY-MM-DD。この日を含む）
+	// PointsEarned 期間中に作成・達成した達成目録のポイントの合計
+	PointsEarned int `json:"points_earned"`
+	// Achievements 期間中に作成・達成した達成目録の数（同じ達成目録は1つと数える）
+	Achievements int `json:"achievements"`
+	Completions  int `json:"completions"`
+	// PointsSpent 期間中の報酬獲得で使用したポイント（取り消した報酬獲得は含めない）
+	PointsSpent int `json:"points_spent"`
+	Redemptions int `json:"redemptions"`
+	// Balance 作成した時点の現在のポイント
+	Balance     int       `json:"balance"`
+	Text        string    `json:"text"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
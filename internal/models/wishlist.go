@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Favorite お気に入りに登録した報酬
+type Favorite struct {
+	// RewardID お気に入りに登録した報酬のID（同じ報酬は1回だけ登録できる）
+	RewardID  string    `json:"reward_id" dynamodbav:"id"`
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+}
+
+// WishlistItem ほしいものリストに入れた報酬
+type WishlistItem struct {
+	// RewardID ほしいものリストに入れた報酬のID（同じ報酬は1回だけ入れられる）
+	RewardID string `json:"reward_id" dynamodbav:"id"`
+	// Priority 優先度（小さいほど先に並ぶ。同じ優先度の報酬は追加した順）
+	Priority  int       `json:"priority" dynamodbav:"priority"`
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+}
+
+// WishlistEntry ほしいものリストの報酬と、獲得できるまでのポイント
+type WishlistEntry struct {
+	Item   *WishlistItem `json:"item"`
+	Reward *Reward       `json:"reward"`
+	// PointsNeeded 獲得できるまでに貯める必要があるポイント（現在のポイントで獲得できる場合は0）
+	PointsNeeded int `json:"points_needed"`
+}
@@ -0,0 +1,147 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"achievement-management/internal/events"
+	"achievement-management/internal/logging"
+)
+
+// MaxBatchSize SNSの PublishBatch・SQSの SendMessageBatch で1回に送信できるイベントの件数
+const MaxBatchSize = 10
+
+// BatchSender 複数のイベントをまとめて送信する送信先（SNSのトピック・SQSのキュー）
+type BatchSender interface {
+	// SendBatch batch（MaxBatchSize 件以下）を送信し、送信先が受け付けなかったイベントを返す
+	//
+	// リクエスト自体が失敗した場合はエラーを返す（すべてのイベントが送信されていない）。
+	SendBatch(ctx context.Context, batch []events.Event) ([]events.Event, error)
+}
+
+// BatchPublisher イベントをまとめて送信する配信先
+//
+// イベントは size 件になるか、最初のイベントから wait が経過するまで保留する。
+// 送信できなかったイベントは deadLetter（デッドレターキュー）に送り、それも失敗した場合はエラーとして記録する。
+// 保留中のイベントはプロセスを終了する前に Flush で送信すること。
+type BatchPublisher struct {
+	sender     BatchSender
+	deadLetter BatchSender
+	size       int
+	wait       time.Duration
+	logger     logging.Logger
+
+	mu      sync.Mutex
+	pending []events.Event
+	timer   *time.Timer
+}
+
+// NewBatchPublisher sender にまとめて送信する配信先を作成
+//
+// size は 1〜MaxBatchSize 件に丸め、wait が0以下の場合は保留せずにすぐ送信する。deadLetter がnilの場合、送信できなかったイベントは破棄する。
+// logger は wait の経過後に送信した場合の失敗の記録に使用する。
+func NewBatchPublisher(sender, deadLetter BatchSender, size int, wait time.Duration, logger logging.Logger) *BatchPublisher {
+	if size < 1 || size > MaxBatchSize {
+		size = MaxBatchSize
+	}
+	return &BatchPublisher{
+		sender:     sender,
+		deadLetter: deadLetter,
+		size:       size,
+		wait:       wait,
+		logger:     logger,
+	}
+}
+
+// Publish イベントを保留し、size 件たまった場合は送信する
+func (p *BatchPublisher) Publish(ctx context.Context, event events.Event) error {
+	if p.wait <= 0 {
+		return p.send(ctx, []events.Event{event})
+	}
+
+	p.mu.Lock()
+	p.pending = append(p.pending, event)
+	if len(p.pending) < p.size {
+		if p.timer == nil {
+			p.timer = time.AfterFunc(p.wait, p.flushAfterWait)
+		}
+		p.mu.Unlock()
+		return nil
+	}
+	batch := p.take()
+	p.mu.Unlock()
+
+	return p.send(ctx, batch)
+}
+
+// Flush 保留中のイベントを送信
+func (p *BatchPublisher) Flush(ctx context.Context) error {
+	p.mu.Lock()
+	batch := p.take()
+	p.mu.Unlock()
+
+	var failed int
+	for start := 0; start < len(batch); start += p.size {
+		end := min(start+p.size, len(batch))
+		if err := p.send(ctx, batch[start:end]); err != nil {
+			failed += end - start
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to deliver %d of %d pending events", failed, len(batch))
+	}
+	return nil
+}
+
+// flushAfterWait 最初のイベントから wait が経過した場合に保留中のイベントを送信
+func (p *BatchPublisher) flushAfterWait() {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	if err := p.Flush(ctx); err != nil {
+		p.logger.WithField("error", err.Error()).Error("Failed to deliver batched events")
+	}
+}
+
+// take 保留中のイベントを取り出す（mu をロックして呼び出す）
+func (p *BatchPublisher) take() []events.Event {
+	batch := p.pending
+	p.pending = nil
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	return batch
+}
+
+// send batch を送信し、送信できなかったイベントをデッドレターキューに送る
+func (p *BatchPublisher) send(ctx context.Context, batch []events.Event) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	failed, err := p.sender.SendBatch(ctx, batch)
+	if err != nil {
+		failed = batch
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	if err == nil {
+		err = fmt.Errorf("rejected by the destination")
+	}
+	if p.deadLetter == nil {
+		return fmt.Errorf("failed to deliver %d events: %w", len(failed), err)
+	}
+
+	rejected, dlqErr := p.deadLetter.SendBatch(ctx, failed)
+	if dlqErr == nil && len(rejected) > 0 {
+		dlqErr = fmt.Errorf("%d events rejected", len(rejected))
+	}
+	if dlqErr != nil {
+		return fmt.Errorf("failed to deliver %d events: %w (dead-letter queue: %v)", len(failed), err, dlqErr)
+	}
+	p.logger.WithFields(map[string]interface{}{"error": err.Error(), "count": len(failed)}).Warn("Sent undeliverable events to the dead-letter queue")
+	return nil
+}
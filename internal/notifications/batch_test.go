@@ -0,0 +1,149 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"achievement-management/internal/events"
+	"achievement-management/internal/logging"
+)
+
+// recordingBatchSender 送信したバッチを記録する送信先（reject に含まれるIDのイベントは受け付けない）
+type recordingBatchSender struct {
+	mu      sync.Mutex
+	batches [][]events.Event
+	reject  map[string]bool
+	err     error
+}
+
+func (s *recordingBatchSender) SendBatch(ctx context.Context, batch []events.Event) ([]events.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.batches = append(s.batches, batch)
+	var failed []events.Event
+	for _, event := range batch {
+		if s.reject[event.ID] {
+			failed = append(failed, event)
+		}
+	}
+	return failed, nil
+}
+
+func (s *recordingBatchSender) sent() [][]events.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.batches
+}
+
+func nopLogger() logging.Logger {
+	return logging.FromContext(context.Background())
+}
+
+func TestBatchPublisher_SendsFullBatches(t *testing.T) {
+	sender := &recordingBatchSender{}
+	publisher := NewBatchPublisher(sender, nil, 2, time.Hour, nopLogger())
+
+	for _, id := range []string{"1", "2", "3"} {
+		if err := publisher.Publish(context.Background(), events.Event{ID: id}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	if batches := sender.sent(); len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("Expected one batch of 2 events, got %v", batches)
+	}
+
+	if err := publisher.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if batches := sender.sent(); len(batches) != 2 || batches[1][0].ID != "3" {
+		t.Errorf("Expected the pending event to be flushed, got %v", batches)
+	}
+}
+
+func TestBatchPublisher_SendsAfterWait(t *testing.T) {
+	sender := &recordingBatchSender{}
+	publisher := NewBatchPublisher(sender, nil, 10, 20*time.Millisecond, nopLogger())
+
+	if err := publisher.Publish(context.Background(), events.Event{ID: "1"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if len(sender.sent()) != 0 {
+		t.Fatal("Expected the event to be held until the wait elapses")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(sender.sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if batches := sender.sent(); len(batches) != 1 || len(batches[0]) != 1 {
+		t.Errorf("Expected the event to be sent after the wait, got %v", batches)
+	}
+}
+
+func TestBatchPublisher_WithoutWaitSendsImmediately(t *testing.T) {
+	sender := &recordingBatchSender{}
+	publisher := NewBatchPublisher(sender, nil, 10, 0, nopLogger())
+
+	if err := publisher.Publish(context.Background(), events.Event{ID: "1"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if len(sender.sent()) != 1 {
+		t.Error("Expected the event to be sent immediately")
+	}
+}
+
+func TestBatchPublisher_DeadLetterQueue(t *testing.T) {
+	t.Run("rejected events", func(t *testing.T) {
+		sender := &recordingBatchSender{reject: map[string]bool{"2": true}}
+		deadLetter := &recordingBatchSender{}
+		publisher := NewBatchPublisher(sender, deadLetter, 2, time.Hour, nopLogger())
+
+		publisher.Publish(context.Background(), events.Event{ID: "1"})
+		if err := publisher.Publish(context.Background(), events.Event{ID: "2"}); err != nil {
+			t.Fatalf("Expected the rejected event to go to the dead-letter queue, got %v", err)
+		}
+		if batches := deadLetter.sent(); len(batches) != 1 || len(batches[0]) != 1 || batches[0][0].ID != "2" {
+			t.Errorf("Expected only the rejected event in the dead-letter queue, got %v", batches)
+		}
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		sender := &recordingBatchSender{err: errors.New("throttled")}
+		deadLetter := &recordingBatchSender{}
+		publisher := NewBatchPublisher(sender, deadLetter, 10, 0, nopLogger())
+
+		if err := publisher.Publish(context.Background(), events.Event{ID: "1"}); err != nil {
+			t.Fatalf("Expected the event to go to the dead-letter queue, got %v", err)
+		}
+		if len(deadLetter.sent()) != 1 {
+			t.Error("Expected the event in the dead-letter queue")
+		}
+	})
+
+	t.Run("dead-letter queue fails", func(t *testing.T) {
+		sender := &recordingBatchSender{err: errors.New("throttled")}
+		deadLetter := &recordingBatchSender{err: errors.New("queue does not exist")}
+		publisher := NewBatchPublisher(sender, deadLetter, 10, 0, nopLogger())
+
+		err := publisher.Publish(context.Background(), events.Event{ID: "1"})
+		if err == nil || !strings.Contains(err.Error(), "throttled") || !strings.Contains(err.Error(), "queue does not exist") {
+			t.Errorf("Expected both errors, got %v", err)
+		}
+	})
+
+	t.Run("without a dead-letter queue", func(t *testing.T) {
+		sender := &recordingBatchSender{reject: map[string]bool{"1": true}}
+		publisher := NewBatchPublisher(sender, nil, 10, 0, nopLogger())
+
+		if err := publisher.Publish(context.Background(), events.Event{ID: "1"}); err == nil {
+			t.Error("Expected an error for the rejected event")
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package notifications
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/services"
+)
+
+// commandHelp 不明なコマンドへの応答
+const commandHelp = "Commands: `/points` shows the current points, `/redeem <title>` redeems a reward"
+
+// Commands チャットのボットのコマンド（!points・!redeem <title>）を実行する
+type Commands struct {
+	points  services.PointService
+	rewards services.RewardService
+}
+
+// NewCommands ポイント・報酬サービスを呼び出すコマンドを作成
+func NewCommands(points services.PointService, rewards services.RewardService) *Commands {
+	return &Commands{points: points, rewards: rewards}
+}
+
+// Run コマンドを実行し、チャットに返す応答（Discord の Markdown）
+//
+// 先頭の ! または / は省略でき、不明なコマンドには使い方を返す。
+// 報酬が見つからない・ポイントが足りないなど獲得できない理由は応答で伝え、それ以外の失敗はエラーとして返す。
+func (c *Commands) Run(ctx context.Context, input string) (string, error) {
+	name, argument, _ := strings.Cut(strings.TrimSpace(input), " ")
+	switch strings.ToLower(strings.TrimLeft(name, "!/")) {
+	case "points":
+		return c.currentPoints(ctx)
+	case "redeem":
+		return c.redeem(ctx, strings.TrimSpace(argument))
+	}
+	return commandHelp, nil
+}
+
+// currentPoints !points - 現在のポイント
+func (c *Commands) currentPoints(ctx context.Context) (string, error) {
+	current, err := c.points.GetCurrentPoints(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current points: %w", err)
+	}
+	return fmt.Sprintf("You have **%d pt**", current.Point), nil
+}
+
+// redeem !redeem <title> - タイトルの報酬を獲得（大文字・小文字は区別しない）
+func (c *Commands) redeem(ctx context.Context, title string) (string, error) {
+	if title == "" {
+		return "Usage: `/redeem <title>`", nil
+	}
+
+	rewards, err := c.rewards.List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list rewards: %w", err)
+	}
+	var matched []string
+	for _, reward := range rewards {
+		if strings.EqualFold(reward.Title, title) {
+			matched = append(matched, reward.ID)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return fmt.Sprintf("No reward titled **%s**", title), nil
+	case 1:
+	default:
+		return fmt.Sprintf("%d rewards are titled **%s**, redeem it from the CLI or the API instead", len(matched), title), nil
+	}
+
+	history, err := c.rewards.Redeem(ctx, matched[0])
+	var businessErr *errors.BusinessLogicError
+	if stderrors.As(err, &businessErr) {
+		return fmt.Sprintf("Cannot redeem **%s**: %s", title, businessErr.Reason), nil
+	}
+	var validationErr *errors.ValidationError
+	if stderrors.As(err, &validationErr) {
+		return fmt.Sprintf("Cannot redeem **%s**: %s", title, validationErr.Message), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to redeem reward %s: %w", matched[0], err)
+	}
+
+	reply := fmt.Sprintf("Redeemed **%s** for %d pt", history.RewardTitle, history.PointCost)
+	if history.Prize != "" {
+		reply += fmt.Sprintf(" and won **%s**", history.Prize)
+	}
+	if current, err := c.points.GetCurrentPoints(ctx); err == nil {
+		reply += fmt.Sprintf(", %d pt left", current.Point)
+	}
+	return reply, nil
+}
@@ -0,0 +1,82 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/services"
+)
+
+// fakePointService 現在のポイントのみ返すポイントサービス
+type fakePointService struct {
+	services.PointService
+	point int
+}
+
+func (s *fakePointService) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	return &models.CurrentPoints{Point: s.point}, nil
+}
+
+// fakeRewardService 報酬の一覧と獲得のみ行う報酬サービス
+type fakeRewardService struct {
+	services.RewardService
+	rewards []*models.Reward
+	points  *fakePointService
+}
+
+func (s *fakeRewardService) List(ctx context.Context) ([]*models.Reward, error) {
+	return s.rewards, nil
+}
+
+func (s *fakeRewardService) Redeem(ctx context.Context, rewardID string) (*models.RewardHistory, error) {
+	for _, reward := range s.rewards {
+		if reward.ID != rewardID {
+			continue
+		}
+		if s.points.point < reward.Point {
+			return nil, &errors.BusinessLogicError{Operation: "Redeem", Reason: "insufficient points", Code: errors.CodeInsufficientPoints}
+		}
+		s.points.point -= reward.Point
+		return &models.RewardHistory{ID: "h1", RewardID: reward.ID, RewardTitle: reward.Title, PointCost: reward.Point}, nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+func TestCommands_Run(t *testing.T) {
+	points := &fakePointService{point: 100}
+	rewards := &fakeRewardService{
+		rewards: []*models.Reward{
+			{ID: "r1", Title: "Coffee", Point: 30},
+			{ID: "r2", Title: "Movie", Point: 200},
+			{ID: "r3", Title: "Game", Point: 10},
+			{ID: "r4", Title: "game", Point: 20},
+		},
+		points: points,
+	}
+	commands := NewCommands(points, rewards)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"!points", "You have **100 pt**"},
+		{"/points", "You have **100 pt**"},
+		{"!redeem coffee", "Redeemed **Coffee** for 30 pt, 70 pt left"},
+		{"!redeem Movie", "Cannot redeem **Movie**: insufficient points"},
+		{"!redeem Tea", "No reward titled **Tea**"},
+		{"!redeem game", "2 rewards are titled **game**, redeem it from the CLI or the API instead"},
+		{"!redeem", "Usage: `/redeem <title>`"},
+		{"!help", commandHelp},
+	}
+	for _, tt := range tests {
+		reply, err := commands.Run(context.Background(), tt.input)
+		if err != nil {
+			t.Fatalf("Run(%q) failed: %v", tt.input, err)
+		}
+		if reply != tt.expected {
+			t.Errorf("Run(%q) = %q, expected %q", tt.input, reply, tt.expected)
+		}
+	}
+}
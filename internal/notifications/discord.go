@@ -0,0 +1,109 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"achievement-management/internal/events"
+)
+
+// DiscordPublisher イベントをメッセージにしてDiscordのWebhookに投稿する配信先
+type DiscordPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// DiscordMessage Discordに送るメッセージ（Webhookへの投稿とコマンドへの応答に使用）
+type DiscordMessage struct {
+	Content string `json:"content"`
+	// AllowedMentions タイトルなどに含まれる @everyone などでメンションしないように、空にして送る
+	AllowedMentions discordAllowedMentions `json:"allowed_mentions"`
+}
+
+// discordAllowedMentions メンションを許可する種類
+type discordAllowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// NewDiscordMessage メンションしないメッセージを作成
+func NewDiscordMessage(content string) DiscordMessage {
+	return DiscordMessage{Content: content, AllowedMentions: discordAllowedMentions{Parse: []string{}}}
+}
+
+// NewDiscordPublisher Discordの配信先を作成（clientがnilの場合はタイムアウト付きのクライアントを使用）
+func NewDiscordPublisher(url string, client *http.Client) *DiscordPublisher {
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	return &DiscordPublisher{url: url, client: client}
+}
+
+// Publish イベントをメッセージにして投稿（メッセージにしない種類のイベントは投稿しない）
+func (p *DiscordPublisher) Publish(ctx context.Context, event events.Event) error {
+	text, ok := discordMessageText(event)
+	if !ok {
+		return nil
+	}
+
+	body, err := json.Marshal(NewDiscordMessage(text))
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord message for event %s: %w", event.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create discord request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// URLにはトークンが含まれるため、エラーには含めない
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to post event %s to discord: %w", event.ID, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("discord responded with status %d for event %s", resp.StatusCode, event.ID)
+	}
+	return nil
+}
+
+// discordMessageText イベントを投稿するメッセージ（通知しない種類のイベントの場合は false）
+//
+// Discord は Slack の :tada: などの絵文字コードを変換しないため絵文字をそのまま使い、強調は ** で囲む（Discord の Markdown 形式）。
+func discordMessageText(event events.Event) (string, bool) {
+	item := event.Item
+	switch event.Type {
+	case "achievements.created":
+		text := fmt.Sprintf("🎉 New achievement **%v** (+%v pt)", item["title"], item["point"])
+		if description, _ := item["description"].(string); description != "" {
+			text += "\n" + description
+		}
+		return text, true
+	case "rewards.redeemed":
+		text := fmt.Sprintf("🎁 Redeemed **%v** for %v pt", item["reward_title"], item["point_cost"])
+		if prize, _ := item["prize"].(string); prize != "" {
+			text += fmt.Sprintf(" and won **%s**", prize)
+		}
+		return text, true
+	case "points.low_balance":
+		return fmt.Sprintf("⚠️ Only %v pt left after redeeming **%v** (below %v pt)", item["point"], item["reward_title"], item["threshold"]), true
+	case "goals.reached":
+		return fmt.Sprintf("🏆 Goal reached **%v** (%v %v)", item["title"], item["target"], item["type"]), true
+	case "summaries.generated":
+		return fmt.Sprintf("📊 %v", item["text"]), true
+	}
+	return "", false
+}
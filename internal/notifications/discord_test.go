@@ -0,0 +1,50 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"achievement-management/internal/events"
+)
+
+func TestDiscordPublisher_Publish(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	event := events.Event{
+		ID:   "achievements.created:a1",
+		Type: "achievements.created",
+		Item: map[string]interface{}{"title": "@everyone 読書", "point": 10},
+	}
+	if err := NewDiscordPublisher(server.URL, nil).Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if received["content"] != "🎉 New achievement **@everyone 読書** (+10 pt)" {
+		t.Errorf("Unexpected content: %v", received["content"])
+	}
+	// タイトルに含まれるメンションで通知しない
+	mentions, _ := received["allowed_mentions"].(map[string]interface{})
+	if parse, ok := mentions["parse"].([]interface{}); !ok || len(parse) != 0 {
+		t.Errorf("Expected mentions to be disabled, got %v", received["allowed_mentions"])
+	}
+}
+
+func TestDiscordMessageText_AchievementCreated(t *testing.T) {
+	text, ok := discordMessageText(events.Event{
+		Type: "achievements.created",
+		Item: map[string]interface{}{"title": "読書", "point": 10, "description": "30分読む"},
+	})
+	if !ok || text != "🎉 New achievement **読書** (+10 pt)\n30分読む" {
+		t.Errorf("Unexpected message %q", text)
+	}
+}
@@ -0,0 +1,84 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"achievement-management/internal/events"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// emailTemplates イベントの種類ごとのメールのテンプレート（subject と body を定義する）
+var emailTemplates = parseEmailTemplates("achievements.created", "rewards.redeemed", "points.low_balance", "goals.reached", "summaries.generated")
+
+// parseEmailTemplates templates/{種類}.tmpl を読み込み
+func parseEmailTemplates(eventTypes ...string) map[string]*template.Template {
+	templates := make(map[string]*template.Template, len(eventTypes))
+	for _, eventType := range eventTypes {
+		templates[eventType] = template.Must(template.ParseFS(templateFS, "templates/"+eventType+".tmpl"))
+	}
+	return templates
+}
+
+// Email 送信するメール（本文はプレーンテキスト）
+type Email struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// EmailSender メールの送信方法
+type EmailSender interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// EmailPublisher イベントをテンプレートでメールにして送信する配信先
+type EmailPublisher struct {
+	sender EmailSender
+	from   string
+	to     []string
+}
+
+// NewEmailPublisher from から to にメールを送信する配信先を作成
+func NewEmailPublisher(sender EmailSender, from string, to []string) *EmailPublisher {
+	return &EmailPublisher{sender: sender, from: from, to: to}
+}
+
+// Publish イベントをメールにして送信（テンプレートの無い種類のイベントと、日ごとのサマリーは送信しない）
+func (p *EmailPublisher) Publish(ctx context.Context, event events.Event) error {
+	tmpl, ok := emailTemplates[event.Type]
+	if !ok {
+		return nil
+	}
+	// 日ごとのサマリーは毎日届くため、チャットにのみ投稿する
+	if event.Type == "summaries.generated" && event.Item["period"] != "weekly" {
+		return nil
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", event.Item); err != nil {
+		return fmt.Errorf("failed to render email subject for event %s: %w", event.ID, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", event.Item); err != nil {
+		return fmt.Errorf("failed to render email body for event %s: %w", event.ID, err)
+	}
+
+	email := Email{
+		From: p.from,
+		To:   p.to,
+		// 件名は1行にする
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    body.String(),
+	}
+	if err := p.sender.Send(ctx, email); err != nil {
+		return fmt.Errorf("failed to email event %s: %w", event.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"achievement-management/internal/events"
+)
+
+// recordingSender 送信したメールを記録する送信方法
+type recordingSender struct {
+	sent []Email
+}
+
+func (s *recordingSender) Send(ctx context.Context, email Email) error {
+	s.sent = append(s.sent, email)
+	return nil
+}
+
+func TestEmailPublisher_Publish(t *testing.T) {
+	sender := &recordingSender{}
+	publisher := NewEmailPublisher(sender, "app@example.com", []string{"family@example.com"})
+	ctx := context.Background()
+
+	goal := events.Event{
+		ID:   "goals.reached:g1",
+		Type: "goals.reached",
+		Item: map[string]interface{}{"title": "Save 500 pt", "type": "points", "target": 500, "current": 520, "description": ""},
+	}
+	if err := publisher.Publish(ctx, goal); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	summary := func(period string) events.Event {
+		return events.Event{
+			ID:   "summaries.generated:" + period,
+			Type: "summaries.generated",
+			Item: map[string]interface{}{
+				"period": period, "from": "2025-01-06", "to": "2025-01-12", "text": "In the last 7 days you earned 120 points.",
+				"points_earned": 120, "achievements": 4, "completions": 6, "points_spent": 30, "redemptions": 1, "balance": 250,
+			},
+		}
+	}
+	// 日ごとのサマリーは送信しない
+	for _, event := range []events.Event{summary("daily"), summary("weekly"), {ID: "reminder", Type: "achievements.reminder"}} {
+		if err := publisher.Publish(ctx, event); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+
+	if len(sender.sent) != 2 {
+		t.Fatalf("Expected 2 emails, got %d", len(sender.sent))
+	}
+	if sender.sent[0].Subject != "Goal reached: Save 500 pt" || !strings.Contains(sender.sent[0].Body, "Target:  500 points") {
+		t.Errorf("Unexpected goal email: %+v", sender.sent[0])
+	}
+	if sender.sent[1].Subject != "Weekly summary 2025-01-06 - 2025-01-12" || !strings.Contains(sender.sent[1].Body, "Balance:       250") {
+		t.Errorf("Unexpected summary email: %+v", sender.sent[1])
+	}
+	if sender.sent[1].From != "app@example.com" || sender.sent[1].To[0] != "family@example.com" {
+		t.Errorf("Unexpected addresses: %+v", sender.sent[1])
+	}
+}
+
+func TestEmailTemplates(t *testing.T) {
+	// 通知できるすべてのイベントにテンプレートがある
+	for _, eventType := range []string{"achievements.created", "rewards.redeemed", "points.low_balance", "goals.reached", "summaries.generated"} {
+		tmpl, ok := emailTemplates[eventType]
+		if !ok {
+			t.Errorf("Missing email template for %s", eventType)
+			continue
+		}
+		for _, name := range []string{"subject", "body"} {
+			if tmpl.Lookup(name) == nil {
+				t.Errorf("Email template for %s does not define %s", eventType, name)
+			}
+		}
+	}
+}
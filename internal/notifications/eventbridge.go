@@ -0,0 +1,74 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+
+	"achievement-management/internal/events"
+)
+
+// EventBridgePublisher Amazon EventBridge のイベントバスにイベントを送信する配信先
+//
+// detail-type は「{プレフィックス}{イベントの種類}」（AchievementManagement.achievements.created など）、
+// detail はイベントのJSON。実行するロールには events:PutEvents の権限が必要。
+type EventBridgePublisher struct {
+	client           *eventbridge.Client
+	busName          string
+	source           string
+	detailTypePrefix string
+}
+
+// NewEventBridgePublisher busName のイベントバスに source として送信する配信先を作成（endpoint が空の場合はリージョンのエンドポイント）
+func NewEventBridgePublisher(awsConfig aws.Config, busName, source, detailTypePrefix, endpoint string, client *http.Client) *EventBridgePublisher {
+	return &EventBridgePublisher{
+		client: eventbridge.NewFromConfig(awsConfig, func(o *eventbridge.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+			}
+			if client != nil {
+				o.HTTPClient = client
+			}
+		}),
+		busName:          busName,
+		source:           source,
+		detailTypePrefix: detailTypePrefix,
+	}
+}
+
+// Publish イベントをイベントバスに送信
+func (p *EventBridgePublisher) Publish(ctx context.Context, event events.Event) error {
+	detail, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+	}
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	// 失敗したイベントはエラーにならず ErrorCode で返される
+	output, err := p.client.PutEvents(ctx, &eventbridge.PutEventsInput{
+		Entries: []types.PutEventsRequestEntry{{
+			EventBusName: aws.String(p.busName),
+			Source:       aws.String(p.source),
+			DetailType:   aws.String(p.detailTypePrefix + event.Type),
+			Detail:       aws.String(string(detail)),
+			Time:         aws.Time(occurredAt),
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put event %s to eventbridge: %w", event.ID, err)
+	}
+	if output.FailedEntryCount > 0 && len(output.Entries) > 0 {
+		entry := output.Entries[0]
+		return fmt.Errorf("eventbridge rejected event %s: %s: %s", event.ID, aws.ToString(entry.ErrorCode), aws.ToString(entry.ErrorMessage))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/events"
+)
+
+func TestEventBridgePublisher_Publish(t *testing.T) {
+	var target, authorization string
+	var request struct {
+		Entries []struct {
+			EventBusName string  `json:"EventBusName"`
+			Source       string  `json:"Source"`
+			DetailType   string  `json:"DetailType"`
+			Detail       string  `json:"Detail"`
+			Time         float64 `json:"Time"`
+		} `json:"Entries"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.Header.Get("X-Amz-Target")
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.Write([]byte(`{"FailedEntryCount": 0, "Entries": [{"EventId": "event-1"}]}`))
+	}))
+	defer server.Close()
+
+	occurredAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	publisher := NewEventBridgePublisher(testAWSConfig(), "family", "achievement-management", "AchievementManagement.", server.URL, nil)
+	err := publisher.Publish(context.Background(), events.Event{
+		ID:         "1",
+		Type:       "achievements.created",
+		Item:       map[string]interface{}{"title": "Run 5km", "point": 50},
+		OccurredAt: occurredAt,
+	})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if target != "AWSEvents.PutEvents" {
+		t.Errorf("Unexpected target %q", target)
+	}
+	if !strings.Contains(authorization, "/ap-northeast-1/events/aws4_request") {
+		t.Errorf("Expected a SigV4 signature for events, got %q", authorization)
+	}
+	if len(request.Entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(request.Entries))
+	}
+	entry := request.Entries[0]
+	if entry.EventBusName != "family" || entry.Source != "achievement-management" || entry.DetailType != "AchievementManagement.achievements.created" || int64(entry.Time) != occurredAt.Unix() {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	var detail events.Event
+	if err := json.Unmarshal([]byte(entry.Detail), &detail); err != nil || detail.Item["title"] != "Run 5km" {
+		t.Errorf("Expected the event as detail, got %s", entry.Detail)
+	}
+}
+
+func TestEventBridgePublisher_FailedEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"FailedEntryCount": 1, "Entries": [{"ErrorCode": "NotAuthorizedForSourceException", "ErrorMessage": "Not authorized for the source."}]}`))
+	}))
+	defer server.Close()
+
+	publisher := NewEventBridgePublisher(testAWSConfig(), "default", "achievement-management", "", server.URL, nil)
+	err := publisher.Publish(context.Background(), events.Event{ID: "1", Type: "rewards.redeemed"})
+	if err == nil || !strings.Contains(err.Error(), "NotAuthorizedForSourceException") {
+		t.Errorf("Expected the failed entry error, got %v", err)
+	}
+}
+
+func TestEventBridgePublisher_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type": "ResourceNotFoundException", "Message": "Event bus family does not exist."}`))
+	}))
+	defer server.Close()
+
+	publisher := NewEventBridgePublisher(testAWSConfig(), "family", "achievement-management", "", server.URL, nil)
+	err := publisher.Publish(context.Background(), events.Event{ID: "1", Type: "rewards.redeemed"})
+	if err == nil || !strings.Contains(err.Error(), "Event bus family does not exist.") {
+		t.Errorf("Expected the eventbridge error message, got %v", err)
+	}
+}
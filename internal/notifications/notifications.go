@@ -0,0 +1,168 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/events"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/repository"
+)
+
+// New notifications の設定で有効にしたチャンネルに配信する配信先を作成（有効なチャンネルが無い場合はnil）
+func New(ctx context.Context, appConfig *config.Config) (events.Publisher, error) {
+	cfg := appConfig.Notifications
+
+	var channels []events.Publisher
+	if cfg.Slack.WebhookURL != "" {
+		channels = append(channels, Filter(cfg.Slack.Events, NewSlackPublisher(cfg.Slack.WebhookURL, cfg.Slack.Channel, nil)))
+	}
+	if cfg.Discord.WebhookURL != "" {
+		channels = append(channels, Filter(cfg.Discord.Events, NewDiscordPublisher(cfg.Discord.WebhookURL, nil)))
+	}
+
+	var sender EmailSender
+	switch cfg.Email.Provider {
+	case config.EmailProviderSMTP:
+		sender = NewSMTPSender(cfg.Email.SMTPHost, cfg.Email.SMTPPort, cfg.Email.SMTPUsername, cfg.Email.SMTPPassword)
+	case config.EmailProviderSES:
+		awsConfig, err := repository.LoadAWSConfig(ctx, appConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load aws config for ses: %w", err)
+		}
+		sender = NewSESSender(awsConfig, cfg.Email.SESEndpoint, nil)
+	}
+	if sender != nil {
+		channels = append(channels, Filter(cfg.Email.Events, NewEmailPublisher(sender, cfg.Email.From, cfg.Email.To)))
+	}
+
+	if cfg.EventBridge.BusName != "" {
+		awsConfig, err := repository.LoadAWSConfig(ctx, appConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load aws config for eventbridge: %w", err)
+		}
+		publisher := NewEventBridgePublisher(awsConfig, cfg.EventBridge.BusName, cfg.EventBridge.Source, cfg.EventBridge.DetailTypePrefix, cfg.EventBridge.Endpoint, nil)
+		channels = append(channels, Filter(cfg.EventBridge.Events, publisher))
+	}
+
+	// SNS・SQSへはまとめて送信する（送信できなかったイベントはデッドレターキューに送る）
+	var batches []*BatchPublisher
+	if cfg.SNS.TopicARN != "" || cfg.SQS.QueueURL != "" {
+		awsConfig, err := repository.LoadAWSConfig(ctx, appConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load aws config for sns/sqs: %w", err)
+		}
+		logger, err := logging.NewLogger(appConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create logger for sns/sqs: %w", err)
+		}
+		deadLetter := func(queueURL string) BatchSender {
+			if queueURL == "" {
+				return nil
+			}
+			return NewSQSSender(awsConfig, queueURL, cfg.SQS.Endpoint, nil)
+		}
+		wait := time.Duration(cfg.BatchWaitMs) * time.Millisecond
+		if cfg.SNS.TopicARN != "" {
+			sns := NewSNSSender(awsConfig, cfg.SNS.TopicARN, cfg.SNS.Endpoint, nil)
+			batches = append(batches, NewBatchPublisher(sns, deadLetter(cfg.SNS.DeadLetterQueueURL), cfg.BatchSize, wait, logger.WithField("channel", "sns")))
+			channels = append(channels, Filter(cfg.SNS.Events, batches[len(batches)-1]))
+		}
+		if cfg.SQS.QueueURL != "" {
+			sqs := NewSQSSender(awsConfig, cfg.SQS.QueueURL, cfg.SQS.Endpoint, nil)
+			batches = append(batches, NewBatchPublisher(sqs, deadLetter(cfg.SQS.DeadLetterQueueURL), cfg.BatchSize, wait, logger.WithField("channel", "sqs")))
+			channels = append(channels, Filter(cfg.SQS.Events, batches[len(batches)-1]))
+		}
+	}
+
+	if len(channels) == 0 {
+		return nil, nil
+	}
+	if len(batches) == 0 {
+		return events.NewBus(channels...), nil
+	}
+	return &batchingBus{Bus: events.NewBus(channels...), batches: batches}, nil
+}
+
+// batchingBus まとめて送信するチャンネルを含む配信先（終了する前に Flush で保留中のイベントを送信する）
+type batchingBus struct {
+	*events.Bus
+	batches []*BatchPublisher
+}
+
+// Flush まとめて送信するチャンネルの保留中のイベントを送信
+func (b *batchingBus) Flush(ctx context.Context) error {
+	var errs []error
+	for _, batch := range b.batches {
+		errs = append(errs, batch.Flush(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// Flush New で作成した notifier が保留中のイベント（SNS・SQSにまとめて送信するイベント）を送信
+//
+// プロセスを終了する前や、Lambdaの呼び出しごとに呼び出す。notifier がnilの場合や保留するチャンネルが無い場合は何もしない。
+func Flush(ctx context.Context, notifier events.Publisher) error {
+	if flusher, ok := notifier.(interface{ Flush(context.Context) error }); ok {
+		return flusher.Flush(ctx)
+	}
+	return nil
+}
+
+// Subscribe bus に notifier を購読者として追加（notifier がnilの場合は追加しない）
+//
+// 目標の達成・サマリーなど、Webhookに配信するイベントを通知にも配信する場合に使用する。
+func Subscribe(bus *events.Bus, notifier events.Publisher) *events.Bus {
+	if notifier != nil {
+		bus.Subscribe(notifier)
+	}
+	return bus
+}
+
+// Filter types の種類のイベントのみ next に配信する配信先を作成（types が空の場合はすべて配信する）
+func Filter(types []string, next events.Publisher) events.Publisher {
+	if len(types) == 0 {
+		return next
+	}
+	allowed := make(map[string]bool, len(types))
+	for _, eventType := range types {
+		allowed[eventType] = true
+	}
+	return events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		if !allowed[event.Type] {
+			return nil
+		}
+		return next.Publish(ctx, event)
+	})
+}
+
+// Message イベントを投稿するメッセージ（通知しない種類のイベントの場合は false）
+//
+// 強調は * で囲む（Slack の mrkdwn 形式）。
+func Message(event events.Event) (string, bool) {
+	item := event.Item
+	switch event.Type {
+	case "achievements.created":
+		text := fmt.Sprintf(":tada: New achievement *%v* (+%v pt)", item["title"], item["point"])
+		if description, _ := item["description"].(string); description != "" {
+			text += "\n" + description
+		}
+		return text, true
+	case "rewards.redeemed":
+		text := fmt.Sprintf(":gift: Redeemed *%v* for %v pt", item["reward_title"], item["point_cost"])
+		if prize, _ := item["prize"].(string); prize != "" {
+			text += fmt.Sprintf(" and won *%s*", prize)
+		}
+		return text, true
+	case "points.low_balance":
+		return fmt.Sprintf(":warning: Only %v pt left after redeeming *%v* (below %v pt)", item["point"], item["reward_title"], item["threshold"]), true
+	case "goals.reached":
+		return fmt.Sprintf(":trophy: Goal reached *%v* (%v %v)", item["title"], item["target"], item["type"]), true
+	case "summaries.generated":
+		return fmt.Sprintf(":bar_chart: %v", item["text"]), true
+	}
+	return "", false
+}
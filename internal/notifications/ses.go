@@ -0,0 +1,50 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// SESSender Amazon SES（API v2 の SendEmail）でメールを送信する
+//
+// 実行するロールには ses:SendEmail の権限が必要。
+type SESSender struct {
+	client *sesv2.Client
+}
+
+// NewSESSender awsConfig のリージョン・認証情報で送信する SESSender を作成（endpoint が空の場合はリージョンのエンドポイント）
+func NewSESSender(awsConfig aws.Config, endpoint string, client *http.Client) *SESSender {
+	return &SESSender{client: sesv2.NewFromConfig(awsConfig, func(o *sesv2.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		if client != nil {
+			o.HTTPClient = client
+		}
+	})}
+}
+
+// Send メールを送信
+func (s *SESSender) Send(ctx context.Context, email Email) error {
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(email.From),
+		Destination:      &types.Destination{ToAddresses: email.To},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(email.Subject), Charset: aws.String("UTF-8")},
+				Body: &types.Body{
+					Text: &types.Content{Data: aws.String(email.Body), Charset: aws.String("UTF-8")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
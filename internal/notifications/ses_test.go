@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func testAWSConfig() aws.Config {
+	return aws.Config{
+		Region: "ap-northeast-1",
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		}),
+	}
+}
+
+func TestSESSender_Send(t *testing.T) {
+	var path, authorization string
+	var request struct {
+		FromEmailAddress string `json:"FromEmailAddress"`
+		Content          struct {
+			Simple struct {
+				Subject struct {
+					Data string `json:"Data"`
+				} `json:"Subject"`
+			} `json:"Simple"`
+		} `json:"Content"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.Write([]byte(`{"MessageId": "message-1"}`))
+	}))
+	defer server.Close()
+
+	err := NewSESSender(testAWSConfig(), server.URL, nil).Send(context.Background(), Email{
+		From:    "app@example.com",
+		To:      []string{"family@example.com"},
+		Subject: "Weekly summary",
+		Body:    "In the last 7 days you earned 120 points.",
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if path != "/v2/email/outbound-emails" {
+		t.Errorf("Unexpected path %s", path)
+	}
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(authorization, "/ap-northeast-1/ses/aws4_request") {
+		t.Errorf("Expected a SigV4 signature for ses, got %q", authorization)
+	}
+	if request.FromEmailAddress != "app@example.com" || request.Content.Simple.Subject.Data != "Weekly summary" {
+		t.Errorf("Unexpected request: %+v", request)
+	}
+}
+
+func TestSESSender_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "Email address is not verified."}`))
+	}))
+	defer server.Close()
+
+	err := NewSESSender(testAWSConfig(), server.URL, nil).Send(context.Background(), Email{From: "app@example.com", To: []string{"family@example.com"}})
+	if err == nil || !strings.Contains(err.Error(), "Email address is not verified.") {
+		t.Errorf("Expected the ses error message, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"achievement-management/internal/events"
+)
+
+// defaultTimeout 通知の送信のタイムアウト
+const defaultTimeout = 10 * time.Second
+
+// SlackPublisher イベントをメッセージにしてSlackのIncoming Webhookに投稿する配信先
+type SlackPublisher struct {
+	url string
+	// channel 投稿先のチャンネル（空の場合はWebhookに設定したチャンネル）
+	channel string
+	client  *http.Client
+}
+
+// slackMessage Incoming Webhookに送るメッセージ
+type slackMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+// NewSlackPublisher Slackの配信先を作成（clientがnilの場合はタイムアウト付きのクライアントを使用）
+func NewSlackPublisher(url, channel string, client *http.Client) *SlackPublisher {
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	return &SlackPublisher{url: url, channel: channel, client: client}
+}
+
+// Publish イベントをメッセージにして投稿（メッセージにしない種類のイベントは投稿しない）
+func (p *SlackPublisher) Publish(ctx context.Context, event events.Event) error {
+	text, ok := Message(event)
+	if !ok {
+		return nil
+	}
+
+	body, err := json.Marshal(slackMessage{Channel: p.channel, Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack message for event %s: %w", event.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// URLにはトークンが含まれるため、エラーには含めない
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to post event %s to slack: %w", event.ID, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack responded with status %d for event %s", resp.StatusCode, event.ID)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/events"
+)
+
+func TestSlackPublisher_Publish(t *testing.T) {
+	var received []slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message slackMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		received = append(received, message)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	publisher := NewSlackPublisher(server.URL, "#kudos", nil)
+	event := events.Event{
+		ID:   "rewards.redeemed:h1",
+		Type: "rewards.redeemed",
+		Item: map[string]interface{}{"reward_title": "コーヒー", "point_cost": 30, "prize": ""},
+	}
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	// メッセージにしない種類のイベントは投稿しない
+	if err := publisher.Publish(context.Background(), events.Event{ID: "goal", Type: "achievements.reminder"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(received))
+	}
+	if received[0].Channel != "#kudos" || received[0].Text != ":gift: Redeemed *コーヒー* for 30 pt" {
+		t.Errorf("Unexpected message: %+v", received[0])
+	}
+}
+
+func TestSlackPublisher_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := NewSlackPublisher(server.URL, "", nil).Publish(context.Background(), events.Event{ID: "a1", Type: "achievements.created"})
+	if err == nil || strings.Contains(err.Error(), server.URL) {
+		t.Errorf("Expected an error without the webhook url, got %v", err)
+	}
+}
+
+func TestNew_FiltersEvents(t *testing.T) {
+	ctx := context.Background()
+	if notifier, err := New(ctx, &config.Config{}); err != nil || notifier != nil {
+		t.Errorf("Expected no notifier without channels, got %v, %v", notifier, err)
+	}
+
+	var types []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message slackMessage
+		_ = json.NewDecoder(r.Body).Decode(&message)
+		types = append(types, message.Text)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier, err := New(ctx, &config.Config{Notifications: config.NotificationsConfig{Slack: config.SlackNotificationsConfig{
+		WebhookURL: server.URL,
+		Events:     []string{"points.low_balance"},
+	}}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	_ = notifier.Publish(ctx, events.Event{Type: "achievements.created", Item: map[string]interface{}{"title": "読書", "point": 10}})
+	_ = notifier.Publish(ctx, events.Event{Type: "points.low_balance", Item: map[string]interface{}{"point": 20, "reward_title": "映画", "threshold": 50}})
+
+	if len(types) != 1 || !strings.Contains(types[0], "Only 20 pt left") {
+		t.Errorf("Expected only the low balance message, got %v", types)
+	}
+}
+
+func TestMessage_AchievementCreated(t *testing.T) {
+	text, ok := Message(events.Event{
+		Type: "achievements.created",
+		Item: map[string]interface{}{"title": "読書", "point": 10, "description": "30分読む"},
+	})
+	if !ok || text != ":tada: New achievement *読書* (+10 pt)\n30分読む" {
+		t.Errorf("Unexpected message %q", text)
+	}
+}
+
+func TestMessage_Unsupported(t *testing.T) {
+	if _, ok := Message(events.Event{Type: "achievements.reminder"}); ok {
+		t.Error("Expected no message for an unsupported event")
+	}
+}
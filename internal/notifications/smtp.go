@@ -0,0 +1,123 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPSender SMTPサーバーからメールを送信する（サーバーが対応している場合はSTARTTLSで暗号化する）
+type SMTPSender struct {
+	host string
+	addr string
+	// auth ユーザー名を設定した場合のPLAIN認証（net/smtp はTLS以外の接続ではlocalhost以外への認証を拒否する）
+	auth smtp.Auth
+	now  func() time.Time
+}
+
+// NewSMTPSender host:port のSMTPサーバーから送信する SMTPSender を作成（username が空の場合は認証しない）
+func NewSMTPSender(host string, port int, username, password string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		host: host,
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		now:  time.Now,
+	}
+}
+
+// Send メールを送信
+func (s *SMTPSender) Send(ctx context.Context, email Email) error {
+	dialer := &net.Dialer{Timeout: defaultTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server %s: %w", s.addr, err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = s.now().Add(defaultTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session with %s: %w", s.addr, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("failed to start tls with %s: %w", s.addr, err)
+		}
+	}
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return fmt.Errorf("failed to authenticate with %s: %w", s.addr, err)
+		}
+	}
+
+	if err := client.Mail(envelopeAddress(email.From)); err != nil {
+		return fmt.Errorf("smtp server rejected sender %s: %w", email.From, err)
+	}
+	for _, to := range email.To {
+		if err := client.Rcpt(envelopeAddress(to)); err != nil {
+			return fmt.Errorf("smtp server rejected recipient %s: %w", to, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start smtp data: %w", err)
+	}
+	if _, err := w.Write(s.message(email)); err != nil {
+		return fmt.Errorf("failed to write smtp data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected the message: %w", err)
+	}
+	return client.Quit()
+}
+
+// envelopeAddress 「名前 <アドレス>」形式のアドレスからSMTPのエンベロープに使うアドレスを取り出す
+func envelopeAddress(address string) string {
+	if parsed, err := mail.ParseAddress(address); err == nil {
+		return parsed.Address
+	}
+	return address
+}
+
+// message ヘッダーとBase64の本文からなるメッセージ（件名と本文は日本語を含められるようにUTF-8で符号化する）
+func (s *SMTPSender) message(email Email) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", email.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(email.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	// 1行76文字以内に折り返す
+	encoded := base64.StdEncoding.EncodeToString([]byte(email.Body))
+	for len(encoded) > 76 {
+		msg.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	msg.WriteString(encoded + "\r\n")
+	return msg.Bytes()
+}
@@ -0,0 +1,97 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// serveSMTP 1通だけ受け取るSMTPサーバー（STARTTLS・認証には対応しない）を起動し、受け取ったコマンドとデータを返す
+func serveSMTP(t *testing.T) (string, int, <-chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	received := make(chan []string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var lines []string
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250 localhost")
+			case line == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := reader.ReadString('\n')
+					if err != nil || data == ".\r\n" {
+						break
+					}
+					lines = append(lines, strings.TrimRight(data, "\r\n"))
+				}
+				reply("250 queued")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+		received <- lines
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return host, portNumber, received
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	host, port, received := serveSMTP(t)
+
+	sender := NewSMTPSender(host, port, "", "")
+	err := sender.Send(context.Background(), Email{
+		From:    "Achievements <app@example.com>",
+		To:      []string{"alice@example.com", "bob@example.com"},
+		Subject: "目標を達成しました",
+		Body:    "Goal reached",
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	lines := strings.Join(<-received, "\n")
+	for _, expected := range []string{
+		"MAIL FROM:<app@example.com>",
+		"From: Achievements <app@example.com>",
+		"RCPT TO:<alice@example.com>",
+		"RCPT TO:<bob@example.com>",
+		"To: alice@example.com, bob@example.com",
+		"Subject: =?utf-8?q?",
+		"Content-Type: text/plain; charset=UTF-8",
+		base64.StdEncoding.EncodeToString([]byte("Goal reached")),
+	} {
+		if !strings.Contains(lines, expected) {
+			t.Errorf("Expected %q in the smtp session:\n%s", expected, lines)
+		}
+	}
+}
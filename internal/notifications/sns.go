@@ -0,0 +1,82 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+
+	"achievement-management/internal/events"
+)
+
+// SNSSender Amazon SNS のトピックにイベントをまとめて送信する（PublishBatch）
+//
+// メッセージはイベントのJSONで、イベントの種類を event_type 属性に設定する（サブスクリプションのフィルターポリシーで絞り込める）。
+// FIFOトピックの場合はテーブルごとのメッセージグループにし、イベントのIDで重複を除く。
+// 実行するロールには sns:Publish の権限が必要。
+type SNSSender struct {
+	client   *sns.Client
+	topicARN string
+	fifo     bool
+}
+
+// NewSNSSender topicARN のトピックに送信する SNSSender を作成（endpoint が空の場合はリージョンのエンドポイント）
+func NewSNSSender(awsConfig aws.Config, topicARN, endpoint string, client *http.Client) *SNSSender {
+	return &SNSSender{
+		client: sns.NewFromConfig(awsConfig, func(o *sns.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+			}
+			if client != nil {
+				o.HTTPClient = client
+			}
+		}),
+		topicARN: topicARN,
+		fifo:     strings.HasSuffix(topicARN, ".fifo"),
+	}
+}
+
+// SendBatch イベントをトピックに送信
+func (s *SNSSender) SendBatch(ctx context.Context, batch []events.Event) ([]events.Event, error) {
+	entries := make([]types.PublishBatchRequestEntry, 0, len(batch))
+	for i, event := range batch {
+		message, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+		}
+		entry := types.PublishBatchRequestEntry{
+			Id:      aws.String(strconv.Itoa(i)),
+			Message: aws.String(string(message)),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				eventTypeAttribute: {DataType: aws.String("String"), StringValue: aws.String(event.Type)},
+			},
+		}
+		if s.fifo {
+			entry.MessageGroupId = aws.String(messageGroup(event))
+			entry.MessageDeduplicationId = aws.String(event.ID)
+		}
+		entries = append(entries, entry)
+	}
+
+	// 失敗したメッセージはエラーにならず Failed で返される
+	output, err := s.client.PublishBatch(ctx, &sns.PublishBatchInput{
+		TopicArn:                   aws.String(s.topicARN),
+		PublishBatchRequestEntries: entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish events to sns: %w", err)
+	}
+	var failed []events.Event
+	for _, entry := range output.Failed {
+		if i, err := strconv.Atoi(aws.ToString(entry.Id)); err == nil && i < len(batch) {
+			failed = append(failed, batch[i])
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,78 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"achievement-management/internal/events"
+)
+
+func TestSNSSender_SendBatch(t *testing.T) {
+	var form map[string][]string
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		form = r.PostForm
+		w.Write([]byte(`<PublishBatchResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/">
+  <PublishBatchResult>
+    <Successful><member><Id>1</Id><MessageId>message-1</MessageId></member></Successful>
+    <Failed><member><Id>0</Id><Code>InternalError</Code><Message>retry</Message><SenderFault>false</SenderFault></member></Failed>
+  </PublishBatchResult>
+</PublishBatchResponse>`))
+	}))
+	defer server.Close()
+
+	topicARN := "arn:aws:sns:ap-northeast-1:123456789012:events"
+	batch := []events.Event{
+		{ID: "1", Type: "achievements.created", Table: "achievements"},
+		{ID: "2", Type: "rewards.redeemed", Table: "reward_history"},
+	}
+	failed, err := NewSNSSender(testAWSConfig(), topicARN, server.URL, nil).SendBatch(context.Background(), batch)
+	if err != nil {
+		t.Fatalf("SendBatch failed: %v", err)
+	}
+
+	if !strings.Contains(authorization, "/ap-northeast-1/sns/aws4_request") {
+		t.Errorf("Expected a SigV4 signature for sns, got %q", authorization)
+	}
+	get := func(key string) string {
+		if values := form[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	if get("Action") != "PublishBatch" || get("TopicArn") != topicARN {
+		t.Errorf("Unexpected request: %v", form)
+	}
+	if get("PublishBatchRequestEntries.member.2.MessageAttributes.entry.1.Value.StringValue") != "rewards.redeemed" {
+		t.Errorf("Expected the event type attribute, got %v", form)
+	}
+	if !strings.Contains(get("PublishBatchRequestEntries.member.1.Message"), `"type":"achievements.created"`) {
+		t.Errorf("Expected the event as the message, got %v", form)
+	}
+	if get("PublishBatchRequestEntries.member.1.MessageGroupId") != "" {
+		t.Error("Expected no message group for a standard topic")
+	}
+	if len(failed) != 1 || failed[0].ID != "1" {
+		t.Errorf("Expected the first event to fail, got %v", failed)
+	}
+}
+
+func TestSNSSender_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>NotFound</Code><Message>Topic does not exist</Message></Error></ErrorResponse>`))
+	}))
+	defer server.Close()
+
+	_, err := NewSNSSender(testAWSConfig(), "arn:aws:sns:ap-northeast-1:123456789012:missing", server.URL, nil).SendBatch(context.Background(), []events.Event{{ID: "1"}})
+	if err == nil || !strings.Contains(err.Error(), "Topic does not exist") {
+		t.Errorf("Expected the sns error message, got %v", err)
+	}
+}
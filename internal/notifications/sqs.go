@@ -0,0 +1,92 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"achievement-management/internal/events"
+)
+
+// eventTypeAttribute イベントの種類を設定するメッセージ属性（SNSのサブスクリプションのフィルターなどに使用する）
+const eventTypeAttribute = "event_type"
+
+// SQSSender Amazon SQS のキューにイベントをまとめて送信する（SendMessageBatch）
+//
+// 本文はイベントのJSON。FIFOキューの場合はテーブルごとのメッセージグループにし、イベントのIDで重複を除く。
+// 実行するロールには sqs:SendMessage の権限が必要。
+type SQSSender struct {
+	client   *sqs.Client
+	queueURL string
+	fifo     bool
+}
+
+// NewSQSSender queueURL のキューに送信する SQSSender を作成（endpoint が空の場合はリージョンのエンドポイント）
+func NewSQSSender(awsConfig aws.Config, queueURL, endpoint string, client *http.Client) *SQSSender {
+	return &SQSSender{
+		client: sqs.NewFromConfig(awsConfig, func(o *sqs.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+			}
+			if client != nil {
+				o.HTTPClient = client
+			}
+		}),
+		queueURL: queueURL,
+		fifo:     strings.HasSuffix(queueURL, ".fifo"),
+	}
+}
+
+// SendBatch イベントをキューに送信
+func (s *SQSSender) SendBatch(ctx context.Context, batch []events.Event) ([]events.Event, error) {
+	entries := make([]types.SendMessageBatchRequestEntry, 0, len(batch))
+	for i, event := range batch {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+		}
+		entry := types.SendMessageBatchRequestEntry{
+			Id:          aws.String(strconv.Itoa(i)),
+			MessageBody: aws.String(string(body)),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				eventTypeAttribute: {DataType: aws.String("String"), StringValue: aws.String(event.Type)},
+			},
+		}
+		if s.fifo {
+			entry.MessageGroupId = aws.String(messageGroup(event))
+			entry.MessageDeduplicationId = aws.String(event.ID)
+		}
+		entries = append(entries, entry)
+	}
+
+	// 失敗したメッセージはエラーにならず Failed で返される
+	output, err := s.client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+		QueueUrl: aws.String(s.queueURL),
+		Entries:  entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send events to sqs: %w", err)
+	}
+	var failed []events.Event
+	for _, entry := range output.Failed {
+		if i, err := strconv.Atoi(aws.ToString(entry.Id)); err == nil && i < len(batch) {
+			failed = append(failed, batch[i])
+		}
+	}
+	return failed, nil
+}
+
+// messageGroup FIFOのトピック・キューでイベントの順序を保証する単位（テーブルごと）
+func messageGroup(event events.Event) string {
+	if event.Table != "" {
+		return event.Table
+	}
+	return event.Type
+}
@@ -0,0 +1,73 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"achievement-management/internal/events"
+)
+
+func TestSQSSender_SendBatch(t *testing.T) {
+	var target, authorization string
+	var request struct {
+		QueueURL string `json:"QueueUrl"`
+		Entries  []struct {
+			ID                string `json:"Id"`
+			MessageAttributes map[string]struct {
+				StringValue string `json:"StringValue"`
+			} `json:"MessageAttributes"`
+			MessageGroupID         string `json:"MessageGroupId"`
+			MessageDeduplicationID string `json:"MessageDeduplicationId"`
+		} `json:"Entries"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.Header.Get("X-Amz-Target")
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.Write([]byte(`{"Successful": [{"Id": "0"}], "Failed": [{"Id": "1", "Code": "InternalError", "Message": "retry"}]}`))
+	}))
+	defer server.Close()
+
+	queueURL := "https://sqs.ap-northeast-1.amazonaws.com/123456789012/events.fifo"
+	batch := []events.Event{
+		{ID: "1", Type: "achievements.created", Table: "achievements"},
+		{ID: "2", Type: "rewards.redeemed", Table: "reward_history"},
+	}
+	failed, err := NewSQSSender(testAWSConfig(), queueURL, server.URL, nil).SendBatch(context.Background(), batch)
+	if err != nil {
+		t.Fatalf("SendBatch failed: %v", err)
+	}
+
+	if target != "AmazonSQS.SendMessageBatch" || !strings.Contains(authorization, "/ap-northeast-1/sqs/aws4_request") {
+		t.Errorf("Unexpected target %q or signature %q", target, authorization)
+	}
+	if request.QueueURL != queueURL || len(request.Entries) != 2 {
+		t.Fatalf("Unexpected request: %+v", request)
+	}
+	entry := request.Entries[1]
+	if entry.ID != "1" || entry.MessageAttributes[eventTypeAttribute].StringValue != "rewards.redeemed" || entry.MessageGroupID != "reward_history" || entry.MessageDeduplicationID != "2" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if len(failed) != 1 || failed[0].ID != "2" {
+		t.Errorf("Expected the second event to fail, got %v", failed)
+	}
+}
+
+func TestSQSSender_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type": "com.amazonaws.sqs#QueueDoesNotExist", "message": "The specified queue does not exist."}`))
+	}))
+	defer server.Close()
+
+	_, err := NewSQSSender(testAWSConfig(), "https://sqs.example.com/1/events", server.URL, nil).SendBatch(context.Background(), []events.Event{{ID: "1"}})
+	if err == nil || !strings.Contains(err.Error(), "The specified queue does not exist.") {
+		t.Errorf("Expected the sqs error message, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"achievement-management/internal/models"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Format レポートの出力形式
+type Format string
+
+const (
+	// FormatMarkdown Markdown形式
+	FormatMarkdown Format = "markdown"
+	// FormatHTML HTML形式
+	FormatHTML Format = "html"
+)
+
+// Translator ラベルのローカライズ関数
+type Translator func(key string, args ...interface{}) string
+
+// ParseFormat 文字列から出力形式を判定
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "html":
+		return FormatHTML, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s (must be one of: html, markdown)", value)
+	}
+}
+
+// RenderMonthly 月次レポートを指定した形式で出力
+func RenderMonthly(w io.Writer, report *models.MonthlyReport, format Format, t Translator) error {
+	funcs := map[string]interface{}{
+		"t": t,
+		"datetime": func(value time.Time) string {
+			return value.Format("2006-01-02 15:04")
+		},
+	}
+
+	switch format {
+	case FormatMarkdown:
+		tmpl, err := texttemplate.New("monthly.md.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/monthly.md.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to parse markdown template: %w", err)
+		}
+		return tmpl.Execute(w, report)
+	case FormatHTML:
+		tmpl, err := htmltemplate.New("monthly.html.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/monthly.html.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to parse html template: %w", err)
+		}
+		return tmpl.Execute(w, report)
+	default:
+		return fmt.Errorf("unsupported report format: %s", format)
+	}
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/models"
+)
+
+func testTranslator(key string, args ...interface{}) string {
+	if len(args) == 0 {
+		return key
+	}
+	return fmt.Sprintf("%s %v", key, args)
+}
+
+func testReport() *models.MonthlyReport {
+	return &models.MonthlyReport{
+		Month: "2024-06",
+		Achievements: []*models.Achievement{
+			{ID: "1", Title: "Run <5km>", Point: 10, CreatedAt: time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)},
+		},
+		Redemptions:   []*models.RewardHistory{},
+		PointsEarned:  10,
+		NetPoints:     10,
+		ActiveDays:    1,
+		LongestStreak: 1,
+		GeneratedAt:   time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("md"); err != nil || f != FormatMarkdown {
+		t.Errorf("Expected markdown, got %s (%v)", f, err)
+	}
+	if f, err := ParseFormat("HTML"); err != nil || f != FormatHTML {
+		t.Errorf("Expected html, got %s (%v)", f, err)
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestRenderMonthly_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderMonthly(&buf, testReport(), FormatMarkdown, testTranslator); err != nil {
+		t.Fatalf("RenderMonthly failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "# report.title [2024-06]") {
+		t.Errorf("Expected title in output:\n%s", output)
+	}
+	if !strings.Contains(output, "| 2024-06-03 09:00 | Run <5km> | 10 |") {
+		t.Errorf("Expected achievement row in output:\n%s", output)
+	}
+	if !strings.Contains(output, "report.no_redemptions") {
+		t.Errorf("Expected empty redemptions message in output:\n%s", output)
+	}
+}
+
+func TestRenderMonthly_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderMonthly(&buf, testReport(), FormatHTML, testTranslator); err != nil {
+		t.Fatalf("RenderMonthly failed: %v", err)
+	}
+
+	output := buf.String()
+	// HTMLではタイトルがエスケープされる
+	if !strings.Contains(output, "Run &lt;5km&gt;") {
+		t.Errorf("Expected escaped title in output:\n%s", output)
+	}
+	if !strings.Contains(output, "<h1>report.title [2024-06]</h1>") {
+		t.Errorf("Expected heading in output:\n%s", output)
+	}
+}
@@ -1,16 +1,40 @@
 package repository
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"achievement-management/internal/config"
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
 
 	"github.com/oklog/ulid/v2"
 )
 
+// TimerStartedAtAttribute 動いているタイマーを開始した日時の属性（タイマーが止まっている場合は属性が無い）
+const TimerStartedAtAttribute = "timer_started_at"
+
+// タイマーの開始・停止の条件式
+const (
+	// conditionTimerStopped タイマーの開始時（削除済みの達成目録や、すでに動いているタイマーは開始しない）
+	conditionTimerStopped = "attribute_exists(id) AND attribute_not_exists(" + TimerStartedAtAttribute + ")"
+	// conditionTimerRunning タイマーの停止時（読み取った時点と同じタイマーが動いている場合のみ止める）
+	conditionTimerRunning = TimerStartedAtAttribute + " = :started_at"
+)
+
+// 達成回数の上限を確認するカウンター（達成目録のアイテムに保存し、上限のある達成目録を達成したときのみ更新する）
+const (
+	// CompletionCountAttribute 通算の達成回数
+	CompletionCountAttribute = "completion_count"
+	// dailyCompletionsAttributePrefix 1日の達成回数の属性名の前置き（後ろに YYYYMMDD の達成日を付ける）
+	dailyCompletionsAttributePrefix = "completions_on_"
+)
+
 // AchievementRepositoryImpl 達成目録リポジトリの実装
 type AchievementRepositoryImpl struct {
 	repo   Repository
@@ -26,13 +50,66 @@ func NewAchievementRepository(repo Repository, config *config.Config) Achievemen
 }
 
 // Create 達成目録を作成
-func (r *AchievementRepositoryImpl) Create(achievement *models.Achievement) error {
+func (r *AchievementRepositoryImpl) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := prepareAchievement(achievement); err != nil {
+		return err
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Achievements, newAchievementItem(ctx, achievement), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Create",
+			Table:     r.config.Tables.Achievements,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// CreateWithPoints 達成目録の作成・ポイント加算・台帳への記録をトランザクションで実行
+func (r *AchievementRepositoryImpl) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if err := prepareAchievement(achievement); err != nil {
+		return err
+	}
+
+	entry := NewLedgerEntry(models.LedgerEntryGrant, achievement.Point, achievement.ID)
+	err := r.repo.TransactWrite(ctx, []TransactWriteItem{
+		{
+			TableName:           r.config.Tables.Achievements,
+			Item:                newAchievementItem(ctx, achievement),
+			Operation:           "PUT",
+			ConditionExpression: conditionNotExists,
+		},
+		ledgerPut(ctx, r.config, entry),
+		counterUpdate(ctx, r.config, achievement.Point, entry.CreatedAt),
+	})
+	if err != nil {
+		// 加算には条件が無いため、条件を満たさないのは同じIDの達成目録がある場合のみ
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "CreateWithPoints",
+			Table:     r.config.Tables.Achievements + "," + pointTables(r.config),
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// prepareAchievement 作成する達成目録を検証し、ID・作成日時・バージョンを設定
+func prepareAchievement(achievement *models.Achievement) error {
 	if achievement == nil {
 		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
 	}
 
 	// バリデーション
-	if err := r.validateAchievement(achievement); err != nil {
+	if err := ValidateAchievement(achievement); err != nil {
 		return err
 	}
 
@@ -45,21 +122,31 @@ func (r *AchievementRepositoryImpl) Create(achievement *models.Achievement) erro
 	if achievement.CreatedAt.IsZero() {
 		achievement.CreatedAt = time.Now()
 	}
+	achievement.Version = 1
+	return nil
+}
+
+// Update 達成目録を更新
+func (r *AchievementRepositoryImpl) Update(ctx context.Context, achievement *models.Achievement) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+
+	if achievement.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
 
-	err := r.repo.PutItem(r.config.Tables.Achievements, achievement)
+	_, expectedVersion, err := r.prepareUpdate(ctx, achievement)
 	if err != nil {
-		return &errors.DatabaseError{
-			Operation: "Create",
-			Table:     r.config.Tables.Achievements,
-			Cause:     err,
-		}
+		return err
 	}
 
-	return nil
+	return r.putVersioned(ctx, achievement, expectedVersion)
 }
 
-// Update 達成目録を更新
-func (r *AchievementRepositoryImpl) Update(achievement *models.Achievement) error {
+// UpdateWithPoints 達成目録の更新と、ポイントの差分の残高への反映・台帳への記録をトランザクションで実行
+// 減らした分の残高が足りない場合は ErrInsufficientPoints、読み取り後に更新された場合は ErrVersionConflict を返す
+func (r *AchievementRepositoryImpl) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
 	if achievement == nil {
 		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
 	}
@@ -68,22 +155,91 @@ func (r *AchievementRepositoryImpl) Update(achievement *models.Achievement) erro
 		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
 	}
 
-	// バリデーション
-	if err := r.validateAchievement(achievement); err != nil {
+	existing, expectedVersion, err := r.prepareUpdate(ctx, achievement)
+	if err != nil {
 		return err
 	}
 
-	// 既存のアイテムが存在するかチェック
-	existing, err := r.GetByID(achievement.ID)
+	delta := achievement.Point - existing.Point
+	if delta == 0 {
+		return r.putVersioned(ctx, achievement, expectedVersion)
+	}
+
+	entryType := models.LedgerEntryGrant
+	if delta < 0 {
+		entryType = models.LedgerEntryRevoke
+	}
+	entry := NewLedgerEntry(entryType, delta, achievement.ID)
+
+	stored := *achievement
+	stored.Version = expectedVersion + 1
+	err = r.repo.TransactWrite(ctx, []TransactWriteItem{
+		{
+			TableName: r.config.Tables.Achievements,
+			Item:      newAchievementItem(ctx, &stored),
+			Operation: "PUT",
+			// 差分は読み取った時点のポイントから計算しているため、バージョンが進んでいれば書き込まない
+			ConditionExpression:       versionCondition(expectedVersion),
+			ExpressionAttributeNames:  map[string]string{"#version": VersionAttribute},
+			ExpressionAttributeValues: map[string]interface{}{":expected": expectedVersion},
+		},
+		ledgerPut(ctx, r.config, entry),
+		counterUpdate(ctx, r.config, delta, entry.CreatedAt),
+	})
 	if err != nil {
-		return err
+		if stderrors.Is(err, ErrConditionFailed) {
+			return r.updateConflict(ctx, achievement.ID, expectedVersion)
+		}
+		return &errors.DatabaseError{
+			Operation: "UpdateWithPoints",
+			Table:     r.config.Tables.Achievements + "," + pointTables(r.config),
+			Cause:     err,
+		}
 	}
 
-	// 作成日時は元の値を保持
+	achievement.Version = stored.Version
+	return nil
+}
+
+// prepareUpdate 更新内容を検証し、既存の達成目録と書き込みの前提とするバージョンを返す
+func (r *AchievementRepositoryImpl) prepareUpdate(ctx context.Context, achievement *models.Achievement) (*models.Achievement, int, error) {
+	// バリデーション
+	if err := ValidateAchievement(achievement); err != nil {
+		return nil, 0, err
+	}
+
+	// 既存のアイテムが存在するかチェック（取得した時点のバージョンを使うため強い整合性で読み取る）
+	existing, err := r.getByID(ctx, achievement.ID, r.repo.GetItemConsistent)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 作成日時・添付ファイル・タイマーは元の値を保持（アイテム全体を書き込むため）
 	achievement.CreatedAt = existing.CreatedAt
+	achievement.AttachmentKey = existing.AttachmentKey
+	achievement.TimerStartedAt = existing.TimerStartedAt
+
+	// バージョンが指定されていない場合は取得した時点のバージョンから更新する
+	expectedVersion := achievement.Version
+	if expectedVersion == 0 {
+		expectedVersion = existing.Version
+	}
+
+	return existing, expectedVersion, nil
+}
 
-	err = r.repo.PutItem(r.config.Tables.Achievements, achievement)
+// putVersioned 保存済みのバージョンが expectedVersion の場合のみ達成目録を書き込む
+func (r *AchievementRepositoryImpl) putVersioned(ctx context.Context, achievement *models.Achievement, expectedVersion int) error {
+	err := r.repo.PutItemWithVersion(ctx, r.config.Tables.Achievements, newAchievementItem(ctx, achievement), expectedVersion)
 	if err != nil {
+		// 取得後に削除された場合
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
+		// 別の更新が先に書き込まれていた場合
+		if stderrors.Is(err, ErrVersionMismatch) {
+			return errors.ErrVersionConflict
+		}
 		return &errors.DatabaseError{
 			Operation: "Update",
 			Table:     r.config.Tables.Achievements,
@@ -91,23 +247,40 @@ func (r *AchievementRepositoryImpl) Update(achievement *models.Achievement) erro
 		}
 	}
 
+	achievement.Version = expectedVersion + 1
 	return nil
 }
 
+// updateConflict UpdateWithPoints の条件を満たさなかった理由を達成目録を読み直して判定
+func (r *AchievementRepositoryImpl) updateConflict(ctx context.Context, id string, expectedVersion int) error {
+	current, err := r.getByID(ctx, id, r.repo.GetItemConsistent)
+	switch {
+	case err != nil:
+		return err
+	case current.Version != expectedVersion:
+		return errors.ErrVersionConflict
+	default:
+		return errors.ErrInsufficientPoints
+	}
+}
+
 // GetByID IDで達成目録を取得
-func (r *AchievementRepositoryImpl) GetByID(id string) (*models.Achievement, error) {
+func (r *AchievementRepositoryImpl) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	return r.getByID(ctx, id, r.repo.GetItem)
+}
+
+// getByID 指定した読み取り方法でIDの達成目録を取得
+func (r *AchievementRepositoryImpl) getByID(ctx context.Context, id string, getItem itemGetter) (*models.Achievement, error) {
 	if id == "" {
 		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
 	}
 
-	key := map[string]interface{}{
-		"id": id,
-	}
+	key := itemKey(ctx, id)
 
 	var achievement models.Achievement
-	err := r.repo.GetItem(r.config.Tables.Achievements, key, &achievement)
+	err := getItem(ctx, r.config.Tables.Achievements, key, &achievement)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("item not found in table %s", r.config.Tables.Achievements) {
+		if stderrors.Is(err, ErrItemNotFound) {
 			return nil, errors.ErrNotFound
 		}
 		return nil, &errors.DatabaseError{
@@ -117,44 +290,159 @@ func (r *AchievementRepositoryImpl) GetByID(id string) (*models.Achievement, err
 		}
 	}
 
+	achievement.ID = tenant.EntityID(ctx, achievement.ID)
 	return &achievement, nil
 }
 
-// List すべての達成目録を取得
-func (r *AchievementRepositoryImpl) List() ([]*models.Achievement, error) {
+// List すべての達成目録を作成日時順に取得
+func (r *AchievementRepositoryImpl) List(ctx context.Context) ([]*models.Achievement, error) {
+	return r.list(ctx, "List", nil)
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイントのみを作成日時順に取得
+func (r *AchievementRepositoryImpl) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	return r.list(ctx, "ListSummaries", summaryProjection)
+}
+
+// list 指定した属性のみの達成目録を作成日時順に取得（projection が空の場合はすべての属性）
+func (r *AchievementRepositoryImpl) list(ctx context.Context, operation string, projection []string) ([]*models.Achievement, error) {
+	input := entityTypeQuery(ctx, r.config.Tables.Achievements, CreatedAtIndex, EntityTypeAchievement)
+	input.Projection = projection
+
 	var achievements []*models.Achievement
-	err := r.repo.Scan(r.config.Tables.Achievements, &achievements)
+	_, err := r.repo.Query(ctx, input, &achievements)
 	if err != nil {
 		return nil, &errors.DatabaseError{
-			Operation: "List",
+			Operation: operation,
 			Table:     r.config.Tables.Achievements,
 			Cause:     err,
 		}
 	}
 
+	for _, achievement := range achievements {
+		achievement.ID = tenant.EntityID(ctx, achievement.ID)
+	}
 	return achievements, nil
 }
 
+// Count 達成目録の件数を取得（テナントを分けない場合はテーブルのおおよそのアイテム数のため、直近の変更は反映されない）
+func (r *AchievementRepositoryImpl) Count(ctx context.Context) (int, error) {
+	count, err := entityCount(ctx, r.repo, r.config, r.config.Tables.Achievements, CreatedAtIndex, EntityTypeAchievement)
+	if err != nil {
+		return 0, &errors.DatabaseError{
+			Operation: "Count",
+			Table:     r.config.Tables.Achievements,
+			Cause:     err,
+		}
+	}
+	return int(count), nil
+}
+
 // Delete 達成目録を削除
-func (r *AchievementRepositoryImpl) Delete(id string) error {
+func (r *AchievementRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	// 存在確認（IDのみを読み取る）
+	var item struct {
+		ID string `dynamodbav:"id"`
+	}
+	err := r.repo.GetItemWithProjection(ctx, r.config.Tables.Achievements, itemKey(ctx, id), idProjection, &item)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Achievements,
+			Cause:     err,
+		}
+	}
+
+	err = r.repo.DeleteItem(ctx, r.config.Tables.Achievements, itemKey(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Achievements,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// DeleteWithPoints 達成目録の削除・ポイント減算・台帳への記録をトランザクションで実行
+// 残高が足りない場合は ErrInsufficientPoints、読み取り後にポイントが変更された場合は ErrVersionConflict を返す
+func (r *AchievementRepositoryImpl) DeleteWithPoints(ctx context.Context, id string) error {
 	if id == "" {
 		return &errors.ValidationError{Field: "id", Message: "id is required"}
 	}
 
-	// 存在確認
-	_, err := r.GetByID(id)
+	// 減算するポイントを確定するため強い整合性で読み取る
+	achievement, err := r.getByID(ctx, id, r.repo.GetItemConsistent)
 	if err != nil {
 		return err
 	}
 
-	key := map[string]interface{}{
-		"id": id,
+	entry := NewLedgerEntry(models.LedgerEntryRevoke, -achievement.Point, achievement.ID)
+	err = r.repo.TransactWrite(ctx, []TransactWriteItem{
+		{
+			TableName: r.config.Tables.Achievements,
+			Item:      itemKey(ctx, id),
+			Operation: "DELETE",
+			// 読み取り後にポイントが変更されていれば減算する値が変わるため削除しない
+			ConditionExpression:       conditionExists + " AND point = :point",
+			ExpressionAttributeValues: map[string]interface{}{":point": achievement.Point},
+		},
+		ledgerPut(ctx, r.config, entry),
+		counterUpdate(ctx, r.config, -achievement.Point, entry.CreatedAt),
+	})
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return r.deleteConflict(ctx, achievement)
+		}
+		return &errors.DatabaseError{
+			Operation: "DeleteWithPoints",
+			Table:     r.config.Tables.Achievements + "," + pointTables(r.config),
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// deleteConflict DeleteWithPoints の条件を満たさなかった理由を達成目録を読み直して判定
+func (r *AchievementRepositoryImpl) deleteConflict(ctx context.Context, deleted *models.Achievement) error {
+	current, err := r.getByID(ctx, deleted.ID, r.repo.GetItemConsistent)
+	switch {
+	case err != nil:
+		return err
+	case current.Point != deleted.Point:
+		return errors.ErrVersionConflict
+	default:
+		return errors.ErrInsufficientPoints
+	}
+}
+
+// DeleteMany 複数の達成目録をまとめて削除
+func (r *AchievementRepositoryImpl) DeleteMany(ctx context.Context, ids []string) error {
+	keys := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return &errors.ValidationError{Field: "id", Message: "id is required"}
+		}
+		keys = append(keys, itemKey(ctx, id))
+	}
+
+	if len(keys) == 0 {
+		return nil
 	}
 
-	err = r.repo.DeleteItem(r.config.Tables.Achievements, key)
+	err := r.repo.BatchDeleteItems(ctx, r.config.Tables.Achievements, keys)
 	if err != nil {
 		return &errors.DatabaseError{
-			Operation: "Delete",
+			Operation: "DeleteMany",
 			Table:     r.config.Tables.Achievements,
 			Cause:     err,
 		}
@@ -163,8 +451,290 @@ func (r *AchievementRepositoryImpl) Delete(id string) error {
 	return nil
 }
 
-// validateAchievement 達成目録のバリデーション
-func (r *AchievementRepositoryImpl) validateAchievement(achievement *models.Achievement) error {
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存
+func (r *AchievementRepositoryImpl) SetAttachment(ctx context.Context, id, key string) error {
+	return setAttachmentKey(ctx, r.repo, r.config.Tables.Achievements, "SetAttachment", id, key)
+}
+
+// StartTimer 達成目録のタイマーを開始（バージョンは進めない）
+// 達成目録が削除されていた場合や、すでにタイマーが動いている場合は ErrVersionConflict を返す
+func (r *AchievementRepositoryImpl) StartTimer(ctx context.Context, id string, at time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	err := r.repo.UpdateItemWithCondition(ctx, r.config.Tables.Achievements, itemKey(ctx, id),
+		"SET "+TimerStartedAtAttribute+" = :started_at", conditionTimerStopped, map[string]interface{}{
+			":started_at": at,
+		})
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrVersionConflict
+		}
+		return &errors.DatabaseError{
+			Operation: "StartTimer",
+			Table:     r.config.Tables.Achievements,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Complete 達成記録の作成・ポイントの付与・台帳への記録をトランザクションで実行
+// 達成目録が削除されていた場合は ErrNotFound を返す
+// タイマーを止めて達成する場合（StartedAt を指定した場合）は同じトランザクションでタイマーを止め、
+// 読み取った後にタイマーが止められていた場合は ErrVersionConflict を返す
+// 達成回数の上限（Limit）を指定した場合は同じトランザクションでカウンターを増やし、上限に達している場合は BusinessLogicError を返す
+func (r *AchievementRepositoryImpl) Complete(ctx context.Context, completion *models.Completion) error {
+	if completion == nil {
+		return &errors.ValidationError{Field: "completion", Message: "completion cannot be nil"}
+	}
+
+	if err := ValidateCompletion(completion); err != nil {
+		return err
+	}
+
+	// IDと日時を設定
+	if completion.ID == "" {
+		completion.ID = ulid.Make().String()
+	}
+	if completion.CompletedAt.IsZero() {
+		completion.CompletedAt = time.Now()
+	}
+
+	achievementCheck, err := r.achievementCheck(ctx, completion)
+	if err != nil {
+		return err
+	}
+	entry := NewLedgerEntry(models.LedgerEntryGrant, completion.Point, completion.ID)
+	items := []TransactWriteItem{
+		achievementCheck,
+		{
+			TableName: r.config.Tables.Completions,
+			Item:      newCompletionItem(ctx, completion),
+			Operation: "PUT",
+		},
+		ledgerPut(ctx, r.config, entry),
+	}
+	// 連続達成のボーナスは達成目録のポイントと分けて台帳に記録する
+	if completion.BonusPoint > 0 {
+		items = append(items, ledgerPut(ctx, r.config, NewLedgerEntry(models.LedgerEntryBonus, completion.BonusPoint, completion.ID)))
+	}
+	items = append(items, counterUpdate(ctx, r.config, completion.Point+completion.BonusPoint, entry.CreatedAt))
+
+	err = r.repo.TransactWrite(ctx, items)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return r.completeConflict(ctx, completion)
+		}
+		return &errors.DatabaseError{
+			Operation: "Complete",
+			Table:     r.config.Tables.Completions + "," + pointTables(r.config),
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// achievementCheck 達成記録と同じトランザクションで達成目録に書き込む操作
+//
+// 達成目録が削除されていないこと・タイマーを止める場合は読み取った時点のタイマーが動いていることに加えて、
+// 上限を指定した場合は達成回数のカウンターが上限未満であることを条件にしてカウンターを増やす。
+// カウンターが無い場合（上限を設定する前の達成目録や、編集で書き直された達成目録）は達成記録の件数から数え始める。
+func (r *AchievementRepositoryImpl) achievementCheck(ctx context.Context, completion *models.Completion) (TransactWriteItem, error) {
+	item := TransactWriteItem{
+		// 読み取った後に削除された達成目録ではポイントを付与しない
+		TableName:           r.config.Tables.Achievements,
+		Key:                 itemKey(ctx, completion.AchievementID),
+		Operation:           "CONDITION_CHECK",
+		ConditionExpression: conditionExists,
+	}
+	var sets, removes []string
+	values := map[string]interface{}{}
+	if completion.StartedAt != nil {
+		// 同じタイマーを二重に止めて達成しないよう、読み取った時点のタイマーが動いている場合のみ止める
+		removes = append(removes, TimerStartedAtAttribute)
+		item.ConditionExpression = conditionTimerRunning
+		values[":started_at"] = *completion.StartedAt
+	}
+
+	if limit := completion.Limit; limit != nil && (limit.MaxTotal > 0 || limit.MaxPerDay > 0) {
+		completions, err := r.ListCompletions(ctx, completion.AchievementID)
+		if err != nil {
+			return TransactWriteItem{}, err
+		}
+		total, today := CountCompletions(limit, completions)
+		if err := CheckCompletionLimit(limit, total, today); err != nil {
+			return TransactWriteItem{}, err
+		}
+
+		values[":one"] = 1
+		if limit.MaxTotal > 0 {
+			sets = append(sets, CompletionCountAttribute+" = if_not_exists("+CompletionCountAttribute+", :completed) + :one")
+			item.ConditionExpression += " AND (attribute_not_exists(" + CompletionCountAttribute + ") OR " + CompletionCountAttribute + " < :max_total)"
+			values[":completed"] = total
+			values[":max_total"] = limit.MaxTotal
+		}
+		if limit.MaxPerDay > 0 {
+			item.ExpressionAttributeNames = map[string]string{"#today": dailyCompletionsAttribute(limit.DayStart, limit.DayStart)}
+			sets = append(sets, "#today = if_not_exists(#today, :completed_today) + :one")
+			item.ConditionExpression += " AND (attribute_not_exists(#today) OR #today < :max_per_day)"
+			values[":completed_today"] = today
+			values[":max_per_day"] = limit.MaxPerDay
+			// 前回の達成日のカウンターは使わなくなるため削除する（それより前の日のカウンターは前回の達成時に削除済み）
+			if len(completions) > 0 {
+				last := completions[len(completions)-1].CompletedAt
+				if last.Before(limit.DayStart) {
+					item.ExpressionAttributeNames["#previous"] = dailyCompletionsAttribute(last, limit.DayStart)
+					removes = append(removes, "#previous")
+				}
+			}
+		}
+	}
+
+	if len(sets) > 0 || len(removes) > 0 {
+		item.Operation = "UPDATE"
+		var expression []string
+		if len(sets) > 0 {
+			expression = append(expression, "SET "+strings.Join(sets, ", "))
+		}
+		if len(removes) > 0 {
+			expression = append(expression, "REMOVE "+strings.Join(removes, ", "))
+		}
+		item.UpdateExpression = strings.Join(expression, " ")
+	}
+	if len(values) > 0 {
+		item.ExpressionAttributeValues = values
+	}
+	return item, nil
+}
+
+// dailyCompletionsAttribute 達成日の1日の達成回数の属性名（日付は dayStart と同じタイムゾーンで区切る）
+func dailyCompletionsAttribute(at, dayStart time.Time) string {
+	return dailyCompletionsAttributePrefix + at.In(dayStart.Location()).Format("20060102")
+}
+
+// completeConflict Complete の条件を満たさなかった理由を達成目録と達成記録を読み直して判定
+func (r *AchievementRepositoryImpl) completeConflict(ctx context.Context, completion *models.Completion) error {
+	missing := errors.ErrNotFound
+	if completion.StartedAt != nil {
+		missing = errors.ErrVersionConflict
+	}
+	if completion.Limit == nil {
+		return missing
+	}
+
+	current, err := r.getByID(ctx, completion.AchievementID, r.repo.GetItemConsistent)
+	switch {
+	case err == errors.ErrNotFound:
+		return missing
+	case err != nil:
+		return err
+	case completion.StartedAt != nil && (current.TimerStartedAt == nil || !current.TimerStartedAt.Equal(*completion.StartedAt)):
+		return errors.ErrVersionConflict
+	}
+
+	// 達成目録もタイマーも読み取った時点のままの場合は、同時に達成されて上限に達している
+	completions, err := r.ListCompletions(ctx, completion.AchievementID)
+	if err != nil {
+		return err
+	}
+	total, today := CountCompletions(completion.Limit, completions)
+	if err := CheckCompletionLimit(completion.Limit, total, today); err != nil {
+		return err
+	}
+	// 同時の達成がまだインデックスに反映されていない場合は、同時に更新されたことだけを返す
+	return errors.ErrVersionConflict
+}
+
+// ListCompletions 達成目録の達成記録を達成日時順に取得
+func (r *AchievementRepositoryImpl) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	if achievementID == "" {
+		return nil, &errors.ValidationError{Field: "achievement_id", Message: "achievement_id is required"}
+	}
+
+	input := QueryInput{
+		TableName:              r.config.Tables.Completions,
+		IndexName:              AchievementKeyIndex,
+		KeyConditionExpression: AchievementKeyAttribute + " = :achievement_key",
+		ExpressionAttributeValues: map[string]interface{}{
+			":achievement_key": tenant.Key(ctx, achievementID),
+		},
+	}
+
+	var completions []*models.Completion
+	if _, err := r.repo.Query(ctx, input, &completions); err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "ListCompletions",
+			Table:     r.config.Tables.Completions,
+			Cause:     err,
+		}
+	}
+
+	for _, completion := range completions {
+		completion.ID = tenant.EntityID(ctx, completion.ID)
+	}
+	// インデックスにソートキーが無いため、取得後に並べ替える
+	sort.Slice(completions, func(i, j int) bool {
+		if completions[i].CompletedAt.Equal(completions[j].CompletedAt) {
+			return completions[i].ID < completions[j].ID
+		}
+		return completions[i].CompletedAt.Before(completions[j].CompletedAt)
+	})
+	return completions, nil
+}
+
+// ValidateCompletion 達成記録のバリデーション（すべてのストレージで共通）
+func ValidateCompletion(completion *models.Completion) error {
+	if completion.AchievementID == "" {
+		return &errors.ValidationError{Field: "achievement_id", Message: "achievement_id is required"}
+	}
+
+	// 1日の獲得ポイントの上限を超えた達成は0ポイントで記録する
+	if completion.Point < 0 {
+		return &errors.ValidationError{Field: "point", Message: "point must not be negative"}
+	}
+
+	if completion.BonusPoint < 0 {
+		return &errors.ValidationError{Field: "bonus_point", Message: "bonus_point must not be negative"}
+	}
+
+	return nil
+}
+
+// CountCompletions 達成記録の通算の件数と、上限の1日の期間に達成した件数
+func CountCompletions(limit *models.CompletionLimit, completions []*models.Completion) (total, today int) {
+	for _, completion := range completions {
+		if !completion.CompletedAt.Before(limit.DayStart) && completion.CompletedAt.Before(limit.DayEnd) {
+			today++
+		}
+	}
+	return len(completions), today
+}
+
+// CheckCompletionLimit 達成回数が上限に達している場合は BusinessLogicError を返す（すべてのストレージで共通）
+func CheckCompletionLimit(limit *models.CompletionLimit, total, today int) error {
+	if limit.MaxTotal > 0 && total >= limit.MaxTotal {
+		return &errors.BusinessLogicError{
+			Operation: "Complete",
+			Reason:    fmt.Sprintf("achievement can be completed at most %d time(s) in total", limit.MaxTotal),
+			Code:      errors.CodeCompletionLimit,
+		}
+	}
+	if limit.MaxPerDay > 0 && today >= limit.MaxPerDay {
+		return &errors.BusinessLogicError{
+			Operation: "Complete",
+			Reason:    fmt.Sprintf("achievement can be completed at most %d time(s) per day", limit.MaxPerDay),
+			Code:      errors.CodeCompletionLimit,
+		}
+	}
+	return nil
+}
+
+// ValidateAchievement 達成目録のバリデーション（すべてのストレージで共通）
+func ValidateAchievement(achievement *models.Achievement) error {
 	if achievement.Title == "" {
 		return &errors.ValidationError{Field: "title", Message: "title is required"}
 	}
@@ -1,56 +1,168 @@
 package repository
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"achievement-management/internal/config"
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
 )
 
 // MockRepository リポジトリのモック
 type MockRepository struct {
 	putItemFunc    func(tableName string, item interface{}) error
+	conditionFunc  func(tableName string, item interface{}, conditionExpression string) error
+	versionFunc    func(tableName string, item interface{}, expectedVersion int) error
 	getItemFunc    func(tableName string, key map[string]interface{}, result interface{}) error
-	scanFunc       func(tableName string, result interface{}) error
+	scanFunc       func(input ScanInput, result interface{}) (string, error)
+	scanEachFunc   func(input ScanInput, fn ItemHandler) error
+	queryFunc      func(input QueryInput, result interface{}) (string, error)
+	queryEachFunc  func(input QueryInput, fn ItemHandler) error
+	itemCountFunc  func(tableName string) (int64, error)
+	queryCountFunc func(input QueryInput) (int64, error)
+	updateItemFunc func(tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error
+	updateCondFunc func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error
 	deleteItemFunc func(tableName string, key map[string]interface{}) error
+	batchPutFunc   func(tableName string, items []interface{}) error
+	batchDelFunc   func(tableName string, keys []map[string]interface{}) error
+	batchGetFunc   func(tableName string, keys []map[string]interface{}, result interface{}) error
+	transactFunc   func(items []TransactWriteItem) error
+	consistentGets int
+	projections    [][]string
 }
 
-func (m *MockRepository) PutItem(tableName string, item interface{}) error {
+func (m *MockRepository) PutItem(ctx context.Context, tableName string, item interface{}) error {
 	if m.putItemFunc != nil {
 		return m.putItemFunc(tableName, item)
 	}
 	return nil
 }
 
-func (m *MockRepository) GetItem(tableName string, key map[string]interface{}, result interface{}) error {
+func (m *MockRepository) PutItemWithCondition(ctx context.Context, tableName string, item interface{}, conditionExpression string) error {
+	if m.conditionFunc != nil {
+		return m.conditionFunc(tableName, item, conditionExpression)
+	}
+	return m.PutItem(ctx, tableName, item)
+}
+
+func (m *MockRepository) PutItemWithVersion(ctx context.Context, tableName string, item interface{}, expectedVersion int) error {
+	if m.versionFunc != nil {
+		return m.versionFunc(tableName, item, expectedVersion)
+	}
+	return m.PutItem(ctx, tableName, item)
+}
+
+func (m *MockRepository) GetItem(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error {
 	if m.getItemFunc != nil {
 		return m.getItemFunc(tableName, key, result)
 	}
 	return nil
 }
 
-func (m *MockRepository) UpdateItem(tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error {
+func (m *MockRepository) GetItemConsistent(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error {
+	m.consistentGets++
+	return m.GetItem(ctx, tableName, key, result)
+}
+
+func (m *MockRepository) GetItemWithProjection(ctx context.Context, tableName string, key map[string]interface{}, projection []string, result interface{}) error {
+	m.projections = append(m.projections, projection)
+	return m.GetItem(ctx, tableName, key, result)
+}
+
+func (m *MockRepository) UpdateItem(ctx context.Context, tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error {
+	if m.updateItemFunc != nil {
+		return m.updateItemFunc(tableName, key, updateExpression, expressionAttributeValues)
+	}
 	return nil
 }
 
-func (m *MockRepository) Scan(tableName string, result interface{}) error {
+func (m *MockRepository) UpdateItemWithCondition(ctx context.Context, tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+	if m.updateCondFunc != nil {
+		return m.updateCondFunc(tableName, key, updateExpression, conditionExpression, expressionAttributeValues)
+	}
+	return m.UpdateItem(ctx, tableName, key, updateExpression, expressionAttributeValues)
+}
+
+func (m *MockRepository) Scan(ctx context.Context, input ScanInput, result interface{}) (string, error) {
 	if m.scanFunc != nil {
-		return m.scanFunc(tableName, result)
+		return m.scanFunc(input, result)
+	}
+	return "", nil
+}
+
+func (m *MockRepository) ScanEach(ctx context.Context, input ScanInput, fn ItemHandler) error {
+	if m.scanEachFunc != nil {
+		return m.scanEachFunc(input, fn)
+	}
+	return nil
+}
+
+func (m *MockRepository) Query(ctx context.Context, input QueryInput, result interface{}) (string, error) {
+	if m.queryFunc != nil {
+		return m.queryFunc(input, result)
+	}
+	return "", nil
+}
+
+func (m *MockRepository) QueryEach(ctx context.Context, input QueryInput, fn ItemHandler) error {
+	if m.queryEachFunc != nil {
+		return m.queryEachFunc(input, fn)
 	}
 	return nil
 }
 
-func (m *MockRepository) DeleteItem(tableName string, key map[string]interface{}) error {
+func (m *MockRepository) ItemCount(ctx context.Context, tableName string) (int64, error) {
+	if m.itemCountFunc != nil {
+		return m.itemCountFunc(tableName)
+	}
+	return 0, nil
+}
+
+func (m *MockRepository) QueryCount(ctx context.Context, input QueryInput) (int64, error) {
+	if m.queryCountFunc != nil {
+		return m.queryCountFunc(input)
+	}
+	return 0, nil
+}
+
+func (m *MockRepository) DeleteItem(ctx context.Context, tableName string, key map[string]interface{}) error {
 	if m.deleteItemFunc != nil {
 		return m.deleteItemFunc(tableName, key)
 	}
 	return nil
 }
 
-func (m *MockRepository) TransactWrite(items []TransactWriteItem) error {
+func (m *MockRepository) TransactWrite(ctx context.Context, items []TransactWriteItem) error {
+	if m.transactFunc != nil {
+		return m.transactFunc(items)
+	}
+	return nil
+}
+
+func (m *MockRepository) BatchPutItems(ctx context.Context, tableName string, items []interface{}) error {
+	if m.batchPutFunc != nil {
+		return m.batchPutFunc(tableName, items)
+	}
+	return nil
+}
+
+func (m *MockRepository) BatchDeleteItems(ctx context.Context, tableName string, keys []map[string]interface{}) error {
+	if m.batchDelFunc != nil {
+		return m.batchDelFunc(tableName, keys)
+	}
+	return nil
+}
+
+func (m *MockRepository) BatchGetItems(ctx context.Context, tableName string, keys []map[string]interface{}, result interface{}) error {
+	if m.batchGetFunc != nil {
+		return m.batchGetFunc(tableName, keys, result)
+	}
 	return nil
 }
 
@@ -69,7 +181,7 @@ func TestAchievementRepository_Create(t *testing.T) {
 		Point:       100,
 	}
 
-	err := repo.Create(achievement)
+	err := repo.Create(context.Background(), achievement)
 	if err != nil {
 		t.Errorf("Create failed: %v", err)
 	}
@@ -132,7 +244,7 @@ Achievements: "test-achievements",
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.Create(tt.achievement)
+			err := repo.Create(context.Background(), tt.achievement)
 			if err == nil {
 				t.Error("Expected validation error")
 			}
@@ -143,6 +255,567 @@ Achievements: "test-achievements",
 	}
 }
 
+func TestAchievementRepository_CreateWithPoints(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			Achievements:  "test-achievements",
+			CurrentPoints: "test-current-points",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	achievement := &models.Achievement{Title: "Test Achievement", Point: 100}
+	if err := repo.CreateWithPoints(context.Background(), achievement); err != nil {
+		t.Fatalf("CreateWithPoints failed: %v", err)
+	}
+	if achievement.ID == "" {
+		t.Error("ID should be generated")
+	}
+
+	// 達成目録・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 3 {
+		t.Fatalf("Expected 3 transaction items, got %d", len(written))
+	}
+	if written[0].TableName != "test-achievements" || written[0].Operation != "PUT" || written[0].ConditionExpression != conditionNotExists {
+		t.Errorf("Unexpected achievement item: %+v", written[0])
+	}
+	ledger, ok := written[1].Item.(pointLedgerItem)
+	if !ok || written[1].TableName != "test-point-ledger" {
+		t.Fatalf("Unexpected ledger item: %+v", written[1])
+	}
+	if ledger.Type != models.LedgerEntryGrant || ledger.Amount != 100 || ledger.Reference != achievement.ID {
+		t.Errorf("Unexpected ledger entry: %+v", ledger.PointLedgerEntry)
+	}
+	counter := written[2]
+	if counter.TableName != "test-current-points" || counter.Operation != "UPDATE" {
+		t.Errorf("Unexpected counter item: %+v", counter)
+	}
+	if counter.ExpressionAttributeValues[":delta"] != 100 {
+		t.Errorf("Unexpected counter update: %+v", counter)
+	}
+}
+
+func TestAchievementRepository_DeleteWithPoints(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			*result.(*models.Achievement) = models.Achievement{ID: "test-id", Title: "Test Achievement", Point: 100, Version: 2}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			Achievements:  "test-achievements",
+			CurrentPoints: "test-current-points",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	if err := repo.DeleteWithPoints(context.Background(), "test-id"); err != nil {
+		t.Fatalf("DeleteWithPoints failed: %v", err)
+	}
+	if mockRepo.consistentGets != 1 {
+		t.Errorf("Expected 1 consistent read, got %d", mockRepo.consistentGets)
+	}
+
+	// 削除・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 3 {
+		t.Fatalf("Expected 3 transaction items, got %d", len(written))
+	}
+	if written[0].TableName != "test-achievements" || written[0].Operation != "DELETE" || written[0].ExpressionAttributeValues[":point"] != 100 {
+		t.Errorf("Unexpected achievement item: %+v", written[0])
+	}
+	ledger, ok := written[1].Item.(pointLedgerItem)
+	if !ok || written[1].TableName != "test-point-ledger" {
+		t.Fatalf("Unexpected ledger item: %+v", written[1])
+	}
+	if ledger.Type != models.LedgerEntryRevoke || ledger.Amount != -100 || ledger.Reference != "test-id" {
+		t.Errorf("Unexpected ledger entry: %+v", ledger.PointLedgerEntry)
+	}
+	counter := written[2]
+	if counter.ExpressionAttributeValues[":delta"] != -100 || counter.ConditionExpression == "" {
+		t.Errorf("Expected a conditional decrement, got %+v", counter)
+	}
+}
+
+func TestAchievementRepository_DeleteWithPoints_Errors(t *testing.T) {
+	config := &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}}
+	conditionFailed := func(items []TransactWriteItem) error {
+		return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+	}
+
+	// 削除後に読み直したポイントが変わっていない場合は残高不足
+	repo := NewAchievementRepository(&MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			*result.(*models.Achievement) = models.Achievement{ID: "test-id", Title: "Test", Point: 100}
+			return nil
+		},
+		transactFunc: conditionFailed,
+	}, config)
+	if err := repo.DeleteWithPoints(context.Background(), "test-id"); err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+
+	// 読み取り後にポイントが変更されていた場合
+	reads := 0
+	repo = NewAchievementRepository(&MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			reads++
+			*result.(*models.Achievement) = models.Achievement{ID: "test-id", Title: "Test", Point: 100 * reads}
+			return nil
+		},
+		transactFunc: conditionFailed,
+	}, config)
+	if err := repo.DeleteWithPoints(context.Background(), "test-id"); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	// 存在しない場合は書き込まない
+	repo = NewAchievementRepository(&MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			return fmt.Errorf("%w in table test-achievements", ErrItemNotFound)
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			t.Error("TransactWrite should not be called")
+			return nil
+		},
+	}, config)
+	if err := repo.DeleteWithPoints(context.Background(), "test-id"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAchievementRepository_UpdateWithPoints(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			*result.(*models.Achievement) = models.Achievement{ID: "test-id", Title: "Test Achievement", Point: 100, Version: 2}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+		versionFunc: func(tableName string, item interface{}, expectedVersion int) error {
+			t.Error("PutItemWithVersion should not be called when the point changes")
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			Achievements:  "test-achievements",
+			CurrentPoints: "test-current-points",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	achievement := &models.Achievement{ID: "test-id", Title: "Test Achievement", Point: 40}
+	if err := repo.UpdateWithPoints(context.Background(), achievement); err != nil {
+		t.Fatalf("UpdateWithPoints failed: %v", err)
+	}
+	if achievement.Version != 3 {
+		t.Errorf("Expected version 3, got %d", achievement.Version)
+	}
+
+	// 更新・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 3 {
+		t.Fatalf("Expected 3 transaction items, got %d", len(written))
+	}
+	item, ok := written[0].Item.(achievementItem)
+	if !ok || written[0].Operation != "PUT" || item.Version != 3 || item.Point != 40 {
+		t.Errorf("Unexpected achievement item: %+v", written[0])
+	}
+	if written[0].ExpressionAttributeValues[":expected"] != 2 || written[0].ExpressionAttributeNames["#version"] != VersionAttribute {
+		t.Errorf("Expected a version condition, got %+v", written[0])
+	}
+	ledger, ok := written[1].Item.(pointLedgerItem)
+	if !ok || ledger.Type != models.LedgerEntryRevoke || ledger.Amount != -60 || ledger.Reference != "test-id" {
+		t.Errorf("Unexpected ledger item: %+v", written[1])
+	}
+	if written[2].ExpressionAttributeValues[":delta"] != -60 || written[2].ConditionExpression == "" {
+		t.Errorf("Expected a conditional decrement, got %+v", written[2])
+	}
+
+	// ポイントを増やした場合は付与として記録する
+	if err := repo.UpdateWithPoints(context.Background(), &models.Achievement{ID: "test-id", Title: "Test", Point: 150}); err != nil {
+		t.Fatalf("UpdateWithPoints failed: %v", err)
+	}
+	ledger = written[1].Item.(pointLedgerItem)
+	if ledger.Type != models.LedgerEntryGrant || ledger.Amount != 50 || written[2].ConditionExpression != "" {
+		t.Errorf("Expected an unconditional grant, got %+v / %+v", ledger.PointLedgerEntry, written[2])
+	}
+
+	// ポイントが変わらない場合はトランザクションを使わない
+	written = nil
+	puts := 0
+	mockRepo.versionFunc = func(tableName string, item interface{}, expectedVersion int) error {
+		puts++
+		return nil
+	}
+	if err := repo.UpdateWithPoints(context.Background(), &models.Achievement{ID: "test-id", Title: "Renamed", Point: 100}); err != nil {
+		t.Fatalf("UpdateWithPoints failed: %v", err)
+	}
+	if puts != 1 || written != nil {
+		t.Errorf("Expected a plain versioned put, got %d puts and %d transaction items", puts, len(written))
+	}
+}
+
+func TestAchievementRepository_UpdateWithPoints_Errors(t *testing.T) {
+	config := &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}}
+	conditionFailed := func(items []TransactWriteItem) error {
+		return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+	}
+
+	// 読み直したバージョンが変わっていない場合は残高不足
+	repo := NewAchievementRepository(&MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			*result.(*models.Achievement) = models.Achievement{ID: "test-id", Title: "Test", Point: 100, Version: 1}
+			return nil
+		},
+		transactFunc: conditionFailed,
+	}, config)
+	achievement := &models.Achievement{ID: "test-id", Title: "Test", Point: 10}
+	if err := repo.UpdateWithPoints(context.Background(), achievement); err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+	if achievement.Version != 0 {
+		t.Errorf("Expected version to be left unchanged, got %d", achievement.Version)
+	}
+
+	// 読み取り後に別の更新が書き込まれていた場合
+	reads := 0
+	repo = NewAchievementRepository(&MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			reads++
+			*result.(*models.Achievement) = models.Achievement{ID: "test-id", Title: "Test", Point: 100, Version: reads}
+			return nil
+		},
+		transactFunc: conditionFailed,
+	}, config)
+	if err := repo.UpdateWithPoints(context.Background(), &models.Achievement{ID: "test-id", Title: "Test", Point: 10}); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	// 存在しない場合は書き込まない
+	repo = NewAchievementRepository(&MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			return fmt.Errorf("%w in table test-achievements", ErrItemNotFound)
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			t.Error("TransactWrite should not be called")
+			return nil
+		},
+	}, config)
+	if err := repo.UpdateWithPoints(context.Background(), &models.Achievement{ID: "test-id", Title: "Test", Point: 10}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAchievementRepository_Complete(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			Achievements:  "test-achievements",
+			Completions:   "test-completions",
+			CurrentPoints: "test-current-points",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	completion := &models.Completion{AchievementID: "test-id", AchievementTitle: "Test Achievement", Point: 30}
+	if err := repo.Complete(context.Background(), completion); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if completion.ID == "" || completion.CompletedAt.IsZero() {
+		t.Errorf("Expected ID and CompletedAt to be set, got %+v", completion)
+	}
+
+	// 達成目録の存在確認・達成記録・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 4 {
+		t.Fatalf("Expected 4 transaction items, got %d", len(written))
+	}
+	if written[0].Operation != "CONDITION_CHECK" || written[0].TableName != "test-achievements" || written[0].Key["id"] != "test-id" {
+		t.Errorf("Expected an existence check on the achievement, got %+v", written[0])
+	}
+	item, ok := written[1].Item.(completionItem)
+	if !ok || written[1].TableName != "test-completions" || item.AchievementKey != "test-id" || item.EntityType != EntityTypeCompletion {
+		t.Errorf("Unexpected completion item: %+v", written[1])
+	}
+	ledger, ok := written[2].Item.(pointLedgerItem)
+	if !ok || ledger.Type != models.LedgerEntryGrant || ledger.Amount != 30 || ledger.Reference != completion.ID {
+		t.Errorf("Unexpected ledger item: %+v", written[2])
+	}
+	if written[3].ExpressionAttributeValues[":delta"] != 30 {
+		t.Errorf("Expected the balance to be incremented, got %+v", written[3])
+	}
+
+	// 連続達成のボーナスは別の台帳エントリとして記録し、残高には合計を加算する
+	bonus := &models.Completion{AchievementID: "test-id", Point: 30, BonusPoint: 10}
+	if err := repo.Complete(context.Background(), bonus); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if len(written) != 5 {
+		t.Fatalf("Expected 5 transaction items, got %d", len(written))
+	}
+	ledger, ok = written[3].Item.(pointLedgerItem)
+	if !ok || ledger.Type != models.LedgerEntryBonus || ledger.Amount != 10 || ledger.Reference != bonus.ID {
+		t.Errorf("Unexpected bonus ledger item: %+v", written[3])
+	}
+	if written[4].ExpressionAttributeValues[":delta"] != 40 {
+		t.Errorf("Expected the balance to be incremented by 40, got %+v", written[4])
+	}
+
+	// 達成目録が削除されていた場合は ErrNotFound
+	mockRepo.transactFunc = func(items []TransactWriteItem) error {
+		return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+	}
+	if err := repo.Complete(context.Background(), &models.Completion{AchievementID: "missing", Point: 30}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	mockRepo.transactFunc = func(items []TransactWriteItem) error {
+		return fmt.Errorf("connection reset")
+	}
+	err := repo.Complete(context.Background(), &models.Completion{AchievementID: "test-id", Point: 30})
+	if _, ok := err.(*errors.DatabaseError); !ok {
+		t.Errorf("Expected DatabaseError, got %v", err)
+	}
+
+	err = repo.Complete(context.Background(), &models.Completion{AchievementID: "test-id", Point: -10})
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Errorf("Expected ValidationError for a negative point, got %v", err)
+	}
+}
+
+func TestAchievementRepository_Timer(t *testing.T) {
+	var updated struct {
+		table, expression, condition string
+		values                       map[string]interface{}
+	}
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression, conditionExpression string, values map[string]interface{}) error {
+			updated.table, updated.expression, updated.condition, updated.values = tableName, updateExpression, conditionExpression, values
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			Achievements:  "test-achievements",
+			Completions:   "test-completions",
+			CurrentPoints: "test-current-points",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	startedAt := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	if err := repo.StartTimer(context.Background(), "test-id", startedAt); err != nil {
+		t.Fatalf("StartTimer failed: %v", err)
+	}
+	if updated.table != "test-achievements" || updated.expression != "SET timer_started_at = :started_at" ||
+		updated.condition != conditionTimerStopped || updated.values[":started_at"] != startedAt {
+		t.Errorf("Unexpected timer update: %+v", updated)
+	}
+
+	// タイマーを止めて達成する場合は、存在確認の代わりに読み取った時点のタイマーを止める
+	completion := &models.Completion{AchievementID: "test-id", Point: 60, StartedAt: &startedAt, DurationSeconds: 5400}
+	if err := repo.Complete(context.Background(), completion); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if written[0].Operation != "UPDATE" || written[0].UpdateExpression != "REMOVE timer_started_at" ||
+		written[0].ConditionExpression != conditionTimerRunning || written[0].ExpressionAttributeValues[":started_at"] != startedAt {
+		t.Errorf("Expected the timer to be stopped in the transaction, got %+v", written[0])
+	}
+
+	// すでにタイマーが動いている・止められていた場合は ErrVersionConflict
+	mockRepo.updateCondFunc = func(string, map[string]interface{}, string, string, map[string]interface{}) error {
+		return ErrConditionFailed
+	}
+	if err := repo.StartTimer(context.Background(), "test-id", startedAt); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+	mockRepo.transactFunc = func(items []TransactWriteItem) error {
+		return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+	}
+	if err := repo.Complete(context.Background(), &models.Completion{AchievementID: "test-id", Point: 60, StartedAt: &startedAt}); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+}
+
+func TestAchievementRepository_ListCompletions(t *testing.T) {
+	now := time.Now()
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			if input.TableName != "test-completions" || input.IndexName != AchievementKeyIndex {
+				t.Errorf("Expected query on %s of test-completions, got %s of %s", AchievementKeyIndex, input.IndexName, input.TableName)
+			}
+			if input.ExpressionAttributeValues[":achievement_key"] != "test-id" {
+				t.Errorf("Unexpected key condition values: %v", input.ExpressionAttributeValues)
+			}
+			*result.(*[]*models.Completion) = []*models.Completion{
+				{ID: "later", AchievementID: "test-id", Point: 30, CompletedAt: now},
+				{ID: "earlier", AchievementID: "test-id", Point: 30, CompletedAt: now.Add(-time.Hour)},
+			}
+			return "", nil
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{Completions: "test-completions"}}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	completions, err := repo.ListCompletions(context.Background(), "test-id")
+	if err != nil {
+		t.Fatalf("ListCompletions failed: %v", err)
+	}
+	if len(completions) != 2 || completions[0].ID != "earlier" || completions[1].ID != "later" {
+		t.Errorf("Expected completions sorted by completed_at, got %+v", completions)
+	}
+}
+
+func TestAchievementRepository_CompleteLimit(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	dayStart := time.Date(2024, 6, 10, 0, 0, 0, 0, tokyo)
+	limit := &models.CompletionLimit{MaxPerDay: 2, MaxTotal: 5, DayStart: dayStart, DayEnd: dayStart.AddDate(0, 0, 1)}
+	completions := completionsAt(time.Date(2024, 6, 9, 23, 0, 0, 0, tokyo), time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo))
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			*result.(*models.Achievement) = models.Achievement{ID: "test-id", Title: "水を飲んだ", Point: 1}
+			return nil
+		},
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			*result.(*[]*models.Completion) = completions
+			return "", nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{Achievements: "test-achievements", Completions: "test-completions"}}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	// 通算・その日のカウンターが上限未満の場合のみ増やす（カウンターが無い場合は達成記録の件数から数える）
+	if err := repo.Complete(context.Background(), &models.Completion{AchievementID: "test-id", Point: 1, Limit: limit}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	check := written[0]
+	if check.Operation != "UPDATE" ||
+		check.UpdateExpression != "SET completion_count = if_not_exists(completion_count, :completed) + :one, #today = if_not_exists(#today, :completed_today) + :one" ||
+		check.ConditionExpression != conditionExists+" AND (attribute_not_exists(completion_count) OR completion_count < :max_total) AND (attribute_not_exists(#today) OR #today < :max_per_day)" ||
+		check.ExpressionAttributeNames["#today"] != "completions_on_20240610" {
+		t.Errorf("Unexpected counter update: %+v", check)
+	}
+	if check.ExpressionAttributeValues[":completed"] != 2 || check.ExpressionAttributeValues[":completed_today"] != 1 ||
+		check.ExpressionAttributeValues[":max_total"] != 5 || check.ExpressionAttributeValues[":max_per_day"] != 2 {
+		t.Errorf("Unexpected counter values: %v", check.ExpressionAttributeValues)
+	}
+
+	// その日に初めて達成する場合は、前回の達成日のカウンターを削除する
+	completions = completionsAt(time.Date(2024, 6, 9, 23, 0, 0, 0, tokyo))
+	if err := repo.Complete(context.Background(), &models.Completion{AchievementID: "test-id", Point: 1, Limit: limit}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if !strings.HasSuffix(written[0].UpdateExpression, " REMOVE #previous") || written[0].ExpressionAttributeNames["#previous"] != "completions_on_20240609" {
+		t.Errorf("Expected the previous day counter to be removed, got %+v", written[0])
+	}
+
+	// 読み取った時点で上限に達している場合は書き込まない
+	written = nil
+	completions = completionsAt(time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo), time.Date(2024, 6, 10, 9, 0, 0, 0, tokyo))
+	err := repo.Complete(context.Background(), &models.Completion{AchievementID: "test-id", Point: 1, Limit: limit})
+	var businessErr *errors.BusinessLogicError
+	if !stderrors.As(err, &businessErr) || businessErr.Code != errors.CodeCompletionLimit || written != nil {
+		t.Errorf("Expected a completion limit error without writing, got %v", err)
+	}
+
+	// 同時に達成されて条件を満たさなかった場合は、読み直した達成記録で上限に達していることを確認する
+	completions = completionsAt(time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo))
+	mockRepo.transactFunc = func(items []TransactWriteItem) error {
+		completions = completionsAt(time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo), time.Date(2024, 6, 10, 9, 0, 0, 0, tokyo))
+		return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+	}
+	err = repo.Complete(context.Background(), &models.Completion{AchievementID: "test-id", Point: 1, Limit: limit})
+	if !stderrors.As(err, &businessErr) || businessErr.Code != errors.CodeCompletionLimit {
+		t.Errorf("Expected a completion limit error, got %v", err)
+	}
+	if mockRepo.consistentGets != 1 {
+		t.Errorf("Expected the achievement to be read consistently, got %d consistent reads", mockRepo.consistentGets)
+	}
+}
+
+// completionsAt 指定した日時の達成記録を作成
+func completionsAt(times ...time.Time) []*models.Completion {
+	completions := make([]*models.Completion, len(times))
+	for i, at := range times {
+		completions[i] = &models.Completion{ID: fmt.Sprintf("c%d", i), AchievementID: "test-id", Point: 1, CompletedAt: at}
+	}
+	return completions
+}
+
+func TestAchievementRepository_CreateWithPoints_Errors(t *testing.T) {
+	config := &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}}
+
+	// 同じIDの達成目録が既に存在する場合
+	repo := NewAchievementRepository(&MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
+	}, config)
+	err := repo.CreateWithPoints(context.Background(), &models.Achievement{ID: "test-id", Title: "Test", Point: 10})
+	if err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	// それ以外のエラーはデータベースエラー
+	repo = NewAchievementRepository(&MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			return fmt.Errorf("transaction canceled")
+		},
+	}, config)
+	err = repo.CreateWithPoints(context.Background(), &models.Achievement{Title: "Test", Point: 10})
+	if _, ok := err.(*errors.DatabaseError); !ok {
+		t.Errorf("Expected DatabaseError, got %v", err)
+	}
+
+	// 検証エラーの場合は書き込まない
+	repo = NewAchievementRepository(&MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			t.Error("TransactWrite should not be called")
+			return nil
+		},
+	}, config)
+	err = repo.CreateWithPoints(context.Background(), &models.Achievement{Title: "", Point: 10})
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
 func TestAchievementRepository_GetByID(t *testing.T) {
 	testAchievement := &models.Achievement{
 		ID:          "test-id",
@@ -168,7 +841,7 @@ Achievements: "test-achievements",
 }
 	repo := NewAchievementRepository(mockRepo, config)
 
-	result, err := repo.GetByID("test-id")
+	result, err := repo.GetByID(context.Background(), "test-id")
 	if err != nil {
 		t.Errorf("GetByID failed: %v", err)
 	}
@@ -181,7 +854,7 @@ Achievements: "test-achievements",
 func TestAchievementRepository_GetByID_NotFound(t *testing.T) {
 	mockRepo := &MockRepository{
 		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			return fmt.Errorf("item not found in table test-achievements")
+			return fmt.Errorf("%w in table test-achievements", ErrItemNotFound)
 		},
 	}
 
@@ -192,7 +865,7 @@ Achievements: "test-achievements",
 }
 	repo := NewAchievementRepository(mockRepo, config)
 
-	_, err := repo.GetByID("non-existent-id")
+	_, err := repo.GetByID(context.Background(), "non-existent-id")
 	if err != errors.ErrNotFound {
 		t.Errorf("Expected ErrNotFound, got %v", err)
 	}
@@ -207,7 +880,7 @@ Achievements: "test-achievements",
 }
 	repo := NewAchievementRepository(mockRepo, config)
 
-	_, err := repo.GetByID("")
+	_, err := repo.GetByID(context.Background(), "")
 	if err == nil {
 		t.Error("Expected validation error for empty ID")
 	}
@@ -232,11 +905,14 @@ func TestAchievementRepository_List(t *testing.T) {
 	}
 
 	mockRepo := &MockRepository{
-		scanFunc: func(tableName string, result interface{}) error {
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			if input.IndexName != CreatedAtIndex {
+				t.Errorf("Expected query on %s, got %s", CreatedAtIndex, input.IndexName)
+			}
 			if achievements, ok := result.(*[]*models.Achievement); ok {
 				*achievements = testAchievements
 			}
-			return nil
+			return "", nil
 		},
 	}
 
@@ -247,7 +923,7 @@ Achievements: "test-achievements",
 }
 	repo := NewAchievementRepository(mockRepo, config)
 
-	results, err := repo.List()
+	results, err := repo.List(context.Background())
 	if err != nil {
 		t.Errorf("List failed: %v", err)
 	}
@@ -257,6 +933,90 @@ Achievements: "test-achievements",
 	}
 }
 
+func TestAchievementRepository_ListSummaries(t *testing.T) {
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			// 分類ごとの集計とポイントの提案のため分類・難易度も読み取る
+			if fmt.Sprint(input.Projection) != fmt.Sprint([]string{"id", "title", "point", "category", "difficulty"}) {
+				t.Errorf("Expected id/title/point/category/difficulty projection, got %v", input.Projection)
+			}
+			if achievements, ok := result.(*[]*models.Achievement); ok {
+				*achievements = []*models.Achievement{{ID: "test-id-1", Title: "Test Achievement 1", Point: 100, Category: "health"}}
+			}
+			return "", nil
+		},
+	}
+
+	repo := NewAchievementRepository(mockRepo, &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}})
+
+	results, err := repo.ListSummaries(context.Background())
+	if err != nil {
+		t.Fatalf("ListSummaries failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Point != 100 || results[0].Category != "health" {
+		t.Errorf("Unexpected summaries: %+v", results)
+	}
+}
+
+func TestAchievementRepository_Count(t *testing.T) {
+	mockRepo := &MockRepository{
+		itemCountFunc: func(tableName string) (int64, error) {
+			if tableName != "test-achievements" {
+				t.Errorf("Expected test-achievements, got %s", tableName)
+			}
+			return 5, nil
+		},
+		// 件数の取得でテーブルをスキャンしないことを確認
+		scanFunc: func(input ScanInput, result interface{}) (string, error) {
+			t.Error("Count should not scan the table")
+			return "", nil
+		},
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			t.Error("Count should not query the table")
+			return "", nil
+		},
+	}
+
+	repo := NewAchievementRepository(mockRepo, &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}})
+
+	count, err := repo.Count(context.Background())
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected 5, got %d", count)
+	}
+}
+
+func TestAchievementRepository_CountTenant(t *testing.T) {
+	mockRepo := &MockRepository{
+		// テーブルを共有する他のテナントの件数を含めない
+		itemCountFunc: func(tableName string) (int64, error) {
+			t.Error("Count should not use the table item count when tenancy is enabled")
+			return 0, nil
+		},
+		queryCountFunc: func(input QueryInput) (int64, error) {
+			if input.IndexName != CreatedAtIndex || input.ExpressionAttributeValues[":entity_type"] != "family-a#"+EntityTypeAchievement {
+				t.Errorf("Expected a count of the tenant's achievements, got %+v", input)
+			}
+			return 3, nil
+		},
+	}
+
+	repo := NewAchievementRepository(mockRepo, &config.Config{
+		Tables:  config.TableConfig{Achievements: "test-achievements"},
+		Tenancy: config.TenancyConfig{Enabled: true},
+	})
+
+	count, err := repo.Count(tenant.WithID(context.Background(), "family-a"))
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3, got %d", count)
+	}
+}
+
 func TestAchievementRepository_Update(t *testing.T) {
 	existingAchievement := &models.Achievement{
 		ID:          "test-id",
@@ -289,7 +1049,7 @@ Achievements: "test-achievements",
 		Point:       200,
 	}
 
-	err := repo.Update(updatedAchievement)
+	err := repo.Update(context.Background(), updatedAchievement)
 	if err != nil {
 		t.Errorf("Update failed: %v", err)
 	}
@@ -325,16 +1085,21 @@ Achievements: "test-achievements",
 }
 	repo := NewAchievementRepository(mockRepo, config)
 
-	err := repo.Delete("test-id")
+	err := repo.Delete(context.Background(), "test-id")
 	if err != nil {
 		t.Errorf("Delete failed: %v", err)
 	}
+
+	// 存在確認ではIDのみを読み取る
+	if len(mockRepo.projections) != 1 || fmt.Sprint(mockRepo.projections[0]) != "[id]" {
+		t.Errorf("Expected an id-only read, got %v", mockRepo.projections)
+	}
 }
 
 func TestAchievementRepository_Delete_NotFound(t *testing.T) {
 	mockRepo := &MockRepository{
 		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			return fmt.Errorf("item not found in table test-achievements")
+			return fmt.Errorf("%w in table test-achievements", ErrItemNotFound)
 		},
 	}
 
@@ -345,8 +1110,166 @@ Achievements: "test-achievements",
 }
 	repo := NewAchievementRepository(mockRepo, config)
 
-	err := repo.Delete("non-existent-id")
+	err := repo.Delete(context.Background(), "non-existent-id")
 	if err != errors.ErrNotFound {
 		t.Errorf("Expected ErrNotFound, got %v", err)
 	}
-}
\ No newline at end of file
+}
+
+func TestAchievementRepository_ConditionalWrites(t *testing.T) {
+	var conditions []string
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			conditions = append(conditions, conditionExpression)
+			return fmt.Errorf("failed to put item: %w", ErrConditionFailed)
+		},
+		versionFunc: func(tableName string, item interface{}, expectedVersion int) error {
+			return fmt.Errorf("failed to put item: %w", ErrConditionFailed)
+		},
+	}
+
+	config := &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	// 同じIDの達成目録が既に存在する場合
+	err := repo.Create(context.Background(), &models.Achievement{ID: "test-id", Title: "Test", Point: 10})
+	if err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	// 取得後に削除された場合
+	err = repo.Update(context.Background(), &models.Achievement{ID: "test-id", Title: "Test", Point: 10})
+	if err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if len(conditions) != 1 || conditions[0] != "attribute_not_exists(id)" {
+		t.Errorf("Unexpected condition expressions: %v", conditions)
+	}
+}
+
+func TestAchievementRepository_Update_Version(t *testing.T) {
+	var expectedVersions []int
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if achievement, ok := result.(*models.Achievement); ok {
+				*achievement = models.Achievement{ID: "test-id", Title: "Original", Point: 10, Version: 3}
+			}
+			return nil
+		},
+		versionFunc: func(tableName string, item interface{}, expectedVersion int) error {
+			expectedVersions = append(expectedVersions, expectedVersion)
+			if expectedVersion != 3 {
+				return fmt.Errorf("failed to put item: %w", ErrVersionMismatch)
+			}
+			return nil
+		},
+	}
+
+	config := &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	// バージョンを指定しない場合は取得したバージョンから更新する
+	achievement := &models.Achievement{ID: "test-id", Title: "Updated", Point: 20}
+	if err := repo.Update(context.Background(), achievement); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if achievement.Version != 4 {
+		t.Errorf("Expected version 4 after update, got %d", achievement.Version)
+	}
+	if mockRepo.consistentGets != 1 {
+		t.Errorf("Expected existing item to be read consistently, got %d consistent reads", mockRepo.consistentGets)
+	}
+
+	// 古いバージョンを指定した場合は競合する
+	err := repo.Update(context.Background(), &models.Achievement{ID: "test-id", Title: "Stale", Point: 20, Version: 2})
+	if err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	if fmt.Sprint(expectedVersions) != "[3 2]" {
+		t.Errorf("Unexpected expected versions: %v", expectedVersions)
+	}
+}
+
+func TestAchievementRepository_DeleteMany(t *testing.T) {
+	var deletedKeys []map[string]interface{}
+	mockRepo := &MockRepository{
+		batchDelFunc: func(tableName string, keys []map[string]interface{}) error {
+			if tableName != "test-achievements" {
+				t.Errorf("Expected table name 'test-achievements', got '%s'", tableName)
+			}
+			deletedKeys = keys
+			return nil
+		},
+	}
+
+	config := &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}}
+	repo := NewAchievementRepository(mockRepo, config)
+
+	if err := repo.DeleteMany(context.Background(), []string{"id-1", "id-2"}); err != nil {
+		t.Fatalf("DeleteMany failed: %v", err)
+	}
+	if len(deletedKeys) != 2 || deletedKeys[0]["id"] != "id-1" || deletedKeys[1]["id"] != "id-2" {
+		t.Errorf("Unexpected keys: %v", deletedKeys)
+	}
+
+	// 空のIDが含まれる場合
+	err := repo.DeleteMany(context.Background(), []string{"id-1", ""})
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestAchievementRepository_TenantKeys(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "family-a")
+	var stored achievementItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			stored = item.(achievementItem)
+			return nil
+		},
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if key["id"] != "family-a#test-id" {
+				t.Errorf("Expected tenant key, got %v", key["id"])
+			}
+			*result.(*models.Achievement) = models.Achievement{ID: "family-a#test-id", Title: "Test", Point: 10}
+			return nil
+		},
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			if input.ExpressionAttributeValues[":entity_type"] != "family-a#"+EntityTypeAchievement {
+				t.Errorf("Expected tenant entity_type, got %v", input.ExpressionAttributeValues[":entity_type"])
+			}
+			*result.(*[]*models.Achievement) = []*models.Achievement{{ID: "family-a#test-id", Title: "Test", Point: 10}}
+			return "", nil
+		},
+	}
+	repo := NewAchievementRepository(mockRepo, &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}})
+
+	achievement := &models.Achievement{ID: "test-id", Title: "Test", Point: 10}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if stored.ID != "family-a#test-id" || stored.EntityType != "family-a#"+EntityTypeAchievement {
+		t.Errorf("Expected item stored under the tenant key, got id=%s entity_type=%s", stored.ID, stored.EntityType)
+	}
+	if achievement.ID != "test-id" {
+		t.Errorf("Expected caller's ID to be unchanged, got %s", achievement.ID)
+	}
+
+	result, err := repo.GetByID(ctx, "test-id")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if result.ID != "test-id" {
+		t.Errorf("Expected tenant prefix to be removed, got %s", result.ID)
+	}
+
+	results, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "test-id" {
+		t.Errorf("Expected tenant prefix to be removed from list, got %+v", results)
+	}
+}
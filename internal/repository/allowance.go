@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// conditionNotGranted お小遣いの付与時（削除済みのルールや、同じ分またはそれ以降に付与済みのルールには付与しない）
+const conditionNotGranted = "attribute_exists(id) AND (attribute_not_exists(last_granted_at) OR last_granted_at < :granted_at)"
+
+// AllowanceRepositoryImpl お小遣いのルールのリポジトリの実装
+type AllowanceRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewAllowanceRepository お小遣いのルールのリポジトリを作成
+func NewAllowanceRepository(repo Repository, config *config.Config) AllowanceRepository {
+	return &AllowanceRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Create お小遣いのルールを作成
+func (r *AllowanceRepositoryImpl) Create(ctx context.Context, allowance *models.Allowance) error {
+	if err := PrepareAllowance(allowance); err != nil {
+		return err
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Allowances, newAllowanceItem(ctx, allowance), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Create",
+			Table:     r.config.Tables.Allowances,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetByID IDでお小遣いのルールを取得
+func (r *AllowanceRepositoryImpl) GetByID(ctx context.Context, id string) (*models.Allowance, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	var allowance models.Allowance
+	err := r.repo.GetItem(ctx, r.config.Tables.Allowances, itemKey(ctx, id), &allowance)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{
+			Operation: "GetByID",
+			Table:     r.config.Tables.Allowances,
+			Cause:     err,
+		}
+	}
+
+	allowance.ID = tenant.EntityID(ctx, allowance.ID)
+	return &allowance, nil
+}
+
+// List すべてのお小遣いのルールを作成日時順に取得
+func (r *AllowanceRepositoryImpl) List(ctx context.Context) ([]*models.Allowance, error) {
+	var allowances []*models.Allowance
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Allowances, CreatedAtIndex, EntityTypeAllowance), &allowances)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Allowances,
+			Cause:     err,
+		}
+	}
+
+	for _, allowance := range allowances {
+		allowance.ID = tenant.EntityID(ctx, allowance.ID)
+	}
+	return allowances, nil
+}
+
+// Delete お小遣いのルールを削除（付与済みのポイントは取り消さない）
+func (r *AllowanceRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	// 存在確認（IDのみを読み取る）
+	var item struct {
+		ID string `dynamodbav:"id"`
+	}
+	err := r.repo.GetItemWithProjection(ctx, r.config.Tables.Allowances, itemKey(ctx, id), idProjection, &item)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Allowances,
+			Cause:     err,
+		}
+	}
+
+	err = r.repo.DeleteItem(ctx, r.config.Tables.Allowances, itemKey(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Allowances,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Grant お小遣いの付与日時の記録と、ポイントの付与・台帳への記録をトランザクションで実行
+//
+// 削除されたルールや、同時に実行した別のスケジューラーが同じ分に先に付与したルールには付与せず errors.ErrVersionConflict を返す。
+func (r *AllowanceRepositoryImpl) Grant(ctx context.Context, id string, points int, at time.Time) error {
+	grantedAt, err := PrepareAllowanceGrant(id, points, at)
+	if err != nil {
+		return err
+	}
+
+	entry := NewLedgerEntry(models.LedgerEntryAllowance, points, id)
+	items := []TransactWriteItem{
+		{
+			TableName:                 r.config.Tables.Allowances,
+			Operation:                 "UPDATE",
+			Key:                       itemKey(ctx, id),
+			UpdateExpression:          "SET last_granted_at = :granted_at",
+			ConditionExpression:       conditionNotGranted,
+			ExpressionAttributeValues: map[string]interface{}{":granted_at": grantedAt},
+		},
+		ledgerPut(ctx, r.config, entry),
+		counterUpdate(ctx, r.config, points, entry.CreatedAt),
+	}
+
+	err = r.repo.TransactWrite(ctx, items)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrVersionConflict
+		}
+		return &errors.DatabaseError{
+			Operation: "Grant",
+			Table:     pointTables(r.config) + "," + r.config.Tables.Allowances,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// PrepareAllowance 作成するお小遣いのルールを検証し、IDと作成日時が未設定の場合は設定（すべてのストレージで共通）
+//
+// 付与する日時のcron式の構文はサービスで検証する。
+func PrepareAllowance(allowance *models.Allowance) error {
+	if allowance == nil {
+		return &errors.ValidationError{Field: "allowance", Message: "allowance cannot be nil"}
+	}
+	if allowance.Title == "" {
+		return &errors.ValidationError{Field: "title", Message: "title is required"}
+	}
+	if allowance.Points <= 0 {
+		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+	if allowance.Schedule == "" {
+		return &errors.ValidationError{Field: "schedule", Message: "schedule is required"}
+	}
+
+	// IDが空の場合はULIDを生成
+	if allowance.ID == "" {
+		allowance.ID = ulid.Make().String()
+	}
+	if allowance.CreatedAt.IsZero() {
+		allowance.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// PrepareAllowanceGrant 付与するお小遣いを検証し、記録する付与日時（UTCの分単位）を返す（すべてのストレージで共通）
+//
+// 同じ分の付与は1回だけにするため、スケジューラーが判定した日時を分に丸めて比較する。
+func PrepareAllowanceGrant(id string, points int, at time.Time) (time.Time, error) {
+	if id == "" {
+		return time.Time{}, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+	if points <= 0 {
+		return time.Time{}, &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+	return at.UTC().Truncate(time.Minute), nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testAllowanceConfig() *config.Config {
+	return &config.Config{
+		Tables: config.TableConfig{
+			Allowances:    "test-allowances",
+			CurrentPoints: "test-current-points",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+}
+
+func TestAllowanceRepository_Create(t *testing.T) {
+	var putItem allowanceItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			putItem = item.(allowanceItem)
+			return nil
+		},
+	}
+	repo := NewAllowanceRepository(mockRepo, testAllowanceConfig())
+
+	allowance := &models.Allowance{Title: "お小遣い", Points: 50, Schedule: "0 9 * * 1"}
+	if err := repo.Create(tenant.WithID(context.Background(), "acme"), allowance); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if allowance.ID == "" || allowance.CreatedAt.IsZero() {
+		t.Error("ID and CreatedAt should be set")
+	}
+	if putItem.ID != "acme#"+allowance.ID || putItem.EntityType != "acme#"+EntityTypeAllowance {
+		t.Errorf("Expected tenant keys, got %s / %s", putItem.ID, putItem.EntityType)
+	}
+
+	invalid := []*models.Allowance{
+		nil,
+		{Points: 50, Schedule: "0 9 * * 1"},
+		{Title: "お小遣い", Schedule: "0 9 * * 1"},
+		{Title: "お小遣い", Points: 50},
+	}
+	for _, allowance := range invalid {
+		if _, ok := repo.Create(context.Background(), allowance).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", allowance)
+		}
+	}
+}
+
+func TestAllowanceRepository_Grant(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	repo := NewAllowanceRepository(mockRepo, testAllowanceConfig())
+
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	if err := repo.Grant(context.Background(), "allowance-123", 50, time.Date(2024, 6, 10, 9, 0, 42, 0, tokyo)); err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+
+	// 付与日時・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 3 {
+		t.Fatalf("Expected 3 transaction items, got %d", len(written))
+	}
+	if written[0].TableName != "test-allowances" || written[0].ConditionExpression != conditionNotGranted {
+		t.Errorf("Unexpected allowance update: %+v", written[0])
+	}
+	// 付与日時はUTCの分単位で記録する
+	if grantedAt := written[0].ExpressionAttributeValues[":granted_at"]; grantedAt != time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("Expected the granted minute in UTC, got %v", grantedAt)
+	}
+	ledger, ok := written[1].Item.(pointLedgerItem)
+	if !ok || ledger.Type != models.LedgerEntryAllowance || ledger.Amount != 50 || ledger.Reference != "allowance-123" {
+		t.Errorf("Unexpected ledger item: %+v", written[1])
+	}
+	if written[2].TableName != "test-current-points" || written[2].ExpressionAttributeValues[":delta"] != 50 {
+		t.Errorf("Unexpected counter update: %+v", written[2])
+	}
+}
+
+func TestAllowanceRepository_Grant_AlreadyGranted(t *testing.T) {
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
+	}
+	repo := NewAllowanceRepository(mockRepo, testAllowanceConfig())
+
+	if err := repo.Grant(context.Background(), "allowance-123", 50, time.Now()); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+	if _, ok := repo.Grant(context.Background(), "allowance-123", 0, time.Now()).(*errors.ValidationError); !ok {
+		t.Error("Expected validation error for zero points")
+	}
+}
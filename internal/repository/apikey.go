@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// APIKeyRepositoryImpl APIキーのリポジトリの実装
+type APIKeyRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewAPIKeyRepository APIキーのリポジトリを作成
+func NewAPIKeyRepository(repo Repository, config *config.Config) APIKeyRepository {
+	return &APIKeyRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Create APIキーを作成
+func (r *APIKeyRepositoryImpl) Create(ctx context.Context, key *models.APIKey) error {
+	if err := PrepareAPIKey(key); err != nil {
+		return err
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.APIKeys, newAPIKeyItem(ctx, key), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Create",
+			Table:     r.config.Tables.APIKeys,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Update APIキーのラベル・範囲・ハッシュ値・無効にした日時を更新
+func (r *APIKeyRepositoryImpl) Update(ctx context.Context, key *models.APIKey) error {
+	if err := ValidateAPIKey(key); err != nil {
+		return err
+	}
+	if key.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.APIKeys, newAPIKeyItem(ctx, key), conditionExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Update",
+			Table:     r.config.Tables.APIKeys,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetByID IDでAPIキーを取得
+func (r *APIKeyRepositoryImpl) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	var key models.APIKey
+	err := r.repo.GetItem(ctx, r.config.Tables.APIKeys, itemKey(ctx, id), &key)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{
+			Operation: "GetByID",
+			Table:     r.config.Tables.APIKeys,
+			Cause:     err,
+		}
+	}
+
+	key.ID = tenant.EntityID(ctx, key.ID)
+	return &key, nil
+}
+
+// List すべてのAPIキーを作成日時順に取得（無効にしたキーを含む）
+func (r *APIKeyRepositoryImpl) List(ctx context.Context) ([]*models.APIKey, error) {
+	var keys []*models.APIKey
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.APIKeys, CreatedAtIndex, EntityTypeAPIKey), &keys)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.APIKeys,
+			Cause:     err,
+		}
+	}
+
+	for _, key := range keys {
+		key.ID = tenant.EntityID(ctx, key.ID)
+	}
+	return keys, nil
+}
+
+// ValidateAPIKey APIキーのバリデーション（すべてのストレージで共通）
+func ValidateAPIKey(key *models.APIKey) error {
+	if key == nil {
+		return &errors.ValidationError{Field: "api_key", Message: "api key cannot be nil"}
+	}
+	if key.Label == "" {
+		return &errors.ValidationError{Field: "label", Message: "label is required"}
+	}
+	if !key.Scope.Valid() {
+		return &errors.ValidationError{Field: "scope", Message: "scope must be one or more known scopes separated by spaces"}
+	}
+	if key.Hash == "" {
+		return &errors.ValidationError{Field: "hash", Message: "hash is required"}
+	}
+	return nil
+}
+
+// PrepareAPIKey 作成するAPIキーを検証し、IDと作成日時が未設定の場合は設定（すべてのストレージで共通）
+func PrepareAPIKey(key *models.APIKey) error {
+	if err := ValidateAPIKey(key); err != nil {
+		return err
+	}
+
+	// IDが空の場合はULIDを生成
+	if key.ID == "" {
+		key.ID = ulid.Make().String()
+	}
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testAPIKeyConfig() *config.Config {
+	return &config.Config{
+		Tables: config.TableConfig{
+			APIKeys: "test-api-keys",
+		},
+	}
+}
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	var putItem apiKeyItem
+	var condition string
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			putItem = item.(apiKeyItem)
+			condition = conditionExpression
+			return nil
+		},
+	}
+	repo := NewAPIKeyRepository(mockRepo, testAPIKeyConfig())
+
+	key := &models.APIKey{Label: "ダッシュボード", Scope: models.APIKeyScopeRead, Hash: "5e884898"}
+	if err := repo.Create(tenant.WithID(context.Background(), "acme"), key); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if key.ID == "" || key.CreatedAt.IsZero() {
+		t.Error("ID and CreatedAt should be set")
+	}
+	if putItem.ID != "acme#"+key.ID || putItem.EntityType != "acme#"+EntityTypeAPIKey || condition != conditionNotExists {
+		t.Errorf("Expected tenant keys, got %s / %s (%s)", putItem.ID, putItem.EntityType, condition)
+	}
+
+	invalid := []*models.APIKey{
+		nil,
+		{Scope: models.APIKeyScopeRead, Hash: "5e884898"},
+		{Label: "ダッシュボード", Scope: "superuser", Hash: "5e884898"},
+		{Label: "ダッシュボード", Scope: models.APIKeyScopeWrite},
+	}
+	for _, key := range invalid {
+		if _, ok := repo.Create(context.Background(), key).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", key)
+		}
+	}
+}
+
+func TestAPIKeyRepository_Update_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			if conditionExpression != conditionExists {
+				t.Errorf("Expected %s, got %s", conditionExists, conditionExpression)
+			}
+			return fmt.Errorf("failed to put item: %w", ErrConditionFailed)
+		},
+	}
+	repo := NewAPIKeyRepository(mockRepo, testAPIKeyConfig())
+
+	key := &models.APIKey{ID: "key-123", Label: "ダッシュボード", Scope: models.APIKeyScopeRead, Hash: "5e884898"}
+	if err := repo.Update(context.Background(), key); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
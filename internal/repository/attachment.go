@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+
+	"achievement-management/internal/errors"
+)
+
+// AttachmentKeyAttribute 添付ファイルのS3オブジェクトキーを保存する属性
+const AttachmentKeyAttribute = "attachment_key"
+
+// ValidateAttachment 添付ファイルを保存する記録のIDとオブジェクトキーのバリデーション（すべてのストレージで共通）
+func ValidateAttachment(id, key string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+	if key == "" {
+		return &errors.ValidationError{Field: "attachment_key", Message: "attachment_key is required"}
+	}
+	return nil
+}
+
+// setAttachmentKey 既存のアイテムに添付ファイルのオブジェクトキーを保存（以前のキーは置き換える。アイテムが無い場合は ErrNotFound）
+//
+// 添付ファイルは内容の編集ではないため、達成目録のバージョンは進めない。
+func setAttachmentKey(ctx context.Context, repo Repository, tableName, operation, id, key string) error {
+	if err := ValidateAttachment(id, key); err != nil {
+		return err
+	}
+
+	err := repo.UpdateItemWithCondition(ctx, tableName, itemKey(ctx, id), "SET "+AttachmentKeyAttribute+" = :attachment_key", conditionExists, map[string]interface{}{
+		":attachment_key": key,
+	})
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: operation,
+			Table:     tableName,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
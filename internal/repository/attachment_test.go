@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/tenant"
+)
+
+func TestAchievementRepository_SetAttachment(t *testing.T) {
+	var updatedTable, updatedExpression, updatedCondition string
+	var updatedKey, updatedValues map[string]interface{}
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			updatedTable, updatedKey, updatedExpression, updatedCondition, updatedValues = tableName, key, updateExpression, conditionExpression, expressionAttributeValues
+			return nil
+		},
+	}
+	repo := NewAchievementRepository(mockRepo, &config.Config{Tables: config.TableConfig{Achievements: "test-achievements"}})
+
+	if err := repo.SetAttachment(tenant.WithID(context.Background(), "acme"), "achievement-1", "attachments/acme/achievement/achievement-1/1.jpg"); err != nil {
+		t.Fatalf("SetAttachment failed: %v", err)
+	}
+
+	if updatedTable != "test-achievements" || updatedKey["id"] != "acme#achievement-1" {
+		t.Errorf("Unexpected update target: %s %v", updatedTable, updatedKey)
+	}
+	// アイテム全体を書き換えず、オブジェクトキーの属性だけを既存のアイテムに設定する（バージョンは進めない）
+	if updatedExpression != "SET attachment_key = :attachment_key" || updatedCondition != conditionExists {
+		t.Errorf("Unexpected update: %q if %q", updatedExpression, updatedCondition)
+	}
+	if updatedValues[":attachment_key"] != "attachments/acme/achievement/achievement-1/1.jpg" {
+		t.Errorf("Unexpected attachment key: %v", updatedValues)
+	}
+}
+
+func TestPointRepository_SetRedemptionAttachment(t *testing.T) {
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			return ErrConditionFailed
+		},
+	}
+	repo := NewPointRepository(mockRepo, &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}})
+
+	// 報酬獲得履歴が無い場合は添付しない
+	err := repo.SetRedemptionAttachment(context.Background(), "missing", "attachments/receipt.pdf")
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	var validationErr *errors.ValidationError
+	if err := repo.SetRedemptionAttachment(context.Background(), "history-1", ""); !stderrors.As(err, &validationErr) {
+		t.Errorf("Expected validation error for empty key, got %v", err)
+	}
+}
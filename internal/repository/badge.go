@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+// BadgeRepositoryImpl バッジリポジトリの実装
+type BadgeRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewBadgeRepository バッジリポジトリを作成
+func NewBadgeRepository(repo Repository, config *config.Config) BadgeRepository {
+	return &BadgeRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Award バッジの獲得を記録（獲得済みの場合は errors.ErrDuplicateResource）
+func (r *BadgeRepositoryImpl) Award(ctx context.Context, badge *models.Badge) error {
+	if err := ValidateBadge(badge); err != nil {
+		return err
+	}
+
+	if badge.EarnedAt.IsZero() {
+		badge.EarnedAt = time.Now()
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Badges, newBadgeItem(ctx, badge), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Award",
+			Table:     r.config.Tables.Badges,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// List 獲得したバッジを獲得日時順に取得
+func (r *BadgeRepositoryImpl) List(ctx context.Context) ([]*models.Badge, error) {
+	var badges []*models.Badge
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Badges, EarnedAtIndex, EntityTypeBadge), &badges)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Badges,
+			Cause:     err,
+		}
+	}
+
+	for _, badge := range badges {
+		badge.ID = tenant.EntityID(ctx, badge.ID)
+	}
+	return badges, nil
+}
+
+// ValidateBadge バッジのバリデーション
+func ValidateBadge(badge *models.Badge) error {
+	if badge == nil {
+		return &errors.ValidationError{Field: "badge", Message: "badge cannot be nil"}
+	}
+	if badge.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+	if badge.Name == "" {
+		return &errors.ValidationError{Field: "name", Message: "name is required"}
+	}
+	return nil
+}
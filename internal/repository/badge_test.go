@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestBadgeRepository_Award(t *testing.T) {
+	var putTable, putCondition string
+	var putItem badgeItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			putTable, putCondition = tableName, conditionExpression
+			putItem = item.(badgeItem)
+			return nil
+		},
+	}
+	repo := NewBadgeRepository(mockRepo, &config.Config{Tables: config.TableConfig{Badges: "test-badges"}})
+
+	badge := &models.Badge{ID: "first_achievement", Name: "First Step"}
+	if err := repo.Award(tenant.WithID(context.Background(), "acme"), badge); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+
+	if badge.EarnedAt.IsZero() {
+		t.Error("EarnedAt should be set")
+	}
+	if putTable != "test-badges" || putCondition != conditionNotExists {
+		t.Errorf("Expected conditional put to test-badges, got %s (%s)", putTable, putCondition)
+	}
+	// 同じバッジはテナントごとに1回だけ獲得できるよう、テナントのキーで保存する
+	if putItem.ID != "acme#first_achievement" || putItem.EntityType != "acme#"+EntityTypeBadge {
+		t.Errorf("Expected tenant keys, got %s / %s", putItem.ID, putItem.EntityType)
+	}
+	if badge.ID != "first_achievement" {
+		t.Errorf("Award should not change the badge ID, got %s", badge.ID)
+	}
+}
+
+func TestBadgeRepository_Award_AlreadyEarned(t *testing.T) {
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			return ErrConditionFailed
+		},
+	}
+	repo := NewBadgeRepository(mockRepo, &config.Config{Tables: config.TableConfig{Badges: "test-badges"}})
+
+	err := repo.Award(context.Background(), &models.Badge{ID: "first_achievement", Name: "First Step"})
+	if !stderrors.Is(err, errors.ErrDuplicateResource) {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+}
+
+func TestBadgeRepository_Award_ValidationError(t *testing.T) {
+	repo := NewBadgeRepository(&MockRepository{}, &config.Config{Tables: config.TableConfig{Badges: "test-badges"}})
+
+	for _, badge := range []*models.Badge{nil, {Name: "First Step"}, {ID: "first_achievement"}} {
+		if _, ok := repo.Award(context.Background(), badge).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", badge)
+		}
+	}
+}
+
+func TestBadgeRepository_List(t *testing.T) {
+	var queried QueryInput
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			queried = input
+			*result.(*[]*models.Badge) = []*models.Badge{
+				{ID: "acme#first_achievement", Name: "First Step", EarnedAt: time.Now()},
+			}
+			return "", nil
+		},
+	}
+	repo := NewBadgeRepository(mockRepo, &config.Config{Tables: config.TableConfig{Badges: "test-badges"}})
+
+	badges, err := repo.List(tenant.WithID(context.Background(), "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if queried.TableName != "test-badges" || queried.IndexName != EarnedAtIndex {
+		t.Errorf("Expected query on %s of test-badges, got %s of %s", EarnedAtIndex, queried.IndexName, queried.TableName)
+	}
+	if queried.ExpressionAttributeValues[":entity_type"] != "acme#"+EntityTypeBadge {
+		t.Errorf("Expected tenant entity type, got %v", queried.ExpressionAttributeValues[":entity_type"])
+	}
+	if len(badges) != 1 || badges[0].ID != "first_achievement" {
+		t.Errorf("Expected badge IDs without tenant prefix, got %+v", badges)
+	}
+}
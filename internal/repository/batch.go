@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// batchWriteLimit BatchWriteItemの1リクエストあたりの最大件数
+	batchWriteLimit = 25
+	// batchGetLimit BatchGetItemの1リクエストあたりの最大件数
+	batchGetLimit = 100
+	// maxBatchRetries 未処理アイテムを再送する最大回数
+	maxBatchRetries = 5
+)
+
+// batchRetryBackoff 未処理アイテム再送時の待機時間の最小値
+var batchRetryBackoff = 100 * time.Millisecond
+
+// batchBackoff 一括操作の送信間隔
+//
+// 未処理アイテムが返るたびに間隔を2倍に広げ、すべて処理されると半分に戻す。
+// 同じ一括操作の後続のリクエストにも間隔を引き継ぎ、容量が回復するまで送信の速度を落とす。
+type batchBackoff struct {
+	delay time.Duration
+}
+
+// wait 現在の送信間隔だけ待機
+func (b *batchBackoff) wait(ctx context.Context) error {
+	if b.delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(b.delay):
+		return nil
+	}
+}
+
+// throttled 未処理アイテムが返ったため送信間隔を広げる
+func (b *batchBackoff) throttled() {
+	b.delay *= 2
+	if b.delay < batchRetryBackoff {
+		b.delay = batchRetryBackoff
+	}
+	if b.delay > maxRetryDelay {
+		b.delay = maxRetryDelay
+	}
+}
+
+// succeeded すべて処理されたため送信間隔を狭める
+func (b *batchBackoff) succeeded() {
+	b.delay /= 2
+	if b.delay < batchRetryBackoff {
+		b.delay = 0
+	}
+}
+
+// retryAfter リトライを使い切った場合に呼び出し元に返す再試行までの時間
+func (b *batchBackoff) retryAfter() time.Duration {
+	if b.delay < throttledRetryAfter {
+		return throttledRetryAfter
+	}
+	return b.delay
+}
+
+// BatchPutItems 複数のアイテムを25件ずつまとめて書き込み
+func (r *DynamoDBRepository) BatchPutItems(ctx context.Context, tableName string, items []interface{}) error {
+	requests := make([]types.WriteRequest, 0, len(items))
+	for _, item := range items {
+		av, err := attributevalue.MarshalMap(item)
+		if err != nil {
+			return fmt.Errorf("failed to marshal item: %w", err)
+		}
+		requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: av}})
+	}
+
+	return r.batchWrite(ctx, tableName, requests)
+}
+
+// BatchDeleteItems 複数のアイテムを25件ずつまとめて削除
+func (r *DynamoDBRepository) BatchDeleteItems(ctx context.Context, tableName string, keys []map[string]interface{}) error {
+	requests := make([]types.WriteRequest, 0, len(keys))
+	for _, key := range keys {
+		keyAv, err := attributevalue.MarshalMap(key)
+		if err != nil {
+			return fmt.Errorf("failed to marshal key: %w", err)
+		}
+		requests = append(requests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: keyAv}})
+	}
+
+	return r.batchWrite(ctx, tableName, requests)
+}
+
+// batchWrite 書き込みリクエストを分割して送信し、未処理アイテムは送信間隔を広げながら再送
+func (r *DynamoDBRepository) batchWrite(ctx context.Context, tableName string, requests []types.WriteRequest) error {
+	var backoff batchBackoff
+	for start := 0; start < len(requests); start += batchWriteLimit {
+		end := start + batchWriteLimit
+		if end > len(requests) {
+			end = len(requests)
+		}
+
+		pending := requests[start:end]
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt > maxBatchRetries {
+				err := fmt.Errorf("failed to batch write to table %s: %d items unprocessed after %d retries", tableName, len(pending), maxBatchRetries)
+				return throttled(err, "unprocessed items", backoff.retryAfter())
+			}
+			if err := backoff.wait(ctx); err != nil {
+				return fmt.Errorf("batch write to table %s cancelled: %w", tableName, err)
+			}
+
+			resp, err := r.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{tableName: pending},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to batch write to table %s: %w", tableName, err)
+			}
+
+			pending = resp.UnprocessedItems[tableName]
+			if len(pending) > 0 {
+				backoff.throttled()
+			} else {
+				backoff.succeeded()
+			}
+		}
+	}
+
+	return nil
+}
+
+// BatchGetItems 複数のキーのアイテムを100件ずつまとめて取得し、resultのスライスに格納（順序は保証されず、存在しないキーは含まれない）
+func (r *DynamoDBRepository) BatchGetItems(ctx context.Context, tableName string, keys []map[string]interface{}, result interface{}) error {
+	keyAvs := make([]map[string]types.AttributeValue, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		// 同じキーが含まれるとBatchGetItemがエラーになるため重複を除外
+		id := fmt.Sprint(key)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		keyAv, err := attributevalue.MarshalMap(key)
+		if err != nil {
+			return fmt.Errorf("failed to marshal key: %w", err)
+		}
+		keyAvs = append(keyAvs, keyAv)
+	}
+
+	var items []map[string]types.AttributeValue
+	var backoff batchBackoff
+	for start := 0; start < len(keyAvs); start += batchGetLimit {
+		end := start + batchGetLimit
+		if end > len(keyAvs) {
+			end = len(keyAvs)
+		}
+
+		pending := keyAvs[start:end]
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt > maxBatchRetries {
+				err := fmt.Errorf("failed to batch get from table %s: %d keys unprocessed after %d retries", tableName, len(pending), maxBatchRetries)
+				return throttled(err, "unprocessed keys", backoff.retryAfter())
+			}
+			if err := backoff.wait(ctx); err != nil {
+				return fmt.Errorf("batch get from table %s cancelled: %w", tableName, err)
+			}
+
+			resp, err := r.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: map[string]types.KeysAndAttributes{tableName: {Keys: pending}},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to batch get from table %s: %w", tableName, err)
+			}
+
+			items = append(items, resp.Responses[tableName]...)
+			pending = resp.UnprocessedKeys[tableName].Keys
+			if len(pending) > 0 {
+				backoff.throttled()
+			} else {
+				backoff.succeeded()
+			}
+		}
+	}
+
+	if err := attributevalue.UnmarshalListOfMaps(items, result); err != nil {
+		return fmt.Errorf("failed to unmarshal items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	apperrors "achievement-management/internal/errors"
+)
+
+func TestDynamoDBRepository_BatchPutItems_Chunking(t *testing.T) {
+	var sizes []int
+	mockClient := &MockDynamoDBClient{
+		batchWriteItemFunc: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			sizes = append(sizes, len(params.RequestItems["test-table"]))
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	items := make([]interface{}, 60)
+	for i := range items {
+		items[i] = TestItem{ID: string(rune('a' + i%26)), Name: "item"}
+	}
+
+	if err := repo.BatchPutItems(context.Background(), "test-table", items); err != nil {
+		t.Fatalf("BatchPutItems failed: %v", err)
+	}
+
+	if len(sizes) != 3 || sizes[0] != 25 || sizes[1] != 25 || sizes[2] != 10 {
+		t.Errorf("Expected chunks of [25 25 10], got %v", sizes)
+	}
+}
+
+func TestDynamoDBRepository_BatchDeleteItems_RetriesUnprocessed(t *testing.T) {
+	original := batchRetryBackoff
+	batchRetryBackoff = time.Millisecond
+	defer func() { batchRetryBackoff = original }()
+
+	calls := 0
+	mockClient := &MockDynamoDBClient{
+		batchWriteItemFunc: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			calls++
+			requests := params.RequestItems["test-table"]
+			if requests[0].DeleteRequest == nil {
+				t.Error("Expected delete requests")
+			}
+			// 1回目は1件を未処理として返す
+			if calls == 1 {
+				return &dynamodb.BatchWriteItemOutput{
+					UnprocessedItems: map[string][]types.WriteRequest{"test-table": requests[:1]},
+				}, nil
+			}
+			if len(requests) != 1 {
+				t.Errorf("Expected only the unprocessed item to be resent, got %d", len(requests))
+			}
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	keys := []map[string]interface{}{{"id": "1"}, {"id": "2"}, {"id": "3"}}
+	if err := repo.BatchDeleteItems(context.Background(), "test-table", keys); err != nil {
+		t.Fatalf("BatchDeleteItems failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestDynamoDBRepository_BatchWrite_GivesUp(t *testing.T) {
+	original := batchRetryBackoff
+	batchRetryBackoff = time.Millisecond
+	defer func() { batchRetryBackoff = original }()
+
+	calls := 0
+	mockClient := &MockDynamoDBClient{
+		batchWriteItemFunc: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			calls++
+			return &dynamodb.BatchWriteItemOutput{UnprocessedItems: params.RequestItems}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	err := repo.BatchDeleteItems(context.Background(), "test-table", []map[string]interface{}{{"id": "1"}})
+	if err == nil || !strings.Contains(err.Error(), "1 items unprocessed") {
+		t.Errorf("Expected unprocessed error, got %v", err)
+	}
+	if calls != maxBatchRetries+1 {
+		t.Errorf("Expected %d calls, got %d", maxBatchRetries+1, calls)
+	}
+
+	// 未処理のまま残った場合はスロットリングとして再試行までの時間を返す
+	var throttledErr *apperrors.ThrottledError
+	if !errors.Is(err, ErrThrottled) || !errors.As(err, &throttledErr) || throttledErr.RetryAfter < throttledRetryAfter {
+		t.Errorf("Expected ThrottledError, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_BatchWrite_AdaptiveBackoff(t *testing.T) {
+	original := batchRetryBackoff
+	batchRetryBackoff = 5 * time.Millisecond
+	defer func() { batchRetryBackoff = original }()
+
+	// 1つ目のチャンクで2回続けて未処理アイテムが返る
+	var sent []time.Time
+	mockClient := &MockDynamoDBClient{
+		batchWriteItemFunc: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			sent = append(sent, time.Now())
+			if len(sent) <= 2 {
+				return &dynamodb.BatchWriteItemOutput{UnprocessedItems: params.RequestItems}, nil
+			}
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	items := make([]interface{}, 50)
+	for i := range items {
+		items[i] = TestItem{ID: "id"}
+	}
+	if err := repo.BatchPutItems(context.Background(), "test-table", items); err != nil {
+		t.Fatalf("BatchPutItems failed: %v", err)
+	}
+	if len(sent) != 4 {
+		t.Fatalf("Expected 4 calls, got %d", len(sent))
+	}
+
+	// 送信間隔は 5ms → 10ms と広がり、成功後も半分の間隔を空けて次のチャンクを送る
+	for i, min := range []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 5 * time.Millisecond} {
+		if gap := sent[i+1].Sub(sent[i]); gap < min {
+			t.Errorf("Expected call %d to wait at least %s, waited %s", i+2, min, gap)
+		}
+	}
+}
+
+func TestBatchBackoff(t *testing.T) {
+	var b batchBackoff
+	if b.delay != 0 || b.retryAfter() != throttledRetryAfter {
+		t.Errorf("Unexpected initial backoff: %+v", b)
+	}
+
+	for i := 0; i < 20; i++ {
+		b.throttled()
+	}
+	if b.delay != maxRetryDelay || b.retryAfter() != maxRetryDelay {
+		t.Errorf("Expected delay capped at %s, got %s", maxRetryDelay, b.delay)
+	}
+
+	for i := 0; i < 20; i++ {
+		b.succeeded()
+	}
+	if b.delay != 0 {
+		t.Errorf("Expected delay to recover to zero, got %s", b.delay)
+	}
+}
+
+func TestDynamoDBRepository_BatchGetItems(t *testing.T) {
+	original := batchRetryBackoff
+	batchRetryBackoff = time.Millisecond
+	defer func() { batchRetryBackoff = original }()
+
+	calls := 0
+	mockClient := &MockDynamoDBClient{
+		batchGetItemFunc: func(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			calls++
+			keys := params.RequestItems["test-table"].Keys
+			resp := &dynamodb.BatchGetItemOutput{Responses: map[string][]map[string]types.AttributeValue{}}
+			// 1回目は最後のキーを未処理として返す
+			processed := keys
+			if calls == 1 {
+				processed = keys[:len(keys)-1]
+				resp.UnprocessedKeys = map[string]types.KeysAndAttributes{"test-table": {Keys: keys[len(keys)-1:]}}
+			}
+			for _, key := range processed {
+				resp.Responses["test-table"] = append(resp.Responses["test-table"], map[string]types.AttributeValue{
+					"id":   key["id"],
+					"name": &types.AttributeValueMemberS{Value: "item"},
+				})
+			}
+			return resp, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	// 重複したキーは1回だけ取得される
+	keys := []map[string]interface{}{{"id": "1"}, {"id": "2"}, {"id": "1"}, {"id": "3"}}
+	var items []TestItem
+	if err := repo.BatchGetItems(context.Background(), "test-table", keys, &items); err != nil {
+		t.Fatalf("BatchGetItems failed: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+	if len(items) != 3 {
+		t.Errorf("Expected 3 items, got %d", len(items))
+	}
+}
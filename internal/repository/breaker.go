@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	appconfig "achievement-management/internal/config"
+	"achievement-management/internal/errors"
+)
+
+// ErrCircuitOpen 連続した障害によりDynamoDBの呼び出しを遮断している
+var ErrCircuitOpen = stderrors.New("circuit breaker is open")
+
+// halfOpenRetryAfter 試しの呼び出し中に拒否したリクエストに返す再試行までの時間
+const halfOpenRetryAfter = time.Second
+
+// CircuitBreaker 連続した障害を検知し、一定時間DynamoDBを呼び出さずに即座に失敗させる
+//
+// 遮断から OpenDuration が経過すると1件だけ試しに呼び出し、成功すれば再開、失敗すれば再び遮断する。
+type CircuitBreaker struct {
+	FailureThreshold int
+	OpenDuration     time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// NewCircuitBreaker 設定からサーキットブレーカーを作成
+func NewCircuitBreaker(cfg appconfig.CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: cfg.FailureThreshold,
+		OpenDuration:     time.Duration(cfg.OpenSeconds) * time.Second,
+		now:              time.Now,
+	}
+}
+
+// Do 遮断していなければ fn を呼び出し、結果を記録する（遮断中は ErrCircuitOpen を返す）
+func (b *CircuitBreaker) Do(fn func() error) error {
+	if retryAfter, ok := b.allow(); !ok {
+		return &classifiedError{
+			kind: ErrCircuitOpen,
+			err:  &errors.UnavailableError{Reason: "DynamoDB is failing", RetryAfter: retryAfter},
+		}
+	}
+
+	err := fn()
+	b.record(err)
+	return err
+}
+
+// allow 呼び出してよいか判定し、拒否する場合は再試行までの時間を返す
+func (b *CircuitBreaker) allow() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return 0, true
+	}
+	if now := b.now(); now.Before(b.openUntil) {
+		return b.openUntil.Sub(now), false
+	}
+	if b.probing {
+		return halfOpenRetryAfter, false
+	}
+	b.probing = true
+	return 0, true
+}
+
+// record 呼び出しの結果を記録し、障害が続いた場合は遮断する
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isOutage(err) {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.probing || b.failures >= b.FailureThreshold {
+		b.openUntil = b.now().Add(b.OpenDuration)
+		b.failures = 0
+		b.probing = false
+	}
+}
+
+// isOutage DynamoDB側の障害によるエラーか判定（条件付き書き込みの失敗などは正常な応答として扱う）
+func isOutage(err error) bool {
+	if err == nil || stderrors.Is(err, context.Canceled) {
+		return false
+	}
+	var sendErr *smithyhttp.RequestSendError
+	return IsRetryable(err) || stderrors.As(err, &sendErr) || stderrors.Is(err, context.DeadlineExceeded)
+}
+
+// breakerCall 戻り値のある呼び出しをサーキットブレーカー経由で実行
+func breakerCall[T any](breaker *CircuitBreaker, call func() (T, error)) (T, error) {
+	var out T
+	err := breaker.Do(func() error {
+		var err error
+		out, err = call()
+		return err
+	})
+	return out, err
+}
+
+// breakingClient すべての操作をサーキットブレーカー経由で呼び出すDynamoDBクライアント
+type breakingClient struct {
+	client  DynamoDBAPI
+	breaker *CircuitBreaker
+}
+
+// newBreakingClient サーキットブレーカー付きクライアントを作成
+func newBreakingClient(client DynamoDBAPI, breaker *CircuitBreaker) DynamoDBAPI {
+	return &breakingClient{client: client, breaker: breaker}
+}
+
+func (c *breakingClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.PutItemOutput, error) { return c.client.PutItem(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.GetItemOutput, error) { return c.client.GetItem(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.UpdateItemOutput, error) { return c.client.UpdateItem(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.ScanOutput, error) { return c.client.Scan(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.QueryOutput, error) { return c.client.Query(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.DeleteItemOutput, error) { return c.client.DeleteItem(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.TransactWriteItemsOutput, error) {
+		return c.client.TransactWriteItems(ctx, params, optFns...)
+	})
+}
+
+func (c *breakingClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.BatchWriteItemOutput, error) { return c.client.BatchWriteItem(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.BatchGetItemOutput, error) { return c.client.BatchGetItem(ctx, params, optFns...) })
+}
+
+func (c *breakingClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return breakerCall(c.breaker, func() (*dynamodb.DescribeTableOutput, error) { return c.client.DescribeTable(ctx, params, optFns...) })
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	appconfig "achievement-management/internal/config"
+	apperrors "achievement-management/internal/errors"
+)
+
+// newTestBreaker 時刻を操作できるサーキットブレーカーを作成
+func newTestBreaker(threshold int) (*CircuitBreaker, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(appconfig.CircuitBreakerConfig{Enabled: true, FailureThreshold: threshold, OpenSeconds: 30})
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(3)
+
+	calls := 0
+	failing := func() error {
+		calls++
+		return throttlingError()
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := breaker.Do(failing); !IsThrottled(err) {
+			t.Fatalf("Expected the throttling error to be returned, got %v", err)
+		}
+	}
+
+	// 遮断中は呼び出さずに失敗する
+	err := breaker.Do(failing)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	var unavailable *apperrors.UnavailableError
+	if !errors.As(err, &unavailable) || unavailable.RetryAfter != 30*time.Second {
+		t.Errorf("Expected UnavailableError with RetryAfter 30s, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(2)
+
+	_ = breaker.Do(func() error { return throttlingError() })
+	_ = breaker.Do(func() error { return nil })
+	_ = breaker.Do(func() error { return throttlingError() })
+
+	if err := breaker.Do(func() error { return nil }); err != nil {
+		t.Errorf("Expected the breaker to stay closed, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_IgnoresNonOutageErrors(t *testing.T) {
+	breaker, _ := newTestBreaker(1)
+
+	for _, err := range []error{
+		&types.ConditionalCheckFailedException{},
+		context.Canceled,
+		errors.New("validation failed"),
+	} {
+		_ = breaker.Do(func() error { return err })
+	}
+
+	if err := breaker.Do(func() error { return nil }); err != nil {
+		t.Errorf("Expected the breaker to stay closed, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	breaker, now := newTestBreaker(1)
+	_ = breaker.Do(func() error { return throttlingError() })
+
+	// 遮断時間の経過後は1件だけ試しに呼び出す
+	*now = now.Add(30 * time.Second)
+	started := make(chan struct{})
+	probe := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- breaker.Do(func() error {
+			close(started)
+			<-probe
+			return throttlingError()
+		})
+	}()
+	<-started
+
+	// 試しの呼び出しの完了を待つ間、他の呼び出しは拒否する
+	err := breaker.Do(func() error { return nil })
+	var unavailable *apperrors.UnavailableError
+	if !errors.As(err, &unavailable) || unavailable.RetryAfter != halfOpenRetryAfter {
+		t.Fatalf("Expected calls to be rejected while probing, got %v", err)
+	}
+	close(probe)
+	<-done
+
+	// 試しの呼び出しが失敗した場合は再び遮断する
+	if err := breaker.Do(func() error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen after a failed probe, got %v", err)
+	}
+
+	// 成功すれば再開する
+	*now = now.Add(30 * time.Second)
+	if err := breaker.Do(func() error { return nil }); err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+	if err := breaker.Do(func() error { return nil }); err != nil {
+		t.Errorf("Expected the breaker to be closed, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_CircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	mockClient := &MockDynamoDBClient{
+		getItemFunc: func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			calls++
+			return nil, throttlingError()
+		},
+	}
+	breaker, _ := newTestBreaker(2)
+	repo := &DynamoDBRepository{client: newClassifyingClient(newBreakingClient(mockClient, breaker))}
+
+	var item TestItem
+	key := map[string]interface{}{"id": "test-id"}
+	for i := 0; i < 2; i++ {
+		if err := repo.GetItem(ctx, "test-table", key, &item); !errors.Is(err, ErrThrottled) {
+			t.Fatalf("Expected ErrThrottled, got %v", err)
+		}
+	}
+
+	err := repo.GetItem(ctx, "test-table", key, &item)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls to DynamoDB, got %d", calls)
+	}
+}
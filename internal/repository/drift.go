@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// DriftRepositoryImpl ポイントの差異のリポジトリの実装
+type DriftRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewDriftRepository ポイントの差異のリポジトリを作成
+func NewDriftRepository(repo Repository, config *config.Config) DriftRepository {
+	return &DriftRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Record 検出したポイントの差異を記録
+func (r *DriftRepositoryImpl) Record(ctx context.Context, event *models.DriftEvent) error {
+	if err := ValidateDriftEvent(event); err != nil {
+		return err
+	}
+
+	// IDが空の場合はULIDを生成
+	if event.ID == "" {
+		event.ID = ulid.Make().String()
+	}
+
+	if event.DetectedAt.IsZero() {
+		event.DetectedAt = time.Now()
+	}
+
+	if err := r.repo.PutItem(ctx, r.config.Tables.DriftEvents, newDriftEventItem(ctx, event)); err != nil {
+		return &errors.DatabaseError{
+			Operation: "Record",
+			Table:     r.config.Tables.DriftEvents,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// List 記録したポイントの差異を検出日時順に取得
+func (r *DriftRepositoryImpl) List(ctx context.Context) ([]*models.DriftEvent, error) {
+	var driftEvents []*models.DriftEvent
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.DriftEvents, DetectedAtIndex, EntityTypeDriftEvent), &driftEvents)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.DriftEvents,
+			Cause:     err,
+		}
+	}
+
+	for _, event := range driftEvents {
+		event.ID = tenant.EntityID(ctx, event.ID)
+	}
+	return driftEvents, nil
+}
+
+// ValidateDriftEvent ポイントの差異のバリデーション
+func ValidateDriftEvent(event *models.DriftEvent) error {
+	if event == nil {
+		return &errors.ValidationError{Field: "drift_event", Message: "drift event cannot be nil"}
+	}
+	if event.Threshold < 0 {
+		return &errors.ValidationError{Field: "threshold", Message: "threshold cannot be negative"}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestDriftRepository_Record(t *testing.T) {
+	var putTable string
+	var putItem driftEventItem
+	mockRepo := &MockRepository{
+		putItemFunc: func(tableName string, item interface{}) error {
+			putTable = tableName
+			putItem = item.(driftEventItem)
+			return nil
+		},
+	}
+	repo := NewDriftRepository(mockRepo, &config.Config{Tables: config.TableConfig{DriftEvents: "test-drift-events"}})
+
+	event := &models.DriftEvent{TotalPoints: 120, CurrentBalance: 100, Difference: 20}
+	if err := repo.Record(tenant.WithID(context.Background(), "acme"), event); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	if event.ID == "" || event.DetectedAt.IsZero() {
+		t.Errorf("ID and DetectedAt should be set, got %+v", event)
+	}
+	if putTable != "test-drift-events" {
+		t.Errorf("Expected put to test-drift-events, got %s", putTable)
+	}
+	if putItem.ID != "acme#"+event.ID || putItem.EntityType != "acme#"+EntityTypeDriftEvent {
+		t.Errorf("Expected tenant keys, got %s / %s", putItem.ID, putItem.EntityType)
+	}
+}
+
+func TestDriftRepository_Record_ValidationError(t *testing.T) {
+	repo := NewDriftRepository(&MockRepository{}, &config.Config{Tables: config.TableConfig{DriftEvents: "test-drift-events"}})
+
+	for _, event := range []*models.DriftEvent{nil, {Difference: 5, Threshold: -1}} {
+		if _, ok := repo.Record(context.Background(), event).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", event)
+		}
+	}
+}
+
+func TestDriftRepository_List(t *testing.T) {
+	var queried QueryInput
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			queried = input
+			*result.(*[]*models.DriftEvent) = []*models.DriftEvent{
+				{ID: "acme#01HZ", Difference: 20, DetectedAt: time.Now()},
+			}
+			return "", nil
+		},
+	}
+	repo := NewDriftRepository(mockRepo, &config.Config{Tables: config.TableConfig{DriftEvents: "test-drift-events"}})
+
+	driftEvents, err := repo.List(tenant.WithID(context.Background(), "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if queried.TableName != "test-drift-events" || queried.IndexName != DetectedAtIndex {
+		t.Errorf("Expected query on %s of test-drift-events, got %s of %s", DetectedAtIndex, queried.IndexName, queried.TableName)
+	}
+	if queried.ExpressionAttributeValues[":entity_type"] != "acme#"+EntityTypeDriftEvent {
+		t.Errorf("Expected tenant entity type, got %v", queried.ExpressionAttributeValues[":entity_type"])
+	}
+	if len(driftEvents) != 1 || driftEvents[0].ID != "01HZ" {
+		t.Errorf("Expected drift event IDs without tenant prefix, got %+v", driftEvents)
+	}
+}
@@ -2,14 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
 	
 	appconfig "achievement-management/internal/config"
 )
@@ -20,18 +22,76 @@ type DynamoDBAPI interface {
 	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
 	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
 	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
 	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
 	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
 }
 
 // DynamoDBRepository DynamoDB操作の実装
 type DynamoDBRepository struct {
 	client DynamoDBAPI
-	ctx    context.Context
+	// consistentRead GetItemを強い整合性で読み取る
+	consistentRead bool
 }
 
-// NewDynamoDBRepository DynamoDBリポジトリの作成
+// NewDynamoDBRepository DynamoDBリポジトリの作成（リトライとサーキットブレーカーは設定に従う）
 func NewDynamoDBRepository(ctx context.Context, appConfig *appconfig.Config) (*DynamoDBRepository, error) {
+	return NewDynamoDBRepositoryWithObserver(ctx, appConfig, nil)
+}
+
+// NewDynamoDBRepositoryWithObserver API呼び出しごとの所要時間と結果を observer に渡すDynamoDBリポジトリの作成（observer が nil の場合は記録しない）
+func NewDynamoDBRepositoryWithObserver(ctx context.Context, appConfig *appconfig.Config, observer CallObserver) (*DynamoDBRepository, error) {
+	// SDK側のリトライは無効にして二重にリトライしないようにする
+	client, err := NewDynamoDBClient(ctx, appConfig, func(o *dynamodb.Options) {
+		o.Retryer = aws.NopRetryer{}
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	// リトライの1回ごとに記録するため、リトライより内側で記録する
+	var wrapped DynamoDBAPI = client
+	if observer != nil {
+		wrapped = newObservingClient(wrapped, observer)
+	}
+
+	// リトライしても失敗が続く場合はサーキットブレーカーで呼び出しを遮断する
+	wrapped = newRetryingClient(wrapped, NewRetryPolicy(appConfig.Retry))
+	if appConfig.CircuitBreaker.Enabled {
+		wrapped = newBreakingClient(wrapped, NewCircuitBreaker(appConfig.CircuitBreaker))
+	}
+
+	return &DynamoDBRepository{
+		client:         newClassifyingClient(wrapped),
+		consistentRead: appConfig.AWS.ConsistentRead,
+	}, nil
+}
+
+// NewDynamoDBClient 設定からDynamoDBクライアントを作成
+func NewDynamoDBClient(ctx context.Context, appConfig *appconfig.Config, optFns ...func(*dynamodb.Options)) (*dynamodb.Client, error) {
+	awsConfig, err := LoadAWSConfig(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamodb.NewFromConfig(awsConfig, optFns...), nil
+}
+
+// NewDynamoDBStreamsClient DynamoDB Streamsクライアントを作成
+func NewDynamoDBStreamsClient(ctx context.Context, appConfig *appconfig.Config) (*dynamodbstreams.Client, error) {
+	awsConfig, err := LoadAWSConfig(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamodbstreams.NewFromConfig(awsConfig), nil
+}
+
+// LoadAWSConfig アプリケーション設定からAWS設定を読み込み（DynamoDB以外のクライアントの作成にも使用する）
+func LoadAWSConfig(ctx context.Context, appConfig *appconfig.Config) (aws.Config, error) {
 	// AWS設定を読み込み
 	var awsConfig aws.Config
 	var err error
@@ -42,13 +102,15 @@ func NewDynamoDBRepository(ctx context.Context, appConfig *appconfig.Config) (*D
 			config.WithRegion(appConfig.AWS.Region),
 			config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
 				func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-					if service == dynamodb.ServiceID {
+					// ローカルDynamoDBはストリームも同じエンドポイントで提供する
+					if service == dynamodb.ServiceID || service == dynamodbstreams.ServiceID {
 						return aws.Endpoint{
 							URL:           appConfig.AWS.DynamoDBEndpoint,
 							SigningRegion: appConfig.AWS.Region,
 						}, nil
 					}
-					return aws.Endpoint{}, fmt.Errorf("unknown endpoint requested")
+					// S3などそれ以外のサービスは通常のエンドポイント解決に任せる
+					return aws.Endpoint{}, &aws.EndpointNotFoundError{Err: fmt.Errorf("no local endpoint for %s", service)}
 				})),
 		)
 	} else {
@@ -76,27 +138,29 @@ func NewDynamoDBRepository(ctx context.Context, appConfig *appconfig.Config) (*D
 	}
 	
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	client := dynamodb.NewFromConfig(awsConfig)
-	
-	return &DynamoDBRepository{
-		client: client,
-		ctx:    ctx,
-	}, nil
+	return awsConfig, nil
 }
 
 // NewDynamoDBRepositoryWithClient カスタムクライアントでDynamoDBリポジトリを作成
-func NewDynamoDBRepositoryWithClient(ctx context.Context, client DynamoDBAPI) *DynamoDBRepository {
+func NewDynamoDBRepositoryWithClient(client DynamoDBAPI) *DynamoDBRepository {
 	return &DynamoDBRepository{
-		client: client,
-		ctx:    ctx,
+		client: newClassifyingClient(client),
 	}
 }
 
+// ErrConditionFailed 条件付き書き込みの条件を満たさなかった
+var ErrConditionFailed = errors.New("conditional check failed")
+
 // PutItem アイテムを追加
-func (r *DynamoDBRepository) PutItem(tableName string, item interface{}) error {
+func (r *DynamoDBRepository) PutItem(ctx context.Context, tableName string, item interface{}) error {
+	return r.PutItemWithCondition(ctx, tableName, item, "")
+}
+
+// PutItemWithCondition 条件式を満たす場合のみアイテムを書き込み（満たさない場合は ErrConditionFailed）
+func (r *DynamoDBRepository) PutItemWithCondition(ctx context.Context, tableName string, item interface{}, conditionExpression string) error {
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return fmt.Errorf("failed to marshal item: %w", err)
@@ -106,34 +170,108 @@ func (r *DynamoDBRepository) PutItem(tableName string, item interface{}) error {
 		TableName: aws.String(tableName),
 		Item:      av,
 	}
+	if conditionExpression != "" {
+		input.ConditionExpression = aws.String(conditionExpression)
+	}
+
+	_, err = r.client.PutItem(ctx, input)
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return fmt.Errorf("failed to put item to table %s: %w", tableName, ErrConditionFailed)
+		}
+		return fmt.Errorf("failed to put item to table %s: %w", tableName, err)
+	}
+
+	return nil
+}
 
-	_, err = r.client.PutItem(r.ctx, input)
+// ErrVersionMismatch 保存済みのアイテムのバージョンが期待した値と異なる
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// PutItemWithVersion 保存済みのバージョンが expectedVersion の場合のみアイテムを書き込み、バージョン属性を1つ進める
+// アイテムが存在しない場合は ErrConditionFailed、別の書き込みでバージョンが進んでいた場合は ErrVersionMismatch を返す
+func (r *DynamoDBRepository) PutItemWithVersion(ctx context.Context, tableName string, item interface{}, expectedVersion int) error {
+	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
+		return fmt.Errorf("failed to marshal item: %w", err)
+	}
+	av[VersionAttribute] = &types.AttributeValueMemberN{Value: strconv.Itoa(expectedVersion + 1)}
+
+	input := &dynamodb.PutItemInput{
+		TableName:                aws.String(tableName),
+		Item:                     av,
+		ConditionExpression:      aws.String(versionCondition(expectedVersion)),
+		ExpressionAttributeNames: map[string]string{"#version": VersionAttribute},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":expected": &types.AttributeValueMemberN{Value: strconv.Itoa(expectedVersion)},
+		},
+		// 条件を満たさなかった場合に既存のアイテムを返させ、削除済みかバージョンの競合かを区別する
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}
+
+	_, err = r.client.PutItem(ctx, input)
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			if conditionErr.Item != nil {
+				return fmt.Errorf("failed to put item to table %s: %w", tableName, ErrVersionMismatch)
+			}
+			return fmt.Errorf("failed to put item to table %s: %w", tableName, ErrConditionFailed)
+		}
 		return fmt.Errorf("failed to put item to table %s: %w", tableName, err)
 	}
 
 	return nil
 }
 
-// GetItem アイテムを取得
-func (r *DynamoDBRepository) GetItem(tableName string, key map[string]interface{}, result interface{}) error {
+// versionCondition 保存済みのバージョンが :expected と一致することを確認する条件式（#version にバージョン属性名を割り当てて使う）
+func versionCondition(expectedVersion int) string {
+	if expectedVersion == 0 {
+		// バージョン導入前に保存されたアイテムはバージョン属性を持たない
+		return conditionExists + " AND (attribute_not_exists(#version) OR #version = :expected)"
+	}
+	return conditionExists + " AND #version = :expected"
+}
+
+// GetItem アイテムを取得（設定の aws.consistent_read が有効な場合は強い整合性で読み取る）
+func (r *DynamoDBRepository) GetItem(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error {
+	return r.getItem(ctx, tableName, key, nil, result, r.consistentRead)
+}
+
+// GetItemConsistent 直前の書き込みを必ず反映した値を取得（強い整合性の読み取り）
+func (r *DynamoDBRepository) GetItemConsistent(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error {
+	return r.getItem(ctx, tableName, key, nil, result, true)
+}
+
+// GetItemWithProjection 指定した属性のみを取得（存在確認など一部の属性だけが必要な場合に使用する）
+func (r *DynamoDBRepository) GetItemWithProjection(ctx context.Context, tableName string, key map[string]interface{}, projection []string, result interface{}) error {
+	return r.getItem(ctx, tableName, key, projection, result, r.consistentRead)
+}
+
+// getItem 取得する属性と整合性を指定してアイテムを取得（存在しない場合は ErrItemNotFound）
+func (r *DynamoDBRepository) getItem(ctx context.Context, tableName string, key map[string]interface{}, projection []string, result interface{}, consistentRead bool) error {
 	keyAv, err := attributevalue.MarshalMap(key)
 	if err != nil {
 		return fmt.Errorf("failed to marshal key: %w", err)
 	}
 
+	projectionExpr, names := projectionExpression(projection)
 	input := &dynamodb.GetItemInput{
-		TableName: aws.String(tableName),
-		Key:       keyAv,
+		TableName:                aws.String(tableName),
+		Key:                      keyAv,
+		ConsistentRead:           aws.Bool(consistentRead),
+		ProjectionExpression:     projectionExpr,
+		ExpressionAttributeNames: names,
 	}
 
-	resp, err := r.client.GetItem(r.ctx, input)
+	resp, err := r.client.GetItem(ctx, input)
 	if err != nil {
 		return fmt.Errorf("failed to get item from table %s: %w", tableName, err)
 	}
 
 	if resp.Item == nil {
-		return fmt.Errorf("item not found in table %s", tableName)
+		return fmt.Errorf("%w in table %s", ErrItemNotFound, tableName)
 	}
 
 	err = attributevalue.UnmarshalMap(resp.Item, result)
@@ -145,7 +283,12 @@ func (r *DynamoDBRepository) GetItem(tableName string, key map[string]interface{
 }
 
 // UpdateItem アイテムを更新
-func (r *DynamoDBRepository) UpdateItem(tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error {
+func (r *DynamoDBRepository) UpdateItem(ctx context.Context, tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error {
+	return r.UpdateItemWithCondition(ctx, tableName, key, updateExpression, "", expressionAttributeValues)
+}
+
+// UpdateItemWithCondition 条件式を満たす場合のみアイテムを更新（満たさない場合は ErrConditionFailed）
+func (r *DynamoDBRepository) UpdateItemWithCondition(ctx context.Context, tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
 	keyAv, err := attributevalue.MarshalMap(key)
 	if err != nil {
 		return fmt.Errorf("failed to marshal key: %w", err)
@@ -165,36 +308,184 @@ func (r *DynamoDBRepository) UpdateItem(tableName string, key map[string]interfa
 		UpdateExpression:          aws.String(updateExpression),
 		ExpressionAttributeValues: eavAv,
 	}
+	if conditionExpression != "" {
+		input.ConditionExpression = aws.String(conditionExpression)
+	}
 
-	_, err = r.client.UpdateItem(r.ctx, input)
+	_, err = r.client.UpdateItem(ctx, input)
 	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return fmt.Errorf("failed to update item in table %s: %w", tableName, ErrConditionFailed)
+		}
 		return fmt.Errorf("failed to update item in table %s: %w", tableName, err)
 	}
 
 	return nil
 }
 
-// Scan テーブル全体をスキャン
-func (r *DynamoDBRepository) Scan(tableName string, result interface{}) error {
-	input := &dynamodb.ScanInput{
-		TableName: aws.String(tableName),
+// Scan テーブルをスキャン（全ページを取得し、Limit に達した場合は続きのカーソルを返す）
+func (r *DynamoDBRepository) Scan(ctx context.Context, input ScanInput, result interface{}) (string, error) {
+	collect, finish := collectInto(result)
+	cursor, err := paginate(input.Cursor, input.Limit, r.scanFetcher(ctx, input), collect)
+	if err != nil {
+		return "", err
 	}
+	return cursor, finish()
+}
 
-	resp, err := r.client.Scan(r.ctx, input)
+// ScanEach テーブルをスキャンし、1件ずつ fn に渡す
+func (r *DynamoDBRepository) ScanEach(ctx context.Context, input ScanInput, fn ItemHandler) error {
+	_, err := paginate(input.Cursor, input.Limit, r.scanFetcher(ctx, input), streamTo(fn))
+	if errors.Is(err, ErrStopIteration) {
+		return nil
+	}
+	return err
+}
+
+// scanFetcher Scanの1ページ分を取得する関数を作成
+func (r *DynamoDBRepository) scanFetcher(ctx context.Context, input ScanInput) pageFetcher {
+	return func(startKey map[string]types.AttributeValue, limit int32) ([]map[string]types.AttributeValue, map[string]types.AttributeValue, error) {
+		projectionExpr, names := projectionExpression(input.Projection)
+		scanInput := &dynamodb.ScanInput{
+			TableName:                aws.String(input.TableName),
+			ExclusiveStartKey:        startKey,
+			ProjectionExpression:     projectionExpr,
+			ExpressionAttributeNames: names,
+		}
+		if limit > 0 {
+			scanInput.Limit = aws.Int32(limit)
+		}
+		if input.TotalSegments > 0 {
+			scanInput.Segment = aws.Int32(int32(input.Segment))
+			scanInput.TotalSegments = aws.Int32(int32(input.TotalSegments))
+		}
+
+		resp, err := r.client.Scan(ctx, scanInput)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan table %s: %w", input.TableName, err)
+		}
+		return resp.Items, resp.LastEvaluatedKey, nil
+	}
+}
+
+// Query キー条件に一致するアイテムをソートキー順に取得（全ページを取得し、Limit に達した場合は続きのカーソルを返す）
+func (r *DynamoDBRepository) Query(ctx context.Context, input QueryInput, result interface{}) (string, error) {
+	fetch, err := r.queryFetcher(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	collect, finish := collectInto(result)
+	cursor, err := paginate(input.Cursor, input.Limit, fetch, collect)
 	if err != nil {
-		return fmt.Errorf("failed to scan table %s: %w", tableName, err)
+		return "", err
 	}
+	return cursor, finish()
+}
 
-	err = attributevalue.UnmarshalListOfMaps(resp.Items, result)
+// QueryEach キー条件に一致するアイテムをソートキー順に1件ずつ fn に渡す
+func (r *DynamoDBRepository) QueryEach(ctx context.Context, input QueryInput, fn ItemHandler) error {
+	fetch, err := r.queryFetcher(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal scan result: %w", err)
+		return err
 	}
 
+	_, err = paginate(input.Cursor, input.Limit, fetch, streamTo(fn))
+	if errors.Is(err, ErrStopIteration) {
+		return nil
+	}
+	return err
+}
+
+// ItemCount テーブルのおおよそのアイテム数を取得
+//
+// DescribeTable の値を使うためスキャンは発生しないが、DynamoDBが約6時間ごとに更新する値のため直近の書き込みは反映されない。
+func (r *DynamoDBRepository) ItemCount(ctx context.Context, tableName string) (int64, error) {
+	resp, err := r.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
+	return aws.ToInt64(resp.Table.ItemCount), nil
+}
+
+// QueryCount Queryに一致するアイテム数を取得（Select: COUNT のため項目は読み取らないが、一致するアイテム分の読み込みキャパシティを消費する）
+func (r *DynamoDBRepository) QueryCount(ctx context.Context, input QueryInput) (int64, error) {
+	eavAv, err := attributevalue.MarshalMap(input.ExpressionAttributeValues)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal expression attribute values: %w", err)
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(input.TableName),
+		KeyConditionExpression:    aws.String(input.KeyConditionExpression),
+		ExpressionAttributeValues: eavAv,
+		Select:                    types.SelectCount,
+	}
+	if input.IndexName != "" {
+		queryInput.IndexName = aws.String(input.IndexName)
+	}
+
+	var count int64
+	for {
+		resp, err := r.client.Query(ctx, queryInput)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count items in table %s: %w", input.TableName, err)
+		}
+		count += int64(resp.Count)
+		if len(resp.LastEvaluatedKey) == 0 {
+			return count, nil
+		}
+		queryInput.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+}
+
+// Ping テーブルにアクセスできるか確認（ヘルスチェック用）
+//
+// DescribeTable を使うため、テーブルの読み込みキャパシティは消費しない。
+func (r *DynamoDBRepository) Ping(ctx context.Context, tableName string) error {
+	if _, err := r.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)}); err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
 	return nil
 }
 
+// queryFetcher Queryの1ページ分を取得する関数を作成
+func (r *DynamoDBRepository) queryFetcher(ctx context.Context, input QueryInput) (pageFetcher, error) {
+	eavAv, err := attributevalue.MarshalMap(input.ExpressionAttributeValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal expression attribute values: %w", err)
+	}
+
+	projectionExpr, names := projectionExpression(input.Projection)
+
+	return func(startKey map[string]types.AttributeValue, limit int32) ([]map[string]types.AttributeValue, map[string]types.AttributeValue, error) {
+		queryInput := &dynamodb.QueryInput{
+			TableName:                 aws.String(input.TableName),
+			KeyConditionExpression:    aws.String(input.KeyConditionExpression),
+			ExpressionAttributeValues: eavAv,
+			ScanIndexForward:          aws.Bool(!input.Descending),
+			ExclusiveStartKey:         startKey,
+			ProjectionExpression:      projectionExpr,
+			ExpressionAttributeNames:  names,
+		}
+		if input.IndexName != "" {
+			queryInput.IndexName = aws.String(input.IndexName)
+		}
+		if limit > 0 {
+			queryInput.Limit = aws.Int32(limit)
+		}
+
+		resp, err := r.client.Query(ctx, queryInput)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to query table %s: %w", input.TableName, err)
+		}
+		return resp.Items, resp.LastEvaluatedKey, nil
+	}, nil
+}
+
 // DeleteItem アイテムを削除
-func (r *DynamoDBRepository) DeleteItem(tableName string, key map[string]interface{}) error {
+func (r *DynamoDBRepository) DeleteItem(ctx context.Context, tableName string, key map[string]interface{}) error {
 	keyAv, err := attributevalue.MarshalMap(key)
 	if err != nil {
 		return fmt.Errorf("failed to marshal key: %w", err)
@@ -205,7 +496,7 @@ func (r *DynamoDBRepository) DeleteItem(tableName string, key map[string]interfa
 		Key:       keyAv,
 	}
 
-	_, err = r.client.DeleteItem(r.ctx, input)
+	_, err = r.client.DeleteItem(ctx, input)
 	if err != nil {
 		return fmt.Errorf("failed to delete item from table %s: %w", tableName, err)
 	}
@@ -214,7 +505,7 @@ func (r *DynamoDBRepository) DeleteItem(tableName string, key map[string]interfa
 }
 
 // TransactWrite トランザクション書き込み
-func (r *DynamoDBRepository) TransactWrite(items []TransactWriteItem) error {
+func (r *DynamoDBRepository) TransactWrite(ctx context.Context, items []TransactWriteItem) error {
 	if len(items) == 0 {
 		return fmt.Errorf("no items provided for transaction")
 	}
@@ -222,28 +513,77 @@ func (r *DynamoDBRepository) TransactWrite(items []TransactWriteItem) error {
 	transactItems := make([]types.TransactWriteItem, 0, len(items))
 
 	for _, item := range items {
-		av, err := attributevalue.MarshalMap(item.Item)
-		if err != nil {
-			return fmt.Errorf("failed to marshal transaction item: %w", err)
+		var condition *string
+		if item.ConditionExpression != "" {
+			condition = aws.String(item.ConditionExpression)
+		}
+
+		var values map[string]types.AttributeValue
+		if item.ExpressionAttributeValues != nil {
+			var err error
+			values, err = attributevalue.MarshalMap(item.ExpressionAttributeValues)
+			if err != nil {
+				return fmt.Errorf("failed to marshal expression attribute values: %w", err)
+			}
 		}
 
 		switch item.Operation {
 		case "PUT":
+			av, err := attributevalue.MarshalMap(item.Item)
+			if err != nil {
+				return fmt.Errorf("failed to marshal transaction item: %w", err)
+			}
 			transactItems = append(transactItems, types.TransactWriteItem{
 				Put: &types.Put{
-					TableName: aws.String(item.TableName),
-					Item:      av,
+					TableName:                 aws.String(item.TableName),
+					Item:                      av,
+					ConditionExpression:       condition,
+					ExpressionAttributeNames:  item.ExpressionAttributeNames,
+					ExpressionAttributeValues: values,
 				},
 			})
 		case "UPDATE":
-			// For UPDATE operations, the item should contain key and update expression
-			// This is a simplified implementation - in practice, you'd need more complex handling
-			return fmt.Errorf("UPDATE operation not implemented in this simplified version")
+			keyAv, err := attributevalue.MarshalMap(item.Key)
+			if err != nil {
+				return fmt.Errorf("failed to marshal key: %w", err)
+			}
+			transactItems = append(transactItems, types.TransactWriteItem{
+				Update: &types.Update{
+					TableName:                 aws.String(item.TableName),
+					Key:                       keyAv,
+					UpdateExpression:          aws.String(item.UpdateExpression),
+					ConditionExpression:       condition,
+					ExpressionAttributeNames:  item.ExpressionAttributeNames,
+					ExpressionAttributeValues: values,
+				},
+			})
 		case "DELETE":
+			av, err := attributevalue.MarshalMap(item.Item)
+			if err != nil {
+				return fmt.Errorf("failed to marshal transaction item: %w", err)
+			}
 			transactItems = append(transactItems, types.TransactWriteItem{
 				Delete: &types.Delete{
-					TableName: aws.String(item.TableName),
-					Key:       av,
+					TableName:                 aws.String(item.TableName),
+					Key:                       av,
+					ConditionExpression:       condition,
+					ExpressionAttributeNames:  item.ExpressionAttributeNames,
+					ExpressionAttributeValues: values,
+				},
+			})
+		case "CONDITION_CHECK":
+			// 書き込まずに、他のアイテムの状態をトランザクションの条件にする
+			keyAv, err := attributevalue.MarshalMap(item.Key)
+			if err != nil {
+				return fmt.Errorf("failed to marshal key: %w", err)
+			}
+			transactItems = append(transactItems, types.TransactWriteItem{
+				ConditionCheck: &types.ConditionCheck{
+					TableName:                 aws.String(item.TableName),
+					Key:                       keyAv,
+					ConditionExpression:       condition,
+					ExpressionAttributeNames:  item.ExpressionAttributeNames,
+					ExpressionAttributeValues: values,
 				},
 			})
 		default:
@@ -255,32 +595,19 @@ func (r *DynamoDBRepository) TransactWrite(items []TransactWriteItem) error {
 		TransactItems: transactItems,
 	}
 
-	_, err := r.client.TransactWriteItems(r.ctx, input)
+	_, err := r.client.TransactWriteItems(ctx, input)
 	if err != nil {
+		// いずれかのアイテムの条件を満たさずにキャンセルされた場合
+		var canceledErr *types.TransactionCanceledException
+		if errors.As(err, &canceledErr) {
+			for _, reason := range canceledErr.CancellationReasons {
+				if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+					return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+				}
+			}
+		}
 		return fmt.Errorf("failed to execute transaction: %w", err)
 	}
 
 	return nil
 }
-
-// WithRetry リトライロジック付きで操作を実行
-func (r *DynamoDBRepository) WithRetry(operation func() error, maxRetries int) error {
-	var lastErr error
-	
-	for i := 0; i <= maxRetries; i++ {
-		err := operation()
-		if err == nil {
-			return nil
-		}
-		
-		lastErr = err
-		
-		// 最後の試行でない場合は待機
-		if i < maxRetries {
-			backoffDuration := time.Duration(i+1) * 100 * time.Millisecond
-			time.Sleep(backoffDuration)
-		}
-	}
-	
-	return fmt.Errorf("operation failed after %d retries: %w", maxRetries, lastErr)
-}
\ No newline at end of file
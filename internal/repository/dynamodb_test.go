@@ -3,10 +3,16 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"achievement-management/internal/models"
 )
 
 // MockDynamoDBClient DynamoDBクライアントのモック
@@ -15,8 +21,12 @@ type MockDynamoDBClient struct {
 	getItemFunc           func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
 	updateItemFunc        func(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
 	scanFunc              func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	queryFunc             func(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
 	deleteItemFunc        func(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
 	transactWriteItemsFunc func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	batchWriteItemFunc    func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	batchGetItemFunc      func(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	describeTableFunc     func(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
 }
 
 func (m *MockDynamoDBClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
@@ -47,6 +57,13 @@ func (m *MockDynamoDBClient) Scan(ctx context.Context, params *dynamodb.ScanInpu
 	return &dynamodb.ScanOutput{}, nil
 }
 
+func (m *MockDynamoDBClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	if m.queryFunc != nil {
+		return m.queryFunc(ctx, params, optFns...)
+	}
+	return &dynamodb.QueryOutput{}, nil
+}
+
 func (m *MockDynamoDBClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
 	if m.deleteItemFunc != nil {
 		return m.deleteItemFunc(ctx, params, optFns...)
@@ -61,6 +78,27 @@ func (m *MockDynamoDBClient) TransactWriteItems(ctx context.Context, params *dyn
 	return &dynamodb.TransactWriteItemsOutput{}, nil
 }
 
+func (m *MockDynamoDBClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	if m.batchWriteItemFunc != nil {
+		return m.batchWriteItemFunc(ctx, params, optFns...)
+	}
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+func (m *MockDynamoDBClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	if m.batchGetItemFunc != nil {
+		return m.batchGetItemFunc(ctx, params, optFns...)
+	}
+	return &dynamodb.BatchGetItemOutput{}, nil
+}
+
+func (m *MockDynamoDBClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	if m.describeTableFunc != nil {
+		return m.describeTableFunc(ctx, params, optFns...)
+	}
+	return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{}}, nil
+}
+
 // TestItem テスト用のアイテム構造体
 type TestItem struct {
 	ID    string `dynamodbav:"id"`
@@ -71,7 +109,7 @@ type TestItem struct {
 func TestDynamoDBRepository_PutItem(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockDynamoDBClient{}
-	repo := NewDynamoDBRepositoryWithClient(ctx, mockClient)
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
 
 	testItem := TestItem{
 		ID:    "test-id",
@@ -79,12 +117,96 @@ func TestDynamoDBRepository_PutItem(t *testing.T) {
 		Value: 100,
 	}
 
-	err := repo.PutItem("test-table", testItem)
+	err := repo.PutItem(ctx, "test-table", testItem)
 	if err != nil {
 		t.Errorf("PutItem failed: %v", err)
 	}
 }
 
+func TestDynamoDBRepository_PutItemWithCondition(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockDynamoDBClient{
+		putItemFunc: func(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			if aws.ToString(params.ConditionExpression) != "attribute_not_exists(id)" {
+				t.Errorf("Unexpected condition expression: %s", aws.ToString(params.ConditionExpression))
+			}
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	err := repo.PutItemWithCondition(ctx, "test-table", TestItem{ID: "test-id"}, "attribute_not_exists(id)")
+	if !errors.Is(err, ErrConditionFailed) {
+		t.Errorf("Expected ErrConditionFailed, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_PutItemWithVersion(t *testing.T) {
+	ctx := context.Background()
+	var inputs []*dynamodb.PutItemInput
+	var existing map[string]types.AttributeValue
+	mockClient := &MockDynamoDBClient{
+		putItemFunc: func(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			inputs = append(inputs, params)
+			if params.Item["id"].(*types.AttributeValueMemberS).Value == "stale" {
+				return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed"), Item: existing}
+			}
+			return &dynamodb.PutItemOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	// 書き込むアイテムのバージョンは期待したバージョンの次になる
+	if err := repo.PutItemWithVersion(ctx, "test-table", TestItem{ID: "test-id"}, 2); err != nil {
+		t.Fatalf("PutItemWithVersion failed: %v", err)
+	}
+	input := inputs[0]
+	if version := input.Item["version"].(*types.AttributeValueMemberN).Value; version != "3" {
+		t.Errorf("Expected version 3 to be written, got %s", version)
+	}
+	if aws.ToString(input.ConditionExpression) != "attribute_exists(id) AND #version = :expected" {
+		t.Errorf("Unexpected condition expression: %s", aws.ToString(input.ConditionExpression))
+	}
+	if expected := input.ExpressionAttributeValues[":expected"].(*types.AttributeValueMemberN).Value; expected != "2" {
+		t.Errorf("Expected condition on version 2, got %s", expected)
+	}
+
+	// バージョン0の場合はバージョン属性の無い既存のアイテムも更新できる
+	repo.PutItemWithVersion(ctx, "test-table", TestItem{ID: "test-id"}, 0)
+	if condition := aws.ToString(inputs[1].ConditionExpression); condition != "attribute_exists(id) AND (attribute_not_exists(#version) OR #version = :expected)" {
+		t.Errorf("Unexpected condition expression for version 0: %s", condition)
+	}
+
+	// 既存のアイテムが返された場合はバージョンの競合、返されない場合は削除済み
+	existing = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "stale"}}
+	if err := repo.PutItemWithVersion(ctx, "test-table", TestItem{ID: "stale"}, 1); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("Expected ErrVersionMismatch, got %v", err)
+	}
+	existing = nil
+	if err := repo.PutItemWithVersion(ctx, "test-table", TestItem{ID: "stale"}, 1); !errors.Is(err, ErrConditionFailed) {
+		t.Errorf("Expected ErrConditionFailed, got %v", err)
+	}
+}
+
+func TestDynamoDBRepository_UpdateItemWithCondition(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockDynamoDBClient{
+		updateItemFunc: func(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+			if aws.ToString(params.ConditionExpression) != "point >= :cost" {
+				t.Errorf("Unexpected condition expression: %s", aws.ToString(params.ConditionExpression))
+			}
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	err := repo.UpdateItemWithCondition(ctx, "test-table", map[string]interface{}{"id": "current"},
+		"ADD point :delta", "point >= :cost", map[string]interface{}{":delta": -10, ":cost": 10})
+	if !errors.Is(err, ErrConditionFailed) {
+		t.Errorf("Expected ErrConditionFailed, got %v", err)
+	}
+}
+
 func TestDynamoDBRepository_GetItem(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockDynamoDBClient{
@@ -98,14 +220,14 @@ func TestDynamoDBRepository_GetItem(t *testing.T) {
 			}, nil
 		},
 	}
-	repo := NewDynamoDBRepositoryWithClient(ctx, mockClient)
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
 
 	key := map[string]interface{}{
 		"id": "test-id",
 	}
 
 	var result TestItem
-	err := repo.GetItem("test-table", key, &result)
+	err := repo.GetItem(ctx, "test-table", key, &result)
 	if err != nil {
 		t.Errorf("GetItem failed: %v", err)
 	}
@@ -115,6 +237,37 @@ func TestDynamoDBRepository_GetItem(t *testing.T) {
 	}
 }
 
+func TestDynamoDBRepository_GetItem_ConsistentRead(t *testing.T) {
+	ctx := context.Background()
+	var consistent []bool
+	mockClient := &MockDynamoDBClient{
+		getItemFunc: func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			consistent = append(consistent, aws.ToBool(params.ConsistentRead))
+			return &dynamodb.GetItemOutput{
+				Item: map[string]types.AttributeValue{
+					"id": &types.AttributeValueMemberS{Value: "test-id"},
+				},
+			}, nil
+		},
+	}
+	key := map[string]interface{}{"id": "test-id"}
+	var result TestItem
+
+	// 既定では結果整合性で読み取り、GetItemConsistent のみ強い整合性を使う
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+	repo.GetItem(ctx, "test-table", key, &result)
+	repo.GetItemConsistent(ctx, "test-table", key, &result)
+
+	// 設定で有効にした場合はすべての読み取りが強い整合性になる
+	configured := &DynamoDBRepository{client: mockClient, consistentRead: true}
+	configured.GetItem(ctx, "test-table", key, &result)
+
+	expected := []bool{false, true, true}
+	if fmt.Sprint(consistent) != fmt.Sprint(expected) {
+		t.Errorf("Expected ConsistentRead %v, got %v", expected, consistent)
+	}
+}
+
 func TestDynamoDBRepository_GetItem_NotFound(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockDynamoDBClient{
@@ -124,23 +277,323 @@ func TestDynamoDBRepository_GetItem_NotFound(t *testing.T) {
 			}, nil
 		},
 	}
-	repo := NewDynamoDBRepositoryWithClient(ctx, mockClient)
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
 
 	key := map[string]interface{}{
 		"id": "non-existent-id",
 	}
 
 	var result TestItem
-	err := repo.GetItem("test-table", key, &result)
+	err := repo.GetItem(ctx, "test-table", key, &result)
 	if err == nil {
 		t.Error("GetItem should have failed for non-existent item")
 	}
 }
 
+func TestDynamoDBRepository_Query(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockDynamoDBClient{
+		queryFunc: func(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+			if aws.ToString(params.IndexName) != "test-index" {
+				t.Errorf("Expected index test-index, got %s", aws.ToString(params.IndexName))
+			}
+			if !aws.ToBool(params.ScanIndexForward) {
+				t.Error("Expected ascending order by default")
+			}
+			return &dynamodb.QueryOutput{
+				Items: []map[string]types.AttributeValue{
+					{
+						"id":    &types.AttributeValueMemberS{Value: "test-id"},
+						"name":  &types.AttributeValueMemberS{Value: "test-name"},
+						"value": &types.AttributeValueMemberN{Value: "100"},
+					},
+				},
+			}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	var results []TestItem
+	_, err := repo.Query(ctx, QueryInput{
+		TableName:                 "test-table",
+		IndexName:                 "test-index",
+		KeyConditionExpression:    "entity_type = :entity_type",
+		ExpressionAttributeValues: map[string]interface{}{":entity_type": "TEST"},
+	}, &results)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "test-name" {
+		t.Errorf("Unexpected query results: %+v", results)
+	}
+}
+
+func TestDynamoDBRepository_ItemCount(t *testing.T) {
+	mockClient := &MockDynamoDBClient{
+		describeTableFunc: func(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+			if aws.ToString(params.TableName) != "test-table" {
+				t.Errorf("Expected test-table, got %s", aws.ToString(params.TableName))
+			}
+			return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{ItemCount: aws.Int64(12)}}, nil
+		},
+	}
+	repo := &DynamoDBRepository{client: mockClient}
+
+	count, err := repo.ItemCount(context.Background(), "test-table")
+	if err != nil {
+		t.Fatalf("ItemCount failed: %v", err)
+	}
+	if count != 12 {
+		t.Errorf("Expected 12 items, got %d", count)
+	}
+}
+
+func TestDynamoDBRepository_QueryCount(t *testing.T) {
+	calls := 0
+	mockClient := &MockDynamoDBClient{
+		queryFunc: func(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+			calls++
+			if params.Select != types.SelectCount {
+				t.Errorf("Expected Select COUNT, got %s", params.Select)
+			}
+			// 2ページ目は前のページの続きから数える
+			if calls == 1 {
+				return &dynamodb.QueryOutput{Count: 2, LastEvaluatedKey: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "b"}}}, nil
+			}
+			if params.ExclusiveStartKey == nil {
+				t.Error("Expected the second page to start after the first")
+			}
+			return &dynamodb.QueryOutput{Count: 1}, nil
+		},
+	}
+	repo := &DynamoDBRepository{client: mockClient}
+
+	count, err := repo.QueryCount(context.Background(), QueryInput{
+		TableName:                 "test-table",
+		IndexName:                 CreatedAtIndex,
+		KeyConditionExpression:    EntityTypeAttribute + " = :entity_type",
+		ExpressionAttributeValues: map[string]interface{}{":entity_type": EntityTypeAchievement},
+	})
+	if err != nil {
+		t.Fatalf("QueryCount failed: %v", err)
+	}
+	if count != 3 || calls != 2 {
+		t.Errorf("Expected 3 items over 2 pages, got %d over %d", count, calls)
+	}
+}
+
+func TestDynamoDBRepository_Projection(t *testing.T) {
+	ctx := context.Background()
+	var getInput *dynamodb.GetItemInput
+	var queryInput *dynamodb.QueryInput
+	var scanInput *dynamodb.ScanInput
+	mockClient := &MockDynamoDBClient{
+		getItemFunc: func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			getInput = params
+			return &dynamodb.GetItemOutput{
+				Item: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "test-id"}},
+			}, nil
+		},
+		queryFunc: func(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+			queryInput = params
+			return &dynamodb.QueryOutput{}, nil
+		},
+		scanFunc: func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			scanInput = params
+			return &dynamodb.ScanOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	var item TestItem
+	if err := repo.GetItemWithProjection(ctx, "test-table", map[string]interface{}{"id": "test-id"}, []string{"id", "name"}, &item); err != nil {
+		t.Fatalf("GetItemWithProjection failed: %v", err)
+	}
+	var items []TestItem
+	if _, err := repo.Query(ctx, QueryInput{
+		TableName:                 "test-table",
+		KeyConditionExpression:    "entity_type = :entity_type",
+		ExpressionAttributeValues: map[string]interface{}{":entity_type": "TEST"},
+		Projection:                []string{"id", "name"},
+	}, &items); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if _, err := repo.Scan(ctx, ScanInput{TableName: "test-table", Projection: []string{"id", "name"}}, &items); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	// 予約語の name もプレースホルダー経由で指定する
+	expectedNames := map[string]string{"#p0": "id", "#p1": "name"}
+	for operation, got := range map[string]struct {
+		expression *string
+		names      map[string]string
+	}{
+		"GetItem": {getInput.ProjectionExpression, getInput.ExpressionAttributeNames},
+		"Query":   {queryInput.ProjectionExpression, queryInput.ExpressionAttributeNames},
+		"Scan":    {scanInput.ProjectionExpression, scanInput.ExpressionAttributeNames},
+	} {
+		if aws.ToString(got.expression) != "#p0, #p1" {
+			t.Errorf("%s: unexpected projection expression %q", operation, aws.ToString(got.expression))
+		}
+		if fmt.Sprint(got.names) != fmt.Sprint(expectedNames) {
+			t.Errorf("%s: unexpected attribute names %v", operation, got.names)
+		}
+	}
+
+	// 指定しない場合はすべての属性を取得する
+	if err := repo.GetItem(ctx, "test-table", map[string]interface{}{"id": "test-id"}, &item); err != nil {
+		t.Fatalf("GetItem failed: %v", err)
+	}
+	if getInput.ProjectionExpression != nil || getInput.ExpressionAttributeNames != nil {
+		t.Errorf("Expected no projection, got %q", aws.ToString(getInput.ProjectionExpression))
+	}
+}
+
+// pagedScanClient 1ページ2件ずつ返すScanのモック
+func pagedScanClient(total int, calls *int) *MockDynamoDBClient {
+	return &MockDynamoDBClient{
+		scanFunc: func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			*calls++
+			start := 0
+			if params.ExclusiveStartKey != nil {
+				var key struct {
+					ID string `dynamodbav:"id"`
+				}
+				if err := attributevalue.UnmarshalMap(params.ExclusiveStartKey, &key); err != nil {
+					return nil, err
+				}
+				fmt.Sscanf(key.ID, "item-%d", &start)
+				start++
+			}
+
+			pageSize := 2
+			if params.Limit != nil && int(*params.Limit) < pageSize {
+				pageSize = int(*params.Limit)
+			}
+
+			output := &dynamodb.ScanOutput{}
+			for i := start; i < total && i < start+pageSize; i++ {
+				output.Items = append(output.Items, map[string]types.AttributeValue{
+					"id": &types.AttributeValueMemberS{Value: fmt.Sprintf("item-%d", i)},
+				})
+			}
+			if start+pageSize < total {
+				output.LastEvaluatedKey = output.Items[len(output.Items)-1]
+			}
+			return output, nil
+		},
+	}
+}
+
+func TestDynamoDBRepository_Scan_Pagination(t *testing.T) {
+	calls := 0
+	ctx := context.Background()
+	repo := NewDynamoDBRepositoryWithClient(pagedScanClient(5, &calls))
+
+	var results []TestItem
+	cursor, err := repo.Scan(ctx, ScanInput{TableName: "test-table"}, &results)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	// LastEvaluatedKey をたどってすべてのページを取得することを確認
+	if len(results) != 5 || calls != 3 {
+		t.Errorf("Expected 5 items in 3 calls, got %d items in %d calls", len(results), calls)
+	}
+	if cursor != "" {
+		t.Errorf("Expected empty cursor after reading all pages, got %q", cursor)
+	}
+}
+
+func TestDynamoDBRepository_Scan_LimitAndCursor(t *testing.T) {
+	calls := 0
+	ctx := context.Background()
+	repo := NewDynamoDBRepositoryWithClient(pagedScanClient(5, &calls))
+
+	var first []TestItem
+	cursor, err := repo.Scan(ctx, ScanInput{TableName: "test-table", Limit: 3}, &first)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(first) != 3 || cursor == "" {
+		t.Fatalf("Expected 3 items and a cursor, got %d items and %q", len(first), cursor)
+	}
+
+	var rest []TestItem
+	cursor, err = repo.Scan(ctx, ScanInput{TableName: "test-table", Cursor: cursor}, &rest)
+	if err != nil {
+		t.Fatalf("Scan with cursor failed: %v", err)
+	}
+	if len(rest) != 2 || rest[0].ID != "item-3" {
+		t.Errorf("Expected to resume at item-3, got %+v", rest)
+	}
+	if cursor != "" {
+		t.Errorf("Expected empty cursor, got %q", cursor)
+	}
+
+	if _, err := repo.Scan(ctx, ScanInput{TableName: "test-table", Cursor: "not a cursor"}, &rest); err == nil {
+		t.Error("Expected error for invalid cursor")
+	}
+}
+
+func TestDynamoDBRepository_ScanEach(t *testing.T) {
+	calls := 0
+	ctx := context.Background()
+	repo := NewDynamoDBRepositoryWithClient(pagedScanClient(5, &calls))
+
+	var ids []string
+	err := repo.ScanEach(ctx, ScanInput{TableName: "test-table"}, func(item Item) error {
+		var result TestItem
+		if err := item.Unmarshal(&result); err != nil {
+			return err
+		}
+		ids = append(ids, result.ID)
+		if len(ids) == 3 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanEach failed: %v", err)
+	}
+
+	// ErrStopIteration で残りのページを読まずに終了することを確認
+	if len(ids) != 3 || calls != 2 {
+		t.Errorf("Expected 3 items in 2 calls, got %d items in %d calls", len(ids), calls)
+	}
+}
+
+func TestDynamoDBRepository_PutItem_EntityType(t *testing.T) {
+	ctx := context.Background()
+	var stored map[string]types.AttributeValue
+	mockClient := &MockDynamoDBClient{
+		putItemFunc: func(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			stored = params.Item
+			return &dynamodb.PutItemOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	achievement := &models.Achievement{ID: "test-id", Title: "Test", Point: 10, CreatedAt: time.Now()}
+	if err := repo.PutItem(ctx, "test-table", achievementItem{Achievement: achievement, EntityType: EntityTypeAchievement}); err != nil {
+		t.Fatalf("PutItem failed: %v", err)
+	}
+
+	// 埋め込んだモデルのフィールドがフラットに保存されることを確認
+	if _, ok := stored["title"]; !ok {
+		t.Errorf("Expected title attribute, got %v", stored)
+	}
+	entityType, ok := stored[EntityTypeAttribute].(*types.AttributeValueMemberS)
+	if !ok || entityType.Value != EntityTypeAchievement {
+		t.Errorf("Expected entity_type %s, got %v", EntityTypeAchievement, stored[EntityTypeAttribute])
+	}
+}
+
 func TestDynamoDBRepository_TransactWrite(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockDynamoDBClient{}
-	repo := NewDynamoDBRepositoryWithClient(ctx, mockClient)
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
 
 	items := []TransactWriteItem{
 		{
@@ -163,47 +616,128 @@ func TestDynamoDBRepository_TransactWrite(t *testing.T) {
 		},
 	}
 
-	err := repo.TransactWrite(items)
+	err := repo.TransactWrite(ctx, items)
 	if err != nil {
 		t.Errorf("TransactWrite failed: %v", err)
 	}
 }
 
-func TestDynamoDBRepository_WithRetry(t *testing.T) {
+func TestDynamoDBRepository_TransactWrite_Update(t *testing.T) {
 	ctx := context.Background()
-	mockClient := &MockDynamoDBClient{}
-	repo := NewDynamoDBRepositoryWithClient(ctx, mockClient)
+	var input *dynamodb.TransactWriteItemsInput
+	mockClient := &MockDynamoDBClient{
+		transactWriteItemsFunc: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			input = params
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
 
-	callCount := 0
-	operation := func() error {
-		callCount++
-		if callCount < 3 {
-			return errors.New("temporary error")
-		}
-		return nil
+	err := repo.TransactWrite(ctx, []TransactWriteItem{{
+		TableName:                 "test-table",
+		Operation:                 "UPDATE",
+		Key:                       map[string]interface{}{"id": "current"},
+		UpdateExpression:          "ADD point :delta",
+		ConditionExpression:       "point >= :cost",
+		ExpressionAttributeValues: map[string]interface{}{":delta": -10, ":cost": 10},
+	}})
+	if err != nil {
+		t.Fatalf("TransactWrite failed: %v", err)
+	}
+
+	update := input.TransactItems[0].Update
+	if update == nil {
+		t.Fatal("Expected an update item")
+	}
+	if aws.ToString(update.UpdateExpression) != "ADD point :delta" || aws.ToString(update.ConditionExpression) != "point >= :cost" {
+		t.Errorf("Unexpected update: %+v", update)
 	}
+	if cost, ok := update.ExpressionAttributeValues[":cost"].(*types.AttributeValueMemberN); !ok || cost.Value != "10" {
+		t.Errorf("Expected :cost to be 10, got %v", update.ExpressionAttributeValues[":cost"])
+	}
+}
+
+func TestDynamoDBRepository_TransactWrite_ConditionCheck(t *testing.T) {
+	ctx := context.Background()
+	var input *dynamodb.TransactWriteItemsInput
+	mockClient := &MockDynamoDBClient{
+		transactWriteItemsFunc: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			input = params
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
 
-	err := repo.WithRetry(operation, 3)
+	err := repo.TransactWrite(ctx, []TransactWriteItem{{
+		TableName:           "test-table",
+		Operation:           "CONDITION_CHECK",
+		Key:                 map[string]interface{}{"id": "test-id"},
+		ConditionExpression: "attribute_exists(id)",
+	}})
 	if err != nil {
-		t.Errorf("WithRetry failed: %v", err)
+		t.Fatalf("TransactWrite failed: %v", err)
 	}
 
-	if callCount != 3 {
-		t.Errorf("Expected 3 calls, got %d", callCount)
+	check := input.TransactItems[0].ConditionCheck
+	if check == nil {
+		t.Fatal("Expected a condition check item")
+	}
+	if aws.ToString(check.TableName) != "test-table" || aws.ToString(check.ConditionExpression) != "attribute_exists(id)" {
+		t.Errorf("Unexpected condition check: %+v", check)
+	}
+	if id, ok := check.Key["id"].(*types.AttributeValueMemberS); !ok || id.Value != "test-id" {
+		t.Errorf("Expected key id to be test-id, got %v", check.Key["id"])
 	}
 }
 
-func TestDynamoDBRepository_WithRetry_MaxRetriesExceeded(t *testing.T) {
+func TestDynamoDBRepository_TransactWrite_AttributeNames(t *testing.T) {
 	ctx := context.Background()
-	mockClient := &MockDynamoDBClient{}
-	repo := NewDynamoDBRepositoryWithClient(ctx, mockClient)
+	var input *dynamodb.TransactWriteItemsInput
+	mockClient := &MockDynamoDBClient{
+		transactWriteItemsFunc: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			input = params
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
 
-	operation := func() error {
-		return errors.New("persistent error")
+	err := repo.TransactWrite(ctx, []TransactWriteItem{{
+		TableName:                 "test-table",
+		Item:                      TestItem{ID: "test-id", Name: "test-name", Value: 100},
+		Operation:                 "PUT",
+		ConditionExpression:       versionCondition(2),
+		ExpressionAttributeNames:  map[string]string{"#version": VersionAttribute},
+		ExpressionAttributeValues: map[string]interface{}{":expected": 2},
+	}})
+	if err != nil {
+		t.Fatalf("TransactWrite failed: %v", err)
 	}
 
-	err := repo.WithRetry(operation, 2)
-	if err == nil {
-		t.Error("WithRetry should have failed after max retries")
+	put := input.TransactItems[0].Put
+	if put == nil || put.ExpressionAttributeNames["#version"] != VersionAttribute {
+		t.Errorf("Expected #version to be passed through, got %+v", put)
+	}
+}
+
+func TestDynamoDBRepository_TransactWrite_ConditionalCheckFailed(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockDynamoDBClient{
+		transactWriteItemsFunc: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			return nil, &types.TransactionCanceledException{
+				CancellationReasons: []types.CancellationReason{
+					{Code: aws.String("None")},
+					{Code: aws.String("ConditionalCheckFailed")},
+				},
+			}
+		},
 	}
-}
\ No newline at end of file
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	err := repo.TransactWrite(ctx, []TransactWriteItem{
+		{TableName: "test-table", Operation: "PUT", Item: TestItem{ID: "test-id", Name: "test", Value: 1}},
+		{TableName: "test-table", Operation: "UPDATE", Key: map[string]interface{}{"id": "current"}, UpdateExpression: "ADD point :delta"},
+	})
+	if !errors.Is(err, ErrConditionFailed) {
+		t.Errorf("Expected ErrConditionFailed, got %v", err)
+	}
+}
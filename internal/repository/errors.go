@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	apperrors "achievement-management/internal/errors"
+)
+
+// DynamoDBの呼び出しで発生するエラーの種類（errors.Is で判定する。条件付き書き込みの失敗は ErrConditionFailed）
+var (
+	// ErrItemNotFound 指定したキーのアイテムが存在しない
+	ErrItemNotFound = errors.New("item not found")
+	// ErrThrottled スロットリングによりリクエストが拒否された（リトライしても解消しなかった場合を含む）
+	ErrThrottled = errors.New("request throttled")
+	// ErrResourceNotFound テーブルまたはインデックスが存在しない（作成前・作成中を含む）
+	ErrResourceNotFound = errors.New("table or index not found")
+	// ErrNetwork DynamoDBにリクエストを送信できなかった
+	ErrNetwork = errors.New("network error")
+)
+
+// throttledRetryAfter スロットリングのリトライを使い切った場合に返す再試行までの時間
+const throttledRetryAfter = time.Second
+
+// classifiedError SDKのエラーに種類を付与したエラー（メッセージと元のエラーはそのまま保持する）
+type classifiedError struct {
+	kind error
+	err  error
+	// detail ハンドラーが応答を決めるためのエラー（スロットリングの場合の再試行までの時間など）
+	detail error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	if e.detail != nil {
+		return []error{e.kind, e.detail, e.err}
+	}
+	return []error{e.kind, e.err}
+}
+
+// throttled スロットリングの種類と再試行までの時間を付与したエラー
+func throttled(err error, reason string, retryAfter time.Duration) error {
+	return &classifiedError{
+		kind:   ErrThrottled,
+		err:    err,
+		detail: &apperrors.ThrottledError{Reason: reason, RetryAfter: retryAfter},
+	}
+}
+
+// classifyError SDKのエラーに種類を付与（該当しない場合はそのまま返す）
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr smithy.APIError
+	var conditionErr *types.ConditionalCheckFailedException
+	var resourceErr *types.ResourceNotFoundException
+	var sendErr *smithyhttp.RequestSendError
+	var netErr net.Error
+
+	var kind error
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		// キャンセル・期限切れは呼び出し側の都合のため分類しない
+		return err
+	case errors.As(err, &apiErr) && throttlingErrorCodes[apiErr.ErrorCode()]:
+		return throttled(err, apiErr.ErrorCode(), throttledRetryAfter)
+	case errors.As(err, &conditionErr):
+		kind = ErrConditionFailed
+	case errors.As(err, &resourceErr):
+		kind = ErrResourceNotFound
+	case errors.As(err, &sendErr), errors.As(err, &netErr):
+		kind = ErrNetwork
+	default:
+		return err
+	}
+	return &classifiedError{kind: kind, err: err}
+}
+
+// classifyingClient すべての操作のエラーに種類を付与するDynamoDBクライアント
+type classifyingClient struct {
+	client DynamoDBAPI
+}
+
+// newClassifyingClient エラーを分類するクライアントを作成
+func newClassifyingClient(client DynamoDBAPI) DynamoDBAPI {
+	return &classifyingClient{client: client}
+}
+
+func (c *classifyingClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	out, err := c.client.PutItem(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	out, err := c.client.GetItem(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	out, err := c.client.UpdateItem(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	out, err := c.client.Scan(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	out, err := c.client.Query(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	out, err := c.client.DeleteItem(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	out, err := c.client.TransactWriteItems(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	out, err := c.client.BatchWriteItem(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	out, err := c.client.BatchGetItem(ctx, params, optFns...)
+	return out, classifyError(err)
+}
+
+func (c *classifyingClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	out, err := c.client.DescribeTable(ctx, params, optFns...)
+	return out, classifyError(err)
+}
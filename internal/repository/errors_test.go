@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	apperrors "achievement-management/internal/errors"
+)
+
+func TestClassifyError(t *testing.T) {
+	sendErr := &smithyhttp.RequestSendError{Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "throughput exceeded", err: throttlingError(), expected: ErrThrottled},
+		{name: "throttling", err: &smithy.GenericAPIError{Code: "ThrottlingException"}, expected: ErrThrottled},
+		{name: "conditional check failed", err: &types.ConditionalCheckFailedException{}, expected: ErrConditionFailed},
+		{name: "missing table", err: &types.ResourceNotFoundException{Message: aws.String("Requested resource not found")}, expected: ErrResourceNotFound},
+		{name: "request not sent", err: sendErr, expected: ErrNetwork},
+		{name: "wrapped network error", err: fmt.Errorf("operation error: %w", &net.DNSError{Err: "no such host", IsTimeout: true}), expected: ErrNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyError(tt.err)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			// 元のエラーとメッセージは保持する
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected the SDK error to be kept, got %v", err)
+			}
+			if err.Error() != tt.err.Error() {
+				t.Errorf("Expected message %q, got %q", tt.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestClassifyError_Unclassified(t *testing.T) {
+	if classifyError(nil) != nil {
+		t.Error("Expected nil for nil")
+	}
+
+	// キャンセル・期限切れは通信エラーとして扱わない
+	canceled := &smithyhttp.RequestSendError{Err: context.Canceled}
+	for _, err := range []error{canceled, context.DeadlineExceeded, errors.New("boom")} {
+		classified := classifyError(err)
+		if classified != err {
+			t.Errorf("Expected %v to be returned as is, got %v", err, classified)
+		}
+	}
+}
+
+func TestDynamoDBRepository_ClassifiesErrors(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockDynamoDBClient{
+		getItemFunc: func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			if aws.ToString(params.TableName) == "missing-table" {
+				return nil, &types.ResourceNotFoundException{Message: aws.String("Requested resource not found")}
+			}
+			return &dynamodb.GetItemOutput{}, nil
+		},
+		scanFunc: func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			return nil, throttlingError()
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	var item TestItem
+	err := repo.GetItem(ctx, "missing-table", map[string]interface{}{"id": "test-id"}, &item)
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Expected ErrResourceNotFound, got %v", err)
+	}
+
+	err = repo.GetItem(ctx, "test-table", map[string]interface{}{"id": "test-id"}, &item)
+	if !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("Expected ErrItemNotFound, got %v", err)
+	}
+	if err.Error() != "item not found in table test-table" {
+		t.Errorf("Unexpected message: %v", err)
+	}
+
+	var items []TestItem
+	_, err = repo.Scan(ctx, ScanInput{TableName: "test-table"}, &items)
+	if !errors.Is(err, ErrThrottled) || !IsThrottled(err) {
+		t.Errorf("Expected ErrThrottled, got %v", err)
+	}
+
+	// ハンドラーが429と Retry-After を返せるように再試行までの時間を付与する
+	var throttledErr *apperrors.ThrottledError
+	if !errors.As(err, &throttledErr) || throttledErr.RetryAfter != throttledRetryAfter || throttledErr.Reason != "ProvisionedThroughputExceededException" {
+		t.Errorf("Expected ThrottledError, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// conditionNotReached 目標の達成の記録時（削除済み・達成済みの目標には記録しない）
+const conditionNotReached = "attribute_exists(id) AND attribute_not_exists(reached_at)"
+
+// GoalRepositoryImpl 目標リポジトリの実装
+type GoalRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewGoalRepository 目標リポジトリを作成
+func NewGoalRepository(repo Repository, config *config.Config) GoalRepository {
+	return &GoalRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Create 目標を作成
+func (r *GoalRepositoryImpl) Create(ctx context.Context, goal *models.Goal) error {
+	if err := ValidateGoal(goal); err != nil {
+		return err
+	}
+
+	// IDが空の場合はULIDを生成
+	if goal.ID == "" {
+		goal.ID = ulid.Make().String()
+	}
+
+	if goal.CreatedAt.IsZero() {
+		goal.CreatedAt = time.Now()
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Goals, newGoalItem(ctx, goal), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Create",
+			Table:     r.config.Tables.Goals,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Update 目標を更新（達成日時を含めて上書きする）
+func (r *GoalRepositoryImpl) Update(ctx context.Context, goal *models.Goal) error {
+	if err := ValidateGoal(goal); err != nil {
+		return err
+	}
+	if goal.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Goals, newGoalItem(ctx, goal), conditionExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Update",
+			Table:     r.config.Tables.Goals,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetByID IDで目標を取得
+func (r *GoalRepositoryImpl) GetByID(ctx context.Context, id string) (*models.Goal, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	var goal models.Goal
+	err := r.repo.GetItem(ctx, r.config.Tables.Goals, itemKey(ctx, id), &goal)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{
+			Operation: "GetByID",
+			Table:     r.config.Tables.Goals,
+			Cause:     err,
+		}
+	}
+
+	goal.ID = tenant.EntityID(ctx, goal.ID)
+	return &goal, nil
+}
+
+// List すべての目標を作成日時順に取得
+func (r *GoalRepositoryImpl) List(ctx context.Context) ([]*models.Goal, error) {
+	var goals []*models.Goal
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Goals, CreatedAtIndex, EntityTypeGoal), &goals)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Goals,
+			Cause:     err,
+		}
+	}
+
+	for _, goal := range goals {
+		goal.ID = tenant.EntityID(ctx, goal.ID)
+	}
+	return goals, nil
+}
+
+// Delete 目標を削除
+func (r *GoalRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	// 存在確認（IDのみを読み取る）
+	var item struct {
+		ID string `dynamodbav:"id"`
+	}
+	err := r.repo.GetItemWithProjection(ctx, r.config.Tables.Goals, itemKey(ctx, id), idProjection, &item)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Goals,
+			Cause:     err,
+		}
+	}
+
+	err = r.repo.DeleteItem(ctx, r.config.Tables.Goals, itemKey(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Goals,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// MarkReached 目標の達成日時を記録
+//
+// 削除された目標や、同時に評価した別のリクエストが先に記録した目標には記録せず errors.ErrVersionConflict を返す。
+func (r *GoalRepositoryImpl) MarkReached(ctx context.Context, id string, reachedAt time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	err := r.repo.UpdateItemWithCondition(ctx, r.config.Tables.Goals, itemKey(ctx, id), "SET reached_at = :reached_at", conditionNotReached, map[string]interface{}{
+		":reached_at": reachedAt,
+	})
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrVersionConflict
+		}
+		return &errors.DatabaseError{
+			Operation: "MarkReached",
+			Table:     r.config.Tables.Goals,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// ValidateGoal 目標のバリデーション（すべてのストレージで共通）
+func ValidateGoal(goal *models.Goal) error {
+	if goal == nil {
+		return &errors.ValidationError{Field: "goal", Message: "goal cannot be nil"}
+	}
+	if goal.Title == "" {
+		return &errors.ValidationError{Field: "title", Message: "title is required"}
+	}
+	if goal.Type != models.GoalTypePoints && goal.Type != models.GoalTypeAchievements {
+		return &errors.ValidationError{Field: "type", Message: "type must be points or achievements"}
+	}
+	if goal.Target <= 0 {
+		return &errors.ValidationError{Field: "target", Message: "target must be positive"}
+	}
+	return nil
+}
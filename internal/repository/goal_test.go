@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testGoalConfig() *config.Config {
+	return &config.Config{Tables: config.TableConfig{Goals: "test-goals"}}
+}
+
+func TestGoalRepository_Create(t *testing.T) {
+	var putCondition string
+	var putItem goalItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			putCondition = conditionExpression
+			putItem = item.(goalItem)
+			return nil
+		},
+	}
+	repo := NewGoalRepository(mockRepo, testGoalConfig())
+
+	goal := &models.Goal{Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 500}
+	if err := repo.Create(tenant.WithID(context.Background(), "acme"), goal); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if goal.ID == "" || goal.CreatedAt.IsZero() {
+		t.Error("ID and CreatedAt should be set")
+	}
+	if putCondition != conditionNotExists {
+		t.Errorf("Expected condition %s, got %s", conditionNotExists, putCondition)
+	}
+	if putItem.ID != "acme#"+goal.ID || putItem.EntityType != "acme#"+EntityTypeGoal {
+		t.Errorf("Expected tenant keys, got %s / %s", putItem.ID, putItem.EntityType)
+	}
+}
+
+func TestGoalRepository_Create_ValidationError(t *testing.T) {
+	repo := NewGoalRepository(&MockRepository{}, testGoalConfig())
+
+	invalid := []*models.Goal{
+		nil,
+		{Type: models.GoalTypePoints, Target: 500},
+		{Title: "目標", Type: "streak", Target: 5},
+		{Title: "目標", Type: models.GoalTypeAchievements, Target: 0},
+	}
+	for _, goal := range invalid {
+		if _, ok := repo.Create(context.Background(), goal).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", goal)
+		}
+	}
+}
+
+func TestGoalRepository_Update_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			if conditionExpression != conditionExists {
+				t.Errorf("Expected condition %s, got %s", conditionExists, conditionExpression)
+			}
+			return ErrConditionFailed
+		},
+	}
+	repo := NewGoalRepository(mockRepo, testGoalConfig())
+
+	err := repo.Update(context.Background(), &models.Goal{ID: "goal-1", Title: "目標", Type: models.GoalTypePoints, Target: 500})
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGoalRepository_List(t *testing.T) {
+	var queried QueryInput
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			queried = input
+			*result.(*[]*models.Goal) = []*models.Goal{
+				{ID: "acme#goal-1", Title: "目標", Type: models.GoalTypePoints, Target: 500},
+			}
+			return "", nil
+		},
+	}
+	repo := NewGoalRepository(mockRepo, testGoalConfig())
+
+	goals, err := repo.List(tenant.WithID(context.Background(), "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if queried.TableName != "test-goals" || queried.IndexName != CreatedAtIndex {
+		t.Errorf("Expected query on %s of test-goals, got %s of %s", CreatedAtIndex, queried.IndexName, queried.TableName)
+	}
+	if len(goals) != 1 || goals[0].ID != "goal-1" {
+		t.Errorf("Expected goal IDs without tenant prefix, got %+v", goals)
+	}
+}
+
+func TestGoalRepository_MarkReached(t *testing.T) {
+	reachedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	var updatedKey map[string]interface{}
+	var updatedCondition string
+	var updatedValues map[string]interface{}
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			updatedKey, updatedCondition, updatedValues = key, conditionExpression, expressionAttributeValues
+			return nil
+		},
+	}
+	repo := NewGoalRepository(mockRepo, testGoalConfig())
+
+	if err := repo.MarkReached(tenant.WithID(context.Background(), "acme"), "goal-1", reachedAt); err != nil {
+		t.Fatalf("MarkReached failed: %v", err)
+	}
+
+	if updatedKey["id"] != "acme#goal-1" {
+		t.Errorf("Expected tenant key, got %v", updatedKey["id"])
+	}
+	// 達成済みの目標の達成日時は上書きしない
+	if updatedCondition != conditionNotReached {
+		t.Errorf("Expected condition %s, got %s", conditionNotReached, updatedCondition)
+	}
+	if updatedValues[":reached_at"] != reachedAt {
+		t.Errorf("Expected reached_at %v, got %v", reachedAt, updatedValues[":reached_at"])
+	}
+}
+
+func TestGoalRepository_MarkReached_AlreadyReached(t *testing.T) {
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			return ErrConditionFailed
+		},
+	}
+	repo := NewGoalRepository(mockRepo, testGoalConfig())
+
+	err := repo.MarkReached(context.Background(), "goal-1", time.Now())
+	if !stderrors.Is(err, errors.ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+}
@@ -1,49 +1,213 @@
 package repository
 
-import "achievement-management/internal/models"
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/models"
+)
 
 // TransactWriteItem DynamoDB トランザクション書き込みアイテム
 type TransactWriteItem struct {
 	TableName string
-	Item      interface{}
-	Operation string // "PUT", "UPDATE", "DELETE"
+	Item      interface{} // PUT の場合は書き込むアイテム、DELETE の場合は削除するキー
+	Operation string      // "PUT", "UPDATE", "DELETE", "CONDITION_CHECK"
+	// Key UPDATE・CONDITION_CHECK の対象のキー
+	Key map[string]interface{}
+	// UpdateExpression UPDATE の更新式
+	UpdateExpression string
+	// ConditionExpression 書き込みの条件式（空の場合は無条件）
+	ConditionExpression string
+	// ExpressionAttributeNames 更新式・条件式で使用する属性名のプレースホルダー
+	ExpressionAttributeNames map[string]string
+	// ExpressionAttributeValues 更新式・条件式で使用する値
+	ExpressionAttributeValues map[string]interface{}
+}
+
+// ScanInput DynamoDB Scanの入力
+type ScanInput struct {
+	TableName string
+	Limit     int    // 取得する最大件数（0の場合はすべて）
+	Cursor    string // 前回の取得で返されたカーソル（空の場合は先頭から）
+	// Projection 取得する属性（空の場合はすべての属性）
+	Projection []string
+	// Segment 並列スキャンでこのスキャンが担当するセグメント（0 から TotalSegments-1）
+	Segment int
+	// TotalSegments 並列スキャンのセグメント数（0の場合はテーブル全体をスキャンする）
+	TotalSegments int
+}
+
+// QueryInput DynamoDB Queryの入力
+type QueryInput struct {
+	TableName                 string
+	IndexName                 string
+	KeyConditionExpression    string
+	ExpressionAttributeValues map[string]interface{}
+	Descending                bool   // trueの場合はソートキーの降順
+	Limit                     int    // 取得する最大件数（0の場合はすべて）
+	Cursor                    string // 前回の取得で返されたカーソル（空の場合は先頭から）
+	// Projection 取得する属性（空の場合はすべての属性）
+	Projection []string
 }
 
 // Repository DynamoDB操作の抽象化
 type Repository interface {
-	PutItem(tableName string, item interface{}) error
-	GetItem(tableName string, key map[string]interface{}, result interface{}) error
-	UpdateItem(tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error
-	Scan(tableName string, result interface{}) error
-	DeleteItem(tableName string, key map[string]interface{}) error
-	TransactWrite(items []TransactWriteItem) error
+	PutItem(ctx context.Context, tableName string, item interface{}) error
+	PutItemWithCondition(ctx context.Context, tableName string, item interface{}, conditionExpression string) error
+	PutItemWithVersion(ctx context.Context, tableName string, item interface{}, expectedVersion int) error
+	GetItem(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error
+	GetItemConsistent(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error
+	GetItemWithProjection(ctx context.Context, tableName string, key map[string]interface{}, projection []string, result interface{}) error
+	UpdateItem(ctx context.Context, tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error
+	UpdateItemWithCondition(ctx context.Context, tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error
+	Scan(ctx context.Context, input ScanInput, result interface{}) (string, error)
+	ScanEach(ctx context.Context, input ScanInput, fn ItemHandler) error
+	Query(ctx context.Context, input QueryInput, result interface{}) (string, error)
+	QueryEach(ctx context.Context, input QueryInput, fn ItemHandler) error
+	ItemCount(ctx context.Context, tableName string) (int64, error)
+	QueryCount(ctx context.Context, input QueryInput) (int64, error)
+	DeleteItem(ctx context.Context, tableName string, key map[string]interface{}) error
+	TransactWrite(ctx context.Context, items []TransactWriteItem) error
+	BatchPutItems(ctx context.Context, tableName string, items []interface{}) error
+	BatchDeleteItems(ctx context.Context, tableName string, keys []map[string]interface{}) error
+	BatchGetItems(ctx context.Context, tableName string, keys []map[string]interface{}, result interface{}) error
 }
 
+// itemGetter Repository.GetItem と Repository.GetItemConsistent の共通シグネチャ
+type itemGetter func(ctx context.Context, tableName string, key map[string]interface{}, result interface{}) error
+
 // AchievementRepository 達成目録リポジトリ
 type AchievementRepository interface {
-	Create(achievement *models.Achievement) error
-	Update(achievement *models.Achievement) error
-	GetByID(id string) (*models.Achievement, error)
-	List() ([]*models.Achievement, error)
-	Delete(id string) error
+	Create(ctx context.Context, achievement *models.Achievement) error
+	CreateWithPoints(ctx context.Context, achievement *models.Achievement) error
+	Update(ctx context.Context, achievement *models.Achievement) error
+	UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error
+	GetByID(ctx context.Context, id string) (*models.Achievement, error)
+	List(ctx context.Context) ([]*models.Achievement, error)
+	ListSummaries(ctx context.Context) ([]*models.Achievement, error)
+	Count(ctx context.Context) (int, error)
+	Delete(ctx context.Context, id string) error
+	DeleteWithPoints(ctx context.Context, id string) error
+	DeleteMany(ctx context.Context, ids []string) error
+	SetAttachment(ctx context.Context, id, key string) error
+	StartTimer(ctx context.Context, id string, at time.Time) error
+	Complete(ctx context.Context, completion *models.Completion) error
+	ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error)
 }
 
 // RewardRepository 報酬リポジトリ
 type RewardRepository interface {
-	Create(reward *models.Reward) error
-	Update(reward *models.Reward) error
-	GetByID(id string) (*models.Reward, error)
-	List() ([]*models.Reward, error)
-	Delete(id string) error
+	Create(ctx context.Context, reward *models.Reward) error
+	Update(ctx context.Context, reward *models.Reward) error
+	GetByID(ctx context.Context, id string) (*models.Reward, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error)
+	List(ctx context.Context) ([]*models.Reward, error)
+	Count(ctx context.Context) (int, error)
+	Delete(ctx context.Context, id string) error
 }
 
 // PointRepository ポイントリポジトリ
 type PointRepository interface {
-	GetCurrentPoints() (*models.CurrentPoints, error)
-	UpdateCurrentPoints(points *models.CurrentPoints) error
-	CreateRewardHistory(history *models.RewardHistory) error
-	GetRewardHistory() ([]*models.RewardHistory, error)
-	TransactPointsAndHistory(pointsUpdate *models.CurrentPoints, history *models.RewardHistory) error
-	AddPoints(points int) error
-	SubtractPoints(points int) error
-}
\ No newline at end of file
+	GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error)
+	GetCurrentPointsConsistent(ctx context.Context) (*models.CurrentPoints, error)
+	UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) error
+	CreateRewardHistory(ctx context.Context, history *models.RewardHistory) error
+	GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error)
+	GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error)
+	CountRewardHistory(ctx context.Context) (int, error)
+	RedeemPoints(ctx context.Context, history *models.RewardHistory) error
+	GetRewardHistoryByID(ctx context.Context, id string) (*models.RewardHistory, error)
+	RefundRedemption(ctx context.Context, history *models.RewardHistory) error
+	SetRedemptionAttachment(ctx context.Context, id, key string) error
+	AnnotateRedemption(ctx context.Context, id, note string, tags []string) error
+	GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error)
+	GetLedgerBetween(ctx context.Context, from, to time.Time) ([]*models.PointLedgerEntry, error)
+	AddPoints(ctx context.Context, points int) error
+	SubtractPoints(ctx context.Context, points int) error
+}
+
+// BadgeRepository 獲得したバッジのリポジトリ
+type BadgeRepository interface {
+	Award(ctx context.Context, badge *models.Badge) error
+	List(ctx context.Context) ([]*models.Badge, error)
+}
+
+// GoalRepository 目標リポジトリ
+type GoalRepository interface {
+	Create(ctx context.Context, goal *models.Goal) error
+	Update(ctx context.Context, goal *models.Goal) error
+	GetByID(ctx context.Context, id string) (*models.Goal, error)
+	List(ctx context.Context) ([]*models.Goal, error)
+	Delete(ctx context.Context, id string) error
+	MarkReached(ctx context.Context, id string, reachedAt time.Time) error
+}
+
+// FavoriteRepository お気に入りリポジトリ
+type FavoriteRepository interface {
+	Add(ctx context.Context, favorite *models.Favorite) error
+	Remove(ctx context.Context, rewardID string) error
+	List(ctx context.Context) ([]*models.Favorite, error)
+}
+
+// WishlistRepository ほしいものリストリポジトリ
+type WishlistRepository interface {
+	Add(ctx context.Context, item *models.WishlistItem) error
+	UpdatePriority(ctx context.Context, rewardID string, priority int) error
+	Remove(ctx context.Context, rewardID string) error
+	List(ctx context.Context) ([]*models.WishlistItem, error)
+}
+
+// NoteRepository 記録に付けるメモのリポジトリ
+type NoteRepository interface {
+	Create(ctx context.Context, note *models.Note) error
+	GetByID(ctx context.Context, id string) (*models.Note, error)
+	ListByTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// DriftRepository 整合性チェックで検出したポイントの差異のリポジトリ
+type DriftRepository interface {
+	Record(ctx context.Context, event *models.DriftEvent) error
+	List(ctx context.Context) ([]*models.DriftEvent, error)
+}
+
+// ReservationRepository 報酬のために取り置いたポイントのリポジトリ（Put は取り置き済みの場合に取り置くポイントを置き換える）
+type ReservationRepository interface {
+	Put(ctx context.Context, reservation *models.Reservation) error
+	Remove(ctx context.Context, rewardID string) error
+	List(ctx context.Context) ([]*models.Reservation, error)
+}
+
+// QuestRepository クエストのリポジトリ
+type QuestRepository interface {
+	Create(ctx context.Context, quest *models.Quest) error
+	GetByID(ctx context.Context, id string) (*models.Quest, error)
+	List(ctx context.Context) ([]*models.Quest, error)
+	Delete(ctx context.Context, id string) error
+	Complete(ctx context.Context, quest *models.Quest) error
+}
+
+// OperationRepository 元に戻せる操作の操作履歴のリポジトリ（SetUndone に nil を指定すると元に戻した日時を消去する）
+type OperationRepository interface {
+	Record(ctx context.Context, operation *models.Operation) error
+	List(ctx context.Context) ([]*models.Operation, error)
+	SetUndone(ctx context.Context, id string, undoneAt *time.Time) error
+	Delete(ctx context.Context, id string) error
+}
+
+// AllowanceRepository お小遣いのルールのリポジトリ
+type AllowanceRepository interface {
+	Create(ctx context.Context, allowance *models.Allowance) error
+	GetByID(ctx context.Context, id string) (*models.Allowance, error)
+	List(ctx context.Context) ([]*models.Allowance, error)
+	Delete(ctx context.Context, id string) error
+	Grant(ctx context.Context, id string, points int, at time.Time) error
+}
+
+// APIKeyRepository APIキーのリポジトリ（無効にしたキーも記録として残すため削除はしない）
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	Update(ctx context.Context, key *models.APIKey) error
+	GetByID(ctx context.Context, id string) (*models.APIKey, error)
+	List(ctx context.Context) ([]*models.APIKey, error)
+}
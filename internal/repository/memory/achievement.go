@@ -0,0 +1,389 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// AchievementRepository メモリを使用した達成目録リポジトリ
+type AchievementRepository struct {
+	store *Store
+}
+
+// NewAchievementRepository 達成目録リポジトリを作成
+func NewAchievementRepository(store *Store) repository.AchievementRepository {
+	return &AchievementRepository{store: store}
+}
+
+// Create 達成目録を作成
+func (r *AchievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := prepareAchievement(achievement); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.achievements[achievement.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.achievements[achievement.ID] = *achievement
+	return nil
+}
+
+// CreateWithPoints 達成目録の作成・ポイント加算・台帳への記録を1つのロック内でまとめて実行
+func (r *AchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if err := prepareAchievement(achievement); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.achievements[achievement.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.achievements[achievement.ID] = *achievement
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntryGrant, achievement.Point, achievement.ID))
+	return nil
+}
+
+// prepareAchievement 作成する達成目録を検証し、ID・作成日時・バージョンを設定
+func prepareAchievement(achievement *models.Achievement) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+
+	if err := repository.ValidateAchievement(achievement); err != nil {
+		return err
+	}
+
+	if achievement.ID == "" {
+		achievement.ID = ulid.Make().String()
+	}
+	if achievement.CreatedAt.IsZero() {
+		achievement.CreatedAt = time.Now()
+	}
+	achievement.Version = 1
+	return nil
+}
+
+// Update 達成目録を更新
+func (r *AchievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+
+	if achievement.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	if err := repository.ValidateAchievement(achievement); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, err := data.updatable(achievement); err != nil {
+		return err
+	}
+	data.replaceAchievement(achievement)
+	return nil
+}
+
+// UpdateWithPoints 達成目録を更新し、ポイントの差分を残高と台帳に反映
+func (r *AchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+
+	if achievement.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	if err := repository.ValidateAchievement(achievement); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	existing, err := data.updatable(achievement)
+	if err != nil {
+		return err
+	}
+
+	delta := achievement.Point - existing.Point
+	if data.balance()+delta < 0 {
+		return errors.ErrInsufficientPoints
+	}
+	data.replaceAchievement(achievement)
+	switch {
+	case delta > 0:
+		data.addPoints(repository.NewLedgerEntry(models.LedgerEntryGrant, delta, achievement.ID))
+	case delta < 0:
+		data.addPoints(repository.NewLedgerEntry(models.LedgerEntryRevoke, delta, achievement.ID))
+	}
+	return nil
+}
+
+// updatable 更新対象の達成目録を取得（バージョンが指定されている場合は保存済みのバージョンと一致する場合のみ）
+func (p *partition) updatable(achievement *models.Achievement) (models.Achievement, error) {
+	existing, exists := p.achievements[achievement.ID]
+	if !exists {
+		return models.Achievement{}, errors.ErrNotFound
+	}
+
+	if achievement.Version != 0 && achievement.Version != existing.Version {
+		return models.Achievement{}, errors.ErrVersionConflict
+	}
+	return existing, nil
+}
+
+// replaceAchievement 作成日時・添付ファイル・タイマーを保持したままバージョンを進めて達成目録を置き換え
+func (p *partition) replaceAchievement(achievement *models.Achievement) {
+	existing := p.achievements[achievement.ID]
+	achievement.CreatedAt = existing.CreatedAt
+	achievement.AttachmentKey = existing.AttachmentKey
+	achievement.TimerStartedAt = existing.TimerStartedAt
+	achievement.Version = existing.Version + 1
+	p.achievements[achievement.ID] = *achievement
+}
+
+// GetByID IDで達成目録を取得
+func (r *AchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	achievement, exists := data.achievements[id]
+	if !exists {
+		return nil, errors.ErrNotFound
+	}
+	return &achievement, nil
+}
+
+// List すべての達成目録を作成日時順に取得
+func (r *AchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	achievements := make([]*models.Achievement, 0, len(data.achievements))
+	for _, achievement := range data.achievements {
+		achievement := achievement
+		achievements = append(achievements, &achievement)
+	}
+	sortAchievements(achievements)
+	return achievements, nil
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイント・分類・難易度のみを作成日時順に取得
+func (r *AchievementRepository) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	achievements, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*models.Achievement, len(achievements))
+	for i, achievement := range achievements {
+		summaries[i] = &models.Achievement{ID: achievement.ID, Title: achievement.Title, Point: achievement.Point, Category: achievement.Category, Difficulty: achievement.Difficulty}
+	}
+	return summaries, nil
+}
+
+// Count 達成目録の件数を取得
+func (r *AchievementRepository) Count(ctx context.Context) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return len(r.store.forRead(ctx).achievements), nil
+}
+
+// Delete 達成目録を削除
+func (r *AchievementRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.achievements[id]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.achievements, id)
+	return nil
+}
+
+// DeleteWithPoints 達成目録の削除とポイント減算・台帳への記録を同時に実行（残高が足りない場合は ErrInsufficientPoints）
+func (r *AchievementRepository) DeleteWithPoints(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	achievement, exists := data.achievements[id]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	if data.balance() < achievement.Point {
+		return errors.ErrInsufficientPoints
+	}
+	delete(data.achievements, id)
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntryRevoke, -achievement.Point, achievement.ID))
+	return nil
+}
+
+// DeleteMany 複数の達成目録をまとめて削除
+func (r *AchievementRepository) DeleteMany(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		if id == "" {
+			return &errors.ValidationError{Field: "id", Message: "id is required"}
+		}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	for _, id := range ids {
+		delete(data.achievements, id)
+	}
+	return nil
+}
+
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存（バージョンは進めない）
+func (r *AchievementRepository) SetAttachment(ctx context.Context, id, key string) error {
+	if err := repository.ValidateAttachment(id, key); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	achievement, exists := data.achievements[id]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	achievement.AttachmentKey = key
+	data.achievements[id] = achievement
+	return nil
+}
+
+// StartTimer 達成目録のタイマーを開始（バージョンは進めない。すでにタイマーが動いている場合は ErrVersionConflict）
+func (r *AchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	achievement, exists := data.achievements[id]
+	if !exists || achievement.TimerStartedAt != nil {
+		return errors.ErrVersionConflict
+	}
+	achievement.TimerStartedAt = &at
+	data.achievements[id] = achievement
+	return nil
+}
+
+// Complete 達成記録を作成し、達成目録のポイントを付与（StartedAt を指定した場合は同じタイマーが動いている場合のみ止めて達成する）
+// 達成回数の上限（Limit）を指定した場合は、上限に達していれば BusinessLogicError を返す
+func (r *AchievementRepository) Complete(ctx context.Context, completion *models.Completion) error {
+	if completion == nil {
+		return &errors.ValidationError{Field: "completion", Message: "completion cannot be nil"}
+	}
+
+	if err := repository.ValidateCompletion(completion); err != nil {
+		return err
+	}
+
+	if completion.ID == "" {
+		completion.ID = ulid.Make().String()
+	}
+	if completion.CompletedAt.IsZero() {
+		completion.CompletedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	achievement, exists := data.achievements[completion.AchievementID]
+	if !exists {
+		if completion.StartedAt != nil {
+			return errors.ErrVersionConflict
+		}
+		return errors.ErrNotFound
+	}
+	if completion.StartedAt != nil && (achievement.TimerStartedAt == nil || !achievement.TimerStartedAt.Equal(*completion.StartedAt)) {
+		return errors.ErrVersionConflict
+	}
+	if limit := completion.Limit; limit != nil {
+		var completions []*models.Completion
+		for i := range data.completions {
+			if data.completions[i].AchievementID == completion.AchievementID {
+				completions = append(completions, &data.completions[i])
+			}
+		}
+		total, today := repository.CountCompletions(limit, completions)
+		if err := repository.CheckCompletionLimit(limit, total, today); err != nil {
+			return err
+		}
+	}
+	if completion.StartedAt != nil {
+		achievement.TimerStartedAt = nil
+		data.achievements[completion.AchievementID] = achievement
+	}
+	stored := *completion
+	stored.Limit = nil
+	data.completions = append(data.completions, stored)
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntryGrant, completion.Point, completion.ID))
+	if completion.BonusPoint > 0 {
+		data.addPoints(repository.NewLedgerEntry(models.LedgerEntryBonus, completion.BonusPoint, completion.ID))
+	}
+	return nil
+}
+
+// ListCompletions 達成目録の達成記録を達成日時順に取得
+func (r *AchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	if achievementID == "" {
+		return nil, &errors.ValidationError{Field: "achievement_id", Message: "achievement_id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	completions := []*models.Completion{}
+	for _, completion := range data.completions {
+		if completion.AchievementID == achievementID {
+			c := completion
+			completions = append(completions, &c)
+		}
+	}
+	sortCompletions(completions)
+	return completions, nil
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// AllowanceRepository メモリを使用したお小遣いのルールのリポジトリ
+type AllowanceRepository struct {
+	store *Store
+}
+
+// NewAllowanceRepository お小遣いのルールのリポジトリを作成
+func NewAllowanceRepository(store *Store) repository.AllowanceRepository {
+	return &AllowanceRepository{store: store}
+}
+
+// Create お小遣いのルールを作成
+func (r *AllowanceRepository) Create(ctx context.Context, allowance *models.Allowance) error {
+	if err := repository.PrepareAllowance(allowance); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.allowances[allowance.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.allowances[allowance.ID] = *allowance
+	return nil
+}
+
+// GetByID IDでお小遣いのルールを取得
+func (r *AllowanceRepository) GetByID(ctx context.Context, id string) (*models.Allowance, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	allowance, exists := data.allowances[id]
+	if !exists {
+		return nil, errors.ErrNotFound
+	}
+	return &allowance, nil
+}
+
+// List すべてのお小遣いのルールを作成日時順に取得
+func (r *AllowanceRepository) List(ctx context.Context) ([]*models.Allowance, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	allowances := make([]*models.Allowance, 0, len(data.allowances))
+	for _, allowance := range data.allowances {
+		allowance := allowance
+		allowances = append(allowances, &allowance)
+	}
+	sortAllowances(allowances)
+	return allowances, nil
+}
+
+// Delete お小遣いのルールを削除（付与済みのポイントは取り消さない）
+func (r *AllowanceRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.allowances[id]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.allowances, id)
+	return nil
+}
+
+// Grant 付与日時を記録してお小遣いのポイントを付与（削除済み・同じ分以降に付与済みの場合は errors.ErrVersionConflict）
+func (r *AllowanceRepository) Grant(ctx context.Context, id string, points int, at time.Time) error {
+	grantedAt, err := repository.PrepareAllowanceGrant(id, points, at)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	stored, exists := data.allowances[id]
+	if !exists || (stored.LastGrantedAt != nil && !stored.LastGrantedAt.Before(grantedAt)) {
+		return errors.ErrVersionConflict
+	}
+	stored.LastGrantedAt = &grantedAt
+	data.allowances[id] = stored
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntryAllowance, points, id))
+	return nil
+}
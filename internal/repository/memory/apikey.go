@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// APIKeyRepository メモリを使用したAPIキーのリポジトリ
+type APIKeyRepository struct {
+	store *Store
+}
+
+// NewAPIKeyRepository APIキーのリポジトリを作成
+func NewAPIKeyRepository(store *Store) repository.APIKeyRepository {
+	return &APIKeyRepository{store: store}
+}
+
+// Create APIキーを作成
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	if err := repository.PrepareAPIKey(key); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.apiKeys[key.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.apiKeys[key.ID] = *key
+	return nil
+}
+
+// Update APIキーのラベル・範囲・ハッシュ値・無効にした日時を更新
+func (r *APIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	if err := repository.ValidateAPIKey(key); err != nil {
+		return err
+	}
+	if key.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.apiKeys[key.ID]; !exists {
+		return errors.ErrNotFound
+	}
+	data.apiKeys[key.ID] = *key
+	return nil
+}
+
+// GetByID IDでAPIキーを取得
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	key, exists := data.apiKeys[id]
+	if !exists {
+		return nil, errors.ErrNotFound
+	}
+	return &key, nil
+}
+
+// List すべてのAPIキーを作成日時順に取得（無効にしたキーを含む）
+func (r *APIKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	keys := make([]*models.APIKey, 0, len(data.apiKeys))
+	for _, key := range data.apiKeys {
+		key := key
+		keys = append(keys, &key)
+	}
+	sortAPIKeys(keys)
+	return keys, nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestAPIKeyRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPIKeyRepository(NewStore())
+
+	key := &models.APIKey{Label: "ダッシュボード", Scope: models.APIKeyScopeRead, Hash: "5e884898"}
+	if err := repo.Create(ctx, key); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, &models.APIKey{Label: "ダッシュボード", Scope: "superuser", Hash: "5e884898"}); err == nil {
+		t.Error("Expected validation error for an unknown scope")
+	}
+
+	revokedAt := time.Now()
+	key.Scope = models.APIKeyScopeWrite
+	key.RevokedAt = &revokedAt
+	if err := repo.Update(ctx, key); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	keys, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Scope != models.APIKeyScopeWrite || !keys[0].Revoked() {
+		t.Errorf("Expected the updated key, got %+v", keys)
+	}
+
+	// 他のテナントからは見えない
+	if _, err := repo.GetByID(tenant.WithID(ctx, "acme"), key.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant, got %v", err)
+	}
+	if err := repo.Update(ctx, &models.APIKey{ID: "missing", Label: "CI", Scope: models.APIKeyScopeRead, Hash: "5e884898"}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for a missing key, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// BadgeRepository メモリを使用したバッジリポジトリ
+type BadgeRepository struct {
+	store *Store
+}
+
+// NewBadgeRepository バッジリポジトリを作成
+func NewBadgeRepository(store *Store) repository.BadgeRepository {
+	return &BadgeRepository{store: store}
+}
+
+// Award バッジの獲得を記録（獲得済みの場合は errors.ErrDuplicateResource）
+func (r *BadgeRepository) Award(ctx context.Context, badge *models.Badge) error {
+	if err := repository.ValidateBadge(badge); err != nil {
+		return err
+	}
+
+	if badge.EarnedAt.IsZero() {
+		badge.EarnedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.badges[badge.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.badges[badge.ID] = *badge
+	return nil
+}
+
+// List 獲得したバッジを獲得日時順に取得
+func (r *BadgeRepository) List(ctx context.Context) ([]*models.Badge, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	badges := make([]*models.Badge, 0, len(data.badges))
+	for _, badge := range data.badges {
+		badge := badge
+		badges = append(badges, &badge)
+	}
+	sortBadges(badges)
+	return badges, nil
+}
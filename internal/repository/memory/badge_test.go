@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestBadgeRepository_AwardAndList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBadgeRepository(NewStore())
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.Award(ctx, &models.Badge{ID: "first_redemption", Name: "First Reward", EarnedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if err := repo.Award(ctx, &models.Badge{ID: "first_achievement", Name: "First Step", EarnedAt: base}); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if err := repo.Award(ctx, &models.Badge{ID: "first_achievement", Name: "First Step"}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	badges, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(badges) != 2 || badges[0].ID != "first_achievement" || badges[1].ID != "first_redemption" {
+		t.Errorf("Expected badges in earned order, got %+v", badges)
+	}
+
+	// 他のテナントは獲得していない
+	other, err := repo.List(tenant.WithID(ctx, "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no badges for another tenant, got %d", len(other))
+	}
+}
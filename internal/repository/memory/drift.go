@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// DriftRepository メモリを使用したポイントの差異のリポジトリ
+type DriftRepository struct {
+	store *Store
+}
+
+// NewDriftRepository ポイントの差異のリポジトリを作成
+func NewDriftRepository(store *Store) repository.DriftRepository {
+	return &DriftRepository{store: store}
+}
+
+// Record 検出したポイントの差異を記録
+func (r *DriftRepository) Record(ctx context.Context, event *models.DriftEvent) error {
+	if err := repository.ValidateDriftEvent(event); err != nil {
+		return err
+	}
+
+	if event.ID == "" {
+		event.ID = ulid.Make().String()
+	}
+	if event.DetectedAt.IsZero() {
+		event.DetectedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.forWrite(ctx).driftEvents[event.ID] = *event
+	return nil
+}
+
+// List 記録したポイントの差異を検出日時順に取得
+func (r *DriftRepository) List(ctx context.Context) ([]*models.DriftEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	driftEvents := make([]*models.DriftEvent, 0, len(data.driftEvents))
+	for _, event := range data.driftEvents {
+		event := event
+		driftEvents = append(driftEvents, &event)
+	}
+	sortDriftEvents(driftEvents)
+	return driftEvents, nil
+}
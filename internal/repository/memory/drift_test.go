@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestDriftRepository_RecordAndList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewDriftRepository(NewStore())
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.Record(ctx, &models.DriftEvent{Difference: 30, DetectedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	first := &models.DriftEvent{TotalPoints: 120, CurrentBalance: 100, Difference: 20, DetectedAt: base}
+	if err := repo.Record(ctx, first); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if first.ID == "" {
+		t.Error("ID should be generated")
+	}
+
+	driftEvents, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(driftEvents) != 2 || driftEvents[0].Difference != 20 || driftEvents[1].Difference != 30 {
+		t.Errorf("Expected drift events in detected order, got %+v", driftEvents)
+	}
+
+	// 他のテナントでは記録していない
+	other, err := repo.List(tenant.WithID(ctx, "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no drift events for another tenant, got %d", len(other))
+	}
+}
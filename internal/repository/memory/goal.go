@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// GoalRepository メモリを使用した目標リポジトリ
+type GoalRepository struct {
+	store *Store
+}
+
+// NewGoalRepository 目標リポジトリを作成
+func NewGoalRepository(store *Store) repository.GoalRepository {
+	return &GoalRepository{store: store}
+}
+
+// Create 目標を作成
+func (r *GoalRepository) Create(ctx context.Context, goal *models.Goal) error {
+	if err := repository.ValidateGoal(goal); err != nil {
+		return err
+	}
+
+	if goal.ID == "" {
+		goal.ID = ulid.Make().String()
+	}
+	if goal.CreatedAt.IsZero() {
+		goal.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.goals[goal.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.goals[goal.ID] = *goal
+	return nil
+}
+
+// Update 目標を更新（達成日時を含めて上書きする）
+func (r *GoalRepository) Update(ctx context.Context, goal *models.Goal) error {
+	if err := repository.ValidateGoal(goal); err != nil {
+		return err
+	}
+	if goal.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.goals[goal.ID]; !exists {
+		return errors.ErrNotFound
+	}
+	data.goals[goal.ID] = *goal
+	return nil
+}
+
+// GetByID IDで目標を取得
+func (r *GoalRepository) GetByID(ctx context.Context, id string) (*models.Goal, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	goal, exists := data.goals[id]
+	if !exists {
+		return nil, errors.ErrNotFound
+	}
+	return &goal, nil
+}
+
+// List すべての目標を作成日時順に取得
+func (r *GoalRepository) List(ctx context.Context) ([]*models.Goal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	goals := make([]*models.Goal, 0, len(data.goals))
+	for _, goal := range data.goals {
+		goal := goal
+		goals = append(goals, &goal)
+	}
+	sortGoals(goals)
+	return goals, nil
+}
+
+// Delete 目標を削除
+func (r *GoalRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.goals[id]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.goals, id)
+	return nil
+}
+
+// MarkReached 目標の達成日時を記録（削除済み・達成済みの場合は errors.ErrVersionConflict）
+func (r *GoalRepository) MarkReached(ctx context.Context, id string, reachedAt time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	goal, exists := data.goals[id]
+	if !exists || goal.ReachedAt != nil {
+		return errors.ErrVersionConflict
+	}
+	goal.ReachedAt = &reachedAt
+	data.goals[id] = goal
+	return nil
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// NoteRepository メモリを使用した記録に付けるメモのリポジトリ
+type NoteRepository struct {
+	store *Store
+}
+
+// NewNoteRepository 記録に付けるメモのリポジトリを作成
+func NewNoteRepository(store *Store) repository.NoteRepository {
+	return &NoteRepository{store: store}
+}
+
+// Create メモを作成
+func (r *NoteRepository) Create(ctx context.Context, note *models.Note) error {
+	if err := repository.ValidateNote(note); err != nil {
+		return err
+	}
+
+	if note.ID == "" {
+		note.ID = ulid.Make().String()
+	}
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.notes[note.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.notes[note.ID] = *note
+	return nil
+}
+
+// GetByID IDでメモを取得
+func (r *NoteRepository) GetByID(ctx context.Context, id string) (*models.Note, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	note, exists := data.notes[id]
+	if !exists {
+		return nil, errors.ErrNotFound
+	}
+	return &note, nil
+}
+
+// ListByTarget 記録に付けたメモを作成日時順に取得
+func (r *NoteRepository) ListByTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error) {
+	if targetID == "" {
+		return nil, &errors.ValidationError{Field: "target_id", Message: "target_id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	notes := []*models.Note{}
+	for _, note := range data.notes {
+		if note.TargetType != targetType || note.TargetID != targetID {
+			continue
+		}
+		note := note
+		notes = append(notes, &note)
+	}
+	sortNotes(notes)
+	return notes, nil
+}
+
+// Delete メモを削除
+func (r *NoteRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.notes[id]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.notes, id)
+	return nil
+}
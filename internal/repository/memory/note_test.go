@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestNoteRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNoteRepository(NewStore())
+
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	notes := []*models.Note{
+		{TargetType: models.NoteTargetRedemption, TargetID: "history-1", Body: "お店を予約した", CreatedAt: base.Add(time.Hour)},
+		{TargetType: models.NoteTargetRedemption, TargetID: "history-1", Body: "誕生日のディナーに使った", CreatedAt: base},
+		// 同じIDでも種類の異なる記録のメモは含めない
+		{TargetType: models.NoteTargetReward, TargetID: "history-1", Body: "別の記録", CreatedAt: base},
+	}
+	for _, note := range notes {
+		if err := repo.Create(ctx, note); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	listed, err := repo.ListByTarget(ctx, models.NoteTargetRedemption, "history-1")
+	if err != nil {
+		t.Fatalf("ListByTarget failed: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Body != "誕生日のディナーに使った" || listed[1].Body != "お店を予約した" {
+		t.Errorf("Expected the redemption notes in creation order, got %+v", listed)
+	}
+
+	// 他のテナントからは見えない
+	if _, err := repo.GetByID(tenant.WithID(ctx, "acme"), notes[0].ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, notes[0].ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, notes[0].ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// OperationRepository メモリを使用した操作履歴のリポジトリ
+type OperationRepository struct {
+	store *Store
+}
+
+// NewOperationRepository 操作履歴のリポジトリを作成
+func NewOperationRepository(store *Store) repository.OperationRepository {
+	return &OperationRepository{store: store}
+}
+
+// Record 操作を記録
+func (r *OperationRepository) Record(ctx context.Context, operation *models.Operation) error {
+	if err := repository.PrepareOperation(operation); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.operations[operation.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.operations[operation.ID] = *operation
+	return nil
+}
+
+// List 操作履歴を記録した順に取得
+func (r *OperationRepository) List(ctx context.Context) ([]*models.Operation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	operations := make([]*models.Operation, 0, len(data.operations))
+	for _, operation := range data.operations {
+		operation := operation
+		operations = append(operations, &operation)
+	}
+	sortOperations(operations)
+	return operations, nil
+}
+
+// SetUndone 操作を元に戻した日時を記録（nil の場合は消去する。記録されていない場合は errors.ErrNotFound）
+func (r *OperationRepository) SetUndone(ctx context.Context, id string, undoneAt *time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	operation, exists := data.operations[id]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	operation.UndoneAt = undoneAt
+	data.operations[id] = operation
+	return nil
+}
+
+// Delete 操作を操作履歴から削除（記録されていない場合も成功する）
+func (r *OperationRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	delete(r.store.forWrite(ctx).operations, id)
+	return nil
+}
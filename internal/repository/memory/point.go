@@ -0,0 +1,358 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// currentPointsID 現在のポイントのID
+const currentPointsID = "current"
+
+// PointRepository メモリを使用したポイントリポジトリ
+type PointRepository struct {
+	store *Store
+}
+
+// NewPointRepository ポイントリポジトリを作成
+func NewPointRepository(store *Store) repository.PointRepository {
+	return &PointRepository{store: store}
+}
+
+// GetCurrentPoints 現在のポイントを取得
+func (r *PointRepository) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	if data.currentPoints == nil {
+		// 初回の場合は0ポイントで初期化
+		return &models.CurrentPoints{
+			ID:        currentPointsID,
+			Point:     0,
+			UpdatedAt: time.Now(),
+		}, nil
+	}
+
+	points := *data.currentPoints
+	points.Reserved = data.reserved("")
+	return &points, nil
+}
+
+// GetCurrentPointsConsistent 現在のポイントを取得（メモリの読み取りは常に最新の値を返す）
+func (r *PointRepository) GetCurrentPointsConsistent(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.GetCurrentPoints(ctx)
+}
+
+// UpdateCurrentPoints 現在のポイントを指定した値に修正し、差分を台帳に記録
+func (r *PointRepository) UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) error {
+	if points == nil {
+		return &errors.ValidationError{Field: "points", Message: "points cannot be nil"}
+	}
+
+	points.ID = currentPointsID
+	points.UpdatedAt = time.Now()
+
+	if points.Point < 0 {
+		return &errors.ValidationError{Field: "point", Message: "point cannot be negative"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if delta := points.Point - data.balance(); delta != 0 {
+		data.appendLedger(repository.NewLedgerEntry(models.LedgerEntryAdjustment, delta, ""))
+	}
+	data.putCurrentPoints(points)
+	return nil
+}
+
+// CreateRewardHistory 報酬獲得履歴を作成
+func (r *PointRepository) CreateRewardHistory(ctx context.Context, history *models.RewardHistory) error {
+	if history == nil {
+		return &errors.ValidationError{Field: "history", Message: "history cannot be nil"}
+	}
+
+	if err := repository.ValidateRewardHistory(history); err != nil {
+		return err
+	}
+
+	prepareRewardHistory(history)
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if err := data.insertRewardHistory(history); err != nil {
+		return &errors.DatabaseError{Operation: "CreateRewardHistory", Table: rewardHistoryTable, Cause: err}
+	}
+	return nil
+}
+
+// GetRewardHistory 報酬獲得履歴を獲得日時順に取得
+func (r *PointRepository) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
+	return r.GetRewardHistoryBetween(ctx, time.Time{}, time.Time{})
+}
+
+// GetRewardHistoryBetween 獲得日時が from 以上 to 未満の報酬獲得履歴を獲得日時順に取得（ゼロ値の側は制限しない）
+func (r *PointRepository) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	if err := repository.ValidateRewardHistoryRange(from, to); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	history := make([]*models.RewardHistory, 0, len(data.rewardHistory))
+	for _, item := range data.rewardHistory {
+		if (!from.IsZero() && item.RedeemedAt.Before(from)) || (!to.IsZero() && !item.RedeemedAt.Before(to)) {
+			continue
+		}
+		item := item
+		history = append(history, &item)
+	}
+	sortRewardHistory(history)
+	return history, nil
+}
+
+// CountRewardHistory 報酬獲得履歴の件数を取得
+func (r *PointRepository) CountRewardHistory(ctx context.Context) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return len(r.store.forRead(ctx).rewardHistory), nil
+}
+
+// RedeemPoints 報酬獲得のポイント減算・台帳への記録・履歴記録を1つのロック内でまとめて実行
+//
+// 他の報酬のために取り置いたポイントは使えず、獲得する報酬の取り置きは同じロック内で解除する。
+func (r *PointRepository) RedeemPoints(ctx context.Context, history *models.RewardHistory) error {
+	if history == nil {
+		return &errors.ValidationError{Field: "history", Message: "history cannot be nil"}
+	}
+
+	if err := repository.ValidateRewardHistory(history); err != nil {
+		return err
+	}
+
+	prepareRewardHistory(history)
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	// 他の報酬のために取り置いたポイントは使えない
+	if data.balance()-data.reserved(history.RewardID) < history.PointCost {
+		return errors.ErrInsufficientPoints
+	}
+	// 履歴を書き込めない場合はポイントも減算しない
+	if err := data.insertRewardHistory(history); err != nil {
+		return &errors.DatabaseError{
+			Operation: "RedeemPoints",
+			Table:     fmt.Sprintf("%s,%s,%s", currentPointsTable, pointLedgerTable, rewardHistoryTable),
+			Cause:     err,
+		}
+	}
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntrySpend, -history.PointCost, history.ID))
+	delete(data.reservations, history.RewardID)
+	return nil
+}
+
+// GetRewardHistoryByID IDで報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryByID(ctx context.Context, id string) (*models.RewardHistory, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	history, ok := r.store.forRead(ctx).rewardHistory[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return &history, nil
+}
+
+// RefundRedemption 報酬獲得の取り消し・ポイントの返還・台帳への記録を1つのロック内でまとめて実行
+//
+// 取り消し済みまたは削除済みの履歴の場合は errors.ErrVersionConflict を返す。
+func (r *PointRepository) RefundRedemption(ctx context.Context, history *models.RewardHistory) error {
+	if err := repository.PrepareRefund(history); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	stored, ok := data.rewardHistory[history.ID]
+	if !ok || stored.RefundedAt != nil {
+		return errors.ErrVersionConflict
+	}
+	refundedAt := *history.RefundedAt
+	stored.RefundedAt = &refundedAt
+	data.rewardHistory[history.ID] = stored
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntryRefund, history.PointCost, history.ID))
+	return nil
+}
+
+// SetRedemptionAttachment 報酬獲得履歴に添付ファイルのオブジェクトキーを保存
+func (r *PointRepository) SetRedemptionAttachment(ctx context.Context, id, key string) error {
+	if err := repository.ValidateAttachment(id, key); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	history, exists := data.rewardHistory[id]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	history.AttachmentKey = key
+	data.rewardHistory[id] = history
+	return nil
+}
+
+// AnnotateRedemption 報酬獲得履歴の注記とタグを置き換える（ポイント・獲得日時などは変更しない）
+func (r *PointRepository) AnnotateRedemption(ctx context.Context, id, note string, tags []string) error {
+	if err := repository.ValidateRedemptionAnnotation(id, tags); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	history, exists := data.rewardHistory[id]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	history.Note = note
+	history.Tags = append([]string(nil), tags...)
+	data.rewardHistory[id] = history
+	return nil
+}
+
+// GetLedger ポイント台帳を記録順に取得
+func (r *PointRepository) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	return r.GetLedgerBetween(ctx, time.Time{}, time.Time{})
+}
+
+// GetLedgerBetween 記録日時が from 以上 to 未満のポイント台帳のエントリを記録順に取得（ゼロ値の側は制限しない）
+func (r *PointRepository) GetLedgerBetween(ctx context.Context, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	if err := repository.ValidateLedgerRange(from, to); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	entries := make([]*models.PointLedgerEntry, 0, len(data.pointLedger))
+	for _, entry := range data.pointLedger {
+		if (!from.IsZero() && entry.CreatedAt.Before(from)) || (!to.IsZero() && !entry.CreatedAt.Before(to)) {
+			continue
+		}
+		entry := entry
+		entries = append(entries, &entry)
+	}
+	sortLedger(entries)
+	return entries, nil
+}
+
+// AddPoints ポイントを加算（達成目録追加時に使用）
+func (r *PointRepository) AddPoints(ctx context.Context, points int) error {
+	if points <= 0 {
+		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntryGrant, points, ""))
+	return nil
+}
+
+// SubtractPoints ポイントを減算（報酬獲得時に使用）
+func (r *PointRepository) SubtractPoints(ctx context.Context, points int) error {
+	if points <= 0 {
+		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	// 残高不足または未作成（0ポイント）の場合
+	if data.balance() < points {
+		return errors.ErrInsufficientPoints
+	}
+	data.addPoints(repository.NewLedgerEntry(models.LedgerEntrySpend, -points, ""))
+	return nil
+}
+
+// balance 現在の残高（呼び出し側でロックを取得すること）
+func (p *partition) balance() int {
+	if p.currentPoints == nil {
+		return 0
+	}
+	return p.currentPoints.Point
+}
+
+// reserved except 以外の報酬のために取り置いたポイントの合計（呼び出し側でロックを取得すること）
+func (p *partition) reserved(except string) int {
+	total := 0
+	for rewardID, reservation := range p.reservations {
+		if rewardID != except {
+			total += reservation.Points
+		}
+	}
+	return total
+}
+
+// addPoints 台帳にエントリを追記し、残高に反映（呼び出し側でロックを取得すること）
+func (p *partition) addPoints(entry *models.PointLedgerEntry) {
+	p.appendLedger(entry)
+	p.putCurrentPoints(&models.CurrentPoints{ID: currentPointsID, Point: p.balance() + entry.Amount, UpdatedAt: entry.CreatedAt})
+}
+
+// appendLedger 台帳にエントリを追記（呼び出し側でロックを取得すること）
+func (p *partition) appendLedger(entry *models.PointLedgerEntry) {
+	p.pointLedger = append(p.pointLedger, *entry)
+}
+
+// putCurrentPoints 現在のポイントを書き込み（呼び出し側でロックを取得すること）
+func (p *partition) putCurrentPoints(points *models.CurrentPoints) {
+	stored := *points
+	p.currentPoints = &stored
+}
+
+// insertRewardHistory 報酬獲得履歴を書き込み（呼び出し側でロックを取得すること）
+func (p *partition) insertRewardHistory(history *models.RewardHistory) error {
+	if _, exists := p.rewardHistory[history.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	p.rewardHistory[history.ID] = *history
+	return nil
+}
+
+// prepareRewardHistory IDと獲得日時が未設定の場合に設定
+func prepareRewardHistory(history *models.RewardHistory) {
+	if history.ID == "" {
+		history.ID = ulid.Make().String()
+	}
+	if history.RedeemedAt.IsZero() {
+		history.RedeemedAt = time.Now()
+	}
+}
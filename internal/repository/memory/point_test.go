@@ -0,0 +1,339 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+func TestPointRepository_AddAndSubtractPoints(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	// 初回は0ポイント
+	points, err := repo.GetCurrentPoints(ctx)
+	if err != nil || points.Point != 0 {
+		t.Fatalf("Expected 0 points initially, got %v (%v)", points, err)
+	}
+
+	// 行が無い状態での減算は残高不足
+	if err := repo.SubtractPoints(ctx, 1); err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+
+	if err := repo.AddPoints(ctx, 30); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	if err := repo.AddPoints(ctx, 20); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	if err := repo.SubtractPoints(ctx, 40); err != nil {
+		t.Fatalf("SubtractPoints failed: %v", err)
+	}
+	if err := repo.SubtractPoints(ctx, 11); err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+
+	points, _ = repo.GetCurrentPoints(ctx)
+	if points.Point != 10 {
+		t.Errorf("Expected 10 points, got %d", points.Point)
+	}
+}
+
+func TestPointRepository_UpdateCurrentPoints(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	if err := repo.UpdateCurrentPoints(ctx, &models.CurrentPoints{Point: -1}); err == nil {
+		t.Error("Expected validation error for negative points")
+	}
+
+	if err := repo.UpdateCurrentPoints(ctx, &models.CurrentPoints{Point: 42}); err != nil {
+		t.Fatalf("UpdateCurrentPoints failed: %v", err)
+	}
+	points, _ := repo.GetCurrentPoints(ctx)
+	if points.ID != "current" || points.Point != 42 {
+		t.Errorf("Unexpected points: %+v", points)
+	}
+}
+
+func TestPointRepository_RewardHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	later := &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 100, RedeemedAt: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)}
+	if err := repo.CreateRewardHistory(ctx, later); err != nil {
+		t.Fatalf("CreateRewardHistory failed: %v", err)
+	}
+
+	if err := repo.AddPoints(ctx, 57); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	earlier := &models.RewardHistory{RewardID: "r2", RewardTitle: "ケーキ", PointCost: 50, RedeemedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	if err := repo.RedeemPoints(ctx, earlier); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+
+	history, err := repo.GetRewardHistory(ctx)
+	if err != nil {
+		t.Fatalf("GetRewardHistory failed: %v", err)
+	}
+	if len(history) != 2 || history[0].RewardID != "r2" || history[1].RewardID != "r1" {
+		t.Errorf("Expected history ordered by redeemed_at, got %v", history)
+	}
+
+	points, _ := repo.GetCurrentPoints(ctx)
+	if points.Point != 7 {
+		t.Errorf("Expected 7 points after transaction, got %d", points.Point)
+	}
+
+	// 同じIDの履歴は書き込めず、ポイントも減算されない
+	duplicate := &models.RewardHistory{ID: earlier.ID, RewardID: "r3", RewardTitle: "本", PointCost: 5}
+	if err := repo.RedeemPoints(ctx, duplicate); err == nil {
+		t.Fatal("Expected error for duplicate history ID")
+	}
+	points, _ = repo.GetCurrentPoints(ctx)
+	if points.Point != 7 {
+		t.Errorf("Expected points update to be rolled back, got %d", points.Point)
+	}
+
+	if err := repo.CreateRewardHistory(ctx, &models.RewardHistory{RewardID: "r1"}); err == nil {
+		t.Error("Expected validation error for history without title")
+	}
+}
+
+func TestPointRepository_GetRewardHistoryBetween(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	jst := time.FixedZone("JST", 9*60*60)
+	for _, history := range []*models.RewardHistory{
+		{RewardID: "before", RewardTitle: "コーヒー券", PointCost: 10, RedeemedAt: time.Date(2024, 5, 31, 23, 59, 59, 0, time.UTC)},
+		{RewardID: "start", RewardTitle: "ケーキ", PointCost: 10, RedeemedAt: time.Date(2024, 6, 1, 9, 0, 0, 0, jst)},
+		{RewardID: "middle", RewardTitle: "本", PointCost: 10, RedeemedAt: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{RewardID: "end", RewardTitle: "映画", PointCost: 10, RedeemedAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		if err := repo.CreateRewardHistory(ctx, history); err != nil {
+			t.Fatalf("CreateRewardHistory failed: %v", err)
+		}
+	}
+
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// from は含み、to は含まない
+	history, err := repo.GetRewardHistoryBetween(ctx, from, to)
+	if err != nil {
+		t.Fatalf("GetRewardHistoryBetween failed: %v", err)
+	}
+	if len(history) != 2 || history[0].RewardID != "start" || history[1].RewardID != "middle" {
+		t.Errorf("Unexpected history in range: %v", history)
+	}
+
+	// 片側のみの指定
+	history, _ = repo.GetRewardHistoryBetween(ctx, from, time.Time{})
+	if len(history) != 3 {
+		t.Errorf("Expected 3 records from %s, got %d", from, len(history))
+	}
+	history, _ = repo.GetRewardHistoryBetween(ctx, time.Time{}, from)
+	if len(history) != 1 || history[0].RewardID != "before" {
+		t.Errorf("Unexpected history before %s: %v", from, history)
+	}
+
+	if _, err := repo.GetRewardHistoryBetween(ctx, to, from); err == nil {
+		t.Error("Expected validation error for to before from")
+	}
+}
+
+func TestPointRepository_Ledger(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	if err := repo.AddPoints(ctx, 100); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	history := &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 30}
+	if err := repo.RedeemPoints(ctx, history); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+	if err := repo.UpdateCurrentPoints(ctx, &models.CurrentPoints{Point: 50}); err != nil {
+		t.Fatalf("UpdateCurrentPoints failed: %v", err)
+	}
+	// 残高が変わらない修正は台帳に記録しない
+	if err := repo.UpdateCurrentPoints(ctx, &models.CurrentPoints{Point: 50}); err != nil {
+		t.Fatalf("UpdateCurrentPoints failed: %v", err)
+	}
+	// 残高不足の場合は台帳に記録しない
+	if err := repo.RedeemPoints(ctx, &models.RewardHistory{RewardID: "r2", RewardTitle: "ケーキ", PointCost: 500}); err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+
+	entries, err := repo.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+
+	// 記録日時の範囲で絞り込む
+	now := time.Now()
+	if between, err := repo.GetLedgerBetween(ctx, now.Add(-time.Hour), now.Add(time.Hour)); err != nil || len(between) != len(entries) {
+		t.Errorf("Expected every entry within the hour, got %d (%v)", len(between), err)
+	}
+	if between, err := repo.GetLedgerBetween(ctx, now.Add(time.Hour), now.Add(2*time.Hour)); err != nil || len(between) != 0 {
+		t.Errorf("Expected no entries in a later range, got %d (%v)", len(between), err)
+	}
+	if _, err := repo.GetLedgerBetween(ctx, now, now); err == nil {
+		t.Error("Expected an error for an empty range")
+	}
+	expected := []struct {
+		entryType string
+		amount    int
+	}{
+		{models.LedgerEntryGrant, 100},
+		{models.LedgerEntrySpend, -30},
+		{models.LedgerEntryAdjustment, -20},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d ledger entries, got %d", len(expected), len(entries))
+	}
+	sum := 0
+	for i, entry := range entries {
+		if entry.Type != expected[i].entryType || entry.Amount != expected[i].amount {
+			t.Errorf("Entry %d: expected %s %d, got %s %d", i, expected[i].entryType, expected[i].amount, entry.Type, entry.Amount)
+		}
+		sum += entry.Amount
+	}
+	if entries[1].Reference != history.ID {
+		t.Errorf("Expected spend entry to reference history %s, got %q", history.ID, entries[1].Reference)
+	}
+
+	// 台帳の合計は残高と一致する
+	points, _ := repo.GetCurrentPoints(ctx)
+	if sum != points.Point {
+		t.Errorf("Expected ledger sum %d to match balance %d", sum, points.Point)
+	}
+}
+
+func TestPointRepository_RefundRedemption(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	if err := repo.AddPoints(ctx, 100); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	history := &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 30, RedeemedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	if err := repo.RedeemPoints(ctx, history); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+
+	stored, err := repo.GetRewardHistoryByID(ctx, history.ID)
+	if err != nil {
+		t.Fatalf("GetRewardHistoryByID failed: %v", err)
+	}
+	if stored.RefundedAt != nil || stored.PointCost != 30 {
+		t.Errorf("Unexpected history: %+v", stored)
+	}
+
+	refundedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stored.RefundedAt = &refundedAt
+	if err := repo.RefundRedemption(ctx, stored); err != nil {
+		t.Fatalf("RefundRedemption failed: %v", err)
+	}
+
+	points, _ := repo.GetCurrentPoints(ctx)
+	if points.Point != 100 {
+		t.Errorf("Expected points to be restored to 100, got %d", points.Point)
+	}
+	refunded, _ := repo.GetRewardHistoryByID(ctx, history.ID)
+	if refunded.RefundedAt == nil || !refunded.RefundedAt.Equal(refundedAt) {
+		t.Errorf("Expected refunded_at %v, got %v", refundedAt, refunded.RefundedAt)
+	}
+	entries, _ := repo.GetLedger(ctx)
+	if len(entries) != 3 || entries[2].Type != models.LedgerEntryRefund || entries[2].Amount != 30 || entries[2].Reference != history.ID {
+		t.Errorf("Expected refund ledger entry referencing %s, got %+v", history.ID, entries[len(entries)-1])
+	}
+
+	// 取り消し済みの履歴は再度取り消せず、ポイントも返還しない
+	if err := repo.RefundRedemption(ctx, refunded); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+	points, _ = repo.GetCurrentPoints(ctx)
+	if points.Point != 100 {
+		t.Errorf("Expected points to stay 100, got %d", points.Point)
+	}
+
+	if err := repo.RefundRedemption(ctx, &models.RewardHistory{ID: "missing", PointCost: 10}); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict for missing history, got %v", err)
+	}
+	if _, err := repo.GetRewardHistoryByID(ctx, "missing"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPointRepository_SetRedemptionAttachment(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	repo.AddPoints(ctx, 100)
+	history := &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 30, RedeemedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	if err := repo.RedeemPoints(ctx, history); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+
+	if err := repo.SetRedemptionAttachment(ctx, history.ID, "attachments/default/redemption/receipt.pdf"); err != nil {
+		t.Fatalf("SetRedemptionAttachment failed: %v", err)
+	}
+	stored, _ := repo.GetRewardHistoryByID(ctx, history.ID)
+	if stored.AttachmentKey != "attachments/default/redemption/receipt.pdf" {
+		t.Errorf("Unexpected attachment key: %q", stored.AttachmentKey)
+	}
+	all, _ := repo.GetRewardHistory(ctx)
+	if len(all) != 1 || all[0].AttachmentKey != stored.AttachmentKey {
+		t.Errorf("Expected attachment key in history, got %+v", all)
+	}
+
+	if err := repo.SetRedemptionAttachment(ctx, "missing", "attachments/x.pdf"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPointRepository_AnnotateRedemption(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPointRepository(NewStore())
+
+	repo.AddPoints(ctx, 100)
+	history := &models.RewardHistory{RewardID: "r1", RewardTitle: "コーヒー券", PointCost: 30, RedeemedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	if err := repo.RedeemPoints(ctx, history); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+
+	if err := repo.AnnotateRedemption(ctx, history.ID, "友達と映画", []string{"family", "weekend"}); err != nil {
+		t.Fatalf("AnnotateRedemption failed: %v", err)
+	}
+	stored, _ := repo.GetRewardHistoryByID(ctx, history.ID)
+	if stored.Note != "友達と映画" || len(stored.Tags) != 2 || stored.Tags[1] != "weekend" {
+		t.Errorf("Unexpected annotation: note=%q tags=%v", stored.Note, stored.Tags)
+	}
+	if stored.PointCost != 30 {
+		t.Errorf("Expected point cost to be unchanged, got %d", stored.PointCost)
+	}
+	all, _ := repo.GetRewardHistory(ctx)
+	if len(all) != 1 || all[0].Note != stored.Note {
+		t.Errorf("Expected note in history, got %+v", all)
+	}
+
+	// 空のメモとタグで注釈を消せる
+	if err := repo.AnnotateRedemption(ctx, history.ID, "", nil); err != nil {
+		t.Fatalf("AnnotateRedemption failed: %v", err)
+	}
+	stored, _ = repo.GetRewardHistoryByID(ctx, history.ID)
+	if stored.Note != "" || len(stored.Tags) != 0 {
+		t.Errorf("Expected annotation to be cleared, got note=%q tags=%v", stored.Note, stored.Tags)
+	}
+
+	if err := repo.AnnotateRedemption(ctx, "missing", "note", nil); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
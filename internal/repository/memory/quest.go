@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// QuestRepository メモリを使用したクエストリポジトリ
+type QuestRepository struct {
+	store *Store
+}
+
+// NewQuestRepository クエストリポジトリを作成
+func NewQuestRepository(store *Store) repository.QuestRepository {
+	return &QuestRepository{store: store}
+}
+
+// Create クエストを作成
+func (r *QuestRepository) Create(ctx context.Context, quest *models.Quest) error {
+	if err := repository.ValidateQuest(quest); err != nil {
+		return err
+	}
+
+	if quest.ID == "" {
+		quest.ID = ulid.Make().String()
+	}
+	if quest.CreatedAt.IsZero() {
+		quest.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.quests[quest.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.quests[quest.ID] = *quest
+	return nil
+}
+
+// GetByID IDでクエストを取得
+func (r *QuestRepository) GetByID(ctx context.Context, id string) (*models.Quest, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	quest, exists := data.quests[id]
+	if !exists {
+		return nil, errors.ErrNotFound
+	}
+	return &quest, nil
+}
+
+// List すべてのクエストを作成日時順に取得
+func (r *QuestRepository) List(ctx context.Context) ([]*models.Quest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	quests := make([]*models.Quest, 0, len(data.quests))
+	for _, quest := range data.quests {
+		quest := quest
+		quests = append(quests, &quest)
+	}
+	sortQuests(quests)
+	return quests, nil
+}
+
+// Delete クエストを削除（完了時に付与したボーナスは取り消さない）
+func (r *QuestRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.quests[id]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.quests, id)
+	return nil
+}
+
+// Complete クエストの完了日時を記録してボーナスのポイントを付与（削除済み・完了済みの場合は errors.ErrVersionConflict）
+func (r *QuestRepository) Complete(ctx context.Context, quest *models.Quest) error {
+	if err := repository.PrepareQuestCompletion(quest); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	stored, exists := data.quests[quest.ID]
+	if !exists || stored.CompletedAt != nil {
+		return errors.ErrVersionConflict
+	}
+	stored.CompletedAt = quest.CompletedAt
+	data.quests[quest.ID] = stored
+	if quest.BonusPoint > 0 {
+		data.addPoints(repository.NewLedgerEntry(models.LedgerEntryBonus, quest.BonusPoint, quest.ID))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// ReservationRepository メモリを使用したポイントの取り置きリポジトリ
+type ReservationRepository struct {
+	store *Store
+}
+
+// NewReservationRepository ポイントの取り置きリポジトリを作成
+func NewReservationRepository(store *Store) repository.ReservationRepository {
+	return &ReservationRepository{store: store}
+}
+
+// Put 報酬のためにポイントを取り置く（取り置き済みの場合は取り置くポイントを置き換える）
+//
+// 取り置いていないポイントが足りない場合は errors.ErrInsufficientPoints を返す。
+func (r *ReservationRepository) Put(ctx context.Context, reservation *models.Reservation) error {
+	if err := repository.ValidateReservation(reservation); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if reservation.CreatedAt.IsZero() {
+		reservation.CreatedAt = now
+	}
+	reservation.UpdatedAt = now
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if data.reserved(reservation.RewardID)+reservation.Points > data.balance() {
+		return errors.ErrInsufficientPoints
+	}
+	data.reservations[reservation.RewardID] = *reservation
+	return nil
+}
+
+// Remove 取り置きを解除（取り置いていない場合は errors.ErrNotFound）
+func (r *ReservationRepository) Remove(ctx context.Context, rewardID string) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.reservations[rewardID]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.reservations, rewardID)
+	return nil
+}
+
+// List 取り置きを取り置いた日時順に取得
+func (r *ReservationRepository) List(ctx context.Context) ([]*models.Reservation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	reservations := make([]*models.Reservation, 0, len(data.reservations))
+	for _, reservation := range data.reservations {
+		reservation := reservation
+		reservations = append(reservations, &reservation)
+	}
+	sortReservations(reservations)
+	return reservations, nil
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestReservationRepository_PutListRemove(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewReservationRepository(store)
+	if err := NewPointRepository(store).UpdateCurrentPoints(ctx, &models.CurrentPoints{Point: 1000}); err != nil {
+		t.Fatalf("UpdateCurrentPoints failed: %v", err)
+	}
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "coffee", Points: 50, CreatedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "switch", Points: 300, CreatedAt: base}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// 取り置き済みの報酬は取り置くポイントを置き換える
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "switch", Points: 500, CreatedAt: base}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	reservations, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(reservations) != 2 || reservations[0].RewardID != "switch" || reservations[0].Points != 500 || reservations[1].RewardID != "coffee" {
+		t.Fatalf("Expected reservations in reserved order, got %+v", reservations)
+	}
+	if !reservations[0].CreatedAt.Equal(base) || reservations[0].UpdatedAt.IsZero() {
+		t.Errorf("Unexpected reservation times: %+v", reservations[0])
+	}
+
+	// 他のテナントでは取り置いていない
+	other, err := repo.List(tenant.WithID(ctx, "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no reservations for another tenant, got %d", len(other))
+	}
+
+	if err := repo.Remove(ctx, "switch"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := repo.Remove(ctx, "switch"); !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestReservationRepository_ReservedPoints(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewReservationRepository(store)
+	points := NewPointRepository(store)
+	if err := points.UpdateCurrentPoints(ctx, &models.CurrentPoints{Point: 500}); err != nil {
+		t.Fatalf("UpdateCurrentPoints failed: %v", err)
+	}
+
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "switch", Points: 300}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "coffee", Points: 100}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// 取り置いていないポイント（100）を超えては取り置けない
+	if err := repo.Put(ctx, &models.Reservation{RewardID: "book", Points: 150}); !stderrors.Is(err, errors.ErrInsufficientPoints) {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+	current, err := points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Point != 500 || current.Reserved != 400 {
+		t.Errorf("Expected 500 points with 400 reserved, got %+v", current)
+	}
+
+	// 他の報酬のために取り置いたポイントは獲得に使えない
+	err = points.RedeemPoints(ctx, &models.RewardHistory{RewardID: "book", RewardTitle: "Book", PointCost: 150})
+	if !stderrors.Is(err, errors.ErrInsufficientPoints) {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+
+	// 取り置いた報酬の獲得には取り置いたポイントを使い、取り置きを解除する
+	if err := points.RedeemPoints(ctx, &models.RewardHistory{RewardID: "switch", RewardTitle: "Switch", PointCost: 350}); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+	current, err = points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Point != 150 || current.Reserved != 100 {
+		t.Errorf("Expected 150 points with 100 reserved, got %+v", current)
+	}
+	reservations, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(reservations) != 1 || reservations[0].RewardID != "coffee" {
+		t.Errorf("Expected only the coffee reservation, got %+v", reservations)
+	}
+
+	// 解除すると合計から除く
+	if err := repo.Remove(ctx, "coffee"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	current, err = points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Reserved != 0 {
+		t.Errorf("Expected nothing reserved, got %d", current.Reserved)
+	}
+}
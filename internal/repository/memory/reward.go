@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// RewardRepository メモリを使用した報酬リポジトリ
+type RewardRepository struct {
+	store *Store
+}
+
+// NewRewardRepository 報酬リポジトリを作成
+func NewRewardRepository(store *Store) repository.RewardRepository {
+	return &RewardRepository{store: store}
+}
+
+// Create 報酬を作成
+func (r *RewardRepository) Create(ctx context.Context, reward *models.Reward) error {
+	if reward == nil {
+		return &errors.ValidationError{Field: "reward", Message: "reward cannot be nil"}
+	}
+
+	if err := repository.ValidateReward(reward); err != nil {
+		return err
+	}
+
+	if reward.ID == "" {
+		reward.ID = ulid.Make().String()
+	}
+	if reward.CreatedAt.IsZero() {
+		reward.CreatedAt = time.Now()
+	}
+	reward.Version = 1
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.rewards[reward.ID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.rewards[reward.ID] = storedReward(reward)
+	return nil
+}
+
+// Update 報酬を更新
+func (r *RewardRepository) Update(ctx context.Context, reward *models.Reward) error {
+	if reward == nil {
+		return &errors.ValidationError{Field: "reward", Message: "reward cannot be nil"}
+	}
+
+	if reward.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	if err := repository.ValidateReward(reward); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	existing, exists := data.rewards[reward.ID]
+	if !exists {
+		return errors.ErrNotFound
+	}
+
+	// バージョンが指定されている場合は保存済みのバージョンと一致する場合のみ更新する
+	if reward.Version != 0 && reward.Version != existing.Version {
+		return errors.ErrVersionConflict
+	}
+
+	// 作成日時は元の値を保持
+	reward.CreatedAt = existing.CreatedAt
+	reward.Version = existing.Version + 1
+	data.rewards[reward.ID] = storedReward(reward)
+	return nil
+}
+
+// GetByID IDで報酬を取得
+func (r *RewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	reward, exists := data.rewards[id]
+	if !exists {
+		return nil, errors.ErrNotFound
+	}
+	return &reward, nil
+}
+
+// GetByIDs 複数のIDの報酬をまとめて取得（存在しないIDは結果に含まれない）
+func (r *RewardRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	for _, id := range ids {
+		if id == "" {
+			return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+		}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	rewards := []*models.Reward{}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		reward, exists := data.rewards[id]
+		if !exists || seen[id] {
+			continue
+		}
+		seen[id] = true
+		rewards = append(rewards, &reward)
+	}
+	return rewards, nil
+}
+
+// List すべての報酬を作成日時順に取得
+func (r *RewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	rewards := make([]*models.Reward, 0, len(data.rewards))
+	for _, reward := range data.rewards {
+		reward := reward
+		rewards = append(rewards, &reward)
+	}
+	sortRewards(rewards)
+	return rewards, nil
+}
+
+// Count 報酬の件数を取得
+func (r *RewardRepository) Count(ctx context.Context) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return len(r.store.forRead(ctx).rewards), nil
+}
+
+// Delete 報酬を削除
+func (r *RewardRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.rewards[id]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.rewards, id)
+	return nil
+}
+
+// storedReward 保存する報酬（呼び出し元が景品を変更しても保存した値が変わらないようにコピーする）
+func storedReward(reward *models.Reward) models.Reward {
+	stored := *reward
+	stored.Prizes = append([]models.RafflePrize(nil), reward.Prizes...)
+	return stored
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+func TestRewardRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRewardRepository(NewStore())
+
+	reward := &models.Reward{Title: "コーヒー券", Point: 100}
+	if err := repo.Create(ctx, reward); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, &models.Reward{ID: reward.ID, Title: "重複", Point: 1}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	if err := repo.Update(ctx, &models.Reward{ID: reward.ID, Title: "コーヒー券（更新）", Point: 120}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, err := repo.GetByID(ctx, reward.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "コーヒー券（更新）" || got.Point != 120 || !got.CreatedAt.Equal(reward.CreatedAt) {
+		t.Errorf("Unexpected reward: %+v", got)
+	}
+
+	// 更新前のバージョンを指定した場合は競合する
+	if err := repo.Update(ctx, &models.Reward{ID: reward.ID, Title: "古い更新", Point: 1, Version: 1}); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, reward.ID); got.Version != 2 {
+		t.Errorf("Expected version 2 after one update, got %d", got.Version)
+	}
+
+	if err := repo.Delete(ctx, reward.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, reward.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestRewardRepository_ListAndGetByIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRewardRepository(NewStore())
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"b", "a"} {
+		if err := repo.Create(ctx, &models.Reward{ID: id, Title: id, Point: 10, CreatedAt: base.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "b" || list[1].ID != "a" {
+		t.Errorf("Expected creation order b, a, got %v", list)
+	}
+
+	// 存在しないIDは結果に含まれない
+	rewards, err := repo.GetByIDs(ctx, []string{"a", "missing"})
+	if err != nil {
+		t.Fatalf("GetByIDs failed: %v", err)
+	}
+	if len(rewards) != 1 || rewards[0].ID != "a" {
+		t.Errorf("Expected only reward a, got %v", rewards)
+	}
+
+	rewards, err = repo.GetByIDs(ctx, nil)
+	if err != nil || len(rewards) != 0 {
+		t.Errorf("Expected empty result for no IDs, got %v (%v)", rewards, err)
+	}
+}
@@ -0,0 +1,241 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+// テーブル名（エラーメッセージ用。他のSQLバックエンドと揃える）
+const (
+	achievementsTable  = "achievements"
+	rewardsTable       = "rewards"
+	currentPointsTable = "current_points"
+	rewardHistoryTable = "reward_history"
+	pointLedgerTable   = "point_ledger"
+	completionsTable   = "completions"
+	badgesTable        = "badges"
+	goalsTable         = "goals"
+	favoritesTable     = "favorites"
+	wishlistTable      = "wishlist"
+	notesTable         = "notes"
+	driftEventsTable   = "drift_events"
+	reservationsTable  = "reservations"
+	questsTable        = "quests"
+	operationsTable    = "operations"
+	allowancesTable    = "allowances"
+	apiKeysTable       = "api_keys"
+)
+
+// Store プロセス内のメモリにデータを保持するストア（プロセス終了時に破棄される）
+type Store struct {
+	mu      sync.RWMutex
+	tenants map[string]*partition
+}
+
+// partition テナントごとのデータ
+type partition struct {
+	achievements  map[string]models.Achievement
+	rewards       map[string]models.Reward
+	currentPoints *models.CurrentPoints
+	rewardHistory map[string]models.RewardHistory
+	pointLedger   []models.PointLedgerEntry
+	completions   []models.Completion
+	badges        map[string]models.Badge
+	goals         map[string]models.Goal
+	favorites     map[string]models.Favorite
+	wishlist      map[string]models.WishlistItem
+	notes         map[string]models.Note
+	driftEvents   map[string]models.DriftEvent
+	reservations  map[string]models.Reservation
+	quests        map[string]models.Quest
+	operations    map[string]models.Operation
+	allowances    map[string]models.Allowance
+	apiKeys       map[string]models.APIKey
+}
+
+// NewStore 空のストアを作成
+func NewStore() *Store {
+	return &Store{tenants: map[string]*partition{}}
+}
+
+// newPartition 空のテナントのデータを作成
+func newPartition() *partition {
+	return &partition{
+		achievements:  map[string]models.Achievement{},
+		rewards:       map[string]models.Reward{},
+		rewardHistory: map[string]models.RewardHistory{},
+		badges:        map[string]models.Badge{},
+		goals:         map[string]models.Goal{},
+		favorites:     map[string]models.Favorite{},
+		wishlist:      map[string]models.WishlistItem{},
+		notes:         map[string]models.Note{},
+		driftEvents:   map[string]models.DriftEvent{},
+		reservations:  map[string]models.Reservation{},
+		quests:        map[string]models.Quest{},
+		operations:    map[string]models.Operation{},
+		allowances:    map[string]models.Allowance{},
+		apiKeys:       map[string]models.APIKey{},
+	}
+}
+
+// forRead コンテキストのテナントのデータを取得（呼び出し側で読み取りロックを取得すること。未作成の場合は空のデータを返す）
+func (s *Store) forRead(ctx context.Context) *partition {
+	if data, ok := s.tenants[tenant.FromContext(ctx)]; ok {
+		return data
+	}
+	return newPartition()
+}
+
+// forWrite コンテキストのテナントのデータを取得（呼び出し側で書き込みロックを取得すること。未作成の場合は作成する）
+func (s *Store) forWrite(ctx context.Context) *partition {
+	tenantID := tenant.FromContext(ctx)
+	data, ok := s.tenants[tenantID]
+	if !ok {
+		data = newPartition()
+		s.tenants[tenantID] = data
+	}
+	return data
+}
+
+// byCreatedAt 作成日時・ID順に並べ替え（SQLバックエンドの ORDER BY created_at, id と同じ順序）
+func byCreatedAt(createdAt func(i int) time.Time, id func(i int) string) func(i, j int) bool {
+	return func(i, j int) bool {
+		if !createdAt(i).Equal(createdAt(j)) {
+			return createdAt(i).Before(createdAt(j))
+		}
+		return id(i) < id(j)
+	}
+}
+
+// sortAchievements 達成目録を作成日時順に並べ替え
+func sortAchievements(achievements []*models.Achievement) {
+	sort.Slice(achievements, byCreatedAt(
+		func(i int) time.Time { return achievements[i].CreatedAt },
+		func(i int) string { return achievements[i].ID },
+	))
+}
+
+// sortRewards 報酬を作成日時順に並べ替え
+func sortRewards(rewards []*models.Reward) {
+	sort.Slice(rewards, byCreatedAt(
+		func(i int) time.Time { return rewards[i].CreatedAt },
+		func(i int) string { return rewards[i].ID },
+	))
+}
+
+// sortLedger ポイント台帳を記録日時順に並べ替え
+func sortLedger(entries []*models.PointLedgerEntry) {
+	sort.Slice(entries, byCreatedAt(
+		func(i int) time.Time { return entries[i].CreatedAt },
+		func(i int) string { return entries[i].ID },
+	))
+}
+
+// sortCompletions 達成記録を達成日時順に並べ替え
+func sortCompletions(completions []*models.Completion) {
+	sort.Slice(completions, byCreatedAt(
+		func(i int) time.Time { return completions[i].CompletedAt },
+		func(i int) string { return completions[i].ID },
+	))
+}
+
+// sortBadges バッジを獲得日時順に並べ替え
+func sortBadges(badges []*models.Badge) {
+	sort.Slice(badges, byCreatedAt(
+		func(i int) time.Time { return badges[i].EarnedAt },
+		func(i int) string { return badges[i].ID },
+	))
+}
+
+// sortGoals 目標を作成日時順に並べ替え
+func sortGoals(goals []*models.Goal) {
+	sort.Slice(goals, byCreatedAt(
+		func(i int) time.Time { return goals[i].CreatedAt },
+		func(i int) string { return goals[i].ID },
+	))
+}
+
+// sortFavorites お気に入りを登録日時順に並べ替え
+func sortFavorites(favorites []*models.Favorite) {
+	sort.Slice(favorites, byCreatedAt(
+		func(i int) time.Time { return favorites[i].CreatedAt },
+		func(i int) string { return favorites[i].RewardID },
+	))
+}
+
+// sortWishlist ほしいものリストを追加日時順に並べ替え
+func sortWishlist(items []*models.WishlistItem) {
+	sort.Slice(items, byCreatedAt(
+		func(i int) time.Time { return items[i].CreatedAt },
+		func(i int) string { return items[i].RewardID },
+	))
+}
+
+// sortNotes メモを作成日時順に並べ替え
+func sortNotes(notes []*models.Note) {
+	sort.Slice(notes, byCreatedAt(
+		func(i int) time.Time { return notes[i].CreatedAt },
+		func(i int) string { return notes[i].ID },
+	))
+}
+
+// sortDriftEvents ポイントの差異を検出日時順に並べ替え
+func sortDriftEvents(driftEvents []*models.DriftEvent) {
+	sort.Slice(driftEvents, byCreatedAt(
+		func(i int) time.Time { return driftEvents[i].DetectedAt },
+		func(i int) string { return driftEvents[i].ID },
+	))
+}
+
+// sortReservations 取り置きを取り置いた日時順に並べ替え
+func sortReservations(reservations []*models.Reservation) {
+	sort.Slice(reservations, byCreatedAt(
+		func(i int) time.Time { return reservations[i].CreatedAt },
+		func(i int) string { return reservations[i].RewardID },
+	))
+}
+
+// sortQuests クエストを作成日時順に並べ替え
+func sortQuests(quests []*models.Quest) {
+	sort.Slice(quests, byCreatedAt(
+		func(i int) time.Time { return quests[i].CreatedAt },
+		func(i int) string { return quests[i].ID },
+	))
+}
+
+// sortOperations 操作履歴を記録した順に並べ替え
+func sortOperations(operations []*models.Operation) {
+	sort.Slice(operations, byCreatedAt(
+		func(i int) time.Time { return operations[i].CreatedAt },
+		func(i int) string { return operations[i].ID },
+	))
+}
+
+// sortAllowances お小遣いのルールを作成日時順に並べ替え
+func sortAllowances(allowances []*models.Allowance) {
+	sort.Slice(allowances, byCreatedAt(
+		func(i int) time.Time { return allowances[i].CreatedAt },
+		func(i int) string { return allowances[i].ID },
+	))
+}
+
+// sortAPIKeys APIキーを作成日時順に並べ替え
+func sortAPIKeys(keys []*models.APIKey) {
+	sort.Slice(keys, byCreatedAt(
+		func(i int) time.Time { return keys[i].CreatedAt },
+		func(i int) string { return keys[i].ID },
+	))
+}
+
+// sortRewardHistory 報酬獲得履歴を獲得日時順に並べ替え
+func sortRewardHistory(history []*models.RewardHistory) {
+	sort.Slice(history, byCreatedAt(
+		func(i int) time.Time { return history[i].RedeemedAt },
+		func(i int) string { return history[i].ID },
+	))
+}
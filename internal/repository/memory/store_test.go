@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestStore_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(NewStore())
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// 呼び出し側で値を書き換えても保存済みのデータは変わらない
+	achievement.Title = "書き換え"
+	got, _ := repo.GetByID(ctx, achievement.ID)
+	got.Point = 99
+
+	list, _ := repo.List(ctx)
+	if len(list) != 1 || list[0].Title != "初回ログイン" || list[0].Point != 10 {
+		t.Errorf("Expected stored achievement to be unchanged, got %+v", list[0])
+	}
+}
+
+func TestStore_ConcurrentPoints(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	points := NewPointRepository(store)
+	achievements := NewAchievementRepository(store)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			points.AddPoints(ctx, 2)
+		}()
+		go func() {
+			defer wg.Done()
+			achievements.Create(ctx, &models.Achievement{Title: "並行", Point: 2})
+			achievements.List(ctx)
+		}()
+	}
+	wg.Wait()
+
+	// 残高以上の減算は同時に実行しても1回分しか成功しない
+	var succeeded int
+	var mu sync.Mutex
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if points.SubtractPoints(ctx, 60) == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	current, _ := points.GetCurrentPoints(ctx)
+	if succeeded != 1 || current.Point != 40 {
+		t.Errorf("Expected one subtraction leaving 40 points, got %d subtraction(s) and %d points", succeeded, current.Point)
+	}
+
+	list, _ := achievements.List(ctx)
+	if len(list) != 50 {
+		t.Errorf("Expected 50 achievements, got %d", len(list))
+	}
+}
+
+func TestStore_TenantsAreIsolated(t *testing.T) {
+	store := NewStore()
+	achievements := NewAchievementRepository(store)
+	points := NewPointRepository(store)
+	familyA := tenant.WithID(context.Background(), "family-a")
+	familyB := tenant.WithID(context.Background(), "family-b")
+
+	achievement := &models.Achievement{ID: "shared-id", Title: "初回ログイン", Point: 10}
+	if err := achievements.Create(familyA, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	// 別のテナントでは同じIDで作成できる
+	if err := achievements.Create(familyB, &models.Achievement{ID: "shared-id", Title: "別の家族", Point: 5}); err != nil {
+		t.Fatalf("Create in another tenant failed: %v", err)
+	}
+	if err := points.AddPoints(familyA, 10); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+
+	got, err := achievements.GetByID(familyB, "shared-id")
+	if err != nil || got.Title != "別の家族" {
+		t.Errorf("Expected family-b's achievement, got %+v (%v)", got, err)
+	}
+	if list, _ := achievements.List(context.Background()); len(list) != 0 {
+		t.Errorf("Expected default tenant to be empty, got %d achievements", len(list))
+	}
+
+	current, _ := points.GetCurrentPoints(familyB)
+	if current.Point != 0 {
+		t.Errorf("Expected family-b balance 0, got %d", current.Point)
+	}
+	current, _ = points.GetCurrentPoints(familyA)
+	if current.Point != 10 {
+		t.Errorf("Expected family-a balance 10, got %d", current.Point)
+	}
+}
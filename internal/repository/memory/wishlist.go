@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// FavoriteRepository メモリを使用したお気に入りリポジトリ
+type FavoriteRepository struct {
+	store *Store
+}
+
+// NewFavoriteRepository お気に入りリポジトリを作成
+func NewFavoriteRepository(store *Store) repository.FavoriteRepository {
+	return &FavoriteRepository{store: store}
+}
+
+// Add 報酬をお気に入りに登録（登録済みの場合は errors.ErrDuplicateResource）
+func (r *FavoriteRepository) Add(ctx context.Context, favorite *models.Favorite) error {
+	if favorite == nil {
+		return &errors.ValidationError{Field: "favorite", Message: "favorite cannot be nil"}
+	}
+	if favorite.RewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+	if favorite.CreatedAt.IsZero() {
+		favorite.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.favorites[favorite.RewardID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.favorites[favorite.RewardID] = *favorite
+	return nil
+}
+
+// Remove 報酬をお気に入りから外す（登録されていない場合は errors.ErrNotFound）
+func (r *FavoriteRepository) Remove(ctx context.Context, rewardID string) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.favorites[rewardID]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.favorites, rewardID)
+	return nil
+}
+
+// List お気に入りを登録日時順に取得
+func (r *FavoriteRepository) List(ctx context.Context) ([]*models.Favorite, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	favorites := make([]*models.Favorite, 0, len(data.favorites))
+	for _, favorite := range data.favorites {
+		favorite := favorite
+		favorites = append(favorites, &favorite)
+	}
+	sortFavorites(favorites)
+	return favorites, nil
+}
+
+// WishlistRepository メモリを使用したほしいものリストリポジトリ
+type WishlistRepository struct {
+	store *Store
+}
+
+// NewWishlistRepository ほしいものリストリポジトリを作成
+func NewWishlistRepository(store *Store) repository.WishlistRepository {
+	return &WishlistRepository{store: store}
+}
+
+// Add 報酬をほしいものリストに追加（追加済みの場合は errors.ErrDuplicateResource）
+func (r *WishlistRepository) Add(ctx context.Context, item *models.WishlistItem) error {
+	if err := repository.ValidateWishlistItem(item); err != nil {
+		return err
+	}
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.wishlist[item.RewardID]; exists {
+		return errors.ErrDuplicateResource
+	}
+	data.wishlist[item.RewardID] = *item
+	return nil
+}
+
+// UpdatePriority ほしいものリストの報酬の優先度を変更（追加されていない場合は errors.ErrNotFound）
+func (r *WishlistRepository) UpdatePriority(ctx context.Context, rewardID string, priority int) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+	if priority < 0 {
+		return &errors.ValidationError{Field: "priority", Message: "priority cannot be negative"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	item, exists := data.wishlist[rewardID]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	item.Priority = priority
+	data.wishlist[rewardID] = item
+	return nil
+}
+
+// Remove 報酬をほしいものリストから外す（追加されていない場合は errors.ErrNotFound）
+func (r *WishlistRepository) Remove(ctx context.Context, rewardID string) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	data := r.store.forWrite(ctx)
+
+	if _, exists := data.wishlist[rewardID]; !exists {
+		return errors.ErrNotFound
+	}
+	delete(data.wishlist, rewardID)
+	return nil
+}
+
+// List ほしいものリストを追加日時順に取得
+func (r *WishlistRepository) List(ctx context.Context) ([]*models.WishlistItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	data := r.store.forRead(ctx)
+
+	items := make([]*models.WishlistItem, 0, len(data.wishlist))
+	for _, item := range data.wishlist {
+		item := item
+		items = append(items, &item)
+	}
+	sortWishlist(items)
+	return items, nil
+}
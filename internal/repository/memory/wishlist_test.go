@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestFavoriteRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFavoriteRepository(NewStore())
+
+	if err := repo.Add(ctx, &models.Favorite{RewardID: "reward-1"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := repo.Add(ctx, &models.Favorite{RewardID: "reward-1"}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	// 他のテナントからは見えない
+	other, err := repo.List(tenant.WithID(ctx, "acme"))
+	if err != nil || len(other) != 0 {
+		t.Errorf("Expected no favorites for another tenant, got %v (%v)", other, err)
+	}
+
+	if err := repo.Remove(ctx, "reward-1"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := repo.Remove(ctx, "reward-1"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWishlistRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWishlistRepository(NewStore())
+
+	if err := repo.Add(ctx, &models.WishlistItem{RewardID: "reward-1", Priority: 2}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := repo.Add(ctx, &models.WishlistItem{RewardID: "reward-1", Priority: 1}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	if err := repo.UpdatePriority(ctx, "reward-1", 5); err != nil {
+		t.Fatalf("UpdatePriority failed: %v", err)
+	}
+	if err := repo.UpdatePriority(ctx, "missing", 5); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	items, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Priority != 5 || items[0].CreatedAt.IsZero() {
+		t.Errorf("Unexpected wishlist: %+v", items)
+	}
+
+	if err := repo.Remove(ctx, "reward-1"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := repo.Remove(ctx, "reward-1"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// NoteRepositoryImpl 記録に付けるメモのリポジトリの実装
+type NoteRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewNoteRepository 記録に付けるメモのリポジトリを作成
+func NewNoteRepository(repo Repository, config *config.Config) NoteRepository {
+	return &NoteRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Create メモを作成
+func (r *NoteRepositoryImpl) Create(ctx context.Context, note *models.Note) error {
+	if err := ValidateNote(note); err != nil {
+		return err
+	}
+
+	// IDが空の場合はULIDを生成
+	if note.ID == "" {
+		note.ID = ulid.Make().String()
+	}
+
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = time.Now()
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Notes, newNoteItem(ctx, note), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Create",
+			Table:     r.config.Tables.Notes,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetByID IDでメモを取得
+func (r *NoteRepositoryImpl) GetByID(ctx context.Context, id string) (*models.Note, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	var note models.Note
+	err := r.repo.GetItem(ctx, r.config.Tables.Notes, itemKey(ctx, id), &note)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{
+			Operation: "GetByID",
+			Table:     r.config.Tables.Notes,
+			Cause:     err,
+		}
+	}
+
+	note.ID = tenant.EntityID(ctx, note.ID)
+	return &note, nil
+}
+
+// ListByTarget 記録に付けたメモを作成日時順に取得
+func (r *NoteRepositoryImpl) ListByTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error) {
+	if targetID == "" {
+		return nil, &errors.ValidationError{Field: "target_id", Message: "target_id is required"}
+	}
+
+	input := QueryInput{
+		TableName:              r.config.Tables.Notes,
+		IndexName:              TargetKeyIndex,
+		KeyConditionExpression: TargetKeyAttribute + " = :target_key",
+		ExpressionAttributeValues: map[string]interface{}{
+			":target_key": noteTargetKey(ctx, targetType, targetID),
+		},
+	}
+
+	var notes []*models.Note
+	if _, err := r.repo.Query(ctx, input, &notes); err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "ListByTarget",
+			Table:     r.config.Tables.Notes,
+			Cause:     err,
+		}
+	}
+
+	for _, note := range notes {
+		note.ID = tenant.EntityID(ctx, note.ID)
+	}
+	return notes, nil
+}
+
+// Delete メモを削除
+func (r *NoteRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	// 存在確認（IDのみを読み取る）
+	var item struct {
+		ID string `dynamodbav:"id"`
+	}
+	err := r.repo.GetItemWithProjection(ctx, r.config.Tables.Notes, itemKey(ctx, id), idProjection, &item)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Notes,
+			Cause:     err,
+		}
+	}
+
+	err = r.repo.DeleteItem(ctx, r.config.Tables.Notes, itemKey(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Notes,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// ValidateNote メモのバリデーション（すべてのストレージで共通）
+func ValidateNote(note *models.Note) error {
+	if note == nil {
+		return &errors.ValidationError{Field: "note", Message: "note cannot be nil"}
+	}
+	switch note.TargetType {
+	case models.NoteTargetAchievement, models.NoteTargetReward, models.NoteTargetRedemption:
+	default:
+		return &errors.ValidationError{Field: "target_type", Message: "target_type must be achievement, reward or redemption"}
+	}
+	if note.TargetID == "" {
+		return &errors.ValidationError{Field: "target_id", Message: "target_id is required"}
+	}
+	if note.Body == "" {
+		return &errors.ValidationError{Field: "body", Message: "body is required"}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testNoteConfig() *config.Config {
+	return &config.Config{Tables: config.TableConfig{Notes: "test-notes"}}
+}
+
+func TestNoteRepository_Create(t *testing.T) {
+	var putCondition string
+	var putItem noteItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			putCondition = conditionExpression
+			putItem = item.(noteItem)
+			return nil
+		},
+	}
+	repo := NewNoteRepository(mockRepo, testNoteConfig())
+
+	note := &models.Note{TargetType: models.NoteTargetRedemption, TargetID: "history-1", Body: "誕生日のディナーに使った"}
+	if err := repo.Create(tenant.WithID(context.Background(), "acme"), note); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if note.ID == "" || note.CreatedAt.IsZero() {
+		t.Error("ID and CreatedAt should be set")
+	}
+	if putCondition != conditionNotExists {
+		t.Errorf("Expected condition %s, got %s", conditionNotExists, putCondition)
+	}
+	if putItem.ID != "acme#"+note.ID || putItem.EntityType != "acme#"+EntityTypeNote {
+		t.Errorf("Expected tenant keys, got %s / %s", putItem.ID, putItem.EntityType)
+	}
+	if putItem.TargetKey != "acme#redemption#history-1" {
+		t.Errorf("Expected target key acme#redemption#history-1, got %s", putItem.TargetKey)
+	}
+}
+
+func TestNoteRepository_Create_ValidationError(t *testing.T) {
+	repo := NewNoteRepository(&MockRepository{}, testNoteConfig())
+
+	invalid := []*models.Note{
+		nil,
+		{TargetType: "goal", TargetID: "goal-1", Body: "メモ"},
+		{TargetType: models.NoteTargetAchievement, Body: "メモ"},
+		{TargetType: models.NoteTargetReward, TargetID: "reward-1"},
+	}
+	for _, note := range invalid {
+		if _, ok := repo.Create(context.Background(), note).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", note)
+		}
+	}
+}
+
+func TestNoteRepository_ListByTarget(t *testing.T) {
+	var queried QueryInput
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			queried = input
+			*result.(*[]*models.Note) = []*models.Note{
+				{ID: "acme#note-1", TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "メモ"},
+			}
+			return "", nil
+		},
+	}
+	repo := NewNoteRepository(mockRepo, testNoteConfig())
+
+	notes, err := repo.ListByTarget(tenant.WithID(context.Background(), "acme"), models.NoteTargetAchievement, "achievement-1")
+	if err != nil {
+		t.Fatalf("ListByTarget failed: %v", err)
+	}
+
+	if queried.TableName != "test-notes" || queried.IndexName != TargetKeyIndex {
+		t.Errorf("Expected query on %s of test-notes, got %s of %s", TargetKeyIndex, queried.IndexName, queried.TableName)
+	}
+	if queried.ExpressionAttributeValues[":target_key"] != "acme#achievement#achievement-1" {
+		t.Errorf("Unexpected key condition values: %v", queried.ExpressionAttributeValues)
+	}
+	if len(notes) != 1 || notes[0].ID != "note-1" {
+		t.Errorf("Expected note IDs without tenant prefix, got %+v", notes)
+	}
+}
+
+func TestNoteRepository_Delete_NotFound(t *testing.T) {
+	deleted := false
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			return ErrItemNotFound
+		},
+		deleteItemFunc: func(tableName string, key map[string]interface{}) error {
+			deleted = true
+			return nil
+		},
+	}
+	repo := NewNoteRepository(mockRepo, testNoteConfig())
+
+	err := repo.Delete(context.Background(), "note-1")
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if deleted {
+		t.Error("Missing note should not be deleted")
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// CallObserver DynamoDBのAPI呼び出しの結果を受け取る（metrics.Registry が実装する）
+//
+// リトライした場合は1回ごとに呼ばれるため、リトライで解消したスロットリングも記録できる。
+// err は ErrThrottled・ErrConditionFailed などの種類を付与したエラー。
+type CallObserver interface {
+	ObserveDynamoDBCall(operation, table string, duration time.Duration, err error)
+}
+
+// observeCall 呼び出しの所要時間と結果を observer に渡す
+func observeCall[T any](observer CallObserver, operation, table string, call func() (T, error)) (T, error) {
+	start := time.Now()
+	out, err := call()
+	observer.ObserveDynamoDBCall(operation, table, time.Since(start), classifyError(err))
+	return out, err
+}
+
+// joinTables 複数のテーブルにまたがる操作の table ラベル（テーブル名を並べ替えてカンマで区切る）
+func joinTables(tables []string) string {
+	unique := make(map[string]bool, len(tables))
+	names := make([]string, 0, len(tables))
+	for _, table := range tables {
+		if table != "" && !unique[table] {
+			unique[table] = true
+			names = append(names, table)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// mapTables バッチ操作のリクエストのテーブル名
+func mapTables[V any](requests map[string]V) string {
+	tables := make([]string, 0, len(requests))
+	for table := range requests {
+		tables = append(tables, table)
+	}
+	return joinTables(tables)
+}
+
+// transactTables トランザクションで書き込むテーブル名
+func transactTables(items []types.TransactWriteItem) string {
+	tables := make([]string, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item.Put != nil:
+			tables = append(tables, aws.ToString(item.Put.TableName))
+		case item.Update != nil:
+			tables = append(tables, aws.ToString(item.Update.TableName))
+		case item.Delete != nil:
+			tables = append(tables, aws.ToString(item.Delete.TableName))
+		case item.ConditionCheck != nil:
+			tables = append(tables, aws.ToString(item.ConditionCheck.TableName))
+		}
+	}
+	return joinTables(tables)
+}
+
+// observingClient すべての操作の所要時間と結果を記録するDynamoDBクライアント
+type observingClient struct {
+	client   DynamoDBAPI
+	observer CallObserver
+}
+
+// newObservingClient 呼び出しを記録するクライアントを作成
+func newObservingClient(client DynamoDBAPI, observer CallObserver) DynamoDBAPI {
+	return &observingClient{client: client, observer: observer}
+}
+
+func (c *observingClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return observeCall(c.observer, "PutItem", aws.ToString(params.TableName), func() (*dynamodb.PutItemOutput, error) {
+		return c.client.PutItem(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return observeCall(c.observer, "GetItem", aws.ToString(params.TableName), func() (*dynamodb.GetItemOutput, error) {
+		return c.client.GetItem(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return observeCall(c.observer, "UpdateItem", aws.ToString(params.TableName), func() (*dynamodb.UpdateItemOutput, error) {
+		return c.client.UpdateItem(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	return observeCall(c.observer, "Scan", aws.ToString(params.TableName), func() (*dynamodb.ScanOutput, error) {
+		return c.client.Scan(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return observeCall(c.observer, "Query", aws.ToString(params.TableName), func() (*dynamodb.QueryOutput, error) {
+		return c.client.Query(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return observeCall(c.observer, "DeleteItem", aws.ToString(params.TableName), func() (*dynamodb.DeleteItemOutput, error) {
+		return c.client.DeleteItem(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return observeCall(c.observer, "TransactWriteItems", transactTables(params.TransactItems), func() (*dynamodb.TransactWriteItemsOutput, error) {
+		return c.client.TransactWriteItems(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	return observeCall(c.observer, "BatchWriteItem", mapTables(params.RequestItems), func() (*dynamodb.BatchWriteItemOutput, error) {
+		return c.client.BatchWriteItem(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	return observeCall(c.observer, "BatchGetItem", mapTables(params.RequestItems), func() (*dynamodb.BatchGetItemOutput, error) {
+		return c.client.BatchGetItem(ctx, params, optFns...)
+	})
+}
+
+func (c *observingClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return observeCall(c.observer, "DescribeTable", aws.ToString(params.TableName), func() (*dynamodb.DescribeTableOutput, error) {
+		return c.client.DescribeTable(ctx, params, optFns...)
+	})
+}
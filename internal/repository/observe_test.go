@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// observedCall 記録されたAPI呼び出し
+type observedCall struct {
+	operation string
+	table     string
+	err       error
+}
+
+// recordingObserver 呼び出しを記録する CallObserver
+type recordingObserver struct {
+	calls []observedCall
+}
+
+func (o *recordingObserver) ObserveDynamoDBCall(operation, table string, duration time.Duration, err error) {
+	o.calls = append(o.calls, observedCall{operation: operation, table: table, err: err})
+}
+
+func TestObservingClient_RecordsEachAttempt(t *testing.T) {
+	calls := 0
+	mockClient := &MockDynamoDBClient{
+		getItemFunc: func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			calls++
+			if calls == 1 {
+				return nil, throttlingError()
+			}
+			return &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: "test-id"},
+			}}, nil
+		},
+	}
+	observer := &recordingObserver{}
+	client := newRetryingClient(newObservingClient(mockClient, observer), RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+	repo := NewDynamoDBRepositoryWithClient(client)
+
+	var item TestItem
+	if err := repo.GetItem(context.Background(), "test-table", map[string]interface{}{"id": "test-id"}, &item); err != nil {
+		t.Fatalf("GetItem failed: %v", err)
+	}
+
+	// リトライで解消したスロットリングも記録する
+	if len(observer.calls) != 2 {
+		t.Fatalf("Expected 2 observed calls, got %+v", observer.calls)
+	}
+	first, second := observer.calls[0], observer.calls[1]
+	if first.operation != "GetItem" || first.table != "test-table" || !errors.Is(first.err, ErrThrottled) {
+		t.Errorf("Expected a throttled GetItem on test-table, got %+v", first)
+	}
+	if second.err != nil {
+		t.Errorf("Expected the retry to succeed, got %v", second.err)
+	}
+}
+
+func TestTransactTables(t *testing.T) {
+	items := []types.TransactWriteItem{
+		{Put: &types.Put{TableName: aws.String("points")}},
+		{Update: &types.Update{TableName: aws.String("achievements")}},
+		{Put: &types.Put{TableName: aws.String("point-ledger")}},
+		{ConditionCheck: &types.ConditionCheck{TableName: aws.String("achievements")}},
+	}
+	if got := transactTables(items); got != "achievements,point-ledger,points" {
+		t.Errorf("Expected sorted unique tables, got %s", got)
+	}
+
+	requests := map[string][]types.WriteRequest{"rewards": nil, "achievements": nil}
+	if got := mapTables(requests); got != "achievements,rewards" {
+		t.Errorf("Expected sorted tables, got %s", got)
+	}
+}
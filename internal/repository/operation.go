@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// OperationRepositoryImpl 操作履歴のリポジトリの実装
+type OperationRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewOperationRepository 操作履歴のリポジトリを作成
+func NewOperationRepository(repo Repository, config *config.Config) OperationRepository {
+	return &OperationRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Record 操作を記録
+func (r *OperationRepositoryImpl) Record(ctx context.Context, operation *models.Operation) error {
+	if err := PrepareOperation(operation); err != nil {
+		return err
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Operations, newOperationItem(ctx, operation), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Record",
+			Table:     r.config.Tables.Operations,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// List 操作履歴を記録した順に取得
+func (r *OperationRepositoryImpl) List(ctx context.Context) ([]*models.Operation, error) {
+	var operations []*models.Operation
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Operations, CreatedAtIndex, EntityTypeOperation), &operations)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Operations,
+			Cause:     err,
+		}
+	}
+
+	for _, operation := range operations {
+		operation.ID = tenant.EntityID(ctx, operation.ID)
+	}
+	return operations, nil
+}
+
+// SetUndone 操作を元に戻した日時を記録（nil の場合は消去する。記録されていない場合は errors.ErrNotFound）
+func (r *OperationRepositoryImpl) SetUndone(ctx context.Context, id string, undoneAt *time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	updateExpression := "REMOVE undone_at"
+	var values map[string]interface{}
+	if undoneAt != nil {
+		updateExpression = "SET undone_at = :undone_at"
+		values = map[string]interface{}{":undone_at": *undoneAt}
+	}
+
+	err := r.repo.UpdateItemWithCondition(ctx, r.config.Tables.Operations, itemKey(ctx, id), updateExpression, conditionExists, values)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "SetUndone",
+			Table:     r.config.Tables.Operations,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Delete 操作を操作履歴から削除（記録されていない場合も成功する）
+func (r *OperationRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	if err := r.repo.DeleteItem(ctx, r.config.Tables.Operations, itemKey(ctx, id)); err != nil {
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Operations,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// PrepareOperation 記録する操作を検証し、IDと記録日時が未設定の場合は設定（すべてのストレージで共通）
+func PrepareOperation(operation *models.Operation) error {
+	if operation == nil {
+		return &errors.ValidationError{Field: "operation", Message: "operation cannot be nil"}
+	}
+	switch operation.Entity {
+	case models.OperationEntityAchievement, models.OperationEntityReward:
+	default:
+		return &errors.ValidationError{Field: "entity", Message: "entity must be achievement or reward"}
+	}
+	switch operation.Action {
+	case models.OperationActionCreate, models.OperationActionUpdate, models.OperationActionDelete:
+	default:
+		return &errors.ValidationError{Field: "action", Message: "action must be create, update or delete"}
+	}
+	if operation.EntityID == "" {
+		return &errors.ValidationError{Field: "entity_id", Message: "entity_id is required"}
+	}
+
+	// IDが空の場合はULIDを生成
+	if operation.ID == "" {
+		operation.ID = ulid.Make().String()
+	}
+	if operation.CreatedAt.IsZero() {
+		operation.CreatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testOperationConfig() *config.Config {
+	return &config.Config{
+		Tables: config.TableConfig{Operations: "test-operations"},
+	}
+}
+
+func TestOperationRepository_Record(t *testing.T) {
+	var putCondition string
+	var putItem operationItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			putCondition = conditionExpression
+			putItem = item.(operationItem)
+			return nil
+		},
+	}
+	repo := NewOperationRepository(mockRepo, testOperationConfig())
+
+	operation := &models.Operation{Entity: models.OperationEntityReward, Action: models.OperationActionDelete, EntityID: "reward-123", Before: `{"id":"reward-123"}`}
+	if err := repo.Record(tenant.WithID(context.Background(), "acme"), operation); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	if operation.ID == "" || operation.CreatedAt.IsZero() {
+		t.Error("ID and CreatedAt should be set")
+	}
+	if putCondition != conditionNotExists {
+		t.Errorf("Expected condition %s, got %s", conditionNotExists, putCondition)
+	}
+	// 対象のIDはテナントの接頭辞を付けずに保存する
+	if putItem.ID != "acme#"+operation.ID || putItem.EntityType != "acme#"+EntityTypeOperation || putItem.EntityID != "reward-123" {
+		t.Errorf("Expected tenant keys, got %s / %s / %s", putItem.ID, putItem.EntityType, putItem.EntityID)
+	}
+}
+
+func TestOperationRepository_Record_ValidationError(t *testing.T) {
+	repo := NewOperationRepository(&MockRepository{}, testOperationConfig())
+
+	invalid := []*models.Operation{
+		nil,
+		{Entity: "badge", Action: models.OperationActionCreate, EntityID: "badge-123"},
+		{Entity: models.OperationEntityReward, Action: "complete", EntityID: "reward-123"},
+		{Entity: models.OperationEntityReward, Action: models.OperationActionCreate},
+	}
+	for _, operation := range invalid {
+		if _, ok := repo.Record(context.Background(), operation).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", operation)
+		}
+	}
+}
+
+func TestOperationRepository_SetUndone(t *testing.T) {
+	var expressions []string
+	var values []map[string]interface{}
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			if conditionExpression != conditionExists {
+				t.Errorf("Expected condition %s, got %s", conditionExists, conditionExpression)
+			}
+			expressions = append(expressions, updateExpression)
+			values = append(values, expressionAttributeValues)
+			return nil
+		},
+	}
+	repo := NewOperationRepository(mockRepo, testOperationConfig())
+
+	undoneAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := repo.SetUndone(context.Background(), "op-123", &undoneAt); err != nil {
+		t.Fatalf("SetUndone failed: %v", err)
+	}
+	// やり直した場合は元に戻した日時を消去する
+	if err := repo.SetUndone(context.Background(), "op-123", nil); err != nil {
+		t.Fatalf("SetUndone failed: %v", err)
+	}
+
+	if expressions[0] != "SET undone_at = :undone_at" || values[0][":undone_at"] != undoneAt {
+		t.Errorf("Unexpected update: %s %v", expressions[0], values[0])
+	}
+	if expressions[1] != "REMOVE undone_at" || values[1] != nil {
+		t.Errorf("Unexpected update: %s %v", expressions[1], values[1])
+	}
+}
+
+func TestOperationRepository_SetUndone_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			return ErrConditionFailed
+		},
+	}
+	repo := NewOperationRepository(mockRepo, testOperationConfig())
+
+	if err := repo.SetUndone(context.Background(), "missing", nil); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
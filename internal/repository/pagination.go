@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ErrStopIteration ItemHandler から返すとストリーミング取得をエラーなしで終了する
+var ErrStopIteration = errors.New("stop iteration")
+
+// Item ストリーミング取得した1件のアイテム
+type Item struct {
+	av map[string]types.AttributeValue
+}
+
+// NewItem 任意の値からアイテムを作成（リポジトリの外でストリーミング取得を模擬する場合に使用する）
+func NewItem(in interface{}) (Item, error) {
+	av, err := attributevalue.MarshalMap(in)
+	if err != nil {
+		return Item{}, fmt.Errorf("failed to marshal item: %w", err)
+	}
+	return Item{av: av}, nil
+}
+
+// Unmarshal アイテムを任意の型に変換
+func (i Item) Unmarshal(out interface{}) error {
+	if err := attributevalue.UnmarshalMap(i.av, out); err != nil {
+		return fmt.Errorf("failed to unmarshal item: %w", err)
+	}
+	return nil
+}
+
+// ItemHandler ストリーミング取得で1件ごとに呼び出される関数
+type ItemHandler func(item Item) error
+
+// pageFetcher 開始キーと最大件数を受け取り1ページ分を取得する関数
+type pageFetcher func(startKey map[string]types.AttributeValue, limit int32) (items []map[string]types.AttributeValue, lastKey map[string]types.AttributeValue, err error)
+
+// paginate LastEvaluatedKey をたどって全ページを取得し、次の取得開始位置のカーソルを返す
+//
+// limit が正の場合はその件数に達した時点で取得を終了する。すべて取得した場合のカーソルは空文字列。
+func paginate(cursor string, limit int, fetch pageFetcher, handle func(items []map[string]types.AttributeValue) error) (string, error) {
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	remaining := limit
+	for {
+		var pageLimit int32
+		if limit > 0 {
+			pageLimit = int32(remaining)
+		}
+
+		items, lastKey, err := fetch(startKey, pageLimit)
+		if err != nil {
+			return "", err
+		}
+
+		if err := handle(items); err != nil {
+			return "", err
+		}
+
+		if len(lastKey) == 0 {
+			return "", nil
+		}
+
+		if limit > 0 {
+			remaining -= len(items)
+			if remaining <= 0 {
+				return encodeCursor(lastKey)
+			}
+		}
+
+		startKey = lastKey
+	}
+}
+
+// collectInto 全ページのアイテムをまとめてresultに変換する
+func collectInto(result interface{}) (func(items []map[string]types.AttributeValue) error, func() error) {
+	var all []map[string]types.AttributeValue
+	collect := func(items []map[string]types.AttributeValue) error {
+		all = append(all, items...)
+		return nil
+	}
+	finish := func() error {
+		if all == nil {
+			all = []map[string]types.AttributeValue{}
+		}
+		if err := attributevalue.UnmarshalListOfMaps(all, result); err != nil {
+			return fmt.Errorf("failed to unmarshal items: %w", err)
+		}
+		return nil
+	}
+	return collect, finish
+}
+
+// streamTo 各アイテムをItemHandlerに渡す
+func streamTo(fn ItemHandler) func(items []map[string]types.AttributeValue) error {
+	return func(items []map[string]types.AttributeValue) error {
+		for _, av := range items {
+			if err := fn(Item{av: av}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// encodeCursor LastEvaluatedKey を不透明なカーソル文字列に変換
+func encodeCursor(key map[string]types.AttributeValue) (string, error) {
+	var plain map[string]interface{}
+	if err := attributevalue.UnmarshalMap(key, &plain); err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	data, err := json.Marshal(plain)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor カーソル文字列を ExclusiveStartKey に変換
+func decodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var plain map[string]interface{}
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	key, err := attributevalue.MarshalMap(plain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return key, nil
+}
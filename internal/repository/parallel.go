@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ParallelScanEach テーブルを segments 個のセグメントに分け、workers 個のワーカーで並列にスキャンして1件ずつ fn に渡す
+//
+// fn は同時には呼び出さないが、アイテムの順序は保証しない。テーブル全体を対象にするため input の Cursor と Limit は使用しない。
+// segments が1以下の場合は ScanEach と同じ。workers が0以下またはセグメント数より多い場合はセグメント数に揃える。
+func ParallelScanEach(ctx context.Context, repo Repository, input ScanInput, segments, workers int, fn ItemHandler) error {
+	if segments <= 1 {
+		return repo.ScanEach(ctx, input, fn)
+	}
+	if workers <= 0 || workers > segments {
+		workers = segments
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		stopped  bool
+		firstErr error
+	)
+	// いずれかのセグメントで失敗または打ち切られた場合は残りのスキャンを中止する
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil && !stopped {
+			firstErr = err
+		}
+		cancel()
+	}
+	handle := func(item Item) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped || firstErr != nil {
+			return ErrStopIteration
+		}
+		err := fn(item)
+		if errors.Is(err, ErrStopIteration) {
+			stopped = true
+			cancel()
+		}
+		return err
+	}
+
+	queue := make(chan int, segments)
+	for segment := 0; segment < segments; segment++ {
+		queue <- segment
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for segment := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				segmentInput := input
+				segmentInput.Cursor = ""
+				segmentInput.Limit = 0
+				segmentInput.Segment = segment
+				segmentInput.TotalSegments = segments
+				if err := repo.ScanEach(ctx, segmentInput, handle); err != nil && !errors.Is(err, ErrStopIteration) {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if stopped {
+		return nil
+	}
+	if firstErr == nil {
+		// 呼び出し元のコンテキストが取り消された場合
+		return ctx.Err()
+	}
+	return firstErr
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// segmentedRepository id をセグメント数で割った余りでアイテムをセグメントに振り分けるリポジトリ
+func segmentedRepository(items int, scanned *sync.Map) *MockRepository {
+	return &MockRepository{
+		scanEachFunc: func(input ScanInput, fn ItemHandler) error {
+			scanned.Store(input.Segment, input.TotalSegments)
+			for id := input.Segment; id < items; id += input.TotalSegments {
+				item, err := NewItem(map[string]interface{}{"id": id})
+				if err != nil {
+					return err
+				}
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestParallelScanEach(t *testing.T) {
+	var scanned sync.Map
+	repo := segmentedRepository(100, &scanned)
+
+	seen := map[int]int{}
+	err := ParallelScanEach(context.Background(), repo, ScanInput{TableName: "test-table", Cursor: "ignored", Limit: 1}, 8, 3, func(item Item) error {
+		var values struct {
+			ID int `dynamodbav:"id"`
+		}
+		if err := item.Unmarshal(&values); err != nil {
+			return err
+		}
+		// fn は同時に呼び出されないため、ロックせずに集計できる
+		seen[values.ID]++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParallelScanEach failed: %v", err)
+	}
+
+	if len(seen) != 100 {
+		t.Errorf("Expected 100 items, got %d", len(seen))
+	}
+	for id, count := range seen {
+		if count != 1 {
+			t.Errorf("Item %d was handled %d times", id, count)
+		}
+	}
+	for segment := 0; segment < 8; segment++ {
+		total, ok := scanned.Load(segment)
+		if !ok || total != 8 {
+			t.Errorf("Segment %d was not scanned with 8 total segments", segment)
+		}
+	}
+}
+
+func TestParallelScanEach_SingleSegment(t *testing.T) {
+	var inputs []ScanInput
+	repo := &MockRepository{
+		scanEachFunc: func(input ScanInput, fn ItemHandler) error {
+			inputs = append(inputs, input)
+			return nil
+		},
+	}
+
+	if err := ParallelScanEach(context.Background(), repo, ScanInput{TableName: "test-table"}, 1, 4, func(Item) error { return nil }); err != nil {
+		t.Fatalf("ParallelScanEach failed: %v", err)
+	}
+	if len(inputs) != 1 || inputs[0].TotalSegments != 0 {
+		t.Errorf("Expected a single unsegmented scan, got %+v", inputs)
+	}
+}
+
+func TestParallelScanEach_StopsOnError(t *testing.T) {
+	var scanned sync.Map
+	repo := segmentedRepository(1000, &scanned)
+
+	handled := 0
+	err := ParallelScanEach(context.Background(), repo, ScanInput{TableName: "test-table"}, 4, 4, func(Item) error {
+		handled++
+		if handled == 10 {
+			return fmt.Errorf("encode failed")
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "encode failed" {
+		t.Errorf("Expected encode error, got %v", err)
+	}
+	if handled != 10 {
+		t.Errorf("Expected handling to stop after the error, got %d items", handled)
+	}
+
+	// スキャン自体の失敗も返す
+	repo = &MockRepository{
+		scanEachFunc: func(input ScanInput, fn ItemHandler) error {
+			if input.Segment == 2 {
+				return ErrThrottled
+			}
+			return nil
+		},
+	}
+	err = ParallelScanEach(context.Background(), repo, ScanInput{TableName: "test-table"}, 4, 2, func(Item) error { return nil })
+	if !errors.Is(err, ErrThrottled) {
+		t.Errorf("Expected ErrThrottled, got %v", err)
+	}
+}
+
+func TestParallelScanEach_StopIteration(t *testing.T) {
+	var scanned sync.Map
+	repo := segmentedRepository(1000, &scanned)
+
+	handled := 0
+	err := ParallelScanEach(context.Background(), repo, ScanInput{TableName: "test-table"}, 4, 4, func(Item) error {
+		handled++
+		if handled == 5 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Expected no error when stopping early, got %v", err)
+	}
+	if handled != 5 {
+		t.Errorf("Expected 5 items before stopping, got %d", handled)
+	}
+}
+
+func TestDynamoDBRepository_ScanSegment(t *testing.T) {
+	var scanInput *dynamodb.ScanInput
+	mockClient := &MockDynamoDBClient{
+		scanFunc: func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			scanInput = params
+			return &dynamodb.ScanOutput{Items: []map[string]types.AttributeValue{}}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(mockClient)
+
+	err := repo.ScanEach(context.Background(), ScanInput{TableName: "test-table", Segment: 2, TotalSegments: 4}, func(Item) error { return nil })
+	if err != nil {
+		t.Fatalf("ScanEach failed: %v", err)
+	}
+	if aws.ToInt32(scanInput.Segment) != 2 || aws.ToInt32(scanInput.TotalSegments) != 4 {
+		t.Errorf("Unexpected segment %v/%v", scanInput.Segment, scanInput.TotalSegments)
+	}
+
+	// 分割しない場合はセグメントを指定しない
+	if err := repo.ScanEach(context.Background(), ScanInput{TableName: "test-table"}, func(Item) error { return nil }); err != nil {
+		t.Fatalf("ScanEach failed: %v", err)
+	}
+	if scanInput.Segment != nil || scanInput.TotalSegments != nil {
+		t.Errorf("Expected no segment, got %v/%v", scanInput.Segment, scanInput.TotalSegments)
+	}
+}
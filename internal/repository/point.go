@@ -1,12 +1,15 @@
 package repository
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
 	"time"
 
 	"achievement-management/internal/config"
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
 
 	"github.com/oklog/ulid/v2"
 )
@@ -26,18 +29,24 @@ func NewPointRepository(repo Repository, config *config.Config) PointRepository
 }
 
 // GetCurrentPoints 現在のポイントを取得
-func (r *PointRepositoryImpl) GetCurrentPoints() (*models.CurrentPoints, error) {
-	key := map[string]interface{}{
-		"id": "current",
-	}
+func (r *PointRepositoryImpl) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.getCurrentPoints(ctx, r.repo.GetItem)
+}
 
+// GetCurrentPointsConsistent 直前の書き込みを反映した現在のポイントを取得（報酬獲得前の残高確認に使用）
+func (r *PointRepositoryImpl) GetCurrentPointsConsistent(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.getCurrentPoints(ctx, r.repo.GetItemConsistent)
+}
+
+// getCurrentPoints 指定した読み取り方法で現在のポイントを取得
+func (r *PointRepositoryImpl) getCurrentPoints(ctx context.Context, getItem itemGetter) (*models.CurrentPoints, error) {
 	var currentPoints models.CurrentPoints
-	err := r.repo.GetItem(r.config.Tables.CurrentPoints, key, &currentPoints)
+	err := getItem(ctx, r.config.Tables.CurrentPoints, currentPointsKey(ctx), &currentPoints)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("item not found in table %s", r.config.Tables.CurrentPoints) {
+		if stderrors.Is(err, ErrItemNotFound) {
 			// 初回の場合は0ポイントで初期化
 			return &models.CurrentPoints{
-				ID:        "current",
+				ID:        currentPointsID,
 				Point:     0,
 				UpdatedAt: time.Now(),
 			}, nil
@@ -49,17 +58,18 @@ func (r *PointRepositoryImpl) GetCurrentPoints() (*models.CurrentPoints, error)
 		}
 	}
 
+	currentPoints.ID = currentPointsID
 	return &currentPoints, nil
 }
 
-// UpdateCurrentPoints 現在のポイントを更新
-func (r *PointRepositoryImpl) UpdateCurrentPoints(points *models.CurrentPoints) error {
+// UpdateCurrentPoints 現在のポイントを指定した値に修正し、差分を台帳に記録
+func (r *PointRepositoryImpl) UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) error {
 	if points == nil {
 		return &errors.ValidationError{Field: "points", Message: "points cannot be nil"}
 	}
 
 	// IDを固定値に設定
-	points.ID = "current"
+	points.ID = currentPointsID
 	
 	// 更新日時を設定
 	points.UpdatedAt = time.Now()
@@ -69,11 +79,38 @@ func (r *PointRepositoryImpl) UpdateCurrentPoints(points *models.CurrentPoints)
 		return &errors.ValidationError{Field: "point", Message: "point cannot be negative"}
 	}
 
-	err := r.repo.PutItem(r.config.Tables.CurrentPoints, points)
+	current, err := r.GetCurrentPointsConsistent(ctx)
+	if err != nil {
+		return err
+	}
+	if current.Point == points.Point {
+		return nil
+	}
+
+	// 読み取った後に残高が変わっていた場合は差分が正しくないため書き込まない
+	entry := NewLedgerEntry(models.LedgerEntryAdjustment, points.Point-current.Point, "")
+	err = r.repo.TransactWrite(ctx, []TransactWriteItem{
+		ledgerPut(ctx, r.config, entry),
+		{
+			TableName:           r.config.Tables.CurrentPoints,
+			Operation:           "UPDATE",
+			Key:                 currentPointsKey(ctx),
+			UpdateExpression:    "SET point = :point, updated_at = :now",
+			ConditionExpression: "attribute_not_exists(id) OR point = :previous",
+			ExpressionAttributeValues: map[string]interface{}{
+				":point":    points.Point,
+				":previous": current.Point,
+				":now":      points.UpdatedAt,
+			},
+		},
+	})
 	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrVersionConflict
+		}
 		return &errors.DatabaseError{
 			Operation: "UpdateCurrentPoints",
-			Table:     r.config.Tables.CurrentPoints,
+			Table:     pointTables(r.config),
 			Cause:     err,
 		}
 	}
@@ -82,13 +119,13 @@ func (r *PointRepositoryImpl) UpdateCurrentPoints(points *models.CurrentPoints)
 }
 
 // CreateRewardHistory 報酬獲得履歴を作成
-func (r *PointRepositoryImpl) CreateRewardHistory(history *models.RewardHistory) error {
+func (r *PointRepositoryImpl) CreateRewardHistory(ctx context.Context, history *models.RewardHistory) error {
 	if history == nil {
 		return &errors.ValidationError{Field: "history", Message: "history cannot be nil"}
 	}
 
 	// バリデーション
-	if err := r.validateRewardHistory(history); err != nil {
+	if err := ValidateRewardHistory(history); err != nil {
 		return err
 	}
 
@@ -102,7 +139,7 @@ func (r *PointRepositoryImpl) CreateRewardHistory(history *models.RewardHistory)
 		history.RedeemedAt = time.Now()
 	}
 
-	err := r.repo.PutItem(r.config.Tables.RewardHistory, history)
+	err := r.repo.PutItem(ctx, r.config.Tables.RewardHistory, newRewardHistoryItem(ctx, history))
 	if err != nil {
 		return &errors.DatabaseError{
 			Operation: "CreateRewardHistory",
@@ -114,44 +151,79 @@ func (r *PointRepositoryImpl) CreateRewardHistory(history *models.RewardHistory)
 	return nil
 }
 
-// GetRewardHistory 報酬獲得履歴を取得
-func (r *PointRepositoryImpl) GetRewardHistory() ([]*models.RewardHistory, error) {
+// GetRewardHistory 報酬獲得履歴を獲得日時順に取得
+func (r *PointRepositoryImpl) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
+	return r.getRewardHistory(ctx, "GetRewardHistory", time.Time{}, time.Time{})
+}
+
+// GetRewardHistoryBetween 獲得日時が from 以上 to 未満の報酬獲得履歴を獲得日時順に取得（ゼロ値の側は制限しない）
+func (r *PointRepositoryImpl) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	if err := ValidateRewardHistoryRange(from, to); err != nil {
+		return nil, err
+	}
+	return r.getRewardHistory(ctx, "GetRewardHistoryBetween", from, to)
+}
+
+// getRewardHistory 獲得日時の範囲をGSIのキー条件で絞り込んで報酬獲得履歴を取得
+func (r *PointRepositoryImpl) getRewardHistory(ctx context.Context, operation string, from, to time.Time) ([]*models.RewardHistory, error) {
+	input := entityTypeQuery(ctx, r.config.Tables.RewardHistory, RedeemedAtIndex, EntityTypeRewardHistory)
+	switch {
+	case !from.IsZero() && !to.IsZero():
+		// BETWEEN は上限を含むため、to の直前までを指定する
+		input.KeyConditionExpression += " AND " + RedeemedAtKeyAttribute + " BETWEEN :from AND :to"
+		input.ExpressionAttributeValues[":from"] = redeemedAtKey(from)
+		input.ExpressionAttributeValues[":to"] = redeemedAtKey(to.Add(-time.Nanosecond))
+	case !from.IsZero():
+		input.KeyConditionExpression += " AND " + RedeemedAtKeyAttribute + " >= :from"
+		input.ExpressionAttributeValues[":from"] = redeemedAtKey(from)
+	case !to.IsZero():
+		input.KeyConditionExpression += " AND " + RedeemedAtKeyAttribute + " < :to"
+		input.ExpressionAttributeValues[":to"] = redeemedAtKey(to)
+	}
+
 	var history []*models.RewardHistory
-	err := r.repo.Scan(r.config.Tables.RewardHistory, &history)
+	_, err := r.repo.Query(ctx, input, &history)
 	if err != nil {
 		return nil, &errors.DatabaseError{
-			Operation: "GetRewardHistory",
+			Operation: operation,
 			Table:     r.config.Tables.RewardHistory,
 			Cause:     err,
 		}
 	}
 
+	for _, h := range history {
+		h.ID = tenant.EntityID(ctx, h.ID)
+	}
 	return history, nil
 }
 
-// TransactPointsAndHistory ポイント更新と履歴記録をトランザクションで実行
-func (r *PointRepositoryImpl) TransactPointsAndHistory(pointsUpdate *models.CurrentPoints, history *models.RewardHistory) error {
-	if pointsUpdate == nil {
-		return &errors.ValidationError{Field: "pointsUpdate", Message: "pointsUpdate cannot be nil"}
+// CountRewardHistory 報酬獲得履歴の件数を取得（テナントを分けない場合はおおよその値、期限切れで削除待ちの履歴も含む）
+func (r *PointRepositoryImpl) CountRewardHistory(ctx context.Context) (int, error) {
+	count, err := entityCount(ctx, r.repo, r.config, r.config.Tables.RewardHistory, RedeemedAtIndex, EntityTypeRewardHistory)
+	if err != nil {
+		return 0, &errors.DatabaseError{
+			Operation: "CountRewardHistory",
+			Table:     r.config.Tables.RewardHistory,
+			Cause:     err,
+		}
 	}
+	return int(count), nil
+}
+
+// RedeemPoints 報酬獲得のポイント減算・台帳への記録・履歴記録をトランザクションで実行（残高不足の場合は ErrInsufficientPoints）
+//
+// 他の報酬のために取り置いたポイントは使えず、獲得する報酬の取り置きは同じトランザクションで解除する。
+func (r *PointRepositoryImpl) RedeemPoints(ctx context.Context, history *models.RewardHistory) error {
 	if history == nil {
 		return &errors.ValidationError{Field: "history", Message: "history cannot be nil"}
 	}
 
 	// バリデーション
-	if err := r.validateRewardHistory(history); err != nil {
+	if err := ValidateRewardHistory(history); err != nil {
 		return err
 	}
 
-	// ポイントが負の値にならないようにチェック
-	if pointsUpdate.Point < 0 {
-		return &errors.ValidationError{Field: "point", Message: "point cannot be negative"}
-	}
-
 	// IDと日時を設定
-	pointsUpdate.ID = "current"
-	pointsUpdate.UpdatedAt = time.Now()
-
 	if history.ID == "" {
 		history.ID = ulid.Make().String()
 	}
@@ -159,34 +231,246 @@ func (r *PointRepositoryImpl) TransactPointsAndHistory(pointsUpdate *models.Curr
 		history.RedeemedAt = time.Now()
 	}
 
-	// トランザクションアイテムを準備
-	transactItems := []TransactWriteItem{
+	// 獲得する報酬の取り置きは同じトランザクションで解除し、他の報酬のために取り置いたポイントは使わない
+	current, err := r.GetCurrentPointsConsistent(ctx)
+	if err != nil {
+		return err
+	}
+	release := 0
+	if current.Reserved > 0 {
+		release, err = reservedPoints(ctx, r.repo, r.config, history.RewardID)
+		if err != nil {
+			return err
+		}
+	}
+
+	entry := NewLedgerEntry(models.LedgerEntrySpend, -history.PointCost, history.ID)
+	items := []TransactWriteItem{
 		{
-			TableName: r.config.Tables.CurrentPoints,
-			Item:      pointsUpdate,
+			TableName: r.config.Tables.RewardHistory,
+			Item:      newRewardHistoryItem(ctx, history),
 			Operation: "PUT",
 		},
+		ledgerPut(ctx, r.config, entry),
+		redeemCounterUpdate(ctx, r.config, history.PointCost, current.Reserved, release, entry.CreatedAt),
+	}
+	if release > 0 {
+		items = append(items, reservationDelete(ctx, r.config, history.RewardID, release))
+	}
+	err = r.repo.TransactWrite(ctx, items)
+	if err != nil {
+		// 残高不足・アイテム未作成（0ポイント）、または読み取った後に取り置きが増えていた場合
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrInsufficientPoints
+		}
+		return &errors.DatabaseError{
+			Operation: "RedeemPoints",
+			Table:     pointTables(r.config) + "," + r.config.Tables.RewardHistory,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetRewardHistoryByID IDで報酬獲得履歴を取得
+func (r *PointRepositoryImpl) GetRewardHistoryByID(ctx context.Context, id string) (*models.RewardHistory, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	var history models.RewardHistory
+	err := r.repo.GetItemConsistent(ctx, r.config.Tables.RewardHistory, itemKey(ctx, id), &history)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{
+			Operation: "GetRewardHistoryByID",
+			Table:     r.config.Tables.RewardHistory,
+			Cause:     err,
+		}
+	}
+
+	history.ID = tenant.EntityID(ctx, history.ID)
+	return &history, nil
+}
+
+// RefundRedemption 報酬獲得を取り消し、ポイントの返還・台帳への記録・取り消し日時の記録をトランザクションで実行
+//
+// 取り消し済みまたは削除済みの履歴の場合は errors.ErrVersionConflict を返す。
+func (r *PointRepositoryImpl) RefundRedemption(ctx context.Context, history *models.RewardHistory) error {
+	if err := PrepareRefund(history); err != nil {
+		return err
+	}
+
+	entry := NewLedgerEntry(models.LedgerEntryRefund, history.PointCost, history.ID)
+	err := r.repo.TransactWrite(ctx, []TransactWriteItem{
 		{
-			TableName: r.config.Tables.RewardHistory,
-			Item:      history,
-			Operation: "PUT",
+			TableName:                 r.config.Tables.RewardHistory,
+			Operation:                 "UPDATE",
+			Key:                       itemKey(ctx, history.ID),
+			UpdateExpression:          "SET refunded_at = :refunded_at",
+			ConditionExpression:       conditionNotRefunded,
+			ExpressionAttributeValues: map[string]interface{}{":refunded_at": *history.RefundedAt},
 		},
+		ledgerPut(ctx, r.config, entry),
+		counterUpdate(ctx, r.config, history.PointCost, entry.CreatedAt),
+	})
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrVersionConflict
+		}
+		return &errors.DatabaseError{
+			Operation: "RefundRedemption",
+			Table:     pointTables(r.config) + "," + r.config.Tables.RewardHistory,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// SetRedemptionAttachment 報酬獲得履歴に添付ファイルのオブジェクトキーを保存
+func (r *PointRepositoryImpl) SetRedemptionAttachment(ctx context.Context, id, key string) error {
+	return setAttachmentKey(ctx, r.repo, r.config.Tables.RewardHistory, "SetRedemptionAttachment", id, key)
+}
+
+// AnnotateRedemption 報酬獲得履歴の注記とタグを置き換える（ポイント・獲得日時などは変更しない）
+func (r *PointRepositoryImpl) AnnotateRedemption(ctx context.Context, id, note string, tags []string) error {
+	if err := ValidateRedemptionAnnotation(id, tags); err != nil {
+		return err
+	}
+	if tags == nil {
+		tags = []string{}
 	}
 
-	err := r.repo.TransactWrite(transactItems)
+	err := r.repo.UpdateItemWithCondition(ctx, r.config.Tables.RewardHistory, itemKey(ctx, id), "SET note = :note, tags = :tags", conditionExists, map[string]interface{}{
+		":note": note,
+		":tags": tags,
+	})
 	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
 		return &errors.DatabaseError{
-			Operation: "TransactPointsAndHistory",
-			Table:     fmt.Sprintf("%s,%s", r.config.Tables.CurrentPoints, r.config.Tables.RewardHistory),
+			Operation: "AnnotateRedemption",
+			Table:     r.config.Tables.RewardHistory,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// ValidateRedemptionAnnotation 報酬獲得履歴の注記の変更を検証（すべてのストレージで共通）
+func ValidateRedemptionAnnotation(id string, tags []string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+	for _, tag := range tags {
+		if tag == "" {
+			return &errors.ValidationError{Field: "tags", Message: "tags cannot contain an empty tag"}
+		}
+	}
+	return nil
+}
+
+// conditionNotRefunded 報酬獲得履歴が存在し、まだ取り消していないことの条件
+const conditionNotRefunded = "attribute_exists(id) AND attribute_not_exists(refunded_at)"
+
+// PrepareRefund 取り消す報酬獲得履歴を検証し、取り消し日時が未設定の場合は現在日時を設定（すべてのストレージで共通）
+func PrepareRefund(history *models.RewardHistory) error {
+	if history == nil {
+		return &errors.ValidationError{Field: "history", Message: "history cannot be nil"}
+	}
+	if history.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+	if history.PointCost <= 0 {
+		return &errors.ValidationError{Field: "point_cost", Message: "point_cost must be positive"}
+	}
+	if history.RefundedAt == nil {
+		now := time.Now()
+		history.RefundedAt = &now
+	}
+	return nil
+}
+
+// GetLedger ポイント台帳を記録順に取得
+func (r *PointRepositoryImpl) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	return r.getLedger(ctx, "GetLedger", time.Time{}, time.Time{})
+}
+
+// GetLedgerBetween 記録日時が from 以上 to 未満のポイント台帳のエントリを記録順に取得（ゼロ値の側は制限しない）
+func (r *PointRepositoryImpl) GetLedgerBetween(ctx context.Context, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	if err := ValidateLedgerRange(from, to); err != nil {
+		return nil, err
+	}
+	return r.getLedger(ctx, "GetLedgerBetween", from, to)
+}
+
+// ledgerCreatedAtMargin 台帳の created_at をGSIのキー条件で絞り込む際に範囲を広げる幅
+//
+// created_at は書き込んだプロセスのタイムゾーンの時刻で保存されるため、文字列順と日時順はUTCとの時差（最大14時間）と
+// 小数秒の桁数の分だけずれる。キー条件は広めに指定し、正確な範囲は読み取った後に絞り込む。
+const ledgerCreatedAtMargin = 14*time.Hour + time.Second
+
+// getLedger 記録日時の範囲をGSIのキー条件で絞り込んでポイント台帳を取得
+func (r *PointRepositoryImpl) getLedger(ctx context.Context, operation string, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	input := entityTypeQuery(ctx, r.config.Tables.PointLedger, CreatedAtIndex, EntityTypePointLedger)
+	switch {
+	case !from.IsZero() && !to.IsZero():
+		input.KeyConditionExpression += " AND created_at BETWEEN :from AND :to"
+		input.ExpressionAttributeValues[":from"] = from.Add(-ledgerCreatedAtMargin).UTC().Format(time.RFC3339)
+		input.ExpressionAttributeValues[":to"] = to.Add(ledgerCreatedAtMargin).UTC().Format(time.RFC3339)
+	case !from.IsZero():
+		input.KeyConditionExpression += " AND created_at >= :from"
+		input.ExpressionAttributeValues[":from"] = from.Add(-ledgerCreatedAtMargin).UTC().Format(time.RFC3339)
+	case !to.IsZero():
+		input.KeyConditionExpression += " AND created_at <= :to"
+		input.ExpressionAttributeValues[":to"] = to.Add(ledgerCreatedAtMargin).UTC().Format(time.RFC3339)
+	}
+
+	var entries []*models.PointLedgerEntry
+	_, err := r.repo.Query(ctx, input, &entries)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: operation,
+			Table:     r.config.Tables.PointLedger,
 			Cause:     err,
 		}
 	}
 
+	result := entries[:0]
+	for _, entry := range entries {
+		if (!from.IsZero() && entry.CreatedAt.Before(from)) || (!to.IsZero() && !entry.CreatedAt.Before(to)) {
+			continue
+		}
+		entry.ID = tenant.EntityID(ctx, entry.ID)
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// ValidateRewardHistoryRange 報酬獲得履歴を取得する獲得日時の範囲のバリデーション（すべてのストレージで共通）
+func ValidateRewardHistoryRange(from, to time.Time) error {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return &errors.ValidationError{Field: "to", Message: "to must be after from"}
+	}
+	return nil
+}
+
+// ValidateLedgerRange ポイント台帳を取得する記録日時の範囲のバリデーション（すべてのストレージで共通）
+func ValidateLedgerRange(from, to time.Time) error {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return &errors.ValidationError{Field: "to", Message: "to must be after from"}
+	}
 	return nil
 }
 
-// validateRewardHistory 報酬獲得履歴のバリデーション
-func (r *PointRepositoryImpl) validateRewardHistory(history *models.RewardHistory) error {
+// ValidateRewardHistory 報酬獲得履歴のバリデーション（すべてのストレージで共通）
+func ValidateRewardHistory(history *models.RewardHistory) error {
 	if history.RewardID == "" {
 		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
 	}
@@ -203,44 +487,133 @@ func (r *PointRepositoryImpl) validateRewardHistory(history *models.RewardHistor
 }
 
 // AddPoints ポイントを加算（達成目録追加時に使用）
-func (r *PointRepositoryImpl) AddPoints(points int) error {
+func (r *PointRepositoryImpl) AddPoints(ctx context.Context, points int) error {
 	if points <= 0 {
 		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
 	}
 
-	// 現在のポイントを取得
-	currentPoints, err := r.GetCurrentPoints()
+	entry := NewLedgerEntry(models.LedgerEntryGrant, points, "")
+	err := r.repo.TransactWrite(ctx, []TransactWriteItem{ledgerPut(ctx, r.config, entry), counterUpdate(ctx, r.config, points, entry.CreatedAt)})
 	if err != nil {
-		return err
+		return &errors.DatabaseError{
+			Operation: "AddPoints",
+			Table:     pointTables(r.config),
+			Cause:     err,
+		}
 	}
 
-	// ポイントを加算
-	currentPoints.Point += points
-
-	// 更新
-	return r.UpdateCurrentPoints(currentPoints)
+	return nil
 }
 
 // SubtractPoints ポイントを減算（報酬獲得時に使用）
-func (r *PointRepositoryImpl) SubtractPoints(points int) error {
+func (r *PointRepositoryImpl) SubtractPoints(ctx context.Context, points int) error {
 	if points <= 0 {
 		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
 	}
 
-	// 現在のポイントを取得
-	currentPoints, err := r.GetCurrentPoints()
+	entry := NewLedgerEntry(models.LedgerEntrySpend, -points, "")
+	err := r.repo.TransactWrite(ctx, []TransactWriteItem{ledgerPut(ctx, r.config, entry), counterUpdate(ctx, r.config, -points, entry.CreatedAt)})
 	if err != nil {
-		return err
+		// 残高不足またはアイテム未作成（0ポイント）の場合
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrInsufficientPoints
+		}
+		return &errors.DatabaseError{
+			Operation: "SubtractPoints",
+			Table:     pointTables(r.config),
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// ledgerPut ポイント台帳にエントリを追記する書き込み
+func ledgerPut(ctx context.Context, cfg *config.Config, entry *models.PointLedgerEntry) TransactWriteItem {
+	return TransactWriteItem{
+		TableName: cfg.Tables.PointLedger,
+		Item:      newPointLedgerItem(ctx, entry),
+		Operation: "PUT",
+	}
+}
+
+// counterUpdate 読み取りを挟まずに残高をアトミックに増減する書き込み（減算は残高が足りる場合のみ）
+func counterUpdate(ctx context.Context, cfg *config.Config, delta int, now time.Time) TransactWriteItem {
+	item := TransactWriteItem{
+		TableName:                 cfg.Tables.CurrentPoints,
+		Operation:                 "UPDATE",
+		Key:                       currentPointsKey(ctx),
+		UpdateExpression:          "SET updated_at = :now ADD point :delta",
+		ExpressionAttributeValues: map[string]interface{}{":delta": delta, ":now": now},
+	}
+	if delta < 0 {
+		item.ConditionExpression = "point >= :cost"
+		item.ExpressionAttributeValues[":cost"] = -delta
+	}
+	return item
+}
+
+// conditionSpendable 取り置いていないポイントが足りる条件
+//
+// DynamoDBの条件式では属性同士を計算できない（point - reserved >= :cost と書けない）ため、読み取った取り置きの合計 :reserved を上限として固定し、
+// point >= :required（:required は必要なポイントに :reserved を足したもの）を条件にする。読み取った後に取り置きが増えていた場合は条件を満たさない。
+const conditionSpendable = "point >= :required AND (attribute_not_exists(reserved) OR reserved <= :reserved)"
+
+// redeemCounterUpdate 報酬獲得で残高から cost を減算し、獲得する報酬の取り置き release を合計から除くトランザクションの項目
+//
+// reserved は読み取った取り置きの合計（release を含む）。
+func redeemCounterUpdate(ctx context.Context, cfg *config.Config, cost, reserved, release int, now time.Time) TransactWriteItem {
+	item := counterUpdate(ctx, cfg, -cost, now)
+	delete(item.ExpressionAttributeValues, ":cost")
+	item.ConditionExpression = conditionSpendable
+	item.ExpressionAttributeValues[":required"] = cost + reserved - release
+	item.ExpressionAttributeValues[":reserved"] = reserved
+	if release > 0 {
+		item.UpdateExpression += ", reserved :release"
+		item.ExpressionAttributeValues[":release"] = -release
+	}
+	return item
+}
+
+// reservedUpdate 取り置いたポイントの合計を delta だけ増減するトランザクションの項目
+//
+// 増やす場合は、読み取った合計 reserved を上限として取り置いていないポイントが delta 以上あることを条件にする。
+func reservedUpdate(ctx context.Context, cfg *config.Config, delta, reserved int) TransactWriteItem {
+	item := TransactWriteItem{
+		TableName:                 cfg.Tables.CurrentPoints,
+		Operation:                 "UPDATE",
+		Key:                       currentPointsKey(ctx),
+		UpdateExpression:          "ADD reserved :delta",
+		ExpressionAttributeValues: map[string]interface{}{":delta": delta},
+	}
+	if delta > 0 {
+		item.ConditionExpression = conditionSpendable
+		item.ExpressionAttributeValues[":required"] = reserved + delta
+		item.ExpressionAttributeValues[":reserved"] = reserved
 	}
+	return item
+}
+
+// pointTables 残高と台帳のテーブル名（エラーメッセージ用）
+func pointTables(cfg *config.Config) string {
+	return fmt.Sprintf("%s,%s", cfg.Tables.CurrentPoints, cfg.Tables.PointLedger)
+}
 
-	// ポイントが不足していないかチェック
-	if currentPoints.Point < points {
-		return errors.ErrInsufficientPoints
+// NewLedgerEntry IDと記録日時を設定したポイント台帳のエントリを作成
+func NewLedgerEntry(entryType string, amount int, reference string) *models.PointLedgerEntry {
+	return &models.PointLedgerEntry{
+		ID:        ulid.Make().String(),
+		Type:      entryType,
+		Amount:    amount,
+		Reference: reference,
+		CreatedAt: time.Now(),
 	}
+}
 
-	// ポイントを減算
-	currentPoints.Point -= points
+// currentPointsID 現在のポイントアイテムのID（テナントごとに1件）
+const currentPointsID = "current"
 
-	// 更新
-	return r.UpdateCurrentPoints(currentPoints)
-}
\ No newline at end of file
+// currentPointsKey テナントの現在のポイントアイテムのキー
+func currentPointsKey(ctx context.Context) map[string]interface{} {
+	return itemKey(ctx, currentPointsID)
+}
@@ -1,13 +1,16 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"achievement-management/internal/config"
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
 )
 
 func TestPointRepository_GetCurrentPoints(t *testing.T) {
@@ -26,10 +29,10 @@ func TestPointRepository_GetCurrentPoints(t *testing.T) {
 		},
 	}
 
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
 	repo := NewPointRepository(mockRepo, config)
 
-	result, err := repo.GetCurrentPoints()
+	result, err := repo.GetCurrentPoints(context.Background())
 	if err != nil {
 		t.Errorf("GetCurrentPoints failed: %v", err)
 	}
@@ -39,17 +42,42 @@ func TestPointRepository_GetCurrentPoints(t *testing.T) {
 	}
 }
 
+func TestPointRepository_GetCurrentPointsConsistent(t *testing.T) {
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if points, ok := result.(*models.CurrentPoints); ok {
+				points.Point = 100
+			}
+			return nil
+		},
+	}
+
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	result, err := repo.GetCurrentPointsConsistent(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentPointsConsistent failed: %v", err)
+	}
+	if result.Point != 100 {
+		t.Errorf("Expected point 100, got %d", result.Point)
+	}
+	if mockRepo.consistentGets != 1 {
+		t.Errorf("Expected 1 consistent read, got %d", mockRepo.consistentGets)
+	}
+}
+
 func TestPointRepository_GetCurrentPoints_NotFound(t *testing.T) {
 	mockRepo := &MockRepository{
 		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			return fmt.Errorf("item not found in table test-current-points")
+			return fmt.Errorf("%w in table test-current-points", ErrItemNotFound)
 		},
 	}
 
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
 	repo := NewPointRepository(mockRepo, config)
 
-	result, err := repo.GetCurrentPoints()
+	result, err := repo.GetCurrentPoints(context.Background())
 	if err != nil {
 		t.Errorf("GetCurrentPoints should not fail when item not found: %v", err)
 	}
@@ -65,14 +93,14 @@ func TestPointRepository_GetCurrentPoints_NotFound(t *testing.T) {
 
 func TestPointRepository_UpdateCurrentPoints(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
 	repo := NewPointRepository(mockRepo, config)
 
 	points := &models.CurrentPoints{
 		Point: 150,
 	}
 
-	err := repo.UpdateCurrentPoints(points)
+	err := repo.UpdateCurrentPoints(context.Background(), points)
 	if err != nil {
 		t.Errorf("UpdateCurrentPoints failed: %v", err)
 	}
@@ -88,9 +116,85 @@ func TestPointRepository_UpdateCurrentPoints(t *testing.T) {
 	}
 }
 
+func TestPointRepository_UpdateCurrentPoints_RecordsAdjustment(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if points, ok := result.(*models.CurrentPoints); ok {
+				*points = models.CurrentPoints{ID: "current", Point: 100}
+			}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points", PointLedger: "test-point-ledger"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	err := repo.UpdateCurrentPoints(context.Background(), &models.CurrentPoints{Point: 70})
+	if err != nil {
+		t.Fatalf("UpdateCurrentPoints failed: %v", err)
+	}
+	if mockRepo.consistentGets != 1 {
+		t.Errorf("Expected current points to be read consistently, got %d consistent reads", mockRepo.consistentGets)
+	}
+	if len(written) != 2 {
+		t.Fatalf("Expected ledger and counter writes, got %d items", len(written))
+	}
+
+	// 修正前との差分が台帳に記録されることを確認
+	ledger := written[0].Item.(pointLedgerItem)
+	if ledger.Type != models.LedgerEntryAdjustment || ledger.Amount != -30 {
+		t.Errorf("Unexpected ledger entry: %+v", ledger.PointLedgerEntry)
+	}
+	counter := written[1]
+	if counter.ExpressionAttributeValues[":point"] != 70 || counter.ExpressionAttributeValues[":previous"] != 100 {
+		t.Errorf("Unexpected expression attribute values: %v", counter.ExpressionAttributeValues)
+	}
+}
+
+func TestPointRepository_UpdateCurrentPoints_Unchanged(t *testing.T) {
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if points, ok := result.(*models.CurrentPoints); ok {
+				*points = models.CurrentPoints{ID: "current", Point: 100}
+			}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			t.Error("UpdateCurrentPoints should not write when the balance is unchanged")
+			return nil
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	if err := repo.UpdateCurrentPoints(context.Background(), &models.CurrentPoints{Point: 100}); err != nil {
+		t.Errorf("UpdateCurrentPoints failed: %v", err)
+	}
+}
+
+func TestPointRepository_UpdateCurrentPoints_Conflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			// 読み取った後に残高が変わっていた
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	err := repo.UpdateCurrentPoints(context.Background(), &models.CurrentPoints{Point: 150})
+	if err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+}
+
 func TestPointRepository_UpdateCurrentPoints_ValidationError(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
 	repo := NewPointRepository(mockRepo, config)
 
 	tests := []struct {
@@ -114,7 +218,7 @@ func TestPointRepository_UpdateCurrentPoints_ValidationError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.UpdateCurrentPoints(tt.points)
+			err := repo.UpdateCurrentPoints(context.Background(), tt.points)
 			if err == nil {
 				t.Error("Expected validation error")
 			}
@@ -126,8 +230,14 @@ func TestPointRepository_UpdateCurrentPoints_ValidationError(t *testing.T) {
 }
 
 func TestPointRepository_CreateRewardHistory(t *testing.T) {
-	mockRepo := &MockRepository{}
-	config := &config.Config{RewardHistoryTable: "test-reward-history"}
+	var stored rewardHistoryItem
+	mockRepo := &MockRepository{
+		putItemFunc: func(tableName string, item interface{}) error {
+			stored = item.(rewardHistoryItem)
+			return nil
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}}
 	repo := NewPointRepository(mockRepo, config)
 
 	history := &models.RewardHistory{
@@ -136,7 +246,7 @@ func TestPointRepository_CreateRewardHistory(t *testing.T) {
 		PointCost:   50,
 	}
 
-	err := repo.CreateRewardHistory(history)
+	err := repo.CreateRewardHistory(context.Background(), history)
 	if err != nil {
 		t.Errorf("CreateRewardHistory failed: %v", err)
 	}
@@ -150,11 +260,16 @@ func TestPointRepository_CreateRewardHistory(t *testing.T) {
 	if history.RedeemedAt.IsZero() {
 		t.Error("RedeemedAt should be set")
 	}
+
+	// 獲得日時の範囲検索に使うソートキーが保存されることを確認
+	if stored.RedeemedAtKey != redeemedAtKey(history.RedeemedAt) {
+		t.Errorf("Expected redeemed_at_key %s, got %s", redeemedAtKey(history.RedeemedAt), stored.RedeemedAtKey)
+	}
 }
 
 func TestPointRepository_CreateRewardHistory_ValidationError(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{RewardHistoryTable: "test-reward-history"}
+	config := &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}}
 	repo := NewPointRepository(mockRepo, config)
 
 	tests := []struct {
@@ -207,7 +322,7 @@ func TestPointRepository_CreateRewardHistory_ValidationError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.CreateRewardHistory(tt.history)
+			err := repo.CreateRewardHistory(context.Background(), tt.history)
 			if err == nil {
 				t.Error("Expected validation error")
 			}
@@ -237,18 +352,21 @@ func TestPointRepository_GetRewardHistory(t *testing.T) {
 	}
 
 	mockRepo := &MockRepository{
-		scanFunc: func(tableName string, result interface{}) error {
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			if input.IndexName != RedeemedAtIndex {
+				t.Errorf("Expected query on %s, got %s", RedeemedAtIndex, input.IndexName)
+			}
 			if history, ok := result.(*[]*models.RewardHistory); ok {
 				*history = testHistory
 			}
-			return nil
+			return "", nil
 		},
 	}
 
-	config := &config.Config{RewardHistoryTable: "test-reward-history"}
+	config := &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}}
 	repo := NewPointRepository(mockRepo, config)
 
-	results, err := repo.GetRewardHistory()
+	results, err := repo.GetRewardHistory(context.Background())
 	if err != nil {
 		t.Errorf("GetRewardHistory failed: %v", err)
 	}
@@ -258,17 +376,113 @@ func TestPointRepository_GetRewardHistory(t *testing.T) {
 	}
 }
 
-func TestPointRepository_TransactPointsAndHistory(t *testing.T) {
-	mockRepo := &MockRepository{}
-	config := &config.Config{
-		CurrentPointsTable:  "test-current-points",
-		RewardHistoryTable: "test-reward-history",
+func TestPointRepository_GetRewardHistoryBetween(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		from          time.Time
+		to            time.Time
+		wantCondition string
+		wantValues    map[string]interface{}
+	}{
+		{
+			name:          "from and to",
+			from:          from,
+			to:            to,
+			wantCondition: "entity_type = :entity_type AND redeemed_at_key BETWEEN :from AND :to",
+			wantValues: map[string]interface{}{
+				":from": "2024-06-01T00:00:00.000000000Z",
+				":to":   "2024-06-30T23:59:59.999999999Z",
+			},
+		},
+		{
+			name:          "from only",
+			from:          from,
+			wantCondition: "entity_type = :entity_type AND redeemed_at_key >= :from",
+			wantValues:    map[string]interface{}{":from": "2024-06-01T00:00:00.000000000Z"},
+		},
+		{
+			name:          "to only",
+			to:            to,
+			wantCondition: "entity_type = :entity_type AND redeemed_at_key < :to",
+			wantValues:    map[string]interface{}{":to": "2024-07-01T00:00:00.000000000Z"},
+		},
+		{
+			name:          "unbounded",
+			wantCondition: "entity_type = :entity_type",
+			wantValues:    map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got QueryInput
+			mockRepo := &MockRepository{
+				queryFunc: func(input QueryInput, result interface{}) (string, error) {
+					got = input
+					return "", nil
+				},
+			}
+			config := &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}}
+			repo := NewPointRepository(mockRepo, config)
+
+			if _, err := repo.GetRewardHistoryBetween(context.Background(), tt.from, tt.to); err != nil {
+				t.Fatalf("GetRewardHistoryBetween failed: %v", err)
+			}
+
+			if got.IndexName != RedeemedAtIndex {
+				t.Errorf("Expected query on %s, got %s", RedeemedAtIndex, got.IndexName)
+			}
+			if got.KeyConditionExpression != tt.wantCondition {
+				t.Errorf("Expected key condition %q, got %q", tt.wantCondition, got.KeyConditionExpression)
+			}
+			for name, want := range tt.wantValues {
+				if got.ExpressionAttributeValues[name] != want {
+					t.Errorf("Expected %s = %v, got %v", name, want, got.ExpressionAttributeValues[name])
+				}
+			}
+			if len(got.ExpressionAttributeValues) != len(tt.wantValues)+1 {
+				t.Errorf("Unexpected expression values: %v", got.ExpressionAttributeValues)
+			}
+		})
 	}
+}
+
+func TestPointRepository_GetRewardHistoryBetween_InvalidRange(t *testing.T) {
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			t.Error("Query should not be called for an invalid range")
+			return "", nil
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}}
 	repo := NewPointRepository(mockRepo, config)
 
-	pointsUpdate := &models.CurrentPoints{
-		Point: 50,
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, err := repo.GetRewardHistoryBetween(context.Background(), day, day)
+	if err == nil || err.Error() != "validation error for field 'to': to must be after from" {
+		t.Errorf("Expected validation error, got %v", err)
 	}
+}
+
+func TestPointRepository_RedeemPoints(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			CurrentPoints: "test-current-points",
+			RewardHistory: "test-reward-history",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+	repo := NewPointRepository(mockRepo, config)
 
 	history := &models.RewardHistory{
 		RewardID:    "reward-123",
@@ -276,75 +490,160 @@ func TestPointRepository_TransactPointsAndHistory(t *testing.T) {
 		PointCost:   50,
 	}
 
-	err := repo.TransactPointsAndHistory(pointsUpdate, history)
+	err := repo.RedeemPoints(context.Background(), history)
 	if err != nil {
-		t.Errorf("TransactPointsAndHistory failed: %v", err)
+		t.Errorf("RedeemPoints failed: %v", err)
 	}
 
 	// IDと日時が設定されることを確認
-	if pointsUpdate.ID != "current" {
-		t.Errorf("Expected points ID 'current', got %s", pointsUpdate.ID)
-	}
-	if pointsUpdate.UpdatedAt.IsZero() {
-		t.Error("UpdatedAt should be set")
-	}
 	if history.ID == "" {
 		t.Error("History ID should be generated")
 	}
 	if history.RedeemedAt.IsZero() {
 		t.Error("RedeemedAt should be set")
 	}
+
+	// 履歴・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 3 {
+		t.Fatalf("Expected 3 transaction items, got %d", len(written))
+	}
+	if written[0].TableName != "test-reward-history" || written[0].Operation != "PUT" {
+		t.Errorf("Unexpected history item: %+v", written[0])
+	}
+	ledger, ok := written[1].Item.(pointLedgerItem)
+	if !ok || written[1].TableName != "test-point-ledger" {
+		t.Fatalf("Unexpected ledger item: %+v", written[1])
+	}
+	if ledger.Type != models.LedgerEntrySpend || ledger.Amount != -50 || ledger.Reference != history.ID {
+		t.Errorf("Unexpected ledger entry: %+v", ledger.PointLedgerEntry)
+	}
+	if ledger.EntityType != EntityTypePointLedger {
+		t.Errorf("Expected entity type %s, got %s", EntityTypePointLedger, ledger.EntityType)
+	}
+	counter := written[2]
+	if counter.TableName != "test-current-points" || counter.Operation != "UPDATE" {
+		t.Errorf("Unexpected counter item: %+v", counter)
+	}
+	// 取り置きが無い場合は獲得に必要なポイントだけを条件にする
+	if counter.ConditionExpression != conditionSpendable || counter.ExpressionAttributeValues[":delta"] != -50 ||
+		counter.ExpressionAttributeValues[":required"] != 50 || counter.ExpressionAttributeValues[":reserved"] != 0 {
+		t.Errorf("Unexpected counter update: %+v", counter)
+	}
+}
+
+func TestPointRepository_RedeemPoints_Reservations(t *testing.T) {
+	var written []TransactWriteItem
+	var reservationKeys []map[string]interface{}
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			switch tableName {
+			case "test-current-points":
+				*result.(*models.CurrentPoints) = models.CurrentPoints{Point: 500, Reserved: 350}
+			case "test-reservations":
+				reservationKeys = append(reservationKeys, key)
+				*result.(*models.Reservation) = models.Reservation{RewardID: "acme#switch", Points: 300}
+			}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			CurrentPoints: "test-current-points",
+			RewardHistory: "test-reward-history",
+			PointLedger:   "test-point-ledger",
+			Reservations:  "test-reservations",
+		},
+	}
+	repo := NewPointRepository(mockRepo, config)
+
+	history := &models.RewardHistory{RewardID: "switch", RewardTitle: "Switch", PointCost: 400}
+	if err := repo.RedeemPoints(tenant.WithID(context.Background(), "acme"), history); err != nil {
+		t.Fatalf("RedeemPoints failed: %v", err)
+	}
+
+	if len(reservationKeys) != 1 || reservationKeys[0]["id"] != "acme#switch" {
+		t.Errorf("Expected the reservation of the redeemed reward to be read, got %v", reservationKeys)
+	}
+	if len(written) != 4 {
+		t.Fatalf("Expected 4 transaction items, got %d", len(written))
+	}
+
+	// 他の報酬の取り置き（50）を除いたポイントが足りることを条件にし、獲得する報酬の取り置きを合計から除く
+	counter := written[2]
+	if counter.UpdateExpression != "SET updated_at = :now ADD point :delta, reserved :release" || counter.ConditionExpression != conditionSpendable {
+		t.Errorf("Unexpected counter update: %+v", counter)
+	}
+	if counter.ExpressionAttributeValues[":required"] != 450 || counter.ExpressionAttributeValues[":reserved"] != 350 || counter.ExpressionAttributeValues[":release"] != -300 {
+		t.Errorf("Unexpected counter values: %v", counter.ExpressionAttributeValues)
+	}
+
+	release := written[3]
+	if release.TableName != "test-reservations" || release.Operation != "DELETE" || release.ConditionExpression != "points = :points" {
+		t.Errorf("Unexpected reservation delete: %+v", release)
+	}
+	if key := release.Item.(map[string]interface{}); key["id"] != "acme#switch" || release.ExpressionAttributeValues[":points"] != 300 {
+		t.Errorf("Unexpected reservation delete key: %+v", release)
+	}
+}
+
+func TestPointRepository_RedeemPoints_InsufficientPoints(t *testing.T) {
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			// 残高不足で条件を満たさない
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points", RewardHistory: "test-reward-history"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	err := repo.RedeemPoints(context.Background(), &models.RewardHistory{
+		RewardID:    "reward-123",
+		RewardTitle: "Test Reward",
+		PointCost:   50,
+	})
+	if err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
 }
 
-func TestPointRepository_TransactPointsAndHistory_ValidationError(t *testing.T) {
+func TestPointRepository_RedeemPoints_ValidationError(t *testing.T) {
 	mockRepo := &MockRepository{}
 	config := &config.Config{
-		CurrentPointsTable:  "test-current-points",
-		RewardHistoryTable: "test-reward-history",
+		Tables: config.TableConfig{
+			CurrentPoints: "test-current-points",
+			RewardHistory: "test-reward-history",
+		},
 	}
 	repo := NewPointRepository(mockRepo, config)
 
 	tests := []struct {
-		name         string
-		pointsUpdate *models.CurrentPoints
-		history      *models.RewardHistory
-		expectedErr  string
+		name        string
+		history     *models.RewardHistory
+		expectedErr string
 	}{
 		{
-			name:         "nil pointsUpdate",
-			pointsUpdate: nil,
-			history: &models.RewardHistory{
-				RewardID:    "reward-123",
-				RewardTitle: "Test",
-				PointCost:   50,
-			},
-			expectedErr: "validation error for field 'pointsUpdate': pointsUpdate cannot be nil",
-		},
-		{
-			name: "nil history",
-			pointsUpdate: &models.CurrentPoints{
-				Point: 50,
-			},
+			name:        "nil history",
 			history:     nil,
 			expectedErr: "validation error for field 'history': history cannot be nil",
 		},
 		{
-			name: "negative points",
-			pointsUpdate: &models.CurrentPoints{
-				Point: -10,
-			},
+			name: "zero point_cost",
 			history: &models.RewardHistory{
 				RewardID:    "reward-123",
 				RewardTitle: "Test",
-				PointCost:   50,
+				PointCost:   0,
 			},
-			expectedErr: "validation error for field 'point': point cannot be negative",
+			expectedErr: "validation error for field 'point_cost': point_cost must be positive",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.TransactPointsAndHistory(tt.pointsUpdate, tt.history)
+			err := repo.RedeemPoints(context.Background(), tt.history)
 			if err == nil {
 				t.Error("Expected validation error")
 			}
@@ -355,34 +654,219 @@ func TestPointRepository_TransactPointsAndHistory_ValidationError(t *testing.T)
 	}
 }
 
-func TestPointRepository_AddPoints(t *testing.T) {
-	currentPoints := &models.CurrentPoints{
-		ID:        "current",
-		Point:     100,
-		UpdatedAt: time.Now(),
+func TestPointRepository_GetRewardHistoryByID(t *testing.T) {
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if key["id"] == "missing" {
+				return ErrItemNotFound
+			}
+			if history, ok := result.(*models.RewardHistory); ok {
+				history.ID = "history-123"
+				history.PointCost = 50
+			}
+			return nil
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	history, err := repo.GetRewardHistoryByID(context.Background(), "history-123")
+	if err != nil {
+		t.Fatalf("GetRewardHistoryByID failed: %v", err)
+	}
+	if history.ID != "history-123" || history.PointCost != 50 || history.RefundedAt != nil {
+		t.Errorf("Unexpected history: %+v", history)
+	}
+	// 取り消しの判定に使うため強い整合性で読み取る
+	if mockRepo.consistentGets != 1 {
+		t.Errorf("Expected 1 consistent read, got %d", mockRepo.consistentGets)
 	}
 
+	if _, err := repo.GetRewardHistoryByID(context.Background(), "missing"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPointRepository_RefundRedemption(t *testing.T) {
+	var written []TransactWriteItem
 	mockRepo := &MockRepository{
-		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			if points, ok := result.(*models.CurrentPoints); ok {
-				*points = *currentPoints
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	config := &config.Config{
+		Tables: config.TableConfig{
+			CurrentPoints: "test-current-points",
+			RewardHistory: "test-reward-history",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+	repo := NewPointRepository(mockRepo, config)
+
+	history := &models.RewardHistory{ID: "history-123", RewardID: "reward-123", RewardTitle: "Test Reward", PointCost: 50}
+	if err := repo.RefundRedemption(context.Background(), history); err != nil {
+		t.Fatalf("RefundRedemption failed: %v", err)
+	}
+	if history.RefundedAt == nil {
+		t.Error("RefundedAt should be set")
+	}
+
+	// 取り消し日時・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 3 {
+		t.Fatalf("Expected 3 transaction items, got %d", len(written))
+	}
+	refund := written[0]
+	if refund.TableName != "test-reward-history" || refund.Operation != "UPDATE" || refund.ConditionExpression != conditionNotRefunded {
+		t.Errorf("Unexpected history update: %+v", refund)
+	}
+	ledger, ok := written[1].Item.(pointLedgerItem)
+	if !ok || ledger.Type != models.LedgerEntryRefund || ledger.Amount != 50 || ledger.Reference != "history-123" {
+		t.Errorf("Unexpected ledger item: %+v", written[1])
+	}
+	if written[2].TableName != "test-current-points" || written[2].ExpressionAttributeValues[":delta"] != 50 {
+		t.Errorf("Unexpected counter update: %+v", written[2])
+	}
+}
+
+func TestPointRepository_RefundRedemption_AlreadyRefunded(t *testing.T) {
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
+	}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points", RewardHistory: "test-reward-history"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	err := repo.RefundRedemption(context.Background(), &models.RewardHistory{ID: "history-123", PointCost: 50})
+	if err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	if err := repo.RefundRedemption(context.Background(), &models.RewardHistory{ID: "history-123"}); err == nil {
+		t.Error("Expected validation error for history without point cost")
+	}
+}
+
+func TestPointRepository_GetLedger(t *testing.T) {
+	testEntries := []*models.PointLedgerEntry{
+		{ID: "entry-1", Type: models.LedgerEntryGrant, Amount: 100, CreatedAt: time.Now()},
+		{ID: "entry-2", Type: models.LedgerEntrySpend, Amount: -30, Reference: "history-1", CreatedAt: time.Now()},
+	}
+
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			if input.TableName != "test-point-ledger" || input.IndexName != CreatedAtIndex {
+				t.Errorf("Unexpected query: %+v", input)
+			}
+			if input.ExpressionAttributeValues[":entity_type"] != EntityTypePointLedger {
+				t.Errorf("Expected entity type %s, got %v", EntityTypePointLedger, input.ExpressionAttributeValues[":entity_type"])
+			}
+			if entries, ok := result.(*[]*models.PointLedgerEntry); ok {
+				*entries = testEntries
+			}
+			return "", nil
+		},
+	}
+
+	config := &config.Config{Tables: config.TableConfig{PointLedger: "test-point-ledger"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	entries, err := repo.GetLedger(context.Background())
+	if err != nil {
+		t.Errorf("GetLedger failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 ledger entries, got %d", len(entries))
+	}
+}
+
+func TestPointRepository_GetLedgerBetween(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	from := time.Date(2024, 6, 10, 0, 0, 0, 0, tokyo)
+	to := from.AddDate(0, 0, 1)
+
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			if input.IndexName != CreatedAtIndex || !strings.HasSuffix(input.KeyConditionExpression, " AND created_at BETWEEN :from AND :to") {
+				t.Errorf("Expected a created_at range on %s, got %+v", CreatedAtIndex, input)
+			}
+			// 保存した時刻のタイムゾーンに関係なく含まれるよう、キー条件は範囲を広げる
+			if input.ExpressionAttributeValues[":from"] != "2024-06-09T00:59:59Z" || input.ExpressionAttributeValues[":to"] != "2024-06-11T05:00:01Z" {
+				t.Errorf("Unexpected range: %v - %v", input.ExpressionAttributeValues[":from"], input.ExpressionAttributeValues[":to"])
+			}
+			if entries, ok := result.(*[]*models.PointLedgerEntry); ok {
+				*entries = []*models.PointLedgerEntry{
+					{ID: "before", Amount: 10, CreatedAt: from.Add(-time.Nanosecond)},
+					{ID: "first", Amount: 20, CreatedAt: from},
+					{ID: "utc", Amount: 30, CreatedAt: time.Date(2024, 6, 10, 14, 0, 0, 0, time.UTC)},
+					{ID: "after", Amount: 40, CreatedAt: to},
+				}
 			}
+			return "", nil
+		},
+	}
+	repo := NewPointRepository(mockRepo, &config.Config{Tables: config.TableConfig{PointLedger: "test-point-ledger"}})
+
+	entries, err := repo.GetLedgerBetween(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("GetLedgerBetween failed: %v", err)
+	}
+	// 読み取った後に正確な範囲で絞り込む
+	if len(entries) != 2 || entries[0].ID != "first" || entries[1].ID != "utc" {
+		t.Errorf("Expected the entries within the range, got %+v", entries)
+	}
+
+	if _, err := repo.GetLedgerBetween(context.Background(), to, from); err == nil {
+		t.Error("Expected an error for a reversed range")
+	}
+}
+
+func TestPointRepository_AddPoints(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			t.Error("AddPoints should not read the current points")
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
 			return nil
 		},
 	}
 
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points", PointLedger: "test-point-ledger"}}
 	repo := NewPointRepository(mockRepo, config)
 
-	err := repo.AddPoints(50)
+	err := repo.AddPoints(context.Background(), 50)
 	if err != nil {
 		t.Errorf("AddPoints failed: %v", err)
 	}
+	if len(written) != 2 {
+		t.Fatalf("Expected ledger and counter writes, got %d items", len(written))
+	}
+	ledger := written[0].Item.(pointLedgerItem)
+	if ledger.Type != models.LedgerEntryGrant || ledger.Amount != 50 {
+		t.Errorf("Unexpected ledger entry: %+v", ledger.PointLedgerEntry)
+	}
+	counter := written[1]
+	if counter.Key["id"] != "current" {
+		t.Errorf("Expected key id 'current', got %v", counter.Key["id"])
+	}
+	if counter.UpdateExpression != "SET updated_at = :now ADD point :delta" {
+		t.Errorf("Unexpected update expression: %s", counter.UpdateExpression)
+	}
+	if counter.ConditionExpression != "" {
+		t.Errorf("Expected no condition when adding points, got %s", counter.ConditionExpression)
+	}
+	if counter.ExpressionAttributeValues[":delta"] != 50 {
+		t.Errorf("Expected delta 50, got %v", counter.ExpressionAttributeValues[":delta"])
+	}
 }
 
 func TestPointRepository_AddPoints_ValidationError(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
 	repo := NewPointRepository(mockRepo, config)
 
 	tests := []struct {
@@ -404,7 +888,7 @@ func TestPointRepository_AddPoints_ValidationError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.AddPoints(tt.points)
+			err := repo.AddPoints(context.Background(), tt.points)
 			if err == nil {
 				t.Error("Expected validation error")
 			}
@@ -416,51 +900,91 @@ func TestPointRepository_AddPoints_ValidationError(t *testing.T) {
 }
 
 func TestPointRepository_SubtractPoints(t *testing.T) {
-	currentPoints := &models.CurrentPoints{
-		ID:        "current",
-		Point:     100,
-		UpdatedAt: time.Now(),
-	}
-
+	var written []TransactWriteItem
 	mockRepo := &MockRepository{
-		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			if points, ok := result.(*models.CurrentPoints); ok {
-				*points = *currentPoints
-			}
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
 			return nil
 		},
 	}
 
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points", PointLedger: "test-point-ledger"}}
 	repo := NewPointRepository(mockRepo, config)
 
-	err := repo.SubtractPoints(50)
+	err := repo.SubtractPoints(context.Background(), 50)
 	if err != nil {
 		t.Errorf("SubtractPoints failed: %v", err)
 	}
+	if len(written) != 2 {
+		t.Fatalf("Expected ledger and counter writes, got %d items", len(written))
+	}
+	ledger := written[0].Item.(pointLedgerItem)
+	if ledger.Type != models.LedgerEntrySpend || ledger.Amount != -50 {
+		t.Errorf("Unexpected ledger entry: %+v", ledger.PointLedgerEntry)
+	}
+	counter := written[1]
+	if counter.UpdateExpression != "SET updated_at = :now ADD point :delta" {
+		t.Errorf("Unexpected update expression: %s", counter.UpdateExpression)
+	}
+	if counter.ConditionExpression != "point >= :cost" {
+		t.Errorf("Unexpected condition expression: %s", counter.ConditionExpression)
+	}
+	if counter.ExpressionAttributeValues[":delta"] != -50 || counter.ExpressionAttributeValues[":cost"] != 50 {
+		t.Errorf("Unexpected expression attribute values: %v", counter.ExpressionAttributeValues)
+	}
 }
 
 func TestPointRepository_SubtractPoints_InsufficientPoints(t *testing.T) {
-	currentPoints := &models.CurrentPoints{
-		ID:        "current",
-		Point:     30, // 不足
-		UpdatedAt: time.Now(),
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			// 残高不足で条件を満たさない
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
 	}
 
+	config := &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points"}}
+	repo := NewPointRepository(mockRepo, config)
+
+	err := repo.SubtractPoints(context.Background(), 50)
+	if err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+}
+
+func TestPointRepository_TenantKeys(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "family-a")
+	var written []TransactWriteItem
 	mockRepo := &MockRepository{
 		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			if points, ok := result.(*models.CurrentPoints); ok {
-				*points = *currentPoints
+			if key["id"] != "family-a#current" {
+				t.Errorf("Expected tenant key, got %v", key["id"])
 			}
+			*result.(*models.CurrentPoints) = models.CurrentPoints{ID: "family-a#current", Point: 30}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
 			return nil
 		},
 	}
+	repo := NewPointRepository(mockRepo, &config.Config{Tables: config.TableConfig{CurrentPoints: "test-current-points", PointLedger: "test-point-ledger"}})
 
-	config := &config.Config{CurrentPointsTable: "test-current-points"}
-	repo := NewPointRepository(mockRepo, config)
+	current, err := repo.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.ID != "current" || current.Point != 30 {
+		t.Errorf("Unexpected current points: %+v", current)
+	}
 
-	err := repo.SubtractPoints(50)
-	if err != errors.ErrInsufficientPoints {
-		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	if err := repo.AddPoints(ctx, 5); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	ledger := written[0].Item.(pointLedgerItem)
+	if ledger.EntityType != "family-a#"+EntityTypePointLedger || ledger.ID[:len("family-a#")] != "family-a#" {
+		t.Errorf("Expected ledger entry under the tenant key, got id=%s entity_type=%s", ledger.ID, ledger.EntityType)
 	}
-}
\ No newline at end of file
+	if written[1].Key["id"] != "family-a#current" {
+		t.Errorf("Expected tenant counter key, got %v", written[1].Key["id"])
+	}
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// projectionExpression 取得する属性名から ProjectionExpression と属性名のプレースホルダーを作成
+//
+// 予約語（name など）と衝突しないよう、すべての属性名を #p0, #p1 ... に置き換える。
+// attributes が空の場合はすべての属性を取得するため nil を返す。
+func projectionExpression(attributes []string) (*string, map[string]string) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(attributes))
+	names := make(map[string]string, len(attributes))
+	for i, attribute := range attributes {
+		placeholder := fmt.Sprintf("#p%d", i)
+		placeholders[i] = placeholder
+		names[placeholder] = attribute
+	}
+	return aws.String(strings.Join(placeholders, ", ")), names
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// conditionNotCompleted クエストの完了の記録時（削除済み・完了済みのクエストには記録しない）
+const conditionNotCompleted = "attribute_exists(id) AND attribute_not_exists(completed_at)"
+
+// QuestRepositoryImpl クエストリポジトリの実装
+type QuestRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewQuestRepository クエストリポジトリを作成
+func NewQuestRepository(repo Repository, config *config.Config) QuestRepository {
+	return &QuestRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Create クエストを作成
+func (r *QuestRepositoryImpl) Create(ctx context.Context, quest *models.Quest) error {
+	if err := ValidateQuest(quest); err != nil {
+		return err
+	}
+
+	// IDが空の場合はULIDを生成
+	if quest.ID == "" {
+		quest.ID = ulid.Make().String()
+	}
+
+	if quest.CreatedAt.IsZero() {
+		quest.CreatedAt = time.Now()
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Quests, newQuestItem(ctx, quest), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Create",
+			Table:     r.config.Tables.Quests,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetByID IDでクエストを取得
+func (r *QuestRepositoryImpl) GetByID(ctx context.Context, id string) (*models.Quest, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	var quest models.Quest
+	err := r.repo.GetItem(ctx, r.config.Tables.Quests, itemKey(ctx, id), &quest)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{
+			Operation: "GetByID",
+			Table:     r.config.Tables.Quests,
+			Cause:     err,
+		}
+	}
+
+	quest.ID = tenant.EntityID(ctx, quest.ID)
+	return &quest, nil
+}
+
+// List すべてのクエストを作成日時順に取得
+func (r *QuestRepositoryImpl) List(ctx context.Context) ([]*models.Quest, error) {
+	var quests []*models.Quest
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Quests, CreatedAtIndex, EntityTypeQuest), &quests)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Quests,
+			Cause:     err,
+		}
+	}
+
+	for _, quest := range quests {
+		quest.ID = tenant.EntityID(ctx, quest.ID)
+	}
+	return quests, nil
+}
+
+// Delete クエストを削除（完了時に付与したボーナスは取り消さない）
+func (r *QuestRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	// 存在確認（IDのみを読み取る）
+	var item struct {
+		ID string `dynamodbav:"id"`
+	}
+	err := r.repo.GetItemWithProjection(ctx, r.config.Tables.Quests, itemKey(ctx, id), idProjection, &item)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Quests,
+			Cause:     err,
+		}
+	}
+
+	err = r.repo.DeleteItem(ctx, r.config.Tables.Quests, itemKey(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Quests,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Complete クエストの完了日時の記録と、ボーナスのポイントの付与・台帳への記録をトランザクションで実行
+//
+// 削除されたクエストや、同時に評価した別のリクエストが先に完了を記録したクエストには記録せず errors.ErrVersionConflict を返す。
+func (r *QuestRepositoryImpl) Complete(ctx context.Context, quest *models.Quest) error {
+	if err := PrepareQuestCompletion(quest); err != nil {
+		return err
+	}
+
+	items := []TransactWriteItem{
+		{
+			TableName:                 r.config.Tables.Quests,
+			Operation:                 "UPDATE",
+			Key:                       itemKey(ctx, quest.ID),
+			UpdateExpression:          "SET completed_at = :completed_at",
+			ConditionExpression:       conditionNotCompleted,
+			ExpressionAttributeValues: map[string]interface{}{":completed_at": *quest.CompletedAt},
+		},
+	}
+	if quest.BonusPoint > 0 {
+		entry := NewLedgerEntry(models.LedgerEntryBonus, quest.BonusPoint, quest.ID)
+		items = append(items, ledgerPut(ctx, r.config, entry), counterUpdate(ctx, r.config, quest.BonusPoint, entry.CreatedAt))
+	}
+
+	err := r.repo.TransactWrite(ctx, items)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrVersionConflict
+		}
+		return &errors.DatabaseError{
+			Operation: "Complete",
+			Table:     pointTables(r.config) + "," + r.config.Tables.Quests,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// ValidateQuest クエストのバリデーション（すべてのストレージで共通）
+func ValidateQuest(quest *models.Quest) error {
+	if quest == nil {
+		return &errors.ValidationError{Field: "quest", Message: "quest cannot be nil"}
+	}
+	if quest.Title == "" {
+		return &errors.ValidationError{Field: "title", Message: "title is required"}
+	}
+	if len(quest.Steps) == 0 {
+		return &errors.ValidationError{Field: "steps", Message: "steps must contain at least one achievement"}
+	}
+	for _, step := range quest.Steps {
+		if step == "" {
+			return &errors.ValidationError{Field: "steps", Message: "steps cannot contain an empty achievement id"}
+		}
+	}
+	if quest.BonusPoint < 0 {
+		return &errors.ValidationError{Field: "bonus_point", Message: "bonus_point cannot be negative"}
+	}
+	return nil
+}
+
+// PrepareQuestCompletion 完了するクエストを検証し、完了日時が未設定の場合は現在日時を設定（すべてのストレージで共通）
+func PrepareQuestCompletion(quest *models.Quest) error {
+	if quest == nil {
+		return &errors.ValidationError{Field: "quest", Message: "quest cannot be nil"}
+	}
+	if quest.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+	if quest.BonusPoint < 0 {
+		return &errors.ValidationError{Field: "bonus_point", Message: "bonus_point cannot be negative"}
+	}
+	if quest.CompletedAt == nil {
+		now := time.Now()
+		quest.CompletedAt = &now
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testQuestConfig() *config.Config {
+	return &config.Config{
+		Tables: config.TableConfig{
+			Quests:        "test-quests",
+			CurrentPoints: "test-current-points",
+			PointLedger:   "test-point-ledger",
+		},
+	}
+}
+
+func TestQuestRepository_Create(t *testing.T) {
+	var putCondition string
+	var putItem questItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			putCondition = conditionExpression
+			putItem = item.(questItem)
+			return nil
+		},
+	}
+	repo := NewQuestRepository(mockRepo, testQuestConfig())
+
+	quest := &models.Quest{Title: "朝活", Steps: []string{"wake-up", "run"}, BonusPoint: 50}
+	if err := repo.Create(tenant.WithID(context.Background(), "acme"), quest); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if quest.ID == "" || quest.CreatedAt.IsZero() {
+		t.Error("ID and CreatedAt should be set")
+	}
+	if putCondition != conditionNotExists {
+		t.Errorf("Expected condition %s, got %s", conditionNotExists, putCondition)
+	}
+	// ステップの達成目録のIDはテナントの接頭辞を付けずに保存する
+	if putItem.ID != "acme#"+quest.ID || putItem.EntityType != "acme#"+EntityTypeQuest || putItem.Steps[0] != "wake-up" {
+		t.Errorf("Expected tenant keys, got %s / %s / %v", putItem.ID, putItem.EntityType, putItem.Steps)
+	}
+}
+
+func TestQuestRepository_Create_ValidationError(t *testing.T) {
+	repo := NewQuestRepository(&MockRepository{}, testQuestConfig())
+
+	invalid := []*models.Quest{
+		nil,
+		{Steps: []string{"run"}},
+		{Title: "朝活"},
+		{Title: "朝活", Steps: []string{"run", ""}},
+		{Title: "朝活", Steps: []string{"run"}, BonusPoint: -1},
+	}
+	for _, quest := range invalid {
+		if _, ok := repo.Create(context.Background(), quest).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", quest)
+		}
+	}
+}
+
+func TestQuestRepository_Complete(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	repo := NewQuestRepository(mockRepo, testQuestConfig())
+
+	quest := &models.Quest{ID: "quest-123", Title: "朝活", Steps: []string{"run"}, BonusPoint: 50}
+	if err := repo.Complete(context.Background(), quest); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if quest.CompletedAt == nil {
+		t.Error("CompletedAt should be set")
+	}
+
+	// 完了日時・台帳・残高が1つのトランザクションで書き込まれることを確認
+	if len(written) != 3 {
+		t.Fatalf("Expected 3 transaction items, got %d", len(written))
+	}
+	if written[0].TableName != "test-quests" || written[0].Operation != "UPDATE" || written[0].ConditionExpression != conditionNotCompleted {
+		t.Errorf("Unexpected quest update: %+v", written[0])
+	}
+	ledger, ok := written[1].Item.(pointLedgerItem)
+	if !ok || ledger.Type != models.LedgerEntryBonus || ledger.Amount != 50 || ledger.Reference != "quest-123" {
+		t.Errorf("Unexpected ledger item: %+v", written[1])
+	}
+	if written[2].TableName != "test-current-points" || written[2].ExpressionAttributeValues[":delta"] != 50 {
+		t.Errorf("Unexpected counter update: %+v", written[2])
+	}
+
+	// ボーナスのないクエストは完了日時だけを記録する
+	if err := repo.Complete(context.Background(), &models.Quest{ID: "quest-456", Title: "読書", Steps: []string{"read"}}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if len(written) != 1 {
+		t.Errorf("Expected 1 transaction item, got %d", len(written))
+	}
+}
+
+func TestQuestRepository_Complete_AlreadyCompleted(t *testing.T) {
+	mockRepo := &MockRepository{
+		transactFunc: func(items []TransactWriteItem) error {
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
+	}
+	repo := NewQuestRepository(mockRepo, testQuestConfig())
+
+	err := repo.Complete(context.Background(), &models.Quest{ID: "quest-123", BonusPoint: 50})
+	if err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	if _, ok := repo.Complete(context.Background(), &models.Quest{}).(*errors.ValidationError); !ok {
+		t.Error("Expected validation error for quest without id")
+	}
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+// ReservationRepositoryImpl ポイントの取り置きリポジトリの実装
+type ReservationRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewReservationRepository ポイントの取り置きリポジトリを作成
+func NewReservationRepository(repo Repository, config *config.Config) ReservationRepository {
+	return &ReservationRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Put 報酬のためにポイントを取り置く（取り置き済みの場合は取り置くポイントを置き換える）
+//
+// 取り置きと同じトランザクションで現在のポイントの取り置きの合計を増減する。取り置いていないポイントが足りない場合は
+// errors.ErrInsufficientPoints、読み取った後に同じ報酬の取り置きが変わっていた場合は errors.ErrVersionConflict を返す。
+func (r *ReservationRepositoryImpl) Put(ctx context.Context, reservation *models.Reservation) error {
+	if err := ValidateReservation(reservation); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if reservation.CreatedAt.IsZero() {
+		reservation.CreatedAt = now
+	}
+	reservation.UpdatedAt = now
+
+	previous, err := reservedPoints(ctx, r.repo, r.config, reservation.RewardID)
+	if err != nil {
+		return err
+	}
+	var current models.CurrentPoints
+	err = r.repo.GetItemConsistent(ctx, r.config.Tables.CurrentPoints, currentPointsKey(ctx), &current)
+	if err != nil && !stderrors.Is(err, ErrItemNotFound) {
+		return &errors.DatabaseError{
+			Operation: "Put",
+			Table:     r.config.Tables.CurrentPoints,
+			Cause:     err,
+		}
+	}
+
+	// 読み取った後に同じ報酬の取り置きが変わっていた場合は合計の差分が正しくないため書き込まない
+	put := TransactWriteItem{
+		TableName:           r.config.Tables.Reservations,
+		Item:                newReservationItem(ctx, reservation),
+		Operation:           "PUT",
+		ConditionExpression: "attribute_not_exists(id)",
+	}
+	if previous > 0 {
+		put.ConditionExpression = "points = :previous"
+		put.ExpressionAttributeValues = map[string]interface{}{":previous": previous}
+	}
+	items := []TransactWriteItem{put}
+	delta := reservation.Points - previous
+	if delta != 0 {
+		items = append(items, reservedUpdate(ctx, r.config, delta, current.Reserved))
+	}
+
+	if err := r.repo.TransactWrite(ctx, items); err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			if delta > 0 {
+				return errors.ErrInsufficientPoints
+			}
+			return errors.ErrVersionConflict
+		}
+		return &errors.DatabaseError{
+			Operation: "Put",
+			Table:     r.config.Tables.Reservations + "," + r.config.Tables.CurrentPoints,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Remove 取り置きを解除し、同じトランザクションで取り置きの合計から除く（取り置いていない場合は errors.ErrNotFound）
+func (r *ReservationRepositoryImpl) Remove(ctx context.Context, rewardID string) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	points, err := reservedPoints(ctx, r.repo, r.config, rewardID)
+	if err != nil {
+		return err
+	}
+	if points == 0 {
+		return errors.ErrNotFound
+	}
+
+	err = r.repo.TransactWrite(ctx, []TransactWriteItem{
+		reservationDelete(ctx, r.config, rewardID, points),
+		reservedUpdate(ctx, r.config, -points, 0),
+	})
+	if err != nil {
+		// 読み取った後に解除・変更されていた場合
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrVersionConflict
+		}
+		return &errors.DatabaseError{
+			Operation: "Remove",
+			Table:     r.config.Tables.Reservations + "," + r.config.Tables.CurrentPoints,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// List 取り置きを取り置いた日時順に取得
+func (r *ReservationRepositoryImpl) List(ctx context.Context) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Reservations, CreatedAtIndex, EntityTypeReservation), &reservations)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Reservations,
+			Cause:     err,
+		}
+	}
+
+	for _, reservation := range reservations {
+		reservation.RewardID = tenant.EntityID(ctx, reservation.RewardID)
+	}
+	return reservations, nil
+}
+
+// reservedPoints rewardID のために取り置いたポイントを強い整合性で読み取る（取り置いていない場合は0）
+func reservedPoints(ctx context.Context, repo Repository, cfg *config.Config, rewardID string) (int, error) {
+	var reservation models.Reservation
+	err := repo.GetItemConsistent(ctx, cfg.Tables.Reservations, itemKey(ctx, rewardID), &reservation)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return 0, nil
+		}
+		return 0, &errors.DatabaseError{
+			Operation: "GetReservation",
+			Table:     cfg.Tables.Reservations,
+			Cause:     err,
+		}
+	}
+	return reservation.Points, nil
+}
+
+// reservationDelete 読み取った時から変わっていない取り置きを削除するトランザクションの項目
+func reservationDelete(ctx context.Context, cfg *config.Config, rewardID string, points int) TransactWriteItem {
+	return TransactWriteItem{
+		TableName:                 cfg.Tables.Reservations,
+		Item:                      itemKey(ctx, rewardID),
+		Operation:                 "DELETE",
+		ConditionExpression:       "points = :points",
+		ExpressionAttributeValues: map[string]interface{}{":points": points},
+	}
+}
+
+// ValidateReservation ポイントの取り置きのバリデーション（すべてのストレージで共通）
+func ValidateReservation(reservation *models.Reservation) error {
+	if reservation == nil {
+		return &errors.ValidationError{Field: "reservation", Message: "reservation cannot be nil"}
+	}
+	if reservation.RewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+	if reservation.Points <= 0 {
+		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+	return nil
+}
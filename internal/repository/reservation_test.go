@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testReservationConfig() *config.Config {
+	return &config.Config{Tables: config.TableConfig{Reservations: "test-reservations", CurrentPoints: "test-current-points"}}
+}
+
+func TestReservationRepository_Put(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if tableName == "test-current-points" {
+				*result.(*models.CurrentPoints) = models.CurrentPoints{Point: 1000, Reserved: 200}
+				return nil
+			}
+			return ErrItemNotFound
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	repo := NewReservationRepository(mockRepo, testReservationConfig())
+
+	createdAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	reservation := &models.Reservation{RewardID: "switch", Points: 300, CreatedAt: createdAt}
+	if err := repo.Put(tenant.WithID(context.Background(), "acme"), reservation); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// 取り置いた日時は保ち、更新日時を設定する
+	if !reservation.CreatedAt.Equal(createdAt) || reservation.UpdatedAt.IsZero() {
+		t.Errorf("Expected CreatedAt to be kept and UpdatedAt to be set, got %+v", reservation)
+	}
+	if len(written) != 2 {
+		t.Fatalf("Expected 2 transaction items, got %d", len(written))
+	}
+	put := written[0]
+	if put.TableName != "test-reservations" || put.ConditionExpression != "attribute_not_exists(id)" {
+		t.Errorf("Unexpected reservation put: %+v", put)
+	}
+	putItem := put.Item.(reservationItem)
+	if putItem.RewardID != "acme#switch" || putItem.EntityType != "acme#"+EntityTypeReservation {
+		t.Errorf("Expected tenant keys, got %s / %s", putItem.RewardID, putItem.EntityType)
+	}
+
+	// 取り置きの合計を同じトランザクションで増やし、取り置いていないポイントが足りることを条件にする
+	total := written[1]
+	if total.TableName != "test-current-points" || total.Key["id"] != "acme#current" || total.UpdateExpression != "ADD reserved :delta" {
+		t.Errorf("Unexpected reserved update: %+v", total)
+	}
+	if total.ConditionExpression != conditionSpendable || total.ExpressionAttributeValues[":delta"] != 300 ||
+		total.ExpressionAttributeValues[":required"] != 500 || total.ExpressionAttributeValues[":reserved"] != 200 {
+		t.Errorf("Unexpected reserved condition: %+v", total)
+	}
+}
+
+func TestReservationRepository_Put_Replace(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if tableName == "test-reservations" {
+				*result.(*models.Reservation) = models.Reservation{RewardID: "switch", Points: 300}
+			}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return fmt.Errorf("failed to execute transaction: %w", ErrConditionFailed)
+		},
+	}
+	repo := NewReservationRepository(mockRepo, testReservationConfig())
+
+	// 取り置くポイントを減らす場合は合計を減らすだけで、残高を条件にしない
+	err := repo.Put(context.Background(), &models.Reservation{RewardID: "switch", Points: 100})
+	if !stderrors.Is(err, errors.ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict when the reservation changed, got %v", err)
+	}
+	if written[0].ConditionExpression != "points = :previous" || written[0].ExpressionAttributeValues[":previous"] != 300 {
+		t.Errorf("Expected the previous reservation to be pinned, got %+v", written[0])
+	}
+	if written[1].ConditionExpression != "" || written[1].ExpressionAttributeValues[":delta"] != -200 {
+		t.Errorf("Unexpected reserved update: %+v", written[1])
+	}
+
+	// 増やす場合に条件を満たさなければ残高不足
+	err = repo.Put(context.Background(), &models.Reservation{RewardID: "switch", Points: 400})
+	if !stderrors.Is(err, errors.ErrInsufficientPoints) {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+}
+
+func TestReservationRepository_Remove(t *testing.T) {
+	var written []TransactWriteItem
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if key["id"] != "switch" {
+				return ErrItemNotFound
+			}
+			*result.(*models.Reservation) = models.Reservation{RewardID: "switch", Points: 300}
+			return nil
+		},
+		transactFunc: func(items []TransactWriteItem) error {
+			written = items
+			return nil
+		},
+	}
+	repo := NewReservationRepository(mockRepo, testReservationConfig())
+
+	if err := repo.Remove(context.Background(), "switch"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if len(written) != 2 || written[0].Operation != "DELETE" || written[0].ExpressionAttributeValues[":points"] != 300 {
+		t.Fatalf("Unexpected transaction: %+v", written)
+	}
+	if written[1].UpdateExpression != "ADD reserved :delta" || written[1].ExpressionAttributeValues[":delta"] != -300 {
+		t.Errorf("Unexpected reserved update: %+v", written[1])
+	}
+
+	if err := repo.Remove(context.Background(), "coffee"); !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestReservationRepository_Put_ValidationError(t *testing.T) {
+	repo := NewReservationRepository(&MockRepository{}, testReservationConfig())
+
+	for _, reservation := range []*models.Reservation{nil, {Points: 300}, {RewardID: "switch"}, {RewardID: "switch", Points: -1}} {
+		if _, ok := repo.Put(context.Background(), reservation).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", reservation)
+		}
+	}
+}
+
+func TestReservationRepository_List(t *testing.T) {
+	var queried QueryInput
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			queried = input
+			*result.(*[]*models.Reservation) = []*models.Reservation{{RewardID: "acme#switch", Points: 300}}
+			return "", nil
+		},
+	}
+	repo := NewReservationRepository(mockRepo, testReservationConfig())
+
+	reservations, err := repo.List(tenant.WithID(context.Background(), "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if queried.TableName != "test-reservations" || queried.IndexName != CreatedAtIndex {
+		t.Errorf("Expected query on %s of test-reservations, got %s of %s", CreatedAtIndex, queried.IndexName, queried.TableName)
+	}
+	if queried.ExpressionAttributeValues[":entity_type"] != "acme#"+EntityTypeReservation {
+		t.Errorf("Expected tenant entity type, got %v", queried.ExpressionAttributeValues[":entity_type"])
+	}
+	if len(reservations) != 1 || reservations[0].RewardID != "switch" {
+		t.Errorf("Expected reward IDs without tenant prefix, got %+v", reservations)
+	}
+}
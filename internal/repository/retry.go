@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go"
+	"github.com/oklog/ulid/v2"
+
+	appconfig "achievement-management/internal/config"
+)
+
+// maxRetryDelay 1回あたりの待機時間の上限
+const maxRetryDelay = 5 * time.Second
+
+// retryableErrorCodes スロットリングや一時的な障害を表すAWSのエラーコード
+var retryableErrorCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"ThrottlingException":                    true,
+	"RequestLimitExceeded":                   true,
+	"TransactionConflictException":           true,
+	"InternalServerError":                    true,
+	"ServiceUnavailable":                     true,
+}
+
+// serverErrorCodes 応答を返す前に処理が失敗したかどうか分からないAWSのエラーコード
+var serverErrorCodes = map[string]bool{
+	"InternalServerError": true,
+	"ServiceUnavailable":  true,
+}
+
+// throttlingErrorCodes スロットリングを表すAWSのエラーコード
+var throttlingErrorCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"ThrottlingException":                    true,
+	"RequestLimitExceeded":                   true,
+}
+
+// RetryPolicy 一時的なエラーに対するリトライ方針
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// NewRetryPolicy 設定からリトライ方針を作成
+func NewRetryPolicy(cfg appconfig.RetryConfig) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: cfg.MaxRetries,
+		BaseDelay:  time.Duration(cfg.BackoffMs) * time.Millisecond,
+		MaxDelay:   maxRetryDelay,
+	}
+}
+
+// Do 操作を実行し、リトライ可能なエラーの場合はジッター付き指数バックオフで再実行
+//
+// コンテキストの期限までに待機が終わらない場合は待たずに最後のエラーを返す。
+func (p RetryPolicy) Do(ctx context.Context, operation func() error) error {
+	return p.do(ctx, IsRetryable, operation)
+}
+
+// do 操作を実行し、retryable が true を返すエラーの場合は再実行
+func (p RetryPolicy) do(ctx context.Context, retryable func(error) bool, operation func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := operation()
+		if err == nil || attempt >= p.MaxRetries || !retryable(err) {
+			return err
+		}
+
+		delay := p.delay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// delay 試行回数に応じた待機時間（上限までの範囲でランダム）
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.BaseDelay << attempt
+	if p.MaxDelay > 0 && (backoff <= 0 || backoff > p.MaxDelay) {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return rand.N(backoff) + 1
+}
+
+// IsRetryable スロットリングや一時的な障害によるエラーかどうか
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && retryableErrorCodes[apiErr.ErrorCode()] {
+		return true
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() >= 500 {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isAmbiguous 操作が適用されたかどうか分からないエラーか（応答を受け取る前のタイムアウト・サーバーエラー）
+//
+// スロットリングなど処理する前に拒否されたエラーは、適用されていないことが分かるため含めない。
+func isAmbiguous(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && serverErrorCodes[apiErr.ErrorCode()] {
+		return true
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() >= 500 {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isRetryableOnce 1回だけ適用しなければならない操作でリトライ可能なエラーか（適用されたか分からないエラーはリトライしない）
+func isRetryableOnce(err error) bool {
+	return IsRetryable(err) && !isAmbiguous(err)
+}
+
+// addsValues 再実行すると結果が変わる更新式か（ADD で数値を加算する・集合に要素を追加する）
+func addsValues(updateExpression *string) bool {
+	for _, token := range strings.Fields(aws.ToString(updateExpression)) {
+		if strings.EqualFold(token, "ADD") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsThrottled DynamoDBのスロットリングによるエラーか判定（リトライしても解消しなかった場合を含む）
+func IsThrottled(err error) bool {
+	if errors.Is(err, ErrThrottled) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && throttlingErrorCodes[apiErr.ErrorCode()]
+}
+
+// retryCall 戻り値のある呼び出しをリトライ方針に従って実行
+func retryCall[T any](ctx context.Context, policy RetryPolicy, call func() (T, error)) (T, error) {
+	return retryCallIf(ctx, policy, IsRetryable, call)
+}
+
+// retryCallIf 戻り値のある呼び出しを実行し、retryable が true を返すエラーの場合は再実行
+func retryCallIf[T any](ctx context.Context, policy RetryPolicy, retryable func(error) bool, call func() (T, error)) (T, error) {
+	var out T
+	err := policy.do(ctx, retryable, func() error {
+		var err error
+		out, err = call()
+		return err
+	})
+	return out, err
+}
+
+// retryingClient すべての操作にリトライ方針を適用するDynamoDBクライアント
+type retryingClient struct {
+	client DynamoDBAPI
+	policy RetryPolicy
+}
+
+// newRetryingClient リトライ付きクライアントを作成
+func newRetryingClient(client DynamoDBAPI, policy RetryPolicy) DynamoDBAPI {
+	return &retryingClient{client: client, policy: policy}
+}
+
+func (c *retryingClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.PutItemOutput, error) { return c.client.PutItem(ctx, params, optFns...) })
+}
+
+func (c *retryingClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.GetItemOutput, error) { return c.client.GetItem(ctx, params, optFns...) })
+}
+
+// UpdateItem ADD で加算する更新は、適用されたか分からないエラーの場合に再実行すると二重に加算するためリトライしない
+func (c *retryingClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	retryable := IsRetryable
+	if addsValues(params.UpdateExpression) {
+		retryable = isRetryableOnce
+	}
+	return retryCallIf(ctx, c.policy, retryable, func() (*dynamodb.UpdateItemOutput, error) { return c.client.UpdateItem(ctx, params, optFns...) })
+}
+
+func (c *retryingClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.ScanOutput, error) { return c.client.Scan(ctx, params, optFns...) })
+}
+
+func (c *retryingClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.QueryOutput, error) { return c.client.Query(ctx, params, optFns...) })
+}
+
+func (c *retryingClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.DeleteItemOutput, error) { return c.client.DeleteItem(ctx, params, optFns...) })
+}
+
+// TransactWriteItems すべての試行で同じ ClientRequestToken を使用する
+//
+// SDKは呼び出しごとにトークンを作成するため、コミットした後にタイムアウトした場合などに再実行すると、
+// 台帳・現在のポイントへの加算が二重に適用される。同じトークンの再実行は10分以内であれば適用済みとして扱われる。
+func (c *retryingClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	if params.ClientRequestToken == nil {
+		withToken := *params
+		withToken.ClientRequestToken = aws.String(ulid.Make().String())
+		params = &withToken
+	}
+	return retryCall(ctx, c.policy, func() (*dynamodb.TransactWriteItemsOutput, error) {
+		return c.client.TransactWriteItems(ctx, params, optFns...)
+	})
+}
+
+func (c *retryingClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.BatchWriteItemOutput, error) { return c.client.BatchWriteItem(ctx, params, optFns...) })
+}
+
+func (c *retryingClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.BatchGetItemOutput, error) { return c.client.BatchGetItem(ctx, params, optFns...) })
+}
+
+func (c *retryingClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return retryCall(ctx, c.policy, func() (*dynamodb.DescribeTableOutput, error) { return c.client.DescribeTable(ctx, params, optFns...) })
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+
+	"achievement-management/internal/config"
+)
+
+// throttlingError スロットリングを表すエラー
+func throttlingError() error {
+	return &types.ProvisionedThroughputExceededException{Message: aws.String("throughput exceeded")}
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy := NewRetryPolicy(config.RetryConfig{MaxRetries: 4, BackoffMs: 50})
+
+	if policy.MaxRetries != 4 {
+		t.Errorf("Expected MaxRetries 4, got %d", policy.MaxRetries)
+	}
+	if policy.BaseDelay != 50*time.Millisecond {
+		t.Errorf("Expected BaseDelay 50ms, got %v", policy.BaseDelay)
+	}
+}
+
+func TestRetryPolicy_Do_RetriesThrottling(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}
+
+	calls := 0
+	err := policy.Do(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return throttlingError()
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Do failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_Do_MaxRetriesExceeded(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+
+	calls := 0
+	err := policy.Do(context.Background(), func() error {
+		calls++
+		return throttlingError()
+	})
+
+	var throughputErr *types.ProvisionedThroughputExceededException
+	if !errors.As(err, &throughputErr) {
+		t.Errorf("Expected throttling error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_Do_DoesNotRetryPermanentErrors(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "plain error", err: errors.New("validation failed")},
+		{name: "conditional check failed", err: &types.ConditionalCheckFailedException{}},
+		{name: "resource not found", err: &types.ResourceNotFoundException{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := policy.Do(context.Background(), func() error {
+				calls++
+				return tt.err
+			})
+
+			if err != tt.err {
+				t.Errorf("Expected original error, got %v", err)
+			}
+			if calls != 1 {
+				t.Errorf("Expected 1 call, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Second}
+
+	calls := 0
+	err := policy.Do(ctx, func() error {
+		calls++
+		cancel()
+		return throttlingError()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// キャンセル後はリトライしないことを確認
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_Do_StopsBeforeDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Minute, MaxDelay: time.Minute}
+
+	calls := 0
+	start := time.Now()
+	err := policy.Do(ctx, func() error {
+		calls++
+		return throttlingError()
+	})
+
+	if err == nil {
+		t.Error("Expected error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Do should not wait past the context deadline")
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := policy.delay(attempt)
+		if delay <= 0 || delay > 300*time.Millisecond {
+			t.Errorf("Attempt %d: delay %v out of range", attempt, delay)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "throughput exceeded", err: throttlingError(), expected: true},
+		{name: "wrapped throttling", err: fmt.Errorf("failed: %w", &smithy.GenericAPIError{Code: "ThrottlingException"}), expected: true},
+		{name: "internal server error", err: &types.InternalServerError{}, expected: true},
+		{name: "validation", err: &smithy.GenericAPIError{Code: "ValidationException"}, expected: false},
+		{name: "context cancelled", err: context.Canceled, expected: false},
+		{name: "plain error", err: errors.New("boom"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsThrottled(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "throughput exceeded", err: throttlingError(), expected: true},
+		{name: "wrapped throttling", err: fmt.Errorf("failed: %w", &smithy.GenericAPIError{Code: "RequestLimitExceeded"}), expected: true},
+		{name: "internal server error", err: &types.InternalServerError{}, expected: false},
+		{name: "plain error", err: errors.New("boom"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsThrottled(tt.err); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryingClient_AppliesPolicy(t *testing.T) {
+	calls := 0
+	mockClient := &MockDynamoDBClient{
+		getItemFunc: func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			calls++
+			if calls == 1 {
+				return nil, throttlingError()
+			}
+			return &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: "test-id"},
+			}}, nil
+		},
+	}
+	repo := NewDynamoDBRepositoryWithClient(newRetryingClient(mockClient, RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}))
+
+	var item TestItem
+	if err := repo.GetItem(context.Background(), "test-table", map[string]interface{}{"id": "test-id"}, &item); err != nil {
+		t.Fatalf("GetItem failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+	if item.ID != "test-id" {
+		t.Errorf("Expected ID 'test-id', got '%s'", item.ID)
+	}
+}
+
+// timeoutError 応答を受け取る前のタイムアウトを表すエラー
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryingClient_TransactWriteItemsReusesToken(t *testing.T) {
+	var tokens []string
+	mockClient := &MockDynamoDBClient{
+		transactWriteItemsFunc: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			tokens = append(tokens, aws.ToString(params.ClientRequestToken))
+			if len(tokens) == 1 {
+				// コミットした後に応答を受け取れなかった場合
+				return nil, timeoutError{}
+			}
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	client := newRetryingClient(mockClient, RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+
+	input := &dynamodb.TransactWriteItemsInput{}
+	if _, err := client.TransactWriteItems(context.Background(), input); err != nil {
+		t.Fatalf("TransactWriteItems failed: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] == "" || tokens[0] != tokens[1] {
+		t.Errorf("Expected both attempts to use the same token, got %v", tokens)
+	}
+	if input.ClientRequestToken != nil {
+		t.Error("Expected the caller's input not to be modified")
+	}
+
+	// 呼び出し側が指定したトークンはそのまま使用する
+	tokens = nil
+	if _, err := client.TransactWriteItems(context.Background(), &dynamodb.TransactWriteItemsInput{ClientRequestToken: aws.String("caller-token")}); err != nil {
+		t.Fatalf("TransactWriteItems failed: %v", err)
+	}
+	if tokens[0] != "caller-token" {
+		t.Errorf("Expected the caller's token, got %v", tokens)
+	}
+}
+
+func TestRetryingClient_UpdateItemDoesNotRepeatAmbiguousAdds(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		err        error
+		calls      int
+	}{
+		{name: "add after timeout", expression: "SET updated_at = :now ADD point :delta", err: timeoutError{}, calls: 1},
+		{name: "add after server error", expression: "SET updated_at = :now ADD point :delta", err: &types.InternalServerError{}, calls: 1},
+		{name: "add after throttling", expression: "SET updated_at = :now ADD point :delta", err: throttlingError(), calls: 2},
+		{name: "set after timeout", expression: "SET point = :point", err: timeoutError{}, calls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			mockClient := &MockDynamoDBClient{
+				updateItemFunc: func(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+					calls++
+					if calls == 1 {
+						return nil, tt.err
+					}
+					return &dynamodb.UpdateItemOutput{}, nil
+				},
+			}
+			client := newRetryingClient(mockClient, RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+
+			client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{UpdateExpression: aws.String(tt.expression)})
+			if calls != tt.calls {
+				t.Errorf("Expected %d calls, got %d", tt.calls, calls)
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package repository
 
 import (
-	"fmt"
+	"context"
+	stderrors "errors"
 	"time"
 
 	"achievement-management/internal/config"
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
 
 	"github.com/oklog/ulid/v2"
 )
@@ -26,13 +28,13 @@ func NewRewardRepository(repo Repository, config *config.Config) RewardRepositor
 }
 
 // Create 報酬を作成
-func (r *RewardRepositoryImpl) Create(reward *models.Reward) error {
+func (r *RewardRepositoryImpl) Create(ctx context.Context, reward *models.Reward) error {
 	if reward == nil {
 		return &errors.ValidationError{Field: "reward", Message: "reward cannot be nil"}
 	}
 
 	// バリデーション
-	if err := r.validateReward(reward); err != nil {
+	if err := ValidateReward(reward); err != nil {
 		return err
 	}
 
@@ -45,9 +47,13 @@ func (r *RewardRepositoryImpl) Create(reward *models.Reward) error {
 	if reward.CreatedAt.IsZero() {
 		reward.CreatedAt = time.Now()
 	}
+	reward.Version = 1
 
-	err := r.repo.PutItem(r.config.Tables.Rewards, reward)
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Rewards, newRewardItem(ctx, reward), conditionNotExists)
 	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
 		return &errors.DatabaseError{
 			Operation: "Create",
 			Table:     r.config.Tables.Rewards,
@@ -59,7 +65,7 @@ func (r *RewardRepositoryImpl) Create(reward *models.Reward) error {
 }
 
 // Update 報酬を更新
-func (r *RewardRepositoryImpl) Update(reward *models.Reward) error {
+func (r *RewardRepositoryImpl) Update(ctx context.Context, reward *models.Reward) error {
 	if reward == nil {
 		return &errors.ValidationError{Field: "reward", Message: "reward cannot be nil"}
 	}
@@ -69,12 +75,12 @@ func (r *RewardRepositoryImpl) Update(reward *models.Reward) error {
 	}
 
 	// バリデーション
-	if err := r.validateReward(reward); err != nil {
+	if err := ValidateReward(reward); err != nil {
 		return err
 	}
 
-	// 既存のアイテムが存在するかチェック
-	existing, err := r.GetByID(reward.ID)
+	// 既存のアイテムが存在するかチェック（取得した時点のバージョンを使うため強い整合性で読み取る）
+	existing, err := r.getByID(ctx, reward.ID, r.repo.GetItemConsistent)
 	if err != nil {
 		return err
 	}
@@ -82,8 +88,22 @@ func (r *RewardRepositoryImpl) Update(reward *models.Reward) error {
 	// 作成日時は元の値を保持
 	reward.CreatedAt = existing.CreatedAt
 
-	err = r.repo.PutItem(r.config.Tables.Rewards, reward)
+	// バージョンが指定されていない場合は取得した時点のバージョンから更新する
+	expectedVersion := reward.Version
+	if expectedVersion == 0 {
+		expectedVersion = existing.Version
+	}
+
+	err = r.repo.PutItemWithVersion(ctx, r.config.Tables.Rewards, newRewardItem(ctx, reward), expectedVersion)
 	if err != nil {
+		// 取得後に削除された場合
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
+		// 別の更新が先に書き込まれていた場合
+		if stderrors.Is(err, ErrVersionMismatch) {
+			return errors.ErrVersionConflict
+		}
 		return &errors.DatabaseError{
 			Operation: "Update",
 			Table:     r.config.Tables.Rewards,
@@ -91,23 +111,27 @@ func (r *RewardRepositoryImpl) Update(reward *models.Reward) error {
 		}
 	}
 
+	reward.Version = expectedVersion + 1
 	return nil
 }
 
 // GetByID IDで報酬を取得
-func (r *RewardRepositoryImpl) GetByID(id string) (*models.Reward, error) {
+func (r *RewardRepositoryImpl) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	return r.getByID(ctx, id, r.repo.GetItem)
+}
+
+// getByID 指定した読み取り方法でIDの報酬を取得
+func (r *RewardRepositoryImpl) getByID(ctx context.Context, id string, getItem itemGetter) (*models.Reward, error) {
 	if id == "" {
 		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
 	}
 
-	key := map[string]interface{}{
-		"id": id,
-	}
+	key := itemKey(ctx, id)
 
 	var reward models.Reward
-	err := r.repo.GetItem(r.config.Tables.Rewards, key, &reward)
+	err := getItem(ctx, r.config.Tables.Rewards, key, &reward)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("item not found in table %s", r.config.Tables.Rewards) {
+		if stderrors.Is(err, ErrItemNotFound) {
 			return nil, errors.ErrNotFound
 		}
 		return nil, &errors.DatabaseError{
@@ -117,13 +141,44 @@ func (r *RewardRepositoryImpl) GetByID(id string) (*models.Reward, error) {
 		}
 	}
 
+	reward.ID = tenant.EntityID(ctx, reward.ID)
 	return &reward, nil
 }
 
-// List すべての報酬を取得
-func (r *RewardRepositoryImpl) List() ([]*models.Reward, error) {
+// GetByIDs 複数のIDの報酬をまとめて取得（存在しないIDは結果に含まれない）
+func (r *RewardRepositoryImpl) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	keys := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+		}
+		keys = append(keys, itemKey(ctx, id))
+	}
+
+	if len(keys) == 0 {
+		return []*models.Reward{}, nil
+	}
+
 	var rewards []*models.Reward
-	err := r.repo.Scan(r.config.Tables.Rewards, &rewards)
+	err := r.repo.BatchGetItems(ctx, r.config.Tables.Rewards, keys, &rewards)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "GetByIDs",
+			Table:     r.config.Tables.Rewards,
+			Cause:     err,
+		}
+	}
+
+	for _, reward := range rewards {
+		reward.ID = tenant.EntityID(ctx, reward.ID)
+	}
+	return rewards, nil
+}
+
+// List すべての報酬を作成日時順に取得
+func (r *RewardRepositoryImpl) List(ctx context.Context) ([]*models.Reward, error) {
+	var rewards []*models.Reward
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Rewards, CreatedAtIndex, EntityTypeReward), &rewards)
 	if err != nil {
 		return nil, &errors.DatabaseError{
 			Operation: "List",
@@ -132,26 +187,48 @@ func (r *RewardRepositoryImpl) List() ([]*models.Reward, error) {
 		}
 	}
 
+	for _, reward := range rewards {
+		reward.ID = tenant.EntityID(ctx, reward.ID)
+	}
 	return rewards, nil
 }
 
+// Count 報酬の件数を取得（テナントを分けない場合は Repository.ItemCount のおおよその値）
+func (r *RewardRepositoryImpl) Count(ctx context.Context) (int, error) {
+	count, err := entityCount(ctx, r.repo, r.config, r.config.Tables.Rewards, CreatedAtIndex, EntityTypeReward)
+	if err != nil {
+		return 0, &errors.DatabaseError{
+			Operation: "Count",
+			Table:     r.config.Tables.Rewards,
+			Cause:     err,
+		}
+	}
+	return int(count), nil
+}
+
 // Delete 報酬を削除
-func (r *RewardRepositoryImpl) Delete(id string) error {
+func (r *RewardRepositoryImpl) Delete(ctx context.Context, id string) error {
 	if id == "" {
 		return &errors.ValidationError{Field: "id", Message: "id is required"}
 	}
 
-	// 存在確認
-	_, err := r.GetByID(id)
-	if err != nil {
-		return err
+	// 存在確認（IDのみを読み取る）
+	var item struct {
+		ID string `dynamodbav:"id"`
 	}
-
-	key := map[string]interface{}{
-		"id": id,
+	err := r.repo.GetItemWithProjection(ctx, r.config.Tables.Rewards, itemKey(ctx, id), idProjection, &item)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Delete",
+			Table:     r.config.Tables.Rewards,
+			Cause:     err,
+		}
 	}
 
-	err = r.repo.DeleteItem(r.config.Tables.Rewards, key)
+	err = r.repo.DeleteItem(ctx, r.config.Tables.Rewards, itemKey(ctx, id))
 	if err != nil {
 		return &errors.DatabaseError{
 			Operation: "Delete",
@@ -163,8 +240,8 @@ func (r *RewardRepositoryImpl) Delete(id string) error {
 	return nil
 }
 
-// validateReward 報酬のバリデーション
-func (r *RewardRepositoryImpl) validateReward(reward *models.Reward) error {
+// ValidateReward 報酬のバリデーション（すべてのストレージで共通）
+func ValidateReward(reward *models.Reward) error {
 	if reward.Title == "" {
 		return &errors.ValidationError{Field: "title", Message: "title is required"}
 	}
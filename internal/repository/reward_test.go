@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -12,7 +13,7 @@ import (
 
 func TestRewardRepository_Create(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
 	reward := &models.Reward{
@@ -21,7 +22,7 @@ func TestRewardRepository_Create(t *testing.T) {
 		Point:       50,
 	}
 
-	err := repo.Create(reward)
+	err := repo.Create(context.Background(), reward)
 	if err != nil {
 		t.Errorf("Create failed: %v", err)
 	}
@@ -39,7 +40,7 @@ func TestRewardRepository_Create(t *testing.T) {
 
 func TestRewardRepository_Create_ValidationError(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
 	tests := []struct {
@@ -80,7 +81,7 @@ func TestRewardRepository_Create_ValidationError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.Create(tt.reward)
+			err := repo.Create(context.Background(), tt.reward)
 			if err == nil {
 				t.Error("Expected validation error")
 			}
@@ -109,10 +110,10 @@ func TestRewardRepository_GetByID(t *testing.T) {
 		},
 	}
 
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
-	result, err := repo.GetByID("test-id")
+	result, err := repo.GetByID(context.Background(), "test-id")
 	if err != nil {
 		t.Errorf("GetByID failed: %v", err)
 	}
@@ -125,14 +126,14 @@ func TestRewardRepository_GetByID(t *testing.T) {
 func TestRewardRepository_GetByID_NotFound(t *testing.T) {
 	mockRepo := &MockRepository{
 		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			return fmt.Errorf("item not found in table test-rewards")
+			return fmt.Errorf("%w in table test-rewards", ErrItemNotFound)
 		},
 	}
 
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
-	_, err := repo.GetByID("non-existent-id")
+	_, err := repo.GetByID(context.Background(), "non-existent-id")
 	if err != errors.ErrNotFound {
 		t.Errorf("Expected ErrNotFound, got %v", err)
 	}
@@ -140,10 +141,10 @@ func TestRewardRepository_GetByID_NotFound(t *testing.T) {
 
 func TestRewardRepository_GetByID_EmptyID(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
-	_, err := repo.GetByID("")
+	_, err := repo.GetByID(context.Background(), "")
 	if err == nil {
 		t.Error("Expected validation error for empty ID")
 	}
@@ -168,18 +169,18 @@ func TestRewardRepository_List(t *testing.T) {
 	}
 
 	mockRepo := &MockRepository{
-		scanFunc: func(tableName string, result interface{}) error {
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
 			if rewards, ok := result.(*[]*models.Reward); ok {
 				*rewards = testRewards
 			}
-			return nil
+			return "", nil
 		},
 	}
 
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
-	results, err := repo.List()
+	results, err := repo.List(context.Background())
 	if err != nil {
 		t.Errorf("List failed: %v", err)
 	}
@@ -207,7 +208,7 @@ func TestRewardRepository_Update(t *testing.T) {
 		},
 	}
 
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
 	updatedReward := &models.Reward{
@@ -217,7 +218,7 @@ func TestRewardRepository_Update(t *testing.T) {
 		Point:       100,
 	}
 
-	err := repo.Update(updatedReward)
+	err := repo.Update(context.Background(), updatedReward)
 	if err != nil {
 		t.Errorf("Update failed: %v", err)
 	}
@@ -228,9 +229,34 @@ func TestRewardRepository_Update(t *testing.T) {
 	}
 }
 
+func TestRewardRepository_Update_VersionConflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			if reward, ok := result.(*models.Reward); ok {
+				*reward = models.Reward{ID: "test-id", Title: "Original", Point: 50, Version: 1}
+			}
+			return nil
+		},
+		versionFunc: func(tableName string, item interface{}, expectedVersion int) error {
+			return fmt.Errorf("failed to put item: %w", ErrVersionMismatch)
+		},
+	}
+
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
+	repo := NewRewardRepository(mockRepo, config)
+
+	reward := &models.Reward{ID: "test-id", Title: "Updated", Point: 100}
+	if err := repo.Update(context.Background(), reward); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+	if reward.Version != 0 {
+		t.Errorf("Expected version to be unchanged after conflict, got %d", reward.Version)
+	}
+}
+
 func TestRewardRepository_Update_ValidationError(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
 	tests := []struct {
@@ -256,7 +282,7 @@ func TestRewardRepository_Update_ValidationError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.Update(tt.reward)
+			err := repo.Update(context.Background(), tt.reward)
 			if err == nil {
 				t.Error("Expected validation error")
 			}
@@ -285,10 +311,10 @@ func TestRewardRepository_Delete(t *testing.T) {
 		},
 	}
 
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
-	err := repo.Delete("test-id")
+	err := repo.Delete(context.Background(), "test-id")
 	if err != nil {
 		t.Errorf("Delete failed: %v", err)
 	}
@@ -297,14 +323,14 @@ func TestRewardRepository_Delete(t *testing.T) {
 func TestRewardRepository_Delete_NotFound(t *testing.T) {
 	mockRepo := &MockRepository{
 		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
-			return fmt.Errorf("item not found in table test-rewards")
+			return fmt.Errorf("%w in table test-rewards", ErrItemNotFound)
 		},
 	}
 
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
-	err := repo.Delete("non-existent-id")
+	err := repo.Delete(context.Background(), "non-existent-id")
 	if err != errors.ErrNotFound {
 		t.Errorf("Expected ErrNotFound, got %v", err)
 	}
@@ -312,11 +338,43 @@ func TestRewardRepository_Delete_NotFound(t *testing.T) {
 
 func TestRewardRepository_Delete_EmptyID(t *testing.T) {
 	mockRepo := &MockRepository{}
-	config := &config.Config{RewardsTable: "test-rewards"}
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
 	repo := NewRewardRepository(mockRepo, config)
 
-	err := repo.Delete("")
+	err := repo.Delete(context.Background(), "")
 	if err == nil {
 		t.Error("Expected validation error for empty ID")
 	}
-}
\ No newline at end of file
+}
+
+func TestRewardRepository_GetByIDs(t *testing.T) {
+	mockRepo := &MockRepository{
+		batchGetFunc: func(tableName string, keys []map[string]interface{}, result interface{}) error {
+			if tableName != "test-rewards" {
+				t.Errorf("Expected table name 'test-rewards', got '%s'", tableName)
+			}
+			rewards := result.(*[]*models.Reward)
+			for _, key := range keys {
+				*rewards = append(*rewards, &models.Reward{ID: key["id"].(string), Title: "Reward", Point: 10})
+			}
+			return nil
+		},
+	}
+
+	config := &config.Config{Tables: config.TableConfig{Rewards: "test-rewards"}}
+	repo := NewRewardRepository(mockRepo, config)
+
+	rewards, err := repo.GetByIDs(context.Background(), []string{"id-1", "id-2"})
+	if err != nil {
+		t.Fatalf("GetByIDs failed: %v", err)
+	}
+	if len(rewards) != 2 || rewards[0].ID != "id-1" || rewards[1].ID != "id-2" {
+		t.Errorf("Unexpected rewards: %v", rewards)
+	}
+
+	// 空のIDが含まれる場合
+	_, err = repo.GetByIDs(context.Background(), []string{""})
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,482 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	appconfig "achievement-management/internal/config"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+// 一覧取得用のキー設計
+//
+// 各アイテムに固定値の entity_type 属性を付与し、entity_type をパーティションキー、
+// 作成日時（報酬獲得履歴は獲得日時）をソートキーとするGSIをQueryすることで、
+// テーブル全体のScanを行わずに作成日時順の一覧を取得する。
+//
+// id と entity_type はテナントごとに「{tenant_id}#{値}」とし（既定のテナントは接頭辞なし）、
+// 同じテーブルとGSIを共有しながらテナントのアイテムだけを取得する。
+const (
+	// EntityTypeAttribute 一覧取得用GSIのパーティションキー属性
+	EntityTypeAttribute = "entity_type"
+
+	// CreatedAtIndex 作成日時順に一覧を取得するGSI
+	CreatedAtIndex = "entity_type-created_at-index"
+	// RedeemedAtIndex 獲得日時順に報酬獲得履歴を取得するGSI（獲得日時の範囲をキー条件で絞り込める）
+	RedeemedAtIndex = "entity_type-redeemed_at_key-index"
+	// AchievementKeyIndex 達成目録ごとに達成記録を取得するGSI
+	AchievementKeyIndex = "achievement_key-index"
+	// EarnedAtIndex 獲得日時順にバッジを取得するGSI
+	EarnedAtIndex = "entity_type-earned_at-index"
+	// TargetKeyIndex 記録ごとにメモを作成日時順に取得するGSI
+	TargetKeyIndex = "target_key-created_at-index"
+	// DetectedAtIndex 検出日時順にポイントの差異を取得するGSI
+	DetectedAtIndex = "entity_type-detected_at-index"
+
+	// EntityTypeAchievement 達成目録のentity_type
+	EntityTypeAchievement = "ACHIEVEMENT"
+	// EntityTypeReward 報酬のentity_type
+	EntityTypeReward = "REWARD"
+	// EntityTypeRewardHistory 報酬獲得履歴のentity_type
+	EntityTypeRewardHistory = "REWARD_HISTORY"
+	// EntityTypePointLedger ポイント台帳のentity_type
+	EntityTypePointLedger = "POINT_LEDGER"
+	// EntityTypeCompletion 達成記録のentity_type
+	EntityTypeCompletion = "COMPLETION"
+	// EntityTypeBadge 獲得したバッジのentity_type
+	EntityTypeBadge = "BADGE"
+	// EntityTypeGoal 目標のentity_type
+	EntityTypeGoal = "GOAL"
+	// EntityTypeFavorite お気に入りのentity_type
+	EntityTypeFavorite = "FAVORITE"
+	// EntityTypeWishlistItem ほしいものリストのentity_type
+	EntityTypeWishlistItem = "WISHLIST_ITEM"
+	// EntityTypeNote メモのentity_type
+	EntityTypeNote = "NOTE"
+	// EntityTypeDriftEvent ポイントの差異のentity_type
+	EntityTypeDriftEvent = "DRIFT_EVENT"
+	// EntityTypeReservation ポイントの取り置きのentity_type
+	EntityTypeReservation = "RESERVATION"
+	// EntityTypeQuest クエストのentity_type
+	EntityTypeQuest = "QUEST"
+	// EntityTypeOperation 操作履歴のentity_type
+	EntityTypeOperation = "OPERATION"
+	// EntityTypeAllowance お小遣いのルールのentity_type
+	EntityTypeAllowance = "ALLOWANCE"
+	// EntityTypeAPIKey APIキーのentity_type
+	EntityTypeAPIKey = "API_KEY"
+)
+
+// 条件付き書き込みの条件式
+const (
+	// conditionNotExists 新規作成時（同じIDのアイテムを上書きしない）
+	conditionNotExists = "attribute_not_exists(id)"
+	// conditionExists 更新時（削除済みのアイテムを再作成しない）
+	conditionExists = "attribute_exists(id)"
+)
+
+// VersionAttribute 楽観的ロックに使うバージョン属性
+const VersionAttribute = "version"
+
+// RedeemedAtKeyAttribute 報酬獲得履歴の獲得日時を文字列順で比較できる形式で保存するソートキー属性
+//
+// redeemed_at はタイムゾーンと小数秒の桁数が一定でないため、文字列順が日時順にならない。
+const RedeemedAtKeyAttribute = "redeemed_at_key"
+
+// AchievementKeyAttribute 達成記録の達成目録をテナントのキーで保存する属性（AchievementKeyIndex のパーティションキー）
+const AchievementKeyAttribute = "achievement_key"
+
+// TargetKeyAttribute メモを付けた記録の種類とIDをテナントのキーで保存する属性（TargetKeyIndex のパーティションキー）
+const TargetKeyAttribute = "target_key"
+
+// redeemedAtKeyLayout redeemed_at_key の書式（UTC・ナノ秒までの固定長）
+const redeemedAtKeyLayout = "2006-01-02T15:04:05.000000000Z"
+
+// redeemedAtKey 獲得日時を redeemed_at_key の値に変換
+func redeemedAtKey(t time.Time) string {
+	return t.UTC().Format(redeemedAtKeyLayout)
+}
+
+// 一部の属性のみを読み取る場合の射影
+var (
+	// idProjection 存在確認用（IDのみ）
+	idProjection = []string{"id"}
+	// summaryProjection 件数・ポイントの集計用（ID・タイトル・ポイント・分類・難易度のみ）
+	summaryProjection = []string{"id", "title", "point", "category", "difficulty"}
+)
+
+// achievementItem DynamoDBに保存する達成目録
+type achievementItem struct {
+	*models.Achievement
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// rewardItem DynamoDBに保存する報酬
+type rewardItem struct {
+	*models.Reward
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// rewardHistoryItem DynamoDBに保存する報酬獲得履歴
+type rewardHistoryItem struct {
+	*models.RewardHistory
+	EntityType    string `dynamodbav:"entity_type"`
+	RedeemedAtKey string `dynamodbav:"redeemed_at_key"`
+}
+
+// pointLedgerItem DynamoDBに保存するポイント台帳のエントリ
+type pointLedgerItem struct {
+	*models.PointLedgerEntry
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// completionItem DynamoDBに保存する達成記録
+type completionItem struct {
+	*models.Completion
+	EntityType     string `dynamodbav:"entity_type"`
+	AchievementKey string `dynamodbav:"achievement_key"`
+}
+
+// badgeItem DynamoDBに保存する獲得したバッジ
+type badgeItem struct {
+	*models.Badge
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// goalItem DynamoDBに保存する目標
+type goalItem struct {
+	*models.Goal
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// favoriteItem DynamoDBに保存するお気に入り
+type favoriteItem struct {
+	*models.Favorite
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// wishlistItem DynamoDBに保存するほしいものリストの報酬
+type wishlistItem struct {
+	*models.WishlistItem
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// noteItem DynamoDBに保存するメモ
+type noteItem struct {
+	*models.Note
+	EntityType string `dynamodbav:"entity_type"`
+	TargetKey  string `dynamodbav:"target_key"`
+}
+
+// driftEventItem DynamoDBに保存するポイントの差異
+type driftEventItem struct {
+	*models.DriftEvent
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// reservationItem DynamoDBに保存するポイントの取り置き
+type reservationItem struct {
+	*models.Reservation
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// questItem DynamoDBに保存するクエスト
+type questItem struct {
+	*models.Quest
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// operationItem DynamoDBに保存する操作履歴
+type operationItem struct {
+	*models.Operation
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// allowanceItem DynamoDBに保存するお小遣いのルール
+type allowanceItem struct {
+	*models.Allowance
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// apiKeyItem DynamoDBに保存するAPIキー
+type apiKeyItem struct {
+	*models.APIKey
+	EntityType string `dynamodbav:"entity_type"`
+}
+
+// newAchievementItem テナントのキーでDynamoDBに保存する達成目録を作成
+func newAchievementItem(ctx context.Context, achievement *models.Achievement) achievementItem {
+	stored := *achievement
+	stored.ID = tenant.Key(ctx, achievement.ID)
+	return achievementItem{Achievement: &stored, EntityType: tenant.Key(ctx, EntityTypeAchievement)}
+}
+
+// newRewardItem テナントのキーでDynamoDBに保存する報酬を作成
+func newRewardItem(ctx context.Context, reward *models.Reward) rewardItem {
+	stored := *reward
+	stored.ID = tenant.Key(ctx, reward.ID)
+	return rewardItem{Reward: &stored, EntityType: tenant.Key(ctx, EntityTypeReward)}
+}
+
+// newRewardHistoryItem テナントのキーでDynamoDBに保存する報酬獲得履歴を作成
+func newRewardHistoryItem(ctx context.Context, history *models.RewardHistory) rewardHistoryItem {
+	stored := *history
+	stored.ID = tenant.Key(ctx, history.ID)
+	return rewardHistoryItem{
+		RewardHistory: &stored,
+		EntityType:    tenant.Key(ctx, EntityTypeRewardHistory),
+		RedeemedAtKey: redeemedAtKey(history.RedeemedAt),
+	}
+}
+
+// newPointLedgerItem テナントのキーでDynamoDBに保存するポイント台帳のエントリを作成
+func newPointLedgerItem(ctx context.Context, entry *models.PointLedgerEntry) pointLedgerItem {
+	stored := *entry
+	stored.ID = tenant.Key(ctx, entry.ID)
+	return pointLedgerItem{PointLedgerEntry: &stored, EntityType: tenant.Key(ctx, EntityTypePointLedger)}
+}
+
+// newCompletionItem テナントのキーでDynamoDBに保存する達成記録を作成
+func newCompletionItem(ctx context.Context, completion *models.Completion) completionItem {
+	stored := *completion
+	stored.ID = tenant.Key(ctx, completion.ID)
+	return completionItem{
+		Completion:     &stored,
+		EntityType:     tenant.Key(ctx, EntityTypeCompletion),
+		AchievementKey: tenant.Key(ctx, completion.AchievementID),
+	}
+}
+
+// newBadgeItem テナントのキーでDynamoDBに保存する獲得したバッジを作成
+func newBadgeItem(ctx context.Context, badge *models.Badge) badgeItem {
+	stored := *badge
+	stored.ID = tenant.Key(ctx, badge.ID)
+	return badgeItem{Badge: &stored, EntityType: tenant.Key(ctx, EntityTypeBadge)}
+}
+
+// newGoalItem テナントのキーでDynamoDBに保存する目標を作成
+func newGoalItem(ctx context.Context, goal *models.Goal) goalItem {
+	stored := *goal
+	stored.ID = tenant.Key(ctx, goal.ID)
+	return goalItem{Goal: &stored, EntityType: tenant.Key(ctx, EntityTypeGoal)}
+}
+
+// newFavoriteItem テナントのキーでDynamoDBに保存するお気に入りを作成
+func newFavoriteItem(ctx context.Context, favorite *models.Favorite) favoriteItem {
+	stored := *favorite
+	stored.RewardID = tenant.Key(ctx, favorite.RewardID)
+	return favoriteItem{Favorite: &stored, EntityType: tenant.Key(ctx, EntityTypeFavorite)}
+}
+
+// newWishlistItem テナントのキーでDynamoDBに保存するほしいものリストの報酬を作成
+func newWishlistItem(ctx context.Context, item *models.WishlistItem) wishlistItem {
+	stored := *item
+	stored.RewardID = tenant.Key(ctx, item.RewardID)
+	return wishlistItem{WishlistItem: &stored, EntityType: tenant.Key(ctx, EntityTypeWishlistItem)}
+}
+
+// newNoteItem テナントのキーでDynamoDBに保存するメモを作成
+func newNoteItem(ctx context.Context, note *models.Note) noteItem {
+	stored := *note
+	stored.ID = tenant.Key(ctx, note.ID)
+	return noteItem{
+		Note:       &stored,
+		EntityType: tenant.Key(ctx, EntityTypeNote),
+		TargetKey:  noteTargetKey(ctx, note.TargetType, note.TargetID),
+	}
+}
+
+// newDriftEventItem テナントのキーでDynamoDBに保存するポイントの差異を作成
+func newDriftEventItem(ctx context.Context, event *models.DriftEvent) driftEventItem {
+	stored := *event
+	stored.ID = tenant.Key(ctx, event.ID)
+	return driftEventItem{DriftEvent: &stored, EntityType: tenant.Key(ctx, EntityTypeDriftEvent)}
+}
+
+// newReservationItem テナントのキーでDynamoDBに保存するポイントの取り置きを作成
+func newReservationItem(ctx context.Context, reservation *models.Reservation) reservationItem {
+	stored := *reservation
+	stored.RewardID = tenant.Key(ctx, reservation.RewardID)
+	return reservationItem{Reservation: &stored, EntityType: tenant.Key(ctx, EntityTypeReservation)}
+}
+
+// newQuestItem テナントのキーでDynamoDBに保存するクエストを作成
+func newQuestItem(ctx context.Context, quest *models.Quest) questItem {
+	stored := *quest
+	stored.ID = tenant.Key(ctx, quest.ID)
+	return questItem{Quest: &stored, EntityType: tenant.Key(ctx, EntityTypeQuest)}
+}
+
+// newOperationItem テナントのキーでDynamoDBに保存する操作履歴を作成
+func newOperationItem(ctx context.Context, operation *models.Operation) operationItem {
+	stored := *operation
+	stored.ID = tenant.Key(ctx, operation.ID)
+	return operationItem{Operation: &stored, EntityType: tenant.Key(ctx, EntityTypeOperation)}
+}
+
+// newAllowanceItem テナントのキーでDynamoDBに保存するお小遣いのルールを作成
+func newAllowanceItem(ctx context.Context, allowance *models.Allowance) allowanceItem {
+	stored := *allowance
+	stored.ID = tenant.Key(ctx, allowance.ID)
+	return allowanceItem{Allowance: &stored, EntityType: tenant.Key(ctx, EntityTypeAllowance)}
+}
+
+// newAPIKeyItem テナントのキーでDynamoDBに保存するAPIキーを作成
+func newAPIKeyItem(ctx context.Context, key *models.APIKey) apiKeyItem {
+	stored := *key
+	stored.ID = tenant.Key(ctx, key.ID)
+	return apiKeyItem{APIKey: &stored, EntityType: tenant.Key(ctx, EntityTypeAPIKey)}
+}
+
+// noteTargetKey メモを付けた記録の target_key の値（「{種類}#{ID}」をテナントのキーにする）
+func noteTargetKey(ctx context.Context, targetType models.NoteTargetType, targetID string) string {
+	return tenant.Key(ctx, string(targetType)+"#"+targetID)
+}
+
+// itemKey テナントのアイテムのキー
+func itemKey(ctx context.Context, id string) map[string]interface{} {
+	return map[string]interface{}{
+		"id": tenant.Key(ctx, id),
+	}
+}
+
+// entityCount テナントのアイテム数を取得
+//
+// テナントを分けない場合はテーブルのおおよそのアイテム数（DescribeTable）を返し、
+// tenancy.enabled の場合はテーブルを共有する他のテナントを含めないよう、entity_type のGSIをCOUNTでQueryする。
+func entityCount(ctx context.Context, repo Repository, cfg *appconfig.Config, tableName, indexName, entityType string) (int64, error) {
+	if !cfg.Tenancy.Enabled {
+		return repo.ItemCount(ctx, tableName)
+	}
+	return repo.QueryCount(ctx, entityTypeQuery(ctx, tableName, indexName, entityType))
+}
+
+// entityTypeQuery テナントの entity_type を指定してGSIを作成日時順にQueryする入力を作成
+func entityTypeQuery(ctx context.Context, tableName, indexName, entityType string) QueryInput {
+	return QueryInput{
+		TableName:              tableName,
+		IndexName:              indexName,
+		KeyConditionExpression: EntityTypeAttribute + " = :entity_type",
+		ExpressionAttributeValues: map[string]interface{}{
+			":entity_type": tenant.Key(ctx, entityType),
+		},
+	}
+}
+
+// BackfillEntityTypes entity_type 属性を持たない既存のアイテムに属性を付与し、更新した件数を返す
+func BackfillEntityTypes(ctx context.Context, repo Repository, cfg *appconfig.Config) (int, error) {
+	targets := []struct {
+		table      string
+		entityType string
+	}{
+		{cfg.Tables.Achievements, EntityTypeAchievement},
+		{cfg.Tables.Rewards, EntityTypeReward},
+		{cfg.Tables.RewardHistory, EntityTypeRewardHistory},
+	}
+
+	updated := 0
+	for _, target := range targets {
+		err := repo.ScanEach(ctx, ScanInput{TableName: target.table}, func(item Item) error {
+			var attributes map[string]interface{}
+			if err := item.Unmarshal(&attributes); err != nil {
+				return err
+			}
+			if _, ok := attributes[EntityTypeAttribute]; ok {
+				return nil
+			}
+
+			key := map[string]interface{}{"id": attributes["id"]}
+			err := repo.UpdateItem(ctx, target.table, key, "SET "+EntityTypeAttribute+" = :entity_type", map[string]interface{}{
+				":entity_type": target.entityType,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to backfill item %v in table %s: %w", attributes["id"], target.table, err)
+			}
+			updated++
+			return nil
+		})
+		if err != nil {
+			return updated, err
+		}
+	}
+
+	return updated, nil
+}
+
+// BackfillRedeemedAtKeys redeemed_at_key 属性を持たない既存の報酬獲得履歴に属性を付与し、更新した件数を返す
+func BackfillRedeemedAtKeys(ctx context.Context, repo Repository, cfg *appconfig.Config) (int, error) {
+	tableName := cfg.Tables.RewardHistory
+
+	updated := 0
+	err := repo.ScanEach(ctx, ScanInput{TableName: tableName}, func(item Item) error {
+		var history struct {
+			ID            string    `dynamodbav:"id"`
+			RedeemedAt    time.Time `dynamodbav:"redeemed_at"`
+			RedeemedAtKey string    `dynamodbav:"redeemed_at_key"`
+		}
+		if err := item.Unmarshal(&history); err != nil {
+			return err
+		}
+		if history.RedeemedAtKey != "" {
+			return nil
+		}
+
+		// 走査中に削除されたアイテムは再作成しない
+		key := map[string]interface{}{"id": history.ID}
+		err := repo.UpdateItemWithCondition(ctx, tableName, key, "SET "+RedeemedAtKeyAttribute+" = :redeemed_at_key", conditionExists, map[string]interface{}{
+			":redeemed_at_key": redeemedAtKey(history.RedeemedAt),
+		})
+		if err != nil && !errors.Is(err, ErrConditionFailed) {
+			return fmt.Errorf("failed to backfill item %s in table %s: %w", history.ID, tableName, err)
+		}
+		if err == nil {
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return updated, err
+	}
+
+	return updated, nil
+}
+
+// BackfillReservedPoints 既存の取り置きからテナントごとに取り置いたポイントの合計を求め、現在のポイントの reserved 属性に書き込んだ件数を返す
+//
+// 取り置き・解除と同時に実行すると合計がずれるため、書き込みを止めてから実行する。
+func BackfillReservedPoints(ctx context.Context, repo Repository, cfg *appconfig.Config) (int, error) {
+	// 取り置きの entity_type の接頭辞（テナントのキーの接頭辞）ごとの合計
+	totals := map[string]int{}
+	err := repo.ScanEach(ctx, ScanInput{TableName: cfg.Tables.Reservations}, func(item Item) error {
+		var reservation struct {
+			Points     int    `dynamodbav:"points"`
+			EntityType string `dynamodbav:"entity_type"`
+		}
+		if err := item.Unmarshal(&reservation); err != nil {
+			return err
+		}
+		prefix := strings.TrimSuffix(reservation.EntityType, EntityTypeReservation)
+		totals[prefix] += reservation.Points
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for prefix, reserved := range totals {
+		key := map[string]interface{}{"id": prefix + currentPointsID}
+		if err := repo.UpdateItem(ctx, cfg.Tables.CurrentPoints, key, "SET reserved = :reserved", map[string]interface{}{
+			":reserved": reserved,
+		}); err != nil {
+			return updated, fmt.Errorf("failed to backfill item %s in table %s: %w", key["id"], cfg.Tables.CurrentPoints, err)
+		}
+		updated++
+	}
+
+	return updated, nil
+}
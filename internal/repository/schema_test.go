@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+
+	"achievement-management/internal/config"
+)
+
+func TestBackfillEntityTypes(t *testing.T) {
+	tableItems := map[string][]map[string]interface{}{
+		"test-achievements": {
+			{"id": "a1"},
+			{"id": "a2", "entity_type": EntityTypeAchievement},
+		},
+		"test-reward-history": {{"id": "h1"}},
+	}
+
+	updated := map[string]string{}
+	mockRepo := &MockRepository{
+		scanEachFunc: func(input ScanInput, fn ItemHandler) error {
+			for _, attributes := range tableItems[input.TableName] {
+				av, err := attributevalue.MarshalMap(attributes)
+				if err != nil {
+					return err
+				}
+				if err := fn(Item{av: av}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		updateItemFunc: func(tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error {
+			updated[key["id"].(string)] = expressionAttributeValues[":entity_type"].(string)
+			return nil
+		},
+	}
+
+	cfg := &config.Config{Tables: config.TableConfig{
+		Achievements:  "test-achievements",
+		Rewards:       "test-rewards",
+		RewardHistory: "test-reward-history",
+	}}
+
+	count, err := BackfillEntityTypes(context.Background(), mockRepo, cfg)
+	if err != nil {
+		t.Fatalf("BackfillEntityTypes failed: %v", err)
+	}
+
+	// entity_type を持たないアイテムのみ更新されることを確認
+	if count != 2 {
+		t.Errorf("Expected 2 updated items, got %d", count)
+	}
+	if updated["a1"] != EntityTypeAchievement || updated["h1"] != EntityTypeRewardHistory {
+		t.Errorf("Unexpected updates: %v", updated)
+	}
+	if _, ok := updated["a2"]; ok {
+		t.Error("Item with entity_type should not be updated")
+	}
+}
+
+func TestRedeemedAtKey_SortsChronologically(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	// RFC3339Nano の文字列順では逆転する組み合わせ（タイムゾーン違い・小数秒の桁数違い）
+	earlier := []time.Time{
+		time.Date(2024, 6, 1, 9, 0, 0, 0, jst),
+		time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	later := []time.Time{
+		time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 1, 0, 0, 0, 500, time.UTC),
+	}
+
+	for i := range earlier {
+		if redeemedAtKey(earlier[i]) >= redeemedAtKey(later[i]) {
+			t.Errorf("Expected %q to sort before %q", redeemedAtKey(earlier[i]), redeemedAtKey(later[i]))
+		}
+	}
+	if key := redeemedAtKey(earlier[0]); key != "2024-06-01T00:00:00.000000000Z" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+}
+
+func TestBackfillRedeemedAtKeys(t *testing.T) {
+	items := []map[string]interface{}{
+		{"id": "h1", "redeemed_at": "2024-06-01T09:00:00+09:00"},
+		{"id": "h2", "redeemed_at": "2024-06-02T00:00:00Z", "redeemed_at_key": "2024-06-02T00:00:00.000000000Z"},
+		{"id": "h3", "redeemed_at": "2024-06-03T00:00:00Z"},
+	}
+
+	updated := map[string]string{}
+	mockRepo := &MockRepository{
+		scanEachFunc: func(input ScanInput, fn ItemHandler) error {
+			if input.TableName != "test-reward-history" {
+				t.Errorf("Expected scan on test-reward-history, got %s", input.TableName)
+			}
+			for _, attributes := range items {
+				av, err := attributevalue.MarshalMap(attributes)
+				if err != nil {
+					return err
+				}
+				if err := fn(Item{av: av}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			// 走査中に削除されたアイテムは条件付き更新が失敗する
+			if key["id"] == "h3" {
+				return ErrConditionFailed
+			}
+			updated[key["id"].(string)] = expressionAttributeValues[":redeemed_at_key"].(string)
+			return nil
+		},
+	}
+
+	cfg := &config.Config{Tables: config.TableConfig{RewardHistory: "test-reward-history"}}
+
+	count, err := BackfillRedeemedAtKeys(context.Background(), mockRepo, cfg)
+	if err != nil {
+		t.Fatalf("BackfillRedeemedAtKeys failed: %v", err)
+	}
+
+	// redeemed_at_key を持たず、削除されていないアイテムのみ更新されることを確認
+	if count != 1 {
+		t.Errorf("Expected 1 updated item, got %d", count)
+	}
+	if updated["h1"] != "2024-06-01T00:00:00.000000000Z" {
+		t.Errorf("Unexpected updates: %v", updated)
+	}
+	if _, ok := updated["h2"]; ok {
+		t.Error("Item with redeemed_at_key should not be updated")
+	}
+}
+
+func TestBackfillReservedPoints(t *testing.T) {
+	items := []map[string]interface{}{
+		{"id": "switch", "points": 300, "entity_type": EntityTypeReservation},
+		{"id": "coffee", "points": 50, "entity_type": EntityTypeReservation},
+		{"id": "acme#book", "points": 120, "entity_type": "acme#" + EntityTypeReservation},
+	}
+
+	updated := map[string]interface{}{}
+	mockRepo := &MockRepository{
+		scanEachFunc: func(input ScanInput, fn ItemHandler) error {
+			if input.TableName != "test-reservations" {
+				t.Errorf("Expected scan on test-reservations, got %s", input.TableName)
+			}
+			for _, attributes := range items {
+				av, err := attributevalue.MarshalMap(attributes)
+				if err != nil {
+					return err
+				}
+				if err := fn(Item{av: av}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		updateItemFunc: func(tableName string, key map[string]interface{}, updateExpression string, expressionAttributeValues map[string]interface{}) error {
+			if tableName != "test-current-points" || updateExpression != "SET reserved = :reserved" {
+				t.Errorf("Unexpected update %s on %s", updateExpression, tableName)
+			}
+			updated[key["id"].(string)] = expressionAttributeValues[":reserved"]
+			return nil
+		},
+	}
+
+	cfg := &config.Config{Tables: config.TableConfig{Reservations: "test-reservations", CurrentPoints: "test-current-points"}}
+
+	count, err := BackfillReservedPoints(context.Background(), mockRepo, cfg)
+	if err != nil {
+		t.Fatalf("BackfillReservedPoints failed: %v", err)
+	}
+
+	// テナントごとの合計を現在のポイントのアイテムに書き込む
+	if count != 2 || updated["current"] != 350 || updated["acme#current"] != 120 {
+		t.Errorf("Unexpected updates (%d): %v", count, updated)
+	}
+}
@@ -0,0 +1,530 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// AchievementRepository SQLデータベースを使用した達成目録リポジトリ
+type AchievementRepository struct {
+	db *DB
+}
+
+// NewAchievementRepository 達成目録リポジトリを作成
+func NewAchievementRepository(db *DB) repository.AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+// Create 達成目録を作成
+func (r *AchievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.prepare(achievement); err != nil {
+		return err
+	}
+
+	err := r.insert(ctx, r.db.db, achievement)
+	if err == errors.ErrDuplicateResource {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: achievementsTable, Cause: err}
+	}
+	return nil
+}
+
+// CreateWithPoints 達成目録の作成・ポイント加算・台帳への記録をトランザクションで実行
+func (r *AchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if err := r.prepare(achievement); err != nil {
+		return err
+	}
+
+	entry := r.db.newLedgerEntry(models.LedgerEntryGrant, achievement.Point, achievement.ID)
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		if err := r.insert(ctx, tx, achievement); err != nil {
+			return err
+		}
+		return r.db.addToBalance(ctx, tx, entry)
+	})
+	if err == errors.ErrDuplicateResource {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "CreateWithPoints", Table: achievementsTable + "," + pointTables, Cause: err}
+	}
+	return nil
+}
+
+// prepare 作成する達成目録を検証し、ID・作成日時・バージョンを設定
+func (r *AchievementRepository) prepare(achievement *models.Achievement) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+
+	if err := repository.ValidateAchievement(achievement); err != nil {
+		return err
+	}
+
+	if achievement.ID == "" {
+		achievement.ID = ulid.Make().String()
+	}
+	if achievement.CreatedAt.IsZero() {
+		achievement.CreatedAt = time.Now()
+	}
+	achievement.CreatedAt = r.db.truncate(achievement.CreatedAt)
+	achievement.Version = 1
+	return nil
+}
+
+// insert 達成目録を書き込み（同じIDがある場合は ErrDuplicateResource）
+func (r *AchievementRepository) insert(ctx context.Context, ex execer, achievement *models.Achievement) error {
+	result, err := r.db.execWith(ctx, ex,
+		`INSERT INTO achievements (id, tenant_id, title, description, point, category, created_at, version, due_date, reminder, difficulty, max_per_day, max_total, pinned, sort_order, points_per_hour, timer_started_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, achievement.ID), tenant.FromContext(ctx), achievement.Title, achievement.Description, achievement.Point, achievement.Category, achievement.CreatedAt, achievement.Version, achievement.DueDate, achievement.Reminder, achievement.Difficulty, achievement.MaxPerDay, achievement.MaxTotal, achievement.Pinned, achievement.SortOrder, achievement.PointsPerHour, achievement.TimerStartedAt)
+	if err != nil {
+		return err
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// Update 達成目録を更新
+func (r *AchievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+
+	if achievement.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	_, expectedVersion, err := r.prepareUpdate(ctx, achievement)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.updateVersioned(ctx, r.db.db, achievement, expectedVersion)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Update", Table: achievementsTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return r.updateConflict(ctx, achievement.ID)
+	}
+
+	achievement.Version = expectedVersion + 1
+	return nil
+}
+
+// UpdateWithPoints 達成目録を更新し、ポイントの差分を同じトランザクションで残高と台帳に反映
+func (r *AchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+
+	if achievement.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	existing, expectedVersion, err := r.prepareUpdate(ctx, achievement)
+	if err != nil {
+		return err
+	}
+
+	delta := achievement.Point - existing.Point
+	entryType := models.LedgerEntryGrant
+	if delta < 0 {
+		entryType = models.LedgerEntryRevoke
+	}
+	entry := r.db.newLedgerEntry(entryType, delta, achievement.ID)
+
+	err = r.db.withTx(ctx, func(tx *sql.Tx) error {
+		// 差分は読み取った時点のポイントから計算しているため、バージョンが進んでいれば更新しない
+		result, err := r.updateVersioned(ctx, tx, achievement, expectedVersion)
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+			return errors.ErrVersionConflict
+		}
+		if delta == 0 {
+			return nil
+		}
+		return r.db.addToBalance(ctx, tx, entry)
+	})
+	if err == errors.ErrVersionConflict {
+		return r.updateConflict(ctx, achievement.ID)
+	}
+	if err == errors.ErrInsufficientPoints {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "UpdateWithPoints", Table: achievementsTable + "," + pointTables, Cause: err}
+	}
+
+	achievement.Version = expectedVersion + 1
+	return nil
+}
+
+// prepareUpdate 更新内容を検証し、既存の達成目録と更新の前提とするバージョンを返す
+func (r *AchievementRepository) prepareUpdate(ctx context.Context, achievement *models.Achievement) (*models.Achievement, int, error) {
+	if err := repository.ValidateAchievement(achievement); err != nil {
+		return nil, 0, err
+	}
+
+	existing, err := r.GetByID(ctx, achievement.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 作成日時は元の値を保持
+	achievement.CreatedAt = existing.CreatedAt
+
+	// バージョンが指定されていない場合は取得した時点のバージョンから更新する
+	expectedVersion := achievement.Version
+	if expectedVersion == 0 {
+		expectedVersion = existing.Version
+	}
+
+	return existing, expectedVersion, nil
+}
+
+// updateVersioned 保存済みのバージョンが expectedVersion の場合のみ達成目録を更新
+func (r *AchievementRepository) updateVersioned(ctx context.Context, ex execer, achievement *models.Achievement, expectedVersion int) (sql.Result, error) {
+	return r.db.execWith(ctx, ex,
+		`UPDATE achievements SET title = ?, description = ?, point = ?, category = ?, due_date = ?, reminder = ?, difficulty = ?, max_per_day = ?, max_total = ?, pinned = ?, sort_order = ?, points_per_hour = ?, version = version + 1 WHERE id = ? AND version = ?`,
+		achievement.Title, achievement.Description, achievement.Point, achievement.Category, achievement.DueDate, achievement.Reminder, achievement.Difficulty, achievement.MaxPerDay, achievement.MaxTotal, achievement.Pinned, achievement.SortOrder, achievement.PointsPerHour, tenant.Key(ctx, achievement.ID), expectedVersion)
+}
+
+// updateConflict 更新対象の行がなかった理由を判定
+func (r *AchievementRepository) updateConflict(ctx context.Context, id string) error {
+	// 取得後に削除された場合
+	if _, err := r.GetByID(ctx, id); stderrors.Is(err, errors.ErrNotFound) {
+		return errors.ErrNotFound
+	}
+	// 別の更新が先に書き込まれていた場合
+	return errors.ErrVersionConflict
+}
+
+// GetByID IDで達成目録を取得
+func (r *AchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	row := r.db.queryRow(ctx,
+		`SELECT id, title, description, point, category, created_at, version, attachment_key, due_date, reminder, difficulty, max_per_day, max_total, pinned, sort_order, points_per_hour, timer_started_at FROM achievements WHERE id = ?`, tenant.Key(ctx, id))
+	achievement, err := scanAchievement(ctx, row)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetByID", Table: achievementsTable, Cause: err}
+	}
+
+	return achievement, nil
+}
+
+// List すべての達成目録を作成日時順に取得
+func (r *AchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, title, description, point, category, created_at, version, attachment_key, due_date, reminder, difficulty, max_per_day, max_total, pinned, sort_order, points_per_hour, timer_started_at FROM achievements WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: achievementsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	achievements := []*models.Achievement{}
+	for rows.Next() {
+		achievement, err := scanAchievement(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: achievementsTable, Cause: err}
+		}
+		achievements = append(achievements, achievement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: achievementsTable, Cause: err}
+	}
+
+	return achievements, nil
+}
+
+// ListSummaries すべての達成目録のID・タイトル・ポイント・分類・難易度のみを作成日時順に取得
+func (r *AchievementRepository) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, title, point, category, difficulty FROM achievements WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "ListSummaries", Table: achievementsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	achievements := []*models.Achievement{}
+	for rows.Next() {
+		var achievement models.Achievement
+		if err := rows.Scan(&achievement.ID, &achievement.Title, &achievement.Point, &achievement.Category, &achievement.Difficulty); err != nil {
+			return nil, &errors.DatabaseError{Operation: "ListSummaries", Table: achievementsTable, Cause: err}
+		}
+		achievement.ID = tenant.EntityID(ctx, achievement.ID)
+		achievements = append(achievements, &achievement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "ListSummaries", Table: achievementsTable, Cause: err}
+	}
+
+	return achievements, nil
+}
+
+// Count 達成目録の件数を取得
+func (r *AchievementRepository) Count(ctx context.Context) (int, error) {
+	count, err := r.db.count(ctx, achievementsTable)
+	if err != nil {
+		return 0, &errors.DatabaseError{Operation: "Count", Table: achievementsTable, Cause: err}
+	}
+	return count, nil
+}
+
+// Delete 達成目録を削除
+func (r *AchievementRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx, `DELETE FROM achievements WHERE id = ?`, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Delete", Table: achievementsTable, Cause: err}
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteWithPoints 達成目録の削除・ポイント減算・台帳への記録をトランザクションで実行
+// 残高が足りない場合は ErrInsufficientPoints、読み取り後にポイントが変更された場合は ErrVersionConflict を返す
+func (r *AchievementRepository) DeleteWithPoints(ctx context.Context, id string) error {
+	achievement, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	entry := r.db.newLedgerEntry(models.LedgerEntryRevoke, -achievement.Point, achievement.ID)
+	err = r.db.withTx(ctx, func(tx *sql.Tx) error {
+		// 読み取った後にポイントが変わっていた場合は減算する値が正しくないため削除しない
+		result, err := r.db.execWith(ctx, tx,
+			`DELETE FROM achievements WHERE id = ? AND point = ?`, tenant.Key(ctx, id), achievement.Point)
+		if err != nil {
+			return err
+		}
+		if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+			return errors.ErrVersionConflict
+		}
+		return r.db.addToBalance(ctx, tx, entry)
+	})
+	if err == errors.ErrVersionConflict {
+		// 読み取り後に削除されていた場合
+		if _, getErr := r.GetByID(ctx, id); getErr != nil {
+			return getErr
+		}
+		return err
+	}
+	if err == errors.ErrInsufficientPoints {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "DeleteWithPoints", Table: achievementsTable + "," + pointTables, Cause: err}
+	}
+	return nil
+}
+
+// DeleteMany 複数の達成目録をまとめて削除
+func (r *AchievementRepository) DeleteMany(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		if id == "" {
+			return &errors.ValidationError{Field: "id", Message: "id is required"}
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	marks, args := placeholders(ctx, ids)
+	if _, err := r.db.exec(ctx, `DELETE FROM achievements WHERE id IN (`+marks+`)`, args...); err != nil {
+		return &errors.DatabaseError{Operation: "DeleteMany", Table: achievementsTable, Cause: err}
+	}
+
+	return nil
+}
+
+// SetAttachment 達成目録に添付ファイルのオブジェクトキーを保存（バージョンは進めない）
+func (r *AchievementRepository) SetAttachment(ctx context.Context, id, key string) error {
+	if err := repository.ValidateAttachment(id, key); err != nil {
+		return err
+	}
+	return r.db.setAttachmentKey(ctx, achievementsTable, "SetAttachment", id, key)
+}
+
+// StartTimer 達成目録のタイマーを開始（バージョンは進めない）
+// 達成目録が削除されていた場合や、すでにタイマーが動いている場合は ErrVersionConflict を返す
+func (r *AchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx,
+		`UPDATE achievements SET timer_started_at = ? WHERE id = ? AND timer_started_at IS NULL`, r.db.truncate(at), tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "StartTimer", Table: achievementsTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrVersionConflict
+	}
+	return nil
+}
+
+// Complete 達成記録の作成・ポイントの付与・台帳への記録をトランザクションで実行
+// タイマーを止めて達成する場合（StartedAt を指定した場合）は同じトランザクションでタイマーを止め、
+// 読み取った後にタイマーが止められていた場合は ErrVersionConflict を返す
+// 達成回数の上限（Limit）を指定した場合は同じトランザクションで達成記録を数え、上限に達している場合は BusinessLogicError を返す
+func (r *AchievementRepository) Complete(ctx context.Context, completion *models.Completion) error {
+	if completion == nil {
+		return &errors.ValidationError{Field: "completion", Message: "completion cannot be nil"}
+	}
+
+	if err := repository.ValidateCompletion(completion); err != nil {
+		return err
+	}
+
+	if completion.ID == "" {
+		completion.ID = ulid.Make().String()
+	}
+	if completion.CompletedAt.IsZero() {
+		completion.CompletedAt = time.Now()
+	}
+	completion.CompletedAt = r.db.truncate(completion.CompletedAt)
+	entry := r.db.newLedgerEntry(models.LedgerEntryGrant, completion.Point, completion.ID)
+	var bonus *models.PointLedgerEntry
+	if completion.BonusPoint > 0 {
+		bonus = r.db.newLedgerEntry(models.LedgerEntryBonus, completion.BonusPoint, completion.ID)
+	}
+
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		if completion.StartedAt != nil {
+			// 同じタイマーを二重に止めて達成しないよう、読み取った時点のタイマーが動いている場合のみ止める
+			result, err := r.db.execWith(ctx, tx,
+				`UPDATE achievements SET timer_started_at = NULL WHERE id = ? AND timer_started_at = ?`, tenant.Key(ctx, completion.AchievementID), completion.StartedAt)
+			if err != nil {
+				return err
+			}
+			if stopped, err := result.RowsAffected(); err == nil && stopped == 0 {
+				return errors.ErrVersionConflict
+			}
+		} else {
+			// 達成目録の行をロックし、コミットまでの間に削除されないようにする
+			result, err := r.db.execWith(ctx, tx,
+				`UPDATE achievements SET version = version WHERE id = ?`, tenant.Key(ctx, completion.AchievementID))
+			if err != nil {
+				return err
+			}
+			if locked, err := result.RowsAffected(); err == nil && locked == 0 {
+				return errors.ErrNotFound
+			}
+		}
+
+		// 達成目録の行をロックした後に数えるため、同時に達成しても上限を超えない
+		if limit := completion.Limit; limit != nil && (limit.MaxTotal > 0 || limit.MaxPerDay > 0) {
+			var total, today int
+			err := r.db.queryRowWith(ctx, tx,
+				`SELECT COUNT(*), COUNT(CASE WHEN completed_at >= ? AND completed_at < ? THEN 1 END) FROM completions WHERE tenant_id = ? AND achievement_id = ?`,
+				limit.DayStart, limit.DayEnd, tenant.FromContext(ctx), completion.AchievementID).Scan(&total, &today)
+			if err != nil {
+				return err
+			}
+			if err := repository.CheckCompletionLimit(limit, total, today); err != nil {
+				return err
+			}
+		}
+
+		_, err := r.db.execWith(ctx, tx,
+			`INSERT INTO completions (id, tenant_id, achievement_id, achievement_title, point, bonus_point, completed_at, started_at, duration_seconds) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			tenant.Key(ctx, completion.ID), tenant.FromContext(ctx), completion.AchievementID, completion.AchievementTitle, completion.Point, completion.BonusPoint, completion.CompletedAt, completion.StartedAt, completion.DurationSeconds)
+		if err != nil {
+			return err
+		}
+		if err := r.db.addToBalance(ctx, tx, entry); err != nil {
+			return err
+		}
+		if bonus != nil {
+			return r.db.addToBalance(ctx, tx, bonus)
+		}
+		return nil
+	})
+	if _, limited := err.(*errors.BusinessLogicError); limited || err == errors.ErrNotFound || err == errors.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Complete", Table: completionsTable + "," + pointTables, Cause: err}
+	}
+	return nil
+}
+
+// ListCompletions 達成目録の達成記録を達成日時順に取得
+func (r *AchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	if achievementID == "" {
+		return nil, &errors.ValidationError{Field: "achievement_id", Message: "achievement_id is required"}
+	}
+
+	rows, err := r.db.query(ctx,
+		`SELECT id, achievement_id, achievement_title, point, bonus_point, completed_at, started_at, duration_seconds FROM completions WHERE tenant_id = ? AND achievement_id = ? ORDER BY completed_at, id`,
+		tenant.FromContext(ctx), achievementID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "ListCompletions", Table: completionsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	completions := []*models.Completion{}
+	for rows.Next() {
+		var completion models.Completion
+		var completedAt timestamp
+		var startedAt nullTimestamp
+		if err := rows.Scan(&completion.ID, &completion.AchievementID, &completion.AchievementTitle, &completion.Point, &completion.BonusPoint, &completedAt, &startedAt, &completion.DurationSeconds); err != nil {
+			return nil, &errors.DatabaseError{Operation: "ListCompletions", Table: completionsTable, Cause: err}
+		}
+		completion.ID = tenant.EntityID(ctx, completion.ID)
+		completion.CompletedAt = completedAt.Time
+		completion.StartedAt = startedAt.Time
+		completions = append(completions, &completion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "ListCompletions", Table: completionsTable, Cause: err}
+	}
+
+	return completions, nil
+}
+
+// scanAchievement 行をテナントの達成目録に変換
+func scanAchievement(ctx context.Context, row rowScanner) (*models.Achievement, error) {
+	var achievement models.Achievement
+	var createdAt timestamp
+	var timerStartedAt nullTimestamp
+	if err := row.Scan(&achievement.ID, &achievement.Title, &achievement.Description, &achievement.Point, &achievement.Category, &createdAt, &achievement.Version, &achievement.AttachmentKey, &achievement.DueDate, &achievement.Reminder, &achievement.Difficulty, &achievement.MaxPerDay, &achievement.MaxTotal, &achievement.Pinned, &achievement.SortOrder, &achievement.PointsPerHour, &timerStartedAt); err != nil {
+		return nil, err
+	}
+	achievement.ID = tenant.EntityID(ctx, achievement.ID)
+	achievement.CreatedAt = createdAt.Time
+	achievement.TimerStartedAt = timerStartedAt.Time
+	return &achievement, nil
+}
@@ -0,0 +1,725 @@
+package sqlstore
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestAchievementRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 123000, time.UTC)
+	achievement := &models.Achievement{Title: "初回ログイン", Description: "説明", Point: 10, CreatedAt: createdAt}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if achievement.ID == "" {
+		t.Fatal("Expected ID to be generated")
+	}
+
+	got, err := repo.GetByID(ctx, achievement.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "初回ログイン" || got.Point != 10 || !got.CreatedAt.Equal(createdAt) {
+		t.Errorf("Unexpected achievement: %+v", got)
+	}
+
+	// 作成日時は更新されないことを確認
+	update := &models.Achievement{ID: achievement.ID, Title: "更新", Point: 15, CreatedAt: time.Now()}
+	if err := repo.Update(ctx, update); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, achievement.ID)
+	if got.Title != "更新" || got.Point != 15 || !got.CreatedAt.Equal(createdAt) {
+		t.Errorf("Unexpected updated achievement: %+v", got)
+	}
+
+	if err := repo.Delete(ctx, achievement.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, achievement.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, achievement.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound when deleting twice, got %v", err)
+	}
+}
+
+func TestAchievementRepository_Errors(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	var validationErr *errors.ValidationError
+	if err := repo.Create(ctx, &models.Achievement{Title: "", Point: 10}); !stderrors.As(err, &validationErr) {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+
+	if err := repo.Create(ctx, &models.Achievement{ID: "dup", Title: "A", Point: 1}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, &models.Achievement{ID: "dup", Title: "B", Point: 1}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	if err := repo.Update(ctx, &models.Achievement{ID: "missing", Title: "A", Point: 1}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for missing achievement, got %v", err)
+	}
+}
+
+func TestAchievementRepository_CreateWithPoints(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDB(t)
+	repo := NewAchievementRepository(store)
+	points := NewPointRepository(store)
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.CreateWithPoints(ctx, achievement); err != nil {
+		t.Fatalf("CreateWithPoints failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, achievement.ID); err != nil {
+		t.Errorf("GetByID failed: %v", err)
+	}
+
+	// 同じIDの場合は達成目録もポイントも書き込まない
+	if err := repo.CreateWithPoints(ctx, &models.Achievement{ID: achievement.ID, Title: "重複", Point: 50}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	current, err := points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Point != 10 {
+		t.Errorf("Expected 10 points, got %d", current.Point)
+	}
+	entries, err := points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Type != models.LedgerEntryGrant || entries[0].Amount != 10 || entries[0].Reference != achievement.ID {
+		t.Errorf("Unexpected ledger entries: %+v", entries)
+	}
+}
+
+func TestAchievementRepository_DeleteWithPoints(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDB(t)
+	repo := NewAchievementRepository(store)
+	points := NewPointRepository(store)
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.CreateWithPoints(ctx, achievement); err != nil {
+		t.Fatalf("CreateWithPoints failed: %v", err)
+	}
+	if err := points.SubtractPoints(ctx, 5); err != nil {
+		t.Fatalf("SubtractPoints failed: %v", err)
+	}
+
+	// 付与したポイントを使っていて残高が足りない場合は削除しない
+	if err := repo.DeleteWithPoints(ctx, achievement.ID); err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, achievement.ID); err != nil {
+		t.Errorf("Expected achievement to remain, got %v", err)
+	}
+
+	if err := points.AddPoints(ctx, 5); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	if err := repo.DeleteWithPoints(ctx, achievement.ID); err != nil {
+		t.Fatalf("DeleteWithPoints failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, achievement.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := repo.DeleteWithPoints(ctx, achievement.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for a deleted achievement, got %v", err)
+	}
+
+	current, err := points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Point != 0 {
+		t.Errorf("Expected 0 points, got %d", current.Point)
+	}
+	entries, err := points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	last := entries[len(entries)-1]
+	if len(entries) != 4 || last.Type != models.LedgerEntryRevoke || last.Amount != -10 || last.Reference != achievement.ID {
+		t.Errorf("Unexpected ledger entries: %+v", entries)
+	}
+}
+func TestAchievementRepository_UpdateWithPoints(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDB(t)
+	repo := NewAchievementRepository(store)
+	points := NewPointRepository(store)
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	if err := repo.CreateWithPoints(ctx, achievement); err != nil {
+		t.Fatalf("CreateWithPoints failed: %v", err)
+	}
+
+	// ポイントを増やした分だけ残高に加算する
+	achievement.Point = 50
+	if err := repo.UpdateWithPoints(ctx, achievement); err != nil {
+		t.Fatalf("UpdateWithPoints failed: %v", err)
+	}
+	if achievement.Version != 2 {
+		t.Errorf("Expected version 2, got %d", achievement.Version)
+	}
+	assertBalance := func(expected int) {
+		t.Helper()
+		current, err := points.GetCurrentPoints(ctx)
+		if err != nil {
+			t.Fatalf("GetCurrentPoints failed: %v", err)
+		}
+		if current.Point != expected {
+			t.Errorf("Expected %d points, got %d", expected, current.Point)
+		}
+	}
+	assertBalance(50)
+
+	// 減らした分の残高が足りない場合は更新しない
+	if err := points.SubtractPoints(ctx, 45); err != nil {
+		t.Fatalf("SubtractPoints failed: %v", err)
+	}
+	if err := repo.UpdateWithPoints(ctx, &models.Achievement{ID: achievement.ID, Title: "初回ログイン", Point: 20}); err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+	if stored, _ := repo.GetByID(ctx, achievement.ID); stored.Point != 50 {
+		t.Errorf("Expected point to remain 50, got %d", stored.Point)
+	}
+
+	// 古いバージョンを指定した場合は競合
+	stale := &models.Achievement{ID: achievement.ID, Title: "初回ログイン", Point: 40, Version: 1}
+	if err := repo.UpdateWithPoints(ctx, stale); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	if err := points.AddPoints(ctx, 40); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	reduced := &models.Achievement{ID: achievement.ID, Title: "初回ログイン", Point: 20}
+	if err := repo.UpdateWithPoints(ctx, reduced); err != nil {
+		t.Fatalf("UpdateWithPoints failed: %v", err)
+	}
+	assertBalance(15)
+
+	// ポイントを変えない更新は台帳に記録しない
+	entries, err := points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	renamed := &models.Achievement{ID: achievement.ID, Title: "はじめてのログイン", Point: 20}
+	if err := repo.UpdateWithPoints(ctx, renamed); err != nil {
+		t.Fatalf("UpdateWithPoints failed: %v", err)
+	}
+	after, err := points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	if len(after) != len(entries) {
+		t.Errorf("Expected no ledger entry for an unchanged point, got %d entries (was %d)", len(after), len(entries))
+	}
+	last := entries[len(entries)-1]
+	if last.Type != models.LedgerEntryRevoke || last.Amount != -30 || last.Reference != achievement.ID {
+		t.Errorf("Unexpected ledger entry: %+v", last)
+	}
+	assertBalance(15)
+
+	if err := repo.UpdateWithPoints(ctx, &models.Achievement{ID: "missing", Title: "存在しない", Point: 10}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAchievementRepository_Complete(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDB(t)
+	repo := NewAchievementRepository(store)
+	points := NewPointRepository(store)
+
+	achievement := &models.Achievement{Title: "朝のランニング", Point: 30}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// 同じ達成目録を繰り返し達成でき、そのたびにポイントを付与する
+	first := &models.Completion{AchievementID: achievement.ID, AchievementTitle: achievement.Title, Point: achievement.Point, CompletedAt: time.Now().Add(-time.Hour)}
+	second := &models.Completion{AchievementID: achievement.ID, AchievementTitle: achievement.Title, Point: achievement.Point}
+	for _, completion := range []*models.Completion{second, first} {
+		if err := repo.Complete(ctx, completion); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+		if completion.ID == "" {
+			t.Error("Expected completion ID to be set")
+		}
+	}
+
+	completions, err := repo.ListCompletions(ctx, achievement.ID)
+	if err != nil {
+		t.Fatalf("ListCompletions failed: %v", err)
+	}
+	if len(completions) != 2 || completions[0].ID != first.ID || completions[1].ID != second.ID {
+		t.Fatalf("Expected completions in completion order, got %+v", completions)
+	}
+	if completions[0].AchievementTitle != "朝のランニング" || completions[0].Point != 30 {
+		t.Errorf("Unexpected completion: %+v", completions[0])
+	}
+
+	current, err := points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Point != 60 {
+		t.Errorf("Expected 60 points, got %d", current.Point)
+	}
+	entries, err := points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Type != models.LedgerEntryGrant || entries[0].Amount != 30 {
+		t.Errorf("Unexpected ledger entries: %+v", entries)
+	}
+	references := map[string]bool{entries[0].Reference: true, entries[1].Reference: true}
+	if !references[first.ID] || !references[second.ID] {
+		t.Errorf("Expected ledger entries to reference completions, got %+v", entries)
+	}
+
+	// 存在しない達成目録は達成できず、ポイントも付与しない
+	if err := repo.Complete(ctx, &models.Completion{AchievementID: "missing", Point: 10}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	var validationErr *errors.ValidationError
+	if err := repo.Complete(ctx, &models.Completion{AchievementID: achievement.ID, Point: -10}); !stderrors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError for a negative point, got %v", err)
+	}
+	if current, _ := points.GetCurrentPoints(ctx); current.Point != 60 {
+		t.Errorf("Expected balance to remain 60, got %d", current.Point)
+	}
+
+	// 連続達成のボーナスは達成目録のポイントとは別に台帳に記録する
+	bonus := &models.Completion{AchievementID: achievement.ID, AchievementTitle: achievement.Title, Point: 30, BonusPoint: 10}
+	if err := repo.Complete(ctx, bonus); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if current, _ := points.GetCurrentPoints(ctx); current.Point != 100 {
+		t.Errorf("Expected 100 points after a bonus, got %d", current.Point)
+	}
+	entries, err = points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	bonusEntries := 0
+	for _, entry := range entries {
+		if entry.Type == models.LedgerEntryBonus && entry.Amount == 10 && entry.Reference == bonus.ID {
+			bonusEntries++
+		}
+	}
+	if len(entries) != 4 || bonusEntries != 1 {
+		t.Errorf("Expected a bonus ledger entry, got %+v", entries)
+	}
+	completions, err = repo.ListCompletions(ctx, achievement.ID)
+	if err != nil {
+		t.Fatalf("ListCompletions failed: %v", err)
+	}
+	if last := completions[len(completions)-1]; last.ID != bonus.ID || last.BonusPoint != 10 {
+		t.Errorf("Expected the bonus to be stored with the completion, got %+v", last)
+	}
+
+	// 他のテナントの達成記録は見えない
+	other, err := repo.ListCompletions(tenant.WithID(ctx, "family-b"), achievement.ID)
+	if err != nil {
+		t.Fatalf("ListCompletions failed: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no completions for another tenant, got %d", len(other))
+	}
+}
+
+func TestAchievementRepository_CompleteLimit(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDB(t)
+	repo := NewAchievementRepository(store)
+
+	achievement := &models.Achievement{Title: "水を飲んだ", Point: 1, MaxPerDay: 1, MaxTotal: 3}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	limitOn := func(day time.Time) *models.CompletionLimit {
+		return &models.CompletionLimit{MaxPerDay: 1, MaxTotal: 3, DayStart: day, DayEnd: day.AddDate(0, 0, 1)}
+	}
+	complete := func(at, day time.Time) error {
+		return repo.Complete(ctx, &models.Completion{AchievementID: achievement.ID, Point: 1, CompletedAt: at, Limit: limitOn(day)})
+	}
+	june9 := time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC)
+	june10 := june9.AddDate(0, 0, 1)
+	june11 := june10.AddDate(0, 0, 1)
+
+	// 前日の達成はその日の回数に含めない
+	if err := complete(june9.Add(20*time.Hour), june9); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if err := complete(june10.Add(8*time.Hour), june10); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	var businessErr *errors.BusinessLogicError
+	err := complete(june10.Add(9*time.Hour), june10)
+	if !stderrors.As(err, &businessErr) || businessErr.Code != errors.CodeCompletionLimit || !strings.Contains(businessErr.Reason, "per day") {
+		t.Errorf("Expected the daily limit, got %v", err)
+	}
+	if err := complete(june11.Add(8*time.Hour), june11); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	err = complete(june11.AddDate(0, 0, 1), june11.AddDate(0, 0, 1))
+	if !stderrors.As(err, &businessErr) || businessErr.Code != errors.CodeCompletionLimit || !strings.Contains(businessErr.Reason, "in total") {
+		t.Errorf("Expected the total limit, got %v", err)
+	}
+
+	if completions, _ := repo.ListCompletions(ctx, achievement.ID); len(completions) != 3 {
+		t.Errorf("Expected 3 completions, got %d", len(completions))
+	}
+}
+
+func TestAchievementRepository_Version(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	repo.Create(ctx, achievement)
+	if achievement.Version != 1 {
+		t.Errorf("Expected version 1 after create, got %d", achievement.Version)
+	}
+
+	// 取得したバージョンを指定して更新するとバージョンが進む
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "更新", Point: 15, Version: 1}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	// 更新前のバージョンを指定した場合は競合する
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "古い更新", Point: 20, Version: 1}); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+	// バージョンを指定しない場合は現在のバージョンから更新する
+	update := &models.Achievement{ID: achievement.ID, Title: "再更新", Point: 25}
+	if err := repo.Update(ctx, update); err != nil {
+		t.Fatalf("Update without version failed: %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, achievement.ID)
+	if got.Title != "再更新" || got.Version != 3 || update.Version != 3 {
+		t.Errorf("Expected title 再更新 at version 3, got %+v (update version %d)", got, update.Version)
+	}
+}
+
+func TestAchievementRepository_SetAttachment(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	achievement := &models.Achievement{Title: "初回ログイン", Point: 10}
+	repo.Create(ctx, achievement)
+
+	if err := repo.SetAttachment(ctx, achievement.ID, "attachments/default/achievement/1.jpg"); err != nil {
+		t.Fatalf("SetAttachment failed: %v", err)
+	}
+	got, _ := repo.GetByID(ctx, achievement.ID)
+	if got.AttachmentKey != "attachments/default/achievement/1.jpg" || got.Version != 1 {
+		t.Errorf("Expected attachment key without a version bump, got %+v", got)
+	}
+
+	// 内容を更新しても添付ファイルは保持する
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "更新", Point: 15}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, achievement.ID)
+	if got.AttachmentKey != "attachments/default/achievement/1.jpg" {
+		t.Errorf("Expected attachment key to survive update, got %q", got.AttachmentKey)
+	}
+	if list, _ := repo.List(ctx); len(list) != 1 || list[0].AttachmentKey != got.AttachmentKey {
+		t.Errorf("Expected attachment key in list, got %+v", list)
+	}
+
+	if err := repo.SetAttachment(ctx, "missing", "attachments/x.jpg"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	var validationErr *errors.ValidationError
+	if err := repo.SetAttachment(ctx, achievement.ID, ""); !stderrors.As(err, &validationErr) {
+		t.Errorf("Expected validation error for empty key, got %v", err)
+	}
+}
+
+func TestAchievementRepository_DueDateAndReminder(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	achievement := &models.Achievement{Title: "確定申告", Point: 100, DueDate: "2024-03-15", Reminder: "0 9 * * *"}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	got, _ := repo.GetByID(ctx, achievement.ID)
+	if got.DueDate != "2024-03-15" || got.Reminder != "0 9 * * *" {
+		t.Errorf("Expected due date and reminder to be stored, got %+v", got)
+	}
+
+	// 更新で指定しなかった場合は期限・リマインドを消す
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "確定申告", Point: 100, Reminder: "0 20 * * *"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if list, _ := repo.List(ctx); len(list) != 1 || list[0].DueDate != "" || list[0].Reminder != "0 20 * * *" {
+		t.Errorf("Expected updated due date and reminder in list, got %+v", list)
+	}
+}
+
+func TestAchievementRepository_Difficulty(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	achievement := &models.Achievement{Title: "フルマラソン", Point: 500, Difficulty: models.DifficultyHard}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, achievement.ID); got.Difficulty != models.DifficultyHard {
+		t.Errorf("Expected difficulty to be stored, got %+v", got)
+	}
+
+	// ポイントの提案に使うため、集計用の一覧にも難易度を含める
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "フルマラソン", Point: 500, Difficulty: models.DifficultyMedium}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if summaries, _ := repo.ListSummaries(ctx); len(summaries) != 1 || summaries[0].Difficulty != models.DifficultyMedium {
+		t.Errorf("Expected updated difficulty in summaries, got %+v", summaries)
+	}
+}
+
+func TestAchievementRepository_RepeatLimits(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	achievement := &models.Achievement{Title: "水を飲んだ", Point: 1, MaxPerDay: 8}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, achievement.ID); got.MaxPerDay != 8 || got.MaxTotal != 0 {
+		t.Errorf("Expected repeat limits to be stored, got %+v", got)
+	}
+
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "水を飲んだ", Point: 1, MaxTotal: 100}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if achievements, _ := repo.List(ctx); len(achievements) != 1 || achievements[0].MaxPerDay != 0 || achievements[0].MaxTotal != 100 {
+		t.Errorf("Expected updated repeat limits, got %+v", achievements)
+	}
+}
+
+func TestAchievementRepository_PinnedAndSortOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	achievement := &models.Achievement{Title: "Goを学ぶ", Point: 200, Pinned: true}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, achievement.ID); !got.Pinned || got.SortOrder != 0 {
+		t.Errorf("Expected pinned to be stored, got %+v", got)
+	}
+
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "Goを学ぶ", Point: 200, SortOrder: 3}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, achievement.ID); got.Pinned || got.SortOrder != 3 {
+		t.Errorf("Expected unpinned achievement with sort order 3, got %+v", got)
+	}
+}
+
+func TestAchievementRepository_Timer(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDB(t)
+	repo := NewAchievementRepository(store)
+	points := NewPointRepository(store)
+
+	achievement := &models.Achievement{Title: "ピアノの練習", Point: 10, PointsPerHour: 40}
+	if err := repo.Create(ctx, achievement); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	startedAt := time.Now().Add(-90 * time.Minute)
+	if err := repo.StartTimer(ctx, achievement.ID, startedAt); err != nil {
+		t.Fatalf("StartTimer failed: %v", err)
+	}
+	got, _ := repo.GetByID(ctx, achievement.ID)
+	if got.TimerStartedAt == nil || got.PointsPerHour != 40 || got.Version != 1 {
+		t.Fatalf("Expected a running timer without a version bump, got %+v", got)
+	}
+
+	// 動いているタイマーは開始できず、内容を更新してもタイマーは保持する
+	if err := repo.StartTimer(ctx, achievement.ID, time.Now()); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict for a running timer, got %v", err)
+	}
+	if err := repo.Update(ctx, &models.Achievement{ID: achievement.ID, Title: "ピアノの練習", Point: 10, PointsPerHour: 60}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, achievement.ID)
+	if got.TimerStartedAt == nil || got.PointsPerHour != 60 {
+		t.Fatalf("Expected timer to survive update, got %+v", got)
+	}
+
+	started := *got.TimerStartedAt
+	completion := &models.Completion{AchievementID: achievement.ID, AchievementTitle: achievement.Title, Point: 90, StartedAt: &started, DurationSeconds: 5400}
+	if err := repo.Complete(ctx, completion); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, achievement.ID); got.TimerStartedAt != nil {
+		t.Errorf("Expected timer to be stopped, got %v", got.TimerStartedAt)
+	}
+	completions, _ := repo.ListCompletions(ctx, achievement.ID)
+	if len(completions) != 1 || completions[0].StartedAt == nil || !completions[0].StartedAt.Equal(started) || completions[0].DurationSeconds != 5400 {
+		t.Errorf("Expected tracked time in completion, got %+v", completions)
+	}
+
+	// 止めたタイマーを二重に止めて達成しない
+	again := &models.Completion{AchievementID: achievement.ID, AchievementTitle: achievement.Title, Point: 90, StartedAt: &started, DurationSeconds: 5400}
+	if err := repo.Complete(ctx, again); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict for a stopped timer, got %v", err)
+	}
+	if current, _ := points.GetCurrentPoints(ctx); current.Point != 90 {
+		t.Errorf("Expected 90 points, got %d", current.Point)
+	}
+
+	if err := repo.StartTimer(ctx, "missing", time.Now()); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict for a missing achievement, got %v", err)
+	}
+}
+
+func TestAchievementRepository_ListAndDeleteMany(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"c", "a", "b"} {
+		// 作成日時の順序がID順と異なるように登録
+		achievement := &models.Achievement{ID: id, Title: id, Point: 1, CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := repo.Create(ctx, achievement); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 3 || list[0].ID != "c" || list[1].ID != "a" || list[2].ID != "b" {
+		t.Errorf("Expected creation order c, a, b, got %v", list)
+	}
+
+	if err := repo.DeleteMany(ctx, []string{"a", "c", "missing"}); err != nil {
+		t.Fatalf("DeleteMany failed: %v", err)
+	}
+	list, _ = repo.List(ctx)
+	if len(list) != 1 || list[0].ID != "b" {
+		t.Errorf("Expected only b to remain, got %v", list)
+	}
+}
+
+func TestAchievementRepository_ListSummaries(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAchievementRepository(newTestDB(t))
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"b", "a"} {
+		achievement := &models.Achievement{ID: id, Title: "タイトル" + id, Description: "説明", Point: 10 * (i + 1), Category: "health", CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := repo.Create(ctx, achievement); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	summaries, err := repo.ListSummaries(ctx)
+	if err != nil {
+		t.Fatalf("ListSummaries failed: %v", err)
+	}
+	if len(summaries) != 2 || summaries[0].ID != "b" || summaries[1].ID != "a" {
+		t.Fatalf("Expected creation order b, a, got %v", summaries)
+	}
+	// ID・タイトル・ポイント・分類以外は読み取らない
+	if got := summaries[1]; got.Title != "タイトルa" || got.Point != 20 || got.Category != "health" || got.Description != "" || !got.CreatedAt.IsZero() {
+		t.Errorf("Unexpected summary: %+v", got)
+	}
+}
+
+func TestAchievementRepository_Count(t *testing.T) {
+	familyA := tenant.WithID(context.Background(), "family-a")
+	familyB := tenant.WithID(context.Background(), "family-b")
+	repo := NewAchievementRepository(newTestDB(t))
+
+	for _, title := range []string{"初回ログイン", "連続ログイン"} {
+		if err := repo.Create(familyA, &models.Achievement{Title: title, Point: 10}); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	// テナントごとに数える
+	if count, err := repo.Count(familyA); err != nil || count != 2 {
+		t.Errorf("Expected 2 achievements for family-a, got %d (%v)", count, err)
+	}
+	if count, err := repo.Count(familyB); err != nil || count != 0 {
+		t.Errorf("Expected 0 achievements for family-b, got %d (%v)", count, err)
+	}
+}
+
+func TestAchievementRepository_Tenants(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewAchievementRepository(db)
+	points := NewPointRepository(db)
+	familyA := tenant.WithID(context.Background(), "family-a")
+	familyB := tenant.WithID(context.Background(), "family-b")
+
+	if err := repo.Create(familyA, &models.Achievement{ID: "shared-id", Title: "家族A", Point: 10}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	// 別のテナントでは同じIDで作成できる
+	if err := repo.Create(familyB, &models.Achievement{ID: "shared-id", Title: "家族B", Point: 5}); err != nil {
+		t.Fatalf("Create in another tenant failed: %v", err)
+	}
+	if err := points.AddPoints(familyA, 10); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+
+	got, err := repo.GetByID(familyB, "shared-id")
+	if err != nil || got.ID != "shared-id" || got.Title != "家族B" {
+		t.Errorf("Expected family-b's achievement, got %+v (%v)", got, err)
+	}
+	list, err := repo.List(familyA)
+	if err != nil || len(list) != 1 || list[0].Title != "家族A" {
+		t.Errorf("Expected only family-a's achievement, got %+v (%v)", list, err)
+	}
+	if list, _ := repo.List(context.Background()); len(list) != 0 {
+		t.Errorf("Expected default tenant to be empty, got %d achievements", len(list))
+	}
+
+	if err := repo.Delete(familyB, "shared-id"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(familyA, "shared-id"); err != nil {
+		t.Errorf("Expected family-a's achievement to remain, got %v", err)
+	}
+
+	current, _ := points.GetCurrentPoints(familyB)
+	if current.Point != 0 {
+		t.Errorf("Expected family-b balance 0, got %d", current.Point)
+	}
+	ledger, _ := points.GetLedger(familyA)
+	if len(ledger) != 1 || ledger[0].Amount != 10 {
+		t.Errorf("Expected family-a ledger entry, got %+v", ledger)
+	}
+}
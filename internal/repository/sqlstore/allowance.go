@@ -0,0 +1,158 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// AllowanceRepository SQLデータベースを使用したお小遣いのルールのリポジトリ
+type AllowanceRepository struct {
+	db *DB
+}
+
+// NewAllowanceRepository お小遣いのルールのリポジトリを作成
+func NewAllowanceRepository(db *DB) repository.AllowanceRepository {
+	return &AllowanceRepository{db: db}
+}
+
+// Create お小遣いのルールを作成
+func (r *AllowanceRepository) Create(ctx context.Context, allowance *models.Allowance) error {
+	if err := repository.PrepareAllowance(allowance); err != nil {
+		return err
+	}
+	allowance.CreatedAt = r.db.truncate(allowance.CreatedAt)
+	if allowance.LastGrantedAt != nil {
+		lastGrantedAt := r.db.truncate(*allowance.LastGrantedAt)
+		allowance.LastGrantedAt = &lastGrantedAt
+	}
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO allowances (id, tenant_id, title, points, schedule, last_granted_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, allowance.ID), tenant.FromContext(ctx), allowance.Title, allowance.Points, allowance.Schedule, allowance.LastGrantedAt, allowance.CreatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: allowancesTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// GetByID IDでお小遣いのルールを取得
+func (r *AllowanceRepository) GetByID(ctx context.Context, id string) (*models.Allowance, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	row := r.db.queryRow(ctx,
+		`SELECT id, title, points, schedule, last_granted_at, created_at FROM allowances WHERE id = ?`, tenant.Key(ctx, id))
+	allowance, err := scanAllowance(ctx, row)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetByID", Table: allowancesTable, Cause: err}
+	}
+
+	return allowance, nil
+}
+
+// List すべてのお小遣いのルールを作成日時順に取得
+func (r *AllowanceRepository) List(ctx context.Context) ([]*models.Allowance, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, title, points, schedule, last_granted_at, created_at FROM allowances WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: allowancesTable, Cause: err}
+	}
+	defer rows.Close()
+
+	allowances := []*models.Allowance{}
+	for rows.Next() {
+		allowance, err := scanAllowance(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: allowancesTable, Cause: err}
+		}
+		allowances = append(allowances, allowance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: allowancesTable, Cause: err}
+	}
+
+	return allowances, nil
+}
+
+// Delete お小遣いのルールを削除（付与済みのポイントは取り消さない）
+func (r *AllowanceRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx, `DELETE FROM allowances WHERE id = ?`, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Delete", Table: allowancesTable, Cause: err}
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Grant お小遣いの付与日時の記録と、ポイントの付与・台帳への記録をトランザクションで実行
+//
+// 削除済みのルールや、同じ分またはそれ以降に付与済みのルールの場合は errors.ErrVersionConflict を返す。
+func (r *AllowanceRepository) Grant(ctx context.Context, id string, points int, at time.Time) error {
+	grantedAt, err := repository.PrepareAllowanceGrant(id, points, at)
+	if err != nil {
+		return err
+	}
+	entry := r.db.newLedgerEntry(models.LedgerEntryAllowance, points, id)
+
+	err = r.db.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := r.db.execWith(ctx, tx,
+			`UPDATE allowances SET last_granted_at = ? WHERE id = ? AND (last_granted_at IS NULL OR last_granted_at < ?)`,
+			grantedAt, tenant.Key(ctx, id), grantedAt)
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+			return errors.ErrVersionConflict
+		}
+		return r.db.addToBalance(ctx, tx, entry)
+	})
+	if err == errors.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "Grant",
+			Table:     pointTables + "," + allowancesTable,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// scanAllowance 行をテナントのお小遣いのルールに変換
+func scanAllowance(ctx context.Context, row rowScanner) (*models.Allowance, error) {
+	var allowance models.Allowance
+	var lastGrantedAt nullTimestamp
+	var createdAt timestamp
+	if err := row.Scan(&allowance.ID, &allowance.Title, &allowance.Points, &allowance.Schedule, &lastGrantedAt, &createdAt); err != nil {
+		return nil, err
+	}
+	allowance.ID = tenant.EntityID(ctx, allowance.ID)
+	allowance.LastGrantedAt = lastGrantedAt.Time
+	allowance.CreatedAt = createdAt.Time
+	return &allowance, nil
+}
@@ -0,0 +1,97 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestAllowanceRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAllowanceRepository(newTestDB(t))
+
+	allowance := &models.Allowance{Title: "お小遣い", Points: 50, Schedule: "0 9 * * 1"}
+	if err := repo.Create(ctx, allowance); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, allowance); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+	if err := repo.Create(ctx, &models.Allowance{Title: "お小遣い", Points: 0, Schedule: "0 9 * * 1"}); err == nil {
+		t.Error("Expected validation error for zero points")
+	}
+
+	allowances, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(allowances) != 1 || allowances[0].Schedule != "0 9 * * 1" {
+		t.Errorf("Expected the created allowance, got %+v", allowances)
+	}
+
+	// 他のテナントからは見えない
+	if _, err := repo.GetByID(tenant.WithID(ctx, "acme"), allowance.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, allowance.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, allowance.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestAllowanceRepository_Grant(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := NewAllowanceRepository(db)
+	points := NewPointRepository(db)
+
+	allowance := &models.Allowance{Title: "お小遣い", Points: 50, Schedule: "0 9 * * 1"}
+	if err := repo.Create(ctx, allowance); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	monday := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	if err := repo.Grant(ctx, allowance.ID, allowance.Points, monday); err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+	// 同じ分に重ねて付与しない
+	if err := repo.Grant(ctx, allowance.ID, allowance.Points, monday.Add(30*time.Second)); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+	if err := repo.Grant(ctx, allowance.ID, allowance.Points, monday.AddDate(0, 0, 7)); err != nil {
+		t.Fatalf("Grant for the next week failed: %v", err)
+	}
+	if err := repo.Grant(ctx, "missing", 50, monday); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict for a missing allowance, got %v", err)
+	}
+
+	current, err := points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Point != 100 {
+		t.Errorf("Expected 100 points, got %d", current.Point)
+	}
+	ledger, err := points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	if len(ledger) != 2 || ledger[0].Type != models.LedgerEntryAllowance || ledger[0].Reference != allowance.ID {
+		t.Errorf("Expected allowance ledger entries, got %+v", ledger)
+	}
+
+	stored, err := repo.GetByID(ctx, allowance.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.LastGrantedAt == nil || !stored.LastGrantedAt.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("Expected last granted at the next week, got %v", stored.LastGrantedAt)
+	}
+}
@@ -0,0 +1,146 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// APIKeyRepository SQLデータベースを使用したAPIキーのリポジトリ
+type APIKeyRepository struct {
+	db *DB
+}
+
+// NewAPIKeyRepository APIキーのリポジトリを作成
+func NewAPIKeyRepository(db *DB) repository.APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create APIキーを作成
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	if err := repository.PrepareAPIKey(key); err != nil {
+		return err
+	}
+	r.truncate(key)
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO api_keys (id, tenant_id, label, scope, key_tenant_id, hash, created_at, rotated_at, revoked_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, key.ID), tenant.FromContext(ctx), key.Label, string(key.Scope), apiKeyTenant(key), key.Hash, key.CreatedAt, key.RotatedAt, key.RevokedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: apiKeysTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// Update APIキーのラベル・範囲・ハッシュ値・無効にした日時を更新
+func (r *APIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	if err := repository.ValidateAPIKey(key); err != nil {
+		return err
+	}
+	if key.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+	r.truncate(key)
+
+	result, err := r.db.exec(ctx,
+		`UPDATE api_keys SET label = ?, scope = ?, hash = ?, rotated_at = ?, revoked_at = ? WHERE id = ?`,
+		key.Label, string(key.Scope), key.Hash, key.RotatedAt, key.RevokedAt, tenant.Key(ctx, key.ID))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Update", Table: apiKeysTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID IDでAPIキーを取得
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	row := r.db.queryRow(ctx,
+		`SELECT id, label, scope, key_tenant_id, hash, created_at, rotated_at, revoked_at FROM api_keys WHERE id = ?`, tenant.Key(ctx, id))
+	key, err := scanAPIKey(ctx, row)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetByID", Table: apiKeysTable, Cause: err}
+	}
+
+	return key, nil
+}
+
+// List すべてのAPIキーを作成日時順に取得（無効にしたキーを含む）
+func (r *APIKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, label, scope, key_tenant_id, hash, created_at, rotated_at, revoked_at FROM api_keys WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: apiKeysTable, Cause: err}
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: apiKeysTable, Cause: err}
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: apiKeysTable, Cause: err}
+	}
+
+	return keys, nil
+}
+
+// truncate APIキーの日時をデータベースに保存できる精度に丸める
+func (r *APIKeyRepository) truncate(key *models.APIKey) {
+	key.CreatedAt = r.db.truncate(key.CreatedAt)
+	for _, at := range []**time.Time{&key.RotatedAt, &key.RevokedAt} {
+		if *at != nil {
+			truncated := r.db.truncate(**at)
+			*at = &truncated
+		}
+	}
+}
+
+// apiKeyTenant 保存するキーで操作できるテナント（未設定の場合は既定のテナント）
+func apiKeyTenant(key *models.APIKey) string {
+	if key.TenantID == "" {
+		return tenant.DefaultID
+	}
+	return key.TenantID
+}
+
+// scanAPIKey 行をテナントのAPIキーに変換
+func scanAPIKey(ctx context.Context, row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	var scope string
+	var createdAt timestamp
+	var rotatedAt, revokedAt nullTimestamp
+	if err := row.Scan(&key.ID, &key.Label, &scope, &key.TenantID, &key.Hash, &createdAt, &rotatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	key.ID = tenant.EntityID(ctx, key.ID)
+	key.Scope = models.APIKeyScope(scope)
+	key.CreatedAt = createdAt.Time
+	key.RotatedAt = rotatedAt.Time
+	key.RevokedAt = revokedAt.Time
+	return &key, nil
+}
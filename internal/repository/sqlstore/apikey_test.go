@@ -0,0 +1,55 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestAPIKeyRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPIKeyRepository(newTestDB(t))
+
+	key := &models.APIKey{Label: "ダッシュボード", Scope: models.APIKeyScopeRead, TenantID: "family-a", Hash: "5e884898"}
+	if err := repo.Create(ctx, key); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, key); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	rotatedAt := time.Now()
+	key.Hash = "a665a459"
+	key.RotatedAt = &rotatedAt
+	if err := repo.Update(ctx, key); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, key.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.Hash != "a665a459" || stored.Scope != models.APIKeyScopeRead || stored.RotatedAt == nil || !stored.RotatedAt.Equal(*key.RotatedAt) || stored.Revoked() || stored.TenantID != "family-a" {
+		t.Errorf("Expected the rotated key, got %+v", stored)
+	}
+
+	keys, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Label != "ダッシュボード" {
+		t.Errorf("Expected the created key, got %+v", keys)
+	}
+
+	// 他のテナントからは見えない
+	if _, err := repo.GetByID(tenant.WithID(ctx, "acme"), key.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant, got %v", err)
+	}
+	if err := repo.Update(ctx, &models.APIKey{ID: "missing", Label: "CI", Scope: models.APIKeyScopeRead, Hash: "5e884898"}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for a missing key, got %v", err)
+	}
+}
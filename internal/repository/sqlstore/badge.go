@@ -0,0 +1,73 @@
+package sqlstore
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// BadgeRepository SQLデータベースを使用したバッジリポジトリ
+type BadgeRepository struct {
+	db *DB
+}
+
+// NewBadgeRepository バッジリポジトリを作成
+func NewBadgeRepository(db *DB) repository.BadgeRepository {
+	return &BadgeRepository{db: db}
+}
+
+// Award バッジの獲得を記録（獲得済みの場合は errors.ErrDuplicateResource）
+func (r *BadgeRepository) Award(ctx context.Context, badge *models.Badge) error {
+	if err := repository.ValidateBadge(badge); err != nil {
+		return err
+	}
+
+	if badge.EarnedAt.IsZero() {
+		badge.EarnedAt = time.Now()
+	}
+	badge.EarnedAt = r.db.truncate(badge.EarnedAt)
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO badges (id, tenant_id, name, description, earned_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, badge.ID), tenant.FromContext(ctx), badge.Name, badge.Description, badge.EarnedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Award", Table: badgesTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// List 獲得したバッジを獲得日時順に取得
+func (r *BadgeRepository) List(ctx context.Context) ([]*models.Badge, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, name, description, earned_at FROM badges WHERE tenant_id = ? ORDER BY earned_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: badgesTable, Cause: err}
+	}
+	defer rows.Close()
+
+	badges := []*models.Badge{}
+	for rows.Next() {
+		var badge models.Badge
+		var earnedAt timestamp
+		if err := rows.Scan(&badge.ID, &badge.Name, &badge.Description, &earnedAt); err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: badgesTable, Cause: err}
+		}
+		badge.ID = tenant.EntityID(ctx, badge.ID)
+		badge.EarnedAt = earnedAt.Time
+		badges = append(badges, &badge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: badgesTable, Cause: err}
+	}
+
+	return badges, nil
+}
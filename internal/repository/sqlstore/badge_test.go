@@ -0,0 +1,51 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestBadgeRepository_AwardAndList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBadgeRepository(newTestDB(t))
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.Award(ctx, &models.Badge{ID: "first_redemption", Name: "First Reward", Description: "Redeem a reward", EarnedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if err := repo.Award(ctx, &models.Badge{ID: "first_achievement", Name: "First Step", EarnedAt: base}); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if err := repo.Award(ctx, &models.Badge{ID: "first_achievement", Name: "First Step"}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	badges, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(badges) != 2 || badges[0].ID != "first_achievement" || badges[1].ID != "first_redemption" {
+		t.Fatalf("Expected badges in earned order, got %+v", badges)
+	}
+	if badges[1].Description != "Redeem a reward" || !badges[1].EarnedAt.Equal(base.Add(time.Hour)) {
+		t.Errorf("Unexpected badge: %+v", badges[1])
+	}
+
+	// 同じバッジでもテナントごとに獲得できる
+	acme := tenant.WithID(ctx, "acme")
+	if err := repo.Award(acme, &models.Badge{ID: "first_achievement", Name: "First Step"}); err != nil {
+		t.Fatalf("Award for another tenant failed: %v", err)
+	}
+	other, err := repo.List(acme)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(other) != 1 || other[0].ID != "first_achievement" {
+		t.Errorf("Expected one badge for another tenant, got %+v", other)
+	}
+}
@@ -0,0 +1,242 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/tenant"
+
+	// PostgreSQLドライバー
+	_ "github.com/jackc/pgx/v5/stdlib"
+	// SQLiteドライバー（cgo不要のため全プラットフォーム向けにクロスコンパイルできる）
+	_ "modernc.org/sqlite"
+)
+
+// テーブル名（DynamoDBと異なり1つのデータベースにまとめるため、設定のテーブル名は使用しない）
+const (
+	achievementsTable  = "achievements"
+	rewardsTable       = "rewards"
+	currentPointsTable = "current_points"
+	rewardHistoryTable = "reward_history"
+	pointLedgerTable   = "point_ledger"
+	completionsTable   = "completions"
+	badgesTable        = "badges"
+	goalsTable         = "goals"
+	favoritesTable     = "favorites"
+	wishlistTable      = "wishlist"
+	notesTable         = "notes"
+	driftEventsTable   = "drift_events"
+	reservationsTable  = "reservations"
+	questsTable        = "quests"
+	operationsTable    = "operations"
+	allowancesTable    = "allowances"
+	apiKeysTable       = "api_keys"
+)
+
+// DB SQLデータベースの接続
+type DB struct {
+	db      *sql.DB
+	dialect *dialect
+}
+
+// OpenSQLite SQLiteのデータベースファイルを開き、テーブルが無ければ作成
+func OpenSQLite(ctx context.Context, path string) (*DB, error) {
+	db, err := open(ctx, sqliteDialect, fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// OpenPostgres PostgreSQLに接続し、テーブルが無ければ作成
+func OpenPostgres(ctx context.Context, dsn string) (*DB, error) {
+	// DSNにはパスワードが含まれるためエラーメッセージには含めない
+	db, err := open(ctx, postgresDialect, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("postgres database: %w", err)
+	}
+	return db, nil
+}
+
+// open データベースに接続してスキーマを作成
+func open(ctx context.Context, d *dialect, dsn string) (*DB, error) {
+	db, err := sql.Open(d.driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open: %w", err)
+	}
+	if d.maxOpenConns > 0 {
+		db.SetMaxOpenConns(d.maxOpenConns)
+	}
+
+	for _, statement := range d.schema {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create schema: %w", err)
+		}
+	}
+
+	for _, column := range addedColumns {
+		if err := addColumnIfMissing(ctx, db, d, column); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to add column %s.%s: %w", column.table, column.name, err)
+		}
+	}
+
+	for _, statement := range addedIndexes {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+
+	return &DB{db: db, dialect: d}, nil
+}
+
+// addColumnIfMissing 列が無ければ追加し、既存の行の値を埋める
+func addColumnIfMissing(ctx context.Context, db *sql.DB, d *dialect, column addedColumn) error {
+	var count int
+	if err := db.QueryRowContext(ctx, d.rebind(d.columnExists), column.table, column.name).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	definition := column.definition
+	if column.timestamp {
+		definition = d.timestampType
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, definition)); err != nil {
+		return err
+	}
+	if column.backfill != "" {
+		if _, err := db.ExecContext(ctx, column.backfill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close データベース接続を閉じる
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// execer sql.DB と sql.Tx の共通インターフェース
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// rowScanner sql.Row と sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// exec クエリを実行
+func (d *DB) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return d.execWith(ctx, d.db, query, args...)
+}
+
+// execWith 接続またはトランザクションでクエリを実行
+func (d *DB) execWith(ctx context.Context, ex execer, query string, args ...interface{}) (sql.Result, error) {
+	return ex.ExecContext(ctx, d.dialect.rebind(query), d.dialect.bindArgs(args)...)
+}
+
+// query 複数行を取得
+func (d *DB) query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return d.db.QueryContext(ctx, d.dialect.rebind(query), d.dialect.bindArgs(args)...)
+}
+
+// queryRow 1行を取得
+func (d *DB) queryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return d.db.QueryRowContext(ctx, d.dialect.rebind(query), d.dialect.bindArgs(args)...)
+}
+
+// queryRowWith トランザクションで1行を取得
+func (d *DB) queryRowWith(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) *sql.Row {
+	return tx.QueryRowContext(ctx, d.dialect.rebind(query), d.dialect.bindArgs(args)...)
+}
+
+// count テナントのテーブルの行数を取得
+func (d *DB) count(ctx context.Context, table string) (int, error) {
+	var count int
+	if err := d.queryRow(ctx, `SELECT COUNT(*) FROM `+table+` WHERE tenant_id = ?`, tenant.FromContext(ctx)).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// withTx トランザクション内で関数を実行し、エラーの場合はロールバック
+func (d *DB) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// truncate 日時をデータベースに保存できる精度に丸める
+func (d *DB) truncate(t time.Time) time.Time {
+	return t.Truncate(d.dialect.precision)
+}
+
+// timestamp 保存された日時を読み取る（SQLiteはUNIX時間のナノ秒、PostgreSQLは TIMESTAMPTZ）
+type timestamp struct {
+	time.Time
+}
+
+// Scan sql.Scanner の実装
+func (t *timestamp) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		t.Time = time.Unix(0, v)
+	case time.Time:
+		t.Time = v
+	default:
+		return fmt.Errorf("unsupported timestamp value %T", src)
+	}
+	return nil
+}
+
+// nullTimestamp NULLを許容する保存された日時を読み取る（NULLの場合は Time が nil）
+type nullTimestamp struct {
+	Time *time.Time
+}
+
+// Scan sql.Scanner の実装
+func (t *nullTimestamp) Scan(src interface{}) error {
+	if src == nil {
+		t.Time = nil
+		return nil
+	}
+	var value timestamp
+	if err := value.Scan(src); err != nil {
+		return err
+	}
+	t.Time = &value.Time
+	return nil
+}
+
+// placeholders テナントのキーのIN句用のプレースホルダーと引数を作成
+func placeholders(ctx context.Context, ids []string) (string, []interface{}) {
+	marks := make([]byte, 0, len(ids)*2)
+	args := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		if i > 0 {
+			marks = append(marks, ',')
+		}
+		marks = append(marks, '?')
+		args = append(args, tenant.Key(ctx, id))
+	}
+	return string(marks), args
+}
@@ -0,0 +1,519 @@
+package sqlstore
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dialect データベースごとのSQLの差異
+type dialect struct {
+	// driverName database/sql のドライバー名
+	driverName string
+	// schema テーブル定義（id はテナントのキー、tenant_id はテナントの一覧の取得に使用する）
+	schema []string
+	// columnExists テーブルに列があれば1を返すクエリ（引数はテーブル名と列名）
+	columnExists string
+	// numberedParams プレースホルダーを $1, $2... の形式で書く
+	numberedParams bool
+	// maxOpenConns 同時接続数の上限（0の場合は無制限）
+	maxOpenConns int
+	// precision 保存できる日時の精度
+	precision time.Duration
+	// encodeTime 日時を保存用の値に変換
+	encodeTime func(time.Time) interface{}
+	// timestampType 日時の列の型（後から追加する列の定義に使用する）
+	timestampType string
+}
+
+// sqliteDialect SQLite用の設定（日時はUNIX時間のナノ秒で保存し、並び順を保証する）
+var sqliteDialect = &dialect{
+	driverName:   "sqlite",
+	columnExists: `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`,
+	schema: []string{
+		`CREATE TABLE IF NOT EXISTS achievements (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			point       INTEGER NOT NULL,
+			category    TEXT NOT NULL DEFAULT '',
+			created_at  INTEGER NOT NULL,
+			version     INTEGER NOT NULL DEFAULT 0,
+			attachment_key TEXT NOT NULL DEFAULT '',
+			due_date    TEXT NOT NULL DEFAULT '',
+			reminder    TEXT NOT NULL DEFAULT '',
+			difficulty  TEXT NOT NULL DEFAULT '',
+			max_per_day INTEGER NOT NULL DEFAULT 0,
+			max_total   INTEGER NOT NULL DEFAULT 0,
+			pinned      BOOLEAN NOT NULL DEFAULT FALSE,
+			sort_order  INTEGER NOT NULL DEFAULT 0,
+			points_per_hour  INTEGER NOT NULL DEFAULT 0,
+			timer_started_at INTEGER
+		)`,
+		`CREATE INDEX IF NOT EXISTS achievements_created_at ON achievements (created_at)`,
+		`CREATE TABLE IF NOT EXISTS rewards (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			point       INTEGER NOT NULL,
+			created_at  INTEGER NOT NULL,
+			version     INTEGER NOT NULL DEFAULT 0,
+			prizes      TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE INDEX IF NOT EXISTS rewards_created_at ON rewards (created_at)`,
+		`CREATE TABLE IF NOT EXISTS current_points (
+			id         TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			point      INTEGER NOT NULL,
+			reserved   INTEGER NOT NULL DEFAULT 0,
+			updated_at INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS reward_history (
+			id           TEXT PRIMARY KEY,
+			tenant_id    TEXT NOT NULL DEFAULT 'default',
+			reward_id    TEXT NOT NULL,
+			reward_title TEXT NOT NULL,
+			point_cost   INTEGER NOT NULL,
+			redeemed_at  INTEGER NOT NULL,
+			refunded_at  INTEGER,
+			attachment_key TEXT NOT NULL DEFAULT '',
+			note         TEXT NOT NULL DEFAULT '',
+			tags         TEXT NOT NULL DEFAULT '',
+			prize        TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE INDEX IF NOT EXISTS reward_history_redeemed_at ON reward_history (redeemed_at)`,
+		`CREATE TABLE IF NOT EXISTS point_ledger (
+			id         TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			type       TEXT NOT NULL,
+			amount     INTEGER NOT NULL,
+			reference  TEXT NOT NULL DEFAULT '',
+			created_at INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS point_ledger_created_at ON point_ledger (created_at)`,
+		`CREATE TABLE IF NOT EXISTS completions (
+			id                TEXT PRIMARY KEY,
+			tenant_id         TEXT NOT NULL DEFAULT 'default',
+			achievement_id    TEXT NOT NULL,
+			achievement_title TEXT NOT NULL,
+			point             INTEGER NOT NULL,
+			bonus_point       INTEGER NOT NULL DEFAULT 0,
+			completed_at      INTEGER NOT NULL,
+			started_at        INTEGER,
+			duration_seconds  INTEGER NOT NULL DEFAULT 0
+		)`,
+		`CREATE INDEX IF NOT EXISTS completions_tenant_achievement ON completions (tenant_id, achievement_id, completed_at)`,
+		`CREATE TABLE IF NOT EXISTS badges (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			name        TEXT NOT NULL,
+			description TEXT NOT NULL,
+			earned_at   INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS badges_tenant_earned_at ON badges (tenant_id, earned_at)`,
+		`CREATE TABLE IF NOT EXISTS goals (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL,
+			type        TEXT NOT NULL,
+			target      INTEGER NOT NULL,
+			reached_at  INTEGER,
+			created_at  INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS goals_tenant_created_at ON goals (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS favorites (
+			reward_id  TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			created_at INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS favorites_tenant_created_at ON favorites (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS wishlist (
+			reward_id  TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			priority   INTEGER NOT NULL,
+			created_at INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS wishlist_tenant_created_at ON wishlist (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS notes (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			target_type TEXT NOT NULL,
+			target_id   TEXT NOT NULL,
+			body        TEXT NOT NULL,
+			created_at  INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS notes_tenant_target ON notes (tenant_id, target_type, target_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS drift_events (
+			id              TEXT PRIMARY KEY,
+			tenant_id       TEXT NOT NULL DEFAULT 'default',
+			total_points    INTEGER NOT NULL,
+			current_balance INTEGER NOT NULL,
+			difference      INTEGER NOT NULL,
+			threshold       INTEGER NOT NULL,
+			detected_at     INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS drift_events_tenant_detected_at ON drift_events (tenant_id, detected_at)`,
+		`CREATE TABLE IF NOT EXISTS reservations (
+			reward_id  TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			points     INTEGER NOT NULL,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS reservations_tenant_created_at ON reservations (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS quests (
+			id           TEXT PRIMARY KEY,
+			tenant_id    TEXT NOT NULL DEFAULT 'default',
+			title        TEXT NOT NULL,
+			description  TEXT NOT NULL,
+			steps        TEXT NOT NULL,
+			bonus_point  INTEGER NOT NULL,
+			completed_at INTEGER,
+			created_at   INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS quests_tenant_created_at ON quests (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS operations (
+			id           TEXT PRIMARY KEY,
+			tenant_id    TEXT NOT NULL DEFAULT 'default',
+			entity       TEXT NOT NULL,
+			action       TEXT NOT NULL,
+			entity_id    TEXT NOT NULL,
+			with_points  INTEGER NOT NULL,
+			before_state TEXT NOT NULL,
+			after_state  TEXT NOT NULL,
+			undone_at    INTEGER,
+			created_at   INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS operations_tenant_created_at ON operations (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS allowances (
+			id              TEXT PRIMARY KEY,
+			tenant_id       TEXT NOT NULL DEFAULT 'default',
+			title           TEXT NOT NULL,
+			points          INTEGER NOT NULL,
+			schedule        TEXT NOT NULL,
+			last_granted_at INTEGER,
+			created_at      INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS allowances_tenant_created_at ON allowances (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS api_keys (
+			id            TEXT PRIMARY KEY,
+			tenant_id     TEXT NOT NULL DEFAULT 'default',
+			label         TEXT NOT NULL,
+			scope         TEXT NOT NULL,
+			key_tenant_id TEXT NOT NULL DEFAULT 'default',
+			hash          TEXT NOT NULL,
+			created_at    INTEGER NOT NULL,
+			rotated_at    INTEGER,
+			revoked_at    INTEGER
+		)`,
+		`CREATE INDEX IF NOT EXISTS api_keys_tenant_created_at ON api_keys (tenant_id, created_at)`,
+	},
+	// SQLiteの書き込みは直列のため、接続を1つにして SQLITE_BUSY を避ける
+	maxOpenConns:  1,
+	precision:     time.Nanosecond,
+	timestampType: "INTEGER",
+	encodeTime: func(t time.Time) interface{} {
+		return t.UnixNano()
+	},
+}
+
+// postgresDialect PostgreSQL用の設定（日時はSQLで集計しやすいよう TIMESTAMPTZ で保存する）
+var postgresDialect = &dialect{
+	driverName:   "pgx",
+	columnExists: `SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`,
+	schema: []string{
+		`CREATE TABLE IF NOT EXISTS achievements (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			point       INTEGER NOT NULL,
+			category    TEXT NOT NULL DEFAULT '',
+			created_at  TIMESTAMPTZ NOT NULL,
+			version     INTEGER NOT NULL DEFAULT 0,
+			attachment_key TEXT NOT NULL DEFAULT '',
+			due_date    TEXT NOT NULL DEFAULT '',
+			reminder    TEXT NOT NULL DEFAULT '',
+			difficulty  TEXT NOT NULL DEFAULT '',
+			max_per_day INTEGER NOT NULL DEFAULT 0,
+			max_total   INTEGER NOT NULL DEFAULT 0,
+			pinned      BOOLEAN NOT NULL DEFAULT FALSE,
+			sort_order  INTEGER NOT NULL DEFAULT 0,
+			points_per_hour  INTEGER NOT NULL DEFAULT 0,
+			timer_started_at TIMESTAMPTZ
+		)`,
+		`CREATE INDEX IF NOT EXISTS achievements_created_at ON achievements (created_at)`,
+		`CREATE TABLE IF NOT EXISTS rewards (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			point       INTEGER NOT NULL,
+			created_at  TIMESTAMPTZ NOT NULL,
+			version     INTEGER NOT NULL DEFAULT 0,
+			prizes      TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE INDEX IF NOT EXISTS rewards_created_at ON rewards (created_at)`,
+		`CREATE TABLE IF NOT EXISTS current_points (
+			id         TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			point      INTEGER NOT NULL,
+			reserved   INTEGER NOT NULL DEFAULT 0,
+			updated_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS reward_history (
+			id           TEXT PRIMARY KEY,
+			tenant_id    TEXT NOT NULL DEFAULT 'default',
+			reward_id    TEXT NOT NULL,
+			reward_title TEXT NOT NULL,
+			point_cost   INTEGER NOT NULL,
+			redeemed_at  TIMESTAMPTZ NOT NULL,
+			refunded_at  TIMESTAMPTZ,
+			attachment_key TEXT NOT NULL DEFAULT '',
+			note         TEXT NOT NULL DEFAULT '',
+			tags         TEXT NOT NULL DEFAULT '',
+			prize        TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE INDEX IF NOT EXISTS reward_history_redeemed_at ON reward_history (redeemed_at)`,
+		`CREATE TABLE IF NOT EXISTS point_ledger (
+			id         TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			type       TEXT NOT NULL,
+			amount     INTEGER NOT NULL,
+			reference  TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS point_ledger_created_at ON point_ledger (created_at)`,
+		`CREATE TABLE IF NOT EXISTS completions (
+			id                TEXT PRIMARY KEY,
+			tenant_id         TEXT NOT NULL DEFAULT 'default',
+			achievement_id    TEXT NOT NULL,
+			achievement_title TEXT NOT NULL,
+			point             INTEGER NOT NULL,
+			bonus_point       INTEGER NOT NULL DEFAULT 0,
+			completed_at      TIMESTAMPTZ NOT NULL,
+			started_at        TIMESTAMPTZ,
+			duration_seconds  INTEGER NOT NULL DEFAULT 0
+		)`,
+		`CREATE INDEX IF NOT EXISTS completions_tenant_achievement ON completions (tenant_id, achievement_id, completed_at)`,
+		`CREATE TABLE IF NOT EXISTS badges (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			name        TEXT NOT NULL,
+			description TEXT NOT NULL,
+			earned_at   TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS badges_tenant_earned_at ON badges (tenant_id, earned_at)`,
+		`CREATE TABLE IF NOT EXISTS goals (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL,
+			type        TEXT NOT NULL,
+			target      INTEGER NOT NULL,
+			reached_at  TIMESTAMPTZ,
+			created_at  TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS goals_tenant_created_at ON goals (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS favorites (
+			reward_id  TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			created_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS favorites_tenant_created_at ON favorites (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS wishlist (
+			reward_id  TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			priority   INTEGER NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS wishlist_tenant_created_at ON wishlist (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS notes (
+			id          TEXT PRIMARY KEY,
+			tenant_id   TEXT NOT NULL DEFAULT 'default',
+			target_type TEXT NOT NULL,
+			target_id   TEXT NOT NULL,
+			body        TEXT NOT NULL,
+			created_at  TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS notes_tenant_target ON notes (tenant_id, target_type, target_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS drift_events (
+			id              TEXT PRIMARY KEY,
+			tenant_id       TEXT NOT NULL DEFAULT 'default',
+			total_points    INTEGER NOT NULL,
+			current_balance INTEGER NOT NULL,
+			difference      INTEGER NOT NULL,
+			threshold       INTEGER NOT NULL,
+			detected_at     TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS drift_events_tenant_detected_at ON drift_events (tenant_id, detected_at)`,
+		`CREATE TABLE IF NOT EXISTS reservations (
+			reward_id  TEXT PRIMARY KEY,
+			tenant_id  TEXT NOT NULL DEFAULT 'default',
+			points     INTEGER NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS reservations_tenant_created_at ON reservations (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS quests (
+			id           TEXT PRIMARY KEY,
+			tenant_id    TEXT NOT NULL DEFAULT 'default',
+			title        TEXT NOT NULL,
+			description  TEXT NOT NULL,
+			steps        TEXT NOT NULL,
+			bonus_point  INTEGER NOT NULL,
+			completed_at TIMESTAMPTZ,
+			created_at   TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS quests_tenant_created_at ON quests (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS operations (
+			id           TEXT PRIMARY KEY,
+			tenant_id    TEXT NOT NULL DEFAULT 'default',
+			entity       TEXT NOT NULL,
+			action       TEXT NOT NULL,
+			entity_id    TEXT NOT NULL,
+			with_points  BOOLEAN NOT NULL,
+			before_state TEXT NOT NULL,
+			after_state  TEXT NOT NULL,
+			undone_at    TIMESTAMPTZ,
+			created_at   TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS operations_tenant_created_at ON operations (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS allowances (
+			id              TEXT PRIMARY KEY,
+			tenant_id       TEXT NOT NULL DEFAULT 'default',
+			title           TEXT NOT NULL,
+			points          INTEGER NOT NULL,
+			schedule        TEXT NOT NULL,
+			last_granted_at TIMESTAMPTZ,
+			created_at      TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS allowances_tenant_created_at ON allowances (tenant_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS api_keys (
+			id            TEXT PRIMARY KEY,
+			tenant_id     TEXT NOT NULL DEFAULT 'default',
+			label         TEXT NOT NULL,
+			scope         TEXT NOT NULL,
+			key_tenant_id TEXT NOT NULL DEFAULT 'default',
+			hash          TEXT NOT NULL,
+			created_at    TIMESTAMPTZ NOT NULL,
+			rotated_at    TIMESTAMPTZ,
+			revoked_at    TIMESTAMPTZ
+		)`,
+		`CREATE INDEX IF NOT EXISTS api_keys_tenant_created_at ON api_keys (tenant_id, created_at)`,
+	},
+	numberedParams: true,
+	precision:      time.Microsecond,
+	timestampType:  "TIMESTAMPTZ",
+	encodeTime: func(t time.Time) interface{} {
+		return t
+	},
+}
+
+// addedColumn テーブルの作成後に追加した列
+type addedColumn struct {
+	table      string
+	name       string
+	definition string
+	// timestamp NULLを許容する日時の列（definition の代わりにデータベースの日時の型で追加する）
+	timestamp bool
+	// backfill 列を追加した直後に既存の行の値を埋める文（空の場合は definition の既定値のまま）
+	backfill string
+}
+
+// addedColumns 既存のデータベースに不足していれば追加する列（新しく作成したテーブルには schema の定義で含まれる）
+var addedColumns = []addedColumn{
+	{table: achievementsTable, name: "version", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: rewardsTable, name: "version", definition: "INTEGER NOT NULL DEFAULT 0"},
+	// テナントを分ける前の行は既定のテナント（tenant.DefaultID）に属する
+	{table: achievementsTable, name: "tenant_id", definition: "TEXT NOT NULL DEFAULT 'default'"},
+	{table: rewardsTable, name: "tenant_id", definition: "TEXT NOT NULL DEFAULT 'default'"},
+	{table: currentPointsTable, name: "tenant_id", definition: "TEXT NOT NULL DEFAULT 'default'"},
+	{table: rewardHistoryTable, name: "tenant_id", definition: "TEXT NOT NULL DEFAULT 'default'"},
+	{table: pointLedgerTable, name: "tenant_id", definition: "TEXT NOT NULL DEFAULT 'default'"},
+	{table: completionsTable, name: "bonus_point", definition: "INTEGER NOT NULL DEFAULT 0"},
+	// 取り消していない報酬獲得はNULL
+	{table: rewardHistoryTable, name: "refunded_at", timestamp: true},
+	// 分類を導入する前の達成目録は未分類
+	{table: achievementsTable, name: "category", definition: "TEXT NOT NULL DEFAULT ''"},
+	// 添付ファイルの無い記録は空
+	{table: achievementsTable, name: "attachment_key", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: rewardHistoryTable, name: "attachment_key", definition: "TEXT NOT NULL DEFAULT ''"},
+	// 期限・リマインドの無い達成目録は空
+	{table: achievementsTable, name: "due_date", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: achievementsTable, name: "reminder", definition: "TEXT NOT NULL DEFAULT ''"},
+	// 難易度を設定していない達成目録は空
+	{table: achievementsTable, name: "difficulty", definition: "TEXT NOT NULL DEFAULT ''"},
+	// 達成できる回数を制限していない達成目録は0
+	{table: achievementsTable, name: "max_per_day", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: achievementsTable, name: "max_total", definition: "INTEGER NOT NULL DEFAULT 0"},
+	// 固定・並び替えをしていない達成目録は作成日時順
+	{table: achievementsTable, name: "pinned", definition: "BOOLEAN NOT NULL DEFAULT FALSE"},
+	{table: achievementsTable, name: "sort_order", definition: "INTEGER NOT NULL DEFAULT 0"},
+	// 時間を計測しない達成目録・達成記録は0（タイマーが止まっている達成目録・タイマーを使わない達成記録はNULL）
+	{table: achievementsTable, name: "points_per_hour", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: achievementsTable, name: "timer_started_at", timestamp: true},
+	{table: completionsTable, name: "started_at", timestamp: true},
+	{table: completionsTable, name: "duration_seconds", definition: "INTEGER NOT NULL DEFAULT 0"},
+	// 注記の無い報酬獲得履歴は空（タグはJSONの配列）
+	{table: rewardHistoryTable, name: "note", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: rewardHistoryTable, name: "tags", definition: "TEXT NOT NULL DEFAULT ''"},
+	// 抽選型でない報酬・報酬獲得履歴は空（景品はJSONの配列）
+	{table: rewardsTable, name: "prizes", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: rewardHistoryTable, name: "prize", definition: "TEXT NOT NULL DEFAULT ''"},
+	// 操作できるテナントを記録する前に発行したAPIキーは既定のテナント
+	{table: apiKeysTable, name: "key_tenant_id", definition: "TEXT NOT NULL DEFAULT 'default'"},
+	// 取り置いたポイントの合計は既存の取り置きから求める
+	{table: currentPointsTable, name: "reserved", definition: "INTEGER NOT NULL DEFAULT 0", backfill: `UPDATE current_points SET reserved = COALESCE(
+		(SELECT SUM(points) FROM reservations WHERE reservations.tenant_id = current_points.tenant_id), 0)`},
+}
+
+// addedIndexes 追加した列を使うインデックス（既存のデータベースでは列を追加した後に作成する）
+var addedIndexes = []string{
+	`CREATE INDEX IF NOT EXISTS achievements_tenant_created_at ON achievements (tenant_id, created_at)`,
+	`CREATE INDEX IF NOT EXISTS rewards_tenant_created_at ON rewards (tenant_id, created_at)`,
+	`CREATE INDEX IF NOT EXISTS reward_history_tenant_redeemed_at ON reward_history (tenant_id, redeemed_at)`,
+	`CREATE INDEX IF NOT EXISTS point_ledger_tenant_created_at ON point_ledger (tenant_id, created_at)`,
+}
+
+// rebind ? のプレースホルダーをデータベースの形式に変換
+func (d *dialect) rebind(query string) string {
+	if !d.numberedParams {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
+
+// bindArgs 引数の日時を保存用の値に変換（nil の *time.Time は NULL）
+func (d *dialect) bindArgs(args []interface{}) []interface{} {
+	bound := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch t := arg.(type) {
+		case time.Time:
+			arg = d.encodeTime(t)
+		case *time.Time:
+			if t == nil {
+				arg = nil
+			} else {
+				arg = d.encodeTime(*t)
+			}
+		}
+		bound[i] = arg
+	}
+	return bound
+}
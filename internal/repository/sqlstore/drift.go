@@ -0,0 +1,73 @@
+package sqlstore
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// DriftRepository SQLデータベースを使用したポイントの差異のリポジトリ
+type DriftRepository struct {
+	db *DB
+}
+
+// NewDriftRepository ポイントの差異のリポジトリを作成
+func NewDriftRepository(db *DB) repository.DriftRepository {
+	return &DriftRepository{db: db}
+}
+
+// Record 検出したポイントの差異を記録
+func (r *DriftRepository) Record(ctx context.Context, event *models.DriftEvent) error {
+	if err := repository.ValidateDriftEvent(event); err != nil {
+		return err
+	}
+
+	if event.ID == "" {
+		event.ID = ulid.Make().String()
+	}
+	if event.DetectedAt.IsZero() {
+		event.DetectedAt = time.Now()
+	}
+	event.DetectedAt = r.db.truncate(event.DetectedAt)
+
+	_, err := r.db.exec(ctx,
+		`INSERT INTO drift_events (id, tenant_id, total_points, current_balance, difference, threshold, detected_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		tenant.Key(ctx, event.ID), tenant.FromContext(ctx), event.TotalPoints, event.CurrentBalance, event.Difference, event.Threshold, event.DetectedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Record", Table: driftEventsTable, Cause: err}
+	}
+	return nil
+}
+
+// List 記録したポイントの差異を検出日時順に取得
+func (r *DriftRepository) List(ctx context.Context) ([]*models.DriftEvent, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, total_points, current_balance, difference, threshold, detected_at FROM drift_events WHERE tenant_id = ? ORDER BY detected_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: driftEventsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	driftEvents := []*models.DriftEvent{}
+	for rows.Next() {
+		var event models.DriftEvent
+		var detectedAt timestamp
+		if err := rows.Scan(&event.ID, &event.TotalPoints, &event.CurrentBalance, &event.Difference, &event.Threshold, &detectedAt); err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: driftEventsTable, Cause: err}
+		}
+		event.ID = tenant.EntityID(ctx, event.ID)
+		event.DetectedAt = detectedAt.Time
+		driftEvents = append(driftEvents, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: driftEventsTable, Cause: err}
+	}
+
+	return driftEvents, nil
+}
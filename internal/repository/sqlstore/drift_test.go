@@ -0,0 +1,44 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestDriftRepository_RecordAndList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewDriftRepository(newTestDB(t))
+
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.Record(ctx, &models.DriftEvent{Difference: 30, DetectedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := repo.Record(ctx, &models.DriftEvent{TotalPoints: 120, CurrentBalance: 100, Difference: 20, Threshold: 10, DetectedAt: base}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	driftEvents, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(driftEvents) != 2 || driftEvents[0].Difference != 20 || driftEvents[1].Difference != 30 {
+		t.Fatalf("Expected drift events in detected order, got %+v", driftEvents)
+	}
+	first := driftEvents[0]
+	if first.ID == "" || first.TotalPoints != 120 || first.CurrentBalance != 100 || first.Threshold != 10 || !first.DetectedAt.Equal(base) {
+		t.Errorf("Unexpected drift event: %+v", first)
+	}
+
+	// 他のテナントでは記録していない
+	other, err := repo.List(tenant.WithID(ctx, "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no drift events for another tenant, got %d", len(other))
+	}
+}
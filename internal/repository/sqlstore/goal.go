@@ -0,0 +1,180 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// GoalRepository SQLデータベースを使用した目標リポジトリ
+type GoalRepository struct {
+	db *DB
+}
+
+// NewGoalRepository 目標リポジトリを作成
+func NewGoalRepository(db *DB) repository.GoalRepository {
+	return &GoalRepository{db: db}
+}
+
+// Create 目標を作成
+func (r *GoalRepository) Create(ctx context.Context, goal *models.Goal) error {
+	if err := repository.ValidateGoal(goal); err != nil {
+		return err
+	}
+
+	if goal.ID == "" {
+		goal.ID = ulid.Make().String()
+	}
+	if goal.CreatedAt.IsZero() {
+		goal.CreatedAt = time.Now()
+	}
+	goal.CreatedAt = r.db.truncate(goal.CreatedAt)
+	goal.ReachedAt = r.truncateReachedAt(goal.ReachedAt)
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO goals (id, tenant_id, title, description, type, target, reached_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, goal.ID), tenant.FromContext(ctx), goal.Title, goal.Description, string(goal.Type), goal.Target, goal.ReachedAt, goal.CreatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: goalsTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// Update 目標を更新（達成日時を含めて上書きする）
+func (r *GoalRepository) Update(ctx context.Context, goal *models.Goal) error {
+	if err := repository.ValidateGoal(goal); err != nil {
+		return err
+	}
+	if goal.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+	goal.ReachedAt = r.truncateReachedAt(goal.ReachedAt)
+
+	result, err := r.db.exec(ctx,
+		`UPDATE goals SET title = ?, description = ?, type = ?, target = ?, reached_at = ? WHERE id = ?`,
+		goal.Title, goal.Description, string(goal.Type), goal.Target, goal.ReachedAt, tenant.Key(ctx, goal.ID))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Update", Table: goalsTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID IDで目標を取得
+func (r *GoalRepository) GetByID(ctx context.Context, id string) (*models.Goal, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	row := r.db.queryRow(ctx,
+		`SELECT id, title, description, type, target, reached_at, created_at FROM goals WHERE id = ?`, tenant.Key(ctx, id))
+	goal, err := scanGoal(ctx, row)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetByID", Table: goalsTable, Cause: err}
+	}
+
+	return goal, nil
+}
+
+// List すべての目標を作成日時順に取得
+func (r *GoalRepository) List(ctx context.Context) ([]*models.Goal, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, title, description, type, target, reached_at, created_at FROM goals WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: goalsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	goals := []*models.Goal{}
+	for rows.Next() {
+		goal, err := scanGoal(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: goalsTable, Cause: err}
+		}
+		goals = append(goals, goal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: goalsTable, Cause: err}
+	}
+
+	return goals, nil
+}
+
+// Delete 目標を削除
+func (r *GoalRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx, `DELETE FROM goals WHERE id = ?`, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Delete", Table: goalsTable, Cause: err}
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// MarkReached 目標の達成日時を記録（削除済み・達成済みの場合は errors.ErrVersionConflict）
+func (r *GoalRepository) MarkReached(ctx context.Context, id string, reachedAt time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx,
+		`UPDATE goals SET reached_at = ? WHERE id = ? AND reached_at IS NULL`, r.db.truncate(reachedAt), tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "MarkReached", Table: goalsTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrVersionConflict
+	}
+	return nil
+}
+
+// truncateReachedAt 達成日時をデータベースに保存できる精度に丸める
+func (r *GoalRepository) truncateReachedAt(reachedAt *time.Time) *time.Time {
+	if reachedAt == nil {
+		return nil
+	}
+	truncated := r.db.truncate(*reachedAt)
+	return &truncated
+}
+
+// scanGoal 行をテナントの目標に変換
+func scanGoal(ctx context.Context, row rowScanner) (*models.Goal, error) {
+	var goal models.Goal
+	var goalType string
+	var reachedAt nullTimestamp
+	var createdAt timestamp
+	if err := row.Scan(&goal.ID, &goal.Title, &goal.Description, &goalType, &goal.Target, &reachedAt, &createdAt); err != nil {
+		return nil, err
+	}
+	goal.ID = tenant.EntityID(ctx, goal.ID)
+	goal.Type = models.GoalType(goalType)
+	goal.ReachedAt = reachedAt.Time
+	goal.CreatedAt = createdAt.Time
+	return &goal, nil
+}
@@ -0,0 +1,92 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestGoalRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGoalRepository(newTestDB(t))
+
+	goal := &models.Goal{Title: "Switchのゲーム", Description: "500ポイント貯める", Type: models.GoalTypePoints, Target: 500}
+	if err := repo.Create(ctx, goal); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, goal); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, goal.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.Type != models.GoalTypePoints || stored.Target != 500 || stored.ReachedAt != nil || !stored.CreatedAt.Equal(goal.CreatedAt) {
+		t.Errorf("Unexpected goal: %+v", stored)
+	}
+
+	goal.Target = 600
+	if err := repo.Update(ctx, goal); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	goals, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(goals) != 1 || goals[0].Target != 600 {
+		t.Errorf("Expected updated goal, got %+v", goals)
+	}
+
+	// 他のテナントからは見えない
+	if _, err := repo.GetByID(tenant.WithID(ctx, "acme"), goal.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, goal.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Update(ctx, goal); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestGoalRepository_MarkReached(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGoalRepository(newTestDB(t))
+
+	goal := &models.Goal{Title: "達成目録10件", Type: models.GoalTypeAchievements, Target: 10}
+	if err := repo.Create(ctx, goal); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	reachedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	if err := repo.MarkReached(ctx, goal.ID, reachedAt); err != nil {
+		t.Fatalf("MarkReached failed: %v", err)
+	}
+	// 達成済みの目標には記録しない
+	if err := repo.MarkReached(ctx, goal.ID, reachedAt.Add(time.Hour)); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, goal.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.ReachedAt == nil || !stored.ReachedAt.Equal(reachedAt) {
+		t.Errorf("Expected reached at %v, got %v", reachedAt, stored.ReachedAt)
+	}
+
+	// 更新で達成日時を消すと再び達成を記録できる
+	stored.ReachedAt = nil
+	if err := repo.Update(ctx, stored); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := repo.MarkReached(ctx, goal.ID, reachedAt); err != nil {
+		t.Errorf("Expected MarkReached to succeed after clearing, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// NoteRepository SQLデータベースを使用した記録に付けるメモのリポジトリ
+type NoteRepository struct {
+	db *DB
+}
+
+// NewNoteRepository 記録に付けるメモのリポジトリを作成
+func NewNoteRepository(db *DB) repository.NoteRepository {
+	return &NoteRepository{db: db}
+}
+
+// Create メモを作成
+func (r *NoteRepository) Create(ctx context.Context, note *models.Note) error {
+	if err := repository.ValidateNote(note); err != nil {
+		return err
+	}
+
+	if note.ID == "" {
+		note.ID = ulid.Make().String()
+	}
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = time.Now()
+	}
+	note.CreatedAt = r.db.truncate(note.CreatedAt)
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO notes (id, tenant_id, target_type, target_id, body, created_at) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, note.ID), tenant.FromContext(ctx), string(note.TargetType), note.TargetID, note.Body, note.CreatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: notesTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// GetByID IDでメモを取得
+func (r *NoteRepository) GetByID(ctx context.Context, id string) (*models.Note, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	row := r.db.queryRow(ctx,
+		`SELECT id, target_type, target_id, body, created_at FROM notes WHERE id = ?`, tenant.Key(ctx, id))
+	note, err := scanNote(ctx, row)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetByID", Table: notesTable, Cause: err}
+	}
+
+	return note, nil
+}
+
+// ListByTarget 記録に付けたメモを作成日時順に取得
+func (r *NoteRepository) ListByTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error) {
+	if targetID == "" {
+		return nil, &errors.ValidationError{Field: "target_id", Message: "target_id is required"}
+	}
+
+	rows, err := r.db.query(ctx,
+		`SELECT id, target_type, target_id, body, created_at FROM notes
+		WHERE tenant_id = ? AND target_type = ? AND target_id = ? ORDER BY created_at, id`,
+		tenant.FromContext(ctx), string(targetType), targetID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "ListByTarget", Table: notesTable, Cause: err}
+	}
+	defer rows.Close()
+
+	notes := []*models.Note{}
+	for rows.Next() {
+		note, err := scanNote(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: "ListByTarget", Table: notesTable, Cause: err}
+		}
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "ListByTarget", Table: notesTable, Cause: err}
+	}
+
+	return notes, nil
+}
+
+// Delete メモを削除
+func (r *NoteRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx, `DELETE FROM notes WHERE id = ?`, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Delete", Table: notesTable, Cause: err}
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// scanNote 行をテナントのメモに変換
+func scanNote(ctx context.Context, row rowScanner) (*models.Note, error) {
+	var note models.Note
+	var targetType string
+	var createdAt timestamp
+	if err := row.Scan(&note.ID, &targetType, &note.TargetID, &note.Body, &createdAt); err != nil {
+		return nil, err
+	}
+	note.ID = tenant.EntityID(ctx, note.ID)
+	note.TargetType = models.NoteTargetType(targetType)
+	note.CreatedAt = createdAt.Time
+	return &note, nil
+}
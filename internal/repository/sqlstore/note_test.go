@@ -0,0 +1,64 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestNoteRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNoteRepository(newTestDB(t))
+
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	later := &models.Note{TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "2回目は30分で走れた", CreatedAt: base.Add(time.Hour)}
+	earlier := &models.Note{TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "雨の中で走った", CreatedAt: base}
+	other := &models.Note{TargetType: models.NoteTargetRedemption, TargetID: "achievement-1", Body: "別の記録", CreatedAt: base}
+	for _, note := range []*models.Note{later, earlier, other} {
+		if err := repo.Create(ctx, note); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	if err := repo.Create(ctx, later); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, earlier.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.TargetType != models.NoteTargetAchievement || stored.TargetID != "achievement-1" || stored.Body != "雨の中で走った" || !stored.CreatedAt.Equal(base) {
+		t.Errorf("Unexpected note: %+v", stored)
+	}
+
+	notes, err := repo.ListByTarget(ctx, models.NoteTargetAchievement, "achievement-1")
+	if err != nil {
+		t.Fatalf("ListByTarget failed: %v", err)
+	}
+	if len(notes) != 2 || notes[0].ID != earlier.ID || notes[1].ID != later.ID {
+		t.Errorf("Expected the achievement notes in creation order, got %+v", notes)
+	}
+
+	// 他のテナントからは見えない
+	notes, err = repo.ListByTarget(tenant.WithID(ctx, "acme"), models.NoteTargetAchievement, "achievement-1")
+	if err != nil {
+		t.Fatalf("ListByTarget failed: %v", err)
+	}
+	if len(notes) != 0 {
+		t.Errorf("Expected no notes for another tenant, got %d", len(notes))
+	}
+
+	if err := repo.Delete(ctx, earlier.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, earlier.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, earlier.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for a deleted note, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package sqlstore
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// OperationRepository SQLデータベースを使用した操作履歴のリポジトリ
+type OperationRepository struct {
+	db *DB
+}
+
+// NewOperationRepository 操作履歴のリポジトリを作成
+func NewOperationRepository(db *DB) repository.OperationRepository {
+	return &OperationRepository{db: db}
+}
+
+// Record 操作を記録
+func (r *OperationRepository) Record(ctx context.Context, operation *models.Operation) error {
+	if err := repository.PrepareOperation(operation); err != nil {
+		return err
+	}
+	operation.CreatedAt = r.db.truncate(operation.CreatedAt)
+	if operation.UndoneAt != nil {
+		undoneAt := r.db.truncate(*operation.UndoneAt)
+		operation.UndoneAt = &undoneAt
+	}
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO operations (id, tenant_id, entity, action, entity_id, with_points, before_state, after_state, undone_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, operation.ID), tenant.FromContext(ctx), string(operation.Entity), string(operation.Action), operation.EntityID, operation.WithPoints,
+		operation.Before, operation.After, operation.UndoneAt, operation.CreatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Record", Table: operationsTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// List 操作履歴を記録した順に取得
+func (r *OperationRepository) List(ctx context.Context) ([]*models.Operation, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, entity, action, entity_id, with_points, before_state, after_state, undone_at, created_at FROM operations WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: operationsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	operations := []*models.Operation{}
+	for rows.Next() {
+		var operation models.Operation
+		var entity, action string
+		var undoneAt nullTimestamp
+		var createdAt timestamp
+		if err := rows.Scan(&operation.ID, &entity, &action, &operation.EntityID, &operation.WithPoints, &operation.Before, &operation.After, &undoneAt, &createdAt); err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: operationsTable, Cause: err}
+		}
+		operation.ID = tenant.EntityID(ctx, operation.ID)
+		operation.Entity = models.OperationEntity(entity)
+		operation.Action = models.OperationAction(action)
+		operation.UndoneAt = undoneAt.Time
+		operation.CreatedAt = createdAt.Time
+		operations = append(operations, &operation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: operationsTable, Cause: err}
+	}
+
+	return operations, nil
+}
+
+// SetUndone 操作を元に戻した日時を記録（nil の場合は消去する。記録されていない場合は errors.ErrNotFound）
+func (r *OperationRepository) SetUndone(ctx context.Context, id string, undoneAt *time.Time) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+	if undoneAt != nil {
+		truncated := r.db.truncate(*undoneAt)
+		undoneAt = &truncated
+	}
+
+	result, err := r.db.exec(ctx, `UPDATE operations SET undone_at = ? WHERE id = ?`, undoneAt, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "SetUndone", Table: operationsTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Delete 操作を操作履歴から削除（記録されていない場合も成功する）
+func (r *OperationRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	if _, err := r.db.exec(ctx, `DELETE FROM operations WHERE id = ?`, tenant.Key(ctx, id)); err != nil {
+		return &errors.DatabaseError{Operation: "Delete", Table: operationsTable, Cause: err}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestOperationRepository_RecordAndList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOperationRepository(newTestDB(t))
+
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	first := &models.Operation{Entity: models.OperationEntityAchievement, Action: models.OperationActionUpdate, EntityID: "achievement-1", WithPoints: true, Before: `{"point":10}`, After: `{"point":20}`, CreatedAt: base}
+	second := &models.Operation{Entity: models.OperationEntityReward, Action: models.OperationActionDelete, EntityID: "reward-1", Before: `{"point":30}`, CreatedAt: base.Add(time.Minute)}
+	for _, operation := range []*models.Operation{second, first} {
+		if err := repo.Record(ctx, operation); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	if err := repo.Record(ctx, first); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	operations, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(operations) != 2 || operations[0].ID != first.ID || operations[1].ID != second.ID {
+		t.Fatalf("Expected operations in recorded order, got %+v", operations)
+	}
+	stored := operations[0]
+	if !stored.WithPoints || stored.Before != first.Before || stored.After != first.After || !stored.CreatedAt.Equal(base) || stored.UndoneAt != nil {
+		t.Errorf("Unexpected operation: %+v", stored)
+	}
+	if operations[1].After != "" {
+		t.Errorf("Expected no after state for a delete, got %q", operations[1].After)
+	}
+
+	// 他のテナントからは見えない
+	others, err := repo.List(tenant.WithID(ctx, "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(others) != 0 {
+		t.Errorf("Expected no operations for another tenant, got %+v", others)
+	}
+}
+
+func TestOperationRepository_SetUndoneAndDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOperationRepository(newTestDB(t))
+
+	operation := &models.Operation{Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-1", After: `{"point":30}`}
+	if err := repo.Record(ctx, operation); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	undoneAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := repo.SetUndone(ctx, operation.ID, &undoneAt); err != nil {
+		t.Fatalf("SetUndone failed: %v", err)
+	}
+	operations, _ := repo.List(ctx)
+	if operations[0].UndoneAt == nil || !operations[0].UndoneAt.Equal(undoneAt) {
+		t.Errorf("Expected UndoneAt to be recorded, got %v", operations[0].UndoneAt)
+	}
+
+	if err := repo.SetUndone(ctx, operation.ID, nil); err != nil {
+		t.Fatalf("SetUndone failed: %v", err)
+	}
+	operations, _ = repo.List(ctx)
+	if operations[0].UndoneAt != nil {
+		t.Errorf("Expected UndoneAt to be cleared, got %v", operations[0].UndoneAt)
+	}
+
+	if err := repo.Delete(ctx, operation.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.SetUndone(ctx, operation.ID, nil); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
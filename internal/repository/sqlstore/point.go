@@ -0,0 +1,515 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// currentPointsID 現在のポイントの行のID
+const currentPointsID = "current"
+
+// PointRepository SQLデータベースを使用したポイントリポジトリ
+type PointRepository struct {
+	db *DB
+}
+
+// NewPointRepository ポイントリポジトリを作成
+func NewPointRepository(db *DB) repository.PointRepository {
+	return &PointRepository{db: db}
+}
+
+// GetCurrentPoints 現在のポイントを取得
+func (r *PointRepository) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	var points models.CurrentPoints
+	var updatedAt timestamp
+	err := r.db.queryRow(ctx,
+		`SELECT id, point, reserved, updated_at FROM current_points WHERE id = ?`, tenant.Key(ctx, currentPointsID)).
+		Scan(&points.ID, &points.Point, &points.Reserved, &updatedAt)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			// 初回の場合は0ポイントで初期化
+			return &models.CurrentPoints{
+				ID:        currentPointsID,
+				Point:     0,
+				UpdatedAt: time.Now(),
+			}, nil
+		}
+		return nil, &errors.DatabaseError{Operation: "GetCurrentPoints", Table: currentPointsTable, Cause: err}
+	}
+
+	points.ID = currentPointsID
+	points.UpdatedAt = updatedAt.Time
+	return &points, nil
+}
+
+// GetCurrentPointsConsistent 現在のポイントを取得（SQLデータベースの読み取りは常に最新の値を返す）
+func (r *PointRepository) GetCurrentPointsConsistent(ctx context.Context) (*models.CurrentPoints, error) {
+	return r.GetCurrentPoints(ctx)
+}
+
+// UpdateCurrentPoints 現在のポイントを指定した値に修正し、差分を台帳に記録
+func (r *PointRepository) UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) error {
+	if points == nil {
+		return &errors.ValidationError{Field: "points", Message: "points cannot be nil"}
+	}
+
+	points.ID = currentPointsID
+	points.UpdatedAt = r.db.truncate(time.Now())
+
+	if points.Point < 0 {
+		return &errors.ValidationError{Field: "point", Message: "point cannot be negative"}
+	}
+
+	current, err := r.GetCurrentPoints(ctx)
+	if err != nil {
+		return err
+	}
+	if current.Point == points.Point {
+		return nil
+	}
+
+	entry := r.db.newLedgerEntry(models.LedgerEntryAdjustment, points.Point-current.Point, "")
+	err = r.db.withTx(ctx, func(tx *sql.Tx) error {
+		if err := r.db.insertLedgerEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+		// 読み取った後に残高が変わっていた場合は差分が正しくないため書き込まない
+		result, err := r.db.execWith(ctx, tx,
+			`INSERT INTO current_points (id, tenant_id, point, updated_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET point = excluded.point, updated_at = excluded.updated_at
+			WHERE current_points.point = ?`,
+			tenant.Key(ctx, points.ID), tenant.FromContext(ctx), points.Point, points.UpdatedAt, current.Point)
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+			return errors.ErrVersionConflict
+		}
+		return nil
+	})
+	if err == errors.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "UpdateCurrentPoints", Table: pointTables, Cause: err}
+	}
+
+	return nil
+}
+
+// CreateRewardHistory 報酬獲得履歴を作成
+func (r *PointRepository) CreateRewardHistory(ctx context.Context, history *models.RewardHistory) error {
+	if history == nil {
+		return &errors.ValidationError{Field: "history", Message: "history cannot be nil"}
+	}
+
+	if err := repository.ValidateRewardHistory(history); err != nil {
+		return err
+	}
+
+	r.db.prepareRewardHistory(history)
+
+	if err := r.db.insertRewardHistory(ctx, r.db.db, history); err != nil {
+		return &errors.DatabaseError{Operation: "CreateRewardHistory", Table: rewardHistoryTable, Cause: err}
+	}
+
+	return nil
+}
+
+// GetRewardHistory 報酬獲得履歴を獲得日時順に取得
+func (r *PointRepository) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
+	return r.getRewardHistory(ctx, "GetRewardHistory", time.Time{}, time.Time{})
+}
+
+// GetRewardHistoryBetween 獲得日時が from 以上 to 未満の報酬獲得履歴を獲得日時順に取得（ゼロ値の側は制限しない）
+func (r *PointRepository) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	if err := repository.ValidateRewardHistoryRange(from, to); err != nil {
+		return nil, err
+	}
+	return r.getRewardHistory(ctx, "GetRewardHistoryBetween", from, to)
+}
+
+// getRewardHistory 獲得日時の範囲をインデックスで絞り込んで報酬獲得履歴を取得
+func (r *PointRepository) getRewardHistory(ctx context.Context, operation string, from, to time.Time) ([]*models.RewardHistory, error) {
+	query := `SELECT id, reward_id, reward_title, point_cost, redeemed_at, refunded_at, attachment_key, note, tags, prize FROM reward_history WHERE tenant_id = ?`
+	args := []interface{}{tenant.FromContext(ctx)}
+	if !from.IsZero() {
+		query += ` AND redeemed_at >= ?`
+		args = append(args, from)
+	}
+	if !to.IsZero() {
+		query += ` AND redeemed_at < ?`
+		args = append(args, to)
+	}
+
+	rows, err := r.db.query(ctx, query+` ORDER BY redeemed_at, id`, args...)
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: operation, Table: rewardHistoryTable, Cause: err}
+	}
+	defer rows.Close()
+
+	history := []*models.RewardHistory{}
+	for rows.Next() {
+		item, err := scanRewardHistory(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: operation, Table: rewardHistoryTable, Cause: err}
+		}
+		history = append(history, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: operation, Table: rewardHistoryTable, Cause: err}
+	}
+
+	return history, nil
+}
+
+// CountRewardHistory 報酬獲得履歴の件数を取得
+func (r *PointRepository) CountRewardHistory(ctx context.Context) (int, error) {
+	count, err := r.db.count(ctx, rewardHistoryTable)
+	if err != nil {
+		return 0, &errors.DatabaseError{Operation: "CountRewardHistory", Table: rewardHistoryTable, Cause: err}
+	}
+	return count, nil
+}
+
+// RedeemPoints 報酬獲得のポイント減算・台帳への記録・履歴記録をトランザクションで実行（残高不足の場合は ErrInsufficientPoints）
+//
+// 他の報酬のために取り置いたポイントは使えず、獲得する報酬の取り置きは同じトランザクションで解除する。
+func (r *PointRepository) RedeemPoints(ctx context.Context, history *models.RewardHistory) error {
+	if history == nil {
+		return &errors.ValidationError{Field: "history", Message: "history cannot be nil"}
+	}
+
+	if err := repository.ValidateRewardHistory(history); err != nil {
+		return err
+	}
+
+	r.db.prepareRewardHistory(history)
+	entry := r.db.newLedgerEntry(models.LedgerEntrySpend, -history.PointCost, history.ID)
+
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		// 獲得する報酬の取り置きは解除し、他の報酬のために取り置いたポイントは使わない
+		release, err := r.db.reservedPoints(ctx, tx, history.RewardID)
+		if err != nil {
+			return err
+		}
+		if release > 0 {
+			result, err := r.db.execWith(ctx, tx,
+				`DELETE FROM reservations WHERE reward_id = ? AND points = ?`, tenant.Key(ctx, history.RewardID), release)
+			if err != nil {
+				return err
+			}
+			// 読み取った後に取り置きが変わっていた場合
+			if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+				return errors.ErrInsufficientPoints
+			}
+		}
+
+		result, err := r.db.execWith(ctx, tx,
+			`UPDATE current_points SET point = point + ?, reserved = reserved - ?, updated_at = ? WHERE id = ? AND point - reserved + ? >= ?`,
+			entry.Amount, release, entry.CreatedAt, tenant.Key(ctx, currentPointsID), release, history.PointCost)
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+			return errors.ErrInsufficientPoints
+		}
+		if err := r.db.insertLedgerEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+		return r.db.insertRewardHistory(ctx, tx, history)
+	})
+	if err == errors.ErrInsufficientPoints {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "RedeemPoints",
+			Table:     pointTables + "," + rewardHistoryTable,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetRewardHistoryByID IDで報酬獲得履歴を取得
+func (r *PointRepository) GetRewardHistoryByID(ctx context.Context, id string) (*models.RewardHistory, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	history, err := scanRewardHistory(ctx, r.db.queryRow(ctx,
+		`SELECT id, reward_id, reward_title, point_cost, redeemed_at, refunded_at, attachment_key, note, tags, prize FROM reward_history WHERE id = ?`, tenant.Key(ctx, id)))
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetRewardHistoryByID", Table: rewardHistoryTable, Cause: err}
+	}
+	return history, nil
+}
+
+// RefundRedemption 報酬獲得を取り消し、ポイントの返還・台帳への記録・取り消し日時の記録をトランザクションで実行
+//
+// 取り消し済みまたは削除済みの履歴の場合は errors.ErrVersionConflict を返す。
+func (r *PointRepository) RefundRedemption(ctx context.Context, history *models.RewardHistory) error {
+	if err := repository.PrepareRefund(history); err != nil {
+		return err
+	}
+
+	refundedAt := r.db.truncate(*history.RefundedAt)
+	history.RefundedAt = &refundedAt
+	entry := r.db.newLedgerEntry(models.LedgerEntryRefund, history.PointCost, history.ID)
+
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := r.db.execWith(ctx, tx,
+			`UPDATE reward_history SET refunded_at = ? WHERE id = ? AND refunded_at IS NULL`, refundedAt, tenant.Key(ctx, history.ID))
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+			return errors.ErrVersionConflict
+		}
+		return r.db.addToBalance(ctx, tx, entry)
+	})
+	if err == errors.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "RefundRedemption",
+			Table:     pointTables + "," + rewardHistoryTable,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// GetLedger ポイント台帳を記録順に取得
+func (r *PointRepository) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	return r.getLedger(ctx, "GetLedger", time.Time{}, time.Time{})
+}
+
+// GetLedgerBetween 記録日時が from 以上 to 未満のポイント台帳のエントリを記録順に取得（ゼロ値の側は制限しない）
+func (r *PointRepository) GetLedgerBetween(ctx context.Context, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	if err := repository.ValidateLedgerRange(from, to); err != nil {
+		return nil, err
+	}
+	return r.getLedger(ctx, "GetLedgerBetween", from, to)
+}
+
+// getLedger 記録日時の範囲をインデックスで絞り込んでポイント台帳を取得
+func (r *PointRepository) getLedger(ctx context.Context, operation string, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	query := `SELECT id, type, amount, reference, created_at FROM point_ledger WHERE tenant_id = ?`
+	args := []interface{}{tenant.FromContext(ctx)}
+	if !from.IsZero() {
+		query += ` AND created_at >= ?`
+		args = append(args, from)
+	}
+	if !to.IsZero() {
+		query += ` AND created_at < ?`
+		args = append(args, to)
+	}
+
+	rows, err := r.db.query(ctx, query+` ORDER BY created_at, id`, args...)
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: operation, Table: pointLedgerTable, Cause: err}
+	}
+	defer rows.Close()
+
+	entries := []*models.PointLedgerEntry{}
+	for rows.Next() {
+		var entry models.PointLedgerEntry
+		var createdAt timestamp
+		if err := rows.Scan(&entry.ID, &entry.Type, &entry.Amount, &entry.Reference, &createdAt); err != nil {
+			return nil, &errors.DatabaseError{Operation: operation, Table: pointLedgerTable, Cause: err}
+		}
+		entry.ID = tenant.EntityID(ctx, entry.ID)
+		entry.CreatedAt = createdAt.Time
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: operation, Table: pointLedgerTable, Cause: err}
+	}
+
+	return entries, nil
+}
+
+// AddPoints ポイントを加算（達成目録追加時に使用）
+func (r *PointRepository) AddPoints(ctx context.Context, points int) error {
+	if points <= 0 {
+		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+
+	entry := r.db.newLedgerEntry(models.LedgerEntryGrant, points, "")
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		return r.db.addToBalance(ctx, tx, entry)
+	})
+	if err != nil {
+		return &errors.DatabaseError{Operation: "AddPoints", Table: pointTables, Cause: err}
+	}
+
+	return nil
+}
+
+// SubtractPoints ポイントを減算（報酬獲得時に使用）
+func (r *PointRepository) SubtractPoints(ctx context.Context, points int) error {
+	if points <= 0 {
+		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+
+	entry := r.db.newLedgerEntry(models.LedgerEntrySpend, -points, "")
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		return r.db.addToBalance(ctx, tx, entry)
+	})
+	if err == errors.ErrInsufficientPoints {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "SubtractPoints", Table: pointTables, Cause: err}
+	}
+
+	return nil
+}
+
+// pointTables 残高と台帳のテーブル名（エラーメッセージ用）
+const pointTables = currentPointsTable + "," + pointLedgerTable
+
+// addToBalance 台帳にエントリを追記し、読み取りを挟まずに残高をアトミックに増減
+// 減算は残高が足りる場合のみ行い、足りない場合（行が未作成の0ポイントを含む）は ErrInsufficientPoints を返す
+func (d *DB) addToBalance(ctx context.Context, ex execer, entry *models.PointLedgerEntry) error {
+	if entry.Amount >= 0 {
+		_, err := d.execWith(ctx, ex,
+			`INSERT INTO current_points (id, tenant_id, point, updated_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET point = current_points.point + excluded.point, updated_at = excluded.updated_at`,
+			tenant.Key(ctx, currentPointsID), tenant.FromContext(ctx), entry.Amount, entry.CreatedAt)
+		if err != nil {
+			return err
+		}
+	} else {
+		result, err := d.execWith(ctx, ex,
+			`UPDATE current_points SET point = point + ?, updated_at = ? WHERE id = ? AND point >= ?`,
+			entry.Amount, entry.CreatedAt, tenant.Key(ctx, currentPointsID), -entry.Amount)
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+			return errors.ErrInsufficientPoints
+		}
+	}
+
+	return d.insertLedgerEntry(ctx, ex, entry)
+}
+
+// insertLedgerEntry ポイント台帳にエントリを追記
+func (d *DB) insertLedgerEntry(ctx context.Context, ex execer, entry *models.PointLedgerEntry) error {
+	_, err := d.execWith(ctx, ex,
+		`INSERT INTO point_ledger (id, tenant_id, type, amount, reference, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		tenant.Key(ctx, entry.ID), tenant.FromContext(ctx), entry.Type, entry.Amount, entry.Reference, entry.CreatedAt)
+	return err
+}
+
+// newLedgerEntry 保存できる精度に丸めたポイント台帳のエントリを作成
+func (d *DB) newLedgerEntry(entryType string, amount int, reference string) *models.PointLedgerEntry {
+	entry := repository.NewLedgerEntry(entryType, amount, reference)
+	entry.CreatedAt = d.truncate(entry.CreatedAt)
+	return entry
+}
+
+// SetRedemptionAttachment 報酬獲得履歴に添付ファイルのオブジェクトキーを保存
+func (r *PointRepository) SetRedemptionAttachment(ctx context.Context, id, key string) error {
+	if err := repository.ValidateAttachment(id, key); err != nil {
+		return err
+	}
+	return r.db.setAttachmentKey(ctx, rewardHistoryTable, "SetRedemptionAttachment", id, key)
+}
+
+// AnnotateRedemption 報酬獲得履歴の注記とタグを置き換える（タグはJSONの配列で保存する）
+func (r *PointRepository) AnnotateRedemption(ctx context.Context, id, note string, tags []string) error {
+	if err := repository.ValidateRedemptionAnnotation(id, tags); err != nil {
+		return err
+	}
+
+	encoded := ""
+	if len(tags) > 0 {
+		b, err := json.Marshal(tags)
+		if err != nil {
+			return &errors.DatabaseError{Operation: "AnnotateRedemption", Table: rewardHistoryTable, Cause: err}
+		}
+		encoded = string(b)
+	}
+
+	result, err := r.db.exec(ctx, `UPDATE reward_history SET note = ?, tags = ? WHERE id = ?`, note, encoded, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "AnnotateRedemption", Table: rewardHistoryTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// setAttachmentKey 行に添付ファイルのオブジェクトキーを保存（行が無い場合は ErrNotFound）
+func (d *DB) setAttachmentKey(ctx context.Context, table, operation, id, key string) error {
+	result, err := d.exec(ctx, `UPDATE `+table+` SET attachment_key = ? WHERE id = ?`, key, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: operation, Table: table, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// scanRewardHistory 報酬獲得履歴の行を読み取る
+func scanRewardHistory(ctx context.Context, row rowScanner) (*models.RewardHistory, error) {
+	var history models.RewardHistory
+	var redeemedAt timestamp
+	var refundedAt nullTimestamp
+	var tags string
+	if err := row.Scan(&history.ID, &history.RewardID, &history.RewardTitle, &history.PointCost, &redeemedAt, &refundedAt, &history.AttachmentKey, &history.Note, &tags, &history.Prize); err != nil {
+		return nil, err
+	}
+	if tags != "" {
+		if err := json.Unmarshal([]byte(tags), &history.Tags); err != nil {
+			return nil, err
+		}
+	}
+	history.ID = tenant.EntityID(ctx, history.ID)
+	history.RedeemedAt = redeemedAt.Time
+	history.RefundedAt = refundedAt.Time
+	return &history, nil
+}
+
+// insertRewardHistory 報酬獲得履歴を書き込み
+func (d *DB) insertRewardHistory(ctx context.Context, ex execer, history *models.RewardHistory) error {
+	_, err := d.execWith(ctx, ex,
+		`INSERT INTO reward_history (id, tenant_id, reward_id, reward_title, point_cost, redeemed_at, refunded_at, prize) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		tenant.Key(ctx, history.ID), tenant.FromContext(ctx), history.RewardID, history.RewardTitle, history.PointCost, history.RedeemedAt, history.RefundedAt, history.Prize)
+	return err
+}
+
+// prepareRewardHistory IDと獲得日時が未設定の場合に設定
+func (d *DB) prepareRewardHistory(history *models.RewardHistory) {
+	if history.ID == "" {
+		history.ID = ulid.Make().String()
+	}
+	if history.RedeemedAt.IsZero() {
+		history.RedeemedAt = time.Now()
+	}
+	history.RedeemedAt = d.truncate(history.RedeemedAt)
+}
@@ -0,0 +1,184 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// QuestRepository SQLデータベースを使用したクエストリポジトリ
+type QuestRepository struct {
+	db *DB
+}
+
+// NewQuestRepository クエストリポジトリを作成
+func NewQuestRepository(db *DB) repository.QuestRepository {
+	return &QuestRepository{db: db}
+}
+
+// Create クエストを作成（ステップはJSONの配列で保存する）
+func (r *QuestRepository) Create(ctx context.Context, quest *models.Quest) error {
+	if err := repository.ValidateQuest(quest); err != nil {
+		return err
+	}
+
+	if quest.ID == "" {
+		quest.ID = ulid.Make().String()
+	}
+	if quest.CreatedAt.IsZero() {
+		quest.CreatedAt = time.Now()
+	}
+	quest.CreatedAt = r.db.truncate(quest.CreatedAt)
+	if quest.CompletedAt != nil {
+		completedAt := r.db.truncate(*quest.CompletedAt)
+		quest.CompletedAt = &completedAt
+	}
+
+	steps, err := json.Marshal(quest.Steps)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: questsTable, Cause: err}
+	}
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO quests (id, tenant_id, title, description, steps, bonus_point, completed_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, quest.ID), tenant.FromContext(ctx), quest.Title, quest.Description, string(steps), quest.BonusPoint, quest.CompletedAt, quest.CreatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: questsTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// GetByID IDでクエストを取得
+func (r *QuestRepository) GetByID(ctx context.Context, id string) (*models.Quest, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	row := r.db.queryRow(ctx,
+		`SELECT id, title, description, steps, bonus_point, completed_at, created_at FROM quests WHERE id = ?`, tenant.Key(ctx, id))
+	quest, err := scanQuest(ctx, row)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetByID", Table: questsTable, Cause: err}
+	}
+
+	return quest, nil
+}
+
+// List すべてのクエストを作成日時順に取得
+func (r *QuestRepository) List(ctx context.Context) ([]*models.Quest, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT id, title, description, steps, bonus_point, completed_at, created_at FROM quests WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: questsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	quests := []*models.Quest{}
+	for rows.Next() {
+		quest, err := scanQuest(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: questsTable, Cause: err}
+		}
+		quests = append(quests, quest)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: questsTable, Cause: err}
+	}
+
+	return quests, nil
+}
+
+// Delete クエストを削除（完了時に付与したボーナスは取り消さない）
+func (r *QuestRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx, `DELETE FROM quests WHERE id = ?`, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Delete", Table: questsTable, Cause: err}
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Complete クエストの完了日時の記録と、ボーナスのポイントの付与・台帳への記録をトランザクションで実行
+//
+// 削除済みまたは完了済みのクエストの場合は errors.ErrVersionConflict を返す。
+func (r *QuestRepository) Complete(ctx context.Context, quest *models.Quest) error {
+	if err := repository.PrepareQuestCompletion(quest); err != nil {
+		return err
+	}
+
+	completedAt := r.db.truncate(*quest.CompletedAt)
+	quest.CompletedAt = &completedAt
+	var bonus *models.PointLedgerEntry
+	if quest.BonusPoint > 0 {
+		bonus = r.db.newLedgerEntry(models.LedgerEntryBonus, quest.BonusPoint, quest.ID)
+	}
+
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := r.db.execWith(ctx, tx,
+			`UPDATE quests SET completed_at = ? WHERE id = ? AND completed_at IS NULL`, completedAt, tenant.Key(ctx, quest.ID))
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+			return errors.ErrVersionConflict
+		}
+		if bonus != nil {
+			return r.db.addToBalance(ctx, tx, bonus)
+		}
+		return nil
+	})
+	if err == errors.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{
+			Operation: "Complete",
+			Table:     pointTables + "," + questsTable,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// scanQuest 行をテナントのクエストに変換
+func scanQuest(ctx context.Context, row rowScanner) (*models.Quest, error) {
+	var quest models.Quest
+	var steps string
+	var completedAt nullTimestamp
+	var createdAt timestamp
+	if err := row.Scan(&quest.ID, &quest.Title, &quest.Description, &steps, &quest.BonusPoint, &completedAt, &createdAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(steps), &quest.Steps); err != nil {
+		return nil, err
+	}
+	quest.ID = tenant.EntityID(ctx, quest.ID)
+	quest.CompletedAt = completedAt.Time
+	quest.CreatedAt = createdAt.Time
+	return &quest, nil
+}
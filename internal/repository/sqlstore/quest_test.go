@@ -0,0 +1,94 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestQuestRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewQuestRepository(newTestDB(t))
+
+	quest := &models.Quest{Title: "朝活", Description: "早起きしてから走る", Steps: []string{"wake-up", "run"}, BonusPoint: 50}
+	if err := repo.Create(ctx, quest); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, quest); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, quest.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if len(stored.Steps) != 2 || stored.Steps[0] != "wake-up" || stored.Steps[1] != "run" || stored.BonusPoint != 50 || stored.CompletedAt != nil || !stored.CreatedAt.Equal(quest.CreatedAt) {
+		t.Errorf("Unexpected quest: %+v", stored)
+	}
+
+	quests, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(quests) != 1 || quests[0].ID != quest.ID {
+		t.Errorf("Expected the created quest, got %+v", quests)
+	}
+
+	// 他のテナントからは見えない
+	if _, err := repo.GetByID(tenant.WithID(ctx, "acme"), quest.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, quest.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, quest.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestQuestRepository_Complete(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := NewQuestRepository(db)
+	points := NewPointRepository(db)
+
+	quest := &models.Quest{Title: "朝活", Steps: []string{"wake-up", "run"}, BonusPoint: 50}
+	if err := repo.Create(ctx, quest); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := repo.Complete(ctx, quest); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	// 完了済みのクエストにはボーナスを付与しない
+	if err := repo.Complete(ctx, quest); err != errors.ErrVersionConflict {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	current, err := points.GetCurrentPoints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentPoints failed: %v", err)
+	}
+	if current.Point != 50 {
+		t.Errorf("Expected 50 points, got %d", current.Point)
+	}
+	ledger, err := points.GetLedger(ctx)
+	if err != nil {
+		t.Fatalf("GetLedger failed: %v", err)
+	}
+	if len(ledger) != 1 || ledger[0].Type != models.LedgerEntryBonus || ledger[0].Reference != quest.ID {
+		t.Errorf("Expected a bonus ledger entry, got %+v", ledger)
+	}
+
+	stored, err := repo.GetByID(ctx, quest.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.CompletedAt == nil || !stored.CompletedAt.Equal(*quest.CompletedAt) {
+		t.Errorf("Expected CompletedAt %v, got %v", quest.CompletedAt, stored.CompletedAt)
+	}
+}
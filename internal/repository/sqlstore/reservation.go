@@ -0,0 +1,164 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// ReservationRepository SQLデータベースを使用したポイントの取り置きリポジトリ
+type ReservationRepository struct {
+	db *DB
+}
+
+// NewReservationRepository ポイントの取り置きリポジトリを作成
+func NewReservationRepository(db *DB) repository.ReservationRepository {
+	return &ReservationRepository{db: db}
+}
+
+// Put 報酬のためにポイントを取り置く（取り置き済みの場合は取り置くポイントを置き換える）
+//
+// 取り置きと同じトランザクションで現在のポイントの取り置きの合計を増減する。取り置いていないポイントが足りない場合は
+// errors.ErrInsufficientPoints、読み取った後に同じ報酬の取り置きが変わっていた場合は errors.ErrVersionConflict を返す。
+func (r *ReservationRepository) Put(ctx context.Context, reservation *models.Reservation) error {
+	if err := repository.ValidateReservation(reservation); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if reservation.CreatedAt.IsZero() {
+		reservation.CreatedAt = now
+	}
+	reservation.CreatedAt = r.db.truncate(reservation.CreatedAt)
+	reservation.UpdatedAt = r.db.truncate(now)
+
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		previous, err := r.db.reservedPoints(ctx, tx, reservation.RewardID)
+		if err != nil {
+			return err
+		}
+
+		// 読み取った後に同じ報酬の取り置きが変わっていた場合は合計の差分が正しくないため書き込まない
+		result, err := r.db.execWith(ctx, tx,
+			`INSERT INTO reservations (reward_id, tenant_id, points, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (reward_id) DO UPDATE SET points = excluded.points, updated_at = excluded.updated_at
+			WHERE reservations.points = ?`,
+			tenant.Key(ctx, reservation.RewardID), tenant.FromContext(ctx), reservation.Points, reservation.CreatedAt, reservation.UpdatedAt, previous)
+		if err != nil {
+			return err
+		}
+		if written, err := result.RowsAffected(); err == nil && written == 0 {
+			return errors.ErrVersionConflict
+		}
+
+		return r.db.addToReserved(ctx, tx, reservation.Points-previous)
+	})
+	if err == errors.ErrInsufficientPoints || err == errors.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Put", Table: reservationsTable + "," + currentPointsTable, Cause: err}
+	}
+	return nil
+}
+
+// Remove 取り置きを解除し、同じトランザクションで取り置きの合計から除く（取り置いていない場合は errors.ErrNotFound）
+func (r *ReservationRepository) Remove(ctx context.Context, rewardID string) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	err := r.db.withTx(ctx, func(tx *sql.Tx) error {
+		points, err := r.db.reservedPoints(ctx, tx, rewardID)
+		if err != nil {
+			return err
+		}
+		if points == 0 {
+			return errors.ErrNotFound
+		}
+
+		result, err := r.db.execWith(ctx, tx,
+			`DELETE FROM reservations WHERE reward_id = ? AND points = ?`, tenant.Key(ctx, rewardID), points)
+		if err != nil {
+			return err
+		}
+		// 読み取った後に解除・変更されていた場合
+		if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+			return errors.ErrVersionConflict
+		}
+
+		return r.db.addToReserved(ctx, tx, -points)
+	})
+	if err == errors.ErrNotFound || err == errors.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Remove", Table: reservationsTable + "," + currentPointsTable, Cause: err}
+	}
+	return nil
+}
+
+// List 取り置きを取り置いた日時順に取得
+func (r *ReservationRepository) List(ctx context.Context) ([]*models.Reservation, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT reward_id, points, created_at, updated_at FROM reservations WHERE tenant_id = ? ORDER BY created_at, reward_id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: reservationsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	reservations := []*models.Reservation{}
+	for rows.Next() {
+		var reservation models.Reservation
+		var createdAt, updatedAt timestamp
+		if err := rows.Scan(&reservation.RewardID, &reservation.Points, &createdAt, &updatedAt); err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: reservationsTable, Cause: err}
+		}
+		reservation.RewardID = tenant.EntityID(ctx, reservation.RewardID)
+		reservation.CreatedAt = createdAt.Time
+		reservation.UpdatedAt = updatedAt.Time
+		reservations = append(reservations, &reservation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: reservationsTable, Cause: err}
+	}
+
+	return reservations, nil
+}
+
+// reservedPoints rewardID のために取り置いたポイントをトランザクションで読み取る（取り置いていない場合は0）
+func (d *DB) reservedPoints(ctx context.Context, tx *sql.Tx, rewardID string) (int, error) {
+	var points int
+	err := d.queryRowWith(ctx, tx, `SELECT points FROM reservations WHERE reward_id = ?`, tenant.Key(ctx, rewardID)).Scan(&points)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return points, err
+}
+
+// addToReserved 取り置いたポイントの合計を読み取りを挟まずにアトミックに増減
+// 増やす場合は取り置いていないポイントが足りる場合のみ行い、足りない場合（行が未作成の0ポイントを含む）は ErrInsufficientPoints を返す
+func (d *DB) addToReserved(ctx context.Context, ex execer, delta int) error {
+	if delta <= 0 {
+		_, err := d.execWith(ctx, ex,
+			`UPDATE current_points SET reserved = reserved + ? WHERE id = ?`, delta, tenant.Key(ctx, currentPointsID))
+		return err
+	}
+
+	result, err := d.execWith(ctx, ex,
+		`UPDATE current_points SET reserved = reserved + ? WHERE id = ? AND point - reserved >= ?`,
+		delta, tenant.Key(ctx, currentPointsID), delta)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrInsufficientPoints
+	}
+	return nil
+}
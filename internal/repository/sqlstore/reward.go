@@ -0,0 +1,238 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// RewardRepository SQLデータベースを使用した報酬リポジトリ
+type RewardRepository struct {
+	db *DB
+}
+
+// NewRewardRepository 報酬リポジトリを作成
+func NewRewardRepository(db *DB) repository.RewardRepository {
+	return &RewardRepository{db: db}
+}
+
+// Create 報酬を作成
+func (r *RewardRepository) Create(ctx context.Context, reward *models.Reward) error {
+	if reward == nil {
+		return &errors.ValidationError{Field: "reward", Message: "reward cannot be nil"}
+	}
+
+	if err := repository.ValidateReward(reward); err != nil {
+		return err
+	}
+
+	if reward.ID == "" {
+		reward.ID = ulid.Make().String()
+	}
+	if reward.CreatedAt.IsZero() {
+		reward.CreatedAt = time.Now()
+	}
+	reward.CreatedAt = r.db.truncate(reward.CreatedAt)
+	reward.Version = 1
+
+	prizes, err := encodePrizes(reward.Prizes)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: rewardsTable, Cause: err}
+	}
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO rewards (id, tenant_id, title, description, point, created_at, version, prizes) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		tenant.Key(ctx, reward.ID), tenant.FromContext(ctx), reward.Title, reward.Description, reward.Point, reward.CreatedAt, reward.Version, prizes)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Create", Table: rewardsTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// Update 報酬を更新
+func (r *RewardRepository) Update(ctx context.Context, reward *models.Reward) error {
+	if reward == nil {
+		return &errors.ValidationError{Field: "reward", Message: "reward cannot be nil"}
+	}
+
+	if reward.ID == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required for update"}
+	}
+
+	if err := repository.ValidateReward(reward); err != nil {
+		return err
+	}
+
+	existing, err := r.GetByID(ctx, reward.ID)
+	if err != nil {
+		return err
+	}
+
+	// 作成日時は元の値を保持
+	reward.CreatedAt = existing.CreatedAt
+
+	// バージョンが指定されていない場合は取得した時点のバージョンから更新する
+	expectedVersion := reward.Version
+	if expectedVersion == 0 {
+		expectedVersion = existing.Version
+	}
+
+	prizes, err := encodePrizes(reward.Prizes)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Update", Table: rewardsTable, Cause: err}
+	}
+
+	result, err := r.db.exec(ctx,
+		`UPDATE rewards SET title = ?, description = ?, point = ?, prizes = ?, version = version + 1 WHERE id = ? AND version = ?`,
+		reward.Title, reward.Description, reward.Point, prizes, tenant.Key(ctx, reward.ID), expectedVersion)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Update", Table: rewardsTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		// 取得後に削除された場合
+		if _, err := r.GetByID(ctx, reward.ID); stderrors.Is(err, errors.ErrNotFound) {
+			return errors.ErrNotFound
+		}
+		// 別の更新が先に書き込まれていた場合
+		return errors.ErrVersionConflict
+	}
+
+	reward.Version = expectedVersion + 1
+	return nil
+}
+
+// GetByID IDで報酬を取得
+func (r *RewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	if id == "" {
+		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	row := r.db.queryRow(ctx,
+		`SELECT id, title, description, point, created_at, version, prizes FROM rewards WHERE id = ?`, tenant.Key(ctx, id))
+	reward, err := scanReward(ctx, row)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, &errors.DatabaseError{Operation: "GetByID", Table: rewardsTable, Cause: err}
+	}
+
+	return reward, nil
+}
+
+// GetByIDs 複数のIDの報酬をまとめて取得（存在しないIDは結果に含まれない）
+func (r *RewardRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	for _, id := range ids {
+		if id == "" {
+			return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+		}
+	}
+
+	if len(ids) == 0 {
+		return []*models.Reward{}, nil
+	}
+
+	marks, args := placeholders(ctx, ids)
+	return r.query(ctx, "GetByIDs",
+		`SELECT id, title, description, point, created_at, version, prizes FROM rewards WHERE id IN (`+marks+`)`, args...)
+}
+
+// List すべての報酬を作成日時順に取得
+func (r *RewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
+	return r.query(ctx, "List",
+		`SELECT id, title, description, point, created_at, version, prizes FROM rewards WHERE tenant_id = ? ORDER BY created_at, id`, tenant.FromContext(ctx))
+}
+
+// Count 報酬の件数を取得
+func (r *RewardRepository) Count(ctx context.Context) (int, error) {
+	count, err := r.db.count(ctx, rewardsTable)
+	if err != nil {
+		return 0, &errors.DatabaseError{Operation: "Count", Table: rewardsTable, Cause: err}
+	}
+	return count, nil
+}
+
+// Delete 報酬を削除
+func (r *RewardRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: "id", Message: "id is required"}
+	}
+
+	result, err := r.db.exec(ctx, `DELETE FROM rewards WHERE id = ?`, tenant.Key(ctx, id))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Delete", Table: rewardsTable, Cause: err}
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// query 報酬の一覧を取得
+func (r *RewardRepository) query(ctx context.Context, operation, query string, args ...interface{}) ([]*models.Reward, error) {
+	rows, err := r.db.query(ctx, query, args...)
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: operation, Table: rewardsTable, Cause: err}
+	}
+	defer rows.Close()
+
+	rewards := []*models.Reward{}
+	for rows.Next() {
+		reward, err := scanReward(ctx, rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Operation: operation, Table: rewardsTable, Cause: err}
+		}
+		rewards = append(rewards, reward)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: operation, Table: rewardsTable, Cause: err}
+	}
+
+	return rewards, nil
+}
+
+// scanReward 行をテナントの報酬に変換
+func scanReward(ctx context.Context, row rowScanner) (*models.Reward, error) {
+	var reward models.Reward
+	var createdAt timestamp
+	var prizes string
+	if err := row.Scan(&reward.ID, &reward.Title, &reward.Description, &reward.Point, &createdAt, &reward.Version, &prizes); err != nil {
+		return nil, err
+	}
+	if prizes != "" {
+		if err := json.Unmarshal([]byte(prizes), &reward.Prizes); err != nil {
+			return nil, err
+		}
+	}
+	reward.ID = tenant.EntityID(ctx, reward.ID)
+	reward.CreatedAt = createdAt.Time
+	return &reward, nil
+}
+
+// encodePrizes 抽選型の報酬の景品をJSONの配列に変換（景品が無い場合は空）
+func encodePrizes(prizes []models.RafflePrize) (string, error) {
+	if len(prizes) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(prizes)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,184 @@
+package sqlstore
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// FavoriteRepository SQLデータベースを使用したお気に入りリポジトリ
+type FavoriteRepository struct {
+	db *DB
+}
+
+// NewFavoriteRepository お気に入りリポジトリを作成
+func NewFavoriteRepository(db *DB) repository.FavoriteRepository {
+	return &FavoriteRepository{db: db}
+}
+
+// Add 報酬をお気に入りに登録（登録済みの場合は errors.ErrDuplicateResource）
+func (r *FavoriteRepository) Add(ctx context.Context, favorite *models.Favorite) error {
+	if favorite == nil {
+		return &errors.ValidationError{Field: "favorite", Message: "favorite cannot be nil"}
+	}
+	if favorite.RewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+	if favorite.CreatedAt.IsZero() {
+		favorite.CreatedAt = time.Now()
+	}
+	favorite.CreatedAt = r.db.truncate(favorite.CreatedAt)
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO favorites (reward_id, tenant_id, created_at) VALUES (?, ?, ?)
+		ON CONFLICT (reward_id) DO NOTHING`,
+		tenant.Key(ctx, favorite.RewardID), tenant.FromContext(ctx), favorite.CreatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Add", Table: favoritesTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// Remove 報酬をお気に入りから外す（登録されていない場合は errors.ErrNotFound）
+func (r *FavoriteRepository) Remove(ctx context.Context, rewardID string) error {
+	return removeSavedReward(ctx, r.db, favoritesTable, rewardID)
+}
+
+// List お気に入りを登録日時順に取得
+func (r *FavoriteRepository) List(ctx context.Context) ([]*models.Favorite, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT reward_id, created_at FROM favorites WHERE tenant_id = ? ORDER BY created_at, reward_id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: favoritesTable, Cause: err}
+	}
+	defer rows.Close()
+
+	favorites := []*models.Favorite{}
+	for rows.Next() {
+		var favorite models.Favorite
+		var createdAt timestamp
+		if err := rows.Scan(&favorite.RewardID, &createdAt); err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: favoritesTable, Cause: err}
+		}
+		favorite.RewardID = tenant.EntityID(ctx, favorite.RewardID)
+		favorite.CreatedAt = createdAt.Time
+		favorites = append(favorites, &favorite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: favoritesTable, Cause: err}
+	}
+
+	return favorites, nil
+}
+
+// WishlistRepository SQLデータベースを使用したほしいものリストリポジトリ
+type WishlistRepository struct {
+	db *DB
+}
+
+// NewWishlistRepository ほしいものリストリポジトリを作成
+func NewWishlistRepository(db *DB) repository.WishlistRepository {
+	return &WishlistRepository{db: db}
+}
+
+// Add 報酬をほしいものリストに追加（追加済みの場合は errors.ErrDuplicateResource）
+func (r *WishlistRepository) Add(ctx context.Context, item *models.WishlistItem) error {
+	if err := repository.ValidateWishlistItem(item); err != nil {
+		return err
+	}
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+	item.CreatedAt = r.db.truncate(item.CreatedAt)
+
+	result, err := r.db.exec(ctx,
+		`INSERT INTO wishlist (reward_id, tenant_id, priority, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (reward_id) DO NOTHING`,
+		tenant.Key(ctx, item.RewardID), tenant.FromContext(ctx), item.Priority, item.CreatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Add", Table: wishlistTable, Cause: err}
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return errors.ErrDuplicateResource
+	}
+	return nil
+}
+
+// UpdatePriority ほしいものリストの報酬の優先度を変更（追加されていない場合は errors.ErrNotFound）
+func (r *WishlistRepository) UpdatePriority(ctx context.Context, rewardID string, priority int) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+	if priority < 0 {
+		return &errors.ValidationError{Field: "priority", Message: "priority cannot be negative"}
+	}
+
+	result, err := r.db.exec(ctx, `UPDATE wishlist SET priority = ? WHERE reward_id = ?`, priority, tenant.Key(ctx, rewardID))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "UpdatePriority", Table: wishlistTable, Cause: err}
+	}
+
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Remove 報酬をほしいものリストから外す（追加されていない場合は errors.ErrNotFound）
+func (r *WishlistRepository) Remove(ctx context.Context, rewardID string) error {
+	return removeSavedReward(ctx, r.db, wishlistTable, rewardID)
+}
+
+// List ほしいものリストを追加日時順に取得
+func (r *WishlistRepository) List(ctx context.Context) ([]*models.WishlistItem, error) {
+	rows, err := r.db.query(ctx,
+		`SELECT reward_id, priority, created_at FROM wishlist WHERE tenant_id = ? ORDER BY created_at, reward_id`, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: wishlistTable, Cause: err}
+	}
+	defer rows.Close()
+
+	items := []*models.WishlistItem{}
+	for rows.Next() {
+		var item models.WishlistItem
+		var createdAt timestamp
+		if err := rows.Scan(&item.RewardID, &item.Priority, &createdAt); err != nil {
+			return nil, &errors.DatabaseError{Operation: "List", Table: wishlistTable, Cause: err}
+		}
+		item.RewardID = tenant.EntityID(ctx, item.RewardID)
+		item.CreatedAt = createdAt.Time
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Operation: "List", Table: wishlistTable, Cause: err}
+	}
+
+	return items, nil
+}
+
+// removeSavedReward お気に入り・ほしいものリストのテーブルから報酬を外す（登録されていない場合は errors.ErrNotFound）
+func removeSavedReward(ctx context.Context, db *DB, table, rewardID string) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	// テーブル名は定数のみを渡すため、クエリに埋め込んでよい
+	result, err := db.exec(ctx, `DELETE FROM `+table+` WHERE reward_id = ?`, tenant.Key(ctx, rewardID))
+	if err != nil {
+		return &errors.DatabaseError{Operation: "Remove", Table: table, Cause: err}
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func TestFavoriteRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFavoriteRepository(newTestDB(t))
+
+	for _, rewardID := range []string{"reward-2", "reward-1"} {
+		if err := repo.Add(ctx, &models.Favorite{RewardID: rewardID}); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if err := repo.Add(ctx, &models.Favorite{RewardID: "reward-1"}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	favorites, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(favorites) != 2 || favorites[0].RewardID != "reward-2" || favorites[1].RewardID != "reward-1" {
+		t.Errorf("Expected favorites in the order they were added, got %+v", favorites)
+	}
+
+	// 他のテナントからは外せない
+	if err := repo.Remove(tenant.WithID(ctx, "acme"), "reward-1"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant, got %v", err)
+	}
+	if err := repo.Remove(ctx, "reward-1"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := repo.Remove(ctx, "reward-1"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWishlistRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWishlistRepository(newTestDB(t))
+
+	item := &models.WishlistItem{RewardID: "reward-1", Priority: 3}
+	if err := repo.Add(ctx, item); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := repo.Add(ctx, &models.WishlistItem{RewardID: "reward-1"}); err != errors.ErrDuplicateResource {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+
+	if err := repo.UpdatePriority(ctx, "reward-1", 1); err != nil {
+		t.Fatalf("UpdatePriority failed: %v", err)
+	}
+	if err := repo.UpdatePriority(ctx, "missing", 1); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	items, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Priority != 1 || !items[0].CreatedAt.Equal(item.CreatedAt) {
+		t.Errorf("Unexpected wishlist: %+v", items)
+	}
+
+	if err := repo.Remove(ctx, "reward-1"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if items, _ := repo.List(ctx); len(items) != 0 {
+		t.Errorf("Expected empty wishlist, got %+v", items)
+	}
+}
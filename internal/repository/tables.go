@@ -0,0 +1,457 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	appconfig "achievement-management/internal/config"
+)
+
+// TableAdminAPI テーブル管理操作のインターフェース
+type TableAdminAPI interface {
+	CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	UpdateTable(ctx context.Context, params *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error)
+	UpdateTimeToLive(ctx context.Context, params *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+}
+
+// IndexDefinition グローバルセカンダリインデックスの定義
+type IndexDefinition struct {
+	Name     string
+	HashKey  string
+	RangeKey string
+}
+
+// TableDefinition アプリケーションが使用するテーブルの定義
+type TableDefinition struct {
+	// Key 設定ファイル上のテーブルの識別子（achievements など）
+	Key          string
+	Name         string
+	HashKey      string
+	Indexes      []IndexDefinition
+	TTLAttribute string
+	// StreamEnabled 変更イベントを配信するためにストリームを有効にするか
+	StreamEnabled bool
+}
+
+// TableDefinitions 設定からテーブル定義の一覧を作成
+//
+// TTLは削除済み・期限切れのアイテムを保持しうるテーブルでのみ有効にする（current_points と、追記のみの point_ledger・completions・badges・drift_events は対象外）。
+func TableDefinitions(cfg *appconfig.Config) []TableDefinition {
+	definitions := []TableDefinition{
+		{
+			Key:          "achievements",
+			Name:         cfg.Tables.Achievements,
+			HashKey:      "id",
+			Indexes:      []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+			TTLAttribute: cfg.Tables.TTLAttribute,
+		},
+		{
+			Key:          "rewards",
+			Name:         cfg.Tables.Rewards,
+			HashKey:      "id",
+			Indexes:      []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+			TTLAttribute: cfg.Tables.TTLAttribute,
+		},
+		{Key: "current_points", Name: cfg.Tables.CurrentPoints, HashKey: "id"},
+		{
+			Key:          "reward_history",
+			Name:         cfg.Tables.RewardHistory,
+			HashKey:      "id",
+			Indexes:      []IndexDefinition{{Name: RedeemedAtIndex, HashKey: EntityTypeAttribute, RangeKey: RedeemedAtKeyAttribute}},
+			TTLAttribute: cfg.Tables.TTLAttribute,
+		},
+		{
+			Key:     "point_ledger",
+			Name:    cfg.Tables.PointLedger,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+		},
+		{
+			Key:     "completions",
+			Name:    cfg.Tables.Completions,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: AchievementKeyIndex, HashKey: AchievementKeyAttribute}},
+		},
+		{
+			Key:     "badges",
+			Name:    cfg.Tables.Badges,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: EarnedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "earned_at"}},
+		},
+		{
+			Key:          "goals",
+			Name:         cfg.Tables.Goals,
+			HashKey:      "id",
+			Indexes:      []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+			TTLAttribute: cfg.Tables.TTLAttribute,
+		},
+		{
+			Key:     "favorites",
+			Name:    cfg.Tables.Favorites,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+		},
+		{
+			Key:     "wishlist",
+			Name:    cfg.Tables.Wishlist,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+		},
+		{
+			Key:     "notes",
+			Name:    cfg.Tables.Notes,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: TargetKeyIndex, HashKey: TargetKeyAttribute, RangeKey: "created_at"}},
+		},
+		{
+			Key:     "drift_events",
+			Name:    cfg.Tables.DriftEvents,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: DetectedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "detected_at"}},
+		},
+		{
+			Key:     "reservations",
+			Name:    cfg.Tables.Reservations,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+		},
+		{
+			Key:          "quests",
+			Name:         cfg.Tables.Quests,
+			HashKey:      "id",
+			Indexes:      []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+			TTLAttribute: cfg.Tables.TTLAttribute,
+		},
+		{
+			Key:     "operations",
+			Name:    cfg.Tables.Operations,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+		},
+		{
+			Key:     "allowances",
+			Name:    cfg.Tables.Allowances,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+		},
+		{
+			Key:     "api_keys",
+			Name:    cfg.Tables.APIKeys,
+			HashKey: "id",
+			Indexes: []IndexDefinition{{Name: CreatedAtIndex, HashKey: EntityTypeAttribute, RangeKey: "created_at"}},
+		},
+	}
+
+	for i := range definitions {
+		definitions[i].StreamEnabled = cfg.Streams.Enabled
+	}
+	return definitions
+}
+
+// KeyAttributes テーブルとインデックスのキーに使用する属性名（重複なし）
+func (d TableDefinition) KeyAttributes() []string {
+	seen := map[string]bool{}
+	var attributes []string
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			attributes = append(attributes, name)
+		}
+	}
+
+	add(d.HashKey)
+	for _, index := range d.Indexes {
+		add(index.HashKey)
+		add(index.RangeKey)
+	}
+	return attributes
+}
+
+// createTableInput テーブル定義からCreateTableの入力を作成
+func createTableInput(def TableDefinition) *dynamodb.CreateTableInput {
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(def.Name),
+		BillingMode: types.BillingModePayPerRequest,
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String(def.HashKey), KeyType: types.KeyTypeHash},
+		},
+	}
+
+	for _, name := range def.KeyAttributes() {
+		input.AttributeDefinitions = append(input.AttributeDefinitions, types.AttributeDefinition{
+			AttributeName: aws.String(name),
+			AttributeType: types.ScalarAttributeTypeS,
+		})
+	}
+
+	for _, index := range def.Indexes {
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, globalSecondaryIndex(index))
+	}
+
+	if def.StreamEnabled {
+		input.StreamSpecification = streamSpecification()
+	}
+
+	return input
+}
+
+// streamSpecification 変更前後のアイテムを含むストリームの設定
+func streamSpecification() *types.StreamSpecification {
+	return &types.StreamSpecification{
+		StreamEnabled:  aws.Bool(true),
+		StreamViewType: types.StreamViewTypeNewAndOldImages,
+	}
+}
+
+// globalSecondaryIndex インデックス定義からGSIの定義を作成
+func globalSecondaryIndex(index IndexDefinition) types.GlobalSecondaryIndex {
+	keySchema := []types.KeySchemaElement{
+		{AttributeName: aws.String(index.HashKey), KeyType: types.KeyTypeHash},
+	}
+	if index.RangeKey != "" {
+		keySchema = append(keySchema, types.KeySchemaElement{AttributeName: aws.String(index.RangeKey), KeyType: types.KeyTypeRange})
+	}
+	return types.GlobalSecondaryIndex{
+		IndexName:  aws.String(index.Name),
+		KeySchema:  keySchema,
+		Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+	}
+}
+
+// TableManager テーブルの作成と状態確認を行う
+type TableManager struct {
+	client      TableAdminAPI
+	waitTimeout time.Duration
+}
+
+// NewTableManager テーブルマネージャーを作成
+func NewTableManager(client TableAdminAPI) *TableManager {
+	return &TableManager{
+		client:      client,
+		waitTimeout: 2 * time.Minute,
+	}
+}
+
+// CreateTables テーブルを作成（既存のテーブルはスキップ）し、作成したテーブル名を返す
+func (m *TableManager) CreateTables(ctx context.Context, definitions []TableDefinition) ([]string, error) {
+	var created []string
+
+	for _, def := range definitions {
+		_, err := m.client.CreateTable(ctx, createTableInput(def))
+		if err != nil {
+			var inUse *types.ResourceInUseException
+			if errors.As(err, &inUse) {
+				continue
+			}
+			return created, fmt.Errorf("failed to create table %s: %w", def.Name, err)
+		}
+
+		waiter := dynamodb.NewTableExistsWaiter(m.client)
+		if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(def.Name)}, m.waitTimeout); err != nil {
+			return created, fmt.Errorf("failed waiting for table %s to become active: %w", def.Name, err)
+		}
+
+		if def.TTLAttribute != "" {
+			if err := m.enableTTL(ctx, def); err != nil {
+				return created, err
+			}
+		}
+
+		created = append(created, def.Name)
+	}
+
+	return created, nil
+}
+
+// CheckTables すべてのテーブルが存在しACTIVEであることを確認
+func (m *TableManager) CheckTables(ctx context.Context, definitions []TableDefinition) error {
+	for _, def := range definitions {
+		resp, err := m.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(def.Name),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe table %s: %w", def.Name, err)
+		}
+
+		if resp.Table == nil || resp.Table.TableStatus != types.TableStatusActive {
+			return fmt.Errorf("table %s is not active", def.Name)
+		}
+	}
+
+	return nil
+}
+
+// EnsureIndexes 既存のテーブルに定義上のGSIが無ければ追加し、追加を開始したインデックスを「テーブル名/インデックス名」で返す
+//
+// インデックスの構築は非同期に行われ、完了するまでの間はQueryの結果に既存のアイテムが含まれないことがある。
+func (m *TableManager) EnsureIndexes(ctx context.Context, definitions []TableDefinition) ([]string, error) {
+	var added []string
+
+	for _, def := range definitions {
+		if len(def.Indexes) == 0 {
+			continue
+		}
+
+		resp, err := m.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(def.Name)})
+		if err != nil {
+			return added, fmt.Errorf("failed to describe table %s: %w", def.Name, err)
+		}
+
+		existing := map[string]bool{}
+		for _, index := range resp.Table.GlobalSecondaryIndexes {
+			existing[aws.ToString(index.IndexName)] = true
+		}
+
+		for _, index := range def.Indexes {
+			if existing[index.Name] {
+				continue
+			}
+
+			// UpdateTableで作成できるGSIは1回につき1つ
+			gsi := globalSecondaryIndex(index)
+			create := &types.CreateGlobalSecondaryIndexAction{
+				IndexName:  gsi.IndexName,
+				KeySchema:  gsi.KeySchema,
+				Projection: gsi.Projection,
+			}
+			// プロビジョニング済みのテーブルではテーブルと同じキャパシティを割り当てる
+			if isProvisioned(resp.Table) {
+				create.ProvisionedThroughput = &types.ProvisionedThroughput{
+					ReadCapacityUnits:  resp.Table.ProvisionedThroughput.ReadCapacityUnits,
+					WriteCapacityUnits: resp.Table.ProvisionedThroughput.WriteCapacityUnits,
+				}
+			}
+
+			var attributes []types.AttributeDefinition
+			for _, name := range []string{index.HashKey, index.RangeKey} {
+				if name != "" {
+					attributes = append(attributes, types.AttributeDefinition{
+						AttributeName: aws.String(name),
+						AttributeType: types.ScalarAttributeTypeS,
+					})
+				}
+			}
+
+			_, err := m.client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+				TableName:                   aws.String(def.Name),
+				AttributeDefinitions:        attributes,
+				GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{{Create: create}},
+			})
+			if err != nil {
+				return added, fmt.Errorf("failed to add index %s to table %s: %w", index.Name, def.Name, err)
+			}
+
+			added = append(added, def.Name+"/"+index.Name)
+		}
+	}
+
+	return added, nil
+}
+
+// EnsureTTL TTL属性が定義されたテーブルでTTLが無効であれば有効にし、有効にしたテーブル名を返す
+func (m *TableManager) EnsureTTL(ctx context.Context, definitions []TableDefinition) ([]string, error) {
+	var enabled []string
+
+	for _, def := range definitions {
+		if def.TTLAttribute == "" {
+			continue
+		}
+
+		resp, err := m.client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(def.Name)})
+		if err != nil {
+			return enabled, fmt.Errorf("failed to describe TTL of table %s: %w", def.Name, err)
+		}
+
+		if desc := resp.TimeToLiveDescription; desc != nil && aws.ToString(desc.AttributeName) == def.TTLAttribute &&
+			(desc.TimeToLiveStatus == types.TimeToLiveStatusEnabled || desc.TimeToLiveStatus == types.TimeToLiveStatusEnabling) {
+			continue
+		}
+
+		if err := m.enableTTL(ctx, def); err != nil {
+			return enabled, err
+		}
+		enabled = append(enabled, def.Name)
+	}
+
+	return enabled, nil
+}
+
+// EnsureStreams ストリームが定義されたテーブルでストリームが無効であれば有効にし、有効にしたテーブル名を返す
+func (m *TableManager) EnsureStreams(ctx context.Context, definitions []TableDefinition) ([]string, error) {
+	var enabled []string
+
+	for _, def := range definitions {
+		if !def.StreamEnabled {
+			continue
+		}
+
+		resp, err := m.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(def.Name)})
+		if err != nil {
+			return enabled, fmt.Errorf("failed to describe table %s: %w", def.Name, err)
+		}
+
+		if spec := resp.Table.StreamSpecification; spec != nil && aws.ToBool(spec.StreamEnabled) {
+			continue
+		}
+
+		_, err = m.client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName:           aws.String(def.Name),
+			StreamSpecification: streamSpecification(),
+		})
+		if err != nil {
+			return enabled, fmt.Errorf("failed to enable stream on table %s: %w", def.Name, err)
+		}
+		enabled = append(enabled, def.Name)
+	}
+
+	return enabled, nil
+}
+
+// StreamARN テーブルの最新のストリームのARNを取得
+func (m *TableManager) StreamARN(ctx context.Context, tableName string) (string, error) {
+	resp, err := m.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
+
+	arn := aws.ToString(resp.Table.LatestStreamArn)
+	if arn == "" {
+		return "", fmt.Errorf("table %s has no stream enabled", tableName)
+	}
+	return arn, nil
+}
+
+// enableTTL テーブルのTTLを有効にする
+func (m *TableManager) enableTTL(ctx context.Context, def TableDefinition) error {
+	_, err := m.client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(def.Name),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(def.TTLAttribute),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable TTL on table %s: %w", def.Name, err)
+	}
+	return nil
+}
+
+// isProvisioned テーブルがプロビジョニング済みキャパシティモードかどうか
+func isProvisioned(table *types.TableDescription) bool {
+	if table.ProvisionedThroughput == nil {
+		return false
+	}
+	if table.BillingModeSummary == nil {
+		// 課金モードの情報が無いテーブルはプロビジョニング済みとして作成されたもの
+		return aws.ToInt64(table.ProvisionedThroughput.ReadCapacityUnits) > 0
+	}
+	return table.BillingModeSummary.BillingMode == types.BillingModeProvisioned
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"achievement-management/internal/config"
+)
+
+// MockTableAdminClient テーブル管理クライアントのモック
+type MockTableAdminClient struct {
+	existing map[string]bool
+	created  []string
+	inputs   []*dynamodb.CreateTableInput
+	ttl      map[string]string
+	indexes  map[string][]string
+	updates  []*dynamodb.UpdateTableInput
+	streams  map[string]string
+}
+
+func (m *MockTableAdminClient) CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	name := aws.ToString(params.TableName)
+	if m.existing[name] {
+		return nil, &types.ResourceInUseException{Message: aws.String("table already exists")}
+	}
+	m.existing[name] = true
+	m.created = append(m.created, name)
+	m.inputs = append(m.inputs, params)
+	return &dynamodb.CreateTableOutput{}, nil
+}
+
+func (m *MockTableAdminClient) UpdateTimeToLive(ctx context.Context, params *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error) {
+	if m.ttl == nil {
+		m.ttl = map[string]string{}
+	}
+	m.ttl[aws.ToString(params.TableName)] = aws.ToString(params.TimeToLiveSpecification.AttributeName)
+	return &dynamodb.UpdateTimeToLiveOutput{}, nil
+}
+
+func (m *MockTableAdminClient) DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	desc := &types.TimeToLiveDescription{TimeToLiveStatus: types.TimeToLiveStatusDisabled}
+	if attribute, ok := m.ttl[aws.ToString(params.TableName)]; ok {
+		desc = &types.TimeToLiveDescription{AttributeName: aws.String(attribute), TimeToLiveStatus: types.TimeToLiveStatusEnabled}
+	}
+	return &dynamodb.DescribeTimeToLiveOutput{TimeToLiveDescription: desc}, nil
+}
+
+func (m *MockTableAdminClient) UpdateTable(ctx context.Context, params *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error) {
+	m.updates = append(m.updates, params)
+	return &dynamodb.UpdateTableOutput{}, nil
+}
+
+func (m *MockTableAdminClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	name := aws.ToString(params.TableName)
+	if !m.existing[name] {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("table not found")}
+	}
+	var indexes []types.GlobalSecondaryIndexDescription
+	for _, index := range m.indexes[name] {
+		indexes = append(indexes, types.GlobalSecondaryIndexDescription{IndexName: aws.String(index)})
+	}
+	table := &types.TableDescription{
+		TableName:              params.TableName,
+		TableStatus:            types.TableStatusActive,
+		GlobalSecondaryIndexes: indexes,
+	}
+	if arn, ok := m.streams[name]; ok {
+		table.StreamSpecification = streamSpecification()
+		table.LatestStreamArn = aws.String(arn)
+	}
+	return &dynamodb.DescribeTableOutput{Table: table}, nil
+}
+
+func testTableConfig() *config.Config {
+	return &config.Config{
+		Tables: config.TableConfig{
+			Achievements:  "test-achievements",
+			Rewards:       "test-rewards",
+			CurrentPoints: "test-current-points",
+			RewardHistory: "test-reward-history",
+			PointLedger:   "test-point-ledger",
+			Completions:   "test-completions",
+			Badges:        "test-badges",
+			Goals:         "test-goals",
+			Favorites:     "test-favorites",
+			Wishlist:      "test-wishlist",
+			Notes:         "test-notes",
+			DriftEvents:   "test-drift-events",
+			Reservations:  "test-reservations",
+			Quests:        "test-quests",
+			Operations:    "test-operations",
+			Allowances:    "test-allowances",
+			APIKeys:       "test-api-keys",
+		},
+	}
+}
+
+func TestTableDefinitions_TTL(t *testing.T) {
+	cfg := testTableConfig()
+	cfg.Tables.TTLAttribute = "expires_at"
+
+	for _, def := range TableDefinitions(cfg) {
+		// 現在のポイントと追記のみの台帳・達成記録・バッジ・ポイントの差異、利用者が登録を解除するまで残すお気に入り・ほしいものリスト・メモ・取り置き、件数で上限を設ける操作履歴はTTLを設定しない
+		expected := "expires_at"
+		if def.Key == "current_points" || def.Key == "point_ledger" || def.Key == "completions" || def.Key == "badges" || def.Key == "favorites" || def.Key == "wishlist" || def.Key == "notes" || def.Key == "drift_events" || def.Key == "reservations" || def.Key == "operations" || def.Key == "allowances" || def.Key == "api_keys" {
+			expected = ""
+		}
+		if def.TTLAttribute != expected {
+			t.Errorf("Table %s: expected TTL attribute %q, got %q", def.Key, expected, def.TTLAttribute)
+		}
+	}
+}
+
+func TestTableManager_CreateTables(t *testing.T) {
+	client := &MockTableAdminClient{existing: map[string]bool{"test-rewards": true}}
+	manager := NewTableManager(client)
+
+	created, err := manager.CreateTables(context.Background(), TableDefinitions(testTableConfig()))
+	if err != nil {
+		t.Fatalf("CreateTables failed: %v", err)
+	}
+
+	// 既存のテーブルはスキップされることを確認
+	if len(created) != 16 {
+		t.Errorf("Expected 16 created tables, got %d: %v", len(created), created)
+	}
+	for _, name := range created {
+		if name == "test-rewards" {
+			t.Error("Existing table should not be reported as created")
+		}
+	}
+}
+
+func TestTableManager_CheckTables(t *testing.T) {
+	client := &MockTableAdminClient{existing: map[string]bool{
+		"test-achievements":   true,
+		"test-rewards":        true,
+		"test-current-points": true,
+	}}
+	manager := NewTableManager(client)
+
+	err := manager.CheckTables(context.Background(), TableDefinitions(testTableConfig()))
+	if err == nil {
+		t.Fatal("Expected error for missing table")
+	}
+
+	client.existing["test-reward-history"] = true
+	client.existing["test-point-ledger"] = true
+	client.existing["test-completions"] = true
+	client.existing["test-badges"] = true
+	client.existing["test-goals"] = true
+	client.existing["test-favorites"] = true
+	client.existing["test-wishlist"] = true
+	client.existing["test-notes"] = true
+	client.existing["test-drift-events"] = true
+	client.existing["test-reservations"] = true
+	client.existing["test-quests"] = true
+	client.existing["test-operations"] = true
+	client.existing["test-allowances"] = true
+	client.existing["test-api-keys"] = true
+	if err := manager.CheckTables(context.Background(), TableDefinitions(testTableConfig())); err != nil {
+		t.Errorf("CheckTables failed: %v", err)
+	}
+}
+
+func TestTableManager_CreateTables_IndexesAndTTL(t *testing.T) {
+	client := &MockTableAdminClient{existing: map[string]bool{}}
+	manager := NewTableManager(client)
+
+	definitions := []TableDefinition{{
+		Key:     "reward_history",
+		Name:    "test-reward-history",
+		HashKey: "id",
+		Indexes: []IndexDefinition{
+			{Name: "reward-index", HashKey: "reward_id", RangeKey: "redeemed_at"},
+			{Name: "id-index", HashKey: "id"},
+		},
+		TTLAttribute: "expires_at",
+	}}
+
+	if _, err := manager.CreateTables(context.Background(), definitions); err != nil {
+		t.Fatalf("CreateTables failed: %v", err)
+	}
+
+	input := client.inputs[0]
+	// キー属性は重複なく定義されることを確認
+	if len(input.AttributeDefinitions) != 3 {
+		t.Errorf("Expected 3 attribute definitions, got %d", len(input.AttributeDefinitions))
+	}
+	if len(input.GlobalSecondaryIndexes) != 2 {
+		t.Fatalf("Expected 2 global secondary indexes, got %d", len(input.GlobalSecondaryIndexes))
+	}
+	if len(input.GlobalSecondaryIndexes[0].KeySchema) != 2 {
+		t.Errorf("Expected range key on reward-index")
+	}
+	if client.ttl["test-reward-history"] != "expires_at" {
+		t.Errorf("Expected TTL to be enabled on expires_at, got %q", client.ttl["test-reward-history"])
+	}
+}
+
+func TestTableManager_EnsureIndexes(t *testing.T) {
+	cfg := testTableConfig()
+	client := &MockTableAdminClient{
+		existing: map[string]bool{"test-achievements": true, "test-rewards": true, "test-current-points": true, "test-reward-history": true, "test-point-ledger": true, "test-completions": true, "test-badges": true, "test-goals": true, "test-favorites": true, "test-wishlist": true, "test-notes": true, "test-drift-events": true, "test-reservations": true, "test-quests": true, "test-operations": true, "test-allowances": true, "test-api-keys": true},
+		indexes:  map[string][]string{"test-rewards": {CreatedAtIndex}},
+	}
+	manager := NewTableManager(client)
+
+	added, err := manager.EnsureIndexes(context.Background(), TableDefinitions(cfg))
+	if err != nil {
+		t.Fatalf("EnsureIndexes failed: %v", err)
+	}
+
+	// 既に存在するインデックスは追加されないことを確認
+	expected := []string{"test-achievements/" + CreatedAtIndex, "test-reward-history/" + RedeemedAtIndex, "test-point-ledger/" + CreatedAtIndex, "test-completions/" + AchievementKeyIndex, "test-badges/" + EarnedAtIndex, "test-goals/" + CreatedAtIndex, "test-favorites/" + CreatedAtIndex, "test-wishlist/" + CreatedAtIndex, "test-notes/" + TargetKeyIndex, "test-drift-events/" + DetectedAtIndex, "test-reservations/" + CreatedAtIndex, "test-quests/" + CreatedAtIndex, "test-operations/" + CreatedAtIndex, "test-allowances/" + CreatedAtIndex, "test-api-keys/" + CreatedAtIndex}
+	if len(added) != len(expected) || added[0] != expected[0] || added[1] != expected[1] || added[2] != expected[2] || added[3] != expected[3] || added[4] != expected[4] || added[5] != expected[5] || added[6] != expected[6] || added[7] != expected[7] || added[8] != expected[8] || added[9] != expected[9] || added[10] != expected[10] || added[11] != expected[11] || added[12] != expected[12] {
+		t.Errorf("Expected %v, got %v", expected, added)
+	}
+
+	update := client.updates[0]
+	if len(update.GlobalSecondaryIndexUpdates) != 1 || aws.ToString(update.GlobalSecondaryIndexUpdates[0].Create.IndexName) != CreatedAtIndex {
+		t.Errorf("Unexpected index update: %+v", update.GlobalSecondaryIndexUpdates)
+	}
+	if len(update.AttributeDefinitions) != 2 {
+		t.Errorf("Expected 2 attribute definitions, got %d", len(update.AttributeDefinitions))
+	}
+	if update.GlobalSecondaryIndexUpdates[0].Create.ProvisionedThroughput != nil {
+		t.Error("On-demand tables should not set provisioned throughput")
+	}
+}
+
+func TestTableManager_EnsureTTL(t *testing.T) {
+	client := &MockTableAdminClient{ttl: map[string]string{"already": "expires_at"}}
+	manager := NewTableManager(client)
+
+	definitions := []TableDefinition{
+		{Name: "already", HashKey: "id", TTLAttribute: "expires_at"},
+		{Name: "pending", HashKey: "id", TTLAttribute: "expires_at"},
+		{Name: "none", HashKey: "id"},
+	}
+
+	enabled, err := manager.EnsureTTL(context.Background(), definitions)
+	if err != nil {
+		t.Fatalf("EnsureTTL failed: %v", err)
+	}
+
+	if len(enabled) != 1 || enabled[0] != "pending" {
+		t.Errorf("Expected only 'pending' to be enabled, got %v", enabled)
+	}
+	if client.ttl["pending"] != "expires_at" {
+		t.Errorf("Expected TTL attribute 'expires_at', got '%s'", client.ttl["pending"])
+	}
+}
+
+func TestTableDefinitions_Streams(t *testing.T) {
+	cfg := testTableConfig()
+	for _, def := range TableDefinitions(cfg) {
+		if def.StreamEnabled {
+			t.Errorf("Table %s: expected stream to be disabled by default", def.Key)
+		}
+	}
+
+	cfg.Streams.Enabled = true
+	for _, def := range TableDefinitions(cfg) {
+		if !def.StreamEnabled {
+			t.Errorf("Table %s: expected stream to be enabled", def.Key)
+		}
+		if spec := createTableInput(def).StreamSpecification; spec == nil || spec.StreamViewType != types.StreamViewTypeNewAndOldImages {
+			t.Errorf("Table %s: expected NEW_AND_OLD_IMAGES stream specification, got %+v", def.Key, spec)
+		}
+	}
+}
+
+func TestTableManager_EnsureStreams(t *testing.T) {
+	client := &MockTableAdminClient{
+		existing: map[string]bool{"already": true, "pending": true, "none": true},
+		streams:  map[string]string{"already": "arn:aws:dynamodb:stream/already"},
+	}
+	manager := NewTableManager(client)
+
+	definitions := []TableDefinition{
+		{Name: "already", HashKey: "id", StreamEnabled: true},
+		{Name: "pending", HashKey: "id", StreamEnabled: true},
+		{Name: "none", HashKey: "id"},
+	}
+
+	enabled, err := manager.EnsureStreams(context.Background(), definitions)
+	if err != nil {
+		t.Fatalf("EnsureStreams failed: %v", err)
+	}
+
+	if len(enabled) != 1 || enabled[0] != "pending" {
+		t.Errorf("Expected only 'pending' to be enabled, got %v", enabled)
+	}
+	if len(client.updates) != 1 || !aws.ToBool(client.updates[0].StreamSpecification.StreamEnabled) {
+		t.Errorf("Expected one stream update, got %+v", client.updates)
+	}
+}
+
+func TestTableManager_StreamARN(t *testing.T) {
+	client := &MockTableAdminClient{
+		existing: map[string]bool{"with-stream": true, "without-stream": true},
+		streams:  map[string]string{"with-stream": "arn:aws:dynamodb:stream/with-stream"},
+	}
+	manager := NewTableManager(client)
+
+	arn, err := manager.StreamARN(context.Background(), "with-stream")
+	if err != nil || arn != "arn:aws:dynamodb:stream/with-stream" {
+		t.Errorf("Expected stream ARN, got %q (%v)", arn, err)
+	}
+
+	if _, err := manager.StreamARN(context.Background(), "without-stream"); err == nil {
+		t.Error("Expected error for table without stream")
+	}
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+// FavoriteRepositoryImpl お気に入りリポジトリの実装
+type FavoriteRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewFavoriteRepository お気に入りリポジトリを作成
+func NewFavoriteRepository(repo Repository, config *config.Config) FavoriteRepository {
+	return &FavoriteRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Add 報酬をお気に入りに登録（登録済みの場合は errors.ErrDuplicateResource）
+func (r *FavoriteRepositoryImpl) Add(ctx context.Context, favorite *models.Favorite) error {
+	if favorite == nil {
+		return &errors.ValidationError{Field: "favorite", Message: "favorite cannot be nil"}
+	}
+	if favorite.RewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	if favorite.CreatedAt.IsZero() {
+		favorite.CreatedAt = time.Now()
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Favorites, newFavoriteItem(ctx, favorite), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Add",
+			Table:     r.config.Tables.Favorites,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Remove 報酬をお気に入りから外す（登録されていない場合は errors.ErrNotFound）
+func (r *FavoriteRepositoryImpl) Remove(ctx context.Context, rewardID string) error {
+	return removeSavedReward(ctx, r.repo, r.config.Tables.Favorites, rewardID)
+}
+
+// List お気に入りを登録日時順に取得
+func (r *FavoriteRepositoryImpl) List(ctx context.Context) ([]*models.Favorite, error) {
+	var favorites []*models.Favorite
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Favorites, CreatedAtIndex, EntityTypeFavorite), &favorites)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Favorites,
+			Cause:     err,
+		}
+	}
+
+	for _, favorite := range favorites {
+		favorite.RewardID = tenant.EntityID(ctx, favorite.RewardID)
+	}
+	return favorites, nil
+}
+
+// WishlistRepositoryImpl ほしいものリストリポジトリの実装
+type WishlistRepositoryImpl struct {
+	repo   Repository
+	config *config.Config
+}
+
+// NewWishlistRepository ほしいものリストリポジトリを作成
+func NewWishlistRepository(repo Repository, config *config.Config) WishlistRepository {
+	return &WishlistRepositoryImpl{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Add 報酬をほしいものリストに追加（追加済みの場合は errors.ErrDuplicateResource）
+func (r *WishlistRepositoryImpl) Add(ctx context.Context, item *models.WishlistItem) error {
+	if err := ValidateWishlistItem(item); err != nil {
+		return err
+	}
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	err := r.repo.PutItemWithCondition(ctx, r.config.Tables.Wishlist, newWishlistItem(ctx, item), conditionNotExists)
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrDuplicateResource
+		}
+		return &errors.DatabaseError{
+			Operation: "Add",
+			Table:     r.config.Tables.Wishlist,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// UpdatePriority ほしいものリストの報酬の優先度を変更（追加されていない場合は errors.ErrNotFound）
+func (r *WishlistRepositoryImpl) UpdatePriority(ctx context.Context, rewardID string, priority int) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+	if priority < 0 {
+		return &errors.ValidationError{Field: "priority", Message: "priority cannot be negative"}
+	}
+
+	err := r.repo.UpdateItemWithCondition(ctx, r.config.Tables.Wishlist, itemKey(ctx, rewardID), "SET priority = :priority", conditionExists, map[string]interface{}{
+		":priority": priority,
+	})
+	if err != nil {
+		if stderrors.Is(err, ErrConditionFailed) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "UpdatePriority",
+			Table:     r.config.Tables.Wishlist,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
+
+// Remove 報酬をほしいものリストから外す（追加されていない場合は errors.ErrNotFound）
+func (r *WishlistRepositoryImpl) Remove(ctx context.Context, rewardID string) error {
+	return removeSavedReward(ctx, r.repo, r.config.Tables.Wishlist, rewardID)
+}
+
+// List ほしいものリストを追加日時順に取得（優先度順の並べ替えはサービスで行う）
+func (r *WishlistRepositoryImpl) List(ctx context.Context) ([]*models.WishlistItem, error) {
+	var items []*models.WishlistItem
+	_, err := r.repo.Query(ctx, entityTypeQuery(ctx, r.config.Tables.Wishlist, CreatedAtIndex, EntityTypeWishlistItem), &items)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Operation: "List",
+			Table:     r.config.Tables.Wishlist,
+			Cause:     err,
+		}
+	}
+
+	for _, item := range items {
+		item.RewardID = tenant.EntityID(ctx, item.RewardID)
+	}
+	return items, nil
+}
+
+// ValidateWishlistItem ほしいものリストの報酬のバリデーション（すべてのストレージで共通）
+func ValidateWishlistItem(item *models.WishlistItem) error {
+	if item == nil {
+		return &errors.ValidationError{Field: "item", Message: "wishlist item cannot be nil"}
+	}
+	if item.RewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+	if item.Priority < 0 {
+		return &errors.ValidationError{Field: "priority", Message: "priority cannot be negative"}
+	}
+	return nil
+}
+
+// removeSavedReward お気に入り・ほしいものリストから報酬を外す（登録されていない場合は errors.ErrNotFound）
+func removeSavedReward(ctx context.Context, repo Repository, tableName, rewardID string) error {
+	if rewardID == "" {
+		return &errors.ValidationError{Field: "reward_id", Message: "reward_id is required"}
+	}
+
+	// 存在確認（IDのみを読み取る）
+	var item struct {
+		ID string `dynamodbav:"id"`
+	}
+	err := repo.GetItemWithProjection(ctx, tableName, itemKey(ctx, rewardID), idProjection, &item)
+	if err != nil {
+		if stderrors.Is(err, ErrItemNotFound) {
+			return errors.ErrNotFound
+		}
+		return &errors.DatabaseError{
+			Operation: "Remove",
+			Table:     tableName,
+			Cause:     err,
+		}
+	}
+
+	if err := repo.DeleteItem(ctx, tableName, itemKey(ctx, rewardID)); err != nil {
+		return &errors.DatabaseError{
+			Operation: "Remove",
+			Table:     tableName,
+			Cause:     err,
+		}
+	}
+
+	return nil
+}
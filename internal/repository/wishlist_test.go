@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+func testWishlistConfig() *config.Config {
+	return &config.Config{Tables: config.TableConfig{Favorites: "test-favorites", Wishlist: "test-wishlist"}}
+}
+
+func TestFavoriteRepository_Add(t *testing.T) {
+	var putItem favoriteItem
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			if conditionExpression != conditionNotExists {
+				t.Errorf("Expected condition %s, got %s", conditionNotExists, conditionExpression)
+			}
+			putItem = item.(favoriteItem)
+			return nil
+		},
+	}
+	repo := NewFavoriteRepository(mockRepo, testWishlistConfig())
+
+	favorite := &models.Favorite{RewardID: "reward-1"}
+	if err := repo.Add(tenant.WithID(context.Background(), "acme"), favorite); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	if favorite.CreatedAt.IsZero() {
+		t.Error("CreatedAt should be set")
+	}
+	if putItem.RewardID != "acme#reward-1" || putItem.EntityType != "acme#"+EntityTypeFavorite {
+		t.Errorf("Expected tenant keys, got %s / %s", putItem.RewardID, putItem.EntityType)
+	}
+}
+
+func TestFavoriteRepository_Add_Duplicate(t *testing.T) {
+	mockRepo := &MockRepository{
+		conditionFunc: func(tableName string, item interface{}, conditionExpression string) error {
+			return ErrConditionFailed
+		},
+	}
+	repo := NewFavoriteRepository(mockRepo, testWishlistConfig())
+
+	err := repo.Add(context.Background(), &models.Favorite{RewardID: "reward-1"})
+	if !stderrors.Is(err, errors.ErrDuplicateResource) {
+		t.Errorf("Expected ErrDuplicateResource, got %v", err)
+	}
+}
+
+func TestFavoriteRepository_Remove_NotFound(t *testing.T) {
+	deleted := false
+	mockRepo := &MockRepository{
+		getItemFunc: func(tableName string, key map[string]interface{}, result interface{}) error {
+			return ErrItemNotFound
+		},
+		deleteItemFunc: func(tableName string, key map[string]interface{}) error {
+			deleted = true
+			return nil
+		},
+	}
+	repo := NewFavoriteRepository(mockRepo, testWishlistConfig())
+
+	if err := repo.Remove(context.Background(), "reward-1"); !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if deleted {
+		t.Error("Favorite that does not exist should not be deleted")
+	}
+}
+
+func TestWishlistRepository_Add_ValidationError(t *testing.T) {
+	repo := NewWishlistRepository(&MockRepository{}, testWishlistConfig())
+
+	invalid := []*models.WishlistItem{
+		nil,
+		{Priority: 1},
+		{RewardID: "reward-1", Priority: -1},
+	}
+	for _, item := range invalid {
+		if _, ok := repo.Add(context.Background(), item).(*errors.ValidationError); !ok {
+			t.Errorf("Expected validation error for %+v", item)
+		}
+	}
+}
+
+func TestWishlistRepository_UpdatePriority(t *testing.T) {
+	var updatedKey map[string]interface{}
+	var updatedValues map[string]interface{}
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			if conditionExpression != conditionExists {
+				t.Errorf("Expected condition %s, got %s", conditionExists, conditionExpression)
+			}
+			updatedKey, updatedValues = key, expressionAttributeValues
+			return nil
+		},
+	}
+	repo := NewWishlistRepository(mockRepo, testWishlistConfig())
+
+	if err := repo.UpdatePriority(tenant.WithID(context.Background(), "acme"), "reward-1", 2); err != nil {
+		t.Fatalf("UpdatePriority failed: %v", err)
+	}
+	if updatedKey["id"] != "acme#reward-1" || updatedValues[":priority"] != 2 {
+		t.Errorf("Unexpected update: %v %v", updatedKey, updatedValues)
+	}
+}
+
+func TestWishlistRepository_UpdatePriority_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		updateCondFunc: func(tableName string, key map[string]interface{}, updateExpression string, conditionExpression string, expressionAttributeValues map[string]interface{}) error {
+			return ErrConditionFailed
+		},
+	}
+	repo := NewWishlistRepository(mockRepo, testWishlistConfig())
+
+	if err := repo.UpdatePriority(context.Background(), "reward-1", 2); !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWishlistRepository_List(t *testing.T) {
+	var queried QueryInput
+	mockRepo := &MockRepository{
+		queryFunc: func(input QueryInput, result interface{}) (string, error) {
+			queried = input
+			*result.(*[]*models.WishlistItem) = []*models.WishlistItem{{RewardID: "acme#reward-1", Priority: 1}}
+			return "", nil
+		},
+	}
+	repo := NewWishlistRepository(mockRepo, testWishlistConfig())
+
+	items, err := repo.List(tenant.WithID(context.Background(), "acme"))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if queried.TableName != "test-wishlist" || queried.IndexName != CreatedAtIndex {
+		t.Errorf("Expected query on %s of test-wishlist, got %s of %s", CreatedAtIndex, queried.IndexName, queried.TableName)
+	}
+	if len(items) != 1 || items[0].RewardID != "reward-1" {
+		t.Errorf("Expected reward IDs without tenant prefix, got %+v", items)
+	}
+}
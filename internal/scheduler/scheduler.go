@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/logging"
+	"achievement-management/internal/tenant"
+)
+
+// Job 1分ごとに呼び出され、その分に送る通知を送信する処理（リマインド・サマリー・整合性チェックのサービス）
+type Job interface {
+	// NotifyDue at の分に送る通知を送信し、送信した件数を返す
+	NotifyDue(ctx context.Context, at time.Time) (int, error)
+}
+
+// Scheduler 1分ごとにテナントごとのジョブを実行する
+//
+// 停止中に迎えた時刻の通知は、起動後に改めて送信しない。
+type Scheduler struct {
+	name    string
+	job     Job
+	tenants []string
+	logger  logging.Logger
+	now     func() time.Time
+}
+
+// New ジョブのスケジューラーを作成（name はログに出力するジョブの名前。tenants が空の場合は既定のテナントのみ実行する）
+func New(name string, job Job, tenants []string, logger logging.Logger) *Scheduler {
+	if len(tenants) == 0 {
+		tenants = []string{tenant.DefaultID}
+	}
+	return &Scheduler{
+		name:    name,
+		job:     job,
+		tenants: tenants,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Run ctxがキャンセルされるまで毎分の0秒にジョブを実行
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		next := s.now().Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+		s.Tick(ctx, next)
+	}
+}
+
+// Tick at の分のジョブをテナントごとに実行
+//
+// いずれかのテナントで失敗しても、他のテナントの実行は続ける。
+func (s *Scheduler) Tick(ctx context.Context, at time.Time) {
+	for _, tenantID := range s.tenants {
+		// ジョブのサービスも同じフィールドのロガーを使えるようにする（ジョブによる変更の監査ログの actor は system）
+		jobCtx := logging.NewContext(tenant.WithID(logging.WithActor(ctx, logging.ActorSystem), tenantID), s.logger, map[string]interface{}{
+			"job":       s.name,
+			"tenant_id": tenantID,
+		})
+		sent, err := s.job.NotifyDue(jobCtx, at)
+		logger := logging.FromContext(jobCtx).WithField("sent", sent)
+		if err != nil {
+			logger.Errorf("Failed to run scheduled job: %v", err)
+			continue
+		}
+		if sent > 0 {
+			logger.Info("Scheduled notifications sent")
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/tenant"
+)
+
+// fakeJob 呼び出されたテナントを記録するジョブ
+type fakeJob struct {
+	tenants []string
+	fail    map[string]bool
+	// fields ジョブに渡したロガーの相関フィールド
+	fields []map[string]interface{}
+}
+
+func (f *fakeJob) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	tenantID := tenant.FromContext(ctx)
+	f.tenants = append(f.tenants, tenantID)
+	f.fields = append(f.fields, logging.Fields(ctx))
+	if f.fail[tenantID] {
+		return 0, errors.New("webhook unavailable")
+	}
+	return 1, nil
+}
+
+// newTestLogger 出力を破棄するロガーを作成
+func newTestLogger(t *testing.T) logging.Logger {
+	t.Helper()
+	return logging.NewLoggerWithOutput(&config.Config{Logging: config.LoggingConfig{Level: "error", Format: "json"}}, io.Discard)
+}
+
+func TestScheduler_TickDefaultTenant(t *testing.T) {
+	job := &fakeJob{}
+	New("reminders", job, nil, newTestLogger(t)).Tick(context.Background(), time.Now())
+
+	if len(job.tenants) != 1 || job.tenants[0] != tenant.DefaultID {
+		t.Errorf("Expected only the default tenant, got %v", job.tenants)
+	}
+	if job.fields[0]["job"] != "reminders" || job.fields[0]["tenant_id"] != tenant.DefaultID {
+		t.Errorf("Expected the job logger to carry the job and tenant, got %v", job.fields[0])
+	}
+}
+
+func TestScheduler_TickContinuesAfterFailure(t *testing.T) {
+	job := &fakeJob{fail: map[string]bool{"team-a": true}}
+	New("reminders", job, []string{"team-a", "team-b"}, newTestLogger(t)).Tick(context.Background(), time.Now())
+
+	if len(job.tenants) != 2 || job.tenants[1] != "team-b" {
+		t.Errorf("Expected every tenant to be notified, got %v", job.tenants)
+	}
+}
@@ -1,62 +1,127 @@
 package services
 
 import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/oklog/ulid/v2"
+
+	"achievement-management/internal/cron"
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
 	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
 )
 
 // AchievementServiceImpl 達成目録サービスの実装
 type AchievementServiceImpl struct {
 	achievementRepo repository.AchievementRepository
 	pointRepo       repository.PointRepository
+	streaks         StreakSettings
+	limits          Limits
+	now             func() time.Time
 }
 
-// NewAchievementService 達成目録サービスを作成
+// NewAchievementService 達成目録サービスを作成（連続達成日数はローカル時刻で数え、ボーナスは付与しない）
 func NewAchievementService(achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository) AchievementService {
+	return NewAchievementServiceWithStreaks(achievementRepo, pointRepo, StreakSettings{})
+}
+
+// NewAchievementServiceWithStreaks 連続達成日数の設定を指定して達成目録サービスを作成
+func NewAchievementServiceWithStreaks(achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository, streaks StreakSettings) AchievementService {
+	return NewAchievementServiceWithLimits(achievementRepo, pointRepo, streaks, Limits{})
+}
+
+// NewAchievementServiceWithLimits 連続達成日数の設定と入力の上限を指定して達成目録サービスを作成
+func NewAchievementServiceWithLimits(achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository, streaks StreakSettings, limits Limits) AchievementService {
 	return &AchievementServiceImpl{
 		achievementRepo: achievementRepo,
 		pointRepo:       pointRepo,
+		streaks:         streaks,
+		limits:          limits,
+		now:             time.Now,
 	}
 }
 
-// Create 達成目録を作成し、ポイントを自動加算
-func (s *AchievementServiceImpl) Create(achievement *models.Achievement) error {
+// Create 達成目録を作成し、ポイントを自動加算（作成・加算・台帳への記録は1つのトランザクションで行う）
+func (s *AchievementServiceImpl) Create(ctx context.Context, achievement *models.Achievement) error {
 	if achievement == nil {
 		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
 	}
 
 	// バリデーション
+	normalizeAchievement(achievement)
 	if err := s.validateAchievement(achievement); err != nil {
 		return err
 	}
 
-	// 達成目録を作成
-	if err := s.achievementRepo.Create(achievement); err != nil {
+	clientID := achievement.ID != ""
+	if clientID {
+		id, err := normalizeID(achievement.ID)
+		if err != nil {
+			return err
+		}
+		achievement.ID = id
+	}
+
+	// 1日の獲得ポイントの上限を超える場合は、拒否するかポイントを付与せずに作成する
+	grant, err := s.checkDailyQuota(ctx, "Create", achievement.Point, s.now())
+	if err != nil {
 		return err
 	}
+	if grant {
+		err = s.achievementRepo.CreateWithPoints(ctx, achievement)
+	} else {
+		err = s.achievementRepo.Create(ctx, achievement)
+	}
+	if clientID && stderrors.Is(err, errors.ErrDuplicateResource) {
+		// 同じ内容の再送は作成済みの達成目録を返す（ポイントは加算しない）
+		existing, getErr := s.achievementRepo.GetByID(ctx, achievement.ID)
+		if getErr != nil || !sameAchievement(existing, achievement) {
+			return err
+		}
+		*achievement = *existing
+		return nil
+	}
+	return err
+}
 
-	// ポイントを自動加算
-	if err := s.pointRepo.AddPoints(achievement.Point); err != nil {
-		// ポイント加算に失敗した場合、作成した達成目録を削除してロールバック
-		if deleteErr := s.achievementRepo.Delete(achievement.ID); deleteErr != nil {
-			// ロールバックも失敗した場合は、両方のエラーを含む複合エラーを返す
-			return &errors.DatabaseError{
-				Operation: "Create",
-				Table:     "achievements and current_points",
-				Cause:     err,
-			}
+// Update 達成目録を更新し、ポイントの変更分を現在のポイントに反映（更新・増減・台帳への記録は1つのトランザクションで行う）
+func (s *AchievementServiceImpl) Update(ctx context.Context, id string, achievement *models.Achievement) error {
+	if err := s.prepareUpdate(id, achievement); err != nil {
+		return err
+	}
+
+	err := s.achievementRepo.UpdateWithPoints(ctx, achievement)
+	// 減らした分のポイントをすでに報酬獲得に使っている場合
+	if err == errors.ErrInsufficientPoints {
+		return &errors.BusinessLogicError{
+			Operation: "Update",
+			Reason:    "insufficient points",
+			Code:      errors.CodeInsufficientPoints,
 		}
+	}
+	return err
+}
+
+// UpdateWithoutPoints 現在のポイントを変えずに達成目録を更新
+func (s *AchievementServiceImpl) UpdateWithoutPoints(ctx context.Context, id string, achievement *models.Achievement) error {
+	if err := s.prepareUpdate(id, achievement); err != nil {
 		return err
 	}
 
-	return nil
+	return s.achievementRepo.Update(ctx, achievement)
 }
 
-// Update 達成目録を更新
-func (s *AchievementServiceImpl) Update(id string, achievement *models.Achievement) error {
-	if id == "" {
-		return &errors.ValidationError{Field: "id", Message: "id is required"}
+// prepareUpdate 更新内容を検証し、更新対象のIDを設定
+func (s *AchievementServiceImpl) prepareUpdate(id string, achievement *models.Achievement) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
 	}
 
 	if achievement == nil {
@@ -64,49 +129,332 @@ func (s *AchievementServiceImpl) Update(id string, achievement *models.Achieveme
 	}
 
 	// バリデーション
+	normalizeAchievement(achievement)
 	if err := s.validateAchievement(achievement); err != nil {
 		return err
 	}
 
 	// IDを設定
 	achievement.ID = id
-
-	// 更新実行
-	return s.achievementRepo.Update(achievement)
+	return nil
 }
 
 // GetByID IDで達成目録を取得
-func (s *AchievementServiceImpl) GetByID(id string) (*models.Achievement, error) {
-	if id == "" {
-		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+func (s *AchievementServiceImpl) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	return s.achievementRepo.GetByID(ctx, id)
+}
+
+// List すべての達成目録を、固定した達成目録・並び順を指定した達成目録・その他（作成日時順）の順に取得
+func (s *AchievementServiceImpl) List(ctx context.Context) ([]*models.Achievement, error) {
+	achievements, err := s.achievementRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortAchievements(achievements)
+	return achievements, nil
+}
+
+// Reorder 指定した順に達成目録の並び順を設定（指定しなかった達成目録の並び順は消す）
+func (s *AchievementServiceImpl) Reorder(ctx context.Context, ids []string) ([]*models.Achievement, error) {
+	return reorderAchievements(ctx, s, ids)
+}
+
+// reorderAchievements 並び順の変わる達成目録を1件ずつ UpdateWithoutPoints で更新し、並び替えた一覧を返す
+// （操作履歴に記録するサービスから呼び出した場合は、更新した達成目録ごとに記録される）
+func reorderAchievements(ctx context.Context, service AchievementService, ids []string) ([]*models.Achievement, error) {
+	if len(ids) == 0 {
+		return nil, &errors.ValidationError{Field: "ids", Message: "at least one id is required"}
+	}
+	positions := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if err := tenant.ValidateEntityID("ids", id); err != nil {
+			return nil, err
+		}
+		if _, ok := positions[id]; ok {
+			return nil, &errors.ValidationError{Field: "ids", Message: "ids must not contain duplicates"}
+		}
+		positions[id] = i + 1
 	}
 
-	return s.achievementRepo.GetByID(id)
+	achievements, err := service.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool, len(achievements))
+	for _, achievement := range achievements {
+		found[achievement.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return nil, fmt.Errorf("achievement %s: %w", id, errors.ErrNotFound)
+		}
+	}
+
+	for _, achievement := range achievements {
+		if achievement.SortOrder == positions[achievement.ID] {
+			continue
+		}
+		// 一覧を取得した後に他の更新があった場合は ErrVersionConflict
+		updated := *achievement
+		updated.SortOrder = positions[achievement.ID]
+		if err := service.UpdateWithoutPoints(ctx, achievement.ID, &updated); err != nil {
+			return nil, err
+		}
+	}
+	return service.List(ctx)
 }
 
-// List すべての達成目録を取得
-func (s *AchievementServiceImpl) List() ([]*models.Achievement, error) {
-	return s.achievementRepo.List()
+// sortAchievements 固定した達成目録を先頭に、並び順を指定した達成目録をその後に昇順で並べる（それ以外は元の順のまま）
+func sortAchievements(achievements []*models.Achievement) {
+	sort.SliceStable(achievements, func(i, j int) bool {
+		a, b := achievements[i], achievements[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if (a.SortOrder > 0) != (b.SortOrder > 0) {
+			return a.SortOrder > 0
+		}
+		return a.SortOrder < b.SortOrder
+	})
+}
+
+// Count 達成目録の件数を取得（DynamoDBではテーブル情報から取得するおおよその件数）
+func (s *AchievementServiceImpl) Count(ctx context.Context) (int, error) {
+	return s.achievementRepo.Count(ctx)
 }
 
 // Delete 達成目録を削除
-func (s *AchievementServiceImpl) Delete(id string) error {
-	if id == "" {
-		return &errors.ValidationError{Field: "id", Message: "id is required"}
+func (s *AchievementServiceImpl) Delete(ctx context.Context, id string) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
+	}
+
+	return s.achievementRepo.Delete(ctx, id)
+}
+
+// DeleteWithPoints 達成目録を削除し、付与したポイントを減算（削除・減算・台帳への記録は1つのトランザクションで行う）
+func (s *AchievementServiceImpl) DeleteWithPoints(ctx context.Context, id string) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
+	}
+
+	err := s.achievementRepo.DeleteWithPoints(ctx, id)
+	// 付与したポイントをすでに報酬獲得に使っている場合
+	if err == errors.ErrInsufficientPoints {
+		return &errors.BusinessLogicError{
+			Operation: "DeleteWithPoints",
+			Reason:    "insufficient points",
+			Code:      errors.CodeInsufficientPoints,
+		}
+	}
+	return err
+}
+
+// DeleteMany 複数の達成目録をまとめて削除
+func (s *AchievementServiceImpl) DeleteMany(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		if err := tenant.ValidateEntityID("id", id); err != nil {
+			return err
+		}
+	}
+
+	return s.achievementRepo.DeleteMany(ctx, ids)
+}
+
+// Restore 削除した達成目録を同じIDで作成し直す（withPoints の場合は削除時に減算したポイントも加算し直す）
+func (s *AchievementServiceImpl) Restore(ctx context.Context, achievement *models.Achievement, withPoints bool) error {
+	if achievement == nil {
+		return &errors.ValidationError{Field: "achievement", Message: "achievement cannot be nil"}
+	}
+	if err := tenant.ValidateEntityID("id", achievement.ID); err != nil {
+		return err
 	}
 
-	return s.achievementRepo.Delete(id)
+	normalizeAchievement(achievement)
+	if err := s.validateAchievement(achievement); err != nil {
+		return err
+	}
+
+	achievement.Version = 0
+	// 削除した時点で動いていたタイマーは再開しない
+	achievement.TimerStartedAt = nil
+	if withPoints {
+		return s.achievementRepo.CreateWithPoints(ctx, achievement)
+	}
+	return s.achievementRepo.Create(ctx, achievement)
+}
+
+// Complete 達成目録を達成したことを記録し、達成目録のポイントを付与（記録・加算・台帳への記録は1つのトランザクションで行う）
+// 連続達成日数が節目に達した場合は、設定したボーナスポイントも同じトランザクションで付与する
+func (s *AchievementServiceImpl) Complete(ctx context.Context, id string) (*models.Completion, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	achievement, err := s.achievementRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 後から達成目録が編集・削除されても記録が変わらないよう、タイトルとポイントを複製して保持する
+	completion := &models.Completion{
+		AchievementID:    achievement.ID,
+		AchievementTitle: achievement.Title,
+		Point:            achievement.Point,
+		CompletedAt:      s.now(),
+	}
+	if err := s.complete(ctx, achievement, completion); err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+// complete 達成回数の上限・連続達成のボーナス・1日の獲得ポイントの上限を反映して達成記録を作成
+func (s *AchievementServiceImpl) complete(ctx context.Context, achievement *models.Achievement, completion *models.Completion) error {
+	var err error
+	if err := s.checkRepeatLimits(ctx, achievement, completion); err != nil {
+		return err
+	}
+	if completion.BonusPoint, err = s.streakBonus(ctx, achievement.ID, completion.CompletedAt); err != nil {
+		return err
+	}
+	grant, err := s.checkDailyQuota(ctx, "Complete", completion.Point+completion.BonusPoint, completion.CompletedAt)
+	if err != nil {
+		return err
+	}
+	if !grant {
+		// 上限を超えた達成は0ポイントとして記録する
+		completion.Point = 0
+		completion.BonusPoint = 0
+	}
+	return s.achievementRepo.Complete(ctx, completion)
+}
+
+// checkRepeatLimits 達成目録の1日・通算の達成回数の上限を達成記録に設定し、すでに上限に達している場合は BusinessLogicError を返す
+//
+// 同時に達成された場合も上限を超えないよう、ストレージが達成記録の作成と同じトランザクションでもう一度確認する。
+// 日付は連続達成日数と同じタイムゾーンで区切る。
+func (s *AchievementServiceImpl) checkRepeatLimits(ctx context.Context, achievement *models.Achievement, completion *models.Completion) error {
+	if achievement.MaxPerDay <= 0 && achievement.MaxTotal <= 0 {
+		return nil
+	}
+
+	dayStart, dayEnd := s.dayBounds(completion.CompletedAt)
+	limit := &models.CompletionLimit{
+		MaxPerDay: achievement.MaxPerDay,
+		MaxTotal:  achievement.MaxTotal,
+		DayStart:  dayStart,
+		DayEnd:    dayEnd,
+	}
+
+	completions, err := s.achievementRepo.ListCompletions(ctx, achievement.ID)
+	if err != nil {
+		return err
+	}
+	total, today := repository.CountCompletions(limit, completions)
+	if err := repository.CheckCompletionLimit(limit, total, today); err != nil {
+		return err
+	}
+
+	completion.Limit = limit
+	return nil
+}
+
+// ListCompletions 達成目録の達成記録を達成日時の順に取得
+func (s *AchievementServiceImpl) ListCompletions(ctx context.Context, id string) ([]*models.Completion, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	return s.achievementRepo.ListCompletions(ctx, id)
+}
+
+// normalizeID 呼び出し側が指定したIDがULIDであることを確認し、大文字の表記に揃える
+func normalizeID(id string) (string, error) {
+	parsed, err := ulid.ParseStrict(id)
+	if err != nil {
+		return "", &errors.ValidationError{Field: "id", Message: "id must be a valid ULID"}
+	}
+	return parsed.String(), nil
 }
 
 // validateAchievement 達成目録のバリデーション
 func (s *AchievementServiceImpl) validateAchievement(achievement *models.Achievement) error {
-	if achievement.Title == "" {
-		return &errors.ValidationError{Field: "title", Message: "title is required"}
+	if err := validateText(achievement.Title, achievement.Description); err != nil {
+		return err
+	}
+
+	if err := s.limits.validatePoint(achievement.Point); err != nil {
+		return err
+	}
+
+	if utf8.RuneCountInString(achievement.Category) > maxCategoryLength {
+		return &errors.ValidationError{Field: "category", Message: "category must be at most 64 characters"}
+	}
+
+	if achievement.DueDate != "" {
+		if _, err := time.Parse(dateLayout, achievement.DueDate); err != nil {
+			return &errors.ValidationError{Field: "due_date", Message: "due_date must be a date in YYYY-MM-DD format"}
+		}
+	}
+
+	if achievement.Reminder != "" {
+		if _, err := cron.Parse(achievement.Reminder); err != nil {
+			return &errors.ValidationError{Field: "reminder", Message: err.Error()}
+		}
 	}
 
-	if achievement.Point <= 0 {
-		return &errors.ValidationError{Field: "point", Message: "point must be positive"}
+	if achievement.Difficulty != "" && !validDifficulty(achievement.Difficulty) {
+		return &errors.ValidationError{Field: "difficulty", Message: "difficulty must be easy, medium or hard"}
+	}
+
+	if achievement.MaxPerDay < 0 {
+		return &errors.ValidationError{Field: "max_per_day", Message: "max_per_day must not be negative"}
+	}
+	if achievement.MaxTotal < 0 {
+		return &errors.ValidationError{Field: "max_total", Message: "max_total must not be negative"}
+	}
+	if achievement.SortOrder < 0 {
+		return &errors.ValidationError{Field: "sort_order", Message: "sort_order must not be negative"}
+	}
+	if achievement.PointsPerHour < 0 {
+		return &errors.ValidationError{Field: "points_per_hour", Message: "points_per_hour must not be negative"}
+	}
+	if max := s.limits.maxPoint(); achievement.PointsPerHour > max {
+		return &errors.ValidationError{Field: "points_per_hour", Message: fmt.Sprintf("points_per_hour must be at most %d", max)}
 	}
 
 	return nil
+}
+
+// maxCategoryLength 分類の最大文字数
+const maxCategoryLength = 64
+
+// validDifficulty 設定できる難易度か
+func validDifficulty(difficulty models.Difficulty) bool {
+	for _, d := range models.Difficulties {
+		if difficulty == d {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAchievement タイトル・説明・分類・期限・リマインド・難易度の入力の揺れを揃える
+func normalizeAchievement(achievement *models.Achievement) {
+	achievement.Title = normalizeText(achievement.Title)
+	achievement.Description = normalizeText(achievement.Description)
+	achievement.Category = normalizeCategory(achievement.Category)
+	achievement.DueDate = strings.TrimSpace(achievement.DueDate)
+	achievement.Reminder = strings.TrimSpace(achievement.Reminder)
+	achievement.Difficulty = models.Difficulty(strings.ToLower(strings.TrimSpace(string(achievement.Difficulty))))
+}
+
+// normalizeCategory 分類の全角文字と前後の空白を揃え、英字を小文字に揃える（"Ｈｅａｌｔｈ" と "health" を同じ分類として集計する）
+func normalizeCategory(category string) string {
+	return strings.ToLower(normalizeText(category))
 }
\ No newline at end of file
@@ -1,14 +1,21 @@
 package services
 
 import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/repository/memory"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockAchievementRepository モック達成目録リポジトリ
@@ -16,17 +23,22 @@ type MockAchievementRepository struct {
 	mock.Mock
 }
 
-func (m *MockAchievementRepository) Create(achievement *models.Achievement) error {
+func (m *MockAchievementRepository) Create(ctx context.Context, achievement *models.Achievement) error {
 	args := m.Called(achievement)
 	return args.Error(0)
 }
 
-func (m *MockAchievementRepository) Update(achievement *models.Achievement) error {
+func (m *MockAchievementRepository) Update(ctx context.Context, achievement *models.Achievement) error {
 	args := m.Called(achievement)
 	return args.Error(0)
 }
 
-func (m *MockAchievementRepository) GetByID(id string) (*models.Achievement, error) {
+func (m *MockAchievementRepository) UpdateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	args := m.Called(achievement)
+	return args.Error(0)
+}
+
+func (m *MockAchievementRepository) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -34,7 +46,7 @@ func (m *MockAchievementRepository) GetByID(id string) (*models.Achievement, err
 	return args.Get(0).(*models.Achievement), args.Error(1)
 }
 
-func (m *MockAchievementRepository) List() ([]*models.Achievement, error) {
+func (m *MockAchievementRepository) List(ctx context.Context) ([]*models.Achievement, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -42,17 +54,76 @@ func (m *MockAchievementRepository) List() ([]*models.Achievement, error) {
 	return args.Get(0).([]*models.Achievement), args.Error(1)
 }
 
-func (m *MockAchievementRepository) Delete(id string) error {
+func (m *MockAchievementRepository) CreateWithPoints(ctx context.Context, achievement *models.Achievement) error {
+	args := m.Called(achievement)
+	return args.Error(0)
+}
+
+func (m *MockAchievementRepository) Count(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAchievementRepository) ListSummaries(ctx context.Context) ([]*models.Achievement, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Achievement), args.Error(1)
+}
+
+func (m *MockAchievementRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAchievementRepository) DeleteWithPoints(ctx context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+func (m *MockAchievementRepository) DeleteMany(ctx context.Context, ids []string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *MockAchievementRepository) SetAttachment(ctx context.Context, id, key string) error {
+	args := m.Called(id, key)
+	return args.Error(0)
+}
+
+func (m *MockAchievementRepository) StartTimer(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+func (m *MockAchievementRepository) Complete(ctx context.Context, completion *models.Completion) error {
+	args := m.Called(completion)
+	return args.Error(0)
+}
+
+func (m *MockAchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	args := m.Called(achievementID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Completion), args.Error(1)
+}
+
 // MockPointRepository モックポイントリポジトリ
 type MockPointRepository struct {
 	mock.Mock
 }
 
-func (m *MockPointRepository) GetCurrentPoints() (*models.CurrentPoints, error) {
+func (m *MockPointRepository) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CurrentPoints), args.Error(1)
+}
+
+func (m *MockPointRepository) GetCurrentPointsConsistent(ctx context.Context) (*models.CurrentPoints, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -60,17 +131,17 @@ func (m *MockPointRepository) GetCurrentPoints() (*models.CurrentPoints, error)
 	return args.Get(0).(*models.CurrentPoints), args.Error(1)
 }
 
-func (m *MockPointRepository) UpdateCurrentPoints(points *models.CurrentPoints) error {
+func (m *MockPointRepository) UpdateCurrentPoints(ctx context.Context, points *models.CurrentPoints) error {
 	args := m.Called(points)
 	return args.Error(0)
 }
 
-func (m *MockPointRepository) CreateRewardHistory(history *models.RewardHistory) error {
+func (m *MockPointRepository) CreateRewardHistory(ctx context.Context, history *models.RewardHistory) error {
 	args := m.Called(history)
 	return args.Error(0)
 }
 
-func (m *MockPointRepository) GetRewardHistory() ([]*models.RewardHistory, error) {
+func (m *MockPointRepository) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -78,17 +149,69 @@ func (m *MockPointRepository) GetRewardHistory() ([]*models.RewardHistory, error
 	return args.Get(0).([]*models.RewardHistory), args.Error(1)
 }
 
-func (m *MockPointRepository) TransactPointsAndHistory(pointsUpdate *models.CurrentPoints, history *models.RewardHistory) error {
-	args := m.Called(pointsUpdate, history)
+func (m *MockPointRepository) CountRewardHistory(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPointRepository) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.RewardHistory), args.Error(1)
+}
+
+func (m *MockPointRepository) RedeemPoints(ctx context.Context, history *models.RewardHistory) error {
+	args := m.Called(history)
 	return args.Error(0)
 }
 
-func (m *MockPointRepository) AddPoints(points int) error {
+func (m *MockPointRepository) GetRewardHistoryByID(ctx context.Context, id string) (*models.RewardHistory, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RewardHistory), args.Error(1)
+}
+
+func (m *MockPointRepository) RefundRedemption(ctx context.Context, history *models.RewardHistory) error {
+	args := m.Called(history)
+	return args.Error(0)
+}
+
+func (m *MockPointRepository) SetRedemptionAttachment(ctx context.Context, id, key string) error {
+	args := m.Called(id, key)
+	return args.Error(0)
+}
+
+func (m *MockPointRepository) AnnotateRedemption(ctx context.Context, id, note string, tags []string) error {
+	args := m.Called(id, note, tags)
+	return args.Error(0)
+}
+
+func (m *MockPointRepository) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PointLedgerEntry), args.Error(1)
+}
+
+func (m *MockPointRepository) GetLedgerBetween(ctx context.Context, from, to time.Time) ([]*models.PointLedgerEntry, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PointLedgerEntry), args.Error(1)
+}
+
+func (m *MockPointRepository) AddPoints(ctx context.Context, points int) error {
 	args := m.Called(points)
 	return args.Error(0)
 }
 
-func (m *MockPointRepository) SubtractPoints(points int) error {
+func (m *MockPointRepository) SubtractPoints(ctx context.Context, points int) error {
 	args := m.Called(points)
 	return args.Error(0)
 }
@@ -110,8 +233,8 @@ func TestAchievementService_Create(t *testing.T) {
 				Point:       100,
 			},
 			setupMocks: func(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository) {
-				achievementRepo.On("Create", mock.AnythingOfType("*models.Achievement")).Return(nil)
-				pointRepo.On("AddPoints", 100).Return(nil)
+				// 作成とポイント加算は1つのトランザクションで行い、AddPoints は呼ばない
+				achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -175,42 +298,64 @@ func TestAchievementService_Create(t *testing.T) {
 				Point:       100,
 			},
 			setupMocks: func(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository) {
-				achievementRepo.On("Create", mock.AnythingOfType("*models.Achievement")).Return(&errors.DatabaseError{})
+				// トランザクションが失敗した場合は何も書き込まれないため、削除によるロールバックは行わない
+				achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(&errors.DatabaseError{})
 			},
 			expectedError:     &errors.DatabaseError{},
 			expectedErrorType: &errors.DatabaseError{},
 		},
 		{
-			name: "ポイント加算エラー（ロールバック成功）",
+			name: "同じIDで内容が異なる達成目録",
 			achievement: &models.Achievement{
-				ID:          "test-id",
+				ID:          "01J00000000000000000000000",
 				Title:       "テスト達成目録",
 				Description: "テスト用の達成目録です",
 				Point:       100,
 			},
 			setupMocks: func(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository) {
-				achievementRepo.On("Create", mock.AnythingOfType("*models.Achievement")).Return(nil)
-				pointRepo.On("AddPoints", 100).Return(&errors.DatabaseError{})
-				achievementRepo.On("Delete", "test-id").Return(nil)
+				achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(errors.ErrDuplicateResource)
+				achievementRepo.On("GetByID", "01J00000000000000000000000").Return(&models.Achievement{
+					ID:    "01J00000000000000000000000",
+					Title: "別の達成目録",
+					Point: 100,
+				}, nil)
 			},
-			expectedError:     &errors.DatabaseError{},
-			expectedErrorType: &errors.DatabaseError{},
+			expectedError:     errors.ErrDuplicateResource,
+			expectedErrorType: errors.ErrDuplicateResource,
 		},
 		{
-			name: "ポイント加算エラー（ロールバック失敗）",
+			name: "同じIDと内容での再送",
 			achievement: &models.Achievement{
-				ID:          "test-id",
+				ID:          "01J00000000000000000000000",
 				Title:       "テスト達成目録",
 				Description: "テスト用の達成目録です",
 				Point:       100,
 			},
 			setupMocks: func(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository) {
-				achievementRepo.On("Create", mock.AnythingOfType("*models.Achievement")).Return(nil)
-				pointRepo.On("AddPoints", 100).Return(&errors.DatabaseError{})
-				achievementRepo.On("Delete", "test-id").Return(&errors.DatabaseError{})
+				achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(errors.ErrDuplicateResource)
+				achievementRepo.On("GetByID", "01J00000000000000000000000").Return(&models.Achievement{
+					ID:          "01J00000000000000000000000",
+					Title:       "テスト達成目録",
+					Description: "テスト用の達成目録です",
+					Point:       100,
+					Version:     1,
+				}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "IDがULIDではない達成目録",
+			achievement: &models.Achievement{
+				ID:    "test-id",
+				Title: "テスト達成目録",
+				Point: 100,
+			},
+			setupMocks: func(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository) {
+				// モックの設定は不要
 			},
-			expectedError:     &errors.DatabaseError{},
-			expectedErrorType: &errors.DatabaseError{},
+			expectedError:      &errors.ValidationError{},
+			expectedErrorType:  &errors.ValidationError{},
+			expectedErrorField: "id",
 		},
 	}
 
@@ -222,7 +367,7 @@ func TestAchievementService_Create(t *testing.T) {
 			tt.setupMocks(achievementRepo, pointRepo)
 			
 			service := NewAchievementService(achievementRepo, pointRepo)
-			err := service.Create(tt.achievement)
+			err := service.Create(context.Background(), tt.achievement)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -243,6 +388,110 @@ func TestAchievementService_Create(t *testing.T) {
 	}
 }
 
+func TestAchievementService_Create_NormalizesClientID(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("CreateWithPoints", mock.MatchedBy(func(achievement *models.Achievement) bool {
+		return achievement.ID == "01J00000000000000000000ABC"
+	})).Return(nil)
+
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+	achievement := &models.Achievement{ID: "01j00000000000000000000abc", Title: "テスト達成目録", Point: 100}
+	assert.NoError(t, service.Create(context.Background(), achievement))
+	assert.Equal(t, "01J00000000000000000000ABC", achievement.ID)
+	achievementRepo.AssertExpectations(t)
+}
+
+func TestAchievementService_Category(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("CreateWithPoints", mock.MatchedBy(func(achievement *models.Achievement) bool {
+		return achievement.Category == "health"
+	})).Return(nil)
+	achievementRepo.On("Update", mock.MatchedBy(func(achievement *models.Achievement) bool {
+		return achievement.Category == "learning"
+	})).Return(nil)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+
+	// 前後の空白を除き、英字は小文字に揃えて同じ分類として集計する
+	achievement := &models.Achievement{Title: "朝のランニング", Point: 30, Category: "  Health "}
+	assert.NoError(t, service.Create(context.Background(), achievement))
+	assert.Equal(t, "health", achievement.Category)
+
+	assert.NoError(t, service.UpdateWithoutPoints(context.Background(), "test-id", &models.Achievement{Title: "英単語", Point: 20, Category: "LEARNING"}))
+
+	long := &models.Achievement{Title: "英単語", Point: 20, Category: strings.Repeat("あ", 65)}
+	err := service.Create(context.Background(), long)
+	assert.IsType(t, &errors.ValidationError{}, err)
+	achievementRepo.AssertExpectations(t)
+}
+
+func TestAchievementService_NormalizesText(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(nil)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+
+	// 全角の英数字と空白を半角に、半角カナを全角に揃え、前後の空白を除く
+	achievement := &models.Achievement{Title: "　ＴＯＥＩＣ　９００点 ", Description: " ｶﾞﾝﾊﾞﾙ\n", Point: 100, Category: "Ｌｅａｒｎｉｎｇ"}
+	assert.NoError(t, service.Create(context.Background(), achievement))
+	assert.Equal(t, "TOEIC 900点", achievement.Title)
+	assert.Equal(t, "ガンバル", achievement.Description)
+	assert.Equal(t, "learning", achievement.Category)
+
+	// 空白だけのタイトルは空として扱う
+	err := service.Create(context.Background(), &models.Achievement{Title: "　 ", Point: 100})
+	var validationErr *errors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "title", validationErr.Field)
+	achievementRepo.AssertExpectations(t)
+}
+
+func TestAchievementService_Limits(t *testing.T) {
+	tests := []struct {
+		name        string
+		limits      Limits
+		achievement *models.Achievement
+		field       string
+	}{
+		{
+			name:        "長すぎるタイトル",
+			achievement: &models.Achievement{Title: strings.Repeat("あ", 201), Point: 100},
+			field:       "title",
+		},
+		{
+			name:        "長すぎる説明",
+			achievement: &models.Achievement{Title: "英単語", Description: strings.Repeat("あ", 2001), Point: 100},
+			field:       "description",
+		},
+		{
+			name:        "既定の上限を超えるポイント",
+			achievement: &models.Achievement{Title: "英単語", Point: 1000000},
+			field:       "point",
+		},
+		{
+			name:        "設定した上限を超えるポイント",
+			limits:      Limits{MaxPoint: 500},
+			achievement: &models.Achievement{Title: "英単語", Point: 501},
+			field:       "point",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAchievementServiceWithLimits(new(MockAchievementRepository), new(MockPointRepository), StreakSettings{}, tt.limits)
+			err := service.Create(context.Background(), tt.achievement)
+
+			var validationErr *errors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	// 上限ちょうどの値は受け付ける
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(nil)
+	service := NewAchievementServiceWithLimits(achievementRepo, new(MockPointRepository), StreakSettings{}, Limits{MaxPoint: 500})
+	assert.NoError(t, service.Create(context.Background(), &models.Achievement{Title: strings.Repeat("あ", 200), Description: strings.Repeat("あ", 2000), Point: 500}))
+}
+
 func TestAchievementService_Update(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -262,12 +511,25 @@ func TestAchievementService_Update(t *testing.T) {
 				Point:       150,
 			},
 			setupMocks: func(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository) {
-				achievementRepo.On("Update", mock.MatchedBy(func(a *models.Achievement) bool {
+				achievementRepo.On("UpdateWithPoints", mock.MatchedBy(func(a *models.Achievement) bool {
 					return a.ID == "test-id" && a.Title == "更新されたテスト達成目録"
 				})).Return(nil)
 			},
 			expectedError: nil,
 		},
+		{
+			name: "減らしたポイントの残高が不足",
+			id:   "test-id",
+			achievement: &models.Achievement{
+				Title: "テスト達成目録",
+				Point: 10,
+			},
+			setupMocks: func(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository) {
+				achievementRepo.On("UpdateWithPoints", mock.Anything).Return(errors.ErrInsufficientPoints)
+			},
+			expectedError:     &errors.BusinessLogicError{},
+			expectedErrorType: &errors.BusinessLogicError{},
+		},
 		{
 			name: "IDが空",
 			id:   "",
@@ -319,7 +581,7 @@ func TestAchievementService_Update(t *testing.T) {
 			tt.setupMocks(achievementRepo, pointRepo)
 			
 			service := NewAchievementService(achievementRepo, pointRepo)
-			err := service.Update(tt.id, tt.achievement)
+			err := service.Update(context.Background(), tt.id, tt.achievement)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -401,7 +663,7 @@ func TestAchievementService_GetByID(t *testing.T) {
 			tt.setupMocks(achievementRepo, pointRepo)
 			
 			service := NewAchievementService(achievementRepo, pointRepo)
-			achievement, err := service.GetByID(tt.id)
+			achievement, err := service.GetByID(context.Background(), tt.id)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -501,7 +763,7 @@ func TestAchievementService_List(t *testing.T) {
 			tt.setupMocks(achievementRepo, pointRepo)
 			
 			service := NewAchievementService(achievementRepo, pointRepo)
-			achievements, err := service.List()
+			achievements, err := service.List(context.Background())
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -569,7 +831,7 @@ func TestAchievementService_Delete(t *testing.T) {
 			tt.setupMocks(achievementRepo, pointRepo)
 			
 			service := NewAchievementService(achievementRepo, pointRepo)
-			err := service.Delete(tt.id)
+			err := service.Delete(context.Background(), tt.id)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -591,4 +853,350 @@ func TestAchievementService_Delete(t *testing.T) {
 			pointRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestAchievementService_UpdateWithoutPoints(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	pointRepo := new(MockPointRepository)
+	achievementRepo.On("Update", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "test-id" && a.Point == 50
+	})).Return(nil)
+
+	service := NewAchievementService(achievementRepo, pointRepo)
+	err := service.UpdateWithoutPoints(context.Background(), "test-id", &models.Achievement{Title: "テスト達成目録", Point: 50})
+	assert.NoError(t, err)
+
+	// ポイントを反映しない更新ではトランザクション付きの更新を使わない
+	achievementRepo.AssertNotCalled(t, "UpdateWithPoints", mock.Anything)
+	achievementRepo.AssertExpectations(t)
+
+	// 検証はポイントを反映する更新と共通
+	err = service.UpdateWithoutPoints(context.Background(), "", &models.Achievement{Title: "テスト達成目録", Point: 50})
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
+
+func TestAchievementService_Restore(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("CreateWithPoints", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "test-id" && a.Version == 0
+	})).Return(nil).Once()
+	achievementRepo.On("Create", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "test-id" && a.Version == 0
+	})).Return(nil).Once()
+
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+	// 削除した時点のバージョンは引き継がずに作成し直す
+	assert.NoError(t, service.Restore(context.Background(), &models.Achievement{ID: "test-id", Title: "テスト達成目録", Point: 50, Version: 3}, true))
+	assert.NoError(t, service.Restore(context.Background(), &models.Achievement{ID: "test-id", Title: "テスト達成目録", Point: 50, Version: 3}, false))
+	achievementRepo.AssertExpectations(t)
+
+	err := service.Restore(context.Background(), &models.Achievement{Title: "テスト達成目録", Point: 50}, true)
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
+
+func TestAchievementService_DeleteWithPoints(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		setupMocks    func(*MockAchievementRepository)
+		expectedError error
+	}{
+		{
+			name: "ポイントを減算して削除",
+			id:   "test-id",
+			setupMocks: func(achievementRepo *MockAchievementRepository) {
+				achievementRepo.On("DeleteWithPoints", "test-id").Return(nil)
+			},
+		},
+		{
+			name:          "IDが空",
+			id:            "",
+			setupMocks:    func(achievementRepo *MockAchievementRepository) {},
+			expectedError: &errors.ValidationError{Field: "id", Message: "id is required"},
+		},
+		{
+			name: "残高不足",
+			id:   "test-id",
+			setupMocks: func(achievementRepo *MockAchievementRepository) {
+				achievementRepo.On("DeleteWithPoints", "test-id").Return(errors.ErrInsufficientPoints)
+			},
+			expectedError: &errors.BusinessLogicError{Operation: "DeleteWithPoints", Reason: "insufficient points", Code: errors.CodeInsufficientPoints},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			achievementRepo := new(MockAchievementRepository)
+			pointRepo := new(MockPointRepository)
+			tt.setupMocks(achievementRepo)
+
+			service := NewAchievementService(achievementRepo, pointRepo)
+			err := service.DeleteWithPoints(context.Background(), tt.id)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			achievementRepo.AssertExpectations(t)
+			// 減算はリポジトリのトランザクションで行うためポイントリポジトリは呼び出さない
+			pointRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAchievementService_Complete(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	pointRepo := new(MockPointRepository)
+	achievementRepo.On("GetByID", "test-id").Return(&models.Achievement{ID: "test-id", Title: "朝のランニング", Point: 30}, nil)
+	achievementRepo.On("Complete", mock.MatchedBy(func(c *models.Completion) bool {
+		return c.AchievementID == "test-id" && c.AchievementTitle == "朝のランニング" && c.Point == 30
+	})).Return(nil)
+
+	service := NewAchievementService(achievementRepo, pointRepo)
+	completion, err := service.Complete(context.Background(), "test-id")
+	require.NoError(t, err)
+	assert.Equal(t, 30, completion.Point)
+
+	// ポイントの付与はリポジトリのトランザクションで行う
+	achievementRepo.AssertExpectations(t)
+	pointRepo.AssertExpectations(t)
+}
+
+func TestAchievementService_Complete_Errors(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+
+	_, err := service.Complete(context.Background(), "")
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	achievementRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	_, err = service.Complete(context.Background(), "missing")
+	assert.Equal(t, errors.ErrNotFound, err)
+	achievementRepo.AssertNotCalled(t, "Complete", mock.Anything)
+}
+
+func TestAchievementService_ListCompletions(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	expected := []*models.Completion{{ID: "c1", AchievementID: "test-id", Point: 30}}
+	achievementRepo.On("ListCompletions", "test-id").Return(expected, nil)
+
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+	completions, err := service.ListCompletions(context.Background(), "test-id")
+	require.NoError(t, err)
+	assert.Equal(t, expected, completions)
+
+	_, err = service.ListCompletions(context.Background(), "")
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
+
+func TestAchievementService_Difficulty(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(nil)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+
+	// 難易度は前後の空白を除き小文字に揃える
+	achievement := &models.Achievement{Title: "フルマラソン", Point: 500, Difficulty: " Hard"}
+	assert.NoError(t, service.Create(context.Background(), achievement))
+	assert.Equal(t, models.DifficultyHard, achievement.Difficulty)
+
+	err := service.Create(context.Background(), &models.Achievement{Title: "フルマラソン", Point: 500, Difficulty: "extreme"})
+	var validationErr *errors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "difficulty", validationErr.Field)
+	achievementRepo.AssertNumberOfCalls(t, "CreateWithPoints", 1)
+}
+
+func TestAchievementService_Complete_RepeatLimits(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, tokyo)
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("GetByID", "water").Return(&models.Achievement{ID: "water", Title: "水を飲んだ", Point: 1, MaxPerDay: 2}, nil)
+	achievementRepo.On("GetByID", "marathon").Return(&models.Achievement{ID: "marathon", Title: "フルマラソン", Point: 500, MaxTotal: 2}, nil)
+	// 日本時間の前日23時の達成はその日の回数に含めない
+	achievementRepo.On("ListCompletions", "water").Return(completionsOn("water",
+		time.Date(2024, 6, 9, 23, 0, 0, 0, tokyo),
+		time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo),
+	), nil).Once()
+	achievementRepo.On("ListCompletions", "water").Return(completionsOn("water",
+		time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo),
+		time.Date(2024, 6, 10, 10, 0, 0, 0, tokyo),
+	), nil)
+	achievementRepo.On("ListCompletions", "marathon").Return(completionsOn("marathon",
+		time.Date(2023, 11, 19, 9, 0, 0, 0, tokyo),
+		time.Date(2024, 3, 3, 9, 0, 0, 0, tokyo),
+	), nil)
+	achievementRepo.On("Complete", mock.AnythingOfType("*models.Completion")).Return(nil)
+
+	service := newStreakTestService(achievementRepo, StreakSettings{Location: tokyo}, now)
+
+	_, err := service.Complete(context.Background(), "water")
+	require.NoError(t, err)
+
+	_, err = service.Complete(context.Background(), "water")
+	var businessErr *errors.BusinessLogicError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Contains(t, businessErr.Reason, "2 time(s) per day")
+
+	_, err = service.Complete(context.Background(), "marathon")
+	require.ErrorAs(t, err, &businessErr)
+	assert.Contains(t, businessErr.Reason, "2 time(s) in total")
+
+	achievementRepo.AssertNumberOfCalls(t, "Complete", 1)
+	// ストレージが同じトランザクションで確認できるよう、日本時間の1日の期間を渡す
+	var completion *models.Completion
+	for _, call := range achievementRepo.Calls {
+		if call.Method == "Complete" {
+			completion = call.Arguments.Get(0).(*models.Completion)
+		}
+	}
+	require.NotNil(t, completion.Limit)
+	assert.Equal(t, 2, completion.Limit.MaxPerDay)
+	assert.True(t, completion.Limit.DayStart.Equal(time.Date(2024, 6, 10, 0, 0, 0, 0, tokyo)))
+	assert.True(t, completion.Limit.DayEnd.Equal(time.Date(2024, 6, 11, 0, 0, 0, 0, tokyo)))
+}
+
+// concurrentAchievementRepository 最初の n 回の達成記録の取得を、すべて読み取り終わるまで待たせるリポジトリ
+//
+// すべての達成が上限に達する前の達成記録を読み取った状態で記録する、同時の達成を再現する。
+type concurrentAchievementRepository struct {
+	repository.AchievementRepository
+	calls int32
+	n     int32
+	read  sync.WaitGroup
+}
+
+func newConcurrentAchievementRepository(next repository.AchievementRepository, n int) *concurrentAchievementRepository {
+	r := &concurrentAchievementRepository{AchievementRepository: next, n: int32(n)}
+	r.read.Add(n)
+	return r
+}
+
+func (r *concurrentAchievementRepository) ListCompletions(ctx context.Context, achievementID string) ([]*models.Completion, error) {
+	completions, err := r.AchievementRepository.ListCompletions(ctx, achievementID)
+	if atomic.AddInt32(&r.calls, 1) <= r.n {
+		r.read.Done()
+		r.read.Wait()
+	}
+	return completions, err
+}
+
+func TestAchievementService_Complete_ConcurrentRepeatLimits(t *testing.T) {
+	const requests = 10
+	store := memory.NewStore()
+	achievementRepo := newConcurrentAchievementRepository(memory.NewAchievementRepository(store), requests)
+	service := NewAchievementService(achievementRepo, memory.NewPointRepository(store))
+	ctx := context.Background()
+
+	achievement := &models.Achievement{Title: "水を飲んだ", Point: 1, MaxPerDay: 3, MaxTotal: 5}
+	require.NoError(t, service.Create(ctx, achievement))
+
+	// すべての達成が0件の達成記録を読み取った後に記録しても、ストレージが上限を確認するため上限を超えない
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	completed, limited := 0, 0
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Complete(ctx, achievement.ID)
+			mu.Lock()
+			defer mu.Unlock()
+			var businessErr *errors.BusinessLogicError
+			switch {
+			case err == nil:
+				completed++
+			case assert.ErrorAs(t, err, &businessErr):
+				assert.Equal(t, errors.CodeCompletionLimit, businessErr.Code)
+				limited++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, completed)
+	assert.Equal(t, requests-3, limited)
+	completions, err := service.ListCompletions(ctx, achievement.ID)
+	require.NoError(t, err)
+	assert.Len(t, completions, 3)
+}
+
+func TestAchievementService_RepeatLimitValidation(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+
+	err := service.Create(context.Background(), &models.Achievement{Title: "水を飲んだ", Point: 1, MaxPerDay: -1})
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "max_per_day", validationErr.Field)
+
+	err = service.Create(context.Background(), &models.Achievement{Title: "水を飲んだ", Point: 1, MaxTotal: -1})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "max_total", validationErr.Field)
+	achievementRepo.AssertNotCalled(t, "CreateWithPoints", mock.Anything)
+}
+
+func TestAchievementService_List_PinnedAndOrdered(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "a1", Title: "作成順1"},
+		{ID: "a2", Title: "並び順2", SortOrder: 2},
+		{ID: "a3", Title: "固定", Pinned: true},
+		{ID: "a4", Title: "並び順1", SortOrder: 1},
+		{ID: "a5", Title: "作成順2"},
+	}, nil)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+
+	achievements, err := service.List(context.Background())
+	require.NoError(t, err)
+
+	ids := make([]string, len(achievements))
+	for i, achievement := range achievements {
+		ids[i] = achievement.ID
+	}
+	assert.Equal(t, []string{"a3", "a4", "a2", "a1", "a5"}, ids)
+}
+
+func TestAchievementService_Reorder(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "a1", Title: "読書", Point: 10, Version: 1},
+		{ID: "a2", Title: "ランニング", Point: 20, SortOrder: 1, Version: 3},
+		{ID: "a3", Title: "英語", Point: 30, SortOrder: 2, Version: 2},
+	}, nil)
+	achievementRepo.On("Update", mock.AnythingOfType("*models.Achievement")).Return(nil)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+
+	_, err := service.Reorder(context.Background(), []string{"a1", "a2"})
+	require.NoError(t, err)
+
+	// 並び順の変わる達成目録だけを取得した時点のバージョンで更新し、指定しなかった達成目録の並び順は消す
+	achievementRepo.AssertCalled(t, "Update", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "a1" && a.SortOrder == 1 && a.Version == 1
+	}))
+	achievementRepo.AssertCalled(t, "Update", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "a2" && a.SortOrder == 2 && a.Version == 3
+	}))
+	achievementRepo.AssertCalled(t, "Update", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "a3" && a.SortOrder == 0 && a.Version == 2
+	}))
+	achievementRepo.AssertNotCalled(t, "UpdateWithPoints", mock.Anything)
+}
+
+func TestAchievementService_Reorder_Errors(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{{ID: "a1", Title: "読書", Point: 10}}, nil)
+	service := NewAchievementService(achievementRepo, new(MockPointRepository))
+	ctx := context.Background()
+
+	_, err := service.Reorder(ctx, nil)
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, err = service.Reorder(ctx, []string{"a1", "a1"})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, err = service.Reorder(ctx, []string{"a1", "missing"})
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+	achievementRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/cron"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// AllowanceServiceImpl お小遣いのルールのサービスの実装
+type AllowanceServiceImpl struct {
+	allowanceRepo repository.AllowanceRepository
+	limits        Limits
+	location      *time.Location
+}
+
+// NewAllowanceService お小遣いのルールのサービスを作成
+//
+// ルールの付与する日時は location（nilの場合はサーバーのローカル時刻）で判定する。
+func NewAllowanceService(allowanceRepo repository.AllowanceRepository, limits Limits, location *time.Location) AllowanceService {
+	if location == nil {
+		location = time.Local
+	}
+	return &AllowanceServiceImpl{
+		allowanceRepo: allowanceRepo,
+		limits:        limits,
+		location:      location,
+	}
+}
+
+// Create お小遣いのルールを作成（付与はスケジューラーが NotifyDue で行う）
+func (s *AllowanceServiceImpl) Create(ctx context.Context, allowance *models.Allowance) error {
+	if allowance == nil {
+		return &errors.ValidationError{Field: "allowance", Message: "allowance cannot be nil"}
+	}
+
+	allowance.Title = normalizeText(allowance.Title)
+	if err := validateText(allowance.Title, ""); err != nil {
+		return err
+	}
+	if allowance.Points <= 0 {
+		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+	if max := s.limits.maxPoint(); allowance.Points > max {
+		return &errors.ValidationError{Field: "points", Message: fmt.Sprintf("points must be at most %d", max)}
+	}
+	if allowance.Schedule == "" {
+		return &errors.ValidationError{Field: "schedule", Message: "schedule is required"}
+	}
+	if _, err := cron.Parse(allowance.Schedule); err != nil {
+		return &errors.ValidationError{Field: "schedule", Message: err.Error()}
+	}
+
+	allowance.LastGrantedAt = nil
+	return s.allowanceRepo.Create(ctx, allowance)
+}
+
+// List すべてのお小遣いのルールを取得
+func (s *AllowanceServiceImpl) List(ctx context.Context) ([]*models.Allowance, error) {
+	return s.allowanceRepo.List(ctx)
+}
+
+// Delete お小遣いのルールを削除（付与済みのポイントは取り消さない）
+func (s *AllowanceServiceImpl) Delete(ctx context.Context, id string) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
+	}
+	return s.allowanceRepo.Delete(ctx, id)
+}
+
+// NotifyDue at の分に付与する日時を迎えたルールのポイントを付与し、付与した件数を返す
+//
+// 付与した分はルールに記録するため、同じ分に2回呼び出しても（複数のインスタンスで実行しても）重複して付与しない。
+func (s *AllowanceServiceImpl) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	allowances, err := s.allowanceRepo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	local := at.In(s.location)
+	granted := 0
+	var errs []error
+	for _, allowance := range allowances {
+		schedule, err := cron.Parse(allowance.Schedule)
+		if err != nil || !schedule.Matches(local) {
+			// 作成時に検証しているため、解析できないのはストレージを直接編集したデータのみ
+			continue
+		}
+
+		if err := s.allowanceRepo.Grant(ctx, allowance.ID, allowance.Points, at); err != nil {
+			if stderrors.Is(err, errors.ErrVersionConflict) {
+				// 別のインスタンスが先に付与した、または削除された
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to grant allowance %s: %w", allowance.ID, err))
+			continue
+		}
+		granted++
+	}
+	return granted, stderrors.Join(errs...)
+}
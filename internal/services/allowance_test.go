@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAllowanceRepository お小遣いのルールのリポジトリのモック
+type MockAllowanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockAllowanceRepository) Create(ctx context.Context, allowance *models.Allowance) error {
+	args := m.Called(allowance)
+	return args.Error(0)
+}
+
+func (m *MockAllowanceRepository) GetByID(ctx context.Context, id string) (*models.Allowance, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Allowance), args.Error(1)
+}
+
+func (m *MockAllowanceRepository) List(ctx context.Context) ([]*models.Allowance, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Allowance), args.Error(1)
+}
+
+func (m *MockAllowanceRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAllowanceRepository) Grant(ctx context.Context, id string, points int, at time.Time) error {
+	args := m.Called(id, points, at)
+	return args.Error(0)
+}
+
+func TestAllowanceService_Create(t *testing.T) {
+	repo := new(MockAllowanceRepository)
+	repo.On("Create", mock.MatchedBy(func(a *models.Allowance) bool {
+		return a.Title == "お小遣い" && a.Points == 50 && a.Schedule == "0 9 * * 1"
+	})).Return(nil)
+	service := NewAllowanceService(repo, Limits{}, time.UTC)
+
+	require.NoError(t, service.Create(context.Background(), &models.Allowance{Title: " お小遣い ", Points: 50, Schedule: "0 9 * * 1"}))
+	repo.AssertExpectations(t)
+}
+
+func TestAllowanceService_Create_ValidationError(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowance *models.Allowance
+		field     string
+	}{
+		{name: "タイトルが空", allowance: &models.Allowance{Points: 50, Schedule: "0 9 * * 1"}, field: "title"},
+		{name: "ポイントが0", allowance: &models.Allowance{Title: "お小遣い", Schedule: "0 9 * * 1"}, field: "points"},
+		{name: "ポイントが上限を超える", allowance: &models.Allowance{Title: "お小遣い", Points: 1001, Schedule: "0 9 * * 1"}, field: "points"},
+		{name: "スケジュールが空", allowance: &models.Allowance{Title: "お小遣い", Points: 50}, field: "schedule"},
+		{name: "スケジュールが不正", allowance: &models.Allowance{Title: "お小遣い", Points: 50, Schedule: "every monday"}, field: "schedule"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAllowanceRepository)
+			service := NewAllowanceService(repo, Limits{MaxPoint: 1000}, time.UTC)
+
+			err := service.Create(context.Background(), tt.allowance)
+			var validationErr *errors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+			repo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestAllowanceService_NotifyDue(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// 日本時間の2024-06-10（月曜日）9時
+	at := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	repo := new(MockAllowanceRepository)
+	repo.On("List").Return([]*models.Allowance{
+		{ID: "weekly", Title: "お小遣い", Points: 50, Schedule: "0 9 * * 1"},
+		{ID: "monthly", Title: "月のお小遣い", Points: 500, Schedule: "0 9 1 * *"},
+		{ID: "granted", Title: "お手伝い", Points: 10, Schedule: "0 9 * * *"},
+		{ID: "failing", Title: "お駄賃", Points: 20, Schedule: "0 9 * * *"},
+	}, nil)
+	repo.On("Grant", "weekly", 50, at).Return(nil)
+	// 別のインスタンスが先に付与した場合は数えない
+	repo.On("Grant", "granted", 10, at).Return(errors.ErrVersionConflict)
+	repo.On("Grant", "failing", 20, at).Return(stderrors.New("throttled"))
+	service := NewAllowanceService(repo, Limits{}, tokyo)
+
+	granted, err := service.NotifyDue(context.Background(), at)
+	assert.Equal(t, 1, granted)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failing")
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Grant", "monthly", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// APIKeyPrefix 発行するAPIキーの先頭（ログやリポジトリに紛れ込んだキーを見つけやすくする）
+//
+// キーは「amk_{ID}_{ランダムな64文字}」の形式で、IDで保存したハッシュ値を引いて照合する。
+const APIKeyPrefix = "amk_"
+
+// apiKeySecretBytes APIキーのランダムな部分のバイト数
+const apiKeySecretBytes = 32
+
+// invalidAPIKeyScopeMessage 未定義の範囲を指定した場合のメッセージ
+var invalidAPIKeyScopeMessage = "scope must be one or more of " + joinAPIKeyScopes() + " separated by spaces"
+
+// ErrInvalidAPIKey APIキーの形式が不正・存在しない・無効にしたキー
+var ErrInvalidAPIKey = stderrors.New("invalid or revoked API key")
+
+// APIKeyServiceImpl APIキーのサービスの実装
+type APIKeyServiceImpl struct {
+	apiKeyRepo repository.APIKeyRepository
+	now        func() time.Time
+}
+
+// NewAPIKeyService APIキーのサービスを作成
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository) APIKeyService {
+	return &APIKeyServiceImpl{
+		apiKeyRepo: apiKeyRepo,
+		now:        time.Now,
+	}
+}
+
+// Create コンテキストのテナントを操作するAPIキーを発行し、保存したキーと発行したキーを返す（発行したキーは再表示できない）
+func (s *APIKeyServiceImpl) Create(ctx context.Context, label string, scope models.APIKeyScope) (*models.APIKey, string, error) {
+	label, err := validateAPIKeyLabel(label)
+	if err != nil {
+		return nil, "", err
+	}
+	scope = models.ParseAPIKeyScope(string(scope))
+	if !scope.Valid() {
+		return nil, "", &errors.ValidationError{Field: "scope", Message: invalidAPIKeyScopeMessage}
+	}
+
+	// キーにIDを含めるため、リポジトリで生成せずに先に決める
+	key := &models.APIKey{ID: ulid.Make().String(), Label: label, Scope: scope, TenantID: tenant.FromContext(ctx), CreatedAt: s.now()}
+	secret, err := newAPIKeySecret(key.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	key.Hash = hashAPIKey(secret)
+
+	if err := s.apiKeyRepo.Create(keysContext(ctx), key); err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// List すべてのテナントのAPIキーを取得（無効にしたキーを含む）
+func (s *APIKeyServiceImpl) List(ctx context.Context) ([]*models.APIKey, error) {
+	return s.apiKeyRepo.List(keysContext(ctx))
+}
+
+// Update APIキーのラベル・範囲を変更（空の値は変更しない）
+func (s *APIKeyServiceImpl) Update(ctx context.Context, id, label string, scope models.APIKeyScope) (*models.APIKey, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+	scope = models.ParseAPIKeyScope(string(scope))
+	if label == "" && scope == "" {
+		return nil, &errors.ValidationError{Field: "label", Message: "label or scope is required"}
+	}
+	if scope != "" && !scope.Valid() {
+		return nil, &errors.ValidationError{Field: "scope", Message: invalidAPIKeyScopeMessage}
+	}
+
+	key, err := s.apiKeyRepo.GetByID(keysContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if label != "" {
+		if key.Label, err = validateAPIKeyLabel(label); err != nil {
+			return nil, err
+		}
+	}
+	if scope != "" {
+		key.Scope = scope
+	}
+
+	if err := s.apiKeyRepo.Update(keysContext(ctx), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Rotate APIキーを再発行し、新しいキーを返す（以前のキーはすぐに使えなくなる）
+func (s *APIKeyServiceImpl) Rotate(ctx context.Context, id string) (*models.APIKey, string, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, "", err
+	}
+	key, err := s.apiKeyRepo.GetByID(keysContext(ctx), id)
+	if err != nil {
+		return nil, "", err
+	}
+	if key.Revoked() {
+		return nil, "", &errors.BusinessLogicError{Operation: "Rotate", Reason: "revoked API keys cannot be rotated"}
+	}
+
+	secret, err := newAPIKeySecret(key.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	rotatedAt := s.now()
+	key.Hash = hashAPIKey(secret)
+	key.RotatedAt = &rotatedAt
+
+	if err := s.apiKeyRepo.Update(keysContext(ctx), key); err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// Revoke APIキーを無効にする（無効にしたキーは記録として残し、無効にした日時は最初の1回のみ記録する）
+func (s *APIKeyServiceImpl) Revoke(ctx context.Context, id string) (*models.APIKey, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+	key, err := s.apiKeyRepo.GetByID(keysContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if key.Revoked() {
+		return key, nil
+	}
+
+	revokedAt := s.now()
+	key.RevokedAt = &revokedAt
+	if err := s.apiKeyRepo.Update(keysContext(ctx), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Authenticate APIキーを照合し、有効なキーであれば保存したキーを返す（無効な場合は ErrInvalidAPIKey）
+func (s *APIKeyServiceImpl) Authenticate(ctx context.Context, secret string) (*models.APIKey, error) {
+	id, _, ok := strings.Cut(strings.TrimPrefix(secret, APIKeyPrefix), "_")
+	if !strings.HasPrefix(secret, APIKeyPrefix) || !ok || id == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByID(keysContext(ctx), id)
+	if err != nil {
+		if stderrors.Is(err, errors.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashAPIKey(secret))) != 1 || key.Revoked() {
+		return nil, ErrInvalidAPIKey
+	}
+	return key, nil
+}
+
+// keysContext APIキーを保存するテナントのコンテキスト
+//
+// リクエストのテナントはAPIキーから決めるため、キーはテナントを解決する前に照合できるよう既定のテナントにまとめて保存し、
+// 操作できるテナントはキーの TenantID に記録する。
+func keysContext(ctx context.Context) context.Context {
+	return tenant.WithID(ctx, tenant.DefaultID)
+}
+
+// validateAPIKeyLabel ラベルを正規化して検証
+func validateAPIKeyLabel(label string) (string, error) {
+	label = normalizeText(label)
+	if label == "" {
+		return "", &errors.ValidationError{Field: "label", Message: "label is required"}
+	}
+	if utf8.RuneCountInString(label) > maxTitleLength {
+		return "", &errors.ValidationError{Field: "label", Message: fmt.Sprintf("label must be at most %d characters", maxTitleLength)}
+	}
+	return label, nil
+}
+
+// newAPIKeySecret IDを含むAPIキーを生成
+func newAPIKeySecret(id string) (string, error) {
+	random := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return APIKeyPrefix + id + "_" + hex.EncodeToString(random), nil
+}
+
+// hashAPIKey 保存するAPIキーのハッシュ値（十分な長さのランダムな値のため、ソルトや繰り返しは不要）
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// joinAPIKeyScopes 定義済みの範囲をカンマ区切りで連結
+func joinAPIKeyScopes() string {
+	names := make([]string, len(models.APIKeyScopes))
+	for i, scope := range models.APIKeyScopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, ", ")
+}
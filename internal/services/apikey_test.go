@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAPIKeyRepository APIキーのリポジトリのモック
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.APIKey), args.Error(1)
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	var stored *models.APIKey
+	repo.On("Create", mock.AnythingOfType("*models.APIKey")).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.APIKey)
+	}).Return(nil)
+	service := NewAPIKeyService(repo)
+
+	key, secret, err := service.Create(ctx, " ダッシュボード ", models.APIKeyScopeRead)
+	require.NoError(t, err)
+	assert.Equal(t, "ダッシュボード", key.Label)
+	assert.True(t, strings.HasPrefix(secret, APIKeyPrefix+key.ID+"_"))
+	// キー自体は保存しない
+	assert.Equal(t, hashAPIKey(secret), stored.Hash)
+	assert.NotContains(t, stored.Hash, secret)
+	assert.Equal(t, tenant.DefaultID, stored.TenantID)
+
+	repo.On("GetByID", key.ID).Return(stored, nil)
+	authenticated, err := service.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, authenticated.ID)
+
+	for _, invalid := range []string{"", "amk_", secret + "0", strings.TrimPrefix(secret, APIKeyPrefix)} {
+		_, err := service.Authenticate(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, invalid)
+	}
+	repo.On("GetByID", "UNKNOWN").Return(nil, errors.ErrNotFound)
+	_, err = service.Authenticate(ctx, APIKeyPrefix+"UNKNOWN_00")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, _, err = service.Create(ctx, "CI", "superuser")
+	assert.IsType(t, &errors.ValidationError{}, err)
+	_, _, err = service.Create(ctx, "CI", " , ")
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	// 範囲はカンマ区切りでも指定でき、重複を除いた空白区切りで保存する
+	key, _, err = service.Create(ctx, "ダッシュボード", "achievements:read, points:read achievements:read")
+	require.NoError(t, err)
+	assert.Equal(t, models.APIKeyScope("achievements:read points:read"), key.Scope)
+
+	// 操作できるテナントはキーに記録する
+	familyKey, _, err := service.Create(tenant.WithID(ctx, "family-a"), "家族", models.APIKeyScopeRead)
+	require.NoError(t, err)
+	assert.Equal(t, "family-a", familyKey.TenantID)
+}
+
+func TestAPIKeyService_RotateAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(repo).(*APIKeyServiceImpl)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	stored := &models.APIKey{ID: "key-123", Label: "CI", Scope: models.APIKeyScopeWrite, Hash: hashAPIKey("amk_key-123_old")}
+	repo.On("GetByID", "key-123").Return(stored, nil)
+	repo.On("Update", stored).Return(nil)
+
+	rotated, secret, err := service.Rotate(ctx, "key-123")
+	require.NoError(t, err)
+	assert.Equal(t, now, *rotated.RotatedAt)
+	_, err = service.Authenticate(ctx, "amk_key-123_old")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey, "the previous key must stop working after rotation")
+	_, err = service.Authenticate(ctx, secret)
+	assert.NoError(t, err)
+
+	updated, err := service.Update(ctx, "key-123", "", models.APIKeyScopeRead)
+	require.NoError(t, err)
+	assert.Equal(t, "CI", updated.Label)
+	assert.Equal(t, models.APIKeyScopeRead, updated.Scope)
+
+	revoked, err := service.Revoke(ctx, "key-123")
+	require.NoError(t, err)
+	assert.Equal(t, now, *revoked.RevokedAt)
+	_, err = service.Authenticate(ctx, secret)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	// 無効にした日時は最初の1回のみ記録する
+	now = now.Add(time.Hour)
+	revoked, err = service.Revoke(ctx, "key-123")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), *revoked.RevokedAt)
+
+	_, _, err = service.Rotate(ctx, "key-123")
+	assert.IsType(t, &errors.BusinessLogicError{}, err)
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// attachmentExtensions 添付できるファイルの Content-Type とオブジェクトキーの拡張子
+var attachmentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/heic":      ".heic",
+	"application/pdf": ".pdf",
+}
+
+// AttachmentStorage 添付ファイルの署名付きURLを発行するストレージ
+type AttachmentStorage interface {
+	PresignUpload(ctx context.Context, key, contentType string) (*models.AttachmentUpload, error)
+	PresignDownload(ctx context.Context, key string) (string, error)
+}
+
+// AttachmentServiceImpl 添付ファイルのサービスの実装
+type AttachmentServiceImpl struct {
+	storage         AttachmentStorage
+	prefix          string
+	achievementRepo repository.AchievementRepository
+	pointRepo       repository.PointRepository
+}
+
+// NewAttachmentService 添付ファイルのサービスを作成（prefix はオブジェクトキーの接頭辞）
+func NewAttachmentService(storage AttachmentStorage, prefix string, achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository) AttachmentService {
+	return &AttachmentServiceImpl{
+		storage:         storage,
+		prefix:          prefix,
+		achievementRepo: achievementRepo,
+		pointRepo:       pointRepo,
+	}
+}
+
+// CreateUpload 記録に添付するファイルのアップロード先を発行し、オブジェクトキーを記録に保存
+//
+// 添付のたびに新しいオブジェクトキーを採番し、以前の添付ファイルを置き換える。
+func (s *AttachmentServiceImpl) CreateUpload(ctx context.Context, targetType models.AttachmentTargetType, targetID, contentType string) (*models.AttachmentUpload, error) {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	extension, ok := attachmentExtensions[contentType]
+	if !ok {
+		return nil, &errors.ValidationError{Field: "content_type", Message: "content_type must be image/jpeg, image/png, image/gif, image/webp, image/heic or application/pdf"}
+	}
+	if err := tenant.ValidateEntityID("target_id", targetID); err != nil {
+		return nil, err
+	}
+
+	var setAttachment func(ctx context.Context, id, key string) error
+	switch targetType {
+	case models.AttachmentTargetAchievement:
+		if _, err := s.achievementRepo.GetByID(ctx, targetID); err != nil {
+			return nil, err
+		}
+		setAttachment = s.achievementRepo.SetAttachment
+	case models.AttachmentTargetRedemption:
+		if _, err := s.pointRepo.GetRewardHistoryByID(ctx, targetID); err != nil {
+			return nil, err
+		}
+		setAttachment = s.pointRepo.SetRedemptionAttachment
+	default:
+		return nil, &errors.ValidationError{Field: "target_type", Message: "target_type must be achievement or redemption"}
+	}
+
+	key := s.prefix + path.Join(tenant.FromContext(ctx), string(targetType), targetID, ulid.Make().String()+extension)
+	upload, err := s.storage.PresignUpload(ctx, key, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if err := setAttachment(ctx, targetID, key); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// DownloadURL 添付ファイルをダウンロードする署名付きURLを発行
+func (s *AttachmentServiceImpl) DownloadURL(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", &errors.ValidationError{Field: "attachment_key", Message: "attachment_key is required"}
+	}
+	return s.storage.PresignDownload(ctx, key)
+}
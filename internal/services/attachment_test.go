@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAttachmentStorage 添付ファイルの署名付きURLを発行するストレージのモック
+type MockAttachmentStorage struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentStorage) PresignUpload(ctx context.Context, key, contentType string) (*models.AttachmentUpload, error) {
+	args := m.Called(key, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AttachmentUpload), args.Error(1)
+}
+
+func (m *MockAttachmentStorage) PresignDownload(ctx context.Context, key string) (string, error) {
+	args := m.Called(key)
+	return args.String(0), args.Error(1)
+}
+
+func TestAttachmentService_CreateUpload(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("GetByID", "achievement-1").Return(&models.Achievement{ID: "achievement-1"}, nil)
+	achievementRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	achievementRepo.On("SetAttachment", "achievement-1", mock.Anything).Return(nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryByID", "history-1").Return(&models.RewardHistory{ID: "history-1"}, nil)
+	pointRepo.On("SetRedemptionAttachment", "history-1", mock.Anything).Return(nil)
+	var keys []string
+	storage := new(MockAttachmentStorage)
+	storage.On("PresignUpload", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		keys = append(keys, args.String(0))
+	}).Return(&models.AttachmentUpload{URL: "https://receipts.s3.amazonaws.com/upload", Method: "PUT"}, nil)
+	service := NewAttachmentService(storage, "attachments/", achievementRepo, pointRepo)
+	ctx := tenant.WithID(context.Background(), "acme")
+
+	// テナント・記録ごとに新しいオブジェクトキーを採番し、記録に保存する
+	_, err := service.CreateUpload(ctx, models.AttachmentTargetRedemption, "history-1", " Image/JPEG ")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Regexp(t, regexp.MustCompile(`^attachments/acme/redemption/history-1/[0-9A-Z]{26}\.jpg$`), keys[0])
+	storage.AssertCalled(t, "PresignUpload", keys[0], "image/jpeg")
+	pointRepo.AssertCalled(t, "SetRedemptionAttachment", "history-1", keys[0])
+
+	_, err = service.CreateUpload(context.Background(), models.AttachmentTargetAchievement, "achievement-1", "application/pdf")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Regexp(t, regexp.MustCompile(`^attachments/default/achievement/achievement-1/[0-9A-Z]{26}\.pdf$`), keys[1])
+	achievementRepo.AssertCalled(t, "SetAttachment", "achievement-1", keys[1])
+
+	// 存在しない記録にはアップロード先を発行しない
+	_, err = service.CreateUpload(ctx, models.AttachmentTargetAchievement, "missing", "image/png")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	invalid := []struct {
+		targetType  models.AttachmentTargetType
+		targetID    string
+		contentType string
+	}{
+		{models.AttachmentTargetAchievement, "achievement-1", "text/html"},
+		{models.AttachmentTargetAchievement, "", "image/png"},
+		{"reward", "reward-1", "image/png"},
+	}
+	for _, tt := range invalid {
+		_, err := service.CreateUpload(ctx, tt.targetType, tt.targetID, tt.contentType)
+		assert.IsType(t, &errors.ValidationError{}, err)
+	}
+	storage.AssertNumberOfCalls(t, "PresignUpload", 2)
+}
+
+func TestAttachmentService_DownloadURL(t *testing.T) {
+	storage := new(MockAttachmentStorage)
+	storage.On("PresignDownload", "attachments/default/achievement/a/1.jpg").Return("https://bucket.s3.amazonaws.com/attachments/default/achievement/a/1.jpg?X-Amz-Signature=abc", nil)
+	service := NewAttachmentService(storage, "attachments/", new(MockAchievementRepository), new(MockPointRepository))
+
+	url, err := service.DownloadURL(context.Background(), "attachments/default/achievement/a/1.jpg")
+	require.NoError(t, err)
+	assert.Contains(t, url, "X-Amz-Signature")
+
+	_, err = service.DownloadURL(context.Background(), "")
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// BadgeFacts バッジの獲得条件の評価に使用する記録
+type BadgeFacts struct {
+	// Achievements 現在の達成目録（削除した達成目録は含まない）
+	Achievements []*models.Achievement
+	// Redemptions 報酬獲得履歴
+	Redemptions []*models.RewardHistory
+}
+
+// BadgeRule バッジの定義と獲得条件
+type BadgeRule struct {
+	ID          string
+	Name        string
+	Description string
+	// Earned 記録が獲得条件を満たしているか
+	Earned func(facts *BadgeFacts) bool
+}
+
+// DefaultBadgeRules 組み込みのバッジ
+func DefaultBadgeRules() []BadgeRule {
+	return []BadgeRule{
+		{
+			ID:          "first_achievement",
+			Name:        "First Step",
+			Description: "Create your first achievement",
+			Earned:      func(facts *BadgeFacts) bool { return len(facts.Achievements) >= 1 },
+		},
+		{
+			ID:          "ten_achievements_in_week",
+			Name:        "Busy Week",
+			Description: "Create 10 achievements within 7 days",
+			Earned: func(facts *BadgeFacts) bool {
+				return createdWithin(facts.Achievements, 10, 7*24*time.Hour)
+			},
+		},
+		{
+			ID:          "fifty_achievements",
+			Name:        "Achiever",
+			Description: "Create 50 achievements",
+			Earned:      func(facts *BadgeFacts) bool { return len(facts.Achievements) >= 50 },
+		},
+		{
+			ID:          "first_redemption",
+			Name:        "First Reward",
+			Description: "Redeem your first reward",
+			Earned:      func(facts *BadgeFacts) bool { return len(facts.Redemptions) >= 1 },
+		},
+		{
+			ID:          "ten_redemptions",
+			Name:        "Treat Yourself",
+			Description: "Redeem 10 rewards",
+			Earned:      func(facts *BadgeFacts) bool { return len(facts.Redemptions) >= 10 },
+		},
+	}
+}
+
+// BadgeServiceImpl バッジサービスの実装
+type BadgeServiceImpl struct {
+	badgeRepo       repository.BadgeRepository
+	achievementRepo repository.AchievementRepository
+	pointRepo       repository.PointRepository
+	rules           []BadgeRule
+	now             func() time.Time
+}
+
+// NewBadgeService バッジサービスを作成（rules が空の場合は DefaultBadgeRules を使用する）
+func NewBadgeService(badgeRepo repository.BadgeRepository, achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository, rules []BadgeRule) BadgeService {
+	if len(rules) == 0 {
+		rules = DefaultBadgeRules()
+	}
+	return &BadgeServiceImpl{
+		badgeRepo:       badgeRepo,
+		achievementRepo: achievementRepo,
+		pointRepo:       pointRepo,
+		rules:           rules,
+		now:             time.Now,
+	}
+}
+
+// List 獲得したバッジを獲得日時順に取得
+func (s *BadgeServiceImpl) List(ctx context.Context) ([]*models.Badge, error) {
+	return s.badgeRepo.List(ctx)
+}
+
+// Evaluate 未獲得のバッジの獲得条件を評価し、新たに獲得したバッジを返す
+//
+// 達成目録の作成・報酬の獲得の後に呼び出す。同時に評価して先に獲得を記録されたバッジは返さない。
+func (s *BadgeServiceImpl) Evaluate(ctx context.Context) ([]*models.Badge, error) {
+	earned, err := s.badgeRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[string]bool, len(earned))
+	for _, badge := range earned {
+		owned[badge.ID] = true
+	}
+
+	var pending []BadgeRule
+	for _, rule := range s.rules {
+		if !owned[rule.ID] {
+			pending = append(pending, rule)
+		}
+	}
+	awarded := []*models.Badge{}
+	if len(pending) == 0 {
+		return awarded, nil
+	}
+
+	facts, err := s.facts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range pending {
+		if !rule.Earned(facts) {
+			continue
+		}
+		badge := &models.Badge{ID: rule.ID, Name: rule.Name, Description: rule.Description, EarnedAt: s.now()}
+		if err := s.badgeRepo.Award(ctx, badge); err != nil {
+			if stderrors.Is(err, errors.ErrDuplicateResource) {
+				continue
+			}
+			return nil, err
+		}
+		awarded = append(awarded, badge)
+	}
+	return awarded, nil
+}
+
+// facts 獲得条件の評価に使用する記録を取得
+func (s *BadgeServiceImpl) facts(ctx context.Context) (*BadgeFacts, error) {
+	achievements, err := s.achievementRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	redemptions, err := s.pointRepo.GetRewardHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &BadgeFacts{Achievements: achievements, Redemptions: redemptions}, nil
+}
+
+// createdWithin count 件の達成目録が window の期間内に作成されたことがあるか
+func createdWithin(achievements []*models.Achievement, count int, window time.Duration) bool {
+	if len(achievements) < count {
+		return false
+	}
+
+	times := make([]time.Time, len(achievements))
+	for i, achievement := range achievements {
+		times[i] = achievement.CreatedAt
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	for i := count - 1; i < len(times); i++ {
+		if times[i].Sub(times[i-count+1]) < window {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockBadgeRepository バッジリポジトリのモック
+type MockBadgeRepository struct {
+	mock.Mock
+}
+
+func (m *MockBadgeRepository) Award(ctx context.Context, badge *models.Badge) error {
+	args := m.Called(badge)
+	return args.Error(0)
+}
+
+func (m *MockBadgeRepository) List(ctx context.Context) ([]*models.Badge, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Badge), args.Error(1)
+}
+
+// achievementsCreatedAt 指定した日時に作成した達成目録を作成
+func achievementsCreatedAt(times ...time.Time) []*models.Achievement {
+	achievements := make([]*models.Achievement, len(times))
+	for i, t := range times {
+		achievements[i] = &models.Achievement{ID: t.Format(time.RFC3339), Title: "達成目録", Point: 10, CreatedAt: t}
+	}
+	return achievements
+}
+
+func TestCreatedWithin(t *testing.T) {
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	daily := func(days int) []*models.Achievement {
+		times := make([]time.Time, days)
+		for i := range times {
+			times[i] = base.AddDate(0, 0, i)
+		}
+		return achievementsCreatedAt(times...)
+	}
+
+	// 7日間（6日後の同時刻まで）に7件は条件を満たすが、8日間に8件は7日以内に8件にならない
+	assert.True(t, createdWithin(daily(7), 7, 7*24*time.Hour))
+	assert.False(t, createdWithin(daily(8), 8, 7*24*time.Hour))
+	assert.False(t, createdWithin(daily(3), 10, 7*24*time.Hour))
+	// 作成順に並んでいなくても判定できる
+	shuffled := daily(10)
+	shuffled[0], shuffled[9] = shuffled[9], shuffled[0]
+	assert.True(t, createdWithin(shuffled, 3, 3*24*time.Hour))
+}
+
+func TestBadgeService_Evaluate(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	badgeRepo := new(MockBadgeRepository)
+	achievementRepo := new(MockAchievementRepository)
+	pointRepo := new(MockPointRepository)
+
+	badgeRepo.On("List").Return([]*models.Badge{{ID: "first_achievement", Name: "First Step"}}, nil)
+	achievementRepo.On("List").Return(achievementsCreatedAt(now.Add(-time.Hour)), nil)
+	pointRepo.On("GetRewardHistory").Return([]*models.RewardHistory{{ID: "h1", RewardTitle: "コーヒー券", PointCost: 50}}, nil)
+	badgeRepo.On("Award", mock.MatchedBy(func(badge *models.Badge) bool {
+		return badge.ID == "first_redemption" && badge.Name == "First Reward" && badge.EarnedAt.Equal(now)
+	})).Return(nil)
+
+	service := NewBadgeService(badgeRepo, achievementRepo, pointRepo, nil).(*BadgeServiceImpl)
+	service.now = func() time.Time { return now }
+
+	awarded, err := service.Evaluate(context.Background())
+	require.NoError(t, err)
+
+	// 獲得済みの first_achievement は評価せず、条件を満たした first_redemption だけを獲得する
+	require.Len(t, awarded, 1)
+	assert.Equal(t, "first_redemption", awarded[0].ID)
+	badgeRepo.AssertExpectations(t)
+	badgeRepo.AssertNumberOfCalls(t, "Award", 1)
+}
+
+func TestBadgeService_Evaluate_AllEarned(t *testing.T) {
+	badgeRepo := new(MockBadgeRepository)
+	rules := []BadgeRule{{ID: "first", Name: "First", Earned: func(*BadgeFacts) bool { return true }}}
+	badgeRepo.On("List").Return([]*models.Badge{{ID: "first", Name: "First"}}, nil)
+
+	// すべて獲得済みの場合は記録を取得しない
+	awarded, err := NewBadgeService(badgeRepo, new(MockAchievementRepository), new(MockPointRepository), rules).Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, awarded)
+}
+
+func TestBadgeService_Evaluate_AwardedConcurrently(t *testing.T) {
+	badgeRepo := new(MockBadgeRepository)
+	achievementRepo := new(MockAchievementRepository)
+	pointRepo := new(MockPointRepository)
+	rules := []BadgeRule{{ID: "first", Name: "First", Earned: func(*BadgeFacts) bool { return true }}}
+
+	badgeRepo.On("List").Return([]*models.Badge{}, nil)
+	achievementRepo.On("List").Return([]*models.Achievement{}, nil)
+	pointRepo.On("GetRewardHistory").Return([]*models.RewardHistory{}, nil)
+	badgeRepo.On("Award", mock.Anything).Return(errors.ErrDuplicateResource)
+
+	// 同時の評価で先に獲得を記録されたバッジは新たに獲得したバッジとして返さない
+	awarded, err := NewBadgeService(badgeRepo, achievementRepo, pointRepo, rules).Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, awarded)
+}
+
+func TestBadgeService_Evaluate_Error(t *testing.T) {
+	badgeRepo := new(MockBadgeRepository)
+	achievementRepo := new(MockAchievementRepository)
+	badgeRepo.On("List").Return([]*models.Badge{}, nil)
+	achievementRepo.On("List").Return(nil, &errors.DatabaseError{Operation: "List", Table: "achievements"})
+
+	_, err := NewBadgeService(badgeRepo, achievementRepo, new(MockPointRepository), nil).Evaluate(context.Background())
+	assert.IsType(t, &errors.DatabaseError{}, err)
+}
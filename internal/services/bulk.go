@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+const (
+	// DefaultBulkParallelism 一括操作で同時に実行する項目数の既定値
+	DefaultBulkParallelism = 4
+	// DefaultBulkMaxItems 1回の一括操作で受け付ける項目数の上限の既定値
+	DefaultBulkMaxItems = 100
+)
+
+// BulkServiceImpl 達成目録・報酬の作成・削除を並列に一括で実行するサービスの実装
+//
+// 各項目は達成目録・報酬のサービスを通して実行するため、1件ずつ実行した場合と同じ検証と操作履歴への記録を行う。
+type BulkServiceImpl struct {
+	achievementService AchievementService
+	rewardService      RewardService
+	parallelism        int
+	maxItems           int
+}
+
+// NewBulkService 一括操作のサービスを作成（parallelism・maxItems が0以下の場合は既定値）
+func NewBulkService(achievementService AchievementService, rewardService RewardService, parallelism, maxItems int) BulkService {
+	if parallelism <= 0 {
+		parallelism = DefaultBulkParallelism
+	}
+	if maxItems <= 0 {
+		maxItems = DefaultBulkMaxItems
+	}
+	return &BulkServiceImpl{
+		achievementService: achievementService,
+		rewardService:      rewardService,
+		parallelism:        parallelism,
+		maxItems:           maxItems,
+	}
+}
+
+// bulkTask 一括操作の1項目を実行し、対象のIDと成功した場合に取り消す操作を返す（取り消せない場合は nil）
+type bulkTask func(ctx context.Context) (string, func(context.Context) error, error)
+
+// CreateAchievements 達成目録を一括で作成し、ポイントを加算する
+func (s *BulkServiceImpl) CreateAchievements(ctx context.Context, achievements []*models.Achievement, mode models.BulkMode) (*models.BulkResult, error) {
+	tasks := make([]bulkTask, len(achievements))
+	for i, achievement := range achievements {
+		achievement := achievement
+		tasks[i] = func(ctx context.Context) (string, func(context.Context) error, error) {
+			existed := s.achievementExists(ctx, achievement)
+			if err := s.achievementService.Create(ctx, achievement); err != nil {
+				return "", nil, err
+			}
+			// 同じ内容の再送で作成済みの達成目録が返された場合は、取り消しで削除しない
+			if existed {
+				return achievement.ID, nil, nil
+			}
+			return achievement.ID, func(ctx context.Context) error {
+				return s.achievementService.DeleteWithPoints(ctx, achievement.ID)
+			}, nil
+		}
+	}
+	return s.run(ctx, mode, tasks)
+}
+
+// DeleteAchievements 達成目録を一括で削除する（withPoints の場合は付与したポイントも減算する）
+func (s *BulkServiceImpl) DeleteAchievements(ctx context.Context, ids []string, withPoints bool, mode models.BulkMode) (*models.BulkResult, error) {
+	tasks := make([]bulkTask, len(ids))
+	for i, id := range ids {
+		id := id
+		tasks[i] = func(ctx context.Context) (string, func(context.Context) error, error) {
+			// 取り消しで作成し直すため、削除前の達成目録を取得しておく
+			before, err := s.achievementService.GetByID(ctx, id)
+			if err != nil {
+				return id, nil, err
+			}
+			if withPoints {
+				err = s.achievementService.DeleteWithPoints(ctx, id)
+			} else {
+				err = s.achievementService.Delete(ctx, id)
+			}
+			if err != nil {
+				return id, nil, err
+			}
+			return id, func(ctx context.Context) error {
+				return s.achievementService.Restore(ctx, before, withPoints)
+			}, nil
+		}
+	}
+	return s.run(ctx, mode, tasks)
+}
+
+// CreateRewards 報酬を一括で作成する
+func (s *BulkServiceImpl) CreateRewards(ctx context.Context, rewards []*models.Reward, mode models.BulkMode) (*models.BulkResult, error) {
+	tasks := make([]bulkTask, len(rewards))
+	for i, reward := range rewards {
+		reward := reward
+		tasks[i] = func(ctx context.Context) (string, func(context.Context) error, error) {
+			existed := s.rewardExists(ctx, reward)
+			if err := s.rewardService.Create(ctx, reward); err != nil {
+				return "", nil, err
+			}
+			if existed {
+				return reward.ID, nil, nil
+			}
+			return reward.ID, func(ctx context.Context) error {
+				return s.rewardService.Delete(ctx, reward.ID)
+			}, nil
+		}
+	}
+	return s.run(ctx, mode, tasks)
+}
+
+// DeleteRewards 報酬を一括で削除する
+func (s *BulkServiceImpl) DeleteRewards(ctx context.Context, ids []string, mode models.BulkMode) (*models.BulkResult, error) {
+	tasks := make([]bulkTask, len(ids))
+	for i, id := range ids {
+		id := id
+		tasks[i] = func(ctx context.Context) (string, func(context.Context) error, error) {
+			before, err := s.rewardService.GetByID(ctx, id)
+			if err != nil {
+				return id, nil, err
+			}
+			if err := s.rewardService.Delete(ctx, id); err != nil {
+				return id, nil, err
+			}
+			return id, func(ctx context.Context) error {
+				restored := *before
+				restored.Version = 0
+				return s.rewardService.Create(ctx, &restored)
+			}, nil
+		}
+	}
+	return s.run(ctx, mode, tasks)
+}
+
+// achievementExists クライアントが指定したIDの達成目録がすでに存在するか
+func (s *BulkServiceImpl) achievementExists(ctx context.Context, achievement *models.Achievement) bool {
+	if achievement == nil || achievement.ID == "" {
+		return false
+	}
+	_, err := s.achievementService.GetByID(ctx, achievement.ID)
+	return err == nil
+}
+
+// rewardExists クライアントが指定したIDの報酬がすでに存在するか
+func (s *BulkServiceImpl) rewardExists(ctx context.Context, reward *models.Reward) bool {
+	if reward == nil || reward.ID == "" {
+		return false
+	}
+	_, err := s.rewardService.GetByID(ctx, reward.ID)
+	return err == nil
+}
+
+// run 項目を最大 parallelism 件ずつ並列に実行し、項目ごとの結果をまとめる
+//
+// all_or_nothing の場合は、いずれかの項目が失敗した時点でまだ始めていない項目を実行せず、
+// 成功した項目を逆の操作で取り消す。取り消しは呼び出し元のコンテキストが取り消されても最後まで行う。
+func (s *BulkServiceImpl) run(ctx context.Context, mode models.BulkMode, tasks []bulkTask) (*models.BulkResult, error) {
+	if mode == "" {
+		mode = models.BulkModeBestEffort
+	}
+	if mode != models.BulkModeBestEffort && mode != models.BulkModeAllOrNothing {
+		return nil, &errors.ValidationError{Field: "mode", Message: "mode must be best_effort or all_or_nothing"}
+	}
+	if len(tasks) == 0 {
+		return nil, &errors.ValidationError{Field: "items", Message: "at least one item is required"}
+	}
+	if len(tasks) > s.maxItems {
+		return nil, &errors.ValidationError{Field: "items", Message: fmt.Sprintf("at most %d items can be processed at once", s.maxItems)}
+	}
+
+	result := &models.BulkResult{Mode: mode, Items: make([]*models.BulkItemResult, len(tasks))}
+	for i := range tasks {
+		result.Items[i] = &models.BulkItemResult{Index: i, Status: models.BulkItemSkipped}
+	}
+	reverts := make([]func(context.Context) error, len(tasks))
+
+	var (
+		mu      sync.Mutex
+		aborted bool
+	)
+	queue := make(chan int, len(tasks))
+	for i := range tasks {
+		queue <- i
+	}
+	close(queue)
+
+	workers := s.parallelism
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				mu.Lock()
+				skip := aborted
+				mu.Unlock()
+				if skip {
+					continue
+				}
+
+				id, revert, err := tasks[i](ctx)
+
+				mu.Lock()
+				item := result.Items[i]
+				item.ID = id
+				if err != nil {
+					item.Status = models.BulkItemFailed
+					item.Err = err
+					aborted = aborted || mode == models.BulkModeAllOrNothing
+				} else {
+					item.Status = models.BulkItemSucceeded
+					reverts[i] = revert
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if aborted {
+		// 後の項目から順に取り消す
+		rollbackCtx := context.WithoutCancel(ctx)
+		for i := len(result.Items) - 1; i >= 0; i-- {
+			item := result.Items[i]
+			if item.Status != models.BulkItemSucceeded {
+				continue
+			}
+			if reverts[i] == nil {
+				item.Status = models.BulkItemRolledBack
+				continue
+			}
+			if err := reverts[i](rollbackCtx); err != nil && !stderrors.Is(err, errors.ErrNotFound) {
+				// 取り消せなかった項目は成功したまま残る
+				item.Err = fmt.Errorf("failed to roll back: %w", err)
+				continue
+			}
+			item.Status = models.BulkItemRolledBack
+		}
+		result.RolledBack = true
+	}
+
+	for _, item := range result.Items {
+		switch item.Status {
+		case models.BulkItemSucceeded:
+			result.Succeeded++
+		case models.BulkItemFailed:
+			result.Failed++
+		}
+	}
+	return result, nil
+}
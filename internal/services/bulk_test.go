@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAchievementService 達成目録をメモリに保持し、指定したIDの操作を失敗させる達成目録サービス
+type fakeAchievementService struct {
+	AchievementService
+	mu           sync.Mutex
+	achievements map[string]*models.Achievement
+	failing      map[string]error
+	restored     []string
+	running      int
+	maxRunning   int
+}
+
+func newFakeAchievementService(ids ...string) *fakeAchievementService {
+	s := &fakeAchievementService{achievements: map[string]*models.Achievement{}, failing: map[string]error{}}
+	for _, id := range ids {
+		s.achievements[id] = &models.Achievement{ID: id, Title: id, Point: 10}
+	}
+	return s
+}
+
+func (s *fakeAchievementService) GetByID(ctx context.Context, id string) (*models.Achievement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	achievement, ok := s.achievements[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	copied := *achievement
+	return &copied, nil
+}
+
+func (s *fakeAchievementService) Create(ctx context.Context, achievement *models.Achievement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failing[achievement.Title]; err != nil {
+		return err
+	}
+	if achievement.ID == "" {
+		achievement.ID = fmt.Sprintf("created-%d", len(s.achievements))
+	}
+	s.achievements[achievement.ID] = achievement
+	return nil
+}
+
+func (s *fakeAchievementService) Delete(ctx context.Context, id string) error {
+	return s.DeleteWithPoints(ctx, id)
+}
+
+func (s *fakeAchievementService) DeleteWithPoints(ctx context.Context, id string) error {
+	s.mu.Lock()
+	s.running++
+	if s.running > s.maxRunning {
+		s.maxRunning = s.running
+	}
+	s.mu.Unlock()
+
+	// 並列に実行されていることを確認できるよう少し待つ
+	time.Sleep(5 * time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running--
+	if err := s.failing[id]; err != nil {
+		return err
+	}
+	if _, ok := s.achievements[id]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(s.achievements, id)
+	return nil
+}
+
+func (s *fakeAchievementService) Restore(ctx context.Context, achievement *models.Achievement, withPoints bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.achievements[achievement.ID] = achievement
+	s.restored = append(s.restored, achievement.ID)
+	return nil
+}
+
+func TestBulkService_DeleteAchievements_BestEffort(t *testing.T) {
+	achievements := newFakeAchievementService("a1", "a2", "a3", "a4")
+	achievements.failing["a2"] = errors.ErrInsufficientPoints
+	service := NewBulkService(achievements, nil, 2, 0)
+
+	result, err := service.DeleteAchievements(context.Background(), []string{"a1", "a2", "a3", "missing"}, true, models.BulkModeBestEffort)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	assert.False(t, result.RolledBack)
+	assert.Equal(t, models.BulkItemSucceeded, result.Items[0].Status)
+	assert.Equal(t, models.BulkItemFailed, result.Items[1].Status)
+	assert.Equal(t, errors.ErrInsufficientPoints, result.Items[1].Err)
+	assert.Equal(t, models.BulkItemSucceeded, result.Items[2].Status)
+	assert.Equal(t, "missing", result.Items[3].ID)
+	assert.True(t, stderrors.Is(result.Items[3].Err, errors.ErrNotFound))
+
+	// 失敗した項目以外は削除したまま残る
+	_, err = achievements.GetByID(context.Background(), "a1")
+	assert.Equal(t, errors.ErrNotFound, err)
+	_, err = achievements.GetByID(context.Background(), "a2")
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, achievements.maxRunning, 2)
+}
+
+func TestBulkService_DeleteAchievements_AllOrNothing(t *testing.T) {
+	achievements := newFakeAchievementService("a1", "a2", "a3")
+	achievements.failing["a3"] = stderrors.New("boom")
+	// 1件ずつ実行し、a3 が失敗する前に a1 と a2 を削除させる
+	service := NewBulkService(achievements, nil, 1, 0)
+
+	result, err := service.DeleteAchievements(context.Background(), []string{"a1", "a2", "a3"}, false, models.BulkModeAllOrNothing)
+	require.NoError(t, err)
+
+	assert.True(t, result.RolledBack)
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, models.BulkItemRolledBack, result.Items[0].Status)
+	assert.Equal(t, models.BulkItemRolledBack, result.Items[1].Status)
+	assert.Equal(t, models.BulkItemFailed, result.Items[2].Status)
+
+	// 後の項目から順に作成し直す
+	assert.Equal(t, []string{"a2", "a1"}, achievements.restored)
+	for _, id := range []string{"a1", "a2", "a3"} {
+		_, err := achievements.GetByID(context.Background(), id)
+		assert.NoError(t, err, id)
+	}
+}
+
+func TestBulkService_AllOrNothing_SkipsRemainingItems(t *testing.T) {
+	achievements := newFakeAchievementService("a1", "a2", "a3")
+	achievements.failing["a1"] = stderrors.New("boom")
+	service := NewBulkService(achievements, nil, 1, 0)
+
+	result, err := service.DeleteAchievements(context.Background(), []string{"a1", "a2", "a3"}, false, models.BulkModeAllOrNothing)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.BulkItemFailed, result.Items[0].Status)
+	assert.Equal(t, models.BulkItemSkipped, result.Items[1].Status)
+	assert.Equal(t, models.BulkItemSkipped, result.Items[2].Status)
+	assert.Empty(t, achievements.restored)
+	assert.Len(t, achievements.achievements, 3)
+}
+
+func TestBulkService_CreateAchievements_AllOrNothing(t *testing.T) {
+	achievements := newFakeAchievementService("existing")
+	achievements.failing["invalid"] = &errors.ValidationError{Field: "point", Message: "point must be positive"}
+	service := NewBulkService(achievements, nil, 1, 0)
+
+	result, err := service.CreateAchievements(context.Background(), []*models.Achievement{
+		{Title: "new", Point: 10},
+		// 作成済みの達成目録の再送は取り消しで削除しない
+		{ID: "existing", Title: "existing", Point: 10},
+		{Title: "invalid"},
+	}, models.BulkModeAllOrNothing)
+	require.NoError(t, err)
+
+	assert.True(t, result.RolledBack)
+	assert.Equal(t, models.BulkItemRolledBack, result.Items[0].Status)
+	assert.Equal(t, models.BulkItemRolledBack, result.Items[1].Status)
+	assert.IsType(t, &errors.ValidationError{}, result.Items[2].Err)
+	assert.Len(t, achievements.achievements, 1)
+	assert.Contains(t, achievements.achievements, "existing")
+}
+
+func TestBulkService_Validation(t *testing.T) {
+	service := NewBulkService(newFakeAchievementService(), nil, 0, 2)
+	ctx := context.Background()
+
+	_, err := service.DeleteAchievements(ctx, nil, false, models.BulkModeBestEffort)
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, err = service.DeleteAchievements(ctx, []string{"a1", "a2", "a3"}, false, models.BulkModeBestEffort)
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, err = service.DeleteAchievements(ctx, []string{"a1"}, false, "transactional")
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	// 省略した場合は best_effort
+	result, err := service.DeleteAchievements(ctx, []string{"a1"}, false, "")
+	require.NoError(t, err)
+	assert.Equal(t, models.BulkModeBestEffort, result.Mode)
+}
+
+func TestBulkService_DeleteRewards_AllOrNothing(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	service := NewBulkService(nil, NewRewardService(rewardRepo, new(MockPointRepository)), 1, 0)
+	rewardID := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	rewardRepo.On("GetByID", rewardID).Return(&models.Reward{ID: rewardID, Title: "コーヒー", Point: 30, Version: 2}, nil)
+	rewardRepo.On("Delete", rewardID).Return(nil)
+	rewardRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	rewardRepo.On("Create", &models.Reward{ID: rewardID, Title: "コーヒー", Point: 30}).Return(nil)
+
+	result, err := service.DeleteRewards(context.Background(), []string{rewardID, "missing"}, models.BulkModeAllOrNothing)
+	require.NoError(t, err)
+
+	assert.True(t, result.RolledBack)
+	assert.Equal(t, models.BulkItemRolledBack, result.Items[0].Status)
+	assert.Equal(t, models.BulkItemFailed, result.Items[1].Status)
+	rewardRepo.AssertExpectations(t)
+}
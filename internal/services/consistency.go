@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// DriftEventType ポイントの差異を検出した際に通知するイベントの種類
+const DriftEventType = "points.drift_detected"
+
+// driftTable ポイントの差異のイベントのテーブルの識別子
+const driftTable = "drift_events"
+
+// ConsistencyServiceImpl 整合性チェックのサービスの実装
+type ConsistencyServiceImpl struct {
+	pointService PointService
+	driftRepo    repository.DriftRepository
+	notifier     events.Publisher
+	interval     time.Duration
+	threshold    int
+	now          func() time.Time
+}
+
+// NewConsistencyService 整合性チェックのサービスを作成
+//
+// interval ごとに AggregatePoints の差異を確認し、差異の絶対値が threshold を超えた場合に記録して notifier に通知する（nilの場合は記録のみ）。
+func NewConsistencyService(pointService PointService, driftRepo repository.DriftRepository, notifier events.Publisher, interval time.Duration, threshold int) ConsistencyService {
+	return &ConsistencyServiceImpl{
+		pointService: pointService,
+		driftRepo:    driftRepo,
+		notifier:     notifier,
+		interval:     interval,
+		threshold:    threshold,
+		now:          time.Now,
+	}
+}
+
+// Check ポイントを集計し、差異が許容値を超えていれば記録して通知する
+//
+// 集計結果と、記録したポイントの差異（許容値以内の場合はnil）を返す。
+func (s *ConsistencyServiceImpl) Check(ctx context.Context) (*models.PointSummary, *models.DriftEvent, error) {
+	summary, err := s.pointService.AggregatePoints(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	difference := summary.Difference
+	if difference < 0 {
+		difference = -difference
+	}
+	if difference <= s.threshold {
+		return summary, nil, nil
+	}
+
+	event := &models.DriftEvent{
+		TotalPoints:    summary.TotalPoints,
+		CurrentBalance: summary.CurrentBalance,
+		Difference:     summary.Difference,
+		Threshold:      s.threshold,
+		DetectedAt:     s.now(),
+	}
+	if err := s.driftRepo.Record(ctx, event); err != nil {
+		return summary, nil, err
+	}
+	if err := s.notify(ctx, event); err != nil {
+		return summary, event, err
+	}
+	return summary, event, nil
+}
+
+// Drifts これまでに記録したポイントの差異を検出日時順に取得
+func (s *ConsistencyServiceImpl) Drifts(ctx context.Context) ([]*models.DriftEvent, error) {
+	return s.driftRepo.List(ctx)
+}
+
+// NotifyDue at の分が実行間隔の区切りであれば整合性チェックを実行し、検出した差異の件数を返す
+func (s *ConsistencyServiceImpl) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	minute := at.Truncate(time.Minute)
+	if s.interval <= 0 || !minute.Truncate(s.interval).Equal(minute) {
+		return 0, nil
+	}
+
+	_, event, err := s.Check(ctx)
+	if event == nil {
+		return 0, err
+	}
+	return 1, err
+}
+
+// notify ポイントの差異のイベントを通知
+func (s *ConsistencyServiceImpl) notify(ctx context.Context, event *models.DriftEvent) error {
+	if s.notifier == nil {
+		return nil
+	}
+
+	key := tenant.Key(ctx, event.ID)
+	err := s.notifier.Publish(ctx, events.Event{
+		ID:     fmt.Sprintf("%s:%s", DriftEventType, key),
+		Type:   DriftEventType,
+		Table:  driftTable,
+		Action: events.ActionDetected,
+		Key:    map[string]interface{}{"id": key},
+		Item: map[string]interface{}{
+			"total_points":    event.TotalPoints,
+			"current_balance": event.CurrentBalance,
+			"difference":      event.Difference,
+			"threshold":       event.Threshold,
+		},
+		OccurredAt: event.DetectedAt,
+		Source:     events.SourceConsistencyService,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send drift alert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDriftRepository ポイントの差異のリポジトリのモック
+type MockDriftRepository struct {
+	mock.Mock
+}
+
+func (m *MockDriftRepository) Record(ctx context.Context, event *models.DriftEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockDriftRepository) List(ctx context.Context) ([]*models.DriftEvent, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.DriftEvent), args.Error(1)
+}
+
+// newConsistencyTestService 達成目録のポイントの合計が total、現在のポイントが balance の整合性チェックのサービスを作成
+func newConsistencyTestService(total, balance, threshold int, driftRepo *MockDriftRepository, notifier events.Publisher) *ConsistencyServiceImpl {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("ListSummaries").Return([]*models.Achievement{{ID: "a1", Point: total}}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: balance}, nil)
+
+	service := NewConsistencyService(NewPointService(pointRepo, achievementRepo), driftRepo, notifier, time.Hour, threshold).(*ConsistencyServiceImpl)
+	service.now = func() time.Time { return time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC) }
+	return service
+}
+
+func TestConsistencyService_Check_WithinThreshold(t *testing.T) {
+	driftRepo := new(MockDriftRepository)
+	service := newConsistencyTestService(110, 100, 10, driftRepo, nil)
+
+	summary, event, err := service.Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 10, summary.Difference)
+	assert.Nil(t, event)
+	driftRepo.AssertNotCalled(t, "Record", mock.Anything)
+}
+
+func TestConsistencyService_Check_RecordsAndAlertsDrift(t *testing.T) {
+	driftRepo := new(MockDriftRepository)
+	driftRepo.On("Record", mock.AnythingOfType("*models.DriftEvent")).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.DriftEvent).ID = "drift-1"
+	}).Return(nil)
+
+	var published []events.Event
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	// 現在のポイントが達成目録の合計より多い（負の差異も絶対値で判定する）
+	service := newConsistencyTestService(100, 125, 10, driftRepo, notifier)
+
+	_, event, err := service.Check(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, -25, event.Difference)
+	assert.Equal(t, 100, event.TotalPoints)
+	assert.Equal(t, 125, event.CurrentBalance)
+	assert.Equal(t, 10, event.Threshold)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, DriftEventType, published[0].Type)
+	assert.Equal(t, events.ActionDetected, published[0].Action)
+	assert.Equal(t, "points.drift_detected:drift-1", published[0].ID)
+	assert.Equal(t, -25, published[0].Item["difference"])
+}
+
+func TestConsistencyService_Check_AlertFailure(t *testing.T) {
+	driftRepo := new(MockDriftRepository)
+	driftRepo.On("Record", mock.Anything).Return(nil)
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		return stderrors.New("webhook unavailable")
+	})
+
+	service := newConsistencyTestService(150, 100, 0, driftRepo, notifier)
+
+	// 通知に失敗しても差異は記録済み
+	_, event, err := service.Check(context.Background())
+	require.Error(t, err)
+	assert.NotNil(t, event)
+	driftRepo.AssertNumberOfCalls(t, "Record", 1)
+}
+
+func TestConsistencyService_NotifyDue(t *testing.T) {
+	driftRepo := new(MockDriftRepository)
+	driftRepo.On("Record", mock.Anything).Return(nil)
+	service := newConsistencyTestService(150, 100, 0, driftRepo, nil)
+
+	// 1時間ごとの実行では毎時0分のみ確認する
+	detected, err := service.NotifyDue(context.Background(), time.Date(2024, 6, 10, 9, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 0, detected)
+	driftRepo.AssertNotCalled(t, "Record", mock.Anything)
+
+	detected, err = service.NotifyDue(context.Background(), time.Date(2024, 6, 10, 10, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, detected)
+	driftRepo.AssertNumberOfCalls(t, "Record", 1)
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// filterOperators サポートする比較演算子（同じ位置では長いものを優先）
+var filterOperators = []string{"<=", ">=", "!=", "<", ">", "="}
+
+// FilterCondition 単一の絞り込み条件
+type FilterCondition struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// AchievementFilter 達成目録の絞り込み条件（すべての条件をANDで評価）
+type AchievementFilter struct {
+	Conditions []FilterCondition
+	Before     time.Time
+}
+
+// ParseFilterCondition "point<10" 形式の条件式を解析
+func ParseFilterCondition(expr string) (FilterCondition, error) {
+	expr = strings.TrimSpace(expr)
+	// 値に演算子の文字が含まれていても項目名の直後で分割する（"title=a<b" は title = "a<b"）
+	idx, op := findFilterOperator(expr)
+	if idx <= 0 {
+		return FilterCondition{}, &errors.ValidationError{Field: "where", Message: fmt.Sprintf("invalid condition: %s", expr)}
+	}
+
+	condition := FilterCondition{
+		Field:    strings.ToLower(strings.TrimSpace(expr[:idx])),
+		Operator: op,
+		Value:    strings.Trim(strings.TrimSpace(expr[idx+len(op):]), `"'`),
+	}
+
+	switch condition.Field {
+	case "point":
+		if _, err := strconv.Atoi(condition.Value); err != nil {
+			return FilterCondition{}, &errors.ValidationError{Field: "where", Message: fmt.Sprintf("point must be compared with an integer: %s", expr)}
+		}
+	case "title", "description":
+		if op != "=" && op != "!=" {
+			return FilterCondition{}, &errors.ValidationError{Field: "where", Message: fmt.Sprintf("%s only supports = and !=: %s", condition.Field, expr)}
+		}
+	default:
+		return FilterCondition{}, &errors.ValidationError{Field: "where", Message: fmt.Sprintf("unsupported field: %s", condition.Field)}
+	}
+
+	return condition, nil
+}
+
+// findFilterOperator 最初に現れる演算子の位置と演算子（同じ位置では長いものを優先。見つからない場合は -1）
+func findFilterOperator(expr string) (int, string) {
+	for i := 0; i < len(expr); i++ {
+		for _, op := range filterOperators {
+			if strings.HasPrefix(expr[i:], op) {
+				return i, op
+			}
+		}
+	}
+	return -1, ""
+}
+
+// NewAchievementFilter 条件式と作成日時の上限から絞り込み条件を作成
+func NewAchievementFilter(expressions []string, before time.Time) (*AchievementFilter, error) {
+	filter := &AchievementFilter{Before: before}
+	for _, expr := range expressions {
+		condition, err := ParseFilterCondition(expr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+	return filter, nil
+}
+
+// IsEmpty 絞り込み条件が指定されていないかどうか
+func (f *AchievementFilter) IsEmpty() bool {
+	return len(f.Conditions) == 0 && f.Before.IsZero()
+}
+
+// Matches 達成目録が条件に一致するかどうか
+func (f *AchievementFilter) Matches(achievement *models.Achievement) bool {
+	if achievement == nil {
+		return false
+	}
+
+	if !f.Before.IsZero() && !achievement.CreatedAt.Before(f.Before) {
+		return false
+	}
+
+	for _, condition := range f.Conditions {
+		if !condition.matches(achievement) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply 条件に一致する達成目録のみを返す
+func (f *AchievementFilter) Apply(achievements []*models.Achievement) []*models.Achievement {
+	var matched []*models.Achievement
+	for _, achievement := range achievements {
+		if f.Matches(achievement) {
+			matched = append(matched, achievement)
+		}
+	}
+	return matched
+}
+
+// matches 単一条件の評価
+func (c FilterCondition) matches(achievement *models.Achievement) bool {
+	switch c.Field {
+	case "point":
+		value, _ := strconv.Atoi(c.Value)
+		switch c.Operator {
+		case "<":
+			return achievement.Point < value
+		case "<=":
+			return achievement.Point <= value
+		case ">":
+			return achievement.Point > value
+		case ">=":
+			return achievement.Point >= value
+		case "=":
+			return achievement.Point == value
+		case "!=":
+			return achievement.Point != value
+		}
+	case "title":
+		return compareString(achievement.Title, c.Operator, c.Value)
+	case "description":
+		return compareString(achievement.Description, c.Operator, c.Value)
+	}
+	return false
+}
+
+// compareString 文字列の等価比較
+func compareString(actual, operator, expected string) bool {
+	if operator == "!=" {
+		return actual != expected
+	}
+	return actual == expected
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilterCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected FilterCondition
+		wantErr  bool
+	}{
+		{
+			name:     "less than",
+			expr:     "point<10",
+			expected: FilterCondition{Field: "point", Operator: "<", Value: "10"},
+		},
+		{
+			name:     "greater or equal with spaces",
+			expr:     " point >= 5 ",
+			expected: FilterCondition{Field: "point", Operator: ">=", Value: "5"},
+		},
+		{
+			name:     "quoted title",
+			expr:     `title="Daily run"`,
+			expected: FilterCondition{Field: "title", Operator: "=", Value: "Daily run"},
+		},
+		{
+			// 値に含まれる演算子の文字では分割しない
+			name:     "operator character in title value",
+			expr:     "title=a<b",
+			expected: FilterCondition{Field: "title", Operator: "=", Value: "a<b"},
+		},
+		{
+			name:     "not equal with equals sign in value",
+			expr:     "description!=x=y",
+			expected: FilterCondition{Field: "description", Operator: "!=", Value: "x=y"},
+		},
+		{name: "non-integer point", expr: "point<abc", wantErr: true},
+		{name: "missing field", expr: "=10", wantErr: true},
+		{name: "unsupported field", expr: "owner=me", wantErr: true},
+		{name: "unsupported title operator", expr: "title<abc", wantErr: true},
+		{name: "missing operator", expr: "point", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := ParseFilterCondition(tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, condition)
+		})
+	}
+}
+
+func TestAchievementFilter_Apply(t *testing.T) {
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	achievements := []*models.Achievement{
+		{ID: "1", Title: "Old low", Point: 5, CreatedAt: cutoff.AddDate(0, -1, 0)},
+		{ID: "2", Title: "Old high", Point: 50, CreatedAt: cutoff.AddDate(0, -1, 0)},
+		{ID: "3", Title: "New low", Point: 5, CreatedAt: cutoff.AddDate(0, 1, 0)},
+	}
+
+	filter, err := NewAchievementFilter([]string{"point<10"}, cutoff)
+	assert.NoError(t, err)
+	assert.False(t, filter.IsEmpty())
+
+	matched := filter.Apply(achievements)
+	assert.Len(t, matched, 1)
+	assert.Equal(t, "1", matched[0].ID)
+
+	// 作成日時の条件のみ
+	filter, err = NewAchievementFilter(nil, cutoff)
+	assert.NoError(t, err)
+	assert.Len(t, filter.Apply(achievements), 2)
+
+	// 条件なし
+	filter, err = NewAchievementFilter(nil, time.Time{})
+	assert.NoError(t, err)
+	assert.True(t, filter.IsEmpty())
+}
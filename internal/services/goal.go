@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// GoalReachedEventType 目標を達成した際に通知するイベントの種類
+const GoalReachedEventType = "goals.reached"
+
+// goalTable 目標のイベントのテーブルの識別子
+const goalTable = "goals"
+
+// GoalServiceImpl 目標サービスの実装
+type GoalServiceImpl struct {
+	goalRepo        repository.GoalRepository
+	achievementRepo repository.AchievementRepository
+	pointRepo       repository.PointRepository
+	notifier        events.Publisher
+	now             func() time.Time
+}
+
+// NewGoalService 目標サービスを作成（notifier がnilの場合は達成を通知しない）
+func NewGoalService(goalRepo repository.GoalRepository, achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository, notifier events.Publisher) GoalService {
+	return &GoalServiceImpl{
+		goalRepo:        goalRepo,
+		achievementRepo: achievementRepo,
+		pointRepo:       pointRepo,
+		notifier:        notifier,
+		now:             time.Now,
+	}
+}
+
+// goalTotals 目標の進捗の計算に使用する現在の値
+type goalTotals struct {
+	points       int
+	achievements int
+}
+
+// Create 目標を作成（達成の判定は Evaluate で行う）
+func (s *GoalServiceImpl) Create(ctx context.Context, goal *models.Goal) error {
+	if goal == nil {
+		return &errors.ValidationError{Field: "goal", Message: "goal cannot be nil"}
+	}
+	goal.ReachedAt = nil
+	return s.goalRepo.Create(ctx, goal)
+}
+
+// Update 目標を更新
+//
+// 種類・目標値を変更した場合は達成日時を消し、新しい目標値で改めて達成を判定する。
+func (s *GoalServiceImpl) Update(ctx context.Context, id string, goal *models.Goal) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
+	}
+	if goal == nil {
+		return &errors.ValidationError{Field: "goal", Message: "goal cannot be nil"}
+	}
+
+	existing, err := s.goalRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	goal.ID = id
+	goal.CreatedAt = existing.CreatedAt
+	goal.ReachedAt = existing.ReachedAt
+	if goal.Type != existing.Type || goal.Target != existing.Target {
+		goal.ReachedAt = nil
+	}
+	return s.goalRepo.Update(ctx, goal)
+}
+
+// GetByID IDで目標と進捗を取得
+func (s *GoalServiceImpl) GetByID(ctx context.Context, id string) (*models.GoalProgress, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	goal, err := s.goalRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.totals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return progressOf(goal, totals), nil
+}
+
+// List すべての目標と進捗を作成日時順に取得
+func (s *GoalServiceImpl) List(ctx context.Context) ([]*models.GoalProgress, error) {
+	goals, err := s.goalRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	progress := make([]*models.GoalProgress, 0, len(goals))
+	if len(goals) == 0 {
+		return progress, nil
+	}
+
+	totals, err := s.totals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, goal := range goals {
+		progress = append(progress, progressOf(goal, totals))
+	}
+	return progress, nil
+}
+
+// Delete 目標を削除
+func (s *GoalServiceImpl) Delete(ctx context.Context, id string) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
+	}
+	return s.goalRepo.Delete(ctx, id)
+}
+
+// Evaluate 未達成の目標の進捗を確認し、新たに達成した目標の達成を記録して通知する
+//
+// 達成目録の作成・達成・更新と目標の作成・更新の後に呼び出す。同時に評価して先に達成を記録された目標は返さない。
+// 通知に失敗した場合も達成は記録済みのため、新たに達成した目標とともにエラーを返す。
+func (s *GoalServiceImpl) Evaluate(ctx context.Context) ([]*models.GoalProgress, error) {
+	goals, err := s.goalRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*models.Goal
+	for _, goal := range goals {
+		if goal.ReachedAt == nil {
+			pending = append(pending, goal)
+		}
+	}
+	reached := []*models.GoalProgress{}
+	if len(pending) == 0 {
+		return reached, nil
+	}
+
+	totals, err := s.totals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifyErrs []error
+	for _, goal := range pending {
+		progress := progressOf(goal, totals)
+		if progress.Current < goal.Target {
+			continue
+		}
+
+		reachedAt := s.now()
+		if err := s.goalRepo.MarkReached(ctx, goal.ID, reachedAt); err != nil {
+			if stderrors.Is(err, errors.ErrVersionConflict) {
+				continue
+			}
+			return nil, err
+		}
+		goal.ReachedAt = &reachedAt
+		reached = append(reached, progress)
+
+		if err := s.notify(ctx, progress); err != nil {
+			notifyErrs = append(notifyErrs, err)
+		}
+	}
+	return reached, stderrors.Join(notifyErrs...)
+}
+
+// totals 現在のポイントと達成目録の件数を取得
+func (s *GoalServiceImpl) totals(ctx context.Context) (goalTotals, error) {
+	points, err := s.pointRepo.GetCurrentPoints(ctx)
+	if err != nil {
+		return goalTotals{}, err
+	}
+	// 件数は Count（DynamoDBでは概算）ではなく一覧の件数を使う
+	achievements, err := s.achievementRepo.ListSummaries(ctx)
+	if err != nil {
+		return goalTotals{}, err
+	}
+	return goalTotals{points: points.Point, achievements: len(achievements)}, nil
+}
+
+// notify 目標の達成を通知
+func (s *GoalServiceImpl) notify(ctx context.Context, progress *models.GoalProgress) error {
+	if s.notifier == nil {
+		return nil
+	}
+
+	goal := progress.Goal
+	key := tenant.Key(ctx, goal.ID)
+	event := events.Event{
+		ID:     fmt.Sprintf("%s:%s:%d", GoalReachedEventType, key, goal.ReachedAt.UnixNano()),
+		Type:   GoalReachedEventType,
+		Table:  goalTable,
+		Action: events.ActionReached,
+		Key:    map[string]interface{}{"id": key},
+		Item: map[string]interface{}{
+			"id":          goal.ID,
+			"title":       goal.Title,
+			"description": goal.Description,
+			"type":        string(goal.Type),
+			"target":      goal.Target,
+			"current":     progress.Current,
+			"reached_at":  goal.ReachedAt.UTC().Format(time.RFC3339Nano),
+		},
+		OccurredAt: *goal.ReachedAt,
+		Source:     events.SourceGoalService,
+	}
+	if err := s.notifier.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to notify that goal %s was reached: %w", goal.ID, err)
+	}
+	return nil
+}
+
+// progressOf 目標の進捗を計算
+func progressOf(goal *models.Goal, totals goalTotals) *models.GoalProgress {
+	current := totals.points
+	if goal.Type == models.GoalTypeAchievements {
+		current = totals.achievements
+	}
+
+	percent := 100
+	if current < goal.Target {
+		percent = 0
+		if current > 0 {
+			percent = current * 100 / goal.Target
+		}
+	}
+	return &models.GoalProgress{Goal: goal, Current: current, Percent: percent}
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockGoalRepository 目標リポジトリのモック
+type MockGoalRepository struct {
+	mock.Mock
+}
+
+func (m *MockGoalRepository) Create(ctx context.Context, goal *models.Goal) error {
+	args := m.Called(goal)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) Update(ctx context.Context, goal *models.Goal) error {
+	args := m.Called(goal)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) GetByID(ctx context.Context, id string) (*models.Goal, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) List(ctx context.Context) ([]*models.Goal, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) MarkReached(ctx context.Context, id string, reachedAt time.Time) error {
+	args := m.Called(id, reachedAt)
+	return args.Error(0)
+}
+
+func TestProgressOf(t *testing.T) {
+	totals := goalTotals{points: 250, achievements: 12}
+
+	points := progressOf(&models.Goal{Type: models.GoalTypePoints, Target: 500}, totals)
+	assert.Equal(t, 250, points.Current)
+	assert.Equal(t, 50, points.Percent)
+
+	// 目標を超えても100%にとどめる
+	achievements := progressOf(&models.Goal{Type: models.GoalTypeAchievements, Target: 10}, totals)
+	assert.Equal(t, 12, achievements.Current)
+	assert.Equal(t, 100, achievements.Percent)
+
+	// 残高がマイナスの場合は0%
+	negative := progressOf(&models.Goal{Type: models.GoalTypePoints, Target: 500}, goalTotals{points: -20})
+	assert.Equal(t, 0, negative.Percent)
+}
+
+func TestGoalService_Update_ResetsReachedAtWhenTargetChanges(t *testing.T) {
+	reachedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	existing := &models.Goal{ID: "goal-1", Title: "Switch", Type: models.GoalTypePoints, Target: 500, ReachedAt: &reachedAt}
+
+	goalRepo := new(MockGoalRepository)
+	goalRepo.On("GetByID", "goal-1").Return(existing, nil)
+	goalRepo.On("Update", mock.Anything).Return(nil)
+	service := NewGoalService(goalRepo, new(MockAchievementRepository), new(MockPointRepository), nil)
+
+	// タイトルだけの変更では達成日時を保持する
+	renamed := &models.Goal{Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 500}
+	require.NoError(t, service.Update(context.Background(), "goal-1", renamed))
+	assert.Equal(t, &reachedAt, renamed.ReachedAt)
+
+	// 目標値を変更すると改めて達成を判定する
+	raised := &models.Goal{Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 800}
+	require.NoError(t, service.Update(context.Background(), "goal-1", raised))
+	assert.Nil(t, raised.ReachedAt)
+	assert.Equal(t, "goal-1", raised.ID)
+}
+
+func TestGoalService_Evaluate(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-24 * time.Hour)
+
+	goalRepo := new(MockGoalRepository)
+	achievementRepo := new(MockAchievementRepository)
+	pointRepo := new(MockPointRepository)
+	goalRepo.On("List").Return([]*models.Goal{
+		{ID: "reached", Title: "100ポイント", Type: models.GoalTypePoints, Target: 100, ReachedAt: &earlier},
+		{ID: "points", Title: "Switchのゲーム", Type: models.GoalTypePoints, Target: 500},
+		{ID: "achievements", Title: "達成目録10件", Type: models.GoalTypeAchievements, Target: 10},
+	}, nil)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 520}, nil)
+	achievementRepo.On("ListSummaries").Return(achievementsCreatedAt(now, now), nil)
+	goalRepo.On("MarkReached", "points", now).Return(nil)
+
+	var published []events.Event
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+	service := NewGoalService(goalRepo, achievementRepo, pointRepo, notifier).(*GoalServiceImpl)
+	service.now = func() time.Time { return now }
+
+	reached, err := service.Evaluate(context.Background())
+	require.NoError(t, err)
+
+	// 達成済みの目標と未達成の目標は記録・通知しない
+	require.Len(t, reached, 1)
+	assert.Equal(t, "points", reached[0].Goal.ID)
+	assert.Equal(t, 520, reached[0].Current)
+	assert.Equal(t, now, *reached[0].Goal.ReachedAt)
+	goalRepo.AssertNumberOfCalls(t, "MarkReached", 1)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, GoalReachedEventType, published[0].Type)
+	assert.Equal(t, events.ActionReached, published[0].Action)
+	assert.Equal(t, events.SourceGoalService, published[0].Source)
+	assert.Equal(t, "points", published[0].Item["id"])
+	assert.Equal(t, 520, published[0].Item["current"])
+}
+
+func TestGoalService_Evaluate_ReachedConcurrently(t *testing.T) {
+	goalRepo := new(MockGoalRepository)
+	achievementRepo := new(MockAchievementRepository)
+	pointRepo := new(MockPointRepository)
+	goalRepo.On("List").Return([]*models.Goal{{ID: "points", Title: "Switch", Type: models.GoalTypePoints, Target: 500}}, nil)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 500}, nil)
+	achievementRepo.On("ListSummaries").Return([]*models.Achievement{}, nil)
+	goalRepo.On("MarkReached", "points", mock.Anything).Return(errors.ErrVersionConflict)
+
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		t.Error("Goal reached by another request should not be notified again")
+		return nil
+	})
+
+	// 同時の評価で先に達成を記録された目標は返さない
+	reached, err := NewGoalService(goalRepo, achievementRepo, pointRepo, notifier).Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, reached)
+}
+
+func TestGoalService_Evaluate_NotifyError(t *testing.T) {
+	goalRepo := new(MockGoalRepository)
+	achievementRepo := new(MockAchievementRepository)
+	pointRepo := new(MockPointRepository)
+	goalRepo.On("List").Return([]*models.Goal{{ID: "points", Title: "Switch", Type: models.GoalTypePoints, Target: 500}}, nil)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 500}, nil)
+	achievementRepo.On("ListSummaries").Return([]*models.Achievement{}, nil)
+	goalRepo.On("MarkReached", "points", mock.Anything).Return(nil)
+
+	notifyErr := stderrors.New("webhook unavailable")
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		return notifyErr
+	})
+
+	// 通知に失敗しても達成は記録済みのため、達成した目標をエラーとともに返す
+	reached, err := NewGoalService(goalRepo, achievementRepo, pointRepo, notifier).Evaluate(context.Background())
+	assert.ErrorIs(t, err, notifyErr)
+	require.Len(t, reached, 1)
+	assert.Equal(t, "points", reached[0].Goal.ID)
+}
+
+func TestGoalService_Evaluate_AllReached(t *testing.T) {
+	reachedAt := time.Now()
+	goalRepo := new(MockGoalRepository)
+	goalRepo.On("List").Return([]*models.Goal{{ID: "points", Type: models.GoalTypePoints, Target: 500, ReachedAt: &reachedAt}}, nil)
+
+	// すべて達成済みの場合は現在の値を取得しない
+	reached, err := NewGoalService(goalRepo, new(MockAchievementRepository), new(MockPointRepository), nil).Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, reached)
+}
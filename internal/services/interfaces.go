@@ -1,31 +1,189 @@
 package services
 
-import "achievement-management/internal/models"
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/models"
+)
 
 // AchievementService 達成目録サービス
 type AchievementService interface {
-	Create(achievement *models.Achievement) error
-	Update(id string, achievement *models.Achievement) error
-	GetByID(id string) (*models.Achievement, error)
-	List() ([]*models.Achievement, error)
-	Delete(id string) error
+	Create(ctx context.Context, achievement *models.Achievement) error
+	Update(ctx context.Context, id string, achievement *models.Achievement) error
+	UpdateWithoutPoints(ctx context.Context, id string, achievement *models.Achievement) error
+	GetByID(ctx context.Context, id string) (*models.Achievement, error)
+	List(ctx context.Context) ([]*models.Achievement, error)
+	Reorder(ctx context.Context, ids []string) ([]*models.Achievement, error)
+	Count(ctx context.Context) (int, error)
+	Delete(ctx context.Context, id string) error
+	DeleteWithPoints(ctx context.Context, id string) error
+	DeleteMany(ctx context.Context, ids []string) error
+	Restore(ctx context.Context, achievement *models.Achievement, withPoints bool) error
+	Complete(ctx context.Context, id string) (*models.Completion, error)
+	StartTimer(ctx context.Context, id string) (*models.Achievement, error)
+	StopTimer(ctx context.Context, id string) (*models.Completion, error)
+	ListCompletions(ctx context.Context, id string) ([]*models.Completion, error)
+	GetStreak(ctx context.Context, id string) (*models.Streak, error)
+	GetStreaks(ctx context.Context) (*models.StreakSummary, error)
 }
 
 // RewardService 報酬サービス
 type RewardService interface {
-	Create(reward *models.Reward) error
-	Update(id string, reward *models.Reward) error
-	GetByID(id string) (*models.Reward, error)
-	List() ([]*models.Reward, error)
-	Delete(id string) error
-	Redeem(rewardID string) error
+	Create(ctx context.Context, reward *models.Reward) error
+	Update(ctx context.Context, id string, reward *models.Reward) error
+	GetByID(ctx context.Context, id string) (*models.Reward, error)
+	List(ctx context.Context) ([]*models.Reward, error)
+	Count(ctx context.Context) (int, error)
+	Delete(ctx context.Context, id string) error
+	Redeem(ctx context.Context, rewardID string) (*models.RewardHistory, error)
+	Refund(ctx context.Context, historyID string, admin bool) (*models.RewardHistory, error)
 }
 
 // PointService ポイントサービス
 type PointService interface {
-	GetCurrentPoints() (*models.CurrentPoints, error)
-	AddPoints(points int) error
-	SubtractPoints(points int) error
-	AggregatePoints() (*models.PointSummary, error)
-	GetRewardHistory() ([]*models.RewardHistory, error)
-}
\ No newline at end of file
+	GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error)
+	AddPoints(ctx context.Context, points int) error
+	SubtractPoints(ctx context.Context, points int) error
+	AggregatePoints(ctx context.Context) (*models.PointSummary, error)
+	GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error)
+	GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error)
+	CountRewardHistory(ctx context.Context) (int, error)
+	AnnotateRedemption(ctx context.Context, id string, annotation models.RedemptionAnnotation) (*models.RewardHistory, *models.RewardHistory, error)
+	GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error)
+}
+
+// ReportService レポートサービス
+type ReportService interface {
+	GenerateMonthlyReport(ctx context.Context, month time.Time) (*models.MonthlyReport, error)
+}
+
+// BadgeService バッジサービス
+type BadgeService interface {
+	List(ctx context.Context) ([]*models.Badge, error)
+	Evaluate(ctx context.Context) ([]*models.Badge, error)
+}
+
+// GoalService 目標サービス
+type GoalService interface {
+	Create(ctx context.Context, goal *models.Goal) error
+	Update(ctx context.Context, id string, goal *models.Goal) error
+	GetByID(ctx context.Context, id string) (*models.GoalProgress, error)
+	List(ctx context.Context) ([]*models.GoalProgress, error)
+	Delete(ctx context.Context, id string) error
+	Evaluate(ctx context.Context) ([]*models.GoalProgress, error)
+}
+
+// QuestService 順番に達成する達成目録の連なり（クエスト）のサービス
+type QuestService interface {
+	Create(ctx context.Context, quest *models.Quest) error
+	GetByID(ctx context.Context, id string) (*models.QuestProgress, error)
+	List(ctx context.Context) ([]*models.QuestProgress, error)
+	Delete(ctx context.Context, id string) error
+	Evaluate(ctx context.Context) ([]*models.QuestProgress, error)
+}
+
+// WishlistService お気に入り・ほしいものリストサービス
+type WishlistService interface {
+	AddFavorite(ctx context.Context, rewardID string) error
+	RemoveFavorite(ctx context.Context, rewardID string) error
+	ListFavorites(ctx context.Context) ([]*models.Reward, error)
+	Add(ctx context.Context, item *models.WishlistItem) error
+	UpdatePriority(ctx context.Context, rewardID string, priority int) error
+	Remove(ctx context.Context, rewardID string) error
+	List(ctx context.Context) ([]*models.WishlistEntry, error)
+}
+
+// RecommendationService 現在のポイントで獲得できる報酬を提案するサービス
+type RecommendationService interface {
+	Affordable(ctx context.Context) (*models.AffordableRewards, error)
+}
+
+// ReservationService 報酬のためにポイントを取り置くサービス
+type ReservationService interface {
+	Reserve(ctx context.Context, rewardID string, points int) (*models.Reservation, error)
+	Release(ctx context.Context, rewardID string) error
+	List(ctx context.Context) (*models.ReservationSummary, error)
+}
+
+// NoteService 記録に付けるメモのサービス
+type NoteService interface {
+	Add(ctx context.Context, note *models.Note) error
+	List(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error)
+	Delete(ctx context.Context, targetType models.NoteTargetType, targetID, noteID string) error
+}
+
+// AttachmentService 達成目録・報酬獲得履歴の添付ファイルのサービス
+type AttachmentService interface {
+	CreateUpload(ctx context.Context, targetType models.AttachmentTargetType, targetID, contentType string) (*models.AttachmentUpload, error)
+	DownloadURL(ctx context.Context, key string) (string, error)
+}
+
+// ReminderService 未達成の達成目録のリマインドのサービス
+type ReminderService interface {
+	Pending(ctx context.Context) ([]*models.Reminder, error)
+	NotifyDue(ctx context.Context, at time.Time) (int, error)
+}
+
+// AllowanceService 決まった日時に定期的にポイントを付与するお小遣いのルールのサービス
+type AllowanceService interface {
+	Create(ctx context.Context, allowance *models.Allowance) error
+	List(ctx context.Context) ([]*models.Allowance, error)
+	Delete(ctx context.Context, id string) error
+	NotifyDue(ctx context.Context, at time.Time) (int, error)
+}
+
+// SummaryService 期間中のポイントと残高のサマリーのサービス
+type SummaryService interface {
+	Generate(ctx context.Context, period models.SummaryPeriod, to string) (*models.Summary, error)
+	Notify(ctx context.Context, summary *models.Summary) error
+	NotifyDue(ctx context.Context, at time.Time) (int, error)
+}
+
+// StatsService 獲得・使用したポイントの推移の統計のサービス
+type StatsService interface {
+	Trends(ctx context.Context) (*models.TrendStats, error)
+}
+
+// ConsistencyService 達成目録のポイントの合計と現在のポイントの差異を確認する整合性チェックのサービス
+type ConsistencyService interface {
+	Check(ctx context.Context) (*models.PointSummary, *models.DriftEvent, error)
+	Drifts(ctx context.Context) ([]*models.DriftEvent, error)
+	NotifyDue(ctx context.Context, at time.Time) (int, error)
+}
+
+// JournalService 達成目録・報酬の作成・更新・削除を記録し、元に戻す・やり直すサービス
+type JournalService interface {
+	Record(ctx context.Context, operation *models.Operation) error
+	List(ctx context.Context) ([]*models.Operation, error)
+	Undo(ctx context.Context) (*models.Operation, error)
+	Redo(ctx context.Context) (*models.Operation, error)
+}
+
+// BulkService 達成目録・報酬の作成・削除を並列に一括で実行し、項目ごとの結果を返すサービス
+type BulkService interface {
+	CreateAchievements(ctx context.Context, achievements []*models.Achievement, mode models.BulkMode) (*models.BulkResult, error)
+	DeleteAchievements(ctx context.Context, ids []string, withPoints bool, mode models.BulkMode) (*models.BulkResult, error)
+	CreateRewards(ctx context.Context, rewards []*models.Reward, mode models.BulkMode) (*models.BulkResult, error)
+	DeleteRewards(ctx context.Context, ids []string, mode models.BulkMode) (*models.BulkResult, error)
+}
+
+// PromotionService 達成目録・報酬の定義（履歴は含まない）を別の環境へ昇格するサービス
+type PromotionService interface {
+	Promote(ctx context.Context, opts models.PromotionOptions) (*models.PromotionResult, error)
+}
+
+// SuggestionService 難易度とこれまでの達成目録のポイントからポイントを提案するサービス
+type SuggestionService interface {
+	SuggestPoint(ctx context.Context, difficulty models.Difficulty) (*models.PointSuggestion, error)
+}
+
+// APIKeyService /api の認証に使うAPIキーの発行・管理と認証のサービス（キーを返すのは作成・再発行時のみ）
+type APIKeyService interface {
+	Create(ctx context.Context, label string, scope models.APIKeyScope) (*models.APIKey, string, error)
+	List(ctx context.Context) ([]*models.APIKey, error)
+	Update(ctx context.Context, id, label string, scope models.APIKeyScope) (*models.APIKey, error)
+	Rotate(ctx context.Context, id string) (*models.APIKey, string, error)
+	Revoke(ctx context.Context, id string) (*models.APIKey, error)
+	Authenticate(ctx context.Context, secret string) (*models.APIKey, error)
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// DefaultJournalSize 操作履歴に保持する操作の件数の既定値
+const DefaultJournalSize = 20
+
+// JournalServiceImpl 達成目録・報酬の作成・更新・削除を記録し、元に戻す・やり直すサービスの実装
+type JournalServiceImpl struct {
+	operationRepo   repository.OperationRepository
+	achievementRepo repository.AchievementRepository
+	rewardRepo      repository.RewardRepository
+	size            int
+	now             func() time.Time
+}
+
+// NewJournalService 操作履歴のサービスを作成（size は保持する操作の件数。0以下の場合は DefaultJournalSize）
+func NewJournalService(operationRepo repository.OperationRepository, achievementRepo repository.AchievementRepository, rewardRepo repository.RewardRepository, size int) JournalService {
+	if size <= 0 {
+		size = DefaultJournalSize
+	}
+	return &JournalServiceImpl{
+		operationRepo:   operationRepo,
+		achievementRepo: achievementRepo,
+		rewardRepo:      rewardRepo,
+		size:            size,
+		now:             time.Now,
+	}
+}
+
+// Record 操作を記録し、やり直せる（元に戻した）操作と保持する件数を超えた古い操作を削除する
+func (s *JournalServiceImpl) Record(ctx context.Context, operation *models.Operation) error {
+	if operation.CreatedAt.IsZero() {
+		operation.CreatedAt = s.now()
+	}
+	if err := s.operationRepo.Record(ctx, operation); err != nil {
+		return err
+	}
+
+	operations, err := s.operationRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	kept := 0
+	for i := len(operations) - 1; i >= 0; i-- {
+		existing := operations[i]
+		if existing.ID == operation.ID {
+			kept++
+			continue
+		}
+		// 新しい操作を記録した後は、元に戻した操作をやり直せない
+		if existing.UndoneAt == nil && kept < s.size {
+			kept++
+			continue
+		}
+		if err := s.operationRepo.Delete(ctx, existing.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List 操作履歴を記録した順に取得
+func (s *JournalServiceImpl) List(ctx context.Context) ([]*models.Operation, error) {
+	return s.operationRepo.List(ctx)
+}
+
+// Undo 最後に記録した（まだ元に戻していない）操作を、逆の操作を適用して元に戻す
+//
+// 操作の後に対象が変更・削除されている場合は errors.ErrVersionConflict を返し、元に戻さない。
+func (s *JournalServiceImpl) Undo(ctx context.Context) (*models.Operation, error) {
+	operations, err := s.operationRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var operation *models.Operation
+	for i := len(operations) - 1; i >= 0; i-- {
+		if operations[i].UndoneAt == nil {
+			operation = operations[i]
+			break
+		}
+	}
+	if operation == nil {
+		return nil, &errors.BusinessLogicError{Operation: "Undo", Reason: "nothing to undo", Code: errors.CodeNothingToUndo}
+	}
+
+	if err := s.apply(ctx, "Undo", operation, operation.After, operation.Before); err != nil {
+		return nil, err
+	}
+
+	undoneAt := s.now()
+	if err := s.operationRepo.SetUndone(ctx, operation.ID, &undoneAt); err != nil {
+		return nil, err
+	}
+	operation.UndoneAt = &undoneAt
+	return operation, nil
+}
+
+// Redo 最後に元に戻した操作をやり直す（元に戻した後に別の操作を記録した場合はやり直せない）
+func (s *JournalServiceImpl) Redo(ctx context.Context) (*models.Operation, error) {
+	operations, err := s.operationRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 元に戻した操作は新しいものから順に元に戻しているため、最も古いものが最後に元に戻した操作
+	var operation *models.Operation
+	for i := len(operations) - 1; i >= 0 && operations[i].UndoneAt != nil; i-- {
+		operation = operations[i]
+	}
+	if operation == nil {
+		return nil, &errors.BusinessLogicError{Operation: "Redo", Reason: "nothing to redo", Code: errors.CodeNothingToRedo}
+	}
+
+	if err := s.apply(ctx, "Redo", operation, operation.Before, operation.After); err != nil {
+		return nil, err
+	}
+
+	if err := s.operationRepo.SetUndone(ctx, operation.ID, nil); err != nil {
+		return nil, err
+	}
+	operation.UndoneAt = nil
+	return operation, nil
+}
+
+// apply 対象を from の状態から to の状態に戻す（空の状態は対象が存在しないことを表す）
+func (s *JournalServiceImpl) apply(ctx context.Context, name string, operation *models.Operation, from, to string) error {
+	var err error
+	switch operation.Entity {
+	case models.OperationEntityAchievement:
+		err = s.applyAchievement(ctx, operation, from, to)
+	case models.OperationEntityReward:
+		err = s.applyReward(ctx, operation, from, to)
+	default:
+		return fmt.Errorf("unsupported operation entity: %s", operation.Entity)
+	}
+
+	// 元に戻す達成目録のポイントをすでに報酬獲得に使っている場合
+	if err == errors.ErrInsufficientPoints {
+		return &errors.BusinessLogicError{Operation: name, Reason: "insufficient points", Code: errors.CodeInsufficientPoints}
+	}
+	return err
+}
+
+// applyAchievement 達成目録を from の状態から to の状態に戻す
+func (s *JournalServiceImpl) applyAchievement(ctx context.Context, operation *models.Operation, from, to string) error {
+	var expected, target *models.Achievement
+	if err := decodeState(from, &expected); err != nil {
+		return err
+	}
+	if err := decodeState(to, &target); err != nil {
+		return err
+	}
+
+	// 削除した達成目録を同じIDで作成し直す
+	if expected == nil {
+		target.Version = 0
+		var err error
+		if operation.WithPoints {
+			err = s.achievementRepo.CreateWithPoints(ctx, target)
+		} else {
+			err = s.achievementRepo.Create(ctx, target)
+		}
+		if stderrors.Is(err, errors.ErrDuplicateResource) {
+			return changedSince(operation)
+		}
+		return err
+	}
+
+	current, err := s.achievementRepo.GetByID(ctx, operation.EntityID)
+	if stderrors.Is(err, errors.ErrNotFound) {
+		return changedSince(operation)
+	}
+	if err != nil {
+		return err
+	}
+	if !sameAchievement(current, expected) {
+		return changedSince(operation)
+	}
+
+	if target == nil {
+		if operation.WithPoints {
+			return s.achievementRepo.DeleteWithPoints(ctx, operation.EntityID)
+		}
+		return s.achievementRepo.Delete(ctx, operation.EntityID)
+	}
+
+	// 確認した時点のバージョンから更新する（確認後に更新された場合は errors.ErrVersionConflict）
+	target.Version = current.Version
+	if operation.WithPoints {
+		return s.achievementRepo.UpdateWithPoints(ctx, target)
+	}
+	return s.achievementRepo.Update(ctx, target)
+}
+
+// applyReward 報酬を from の状態から to の状態に戻す
+func (s *JournalServiceImpl) applyReward(ctx context.Context, operation *models.Operation, from, to string) error {
+	var expected, target *models.Reward
+	if err := decodeState(from, &expected); err != nil {
+		return err
+	}
+	if err := decodeState(to, &target); err != nil {
+		return err
+	}
+
+	// 削除した報酬を同じIDで作成し直す
+	if expected == nil {
+		target.Version = 0
+		err := s.rewardRepo.Create(ctx, target)
+		if stderrors.Is(err, errors.ErrDuplicateResource) {
+			return changedSince(operation)
+		}
+		return err
+	}
+
+	current, err := s.rewardRepo.GetByID(ctx, operation.EntityID)
+	if stderrors.Is(err, errors.ErrNotFound) {
+		return changedSince(operation)
+	}
+	if err != nil {
+		return err
+	}
+	if !sameReward(current, expected) {
+		return changedSince(operation)
+	}
+
+	if target == nil {
+		return s.rewardRepo.Delete(ctx, operation.EntityID)
+	}
+
+	target.Version = current.Version
+	return s.rewardRepo.Update(ctx, target)
+}
+
+// sameAchievement 達成目録の内容が同じか（バージョン・添付ファイル・タイマーは比較しない）
+func sameAchievement(a, b *models.Achievement) bool {
+	return a.Title == b.Title && a.Description == b.Description && a.Point == b.Point && a.Category == b.Category &&
+		a.DueDate == b.DueDate && a.Reminder == b.Reminder && a.Difficulty == b.Difficulty && a.MaxPerDay == b.MaxPerDay && a.MaxTotal == b.MaxTotal &&
+		a.Pinned == b.Pinned && a.SortOrder == b.SortOrder && a.PointsPerHour == b.PointsPerHour
+}
+
+// sameReward 報酬の内容が同じか（バージョンは比較しない）
+func sameReward(a, b *models.Reward) bool {
+	if a.Title != b.Title || a.Description != b.Description || a.Point != b.Point || len(a.Prizes) != len(b.Prizes) {
+		return false
+	}
+	for i := range a.Prizes {
+		if a.Prizes[i] != b.Prizes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// changedSince 操作の後に対象が変更・削除されたため元に戻せない（やり直せない）エラー
+func changedSince(operation *models.Operation) error {
+	return fmt.Errorf("%s %s has changed since the operation was recorded: %w", operation.Entity, operation.EntityID, errors.ErrVersionConflict)
+}
+
+// encodeState 操作履歴に保存する対象の状態をJSONに変換
+func encodeState(state interface{}) (string, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode operation state: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeState 操作履歴に保存した対象の状態を読み込む（空の場合は target を変更しない）
+func decodeState(state string, target interface{}) error {
+	if state == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(state), target); err != nil {
+		return fmt.Errorf("failed to decode operation state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockOperationRepository 操作履歴リポジトリのモック
+type MockOperationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOperationRepository) Record(ctx context.Context, operation *models.Operation) error {
+	args := m.Called(operation)
+	return args.Error(0)
+}
+
+func (m *MockOperationRepository) List(ctx context.Context) ([]*models.Operation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Operation), args.Error(1)
+}
+
+func (m *MockOperationRepository) SetUndone(ctx context.Context, id string, undoneAt *time.Time) error {
+	args := m.Called(id, undoneAt)
+	return args.Error(0)
+}
+
+func (m *MockOperationRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// recordingJournal 記録した操作を保持する操作履歴のスタブ
+type recordingJournal struct {
+	JournalService
+	recorded []*models.Operation
+}
+
+func (j *recordingJournal) Record(ctx context.Context, operation *models.Operation) error {
+	j.recorded = append(j.recorded, operation)
+	return nil
+}
+
+func newTestJournalService(operationRepo *MockOperationRepository, achievementRepo *MockAchievementRepository, rewardRepo *MockRewardRepository, size int) *JournalServiceImpl {
+	service := NewJournalService(operationRepo, achievementRepo, rewardRepo, size).(*JournalServiceImpl)
+	service.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return service
+}
+
+func mustEncodeState(t *testing.T, state interface{}) string {
+	t.Helper()
+	encoded, err := encodeState(state)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestJournalService_Record_PrunesRedoableAndOldest(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	service := newTestJournalService(operationRepo, new(MockAchievementRepository), new(MockRewardRepository), 2)
+
+	undoneAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	operation := &models.Operation{ID: "op-4", Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-4"}
+	operationRepo.On("Record", operation).Return(nil)
+	operationRepo.On("List").Return([]*models.Operation{
+		{ID: "op-1"},
+		{ID: "op-2", UndoneAt: &undoneAt},
+		{ID: "op-3"},
+		operation,
+	}, nil)
+	// 元に戻した操作はやり直せなくなり、保持する件数を超えた古い操作は削除する
+	operationRepo.On("Delete", "op-2").Return(nil)
+	operationRepo.On("Delete", "op-1").Return(nil)
+
+	require.NoError(t, service.Record(context.Background(), operation))
+	assert.False(t, operation.CreatedAt.IsZero())
+	operationRepo.AssertExpectations(t)
+	operationRepo.AssertNotCalled(t, "Delete", "op-3")
+}
+
+func TestJournalService_Undo_AchievementCreate(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	achievementRepo := new(MockAchievementRepository)
+	service := newTestJournalService(operationRepo, achievementRepo, new(MockRewardRepository), 0)
+
+	created := &models.Achievement{ID: "achievement-1", Title: "初回ログイン", Point: 10, Version: 1}
+	operation := &models.Operation{ID: "op-1", Entity: models.OperationEntityAchievement, Action: models.OperationActionCreate, EntityID: "achievement-1", WithPoints: true, After: mustEncodeState(t, created)}
+	operationRepo.On("List").Return([]*models.Operation{operation}, nil)
+	achievementRepo.On("GetByID", "achievement-1").Return(created, nil)
+	achievementRepo.On("DeleteWithPoints", "achievement-1").Return(nil)
+	operationRepo.On("SetUndone", "op-1", mock.AnythingOfType("*time.Time")).Return(nil)
+
+	undone, err := service.Undo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", undone.ID)
+	require.NotNil(t, undone.UndoneAt)
+	achievementRepo.AssertExpectations(t)
+	operationRepo.AssertExpectations(t)
+}
+
+func TestJournalService_Undo_RewardDelete(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	rewardRepo := new(MockRewardRepository)
+	service := newTestJournalService(operationRepo, new(MockAchievementRepository), rewardRepo, 0)
+
+	deleted := &models.Reward{ID: "reward-1", Title: "コーヒー", Point: 30, Version: 3}
+	undoneAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	operationRepo.On("List").Return([]*models.Operation{
+		{ID: "op-1", Entity: models.OperationEntityReward, Action: models.OperationActionDelete, EntityID: "reward-1", Before: mustEncodeState(t, deleted)},
+		{ID: "op-2", Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-2", UndoneAt: &undoneAt},
+	}, nil)
+	// 削除した報酬を同じIDで作成し直す
+	rewardRepo.On("Create", mock.MatchedBy(func(reward *models.Reward) bool {
+		return reward.ID == "reward-1" && reward.Title == "コーヒー" && reward.Version == 0
+	})).Return(nil)
+	operationRepo.On("SetUndone", "op-1", mock.AnythingOfType("*time.Time")).Return(nil)
+
+	undone, err := service.Undo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", undone.ID)
+	rewardRepo.AssertExpectations(t)
+	operationRepo.AssertExpectations(t)
+}
+
+func TestJournalService_Undo_ChangedSince(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	achievementRepo := new(MockAchievementRepository)
+	service := newTestJournalService(operationRepo, achievementRepo, new(MockRewardRepository), 0)
+
+	before := &models.Achievement{ID: "achievement-1", Title: "初回ログイン", Point: 10}
+	after := &models.Achievement{ID: "achievement-1", Title: "初回ログイン", Point: 20}
+	operationRepo.On("List").Return([]*models.Operation{
+		{ID: "op-1", Entity: models.OperationEntityAchievement, Action: models.OperationActionUpdate, EntityID: "achievement-1", Before: mustEncodeState(t, before), After: mustEncodeState(t, after)},
+	}, nil)
+	// 操作の後に別の更新があった
+	achievementRepo.On("GetByID", "achievement-1").Return(&models.Achievement{ID: "achievement-1", Title: "初回ログイン", Point: 30, Version: 3}, nil)
+
+	_, err := service.Undo(context.Background())
+	assert.True(t, stderrors.Is(err, errors.ErrVersionConflict), "expected ErrVersionConflict, got %v", err)
+	achievementRepo.AssertNotCalled(t, "Update", mock.Anything)
+	operationRepo.AssertNotCalled(t, "SetUndone", mock.Anything, mock.Anything)
+}
+
+func TestJournalService_Undo_InsufficientPoints(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	achievementRepo := new(MockAchievementRepository)
+	service := newTestJournalService(operationRepo, achievementRepo, new(MockRewardRepository), 0)
+
+	created := &models.Achievement{ID: "achievement-1", Title: "初回ログイン", Point: 10}
+	operationRepo.On("List").Return([]*models.Operation{
+		{ID: "op-1", Entity: models.OperationEntityAchievement, Action: models.OperationActionCreate, EntityID: "achievement-1", WithPoints: true, After: mustEncodeState(t, created)},
+	}, nil)
+	achievementRepo.On("GetByID", "achievement-1").Return(created, nil)
+	achievementRepo.On("DeleteWithPoints", "achievement-1").Return(errors.ErrInsufficientPoints)
+
+	_, err := service.Undo(context.Background())
+	var businessErr *errors.BusinessLogicError
+	require.True(t, stderrors.As(err, &businessErr), "expected BusinessLogicError, got %v", err)
+	assert.Equal(t, "Undo", businessErr.Operation)
+}
+
+func TestJournalService_Undo_NothingToUndo(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	service := newTestJournalService(operationRepo, new(MockAchievementRepository), new(MockRewardRepository), 0)
+
+	undoneAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	operationRepo.On("List").Return([]*models.Operation{{ID: "op-1", UndoneAt: &undoneAt}}, nil)
+
+	_, err := service.Undo(context.Background())
+	assert.IsType(t, &errors.BusinessLogicError{}, err)
+}
+
+func TestJournalService_Redo(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	rewardRepo := new(MockRewardRepository)
+	service := newTestJournalService(operationRepo, new(MockAchievementRepository), rewardRepo, 0)
+
+	before := &models.Reward{ID: "reward-1", Title: "コーヒー", Point: 30}
+	after := &models.Reward{ID: "reward-1", Title: "コーヒー", Point: 50}
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	operationRepo.On("List").Return([]*models.Operation{
+		{ID: "op-1", Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-0"},
+		{ID: "op-2", Entity: models.OperationEntityReward, Action: models.OperationActionUpdate, EntityID: "reward-1", Before: mustEncodeState(t, before), After: mustEncodeState(t, after), UndoneAt: &second},
+		{ID: "op-3", Entity: models.OperationEntityReward, Action: models.OperationActionCreate, EntityID: "reward-3", UndoneAt: &first},
+	}, nil)
+	// 最後に元に戻した op-2 をやり直し、確認した時点のバージョンから更新する
+	rewardRepo.On("GetByID", "reward-1").Return(&models.Reward{ID: "reward-1", Title: "コーヒー", Point: 30, Version: 4}, nil)
+	rewardRepo.On("Update", mock.MatchedBy(func(reward *models.Reward) bool {
+		return reward.Point == 50 && reward.Version == 4
+	})).Return(nil)
+	operationRepo.On("SetUndone", "op-2", (*time.Time)(nil)).Return(nil)
+
+	redone, err := service.Redo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "op-2", redone.ID)
+	assert.Nil(t, redone.UndoneAt)
+	rewardRepo.AssertExpectations(t)
+	operationRepo.AssertExpectations(t)
+}
+
+func TestJournalService_Redo_NothingToRedo(t *testing.T) {
+	operationRepo := new(MockOperationRepository)
+	service := newTestJournalService(operationRepo, new(MockAchievementRepository), new(MockRewardRepository), 0)
+
+	operationRepo.On("List").Return([]*models.Operation{{ID: "op-1"}}, nil)
+
+	_, err := service.Redo(context.Background())
+	assert.IsType(t, &errors.BusinessLogicError{}, err)
+}
+
+func TestJournaledRewardService_RecordsOperations(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	journal := &recordingJournal{}
+	service := NewJournaledRewardService(NewRewardService(rewardRepo, new(MockPointRepository)), journal)
+	ctx := context.Background()
+	rewardID := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	existing := &models.Reward{ID: rewardID, Title: "コーヒー", Point: 30, Version: 1}
+	rewardRepo.On("Create", mock.AnythingOfType("*models.Reward")).Return(nil)
+	rewardRepo.On("GetByID", rewardID).Return(existing, nil)
+	rewardRepo.On("Update", mock.AnythingOfType("*models.Reward")).Return(nil)
+	rewardRepo.On("Delete", rewardID).Return(nil)
+
+	require.NoError(t, service.Create(ctx, &models.Reward{Title: "コーヒー", Point: 30}))
+	require.NoError(t, service.Update(ctx, rewardID, &models.Reward{ID: rewardID, Title: "コーヒー", Point: 50, Version: 1}))
+	require.NoError(t, service.Delete(ctx, rewardID))
+
+	require.Len(t, journal.recorded, 3)
+	assert.Equal(t, models.OperationActionCreate, journal.recorded[0].Action)
+	assert.Empty(t, journal.recorded[0].Before)
+	assert.Equal(t, models.OperationActionUpdate, journal.recorded[1].Action)
+	assert.Contains(t, journal.recorded[1].Before, `"point":30`)
+	assert.Contains(t, journal.recorded[1].After, `"point":50`)
+	assert.Equal(t, models.OperationActionDelete, journal.recorded[2].Action)
+	assert.Empty(t, journal.recorded[2].After)
+}
+
+func TestJournaledAchievementService_Reorder(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	journal := &recordingJournal{}
+	service := NewJournaledAchievementService(NewAchievementService(achievementRepo, new(MockPointRepository)), journal)
+
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "a1", Title: "読書", Point: 10},
+		{ID: "a2", Title: "ランニング", Point: 20, SortOrder: 2},
+	}, nil)
+	achievementRepo.On("GetByID", "a1").Return(&models.Achievement{ID: "a1", Title: "読書", Point: 10}, nil)
+	achievementRepo.On("Update", mock.AnythingOfType("*models.Achievement")).Return(nil)
+
+	_, err := service.(*JournaledAchievementService).Reorder(context.Background(), []string{"a1", "a2"})
+	require.NoError(t, err)
+
+	// 並び順を変えた達成目録ごとに、1件ずつ元に戻せる更新として記録する
+	achievementRepo.AssertNumberOfCalls(t, "Update", 1)
+	require.Len(t, journal.recorded, 1)
+	assert.Equal(t, models.OperationActionUpdate, journal.recorded[0].Action)
+	assert.Equal(t, "a1", journal.recorded[0].EntityID)
+	assert.False(t, journal.recorded[0].WithPoints)
+}
+
+func TestJournaledAchievementService_DoesNotRecordFailedOperations(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	journal := &recordingJournal{}
+	service := NewJournaledAchievementService(NewAchievementService(achievementRepo, new(MockPointRepository)), journal)
+
+	achievementRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+
+	assert.Equal(t, errors.ErrNotFound, service.Delete(context.Background(), "missing"))
+	assert.Empty(t, journal.recorded)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// JournaledAchievementService 作成・更新・削除を操作履歴に記録する達成目録サービス
+type JournaledAchievementService struct {
+	AchievementService
+	journal JournalService
+}
+
+// NewJournaledAchievementService 達成目録サービスに操作履歴への記録を追加
+func NewJournaledAchievementService(next AchievementService, journal JournalService) AchievementService {
+	return &JournaledAchievementService{AchievementService: next, journal: journal}
+}
+
+// Create 達成目録を作成し、操作履歴に記録
+func (s *JournaledAchievementService) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := s.AchievementService.Create(ctx, achievement); err != nil {
+		return err
+	}
+	return recordOperation(ctx, s.journal, models.OperationEntityAchievement, models.OperationActionCreate, achievement.ID, true, nil, achievement)
+}
+
+// Update 達成目録を更新し、更新前後の内容を操作履歴に記録
+func (s *JournaledAchievementService) Update(ctx context.Context, id string, achievement *models.Achievement) error {
+	return s.update(ctx, id, achievement, true, s.AchievementService.Update)
+}
+
+// UpdateWithoutPoints 現在のポイントを変えずに達成目録を更新し、更新前後の内容を操作履歴に記録
+func (s *JournaledAchievementService) UpdateWithoutPoints(ctx context.Context, id string, achievement *models.Achievement) error {
+	return s.update(ctx, id, achievement, false, s.AchievementService.UpdateWithoutPoints)
+}
+
+// update 更新前の達成目録を取得してから更新し、操作履歴に記録
+func (s *JournaledAchievementService) update(ctx context.Context, id string, achievement *models.Achievement, withPoints bool, update func(context.Context, string, *models.Achievement) error) error {
+	before, err := s.AchievementService.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := update(ctx, id, achievement); err != nil {
+		return err
+	}
+	return recordOperation(ctx, s.journal, models.OperationEntityAchievement, models.OperationActionUpdate, id, withPoints, before, achievement)
+}
+
+// Delete 達成目録を削除し、削除前の内容を操作履歴に記録
+func (s *JournaledAchievementService) Delete(ctx context.Context, id string) error {
+	return s.delete(ctx, id, false, s.AchievementService.Delete)
+}
+
+// DeleteWithPoints 達成目録を削除してポイントを減算し、削除前の内容を操作履歴に記録
+func (s *JournaledAchievementService) DeleteWithPoints(ctx context.Context, id string) error {
+	return s.delete(ctx, id, true, s.AchievementService.DeleteWithPoints)
+}
+
+// delete 削除前の達成目録を取得してから削除し、操作履歴に記録
+func (s *JournaledAchievementService) delete(ctx context.Context, id string, withPoints bool, remove func(context.Context, string) error) error {
+	before, err := s.AchievementService.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := remove(ctx, id); err != nil {
+		return err
+	}
+	return recordOperation(ctx, s.journal, models.OperationEntityAchievement, models.OperationActionDelete, id, withPoints, before, nil)
+}
+
+// DeleteMany 複数の達成目録をまとめて削除し、達成目録ごとに削除前の内容を操作履歴に記録（元に戻す場合は1件ずつ戻す）
+func (s *JournaledAchievementService) DeleteMany(ctx context.Context, ids []string) error {
+	var deleted []*models.Achievement
+	for _, id := range ids {
+		before, err := s.AchievementService.GetByID(ctx, id)
+		if stderrors.Is(err, errors.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		deleted = append(deleted, before)
+	}
+
+	if err := s.AchievementService.DeleteMany(ctx, ids); err != nil {
+		return err
+	}
+	for _, before := range deleted {
+		if err := recordOperation(ctx, s.journal, models.OperationEntityAchievement, models.OperationActionDelete, before.ID, false, before, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore 削除した達成目録を作成し直し、作成として操作履歴に記録
+func (s *JournaledAchievementService) Restore(ctx context.Context, achievement *models.Achievement, withPoints bool) error {
+	if err := s.AchievementService.Restore(ctx, achievement, withPoints); err != nil {
+		return err
+	}
+	return recordOperation(ctx, s.journal, models.OperationEntityAchievement, models.OperationActionCreate, achievement.ID, withPoints, nil, achievement)
+}
+
+// Reorder 達成目録を並び替え、並び順を変えた達成目録ごとに更新として操作履歴に記録
+func (s *JournaledAchievementService) Reorder(ctx context.Context, ids []string) ([]*models.Achievement, error) {
+	return reorderAchievements(ctx, s, ids)
+}
+
+// JournaledRewardService 作成・更新・削除を操作履歴に記録する報酬サービス
+type JournaledRewardService struct {
+	RewardService
+	journal JournalService
+}
+
+// NewJournaledRewardService 報酬サービスに操作履歴への記録を追加
+func NewJournaledRewardService(next RewardService, journal JournalService) RewardService {
+	return &JournaledRewardService{RewardService: next, journal: journal}
+}
+
+// Create 報酬を作成し、操作履歴に記録
+func (s *JournaledRewardService) Create(ctx context.Context, reward *models.Reward) error {
+	if err := s.RewardService.Create(ctx, reward); err != nil {
+		return err
+	}
+	return recordOperation(ctx, s.journal, models.OperationEntityReward, models.OperationActionCreate, reward.ID, false, nil, reward)
+}
+
+// Update 報酬を更新し、更新前後の内容を操作履歴に記録
+func (s *JournaledRewardService) Update(ctx context.Context, id string, reward *models.Reward) error {
+	before, err := s.RewardService.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.RewardService.Update(ctx, id, reward); err != nil {
+		return err
+	}
+	return recordOperation(ctx, s.journal, models.OperationEntityReward, models.OperationActionUpdate, id, false, before, reward)
+}
+
+// Delete 報酬を削除し、削除前の内容を操作履歴に記録
+func (s *JournaledRewardService) Delete(ctx context.Context, id string) error {
+	before, err := s.RewardService.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.RewardService.Delete(ctx, id); err != nil {
+		return err
+	}
+	return recordOperation(ctx, s.journal, models.OperationEntityReward, models.OperationActionDelete, id, false, before, nil)
+}
+
+// recordOperation 操作の前後の状態を操作履歴に記録（nil の状態は対象が存在しないことを表す）
+//
+// 操作は適用済みのため、記録に失敗した場合は操作を適用したことをエラーに含める。
+func recordOperation(ctx context.Context, journal JournalService, entity models.OperationEntity, action models.OperationAction, id string, withPoints bool, before, after interface{}) error {
+	operation := &models.Operation{Entity: entity, Action: action, EntityID: id, WithPoints: withPoints}
+
+	var err error
+	if before != nil {
+		if operation.Before, err = encodeState(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if operation.After, err = encodeState(after); err != nil {
+			return err
+		}
+	}
+
+	if err := journal.Record(ctx, operation); err != nil {
+		return fmt.Errorf("%s %s %s was applied but could not be recorded for undo: %w", entity, id, action, err)
+	}
+	return nil
+}
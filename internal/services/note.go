@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// maxNoteLength メモの本文の最大文字数
+const maxNoteLength = 1000
+
+// NoteServiceImpl 記録に付けるメモのサービスの実装
+type NoteServiceImpl struct {
+	noteRepo        repository.NoteRepository
+	achievementRepo repository.AchievementRepository
+	rewardRepo      repository.RewardRepository
+	pointRepo       repository.PointRepository
+}
+
+// NewNoteService 記録に付けるメモのサービスを作成
+func NewNoteService(noteRepo repository.NoteRepository, achievementRepo repository.AchievementRepository, rewardRepo repository.RewardRepository, pointRepo repository.PointRepository) NoteService {
+	return &NoteServiceImpl{
+		noteRepo:        noteRepo,
+		achievementRepo: achievementRepo,
+		rewardRepo:      rewardRepo,
+		pointRepo:       pointRepo,
+	}
+}
+
+// Add 記録にメモを付ける（前後の空白を除いた本文を保存する）
+func (s *NoteServiceImpl) Add(ctx context.Context, note *models.Note) error {
+	if note == nil {
+		return &errors.ValidationError{Field: "note", Message: "note cannot be nil"}
+	}
+	note.Body = strings.TrimSpace(note.Body)
+	if utf8.RuneCountInString(note.Body) > maxNoteLength {
+		return &errors.ValidationError{Field: "body", Message: fmt.Sprintf("body must be at most %d characters", maxNoteLength)}
+	}
+	if err := repository.ValidateNote(note); err != nil {
+		return err
+	}
+	if err := s.checkTarget(ctx, note.TargetType, note.TargetID); err != nil {
+		return err
+	}
+	return s.noteRepo.Create(ctx, note)
+}
+
+// List 記録に付けたメモを作成日時順に取得
+func (s *NoteServiceImpl) List(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error) {
+	if err := s.checkTarget(ctx, targetType, targetID); err != nil {
+		return nil, err
+	}
+	return s.noteRepo.ListByTarget(ctx, targetType, targetID)
+}
+
+// Delete 記録に付けたメモを削除（別の記録のメモは errors.ErrNotFound）
+func (s *NoteServiceImpl) Delete(ctx context.Context, targetType models.NoteTargetType, targetID, noteID string) error {
+	if err := tenant.ValidateEntityID("id", noteID); err != nil {
+		return err
+	}
+	note, err := s.noteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		return err
+	}
+	if note.TargetType != targetType || note.TargetID != targetID {
+		return errors.ErrNotFound
+	}
+	return s.noteRepo.Delete(ctx, noteID)
+}
+
+// checkTarget メモを付ける記録が存在することを確認
+func (s *NoteServiceImpl) checkTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) error {
+	if err := tenant.ValidateEntityID("target_id", targetID); err != nil {
+		return err
+	}
+
+	var err error
+	switch targetType {
+	case models.NoteTargetAchievement:
+		_, err = s.achievementRepo.GetByID(ctx, targetID)
+	case models.NoteTargetReward:
+		_, err = s.rewardRepo.GetByID(ctx, targetID)
+	case models.NoteTargetRedemption:
+		_, err = s.pointRepo.GetRewardHistoryByID(ctx, targetID)
+	default:
+		return &errors.ValidationError{Field: "target_type", Message: "target_type must be achievement, reward or redemption"}
+	}
+	return err
+}
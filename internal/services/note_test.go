@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockNoteRepository 記録に付けるメモのリポジトリのモック
+type MockNoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockNoteRepository) Create(ctx context.Context, note *models.Note) error {
+	args := m.Called(note)
+	return args.Error(0)
+}
+
+func (m *MockNoteRepository) GetByID(ctx context.Context, id string) (*models.Note, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Note), args.Error(1)
+}
+
+func (m *MockNoteRepository) ListByTarget(ctx context.Context, targetType models.NoteTargetType, targetID string) ([]*models.Note, error) {
+	args := m.Called(targetType, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Note), args.Error(1)
+}
+
+func (m *MockNoteRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestNoteService_Add(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("GetByID", "achievement-1").Return(&models.Achievement{ID: "achievement-1"}, nil)
+	achievementRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByID", "reward-1").Return(&models.Reward{ID: "reward-1"}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryByID", "history-1").Return(&models.RewardHistory{ID: "history-1"}, nil)
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("Create", mock.Anything).Return(nil)
+	service := NewNoteService(noteRepo, achievementRepo, rewardRepo, pointRepo)
+	ctx := context.Background()
+
+	// 本文の前後の空白は除いて保存する
+	note := &models.Note{TargetType: models.NoteTargetRedemption, TargetID: "history-1", Body: "  誕生日のディナーに使った\n"}
+	require.NoError(t, service.Add(ctx, note))
+	assert.Equal(t, "誕生日のディナーに使った", note.Body)
+
+	require.NoError(t, service.Add(ctx, &models.Note{TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "雨の中で走った"}))
+	require.NoError(t, service.Add(ctx, &models.Note{TargetType: models.NoteTargetReward, TargetID: "reward-1", Body: "次は友達と行く"}))
+
+	// 存在しない記録にはメモを付けない
+	err := service.Add(ctx, &models.Note{TargetType: models.NoteTargetAchievement, TargetID: "missing", Body: "メモ"})
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	invalid := []*models.Note{
+		{TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: "   "},
+		{TargetType: models.NoteTargetAchievement, TargetID: "achievement-1", Body: strings.Repeat("あ", maxNoteLength+1)},
+		{TargetType: "goal", TargetID: "goal-1", Body: "メモ"},
+	}
+	for _, note := range invalid {
+		assert.IsType(t, &errors.ValidationError{}, service.Add(ctx, note))
+	}
+	noteRepo.AssertNumberOfCalls(t, "Create", 3)
+}
+
+func TestNoteService_List(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByID", "reward-1").Return(&models.Reward{ID: "reward-1"}, nil)
+	rewardRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	noteRepo := new(MockNoteRepository)
+	notes := []*models.Note{{ID: "note-1", TargetType: models.NoteTargetReward, TargetID: "reward-1", Body: "次は友達と行く"}}
+	noteRepo.On("ListByTarget", models.NoteTargetReward, "reward-1").Return(notes, nil)
+	service := NewNoteService(noteRepo, new(MockAchievementRepository), rewardRepo, new(MockPointRepository))
+
+	listed, err := service.List(context.Background(), models.NoteTargetReward, "reward-1")
+	require.NoError(t, err)
+	assert.Equal(t, notes, listed)
+
+	_, err = service.List(context.Background(), models.NoteTargetReward, "missing")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+}
+
+func TestNoteService_Delete(t *testing.T) {
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("GetByID", "note-1").Return(&models.Note{ID: "note-1", TargetType: models.NoteTargetAchievement, TargetID: "achievement-1"}, nil)
+	noteRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	noteRepo.On("Delete", "note-1").Return(nil)
+	service := NewNoteService(noteRepo, new(MockAchievementRepository), new(MockRewardRepository), new(MockPointRepository))
+	ctx := context.Background()
+
+	// 別の記録のメモは削除しない
+	assert.ErrorIs(t, service.Delete(ctx, models.NoteTargetAchievement, "achievement-2", "note-1"), errors.ErrNotFound)
+	assert.ErrorIs(t, service.Delete(ctx, models.NoteTargetReward, "achievement-1", "note-1"), errors.ErrNotFound)
+	assert.ErrorIs(t, service.Delete(ctx, models.NoteTargetAchievement, "achievement-1", "missing"), errors.ErrNotFound)
+	noteRepo.AssertNotCalled(t, "Delete", mock.Anything)
+
+	require.NoError(t, service.Delete(ctx, models.NoteTargetAchievement, "achievement-1", "note-1"))
+	noteRepo.AssertCalled(t, "Delete", "note-1")
+}
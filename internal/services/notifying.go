@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/events"
+	"achievement-management/internal/logging"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+const (
+	// AchievementCreatedEventType 達成目録を作成した際に通知するイベントの種類
+	AchievementCreatedEventType = "achievements.created"
+	// RewardRedeemedEventType 報酬を獲得した際に通知するイベントの種類
+	RewardRedeemedEventType = "rewards.redeemed"
+	// LowBalanceEventType 報酬を獲得した後の現在のポイントがしきい値を下回った際に通知するイベントの種類
+	LowBalanceEventType = "points.low_balance"
+)
+
+// イベントのテーブルの識別子
+const (
+	achievementTable   = "achievements"
+	rewardHistoryTable = "reward_history"
+	currentPointsTable = "current_points"
+)
+
+// NotifyingAchievementService 作成した達成目録を通知する達成目録サービス
+type NotifyingAchievementService struct {
+	AchievementService
+	notifier events.Publisher
+}
+
+// NewNotifyingAchievementService 達成目録サービスに作成の通知を追加（notifier がnilの場合は next をそのまま返す）
+func NewNotifyingAchievementService(next AchievementService, notifier events.Publisher) AchievementService {
+	if notifier == nil {
+		return next
+	}
+	return &NotifyingAchievementService{AchievementService: next, notifier: notifier}
+}
+
+// Create 達成目録を作成し、achievements.created を通知
+func (s *NotifyingAchievementService) Create(ctx context.Context, achievement *models.Achievement) error {
+	if err := s.AchievementService.Create(ctx, achievement); err != nil {
+		return err
+	}
+
+	key := tenant.Key(ctx, achievement.ID)
+	publishNotification(ctx, s.notifier, events.Event{
+		ID:     fmt.Sprintf("%s:%s", AchievementCreatedEventType, key),
+		Type:   AchievementCreatedEventType,
+		Table:  achievementTable,
+		Action: events.ActionCreated,
+		Key:    map[string]interface{}{"id": key},
+		Item: map[string]interface{}{
+			"id":          achievement.ID,
+			"title":       achievement.Title,
+			"description": achievement.Description,
+			"point":       achievement.Point,
+			"category":    achievement.Category,
+		},
+		OccurredAt: achievement.CreatedAt,
+		Source:     events.SourceNotificationService,
+	})
+	return nil
+}
+
+// NotifyingRewardService 報酬の獲得と、獲得後のポイントの残りが少ないことを通知する報酬サービス
+type NotifyingRewardService struct {
+	RewardService
+	pointRepo repository.PointRepository
+	notifier  events.Publisher
+	// lowBalanceThreshold 獲得後の現在のポイントがこの値を下回ったら通知する（0の場合は通知しない）
+	lowBalanceThreshold int
+}
+
+// NewNotifyingRewardService 報酬サービスに獲得の通知を追加（notifier がnilの場合は next をそのまま返す）
+func NewNotifyingRewardService(next RewardService, pointRepo repository.PointRepository, notifier events.Publisher, lowBalanceThreshold int) RewardService {
+	if notifier == nil {
+		return next
+	}
+	return &NotifyingRewardService{
+		RewardService:       next,
+		pointRepo:           pointRepo,
+		notifier:            notifier,
+		lowBalanceThreshold: lowBalanceThreshold,
+	}
+}
+
+// Redeem 報酬を獲得して rewards.redeemed を通知し、現在のポイントがしきい値を下回った場合は points.low_balance も通知
+func (s *NotifyingRewardService) Redeem(ctx context.Context, rewardID string) (*models.RewardHistory, error) {
+	history, err := s.RewardService.Redeem(ctx, rewardID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := tenant.Key(ctx, history.ID)
+	publishNotification(ctx, s.notifier, events.Event{
+		ID:     fmt.Sprintf("%s:%s", RewardRedeemedEventType, key),
+		Type:   RewardRedeemedEventType,
+		Table:  rewardHistoryTable,
+		Action: events.ActionCreated,
+		Key:    map[string]interface{}{"id": key},
+		Item: map[string]interface{}{
+			"id":           history.ID,
+			"reward_id":    history.RewardID,
+			"reward_title": history.RewardTitle,
+			"point_cost":   history.PointCost,
+			"prize":        history.Prize,
+			"redeemed_at":  history.RedeemedAt.UTC().Format(time.RFC3339Nano),
+		},
+		OccurredAt: history.RedeemedAt,
+		Source:     events.SourceNotificationService,
+	})
+
+	if s.lowBalanceThreshold > 0 {
+		s.notifyLowBalance(ctx, history)
+	}
+	return history, nil
+}
+
+// notifyLowBalance 獲得後の現在のポイントがしきい値を下回った場合に points.low_balance を通知
+func (s *NotifyingRewardService) notifyLowBalance(ctx context.Context, history *models.RewardHistory) {
+	current, err := s.pointRepo.GetCurrentPoints(ctx)
+	if err != nil {
+		logging.FromContext(ctx).WithFields(map[string]interface{}{
+			"reward_id": history.RewardID,
+			"error":     err.Error(),
+		}).Warn("Failed to get the current points to check for a low balance")
+		return
+	}
+	if current.Point >= s.lowBalanceThreshold {
+		return
+	}
+
+	key := tenant.Key(ctx, history.ID)
+	publishNotification(ctx, s.notifier, events.Event{
+		ID:     fmt.Sprintf("%s:%s", LowBalanceEventType, key),
+		Type:   LowBalanceEventType,
+		Table:  currentPointsTable,
+		Action: events.ActionDetected,
+		Key:    map[string]interface{}{"id": tenant.Key(ctx, "current")},
+		Item: map[string]interface{}{
+			"point":        current.Point,
+			"threshold":    s.lowBalanceThreshold,
+			"reward_id":    history.RewardID,
+			"reward_title": history.RewardTitle,
+			"point_cost":   history.PointCost,
+		},
+		OccurredAt: history.RedeemedAt,
+		Source:     events.SourceNotificationService,
+	})
+}
+
+// publishNotification 通知を配信（操作は完了しているため、配信に失敗してもエラーにせず記録する）
+func publishNotification(ctx context.Context, notifier events.Publisher, event events.Event) {
+	if err := notifier.Publish(ctx, event); err != nil {
+		logging.FromContext(ctx).WithFields(map[string]interface{}{
+			"event_id":   event.ID,
+			"event_type": event.Type,
+			"error":      err.Error(),
+		}).Warn("Failed to publish a notification")
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher 配信されたイベントを記録する配信先
+type recordingPublisher struct {
+	published []events.Event
+	err       error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event events.Event) error {
+	p.published = append(p.published, event)
+	return p.err
+}
+
+// redeemingRewardService Redeem で固定の獲得履歴を返す報酬サービス
+type redeemingRewardService struct {
+	RewardService
+	history *models.RewardHistory
+}
+
+func (s *redeemingRewardService) Redeem(ctx context.Context, rewardID string) (*models.RewardHistory, error) {
+	return s.history, nil
+}
+
+func TestNotifyingAchievementService_Create(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	notifier := &recordingPublisher{err: stderrors.New("slack is down")}
+	service := NewNotifyingAchievementService(NewAchievementService(achievementRepo, new(MockPointRepository)), notifier)
+
+	achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(nil)
+
+	// 通知に失敗しても作成は成功する
+	require.NoError(t, service.Create(context.Background(), &models.Achievement{Title: "読書", Point: 10}))
+	require.Len(t, notifier.published, 1)
+	assert.Equal(t, AchievementCreatedEventType, notifier.published[0].Type)
+	assert.Equal(t, "読書", notifier.published[0].Item["title"])
+}
+
+func TestNotifyingAchievementService_WithoutNotifier(t *testing.T) {
+	next := NewAchievementService(new(MockAchievementRepository), new(MockPointRepository))
+	assert.Same(t, next, NewNotifyingAchievementService(next, nil))
+}
+
+func TestNotifyingRewardService_Redeem_LowBalance(t *testing.T) {
+	pointRepo := new(MockPointRepository)
+	notifier := &recordingPublisher{}
+	history := &models.RewardHistory{ID: "h1", RewardID: "r1", RewardTitle: "映画", PointCost: 80, RedeemedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	service := NewNotifyingRewardService(&redeemingRewardService{history: history}, pointRepo, notifier, 50)
+
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 20}, nil).Once()
+	_, err := service.Redeem(context.Background(), "r1")
+	require.NoError(t, err)
+	require.Len(t, notifier.published, 2)
+	assert.Equal(t, RewardRedeemedEventType, notifier.published[0].Type)
+	assert.Equal(t, LowBalanceEventType, notifier.published[1].Type)
+	assert.Equal(t, 20, notifier.published[1].Item["point"])
+
+	// しきい値以上の場合は獲得のみ通知する
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 50}, nil).Once()
+	_, err = service.Redeem(context.Background(), "r1")
+	require.NoError(t, err)
+	assert.Len(t, notifier.published, 3)
+}
@@ -1,9 +1,16 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
 	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
 )
 
 // PointServiceImpl ポイントサービスの実装
@@ -21,32 +28,32 @@ func NewPointService(pointRepo repository.PointRepository, achievementRepo repos
 }
 
 // GetCurrentPoints 現在のポイントを取得
-func (s *PointServiceImpl) GetCurrentPoints() (*models.CurrentPoints, error) {
-	return s.pointRepo.GetCurrentPoints()
+func (s *PointServiceImpl) GetCurrentPoints(ctx context.Context) (*models.CurrentPoints, error) {
+	return s.pointRepo.GetCurrentPoints(ctx)
 }
 
 // AddPoints ポイントを加算
-func (s *PointServiceImpl) AddPoints(points int) error {
+func (s *PointServiceImpl) AddPoints(ctx context.Context, points int) error {
 	if points <= 0 {
 		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
 	}
 
-	return s.pointRepo.AddPoints(points)
+	return s.pointRepo.AddPoints(ctx, points)
 }
 
 // SubtractPoints ポイントを減算
-func (s *PointServiceImpl) SubtractPoints(points int) error {
+func (s *PointServiceImpl) SubtractPoints(ctx context.Context, points int) error {
 	if points <= 0 {
 		return &errors.ValidationError{Field: "points", Message: "points must be positive"}
 	}
 
-	return s.pointRepo.SubtractPoints(points)
+	return s.pointRepo.SubtractPoints(ctx, points)
 }
 
 // AggregatePoints 全達成目録のポイントを集計し、現在のポイントと比較
-func (s *PointServiceImpl) AggregatePoints() (*models.PointSummary, error) {
-	// 全達成目録を取得
-	achievements, err := s.achievementRepo.List()
+func (s *PointServiceImpl) AggregatePoints(ctx context.Context) (*models.PointSummary, error) {
+	// 全達成目録のポイントを取得（集計に必要な属性のみを読み取る）
+	achievements, err := s.achievementRepo.ListSummaries(ctx)
 	if err != nil {
 		return nil, &errors.ServiceError{
 			Operation: "AggregatePoints",
@@ -64,7 +71,7 @@ func (s *PointServiceImpl) AggregatePoints() (*models.PointSummary, error) {
 	}
 
 	// 現在のポイントを取得
-	currentPoints, err := s.pointRepo.GetCurrentPoints()
+	currentPoints, err := s.pointRepo.GetCurrentPoints(ctx)
 	if err != nil {
 		return nil, &errors.ServiceError{
 			Operation: "AggregatePoints",
@@ -82,12 +89,118 @@ func (s *PointServiceImpl) AggregatePoints() (*models.PointSummary, error) {
 		TotalPoints:       totalPoints,
 		CurrentBalance:    currentPoints.Point,
 		Difference:        difference,
+		Categories:        aggregateCategories(achievements),
 	}
 
 	return summary, nil
 }
 
 // GetRewardHistory 報酬獲得履歴を取得
-func (s *PointServiceImpl) GetRewardHistory() ([]*models.RewardHistory, error) {
-	return s.pointRepo.GetRewardHistory()
-}
\ No newline at end of file
+func (s *PointServiceImpl) GetRewardHistory(ctx context.Context) ([]*models.RewardHistory, error) {
+	return s.pointRepo.GetRewardHistory(ctx)
+}
+
+// GetRewardHistoryBetween 獲得日時が from 以上 to 未満の報酬獲得履歴を取得（ゼロ値の側は制限しない）
+func (s *PointServiceImpl) GetRewardHistoryBetween(ctx context.Context, from, to time.Time) ([]*models.RewardHistory, error) {
+	return s.pointRepo.GetRewardHistoryBetween(ctx, from, to)
+}
+
+// CountRewardHistory 報酬獲得履歴の件数を取得
+func (s *PointServiceImpl) CountRewardHistory(ctx context.Context) (int, error) {
+	return s.pointRepo.CountRewardHistory(ctx)
+}
+
+// AnnotateRedemption 報酬獲得履歴の注記・タグを変更し、変更前と変更後の履歴を返す（ポイントは変更できない）
+//
+// annotation でnilの項目は変更しない。タグは前後の空白を除いて重複を取り除く。
+func (s *PointServiceImpl) AnnotateRedemption(ctx context.Context, id string, annotation models.RedemptionAnnotation) (*models.RewardHistory, *models.RewardHistory, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, nil, err
+	}
+	if annotation.Note == nil && annotation.Tags == nil {
+		return nil, nil, &errors.ValidationError{Field: "note", Message: "note or tags is required"}
+	}
+
+	previous, err := s.pointRepo.GetRewardHistoryByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := *previous
+	if annotation.Note != nil {
+		updated.Note = normalizeText(*annotation.Note)
+		if utf8.RuneCountInString(updated.Note) > maxDescriptionLength {
+			return nil, nil, &errors.ValidationError{Field: "note", Message: fmt.Sprintf("note must be at most %d characters", maxDescriptionLength)}
+		}
+	}
+	if annotation.Tags != nil {
+		tags, err := normalizeTags(*annotation.Tags)
+		if err != nil {
+			return nil, nil, err
+		}
+		updated.Tags = tags
+	}
+
+	if err := s.pointRepo.AnnotateRedemption(ctx, id, updated.Note, updated.Tags); err != nil {
+		return nil, nil, err
+	}
+	return previous, &updated, nil
+}
+
+// normalizeTags タグを揃えて重複を取り除き、件数と文字数を検証
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = normalizeText(tag)
+		if tag == "" {
+			return nil, &errors.ValidationError{Field: "tags", Message: "tags cannot contain an empty tag"}
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, &errors.ValidationError{Field: "tags", Message: fmt.Sprintf("each tag must be at most %d characters", maxTagLength)}
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTags {
+		return nil, &errors.ValidationError{Field: "tags", Message: fmt.Sprintf("at most %d tags are allowed", maxTags)}
+	}
+	return normalized, nil
+}
+
+// GetLedger ポイント台帳を取得
+func (s *PointServiceImpl) GetLedger(ctx context.Context) ([]*models.PointLedgerEntry, error) {
+	return s.pointRepo.GetLedger(ctx)
+}
+
+// aggregateCategories 達成目録のポイントと件数を分類ごとに集計（ポイントの多い順、同じ場合は分類名の順）
+func aggregateCategories(achievements []*models.Achievement) []models.CategoryPoints {
+	totals := make(map[string]*models.CategoryPoints)
+	for _, achievement := range achievements {
+		if achievement == nil {
+			continue
+		}
+		total, ok := totals[achievement.Category]
+		if !ok {
+			total = &models.CategoryPoints{Category: achievement.Category}
+			totals[achievement.Category] = total
+		}
+		total.Earned += achievement.Point
+		total.Count++
+	}
+
+	categories := make([]models.CategoryPoints, 0, len(totals))
+	for _, total := range totals {
+		categories = append(categories, *total)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Earned != categories[j].Earned {
+			return categories[i].Earned > categories[j].Earned
+		}
+		return categories[i].Category < categories[j].Category
+	})
+	return categories
+}
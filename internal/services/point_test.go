@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -70,7 +71,7 @@ func TestPointService_GetCurrentPoints(t *testing.T) {
 			tt.mockSetup(mockPointRepo)
 
 			service := NewPointService(mockPointRepo, mockAchievementRepo)
-			result, err := service.GetCurrentPoints()
+			result, err := service.GetCurrentPoints(context.Background())
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -139,7 +140,7 @@ func TestPointService_AddPoints(t *testing.T) {
 			tt.mockSetup(mockPointRepo)
 
 			service := NewPointService(mockPointRepo, mockAchievementRepo)
-			err := service.AddPoints(tt.points)
+			err := service.AddPoints(context.Background(), tt.points)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -197,7 +198,7 @@ func TestPointService_SubtractPoints(t *testing.T) {
 			tt.mockSetup(mockPointRepo)
 
 			service := NewPointService(mockPointRepo, mockAchievementRepo)
-			err := service.SubtractPoints(tt.points)
+			err := service.SubtractPoints(context.Background(), tt.points)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -226,7 +227,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 					{ID: "2", Title: "Achievement 2", Point: 30},
 					{ID: "3", Title: "Achievement 3", Point: 20},
 				}
-				ma.On("List").Return(achievements, nil)
+				ma.On("ListSummaries").Return(achievements, nil)
 				
 				currentPoints := &models.CurrentPoints{
 					ID:        "current",
@@ -250,7 +251,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 					{ID: "1", Title: "Achievement 1", Point: 60},
 					{ID: "2", Title: "Achievement 2", Point: 40},
 				}
-				ma.On("List").Return(achievements, nil)
+				ma.On("ListSummaries").Return(achievements, nil)
 				
 				currentPoints := &models.CurrentPoints{
 					ID:        "current",
@@ -273,7 +274,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 				achievements := []*models.Achievement{
 					{ID: "1", Title: "Achievement 1", Point: 30},
 				}
-				ma.On("List").Return(achievements, nil)
+				ma.On("ListSummaries").Return(achievements, nil)
 				
 				currentPoints := &models.CurrentPoints{
 					ID:        "current",
@@ -294,7 +295,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 			name: "正常系: 達成目録が空",
 			mockSetup: func(mp *MockPointRepository, ma *MockAchievementRepository) {
 				achievements := []*models.Achievement{}
-				ma.On("List").Return(achievements, nil)
+				ma.On("ListSummaries").Return(achievements, nil)
 				
 				currentPoints := &models.CurrentPoints{
 					ID:        "current",
@@ -319,7 +320,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 					nil, // nilの達成目録
 					{ID: "2", Title: "Achievement 2", Point: 35},
 				}
-				ma.On("List").Return(achievements, nil)
+				ma.On("ListSummaries").Return(achievements, nil)
 				
 				currentPoints := &models.CurrentPoints{
 					ID:        "current",
@@ -339,7 +340,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 		{
 			name: "異常系: 達成目録取得エラー",
 			mockSetup: func(mp *MockPointRepository, ma *MockAchievementRepository) {
-				ma.On("List").Return(nil, &errors.DatabaseError{
+				ma.On("ListSummaries").Return(nil, &errors.DatabaseError{
 					Operation: "List",
 					Table:     "achievements",
 					Cause:     assert.AnError,
@@ -362,7 +363,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 				achievements := []*models.Achievement{
 					{ID: "1", Title: "Achievement 1", Point: 50},
 				}
-				ma.On("List").Return(achievements, nil)
+				ma.On("ListSummaries").Return(achievements, nil)
 				
 				mp.On("GetCurrentPoints").Return(nil, &errors.DatabaseError{
 					Operation: "GetCurrentPoints",
@@ -390,7 +391,7 @@ func TestPointService_AggregatePoints(t *testing.T) {
 			tt.mockSetup(mockPointRepo, mockAchievementRepo)
 
 			service := NewPointService(mockPointRepo, mockAchievementRepo)
-			result, err := service.AggregatePoints()
+			result, err := service.AggregatePoints(context.Background())
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -409,4 +410,70 @@ func TestPointService_AggregatePoints(t *testing.T) {
 			mockAchievementRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestPointService_AggregatePoints_Categories(t *testing.T) {
+	mockPointRepo := &MockPointRepository{}
+	mockAchievementRepo := &MockAchievementRepository{}
+	mockAchievementRepo.On("ListSummaries").Return([]*models.Achievement{
+		{ID: "1", Title: "朝のランニング", Point: 30, Category: "health"},
+		{ID: "2", Title: "英単語", Point: 20, Category: "learning"},
+		{ID: "3", Title: "ストレッチ", Point: 10, Category: "health"},
+		{ID: "4", Title: "部屋の掃除", Point: 20},
+		nil,
+	}, nil)
+	mockPointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{ID: "current", Point: 80}, nil)
+
+	result, err := NewPointService(mockPointRepo, mockAchievementRepo).AggregatePoints(context.Background())
+	assert.NoError(t, err)
+
+	// ポイントの多い順、同じ場合は分類名の順（未分類は空文字）
+	assert.Equal(t, []models.CategoryPoints{
+		{Category: "health", Earned: 40, Count: 2},
+		{Category: "", Earned: 20, Count: 1},
+		{Category: "learning", Earned: 20, Count: 1},
+	}, result.Categories)
+	assert.Equal(t, 80, result.TotalPoints)
+}
+func TestPointService_AnnotateRedemption(t *testing.T) {
+	mockPointRepo := &MockPointRepository{}
+	previous := &models.RewardHistory{ID: "h1", RewardTitle: "映画", PointCost: 50, Note: "古いメモ", Tags: []string{"old"}}
+	mockPointRepo.On("GetRewardHistoryByID", "h1").Return(previous, nil)
+	mockPointRepo.On("AnnotateRedemption", "h1", "古いメモ", []string{"family", "weekend"}).Return(nil)
+
+	// タグは揃えて重複を取り除き、指定しなかったメモは変更しない
+	tags := []string{" family ", "weekend", "family"}
+	before, after, err := NewPointService(mockPointRepo, &MockAchievementRepository{}).AnnotateRedemption(context.Background(), "h1", models.RedemptionAnnotation{Tags: &tags})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"old"}, before.Tags)
+	assert.Equal(t, "古いメモ", after.Note)
+	assert.Equal(t, []string{"family", "weekend"}, after.Tags)
+	assert.Equal(t, 50, after.PointCost)
+
+	mockPointRepo.AssertExpectations(t)
+}
+
+func TestPointService_AnnotateRedemption_Validation(t *testing.T) {
+	service := NewPointService(&MockPointRepository{}, &MockAchievementRepository{})
+	ctx := context.Background()
+	note := "メモ"
+
+	_, _, err := service.AnnotateRedemption(ctx, "", models.RedemptionAnnotation{Note: &note})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, _, err = service.AnnotateRedemption(ctx, "h1", models.RedemptionAnnotation{})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, err = normalizeTags([]string{"family", "  "})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, err = normalizeTags([]string{"0123456789012345678901234567890"})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	many := make([]string, maxTags+1)
+	for i := range many {
+		many[i] = string(rune('a' + i))
+	}
+	_, err = normalizeTags(many)
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// PromotionServiceImpl 達成目録・報酬の定義を別の環境のテーブルへ昇格するサービスの実装
+//
+// 定義だけを昇格し、達成履歴・報酬の獲得履歴・ポイントは昇格しない。昇格先にはリポジトリへ直接書き込むため、
+// 達成目録を作成・更新しても昇格先のポイントは変わらない。
+type PromotionServiceImpl struct {
+	sourceAchievements repository.AchievementRepository
+	sourceRewards      repository.RewardRepository
+	targetAchievements repository.AchievementRepository
+	targetRewards      repository.RewardRepository
+}
+
+// NewPromotionService 昇格元と昇格先のリポジトリから昇格サービスを作成
+func NewPromotionService(sourceAchievements repository.AchievementRepository, sourceRewards repository.RewardRepository, targetAchievements repository.AchievementRepository, targetRewards repository.RewardRepository) PromotionService {
+	return &PromotionServiceImpl{
+		sourceAchievements: sourceAchievements,
+		sourceRewards:      sourceRewards,
+		targetAchievements: targetAchievements,
+		targetRewards:      targetRewards,
+	}
+}
+
+// Promote 指定した達成目録・報酬の定義を昇格先に作成・更新し、定義ごとの結果を返す
+func (s *PromotionServiceImpl) Promote(ctx context.Context, opts models.PromotionOptions) (*models.PromotionResult, error) {
+	if opts.Match == "" {
+		opts.Match = models.PromotionMatchID
+	}
+	if opts.Conflict == "" {
+		opts.Conflict = models.PromotionConflictSkip
+	}
+	if opts.Match != models.PromotionMatchID && opts.Match != models.PromotionMatchTitle {
+		return nil, &errors.ValidationError{Field: "match", Message: "match must be id or title"}
+	}
+	if opts.Conflict != models.PromotionConflictSkip && opts.Conflict != models.PromotionConflictOverwrite {
+		return nil, &errors.ValidationError{Field: "conflict", Message: "conflict must be skip or overwrite"}
+	}
+	if !opts.All && len(opts.AchievementIDs) == 0 && len(opts.RewardIDs) == 0 {
+		return nil, &errors.ValidationError{Field: "ids", Message: "at least one achievement or reward id is required unless all is set"}
+	}
+
+	achievements, err := s.sourceAchievementsFor(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := s.sourceRewardsFor(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.PromotionResult{DryRun: opts.DryRun}
+	if len(achievements) > 0 {
+		items, err := s.promoteAchievements(ctx, achievements, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, items...)
+	}
+	if len(rewards) > 0 {
+		items, err := s.promoteRewards(ctx, rewards, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, items...)
+	}
+	return result, nil
+}
+
+// sourceAchievementsFor 昇格する昇格元の達成目録（All の場合はすべて）
+func (s *PromotionServiceImpl) sourceAchievementsFor(ctx context.Context, opts models.PromotionOptions) ([]*models.Achievement, error) {
+	if opts.All {
+		return s.sourceAchievements.List(ctx)
+	}
+	achievements := make([]*models.Achievement, 0, len(opts.AchievementIDs))
+	for _, id := range opts.AchievementIDs {
+		achievement, err := s.sourceAchievements.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("achievement %s: %w", id, err)
+		}
+		achievements = append(achievements, achievement)
+	}
+	return achievements, nil
+}
+
+// sourceRewardsFor 昇格する昇格元の報酬（All の場合はすべて）
+func (s *PromotionServiceImpl) sourceRewardsFor(ctx context.Context, opts models.PromotionOptions) ([]*models.Reward, error) {
+	if opts.All {
+		return s.sourceRewards.List(ctx)
+	}
+	rewards := make([]*models.Reward, 0, len(opts.RewardIDs))
+	for _, id := range opts.RewardIDs {
+		reward, err := s.sourceRewards.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("reward %s: %w", id, err)
+		}
+		rewards = append(rewards, reward)
+	}
+	return rewards, nil
+}
+
+// promoteAchievements 達成目録を昇格する
+func (s *PromotionServiceImpl) promoteAchievements(ctx context.Context, achievements []*models.Achievement, opts models.PromotionOptions) ([]*models.PromotionItem, error) {
+	var byTitle map[string]*models.Achievement
+	if opts.Match == models.PromotionMatchTitle {
+		if err := uniqueTitles(models.OperationEntityAchievement, "source", achievementTitles(achievements)); err != nil {
+			return nil, err
+		}
+		existing, err := s.targetAchievements.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := uniqueTitles(models.OperationEntityAchievement, "target", achievementTitles(existing)); err != nil {
+			return nil, err
+		}
+		byTitle = make(map[string]*models.Achievement, len(existing))
+		for _, achievement := range existing {
+			byTitle[achievement.Title] = achievement
+		}
+	}
+
+	items := make([]*models.PromotionItem, 0, len(achievements))
+	for _, source := range achievements {
+		item := &models.PromotionItem{Entity: models.OperationEntityAchievement, SourceID: source.ID, Title: source.Title}
+
+		var target *models.Achievement
+		if opts.Match == models.PromotionMatchTitle {
+			target = byTitle[source.Title]
+		} else {
+			existing, err := s.targetAchievements.GetByID(ctx, source.ID)
+			if err != nil && !stderrors.Is(err, errors.ErrNotFound) {
+				return nil, err
+			}
+			target = existing
+		}
+
+		switch {
+		case target == nil:
+			item.Action = models.PromotionActionCreated
+			promoted := promotedAchievement(source)
+			if opts.Match == models.PromotionMatchTitle {
+				promoted.ID = ""
+			}
+			if !opts.DryRun {
+				if err := s.targetAchievements.Create(ctx, promoted); err != nil {
+					return nil, fmt.Errorf("achievement %s: %w", source.ID, err)
+				}
+			}
+			item.TargetID = promoted.ID
+		case sameAchievement(source, target):
+			item.Action = models.PromotionActionUnchanged
+			item.TargetID = target.ID
+		case opts.Conflict == models.PromotionConflictSkip:
+			item.Action = models.PromotionActionSkipped
+			item.TargetID = target.ID
+		default:
+			item.Action = models.PromotionActionUpdated
+			item.TargetID = target.ID
+			promoted := promotedAchievement(source)
+			promoted.ID = target.ID
+			promoted.CreatedAt = target.CreatedAt
+			promoted.AttachmentKey = target.AttachmentKey
+			promoted.Version = target.Version
+			if !opts.DryRun {
+				if err := s.targetAchievements.Update(ctx, promoted); err != nil {
+					return nil, fmt.Errorf("achievement %s: %w", source.ID, err)
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// promoteRewards 報酬を昇格する
+func (s *PromotionServiceImpl) promoteRewards(ctx context.Context, rewards []*models.Reward, opts models.PromotionOptions) ([]*models.PromotionItem, error) {
+	var byTitle map[string]*models.Reward
+	if opts.Match == models.PromotionMatchTitle {
+		if err := uniqueTitles(models.OperationEntityReward, "source", rewardTitles(rewards)); err != nil {
+			return nil, err
+		}
+		existing, err := s.targetRewards.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := uniqueTitles(models.OperationEntityReward, "target", rewardTitles(existing)); err != nil {
+			return nil, err
+		}
+		byTitle = make(map[string]*models.Reward, len(existing))
+		for _, reward := range existing {
+			byTitle[reward.Title] = reward
+		}
+	}
+
+	items := make([]*models.PromotionItem, 0, len(rewards))
+	for _, source := range rewards {
+		item := &models.PromotionItem{Entity: models.OperationEntityReward, SourceID: source.ID, Title: source.Title}
+
+		var target *models.Reward
+		if opts.Match == models.PromotionMatchTitle {
+			target = byTitle[source.Title]
+		} else {
+			existing, err := s.targetRewards.GetByID(ctx, source.ID)
+			if err != nil && !stderrors.Is(err, errors.ErrNotFound) {
+				return nil, err
+			}
+			target = existing
+		}
+
+		switch {
+		case target == nil:
+			item.Action = models.PromotionActionCreated
+			promoted := &models.Reward{ID: source.ID, Title: source.Title, Description: source.Description, Point: source.Point, Prizes: source.Prizes}
+			if opts.Match == models.PromotionMatchTitle {
+				promoted.ID = ""
+			}
+			if !opts.DryRun {
+				if err := s.targetRewards.Create(ctx, promoted); err != nil {
+					return nil, fmt.Errorf("reward %s: %w", source.ID, err)
+				}
+			}
+			item.TargetID = promoted.ID
+		case sameReward(source, target):
+			item.Action = models.PromotionActionUnchanged
+			item.TargetID = target.ID
+		case opts.Conflict == models.PromotionConflictSkip:
+			item.Action = models.PromotionActionSkipped
+			item.TargetID = target.ID
+		default:
+			item.Action = models.PromotionActionUpdated
+			item.TargetID = target.ID
+			promoted := &models.Reward{
+				ID:          target.ID,
+				Title:       source.Title,
+				Description: source.Description,
+				Point:       source.Point,
+				Prizes:      source.Prizes,
+				CreatedAt:   target.CreatedAt,
+				Version:     target.Version,
+			}
+			if !opts.DryRun {
+				if err := s.targetRewards.Update(ctx, promoted); err != nil {
+					return nil, fmt.Errorf("reward %s: %w", source.ID, err)
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// promotedAchievement 昇格先に作成する達成目録（定義だけをコピーし、添付ファイル・作成日時・バージョン・タイマーはコピーしない）
+func promotedAchievement(source *models.Achievement) *models.Achievement {
+	promoted := *source
+	promoted.AttachmentKey = ""
+	promoted.TimerStartedAt = nil
+	promoted.CreatedAt = time.Time{}
+	promoted.Version = 0
+	return &promoted
+}
+
+// uniqueTitles タイトルで照合する場合に、同じタイトルの定義が複数ないことを確認する
+func uniqueTitles(entity models.OperationEntity, side string, titles []string) error {
+	seen := make(map[string]bool, len(titles))
+	for _, title := range titles {
+		if seen[title] {
+			return &errors.BusinessLogicError{
+				Operation: "promote",
+				Reason:    fmt.Sprintf("%s has more than one %s titled %q; use id matching instead", side, entity, title),
+			}
+		}
+		seen[title] = true
+	}
+	return nil
+}
+
+func achievementTitles(achievements []*models.Achievement) []string {
+	titles := make([]string, len(achievements))
+	for i, achievement := range achievements {
+		titles[i] = achievement.Title
+	}
+	return titles
+}
+
+func rewardTitles(rewards []*models.Reward) []string {
+	titles := make([]string, len(rewards))
+	for i, reward := range rewards {
+		titles[i] = reward.Title
+	}
+	return titles
+}
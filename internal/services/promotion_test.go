@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type promotionRepos struct {
+	sourceAchievements *MockAchievementRepository
+	sourceRewards      *MockRewardRepository
+	targetAchievements *MockAchievementRepository
+	targetRewards      *MockRewardRepository
+}
+
+func newPromotionTestService() (PromotionService, *promotionRepos) {
+	repos := &promotionRepos{
+		sourceAchievements: new(MockAchievementRepository),
+		sourceRewards:      new(MockRewardRepository),
+		targetAchievements: new(MockAchievementRepository),
+		targetRewards:      new(MockRewardRepository),
+	}
+	return NewPromotionService(repos.sourceAchievements, repos.sourceRewards, repos.targetAchievements, repos.targetRewards), repos
+}
+
+func TestPromotionService_Promote_MatchByID(t *testing.T) {
+	service, repos := newPromotionTestService()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repos.sourceAchievements.On("GetByID", "a1").Return(&models.Achievement{ID: "a1", Title: "朝活", Point: 10, AttachmentKey: "staging/a1.png", CreatedAt: created, Version: 3}, nil)
+	repos.sourceAchievements.On("GetByID", "a2").Return(&models.Achievement{ID: "a2", Title: "読書", Point: 20, Version: 1}, nil)
+	repos.sourceRewards.On("GetByID", "r1").Return(&models.Reward{ID: "r1", Title: "コーヒー", Point: 30}, nil)
+	repos.targetAchievements.On("GetByID", "a1").Return(nil, errors.ErrNotFound)
+	repos.targetAchievements.On("GetByID", "a2").Return(&models.Achievement{ID: "a2", Title: "読書", Point: 20, Version: 5}, nil)
+	repos.targetRewards.On("GetByID", "r1").Return(nil, errors.ErrNotFound)
+
+	// 添付ファイル・作成日時・バージョンはコピーせず、同じIDで作成する
+	repos.targetAchievements.On("Create", &models.Achievement{ID: "a1", Title: "朝活", Point: 10}).Return(nil)
+	repos.targetRewards.On("Create", &models.Reward{ID: "r1", Title: "コーヒー", Point: 30}).Return(nil)
+
+	result, err := service.Promote(context.Background(), models.PromotionOptions{AchievementIDs: []string{"a1", "a2"}, RewardIDs: []string{"r1"}})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 3)
+
+	assert.Equal(t, models.PromotionActionCreated, result.Items[0].Action)
+	assert.Equal(t, "a1", result.Items[0].TargetID)
+	assert.Equal(t, models.PromotionActionUnchanged, result.Items[1].Action)
+	assert.Equal(t, models.OperationEntityReward, result.Items[2].Entity)
+	assert.Equal(t, models.PromotionActionCreated, result.Items[2].Action)
+
+	repos.targetAchievements.AssertExpectations(t)
+	repos.targetRewards.AssertExpectations(t)
+	repos.targetAchievements.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestPromotionService_Promote_Conflict(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &models.Achievement{ID: "a1", Title: "朝活", Point: 15}
+	target := &models.Achievement{ID: "a1", Title: "朝活", Point: 10, AttachmentKey: "prod/a1.png", CreatedAt: created, Version: 4}
+
+	t.Run("skip", func(t *testing.T) {
+		service, repos := newPromotionTestService()
+		repos.sourceAchievements.On("GetByID", "a1").Return(source, nil)
+		repos.targetAchievements.On("GetByID", "a1").Return(target, nil)
+
+		result, err := service.Promote(context.Background(), models.PromotionOptions{AchievementIDs: []string{"a1"}})
+		require.NoError(t, err)
+		assert.Equal(t, models.PromotionActionSkipped, result.Items[0].Action)
+		repos.targetAchievements.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		service, repos := newPromotionTestService()
+		repos.sourceAchievements.On("GetByID", "a1").Return(source, nil)
+		repos.targetAchievements.On("GetByID", "a1").Return(target, nil)
+		// 昇格先の添付ファイル・作成日時は残し、読み取ったバージョンで更新する
+		repos.targetAchievements.On("Update", &models.Achievement{ID: "a1", Title: "朝活", Point: 15, AttachmentKey: "prod/a1.png", CreatedAt: created, Version: 4}).Return(nil)
+
+		result, err := service.Promote(context.Background(), models.PromotionOptions{AchievementIDs: []string{"a1"}, Conflict: models.PromotionConflictOverwrite})
+		require.NoError(t, err)
+		assert.Equal(t, models.PromotionActionUpdated, result.Items[0].Action)
+		repos.targetAchievements.AssertExpectations(t)
+	})
+}
+
+func TestPromotionService_Promote_MatchByTitle(t *testing.T) {
+	service, repos := newPromotionTestService()
+
+	repos.sourceAchievements.On("List").Return([]*models.Achievement{
+		{ID: "s1", Title: "朝活", Point: 10},
+		{ID: "s2", Title: "読書", Point: 20},
+	}, nil)
+	repos.sourceRewards.On("List").Return([]*models.Reward{{ID: "s3", Title: "コーヒー", Point: 30}}, nil)
+	repos.targetAchievements.On("List").Return([]*models.Achievement{{ID: "p1", Title: "朝活", Point: 5, Version: 2}}, nil)
+	repos.targetRewards.On("List").Return([]*models.Reward{{ID: "p3", Title: "コーヒー", Description: "本番", Point: 30, Version: 1}}, nil)
+
+	repos.targetAchievements.On("Update", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "p1" && a.Point == 10 && a.Version == 2
+	})).Return(nil)
+	// タイトルで照合する場合は昇格先で新しいIDを割り当てる
+	repos.targetAchievements.On("Create", mock.MatchedBy(func(a *models.Achievement) bool {
+		return a.ID == "" && a.Title == "読書"
+	})).Return(nil)
+	repos.targetRewards.On("Update", &models.Reward{ID: "p3", Title: "コーヒー", Point: 30, Version: 1}).Return(nil)
+
+	result, err := service.Promote(context.Background(), models.PromotionOptions{All: true, Match: models.PromotionMatchTitle, Conflict: models.PromotionConflictOverwrite})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, "p1", result.Items[0].TargetID)
+	assert.Equal(t, models.PromotionActionUpdated, result.Items[0].Action)
+	assert.Equal(t, models.PromotionActionCreated, result.Items[1].Action)
+	assert.Equal(t, "p3", result.Items[2].TargetID)
+	repos.targetAchievements.AssertExpectations(t)
+	repos.targetRewards.AssertExpectations(t)
+}
+
+func TestPromotionService_Promote_DryRun(t *testing.T) {
+	service, repos := newPromotionTestService()
+
+	repos.sourceAchievements.On("GetByID", "a1").Return(&models.Achievement{ID: "a1", Title: "朝活", Point: 15}, nil)
+	repos.sourceAchievements.On("GetByID", "a2").Return(&models.Achievement{ID: "a2", Title: "読書", Point: 20}, nil)
+	repos.targetAchievements.On("GetByID", "a1").Return(&models.Achievement{ID: "a1", Title: "朝活", Point: 10}, nil)
+	repos.targetAchievements.On("GetByID", "a2").Return(nil, errors.ErrNotFound)
+
+	result, err := service.Promote(context.Background(), models.PromotionOptions{
+		AchievementIDs: []string{"a1", "a2"},
+		Conflict:       models.PromotionConflictOverwrite,
+		DryRun:         true,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, models.PromotionActionUpdated, result.Items[0].Action)
+	assert.Equal(t, models.PromotionActionCreated, result.Items[1].Action)
+	repos.targetAchievements.AssertNotCalled(t, "Create", mock.Anything)
+	repos.targetAchievements.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestPromotionService_Promote_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nothing selected", func(t *testing.T) {
+		service, _ := newPromotionTestService()
+		_, err := service.Promote(ctx, models.PromotionOptions{})
+		assert.IsType(t, &errors.ValidationError{}, err)
+	})
+
+	t.Run("invalid match", func(t *testing.T) {
+		service, _ := newPromotionTestService()
+		_, err := service.Promote(ctx, models.PromotionOptions{All: true, Match: "name"})
+		assert.IsType(t, &errors.ValidationError{}, err)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		service, repos := newPromotionTestService()
+		repos.sourceRewards.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+		_, err := service.Promote(ctx, models.PromotionOptions{RewardIDs: []string{"missing"}})
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("duplicate target titles", func(t *testing.T) {
+		service, repos := newPromotionTestService()
+		repos.sourceAchievements.On("GetByID", "a1").Return(&models.Achievement{ID: "a1", Title: "朝活", Point: 10}, nil)
+		repos.targetAchievements.On("List").Return([]*models.Achievement{
+			{ID: "p1", Title: "朝活", Point: 10},
+			{ID: "p2", Title: "朝活", Point: 20},
+		}, nil)
+		_, err := service.Promote(ctx, models.PromotionOptions{AchievementIDs: []string{"a1"}, Match: models.PromotionMatchTitle})
+		assert.IsType(t, &errors.BusinessLogicError{}, err)
+	})
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// QuestServiceImpl クエストサービスの実装
+type QuestServiceImpl struct {
+	questRepo       repository.QuestRepository
+	achievementRepo repository.AchievementRepository
+	limits          Limits
+	now             func() time.Time
+}
+
+// NewQuestService クエストサービスを作成
+func NewQuestService(questRepo repository.QuestRepository, achievementRepo repository.AchievementRepository, limits Limits) QuestService {
+	return &QuestServiceImpl{
+		questRepo:       questRepo,
+		achievementRepo: achievementRepo,
+		limits:          limits,
+		now:             time.Now,
+	}
+}
+
+// Create クエストを作成（ステップは既存の達成目録を重複なく指定する。完了の判定は Evaluate で行う）
+func (s *QuestServiceImpl) Create(ctx context.Context, quest *models.Quest) error {
+	if quest == nil {
+		return &errors.ValidationError{Field: "quest", Message: "quest cannot be nil"}
+	}
+
+	quest.Title = normalizeText(quest.Title)
+	quest.Description = normalizeText(quest.Description)
+	if err := validateText(quest.Title, quest.Description); err != nil {
+		return err
+	}
+	if err := s.validateSteps(ctx, quest); err != nil {
+		return err
+	}
+	if quest.BonusPoint < 0 {
+		return &errors.ValidationError{Field: "bonus_point", Message: "bonus_point cannot be negative"}
+	}
+	if max := s.limits.maxPoint(); quest.BonusPoint > max {
+		return &errors.ValidationError{Field: "bonus_point", Message: fmt.Sprintf("bonus_point must be at most %d", max)}
+	}
+
+	quest.CompletedAt = nil
+	return s.questRepo.Create(ctx, quest)
+}
+
+// GetByID IDでクエストと進捗を取得
+func (s *QuestServiceImpl) GetByID(ctx context.Context, id string) (*models.QuestProgress, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	quest, err := s.questRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.progress(ctx, []*models.Quest{quest})
+	if err != nil {
+		return nil, err
+	}
+	return progress[0], nil
+}
+
+// List すべてのクエストと進捗を作成日時順に取得
+func (s *QuestServiceImpl) List(ctx context.Context) ([]*models.QuestProgress, error) {
+	quests, err := s.questRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, quests)
+}
+
+// Delete クエストを削除（完了時に付与したボーナスは取り消さない）
+func (s *QuestServiceImpl) Delete(ctx context.Context, id string) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
+	}
+	return s.questRepo.Delete(ctx, id)
+}
+
+// Evaluate 未完了のクエストの進捗を確認し、すべてのステップを達成したクエストの完了を記録してボーナスを付与する
+//
+// 達成目録の達成の後に呼び出す。同時に評価して先に完了を記録されたクエストは返さない（ボーナスは1回だけ付与する）。
+func (s *QuestServiceImpl) Evaluate(ctx context.Context) ([]*models.QuestProgress, error) {
+	quests, err := s.questRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*models.Quest
+	for _, quest := range quests {
+		if quest.CompletedAt == nil {
+			pending = append(pending, quest)
+		}
+	}
+	completed := []*models.QuestProgress{}
+	if len(pending) == 0 {
+		return completed, nil
+	}
+
+	progress, err := s.progress(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range progress {
+		if p.Completed < len(p.Quest.Steps) {
+			continue
+		}
+
+		completedAt := s.now()
+		p.Quest.CompletedAt = &completedAt
+		if err := s.questRepo.Complete(ctx, p.Quest); err != nil {
+			if stderrors.Is(err, errors.ErrVersionConflict) {
+				continue
+			}
+			return nil, err
+		}
+		completed = append(completed, p)
+	}
+	return completed, nil
+}
+
+// validateSteps ステップの達成目録のIDの前後の空白を除き、1つ以上・重複なし・既存の達成目録であることを検証
+func (s *QuestServiceImpl) validateSteps(ctx context.Context, quest *models.Quest) error {
+	if len(quest.Steps) == 0 {
+		return &errors.ValidationError{Field: "steps", Message: "steps must contain at least one achievement"}
+	}
+
+	seen := make(map[string]bool, len(quest.Steps))
+	for i, id := range quest.Steps {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return &errors.ValidationError{Field: "steps", Message: "steps cannot contain an empty achievement id"}
+		}
+		if seen[id] {
+			return &errors.ValidationError{Field: "steps", Message: fmt.Sprintf("achievement %s appears more than once in steps", id)}
+		}
+		seen[id] = true
+		quest.Steps[i] = id
+
+		if _, err := s.achievementRepo.GetByID(ctx, id); err != nil {
+			if stderrors.Is(err, errors.ErrNotFound) {
+				return &errors.ValidationError{Field: "steps", Message: fmt.Sprintf("achievement %s not found", id)}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// progress クエストの進捗を計算（達成目録のタイトルと達成記録は一度だけ取得する）
+func (s *QuestServiceImpl) progress(ctx context.Context, quests []*models.Quest) ([]*models.QuestProgress, error) {
+	progress := make([]*models.QuestProgress, 0, len(quests))
+	if len(quests) == 0 {
+		return progress, nil
+	}
+
+	achievements, err := s.achievementRepo.ListSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[string]string, len(achievements))
+	for _, achievement := range achievements {
+		titles[achievement.ID] = achievement.Title
+	}
+
+	completions := map[string][]*models.Completion{}
+	for _, quest := range quests {
+		for _, id := range quest.Steps {
+			if _, loaded := completions[id]; loaded {
+				continue
+			}
+			list, err := s.achievementRepo.ListCompletions(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			completions[id] = list
+		}
+	}
+
+	for _, quest := range quests {
+		progress = append(progress, questProgressOf(quest, titles, completions))
+	}
+	return progress, nil
+}
+
+// questProgressOf クエストの進捗を計算
+//
+// 各ステップは、前のステップを達成した後（最初のステップはクエストの作成後）の最初の達成で達成済みとする。
+// 未達成の最初のステップだけが解放され、それより後のステップの達成は数えない。
+func questProgressOf(quest *models.Quest, titles map[string]string, completions map[string][]*models.Completion) *models.QuestProgress {
+	progress := &models.QuestProgress{Quest: quest, Steps: make([]*models.QuestStep, len(quest.Steps))}
+
+	after := quest.CreatedAt
+	unlocked := true
+	for i, id := range quest.Steps {
+		step := &models.QuestStep{AchievementID: id, Title: titles[id], Status: models.QuestStepLocked}
+		if unlocked {
+			if at := firstCompletionAfter(completions[id], after); at != nil {
+				step.Status = models.QuestStepCompleted
+				step.CompletedAt = at
+				after = *at
+				progress.Completed++
+			} else {
+				step.Status = models.QuestStepUnlocked
+				unlocked = false
+			}
+		}
+		progress.Steps[i] = step
+	}
+	return progress
+}
+
+// firstCompletionAfter after より後の最初の達成日時（ない場合はnil）
+func firstCompletionAfter(completions []*models.Completion, after time.Time) *time.Time {
+	var first *time.Time
+	for _, completion := range completions {
+		if !completion.CompletedAt.After(after) {
+			continue
+		}
+		if first == nil || completion.CompletedAt.Before(*first) {
+			at := completion.CompletedAt
+			first = &at
+		}
+	}
+	return first
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockQuestRepository クエストリポジトリのモック
+type MockQuestRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuestRepository) Create(ctx context.Context, quest *models.Quest) error {
+	args := m.Called(quest)
+	return args.Error(0)
+}
+
+func (m *MockQuestRepository) GetByID(ctx context.Context, id string) (*models.Quest, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Quest), args.Error(1)
+}
+
+func (m *MockQuestRepository) List(ctx context.Context) ([]*models.Quest, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Quest), args.Error(1)
+}
+
+func (m *MockQuestRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockQuestRepository) Complete(ctx context.Context, quest *models.Quest) error {
+	args := m.Called(quest)
+	return args.Error(0)
+}
+
+func TestQuestProgressOf(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 6, 1, hour, 0, 0, 0, time.UTC) }
+	quest := &models.Quest{ID: "quest-1", Steps: []string{"wake-up", "run", "shower"}, CreatedAt: at(5)}
+	titles := map[string]string{"wake-up": "早起き", "run": "ランニング"}
+
+	// ランニングは早起きより前（7時）の達成を数えず、早起きの後（9時）の達成で達成済みにする
+	// シャワーは早起きの後だが、ランニングより前（8時）の達成のため数えない
+	progress := questProgressOf(quest, titles, map[string][]*models.Completion{
+		"wake-up": completionsOn("wake-up", at(4), at(8)),
+		"run":     completionsOn("run", at(7), at(10), at(9)),
+		"shower":  completionsOn("shower", at(8)),
+	})
+
+	assert.Equal(t, 2, progress.Completed)
+	require.Len(t, progress.Steps, 3)
+	assert.Equal(t, models.QuestStepCompleted, progress.Steps[0].Status)
+	assert.Equal(t, at(8), *progress.Steps[0].CompletedAt)
+	assert.Equal(t, models.QuestStepCompleted, progress.Steps[1].Status)
+	assert.Equal(t, at(9), *progress.Steps[1].CompletedAt)
+	assert.Equal(t, "ランニング", progress.Steps[1].Title)
+	assert.Equal(t, models.QuestStepUnlocked, progress.Steps[2].Status)
+	assert.Nil(t, progress.Steps[2].CompletedAt)
+
+	// 前のステップが未達成の間は、後のステップの達成を数えない
+	locked := questProgressOf(quest, titles, map[string][]*models.Completion{
+		"run":    completionsOn("run", at(9)),
+		"shower": completionsOn("shower", at(10)),
+	})
+	assert.Equal(t, 0, locked.Completed)
+	assert.Equal(t, models.QuestStepUnlocked, locked.Steps[0].Status)
+	assert.Equal(t, models.QuestStepLocked, locked.Steps[1].Status)
+	assert.Equal(t, models.QuestStepLocked, locked.Steps[2].Status)
+}
+
+func TestQuestService_Create(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("GetByID", "wake-up").Return(&models.Achievement{ID: "wake-up"}, nil)
+	achievementRepo.On("GetByID", "run").Return(&models.Achievement{ID: "run"}, nil)
+	questRepo := new(MockQuestRepository)
+	questRepo.On("Create", mock.AnythingOfType("*models.Quest")).Return(nil)
+
+	service := NewQuestService(questRepo, achievementRepo, Limits{})
+	completedAt := time.Now()
+	quest := &models.Quest{Title: " 朝活 ", Steps: []string{"wake-up", " run"}, BonusPoint: 50, CompletedAt: &completedAt}
+	require.NoError(t, service.Create(context.Background(), quest))
+
+	assert.Equal(t, "朝活", quest.Title)
+	assert.Equal(t, []string{"wake-up", "run"}, quest.Steps)
+	assert.Nil(t, quest.CompletedAt)
+	questRepo.AssertExpectations(t)
+}
+
+func TestQuestService_Create_Validation(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("GetByID", "run").Return(&models.Achievement{ID: "run"}, nil)
+	achievementRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	service := NewQuestService(new(MockQuestRepository), achievementRepo, Limits{MaxPoint: 1000})
+
+	tests := []struct {
+		name  string
+		quest *models.Quest
+		field string
+	}{
+		{"タイトルなし", &models.Quest{Steps: []string{"run"}}, "title"},
+		{"ステップなし", &models.Quest{Title: "朝活"}, "steps"},
+		{"重複したステップ", &models.Quest{Title: "朝活", Steps: []string{"run", "run"}}, "steps"},
+		{"存在しない達成目録", &models.Quest{Title: "朝活", Steps: []string{"run", "missing"}}, "steps"},
+		{"マイナスのボーナス", &models.Quest{Title: "朝活", Steps: []string{"run"}, BonusPoint: -1}, "bonus_point"},
+		{"上限を超えるボーナス", &models.Quest{Title: "朝活", Steps: []string{"run"}, BonusPoint: 1001}, "bonus_point"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *errors.ValidationError
+			require.ErrorAs(t, service.Create(context.Background(), tt.quest), &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestQuestService_Evaluate(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	completedAt := createdAt.Add(time.Hour)
+	finished := &models.Quest{ID: "finished", Title: "朝活", Steps: []string{"wake-up", "run"}, BonusPoint: 50, CreatedAt: createdAt}
+	ongoing := &models.Quest{ID: "ongoing", Title: "夜活", Steps: []string{"read", "run"}, CreatedAt: createdAt}
+	done := &models.Quest{ID: "done", Title: "完了済み", Steps: []string{"run"}, CreatedAt: createdAt, CompletedAt: &completedAt}
+
+	questRepo := new(MockQuestRepository)
+	questRepo.On("List").Return([]*models.Quest{finished, ongoing, done}, nil)
+	questRepo.On("Complete", finished).Return(nil).Once()
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("ListSummaries").Return([]*models.Achievement{{ID: "wake-up", Title: "早起き"}, {ID: "run", Title: "ランニング"}}, nil)
+	achievementRepo.On("ListCompletions", "wake-up").Return(completionsOn("wake-up", createdAt.Add(time.Hour)), nil)
+	achievementRepo.On("ListCompletions", "run").Return(completionsOn("run", createdAt.Add(2*time.Hour)), nil)
+	achievementRepo.On("ListCompletions", "read").Return([]*models.Completion{}, nil)
+
+	service := NewQuestService(questRepo, achievementRepo, Limits{}).(*QuestServiceImpl)
+	now := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	completed, err := service.Evaluate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+	assert.Equal(t, "finished", completed[0].Quest.ID)
+	assert.Equal(t, now, *completed[0].Quest.CompletedAt)
+	assert.Equal(t, 2, completed[0].Completed)
+	questRepo.AssertExpectations(t)
+
+	// 同時に評価して先に完了を記録されたクエストは返さない
+	finished.CompletedAt = nil
+	questRepo.On("Complete", finished).Return(errors.ErrVersionConflict).Once()
+	completed, err = service.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, completed)
+}
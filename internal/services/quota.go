@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+// checkDailyQuota 今日の獲得ポイントに points を加えても1日の上限以内か確認し、ポイントを付与するかを返す
+//
+// 上限を超える場合、OverQuota が zero なら false を返し（作成・達成は記録してポイントを付与しない）、
+// それ以外は BusinessLogicError を返す。日付は連続達成日数と同じタイムゾーンで区切る。
+//
+// 確認は書き込みとは別に行うため、同時に行われた作成・達成はどちらも確認を通り、合計が上限をわずかに超えることがある
+// （達成回数の上限のようにストレージでは確認しない）。上限は厳密な制限ではなく、1日の獲得ポイントの目安として使用する。
+func (s *AchievementServiceImpl) checkDailyQuota(ctx context.Context, operation string, points int, at time.Time) (bool, error) {
+	if s.limits.DailyQuota <= 0 || points <= 0 {
+		return true, nil
+	}
+
+	earned, err := s.earnedOn(ctx, at)
+	if err != nil {
+		return false, err
+	}
+	if earned+points <= s.limits.DailyQuota {
+		return true, nil
+	}
+
+	if s.limits.OverQuota == OverQuotaZero {
+		return false, nil
+	}
+	return false, &errors.BusinessLogicError{
+		Operation: operation,
+		Reason:    fmt.Sprintf("at most %d points can be earned per day (%d earned today)", s.limits.DailyQuota, earned),
+		Code:      errors.CodeDailyQuotaExceeded,
+	}
+}
+
+// earnedOn at を含む日に達成目録の作成・達成・ボーナスで獲得したポイント
+//
+// その日のポイント台帳の付与とボーナスを合計する。達成目録に紐づかない加算（参照先の無い付与）は含めない。
+func (s *AchievementServiceImpl) earnedOn(ctx context.Context, at time.Time) (int, error) {
+	dayStart, dayEnd := s.dayBounds(at)
+	entries, err := s.pointRepo.GetLedgerBetween(ctx, dayStart, dayEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	earned := 0
+	for _, entry := range entries {
+		if entry.Type != models.LedgerEntryGrant && entry.Type != models.LedgerEntryBonus {
+			continue
+		}
+		if entry.Reference == "" || entry.Amount <= 0 {
+			continue
+		}
+		earned += entry.Amount
+	}
+	return earned, nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newQuotaTestService 1日の獲得ポイントの上限を設定し、現在時刻を固定した達成目録サービスを作成
+func newQuotaTestService(achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository, limits Limits, location *time.Location, now time.Time) *AchievementServiceImpl {
+	service := NewAchievementServiceWithLimits(achievementRepo, pointRepo, StreakSettings{Location: location}, limits).(*AchievementServiceImpl)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+// quotaLedger 日本時間の2024-06-10に50ポイント獲得した台帳（その日のエントリ）
+func quotaLedger(tokyo *time.Location) []*models.PointLedgerEntry {
+	return []*models.PointLedgerEntry{
+		{Type: models.LedgerEntryGrant, Amount: 30, Reference: "a1", CreatedAt: time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo)},
+		{Type: models.LedgerEntryBonus, Amount: 20, Reference: "c1", CreatedAt: time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo)},
+		// 達成目録に紐づかない加算と使用は含めない
+		{Type: models.LedgerEntryGrant, Amount: 500, CreatedAt: time.Date(2024, 6, 10, 9, 0, 0, 0, tokyo)},
+		{Type: models.LedgerEntrySpend, Amount: -40, Reference: "h1", CreatedAt: time.Date(2024, 6, 10, 9, 0, 0, 0, tokyo)},
+	}
+}
+
+// quotaDayStart 台帳を取得する日本時間の2024-06-10の開始
+func quotaDayStart(tokyo *time.Location) time.Time {
+	return time.Date(2024, 6, 10, 0, 0, 0, 0, tokyo)
+}
+
+func TestAchievementService_Create_DailyQuota(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, tokyo)
+
+	t.Run("within quota", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		pointRepo := new(MockPointRepository)
+		pointRepo.On("GetLedgerBetween", quotaDayStart(tokyo), quotaDayStart(tokyo).AddDate(0, 0, 1)).Return(quotaLedger(tokyo), nil)
+		achievementRepo.On("CreateWithPoints", mock.AnythingOfType("*models.Achievement")).Return(nil)
+		service := newQuotaTestService(achievementRepo, pointRepo, Limits{DailyQuota: 100}, tokyo, now)
+
+		require.NoError(t, service.Create(context.Background(), &models.Achievement{Title: "読書", Point: 50}))
+		achievementRepo.AssertExpectations(t)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		pointRepo := new(MockPointRepository)
+		pointRepo.On("GetLedgerBetween", quotaDayStart(tokyo), quotaDayStart(tokyo).AddDate(0, 0, 1)).Return(quotaLedger(tokyo), nil)
+		service := newQuotaTestService(achievementRepo, pointRepo, Limits{DailyQuota: 100}, tokyo, now)
+
+		err := service.Create(context.Background(), &models.Achievement{Title: "読書", Point: 51})
+		var businessErr *errors.BusinessLogicError
+		require.ErrorAs(t, err, &businessErr)
+		assert.Contains(t, businessErr.Reason, "at most 100 points can be earned per day (50 earned today)")
+		achievementRepo.AssertNotCalled(t, "CreateWithPoints", mock.Anything)
+		achievementRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		pointRepo := new(MockPointRepository)
+		pointRepo.On("GetLedgerBetween", quotaDayStart(tokyo), quotaDayStart(tokyo).AddDate(0, 0, 1)).Return(quotaLedger(tokyo), nil)
+		achievementRepo.On("Create", mock.AnythingOfType("*models.Achievement")).Return(nil)
+		service := newQuotaTestService(achievementRepo, pointRepo, Limits{DailyQuota: 100, OverQuota: OverQuotaZero}, tokyo, now)
+
+		// 達成目録は作成し、ポイントは付与しない
+		require.NoError(t, service.Create(context.Background(), &models.Achievement{Title: "読書", Point: 51}))
+		achievementRepo.AssertExpectations(t)
+		achievementRepo.AssertNotCalled(t, "CreateWithPoints", mock.Anything)
+	})
+}
+
+func TestAchievementService_Complete_DailyQuota(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, tokyo)
+	achievement := &models.Achievement{ID: "run", Title: "ランニング", Point: 60}
+
+	t.Run("reject", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		pointRepo := new(MockPointRepository)
+		achievementRepo.On("GetByID", "run").Return(achievement, nil)
+		pointRepo.On("GetLedgerBetween", quotaDayStart(tokyo), quotaDayStart(tokyo).AddDate(0, 0, 1)).Return(quotaLedger(tokyo), nil)
+		service := newQuotaTestService(achievementRepo, pointRepo, Limits{DailyQuota: 100}, tokyo, now)
+
+		_, err := service.Complete(context.Background(), "run")
+		assert.IsType(t, &errors.BusinessLogicError{}, err)
+		achievementRepo.AssertNotCalled(t, "Complete", mock.Anything)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		pointRepo := new(MockPointRepository)
+		achievementRepo.On("GetByID", "run").Return(achievement, nil)
+		pointRepo.On("GetLedgerBetween", quotaDayStart(tokyo), quotaDayStart(tokyo).AddDate(0, 0, 1)).Return(quotaLedger(tokyo), nil)
+		achievementRepo.On("Complete", mock.MatchedBy(func(c *models.Completion) bool {
+			return c.AchievementID == "run" && c.Point == 0 && c.BonusPoint == 0
+		})).Return(nil)
+		service := newQuotaTestService(achievementRepo, pointRepo, Limits{DailyQuota: 100, OverQuota: OverQuotaZero}, tokyo, now)
+
+		completion, err := service.Complete(context.Background(), "run")
+		require.NoError(t, err)
+		assert.Equal(t, 0, completion.Point)
+		achievementRepo.AssertExpectations(t)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		pointRepo := new(MockPointRepository)
+		achievementRepo.On("GetByID", "run").Return(achievement, nil)
+		achievementRepo.On("Complete", mock.AnythingOfType("*models.Completion")).Return(nil)
+		service := newQuotaTestService(achievementRepo, pointRepo, Limits{}, tokyo, now)
+
+		completion, err := service.Complete(context.Background(), "run")
+		require.NoError(t, err)
+		assert.Equal(t, 60, completion.Point)
+		pointRepo.AssertNotCalled(t, "GetLedgerBetween", mock.Anything, mock.Anything)
+	})
+}
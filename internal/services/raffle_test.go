@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// rafflePrizes 重みの合計が10の景品
+func rafflePrizes() []models.RafflePrize {
+	return []models.RafflePrize{
+		{Title: "コーヒー", Weight: 7},
+		{Title: "ランチ", Weight: 2},
+		{Title: "映画", Weight: 1},
+	}
+}
+
+func TestRewardService_DrawPrize(t *testing.T) {
+	service := NewRewardService(new(MockRewardRepository), new(MockPointRepository)).(*RewardServiceImpl)
+
+	tests := []struct {
+		draw     int
+		expected string
+	}{
+		{draw: 0, expected: "コーヒー"},
+		{draw: 6, expected: "コーヒー"},
+		{draw: 7, expected: "ランチ"},
+		{draw: 8, expected: "ランチ"},
+		{draw: 9, expected: "映画"},
+	}
+	for _, tt := range tests {
+		service.random = func(n int) int {
+			assert.Equal(t, 10, n)
+			return tt.draw
+		}
+		assert.Equal(t, tt.expected, service.drawPrize(rafflePrizes()), "draw %d", tt.draw)
+	}
+
+	// 景品が無い通常の報酬は抽選しない
+	service.random = func(n int) int {
+		t.Fatal("random should not be called without prizes")
+		return 0
+	}
+	assert.Equal(t, "", service.drawPrize(nil))
+}
+
+func TestRewardService_Redeem_Raffle(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	pointRepo := new(MockPointRepository)
+	rewardRepo.On("GetByID", "gacha").Return(&models.Reward{ID: "gacha", Title: "ガチャ", Point: 30, Prizes: rafflePrizes(), CreatedAt: time.Now()}, nil)
+	pointRepo.On("GetCurrentPointsConsistent").Return(&models.CurrentPoints{Point: 100}, nil)
+	pointRepo.On("RedeemPoints", mock.MatchedBy(func(h *models.RewardHistory) bool {
+		return h.RewardID == "gacha" && h.PointCost == 30 && h.Prize == "ランチ"
+	})).Return(nil)
+
+	service := NewRewardService(rewardRepo, pointRepo).(*RewardServiceImpl)
+	service.random = func(n int) int { return 8 }
+
+	history, err := service.Redeem(context.Background(), "gacha")
+	require.NoError(t, err)
+	assert.Equal(t, "ランチ", history.Prize)
+	pointRepo.AssertExpectations(t)
+}
+
+func TestRewardService_Create_InvalidPrizes(t *testing.T) {
+	tooMany := make([]models.RafflePrize, maxPrizes+1)
+	for i := range tooMany {
+		tooMany[i] = models.RafflePrize{Title: strings.Repeat("景", i+1), Weight: 1}
+	}
+
+	tests := []struct {
+		name   string
+		prizes []models.RafflePrize
+	}{
+		{name: "タイトルが空", prizes: []models.RafflePrize{{Title: "  ", Weight: 1}}},
+		{name: "重みが0", prizes: []models.RafflePrize{{Title: "コーヒー", Weight: 0}}},
+		{name: "タイトルが重複", prizes: []models.RafflePrize{{Title: "コーヒー", Weight: 1}, {Title: "コーヒー ", Weight: 2}}},
+		{name: "景品が多すぎる", prizes: tooMany},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rewardRepo := new(MockRewardRepository)
+			service := NewRewardService(rewardRepo, new(MockPointRepository))
+
+			err := service.Create(context.Background(), &models.Reward{Title: "ガチャ", Point: 30, Prizes: tt.prizes})
+			var validationErr *errors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "prizes", validationErr.Field)
+			rewardRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// earningRateDays 次の報酬を獲得できるまでの日数の見込みに使う獲得ペースの日数
+const earningRateDays = 30
+
+// RecommendationServiceImpl 現在のポイントで獲得できる報酬を提案するサービスの実装
+type RecommendationServiceImpl struct {
+	rewardRepo      repository.RewardRepository
+	achievementRepo repository.AchievementRepository
+	pointRepo       repository.PointRepository
+	reservationRepo repository.ReservationRepository
+	location        *time.Location
+	now             func() time.Time
+}
+
+// NewRecommendationService 報酬の提案サービスを作成（reservationRepo がnilの場合は取り置きを考慮しない。location は獲得ペースの日付の区切りに使うタイムゾーン）
+func NewRecommendationService(rewardRepo repository.RewardRepository, achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository, reservationRepo repository.ReservationRepository, location *time.Location) RecommendationService {
+	if location == nil {
+		location = time.Local
+	}
+	return &RecommendationServiceImpl{
+		rewardRepo:      rewardRepo,
+		achievementRepo: achievementRepo,
+		pointRepo:       pointRepo,
+		reservationRepo: reservationRepo,
+		location:        location,
+		now:             time.Now,
+	}
+}
+
+// Affordable 現在のポイントで獲得できる報酬を、獲得した後に残るポイントが少ない（残高に近い）順に取得する
+//
+// 報酬の獲得と同じく、他の報酬のために取り置いたポイントは使えない。
+// 獲得できない報酬のうち最も少ないポイントで獲得できる報酬について、直近30日の獲得ペースから獲得できるまでの日数を見積もる。
+func (s *RecommendationServiceImpl) Affordable(ctx context.Context) (*models.AffordableRewards, error) {
+	now := s.now()
+
+	rewards, err := s.rewardRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.pointRepo.GetCurrentPoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := 0
+	own := map[string]int{}
+	if s.reservationRepo != nil {
+		reservations, err := s.reservationRepo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range reservations {
+			reserved += reservation.Points
+			own[reservation.RewardID] = reservation.Points
+		}
+	}
+
+	result := &models.AffordableRewards{
+		Balance:     current.Point,
+		Spendable:   spendable(current.Point, reserved),
+		Rewards:     []*models.AffordableReward{},
+		GeneratedAt: now,
+	}
+
+	var next *models.Reward
+	nextShortfall := 0
+	for _, reward := range rewards {
+		// その報酬のための取り置きは獲得に使える
+		available := current.Point - (reserved - own[reward.ID])
+		if reward.Point <= available {
+			result.Rewards = append(result.Rewards, &models.AffordableReward{Reward: reward, Remaining: available - reward.Point})
+			continue
+		}
+		if shortfall := reward.Point - available; next == nil || shortfall < nextShortfall {
+			next, nextShortfall = reward, shortfall
+		}
+	}
+	// 一覧の順（作成日時順）を保ったまま、残るポイントが少ない順に並べる
+	sort.SliceStable(result.Rewards, func(i, j int) bool {
+		return result.Rewards[i].Remaining < result.Rewards[j].Remaining
+	})
+
+	earned, err := earnings(ctx, s.achievementRepo)
+	if err != nil {
+		return nil, err
+	}
+	year, month, day := now.In(s.location).Date()
+	tomorrow := time.Date(year, month, day+1, 0, 0, 0, 0, s.location)
+	result.AverageDailyPoints = window(earningRateDays, tomorrow, earned, nil).AverageDailyPoints
+
+	if next != nil {
+		result.Next = next
+		result.PointsNeeded = nextShortfall
+		if result.AverageDailyPoints > 0 {
+			days := int(math.Ceil(float64(nextShortfall) / result.AverageDailyPoints))
+			result.DaysUntilNext = &days
+		}
+	}
+
+	return result, nil
+}
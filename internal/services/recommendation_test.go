@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendationService_Affordable(t *testing.T) {
+	now := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("List").Return([]*models.Reward{
+		{ID: "coffee", Title: "コーヒー", Point: 50},
+		{ID: "book", Title: "本", Point: 120},
+		{ID: "switch", Title: "Switchのゲーム", Point: 250},
+		{ID: "tea", Title: "紅茶", Point: 90},
+		{ID: "trip", Title: "旅行", Point: 400},
+	}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 200}, nil)
+	reservationRepo := new(MockReservationRepository)
+	reservationRepo.On("List").Return([]*models.Reservation{{RewardID: "switch", Points: 100}}, nil)
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "run", Title: "Run", Point: 90, CreatedAt: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)},
+	}, nil)
+	achievementRepo.On("ListCompletions", "run").Return([]*models.Completion{}, nil)
+
+	service := NewRecommendationService(rewardRepo, achievementRepo, pointRepo, reservationRepo, time.UTC).(*RecommendationServiceImpl)
+	service.now = func() time.Time { return now }
+
+	affordable, err := service.Affordable(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, affordable.Balance)
+	assert.Equal(t, 100, affordable.Spendable)
+	// Switchのために取り置いた100ポイントは他の報酬に使えないため、100ポイント以内の報酬を残高に近い順に並べる
+	require.Len(t, affordable.Rewards, 2)
+	assert.Equal(t, "tea", affordable.Rewards[0].Reward.ID)
+	assert.Equal(t, 10, affordable.Rewards[0].Remaining)
+	assert.Equal(t, "coffee", affordable.Rewards[1].Reward.ID)
+	assert.Equal(t, 50, affordable.Rewards[1].Remaining)
+
+	// 足りないポイントが最も少ないのは本（Switchは取り置きを含めても50ポイント足りない）
+	require.NotNil(t, affordable.Next)
+	assert.Equal(t, "book", affordable.Next.ID)
+	assert.Equal(t, 20, affordable.PointsNeeded)
+	// 直近30日の獲得は90ポイント（1日あたり3ポイント）
+	assert.Equal(t, 3.0, affordable.AverageDailyPoints)
+	require.NotNil(t, affordable.DaysUntilNext)
+	assert.Equal(t, 7, *affordable.DaysUntilNext)
+	assert.Equal(t, now, affordable.GeneratedAt)
+}
+
+func TestRecommendationService_Affordable_OwnReservation(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("List").Return([]*models.Reward{{ID: "switch", Title: "Switchのゲーム", Point: 250}}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 300}, nil)
+	reservationRepo := new(MockReservationRepository)
+	reservationRepo.On("List").Return([]*models.Reservation{{RewardID: "switch", Points: 250}}, nil)
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{}, nil)
+
+	service := NewRecommendationService(rewardRepo, achievementRepo, pointRepo, reservationRepo, time.UTC)
+
+	affordable, err := service.Affordable(context.Background())
+	require.NoError(t, err)
+
+	// 取り置いた報酬には取り置いたポイントを使える
+	assert.Equal(t, 50, affordable.Spendable)
+	require.Len(t, affordable.Rewards, 1)
+	assert.Equal(t, 50, affordable.Rewards[0].Remaining)
+	assert.Nil(t, affordable.Next)
+	assert.Nil(t, affordable.DaysUntilNext)
+}
+
+func TestRecommendationService_Affordable_NoEarnings(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("List").Return([]*models.Reward{{ID: "trip", Title: "旅行", Point: 400}}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 100}, nil)
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{}, nil)
+
+	// 取り置きのリポジトリがない場合は取り置きを考慮しない
+	service := NewRecommendationService(rewardRepo, achievementRepo, pointRepo, nil, time.UTC)
+
+	affordable, err := service.Affordable(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, affordable.Rewards)
+	require.NotNil(t, affordable.Next)
+	assert.Equal(t, 300, affordable.PointsNeeded)
+	// 獲得がない場合は日数を見積もらない
+	assert.Zero(t, affordable.AverageDailyPoints)
+	assert.Nil(t, affordable.DaysUntilNext)
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/cron"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// ReminderEventType 未達成の達成目録をリマインドする際に通知するイベントの種類
+const ReminderEventType = "achievements.reminder"
+
+// reminderTable リマインドのイベントのテーブルの識別子
+const reminderTable = "achievements"
+
+// dateLayout 期限の日付の形式
+const dateLayout = "2006-01-02"
+
+// ReminderServiceImpl リマインドサービスの実装
+type ReminderServiceImpl struct {
+	achievementRepo repository.AchievementRepository
+	notifier        events.Publisher
+	schedule        *cron.Schedule
+	location        *time.Location
+	now             func() time.Time
+}
+
+// NewReminderService リマインドサービスを作成
+//
+// schedule は reminder を指定していない期限のある達成目録をリマインドする時刻のcron式。
+// 日付と時刻は location（nilの場合はサーバーのローカル時刻）で判定し、notifier がnilの場合は通知しない。
+func NewReminderService(achievementRepo repository.AchievementRepository, notifier events.Publisher, schedule string, location *time.Location) (ReminderService, error) {
+	parsed, err := cron.Parse(schedule)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		location = time.Local
+	}
+
+	return &ReminderServiceImpl{
+		achievementRepo: achievementRepo,
+		notifier:        notifier,
+		schedule:        parsed,
+		location:        location,
+		now:             time.Now,
+	}, nil
+}
+
+// Pending 今日リマインドの対象になる達成目録（リマインドする時刻は問わない）
+//
+// reminder を指定した期限の無い達成目録は今日まだ達成していない場合、期限のある達成目録は期限の日から一度も達成していない間が対象になる。
+func (s *ReminderServiceImpl) Pending(ctx context.Context) ([]*models.Reminder, error) {
+	return s.collect(ctx, s.now(), false)
+}
+
+// NotifyDue at の分にリマインドする時刻を迎えた対象を通知し、通知した件数を返す
+//
+// スケジューラーが1分ごとに呼び出すことを想定しているため、同じ分に2回呼び出すと重複して通知する。
+func (s *ReminderServiceImpl) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	reminders, err := s.collect(ctx, at, true)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, reminder := range reminders {
+		if err := s.notify(ctx, reminder, at); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, stderrors.Join(errs...)
+}
+
+// collect at の日にリマインドの対象になる達成目録を取得（scheduled の場合は at の分にリマインドする時刻を迎えたものに限る）
+func (s *ReminderServiceImpl) collect(ctx context.Context, at time.Time, scheduled bool) ([]*models.Reminder, error) {
+	achievements, err := s.achievementRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	local := at.In(s.location)
+	today := local.Format(dateLayout)
+	reminders := []*models.Reminder{}
+	for _, achievement := range achievements {
+		reminder := reminderOf(achievement, today)
+		if reminder == nil {
+			continue
+		}
+		if reminder.Schedule == "" {
+			reminder.Schedule = s.schedule.String()
+		}
+		if scheduled {
+			schedule, err := cron.Parse(reminder.Schedule)
+			if err != nil || !schedule.Matches(local) {
+				// 作成時に検証しているため、解析できないのは検証を追加する前のデータのみ
+				continue
+			}
+		}
+
+		// 時刻を迎えた対象だけ達成記録を確認する
+		completions, err := s.achievementRepo.ListCompletions(ctx, achievement.ID)
+		if err != nil {
+			return nil, err
+		}
+		if s.completed(reminder, completions, today) {
+			continue
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
+
+// reminderOf 達成目録のリマインド（リマインドを設定していない・期限の日がまだ来ていない場合はnil）
+func reminderOf(achievement *models.Achievement, today string) *models.Reminder {
+	reminder := &models.Reminder{
+		AchievementID:    achievement.ID,
+		AchievementTitle: achievement.Title,
+		Schedule:         achievement.Reminder,
+	}
+
+	switch {
+	case achievement.DueDate != "":
+		// YYYY-MM-DD は文字列の比較で日付の前後を判定できる
+		if achievement.DueDate > today {
+			return nil
+		}
+		reminder.Kind = models.ReminderDue
+		reminder.DueDate = achievement.DueDate
+		reminder.Overdue = achievement.DueDate < today
+	case achievement.Reminder != "":
+		reminder.Kind = models.ReminderRecurring
+	default:
+		return nil
+	}
+	return reminder
+}
+
+// completed リマインドの対象の達成目録を達成済みか（期限のある達成目録は一度でも、毎日の達成目録は今日達成していれば達成済み）
+func (s *ReminderServiceImpl) completed(reminder *models.Reminder, completions []*models.Completion, today string) bool {
+	if reminder.Kind == models.ReminderDue {
+		return len(completions) > 0
+	}
+	for _, completion := range completions {
+		if completion.CompletedAt.In(s.location).Format(dateLayout) == today {
+			return true
+		}
+	}
+	return false
+}
+
+// notify リマインドのイベントを通知
+func (s *ReminderServiceImpl) notify(ctx context.Context, reminder *models.Reminder, at time.Time) error {
+	key := tenant.Key(ctx, reminder.AchievementID)
+	minute := at.Truncate(time.Minute)
+	event := events.Event{
+		ID:     fmt.Sprintf("%s:%s:%d", ReminderEventType, key, minute.UnixNano()),
+		Type:   ReminderEventType,
+		Table:  reminderTable,
+		Action: events.ActionReminded,
+		Key:    map[string]interface{}{"id": key},
+		Item: map[string]interface{}{
+			"achievement_id":    reminder.AchievementID,
+			"achievement_title": reminder.AchievementTitle,
+			"kind":              string(reminder.Kind),
+			"due_date":          reminder.DueDate,
+			"overdue":           reminder.Overdue,
+			"schedule":          reminder.Schedule,
+		},
+		OccurredAt: minute,
+		Source:     events.SourceReminderService,
+	}
+	if err := s.notifier.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to send reminder for achievement %s: %w", reminder.AchievementID, err)
+	}
+	return nil
+}
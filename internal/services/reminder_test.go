@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReminderTestService 現在時刻を固定したリマインドサービスを作成
+func newReminderTestService(t *testing.T, achievementRepo *MockAchievementRepository, notifier events.Publisher, location *time.Location, now time.Time) *ReminderServiceImpl {
+	t.Helper()
+	service, err := NewReminderService(achievementRepo, notifier, "0 20 * * *", location)
+	require.NoError(t, err)
+	impl := service.(*ReminderServiceImpl)
+	impl.now = func() time.Time { return now }
+	return impl
+}
+
+// reminderAchievements リマインドの設定が異なる達成目録
+func reminderAchievements() []*models.Achievement {
+	return []*models.Achievement{
+		{ID: "stretch", Title: "Stretch", Reminder: "0 21 * * *"},
+		{ID: "run", Title: "Run", Reminder: "0 7 * * *"},
+		{ID: "tax", Title: "Tax return", DueDate: "2024-06-09"},
+		{ID: "dentist", Title: "Dentist", DueDate: "2024-06-10", Reminder: "0 9 * * *"},
+		{ID: "trip", Title: "Trip", DueDate: "2024-06-20"},
+		{ID: "plain", Title: "Plain"},
+	}
+}
+
+func TestReminderService_Pending(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, tokyo)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return(reminderAchievements(), nil)
+	// UTCでは6月9日だが、日本時間では今日（6月10日）の達成
+	achievementRepo.On("ListCompletions", "stretch").Return(completionsOn("stretch", time.Date(2024, 6, 9, 23, 0, 0, 0, time.UTC)), nil)
+	// 昨日の達成だけでは今日の分は未達成
+	achievementRepo.On("ListCompletions", "run").Return(completionsOn("run", time.Date(2024, 6, 9, 7, 0, 0, 0, tokyo)), nil)
+	achievementRepo.On("ListCompletions", "tax").Return([]*models.Completion{}, nil)
+	// 期限のある達成目録は期限より前の達成でも達成済み
+	achievementRepo.On("ListCompletions", "dentist").Return(completionsOn("dentist", time.Date(2024, 6, 1, 10, 0, 0, 0, tokyo)), nil)
+
+	service := newReminderTestService(t, achievementRepo, nil, tokyo, now)
+	reminders, err := service.Pending(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.Reminder{
+		{AchievementID: "run", AchievementTitle: "Run", Kind: models.ReminderRecurring, Schedule: "0 7 * * *"},
+		{AchievementID: "tax", AchievementTitle: "Tax return", Kind: models.ReminderDue, DueDate: "2024-06-09", Overdue: true, Schedule: "0 20 * * *"},
+	}, reminders)
+	// 期限前・リマインドを設定していない達成目録は達成記録を確認しない
+	achievementRepo.AssertNotCalled(t, "ListCompletions", "trip")
+	achievementRepo.AssertNotCalled(t, "ListCompletions", "plain")
+}
+
+func TestReminderService_NotifyDue(t *testing.T) {
+	at := time.Date(2024, 6, 10, 20, 0, 30, 0, time.UTC)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return(reminderAchievements(), nil)
+	achievementRepo.On("ListCompletions", "tax").Return([]*models.Completion{}, nil)
+
+	var published []events.Event
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	service := newReminderTestService(t, achievementRepo, notifier, time.UTC, at)
+	sent, err := service.NotifyDue(context.Background(), at)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	// 20:00 に時刻を迎えるのは既定の時刻を使う期限のある達成目録だけ
+	require.Len(t, published, 1)
+	event := published[0]
+	assert.Equal(t, ReminderEventType, event.Type)
+	assert.Equal(t, events.ActionReminded, event.Action)
+	assert.Equal(t, events.SourceReminderService, event.Source)
+	assert.Equal(t, "tax", event.Item["achievement_id"])
+	assert.Equal(t, true, event.Item["overdue"])
+	assert.Equal(t, time.Date(2024, 6, 10, 20, 0, 0, 0, time.UTC), event.OccurredAt)
+	achievementRepo.AssertNotCalled(t, "ListCompletions", "stretch")
+	achievementRepo.AssertNotCalled(t, "ListCompletions", "run")
+}
+
+func TestReminderService_NotifyDue_ContinuesAfterFailure(t *testing.T) {
+	at := time.Date(2024, 6, 10, 21, 0, 0, 0, time.UTC)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "stretch", Title: "Stretch", Reminder: "0 21 * * *"},
+		{ID: "read", Title: "Read", Reminder: "0 21 * * *"},
+	}, nil)
+	achievementRepo.On("ListCompletions", "stretch").Return([]*models.Completion{}, nil)
+	achievementRepo.On("ListCompletions", "read").Return([]*models.Completion{}, nil)
+
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		if event.Item["achievement_id"] == "stretch" {
+			return stderrors.New("webhook unavailable")
+		}
+		return nil
+	})
+
+	service := newReminderTestService(t, achievementRepo, notifier, time.UTC, at)
+	sent, err := service.NotifyDue(context.Background(), at)
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestReminderService_NotifyDue_WithoutNotifier(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+
+	service := newReminderTestService(t, achievementRepo, nil, time.UTC, time.Now())
+	sent, err := service.NotifyDue(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	achievementRepo.AssertNotCalled(t, "List")
+}
+
+func TestNewReminderService_InvalidSchedule(t *testing.T) {
+	_, err := NewReminderService(new(MockAchievementRepository), nil, "every day", nil)
+	assert.Error(t, err)
+}
+
+func TestAchievementService_Create_ValidatesReminder(t *testing.T) {
+	service := NewAchievementService(new(MockAchievementRepository), new(MockPointRepository))
+
+	err := service.Create(context.Background(), &models.Achievement{Title: "Tax return", Point: 10, DueDate: "2024/06/09"})
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "due_date", validationErr.Field)
+
+	err = service.Create(context.Background(), &models.Achievement{Title: "Stretch", Point: 10, Reminder: "0 24 * * *"})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "reminder", validationErr.Field)
+}
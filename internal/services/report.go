@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// ReportServiceImpl レポートサービスの実装
+type ReportServiceImpl struct {
+	achievementRepo repository.AchievementRepository
+	pointRepo       repository.PointRepository
+}
+
+// NewReportService レポートサービスを作成
+func NewReportService(achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository) ReportService {
+	return &ReportServiceImpl{
+		achievementRepo: achievementRepo,
+		pointRepo:       pointRepo,
+	}
+}
+
+// GenerateMonthlyReport 指定した月の達成目録・報酬獲得・連続達成日数を集計
+func (s *ReportServiceImpl) GenerateMonthlyReport(ctx context.Context, month time.Time) (*models.MonthlyReport, error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	end := start.AddDate(0, 1, 0)
+
+	achievements, err := s.achievementRepo.List(ctx)
+	if err != nil {
+		return nil, &errors.ServiceError{
+			Operation: "GenerateMonthlyReport",
+			Message:   "failed to get achievements list",
+			Cause:     err,
+		}
+	}
+
+	// 報酬獲得履歴は対象の月の範囲だけをストレージから取得する
+	history, err := s.pointRepo.GetRewardHistoryBetween(ctx, start, end)
+	if err != nil {
+		return nil, &errors.ServiceError{
+			Operation: "GenerateMonthlyReport",
+			Message:   "failed to get reward history",
+			Cause:     err,
+		}
+	}
+
+	report := &models.MonthlyReport{
+		Month:        start.Format("2006-01"),
+		Achievements: []*models.Achievement{},
+		Redemptions:  []*models.RewardHistory{},
+		GeneratedAt:  time.Now(),
+	}
+
+	// 達成目録の集計
+	activeDays := make(map[int]bool)
+	for _, achievement := range achievements {
+		if achievement == nil || !inRange(achievement.CreatedAt, start, end) {
+			continue
+		}
+		report.Achievements = append(report.Achievements, achievement)
+		report.PointsEarned += achievement.Point
+		activeDays[achievement.CreatedAt.In(start.Location()).Day()] = true
+	}
+	sort.Slice(report.Achievements, func(i, j int) bool {
+		return report.Achievements[i].CreatedAt.Before(report.Achievements[j].CreatedAt)
+	})
+
+	// 報酬獲得の集計（取り消してポイントを返還した報酬獲得は含めない）
+	for _, record := range history {
+		if record == nil || record.RefundedAt != nil {
+			continue
+		}
+		report.Redemptions = append(report.Redemptions, record)
+		report.PointsSpent += record.PointCost
+	}
+	sort.Slice(report.Redemptions, func(i, j int) bool {
+		return report.Redemptions[i].RedeemedAt.Before(report.Redemptions[j].RedeemedAt)
+	})
+
+	report.NetPoints = report.PointsEarned - report.PointsSpent
+	report.ActiveDays = len(activeDays)
+	report.LongestStreak = longestStreak(activeDays, end.AddDate(0, 0, -1).Day())
+
+	return report, nil
+}
+
+// inRange 日時が [start, end) の範囲内かどうか
+func inRange(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
+
+// longestStreak 達成のあった日の最長連続日数を計算
+func longestStreak(activeDays map[int]bool, daysInMonth int) int {
+	longest, current := 0, 0
+	for day := 1; day <= daysInMonth; day++ {
+		if activeDays[day] {
+			current++
+			if current > longest {
+				longest = current
+			}
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportService_GenerateMonthlyReport(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	achievementRepo := &MockAchievementRepository{}
+	pointRepo := &MockPointRepository{}
+	service := NewReportService(achievementRepo, pointRepo)
+
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "1", Title: "Day 3", Point: 10, CreatedAt: day(3)},
+		{ID: "2", Title: "Day 4", Point: 20, CreatedAt: day(4)},
+		{ID: "3", Title: "Day 5", Point: 5, CreatedAt: day(5)},
+		{ID: "4", Title: "Day 5 again", Point: 5, CreatedAt: day(5)},
+		{ID: "5", Title: "Day 10", Point: 30, CreatedAt: day(10)},
+		{ID: "6", Title: "Previous month", Point: 100, CreatedAt: time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC)},
+	}, nil)
+	// 報酬獲得履歴は対象の月の範囲を指定して取得し、取り消した報酬獲得は集計しない
+	refundedAt := day(12)
+	pointRepo.On("GetRewardHistoryBetween", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)).Return([]*models.RewardHistory{
+		{ID: "h1", RewardTitle: "Coffee", PointCost: 15, RedeemedAt: day(11)},
+		{ID: "h2", RewardTitle: "Cake", PointCost: 40, RedeemedAt: day(12), RefundedAt: &refundedAt},
+	}, nil)
+
+	report, err := service.GenerateMonthlyReport(context.Background(), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-06", report.Month)
+	assert.Len(t, report.Achievements, 5)
+	assert.Len(t, report.Redemptions, 1)
+	assert.Equal(t, 70, report.PointsEarned)
+	assert.Equal(t, 15, report.PointsSpent)
+	assert.Equal(t, 55, report.NetPoints)
+	assert.Equal(t, 4, report.ActiveDays)
+	assert.Equal(t, 3, report.LongestStreak)
+	assert.Equal(t, "1", report.Achievements[0].ID)
+}
+
+func TestReportService_GenerateMonthlyReport_Error(t *testing.T) {
+	achievementRepo := &MockAchievementRepository{}
+	pointRepo := &MockPointRepository{}
+	service := NewReportService(achievementRepo, pointRepo)
+
+	achievementRepo.On("List").Return(nil, &errors.DatabaseError{Operation: "List"})
+
+	report, err := service.GenerateMonthlyReport(context.Background(), time.Now())
+	assert.Nil(t, report)
+	assert.IsType(t, &errors.ServiceError{}, err)
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// ReservationServiceImpl ポイントの取り置きサービスの実装
+type ReservationServiceImpl struct {
+	reservationRepo repository.ReservationRepository
+	rewardRepo      repository.RewardRepository
+	pointRepo       repository.PointRepository
+}
+
+// NewReservationService ポイントの取り置きサービスを作成
+func NewReservationService(reservationRepo repository.ReservationRepository, rewardRepo repository.RewardRepository, pointRepo repository.PointRepository) ReservationService {
+	return &ReservationServiceImpl{
+		reservationRepo: reservationRepo,
+		rewardRepo:      rewardRepo,
+		pointRepo:       pointRepo,
+	}
+}
+
+// Reserve 報酬のためにポイントを取り置く（取り置き済みの場合は取り置くポイントを置き換える）
+//
+// 取り置けるのは報酬の獲得に必要なポイントまでで、すべての取り置きの合計は現在のポイントを超えられない。
+func (s *ReservationServiceImpl) Reserve(ctx context.Context, rewardID string, points int) (*models.Reservation, error) {
+	if err := tenant.ValidateEntityID("reward_id", rewardID); err != nil {
+		return nil, err
+	}
+	if points <= 0 {
+		return nil, &errors.ValidationError{Field: "points", Message: "points must be positive"}
+	}
+
+	reward, err := s.rewardRepo.GetByID(ctx, rewardID)
+	if err != nil {
+		return nil, err
+	}
+	if points > reward.Point {
+		return nil, &errors.ValidationError{Field: "points", Message: "points cannot exceed the point cost of the reward"}
+	}
+
+	reservations, err := s.reservationRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reservation := &models.Reservation{RewardID: rewardID, Points: points}
+	reserved := 0
+	for _, existing := range reservations {
+		if existing.RewardID == rewardID {
+			reservation.CreatedAt = existing.CreatedAt
+			continue
+		}
+		reserved += existing.Points
+	}
+
+	current, err := s.pointRepo.GetCurrentPointsConsistent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if reserved+points > current.Point {
+		return nil, &errors.BusinessLogicError{
+			Operation: "Reserve",
+			Reason:    "insufficient points",
+			Code:      errors.CodeInsufficientPoints,
+		}
+	}
+
+	if err := s.reservationRepo.Put(ctx, reservation); err != nil {
+		// 残高の確認後にポイントが減っていた、または別の取り置きが増えていた場合
+		if err == errors.ErrInsufficientPoints {
+			return nil, &errors.BusinessLogicError{
+				Operation: "Reserve",
+				Reason:    "insufficient points",
+				Code:      errors.CodeInsufficientPoints,
+			}
+		}
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Release 取り置きを解除し、ポイントを他の報酬の獲得に使えるようにする
+func (s *ReservationServiceImpl) Release(ctx context.Context, rewardID string) error {
+	if err := tenant.ValidateEntityID("reward_id", rewardID); err != nil {
+		return err
+	}
+	return s.reservationRepo.Remove(ctx, rewardID)
+}
+
+// List 取り置きを取り置いた日時順に取得し、取り置きを除いて使えるポイントを計算する
+//
+// 取り置いた後に削除された報酬の取り置きも、解除するまで残高から除く。
+func (s *ReservationServiceImpl) List(ctx context.Context) (*models.ReservationSummary, error) {
+	reservations, err := s.reservationRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.pointRepo.GetCurrentPoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.ReservationSummary{
+		Balance:      current.Point,
+		Reservations: make([]*models.ReservationEntry, 0, len(reservations)),
+	}
+	if len(reservations) > 0 {
+		ids := make([]string, len(reservations))
+		for i, reservation := range reservations {
+			ids[i] = reservation.RewardID
+		}
+		rewards, err := s.rewardRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*models.Reward, len(rewards))
+		for _, reward := range rewards {
+			byID[reward.ID] = reward
+		}
+
+		for _, reservation := range reservations {
+			summary.Reserved += reservation.Points
+			summary.Reservations = append(summary.Reservations, &models.ReservationEntry{
+				Reservation: reservation,
+				Reward:      byID[reservation.RewardID],
+			})
+		}
+	}
+
+	summary.Spendable = spendable(summary.Balance, summary.Reserved)
+	return summary, nil
+}
+
+// spendable 取り置きを除いて使えるポイント（取り置きが残高を超えている場合は0）
+func spendable(balance, reserved int) int {
+	if balance <= reserved {
+		return 0
+	}
+	return balance - reserved
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockReservationRepository ポイントの取り置きリポジトリのモック
+type MockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReservationRepository) Put(ctx context.Context, reservation *models.Reservation) error {
+	args := m.Called(reservation)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) Remove(ctx context.Context, rewardID string) error {
+	args := m.Called(rewardID)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) List(ctx context.Context) ([]*models.Reservation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func TestReservationService_Reserve(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	reservationRepo := new(MockReservationRepository)
+	reservationRepo.On("List").Return([]*models.Reservation{
+		{RewardID: "switch", Points: 100, CreatedAt: createdAt},
+		{RewardID: "book", Points: 50},
+	}, nil)
+	reservationRepo.On("Put", mock.MatchedBy(func(reservation *models.Reservation) bool {
+		return reservation.RewardID == "switch" && reservation.Points == 250
+	})).Return(nil)
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByID", "switch").Return(&models.Reward{ID: "switch", Title: "Switchのゲーム", Point: 300}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetCurrentPointsConsistent").Return(&models.CurrentPoints{Point: 300}, nil)
+
+	service := NewReservationService(reservationRepo, rewardRepo, pointRepo)
+
+	// 取り置き済みの報酬は取り置いた日時を保って置き換える（他の取り置きとの合計は残高以内）
+	reservation, err := service.Reserve(context.Background(), "switch", 250)
+	require.NoError(t, err)
+	assert.Equal(t, 250, reservation.Points)
+	assert.Equal(t, createdAt, reservation.CreatedAt)
+
+	// 他の取り置きと合わせて残高を超える場合は取り置けない
+	_, err = service.Reserve(context.Background(), "switch", 300)
+	var businessErr *errors.BusinessLogicError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "insufficient points", businessErr.Reason)
+	reservationRepo.AssertNumberOfCalls(t, "Put", 1)
+}
+
+func TestReservationService_Reserve_ConcurrentSpend(t *testing.T) {
+	reservationRepo := new(MockReservationRepository)
+	reservationRepo.On("List").Return([]*models.Reservation{}, nil)
+	// 確認した後に別の獲得・取り置きでポイントが減っていた場合はリポジトリが残高不足を返す
+	reservationRepo.On("Put", mock.AnythingOfType("*models.Reservation")).Return(errors.ErrInsufficientPoints)
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByID", "switch").Return(&models.Reward{ID: "switch", Title: "Switchのゲーム", Point: 300}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetCurrentPointsConsistent").Return(&models.CurrentPoints{Point: 300}, nil)
+
+	service := NewReservationService(reservationRepo, rewardRepo, pointRepo)
+
+	_, err := service.Reserve(context.Background(), "switch", 200)
+	var businessErr *errors.BusinessLogicError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, errors.CodeInsufficientPoints, businessErr.Code)
+}
+
+func TestReservationService_Reserve_Validation(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByID", "coffee").Return(&models.Reward{ID: "coffee", Title: "コーヒー", Point: 30}, nil)
+	rewardRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	service := NewReservationService(new(MockReservationRepository), rewardRepo, new(MockPointRepository))
+
+	var validationErr *errors.ValidationError
+	_, err := service.Reserve(context.Background(), "", 10)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "reward_id", validationErr.Field)
+
+	_, err = service.Reserve(context.Background(), "coffee", 0)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "points", validationErr.Field)
+
+	// 報酬の獲得に必要なポイントを超えて取り置けない
+	_, err = service.Reserve(context.Background(), "coffee", 31)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "points", validationErr.Field)
+
+	_, err = service.Reserve(context.Background(), "missing", 10)
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+}
+
+func TestReservationService_List(t *testing.T) {
+	reservationRepo := new(MockReservationRepository)
+	reservationRepo.On("List").Return([]*models.Reservation{
+		{RewardID: "switch", Points: 250},
+		{RewardID: "deleted", Points: 100},
+	}, nil)
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByIDs", []string{"switch", "deleted"}).Return([]*models.Reward{{ID: "switch", Title: "Switchのゲーム", Point: 300}}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 300}, nil)
+
+	summary, err := NewReservationService(reservationRepo, rewardRepo, pointRepo).List(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 300, summary.Balance)
+	assert.Equal(t, 350, summary.Reserved)
+	// 取り置きが残高を超えている場合は使えるポイントを0とする
+	assert.Equal(t, 0, summary.Spendable)
+	require.Len(t, summary.Reservations, 2)
+	assert.Equal(t, "Switchのゲーム", summary.Reservations[0].Reward.Title)
+	// 削除された報酬の取り置きも解除するまで残す
+	assert.Nil(t, summary.Reservations[1].Reward)
+}
+
+func TestRewardService_Redeem_Reservations(t *testing.T) {
+	newService := func(reservations []*models.Reservation) (RewardService, *MockPointRepository, *MockReservationRepository) {
+		rewardRepo := new(MockRewardRepository)
+		rewardRepo.On("GetByID", "coffee").Return(&models.Reward{ID: "coffee", Title: "コーヒー", Point: 30}, nil)
+		rewardRepo.On("GetByID", "switch").Return(&models.Reward{ID: "switch", Title: "Switchのゲーム", Point: 300}, nil)
+		pointRepo := new(MockPointRepository)
+		pointRepo.On("GetCurrentPointsConsistent").Return(&models.CurrentPoints{Point: 300}, nil)
+		pointRepo.On("RedeemPoints", mock.AnythingOfType("*models.RewardHistory")).Return(nil)
+		reservationRepo := new(MockReservationRepository)
+		reservationRepo.On("List").Return(reservations, nil)
+		return NewRewardServiceWithReservations(rewardRepo, pointRepo, reservationRepo, DefaultRefundWindow, Limits{}), pointRepo, reservationRepo
+	}
+
+	// Switchのために取り置いたポイントはコーヒーの獲得に使えない
+	service, pointRepo, _ := newService([]*models.Reservation{{RewardID: "switch", Points: 280}})
+	_, err := service.Redeem(context.Background(), "coffee")
+	var businessErr *errors.BusinessLogicError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, "points are reserved for other rewards", businessErr.Reason)
+	pointRepo.AssertNotCalled(t, "RedeemPoints", mock.Anything)
+
+	// 取り置いた報酬は取り置いたポイントも使って獲得できる（取り置きの解除は RedeemPoints が同じトランザクションで行う）
+	service, pointRepo, reservationRepo := newService([]*models.Reservation{{RewardID: "switch", Points: 280}})
+	_, err = service.Redeem(context.Background(), "switch")
+	require.NoError(t, err)
+	pointRepo.AssertCalled(t, "RedeemPoints", mock.AnythingOfType("*models.RewardHistory"))
+	reservationRepo.AssertNotCalled(t, "Remove", mock.Anything)
+
+	// 取り置きを除いたポイントで足りる場合は獲得できる
+	service, _, _ = newService([]*models.Reservation{{RewardID: "switch", Points: 200}})
+	_, err = service.Redeem(context.Background(), "coffee")
+	require.NoError(t, err)
+}
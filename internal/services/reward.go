@@ -1,44 +1,103 @@
 package services
 
 import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"math/rand"
+	"time"
+	"unicode/utf8"
+
 	"achievement-management/internal/errors"
 	"achievement-management/internal/models"
 	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
 )
 
+// DefaultRefundWindow 報酬獲得を取り消せる期間の既定値
+const DefaultRefundWindow = 24 * time.Hour
+
 // RewardServiceImpl 報酬サービスの実装
 type RewardServiceImpl struct {
-	rewardRepo repository.RewardRepository
-	pointRepo  repository.PointRepository
+	rewardRepo      repository.RewardRepository
+	pointRepo       repository.PointRepository
+	reservationRepo repository.ReservationRepository
+	refundWindow    time.Duration
+	limits          Limits
+	now             func() time.Time
+	random          func(n int) int // 0以上n未満の乱数（抽選型の報酬の景品の抽選に使う）
 }
 
 // NewRewardService 報酬サービスを作成
 func NewRewardService(rewardRepo repository.RewardRepository, pointRepo repository.PointRepository) RewardService {
+	return NewRewardServiceWithRefundWindow(rewardRepo, pointRepo, DefaultRefundWindow)
+}
+
+// NewRewardServiceWithRefundWindow 報酬獲得を取り消せる期間を指定して報酬サービスを作成（0の場合は管理者のみ取り消せる）
+func NewRewardServiceWithRefundWindow(rewardRepo repository.RewardRepository, pointRepo repository.PointRepository, refundWindow time.Duration) RewardService {
+	return NewRewardServiceWithLimits(rewardRepo, pointRepo, refundWindow, Limits{})
+}
+
+// NewRewardServiceWithLimits 報酬獲得を取り消せる期間と入力の上限を指定して報酬サービスを作成
+func NewRewardServiceWithLimits(rewardRepo repository.RewardRepository, pointRepo repository.PointRepository, refundWindow time.Duration, limits Limits) RewardService {
+	return NewRewardServiceWithReservations(rewardRepo, pointRepo, nil, refundWindow, limits)
+}
+
+// NewRewardServiceWithReservations ポイントの取り置きを考慮して報酬サービスを作成（reservationRepo がnilの場合は獲得前に取り置きを確認しない）
+//
+// 他の報酬のために取り置いたポイントは獲得に使えず、取り置いた報酬を獲得すると取り置きを解除する。
+// どちらも PointRepository.RedeemPoints が獲得と同じトランザクションで行い、ここでは獲得前に分かりやすいエラーを返すために確認する。
+func NewRewardServiceWithReservations(rewardRepo repository.RewardRepository, pointRepo repository.PointRepository, reservationRepo repository.ReservationRepository, refundWindow time.Duration, limits Limits) RewardService {
 	return &RewardServiceImpl{
-		rewardRepo: rewardRepo,
-		pointRepo:  pointRepo,
+		rewardRepo:      rewardRepo,
+		pointRepo:       pointRepo,
+		reservationRepo: reservationRepo,
+		refundWindow:    refundWindow,
+		limits:          limits,
+		now:             time.Now,
+		random:          rand.Intn,
 	}
 }
 
 // Create 報酬を作成
-func (s *RewardServiceImpl) Create(reward *models.Reward) error {
+func (s *RewardServiceImpl) Create(ctx context.Context, reward *models.Reward) error {
 	if reward == nil {
 		return &errors.ValidationError{Field: "reward", Message: "reward cannot be nil"}
 	}
 
 	// バリデーション
+	normalizeReward(reward)
 	if err := s.validateReward(reward); err != nil {
 		return err
 	}
 
+	clientID := reward.ID != ""
+	if clientID {
+		id, err := normalizeID(reward.ID)
+		if err != nil {
+			return err
+		}
+		reward.ID = id
+	}
+
 	// 報酬を作成
-	return s.rewardRepo.Create(reward)
+	err := s.rewardRepo.Create(ctx, reward)
+	if clientID && stderrors.Is(err, errors.ErrDuplicateResource) {
+		// 同じ内容の再送は作成済みの報酬を返す
+		existing, getErr := s.rewardRepo.GetByID(ctx, reward.ID)
+		if getErr != nil || !sameReward(existing, reward) {
+			return err
+		}
+		*reward = *existing
+		return nil
+	}
+	return err
 }
 
 // Update 報酬を更新
-func (s *RewardServiceImpl) Update(id string, reward *models.Reward) error {
-	if id == "" {
-		return &errors.ValidationError{Field: "id", Message: "id is required"}
+func (s *RewardServiceImpl) Update(ctx context.Context, id string, reward *models.Reward) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
 	}
 
 	if reward == nil {
@@ -46,6 +105,7 @@ func (s *RewardServiceImpl) Update(id string, reward *models.Reward) error {
 	}
 
 	// バリデーション
+	normalizeReward(reward)
 	if err := s.validateReward(reward); err != nil {
 		return err
 	}
@@ -54,62 +114,77 @@ func (s *RewardServiceImpl) Update(id string, reward *models.Reward) error {
 	reward.ID = id
 
 	// 更新実行
-	return s.rewardRepo.Update(reward)
+	return s.rewardRepo.Update(ctx, reward)
 }
 
 // GetByID IDで報酬を取得
-func (s *RewardServiceImpl) GetByID(id string) (*models.Reward, error) {
-	if id == "" {
-		return nil, &errors.ValidationError{Field: "id", Message: "id is required"}
+func (s *RewardServiceImpl) GetByID(ctx context.Context, id string) (*models.Reward, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
 	}
 
-	return s.rewardRepo.GetByID(id)
+	return s.rewardRepo.GetByID(ctx, id)
 }
 
 // List すべての報酬を取得
-func (s *RewardServiceImpl) List() ([]*models.Reward, error) {
-	return s.rewardRepo.List()
+func (s *RewardServiceImpl) List(ctx context.Context) ([]*models.Reward, error) {
+	return s.rewardRepo.List(ctx)
+}
+
+// Count 報酬の件数を取得
+func (s *RewardServiceImpl) Count(ctx context.Context) (int, error) {
+	return s.rewardRepo.Count(ctx)
 }
 
 // Delete 報酬を削除
-func (s *RewardServiceImpl) Delete(id string) error {
-	if id == "" {
-		return &errors.ValidationError{Field: "id", Message: "id is required"}
+func (s *RewardServiceImpl) Delete(ctx context.Context, id string) error {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return err
 	}
 
-	return s.rewardRepo.Delete(id)
+	return s.rewardRepo.Delete(ctx, id)
 }
 
-// Redeem 報酬を獲得（ポイント減算と履歴記録）
-func (s *RewardServiceImpl) Redeem(rewardID string) error {
-	if rewardID == "" {
-		return &errors.ValidationError{Field: "rewardID", Message: "rewardID is required"}
+// Redeem 報酬を獲得し（ポイント減算と履歴記録）、記録した報酬獲得履歴を返す
+//
+// 抽選型の報酬は景品を重みに応じて1つ抽選し、当選した景品を履歴に記録する。
+func (s *RewardServiceImpl) Redeem(ctx context.Context, rewardID string) (*models.RewardHistory, error) {
+	if err := tenant.ValidateEntityID("rewardID", rewardID); err != nil {
+		return nil, err
 	}
 
 	// 報酬を取得
-	reward, err := s.rewardRepo.GetByID(rewardID)
+	reward, err := s.rewardRepo.GetByID(ctx, rewardID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// 現在のポイントを取得
-	currentPoints, err := s.pointRepo.GetCurrentPoints()
+	// 現在のポイントを取得（結果整合性の読み取りでは直前の獲得が反映されず残高を超えて獲得できるため、強い整合性で読み取る）
+	currentPoints, err := s.pointRepo.GetCurrentPointsConsistent(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// ポイントが十分かチェック
 	if currentPoints.Point < reward.Point {
-		return &errors.BusinessLogicError{
+		return nil, &errors.BusinessLogicError{
 			Operation: "Redeem",
 			Reason:    "insufficient points",
+			Code:      errors.CodeInsufficientPoints,
 		}
 	}
 
-	// ポイント減算後の値を計算
-	updatedPoints := &models.CurrentPoints{
-		ID:    "current",
-		Point: currentPoints.Point - reward.Point,
+	// 他の報酬のために取り置いたポイントは使えない
+	reserved, err := s.reservedPoints(ctx, reward.ID)
+	if err != nil {
+		return nil, err
+	}
+	if currentPoints.Point-reserved < reward.Point {
+		return nil, &errors.BusinessLogicError{
+			Operation: "Redeem",
+			Reason:    "points are reserved for other rewards",
+			Code:      errors.CodePointsReserved,
+		}
 	}
 
 	// 報酬獲得履歴を作成
@@ -117,25 +192,150 @@ func (s *RewardServiceImpl) Redeem(rewardID string) error {
 		RewardID:    reward.ID,
 		RewardTitle: reward.Title,
 		PointCost:   reward.Point,
+		Prize:       s.drawPrize(reward.Prizes),
 	}
 
-	// トランザクションでポイント減算と履歴記録を実行
-	if err := s.pointRepo.TransactPointsAndHistory(updatedPoints, rewardHistory); err != nil {
-		return err
+	// トランザクションでポイント減算・台帳への記録・履歴記録・獲得する報酬の取り置きの解除を実行
+	if err := s.pointRepo.RedeemPoints(ctx, rewardHistory); err != nil {
+		// 残高の確認後に別の獲得でポイントが減っていた、または取り置きが増えていた場合
+		if err == errors.ErrInsufficientPoints {
+			return nil, &errors.BusinessLogicError{
+				Operation: "Redeem",
+				Reason:    "insufficient points",
+				Code:      errors.CodeInsufficientPoints,
+			}
+		}
+		return nil, err
 	}
 
-	return nil
+	return rewardHistory, nil
+}
+
+// drawPrize 景品を重みに応じて1つ抽選する（景品が無い場合は空）
+func (s *RewardServiceImpl) drawPrize(prizes []models.RafflePrize) string {
+	total := 0
+	for _, prize := range prizes {
+		total += prize.Weight
+	}
+	if total <= 0 {
+		return ""
+	}
+
+	n := s.random(total)
+	for _, prize := range prizes {
+		if n < prize.Weight {
+			return prize.Title
+		}
+		n -= prize.Weight
+	}
+	return ""
+}
+
+// reservedPoints rewardID 以外の報酬のために取り置いたポイントの合計
+func (s *RewardServiceImpl) reservedPoints(ctx context.Context, rewardID string) (int, error) {
+	if s.reservationRepo == nil {
+		return 0, nil
+	}
+
+	reservations, err := s.reservationRepo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	reserved := 0
+	for _, reservation := range reservations {
+		if reservation.RewardID != rewardID {
+			reserved += reservation.Points
+		}
+	}
+	return reserved, nil
+}
+
+// Refund 報酬獲得を取り消し、消費したポイントを返還して履歴に取り消し日時を記録
+//
+// 獲得から取り消し期間が経過した後は管理者（admin が true）のみ取り消せる（それ以外は errors.ErrForbidden）。
+func (s *RewardServiceImpl) Refund(ctx context.Context, historyID string, admin bool) (*models.RewardHistory, error) {
+	if err := tenant.ValidateEntityID("historyID", historyID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.pointRepo.GetRewardHistoryByID(ctx, historyID)
+	if err != nil {
+		return nil, err
+	}
+	if history.RefundedAt != nil {
+		return nil, errAlreadyRefunded
+	}
+
+	now := s.now()
+	if !admin && now.Sub(history.RedeemedAt) > s.refundWindow {
+		return nil, fmt.Errorf("refund window of %s has passed: %w", s.refundWindow, errors.ErrForbidden)
+	}
+
+	history.RefundedAt = &now
+	if err := s.pointRepo.RefundRedemption(ctx, history); err != nil {
+		// 読み取った後に別のリクエストで取り消された場合
+		if stderrors.Is(err, errors.ErrVersionConflict) {
+			return nil, errAlreadyRefunded
+		}
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// errAlreadyRefunded 取り消し済みの報酬獲得を再度取り消そうとした
+var errAlreadyRefunded = &errors.BusinessLogicError{
+	Operation: "Refund",
+	Reason:    "redemption has already been refunded",
+	Code:      errors.CodeAlreadyRefunded,
 }
 
 // validateReward 報酬のバリデーション
 func (s *RewardServiceImpl) validateReward(reward *models.Reward) error {
-	if reward.Title == "" {
-		return &errors.ValidationError{Field: "title", Message: "title is required"}
+	if err := validateText(reward.Title, reward.Description); err != nil {
+		return err
 	}
 
-	if reward.Point <= 0 {
-		return &errors.ValidationError{Field: "point", Message: "point must be positive"}
+	if err := s.limits.validatePoint(reward.Point); err != nil {
+		return err
+	}
+
+	return validatePrizes(reward.Prizes)
+}
+
+// maxPrizes 抽選型の報酬に設定できる景品の最大数
+const maxPrizes = 50
+
+// validatePrizes 抽選型の報酬の景品のタイトル（必須・重複不可）と重み（1以上）を検証
+func validatePrizes(prizes []models.RafflePrize) error {
+	if len(prizes) > maxPrizes {
+		return &errors.ValidationError{Field: "prizes", Message: fmt.Sprintf("at most %d prizes can be set", maxPrizes)}
 	}
 
+	seen := make(map[string]bool, len(prizes))
+	for _, prize := range prizes {
+		if prize.Title == "" {
+			return &errors.ValidationError{Field: "prizes", Message: "prize title is required"}
+		}
+		if utf8.RuneCountInString(prize.Title) > maxTitleLength {
+			return &errors.ValidationError{Field: "prizes", Message: fmt.Sprintf("prize title must be at most %d characters", maxTitleLength)}
+		}
+		if seen[prize.Title] {
+			return &errors.ValidationError{Field: "prizes", Message: fmt.Sprintf("prize %q is set more than once", prize.Title)}
+		}
+		seen[prize.Title] = true
+		if prize.Weight <= 0 {
+			return &errors.ValidationError{Field: "prizes", Message: "prize weight must be positive"}
+		}
+	}
 	return nil
+}
+
+// normalizeReward タイトル・説明・景品のタイトルの全角文字と前後の空白を揃える
+func normalizeReward(reward *models.Reward) {
+	reward.Title = normalizeText(reward.Title)
+	reward.Description = normalizeText(reward.Description)
+	for i := range reward.Prizes {
+		reward.Prizes[i].Title = normalizeText(reward.Prizes[i].Title)
+	}
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -16,17 +17,17 @@ type MockRewardRepository struct {
 	mock.Mock
 }
 
-func (m *MockRewardRepository) Create(reward *models.Reward) error {
+func (m *MockRewardRepository) Create(ctx context.Context, reward *models.Reward) error {
 	args := m.Called(reward)
 	return args.Error(0)
 }
 
-func (m *MockRewardRepository) Update(reward *models.Reward) error {
+func (m *MockRewardRepository) Update(ctx context.Context, reward *models.Reward) error {
 	args := m.Called(reward)
 	return args.Error(0)
 }
 
-func (m *MockRewardRepository) GetByID(id string) (*models.Reward, error) {
+func (m *MockRewardRepository) GetByID(ctx context.Context, id string) (*models.Reward, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -34,7 +35,15 @@ func (m *MockRewardRepository) GetByID(id string) (*models.Reward, error) {
 	return args.Get(0).(*models.Reward), args.Error(1)
 }
 
-func (m *MockRewardRepository) List() ([]*models.Reward, error) {
+func (m *MockRewardRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.Reward, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reward), args.Error(1)
+}
+
+func (m *MockRewardRepository) List(ctx context.Context) ([]*models.Reward, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -42,7 +51,12 @@ func (m *MockRewardRepository) List() ([]*models.Reward, error) {
 	return args.Get(0).([]*models.Reward), args.Error(1)
 }
 
-func (m *MockRewardRepository) Delete(id string) error {
+func (m *MockRewardRepository) Count(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRewardRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -133,6 +147,55 @@ func TestRewardService_Create(t *testing.T) {
 			expectedError:     &errors.DatabaseError{},
 			expectedErrorType: &errors.DatabaseError{},
 		},
+		{
+			name: "同じIDと内容での再送",
+			reward: &models.Reward{
+				ID:    "01J00000000000000000000000",
+				Title: "テスト報酬",
+				Point: 50,
+			},
+			setupMocks: func(rewardRepo *MockRewardRepository, pointRepo *MockPointRepository) {
+				rewardRepo.On("Create", mock.AnythingOfType("*models.Reward")).Return(errors.ErrDuplicateResource)
+				rewardRepo.On("GetByID", "01J00000000000000000000000").Return(&models.Reward{
+					ID:    "01J00000000000000000000000",
+					Title: "テスト報酬",
+					Point: 50,
+				}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "同じIDで内容が異なる報酬",
+			reward: &models.Reward{
+				ID:    "01J00000000000000000000000",
+				Title: "テスト報酬",
+				Point: 50,
+			},
+			setupMocks: func(rewardRepo *MockRewardRepository, pointRepo *MockPointRepository) {
+				rewardRepo.On("Create", mock.AnythingOfType("*models.Reward")).Return(errors.ErrDuplicateResource)
+				rewardRepo.On("GetByID", "01J00000000000000000000000").Return(&models.Reward{
+					ID:    "01J00000000000000000000000",
+					Title: "テスト報酬",
+					Point: 80,
+				}, nil)
+			},
+			expectedError:     errors.ErrDuplicateResource,
+			expectedErrorType: errors.ErrDuplicateResource,
+		},
+		{
+			name: "IDがULIDではない報酬",
+			reward: &models.Reward{
+				ID:    "reward-1",
+				Title: "テスト報酬",
+				Point: 50,
+			},
+			setupMocks: func(rewardRepo *MockRewardRepository, pointRepo *MockPointRepository) {
+				// モックの設定は不要
+			},
+			expectedError:      &errors.ValidationError{},
+			expectedErrorType:  &errors.ValidationError{},
+			expectedErrorField: "id",
+		},
 	}
 
 	for _, tt := range tests {
@@ -143,7 +206,7 @@ func TestRewardService_Create(t *testing.T) {
 			tt.setupMocks(rewardRepo, pointRepo)
 
 			service := NewRewardService(rewardRepo, pointRepo)
-			err := service.Create(tt.reward)
+			err := service.Create(context.Background(), tt.reward)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -240,7 +303,7 @@ func TestRewardService_Update(t *testing.T) {
 			tt.setupMocks(rewardRepo, pointRepo)
 
 			service := NewRewardService(rewardRepo, pointRepo)
-			err := service.Update(tt.id, tt.reward)
+			err := service.Update(context.Background(), tt.id, tt.reward)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -322,7 +385,7 @@ func TestRewardService_GetByID(t *testing.T) {
 			tt.setupMocks(rewardRepo, pointRepo)
 
 			service := NewRewardService(rewardRepo, pointRepo)
-			reward, err := service.GetByID(tt.id)
+			reward, err := service.GetByID(context.Background(), tt.id)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -422,7 +485,7 @@ func TestRewardService_List(t *testing.T) {
 			tt.setupMocks(rewardRepo, pointRepo)
 
 			service := NewRewardService(rewardRepo, pointRepo)
-			rewards, err := service.List()
+			rewards, err := service.List(context.Background())
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -490,7 +553,7 @@ func TestRewardService_Delete(t *testing.T) {
 			tt.setupMocks(rewardRepo, pointRepo)
 
 			service := NewRewardService(rewardRepo, pointRepo)
-			err := service.Delete(tt.id)
+			err := service.Delete(context.Background(), tt.id)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -540,11 +603,8 @@ func TestRewardService_Redeem(t *testing.T) {
 					UpdatedAt: time.Now(),
 				}
 				rewardRepo.On("GetByID", "test-reward-id").Return(reward, nil)
-				pointRepo.On("GetCurrentPoints").Return(currentPoints, nil)
-				pointRepo.On("TransactPointsAndHistory", 
-					mock.MatchedBy(func(p *models.CurrentPoints) bool {
-						return p.Point == 50 // 100 - 50 = 50
-					}),
+				pointRepo.On("GetCurrentPointsConsistent").Return(currentPoints, nil)
+				pointRepo.On("RedeemPoints",
 					mock.MatchedBy(func(h *models.RewardHistory) bool {
 						return h.RewardID == "test-reward-id" && h.RewardTitle == "テスト報酬" && h.PointCost == 50
 					}),
@@ -582,7 +642,7 @@ func TestRewardService_Redeem(t *testing.T) {
 					CreatedAt:   time.Now(),
 				}
 				rewardRepo.On("GetByID", "test-reward-id").Return(reward, nil)
-				pointRepo.On("GetCurrentPoints").Return(nil, &errors.DatabaseError{})
+				pointRepo.On("GetCurrentPointsConsistent").Return(nil, &errors.DatabaseError{})
 			},
 			expectedError:     &errors.DatabaseError{},
 			expectedErrorType: &errors.DatabaseError{},
@@ -604,7 +664,7 @@ func TestRewardService_Redeem(t *testing.T) {
 					UpdatedAt: time.Now(),
 				}
 				rewardRepo.On("GetByID", "test-reward-id").Return(reward, nil)
-				pointRepo.On("GetCurrentPoints").Return(currentPoints, nil)
+				pointRepo.On("GetCurrentPointsConsistent").Return(currentPoints, nil)
 			},
 			expectedError:     &errors.BusinessLogicError{},
 			expectedErrorType: &errors.BusinessLogicError{},
@@ -626,11 +686,8 @@ func TestRewardService_Redeem(t *testing.T) {
 					UpdatedAt: time.Now(),
 				}
 				rewardRepo.On("GetByID", "test-reward-id").Return(reward, nil)
-				pointRepo.On("GetCurrentPoints").Return(currentPoints, nil)
-				pointRepo.On("TransactPointsAndHistory", 
-					mock.MatchedBy(func(p *models.CurrentPoints) bool {
-						return p.Point == 50
-					}),
+				pointRepo.On("GetCurrentPointsConsistent").Return(currentPoints, nil)
+				pointRepo.On("RedeemPoints",
 					mock.MatchedBy(func(h *models.RewardHistory) bool {
 						return h.RewardID == "test-reward-id"
 					}),
@@ -639,6 +696,29 @@ func TestRewardService_Redeem(t *testing.T) {
 			expectedError:     &errors.DatabaseError{},
 			expectedErrorType: &errors.DatabaseError{},
 		},
+		{
+			name:     "残高確認後に別の獲得でポイント不足",
+			rewardID: "test-reward-id",
+			setupMocks: func(rewardRepo *MockRewardRepository, pointRepo *MockPointRepository) {
+				reward := &models.Reward{
+					ID:          "test-reward-id",
+					Title:       "テスト報酬",
+					Description: "テスト用の報酬です",
+					Point:       50,
+					CreatedAt:   time.Now(),
+				}
+				currentPoints := &models.CurrentPoints{
+					ID:        "current",
+					Point:     100,
+					UpdatedAt: time.Now(),
+				}
+				rewardRepo.On("GetByID", "test-reward-id").Return(reward, nil)
+				pointRepo.On("GetCurrentPointsConsistent").Return(currentPoints, nil)
+				pointRepo.On("RedeemPoints", mock.Anything).Return(errors.ErrInsufficientPoints)
+			},
+			expectedError:     &errors.BusinessLogicError{},
+			expectedErrorType: &errors.BusinessLogicError{},
+		},
 		{
 			name:     "ちょうどのポイントで報酬獲得",
 			rewardID: "test-reward-id",
@@ -656,11 +736,8 @@ func TestRewardService_Redeem(t *testing.T) {
 					UpdatedAt: time.Now(),
 				}
 				rewardRepo.On("GetByID", "test-reward-id").Return(reward, nil)
-				pointRepo.On("GetCurrentPoints").Return(currentPoints, nil)
-				pointRepo.On("TransactPointsAndHistory", 
-					mock.MatchedBy(func(p *models.CurrentPoints) bool {
-						return p.Point == 0 // 100 - 100 = 0
-					}),
+				pointRepo.On("GetCurrentPointsConsistent").Return(currentPoints, nil)
+				pointRepo.On("RedeemPoints",
 					mock.MatchedBy(func(h *models.RewardHistory) bool {
 						return h.RewardID == "test-reward-id" && h.RewardTitle == "テスト報酬" && h.PointCost == 100
 					}),
@@ -685,7 +762,7 @@ func TestRewardService_Redeem(t *testing.T) {
 					UpdatedAt: time.Now(),
 				}
 				rewardRepo.On("GetByID", "test-reward-id").Return(reward, nil)
-				pointRepo.On("GetCurrentPoints").Return(currentPoints, nil)
+				pointRepo.On("GetCurrentPointsConsistent").Return(currentPoints, nil)
 			},
 			expectedError:     &errors.BusinessLogicError{},
 			expectedErrorType: &errors.BusinessLogicError{},
@@ -700,7 +777,7 @@ func TestRewardService_Redeem(t *testing.T) {
 			tt.setupMocks(rewardRepo, pointRepo)
 
 			service := NewRewardService(rewardRepo, pointRepo)
-			err := service.Redeem(tt.rewardID)
+			_, err := service.Redeem(context.Background(), tt.rewardID)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -722,4 +799,137 @@ func TestRewardService_Redeem(t *testing.T) {
 			pointRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestRewardService_Refund(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	recent := &models.RewardHistory{ID: "recent", RewardID: "reward-1", RewardTitle: "ケーキ", PointCost: 80, RedeemedAt: now.Add(-2 * time.Hour)}
+	old := &models.RewardHistory{ID: "old", RewardID: "reward-1", RewardTitle: "ケーキ", PointCost: 80, RedeemedAt: now.Add(-48 * time.Hour)}
+	refundedAt := now.Add(-time.Hour)
+	refunded := &models.RewardHistory{ID: "refunded", RewardID: "reward-1", RewardTitle: "ケーキ", PointCost: 80, RedeemedAt: now.Add(-2 * time.Hour), RefundedAt: &refundedAt}
+
+	tests := []struct {
+		name              string
+		historyID         string
+		admin             bool
+		setupMocks        func(*MockPointRepository)
+		expectedError     error
+		expectedErrorType interface{}
+	}{
+		{
+			name:      "期間内の取り消し",
+			historyID: "recent",
+			setupMocks: func(pointRepo *MockPointRepository) {
+				pointRepo.On("GetRewardHistoryByID", "recent").Return(copyHistory(recent), nil)
+				pointRepo.On("RefundRedemption", mock.MatchedBy(func(h *models.RewardHistory) bool {
+					return h.ID == "recent" && h.PointCost == 80 && h.RefundedAt != nil && h.RefundedAt.Equal(now)
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "期間の経過後は管理者以外は取り消せない",
+			historyID: "old",
+			setupMocks: func(pointRepo *MockPointRepository) {
+				pointRepo.On("GetRewardHistoryByID", "old").Return(copyHistory(old), nil)
+			},
+			expectedError: errors.ErrForbidden,
+		},
+		{
+			name:      "期間の経過後の管理者による取り消し",
+			historyID: "old",
+			admin:     true,
+			setupMocks: func(pointRepo *MockPointRepository) {
+				pointRepo.On("GetRewardHistoryByID", "old").Return(copyHistory(old), nil)
+				pointRepo.On("RefundRedemption", mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:      "取り消し済み",
+			historyID: "refunded",
+			admin:     true,
+			setupMocks: func(pointRepo *MockPointRepository) {
+				pointRepo.On("GetRewardHistoryByID", "refunded").Return(copyHistory(refunded), nil)
+			},
+			expectedErrorType: &errors.BusinessLogicError{},
+		},
+		{
+			name:      "同時に取り消された",
+			historyID: "recent",
+			setupMocks: func(pointRepo *MockPointRepository) {
+				pointRepo.On("GetRewardHistoryByID", "recent").Return(copyHistory(recent), nil)
+				pointRepo.On("RefundRedemption", mock.Anything).Return(errors.ErrVersionConflict)
+			},
+			expectedErrorType: &errors.BusinessLogicError{},
+		},
+		{
+			name:      "存在しない履歴",
+			historyID: "missing",
+			setupMocks: func(pointRepo *MockPointRepository) {
+				pointRepo.On("GetRewardHistoryByID", "missing").Return(nil, errors.ErrNotFound)
+			},
+			expectedError: errors.ErrNotFound,
+		},
+		{
+			name:              "historyIDが空",
+			setupMocks:        func(pointRepo *MockPointRepository) {},
+			expectedErrorType: &errors.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pointRepo := new(MockPointRepository)
+			tt.setupMocks(pointRepo)
+			service := NewRewardServiceWithRefundWindow(new(MockRewardRepository), pointRepo, 24*time.Hour).(*RewardServiceImpl)
+			service.now = func() time.Time { return now }
+
+			history, err := service.Refund(context.Background(), tt.historyID, tt.admin)
+
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.expectedErrorType != nil:
+				assert.IsType(t, tt.expectedErrorType, err)
+			default:
+				assert.NoError(t, err)
+				assert.NotNil(t, history.RefundedAt)
+			}
+			if tt.expectedError != nil || tt.expectedErrorType != nil {
+				assert.Nil(t, history)
+			}
+			pointRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRewardService_Refund_ZeroWindow(t *testing.T) {
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryByID", "just-now").Return(&models.RewardHistory{ID: "just-now", PointCost: 80, RedeemedAt: time.Now()}, nil)
+
+	// 期間が0の場合は直後の取り消しも管理者のみ
+	_, err := NewRewardServiceWithRefundWindow(new(MockRewardRepository), pointRepo, 0).Refund(context.Background(), "just-now", false)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+func TestRewardService_NormalizesAndLimits(t *testing.T) {
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("Create", mock.AnythingOfType("*models.Reward")).Return(nil)
+	service := NewRewardServiceWithLimits(rewardRepo, new(MockPointRepository), DefaultRefundWindow, Limits{MaxPoint: 1000})
+
+	reward := &models.Reward{Title: " Ｎｉｎｔｅｎｄｏ　Ｓｗｉｔｃｈ ", Description: "ｹﾞｰﾑ機", Point: 1000}
+	assert.NoError(t, service.Create(context.Background(), reward))
+	assert.Equal(t, "Nintendo Switch", reward.Title)
+	assert.Equal(t, "ゲーム機", reward.Description)
+	rewardRepo.AssertExpectations(t)
+
+	// 1000000 のような入力ミスは上限で拒否する
+	var validationErr *errors.ValidationError
+	err := service.Update(context.Background(), "test-id", &models.Reward{Title: "Switch", Point: 1000000})
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "point", validationErr.Field)
+}
+
+// copyHistory テストケース間で取り消し日時の設定が共有されないよう履歴を複製
+func copyHistory(history *models.RewardHistory) *models.RewardHistory {
+	copied := *history
+	return &copied
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// StatsServiceImpl ポイントの推移の統計サービスの実装
+type StatsServiceImpl struct {
+	achievementRepo repository.AchievementRepository
+	pointRepo       repository.PointRepository
+	location        *time.Location
+	now             func() time.Time
+}
+
+// NewStatsService 統計サービスを作成（location は日付の区切りに使うタイムゾーン。nilの場合はサーバーのローカル時刻）
+func NewStatsService(achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository, location *time.Location) StatsService {
+	if location == nil {
+		location = time.Local
+	}
+	return &StatsServiceImpl{
+		achievementRepo: achievementRepo,
+		pointRepo:       pointRepo,
+		location:        location,
+		now:             time.Now,
+	}
+}
+
+// Trends 直近7日・30日の獲得・使用ポイントと、これまでで最も獲得した日・週を集計
+func (s *StatsServiceImpl) Trends(ctx context.Context) (*models.TrendStats, error) {
+	now := s.now()
+	year, month, day := now.In(s.location).Date()
+	tomorrow := time.Date(year, month, day+1, 0, 0, 0, 0, s.location)
+
+	earned, err := earnings(ctx, s.achievementRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 取り消してポイントを返還した報酬獲得は含めない
+	history, err := s.pointRepo.GetRewardHistoryBetween(ctx, tomorrow.AddDate(0, 0, -30), tomorrow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TrendStats{
+		Last7Days:   window(7, tomorrow, earned, history),
+		Last30Days:  window(30, tomorrow, earned, history),
+		BestDay:     s.best(earned, s.day),
+		BestWeek:    s.best(earned, s.week),
+		GeneratedAt: now,
+	}, nil
+}
+
+// window end の前日までの days 日間に獲得・使用したポイントを集計
+func window(days int, end time.Time, earned []earning, history []*models.RewardHistory) models.TrendWindow {
+	start := end.AddDate(0, 0, -days)
+	window := models.TrendWindow{
+		Days: days,
+		From: start.Format(dateLayout),
+		To:   end.AddDate(0, 0, -1).Format(dateLayout),
+	}
+	for _, e := range earned {
+		if inRange(e.at, start, end) {
+			window.PointsEarned += e.points
+		}
+	}
+	for _, record := range history {
+		if record.RefundedAt == nil && inRange(record.RedeemedAt, start, end) {
+			window.PointsSpent += record.PointCost
+		}
+	}
+	window.AverageDailyPoints = float64(window.PointsEarned) / float64(days)
+	return window
+}
+
+// best period で区切った期間ごとに獲得ポイントを合計し、最も多い期間を返す（同点の場合は古い期間）
+func (s *StatsServiceImpl) best(earned []earning, period func(time.Time) (time.Time, time.Time)) *models.BestPeriod {
+	totals := map[time.Time]int{}
+	ends := map[time.Time]time.Time{}
+	for _, e := range earned {
+		start, end := period(e.at)
+		totals[start] += e.points
+		ends[start] = end
+	}
+
+	var best *models.BestPeriod
+	var bestStart time.Time
+	for start, points := range totals {
+		if best != nil && (points < best.Points || (points == best.Points && start.After(bestStart))) {
+			continue
+		}
+		bestStart = start
+		best = &models.BestPeriod{
+			From:   start.Format(dateLayout),
+			To:     ends[start].Format(dateLayout),
+			Points: points,
+		}
+	}
+	return best
+}
+
+// day t を含む日の最初と最後の日
+func (s *StatsServiceImpl) day(t time.Time) (time.Time, time.Time) {
+	year, month, day := t.In(s.location).Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, s.location)
+	return start, start
+}
+
+// week t を含む週（月曜日から日曜日）の最初と最後の日
+func (s *StatsServiceImpl) week(t time.Time) (time.Time, time.Time) {
+	start, _ := s.day(t)
+	start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	return start, start.AddDate(0, 0, 6)
+}
+
+// earning 達成目録の作成・達成で獲得したポイント
+type earning struct {
+	achievementID string
+	points        int
+	at            time.Time
+	// completion 達成記録による獲得か（false の場合は達成目録の作成による獲得）
+	completion bool
+}
+
+// earnings すべての達成目録の作成・達成で獲得したポイントを列挙
+func earnings(ctx context.Context, achievementRepo repository.AchievementRepository) ([]earning, error) {
+	achievements, err := achievementRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var earned []earning
+	for _, achievement := range achievements {
+		earned = append(earned, earning{achievementID: achievement.ID, points: achievement.Point, at: achievement.CreatedAt})
+
+		completions, err := achievementRepo.ListCompletions(ctx, achievement.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, completion := range completions {
+			earned = append(earned, earning{achievementID: achievement.ID, points: completion.Point, at: completion.CompletedAt, completion: true})
+		}
+	}
+	return earned, nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsService_Trends(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// 2024年6月12日（水曜日）
+	now := time.Date(2024, 6, 12, 21, 0, 0, 0, tokyo)
+	start := time.Date(2024, 5, 14, 0, 0, 0, 0, tokyo)
+	end := time.Date(2024, 6, 13, 0, 0, 0, 0, tokyo)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		// 今日作成して今日達成した達成目録
+		{ID: "run", Title: "Run", Point: 30, CreatedAt: time.Date(2024, 6, 12, 7, 0, 0, 0, tokyo)},
+		// 20日前に作成した達成目録
+		{ID: "read", Title: "Read", Point: 50, CreatedAt: time.Date(2024, 5, 23, 7, 0, 0, 0, tokyo)},
+		// 30日より前に作成した達成目録
+		{ID: "old", Title: "Old", Point: 100, CreatedAt: time.Date(2024, 4, 1, 7, 0, 0, 0, tokyo)},
+	}, nil)
+	achievementRepo.On("ListCompletions", "run").Return(completionsOn("run", time.Date(2024, 6, 12, 8, 0, 0, 0, tokyo)), nil)
+	// UTCでは6月9日（日曜日）だが、日本時間では6月10日（月曜日）の達成
+	achievementRepo.On("ListCompletions", "read").Return(completionsOn("read",
+		time.Date(2024, 6, 9, 16, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 24, 7, 0, 0, 0, tokyo),
+	), nil)
+	achievementRepo.On("ListCompletions", "old").Return([]*models.Completion{}, nil)
+
+	refundedAt := time.Date(2024, 6, 11, 21, 0, 0, 0, tokyo)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryBetween", start, end).Return([]*models.RewardHistory{
+		{ID: "h1", PointCost: 40, RedeemedAt: time.Date(2024, 6, 11, 20, 0, 0, 0, tokyo)},
+		{ID: "h2", PointCost: 100, RedeemedAt: time.Date(2024, 6, 11, 20, 30, 0, 0, tokyo), RefundedAt: &refundedAt},
+		{ID: "h3", PointCost: 25, RedeemedAt: time.Date(2024, 5, 20, 20, 0, 0, 0, tokyo)},
+	}, nil)
+
+	service := NewStatsService(achievementRepo, pointRepo, tokyo).(*StatsServiceImpl)
+	service.now = func() time.Time { return now }
+
+	stats, err := service.Trends(context.Background())
+	require.NoError(t, err)
+
+	// 作成の30ポイント + 達成10ポイント×2回（completionsOn の達成記録は10ポイント）
+	assert.Equal(t, models.TrendWindow{Days: 7, From: "2024-06-06", To: "2024-06-12", PointsEarned: 50, PointsSpent: 40, AverageDailyPoints: 50.0 / 7}, stats.Last7Days)
+	assert.Equal(t, models.TrendWindow{Days: 30, From: "2024-05-14", To: "2024-06-12", PointsEarned: 110, PointsSpent: 65, AverageDailyPoints: 110.0 / 30}, stats.Last30Days)
+	// 最も獲得した日・週は30日より前の獲得も含めて比較する
+	assert.Equal(t, &models.BestPeriod{From: "2024-04-01", To: "2024-04-01", Points: 100}, stats.BestDay)
+	assert.Equal(t, &models.BestPeriod{From: "2024-04-01", To: "2024-04-07", Points: 100}, stats.BestWeek)
+	assert.Equal(t, now, stats.GeneratedAt)
+}
+
+func TestStatsService_Trends_BestWeekAcrossDays(t *testing.T) {
+	now := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "big", Title: "Big", Point: 40, CreatedAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: "daily", Title: "Daily", Point: 0, CreatedAt: time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)},
+	}, nil)
+	achievementRepo.On("ListCompletions", "big").Return([]*models.Completion{}, nil)
+	// 1日あたりは10ポイントだが、週の合計は50ポイントになる
+	achievementRepo.On("ListCompletions", "daily").Return(completionsOn("daily",
+		time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 6, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 9, 9, 0, 0, 0, time.UTC),
+	), nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryBetween", time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 13, 0, 0, 0, 0, time.UTC)).Return([]*models.RewardHistory{}, nil)
+
+	service := NewStatsService(achievementRepo, pointRepo, time.UTC).(*StatsServiceImpl)
+	service.now = func() time.Time { return now }
+
+	stats, err := service.Trends(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, &models.BestPeriod{From: "2024-05-01", To: "2024-05-01", Points: 40}, stats.BestDay)
+	assert.Equal(t, &models.BestPeriod{From: "2024-06-03", To: "2024-06-09", Points: 50}, stats.BestWeek)
+}
+
+func TestStatsService_Trends_NoActivity(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryBetween", mock.Anything, mock.Anything).Return([]*models.RewardHistory{}, nil)
+
+	stats, err := NewStatsService(achievementRepo, pointRepo, time.UTC).Trends(context.Background())
+	require.NoError(t, err)
+
+	assert.Zero(t, stats.Last30Days.PointsEarned)
+	assert.Zero(t, stats.Last30Days.AverageDailyPoints)
+	assert.Nil(t, stats.BestDay)
+	assert.Nil(t, stats.BestWeek)
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+// StreakSettings 連続達成日数の数え方と節目のボーナスの設定
+type StreakSettings struct {
+	// Location 日付の区切りに使うタイムゾーン（nilの場合はサーバーのローカル時刻）
+	Location *time.Location
+	// Bonuses 節目の連続達成日数とボーナスポイント（空の場合はボーナスを付与しない）
+	Bonuses map[int]int
+}
+
+// GetStreak 達成目録の連続達成日数を達成記録から計算
+func (s *AchievementServiceImpl) GetStreak(ctx context.Context, id string) (*models.Streak, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	completions, err := s.achievementRepo.ListCompletions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	streak := s.computeStreak(completionTimes(completions))
+	return &streak, nil
+}
+
+// GetStreaks すべての達成目録を合わせた連続達成日数と、達成したことのある達成目録ごとの連続達成日数を取得
+//
+// 全体の連続達成日数は現在の達成目録の達成記録から計算するため、削除した達成目録の達成は含まない。
+func (s *AchievementServiceImpl) GetStreaks(ctx context.Context) (*models.StreakSummary, error) {
+	achievements, err := s.achievementRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.StreakSummary{Achievements: []models.AchievementStreak{}}
+	var all []time.Time
+	for _, achievement := range achievements {
+		completions, err := s.achievementRepo.ListCompletions(ctx, achievement.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(completions) == 0 {
+			continue
+		}
+
+		times := completionTimes(completions)
+		all = append(all, times...)
+		summary.Achievements = append(summary.Achievements, models.AchievementStreak{
+			AchievementID:    achievement.ID,
+			AchievementTitle: achievement.Title,
+			Streak:           s.computeStreak(times),
+		})
+	}
+	summary.Global = s.computeStreak(all)
+
+	// 続いている連続達成日数の長い順に並べる
+	sort.SliceStable(summary.Achievements, func(i, j int) bool {
+		a, b := summary.Achievements[i], summary.Achievements[j]
+		if a.Current != b.Current {
+			return a.Current > b.Current
+		}
+		return a.Longest > b.Longest
+	})
+	return summary, nil
+}
+
+// streakBonus completedAt の達成で連続達成日数が節目に達する場合のボーナスポイント
+//
+// 同じ日の2回目以降の達成は連続達成日数を増やさないため、ボーナスは1日に1回だけ付与される。
+func (s *AchievementServiceImpl) streakBonus(ctx context.Context, achievementID string, completedAt time.Time) (int, error) {
+	if len(s.streaks.Bonuses) == 0 {
+		return 0, nil
+	}
+
+	completions, err := s.achievementRepo.ListCompletions(ctx, achievementID)
+	if err != nil {
+		return 0, err
+	}
+
+	today := s.day(completedAt)
+	times := completionTimes(completions)
+	for _, t := range times {
+		if s.day(t).Equal(today) {
+			return 0, nil
+		}
+	}
+
+	streak := streakOf(s.days(append(times, completedAt)), today)
+	return s.streaks.Bonuses[streak.Current], nil
+}
+
+// computeStreak 達成日時から現在の連続達成日数を計算
+func (s *AchievementServiceImpl) computeStreak(times []time.Time) models.Streak {
+	return streakOf(s.days(times), s.day(s.now()))
+}
+
+// day 日時を設定したタイムゾーンの日付（UTCの0時）に変換
+func (s *AchievementServiceImpl) day(t time.Time) time.Time {
+	year, month, date := t.In(s.location()).Date()
+	return time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+}
+
+// dayBounds t を含む日の開始と翌日の開始（連続達成日数と同じタイムゾーンで区切る）
+func (s *AchievementServiceImpl) dayBounds(t time.Time) (time.Time, time.Time) {
+	location := s.location()
+	year, month, date := t.In(location).Date()
+	start := time.Date(year, month, date, 0, 0, 0, 0, location)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// location 日付を区切るタイムゾーン（設定していない場合はローカルタイムゾーン）
+func (s *AchievementServiceImpl) location() *time.Location {
+	if s.streaks.Location == nil {
+		return time.Local
+	}
+	return s.streaks.Location
+}
+
+// days 達成日時を重複のない日付の昇順に変換
+func (s *AchievementServiceImpl) days(times []time.Time) []time.Time {
+	seen := map[time.Time]bool{}
+	var days []time.Time
+	for _, t := range times {
+		day := s.day(t)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
+
+// streakOf 昇順の日付から連続達成日数を計算（最後の達成が今日か昨日でなければ現在の連続日数は0）
+func streakOf(days []time.Time, today time.Time) models.Streak {
+	var streak models.Streak
+	if len(days) == 0 {
+		return streak
+	}
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && days[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+	}
+
+	last := days[len(days)-1]
+	if last.Equal(today) || last.AddDate(0, 0, 1).Equal(today) {
+		streak.Current = run
+	}
+	streak.LastCompletedOn = last.Format("2006-01-02")
+	return streak
+}
+
+// completionTimes 達成記録の達成日時
+func completionTimes(completions []*models.Completion) []time.Time {
+	times := make([]time.Time, len(completions))
+	for i, completion := range completions {
+		times[i] = completion.CompletedAt
+	}
+	return times
+}
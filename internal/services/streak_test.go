@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newStreakTestService 現在時刻を固定した達成目録サービスを作成
+func newStreakTestService(achievementRepo *MockAchievementRepository, settings StreakSettings, now time.Time) *AchievementServiceImpl {
+	service := NewAchievementServiceWithStreaks(achievementRepo, new(MockPointRepository), settings).(*AchievementServiceImpl)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+// completionsOn 指定した日時の達成記録を作成
+func completionsOn(achievementID string, times ...time.Time) []*models.Completion {
+	completions := make([]*models.Completion, len(times))
+	for i, t := range times {
+		completions[i] = &models.Completion{ID: t.Format(time.RFC3339), AchievementID: achievementID, Point: 10, CompletedAt: t}
+	}
+	return completions
+}
+
+func TestStreakOf(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		days     []time.Time
+		today    time.Time
+		expected models.Streak
+	}{
+		{
+			name:     "達成なし",
+			today:    day(10),
+			expected: models.Streak{},
+		},
+		{
+			name:     "今日まで続いている",
+			days:     []time.Time{day(1), day(2), day(8), day(9), day(10)},
+			today:    day(10),
+			expected: models.Streak{Current: 3, Longest: 3, LastCompletedOn: "2024-06-10"},
+		},
+		{
+			name:     "昨日までの連続は今日達成すれば続く",
+			days:     []time.Time{day(8), day(9)},
+			today:    day(10),
+			expected: models.Streak{Current: 2, Longest: 2, LastCompletedOn: "2024-06-09"},
+		},
+		{
+			name:     "途切れている",
+			days:     []time.Time{day(1), day(2), day(3), day(7)},
+			today:    day(10),
+			expected: models.Streak{Current: 0, Longest: 3, LastCompletedOn: "2024-06-07"},
+		},
+		{
+			name:     "月をまたいで続く",
+			days:     []time.Time{day(1).AddDate(0, 0, -2), day(1).AddDate(0, 0, -1), day(1)},
+			today:    day(1),
+			expected: models.Streak{Current: 3, Longest: 3, LastCompletedOn: "2024-06-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, streakOf(tt.days, tt.today))
+		})
+	}
+}
+
+func TestAchievementService_GetStreak_UsesTimezone(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, tokyo)
+	achievementRepo := new(MockAchievementRepository)
+	// UTCでは6月8日だが、日本時間では6月9日の達成
+	achievementRepo.On("ListCompletions", "test-id").Return(completionsOn("test-id",
+		time.Date(2024, 6, 8, 20, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo),
+		time.Date(2024, 6, 10, 9, 0, 0, 0, tokyo),
+	), nil)
+
+	service := newStreakTestService(achievementRepo, StreakSettings{Location: tokyo}, now)
+	streak, err := service.GetStreak(context.Background(), "test-id")
+	require.NoError(t, err)
+	assert.Equal(t, &models.Streak{Current: 2, Longest: 2, LastCompletedOn: "2024-06-10"}, streak)
+
+	_, err = service.GetStreak(context.Background(), "")
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
+
+func TestAchievementService_GetStreaks(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 9, 0, 0, 0, time.UTC) }
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		{ID: "run", Title: "ランニング"},
+		{ID: "read", Title: "読書"},
+		{ID: "never", Title: "未達成"},
+	}, nil)
+	achievementRepo.On("ListCompletions", "run").Return(completionsOn("run", day(7), day(9)), nil)
+	achievementRepo.On("ListCompletions", "read").Return(completionsOn("read", day(8), day(9), day(10)), nil)
+	achievementRepo.On("ListCompletions", "never").Return([]*models.Completion{}, nil)
+
+	service := newStreakTestService(achievementRepo, StreakSettings{Location: time.UTC}, now)
+	summary, err := service.GetStreaks(context.Background())
+	require.NoError(t, err)
+
+	// どれかの達成目録を達成した日が続いていれば全体の連続達成日数は続く
+	assert.Equal(t, models.Streak{Current: 4, Longest: 4, LastCompletedOn: "2024-06-10"}, summary.Global)
+	// 達成したことのない達成目録は含まず、続いている連続日数の長い順に並べる
+	require.Len(t, summary.Achievements, 2)
+	assert.Equal(t, "read", summary.Achievements[0].AchievementID)
+	assert.Equal(t, 3, summary.Achievements[0].Current)
+	assert.Equal(t, "run", summary.Achievements[1].AchievementID)
+	assert.Equal(t, 1, summary.Achievements[1].Current)
+}
+
+func TestAchievementService_Complete_StreakBonus(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	previous := completionsOn("test-id")
+	for d := 4; d <= 9; d++ {
+		previous = append(previous, completionsOn("test-id", time.Date(2024, 6, d, 9, 0, 0, 0, time.UTC))...)
+	}
+	settings := StreakSettings{Location: time.UTC, Bonuses: map[int]int{7: 10}}
+
+	// 7日連続になる達成にボーナスを付与する
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("GetByID", "test-id").Return(&models.Achievement{ID: "test-id", Title: "朝のランニング", Point: 30}, nil)
+	achievementRepo.On("ListCompletions", "test-id").Return(previous, nil)
+	achievementRepo.On("Complete", mock.MatchedBy(func(c *models.Completion) bool {
+		return c.Point == 30 && c.BonusPoint == 10 && c.CompletedAt.Equal(now)
+	})).Return(nil)
+
+	completion, err := newStreakTestService(achievementRepo, settings, now).Complete(context.Background(), "test-id")
+	require.NoError(t, err)
+	assert.Equal(t, 10, completion.BonusPoint)
+	achievementRepo.AssertExpectations(t)
+
+	// 同じ日の2回目の達成では連続達成日数が増えないためボーナスを付与しない
+	achievementRepo = new(MockAchievementRepository)
+	achievementRepo.On("GetByID", "test-id").Return(&models.Achievement{ID: "test-id", Title: "朝のランニング", Point: 30}, nil)
+	achievementRepo.On("ListCompletions", "test-id").Return(append(previous, completionsOn("test-id", now.Add(-time.Hour))...), nil)
+	achievementRepo.On("Complete", mock.MatchedBy(func(c *models.Completion) bool {
+		return c.BonusPoint == 0
+	})).Return(nil)
+
+	_, err = newStreakTestService(achievementRepo, settings, now).Complete(context.Background(), "test-id")
+	require.NoError(t, err)
+	achievementRepo.AssertExpectations(t)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+)
+
+// minSuggestionSamples これまでの達成目録のポイントから提案するのに必要な件数
+const minSuggestionSamples = 3
+
+// defaultSuggestions 達成目録が少ない場合に提案するポイント（提案するポイント・範囲の下限・上限）
+var defaultSuggestions = map[models.Difficulty][3]int{
+	models.DifficultyEasy:   {10, 5, 20},
+	models.DifficultyMedium: {30, 20, 50},
+	models.DifficultyHard:   {100, 50, 200},
+}
+
+// difficultyQuantiles すべての達成目録のポイントから提案する場合に、難易度ごとに使う分位（0〜1）
+var difficultyQuantiles = map[models.Difficulty]float64{
+	models.DifficultyEasy:   0.25,
+	models.DifficultyMedium: 0.5,
+	models.DifficultyHard:   0.75,
+}
+
+// SuggestionServiceImpl ポイント提案サービスの実装
+type SuggestionServiceImpl struct {
+	achievementRepo repository.AchievementRepository
+	limits          Limits
+}
+
+// NewSuggestionService ポイント提案サービスを作成（提案するポイントは limits の上限を超えない）
+func NewSuggestionService(achievementRepo repository.AchievementRepository, limits Limits) SuggestionService {
+	return &SuggestionServiceImpl{
+		achievementRepo: achievementRepo,
+		limits:          limits,
+	}
+}
+
+// SuggestPoint 難易度に合うポイントを提案
+// 同じ難易度の達成目録が十分にある場合はそのポイントの中央値（範囲は四分位）、
+// 無い場合はすべての達成目録のポイントの難易度に応じた分位、達成目録が少ない場合は既定値を提案する
+func (s *SuggestionServiceImpl) SuggestPoint(ctx context.Context, difficulty models.Difficulty) (*models.PointSuggestion, error) {
+	difficulty = models.Difficulty(strings.ToLower(strings.TrimSpace(string(difficulty))))
+	if !validDifficulty(difficulty) {
+		return nil, &errors.ValidationError{Field: "difficulty", Message: "difficulty must be easy, medium or hard"}
+	}
+
+	achievements, err := s.achievementRepo.ListSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var same, all []int
+	for _, achievement := range achievements {
+		all = append(all, achievement.Point)
+		if achievement.Difficulty == difficulty {
+			same = append(same, achievement.Point)
+		}
+	}
+	sort.Ints(same)
+	sort.Ints(all)
+
+	suggestion := &models.PointSuggestion{Difficulty: difficulty}
+	switch {
+	case len(same) >= minSuggestionSamples:
+		suggestion.Point = quantile(same, 0.5)
+		suggestion.Min = quantile(same, 0.25)
+		suggestion.Max = quantile(same, 0.75)
+		suggestion.Samples = len(same)
+		suggestion.Basis = models.SuggestionBasisDifficulty
+	case len(all) >= minSuggestionSamples:
+		q := difficultyQuantiles[difficulty]
+		suggestion.Point = quantile(all, q)
+		suggestion.Min = quantile(all, math.Max(q-0.25, 0))
+		suggestion.Max = quantile(all, math.Min(q+0.25, 1))
+		suggestion.Samples = len(all)
+		suggestion.Basis = models.SuggestionBasisHistory
+	default:
+		defaults := defaultSuggestions[difficulty]
+		suggestion.Point, suggestion.Min, suggestion.Max = defaults[0], defaults[1], defaults[2]
+		suggestion.Basis = models.SuggestionBasisDefault
+	}
+
+	// 提案したポイントでそのまま作成できるよう上限に収める
+	suggestion.Point = s.clamp(suggestion.Point)
+	suggestion.Min = s.clamp(suggestion.Min)
+	suggestion.Max = s.clamp(suggestion.Max)
+
+	return suggestion, nil
+}
+
+// clamp ポイントを1以上、上限以下に収める
+func (s *SuggestionServiceImpl) clamp(point int) int {
+	if point < 1 {
+		return 1
+	}
+	if max := s.limits.maxPoint(); point > max {
+		return max
+	}
+	return point
+}
+
+// quantile 昇順に並べたポイントの q 分位（隣り合う値の間は線形補間して四捨五入）
+func quantile(sorted []int, q float64) int {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	value := float64(sorted[lower]) + float64(sorted[upper]-sorted[lower])*(pos-float64(lower))
+	return int(math.Round(value))
+}
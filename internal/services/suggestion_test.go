@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestionService_SuggestPoint(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("ListSummaries").Return([]*models.Achievement{
+		{ID: "1", Point: 200, Difficulty: models.DifficultyHard},
+		{ID: "2", Point: 50, Difficulty: models.DifficultyHard},
+		{ID: "3", Point: 100, Difficulty: models.DifficultyHard},
+		{ID: "4", Point: 150, Difficulty: models.DifficultyHard},
+		{ID: "5", Point: 10, Difficulty: models.DifficultyEasy},
+		{ID: "6", Point: 30},
+	}, nil)
+	service := NewSuggestionService(achievementRepo, Limits{})
+
+	tests := []struct {
+		name       string
+		difficulty models.Difficulty
+		expected   models.PointSuggestion
+	}{
+		{
+			// 同じ難易度の達成目録が3件以上ある場合は中央値と四分位
+			name:       "同じ難易度のポイントから提案",
+			difficulty: " Hard ",
+			expected:   models.PointSuggestion{Difficulty: models.DifficultyHard, Point: 125, Min: 88, Max: 163, Samples: 4, Basis: "difficulty"},
+		},
+		{
+			// 同じ難易度が少ない場合はすべての達成目録（10, 30, 50, 100, 150, 200）の25%分位
+			name:       "すべての達成目録のポイントから提案",
+			difficulty: models.DifficultyEasy,
+			expected:   models.PointSuggestion{Difficulty: models.DifficultyEasy, Point: 35, Min: 10, Max: 75, Samples: 6, Basis: "history"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion, err := service.SuggestPoint(context.Background(), tt.difficulty)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *suggestion)
+		})
+	}
+}
+
+func TestSuggestionService_SuggestPoint_Defaults(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("ListSummaries").Return([]*models.Achievement{{ID: "1", Point: 10}}, nil)
+
+	// 達成目録が少ない場合は既定値を提案し、上限を超えない
+	suggestion, err := NewSuggestionService(achievementRepo, Limits{MaxPoint: 150}).SuggestPoint(context.Background(), models.DifficultyHard)
+	require.NoError(t, err)
+	assert.Equal(t, models.PointSuggestion{Difficulty: models.DifficultyHard, Point: 100, Min: 50, Max: 150, Basis: "default"}, *suggestion)
+}
+
+func TestSuggestionService_SuggestPoint_Validation(t *testing.T) {
+	service := NewSuggestionService(new(MockAchievementRepository), Limits{})
+
+	for _, difficulty := range []models.Difficulty{"", "extreme"} {
+		_, err := service.SuggestPoint(context.Background(), difficulty)
+		var validationErr *errors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "difficulty", validationErr.Field)
+	}
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"achievement-management/internal/cron"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// SummaryEventType サマリーを配信する際に通知するイベントの種類
+const SummaryEventType = "summaries.generated"
+
+// summaryTable サマリーのイベントのテーブルの識別子
+const summaryTable = "summaries"
+
+// SummarySettings サマリーの配信時刻と日付の区切りの設定
+type SummarySettings struct {
+	// DailySchedule 昨日のサマリーを配信する時刻のcron式（空の場合は配信しない）
+	DailySchedule string
+	// WeeklySchedule 昨日までの7日間のサマリーを配信する時刻のcron式（空の場合は配信しない）
+	WeeklySchedule string
+	// Location 日付の区切りと配信時刻の判定に使うタイムゾーン（nilの場合はサーバーのローカル時刻）
+	Location *time.Location
+}
+
+// SummaryServiceImpl サマリーサービスの実装
+type SummaryServiceImpl struct {
+	achievementRepo repository.AchievementRepository
+	pointRepo       repository.PointRepository
+	notifier        events.Publisher
+	schedules       map[models.SummaryPeriod]*cron.Schedule
+	location        *time.Location
+	now             func() time.Time
+}
+
+// NewSummaryService サマリーサービスを作成（notifier がnilの場合は配信しない）
+func NewSummaryService(achievementRepo repository.AchievementRepository, pointRepo repository.PointRepository, notifier events.Publisher, settings SummarySettings) (SummaryService, error) {
+	schedules := map[models.SummaryPeriod]*cron.Schedule{}
+	for period, expr := range map[models.SummaryPeriod]string{
+		models.SummaryDaily:  settings.DailySchedule,
+		models.SummaryWeekly: settings.WeeklySchedule,
+	} {
+		if expr == "" {
+			continue
+		}
+		schedule, err := cron.Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		schedules[period] = schedule
+	}
+
+	location := settings.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	return &SummaryServiceImpl{
+		achievementRepo: achievementRepo,
+		pointRepo:       pointRepo,
+		notifier:        notifier,
+		schedules:       schedules,
+		location:        location,
+		now:             time.Now,
+	}, nil
+}
+
+// Generate to の日までの期間のサマリーを作成（to が空の場合は昨日まで）
+func (s *SummaryServiceImpl) Generate(ctx context.Context, period models.SummaryPeriod, to string) (*models.Summary, error) {
+	last := s.yesterday(s.now())
+	if to != "" {
+		parsed, err := time.ParseInLocation(dateLayout, to, s.location)
+		if err != nil {
+			return nil, &errors.ValidationError{Field: "date", Message: "date must be a date in YYYY-MM-DD format"}
+		}
+		last = parsed
+	}
+	return s.generate(ctx, period, last)
+}
+
+// Notify サマリーを配信
+func (s *SummaryServiceImpl) Notify(ctx context.Context, summary *models.Summary) error {
+	if s.notifier == nil {
+		return nil
+	}
+
+	// 同じ期間のサマリーは同じIDにし、受信側で重複を判定できるようにする
+	key := tenant.Key(ctx, fmt.Sprintf("%s:%s", summary.Period, summary.To))
+	event := events.Event{
+		ID:     fmt.Sprintf("%s:%s", SummaryEventType, key),
+		Type:   SummaryEventType,
+		Table:  summaryTable,
+		Action: events.ActionGenerated,
+		Key:    map[string]interface{}{"id": key},
+		Item: map[string]interface{}{
+			"period":        string(summary.Period),
+			"from":          summary.From,
+			"to":            summary.To,
+			"points_earned": summary.PointsEarned,
+			"achievements":  summary.Achievements,
+			"completions":   summary.Completions,
+			"points_spent":  summary.PointsSpent,
+			"redemptions":   summary.Redemptions,
+			"balance":       summary.Balance,
+			"text":          summary.Text,
+		},
+		OccurredAt: summary.GeneratedAt,
+		Source:     events.SourceSummaryService,
+	}
+	if err := s.notifier.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to send %s summary: %w", summary.Period, err)
+	}
+	return nil
+}
+
+// NotifyDue at の分に配信時刻を迎えたサマリーを作成して配信し、配信した件数を返す
+func (s *SummaryServiceImpl) NotifyDue(ctx context.Context, at time.Time) (int, error) {
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	local := at.In(s.location)
+	sent := 0
+	var errs []error
+	for _, period := range []models.SummaryPeriod{models.SummaryDaily, models.SummaryWeekly} {
+		schedule, ok := s.schedules[period]
+		if !ok || !schedule.Matches(local) {
+			continue
+		}
+
+		summary, err := s.generate(ctx, period, s.yesterday(at))
+		if err == nil {
+			err = s.Notify(ctx, summary)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, stderrors.Join(errs...)
+}
+
+// generate last の日までの期間のサマリーを作成
+func (s *SummaryServiceImpl) generate(ctx context.Context, period models.SummaryPeriod, last time.Time) (*models.Summary, error) {
+	var days int
+	switch period {
+	case models.SummaryDaily:
+		days = 1
+	case models.SummaryWeekly:
+		days = 7
+	default:
+		return nil, &errors.ValidationError{Field: "period", Message: "period must be daily or weekly"}
+	}
+
+	end := last.AddDate(0, 0, 1)
+	start := end.AddDate(0, 0, -days)
+	summary := &models.Summary{
+		Period:      period,
+		From:        start.Format(dateLayout),
+		To:          last.Format(dateLayout),
+		GeneratedAt: s.now(),
+	}
+
+	earned, err := earnings(ctx, s.achievementRepo)
+	if err != nil {
+		return nil, err
+	}
+	touched := map[string]bool{}
+	for _, e := range earned {
+		if !inRange(e.at, start, end) {
+			continue
+		}
+		summary.PointsEarned += e.points
+		if e.completion {
+			summary.Completions++
+		}
+		touched[e.achievementID] = true
+	}
+	summary.Achievements = len(touched)
+
+	// 取り消してポイントを返還した報酬獲得は含めない
+	history, err := s.pointRepo.GetRewardHistoryBetween(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range history {
+		if record.RefundedAt != nil {
+			continue
+		}
+		summary.PointsSpent += record.PointCost
+		summary.Redemptions++
+	}
+
+	points, err := s.pointRepo.GetCurrentPoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary.Balance = points.Point
+	summary.Text = summaryText(summary, last.Equal(s.yesterday(summary.GeneratedAt)))
+
+	return summary, nil
+}
+
+// yesterday t の前日（設定したタイムゾーンの0時）
+func (s *SummaryServiceImpl) yesterday(t time.Time) time.Time {
+	year, month, day := t.In(s.location).Date()
+	return time.Date(year, month, day-1, 0, 0, 0, 0, s.location)
+}
+
+// summaryText 通知先でそのまま表示できるサマリーの文章（endsYesterday の場合は期間を昨日からの相対で表す）
+func summaryText(summary *models.Summary, endsYesterday bool) string {
+	var when string
+	switch {
+	case summary.Period == models.SummaryWeekly && endsYesterday:
+		when = "In the last 7 days"
+	case summary.Period == models.SummaryWeekly:
+		when = fmt.Sprintf("From %s to %s", summary.From, summary.To)
+	case endsYesterday:
+		when = "Yesterday"
+	default:
+		when = "On " + summary.To
+	}
+
+	text := fmt.Sprintf("%s you earned %d points from %d achievements", when, summary.PointsEarned, summary.Achievements)
+	if summary.Redemptions > 0 {
+		text += fmt.Sprintf(" and spent %d points on %d rewards", summary.PointsSpent, summary.Redemptions)
+	}
+	return text + fmt.Sprintf("; your balance is %d points.", summary.Balance)
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/events"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSummaryTestService 現在時刻を固定したサマリーサービスを作成
+func newSummaryTestService(t *testing.T, achievementRepo *MockAchievementRepository, pointRepo *MockPointRepository, notifier events.Publisher, location *time.Location, now time.Time) *SummaryServiceImpl {
+	t.Helper()
+	service, err := NewSummaryService(achievementRepo, pointRepo, notifier, SummarySettings{
+		DailySchedule:  "0 8 * * *",
+		WeeklySchedule: "0 8 * * 1",
+		Location:       location,
+	})
+	require.NoError(t, err)
+	impl := service.(*SummaryServiceImpl)
+	impl.now = func() time.Time { return now }
+	return impl
+}
+
+func TestSummaryService_Generate_Yesterday(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2024, 6, 10, 8, 0, 0, 0, tokyo)
+	start := time.Date(2024, 6, 9, 0, 0, 0, 0, tokyo)
+	end := time.Date(2024, 6, 10, 0, 0, 0, 0, tokyo)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{
+		// 昨日作成して昨日2回達成した達成目録
+		{ID: "run", Title: "Run", Point: 30, CreatedAt: time.Date(2024, 6, 9, 7, 0, 0, 0, tokyo)},
+		// 以前に作成し、昨日達成した達成目録
+		{ID: "read", Title: "Read", Point: 10, CreatedAt: time.Date(2024, 6, 1, 7, 0, 0, 0, tokyo)},
+		// 今日の達成は含めない
+		{ID: "stretch", Title: "Stretch", Point: 5, CreatedAt: time.Date(2024, 6, 1, 7, 0, 0, 0, tokyo)},
+	}, nil)
+	achievementRepo.On("ListCompletions", "run").Return(completionsOn("run",
+		time.Date(2024, 6, 9, 7, 30, 0, 0, tokyo),
+		time.Date(2024, 6, 9, 18, 0, 0, 0, tokyo),
+	), nil)
+	// UTCでは6月8日だが、日本時間では昨日（6月9日）の達成
+	achievementRepo.On("ListCompletions", "read").Return(completionsOn("read", time.Date(2024, 6, 8, 20, 0, 0, 0, time.UTC)), nil)
+	achievementRepo.On("ListCompletions", "stretch").Return(completionsOn("stretch", time.Date(2024, 6, 10, 7, 0, 0, 0, tokyo)), nil)
+
+	refundedAt := time.Date(2024, 6, 9, 21, 0, 0, 0, tokyo)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryBetween", start, end).Return([]*models.RewardHistory{
+		{ID: "h1", PointCost: 40, RedeemedAt: time.Date(2024, 6, 9, 20, 0, 0, 0, tokyo)},
+		{ID: "h2", PointCost: 100, RedeemedAt: time.Date(2024, 6, 9, 20, 30, 0, 0, tokyo), RefundedAt: &refundedAt},
+	}, nil)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 120}, nil)
+
+	service := newSummaryTestService(t, achievementRepo, pointRepo, nil, tokyo, now)
+	summary, err := service.Generate(context.Background(), models.SummaryDaily, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2024-06-09", summary.From)
+	assert.Equal(t, "2024-06-09", summary.To)
+	// 作成の30ポイント + 達成10ポイント×3回（completionsOn の達成記録は10ポイント）
+	assert.Equal(t, 60, summary.PointsEarned)
+	assert.Equal(t, 2, summary.Achievements)
+	assert.Equal(t, 3, summary.Completions)
+	assert.Equal(t, 40, summary.PointsSpent)
+	assert.Equal(t, 1, summary.Redemptions)
+	assert.Equal(t, 120, summary.Balance)
+	assert.Equal(t, "Yesterday you earned 60 points from 2 achievements and spent 40 points on 1 rewards; your balance is 120 points.", summary.Text)
+}
+
+func TestSummaryService_Generate_WeeklyForDate(t *testing.T) {
+	now := time.Date(2024, 6, 20, 8, 0, 0, 0, time.UTC)
+	start := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryBetween", start, end).Return([]*models.RewardHistory{}, nil)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 0}, nil)
+
+	service := newSummaryTestService(t, achievementRepo, pointRepo, nil, time.UTC, now)
+	summary, err := service.Generate(context.Background(), models.SummaryWeekly, "2024-06-09")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2024-06-03", summary.From)
+	assert.Equal(t, "2024-06-09", summary.To)
+	assert.Equal(t, "From 2024-06-03 to 2024-06-09 you earned 0 points from 0 achievements; your balance is 0 points.", summary.Text)
+}
+
+func TestSummaryService_Generate_Validation(t *testing.T) {
+	service := newSummaryTestService(t, new(MockAchievementRepository), new(MockPointRepository), nil, time.UTC, time.Now())
+
+	var validationErr *errors.ValidationError
+	_, err := service.Generate(context.Background(), models.SummaryPeriod("monthly"), "")
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "period", validationErr.Field)
+
+	_, err = service.Generate(context.Background(), models.SummaryDaily, "2024/06/09")
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "date", validationErr.Field)
+}
+
+func TestSummaryService_NotifyDue(t *testing.T) {
+	// 月曜日の8時は日次と週次の両方を配信する
+	at := time.Date(2024, 6, 10, 8, 0, 0, 0, time.UTC)
+
+	achievementRepo := new(MockAchievementRepository)
+	achievementRepo.On("List").Return([]*models.Achievement{}, nil)
+	pointRepo := new(MockPointRepository)
+	pointRepo.On("GetRewardHistoryBetween", time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)).Return([]*models.RewardHistory{}, nil)
+	pointRepo.On("GetRewardHistoryBetween", time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)).Return([]*models.RewardHistory{}, nil)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 50}, nil)
+
+	var published []events.Event
+	notifier := events.PublisherFunc(func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	service := newSummaryTestService(t, achievementRepo, pointRepo, notifier, time.UTC, at)
+	sent, err := service.NotifyDue(context.Background(), at)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	require.Len(t, published, 2)
+	assert.Equal(t, SummaryEventType, published[0].Type)
+	assert.Equal(t, events.ActionGenerated, published[0].Action)
+	assert.Equal(t, "summaries.generated:daily:2024-06-09", published[0].ID)
+	assert.Equal(t, "weekly", published[1].Item["period"])
+
+	// 配信時刻以外は何もしない
+	sent, err = service.NotifyDue(context.Background(), at.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/tenant"
+)
+
+// StartTimer 達成目録のタイマーを開始し、開始した日時を設定した達成目録を返す
+// すでにタイマーが動いている場合は BusinessLogicError、読み取った後に他の開始・停止があった場合は ErrVersionConflict を返す
+func (s *AchievementServiceImpl) StartTimer(ctx context.Context, id string) (*models.Achievement, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	achievement, err := s.achievementRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if achievement.TimerStartedAt != nil {
+		return nil, &errors.BusinessLogicError{
+			Operation: "StartTimer",
+			Reason:    "timer is already running",
+			Code:      errors.CodeTimerAlreadyRunning,
+		}
+	}
+
+	startedAt := s.now()
+	if err := s.achievementRepo.StartTimer(ctx, id, startedAt); err != nil {
+		return nil, err
+	}
+	achievement.TimerStartedAt = &startedAt
+	return achievement, nil
+}
+
+// StopTimer 達成目録のタイマーを止め、計測した時間を記録して達成する
+//
+// 1時間あたりのポイントを設定した達成目録は計測した時間に比例したポイント（1ポイント未満は切り捨て、上限は設定できるポイントの上限）を、
+// それ以外は達成目録のポイントを付与する。達成回数の上限・連続達成のボーナス・1日の獲得ポイントの上限は Complete と同じく反映する。
+// タイマーの停止と達成記録の作成は1つのトランザクションで行い、同時に止めた場合は一方が ErrVersionConflict になる。
+func (s *AchievementServiceImpl) StopTimer(ctx context.Context, id string) (*models.Completion, error) {
+	if err := tenant.ValidateEntityID("id", id); err != nil {
+		return nil, err
+	}
+
+	achievement, err := s.achievementRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if achievement.TimerStartedAt == nil {
+		return nil, &errors.BusinessLogicError{
+			Operation: "StopTimer",
+			Reason:    "timer is not running",
+			Code:      errors.CodeTimerNotRunning,
+		}
+	}
+
+	completedAt := s.now()
+	duration := completedAt.Sub(*achievement.TimerStartedAt)
+	if duration < 0 {
+		// 時計のずれで開始日時が未来になっている場合
+		duration = 0
+	}
+	startedAt := *achievement.TimerStartedAt
+	completion := &models.Completion{
+		AchievementID:    achievement.ID,
+		AchievementTitle: achievement.Title,
+		Point:            s.timedPoints(achievement, duration),
+		CompletedAt:      completedAt,
+		StartedAt:        &startedAt,
+		DurationSeconds:  int(duration / time.Second),
+	}
+	if err := s.complete(ctx, achievement, completion); err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+// timedPoints タイマーで計測した時間に対して付与するポイント
+func (s *AchievementServiceImpl) timedPoints(achievement *models.Achievement, duration time.Duration) int {
+	if achievement.PointsPerHour <= 0 {
+		return achievement.Point
+	}
+
+	max := int64(s.limits.maxPoint())
+	// 上限を超える長さのタイマーは掛け算の前に打ち切る（桁あふれを防ぐ）
+	if int64(duration/time.Hour) >= max {
+		return int(max)
+	}
+	points := int64(duration/time.Second) * int64(achievement.PointsPerHour) / int64(time.Hour/time.Second)
+	if points > max {
+		return int(max)
+	}
+	return int(points)
+}
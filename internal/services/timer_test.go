@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTimerTestService 現在時刻を固定した達成目録サービスを作成
+func newTimerTestService(achievementRepo *MockAchievementRepository, now time.Time) *AchievementServiceImpl {
+	service := NewAchievementServiceWithLimits(achievementRepo, new(MockPointRepository), StreakSettings{}, Limits{MaxPoint: 500}).(*AchievementServiceImpl)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestAchievementService_StartTimer(t *testing.T) {
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("start", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		achievementRepo.On("GetByID", "piano").Return(&models.Achievement{ID: "piano", Title: "ピアノの練習", Point: 10, PointsPerHour: 40}, nil)
+		achievementRepo.On("StartTimer", "piano", now).Return(nil)
+
+		achievement, err := newTimerTestService(achievementRepo, now).StartTimer(context.Background(), "piano")
+		require.NoError(t, err)
+		require.NotNil(t, achievement.TimerStartedAt)
+		assert.True(t, now.Equal(*achievement.TimerStartedAt))
+		achievementRepo.AssertExpectations(t)
+	})
+
+	t.Run("already running", func(t *testing.T) {
+		startedAt := now.Add(-time.Hour)
+		achievementRepo := new(MockAchievementRepository)
+		achievementRepo.On("GetByID", "piano").Return(&models.Achievement{ID: "piano", Title: "ピアノの練習", Point: 10, TimerStartedAt: &startedAt}, nil)
+
+		_, err := newTimerTestService(achievementRepo, now).StartTimer(context.Background(), "piano")
+		assert.IsType(t, &errors.BusinessLogicError{}, err)
+		achievementRepo.AssertNotCalled(t, "StartTimer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		achievementRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+
+		_, err := newTimerTestService(achievementRepo, now).StartTimer(context.Background(), "missing")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
+
+func TestAchievementService_StopTimer(t *testing.T) {
+	now := time.Date(2024, 6, 10, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		elapsed   time.Duration
+		perHour   int
+		wantPoint int
+	}{
+		// 1時間半 × 40ポイント
+		{name: "proportional", elapsed: 90 * time.Minute, perHour: 40, wantPoint: 60},
+		// 1ポイント未満は切り捨てる
+		{name: "round down", elapsed: 80 * time.Second, perHour: 40, wantPoint: 0},
+		// 設定できるポイントの上限で打ち切る
+		{name: "capped", elapsed: 30 * time.Hour, perHour: 40, wantPoint: 500},
+		{name: "capped before overflow", elapsed: 1000 * time.Hour, perHour: 500, wantPoint: 500},
+		// 1時間あたりのポイントが無い場合は達成目録のポイント
+		{name: "fixed point", elapsed: 90 * time.Minute, wantPoint: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startedAt := now.Add(-tt.elapsed)
+			achievementRepo := new(MockAchievementRepository)
+			achievementRepo.On("GetByID", "piano").Return(&models.Achievement{ID: "piano", Title: "ピアノの練習", Point: 10, PointsPerHour: tt.perHour, TimerStartedAt: &startedAt}, nil)
+			achievementRepo.On("Complete", mock.MatchedBy(func(c *models.Completion) bool {
+				return c.AchievementID == "piano" && c.StartedAt != nil && c.StartedAt.Equal(startedAt) && c.CompletedAt.Equal(now)
+			})).Return(nil)
+
+			completion, err := newTimerTestService(achievementRepo, now).StopTimer(context.Background(), "piano")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPoint, completion.Point)
+			assert.Equal(t, int(tt.elapsed/time.Second), completion.DurationSeconds)
+			achievementRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("not running", func(t *testing.T) {
+		achievementRepo := new(MockAchievementRepository)
+		achievementRepo.On("GetByID", "piano").Return(&models.Achievement{ID: "piano", Title: "ピアノの練習", Point: 10}, nil)
+
+		_, err := newTimerTestService(achievementRepo, now).StopTimer(context.Background(), "piano")
+		assert.IsType(t, &errors.BusinessLogicError{}, err)
+		achievementRepo.AssertNotCalled(t, "Complete", mock.Anything)
+	})
+
+	t.Run("repeat limit", func(t *testing.T) {
+		startedAt := now.Add(-time.Hour)
+		achievementRepo := new(MockAchievementRepository)
+		achievementRepo.On("GetByID", "piano").Return(&models.Achievement{ID: "piano", Title: "ピアノの練習", Point: 10, MaxTotal: 1, TimerStartedAt: &startedAt}, nil)
+		achievementRepo.On("ListCompletions", "piano").Return([]*models.Completion{{ID: "c1", AchievementID: "piano", CompletedAt: now.Add(-24 * time.Hour)}}, nil)
+
+		_, err := newTimerTestService(achievementRepo, now).StopTimer(context.Background(), "piano")
+		assert.IsType(t, &errors.BusinessLogicError{}, err)
+		achievementRepo.AssertNotCalled(t, "Complete", mock.Anything)
+	})
+
+	t.Run("stopped concurrently", func(t *testing.T) {
+		startedAt := now.Add(-time.Hour)
+		achievementRepo := new(MockAchievementRepository)
+		achievementRepo.On("GetByID", "piano").Return(&models.Achievement{ID: "piano", Title: "ピアノの練習", Point: 10, TimerStartedAt: &startedAt}, nil)
+		achievementRepo.On("Complete", mock.AnythingOfType("*models.Completion")).Return(errors.ErrVersionConflict)
+
+		_, err := newTimerTestService(achievementRepo, now).StopTimer(context.Background(), "piano")
+		assert.ErrorIs(t, err, errors.ErrVersionConflict)
+	})
+}
+
+func TestAchievementService_Create_InvalidPointsPerHour(t *testing.T) {
+	achievementRepo := new(MockAchievementRepository)
+	service := newTimerTestService(achievementRepo, time.Now())
+
+	for _, perHour := range []int{-1, 501} {
+		err := service.Create(context.Background(), &models.Achievement{Title: "ピアノの練習", Point: 10, PointsPerHour: perHour})
+		var validationErr *errors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "points_per_hour", validationErr.Field)
+	}
+	achievementRepo.AssertNotCalled(t, "CreateWithPoints", mock.Anything)
+}
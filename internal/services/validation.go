@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"achievement-management/internal/errors"
+)
+
+const (
+	// maxTitleLength 達成目録・報酬のタイトルの最大文字数
+	maxTitleLength = 200
+	// maxDescriptionLength 達成目録・報酬の説明の最大文字数
+	maxDescriptionLength = 2000
+	// maxTags 報酬獲得履歴に付けられるタグの最大数
+	maxTags = 10
+	// maxTagLength タグの最大文字数
+	maxTagLength = 30
+)
+
+// DefaultMaxPoint 達成目録・報酬に設定できるポイントの上限の既定値
+const DefaultMaxPoint = 100000
+
+// Limits 達成目録・報酬の入力と1日に獲得できるポイントの上限
+type Limits struct {
+	// MaxPoint 設定できるポイントの上限（1000000 のような入力ミスを防ぐ。0以下の場合は DefaultMaxPoint）
+	MaxPoint int
+	// DailyQuota 1日に達成目録の作成・達成で獲得できるポイントの上限（0以下の場合は制限しない）
+	DailyQuota int
+	// OverQuota 獲得ポイントが DailyQuota を超える作成・達成の扱い（空の場合は OverQuotaReject）
+	OverQuota OverQuotaAction
+}
+
+// OverQuotaAction 1日の獲得ポイントの上限を超える作成・達成の扱い
+type OverQuotaAction string
+
+const (
+	// OverQuotaReject 作成・達成を拒否する
+	OverQuotaReject OverQuotaAction = "reject"
+	// OverQuotaZero 作成・達成は記録し、ポイントを付与しない
+	OverQuotaZero OverQuotaAction = "zero"
+)
+
+// maxPoint ポイントの上限（未設定の場合は既定値）
+func (l Limits) maxPoint() int {
+	if l.MaxPoint <= 0 {
+		return DefaultMaxPoint
+	}
+	return l.MaxPoint
+}
+
+// normalizeText 全角の英数字・記号・空白を半角に、半角カナを全角に揃え（NFKC）、前後の空白を除く
+func normalizeText(text string) string {
+	return strings.TrimSpace(norm.NFKC.String(text))
+}
+
+// validateText タイトル（必須）と説明の文字数を検証
+func validateText(title, description string) error {
+	if title == "" {
+		return &errors.ValidationError{Field: "title", Message: "title is required"}
+	}
+
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return &errors.ValidationError{Field: "title", Message: fmt.Sprintf("title must be at most %d characters", maxTitleLength)}
+	}
+
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return &errors.ValidationError{Field: "description", Message: fmt.Sprintf("description must be at most %d characters", maxDescriptionLength)}
+	}
+
+	return nil
+}
+
+// validatePoint ポイントが1以上、上限以下であることを検証
+func (l Limits) validatePoint(point int) error {
+	if point <= 0 {
+		return &errors.ValidationError{Field: "point", Message: "point must be positive"}
+	}
+
+	if max := l.maxPoint(); point > max {
+		return &errors.ValidationError{Field: "point", Message: fmt.Sprintf("point must be at most %d", max)}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/tenant"
+)
+
+// WishlistServiceImpl お気に入り・ほしいものリストサービスの実装
+type WishlistServiceImpl struct {
+	favoriteRepo repository.FavoriteRepository
+	wishlistRepo repository.WishlistRepository
+	rewardRepo   repository.RewardRepository
+	pointRepo    repository.PointRepository
+}
+
+// NewWishlistService お気に入り・ほしいものリストサービスを作成
+func NewWishlistService(favoriteRepo repository.FavoriteRepository, wishlistRepo repository.WishlistRepository, rewardRepo repository.RewardRepository, pointRepo repository.PointRepository) WishlistService {
+	return &WishlistServiceImpl{
+		favoriteRepo: favoriteRepo,
+		wishlistRepo: wishlistRepo,
+		rewardRepo:   rewardRepo,
+		pointRepo:    pointRepo,
+	}
+}
+
+// AddFavorite 報酬をお気に入りに登録（登録済みの場合は何もしない）
+func (s *WishlistServiceImpl) AddFavorite(ctx context.Context, rewardID string) error {
+	if _, err := s.reward(ctx, rewardID); err != nil {
+		return err
+	}
+
+	err := s.favoriteRepo.Add(ctx, &models.Favorite{RewardID: rewardID})
+	if stderrors.Is(err, errors.ErrDuplicateResource) {
+		return nil
+	}
+	return err
+}
+
+// RemoveFavorite 報酬をお気に入りから外す
+func (s *WishlistServiceImpl) RemoveFavorite(ctx context.Context, rewardID string) error {
+	if err := tenant.ValidateEntityID("reward_id", rewardID); err != nil {
+		return err
+	}
+	return s.favoriteRepo.Remove(ctx, rewardID)
+}
+
+// ListFavorites お気に入りの報酬を登録日時順に取得（削除された報酬は含めない）
+func (s *WishlistServiceImpl) ListFavorites(ctx context.Context) ([]*models.Reward, error) {
+	favorites, err := s.favoriteRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.Reward, 0, len(favorites))
+	if len(favorites) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(favorites))
+	for i, favorite := range favorites {
+		ids[i] = favorite.RewardID
+	}
+	rewards, err := s.rewardsByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, favorite := range favorites {
+		if reward, ok := rewards[favorite.RewardID]; ok {
+			result = append(result, reward)
+		}
+	}
+	return result, nil
+}
+
+// Add 報酬をほしいものリストに追加（追加済みの場合は errors.ErrDuplicateResource）
+func (s *WishlistServiceImpl) Add(ctx context.Context, item *models.WishlistItem) error {
+	if err := repository.ValidateWishlistItem(item); err != nil {
+		return err
+	}
+	if _, err := s.reward(ctx, item.RewardID); err != nil {
+		return err
+	}
+	return s.wishlistRepo.Add(ctx, item)
+}
+
+// UpdatePriority ほしいものリストの報酬の優先度を変更
+func (s *WishlistServiceImpl) UpdatePriority(ctx context.Context, rewardID string, priority int) error {
+	if err := tenant.ValidateEntityID("reward_id", rewardID); err != nil {
+		return err
+	}
+	return s.wishlistRepo.UpdatePriority(ctx, rewardID, priority)
+}
+
+// Remove 報酬をほしいものリストから外す
+func (s *WishlistServiceImpl) Remove(ctx context.Context, rewardID string) error {
+	if err := tenant.ValidateEntityID("reward_id", rewardID); err != nil {
+		return err
+	}
+	return s.wishlistRepo.Remove(ctx, rewardID)
+}
+
+// List ほしいものリストを優先度順（同じ優先度は追加した順）に取得し、獲得できるまでに必要なポイントを計算する
+//
+// ほしいものリストに入れた後に削除された報酬は含めない。
+func (s *WishlistServiceImpl) List(ctx context.Context) ([]*models.WishlistEntry, error) {
+	items, err := s.wishlistRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*models.WishlistEntry{}
+	if len(items) == 0 {
+		return entries, nil
+	}
+
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.RewardID
+	}
+	rewards, err := s.rewardsByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.pointRepo.GetCurrentPoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// リポジトリは追加日時順に返すため、安定ソートで同じ優先度の順序を保つ
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Priority < items[j].Priority })
+	for _, item := range items {
+		reward, ok := rewards[item.RewardID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, &models.WishlistEntry{
+			Item:         item,
+			Reward:       reward,
+			PointsNeeded: pointsNeeded(reward.Point, current.Point),
+		})
+	}
+	return entries, nil
+}
+
+// reward お気に入り・ほしいものリストに入れる報酬を取得（存在しない場合は errors.ErrNotFound）
+func (s *WishlistServiceImpl) reward(ctx context.Context, rewardID string) (*models.Reward, error) {
+	if err := tenant.ValidateEntityID("reward_id", rewardID); err != nil {
+		return nil, err
+	}
+	return s.rewardRepo.GetByID(ctx, rewardID)
+}
+
+// rewardsByID 報酬をまとめて取得し、IDで引けるようにする
+func (s *WishlistServiceImpl) rewardsByID(ctx context.Context, ids []string) (map[string]*models.Reward, error) {
+	rewards, err := s.rewardRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.Reward, len(rewards))
+	for _, reward := range rewards {
+		byID[reward.ID] = reward
+	}
+	return byID, nil
+}
+
+// pointsNeeded 報酬を獲得できるまでに貯める必要があるポイント
+func pointsNeeded(cost, balance int) int {
+	if balance >= cost {
+		return 0
+	}
+	return cost - balance
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFavoriteRepository お気に入りリポジトリのモック
+type MockFavoriteRepository struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteRepository) Add(ctx context.Context, favorite *models.Favorite) error {
+	args := m.Called(favorite)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteRepository) Remove(ctx context.Context, rewardID string) error {
+	args := m.Called(rewardID)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteRepository) List(ctx context.Context) ([]*models.Favorite, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Favorite), args.Error(1)
+}
+
+// MockWishlistRepository ほしいものリストリポジトリのモック
+type MockWishlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWishlistRepository) Add(ctx context.Context, item *models.WishlistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockWishlistRepository) UpdatePriority(ctx context.Context, rewardID string, priority int) error {
+	args := m.Called(rewardID, priority)
+	return args.Error(0)
+}
+
+func (m *MockWishlistRepository) Remove(ctx context.Context, rewardID string) error {
+	args := m.Called(rewardID)
+	return args.Error(0)
+}
+
+func (m *MockWishlistRepository) List(ctx context.Context) ([]*models.WishlistItem, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WishlistItem), args.Error(1)
+}
+
+func TestWishlistService_AddFavorite(t *testing.T) {
+	favoriteRepo := new(MockFavoriteRepository)
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByID", "reward-1").Return(&models.Reward{ID: "reward-1"}, nil)
+	rewardRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	favoriteRepo.On("Add", mock.MatchedBy(func(f *models.Favorite) bool { return f.RewardID == "reward-1" })).Return(errors.ErrDuplicateResource)
+	service := NewWishlistService(favoriteRepo, new(MockWishlistRepository), rewardRepo, new(MockPointRepository))
+
+	// 登録済みの報酬を再度登録してもエラーにしない
+	require.NoError(t, service.AddFavorite(context.Background(), "reward-1"))
+
+	// 存在しない報酬は登録しない
+	assert.ErrorIs(t, service.AddFavorite(context.Background(), "missing"), errors.ErrNotFound)
+	favoriteRepo.AssertNumberOfCalls(t, "Add", 1)
+}
+
+func TestWishlistService_ListFavorites_SkipsDeletedRewards(t *testing.T) {
+	favoriteRepo := new(MockFavoriteRepository)
+	rewardRepo := new(MockRewardRepository)
+	favoriteRepo.On("List").Return([]*models.Favorite{{RewardID: "b"}, {RewardID: "deleted"}, {RewardID: "a"}}, nil)
+	rewardRepo.On("GetByIDs", []string{"b", "deleted", "a"}).Return([]*models.Reward{{ID: "a"}, {ID: "b"}}, nil)
+	service := NewWishlistService(favoriteRepo, new(MockWishlistRepository), rewardRepo, new(MockPointRepository))
+
+	rewards, err := service.ListFavorites(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rewards, 2)
+	assert.Equal(t, "b", rewards[0].ID)
+	assert.Equal(t, "a", rewards[1].ID)
+}
+
+func TestWishlistService_Add(t *testing.T) {
+	wishlistRepo := new(MockWishlistRepository)
+	rewardRepo := new(MockRewardRepository)
+	rewardRepo.On("GetByID", "missing").Return(nil, errors.ErrNotFound)
+	service := NewWishlistService(new(MockFavoriteRepository), wishlistRepo, rewardRepo, new(MockPointRepository))
+
+	var validationErr *errors.ValidationError
+	assert.ErrorAs(t, service.Add(context.Background(), &models.WishlistItem{RewardID: "reward-1", Priority: -1}), &validationErr)
+	assert.ErrorIs(t, service.Add(context.Background(), &models.WishlistItem{RewardID: "missing"}), errors.ErrNotFound)
+	wishlistRepo.AssertNotCalled(t, "Add", mock.Anything)
+}
+
+func TestWishlistService_List(t *testing.T) {
+	wishlistRepo := new(MockWishlistRepository)
+	rewardRepo := new(MockRewardRepository)
+	pointRepo := new(MockPointRepository)
+	wishlistRepo.On("List").Return([]*models.WishlistItem{
+		{RewardID: "cake", Priority: 2},
+		{RewardID: "game", Priority: 1},
+		{RewardID: "movie", Priority: 2},
+		{RewardID: "deleted", Priority: 0},
+	}, nil)
+	rewardRepo.On("GetByIDs", mock.Anything).Return([]*models.Reward{
+		{ID: "movie", Point: 150},
+		{ID: "game", Point: 500},
+		{ID: "cake", Point: 80},
+	}, nil)
+	pointRepo.On("GetCurrentPoints").Return(&models.CurrentPoints{Point: 120}, nil)
+	service := NewWishlistService(new(MockFavoriteRepository), wishlistRepo, rewardRepo, pointRepo)
+
+	entries, err := service.List(context.Background())
+	require.NoError(t, err)
+
+	// 優先度順に並べ、同じ優先度は追加した順を保つ。削除された報酬は含めない
+	require.Len(t, entries, 3)
+	assert.Equal(t, "game", entries[0].Reward.ID)
+	assert.Equal(t, 380, entries[0].PointsNeeded)
+	assert.Equal(t, "cake", entries[1].Reward.ID)
+	assert.Equal(t, 0, entries[1].PointsNeeded)
+	assert.Equal(t, "movie", entries[2].Reward.ID)
+	assert.Equal(t, 30, entries[2].PointsNeeded)
+}
+
+func TestWishlistService_List_Empty(t *testing.T) {
+	wishlistRepo := new(MockWishlistRepository)
+	wishlistRepo.On("List").Return([]*models.WishlistItem{}, nil)
+
+	// 空の場合は報酬やポイントを取得しない
+	entries, err := NewWishlistService(new(MockFavoriteRepository), wishlistRepo, new(MockRewardRepository), new(MockPointRepository)).List(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, entries)
+	assert.Empty(t, entries)
+}
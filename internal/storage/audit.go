@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"achievement-management/internal/audit"
+	"achievement-management/internal/config"
+	"achievement-management/internal/logging"
+)
+
+// withAudit 設定で有効な場合は達成目録・報酬・ポイントの変更を監査ログに記録するリポジトリを追加
+// メンテナンスモードの確認より内側に追加し、拒否した書き込みは記録しない
+func withAudit(repos *Repositories, cfg *config.Config) (*Repositories, error) {
+	if !cfg.Logging.Audit.Enabled {
+		return repos, nil
+	}
+
+	logger, err := logging.NewAuditLogger(cfg)
+	if err != nil {
+		return nil, err
+	}
+	repos.Achievements = audit.NewAchievementRepository(repos.Achievements, logger)
+	repos.Rewards = audit.NewRewardRepository(repos.Rewards, logger)
+	repos.Points = audit.NewPointRepository(repos.Points, logger)
+	return repos, nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"achievement-management/internal/cache"
+	"achievement-management/internal/config"
+)
+
+// withCache 設定で有効な場合は達成目録と報酬のリポジトリに読み取りキャッシュを追加
+// ポイントと報酬獲得履歴は残高の確認に使うため常にストレージから読み取る
+func withCache(repos *Repositories, cfg *config.Config) *Repositories {
+	if !cfg.Cache.Enabled {
+		return repos
+	}
+
+	var c cache.Cache
+	switch cfg.Cache.Driver {
+	case config.CacheDriverRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Cache.RedisAddr,
+			Password: cfg.Cache.RedisPassword,
+			DB:       cfg.Cache.RedisDB,
+		})
+		c = cache.NewRedisCache(client)
+
+		closeStorage := repos.close
+		repos.close = func() error {
+			err := client.Close()
+			if closeStorage != nil {
+				err = errors.Join(err, closeStorage())
+			}
+			return err
+		}
+	default:
+		c = cache.NewLRU(cfg.Cache.Capacity)
+	}
+
+	ttl := time.Duration(cfg.Cache.TTLSeconds) * time.Second
+	repos.Achievements = cache.NewAchievementRepository(repos.Achievements, c, cfg.Tables.Achievements, ttl)
+	repos.Rewards = cache.NewRewardRepository(repos.Rewards, c, cfg.Tables.Rewards, ttl)
+	return repos
+}
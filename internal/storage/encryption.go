@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/encryption"
+	"achievement-management/internal/repository"
+)
+
+// withEncryption encryption.tables に指定したテーブルのリポジトリに説明の暗号化を追加
+// キャッシュの外側に追加し、Redis などのキャッシュにも暗号化した値だけを保持させる
+func withEncryption(ctx context.Context, repos *Repositories, cfg *config.Config) (*Repositories, error) {
+	if cfg.Encryption.Provider == "" || len(cfg.Encryption.Tables) == 0 {
+		return repos, nil
+	}
+
+	cipher, err := newCipher(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range cfg.Encryption.Tables {
+		switch table {
+		case "achievements":
+			repos.Achievements = encryption.NewAchievementRepository(repos.Achievements, cipher)
+		case "rewards":
+			repos.Rewards = encryption.NewRewardRepository(repos.Rewards, cipher)
+		}
+	}
+	return repos, nil
+}
+
+// newCipher 設定の encryption.provider に応じた暗号化方式を作成
+func newCipher(ctx context.Context, cfg *config.Config) (encryption.Cipher, error) {
+	switch cfg.Encryption.Provider {
+	case config.EncryptionProviderPassphrase:
+		return encryption.NewPassphraseCipher(cfg.Encryption.Passphrase)
+	case config.EncryptionProviderKMS:
+		awsCfg, err := repository.LoadAWSConfig(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return encryption.NewKMSCipher(kms.NewFromConfig(awsCfg), cfg.Encryption.KMSKeyID), nil
+	default:
+		return nil, fmt.Errorf("unsupported encryption provider: %s", cfg.Encryption.Provider)
+	}
+}
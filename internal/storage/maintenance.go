@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"achievement-management/internal/config"
+	"achievement-management/internal/maintenance"
+)
+
+// withMaintenance メンテナンスモードの間は書き込みを拒否するリポジトリを追加
+// 一番外側に追加し、拒否した書き込みでキャッシュを無効化したりストレージの呼び出しとして数えたりしない
+func withMaintenance(repos *Repositories, cfg *config.Config) *Repositories {
+	mode := maintenance.NewMode(cfg.Maintenance.ReadOnly)
+
+	repos.Achievements = maintenance.NewAchievementRepository(repos.Achievements, mode)
+	repos.Rewards = maintenance.NewRewardRepository(repos.Rewards, mode)
+	repos.Points = maintenance.NewPointRepository(repos.Points, mode)
+	repos.Badges = maintenance.NewBadgeRepository(repos.Badges, mode)
+	repos.Goals = maintenance.NewGoalRepository(repos.Goals, mode)
+	repos.Favorites = maintenance.NewFavoriteRepository(repos.Favorites, mode)
+	repos.Wishlist = maintenance.NewWishlistRepository(repos.Wishlist, mode)
+	repos.Notes = maintenance.NewNoteRepository(repos.Notes, mode)
+	repos.Drift = maintenance.NewDriftRepository(repos.Drift, mode)
+	repos.Reservations = maintenance.NewReservationRepository(repos.Reservations, mode)
+	repos.Quests = maintenance.NewQuestRepository(repos.Quests, mode)
+	repos.Operations = maintenance.NewOperationRepository(repos.Operations, mode)
+	repos.Allowances = maintenance.NewAllowanceRepository(repos.Allowances, mode)
+	repos.APIKeys = maintenance.NewAPIKeyRepository(repos.APIKeys, mode)
+	repos.Maintenance = mode
+	return repos
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"achievement-management/internal/config"
+	"achievement-management/internal/metrics"
+	"achievement-management/internal/repository"
+)
+
+// dynamoDBObserver 設定で有効な場合はDynamoDBのAPI呼び出しごとのレイテンシとスロットリング・エラーを metrics.Default に記録
+func dynamoDBObserver(cfg *config.Config) repository.CallObserver {
+	if !cfg.Metrics.Enabled {
+		return nil
+	}
+	return metrics.Default
+}
+
+// withMetrics 設定で有効な場合はリポジトリの呼び出しごとにレイテンシとエラーの種類を metrics.Default に記録
+// 読み取りキャッシュより内側に追加し、キャッシュに当たった読み取りはストレージの呼び出しとして数えない
+func withMetrics(repos *Repositories, cfg *config.Config) *Repositories {
+	if !cfg.Metrics.Enabled {
+		return repos
+	}
+
+	repos.Achievements = metrics.NewAchievementRepository(repos.Achievements, metrics.Default, cfg.Tables.Achievements)
+	repos.Rewards = metrics.NewRewardRepository(repos.Rewards, metrics.Default, cfg.Tables.Rewards)
+	repos.Points = metrics.NewPointRepository(repos.Points, metrics.Default, cfg.Tables)
+	repos.Badges = metrics.NewBadgeRepository(repos.Badges, metrics.Default, cfg.Tables.Badges)
+	repos.Goals = metrics.NewGoalRepository(repos.Goals, metrics.Default, cfg.Tables.Goals)
+	repos.Favorites = metrics.NewFavoriteRepository(repos.Favorites, metrics.Default, cfg.Tables.Favorites)
+	repos.Wishlist = metrics.NewWishlistRepository(repos.Wishlist, metrics.Default, cfg.Tables.Wishlist)
+	repos.Notes = metrics.NewNoteRepository(repos.Notes, metrics.Default, cfg.Tables.Notes)
+	repos.Drift = metrics.NewDriftRepository(repos.Drift, metrics.Default, cfg.Tables.DriftEvents)
+	repos.Reservations = metrics.NewReservationRepository(repos.Reservations, metrics.Default, cfg.Tables.Reservations)
+	repos.Quests = metrics.NewQuestRepository(repos.Quests, metrics.Default, cfg.Tables.Quests)
+	repos.Operations = metrics.NewOperationRepository(repos.Operations, metrics.Default, cfg.Tables.Operations)
+	repos.Allowances = metrics.NewAllowanceRepository(repos.Allowances, metrics.Default, cfg.Tables.Allowances)
+	repos.APIKeys = metrics.NewAPIKeyRepository(repos.APIKeys, metrics.Default, cfg.Tables.APIKeys)
+	return repos
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/maintenance"
+	"achievement-management/internal/repository"
+	"achievement-management/internal/repository/memory"
+	"achievement-management/internal/repository/sqlstore"
+)
+
+// Repositories 設定したストレージのリポジトリ一式
+type Repositories struct {
+	Achievements repository.AchievementRepository
+	Rewards      repository.RewardRepository
+	Points       repository.PointRepository
+	Badges       repository.BadgeRepository
+	Goals        repository.GoalRepository
+	Favorites    repository.FavoriteRepository
+	Wishlist     repository.WishlistRepository
+	Notes        repository.NoteRepository
+	Drift        repository.DriftRepository
+	Reservations repository.ReservationRepository
+	Quests       repository.QuestRepository
+	Operations   repository.OperationRepository
+	Allowances   repository.AllowanceRepository
+	APIKeys      repository.APIKeyRepository
+
+	// Maintenance 書き込みを停止するメンテナンスモードの状態（実行中に切り替えられる）
+	Maintenance *maintenance.Mode
+
+	close func() error
+	// ping ストレージに接続できるか確認する（nil の場合は確認しない）
+	ping func(ctx context.Context) error
+}
+
+// Close ストレージの接続を閉じる
+func (r *Repositories) Close() error {
+	if r.close == nil {
+		return nil
+	}
+	return r.close()
+}
+
+// Ping ストレージに接続できるか確認する（ヘルスチェック用。DynamoDB以外のストレージでは常に nil）
+func (r *Repositories) Ping(ctx context.Context) error {
+	if r.ping == nil {
+		return nil
+	}
+	return r.ping(ctx)
+}
+
+// Open 設定の storage.driver に応じたリポジトリを作成（metrics.enabled の場合はメトリクスの記録、cache.enabled の場合は読み取りキャッシュ、encryption.provider の場合は説明の暗号化、logging.audit.enabled の場合は監査ログの記録、メンテナンスモードの確認を追加）
+func Open(ctx context.Context, cfg *config.Config) (*Repositories, error) {
+	repos, err := open(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := withEncryption(ctx, withCache(withMetrics(repos, cfg), cfg), cfg)
+	if err != nil {
+		repos.Close()
+		return nil, err
+	}
+	audited, err := withAudit(encrypted, cfg)
+	if err != nil {
+		repos.Close()
+		return nil, err
+	}
+	return withMaintenance(audited, cfg), nil
+}
+
+// open ストレージのリポジトリを作成
+func open(ctx context.Context, cfg *config.Config) (*Repositories, error) {
+	switch cfg.Storage.Driver {
+	case config.StorageDriverDynamoDB, "":
+		repo, err := repository.NewDynamoDBRepositoryWithObserver(ctx, cfg, dynamoDBObserver(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return &Repositories{
+			Achievements: repository.NewAchievementRepository(repo, cfg),
+			Rewards:      repository.NewRewardRepository(repo, cfg),
+			Points:       repository.NewPointRepository(repo, cfg),
+			Badges:       repository.NewBadgeRepository(repo, cfg),
+			Goals:        repository.NewGoalRepository(repo, cfg),
+			Favorites:    repository.NewFavoriteRepository(repo, cfg),
+			Wishlist:     repository.NewWishlistRepository(repo, cfg),
+			Notes:        repository.NewNoteRepository(repo, cfg),
+			Drift:        repository.NewDriftRepository(repo, cfg),
+			Reservations: repository.NewReservationRepository(repo, cfg),
+			Quests:       repository.NewQuestRepository(repo, cfg),
+			Operations:   repository.NewOperationRepository(repo, cfg),
+			Allowances:   repository.NewAllowanceRepository(repo, cfg),
+			APIKeys:      repository.NewAPIKeyRepository(repo, cfg),
+			ping: func(ctx context.Context) error {
+				return repo.Ping(ctx, cfg.Tables.Achievements)
+			},
+		}, nil
+	case config.StorageDriverSQLite:
+		db, err := sqlstore.OpenSQLite(ctx, cfg.Storage.SQLitePath)
+		if err != nil {
+			return nil, err
+		}
+		return newSQLRepositories(db), nil
+	case config.StorageDriverPostgres:
+		db, err := sqlstore.OpenPostgres(ctx, cfg.Storage.PostgresDSN)
+		if err != nil {
+			return nil, err
+		}
+		return newSQLRepositories(db), nil
+	case config.StorageDriverMemory:
+		store := memory.NewStore()
+		return &Repositories{
+			Achievements: memory.NewAchievementRepository(store),
+			Rewards:      memory.NewRewardRepository(store),
+			Points:       memory.NewPointRepository(store),
+			Badges:       memory.NewBadgeRepository(store),
+			Goals:        memory.NewGoalRepository(store),
+			Favorites:    memory.NewFavoriteRepository(store),
+			Wishlist:     memory.NewWishlistRepository(store),
+			Notes:        memory.NewNoteRepository(store),
+			Drift:        memory.NewDriftRepository(store),
+			Reservations: memory.NewReservationRepository(store),
+			Quests:       memory.NewQuestRepository(store),
+			Operations:   memory.NewOperationRepository(store),
+			Allowances:   memory.NewAllowanceRepository(store),
+			APIKeys:      memory.NewAPIKeyRepository(store),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Storage.Driver)
+	}
+}
+
+// newSQLRepositories SQLデータベースのリポジトリ一式を作成
+func newSQLRepositories(db *sqlstore.DB) *Repositories {
+	return &Repositories{
+		Achievements: sqlstore.NewAchievementRepository(db),
+		Rewards:      sqlstore.NewRewardRepository(db),
+		Points:       sqlstore.NewPointRepository(db),
+		Badges:       sqlstore.NewBadgeRepository(db),
+		Goals:        sqlstore.NewGoalRepository(db),
+		Favorites:    sqlstore.NewFavoriteRepository(db),
+		Wishlist:     sqlstore.NewWishlistRepository(db),
+		Notes:        sqlstore.NewNoteRepository(db),
+		Drift:        sqlstore.NewDriftRepository(db),
+		Reservations: sqlstore.NewReservationRepository(db),
+		Quests:       sqlstore.NewQuestRepository(db),
+		Operations:   sqlstore.NewOperationRepository(db),
+		Allowances:   sqlstore.NewAllowanceRepository(db),
+		APIKeys:      sqlstore.NewAPIKeyRepository(db),
+		close:        db.Close,
+	}
+}
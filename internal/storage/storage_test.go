@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	stderrors "errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"achievement-management/internal/config"
+	"achievement-management/internal/errors"
+	"achievement-management/internal/models"
+)
+
+func TestOpen_SQLite(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{Storage: config.StorageConfig{
+		Driver:     config.StorageDriverSQLite,
+		SQLitePath: filepath.Join(t.TempDir(), "achievement.db"),
+	}}
+
+	repos, err := Open(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer repos.Close()
+
+	if err := repos.Achievements.Create(ctx, &models.Achievement{Title: "初回ログイン", Point: 10}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	list, err := repos.Achievements.List(ctx)
+	if err != nil || len(list) != 1 {
+		t.Errorf("Expected 1 achievement, got %v (%v)", list, err)
+	}
+}
+
+func TestOpen_UnsupportedDriver(t *testing.T) {
+	cfg := &config.Config{Storage: config.StorageConfig{Driver: "mysql"}}
+	if _, err := Open(context.Background(), cfg); err == nil {
+		t.Error("Expected error for unsupported driver")
+	}
+}
+
+func TestOpen_PostgresUnreachable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg := &config.Config{Storage: config.StorageConfig{
+		Driver:      config.StorageDriverPostgres,
+		PostgresDSN: "postgres://achievement@127.0.0.1:1/achievement?connect_timeout=1",
+	}}
+	if _, err := Open(ctx, cfg); err == nil {
+		t.Error("Expected error for unreachable postgres database")
+	}
+}
+
+func TestOpen_Memory(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{Storage: config.StorageConfig{Driver: config.StorageDriverMemory}}
+
+	repos, err := Open(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer repos.Close()
+
+	// 達成目録とポイントは同じストアを共有する
+	if err := repos.Points.AddPoints(ctx, 10); err != nil {
+		t.Fatalf("AddPoints failed: %v", err)
+	}
+	points, err := repos.Points.GetCurrentPoints(ctx)
+	if err != nil || points.Point != 10 {
+		t.Errorf("Expected 10 points, got %v (%v)", points, err)
+	}
+}
+
+func TestOpen_WithCache(t *testing.T) {
+	ctx := context.Background()
+	for _, driver := range []string{config.CacheDriverMemory, config.CacheDriverRedis} {
+		cfg := &config.Config{
+			Storage: config.StorageConfig{Driver: config.StorageDriverMemory},
+			Tables:  config.TableConfig{Achievements: "achievements", Rewards: "rewards"},
+			Cache: config.CacheConfig{
+				Enabled:    true,
+				Driver:     driver,
+				TTLSeconds: 30,
+				Capacity:   10,
+				// 接続できないRedisでもキャッシュを使わずに読み書きできる
+				RedisAddr: "127.0.0.1:1",
+			},
+		}
+
+		repos, err := Open(ctx, cfg)
+		if err != nil {
+			t.Fatalf("Open with %s cache failed: %v", driver, err)
+		}
+		if err := repos.Rewards.Create(ctx, &models.Reward{Title: "コーヒー券", Point: 100}); err != nil {
+			t.Fatalf("Create with %s cache failed: %v", driver, err)
+		}
+		if list, err := repos.Rewards.List(ctx); err != nil || len(list) != 1 {
+			t.Errorf("Expected 1 reward with %s cache, got %v (%v)", driver, list, err)
+		}
+		if err := repos.Close(); err != nil {
+			t.Errorf("Close with %s cache failed: %v", driver, err)
+		}
+	}
+}
+
+func TestOpen_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		Storage:     config.StorageConfig{Driver: config.StorageDriverMemory},
+		Maintenance: config.MaintenanceConfig{ReadOnly: true},
+	}
+
+	repos, err := Open(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer repos.Close()
+
+	if err := repos.Points.AddPoints(ctx, 10); !stderrors.Is(err, errors.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly while read-only, got %v", err)
+	}
+
+	// 実行中にメンテナンスモードを解除できる
+	repos.Maintenance.SetReadOnly(false)
+	if err := repos.Points.AddPoints(ctx, 10); err != nil {
+		t.Errorf("AddPoints failed after maintenance: %v", err)
+	}
+}
+
+func TestOpen_WithEncryption(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		Storage: config.StorageConfig{Driver: config.StorageDriverMemory},
+		Encryption: config.EncryptionConfig{
+			Provider:   config.EncryptionProviderPassphrase,
+			Passphrase: "correct horse battery staple",
+			Tables:     []string{"rewards"},
+		},
+	}
+
+	repos, err := Open(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer repos.Close()
+
+	reward := &models.Reward{Title: "コーヒー券", Description: "駅前のカフェで使える", Point: 100}
+	if err := repos.Rewards.Create(ctx, reward); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	got, err := repos.Rewards.GetByID(ctx, reward.ID)
+	if err != nil || got.Description != "駅前のカフェで使える" {
+		t.Errorf("Expected decrypted description, got %v (%v)", got, err)
+	}
+}
@@ -0,0 +1,80 @@
+package tenant
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"achievement-management/internal/errors"
+)
+
+// キーの設計
+//
+// 複数の家族・チームで同じテーブルを共有するため、ストレージのキーを「{tenant_id}#{entity_id}」とする。
+// テナントを分ける前のデータをそのまま読めるよう、既定のテナントのキーには接頭辞を付けない。
+const (
+	// DefaultID テナントを指定しない場合のテナント
+	DefaultID = "default"
+
+	// separator キーのテナントIDとエンティティIDの区切り文字
+	separator = "#"
+)
+
+// idPattern テナントIDの形式（区切り文字を含まない英小文字・数字・ハイフン・アンダースコア）
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// contextKey コンテキストにテナントIDを保持するキー
+type contextKey struct{}
+
+// WithID テナントIDを設定したコンテキストを作成
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext コンテキストのテナントIDを取得（未設定の場合は既定のテナント）
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return DefaultID
+}
+
+// Validate テナントIDの形式を検証
+func Validate(id string) error {
+	if !idPattern.MatchString(id) {
+		return &errors.ValidationError{Field: "tenant_id", Message: "tenant_id must be 1-64 lowercase letters, digits, '-' or '_'"}
+	}
+	return nil
+}
+
+// ValidateEntityID 呼び出し側が指定したエンティティのIDを検証（field はエラーに含める項目名）
+//
+// 既定のテナントのキーには接頭辞を付けないため、区切り文字を含むIDを受け付けると
+// 「{他のテナントのID}#{エンティティID}」を指定して他のテナントのアイテムを操作できてしまう。
+func ValidateEntityID(field, id string) error {
+	if id == "" {
+		return &errors.ValidationError{Field: field, Message: field + " is required"}
+	}
+	if strings.Contains(id, separator) {
+		return &errors.ValidationError{Field: field, Message: field + " must not contain '" + separator + "'"}
+	}
+	return nil
+}
+
+// Key テナントのストレージのキーを作成
+func Key(ctx context.Context, id string) string {
+	tenantID := FromContext(ctx)
+	if tenantID == DefaultID {
+		return id
+	}
+	return tenantID + separator + id
+}
+
+// EntityID ストレージのキーからテナントの接頭辞を取り除く
+func EntityID(ctx context.Context, key string) string {
+	tenantID := FromContext(ctx)
+	if tenantID == DefaultID {
+		return key
+	}
+	return strings.TrimPrefix(key, tenantID+separator)
+}
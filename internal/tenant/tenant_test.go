@@ -0,0 +1,63 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != DefaultID {
+		t.Errorf("Expected default tenant, got %s", got)
+	}
+	if got := FromContext(WithID(context.Background(), "")); got != DefaultID {
+		t.Errorf("Expected default tenant for empty id, got %s", got)
+	}
+	if got := FromContext(WithID(context.Background(), "family-a")); got != "family-a" {
+		t.Errorf("Expected family-a, got %s", got)
+	}
+}
+
+func TestKey(t *testing.T) {
+	ctx := context.Background()
+	if got := Key(ctx, "01ABC"); got != "01ABC" {
+		t.Errorf("Expected default tenant key without prefix, got %s", got)
+	}
+	if got := EntityID(ctx, "01ABC"); got != "01ABC" {
+		t.Errorf("Expected 01ABC, got %s", got)
+	}
+
+	ctx = WithID(ctx, "family-a")
+	if got := Key(ctx, "01ABC"); got != "family-a#01ABC" {
+		t.Errorf("Expected family-a#01ABC, got %s", got)
+	}
+	if got := EntityID(ctx, "family-a#01ABC"); got != "01ABC" {
+		t.Errorf("Expected 01ABC, got %s", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{"default", "family-a", "team_01", "a"}
+	for _, id := range valid {
+		if err := Validate(id); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", id, err)
+		}
+	}
+
+	invalid := []string{"", "Family", "a#b", "-team", "team a", string(make([]byte, 65))}
+	for _, id := range invalid {
+		if err := Validate(id); err == nil {
+			t.Errorf("Expected %q to be invalid", id)
+		}
+	}
+}
+
+func TestValidateEntityID(t *testing.T) {
+	if err := ValidateEntityID("id", "01J8ZK0000000000000000000A"); err != nil {
+		t.Errorf("Expected a ULID to be valid, got %v", err)
+	}
+	for _, id := range []string{"", "family-a#01ABC", "#"} {
+		if err := ValidateEntityID("id", id); err == nil {
+			t.Errorf("Expected %q to be invalid", id)
+		}
+	}
+}
@@ -11,6 +11,13 @@ locals {
     for key, value in var.dynamodb_tables : key => value
     if key != "achievements"
   }
+
+  # Attributes used only as GSI keys (the table's own keys are defined separately)
+  gsi_attributes = {
+    for key, value in var.dynamodb_tables : key => distinct(compact(flatten([
+      for index in value.global_secondary_indexes : [index.hash_key, index.range_key]
+    ])))
+  }
 }
 
 # Standard DynamoDB Tables (without GSI)
@@ -22,6 +29,10 @@ resource "aws_dynamodb_table" "tables" {
   hash_key     = each.value.hash_key
   range_key    = each.value.range_key
 
+  # Stream for change event delivery
+  stream_enabled   = each.value.stream_enabled
+  stream_view_type = each.value.stream_enabled ? "NEW_AND_OLD_IMAGES" : null
+
   # Provisioned throughput (only used when billing_mode is PROVISIONED)
   read_capacity  = each.value.billing_mode == "PROVISIONED" ? each.value.read_capacity : null
   write_capacity = each.value.billing_mode == "PROVISIONED" ? each.value.write_capacity : null
@@ -41,6 +52,35 @@ resource "aws_dynamodb_table" "tables" {
     }
   }
 
+  # GSI key attribute definitions
+  dynamic "attribute" {
+    for_each = [for name in local.gsi_attributes[each.key] : name if name != each.value.hash_key && name != each.value.range_key]
+    content {
+      name = attribute.value
+      type = "S"
+    }
+  }
+
+  # Global secondary indexes for list queries ordered by creation date
+  dynamic "global_secondary_index" {
+    for_each = each.value.global_secondary_indexes
+    content {
+      name            = global_secondary_index.value.name
+      hash_key        = global_secondary_index.value.hash_key
+      range_key       = global_secondary_index.value.range_key
+      projection_type = "ALL"
+    }
+  }
+
+  # TTL so expired and deleted items are purged automatically
+  dynamic "ttl" {
+    for_each = each.value.ttl_attribute != null ? [each.value.ttl_attribute] : []
+    content {
+      attribute_name = ttl.value
+      enabled        = true
+    }
+  }
+
   # Point-in-time recovery configuration
   point_in_time_recovery {
     enabled = each.value.point_in_time_recovery
@@ -75,6 +115,10 @@ resource "aws_dynamodb_table" "achievements" {
   billing_mode = var.dynamodb_tables.achievements.billing_mode
   hash_key     = var.dynamodb_tables.achievements.hash_key
 
+  # Stream for change event delivery
+  stream_enabled   = var.dynamodb_tables.achievements.stream_enabled
+  stream_view_type = var.dynamodb_tables.achievements.stream_enabled ? "NEW_AND_OLD_IMAGES" : null
+
   # Provisioned throughput (only used when billing_mode is PROVISIONED)
   read_capacity  = var.dynamodb_tables.achievements.billing_mode == "PROVISIONED" ? var.dynamodb_tables.achievements.read_capacity : null
   write_capacity = var.dynamodb_tables.achievements.billing_mode == "PROVISIONED" ? var.dynamodb_tables.achievements.write_capacity : null
@@ -85,6 +129,35 @@ resource "aws_dynamodb_table" "achievements" {
     type = "S"
   }
 
+  # GSI key attribute definitions
+  dynamic "attribute" {
+    for_each = [for name in local.gsi_attributes["achievements"] : name if name != var.dynamodb_tables.achievements.hash_key]
+    content {
+      name = attribute.value
+      type = "S"
+    }
+  }
+
+  # Global secondary indexes for list queries ordered by creation date
+  dynamic "global_secondary_index" {
+    for_each = var.dynamodb_tables.achievements.global_secondary_indexes
+    content {
+      name            = global_secondary_index.value.name
+      hash_key        = global_secondary_index.value.hash_key
+      range_key       = global_secondary_index.value.range_key
+      projection_type = "ALL"
+    }
+  }
+
+  # TTL so expired and deleted items are purged automatically
+  dynamic "ttl" {
+    for_each = var.dynamodb_tables.achievements.ttl_attribute != null ? [var.dynamodb_tables.achievements.ttl_attribute] : []
+    content {
+      attribute_name = ttl.value
+      enabled        = true
+    }
+  }
+
   # Point-in-time recovery configuration
   point_in_time_recovery {
     enabled = var.dynamodb_tables.achievements.point_in_time_recovery
@@ -108,6 +181,6 @@ resource "aws_dynamodb_table" "achievements" {
     EncryptionEnabled = var.dynamodb_tables.achievements.server_side_encryption ? "true" : "false"
     DataStore         = "primary"
     AccessPattern     = "standard"
-    HasGSI            = "true"
+    HasGSI            = length(var.dynamodb_tables.achievements.global_secondary_indexes) > 0 ? "true" : "false"
   })
 }
@@ -65,6 +65,136 @@ output "reward_history_table_arn" {
   value       = try(aws_dynamodb_table.tables["reward_history"].arn, null)
 }
 
+output "point_ledger_table_name" {
+  description = "Name of the point ledger table"
+  value       = try(aws_dynamodb_table.tables["point_ledger"].name, null)
+}
+
+output "point_ledger_table_arn" {
+  description = "ARN of the point ledger table"
+  value       = try(aws_dynamodb_table.tables["point_ledger"].arn, null)
+}
+
+output "completions_table_name" {
+  description = "Name of the completions table"
+  value       = try(aws_dynamodb_table.tables["completions"].name, null)
+}
+
+output "completions_table_arn" {
+  description = "ARN of the completions table"
+  value       = try(aws_dynamodb_table.tables["completions"].arn, null)
+}
+
+output "badges_table_name" {
+  description = "Name of the badges table"
+  value       = try(aws_dynamodb_table.tables["badges"].name, null)
+}
+
+output "badges_table_arn" {
+  description = "ARN of the badges table"
+  value       = try(aws_dynamodb_table.tables["badges"].arn, null)
+}
+
+output "goals_table_name" {
+  description = "Name of the goals table"
+  value       = try(aws_dynamodb_table.tables["goals"].name, null)
+}
+
+output "goals_table_arn" {
+  description = "ARN of the goals table"
+  value       = try(aws_dynamodb_table.tables["goals"].arn, null)
+}
+
+output "favorites_table_name" {
+  description = "Name of the favorites table"
+  value       = try(aws_dynamodb_table.tables["favorites"].name, null)
+}
+
+output "favorites_table_arn" {
+  description = "ARN of the favorites table"
+  value       = try(aws_dynamodb_table.tables["favorites"].arn, null)
+}
+
+output "wishlist_table_name" {
+  description = "Name of the wishlist table"
+  value       = try(aws_dynamodb_table.tables["wishlist"].name, null)
+}
+
+output "wishlist_table_arn" {
+  description = "ARN of the wishlist table"
+  value       = try(aws_dynamodb_table.tables["wishlist"].arn, null)
+}
+
+output "notes_table_name" {
+  description = "Name of the notes table"
+  value       = try(aws_dynamodb_table.tables["notes"].name, null)
+}
+
+output "notes_table_arn" {
+  description = "ARN of the notes table"
+  value       = try(aws_dynamodb_table.tables["notes"].arn, null)
+}
+
+output "drift_events_table_name" {
+  description = "Name of the drift events table"
+  value       = try(aws_dynamodb_table.tables["drift_events"].name, null)
+}
+
+output "drift_events_table_arn" {
+  description = "ARN of the drift events table"
+  value       = try(aws_dynamodb_table.tables["drift_events"].arn, null)
+}
+
+output "reservations_table_name" {
+  description = "Name of the reservations table"
+  value       = try(aws_dynamodb_table.tables["reservations"].name, null)
+}
+
+output "reservations_table_arn" {
+  description = "ARN of the reservations table"
+  value       = try(aws_dynamodb_table.tables["reservations"].arn, null)
+}
+
+output "quests_table_name" {
+  description = "Name of the quests table"
+  value       = try(aws_dynamodb_table.tables["quests"].name, null)
+}
+
+output "quests_table_arn" {
+  description = "ARN of the quests table"
+  value       = try(aws_dynamodb_table.tables["quests"].arn, null)
+}
+
+output "operations_table_name" {
+  description = "Name of the operations table"
+  value       = try(aws_dynamodb_table.tables["operations"].name, null)
+}
+
+output "operations_table_arn" {
+  description = "ARN of the operations table"
+  value       = try(aws_dynamodb_table.tables["operations"].arn, null)
+}
+
+output "allowances_table_name" {
+  description = "Name of the allowances table"
+  value       = try(aws_dynamodb_table.tables["allowances"].name, null)
+}
+
+output "allowances_table_arn" {
+  description = "ARN of the allowances table"
+  value       = try(aws_dynamodb_table.tables["allowances"].arn, null)
+}
+
+output "api_keys_table_name" {
+  description = "Name of the API keys table"
+  value       = try(aws_dynamodb_table.tables["api_keys"].name, null)
+}
+
+output "api_keys_table_arn" {
+  description = "ARN of the API keys table"
+  value       = try(aws_dynamodb_table.tables["api_keys"].arn, null)
+}
+
 output "achievements_gsi_name" {
   description = "Name of the achievements GSI"
   value       = length(aws_dynamodb_table.achievements) > 0 ? "achievement-id-index" : null
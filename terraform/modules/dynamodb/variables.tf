@@ -21,6 +21,16 @@ variable "dynamodb_tables" {
     write_capacity         = optional(number)
     point_in_time_recovery = bool
     server_side_encryption = bool
+    # Indexes used by the application's list queries (entity_type + sort key)
+    global_secondary_indexes = optional(list(object({
+      name      = string
+      hash_key  = string
+      range_key = optional(string)
+    })), [])
+    # Attribute holding the expiry time (epoch seconds) that DynamoDB TTL purges on
+    ttl_attribute = optional(string)
+    # Enable a NEW_AND_OLD_IMAGES stream for change event delivery (achievement-app streams consume)
+    stream_enabled = optional(bool, false)
   }))
 }
 
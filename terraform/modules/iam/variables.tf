@@ -13,7 +13,7 @@ variable "environment" {
 variable "dynamodb_table_names" {
   description = "List of DynamoDB table names that the application needs access to"
   type        = list(string)
-  default     = ["achievements", "rewards", "current_points", "reward_history"]
+  default     = ["achievements", "rewards", "current_points", "reward_history", "point_ledger", "completions", "badges", "goals", "favorites", "wishlist", "notes", "drift_events", "reservations", "quests", "operations", "allowances", "api_keys"]
 }
 
 variable "tags" {
@@ -165,6 +165,16 @@ variable "dynamodb_tables" {
     write_capacity         = optional(number)
     point_in_time_recovery = bool
     server_side_encryption = bool
+    # Indexes used by the application's list queries (entity_type + sort key)
+    global_secondary_indexes = optional(list(object({
+      name      = string
+      hash_key  = string
+      range_key = optional(string)
+    })), [])
+    # Attribute holding the expiry time (epoch seconds) that DynamoDB TTL purges on
+    ttl_attribute = optional(string)
+    # Enable a NEW_AND_OLD_IMAGES stream for change event delivery (achievement-app streams consume)
+    stream_enabled = optional(bool, false)
   }))
 
   default = {
@@ -173,12 +183,24 @@ variable "dynamodb_tables" {
       billing_mode           = "PAY_PER_REQUEST"
       point_in_time_recovery = true
       server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+      ttl_attribute = "expires_at"
     }
     rewards = {
       hash_key               = "id"
       billing_mode           = "PAY_PER_REQUEST"
       point_in_time_recovery = true
       server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+      ttl_attribute = "expires_at"
     }
     current_points = {
       hash_key               = "id"
@@ -191,6 +213,167 @@ variable "dynamodb_tables" {
       billing_mode           = "PAY_PER_REQUEST"
       point_in_time_recovery = true
       server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-redeemed_at_key-index"
+        hash_key  = "entity_type"
+        range_key = "redeemed_at_key"
+      }]
+      ttl_attribute = "expires_at"
+    }
+    # Append-only record of every point change; entries are never expired
+    point_ledger = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # One item per completion of an achievement; kept as history like the ledger
+    completions = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name     = "achievement_key-index"
+        hash_key = "achievement_key"
+      }]
+    }
+    # One item per earned badge; each badge is earned once per tenant
+    badges = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-earned_at-index"
+        hash_key  = "entity_type"
+        range_key = "earned_at"
+      }]
+    }
+    # Goals with their point or achievement count target; reached_at is set once
+    goals = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # Rewards marked as favorites, keyed by reward ID
+    favorites = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # Rewards on the wishlist with their priority, keyed by reward ID
+    wishlist = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # Notes attached to achievements, rewards and redemptions, listed per record by target_key
+    notes = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "target_key-created_at-index"
+        hash_key  = "target_key"
+        range_key = "created_at"
+      }]
+    }
+    # Point drift recorded by the consistency checker, listed in detection order
+    drift_events = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-detected_at-index"
+        hash_key  = "entity_type"
+        range_key = "detected_at"
+      }]
+    }
+    # Points set aside for rewards, one item per reward
+    reservations = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # Ordered chains of achievements with a completion bonus
+    quests = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # Short journal of achievement and reward changes for undo/redo
+    operations = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # Scheduled point grants (pocket-money style allowances)
+    allowances = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
+    }
+    # API keys accepted in the X-API-Key header (only the hash of each key is stored)
+    api_keys = {
+      hash_key               = "id"
+      billing_mode           = "PAY_PER_REQUEST"
+      point_in_time_recovery = true
+      server_side_encryption = true
+      global_secondary_indexes = [{
+        name      = "entity_type-created_at-index"
+        hash_key  = "entity_type"
+        range_key = "created_at"
+      }]
     }
   }
 }